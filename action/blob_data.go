@@ -168,6 +168,11 @@ func (tx *BlobTxData) ValidateSidecar() error {
 	return verifySidecar(tx.sidecar, tx.blobHashes)
 }
 
+// VerifySidecar verifies the sidecar against the versioned blob hashes of the transaction
+func VerifySidecar(sidecar *types.BlobTxSidecar, hashes []common.Hash) error {
+	return verifySidecar(sidecar, hashes)
+}
+
 func verifySidecar(sidecar *types.BlobTxSidecar, hashes []common.Hash) error {
 	size := len(hashes)
 	// Verify the size of hashes, commitments and proofs
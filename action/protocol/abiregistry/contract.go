@@ -0,0 +1,169 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package abiregistry
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+)
+
+const _addressLength = 20
+
+type (
+	// Contract is a version of a system contract, i.e., an address whose executions and event logs are handled by a
+	// protocol natively, with the ABI of its methods and events effective from the activation height
+	Contract struct {
+		Name             string
+		Address          address.Address
+		ABI              string
+		ActivationHeight uint64
+	}
+
+	// ABIVersion is a version of the ABI of a system contract registered in the state
+	ABIVersion struct {
+		Version          uint32
+		ActivationHeight uint64
+		ABI              []byte
+	}
+
+	// ContractABIs are the versions of the ABI of a system contract in the ascending order of activation heights
+	ContractABIs struct {
+		Name     string
+		Versions []*ABIVersion
+	}
+
+	// ContractList is the list of the addresses of the system contracts in the order of registration
+	ContractList []address.Address
+)
+
+// JoinABI joins the JSON fragments of the methods and the events into a compact ABI, which is the same on all the
+// nodes registering it
+func JoinABI(fragments ...string) (string, error) {
+	var entries []json.RawMessage
+	for _, f := range fragments {
+		var e []json.RawMessage
+		if err := json.Unmarshal([]byte(f), &e); err != nil {
+			return "", errors.Wrap(err, "invalid ABI fragment")
+		}
+		entries = append(entries, e...)
+	}
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, data); err != nil {
+		return "", err
+	}
+	if _, err := abi.JSON(strings.NewReader(buf.String())); err != nil {
+		return "", errors.Wrap(err, "invalid ABI")
+	}
+	return buf.String(), nil
+}
+
+// MustJoinABI joins the JSON fragments into a compact ABI, and panics on invalid fragments
+func MustJoinABI(fragments ...string) string {
+	s, err := JoinABI(fragments...)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// At returns the version of the ABI effective at the height, or nil if none is activated yet
+func (c *ContractABIs) At(height uint64) *ABIVersion {
+	i := sort.Search(len(c.Versions), func(i int) bool {
+		return c.Versions[i].ActivationHeight > height
+	})
+	if i == 0 {
+		return nil
+	}
+	return c.Versions[i-1]
+}
+
+// Serialize serializes the versions of the ABI into bytes
+func (c *ContractABIs) Serialize() ([]byte, error) {
+	buf := appendBytes(nil, []byte(c.Name))
+	for _, v := range c.Versions {
+		buf = binary.BigEndian.AppendUint32(buf, v.Version)
+		buf = binary.BigEndian.AppendUint64(buf, v.ActivationHeight)
+		buf = appendBytes(buf, v.ABI)
+	}
+	return buf, nil
+}
+
+// Deserialize deserializes bytes into the versions of the ABI
+func (c *ContractABIs) Deserialize(buf []byte) error {
+	name, buf, err := readBytes(buf)
+	if err != nil {
+		return err
+	}
+	var versions []*ABIVersion
+	for len(buf) > 0 {
+		if len(buf) < 12 {
+			return errors.Errorf("invalid ABI version length %d", len(buf))
+		}
+		v := &ABIVersion{
+			Version:          binary.BigEndian.Uint32(buf[:4]),
+			ActivationHeight: binary.BigEndian.Uint64(buf[4:12]),
+		}
+		if v.ABI, buf, err = readBytes(buf[12:]); err != nil {
+			return err
+		}
+		versions = append(versions, v)
+	}
+	c.Name, c.Versions = string(name), versions
+	return nil
+}
+
+// Serialize serializes the list of the system contracts into bytes
+func (l ContractList) Serialize() ([]byte, error) {
+	buf := make([]byte, 0, len(l)*_addressLength)
+	for _, addr := range l {
+		buf = append(buf, addr.Bytes()...)
+	}
+	return buf, nil
+}
+
+// Deserialize deserializes bytes into the list of the system contracts
+func (l *ContractList) Deserialize(buf []byte) error {
+	if len(buf)%_addressLength != 0 {
+		return errors.Errorf("invalid contract list length %d", len(buf))
+	}
+	list := make(ContractList, 0, len(buf)/_addressLength)
+	for i := 0; i < len(buf); i += _addressLength {
+		addr, err := address.FromBytes(buf[i : i+_addressLength])
+		if err != nil {
+			return err
+		}
+		list = append(list, addr)
+	}
+	*l = list
+	return nil
+}
+
+func appendBytes(buf, b []byte) []byte {
+	buf = binary.BigEndian.AppendUint32(buf, uint32(len(b)))
+	return append(buf, b...)
+}
+
+func readBytes(buf []byte) ([]byte, []byte, error) {
+	if len(buf) < 4 {
+		return nil, nil, errors.Errorf("invalid length prefix %d", len(buf))
+	}
+	n := binary.BigEndian.Uint32(buf[:4])
+	if uint64(len(buf)-4) < uint64(n) {
+		return nil, nil, errors.Errorf("invalid bytes length %d", n)
+	}
+	return buf[4 : 4+n], buf[4+n:], nil
+}
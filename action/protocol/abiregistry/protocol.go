@@ -0,0 +1,195 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package abiregistry
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+	"github.com/iotexproject/iotex-core/v2/state"
+)
+
+const (
+	_protocolID           = "abiregistry"
+	_abiRegistryNamespace = "SystemABI"
+)
+
+var _contractListKey = []byte("contracts")
+
+// Protocol defines the protocol of the registry of the ABIs of the system contracts. The versions of the ABIs are
+// written into the state at their activation heights, so the explorers and the indexers read the ABI effective at a
+// height from the state to decode the executions and the event logs of the system contracts, instead of hardcoding it
+type Protocol struct {
+	contracts []*Contract
+}
+
+// NewProtocol instantiates the registry of the system contracts
+func NewProtocol(contracts ...*Contract) (*Protocol, error) {
+	for _, c := range contracts {
+		if c.Address == nil || c.ActivationHeight == 0 {
+			return nil, errors.Errorf("invalid system contract %s", c.Name)
+		}
+		if _, err := JoinABI(c.ABI); err != nil {
+			return nil, errors.Wrapf(err, "invalid ABI of system contract %s", c.Name)
+		}
+	}
+	return &Protocol{contracts: contracts}, nil
+}
+
+// FindProtocol finds the registered protocol from registry
+func FindProtocol(registry *protocol.Registry) *Protocol {
+	if registry == nil {
+		return nil
+	}
+	p, ok := registry.Find(_protocolID)
+	if !ok {
+		return nil
+	}
+	rp, ok := p.(*Protocol)
+	if !ok {
+		log.S().Panic("fail to cast abi registry protocol")
+	}
+	return rp
+}
+
+// CreatePreStates registers the versions of the ABIs activated at the height
+func (p *Protocol) CreatePreStates(ctx context.Context, sm protocol.StateManager) error {
+	height := protocol.MustGetBlockCtx(ctx).BlockHeight
+	for _, c := range p.contracts {
+		if c.ActivationHeight != height {
+			continue
+		}
+		if err := register(sm, c); err != nil {
+			return errors.Wrapf(err, "failed to register system contract %s", c.Name)
+		}
+	}
+	return nil
+}
+
+// Handle handles nothing, the registry is only updated at the activation heights
+func (p *Protocol) Handle(context.Context, action.Envelope, protocol.StateManager) (*action.Receipt, error) {
+	return nil, nil
+}
+
+// ReadState read the state on blockchain via protocol
+func (p *Protocol) ReadState(ctx context.Context, sr protocol.StateReader, method []byte, args ...[]byte) ([]byte, uint64, error) {
+	switch string(method) {
+	case "SystemContracts":
+		l, height, err := ReadContractList(sr)
+		if err != nil {
+			return nil, 0, err
+		}
+		data, err := l.Serialize()
+		return data, height, err
+	case "ContractABI":
+		if len(args) != 2 {
+			return nil, 0, errors.Errorf("invalid number of arguments %d", len(args))
+		}
+		addr, err := address.FromString(string(args[0]))
+		if err != nil {
+			return nil, 0, err
+		}
+		h, err := strconv.ParseUint(string(args[1]), 10, 64)
+		if err != nil {
+			return nil, 0, err
+		}
+		_, v, height, err := ReadContractABI(sr, addr, h)
+		if err != nil {
+			return nil, 0, err
+		}
+		return v.ABI, height, nil
+	default:
+		return nil, 0, errors.New("corresponding method isn't found")
+	}
+}
+
+// Register registers the protocol with a unique ID
+func (p *Protocol) Register(r *protocol.Registry) error {
+	return r.Register(_protocolID, p)
+}
+
+// ForceRegister registers the protocol with a unique ID and force replacing the previous protocol if it exists
+func (p *Protocol) ForceRegister(r *protocol.Registry) error {
+	return r.ForceRegister(_protocolID, p)
+}
+
+// Name returns the name of protocol
+func (p *Protocol) Name() string {
+	return _protocolID
+}
+
+// ReadContractList reads the addresses of the registered system contracts
+func ReadContractList(sr protocol.StateReader) (ContractList, uint64, error) {
+	var l ContractList
+	height, err := sr.State(&l, protocol.KeyOption(_contractListKey), protocol.NamespaceOption(_abiRegistryNamespace))
+	switch errors.Cause(err) {
+	case nil, state.ErrStateNotExist:
+		return l, height, nil
+	default:
+		return nil, 0, err
+	}
+}
+
+// ReadContractABIs reads the registered versions of the ABI of the system contract
+func ReadContractABIs(sr protocol.StateReader, addr address.Address) (*ContractABIs, uint64, error) {
+	abis := &ContractABIs{}
+	height, err := sr.State(abis, protocol.KeyOption(addr.Bytes()), protocol.NamespaceOption(_abiRegistryNamespace))
+	if err != nil {
+		return nil, 0, err
+	}
+	return abis, height, nil
+}
+
+// ReadContractABI reads the version of the ABI of the system contract effective at the height, it returns
+// state.ErrStateNotExist if the address is not a system contract or the ABI is not activated yet at the height
+func ReadContractABI(sr protocol.StateReader, addr address.Address, height uint64) (string, *ABIVersion, uint64, error) {
+	abis, stateHeight, err := ReadContractABIs(sr, addr)
+	if err != nil {
+		return "", nil, 0, err
+	}
+	v := abis.At(height)
+	if v == nil {
+		return "", nil, 0, errors.Wrapf(state.ErrStateNotExist, "ABI of %s is not activated at height %d", addr.String(), height)
+	}
+	return abis.Name, v, stateHeight, nil
+}
+
+func register(sm protocol.StateManager, c *Contract) error {
+	abis, _, err := ReadContractABIs(sm, c.Address)
+	switch errors.Cause(err) {
+	case nil:
+	case state.ErrStateNotExist:
+		l, _, err := ReadContractList(sm)
+		if err != nil {
+			return err
+		}
+		l = append(l, c.Address)
+		if _, err := sm.PutState(l, protocol.KeyOption(_contractListKey), protocol.NamespaceOption(_abiRegistryNamespace)); err != nil {
+			return err
+		}
+		abis = &ContractABIs{}
+	default:
+		return err
+	}
+	abiJSON, err := JoinABI(c.ABI)
+	if err != nil {
+		return err
+	}
+	abis.Name = c.Name
+	abis.Versions = append(abis.Versions, &ABIVersion{
+		Version:          uint32(len(abis.Versions) + 1),
+		ActivationHeight: c.ActivationHeight,
+		ABI:              []byte(abiJSON),
+	})
+	_, err = sm.PutState(abis, protocol.KeyOption(c.Address.Bytes()), protocol.NamespaceOption(_abiRegistryNamespace))
+	return err
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package abiregistry
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/state"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+	"github.com/iotexproject/iotex-core/v2/testutil/testdb"
+)
+
+const (
+	_testMethodABI = `[
+		{
+			"inputs": [{"internalType": "uint64", "name": "value", "type": "uint64"}],
+			"name": "set",
+			"outputs": [],
+			"stateMutability": "nonpayable",
+			"type": "function"
+		}
+	]`
+	_testEventABI = `[
+		{
+			"anonymous": false,
+			"inputs": [{"indexed": true, "internalType": "uint64", "name": "value", "type": "uint64"}],
+			"name": "Set",
+			"type": "event"
+		}
+	]`
+)
+
+func TestJoinABI(t *testing.T) {
+	require := require.New(t)
+
+	s, err := JoinABI(_testMethodABI, _testEventABI)
+	require.NoError(err)
+	require.NotContains(s, " ")
+	require.Contains(s, `"name":"set"`)
+	require.Contains(s, `"name":"Set"`)
+	_, err = JoinABI(`{"name": "set"}`)
+	require.Error(err)
+	_, err = JoinABI(`[{"type": "unknown"}]`)
+	require.Error(err)
+	require.Panics(func() { MustJoinABI("[") })
+}
+
+func TestContractABIsSerialization(t *testing.T) {
+	require := require.New(t)
+
+	abis := &ContractABIs{
+		Name: "test",
+		Versions: []*ABIVersion{
+			{Version: 1, ActivationHeight: 10, ABI: []byte(_testMethodABI)},
+			{Version: 2, ActivationHeight: 20, ABI: []byte(_testEventABI)},
+		},
+	}
+	require.Nil(abis.At(9))
+	require.EqualValues(1, abis.At(19).Version)
+	require.EqualValues(2, abis.At(20).Version)
+	buf, err := abis.Serialize()
+	require.NoError(err)
+	abis2 := &ContractABIs{}
+	require.NoError(abis2.Deserialize(buf))
+	require.Equal(abis, abis2)
+	require.Error(abis2.Deserialize(buf[:len(buf)-1]))
+
+	l := ContractList{identityset.Address(1), identityset.Address(2)}
+	buf, err = l.Serialize()
+	require.NoError(err)
+	var l2 ContractList
+	require.NoError(l2.Deserialize(buf))
+	require.Equal(l, l2)
+	require.Error(l2.Deserialize(buf[1:]))
+}
+
+func TestProtocol(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm := testdb.NewMockStateManager(ctrl)
+
+	_, err := NewProtocol(&Contract{Name: "test", Address: identityset.Address(1), ABI: _testMethodABI})
+	require.Error(err)
+	_, err = NewProtocol(&Contract{Name: "test", Address: identityset.Address(1), ABI: "[", ActivationHeight: 5})
+	require.Error(err)
+	var (
+		addr1 = identityset.Address(1)
+		addr2 = identityset.Address(2)
+	)
+	p, err := NewProtocol(
+		&Contract{Name: "first", Address: addr1, ABI: _testMethodABI, ActivationHeight: 5},
+		&Contract{Name: "second", Address: addr2, ABI: _testEventABI, ActivationHeight: 5},
+		&Contract{Name: "first", Address: addr1, ABI: MustJoinABI(_testMethodABI, _testEventABI), ActivationHeight: 8},
+	)
+	require.NoError(err)
+	for height := uint64(1); height <= 10; height++ {
+		ctx := protocol.WithBlockCtx(context.Background(), protocol.BlockCtx{BlockHeight: height})
+		require.NoError(p.CreatePreStates(ctx, sm))
+	}
+
+	l, _, err := ReadContractList(sm)
+	require.NoError(err)
+	require.Equal(ContractList{addr1, addr2}, l)
+	data, _, err := p.ReadState(context.Background(), sm, []byte("SystemContracts"))
+	require.NoError(err)
+	var l2 ContractList
+	require.NoError(l2.Deserialize(data))
+	require.Equal(l, l2)
+
+	abis, _, err := ReadContractABIs(sm, addr1)
+	require.NoError(err)
+	require.Len(abis.Versions, 2)
+	_, _, _, err = ReadContractABI(sm, addr1, 4)
+	require.Equal(state.ErrStateNotExist, errors.Cause(err))
+	name, v, _, err := ReadContractABI(sm, addr1, 7)
+	require.NoError(err)
+	require.Equal("first", name)
+	require.EqualValues(1, v.Version)
+	require.Equal(MustJoinABI(_testMethodABI), string(v.ABI))
+	data, _, err = p.ReadState(context.Background(), sm, []byte("ContractABI"), []byte(addr1.String()), []byte(strconv.Itoa(8)))
+	require.NoError(err)
+	require.Equal(MustJoinABI(_testMethodABI, _testEventABI), string(data))
+	_, _, err = p.ReadState(context.Background(), sm, []byte("ContractABI"), []byte(identityset.Address(3).String()), []byte("8"))
+	require.Equal(state.ErrStateNotExist, errors.Cause(err))
+	_, _, err = p.ReadState(context.Background(), sm, []byte("ContractABI"), []byte(addr1.String()))
+	require.Error(err)
+}
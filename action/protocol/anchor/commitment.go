@@ -0,0 +1,203 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package anchor
+
+import (
+	"encoding/binary"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+)
+
+const (
+	_eventsABI = `[
+		{
+			"anonymous": false,
+			"inputs": [
+				{"indexed": true, "internalType": "uint64", "name": "projectID", "type": "uint64"},
+				{"indexed": true, "internalType": "address", "name": "owner", "type": "address"}
+			],
+			"name": "ProjectRegistered",
+			"type": "event"
+		},
+		{
+			"anonymous": false,
+			"inputs": [
+				{"indexed": true, "internalType": "uint64", "name": "projectID", "type": "uint64"},
+				{"indexed": true, "internalType": "address", "name": "device", "type": "address"},
+				{"indexed": false, "internalType": "bool", "name": "authorized", "type": "bool"}
+			],
+			"name": "DeviceUpdated",
+			"type": "event"
+		},
+		{
+			"anonymous": false,
+			"inputs": [
+				{"indexed": true, "internalType": "uint64", "name": "projectID", "type": "uint64"},
+				{"indexed": true, "internalType": "uint64", "name": "seq", "type": "uint64"},
+				{"indexed": true, "internalType": "address", "name": "submitter", "type": "address"},
+				{"indexed": false, "internalType": "bytes32", "name": "root", "type": "bytes32"},
+				{"indexed": false, "internalType": "uint32", "name": "leafCount", "type": "uint32"}
+			],
+			"name": "DataAnchored",
+			"type": "event"
+		}
+	]`
+
+	_projectLength    = common.AddressLength + 8
+	_commitmentLength = common.HashLength + 4 + 8 + common.AddressLength
+)
+
+var (
+	_events               abi.ABI
+	_projectRegisteredEvt abi.Event
+	_deviceUpdatedEvt     abi.Event
+	_dataAnchoredEvt      abi.Event
+)
+
+func init() {
+	var err error
+	_events, err = abi.JSON(strings.NewReader(_eventsABI))
+	if err != nil {
+		panic(err)
+	}
+	_projectRegisteredEvt = _events.Events["ProjectRegistered"]
+	_deviceUpdatedEvt = _events.Events["DeviceUpdated"]
+	_dataAnchoredEvt = _events.Events["DataAnchored"]
+}
+
+type (
+	// Project is a DePIN project anchoring the data of its devices
+	Project struct {
+		Owner common.Address
+		// Commitments is the number of commitments anchored by the project
+		Commitments uint64
+	}
+
+	// Commitment is a batch of device data committed by its merkle root
+	Commitment struct {
+		Root      hash.Hash256
+		LeafCount uint32
+		Height    uint64
+		Submitter common.Address
+	}
+
+	// deviceRecord marks a device authorized to anchor the data of a project
+	deviceRecord struct{}
+
+	// projectCounter is the number of registered projects
+	projectCounter struct {
+		Count uint64
+	}
+)
+
+// Serialize serializes the project into bytes
+func (p *Project) Serialize() ([]byte, error) {
+	buf := make([]byte, 0, _projectLength)
+	buf = append(buf, p.Owner.Bytes()...)
+	return binary.BigEndian.AppendUint64(buf, p.Commitments), nil
+}
+
+// Deserialize deserializes bytes into the project
+func (p *Project) Deserialize(buf []byte) error {
+	if len(buf) != _projectLength {
+		return errors.Errorf("invalid project length %d", len(buf))
+	}
+	p.Owner = common.BytesToAddress(buf[:common.AddressLength])
+	p.Commitments = binary.BigEndian.Uint64(buf[common.AddressLength:])
+	return nil
+}
+
+// Serialize serializes the commitment into bytes
+func (c *Commitment) Serialize() ([]byte, error) {
+	buf := make([]byte, 0, _commitmentLength)
+	buf = append(buf, c.Root[:]...)
+	buf = binary.BigEndian.AppendUint32(buf, c.LeafCount)
+	buf = binary.BigEndian.AppendUint64(buf, c.Height)
+	return append(buf, c.Submitter.Bytes()...), nil
+}
+
+// Deserialize deserializes bytes into the commitment
+func (c *Commitment) Deserialize(buf []byte) error {
+	if len(buf) != _commitmentLength {
+		return errors.Errorf("invalid commitment length %d", len(buf))
+	}
+	copy(c.Root[:], buf[:common.HashLength])
+	buf = buf[common.HashLength:]
+	c.LeafCount = binary.BigEndian.Uint32(buf)
+	c.Height = binary.BigEndian.Uint64(buf[4:])
+	c.Submitter = common.BytesToAddress(buf[12:])
+	return nil
+}
+
+// DeserializeCommitments deserializes the commitments returned by reading the state of the project history
+func DeserializeCommitments(buf []byte) ([]*Commitment, error) {
+	if len(buf)%_commitmentLength != 0 {
+		return nil, errors.Errorf("invalid commitments length %d", len(buf))
+	}
+	ret := make([]*Commitment, 0, len(buf)/_commitmentLength)
+	for i := 0; i < len(buf); i += _commitmentLength {
+		c := &Commitment{}
+		if err := c.Deserialize(buf[i : i+_commitmentLength]); err != nil {
+			return nil, err
+		}
+		ret = append(ret, c)
+	}
+	return ret, nil
+}
+
+// Serialize serializes the device record into bytes
+func (d *deviceRecord) Serialize() ([]byte, error) {
+	return []byte{1}, nil
+}
+
+// Deserialize deserializes bytes into the device record
+func (d *deviceRecord) Deserialize(buf []byte) error {
+	if len(buf) != 1 || buf[0] != 1 {
+		return errors.New("invalid device record")
+	}
+	return nil
+}
+
+// Serialize serializes the counter into bytes
+func (c *projectCounter) Serialize() ([]byte, error) {
+	return binary.BigEndian.AppendUint64(nil, c.Count), nil
+}
+
+// Deserialize deserializes bytes into the counter
+func (c *projectCounter) Deserialize(buf []byte) error {
+	if len(buf) != 8 {
+		return errors.Errorf("invalid project counter length %d", len(buf))
+	}
+	c.Count = binary.BigEndian.Uint64(buf)
+	return nil
+}
+
+func uint64Topic(v uint64) hash.Hash256 {
+	return hash.Hash256(common.BigToHash(new(big.Int).SetUint64(v)))
+}
+
+func addressTopic(addr common.Address) hash.Hash256 {
+	return hash.Hash256(common.BytesToHash(addr.Bytes()))
+}
+
+func newLog(contract string, event abi.Event, topics []hash.Hash256, data ...interface{}) (*action.Log, error) {
+	buf, err := event.Inputs.NonIndexed().Pack(data...)
+	if err != nil {
+		return nil, err
+	}
+	return &action.Log{
+		Address: contract,
+		Topics:  append([]hash.Hash256{hash.Hash256(event.ID)}, topics...),
+		Data:    buf,
+	}, nil
+}
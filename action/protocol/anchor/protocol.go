@@ -0,0 +1,487 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package anchor
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/abiregistry"
+	accountutil "github.com/iotexproject/iotex-core/v2/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+	"github.com/iotexproject/iotex-core/v2/state"
+)
+
+const (
+	_protocolID      = "anchor"
+	_anchorNamespace = "DataAnchor"
+
+	// AnchorGas is the gas charged for anchoring a commitment, which is much lower than the intrinsic gas of an
+	// execution carrying the same call, so that the devices could anchor their data frequently
+	AnchorGas = uint64(5000)
+	// MaxCommitmentsPerRead is the maximum number of commitments returned by reading the project history
+	MaxCommitmentsPerRead = 1000
+
+	_methodsABI = `[
+		{
+			"inputs": [],
+			"name": "registerProject",
+			"outputs": [],
+			"stateMutability": "nonpayable",
+			"type": "function"
+		},
+		{
+			"inputs": [
+				{"internalType": "uint64", "name": "projectID", "type": "uint64"},
+				{"internalType": "address", "name": "device", "type": "address"},
+				{"internalType": "bool", "name": "authorized", "type": "bool"}
+			],
+			"name": "setDevice",
+			"outputs": [],
+			"stateMutability": "nonpayable",
+			"type": "function"
+		},
+		{
+			"inputs": [
+				{"internalType": "uint64", "name": "projectID", "type": "uint64"},
+				{"internalType": "bytes32", "name": "root", "type": "bytes32"},
+				{"internalType": "uint32", "name": "leafCount", "type": "uint32"}
+			],
+			"name": "anchor",
+			"outputs": [],
+			"stateMutability": "nonpayable",
+			"type": "function"
+		}
+	]`
+)
+
+var (
+	_projectCounterKey  = []byte("cnt")
+	_projectPrefix      = []byte("prj")
+	_devicePrefix       = []byte("dev")
+	_commitmentPrefix   = []byte("cmt")
+	_anchorMethodPrefix []byte
+
+	_methods abi.ABI
+
+	// ErrProjectNotExist indicates the project is not registered
+	ErrProjectNotExist = errors.New("project does not exist")
+	// ErrUnauthorized indicates the caller is neither the owner nor a device of the project
+	ErrUnauthorized = errors.New("caller is not authorized by the project")
+)
+
+func init() {
+	var err error
+	_methods, err = abi.JSON(strings.NewReader(_methodsABI))
+	if err != nil {
+		panic(err)
+	}
+	_anchorMethodPrefix = _methods.Methods["anchor"].ID
+}
+
+// Protocol defines the protocol of anchoring the data of DePIN devices. The owner registers a project by calling
+// registerProject on the protocol address and authorizes its devices by calling setDevice, then the owner and the
+// devices anchor the merkle roots of the batched device data by calling anchor, which is charged AnchorGas only.
+// The commitments of a project are indexed by their sequence numbers, and emitted in the DataAnchored event logs
+type Protocol struct {
+	addr       address.Address
+	depositGas protocol.DepositGas
+}
+
+// NewProtocol instantiates the data anchoring protocol
+func NewProtocol(depositGas protocol.DepositGas) *Protocol {
+	return &Protocol{
+		addr:       ProtocolAddr(),
+		depositGas: depositGas,
+	}
+}
+
+// ContractABI returns the ABI of the methods and the events of the anchor as a system contract
+func ContractABI() string {
+	return abiregistry.MustJoinABI(_methodsABI, _eventsABI)
+}
+
+// ProtocolAddr returns the address generated from protocol id
+func ProtocolAddr() address.Address {
+	return protocol.HashStringToAddress(_protocolID)
+}
+
+// FindProtocol finds the registered protocol from registry
+func FindProtocol(registry *protocol.Registry) *Protocol {
+	if registry == nil {
+		return nil
+	}
+	p, ok := registry.Find(_protocolID)
+	if !ok {
+		return nil
+	}
+	ap, ok := p.(*Protocol)
+	if !ok {
+		log.S().Panic("fail to cast anchor protocol")
+	}
+	return ap
+}
+
+// Validate validates a data anchoring action
+func (p *Protocol) Validate(ctx context.Context, elp action.Envelope, _ protocol.StateReader) error {
+	exec, ok := p.anchorExecution(ctx, elp)
+	if !ok {
+		return nil
+	}
+	if exec.Amount().Sign() != 0 {
+		return errors.Wrap(action.ErrInvalidAct, "anchor action cannot transfer value")
+	}
+	if _, _, err := unpackMethod(exec.Data()); err != nil {
+		return errors.Wrap(action.ErrInvalidAct, err.Error())
+	}
+	return nil
+}
+
+// Handle handles the actions on the data anchoring protocol
+func (p *Protocol) Handle(ctx context.Context, elp action.Envelope, sm protocol.StateManager) (*action.Receipt, error) {
+	exec, ok := p.anchorExecution(ctx, elp)
+	if !ok {
+		return nil, nil
+	}
+	gas := protocol.MustGetActionCtx(ctx).IntrinsicGas
+	if IsAnchorCall(exec.Data()) && gas > AnchorGas {
+		gas = AnchorGas
+	}
+	si := sm.Snapshot()
+	logs, err := p.handle(ctx, sm, exec.Data())
+	if err != nil {
+		log.L().Debug("Error when handling anchor action", zap.Error(err))
+		return p.settleAction(ctx, sm, elp, uint64(iotextypes.ReceiptStatus_Failure), si, gas, nil)
+	}
+	return p.settleAction(ctx, sm, elp, uint64(iotextypes.ReceiptStatus_Success), si, gas, logs)
+}
+
+// IsAnchorCall returns true if the data calls anchor, which is charged AnchorGas
+func IsAnchorCall(data []byte) bool {
+	return len(data) >= 4 && bytes.Equal(data[:4], _anchorMethodPrefix)
+}
+
+func (p *Protocol) anchorExecution(ctx context.Context, elp action.Envelope) (*action.Execution, bool) {
+	exec, ok := elp.Action().(*action.Execution)
+	if !ok || exec.Contract() != p.addr.String() {
+		return nil, false
+	}
+	return exec, protocol.MustGetFeatureCtx(ctx).EnableDataAnchor
+}
+
+func unpackMethod(data []byte) (*abi.Method, []interface{}, error) {
+	if len(data) < 4 {
+		return nil, nil, errors.New("invalid anchor call data")
+	}
+	method, err := _methods.MethodById(data[:4])
+	if err != nil {
+		return nil, nil, err
+	}
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to unpack arguments of %s", method.Name)
+	}
+	return method, args, nil
+}
+
+func (p *Protocol) handle(ctx context.Context, sm protocol.StateManager, data []byte) ([]*action.Log, error) {
+	method, args, err := unpackMethod(data)
+	if err != nil {
+		return nil, err
+	}
+	var lg *action.Log
+	switch method.Name {
+	case "registerProject":
+		lg, err = p.registerProject(ctx, sm)
+	case "setDevice":
+		lg, err = p.setDevice(ctx, sm, args[0].(uint64), args[1].(common.Address), args[2].(bool))
+	case "anchor":
+		lg, err = p.anchor(ctx, sm, args[0].(uint64), args[1].([32]byte), args[2].(uint32))
+	}
+	if err != nil {
+		return nil, err
+	}
+	var (
+		blkCtx    = protocol.MustGetBlockCtx(ctx)
+		actionCtx = protocol.MustGetActionCtx(ctx)
+	)
+	lg.BlockHeight = blkCtx.BlockHeight
+	lg.ActionHash = actionCtx.ActionHash
+	return []*action.Log{lg}, nil
+}
+
+// registerProject registers a project owned by the caller, the project ids start from 1
+func (p *Protocol) registerProject(ctx context.Context, sm protocol.StateManager) (*action.Log, error) {
+	var (
+		caller  = common.BytesToAddress(protocol.MustGetActionCtx(ctx).Caller.Bytes())
+		counter = &projectCounter{}
+	)
+	if _, err := p.state(sm, _projectCounterKey, counter); err != nil && errors.Cause(err) != state.ErrStateNotExist {
+		return nil, err
+	}
+	counter.Count++
+	if err := p.putState(sm, uint64Key(_projectPrefix, counter.Count), &Project{Owner: caller}); err != nil {
+		return nil, err
+	}
+	if err := p.putState(sm, _projectCounterKey, counter); err != nil {
+		return nil, err
+	}
+	return newLog(p.addr.String(), _projectRegisteredEvt, []hash.Hash256{uint64Topic(counter.Count), addressTopic(caller)})
+}
+
+// setDevice authorizes or deauthorizes a device to anchor the data of the project
+func (p *Protocol) setDevice(ctx context.Context, sm protocol.StateManager, id uint64, device common.Address, authorized bool) (*action.Log, error) {
+	project, err := p.project(sm, id)
+	if err != nil {
+		return nil, err
+	}
+	caller := common.BytesToAddress(protocol.MustGetActionCtx(ctx).Caller.Bytes())
+	if caller != project.Owner {
+		return nil, errors.Wrapf(ErrUnauthorized, "%s is not the owner of project %d", caller.Hex(), id)
+	}
+	key := deviceKey(id, device)
+	if authorized {
+		err = p.putState(sm, key, &deviceRecord{})
+	} else {
+		_, err = sm.DelState(protocol.KeyOption(key), protocol.NamespaceOption(_anchorNamespace))
+		if errors.Cause(err) == state.ErrStateNotExist {
+			err = nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return newLog(p.addr.String(), _deviceUpdatedEvt, []hash.Hash256{uint64Topic(id), addressTopic(device)}, authorized)
+}
+
+// anchor appends a commitment to the history of the project
+func (p *Protocol) anchor(ctx context.Context, sm protocol.StateManager, id uint64, root [32]byte, leafCount uint32) (*action.Log, error) {
+	if leafCount == 0 {
+		return nil, errors.New("commitment of no leaf")
+	}
+	project, err := p.project(sm, id)
+	if err != nil {
+		return nil, err
+	}
+	caller := common.BytesToAddress(protocol.MustGetActionCtx(ctx).Caller.Bytes())
+	if caller != project.Owner {
+		if _, err := p.state(sm, deviceKey(id, caller), &deviceRecord{}); err != nil {
+			if errors.Cause(err) == state.ErrStateNotExist {
+				return nil, errors.Wrapf(ErrUnauthorized, "%s is not a device of project %d", caller.Hex(), id)
+			}
+			return nil, err
+		}
+	}
+	seq := project.Commitments
+	if err := p.putState(sm, commitmentKey(id, seq), &Commitment{
+		Root:      root,
+		LeafCount: leafCount,
+		Height:    protocol.MustGetBlockCtx(ctx).BlockHeight,
+		Submitter: caller,
+	}); err != nil {
+		return nil, err
+	}
+	project.Commitments++
+	if err := p.putState(sm, uint64Key(_projectPrefix, id), project); err != nil {
+		return nil, err
+	}
+	return newLog(
+		p.addr.String(),
+		_dataAnchoredEvt,
+		[]hash.Hash256{uint64Topic(id), uint64Topic(seq), addressTopic(caller)},
+		root, leafCount,
+	)
+}
+
+func (p *Protocol) project(sr protocol.StateReader, id uint64) (*Project, error) {
+	project := &Project{}
+	if _, err := p.state(sr, uint64Key(_projectPrefix, id), project); err != nil {
+		if errors.Cause(err) == state.ErrStateNotExist {
+			return nil, errors.Wrapf(ErrProjectNotExist, "project %d", id)
+		}
+		return nil, err
+	}
+	return project, nil
+}
+
+// ReadState read the state on blockchain via protocol
+func (p *Protocol) ReadState(ctx context.Context, sr protocol.StateReader, method []byte, args ...[]byte) ([]byte, uint64, error) {
+	switch string(method) {
+	case "Project":
+		if len(args) != 1 {
+			return nil, 0, errors.Errorf("invalid number of arguments %d", len(args))
+		}
+		id, err := strconv.ParseUint(string(args[0]), 10, 64)
+		if err != nil {
+			return nil, 0, err
+		}
+		return p.readState(sr, uint64Key(_projectPrefix, id), &Project{})
+	case "Commitments":
+		// the commitments of the project with the sequence numbers in [start, start+count)
+		if len(args) != 3 {
+			return nil, 0, errors.Errorf("invalid number of arguments %d", len(args))
+		}
+		var params [3]uint64
+		for i := range args {
+			v, err := strconv.ParseUint(string(args[i]), 10, 64)
+			if err != nil {
+				return nil, 0, err
+			}
+			params[i] = v
+		}
+		return p.readCommitments(sr, params[0], params[1], params[2])
+	default:
+		return nil, 0, errors.New("corresponding method isn't found")
+	}
+}
+
+func (p *Protocol) readState(sr protocol.StateReader, key []byte, value state.Serializer) ([]byte, uint64, error) {
+	height, err := p.state(sr, key, value)
+	if err != nil {
+		return nil, 0, err
+	}
+	data, err := value.Serialize()
+	return data, height, err
+}
+
+func (p *Protocol) readCommitments(sr protocol.StateReader, id, start, count uint64) ([]byte, uint64, error) {
+	if count == 0 || count > MaxCommitmentsPerRead {
+		return nil, 0, errors.Errorf("invalid count %d, should be in (0, %d]", count, MaxCommitmentsPerRead)
+	}
+	project, err := p.project(sr, id)
+	if err != nil {
+		return nil, 0, err
+	}
+	if start >= project.Commitments {
+		return nil, 0, errors.Wrapf(state.ErrStateNotExist, "project %d has %d commitments", id, project.Commitments)
+	}
+	if start+count > project.Commitments {
+		count = project.Commitments - start
+	}
+	var (
+		ret    = make([]byte, 0, count*_commitmentLength)
+		height uint64
+	)
+	for seq := start; seq < start+count; seq++ {
+		c := &Commitment{}
+		height, err = p.state(sr, commitmentKey(id, seq), c)
+		if err != nil {
+			return nil, 0, err
+		}
+		data, err := c.Serialize()
+		if err != nil {
+			return nil, 0, err
+		}
+		ret = append(ret, data...)
+	}
+	return ret, height, nil
+}
+
+// Register registers the protocol with a unique ID
+func (p *Protocol) Register(r *protocol.Registry) error {
+	return r.Register(_protocolID, p)
+}
+
+// ForceRegister registers the protocol with a unique ID and force replacing the previous protocol if it exists
+func (p *Protocol) ForceRegister(r *protocol.Registry) error {
+	return r.ForceRegister(_protocolID, p)
+}
+
+// Name returns the name of protocol
+func (p *Protocol) Name() string {
+	return _protocolID
+}
+
+func (p *Protocol) state(sr protocol.StateReader, key []byte, value interface{}) (uint64, error) {
+	return sr.State(value, protocol.KeyOption(key), protocol.NamespaceOption(_anchorNamespace))
+}
+
+func (p *Protocol) putState(sm protocol.StateManager, key []byte, value interface{}) error {
+	_, err := sm.PutState(value, protocol.KeyOption(key), protocol.NamespaceOption(_anchorNamespace))
+	return err
+}
+
+func (p *Protocol) settleAction(
+	ctx context.Context,
+	sm protocol.StateManager,
+	elp action.Envelope,
+	status uint64,
+	si int,
+	gas uint64,
+	logs []*action.Log,
+) (*action.Receipt, error) {
+	var (
+		actionCtx = protocol.MustGetActionCtx(ctx)
+		blkCtx    = protocol.MustGetBlockCtx(ctx)
+		fCtx      = protocol.MustGetFeatureCtx(ctx)
+		tLogs     []*action.TransactionLog
+	)
+	if status == uint64(iotextypes.ReceiptStatus_Failure) {
+		if err := sm.Revert(si); err != nil {
+			return nil, err
+		}
+	}
+	priorityFee, baseFee, err := protocol.SplitGas(ctx, elp, gas)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to split gas")
+	}
+	if p.depositGas != nil {
+		tLogs, err = p.depositGas(ctx, sm, baseFee, protocol.PriorityFeeOption(priorityFee))
+		if err != nil {
+			return nil, err
+		}
+	}
+	accountCreationOpts := []state.AccountCreationOption{}
+	if fCtx.CreateLegacyNonceAccount {
+		accountCreationOpts = append(accountCreationOpts, state.LegacyNonceAccountTypeOption())
+	}
+	acc, err := accountutil.LoadOrCreateAccount(sm, actionCtx.Caller, accountCreationOpts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := acc.SetPendingNonce(actionCtx.Nonce + 1); err != nil {
+		return nil, errors.Wrapf(err, "invalid nonce %d", actionCtx.Nonce)
+	}
+	if err := accountutil.StoreAccount(sm, actionCtx.Caller, acc); err != nil {
+		return nil, err
+	}
+	return (&action.Receipt{
+		Status:            status,
+		BlockHeight:       blkCtx.BlockHeight,
+		ActionHash:        actionCtx.ActionHash,
+		GasConsumed:       gas,
+		ContractAddress:   p.addr.String(),
+		EffectiveGasPrice: protocol.EffectiveGasPrice(ctx, elp),
+	}).AddLogs(logs...).AddTransactionLogs(tLogs...), nil
+}
+
+func uint64Key(prefix []byte, v uint64) []byte {
+	key := make([]byte, len(prefix)+8)
+	copy(key, prefix)
+	binary.BigEndian.PutUint64(key[len(prefix):], v)
+	return key
+}
+
+func deviceKey(id uint64, device common.Address) []byte {
+	return append(uint64Key(_devicePrefix, id), device.Bytes()...)
+}
+
+func commitmentKey(id, seq uint64) []byte {
+	return binary.BigEndian.AppendUint64(uint64Key(_commitmentPrefix, id), seq)
+}
@@ -0,0 +1,179 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package anchor
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+	"github.com/iotexproject/iotex-core/v2/testutil/testdb"
+)
+
+func TestCommitment(t *testing.T) {
+	require := require.New(t)
+
+	c := &Commitment{
+		Root:      hash.Hash256b([]byte("root")),
+		LeafCount: 16,
+		Height:    10,
+		Submitter: common.HexToAddress("0x01"),
+	}
+	buf, err := c.Serialize()
+	require.NoError(err)
+	c2 := &Commitment{}
+	require.NoError(c2.Deserialize(buf))
+	require.Equal(c, c2)
+	cs, err := DeserializeCommitments(append(buf, buf...))
+	require.NoError(err)
+	require.Equal([]*Commitment{c, c}, cs)
+	_, err = DeserializeCommitments(buf[1:])
+	require.Error(err)
+
+	p := &Project{Owner: common.HexToAddress("0x02"), Commitments: 3}
+	buf, err = p.Serialize()
+	require.NoError(err)
+	p2 := &Project{}
+	require.NoError(p2.Deserialize(buf))
+	require.Equal(p, p2)
+}
+
+func TestProtocol(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm := testdb.NewMockStateManager(ctrl)
+	// the failed actions do not write the states before failing
+	sm.EXPECT().Revert(gomock.Any()).Return(nil).AnyTimes()
+
+	var (
+		owner  = identityset.Address(1)
+		device = identityset.Address(2)
+		g      = genesis.TestDefault()
+		p      = NewProtocol(nil)
+	)
+	g.ToBeEnabledBlockHeight = 1
+	nonces := map[int]uint64{}
+	call := func(height uint64, caller int, data []byte) *action.Receipt {
+		nonce := nonces[caller]
+		elp := (&action.EnvelopeBuilder{}).SetNonce(nonce).SetGasPrice(big.NewInt(0)).SetGasLimit(100000).
+			SetAction(action.NewExecution(ProtocolAddr().String(), big.NewInt(0), data)).Build()
+		intrinsicGas, err := elp.IntrinsicGas()
+		require.NoError(err)
+		ctx := genesis.WithGenesisContext(context.Background(), g)
+		ctx = protocol.WithBlockCtx(ctx, protocol.BlockCtx{BlockHeight: height})
+		ctx = protocol.WithActionCtx(ctx, protocol.ActionCtx{
+			Caller:       identityset.Address(caller),
+			ActionHash:   hash.Hash256b(data),
+			Nonce:        nonce,
+			IntrinsicGas: intrinsicGas,
+		})
+		ctx = protocol.WithFeatureCtx(ctx)
+		require.NoError(p.Validate(ctx, elp, sm))
+		r, err := p.Handle(ctx, elp, sm)
+		require.NoError(err)
+		if r != nil {
+			nonces[caller]++
+		}
+		return r
+	}
+	pack := func(method string, args ...interface{}) []byte {
+		data, err := _methods.Pack(method, args...)
+		require.NoError(err)
+		return data
+	}
+	root := hash.Hash256b([]byte("batch"))
+
+	t.Run("registerProject", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			r := call(5, 1, pack("registerProject"))
+			require.EqualValues(iotextypes.ReceiptStatus_Success, r.Status)
+			require.Len(r.Logs(), 1)
+			require.Equal(hash.Hash256(_projectRegisteredEvt.ID), r.Logs()[0].Topics[0])
+			require.Equal(uint64Topic(uint64(i+1)), r.Logs()[0].Topics[1])
+		}
+		data, _, err := p.ReadState(context.Background(), sm, []byte("Project"), []byte("2"))
+		require.NoError(err)
+		project := &Project{}
+		require.NoError(project.Deserialize(data))
+		require.Equal(common.BytesToAddress(owner.Bytes()), project.Owner)
+		_, _, err = p.ReadState(context.Background(), sm, []byte("Project"), []byte("3"))
+		require.Error(err)
+	})
+
+	t.Run("setDevice", func(t *testing.T) {
+		deviceAddr := common.BytesToAddress(device.Bytes())
+		// the device cannot anchor before authorized
+		r := call(6, 2, pack("anchor", uint64(1), root, uint32(8)))
+		require.EqualValues(iotextypes.ReceiptStatus_Failure, r.Status)
+		// only the owner could authorize the devices
+		r = call(6, 2, pack("setDevice", uint64(1), deviceAddr, true))
+		require.EqualValues(iotextypes.ReceiptStatus_Failure, r.Status)
+		r = call(6, 1, pack("setDevice", uint64(3), deviceAddr, true))
+		require.EqualValues(iotextypes.ReceiptStatus_Failure, r.Status)
+		r = call(6, 1, pack("setDevice", uint64(1), deviceAddr, true))
+		require.EqualValues(iotextypes.ReceiptStatus_Success, r.Status)
+		require.Equal(hash.Hash256(_deviceUpdatedEvt.ID), r.Logs()[0].Topics[0])
+	})
+
+	t.Run("anchor", func(t *testing.T) {
+		data := pack("anchor", uint64(1), root, uint32(8))
+		require.True(IsAnchorCall(data))
+		r := call(7, 2, data)
+		require.EqualValues(iotextypes.ReceiptStatus_Success, r.Status)
+		// anchoring is charged a flat gas
+		require.Equal(AnchorGas, r.GasConsumed)
+		require.Len(r.Logs(), 1)
+		require.Equal(hash.Hash256(_dataAnchoredEvt.ID), r.Logs()[0].Topics[0])
+		require.Equal(uint64Topic(0), r.Logs()[0].Topics[2])
+		r = call(8, 1, pack("anchor", uint64(1), root, uint32(4)))
+		require.EqualValues(iotextypes.ReceiptStatus_Success, r.Status)
+		// the project 2 does not authorize the device
+		r = call(8, 2, pack("anchor", uint64(2), root, uint32(8)))
+		require.EqualValues(iotextypes.ReceiptStatus_Failure, r.Status)
+		r = call(8, 1, pack("anchor", uint64(1), root, uint32(0)))
+		require.EqualValues(iotextypes.ReceiptStatus_Failure, r.Status)
+
+		buf, _, err := p.ReadState(context.Background(), sm, []byte("Commitments"), []byte("1"), []byte("0"), []byte("10"))
+		require.NoError(err)
+		cs, err := DeserializeCommitments(buf)
+		require.NoError(err)
+		require.Len(cs, 2)
+		require.Equal(&Commitment{Root: root, LeafCount: 8, Height: 7, Submitter: common.BytesToAddress(device.Bytes())}, cs[0])
+		require.Equal(&Commitment{Root: root, LeafCount: 4, Height: 8, Submitter: common.BytesToAddress(owner.Bytes())}, cs[1])
+		buf, _, err = p.ReadState(context.Background(), sm, []byte("Commitments"), []byte("1"), []byte("1"), []byte("1"))
+		require.NoError(err)
+		cs, err = DeserializeCommitments(buf)
+		require.NoError(err)
+		require.Len(cs, 1)
+		require.EqualValues(4, cs[0].LeafCount)
+		_, _, err = p.ReadState(context.Background(), sm, []byte("Commitments"), []byte("1"), []byte("2"), []byte("1"))
+		require.Error(err)
+		_, _, err = p.ReadState(context.Background(), sm, []byte("Commitments"), []byte("1"), []byte("0"), []byte("0"))
+		require.Error(err)
+
+		// deauthorize the device
+		r = call(9, 1, pack("setDevice", uint64(1), common.BytesToAddress(device.Bytes()), false))
+		require.EqualValues(iotextypes.ReceiptStatus_Success, r.Status)
+		r = call(9, 2, data)
+		require.EqualValues(iotextypes.ReceiptStatus_Failure, r.Status)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		g.ToBeEnabledBlockHeight = 100
+		defer func() { g.ToBeEnabledBlockHeight = 1 }()
+		require.Nil(call(10, 1, pack("registerProject")))
+	})
+}
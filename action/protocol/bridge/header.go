@@ -0,0 +1,111 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package bridge
+
+import (
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrInvalidHeader indicates the counterpart header does not extend the tip of the counterpart chain
+	ErrInvalidHeader = errors.New("invalid counterpart header")
+	// ErrInvalidProof indicates the receipt proof does not verify against the counterpart header
+	ErrInvalidProof = errors.New("invalid receipt proof")
+)
+
+// CounterpartHeader is the header of the counterpart chain submitted by the relayers, the receipts root of which the
+// inbound messages are verified against
+type CounterpartHeader struct {
+	Number       uint64
+	Hash         common.Hash
+	ParentHash   common.Hash
+	ReceiptsRoot common.Hash
+}
+
+// decodeCounterpartHeader decodes the RLP encoded header of the counterpart chain
+func decodeCounterpartHeader(buf []byte) (*CounterpartHeader, error) {
+	h := &types.Header{}
+	if err := rlp.DecodeBytes(buf, h); err != nil {
+		return nil, errors.Wrapf(ErrInvalidHeader, "failed to decode header: %v", err)
+	}
+	if !h.Number.IsUint64() {
+		return nil, errors.Wrap(ErrInvalidHeader, "header number overflows")
+	}
+	return &CounterpartHeader{
+		Number:       h.Number.Uint64(),
+		Hash:         h.Hash(),
+		ParentHash:   h.ParentHash,
+		ReceiptsRoot: h.ReceiptHash,
+	}, nil
+}
+
+// extends checks whether the header is the child of the tip
+func (h *CounterpartHeader) extends(tip *CounterpartHeader) bool {
+	return h.Number == tip.Number+1 && h.ParentHash == tip.Hash
+}
+
+// Serialize serializes the header into bytes
+func (h *CounterpartHeader) Serialize() ([]byte, error) {
+	buf := make([]byte, 8, 8+3*common.HashLength)
+	binary.BigEndian.PutUint64(buf, h.Number)
+	buf = append(buf, h.Hash[:]...)
+	buf = append(buf, h.ParentHash[:]...)
+	return append(buf, h.ReceiptsRoot[:]...), nil
+}
+
+// Deserialize deserializes bytes into the header
+func (h *CounterpartHeader) Deserialize(buf []byte) error {
+	if len(buf) != 8+3*common.HashLength {
+		return errors.Errorf("invalid counterpart header length %d", len(buf))
+	}
+	h.Number = binary.BigEndian.Uint64(buf[:8])
+	buf = buf[8:]
+	h.Hash = common.BytesToHash(buf[:common.HashLength])
+	h.ParentHash = common.BytesToHash(buf[common.HashLength : 2*common.HashLength])
+	h.ReceiptsRoot = common.BytesToHash(buf[2*common.HashLength:])
+	return nil
+}
+
+// verifyReceiptLog verifies the Merkle-Patricia proof of the receipt of the tx at txIndex against the receipts root of
+// the header, and returns the log at logIndex of the receipt
+func verifyReceiptLog(h *CounterpartHeader, txIndex uint64, proof [][]byte, logIndex uint64) (*types.Log, error) {
+	key, err := rlp.EncodeToBytes(txIndex)
+	if err != nil {
+		return nil, err
+	}
+	proofDB := memorydb.New()
+	for _, node := range proof {
+		if err := proofDB.Put(crypto.Keccak256(node), node); err != nil {
+			return nil, err
+		}
+	}
+	value, err := trie.VerifyProof(h.ReceiptsRoot, key, proofDB)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidProof, err.Error())
+	}
+	if len(value) == 0 {
+		return nil, errors.Wrapf(ErrInvalidProof, "receipt of tx %d does not exist", txIndex)
+	}
+	receipt := &types.Receipt{}
+	if err := receipt.UnmarshalBinary(value); err != nil {
+		return nil, errors.Wrapf(ErrInvalidProof, "failed to decode receipt: %v", err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, errors.Wrapf(ErrInvalidProof, "tx %d failed", txIndex)
+	}
+	if logIndex >= uint64(len(receipt.Logs)) {
+		return nil, errors.Wrapf(ErrInvalidProof, "log %d does not exist", logIndex)
+	}
+	return receipt.Logs[logIndex], nil
+}
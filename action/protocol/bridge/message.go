@@ -0,0 +1,186 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package bridge
+
+import (
+	"encoding/binary"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+)
+
+const _eventsABI = `[
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "internalType": "uint64", "name": "nonce", "type": "uint64"},
+			{"indexed": true, "internalType": "address", "name": "sender", "type": "address"},
+			{"indexed": true, "internalType": "address", "name": "recipient", "type": "address"},
+			{"indexed": false, "internalType": "uint64", "name": "sourceChainID", "type": "uint64"},
+			{"indexed": false, "internalType": "uint64", "name": "destChainID", "type": "uint64"},
+			{"indexed": false, "internalType": "bytes", "name": "payload", "type": "bytes"}
+		],
+		"name": "MessageSent",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "internalType": "uint64", "name": "nonce", "type": "uint64"},
+			{"indexed": true, "internalType": "address", "name": "sender", "type": "address"},
+			{"indexed": true, "internalType": "address", "name": "recipient", "type": "address"},
+			{"indexed": false, "internalType": "uint64", "name": "sourceChainID", "type": "uint64"},
+			{"indexed": false, "internalType": "uint64", "name": "destChainID", "type": "uint64"},
+			{"indexed": false, "internalType": "bytes", "name": "payload", "type": "bytes"}
+		],
+		"name": "MessageReceived",
+		"type": "event"
+	}
+]`
+
+var (
+	_messageSentEvent     abi.Event
+	_messageReceivedEvent abi.Event
+	// _messageArgs is the layout of the message, the hash of a message is keccak256(abi.encode(...)) of the fields
+	// so that it could be computed by the bridge contract on the counterpart chain
+	_messageArgs abi.Arguments
+	// _messageDataArgs is the layout of the non-indexed fields in the event logs
+	_messageDataArgs abi.Arguments
+
+	// ErrInvalidMessage indicates the message is malformed or not meant for this chain
+	ErrInvalidMessage = errors.New("invalid bridge message")
+)
+
+func init() {
+	events, err := abi.JSON(strings.NewReader(_eventsABI))
+	if err != nil {
+		panic(err)
+	}
+	_messageSentEvent = events.Events["MessageSent"]
+	_messageReceivedEvent = events.Events["MessageReceived"]
+	_messageDataArgs = _messageSentEvent.Inputs.NonIndexed()
+	for _, input := range _messageSentEvent.Inputs {
+		input.Indexed = false
+		_messageArgs = append(_messageArgs, input)
+	}
+}
+
+// Message is a cross-chain message. The outbound messages are emitted by the bridge protocol in the MessageSent event
+// logs, the inbound messages are emitted by the bridge contract on the counterpart chain in the same format
+type Message struct {
+	Nonce         uint64
+	Sender        common.Address
+	Recipient     common.Address
+	SourceChainID uint64
+	DestChainID   uint64
+	Payload       []byte
+}
+
+// Hash returns the hash of the message
+func (m *Message) Hash() (hash.Hash256, error) {
+	b, err := m.Serialize()
+	if err != nil {
+		return hash.ZeroHash256, err
+	}
+	return hash.BytesToHash256(crypto.Keccak256(b)), nil
+}
+
+// Serialize serializes the message into bytes
+func (m *Message) Serialize() ([]byte, error) {
+	return _messageArgs.Pack(m.Nonce, m.Sender, m.Recipient, m.SourceChainID, m.DestChainID, m.Payload)
+}
+
+// Deserialize deserializes bytes into the message
+func (m *Message) Deserialize(buf []byte) error {
+	values, err := _messageArgs.Unpack(buf)
+	if err != nil {
+		return errors.Wrap(err, "failed to unpack message")
+	}
+	m.Nonce = values[0].(uint64)
+	m.Sender = values[1].(common.Address)
+	m.Recipient = values[2].(common.Address)
+	m.SourceChainID = values[3].(uint64)
+	m.DestChainID = values[4].(uint64)
+	m.Payload = values[5].([]byte)
+	return nil
+}
+
+// toLog converts the message into the log of the event
+func (m *Message) toLog(event abi.Event, addr string) (*action.Log, error) {
+	data, err := _messageDataArgs.Pack(m.SourceChainID, m.DestChainID, m.Payload)
+	if err != nil {
+		return nil, err
+	}
+	return &action.Log{
+		Address: addr,
+		Topics: action.Topics{
+			hash.Hash256(event.ID),
+			hash.Hash256(common.BigToHash(new(big.Int).SetUint64(m.Nonce))),
+			hash.Hash256(common.BytesToHash(m.Sender.Bytes())),
+			hash.Hash256(common.BytesToHash(m.Recipient.Bytes())),
+		},
+		Data: data,
+	}, nil
+}
+
+// messageFromLog decodes the message from the MessageSent event log
+func messageFromLog(topics []common.Hash, data []byte) (*Message, error) {
+	if len(topics) != 4 || topics[0] != _messageSentEvent.ID {
+		return nil, errors.Wrap(ErrInvalidMessage, "not a MessageSent event")
+	}
+	nonce := topics[1].Big()
+	if !nonce.IsUint64() {
+		return nil, errors.Wrap(ErrInvalidMessage, "nonce overflows")
+	}
+	values, err := _messageDataArgs.Unpack(data)
+	if err != nil {
+		return nil, errors.Wrapf(ErrInvalidMessage, "failed to unpack event data: %v", err)
+	}
+	return &Message{
+		Nonce:         nonce.Uint64(),
+		Sender:        common.BytesToAddress(topics[2].Bytes()),
+		Recipient:     common.BytesToAddress(topics[3].Bytes()),
+		SourceChainID: values[0].(uint64),
+		DestChainID:   values[1].(uint64),
+		Payload:       values[2].([]byte),
+	}, nil
+}
+
+// outboundQueue is the queue of the outbound messages. The root accumulates the hashes of all messages sent, as
+// root = keccak256(root || hash), and is committed in the block headers, see block.Header.OutboundRoot
+type outboundQueue struct {
+	Count uint64
+	Root  hash.Hash256
+}
+
+func (q *outboundQueue) push(h hash.Hash256) {
+	q.Count++
+	q.Root = hash.BytesToHash256(crypto.Keccak256(q.Root[:], h[:]))
+}
+
+// Serialize serializes the queue into bytes
+func (q *outboundQueue) Serialize() ([]byte, error) {
+	buf := make([]byte, 8, 8+len(q.Root))
+	binary.BigEndian.PutUint64(buf, q.Count)
+	return append(buf, q.Root[:]...), nil
+}
+
+// Deserialize deserializes bytes into the queue
+func (q *outboundQueue) Deserialize(buf []byte) error {
+	if len(buf) != 8+len(hash.ZeroHash256) {
+		return errors.Errorf("invalid outbound queue length %d", len(buf))
+	}
+	q.Count = binary.BigEndian.Uint64(buf[:8])
+	q.Root = hash.BytesToHash256(buf[8:])
+	return nil
+}
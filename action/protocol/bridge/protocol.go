@@ -0,0 +1,536 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package bridge
+
+import (
+	"context"
+	"encoding/binary"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/abiregistry"
+	accountutil "github.com/iotexproject/iotex-core/v2/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+	"github.com/iotexproject/iotex-core/v2/state"
+)
+
+const (
+	_protocolID      = "bridge"
+	_bridgeNamespace = "Bridge"
+
+	_methodsABI = `[
+		{
+			"inputs": [
+				{"internalType": "address", "name": "recipient", "type": "address"},
+				{"internalType": "bytes", "name": "payload", "type": "bytes"}
+			],
+			"name": "sendMessage",
+			"outputs": [],
+			"stateMutability": "nonpayable",
+			"type": "function"
+		},
+		{
+			"inputs": [
+				{"internalType": "bytes", "name": "header", "type": "bytes"}
+			],
+			"name": "submitHeader",
+			"outputs": [],
+			"stateMutability": "nonpayable",
+			"type": "function"
+		},
+		{
+			"inputs": [
+				{"internalType": "uint64", "name": "blockNumber", "type": "uint64"},
+				{"internalType": "uint64", "name": "txIndex", "type": "uint64"},
+				{"internalType": "bytes[]", "name": "proof", "type": "bytes[]"},
+				{"internalType": "uint64", "name": "logIndex", "type": "uint64"}
+			],
+			"name": "receiveMessage",
+			"outputs": [],
+			"stateMutability": "nonpayable",
+			"type": "function"
+		}
+	]`
+)
+
+var (
+	_outboundQueueKey      = []byte("que")
+	_outboundMessagePrefix = []byte("out")
+	_outboundHeightPrefix  = []byte("blk")
+	_counterpartTipKey     = []byte("tip")
+	_counterpartHdrPrefix  = []byte("hdr")
+	_inboundMessagePrefix  = []byte("inb")
+
+	_methods abi.ABI
+
+	// ErrNotRelayer indicates the caller is not a relayer of the counterpart chain
+	ErrNotRelayer = errors.New("caller is not a bridge relayer")
+	// ErrNotConfirmed indicates the counterpart header is not confirmed yet
+	ErrNotConfirmed = errors.New("counterpart header is not confirmed")
+	// ErrMessageReceived indicates the inbound message has been received already
+	ErrMessageReceived = errors.New("bridge message is received already")
+)
+
+func init() {
+	var err error
+	_methods, err = abi.JSON(strings.NewReader(_methodsABI))
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Protocol defines the protocol of the cross-chain bridge between IoTeX and a counterpart EVM chain. The users send
+// outbound messages by calling sendMessage on the protocol address, the messages are queued and emitted in the
+// MessageSent event logs, which are committed in the block headers by the receipt root, and the queue root in the
+// outbound root field of every block header once the bridge is enabled. The relayers submit the headers of the counterpart chain by calling submitHeader, and anyone proves
+// an inbound message emitted by the counterpart bridge contract by calling receiveMessage with the Merkle-Patricia
+// proof of the receipt against a confirmed header, which is emitted in the MessageReceived event log once
+type Protocol struct {
+	addr              address.Address
+	cfg               genesis.Bridge
+	counterpartBridge common.Address
+	relayers          map[string]struct{}
+	depositGas        protocol.DepositGas
+}
+
+// NewProtocol instantiates the bridge protocol
+func NewProtocol(cfg genesis.Bridge, depositGas protocol.DepositGas) (*Protocol, error) {
+	if cfg.CounterpartChainID == 0 {
+		return nil, errors.New("counterpart chain id is not configured")
+	}
+	if !common.IsHexAddress(cfg.CounterpartBridgeAddress) {
+		return nil, errors.Errorf("invalid counterpart bridge address %s", cfg.CounterpartBridgeAddress)
+	}
+	relayers := make(map[string]struct{}, len(cfg.BridgeRelayers))
+	for _, r := range cfg.BridgeRelayers {
+		addr, err := address.FromString(r)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid bridge relayer %s", r)
+		}
+		relayers[addr.String()] = struct{}{}
+	}
+	return &Protocol{
+		addr:              ProtocolAddr(),
+		cfg:               cfg,
+		counterpartBridge: common.HexToAddress(cfg.CounterpartBridgeAddress),
+		relayers:          relayers,
+		depositGas:        depositGas,
+	}, nil
+}
+
+// ContractABI returns the ABI of the methods and the events of the bridge as a system contract
+func ContractABI() string {
+	return abiregistry.MustJoinABI(_methodsABI, _eventsABI)
+}
+
+// ProtocolAddr returns the address generated from protocol id
+func ProtocolAddr() address.Address {
+	return protocol.HashStringToAddress(_protocolID)
+}
+
+// FindProtocol finds the registered protocol from registry
+func FindProtocol(registry *protocol.Registry) *Protocol {
+	if registry == nil {
+		return nil
+	}
+	p, ok := registry.Find(_protocolID)
+	if !ok {
+		return nil
+	}
+	bp, ok := p.(*Protocol)
+	if !ok {
+		log.S().Panic("fail to cast bridge protocol")
+	}
+	return bp
+}
+
+// Validate validates a bridge action
+func (p *Protocol) Validate(ctx context.Context, elp action.Envelope, _ protocol.StateReader) error {
+	exec, ok := p.bridgeExecution(ctx, elp)
+	if !ok {
+		return nil
+	}
+	if exec.Amount().Sign() != 0 {
+		return errors.Wrap(action.ErrInvalidAct, "bridge action cannot transfer value")
+	}
+	if _, _, err := unpackMethod(exec.Data()); err != nil {
+		return errors.Wrap(action.ErrInvalidAct, err.Error())
+	}
+	return nil
+}
+
+// Handle handles the actions on the bridge protocol
+func (p *Protocol) Handle(ctx context.Context, elp action.Envelope, sm protocol.StateManager) (*action.Receipt, error) {
+	exec, ok := p.bridgeExecution(ctx, elp)
+	if !ok {
+		return nil, nil
+	}
+	si := sm.Snapshot()
+	logs, err := p.handle(ctx, sm, exec.Data())
+	if err != nil {
+		log.L().Debug("Error when handling bridge action", zap.Error(err))
+		return p.settleAction(ctx, sm, elp, uint64(iotextypes.ReceiptStatus_Failure), si, nil)
+	}
+	return p.settleAction(ctx, sm, elp, uint64(iotextypes.ReceiptStatus_Success), si, logs)
+}
+
+func (p *Protocol) bridgeExecution(ctx context.Context, elp action.Envelope) (*action.Execution, bool) {
+	exec, ok := elp.Action().(*action.Execution)
+	if !ok || exec.Contract() != p.addr.String() {
+		return nil, false
+	}
+	return exec, protocol.MustGetFeatureCtx(ctx).EnableBridge
+}
+
+func unpackMethod(data []byte) (*abi.Method, []interface{}, error) {
+	if len(data) < 4 {
+		return nil, nil, errors.New("invalid bridge call data")
+	}
+	method, err := _methods.MethodById(data[:4])
+	if err != nil {
+		return nil, nil, err
+	}
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to unpack arguments of %s", method.Name)
+	}
+	return method, args, nil
+}
+
+func (p *Protocol) handle(ctx context.Context, sm protocol.StateManager, data []byte) ([]*action.Log, error) {
+	method, args, err := unpackMethod(data)
+	if err != nil {
+		return nil, err
+	}
+	var lg *action.Log
+	switch method.Name {
+	case "sendMessage":
+		lg, err = p.sendMessage(ctx, sm, args[0].(common.Address), args[1].([]byte))
+	case "submitHeader":
+		err = p.submitHeader(ctx, sm, args[0].([]byte))
+	case "receiveMessage":
+		lg, err = p.receiveMessage(ctx, sm, args[0].(uint64), args[1].(uint64), args[2].([][]byte), args[3].(uint64))
+	}
+	if err != nil || lg == nil {
+		return nil, err
+	}
+	var (
+		blkCtx    = protocol.MustGetBlockCtx(ctx)
+		actionCtx = protocol.MustGetActionCtx(ctx)
+	)
+	lg.BlockHeight = blkCtx.BlockHeight
+	lg.ActionHash = actionCtx.ActionHash
+	return []*action.Log{lg}, nil
+}
+
+// sendMessage appends an outbound message to the queue
+func (p *Protocol) sendMessage(ctx context.Context, sm protocol.StateManager, recipient common.Address, payload []byte) (*action.Log, error) {
+	var (
+		blkCtx    = protocol.MustGetBlockCtx(ctx)
+		bcCtx     = protocol.MustGetBlockchainCtx(ctx)
+		actionCtx = protocol.MustGetActionCtx(ctx)
+		q         = &outboundQueue{}
+	)
+	if _, err := p.state(sm, _outboundQueueKey, q); err != nil && errors.Cause(err) != state.ErrStateNotExist {
+		return nil, err
+	}
+	msg := &Message{
+		Nonce:         q.Count,
+		Sender:        common.BytesToAddress(actionCtx.Caller.Bytes()),
+		Recipient:     recipient,
+		SourceChainID: uint64(bcCtx.EvmNetworkID),
+		DestChainID:   p.cfg.CounterpartChainID,
+		Payload:       payload,
+	}
+	h, err := msg.Hash()
+	if err != nil {
+		return nil, err
+	}
+	q.push(h)
+	if err := p.putState(sm, uint64Key(_outboundMessagePrefix, msg.Nonce), msg); err != nil {
+		return nil, err
+	}
+	if err := p.putState(sm, _outboundQueueKey, q); err != nil {
+		return nil, err
+	}
+	// keep the queue at each height, for the counterpart chain to verify the messages sent in the block
+	if err := p.putState(sm, uint64Key(_outboundHeightPrefix, blkCtx.BlockHeight), q); err != nil {
+		return nil, err
+	}
+	return msg.toLog(_messageSentEvent, p.addr.String())
+}
+
+// submitHeader appends a header to the counterpart chain, the first header submitted is taken as the anchor
+func (p *Protocol) submitHeader(ctx context.Context, sm protocol.StateManager, buf []byte) error {
+	caller := protocol.MustGetActionCtx(ctx).Caller
+	if _, ok := p.relayers[caller.String()]; !ok {
+		return errors.Wrap(ErrNotRelayer, caller.String())
+	}
+	h, err := decodeCounterpartHeader(buf)
+	if err != nil {
+		return err
+	}
+	tip := &CounterpartHeader{}
+	_, err = p.state(sm, _counterpartTipKey, tip)
+	switch errors.Cause(err) {
+	case nil:
+		if !h.extends(tip) {
+			return errors.Wrapf(ErrInvalidHeader, "header %d %x does not extend tip %d %x", h.Number, h.Hash, tip.Number, tip.Hash)
+		}
+	case state.ErrStateNotExist:
+	default:
+		return err
+	}
+	if err := p.putState(sm, uint64Key(_counterpartHdrPrefix, h.Number), h); err != nil {
+		return err
+	}
+	return p.putState(sm, _counterpartTipKey, h)
+}
+
+// receiveMessage verifies an inbound message against a confirmed counterpart header
+func (p *Protocol) receiveMessage(
+	ctx context.Context,
+	sm protocol.StateManager,
+	blockNumber, txIndex uint64,
+	proof [][]byte,
+	logIndex uint64,
+) (*action.Log, error) {
+	var (
+		blkCtx = protocol.MustGetBlockCtx(ctx)
+		bcCtx  = protocol.MustGetBlockchainCtx(ctx)
+		tip    = &CounterpartHeader{}
+		h      = &CounterpartHeader{}
+	)
+	if _, err := p.state(sm, _counterpartTipKey, tip); err != nil {
+		return nil, err
+	}
+	if blockNumber+p.cfg.BridgeConfirmations > tip.Number {
+		return nil, errors.Wrapf(ErrNotConfirmed, "header %d, tip %d", blockNumber, tip.Number)
+	}
+	if _, err := p.state(sm, uint64Key(_counterpartHdrPrefix, blockNumber), h); err != nil {
+		return nil, errors.Wrapf(err, "failed to read counterpart header %d", blockNumber)
+	}
+	lg, err := verifyReceiptLog(h, txIndex, proof, logIndex)
+	if err != nil {
+		return nil, err
+	}
+	if lg.Address != p.counterpartBridge {
+		return nil, errors.Wrapf(ErrInvalidMessage, "log emitted by %s", lg.Address)
+	}
+	msg, err := messageFromLog(lg.Topics, lg.Data)
+	if err != nil {
+		return nil, err
+	}
+	if msg.SourceChainID != p.cfg.CounterpartChainID || msg.DestChainID != uint64(bcCtx.EvmNetworkID) {
+		return nil, errors.Wrapf(ErrInvalidMessage, "message from chain %d to chain %d", msg.SourceChainID, msg.DestChainID)
+	}
+	mh, err := msg.Hash()
+	if err != nil {
+		return nil, err
+	}
+	key := hashKey(_inboundMessagePrefix, mh)
+	rec := &inboundRecord{}
+	_, err = p.state(sm, key, rec)
+	switch errors.Cause(err) {
+	case nil:
+		return nil, errors.Wrapf(ErrMessageReceived, "message %x received at height %d", mh, rec.Height)
+	case state.ErrStateNotExist:
+	default:
+		return nil, err
+	}
+	if err := p.putState(sm, key, &inboundRecord{Height: blkCtx.BlockHeight}); err != nil {
+		return nil, err
+	}
+	return msg.toLog(_messageReceivedEvent, p.addr.String())
+}
+
+// ReadState read the state on blockchain via protocol
+func (p *Protocol) ReadState(ctx context.Context, sr protocol.StateReader, method []byte, args ...[]byte) ([]byte, uint64, error) {
+	var (
+		key   []byte
+		value state.Serializer
+	)
+	switch string(method) {
+	case "OutboundQueue":
+		// the queue at the tip, or at the given height
+		key, value = _outboundQueueKey, &outboundQueue{}
+		if len(args) == 1 {
+			height, err := strconv.ParseUint(string(args[0]), 10, 64)
+			if err != nil {
+				return nil, 0, err
+			}
+			key = uint64Key(_outboundHeightPrefix, height)
+		}
+	case "OutboundMessage":
+		if len(args) != 1 {
+			return nil, 0, errors.Errorf("invalid number of arguments %d", len(args))
+		}
+		nonce, err := strconv.ParseUint(string(args[0]), 10, 64)
+		if err != nil {
+			return nil, 0, err
+		}
+		key, value = uint64Key(_outboundMessagePrefix, nonce), &Message{}
+	case "CounterpartHeader":
+		// the tip of the counterpart chain, or the header of the given number
+		key, value = _counterpartTipKey, &CounterpartHeader{}
+		if len(args) == 1 {
+			number, err := strconv.ParseUint(string(args[0]), 10, 64)
+			if err != nil {
+				return nil, 0, err
+			}
+			key = uint64Key(_counterpartHdrPrefix, number)
+		}
+	case "InboundMessage":
+		if len(args) != 1 {
+			return nil, 0, errors.Errorf("invalid number of arguments %d", len(args))
+		}
+		mh, err := hash.HexStringToHash256(string(args[0]))
+		if err != nil {
+			return nil, 0, err
+		}
+		key, value = hashKey(_inboundMessagePrefix, mh), &inboundRecord{}
+	default:
+		return nil, 0, errors.New("corresponding method isn't found")
+	}
+	height, err := p.state(sr, key, value)
+	if err != nil {
+		return nil, 0, err
+	}
+	data, err := value.Serialize()
+	return data, height, err
+}
+
+// Register registers the protocol with a unique ID
+func (p *Protocol) Register(r *protocol.Registry) error {
+	return r.Register(_protocolID, p)
+}
+
+// ForceRegister registers the protocol with a unique ID and force replacing the previous protocol if it exists
+func (p *Protocol) ForceRegister(r *protocol.Registry) error {
+	return r.ForceRegister(_protocolID, p)
+}
+
+// Name returns the name of protocol
+func (p *Protocol) Name() string {
+	return _protocolID
+}
+
+// OutboundRoot returns the root of the outbound queue in the state, or zero hash if no message has been sent
+func OutboundRoot(sr protocol.StateReader) (hash.Hash256, error) {
+	q := &outboundQueue{}
+	if _, err := sr.State(q, protocol.KeyOption(_outboundQueueKey), protocol.NamespaceOption(_bridgeNamespace)); err != nil {
+		if errors.Cause(err) == state.ErrStateNotExist {
+			return hash.ZeroHash256, nil
+		}
+		return hash.ZeroHash256, errors.Wrap(err, "failed to read bridge outbound queue")
+	}
+	return q.Root, nil
+}
+
+func (p *Protocol) state(sr protocol.StateReader, key []byte, value interface{}) (uint64, error) {
+	return sr.State(value, protocol.KeyOption(key), protocol.NamespaceOption(_bridgeNamespace))
+}
+
+func (p *Protocol) putState(sm protocol.StateManager, key []byte, value interface{}) error {
+	_, err := sm.PutState(value, protocol.KeyOption(key), protocol.NamespaceOption(_bridgeNamespace))
+	return err
+}
+
+func (p *Protocol) settleAction(
+	ctx context.Context,
+	sm protocol.StateManager,
+	elp action.Envelope,
+	status uint64,
+	si int,
+	logs []*action.Log,
+) (*action.Receipt, error) {
+	var (
+		actionCtx = protocol.MustGetActionCtx(ctx)
+		blkCtx    = protocol.MustGetBlockCtx(ctx)
+		fCtx      = protocol.MustGetFeatureCtx(ctx)
+		tLogs     []*action.TransactionLog
+	)
+	if status == uint64(iotextypes.ReceiptStatus_Failure) {
+		if err := sm.Revert(si); err != nil {
+			return nil, err
+		}
+	}
+	priorityFee, baseFee, err := protocol.SplitGas(ctx, elp, actionCtx.IntrinsicGas)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to split gas")
+	}
+	if p.depositGas != nil {
+		tLogs, err = p.depositGas(ctx, sm, baseFee, protocol.PriorityFeeOption(priorityFee))
+		if err != nil {
+			return nil, err
+		}
+	}
+	accountCreationOpts := []state.AccountCreationOption{}
+	if fCtx.CreateLegacyNonceAccount {
+		accountCreationOpts = append(accountCreationOpts, state.LegacyNonceAccountTypeOption())
+	}
+	acc, err := accountutil.LoadOrCreateAccount(sm, actionCtx.Caller, accountCreationOpts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := acc.SetPendingNonce(actionCtx.Nonce + 1); err != nil {
+		return nil, errors.Wrapf(err, "invalid nonce %d", actionCtx.Nonce)
+	}
+	if err := accountutil.StoreAccount(sm, actionCtx.Caller, acc); err != nil {
+		return nil, err
+	}
+	return (&action.Receipt{
+		Status:            status,
+		BlockHeight:       blkCtx.BlockHeight,
+		ActionHash:        actionCtx.ActionHash,
+		GasConsumed:       actionCtx.IntrinsicGas,
+		ContractAddress:   p.addr.String(),
+		EffectiveGasPrice: protocol.EffectiveGasPrice(ctx, elp),
+	}).AddLogs(logs...).AddTransactionLogs(tLogs...), nil
+}
+
+func uint64Key(prefix []byte, v uint64) []byte {
+	key := make([]byte, len(prefix)+8)
+	copy(key, prefix)
+	binary.BigEndian.PutUint64(key[len(prefix):], v)
+	return key
+}
+
+func hashKey(prefix []byte, h hash.Hash256) []byte {
+	key := make([]byte, 0, len(prefix)+len(h))
+	key = append(key, prefix...)
+	return append(key, h[:]...)
+}
+
+// inboundRecord records the height at which an inbound message is received
+type inboundRecord struct {
+	Height uint64
+}
+
+// Serialize serializes the record into bytes
+func (r *inboundRecord) Serialize() ([]byte, error) {
+	return binary.BigEndian.AppendUint64(nil, r.Height), nil
+}
+
+// Deserialize deserializes bytes into the record
+func (r *inboundRecord) Deserialize(buf []byte) error {
+	if len(buf) != 8 {
+		return errors.Errorf("invalid inbound record length %d", len(buf))
+	}
+	r.Height = binary.BigEndian.Uint64(buf)
+	return nil
+}
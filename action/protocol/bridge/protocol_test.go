@@ -0,0 +1,267 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package bridge
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+	"github.com/iotexproject/iotex-core/v2/testutil/testdb"
+)
+
+const (
+	_testChainID        = 4689
+	_testCounterpartID  = 1
+	_testCounterpartHex = "0x00000000000000000000000000000000000000b1"
+)
+
+func TestMessage(t *testing.T) {
+	require := require.New(t)
+
+	msg := &Message{
+		Nonce:         7,
+		Sender:        common.HexToAddress("0x01"),
+		Recipient:     common.HexToAddress("0x02"),
+		SourceChainID: _testChainID,
+		DestChainID:   _testCounterpartID,
+		Payload:       []byte("hello"),
+	}
+	buf, err := msg.Serialize()
+	require.NoError(err)
+	msg2 := &Message{}
+	require.NoError(msg2.Deserialize(buf))
+	require.Equal(msg, msg2)
+
+	lg, err := msg.toLog(_messageSentEvent, ProtocolAddr().String())
+	require.NoError(err)
+	topics := make([]common.Hash, len(lg.Topics))
+	for i := range lg.Topics {
+		topics[i] = common.Hash(lg.Topics[i])
+	}
+	msg3, err := messageFromLog(topics, lg.Data)
+	require.NoError(err)
+	require.Equal(msg, msg3)
+	topics[0] = _messageReceivedEvent.ID
+	_, err = messageFromLog(topics, lg.Data)
+	require.ErrorIs(err, ErrInvalidMessage)
+
+	q := &outboundQueue{}
+	h, err := msg.Hash()
+	require.NoError(err)
+	q.push(h)
+	buf, err = q.Serialize()
+	require.NoError(err)
+	q2 := &outboundQueue{}
+	require.NoError(q2.Deserialize(buf))
+	require.Equal(q, q2)
+	require.EqualValues(1, q2.Count)
+}
+
+func TestProtocol(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm := testdb.NewMockStateManager(ctrl)
+	// the failed actions do not write the states before failing
+	sm.EXPECT().Revert(gomock.Any()).Return(nil).AnyTimes()
+
+	var (
+		relayer = identityset.Address(1)
+		user    = identityset.Address(2)
+		g       = genesis.TestDefault()
+	)
+	g.ToBeEnabledBlockHeight = 1
+	p, err := NewProtocol(genesis.Bridge{
+		CounterpartChainID:       _testCounterpartID,
+		CounterpartBridgeAddress: _testCounterpartHex,
+		BridgeRelayers:           []string{relayer.String()},
+		BridgeConfirmations:      1,
+	}, nil)
+	require.NoError(err)
+	_, err = NewProtocol(genesis.Bridge{}, nil)
+	require.Error(err)
+
+	nonces := map[int]uint64{}
+	call := func(height uint64, caller int, data []byte) *action.Receipt {
+		nonce := nonces[caller]
+		ctx := genesis.WithGenesisContext(context.Background(), g)
+		ctx = protocol.WithBlockCtx(ctx, protocol.BlockCtx{BlockHeight: height})
+		ctx = protocol.WithBlockchainCtx(ctx, protocol.BlockchainCtx{EvmNetworkID: _testChainID})
+		ctx = protocol.WithActionCtx(ctx, protocol.ActionCtx{
+			Caller:     identityset.Address(caller),
+			ActionHash: hash.Hash256b(data),
+			Nonce:      nonce,
+		})
+		ctx = protocol.WithFeatureCtx(ctx)
+		elp := (&action.EnvelopeBuilder{}).SetNonce(nonce).SetGasPrice(big.NewInt(0)).SetGasLimit(100000).
+			SetAction(action.NewExecution(ProtocolAddr().String(), big.NewInt(0), data)).Build()
+		require.NoError(p.Validate(ctx, elp, sm))
+		r, err := p.Handle(ctx, elp, sm)
+		require.NoError(err)
+		if r != nil {
+			nonces[caller]++
+		}
+		return r
+	}
+	pack := func(method string, args ...interface{}) []byte {
+		data, err := _methods.Pack(method, args...)
+		require.NoError(err)
+		return data
+	}
+
+	t.Run("send", func(t *testing.T) {
+		recipient := common.HexToAddress("0x03")
+		for i := 0; i < 2; i++ {
+			r := call(5, 2, pack("sendMessage", recipient, []byte{byte(i)}))
+			require.EqualValues(iotextypes.ReceiptStatus_Success, r.Status)
+			require.Len(r.Logs(), 1)
+			require.Equal(hash.Hash256(_messageSentEvent.ID), r.Logs()[0].Topics[0])
+		}
+		data, _, err := p.ReadState(context.Background(), sm, []byte("OutboundMessage"), []byte("1"))
+		require.NoError(err)
+		msg := &Message{}
+		require.NoError(msg.Deserialize(data))
+		require.Equal(&Message{
+			Nonce:         1,
+			Sender:        common.BytesToAddress(user.Bytes()),
+			Recipient:     recipient,
+			SourceChainID: _testChainID,
+			DestChainID:   _testCounterpartID,
+			Payload:       []byte{1},
+		}, msg)
+		data, _, err = p.ReadState(context.Background(), sm, []byte("OutboundQueue"), []byte("5"))
+		require.NoError(err)
+		q := &outboundQueue{}
+		require.NoError(q.Deserialize(data))
+		require.EqualValues(2, q.Count)
+		// the root committed in the block header
+		root, err := OutboundRoot(sm)
+		require.NoError(err)
+		require.Equal(q.Root, root)
+		require.NotEqual(hash.ZeroHash256, root)
+	})
+
+	// the counterpart bridge contract emits a message to iotex in tx 0 of block 10
+	inbound := &Message{
+		Nonce:         3,
+		Sender:        common.HexToAddress("0x04"),
+		Recipient:     common.BytesToAddress(user.Bytes()),
+		SourceChainID: _testCounterpartID,
+		DestChainID:   _testChainID,
+		Payload:       []byte("world"),
+	}
+	lg, err := inbound.toLog(_messageSentEvent, "")
+	require.NoError(err)
+	topics := make([]common.Hash, len(lg.Topics))
+	for i := range lg.Topics {
+		topics[i] = common.Hash(lg.Topics[i])
+	}
+	receipt := &types.Receipt{
+		Status: types.ReceiptStatusSuccessful,
+		Logs:   []*types.Log{{Address: common.HexToAddress(_testCounterpartHex), Topics: topics, Data: lg.Data}},
+	}
+	receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+	value, err := receipt.MarshalBinary()
+	require.NoError(err)
+	key, err := rlp.EncodeToBytes(uint64(0))
+	require.NoError(err)
+	tr := trie.NewEmpty(trie.NewDatabase(rawdb.NewMemoryDatabase(), nil))
+	require.NoError(tr.Update(key, value))
+	proofDB := memorydb.New()
+	require.NoError(tr.Prove(key, proofDB))
+	var proof [][]byte
+	it := proofDB.NewIterator(nil, nil)
+	for it.Next() {
+		proof = append(proof, common.CopyBytes(it.Value()))
+	}
+	it.Release()
+
+	header := func(number uint64, parent common.Hash) ([]byte, common.Hash) {
+		h := &types.Header{
+			ParentHash:  parent,
+			Number:      new(big.Int).SetUint64(number),
+			Difficulty:  big.NewInt(0),
+			ReceiptHash: tr.Hash(),
+		}
+		buf, err := rlp.EncodeToBytes(h)
+		require.NoError(err)
+		return buf, h.Hash()
+	}
+	h10, hash10 := header(10, common.Hash{})
+	h11, _ := header(11, hash10)
+
+	t.Run("submitHeader", func(t *testing.T) {
+		// only the relayers could submit headers
+		r := call(6, 2, pack("submitHeader", h10))
+		require.EqualValues(iotextypes.ReceiptStatus_Failure, r.Status)
+		r = call(6, 1, pack("submitHeader", h10))
+		require.EqualValues(iotextypes.ReceiptStatus_Success, r.Status)
+		// the header must extend the tip
+		r = call(6, 1, pack("submitHeader", h10))
+		require.EqualValues(iotextypes.ReceiptStatus_Failure, r.Status)
+		require.ErrorIs(p.submitHeader(protocol.WithActionCtx(context.Background(), protocol.ActionCtx{Caller: relayer}), sm, h10), ErrInvalidHeader)
+	})
+
+	t.Run("receiveMessage", func(t *testing.T) {
+		data := pack("receiveMessage", uint64(10), uint64(0), proof, uint64(0))
+		// the header is not confirmed yet
+		r := call(7, 2, data)
+		require.EqualValues(iotextypes.ReceiptStatus_Failure, r.Status)
+		r = call(7, 1, pack("submitHeader", h11))
+		require.EqualValues(iotextypes.ReceiptStatus_Success, r.Status)
+		r = call(8, 2, data)
+		require.EqualValues(iotextypes.ReceiptStatus_Success, r.Status)
+		require.Len(r.Logs(), 1)
+		require.Equal(hash.Hash256(_messageReceivedEvent.ID), r.Logs()[0].Topics[0])
+
+		mh, err := inbound.Hash()
+		require.NoError(err)
+		buf, _, err := p.ReadState(context.Background(), sm, []byte("InboundMessage"), []byte(hex.EncodeToString(mh[:])))
+		require.NoError(err)
+		rec := &inboundRecord{}
+		require.NoError(rec.Deserialize(buf))
+		require.EqualValues(8, rec.Height)
+
+		// the message could be received only once
+		r = call(9, 2, data)
+		require.EqualValues(iotextypes.ReceiptStatus_Failure, r.Status)
+		// the proof must match the receipts root
+		r = call(9, 2, pack("receiveMessage", uint64(10), uint64(0), proof[1:], uint64(0)))
+		require.EqualValues(iotextypes.ReceiptStatus_Failure, r.Status)
+		_, err = verifyReceiptLog(&CounterpartHeader{ReceiptsRoot: common.Hash{1}}, 0, proof, 0)
+		require.True(errors.Is(err, ErrInvalidProof))
+
+		buf, _, err = p.ReadState(context.Background(), sm, []byte("CounterpartHeader"))
+		require.NoError(err)
+		tip := &CounterpartHeader{}
+		require.NoError(tip.Deserialize(buf))
+		require.EqualValues(11, tip.Number)
+		require.Equal(hash10, tip.ParentHash)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		g.ToBeEnabledBlockHeight = 100
+		defer func() { g.ToBeEnabledBlockHeight = 1 }()
+		require.Nil(call(10, 2, pack("sendMessage", common.Address{}, []byte{})))
+	})
+}
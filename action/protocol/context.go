@@ -8,6 +8,8 @@ package protocol
 import (
 	"context"
 	"math/big"
+	"reflect"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/core/vm"
@@ -157,6 +159,16 @@ type (
 		TimestampedStakingContract              bool
 		PreStateSystemAction                    bool
 		CreatePostActionStates                  bool
+		DeferOperatorRotation                   bool
+		EnableBridge                            bool
+		EnableDataAnchor                        bool
+		EnableBlockExtraData                    bool
+		MigrateContractStake                    bool
+		EnableEpochCandidateSnapshot            bool
+		EnableRewardAutoCompound                bool
+		EnableGovernanceParams                  bool
+		EnableFeeToken                          bool
+		EnableBLSKeyRegistration                bool
 	}
 
 	// FeatureWithHeightCtx provides feature check functions.
@@ -169,6 +181,7 @@ type (
 		CalculateProbationList   CheckFunc
 		LoadCandidatesLegacy     CheckFunc
 		CandCenterHasAlias       CheckFunc
+		EnableRandomBeacon       CheckFunc
 	}
 )
 
@@ -319,6 +332,16 @@ func WithFeatureCtx(ctx context.Context) context.Context {
 			TimestampedStakingContract:              g.IsWake(height),
 			PreStateSystemAction:                    !g.IsWake(height),
 			CreatePostActionStates:                  g.IsWake(height),
+			DeferOperatorRotation:                   g.IsToBeEnabled(height),
+			EnableBridge:                            g.IsToBeEnabled(height),
+			EnableDataAnchor:                        g.IsToBeEnabled(height),
+			EnableBlockExtraData:                    g.IsToBeEnabled(height),
+			MigrateContractStake:                    g.IsToBeEnabled(height),
+			EnableEpochCandidateSnapshot:            g.IsToBeEnabled(height),
+			EnableRewardAutoCompound:                g.IsToBeEnabled(height),
+			EnableGovernanceParams:                  g.IsToBeEnabled(height),
+			EnableFeeToken:                          g.IsToBeEnabled(height),
+			EnableBLSKeyRegistration:                g.IsToBeEnabled(height),
 		},
 	)
 }
@@ -346,6 +369,21 @@ func MustGetFeatureCtx(ctx context.Context) FeatureCtx {
 	return fc
 }
 
+// Hash returns the hash of the names of the enabled features, the nodes running the same set of features at a height
+// have the same hash
+func (fc FeatureCtx) Hash() hash.Hash256 {
+	var (
+		v     = reflect.ValueOf(fc)
+		names []string
+	)
+	for i := 0; i < v.NumField(); i++ {
+		if f := v.Field(i); f.Kind() == reflect.Bool && f.Bool() {
+			names = append(names, v.Type().Field(i).Name)
+		}
+	}
+	return hash.Hash256b([]byte(strings.Join(names, ",")))
+}
+
 // WithFeatureWithHeightCtx add FeatureWithHeightCtx into context.
 func WithFeatureWithHeightCtx(ctx context.Context) context.Context {
 	g := genesis.MustExtractGenesisContext(ctx)
@@ -377,6 +415,9 @@ func WithFeatureWithHeightCtx(ctx context.Context) context.Context {
 			CandCenterHasAlias: func(height uint64) bool {
 				return !g.IsOkhotsk(height)
 			},
+			EnableRandomBeacon: func(height uint64) bool {
+				return g.IsToBeEnabled(height)
+			},
 		},
 	)
 }
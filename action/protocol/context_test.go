@@ -183,3 +183,17 @@ func TestGetVMConfigCtx(t *testing.T) {
 	require.True(ok)
 	require.True(ret.NoBaseFee)
 }
+
+func TestFeatureCtxHash(t *testing.T) {
+	require := require.New(t)
+	fc := FeatureCtx{}
+	h := fc.Hash()
+	require.Equal(h, FeatureCtx{}.Hash())
+	fc.EnableBridge = true
+	h2 := fc.Hash()
+	require.NotEqual(h, h2)
+	fc.EnableDataAnchor = true
+	require.NotEqual(h2, fc.Hash())
+	fc.EnableDataAnchor = false
+	require.Equal(h2, fc.Hash())
+}
@@ -5,6 +5,8 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
 )
 
 type (
@@ -16,17 +18,17 @@ type (
 )
 
 var (
-	minBlobGasPrice            = big.NewInt(params.BlobTxMinBlobGasprice)
-	blobGaspriceUpdateFraction = big.NewInt(params.BlobTxBlobGaspriceUpdateFraction)
+	minBlobGasPrice = big.NewInt(params.BlobTxMinBlobGasprice)
 )
 
 // VerifyEIP4844Header verifies the presence of the excessBlobGas field and that
 // if the current block contains no transactions, the excessBlobGas is updated
 // accordingly.
-func VerifyEIP4844Header(parent *TipInfo, header blockHeader) error {
+func VerifyEIP4844Header(g genesis.Blockchain, parent *TipInfo, header blockHeader) error {
+	height := parent.Height + 1
 	// Verify that the blob gas used remains within reasonable limits.
-	if header.BlobGasUsed() > params.MaxBlobGasPerBlock {
-		return fmt.Errorf("blob gas used %d exceeds maximum allowance %d", header.BlobGasUsed(), params.MaxBlobGasPerBlock)
+	if maxBlobGas := g.MaxBlobGasPerBlock(height); header.BlobGasUsed() > maxBlobGas {
+		return fmt.Errorf("blob gas used %d exceeds maximum allowance %d", header.BlobGasUsed(), maxBlobGas)
 	}
 	if header.BlobGasUsed()%params.BlobTxBlobGasPerBlob != 0 {
 		return fmt.Errorf("blob gas used %d not a multiple of blob gas per blob %d", header.BlobGasUsed(), params.BlobTxBlobGasPerBlob)
@@ -36,7 +38,7 @@ func VerifyEIP4844Header(parent *TipInfo, header blockHeader) error {
 		parentExcessBlobGas = parent.ExcessBlobGas
 		parentBlobGasUsed   = parent.BlobGasUsed
 	)
-	expectedExcessBlobGas := CalcExcessBlobGas(parentExcessBlobGas, parentBlobGasUsed)
+	expectedExcessBlobGas := CalcExcessBlobGas(g, height, parentExcessBlobGas, parentBlobGasUsed)
 	if header.ExcessBlobGas() != expectedExcessBlobGas {
 		return fmt.Errorf("invalid excessBlobGas: have %d, want %d, parent excessBlobGas %d, parent blobDataUsed %d",
 			header.ExcessBlobGas(), expectedExcessBlobGas, parentExcessBlobGas, parentBlobGasUsed)
@@ -45,18 +47,20 @@ func VerifyEIP4844Header(parent *TipInfo, header blockHeader) error {
 }
 
 // CalcExcessBlobGas calculates the excess blob gas after applying the set of
-// blobs on top of the excess blob gas.
-func CalcExcessBlobGas(parentExcessBlobGas uint64, parentBlobGasUsed uint64) uint64 {
+// blobs on top of the excess blob gas, against the target blob gas of the chain
+// at the height of the block.
+func CalcExcessBlobGas(g genesis.Blockchain, height uint64, parentExcessBlobGas uint64, parentBlobGasUsed uint64) uint64 {
 	excessBlobGas := parentExcessBlobGas + parentBlobGasUsed
-	if excessBlobGas < params.BlobTxTargetBlobGasPerBlock {
-		return 0
+	if target := g.TargetBlobGasPerBlock(height); excessBlobGas >= target {
+		return excessBlobGas - target
 	}
-	return excessBlobGas - params.BlobTxTargetBlobGasPerBlock
+	return 0
 }
 
-// CalcBlobFee calculates the blobfee from the header's excess blob gas field.
-func CalcBlobFee(excessBlobGas uint64) *big.Int {
-	return fakeExponential(minBlobGasPrice, new(big.Int).SetUint64(excessBlobGas), blobGaspriceUpdateFraction)
+// CalcBlobFee calculates the blobfee from the header's excess blob gas field,
+// with the blob base fee update fraction of the chain at the height of the block.
+func CalcBlobFee(g genesis.Blockchain, height uint64, excessBlobGas uint64) *big.Int {
+	return fakeExponential(minBlobGasPrice, new(big.Int).SetUint64(excessBlobGas), new(big.Int).SetUint64(g.BlobBaseFeeUpdateFractionByHeight(height)))
 }
 
 // fakeExponential approximates factor * e ** (numerator / denominator) using
@@ -7,6 +7,8 @@ import (
 
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
 )
 
 func TestCalcExcessBlobGas(t *testing.T) {
@@ -35,10 +37,19 @@ func TestCalcExcessBlobGas(t *testing.T) {
 		{params.BlobTxTargetBlobGasPerBlock, (params.BlobTxTargetBlobGasPerBlock / params.BlobTxBlobGasPerBlob) - 2, params.BlobTxTargetBlobGasPerBlock - (2 * params.BlobTxBlobGasPerBlob)},
 		{params.BlobTxBlobGasPerBlob - 1, (params.BlobTxTargetBlobGasPerBlock / params.BlobTxBlobGasPerBlob) - 1, 0},
 	}
+	g := genesis.TestDefault().Blockchain
+	g.ToBeEnabledBlockHeight = 10
+	g.TargetBlobsPerBlock, g.MaxBlobsPerBlock = 1, 8
 	for i, tt := range tests {
-		result := CalcExcessBlobGas(tt.excess, tt.blobs*params.BlobTxBlobGasPerBlob)
+		// the target of the chain takes effect after the fork
+		result := CalcExcessBlobGas(g, 9, tt.excess, tt.blobs*params.BlobTxBlobGasPerBlob)
 		require.Equal(tt.want, result, "test %d: excess blob gas mismatch", i)
 	}
+
+	// the target of the chain is independent of the max blobs
+	require.Zero(CalcExcessBlobGas(g, 10, 0, params.BlobTxBlobGasPerBlob))
+	require.EqualValues(7*params.BlobTxBlobGasPerBlob, CalcExcessBlobGas(g, 10, 0, 8*params.BlobTxBlobGasPerBlob))
+	require.EqualValues(6*params.BlobTxBlobGasPerBlob, CalcExcessBlobGas(g, 10, 7*params.BlobTxBlobGasPerBlob, 0))
 }
 
 func TestCalcBlobFee(t *testing.T) {
@@ -52,12 +63,55 @@ func TestCalcBlobFee(t *testing.T) {
 		{2314058, 2},
 		{10 * 1024 * 1024, 23},
 	}
+	g := genesis.TestDefault().Blockchain
+	g.ToBeEnabledBlockHeight = 10
+	g.BlobBaseFeeUpdateFraction = params.BlobTxBlobGaspriceUpdateFraction / 2
 	for i, tt := range tests {
-		have := CalcBlobFee(tt.excessBlobGas)
+		// the update fraction of the chain takes effect after the fork
+		have := CalcBlobFee(g, 9, tt.excessBlobGas)
 		require.Equal(tt.blobfee, have.Int64(), "test %d: blobfee mismatch", i)
 	}
+
+	// a smaller update fraction changes the blob base fee faster
+	require.EqualValues(2, CalcBlobFee(g, 10, 2314058/2+1).Int64())
+	require.Greater(CalcBlobFee(g, 10, 10*1024*1024).Int64(), int64(23*23))
 }
 
+func TestVerifyEIP4844Header(t *testing.T) {
+	require := require.New(t)
+	g := genesis.TestDefault().Blockchain
+	g.ToBeEnabledBlockHeight = 10
+	g.TargetBlobsPerBlock, g.MaxBlobsPerBlock = 2, 4
+	// the blob limits of the chain are not applied before the fork
+	parent := &TipInfo{Height: 8, BlobGasUsed: 4 * params.BlobTxBlobGasPerBlob}
+	require.NoError(VerifyEIP4844Header(g, parent, &testBlobHeader{blobGasUsed: 6 * params.BlobTxBlobGasPerBlob, excessBlobGas: params.BlobTxBlobGasPerBlob}))
+	parent.Height = 9
+	for _, c := range []struct {
+		blobGasUsed, excessBlobGas uint64
+		err                        string
+	}{
+		{4 * params.BlobTxBlobGasPerBlob, 2 * params.BlobTxBlobGasPerBlob, ""},
+		{5 * params.BlobTxBlobGasPerBlob, 2 * params.BlobTxBlobGasPerBlob, "exceeds maximum allowance"},
+		{params.BlobTxBlobGasPerBlob + 1, 2 * params.BlobTxBlobGasPerBlob, "not a multiple of blob gas per blob"},
+		{0, 3 * params.BlobTxBlobGasPerBlob, "invalid excessBlobGas"},
+	} {
+		err := VerifyEIP4844Header(g, parent, &testBlobHeader{blobGasUsed: c.blobGasUsed, excessBlobGas: c.excessBlobGas})
+		if c.err == "" {
+			require.NoError(err)
+		} else {
+			require.ErrorContains(err, c.err)
+		}
+	}
+}
+
+type testBlobHeader struct {
+	blobGasUsed, excessBlobGas uint64
+}
+
+func (h *testBlobHeader) BaseFee() *big.Int     { return nil }
+func (h *testBlobHeader) BlobGasUsed() uint64   { return h.blobGasUsed }
+func (h *testBlobHeader) ExcessBlobGas() uint64 { return h.excessBlobGas }
+
 func TestFakeExponential(t *testing.T) {
 	require := require.New(t)
 	tests := []struct {
@@ -42,6 +42,11 @@ type (
 		Snapshot() Contract
 	}
 
+	// trieNodeCacher is a state manager sharing the cache of the trie nodes
+	trieNodeCacher interface {
+		TrieNodeCache() *mptrie.NodeCache
+	}
+
 	contract struct {
 		*state.Account
 		async      bool
@@ -177,6 +182,14 @@ func (c *contract) Snapshot() Contract {
 	}
 }
 
+// StorageTrieHashFunc returns the hash func of the storage trie of the contract
+func StorageTrieHashFunc(addr hash.Hash160) mptrie.HashFunc {
+	return func(data []byte) []byte {
+		h := hash.Hash256b(append(addr[:], data...))
+		return h[:]
+	}
+}
+
 // newContract returns a Contract instance
 func newContract(addr hash.Hash160, account *state.Account, sm protocol.StateManager, enableAsync bool) (Contract, error) {
 	c := &contract{
@@ -189,10 +202,7 @@ func newContract(addr hash.Hash160, account *state.Account, sm protocol.StateMan
 	options := []mptrie.Option{
 		mptrie.KVStoreOption(protocol.NewKVStoreForTrieWithStateManager(ContractKVNameSpace, sm)),
 		mptrie.KeyLengthOption(len(hash.Hash256{})),
-		mptrie.HashFuncOption(func(data []byte) []byte {
-			h := hash.Hash256b(append(addr[:], data...))
-			return h[:]
-		}),
+		mptrie.HashFuncOption(StorageTrieHashFunc(addr)),
 	}
 	if account.Root != hash.ZeroHash256 {
 		options = append(options, mptrie.RootHashOption(account.Root[:]))
@@ -200,6 +210,9 @@ func newContract(addr hash.Hash160, account *state.Account, sm protocol.StateMan
 	if enableAsync {
 		options = append(options, mptrie.AsyncOption())
 	}
+	if c, ok := sm.(trieNodeCacher); ok {
+		options = append(options, mptrie.NodeCacheOption(c.TrieNodeCache()))
+	}
 
 	tr, err := mptrie.New(options...)
 	if err != nil {
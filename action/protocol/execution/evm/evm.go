@@ -95,6 +95,8 @@ type (
 		featureCtx  protocol.FeatureCtx
 		actionCtx   protocol.ActionCtx
 		helperCtx   HelperContext
+		// gasBreakdown is the gas consumed by phase, filled in the execution
+		gasBreakdown *action.GasBreakdown
 	}
 
 	stateDB interface {
@@ -178,7 +180,7 @@ func newParams(
 	}
 	if g.IsVanuatu(blkCtx.BlockHeight) {
 		// enable BLOBBASEFEE opcode
-		context.BlobBaseFee = protocol.CalcBlobFee(blkCtx.ExcessBlobGas)
+		context.BlobBaseFee = protocol.CalcBlobFee(g.Blockchain, blkCtx.BlockHeight, blkCtx.ExcessBlobGas)
 		// enable BASEFEE opcode
 		if blkCtx.BaseFee != nil {
 			context.BaseFee = new(big.Int).Set(blkCtx.BaseFee)
@@ -219,6 +221,7 @@ func newParams(
 		featureCtx,
 		actionCtx,
 		helperCtx,
+		&action.GasBreakdown{},
 	}, nil
 }
 
@@ -280,6 +283,7 @@ func ExecuteContract(
 		Status:            uint64(statusCode),
 		EffectiveGasPrice: protocol.EffectiveGasPrice(ctx, execution),
 	}
+	receipt.SetGasBreakdown(ps.gasBreakdown)
 	var (
 		depositLog  []*action.TransactionLog
 		burnLog     *action.TransactionLog
@@ -488,6 +492,11 @@ func executeInEVM(ctx context.Context, evmParams *Params, stateDB stateDB) ([]by
 		accessList types.AccessList
 	)
 	evm := vm.NewEVM(evmParams.context, evmParams.txCtx, stateDB, chainConfig, evmParams.evmConfig)
+	if evmParams.actionCtx.ReadOnly {
+		// only a simulation is aborted once the context is done, the execution of actions in blocks never is
+		stop := context.AfterFunc(ctx, evm.Cancel)
+		defer stop()
+	}
 	if g.IsOkhotsk(blockHeight) {
 		accessList = evmParams.accessList
 	}
@@ -499,6 +508,7 @@ func executeInEVM(ctx context.Context, evmParams *Params, stateDB stateDB) ([]by
 		return nil, evmParams.gas, remainingGas, action.EmptyAddress, iotextypes.ReceiptStatus_Failure, action.ErrInsufficientFunds
 	}
 	remainingGas -= intriGas
+	evmParams.gasBreakdown.Intrinsic = intriGas
 
 	// Set up the initial access list
 	rules := chainConfig.Rules(evm.Context.BlockNumber, g.IsSumatra(evmParams.blkCtx.BlockHeight), evmParams.context.Time)
@@ -534,6 +544,9 @@ func executeInEVM(ctx context.Context, evmParams *Params, stateDB stateDB) ([]by
 		// process contract
 		ret, remainingGas, evmErr = evm.Call(executor, *evmParams.contract, evmParams.data, remainingGas, amount)
 	}
+	if evm.Cancelled() {
+		return nil, evmParams.gas, remainingGas, action.EmptyAddress, iotextypes.ReceiptStatus_Failure, errors.Wrap(context.Cause(ctx), "evm execution is aborted")
+	}
 	if evmErr != nil {
 		log.T(ctx).Debug("evm error", zap.Error(evmErr))
 		// The only possible consensus-error would be if there wasn't
@@ -570,6 +583,8 @@ func executeInEVM(ctx context.Context, evmParams *Params, stateDB stateDB) ([]by
 	if refund > stateDB.GetRefund() {
 		refund = stateDB.GetRefund()
 	}
+	evmParams.gasBreakdown.Execution = evmParams.gas - intriGas - remainingGas
+	evmParams.gasBreakdown.Refund = refund
 	remainingGas += refund
 
 	errCode := iotextypes.ReceiptStatus_Success
@@ -706,7 +721,7 @@ func SimulateExecution(
 			GasLimit:       g.BlockGasLimitByHeight(bcCtx.Tip.Height + 1),
 			Producer:       zeroAddr,
 			BaseFee:        protocol.CalcBaseFee(g.Blockchain, &bcCtx.Tip),
-			ExcessBlobGas:  protocol.CalcExcessBlobGas(bcCtx.Tip.ExcessBlobGas, bcCtx.Tip.BlobGasUsed),
+			ExcessBlobGas:  protocol.CalcExcessBlobGas(g.Blockchain, bcCtx.Tip.Height+1, bcCtx.Tip.ExcessBlobGas, bcCtx.Tip.BlobGasUsed),
 		},
 	))
 	return ExecuteContract(ctx, sm, ex)
@@ -0,0 +1,146 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package feetoken
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+)
+
+const (
+	_eventsABI = `[
+		{
+			"anonymous": false,
+			"inputs": [
+				{"indexed": true, "internalType": "address", "name": "account", "type": "address"},
+				{"indexed": true, "internalType": "address", "name": "token", "type": "address"}
+			],
+			"name": "FeeTokenSet",
+			"type": "event"
+		},
+		{
+			"anonymous": false,
+			"inputs": [
+				{"indexed": true, "internalType": "address", "name": "account", "type": "address"},
+				{"indexed": true, "internalType": "address", "name": "token", "type": "address"},
+				{"indexed": false, "internalType": "uint256", "name": "tokenAmount", "type": "uint256"},
+				{"indexed": false, "internalType": "uint256", "name": "amount", "type": "uint256"}
+			],
+			"name": "GasPaid",
+			"type": "event"
+		}
+	]`
+
+	_payerLength   = common.AddressLength
+	_advanceLength = common.AddressLength + 2*common.HashLength
+	_debtLength    = common.HashLength
+)
+
+var (
+	_events         abi.ABI
+	_feeTokenSetEvt abi.Event
+	_gasPaidEvt     abi.Event
+)
+
+func init() {
+	var err error
+	_events, err = abi.JSON(strings.NewReader(_eventsABI))
+	if err != nil {
+		panic(err)
+	}
+	_feeTokenSetEvt = _events.Events["FeeTokenSet"]
+	_gasPaidEvt = _events.Events["GasPaid"]
+}
+
+type (
+	// payer is the asset an account pays the gas in
+	payer struct {
+		Token common.Address
+	}
+
+	// advance is the IOTX advanced to the caller of the running action for its gas, and the asset charged for it
+	advance struct {
+		Token       common.Address
+		TokenAmount *big.Int
+		Amount      *big.Int
+	}
+
+	// debt is the IOTX advanced in the block not settled from the reserve yet
+	debt struct {
+		Amount *big.Int
+	}
+)
+
+// Serialize serializes the payer into bytes
+func (p *payer) Serialize() ([]byte, error) {
+	return p.Token.Bytes(), nil
+}
+
+// Deserialize deserializes bytes into the payer
+func (p *payer) Deserialize(buf []byte) error {
+	if len(buf) != _payerLength {
+		return errors.Errorf("invalid payer length %d", len(buf))
+	}
+	p.Token = common.BytesToAddress(buf)
+	return nil
+}
+
+// Serialize serializes the advance into bytes
+func (a *advance) Serialize() ([]byte, error) {
+	buf := make([]byte, 0, _advanceLength)
+	buf = append(buf, a.Token.Bytes()...)
+	buf = append(buf, common.BigToHash(a.TokenAmount).Bytes()...)
+	return append(buf, common.BigToHash(a.Amount).Bytes()...), nil
+}
+
+// Deserialize deserializes bytes into the advance
+func (a *advance) Deserialize(buf []byte) error {
+	if len(buf) != _advanceLength {
+		return errors.Errorf("invalid advance length %d", len(buf))
+	}
+	a.Token = common.BytesToAddress(buf[:common.AddressLength])
+	buf = buf[common.AddressLength:]
+	a.TokenAmount = new(big.Int).SetBytes(buf[:common.HashLength])
+	a.Amount = new(big.Int).SetBytes(buf[common.HashLength:])
+	return nil
+}
+
+// Serialize serializes the debt into bytes
+func (d *debt) Serialize() ([]byte, error) {
+	return common.BigToHash(d.Amount).Bytes(), nil
+}
+
+// Deserialize deserializes bytes into the debt
+func (d *debt) Deserialize(buf []byte) error {
+	if len(buf) != _debtLength {
+		return errors.Errorf("invalid debt length %d", len(buf))
+	}
+	d.Amount = new(big.Int).SetBytes(buf)
+	return nil
+}
+
+func addressTopic(addr common.Address) hash.Hash256 {
+	return hash.Hash256(common.BytesToHash(addr.Bytes()))
+}
+
+func newLog(contract string, event abi.Event, topics []hash.Hash256, data ...interface{}) (*action.Log, error) {
+	buf, err := event.Inputs.NonIndexed().Pack(data...)
+	if err != nil {
+		return nil, err
+	}
+	return &action.Log{
+		Address: contract,
+		Topics:  append([]hash.Hash256{hash.Hash256(event.ID)}, topics...),
+		Data:    buf,
+	}, nil
+}
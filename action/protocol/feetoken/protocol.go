@@ -0,0 +1,720 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package feetoken
+
+import (
+	"context"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/abiregistry"
+	accountutil "github.com/iotexproject/iotex-core/v2/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/execution/evm"
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+	"github.com/iotexproject/iotex-core/v2/state"
+)
+
+const (
+	_protocolID        = "feetoken"
+	_feeTokenNamespace = "FeeToken"
+
+	// CallGasLimit is the gas limit of the calls of the protocol to the oracle and the assets
+	CallGasLimit = uint64(200000)
+
+	_methodsABI = `[
+		{
+			"inputs": [
+				{"internalType": "address", "name": "token", "type": "address"}
+			],
+			"name": "setFeeToken",
+			"outputs": [],
+			"stateMutability": "nonpayable",
+			"type": "function"
+		}
+	]`
+
+	_externalABI = `[
+		{
+			"inputs": [{"internalType": "address", "name": "token", "type": "address"}],
+			"name": "rateOf",
+			"outputs": [{"internalType": "uint256", "name": "", "type": "uint256"}],
+			"stateMutability": "view",
+			"type": "function"
+		},
+		{
+			"inputs": [
+				{"internalType": "address", "name": "from", "type": "address"},
+				{"internalType": "address", "name": "to", "type": "address"},
+				{"internalType": "uint256", "name": "amount", "type": "uint256"}
+			],
+			"name": "transferFrom",
+			"outputs": [{"internalType": "bool", "name": "", "type": "bool"}],
+			"stateMutability": "nonpayable",
+			"type": "function"
+		},
+		{
+			"inputs": [
+				{"internalType": "address", "name": "to", "type": "address"},
+				{"internalType": "uint256", "name": "amount", "type": "uint256"}
+			],
+			"name": "transfer",
+			"outputs": [{"internalType": "bool", "name": "", "type": "bool"}],
+			"stateMutability": "nonpayable",
+			"type": "function"
+		}
+	]`
+)
+
+var (
+	_payerPrefix = []byte("pay")
+	_advanceKey  = []byte("adv")
+	_debtKey     = []byte("dbt")
+
+	_methods  abi.ABI
+	_external abi.ABI
+
+	// _rateBase is the amount of the smallest unit of an asset the oracle rate is quoted for
+	_rateBase = new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+
+	// ErrTokenNotWhitelisted indicates the asset is not allowed to pay the gas
+	ErrTokenNotWhitelisted = errors.New("token is not whitelisted to pay the gas")
+)
+
+func init() {
+	var err error
+	_methods, err = abi.JSON(strings.NewReader(_methodsABI))
+	if err != nil {
+		panic(err)
+	}
+	_external, err = abi.JSON(strings.NewReader(_externalABI))
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Protocol defines the experimental protocol of paying the gas in the whitelisted XRC20 wrapped assets. An account
+// opts in by approving the protocol address to spend its asset and calling setFeeToken on the protocol address. Before
+// an action of the account is handled, the protocol charges the asset worth the max gas fee at the rate of the oracle,
+// and advances the max gas fee in IOTX to the account, out of which the gas is deposited as usual. After the action is
+// handled, the unused IOTX is taken back and the asset for it is refunded. The IOTX advanced in a block is settled from
+// the reserve of the protocol, which is the IOTX balance of the protocol address, at the end of the block. The account
+// pays the gas in IOTX as usual if the reserve, the oracle or the asset cannot cover the max gas fee. Only the gas of the
+// transfers and the executions is paid in the asset, whose value the account pays in IOTX by itself. As a gas sponsor,
+// the protocol lets the actpool and the action validation check the IOTX balance of the account against the value
+// only, so that an account holding no IOTX can send them. The advance never pays for anything but the gas, and the
+// reserve is charged the gas actually used
+type Protocol struct {
+	addr       address.Address
+	tokens     map[common.Address]struct{}
+	oracle     common.Address
+	depositGas protocol.DepositGas
+}
+
+// NewProtocol instantiates the fee token protocol
+func NewProtocol(cfg genesis.FeeToken, depositGas protocol.DepositGas) (*Protocol, error) {
+	if len(cfg.FeeTokens) == 0 {
+		return nil, errors.New("no fee token is whitelisted")
+	}
+	tokens := make(map[common.Address]struct{}, len(cfg.FeeTokens))
+	for _, t := range cfg.FeeTokens {
+		addr, err := address.FromString(t)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid fee token %s", t)
+		}
+		tokens[common.BytesToAddress(addr.Bytes())] = struct{}{}
+	}
+	oracle, err := address.FromString(cfg.FeeTokenOracle)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid fee token oracle %s", cfg.FeeTokenOracle)
+	}
+	return &Protocol{
+		addr:       ProtocolAddr(),
+		tokens:     tokens,
+		oracle:     common.BytesToAddress(oracle.Bytes()),
+		depositGas: depositGas,
+	}, nil
+}
+
+// ContractABI returns the ABI of the methods and the events of the protocol as a system contract
+func ContractABI() string {
+	return abiregistry.MustJoinABI(_methodsABI, _eventsABI)
+}
+
+// ProtocolAddr returns the address generated from protocol id
+func ProtocolAddr() address.Address {
+	return protocol.HashStringToAddress(_protocolID)
+}
+
+// FindProtocol finds the registered protocol from registry
+func FindProtocol(registry *protocol.Registry) *Protocol {
+	if registry == nil {
+		return nil
+	}
+	p, ok := registry.Find(_protocolID)
+	if !ok {
+		return nil
+	}
+	fp, ok := p.(*Protocol)
+	if !ok {
+		log.S().Panic("fail to cast fee token protocol")
+	}
+	return fp
+}
+
+// Validate validates an action calling the protocol
+func (p *Protocol) Validate(ctx context.Context, elp action.Envelope, _ protocol.StateReader) error {
+	exec, ok := p.feeTokenExecution(ctx, elp)
+	if !ok {
+		return nil
+	}
+	if exec.Amount().Sign() != 0 {
+		return errors.Wrap(action.ErrInvalidAct, "fee token action cannot transfer value")
+	}
+	if _, _, err := unpackMethod(exec.Data()); err != nil {
+		return errors.Wrap(action.ErrInvalidAct, err.Error())
+	}
+	return nil
+}
+
+// Handle handles the actions calling the protocol
+func (p *Protocol) Handle(ctx context.Context, elp action.Envelope, sm protocol.StateManager) (*action.Receipt, error) {
+	exec, ok := p.feeTokenExecution(ctx, elp)
+	if !ok {
+		return nil, nil
+	}
+	si := sm.Snapshot()
+	logs, err := p.handle(ctx, sm, exec.Data())
+	if err != nil {
+		log.L().Debug("Error when handling fee token action", zap.Error(err))
+		return p.settleAction(ctx, sm, elp, uint64(iotextypes.ReceiptStatus_Failure), si, nil)
+	}
+	return p.settleAction(ctx, sm, elp, uint64(iotextypes.ReceiptStatus_Success), si, logs)
+}
+
+func (p *Protocol) feeTokenExecution(ctx context.Context, elp action.Envelope) (*action.Execution, bool) {
+	exec, ok := elp.Action().(*action.Execution)
+	if !ok || exec.Contract() != p.addr.String() {
+		return nil, false
+	}
+	return exec, protocol.MustGetFeatureCtx(ctx).EnableFeeToken
+}
+
+func unpackMethod(data []byte) (*abi.Method, []interface{}, error) {
+	if len(data) < 4 {
+		return nil, nil, errors.New("invalid fee token call data")
+	}
+	method, err := _methods.MethodById(data[:4])
+	if err != nil {
+		return nil, nil, err
+	}
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to unpack arguments of %s", method.Name)
+	}
+	return method, args, nil
+}
+
+func (p *Protocol) handle(ctx context.Context, sm protocol.StateManager, data []byte) ([]*action.Log, error) {
+	_, args, err := unpackMethod(data)
+	if err != nil {
+		return nil, err
+	}
+	// setFeeToken is the only method
+	lg, err := p.setFeeToken(ctx, sm, args[0].(common.Address))
+	if err != nil {
+		return nil, err
+	}
+	var (
+		blkCtx    = protocol.MustGetBlockCtx(ctx)
+		actionCtx = protocol.MustGetActionCtx(ctx)
+	)
+	lg.BlockHeight = blkCtx.BlockHeight
+	lg.ActionHash = actionCtx.ActionHash
+	return []*action.Log{lg}, nil
+}
+
+// setFeeToken sets the asset the caller pays the gas in, the zero address opts out
+func (p *Protocol) setFeeToken(ctx context.Context, sm protocol.StateManager, token common.Address) (*action.Log, error) {
+	caller := protocol.MustGetActionCtx(ctx).Caller
+	key := payerKey(caller)
+	if token == (common.Address{}) {
+		if _, err := sm.DelState(protocol.KeyOption(key), protocol.NamespaceOption(_feeTokenNamespace)); err != nil && errors.Cause(err) != state.ErrStateNotExist {
+			return nil, err
+		}
+	} else {
+		if _, ok := p.tokens[token]; !ok {
+			return nil, errors.Wrap(ErrTokenNotWhitelisted, token.Hex())
+		}
+		if err := p.putState(sm, key, &payer{Token: token}); err != nil {
+			return nil, err
+		}
+	}
+	return newLog(p.addr.String(), _feeTokenSetEvt, []hash.Hash256{
+		addressTopic(common.BytesToAddress(caller.Bytes())), addressTopic(token),
+	})
+}
+
+// FeeToken returns the asset the account pays the gas in
+func (p *Protocol) FeeToken(sr protocol.StateReader, addr address.Address) (common.Address, error) {
+	py := &payer{}
+	if _, err := p.state(sr, payerKey(addr), py); err != nil {
+		return common.Address{}, err
+	}
+	if _, ok := p.tokens[py.Token]; !ok {
+		return common.Address{}, errors.Wrap(ErrTokenNotWhitelisted, py.Token.Hex())
+	}
+	return py.Token, nil
+}
+
+// SponsorsGas returns if the fee token is enabled
+func (p *Protocol) SponsorsGas(ctx context.Context) bool {
+	return protocol.MustGetFeatureCtx(ctx).EnableFeeToken
+}
+
+// SponsoredGasFee returns the max gas fee of the action if the caller has opted in and the reserve can cover it
+func (p *Protocol) SponsoredGasFee(ctx context.Context, sr protocol.StateReader, selp *action.SealedEnvelope) (*big.Int, error) {
+	none := new(big.Int)
+	if !p.SponsorsGas(ctx) {
+		return none, nil
+	}
+	value, ok := sponsoredValue(selp.Envelope)
+	if !ok {
+		return none, nil
+	}
+	if _, err := p.FeeToken(sr, selp.SenderAddress()); err != nil {
+		switch errors.Cause(err) {
+		case state.ErrStateNotExist, ErrTokenNotWhitelisted:
+			return none, nil
+		default:
+			return nil, err
+		}
+	}
+	maxFee := new(big.Int).Mul(new(big.Int).SetUint64(selp.Gas()), selp.GasFeeCap())
+	reserve, err := accountutil.AccountState(ctx, sr, p.addr)
+	if err != nil {
+		return nil, err
+	}
+	d, err := p.debt(sr)
+	if err != nil {
+		return nil, err
+	}
+	if reserve.Balance.Cmp(new(big.Int).Add(d.Amount, maxFee)) < 0 {
+		return none, nil
+	}
+	// the caller is left to pay the value
+	cost, err := selp.Cost()
+	if err != nil {
+		return nil, err
+	}
+	return cost.Sub(cost, value), nil
+}
+
+// sponsoredValue returns the value of the action whose gas can be paid in the asset, which are the actions spending
+// nothing but the value and the gas of the caller
+func sponsoredValue(elp action.Envelope) (*big.Int, bool) {
+	if len(elp.BlobHashes()) > 0 {
+		return nil, false
+	}
+	switch act := elp.Action().(type) {
+	case *action.Transfer:
+		return act.Amount(), true
+	case *action.Execution:
+		return act.Amount(), true
+	default:
+		return nil, false
+	}
+}
+
+// PreHandle charges the asset for the max gas fee of the action and advances the fee in IOTX to the caller
+func (p *Protocol) PreHandle(ctx context.Context, elp action.Envelope, sm protocol.StateManager) error {
+	if !protocol.MustGetFeatureCtx(ctx).EnableFeeToken {
+		return nil
+	}
+	value, ok := sponsoredValue(elp)
+	if !ok {
+		return nil
+	}
+	caller := protocol.MustGetActionCtx(ctx).Caller
+	token, err := p.FeeToken(sm, caller)
+	switch errors.Cause(err) {
+	case nil:
+	case state.ErrStateNotExist, ErrTokenNotWhitelisted:
+		return nil
+	default:
+		return err
+	}
+	maxFee := new(big.Int).Mul(new(big.Int).SetUint64(elp.Gas()), elp.GasFeeCap())
+	if maxFee.Sign() == 0 {
+		return nil
+	}
+	acc, err := accountutil.LoadAccount(sm, caller)
+	if err != nil {
+		return err
+	}
+	// the caller pays the value by itself, so that the advance cannot be spent as the value
+	if acc.Balance.Cmp(value) < 0 {
+		return nil
+	}
+	si := sm.Snapshot()
+	ok, err = p.advance(ctx, sm, caller, token, maxFee)
+	if err != nil {
+		return err
+	}
+	if ok {
+		return nil
+	}
+	if err := sm.Revert(si); err != nil {
+		return err
+	}
+	cost, err := elp.Cost()
+	if err != nil {
+		return err
+	}
+	if acc.Balance.Cmp(cost) < 0 {
+		return errors.Wrapf(protocol.ErrGasNotSponsored, "failed to pay the gas of %s in %s", caller.String(), token.Hex())
+	}
+	// pay the gas in IOTX
+	return nil
+}
+
+func (p *Protocol) advance(ctx context.Context, sm protocol.StateManager, caller address.Address, token common.Address, maxFee *big.Int) (bool, error) {
+	reserve, err := accountutil.LoadOrCreateAccount(sm, p.addr)
+	if err != nil {
+		return false, err
+	}
+	d, err := p.debt(sm)
+	if err != nil {
+		return false, err
+	}
+	if reserve.Balance.Cmp(new(big.Int).Add(d.Amount, maxFee)) < 0 {
+		log.L().Debug("fee token reserve is insufficient", zap.String("reserve", reserve.Balance.String()))
+		return false, nil
+	}
+	rate, err := p.rateOf(ctx, sm, token)
+	if err != nil || rate.Sign() <= 0 {
+		log.L().Debug("failed to get the rate of fee token", zap.String("token", token.Hex()), zap.Error(err))
+		return false, nil
+	}
+	// round up the asset charged
+	tokenAmount := new(big.Int).Mul(maxFee, _rateBase)
+	tokenAmount.Add(tokenAmount, new(big.Int).Sub(rate, big.NewInt(1))).Div(tokenAmount, rate)
+	from := common.BytesToAddress(caller.Bytes())
+	if err := p.callToken(ctx, sm, token, "transferFrom", from, common.BytesToAddress(p.addr.Bytes()), tokenAmount); err != nil {
+		log.L().Debug("failed to charge fee token", zap.String("token", token.Hex()), zap.Error(err))
+		return false, nil
+	}
+	acc, err := accountutil.LoadAccount(sm, caller)
+	if err != nil {
+		return false, err
+	}
+	if err := acc.AddBalance(maxFee); err != nil {
+		return false, err
+	}
+	if err := accountutil.StoreAccount(sm, caller, acc); err != nil {
+		return false, err
+	}
+	d.Amount.Add(d.Amount, maxFee)
+	if err := p.putState(sm, _debtKey, d); err != nil {
+		return false, err
+	}
+	return true, p.putState(sm, _advanceKey, &advance{Token: token, TokenAmount: tokenAmount, Amount: maxFee})
+}
+
+// HandleReceipt takes back the IOTX advanced but not used by the action, and refunds the asset for it
+func (p *Protocol) HandleReceipt(ctx context.Context, elp action.Envelope, sm protocol.StateManager, receipt *action.Receipt) error {
+	if !protocol.MustGetFeatureCtx(ctx).EnableFeeToken {
+		return nil
+	}
+	adv := &advance{}
+	if _, err := p.state(sm, _advanceKey, adv); err != nil {
+		if errors.Cause(err) == state.ErrStateNotExist {
+			return nil
+		}
+		return err
+	}
+	if _, err := sm.DelState(protocol.KeyOption(_advanceKey), protocol.NamespaceOption(_feeTokenNamespace)); err != nil {
+		return err
+	}
+	caller := protocol.MustGetActionCtx(ctx).Caller
+	price := receipt.EffectiveGasPrice
+	if price == nil {
+		price = elp.GasPrice()
+	}
+	unused := new(big.Int).Sub(adv.Amount, new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasConsumed), price))
+	tokenRefund := new(big.Int)
+	if unused.Sign() > 0 {
+		// the caller has paid the value by itself in PreHandle, so the unused IOTX is always left in its balance
+		acc, err := accountutil.LoadAccount(sm, caller)
+		if err != nil {
+			return err
+		}
+		if err := acc.SubBalance(unused); err != nil {
+			return errors.Wrap(err, "failed to take back the unused advance")
+		}
+		if err := accountutil.StoreAccount(sm, caller, acc); err != nil {
+			return err
+		}
+		d, err := p.debt(sm)
+		if err != nil {
+			return err
+		}
+		d.Amount.Sub(d.Amount, unused)
+		if err := p.putState(sm, _debtKey, d); err != nil {
+			return err
+		}
+		tokenRefund.Mul(adv.TokenAmount, unused).Div(tokenRefund, adv.Amount)
+		if err := p.callToken(ctx, sm, adv.Token, "transfer", common.BytesToAddress(caller.Bytes()), tokenRefund); err != nil {
+			// the reserve is never charged more than the gas used, the asset not refunded stays with the protocol
+			log.L().Debug("failed to refund fee token", zap.String("token", adv.Token.Hex()), zap.Error(err))
+			tokenRefund.SetInt64(0)
+		}
+	}
+	lg, err := newLog(p.addr.String(), _gasPaidEvt, []hash.Hash256{
+		addressTopic(common.BytesToAddress(caller.Bytes())), addressTopic(adv.Token),
+	}, new(big.Int).Sub(adv.TokenAmount, tokenRefund), new(big.Int).Sub(adv.Amount, unused))
+	if err != nil {
+		return err
+	}
+	lg.BlockHeight = receipt.BlockHeight
+	lg.ActionHash = receipt.ActionHash
+	receipt.AddLogs(lg)
+	return nil
+}
+
+// HandleBlockEnd settles the IOTX advanced in the block from the reserve
+func (p *Protocol) HandleBlockEnd(ctx context.Context, sm protocol.StateManager) error {
+	if !protocol.MustGetFeatureCtx(ctx).EnableFeeToken {
+		return nil
+	}
+	d, err := p.debt(sm)
+	if err != nil {
+		return err
+	}
+	if d.Amount.Sign() == 0 {
+		return nil
+	}
+	reserve, err := accountutil.LoadOrCreateAccount(sm, p.addr)
+	if err != nil {
+		return err
+	}
+	if err := reserve.SubBalance(d.Amount); err != nil {
+		return errors.Wrap(err, "failed to settle the advanced gas from the reserve")
+	}
+	if err := accountutil.StoreAccount(sm, p.addr, reserve); err != nil {
+		return err
+	}
+	_, err = sm.DelState(protocol.KeyOption(_debtKey), protocol.NamespaceOption(_feeTokenNamespace))
+	return err
+}
+
+func (p *Protocol) debt(sr protocol.StateReader) (*debt, error) {
+	d := &debt{}
+	if _, err := p.state(sr, _debtKey, d); err != nil {
+		if errors.Cause(err) != state.ErrStateNotExist {
+			return nil, err
+		}
+		d.Amount = new(big.Int)
+	}
+	return d, nil
+}
+
+func (p *Protocol) rateOf(ctx context.Context, sm protocol.StateManager, token common.Address) (*big.Int, error) {
+	data, err := _external.Pack("rateOf", token)
+	if err != nil {
+		return nil, err
+	}
+	// the oracle is read only
+	si := sm.Snapshot()
+	ret, err := p.callContract(ctx, sm, p.oracle, data)
+	if revertErr := sm.Revert(si); revertErr != nil {
+		return nil, revertErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	out, err := _external.Unpack("rateOf", ret)
+	if err != nil {
+		return nil, err
+	}
+	return out[0].(*big.Int), nil
+}
+
+func (p *Protocol) callToken(ctx context.Context, sm protocol.StateManager, token common.Address, method string, args ...interface{}) error {
+	data, err := _external.Pack(method, args...)
+	if err != nil {
+		return err
+	}
+	ret, err := p.callContract(ctx, sm, token, data)
+	if err != nil {
+		return err
+	}
+	// the asset returning nothing succeeds if not reverted
+	if len(ret) > 0 {
+		out, err := _external.Unpack(method, ret)
+		if err != nil {
+			return err
+		}
+		if succeeded, ok := out[0].(bool); !ok || !succeeded {
+			return errors.Errorf("%s returns false", method)
+		}
+	}
+	return nil
+}
+
+// callContract calls the contract from the protocol address, of which the gas is free
+func (p *Protocol) callContract(ctx context.Context, sm protocol.StateManager, contract common.Address, data []byte) ([]byte, error) {
+	addr, err := address.FromBytes(contract.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	elp := (&action.EnvelopeBuilder{}).SetGasLimit(CallGasLimit).SetGasPrice(big.NewInt(0)).
+		SetAction(action.NewExecution(addr.String(), big.NewInt(0), data)).Build()
+	intrinsicGas, err := elp.IntrinsicGas()
+	if err != nil {
+		return nil, err
+	}
+	var (
+		bcCtx     = protocol.MustGetBlockchainCtx(ctx)
+		blkCtx    = protocol.MustGetBlockCtx(ctx)
+		actionCtx = protocol.MustGetActionCtx(ctx)
+	)
+	blkCtx.BaseFee = nil
+	blkCtx.GasLimit = CallGasLimit
+	actionCtx.Caller = p.addr
+	actionCtx.GasPrice = big.NewInt(0)
+	actionCtx.IntrinsicGas = intrinsicGas
+	ctx = evm.WithHelperCtx(protocol.WithBlockCtx(protocol.WithActionCtx(ctx, actionCtx), blkCtx), evm.HelperContext{
+		GetBlockHash: bcCtx.GetBlockHash,
+		GetBlockTime: bcCtx.GetBlockTime,
+		DepositGasFunc: func(context.Context, protocol.StateManager, *big.Int, ...protocol.DepositOption) ([]*action.TransactionLog, error) {
+			return nil, nil
+		},
+	})
+	ret, receipt, err := evm.ExecuteContract(ctx, sm, elp)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status != uint64(iotextypes.ReceiptStatus_Success) {
+		return nil, errors.Errorf("call to %s failed with status %d", contract.Hex(), receipt.Status)
+	}
+	return ret, nil
+}
+
+// ReadState read the state on blockchain via protocol
+func (p *Protocol) ReadState(ctx context.Context, sr protocol.StateReader, method []byte, args ...[]byte) ([]byte, uint64, error) {
+	switch string(method) {
+	case "FeeToken":
+		if len(args) != 1 {
+			return nil, 0, errors.Errorf("invalid number of arguments %d", len(args))
+		}
+		addr, err := address.FromString(string(args[0]))
+		if err != nil {
+			return nil, 0, err
+		}
+		py := &payer{}
+		height, err := p.state(sr, payerKey(addr), py)
+		if err != nil {
+			return nil, 0, err
+		}
+		data, err := py.Serialize()
+		return data, height, err
+	default:
+		return nil, 0, errors.New("corresponding method isn't found")
+	}
+}
+
+// Register registers the protocol with a unique ID
+func (p *Protocol) Register(r *protocol.Registry) error {
+	return r.Register(_protocolID, p)
+}
+
+// ForceRegister registers the protocol with a unique ID and force replacing the previous protocol if it exists
+func (p *Protocol) ForceRegister(r *protocol.Registry) error {
+	return r.ForceRegister(_protocolID, p)
+}
+
+// Name returns the name of protocol
+func (p *Protocol) Name() string {
+	return _protocolID
+}
+
+func (p *Protocol) state(sr protocol.StateReader, key []byte, value interface{}) (uint64, error) {
+	return sr.State(value, protocol.KeyOption(key), protocol.NamespaceOption(_feeTokenNamespace))
+}
+
+func (p *Protocol) putState(sm protocol.StateManager, key []byte, value interface{}) error {
+	_, err := sm.PutState(value, protocol.KeyOption(key), protocol.NamespaceOption(_feeTokenNamespace))
+	return err
+}
+
+func (p *Protocol) settleAction(
+	ctx context.Context,
+	sm protocol.StateManager,
+	elp action.Envelope,
+	status uint64,
+	si int,
+	logs []*action.Log,
+) (*action.Receipt, error) {
+	var (
+		actionCtx = protocol.MustGetActionCtx(ctx)
+		blkCtx    = protocol.MustGetBlockCtx(ctx)
+		fCtx      = protocol.MustGetFeatureCtx(ctx)
+		gas       = actionCtx.IntrinsicGas
+		tLogs     []*action.TransactionLog
+	)
+	if status == uint64(iotextypes.ReceiptStatus_Failure) {
+		if err := sm.Revert(si); err != nil {
+			return nil, err
+		}
+	}
+	priorityFee, baseFee, err := protocol.SplitGas(ctx, elp, gas)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to split gas")
+	}
+	if p.depositGas != nil {
+		tLogs, err = p.depositGas(ctx, sm, baseFee, protocol.PriorityFeeOption(priorityFee))
+		if err != nil {
+			return nil, err
+		}
+	}
+	accountCreationOpts := []state.AccountCreationOption{}
+	if fCtx.CreateLegacyNonceAccount {
+		accountCreationOpts = append(accountCreationOpts, state.LegacyNonceAccountTypeOption())
+	}
+	acc, err := accountutil.LoadOrCreateAccount(sm, actionCtx.Caller, accountCreationOpts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := acc.SetPendingNonce(actionCtx.Nonce + 1); err != nil {
+		return nil, errors.Wrapf(err, "invalid nonce %d", actionCtx.Nonce)
+	}
+	if err := accountutil.StoreAccount(sm, actionCtx.Caller, acc); err != nil {
+		return nil, err
+	}
+	return (&action.Receipt{
+		Status:            status,
+		BlockHeight:       blkCtx.BlockHeight,
+		ActionHash:        actionCtx.ActionHash,
+		GasConsumed:       gas,
+		ContractAddress:   p.addr.String(),
+		EffectiveGasPrice: protocol.EffectiveGasPrice(ctx, elp),
+	}).AddLogs(logs...).AddTransactionLogs(tLogs...), nil
+}
+
+func payerKey(addr address.Address) []byte {
+	return append(append(make([]byte, 0, len(_payerPrefix)+common.AddressLength), _payerPrefix...), addr.Bytes()...)
+}
@@ -0,0 +1,277 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package feetoken
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	accountutil "github.com/iotexproject/iotex-core/v2/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/state"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+	"github.com/iotexproject/iotex-core/v2/testutil/testdb"
+)
+
+func TestSerialization(t *testing.T) {
+	require := require.New(t)
+
+	py := &payer{Token: common.HexToAddress("0x01")}
+	buf, err := py.Serialize()
+	require.NoError(err)
+	py2 := &payer{}
+	require.NoError(py2.Deserialize(buf))
+	require.Equal(py, py2)
+	require.Error(py2.Deserialize(buf[1:]))
+
+	adv := &advance{Token: common.HexToAddress("0x02"), TokenAmount: big.NewInt(30), Amount: big.NewInt(40)}
+	buf, err = adv.Serialize()
+	require.NoError(err)
+	adv2 := &advance{}
+	require.NoError(adv2.Deserialize(buf))
+	require.Equal(adv, adv2)
+	require.Error(adv2.Deserialize(buf[1:]))
+
+	d := &debt{Amount: big.NewInt(50)}
+	buf, err = d.Serialize()
+	require.NoError(err)
+	d2 := &debt{}
+	require.NoError(d2.Deserialize(buf))
+	require.Equal(d, d2)
+	require.Error(d2.Deserialize(buf[1:]))
+}
+
+func TestNewProtocol(t *testing.T) {
+	require := require.New(t)
+	token, oracle := identityset.Address(10).String(), identityset.Address(11).String()
+
+	_, err := NewProtocol(genesis.FeeToken{FeeTokenOracle: oracle}, nil)
+	require.Error(err)
+	_, err = NewProtocol(genesis.FeeToken{FeeTokens: []string{"invalid"}, FeeTokenOracle: oracle}, nil)
+	require.Error(err)
+	_, err = NewProtocol(genesis.FeeToken{FeeTokens: []string{token}}, nil)
+	require.Error(err)
+	p, err := NewProtocol(genesis.FeeToken{FeeTokens: []string{token}, FeeTokenOracle: oracle}, nil)
+	require.NoError(err)
+	require.Equal(_protocolID, p.Name())
+	require.Equal(ProtocolAddr(), p.addr)
+	require.Contains(p.tokens, common.BytesToAddress(identityset.Address(10).Bytes()))
+}
+
+func TestProtocol(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm := testdb.NewMockStateManager(ctrl)
+	// the failed actions do not write the states before failing
+	sm.EXPECT().Revert(gomock.Any()).Return(nil).AnyTimes()
+
+	var (
+		token  = identityset.Address(10)
+		caller = identityset.Address(1)
+		g      = genesis.TestDefault()
+	)
+	p, err := NewProtocol(genesis.FeeToken{
+		FeeTokens:      []string{token.String()},
+		FeeTokenOracle: identityset.Address(11).String(),
+	}, nil)
+	require.NoError(err)
+	g.ToBeEnabledBlockHeight = 1
+	nonce := uint64(0)
+	newCtx := func(height uint64, data []byte) context.Context {
+		ctx := genesis.WithGenesisContext(context.Background(), g)
+		ctx = protocol.WithBlockCtx(ctx, protocol.BlockCtx{BlockHeight: height})
+		ctx = protocol.WithActionCtx(ctx, protocol.ActionCtx{
+			Caller:       caller,
+			ActionHash:   hash.Hash256b(data),
+			Nonce:        nonce,
+			IntrinsicGas: 10000,
+		})
+		return protocol.WithFeatureCtx(ctx)
+	}
+	call := func(height uint64, data []byte) *action.Receipt {
+		elp := (&action.EnvelopeBuilder{}).SetNonce(nonce).SetGasPrice(big.NewInt(0)).SetGasLimit(100000).
+			SetAction(action.NewExecution(ProtocolAddr().String(), big.NewInt(0), data)).Build()
+		ctx := newCtx(height, data)
+		require.NoError(p.Validate(ctx, elp, sm))
+		r, err := p.Handle(ctx, elp, sm)
+		require.NoError(err)
+		if r != nil {
+			nonce++
+		}
+		return r
+	}
+	pack := func(token common.Address) []byte {
+		data, err := _methods.Pack("setFeeToken", token)
+		require.NoError(err)
+		return data
+	}
+	tokenAddr := common.BytesToAddress(token.Bytes())
+
+	t.Run("setFeeToken", func(t *testing.T) {
+		_, err := p.FeeToken(sm, caller)
+		require.ErrorIs(err, state.ErrStateNotExist)
+		// only the whitelisted assets could pay the gas
+		r := call(5, pack(common.HexToAddress("0x01")))
+		require.EqualValues(iotextypes.ReceiptStatus_Failure, r.Status)
+		r = call(5, pack(tokenAddr))
+		require.EqualValues(iotextypes.ReceiptStatus_Success, r.Status)
+		require.Len(r.Logs(), 1)
+		require.Equal(hash.Hash256(_feeTokenSetEvt.ID), r.Logs()[0].Topics[0])
+		feeToken, err := p.FeeToken(sm, caller)
+		require.NoError(err)
+		require.Equal(tokenAddr, feeToken)
+		data, _, err := p.ReadState(context.Background(), sm, []byte("FeeToken"), []byte(caller.String()))
+		require.NoError(err)
+		require.Equal(tokenAddr.Bytes(), data)
+	})
+
+	t.Run("insufficientReserve", func(t *testing.T) {
+		acc, err := accountutil.LoadOrCreateAccount(sm, caller)
+		require.NoError(err)
+		elp := (&action.EnvelopeBuilder{}).SetNonce(nonce).SetGasPrice(big.NewInt(1)).SetGasLimit(100000).
+			SetAction(action.NewTransfer(big.NewInt(1), identityset.Address(2).String(), nil)).Build()
+		ctx := newCtx(6, nil)
+		// the caller pays the gas in IOTX as the reserve is empty
+		require.NoError(p.PreHandle(ctx, elp, sm))
+		acc2, err := accountutil.LoadOrCreateAccount(sm, caller)
+		require.NoError(err)
+		require.Equal(acc.Balance, acc2.Balance)
+		require.NoError(p.HandleReceipt(ctx, elp, sm, &action.Receipt{GasConsumed: 10000}))
+		require.NoError(p.HandleBlockEnd(ctx, sm))
+	})
+
+	t.Run("handleBlockEnd", func(t *testing.T) {
+		reserve, err := accountutil.LoadOrCreateAccount(sm, p.addr)
+		require.NoError(err)
+		require.NoError(reserve.AddBalance(big.NewInt(100)))
+		require.NoError(accountutil.StoreAccount(sm, p.addr, reserve))
+		require.NoError(p.putState(sm, _debtKey, &debt{Amount: big.NewInt(30)}))
+		require.NoError(p.HandleBlockEnd(newCtx(7, nil), sm))
+		reserve, err = accountutil.LoadOrCreateAccount(sm, p.addr)
+		require.NoError(err)
+		require.Equal(big.NewInt(70), reserve.Balance)
+		d, err := p.debt(sm)
+		require.NoError(err)
+		require.Zero(d.Amount.Sign())
+	})
+
+	t.Run("sponsored", func(t *testing.T) {
+		patches := gomonkey.NewPatches()
+		defer patches.Reset()
+		rateErr := error(nil)
+		patches.ApplyPrivateMethod(p, "rateOf", func(_ *Protocol, _ context.Context, _ protocol.StateManager, _ common.Address) (*big.Int, error) {
+			return new(big.Int).Set(_rateBase), rateErr
+		})
+		patches.ApplyPrivateMethod(p, "callToken", func(_ *Protocol, _ context.Context, _ protocol.StateManager, _ common.Address, _ string, _ ...interface{}) error {
+			return nil
+		})
+		balanceOf := func(addr address.Address) *big.Int {
+			acc, err := accountutil.LoadOrCreateAccount(sm, addr)
+			require.NoError(err)
+			return acc.Balance
+		}
+		setBalance := func(addr address.Address, amount *big.Int) {
+			acc, err := accountutil.LoadOrCreateAccount(sm, addr)
+			require.NoError(err)
+			acc.Balance = new(big.Int).Set(amount)
+			require.NoError(accountutil.StoreAccount(sm, addr, acc))
+		}
+		newExec := func(value int64) *action.SealedEnvelope {
+			elp := (&action.EnvelopeBuilder{}).SetNonce(nonce).SetGasPrice(big.NewInt(1)).SetGasLimit(100000).
+				SetAction(action.NewExecution(identityset.Address(2).String(), big.NewInt(value), nil)).Build()
+			selp, err := action.Sign(elp, identityset.PrivateKey(1))
+			require.NoError(err)
+			return selp
+		}
+		setBalance(p.addr, big.NewInt(1000000))
+		r := call(9, pack(tokenAddr))
+		require.EqualValues(iotextypes.ReceiptStatus_Success, r.Status)
+		reg := protocol.NewRegistry()
+		require.NoError(p.Register(reg))
+		ctx := protocol.WithRegistry(newCtx(9, nil), reg)
+
+		// the caller holding no IOTX is only charged the value in the actpool and the validation
+		setBalance(caller, big.NewInt(0))
+		selp := newExec(5)
+		fee, err := p.SponsoredGasFee(ctx, sm, selp)
+		require.NoError(err)
+		require.Equal(big.NewInt(100000), fee)
+		require.Len(protocol.GasSponsors(ctx), 1)
+		cost, err := protocol.CallerCost(ctx, sm, selp, protocol.GasSponsors(ctx))
+		require.NoError(err)
+		require.Equal(big.NewInt(5), cost)
+
+		// the advance cannot be spent as the value
+		require.NoError(p.PreHandle(ctx, selp.Envelope, sm))
+		require.Zero(balanceOf(caller).Sign())
+		d, err := p.debt(sm)
+		require.NoError(err)
+		require.Zero(d.Amount.Sign())
+
+		// the reserve is charged the gas used only
+		setBalance(caller, big.NewInt(5))
+		require.NoError(p.PreHandle(ctx, selp.Envelope, sm))
+		require.Equal(big.NewInt(100005), balanceOf(caller))
+		// the value and the gas used are spent
+		setBalance(caller, big.NewInt(100005-5-30000))
+		r = &action.Receipt{GasConsumed: 30000, EffectiveGasPrice: big.NewInt(1)}
+		require.NoError(p.HandleReceipt(ctx, selp.Envelope, sm, r))
+		require.Zero(balanceOf(caller).Sign())
+		require.Len(r.Logs(), 1)
+		require.NoError(p.HandleBlockEnd(ctx, sm))
+		require.Equal(big.NewInt(1000000-30000), balanceOf(p.addr))
+
+		// the unused advance must be left to be taken back
+		setBalance(caller, big.NewInt(5))
+		require.NoError(p.PreHandle(ctx, selp.Envelope, sm))
+		setBalance(caller, big.NewInt(100))
+		require.ErrorContains(p.HandleReceipt(ctx, selp.Envelope, sm, r), "failed to take back the unused advance")
+		require.NoError(p.putState(sm, _debtKey, &debt{Amount: big.NewInt(0)}))
+
+		// the action is skipped if the caller cannot pay the gas in either way
+		rateErr = errors.New("oracle is down")
+		setBalance(caller, big.NewInt(5))
+		require.ErrorIs(p.PreHandle(ctx, selp.Envelope, sm), protocol.ErrGasNotSponsored)
+		setBalance(caller, big.NewInt(100005))
+		require.NoError(p.PreHandle(ctx, selp.Envelope, sm))
+		require.Equal(big.NewInt(100005), balanceOf(caller))
+
+		// the reserve covering no more than the debt sponsors nothing
+		require.NoError(p.putState(sm, _debtKey, &debt{Amount: big.NewInt(1000000 - 30000)}))
+		fee, err = p.SponsoredGasFee(ctx, sm, selp)
+		require.NoError(err)
+		require.Zero(fee.Sign())
+		require.NoError(p.putState(sm, _debtKey, &debt{Amount: big.NewInt(0)}))
+	})
+
+	t.Run("optOut", func(t *testing.T) {
+		r := call(8, pack(common.Address{}))
+		require.EqualValues(iotextypes.ReceiptStatus_Success, r.Status)
+		_, err := p.FeeToken(sm, caller)
+		require.ErrorIs(err, state.ErrStateNotExist)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		g.ToBeEnabledBlockHeight = 100
+		defer func() { g.ToBeEnabledBlockHeight = 1 }()
+		require.Nil(call(20, pack(tokenAddr)))
+		reg := protocol.NewRegistry()
+		require.NoError(p.Register(reg))
+		require.Empty(protocol.GasSponsors(protocol.WithRegistry(newCtx(20, nil), reg)))
+	})
+}
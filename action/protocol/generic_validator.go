@@ -15,17 +15,23 @@ import (
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 
 	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/v2/state"
 )
 
 type (
 	// AccountState defines a function to return the account state of a given address
 	AccountState func(context.Context, StateReader, address.Address) (*state.Account, error)
+	// SignatureVerifier defines a function to verify the signature of an action
+	SignatureVerifier func(context.Context, *action.SealedEnvelope) error
 	// GenericValidator is the validator for generic action verification
 	GenericValidator struct {
-		accountState AccountState
-		sr           StateReader
+		accountState    AccountState
+		sr              StateReader
+		verifySignature SignatureVerifier
 	}
+	// GenericValidatorOption sets the option of the generic validator
+	GenericValidatorOption func(*GenericValidator)
 )
 
 var (
@@ -34,10 +40,24 @@ var (
 )
 
 // NewGenericValidator constructs a new genericValidator
-func NewGenericValidator(sr StateReader, accountState AccountState) *GenericValidator {
-	return &GenericValidator{
+func NewGenericValidator(sr StateReader, accountState AccountState, opts ...GenericValidatorOption) *GenericValidator {
+	v := &GenericValidator{
 		sr:           sr,
 		accountState: accountState,
+		verifySignature: func(_ context.Context, selp *action.SealedEnvelope) error {
+			return selp.VerifySignature()
+		},
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
+}
+
+// WithSignatureVerifier replaces the signature verification, e.g., with the one backed by a cache of the verdicts
+func WithSignatureVerifier(f SignatureVerifier) GenericValidatorOption {
+	return func(v *GenericValidator) {
+		v.verifySignature = f
 	}
 }
 
@@ -51,7 +71,7 @@ func (v *GenericValidator) Validate(ctx context.Context, selp *action.SealedEnve
 		return action.ErrIntrinsicGas
 	}
 	// Verify action using action sender's public key
-	if err := selp.VerifySignature(); err != nil {
+	if err := v.verifySignature(ctx, selp); err != nil {
 		return err
 	}
 	caller := selp.SenderAddress()
@@ -135,7 +155,7 @@ func (v *GenericValidator) ValidateWithState(ctx context.Context, selp *action.S
 		if err != nil {
 			return errors.Wrapf(err, "invalid state of account %s", caller.String())
 		}
-		cost, err := selp.Cost()
+		cost, err := CallerCost(ctx, v.sr, selp, GasSponsors(ctx))
 		if err != nil {
 			return errors.Wrap(err, "failed to get cost of action")
 		}
@@ -153,7 +173,7 @@ func (v *GenericValidator) ValidateWithState(ctx context.Context, selp *action.S
 	}
 	if featureCtx.EnableBlobTransaction && len(selp.BlobHashes()) > 0 {
 		// blobFeeCap must be not less than the blob price
-		blobfee := CalcBlobFee(blkCtx.ExcessBlobGas)
+		blobfee := CalcBlobFee(genesis.MustExtractGenesisContext(ctx).Blockchain, blkCtx.BlockHeight, blkCtx.ExcessBlobGas)
 		if selp.BlobGasFeeCap().Cmp(blobfee) < 0 {
 			return errors.Wrapf(action.ErrUnderpriced, "blob fee cap is too low: %s, base fee: %s", selp.BlobGasFeeCap().String(), blobfee.String())
 		}
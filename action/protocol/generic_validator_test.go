@@ -161,4 +161,14 @@ func TestActionProtoAndGenericValidator(t *testing.T) {
 		err = valid.Validate(ctx, selp)
 		require.Contains(err.Error(), action.ErrInvalidSender.Error())
 	})
+	t.Run("signature verifier", func(t *testing.T) {
+		errVerifier := errors.New("verifier error")
+		v := NewGenericValidator(nil, valid.accountState, WithSignatureVerifier(func(context.Context, *action.SealedEnvelope) error {
+			return errVerifier
+		}))
+		selp, err := action.SignedTransfer(caller.String(), identityset.PrivateKey(28), 3, big.NewInt(1), nil, 100000, big.NewInt(10))
+		require.NoError(err)
+		require.NoError(valid.Validate(ctx, selp))
+		require.ErrorIs(v.Validate(ctx, selp), errVerifier)
+	})
 }
@@ -0,0 +1,135 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package governance
+
+import (
+	"encoding/binary"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/state"
+)
+
+const _probationParamsLength = 8 + 8 + 8 + 4
+
+type (
+	// ProbationParams are the parameters of putting the unproductive delegates on probation, which take effect on the
+	// probation lists of the epochs starting at or after the activation height
+	ProbationParams struct {
+		ActivationHeight uint64
+		// ProductivityThreshold is the percentage of the expected blocks a delegate needs to produce in an epoch not
+		// to be counted as unproductive
+		ProductivityThreshold uint64
+		// ProbationEpochPeriod is the number of recent epochs counted for the probation of a delegate
+		ProbationEpochPeriod uint64
+		// IntensityRate is the percentage of the votes cut off from a delegate on probation
+		IntensityRate uint32
+	}
+
+	// ProbationSchedule is the list of the probation parameters in the ascending order of activation heights
+	ProbationSchedule []*ProbationParams
+)
+
+// Serialize serializes the probation parameters into bytes
+func (p *ProbationParams) Serialize() ([]byte, error) {
+	buf := make([]byte, 0, _probationParamsLength)
+	buf = binary.BigEndian.AppendUint64(buf, p.ActivationHeight)
+	buf = binary.BigEndian.AppendUint64(buf, p.ProductivityThreshold)
+	buf = binary.BigEndian.AppendUint64(buf, p.ProbationEpochPeriod)
+	return binary.BigEndian.AppendUint32(buf, p.IntensityRate), nil
+}
+
+// Deserialize deserializes bytes into the probation parameters
+func (p *ProbationParams) Deserialize(buf []byte) error {
+	if len(buf) != _probationParamsLength {
+		return errors.Errorf("invalid probation params length %d", len(buf))
+	}
+	p.ActivationHeight = binary.BigEndian.Uint64(buf[:8])
+	p.ProductivityThreshold = binary.BigEndian.Uint64(buf[8:16])
+	p.ProbationEpochPeriod = binary.BigEndian.Uint64(buf[16:24])
+	p.IntensityRate = binary.BigEndian.Uint32(buf[24:])
+	return nil
+}
+
+// Serialize serializes the schedule into bytes
+func (s ProbationSchedule) Serialize() ([]byte, error) {
+	buf := make([]byte, 0, len(s)*_probationParamsLength)
+	for _, p := range s {
+		b, err := p.Serialize()
+		if err != nil {
+			return nil, err
+		}
+		buf = append(buf, b...)
+	}
+	return buf, nil
+}
+
+// Deserialize deserializes bytes into the schedule
+func (s *ProbationSchedule) Deserialize(buf []byte) error {
+	if len(buf)%_probationParamsLength != 0 {
+		return errors.Errorf("invalid probation schedule length %d", len(buf))
+	}
+	schedule := make(ProbationSchedule, 0, len(buf)/_probationParamsLength)
+	for i := 0; i < len(buf); i += _probationParamsLength {
+		p := &ProbationParams{}
+		if err := p.Deserialize(buf[i : i+_probationParamsLength]); err != nil {
+			return err
+		}
+		schedule = append(schedule, p)
+	}
+	*s = schedule
+	return nil
+}
+
+// At returns the parameters effective at the height, or nil if none is activated yet
+func (s ProbationSchedule) At(height uint64) *ProbationParams {
+	i := sort.Search(len(s), func(i int) bool {
+		return s[i].ActivationHeight > height
+	})
+	if i == 0 {
+		return nil
+	}
+	return s[i-1]
+}
+
+// schedule adds the parameters to the schedule, replacing the parameters of the same activation height
+func (s ProbationSchedule) schedule(p *ProbationParams) ProbationSchedule {
+	i := sort.Search(len(s), func(i int) bool {
+		return s[i].ActivationHeight >= p.ActivationHeight
+	})
+	if i < len(s) && s[i].ActivationHeight == p.ActivationHeight {
+		s[i] = p
+		return s
+	}
+	s = append(s, nil)
+	copy(s[i+1:], s[i:])
+	s[i] = p
+	return s
+}
+
+// ReadProbationSchedule reads the probation parameters scheduled by the governors
+func ReadProbationSchedule(sr protocol.StateReader) (ProbationSchedule, uint64, error) {
+	var s ProbationSchedule
+	height, err := sr.State(&s, protocol.KeyOption(_probationScheduleKey), protocol.NamespaceOption(_governanceNamespace))
+	switch errors.Cause(err) {
+	case nil, state.ErrStateNotExist:
+		return s, height, nil
+	default:
+		return nil, 0, err
+	}
+}
+
+// ProbationParamsAt returns the probation parameters scheduled by the governors effective at the height, or nil if
+// none is activated yet, in which case the genesis ones are effective
+func ProbationParamsAt(sr protocol.StateReader, height uint64) (*ProbationParams, error) {
+	s, _, err := ReadProbationSchedule(sr)
+	if err != nil {
+		return nil, err
+	}
+	return s.At(height), nil
+}
@@ -0,0 +1,355 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package governance
+
+import (
+	"context"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/abiregistry"
+	accountutil "github.com/iotexproject/iotex-core/v2/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+	"github.com/iotexproject/iotex-core/v2/state"
+)
+
+const (
+	_protocolID          = "governance"
+	_governanceNamespace = "Governance"
+
+	_methodsABI = `[
+		{
+			"inputs": [
+				{"internalType": "uint64", "name": "activationHeight", "type": "uint64"},
+				{"internalType": "uint64", "name": "productivityThreshold", "type": "uint64"},
+				{"internalType": "uint64", "name": "probationEpochPeriod", "type": "uint64"},
+				{"internalType": "uint32", "name": "intensityRate", "type": "uint32"}
+			],
+			"name": "setProbationParams",
+			"outputs": [],
+			"stateMutability": "nonpayable",
+			"type": "function"
+		}
+	]`
+
+	_eventsABI = `[
+		{
+			"anonymous": false,
+			"inputs": [
+				{"indexed": true, "internalType": "uint64", "name": "activationHeight", "type": "uint64"},
+				{"indexed": false, "internalType": "uint64", "name": "productivityThreshold", "type": "uint64"},
+				{"indexed": false, "internalType": "uint64", "name": "probationEpochPeriod", "type": "uint64"},
+				{"indexed": false, "internalType": "uint32", "name": "intensityRate", "type": "uint32"}
+			],
+			"name": "ProbationParamsScheduled",
+			"type": "event"
+		}
+	]`
+)
+
+var (
+	_probationScheduleKey = []byte("probation")
+
+	_methods                     abi.ABI
+	_probationParamsScheduledEvt abi.Event
+
+	// ErrNotGovernor indicates the caller is not a governor
+	ErrNotGovernor = errors.New("caller is not a governor")
+	// ErrInvalidParams indicates the parameters are out of range
+	ErrInvalidParams = errors.New("invalid governance parameters")
+)
+
+func init() {
+	var err error
+	_methods, err = abi.JSON(strings.NewReader(_methodsABI))
+	if err != nil {
+		panic(err)
+	}
+	events, err := abi.JSON(strings.NewReader(_eventsABI))
+	if err != nil {
+		panic(err)
+	}
+	_probationParamsScheduledEvt = events.Events["ProbationParamsScheduled"]
+}
+
+// Protocol defines the governance parameter store. The governors configured in genesis schedule the parameters by
+// calling the protocol address, e.g., setProbationParams schedules the probation parameters taking effect from the
+// activation height, which is emitted in the ProbationParamsScheduled event log. The parameters scheduled are kept
+// in state along with the ones activated, so that the parameters effective at any height could be looked up
+type Protocol struct {
+	addr       address.Address
+	governors  map[string]struct{}
+	depositGas protocol.DepositGas
+}
+
+// NewProtocol instantiates the governance protocol
+func NewProtocol(cfg genesis.Governance, depositGas protocol.DepositGas) (*Protocol, error) {
+	governors := make(map[string]struct{}, len(cfg.Governors))
+	for _, g := range cfg.Governors {
+		addr, err := address.FromString(g)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid governor %s", g)
+		}
+		governors[addr.String()] = struct{}{}
+	}
+	return &Protocol{
+		addr:       ProtocolAddr(),
+		governors:  governors,
+		depositGas: depositGas,
+	}, nil
+}
+
+// ContractABI returns the ABI of the methods and the events of the governance as a system contract
+func ContractABI() string {
+	return abiregistry.MustJoinABI(_methodsABI, _eventsABI)
+}
+
+// ProtocolAddr returns the address generated from protocol id
+func ProtocolAddr() address.Address {
+	return protocol.HashStringToAddress(_protocolID)
+}
+
+// FindProtocol finds the registered protocol from registry
+func FindProtocol(registry *protocol.Registry) *Protocol {
+	if registry == nil {
+		return nil
+	}
+	p, ok := registry.Find(_protocolID)
+	if !ok {
+		return nil
+	}
+	gp, ok := p.(*Protocol)
+	if !ok {
+		log.S().Panic("fail to cast governance protocol")
+	}
+	return gp
+}
+
+// PackSetProbationParams packs the call data of scheduling the probation parameters
+func PackSetProbationParams(p *ProbationParams) ([]byte, error) {
+	method := _methods.Methods["setProbationParams"]
+	args, err := method.Inputs.Pack(p.ActivationHeight, p.ProductivityThreshold, p.ProbationEpochPeriod, p.IntensityRate)
+	if err != nil {
+		return nil, err
+	}
+	return append(method.ID, args...), nil
+}
+
+// Validate validates a governance action
+func (p *Protocol) Validate(ctx context.Context, elp action.Envelope, _ protocol.StateReader) error {
+	exec, ok := p.governanceExecution(ctx, elp)
+	if !ok {
+		return nil
+	}
+	if exec.Amount().Sign() != 0 {
+		return errors.Wrap(action.ErrInvalidAct, "governance action cannot transfer value")
+	}
+	if _, _, err := unpackMethod(exec.Data()); err != nil {
+		return errors.Wrap(action.ErrInvalidAct, err.Error())
+	}
+	return nil
+}
+
+// Handle handles the actions on the governance protocol
+func (p *Protocol) Handle(ctx context.Context, elp action.Envelope, sm protocol.StateManager) (*action.Receipt, error) {
+	exec, ok := p.governanceExecution(ctx, elp)
+	if !ok {
+		return nil, nil
+	}
+	si := sm.Snapshot()
+	logs, err := p.handle(ctx, sm, exec.Data())
+	if err != nil {
+		log.L().Debug("Error when handling governance action", zap.Error(err))
+		return p.settleAction(ctx, sm, elp, uint64(iotextypes.ReceiptStatus_Failure), si, nil)
+	}
+	return p.settleAction(ctx, sm, elp, uint64(iotextypes.ReceiptStatus_Success), si, logs)
+}
+
+func (p *Protocol) governanceExecution(ctx context.Context, elp action.Envelope) (*action.Execution, bool) {
+	exec, ok := elp.Action().(*action.Execution)
+	if !ok || exec.Contract() != p.addr.String() {
+		return nil, false
+	}
+	return exec, protocol.MustGetFeatureCtx(ctx).EnableGovernanceParams
+}
+
+func unpackMethod(data []byte) (*abi.Method, []interface{}, error) {
+	if len(data) < 4 {
+		return nil, nil, errors.New("invalid governance call data")
+	}
+	method, err := _methods.MethodById(data[:4])
+	if err != nil {
+		return nil, nil, err
+	}
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to unpack arguments of %s", method.Name)
+	}
+	return method, args, nil
+}
+
+func (p *Protocol) handle(ctx context.Context, sm protocol.StateManager, data []byte) ([]*action.Log, error) {
+	caller := protocol.MustGetActionCtx(ctx).Caller
+	if _, ok := p.governors[caller.String()]; !ok {
+		return nil, errors.Wrap(ErrNotGovernor, caller.String())
+	}
+	method, args, err := unpackMethod(data)
+	if err != nil {
+		return nil, err
+	}
+	var lg *action.Log
+	switch method.Name {
+	case "setProbationParams":
+		lg, err = p.setProbationParams(ctx, sm, &ProbationParams{
+			ActivationHeight:      args[0].(uint64),
+			ProductivityThreshold: args[1].(uint64),
+			ProbationEpochPeriod:  args[2].(uint64),
+			IntensityRate:         args[3].(uint32),
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	var (
+		blkCtx    = protocol.MustGetBlockCtx(ctx)
+		actionCtx = protocol.MustGetActionCtx(ctx)
+	)
+	lg.BlockHeight = blkCtx.BlockHeight
+	lg.ActionHash = actionCtx.ActionHash
+	return []*action.Log{lg}, nil
+}
+
+// setProbationParams schedules the probation parameters at a future height. The probation period is bounded by the
+// number of epochs of the unproductive delegates kept in state
+func (p *Protocol) setProbationParams(ctx context.Context, sm protocol.StateManager, params *ProbationParams) (*action.Log, error) {
+	var (
+		blkCtx = protocol.MustGetBlockCtx(ctx)
+		g      = genesis.MustExtractGenesisContext(ctx)
+	)
+	if params.ActivationHeight <= blkCtx.BlockHeight {
+		return nil, errors.Wrapf(ErrInvalidParams, "activation height %d is not after height %d", params.ActivationHeight, blkCtx.BlockHeight)
+	}
+	if params.ProductivityThreshold > 100 || params.IntensityRate > 100 {
+		return nil, errors.Wrapf(ErrInvalidParams, "productivity threshold %d and intensity rate %d should be in [0, 100]", params.ProductivityThreshold, params.IntensityRate)
+	}
+	if params.ProbationEpochPeriod == 0 || params.ProbationEpochPeriod > g.UnproductiveDelegateMaxCacheSize {
+		return nil, errors.Wrapf(ErrInvalidParams, "probation epoch period %d should be in [1, %d]", params.ProbationEpochPeriod, g.UnproductiveDelegateMaxCacheSize)
+	}
+	s, _, err := ReadProbationSchedule(sm)
+	if err != nil {
+		return nil, err
+	}
+	s = s.schedule(params)
+	if _, err := sm.PutState(s, protocol.KeyOption(_probationScheduleKey), protocol.NamespaceOption(_governanceNamespace)); err != nil {
+		return nil, err
+	}
+	data, err := _probationParamsScheduledEvt.Inputs.NonIndexed().Pack(params.ProductivityThreshold, params.ProbationEpochPeriod, params.IntensityRate)
+	if err != nil {
+		return nil, err
+	}
+	return &action.Log{
+		Address: p.addr.String(),
+		Topics: []hash.Hash256{
+			hash.Hash256(_probationParamsScheduledEvt.ID),
+			hash.Hash256(common.BigToHash(new(big.Int).SetUint64(params.ActivationHeight))),
+		},
+		Data: data,
+	}, nil
+}
+
+// ReadState read the state on blockchain via protocol
+func (p *Protocol) ReadState(ctx context.Context, sr protocol.StateReader, method []byte, args ...[]byte) ([]byte, uint64, error) {
+	switch string(method) {
+	case "ProbationSchedule":
+		s, height, err := ReadProbationSchedule(sr)
+		if err != nil {
+			return nil, 0, err
+		}
+		data, err := s.Serialize()
+		return data, height, err
+	default:
+		return nil, 0, errors.New("corresponding method isn't found")
+	}
+}
+
+// Register registers the protocol with a unique ID
+func (p *Protocol) Register(r *protocol.Registry) error {
+	return r.Register(_protocolID, p)
+}
+
+// ForceRegister registers the protocol with a unique ID and force replacing the previous protocol if it exists
+func (p *Protocol) ForceRegister(r *protocol.Registry) error {
+	return r.ForceRegister(_protocolID, p)
+}
+
+// Name returns the name of protocol
+func (p *Protocol) Name() string {
+	return _protocolID
+}
+
+func (p *Protocol) settleAction(
+	ctx context.Context,
+	sm protocol.StateManager,
+	elp action.Envelope,
+	status uint64,
+	si int,
+	logs []*action.Log,
+) (*action.Receipt, error) {
+	var (
+		actionCtx = protocol.MustGetActionCtx(ctx)
+		blkCtx    = protocol.MustGetBlockCtx(ctx)
+		fCtx      = protocol.MustGetFeatureCtx(ctx)
+		tLogs     []*action.TransactionLog
+	)
+	if status == uint64(iotextypes.ReceiptStatus_Failure) {
+		if err := sm.Revert(si); err != nil {
+			return nil, err
+		}
+	}
+	priorityFee, baseFee, err := protocol.SplitGas(ctx, elp, actionCtx.IntrinsicGas)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to split gas")
+	}
+	if p.depositGas != nil {
+		tLogs, err = p.depositGas(ctx, sm, baseFee, protocol.PriorityFeeOption(priorityFee))
+		if err != nil {
+			return nil, err
+		}
+	}
+	accountCreationOpts := []state.AccountCreationOption{}
+	if fCtx.CreateLegacyNonceAccount {
+		accountCreationOpts = append(accountCreationOpts, state.LegacyNonceAccountTypeOption())
+	}
+	acc, err := accountutil.LoadOrCreateAccount(sm, actionCtx.Caller, accountCreationOpts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := acc.SetPendingNonce(actionCtx.Nonce + 1); err != nil {
+		return nil, errors.Wrapf(err, "invalid nonce %d", actionCtx.Nonce)
+	}
+	if err := accountutil.StoreAccount(sm, actionCtx.Caller, acc); err != nil {
+		return nil, err
+	}
+	return (&action.Receipt{
+		Status:            status,
+		BlockHeight:       blkCtx.BlockHeight,
+		ActionHash:        actionCtx.ActionHash,
+		GasConsumed:       actionCtx.IntrinsicGas,
+		ContractAddress:   p.addr.String(),
+		EffectiveGasPrice: protocol.EffectiveGasPrice(ctx, elp),
+	}).AddLogs(logs...).AddTransactionLogs(tLogs...), nil
+}
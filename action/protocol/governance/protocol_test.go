@@ -0,0 +1,147 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package governance
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+	"github.com/iotexproject/iotex-core/v2/testutil/testdb"
+)
+
+func TestProbationSchedule(t *testing.T) {
+	require := require.New(t)
+
+	var s ProbationSchedule
+	require.Nil(s.At(100))
+	for _, h := range []uint64{30, 10, 20} {
+		s = s.schedule(&ProbationParams{ActivationHeight: h, ProductivityThreshold: h})
+	}
+	s = s.schedule(&ProbationParams{ActivationHeight: 20, ProductivityThreshold: 21, ProbationEpochPeriod: 2, IntensityRate: 90})
+	require.Len(s, 3)
+	require.Nil(s.At(9))
+	require.EqualValues(10, s.At(10).ActivationHeight)
+	require.EqualValues(21, s.At(29).ProductivityThreshold)
+	require.EqualValues(30, s.At(100).ActivationHeight)
+
+	buf, err := s.Serialize()
+	require.NoError(err)
+	var s2 ProbationSchedule
+	require.NoError(s2.Deserialize(buf))
+	require.Equal(s, s2)
+	require.Error(s2.Deserialize(buf[1:]))
+}
+
+func TestProtocol(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm := testdb.NewMockStateManager(ctrl)
+	// the failed actions do not write the states before failing
+	sm.EXPECT().Revert(gomock.Any()).Return(nil).AnyTimes()
+
+	g := genesis.TestDefault()
+	g.ToBeEnabledBlockHeight = 1
+	_, err := NewProtocol(genesis.Governance{Governors: []string{"invalid"}}, nil)
+	require.Error(err)
+	p, err := NewProtocol(genesis.Governance{Governors: []string{identityset.Address(1).String()}}, nil)
+	require.NoError(err)
+
+	nonces := map[int]uint64{}
+	call := func(height uint64, caller int, params *ProbationParams) *action.Receipt {
+		data, err := PackSetProbationParams(params)
+		require.NoError(err)
+		nonce := nonces[caller]
+		elp := (&action.EnvelopeBuilder{}).SetNonce(nonce).SetGasPrice(big.NewInt(0)).SetGasLimit(100000).
+			SetAction(action.NewExecution(ProtocolAddr().String(), big.NewInt(0), data)).Build()
+		intrinsicGas, err := elp.IntrinsicGas()
+		require.NoError(err)
+		ctx := genesis.WithGenesisContext(context.Background(), g)
+		ctx = protocol.WithBlockCtx(ctx, protocol.BlockCtx{BlockHeight: height})
+		ctx = protocol.WithActionCtx(ctx, protocol.ActionCtx{
+			Caller:       identityset.Address(caller),
+			ActionHash:   hash.Hash256b(data),
+			Nonce:        nonce,
+			IntrinsicGas: intrinsicGas,
+		})
+		ctx = protocol.WithFeatureCtx(ctx)
+		require.NoError(p.Validate(ctx, elp, sm))
+		r, err := p.Handle(ctx, elp, sm)
+		require.NoError(err)
+		if r != nil {
+			nonces[caller]++
+		}
+		return r
+	}
+	schedule := func() ProbationSchedule {
+		data, _, err := p.ReadState(context.Background(), sm, []byte("ProbationSchedule"))
+		require.NoError(err)
+		var s ProbationSchedule
+		require.NoError(s.Deserialize(data))
+		return s
+	}
+
+	params := &ProbationParams{
+		ActivationHeight:      100,
+		ProductivityThreshold: 80,
+		ProbationEpochPeriod:  3,
+		IntensityRate:         50,
+	}
+	r := call(5, 1, params)
+	require.EqualValues(iotextypes.ReceiptStatus_Success, r.Status)
+	require.Len(r.Logs(), 1)
+	require.Equal(hash.Hash256(_probationParamsScheduledEvt.ID), r.Logs()[0].Topics[0])
+	require.Equal(ProbationSchedule{params}, schedule())
+	pp, err := ProbationParamsAt(sm, 99)
+	require.NoError(err)
+	require.Nil(pp)
+	pp, err = ProbationParamsAt(sm, 100)
+	require.NoError(err)
+	require.Equal(params, pp)
+
+	// only the governors could schedule the parameters
+	require.EqualValues(iotextypes.ReceiptStatus_Failure, call(6, 2, params).Status)
+	for _, invalid := range []*ProbationParams{
+		{ActivationHeight: 6, ProductivityThreshold: 80, ProbationEpochPeriod: 3, IntensityRate: 50},
+		{ActivationHeight: 200, ProductivityThreshold: 101, ProbationEpochPeriod: 3, IntensityRate: 50},
+		{ActivationHeight: 200, ProductivityThreshold: 80, ProbationEpochPeriod: 3, IntensityRate: 101},
+		{ActivationHeight: 200, ProductivityThreshold: 80, ProbationEpochPeriod: 0, IntensityRate: 50},
+		{ActivationHeight: 200, ProductivityThreshold: 80, ProbationEpochPeriod: g.UnproductiveDelegateMaxCacheSize + 1, IntensityRate: 50},
+	} {
+		require.EqualValues(iotextypes.ReceiptStatus_Failure, call(6, 1, invalid).Status)
+	}
+	require.Len(schedule(), 1)
+
+	// the parameters of the same activation height are replaced
+	replaced := &ProbationParams{
+		ActivationHeight:      100,
+		ProductivityThreshold: 70,
+		ProbationEpochPeriod:  4,
+		IntensityRate:         100,
+	}
+	require.EqualValues(iotextypes.ReceiptStatus_Success, call(7, 1, replaced).Status)
+	later := &ProbationParams{
+		ActivationHeight:      200,
+		ProductivityThreshold: 85,
+		ProbationEpochPeriod:  6,
+		IntensityRate:         90,
+	}
+	require.EqualValues(iotextypes.ReceiptStatus_Success, call(7, 1, later).Status)
+	require.Equal(ProbationSchedule{replaced, later}, schedule())
+
+	// disabled
+	g.ToBeEnabledBlockHeight = 100
+	require.Nil(call(8, 1, later))
+}
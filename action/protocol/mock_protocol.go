@@ -426,6 +426,44 @@ func (mr *MockActionHandlerMockRecorder) Handle(arg0, arg1, arg2 any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Handle", reflect.TypeOf((*MockActionHandler)(nil).Handle), arg0, arg1, arg2)
 }
 
+// MockPreActionHandler is a mock of PreActionHandler interface.
+type MockPreActionHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockPreActionHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockPreActionHandlerMockRecorder is the mock recorder for MockPreActionHandler.
+type MockPreActionHandlerMockRecorder struct {
+	mock *MockPreActionHandler
+}
+
+// NewMockPreActionHandler creates a new mock instance.
+func NewMockPreActionHandler(ctrl *gomock.Controller) *MockPreActionHandler {
+	mock := &MockPreActionHandler{ctrl: ctrl}
+	mock.recorder = &MockPreActionHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPreActionHandler) EXPECT() *MockPreActionHandlerMockRecorder {
+	return m.recorder
+}
+
+// PreHandle mocks base method.
+func (m *MockPreActionHandler) PreHandle(ctx context.Context, elp action.Envelope, sm StateManager) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreHandle", ctx, elp, sm)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PreHandle indicates an expected call of PreHandle.
+func (mr *MockPreActionHandlerMockRecorder) PreHandle(ctx, elp, sm any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreHandle", reflect.TypeOf((*MockPreActionHandler)(nil).PreHandle), ctx, elp, sm)
+}
+
 // MockPostActionHandler is a mock of PostActionHandler interface.
 type MockPostActionHandler struct {
 	ctrl     *gomock.Controller
@@ -464,6 +502,44 @@ func (mr *MockPostActionHandlerMockRecorder) HandleReceipt(ctx, elp, sm, receipt
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleReceipt", reflect.TypeOf((*MockPostActionHandler)(nil).HandleReceipt), ctx, elp, sm, receipt)
 }
 
+// MockBlockEndHandler is a mock of BlockEndHandler interface.
+type MockBlockEndHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlockEndHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockBlockEndHandlerMockRecorder is the mock recorder for MockBlockEndHandler.
+type MockBlockEndHandlerMockRecorder struct {
+	mock *MockBlockEndHandler
+}
+
+// NewMockBlockEndHandler creates a new mock instance.
+func NewMockBlockEndHandler(ctrl *gomock.Controller) *MockBlockEndHandler {
+	mock := &MockBlockEndHandler{ctrl: ctrl}
+	mock.recorder = &MockBlockEndHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlockEndHandler) EXPECT() *MockBlockEndHandlerMockRecorder {
+	return m.recorder
+}
+
+// HandleBlockEnd mocks base method.
+func (m *MockBlockEndHandler) HandleBlockEnd(arg0 context.Context, arg1 StateManager) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleBlockEnd", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleBlockEnd indicates an expected call of HandleBlockEnd.
+func (mr *MockBlockEndHandlerMockRecorder) HandleBlockEnd(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleBlockEnd", reflect.TypeOf((*MockBlockEndHandler)(nil).HandleBlockEnd), arg0, arg1)
+}
+
 // MockView is a mock of View interface.
 type MockView struct {
 	ctrl     *gomock.Controller
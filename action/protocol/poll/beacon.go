@@ -0,0 +1,136 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package poll
+
+import (
+	"context"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/vote/candidatesutil"
+	"github.com/iotexproject/iotex-core/v2/crypto"
+	"github.com/iotexproject/iotex-core/v2/state"
+)
+
+// _beaconKey is the key of the random beacon
+const _beaconKey = "RandomBeacon."
+
+// Beacon is the random beacon seeding the proposer ordering. The hash of every block, which covers the signature of
+// the producer, is mixed into the beacon in the next block. The mix at the start of an epoch is frozen as the seed of
+// the next epoch, so that the ordering of an epoch is unpredictable until the start of the previous epoch. The mix is
+// committed in the header of every block once the beacon is enabled, and validated as the blocks are executed
+type Beacon struct {
+	Mix     hash.Hash256
+	Current hash.Hash256
+	Next    hash.Hash256
+}
+
+// Serialize serializes the beacon into bytes
+func (b *Beacon) Serialize() ([]byte, error) {
+	buf := make([]byte, 0, 3*len(hash.ZeroHash256))
+	buf = append(buf, b.Mix[:]...)
+	buf = append(buf, b.Current[:]...)
+	return append(buf, b.Next[:]...), nil
+}
+
+// Deserialize deserializes bytes into the beacon
+func (b *Beacon) Deserialize(buf []byte) error {
+	size := len(hash.ZeroHash256)
+	if len(buf) != 3*size {
+		return errors.Errorf("invalid beacon length %d", len(buf))
+	}
+	b.Mix = hash.BytesToHash256(buf[:size])
+	b.Current = hash.BytesToHash256(buf[size : 2*size])
+	b.Next = hash.BytesToHash256(buf[2*size:])
+	return nil
+}
+
+// seed returns the seed of the proposer ordering, the hardcoded seed is used before the beacon is available
+func seed(h hash.Hash256) []byte {
+	if h == hash.ZeroHash256 {
+		return crypto.CryptoSeed
+	}
+	return h[:]
+}
+
+// updateBeacon mixes the hash of the previous block into the beacon, and freezes the seed of the next epoch at the
+// start of an epoch
+func updateBeacon(ctx context.Context, sm protocol.StateManager, indexer *CandidateIndexer) error {
+	var (
+		blkCtx = protocol.MustGetBlockCtx(ctx)
+		bcCtx  = protocol.MustGetBlockchainCtx(ctx)
+		rp     = rolldpos.MustGetProtocol(protocol.MustGetRegistry(ctx))
+		key    = candidatesutil.ConstructKey(_beaconKey)
+		b      = &Beacon{}
+	)
+	if _, err := sm.State(b, protocol.KeyOption(key[:]), protocol.NamespaceOption(protocol.SystemNamespace)); err != nil &&
+		errors.Cause(err) != state.ErrStateNotExist {
+		return errors.Wrap(err, "failed to read random beacon")
+	}
+	b.Mix = hash.Hash256b(append(b.Mix[:], bcCtx.Tip.Hash[:]...))
+	epochNum := rp.GetEpochNum(blkCtx.BlockHeight)
+	if blkCtx.BlockHeight == rp.GetEpochHeight(epochNum) {
+		b.Current, b.Next = b.Next, b.Mix
+		if indexer != nil {
+			if err := indexer.PutBeacon(rp.GetEpochHeight(epochNum+1), b.Next); err != nil {
+				return errors.Wrap(err, "failed to put random beacon into indexer")
+			}
+		}
+	}
+	_, err := sm.PutState(b, protocol.KeyOption(key[:]), protocol.NamespaceOption(protocol.SystemNamespace))
+	return err
+}
+
+// BeaconMix returns the mix of the random beacon in the state, or zero hash if the beacon is not available yet
+func BeaconMix(sr protocol.StateReader) (hash.Hash256, error) {
+	key := candidatesutil.ConstructKey(_beaconKey)
+	b := &Beacon{}
+	if _, err := sr.State(b, protocol.KeyOption(key[:]), protocol.NamespaceOption(protocol.SystemNamespace)); err != nil {
+		if errors.Cause(err) == state.ErrStateNotExist {
+			return hash.ZeroHash256, nil
+		}
+		return hash.ZeroHash256, errors.Wrap(err, "failed to read random beacon")
+	}
+	return b.Mix, nil
+}
+
+// beaconSeed returns the seed of the proposer ordering of the current or the next epoch
+func beaconSeed(sr protocol.StateReader, readFromNext bool) ([]byte, error) {
+	key := candidatesutil.ConstructKey(_beaconKey)
+	b := &Beacon{}
+	if _, err := sr.State(b, protocol.KeyOption(key[:]), protocol.NamespaceOption(protocol.SystemNamespace)); err != nil {
+		if errors.Cause(err) == state.ErrStateNotExist {
+			return crypto.CryptoSeed, nil
+		}
+		return nil, errors.Wrap(err, "failed to read random beacon")
+	}
+	if readFromNext {
+		return seed(b.Next), nil
+	}
+	return seed(b.Current), nil
+}
+
+// indexedBeaconSeed returns the seed of the proposer ordering of the epoch from the indexer. The seed of an epoch is
+// frozen at the start of the previous epoch, so the hardcoded seed is used if the beacon is not enabled then
+func indexedBeaconSeed(ctx context.Context, indexer *CandidateIndexer, epochStartHeight uint64) ([]byte, error) {
+	rp := rolldpos.MustGetProtocol(protocol.MustGetRegistry(ctx))
+	featureWithHeightCtx := protocol.MustGetFeatureWithHeightCtx(ctx)
+	epochNum := rp.GetEpochNum(epochStartHeight)
+	if epochNum <= 1 || !featureWithHeightCtx.EnableRandomBeacon(rp.GetEpochHeight(epochNum-1)) {
+		return crypto.CryptoSeed, nil
+	}
+	if indexer == nil {
+		return nil, ErrIndexerNotExist
+	}
+	h, err := indexer.Beacon(epochStartHeight)
+	if err != nil {
+		return nil, err
+	}
+	return seed(h), nil
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package poll
+
+import (
+	"context"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/crypto"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/testutil/testdb"
+)
+
+func TestBeacon(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("serialize", func(t *testing.T) {
+		b := &Beacon{
+			Mix:     hash.Hash256b([]byte{1}),
+			Current: hash.Hash256b([]byte{2}),
+			Next:    hash.Hash256b([]byte{3}),
+		}
+		buf, err := b.Serialize()
+		require.NoError(err)
+		b2 := &Beacon{}
+		require.NoError(b2.Deserialize(buf))
+		require.Equal(b, b2)
+		require.Error(b2.Deserialize(buf[1:]))
+	})
+
+	t.Run("update", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		sm := testdb.NewMockStateManager(ctrl)
+		indexer, err := NewCandidateIndexer(db.NewMemKVStore())
+		require.NoError(err)
+		require.NoError(indexer.Start(context.Background()))
+
+		// 2 blocks per epoch, the beacon is enabled since epoch 2
+		registry := protocol.NewRegistry()
+		rp := rolldpos.NewProtocol(2, 2, 1)
+		require.NoError(rp.Register(registry))
+		g := genesis.TestDefault()
+		g.ToBeEnabledBlockHeight = 3
+		ctxAt := func(height uint64) context.Context {
+			ctx := protocol.WithRegistry(context.Background(), registry)
+			ctx = genesis.WithGenesisContext(ctx, g)
+			ctx = protocol.WithBlockCtx(ctx, protocol.BlockCtx{BlockHeight: height})
+			ctx = protocol.WithBlockchainCtx(ctx, protocol.BlockchainCtx{
+				Tip: protocol.TipInfo{Height: height - 1, Hash: hash.Hash256b([]byte{byte(height - 1)})},
+			})
+			return protocol.WithFeatureWithHeightCtx(ctx)
+		}
+
+		// the hardcoded seed is used before the beacon is available
+		seed, err := beaconSeed(sm, false)
+		require.NoError(err)
+		require.Equal(crypto.CryptoSeed, seed)
+		for _, height := range []uint64{1, 3} {
+			seed, err = indexedBeaconSeed(ctxAt(height), indexer, height)
+			require.NoError(err)
+			require.Equal(crypto.CryptoSeed, seed)
+		}
+
+		mix, err := BeaconMix(sm)
+		require.NoError(err)
+		require.Equal(hash.ZeroHash256, mix)
+
+		var frozen []byte
+		for height := uint64(3); height <= 6; height++ {
+			ctx := ctxAt(height)
+			require.True(protocol.MustGetFeatureWithHeightCtx(ctx).EnableRandomBeacon(height))
+			require.NoError(updateBeacon(ctx, sm, indexer))
+			// the hash of the previous block is mixed into the beacon committed in the header
+			newMix, err := BeaconMix(sm)
+			require.NoError(err)
+			tip := protocol.MustGetBlockchainCtx(ctx).Tip.Hash
+			require.Equal(hash.Hash256b(append(mix[:], tip[:]...)), newMix)
+			mix = newMix
+			current, err := beaconSeed(sm, false)
+			require.NoError(err)
+			next, err := beaconSeed(sm, true)
+			require.NoError(err)
+			switch height {
+			case 3:
+				// the first epoch of the beacon still uses the hardcoded seed
+				require.Equal(crypto.CryptoSeed, current)
+				require.NotEqual(crypto.CryptoSeed, next)
+				frozen = next
+			case 4:
+				require.Equal(crypto.CryptoSeed, current)
+				require.Equal(frozen, next)
+			case 5:
+				require.Equal(frozen, current)
+				require.NotEqual(frozen, next)
+				indexed, err := indexedBeaconSeed(ctx, indexer, height)
+				require.NoError(err)
+				require.Equal(frozen, indexed)
+				frozen = next
+			case 6:
+				require.Equal(frozen, next)
+			}
+		}
+		indexed, err := indexedBeaconSeed(ctxAt(7), indexer, 7)
+		require.NoError(err)
+		require.Equal(frozen, indexed)
+		// the beacon of the epoch is required once it is enabled
+		_, err = indexedBeaconSeed(ctxAt(9), indexer, 9)
+		require.Equal(ErrIndexerNotExist, err)
+		_, err = indexedBeaconSeed(ctxAt(9), nil, 9)
+		require.Equal(ErrIndexerNotExist, err)
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"context"
 	"sync"
 
+	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
@@ -24,6 +25,8 @@ var (
 	CandidateNamespace = "candidates"
 	// ProbationNamespace is a namespace to store probationlist
 	ProbationNamespace = "kickout"
+	// BeaconNamespace is a namespace to store the seed of the proposer ordering
+	BeaconNamespace = "beacon"
 	// ErrIndexerNotExist is an error that shows not exist in candidate indexer DB
 	ErrIndexerNotExist = errors.New("not exist in DB")
 )
@@ -79,6 +82,14 @@ func (cd *CandidateIndexer) PutProbationList(height uint64, probationList *vote.
 	return cd.kvStore.Put(ProbationNamespace, byteutil.Uint64ToBytes(height), probationListByte)
 }
 
+// PutBeacon puts the seed of the proposer ordering of the epoch into indexer
+func (cd *CandidateIndexer) PutBeacon(height uint64, seed hash.Hash256) error {
+	cd.mutex.Lock()
+	defer cd.mutex.Unlock()
+	log.L().Debug("put random beacon into candidate indexer", zap.Uint64("height", height))
+	return cd.kvStore.Put(BeaconNamespace, byteutil.Uint64ToBytes(height), seed[:])
+}
+
 // CandidateList gets candidate list from indexer given epoch start height
 func (cd *CandidateIndexer) CandidateList(height uint64) (state.CandidateList, error) {
 	cd.mutex.RLock()
@@ -124,3 +135,17 @@ func (cd *CandidateIndexer) ProbationList(height uint64) (*vote.ProbationList, e
 	}
 	return bl, nil
 }
+
+// Beacon gets the seed of the proposer ordering from indexer given epoch start height
+func (cd *CandidateIndexer) Beacon(height uint64) (hash.Hash256, error) {
+	cd.mutex.RLock()
+	defer cd.mutex.RUnlock()
+	bytes, err := cd.kvStore.Get(BeaconNamespace, byteutil.Uint64ToBytes(height))
+	if err != nil {
+		if errors.Cause(err) == db.ErrNotExist {
+			return hash.ZeroHash256, ErrIndexerNotExist
+		}
+		return hash.ZeroHash256, err
+	}
+	return hash.BytesToHash256(bytes), nil
+}
@@ -186,6 +186,11 @@ func (p *governanceChainCommitteeProtocol) NextCandidates(ctx context.Context, s
 	return candidates, err
 }
 
+// PreviewNextEpoch returns the delegates of next epoch computed ahead of the transition
+func (p *governanceChainCommitteeProtocol) PreviewNextEpoch(ctx context.Context, sr protocol.StateReader) (*EpochPreview, error) {
+	return p.sh.PreviewNextEpoch(ctx, sr, p.CalculateCandidatesByHeight)
+}
+
 func (p *governanceChainCommitteeProtocol) ReadState(
 	ctx context.Context,
 	sr protocol.StateReader,
@@ -18,9 +18,11 @@ import (
 	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-election/test/mock/mock_committee"
 	"github.com/iotexproject/iotex-election/types"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 
 	"github.com/iotexproject/iotex-core/v2/action"
 	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/governance"
 	"github.com/iotexproject/iotex-core/v2/action/protocol/rolldpos"
 	"github.com/iotexproject/iotex-core/v2/action/protocol/vote"
 	"github.com/iotexproject/iotex-core/v2/action/protocol/vote/candidatesutil"
@@ -366,6 +368,101 @@ func TestCreatePreStates(t *testing.T) {
 	}
 }
 
+func TestCreatePreStates_GovernanceParams(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	p, ctx, sm, _, err := initConstruct(ctrl)
+	require.NoError(err)
+
+	psc, ok := p.(protocol.PreStatesCreator)
+	require.True(ok)
+	bcCtx := protocol.MustGetBlockchainCtx(ctx)
+	rp := rolldpos.MustGetProtocol(protocol.MustGetRegistry(ctx))
+	g := genesis.MustExtractGenesisContext(ctx)
+	g.ToBeEnabledBlockHeight = 1
+	ctx = genesis.WithGenesisContext(ctx, g)
+
+	// the governor shortens the probation period to 1 epoch and softens the probation from epoch 4
+	gp, err := governance.NewProtocol(genesis.Governance{Governors: []string{identityset.Address(10).String()}}, nil)
+	require.NoError(err)
+	params := &governance.ProbationParams{
+		ActivationHeight:      rp.GetEpochHeight(4),
+		ProductivityThreshold: g.ProductivityThreshold,
+		ProbationEpochPeriod:  1,
+		IntensityRate:         50,
+	}
+	data, err := governance.PackSetProbationParams(params)
+	require.NoError(err)
+	elp := (&action.EnvelopeBuilder{}).SetGasLimit(100000).SetGasPrice(big.NewInt(0)).
+		SetAction(action.NewExecution(governance.ProtocolAddr().String(), big.NewInt(0), data)).Build()
+	hctx := protocol.WithActionCtx(ctx, protocol.ActionCtx{Caller: identityset.Address(10)})
+	hctx = protocol.WithFeatureCtx(protocol.WithBlockCtx(hctx, protocol.BlockCtx{BlockHeight: 2}))
+	r, err := gp.Handle(hctx, elp, sm)
+	require.NoError(err)
+	require.EqualValues(iotextypes.ReceiptStatus_Success, r.Status)
+
+	expected := map[uint64]*vote.ProbationList{
+		2: {
+			IntensityRate: 90,
+			ProbationInfo: map[string]uint32{
+				identityset.Address(1).String(): 1,
+				identityset.Address(2).String(): 1,
+				identityset.Address(3).String(): 1,
+			},
+		},
+		3: {
+			IntensityRate: 90,
+			ProbationInfo: map[string]uint32{
+				identityset.Address(1).String(): 1,
+				identityset.Address(2).String(): 2,
+				identityset.Address(3).String(): 1,
+				identityset.Address(4).String(): 1,
+			},
+		},
+		// only the unproductive delegates of epoch 3 are counted
+		4: {
+			IntensityRate: 50,
+			ProbationInfo: map[string]uint32{
+				identityset.Address(5).String(): 1,
+				identityset.Address(6).String(): 1,
+			},
+		},
+	}
+	for epochNum := uint64(1); epochNum <= 3; epochNum++ {
+		epochStartHeight := rp.GetEpochHeight(epochNum)
+		bcCtx.Tip.Height = epochStartHeight - 1
+		ctx = protocol.WithBlockchainCtx(ctx, bcCtx)
+		ctx = protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+			BlockHeight: epochStartHeight,
+			Producer:    identityset.Address(1),
+		})
+		ctx = protocol.WithFeatureCtx(protocol.WithFeatureWithHeightCtx(ctx))
+		require.NoError(psc.CreatePreStates(ctx, sm))
+		candidates, err := p.Candidates(ctx, sm)
+		require.NoError(err)
+		require.NoError(setCandidates(ctx, sm, nil, candidates, rp.GetEpochHeight(epochNum+1)))
+
+		epochLastHeight := rp.GetEpochLastBlockHeight(epochNum)
+		bcCtx.Tip.Height = epochLastHeight - 1
+		ctx = protocol.WithBlockchainCtx(ctx, bcCtx)
+		ctx = protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+			BlockHeight: epochLastHeight,
+			Producer:    identityset.Address(1),
+		})
+		ctx = protocol.WithFeatureCtx(protocol.WithFeatureWithHeightCtx(ctx))
+		require.NoError(psc.CreatePreStates(ctx, sm))
+
+		bl := &vote.ProbationList{}
+		key := candidatesutil.ConstructKey(candidatesutil.NxtProbationKey)
+		_, err = sm.State(bl, protocol.KeyOption(key[:]), protocol.NamespaceOption(protocol.SystemNamespace))
+		require.NoError(err)
+		require.Equal(expected[epochNum+1], bl)
+	}
+	upd, err := candidatesutil.UnproductiveDelegateFromDB(sm)
+	require.NoError(err)
+	require.EqualValues(1, upd.ProbationPeriod())
+}
+
 func TestHandle(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
@@ -684,3 +781,43 @@ func TestDelegatesAndNextDelegates(t *testing.T) {
 		require.True(d.Equal(delegates5[i]))
 	}
 }
+
+func TestPreviewNextEpoch(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	p, ctx, sm, _, err := initConstruct(ctrl)
+	require.NoError(err)
+	previewer, ok := p.(NextEpochPreviewer)
+	require.True(ok)
+
+	probationList := &vote.ProbationList{
+		ProbationInfo: map[string]uint32{
+			identityset.Address(1).String(): 1,
+			identityset.Address(2).String(): 1,
+		},
+		IntensityRate: 90,
+	}
+	require.NoError(setNextEpochProbationList(sm, nil, 721, probationList))
+	preview, err := previewer.PreviewNextEpoch(ctx, sm)
+	require.NoError(err)
+	require.Equal(uint64(2), preview.EpochNum)
+	require.Equal(uint64(31), preview.Height)
+	require.False(preview.Projected)
+	require.Equal(probationList, preview.ProbationList)
+	delegates, err := p.NextDelegates(ctx, sm)
+	require.NoError(err)
+	require.Equal(delegates, preview.Delegates)
+
+	// the delegates and the probation list are projected before they are decided
+	_, err = shiftCandidates(sm)
+	require.NoError(err)
+	_, err = shiftProbationList(sm)
+	require.NoError(err)
+	ctx = protocol.WithBlockCtx(ctx, protocol.BlockCtx{BlockHeight: 30})
+	preview, err = previewer.PreviewNextEpoch(ctx, sm)
+	require.NoError(err)
+	require.True(preview.Projected)
+	require.Len(preview.Delegates, 2)
+	require.NotNil(preview.ProbationList)
+	require.Equal(uint32(90), preview.ProbationList.IntensityRate)
+}
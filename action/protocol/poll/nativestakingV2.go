@@ -119,6 +119,11 @@ func (ns *nativeStakingV2) NextCandidates(ctx context.Context, sr protocol.State
 	return candidates, err
 }
 
+// PreviewNextEpoch returns the delegates of next epoch computed ahead of the transition
+func (ns *nativeStakingV2) PreviewNextEpoch(ctx context.Context, sr protocol.StateReader) (*EpochPreview, error) {
+	return ns.slasher.PreviewNextEpoch(ctx, sr, ns.CalculateCandidatesByHeight)
+}
+
 func (ns *nativeStakingV2) ReadState(ctx context.Context, sr protocol.StateReader, method []byte, args ...[]byte) ([]byte, uint64, error) {
 	return ns.slasher.ReadState(ctx, sr, ns.candIndexer, method, args...)
 }
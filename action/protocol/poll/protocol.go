@@ -54,6 +54,9 @@ var ErrDelegatesNotAsExpected = errors.New("delegates are not as expected")
 // ErrDelegatesNotExist is an error that the delegates cannot be prepared
 var ErrDelegatesNotExist = errors.New("delegates cannot be found")
 
+// ErrPreviewNotSupported is an error that the delegates of the next epoch cannot be previewed
+var ErrPreviewNotSupported = errors.New("preview of the next epoch is not supported")
+
 type (
 	// GetCandidates returns the current candidates
 	GetCandidates func(protocol.StateReader, uint64, bool, bool) ([]*state.Candidate, uint64, error)
@@ -70,6 +73,27 @@ type (
 	// Productivity returns the number of produced blocks per producer
 	Productivity func(uint64, uint64) (map[string]uint64, error)
 
+	// EpochPreview is the delegates of the next epoch computed ahead of the transition
+	EpochPreview struct {
+		EpochNum uint64
+		// Height is the start height of the epoch
+		Height uint64
+		// Delegates are the active block producers of the epoch, the one at index height % len(Delegates) is the
+		// proposer of the first round at the height
+		Delegates state.CandidateList
+		// ProbationList is nil if the probation is not enabled in the epoch
+		ProbationList *vote.ProbationList
+		// Projected indicates that the candidates or the probation list are calculated from the current state, which
+		// may change until the candidates are snapshotted in the middle of the epoch and the probation list is decided
+		// at the last block of it
+		Projected bool
+	}
+
+	// NextEpochPreviewer previews the delegates of the next epoch
+	NextEpochPreviewer interface {
+		PreviewNextEpoch(context.Context, protocol.StateReader) (*EpochPreview, error)
+	}
+
 	// Protocol defines the protocol of handling votes
 	Protocol interface {
 		protocol.Protocol
@@ -15,8 +15,10 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/governance"
 	"github.com/iotexproject/iotex-core/v2/action/protocol/rolldpos"
 	"github.com/iotexproject/iotex-core/v2/action/protocol/vote"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/vote/candidatesutil"
 	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/v2/crypto"
 	"github.com/iotexproject/iotex-core/v2/pkg/log"
@@ -92,6 +94,11 @@ func (sh *Slasher) CreatePreStates(ctx context.Context, sm protocol.StateManager
 			return errors.Wrap(err, "failed to update current epoch meta")
 		}
 	}
+	if featureWithHeightCtx.EnableRandomBeacon(blkCtx.BlockHeight) {
+		if err := updateBeacon(ctx, sm, indexer); err != nil {
+			return err
+		}
+	}
 	if blkCtx.BlockHeight == epochLastHeight && featureWithHeightCtx.CalculateProbationList(nextEpochStartHeight) {
 		// if the block height is the end of epoch and next epoch is after the Easter height, calculate probation list for probation and write into state DB
 		unqualifiedList, err := sh.CalculateProbationList(ctx, sm, epochNum+1)
@@ -112,6 +119,14 @@ func (sh *Slasher) CreatePreStates(ctx context.Context, sm protocol.StateManager
 		if prevHeight != afterHeight {
 			return errors.Wrap(ErrInconsistentHeight, "shifting candidate height is not same as shifting probation height")
 		}
+		if featureCtx.EnableEpochCandidateSnapshot {
+			// snapshot the candidates of the epoch, which is committed in the header of the epoch start block
+			candidates, _, err := sh.GetCandidates(ctx, sm, false)
+			if err != nil {
+				return err
+			}
+			return candidatesutil.PutEpochCandidates(sm, epochNum, candidates)
+		}
 	}
 	return nil
 }
@@ -311,7 +326,11 @@ func (sh *Slasher) GetActiveBlockProducers(ctx context.Context, sr protocol.Stat
 	if err != nil {
 		return nil, uint64(0), errors.Wrapf(err, "failed to read block producers at height %d", targetEpochStartHeight)
 	}
-	abp, err := sh.calculateActiveBlockProducer(ctx, blockProducers, targetEpochStartHeight)
+	seed, err := beaconSeed(sr, readFromNext)
+	if err != nil {
+		return nil, uint64(0), err
+	}
+	abp, err := sh.calculateActiveBlockProducer(ctx, blockProducers, targetEpochStartHeight, seed)
 	if err != nil {
 		return nil, uint64(0), err
 	}
@@ -352,7 +371,11 @@ func (sh *Slasher) GetABPFromIndexer(ctx context.Context, epochStartHeight uint6
 	if err != nil {
 		return nil, err
 	}
-	return sh.calculateActiveBlockProducer(ctx, blockProducers, epochStartHeight)
+	seed, err := indexedBeaconSeed(ctx, sh.indexer, epochStartHeight)
+	if err != nil {
+		return nil, err
+	}
+	return sh.calculateActiveBlockProducer(ctx, blockProducers, epochStartHeight, seed)
 }
 
 // GetProbationList returns the probation list at given epoch
@@ -383,36 +406,119 @@ func (sh *Slasher) GetProbationList(ctx context.Context, sr protocol.StateReader
 	return unqualifiedList, stateHeight, nil
 }
 
+// PreviewNextEpoch computes the delegates of the next epoch ahead of the transition. The candidates snapshotted in the
+// middle of the epoch are used once they are taken, otherwise they are calculated from the current state. Likewise,
+// the probation list is projected from the productivity of the epoch so far until it is decided at the last block
+func (sh *Slasher) PreviewNextEpoch(
+	ctx context.Context,
+	sr protocol.StateReader,
+	calculateCandidates func(context.Context, protocol.StateReader, uint64) (state.CandidateList, error),
+) (*EpochPreview, error) {
+	rp := rolldpos.MustGetProtocol(protocol.MustGetRegistry(ctx))
+	featureWithHeightCtx := protocol.MustGetFeatureWithHeightCtx(ctx)
+	height, err := sr.Height()
+	if err != nil {
+		return nil, err
+	}
+	epochNum := rp.GetEpochNum(height)
+	nextEpochStartHeight := rp.GetEpochHeight(epochNum + 1)
+	probation := featureWithHeightCtx.CalculateProbationList(nextEpochStartHeight)
+	preview := &EpochPreview{
+		EpochNum: epochNum + 1,
+		Height:   nextEpochStartHeight,
+	}
+	candidates, _, err := sh.getCandidates(sr, nextEpochStartHeight, !probation, true)
+	if errors.Cause(err) == state.ErrStateNotExist {
+		preview.Projected = true
+		candidates, err = calculateCandidates(ctx, sr, rp.GetEpochHeight(epochNum))
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get candidates of epoch %d", epochNum+1)
+	}
+	if probation {
+		probationList, _, err := sh.getProbationList(sr, true)
+		if errors.Cause(err) == state.ErrStateNotExist {
+			preview.Projected = true
+			probationList, _, err = sh.calculateProbationList(ctx, sr, epochNum+1)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get probation list of epoch %d", epochNum+1)
+		}
+		if candidates, err = filterCandidates(candidates, probationList, nextEpochStartHeight); err != nil {
+			return nil, err
+		}
+		preview.ProbationList = probationList
+	}
+	bp, err := sh.calculateBlockProducer(candidates)
+	if err != nil {
+		return nil, err
+	}
+	seed, err := beaconSeed(sr, true)
+	if err != nil {
+		return nil, err
+	}
+	if preview.Delegates, err = sh.calculateActiveBlockProducer(ctx, bp, nextEpochStartHeight, seed); err != nil {
+		return nil, err
+	}
+	return preview, nil
+}
+
 // CalculateProbationList calculates probation list according to productivity
 func (sh *Slasher) CalculateProbationList(
 	ctx context.Context,
 	sm protocol.StateManager,
 	epochNum uint64,
 ) (*vote.ProbationList, error) {
+	nextProbationlist, upd, err := sh.calculateProbationList(ctx, sm, epochNum)
+	if err != nil {
+		return nil, err
+	}
+	return nextProbationlist, setUnproductiveDelegates(sm, upd)
+}
+
+// calculateProbationList calculates probation list according to productivity, along with the unproductive delegates
+// updated with the current epoch
+func (sh *Slasher) calculateProbationList(
+	ctx context.Context,
+	sr protocol.StateReader,
+	epochNum uint64,
+) (*vote.ProbationList, *vote.UnproductiveDelegate, error) {
 	rp := rolldpos.MustGetProtocol(protocol.MustGetRegistry(ctx))
 	g := genesis.MustExtractGenesisContext(ctx)
 	easterEpochNum := rp.GetEpochNum(g.EasterBlockHeight)
+	params, err := sh.probationParams(ctx, sr, rp.GetEpochHeight(epochNum))
+	if err != nil {
+		return nil, nil, err
+	}
 
 	nextProbationlist := &vote.ProbationList{
-		IntensityRate: sh.probationIntensity,
+		IntensityRate: params.IntensityRate,
 	}
-	upd, err := sh.getUnprodDelegate(sm)
+	upd, err := sh.getUnprodDelegate(sr)
 	if err != nil {
 		if errors.Cause(err) == state.ErrStateNotExist {
-			if upd, err = vote.NewUnproductiveDelegate(sh.probationEpochPeriod, sh.maxProbationPeriod); err != nil {
-				return nil, errors.Wrap(err, "failed to make new upd")
+			if upd, err = vote.NewUnproductiveDelegate(params.ProbationEpochPeriod, sh.maxProbationPeriod); err != nil {
+				return nil, nil, errors.Wrap(err, "failed to make new upd")
 			}
 		} else {
-			return nil, errors.Wrapf(err, "failed to read upd struct from state DB at epoch number %d", epochNum)
+			return nil, nil, errors.Wrapf(err, "failed to read upd struct from state DB at epoch number %d", epochNum)
 		}
 	}
+	recount := epochNum <= easterEpochNum+params.ProbationEpochPeriod
+	if upd.ProbationPeriod() != params.ProbationEpochPeriod {
+		// the probation period is changed on chain, so the probation list is counted over the recent epochs again
+		if upd, err = sh.resizeUnproductiveDelegate(upd, params.ProbationEpochPeriod); err != nil {
+			return nil, nil, err
+		}
+		recount = true
+	}
 	unqualifiedDelegates := make(map[string]uint32)
-	if epochNum <= easterEpochNum+sh.probationEpochPeriod {
+	if recount {
 		// if epoch number is smaller than easterEpochNum+K(probation period), calculate it one-by-one (initialize).
 		log.L().Debug("Before using probation list",
 			zap.Uint64("epochNum", epochNum),
 			zap.Uint64("easterEpochNum", easterEpochNum),
-			zap.Uint64("probationEpochPeriod", sh.probationEpochPeriod),
+			zap.Uint64("probationEpochPeriod", params.ProbationEpochPeriod),
 		)
 		existinglist := upd.DelegateList()
 		for _, listByEpoch := range existinglist {
@@ -425,9 +531,9 @@ func (sh *Slasher) CalculateProbationList(
 			}
 		}
 		// calculate upd of epochNum-1 (latest)
-		uq, err := sh.calculateUnproductiveDelegates(ctx, sm)
+		uq, err := sh.calculateUnproductiveDelegates(ctx, sr)
 		if err != nil {
-			return nil, errors.Wrapf(err, "failed to calculate current epoch upd %d", epochNum-1)
+			return nil, nil, errors.Wrapf(err, "failed to calculate current epoch upd %d", epochNum-1)
 		}
 		for _, addr := range uq {
 			if _, ok := unqualifiedDelegates[addr]; !ok {
@@ -437,20 +543,20 @@ func (sh *Slasher) CalculateProbationList(
 			}
 		}
 		if err := upd.AddRecentUPD(uq); err != nil {
-			return nil, errors.Wrap(err, "failed to add recent upd")
+			return nil, nil, errors.Wrap(err, "failed to add recent upd")
 		}
 		nextProbationlist.ProbationInfo = unqualifiedDelegates
-		return nextProbationlist, setUnproductiveDelegates(sm, upd)
+		return nextProbationlist, upd, nil
 	}
 	// ProbationList[N] = ProbationList[N-1] - Low-productivity-list[N-K-1] + Low-productivity-list[N-1]
 	log.L().Debug("Using probationList",
 		zap.Uint64("epochNum", epochNum),
 		zap.Uint64("easterEpochNum", easterEpochNum),
-		zap.Uint64("probationEpochPeriod", sh.probationEpochPeriod),
+		zap.Uint64("probationEpochPeriod", params.ProbationEpochPeriod),
 	)
-	prevProbationlist, _, err := sh.getProbationList(sm, false)
+	prevProbationlist, _, err := sh.getProbationList(sr, false)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to read latest probation list")
+		return nil, nil, errors.Wrap(err, "failed to read latest probation list")
 	}
 	probationMap := prevProbationlist.ProbationInfo
 	if probationMap == nil {
@@ -464,12 +570,12 @@ func (sh *Slasher) CalculateProbationList(
 		}
 		probationMap[addr]--
 	}
-	addList, err := sh.calculateUnproductiveDelegates(ctx, sm)
+	addList, err := sh.calculateUnproductiveDelegates(ctx, sr)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to calculate current epoch upd %d", epochNum-1)
+		return nil, nil, errors.Wrapf(err, "failed to calculate current epoch upd %d", epochNum-1)
 	}
 	if err := upd.AddRecentUPD(addList); err != nil {
-		return nil, errors.Wrap(err, "failed to add recent upd")
+		return nil, nil, errors.Wrap(err, "failed to add recent upd")
 	}
 	for _, addr := range addList {
 		if _, ok := probationMap[addr]; ok {
@@ -485,7 +591,7 @@ func (sh *Slasher) CalculateProbationList(
 		}
 	}
 	nextProbationlist.ProbationInfo = probationMap
-	return nextProbationlist, setUnproductiveDelegates(sm, upd)
+	return nextProbationlist, upd, nil
 }
 
 func (sh *Slasher) calculateUnproductiveDelegates(ctx context.Context, sr protocol.StateReader) ([]string, error) {
@@ -495,6 +601,11 @@ func (sh *Slasher) calculateUnproductiveDelegates(ctx context.Context, sr protoc
 	rp := rolldpos.MustGetProtocol(protocol.MustGetRegistry(ctx))
 	epochNum := rp.GetEpochNum(blkCtx.BlockHeight)
 	numOfBlocksByEpoch := rp.NumBlocksByEpoch(epochNum)
+	// the productivity of the epoch is evaluated for the probation list of the next epoch
+	params, err := sh.probationParams(ctx, sr, rp.GetEpochHeight(epochNum+1))
+	if err != nil {
+		return nil, err
+	}
 	delegates, _, err := sh.GetActiveBlockProducers(ctx, sr, false)
 	if err != nil {
 		return nil, err
@@ -513,12 +624,14 @@ func (sh *Slasher) calculateUnproductiveDelegates(ctx context.Context, sr protoc
 	if err != nil {
 		return nil, err
 	}
-	// The current block is not included, so add it
-	numBlks++
-	if _, ok := produce[blkCtx.Producer.String()]; ok {
-		produce[blkCtx.Producer.String()]++
-	} else {
-		produce[blkCtx.Producer.String()] = 1
+	if blkCtx.Producer != nil {
+		// The current block is not included, so add it
+		numBlks++
+		if _, ok := produce[blkCtx.Producer.String()]; ok {
+			produce[blkCtx.Producer.String()]++
+		} else {
+			produce[blkCtx.Producer.String()] = 1
+		}
 	}
 
 	for _, abp := range delegates {
@@ -528,14 +641,54 @@ func (sh *Slasher) calculateUnproductiveDelegates(ctx context.Context, sr protoc
 	}
 	unqualified := make([]string, 0)
 	expectedNumBlks := numBlks / uint64(len(produce))
+	if expectedNumBlks == 0 {
+		// too few blocks to tell the productivity, which is the case only if it is projected early in the epoch
+		return unqualified, nil
+	}
 	for addr, actualNumBlks := range produce {
-		if actualNumBlks*100/expectedNumBlks < sh.prodThreshold {
+		if actualNumBlks*100/expectedNumBlks < params.ProductivityThreshold {
 			unqualified = append(unqualified, addr)
 		}
 	}
 	return unqualified, nil
 }
 
+// probationParams returns the probation parameters effective at the height, which are the ones scheduled on chain by
+// the governors if any is activated, or the genesis ones otherwise
+func (sh *Slasher) probationParams(ctx context.Context, sr protocol.StateReader, height uint64) (*governance.ProbationParams, error) {
+	if featureCtx, ok := protocol.GetFeatureCtx(ctx); ok && featureCtx.EnableGovernanceParams {
+		params, err := governance.ProbationParamsAt(sr, height)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read probation params")
+		}
+		if params != nil {
+			return params, nil
+		}
+	}
+	return &governance.ProbationParams{
+		ProductivityThreshold: sh.prodThreshold,
+		ProbationEpochPeriod:  sh.probationEpochPeriod,
+		IntensityRate:         sh.probationIntensity,
+	}, nil
+}
+
+// resizeUnproductiveDelegate keeps the unproductive delegates of the recent epochs for the new probation period. The
+// oldest epoch of the period is left empty, which is dropped once the current epoch is added
+func (sh *Slasher) resizeUnproductiveDelegate(upd *vote.UnproductiveDelegate, period uint64) (*vote.UnproductiveDelegate, error) {
+	resized, err := vote.NewUnproductiveDelegate(period, sh.maxProbationPeriod)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to make new upd")
+	}
+	list := upd.DelegateList()
+	n := min(uint64(len(list)), upd.ProbationPeriod(), period-1)
+	for i := int(n) - 1; i >= 0; i-- {
+		if err := resized.AddRecentUPD(list[i]); err != nil {
+			return nil, err
+		}
+	}
+	return resized, nil
+}
+
 func (sh *Slasher) updateCurrentBlockMeta(ctx context.Context, sm protocol.StateManager) error {
 	blkCtx := protocol.MustGetBlockCtx(ctx)
 	rp := rolldpos.MustGetProtocol(protocol.MustGetRegistry(ctx))
@@ -564,6 +717,7 @@ func (sh *Slasher) calculateActiveBlockProducer(
 	ctx context.Context,
 	blockProducers state.CandidateList,
 	epochStartHeight uint64,
+	seed []byte,
 ) (state.CandidateList, error) {
 	var blockProducerList []string
 	blockProducerMap := make(map[string]*state.Candidate)
@@ -571,7 +725,7 @@ func (sh *Slasher) calculateActiveBlockProducer(
 		blockProducerList = append(blockProducerList, bp.Address)
 		blockProducerMap[bp.Address] = bp
 	}
-	crypto.SortCandidates(blockProducerList, epochStartHeight, crypto.CryptoSeed)
+	crypto.SortCandidates(blockProducerList, epochStartHeight, seed)
 
 	length := int(sh.numDelegates)
 	if len(blockProducerList) < length {
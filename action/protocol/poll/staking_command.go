@@ -145,6 +145,19 @@ func (sc *stakingCommand) NextCandidates(ctx context.Context, sr protocol.StateR
 	return sc.stakingV1.NextCandidates(ctx, sr)
 }
 
+// PreviewNextEpoch returns the delegates of next epoch computed ahead of the transition
+func (sc *stakingCommand) PreviewNextEpoch(ctx context.Context, sr protocol.StateReader) (*EpochPreview, error) {
+	p := sc.stakingV1
+	if sc.useV2(ctx, sr) {
+		p = sc.stakingV2
+	}
+	previewer, ok := p.(NextEpochPreviewer)
+	if !ok {
+		return nil, ErrPreviewNotSupported
+	}
+	return previewer.PreviewNextEpoch(ctx, sr)
+}
+
 func (sc *stakingCommand) ReadState(ctx context.Context, sr protocol.StateReader, method []byte, args ...[]byte) ([]byte, uint64, error) {
 	if sc.useV2(ctx, sr) {
 		res, height, err := sc.stakingV2.ReadState(ctx, sr, method, args...)
@@ -23,6 +23,8 @@ var (
 	ErrUnimplemented = errors.New("method is unimplemented")
 	// ErrNoName indicates the name is not found
 	ErrNoName = errors.New("name is not found")
+	// ErrGasNotSponsored indicates the gas sponsor fails to pay the gas of an action it has sponsored in validation
+	ErrGasNotSponsored = errors.New("gas is not sponsored")
 )
 
 const (
@@ -81,11 +83,61 @@ type ActionHandler interface {
 	Handle(context.Context, action.Envelope, StateManager) (*action.Receipt, error)
 }
 
+// PreActionHandler is the interface for the pre action handlers, which are called before the action is handled
+type PreActionHandler interface {
+	PreHandle(ctx context.Context, elp action.Envelope, sm StateManager) error
+}
+
 // PostActionHandler is the interface for the post action handlers. For each incoming action, the assembled actions
 type PostActionHandler interface {
 	HandleReceipt(ctx context.Context, elp action.Envelope, sm StateManager, receipt *action.Receipt) error
 }
 
+// GasSponsor is the interface for the protocols paying the gas of the actions on behalf of the callers
+type GasSponsor interface {
+	// SponsorsGas returns if the protocol pays the gas of the actions in the context
+	SponsorsGas(ctx context.Context) bool
+	// SponsoredGasFee returns the max gas fee of the action the protocol pays on behalf of the caller
+	SponsoredGasFee(ctx context.Context, sr StateReader, selp *action.SealedEnvelope) (*big.Int, error)
+}
+
+// GasSponsors returns the gas sponsors in the registry of the context which pay the gas in the context
+func GasSponsors(ctx context.Context) []GasSponsor {
+	reg, ok := GetRegistry(ctx)
+	if !ok {
+		return nil
+	}
+	var sponsors []GasSponsor
+	for _, p := range reg.All() {
+		if sponsor, ok := p.(GasSponsor); ok && sponsor.SponsorsGas(ctx) {
+			sponsors = append(sponsors, sponsor)
+		}
+	}
+	return sponsors
+}
+
+// CallerCost returns the cost of the action charged from the balance of the caller, which excludes the gas fee paid
+// by the gas sponsors
+func CallerCost(ctx context.Context, sr StateReader, selp *action.SealedEnvelope, sponsors []GasSponsor) (*big.Int, error) {
+	cost, err := selp.Cost()
+	if err != nil {
+		return nil, err
+	}
+	for _, sponsor := range sponsors {
+		fee, err := sponsor.SponsoredGasFee(ctx, sr, selp)
+		if err != nil {
+			return nil, err
+		}
+		cost.Sub(cost, fee)
+	}
+	return cost, nil
+}
+
+// BlockEndHandler is the interface for the handlers called after all the actions of a block are handled
+type BlockEndHandler interface {
+	HandleBlockEnd(context.Context, StateManager) error
+}
+
 type (
 	DepositOptionCfg struct {
 		PriorityFee *big.Int
@@ -0,0 +1,185 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package rewarding
+
+import (
+	"context"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/abiregistry"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/rewarding/rewardingpb"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/staking"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+)
+
+const _rewardCompoundedEventABI = `[
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "internalType": "uint64", "name": "bucketIndex", "type": "uint64"},
+			{"indexed": true, "internalType": "address", "name": "owner", "type": "address"},
+			{"indexed": false, "internalType": "uint256", "name": "amount", "type": "uint256"}
+		],
+		"name": "RewardCompounded",
+		"type": "event"
+	}
+]`
+
+// RewardCompoundedEvent is the event logged by the rewarding protocol when the reward is compounded into a bucket
+var RewardCompoundedEvent abi.Event
+
+func init() {
+	eventABI, err := abi.JSON(strings.NewReader(_rewardCompoundedEventABI))
+	if err != nil {
+		panic(err)
+	}
+	RewardCompoundedEvent = eventABI.Events["RewardCompounded"]
+}
+
+// CompoundABI returns the ABI of the events logged by the rewarding protocol when compounding the rewards
+func CompoundABI() string {
+	return abiregistry.MustJoinABI(_rewardCompoundedEventABI)
+}
+
+// CompoundRewards claims the unclaimed rewards of the owners rewarded in the epoch from the rewarding fund, and
+// deposits them into the auto-compound buckets of the owners. It is called at the settlement of the epoch reward, so
+// that the owners don't need to claim and restake the rewards themselves. A bucket failing to compound is skipped
+// with the failure recorded on it, and the reward is left unclaimed for the next epoch
+func (p *Protocol) CompoundRewards(ctx context.Context, sm protocol.StateManager, rewardLogs []*action.Log) ([]*action.Log, []*action.TransactionLog, error) {
+	if !protocol.MustGetFeatureCtx(ctx).EnableRewardAutoCompound {
+		return nil, nil, nil
+	}
+	sp := staking.FindProtocol(protocol.MustGetRegistry(ctx))
+	if sp == nil {
+		return nil, nil, nil
+	}
+	owners, err := rewardedAddrs(rewardLogs)
+	if err != nil {
+		return nil, nil, err
+	}
+	var (
+		logs  []*action.Log
+		tLogs []*action.TransactionLog
+	)
+	for _, owner := range owners {
+		si := sm.Snapshot()
+		bucket, amount, err := p.compoundReward(ctx, sm, sp, owner)
+		if err != nil {
+			log.L().Warn("Failed to compound reward", zap.String("owner", owner.String()), zap.Error(err))
+			if err := sm.Revert(si); err != nil {
+				return nil, nil, err
+			}
+			if bucket != nil {
+				if err := sp.RecordCompoundFailure(ctx, sm, bucket.Index); err != nil {
+					return nil, nil, err
+				}
+			}
+			continue
+		}
+		if bucket == nil {
+			continue
+		}
+		rewardLog, err := p.rewardCompoundedLog(ctx, bucket, amount)
+		if err != nil {
+			return nil, nil, err
+		}
+		logs = append(logs, rewardLog)
+		tLogs = append(tLogs,
+			&action.TransactionLog{
+				Type:      iotextypes.TransactionLogType_CLAIM_FROM_REWARDING_FUND,
+				Sender:    address.RewardingPoolAddr,
+				Recipient: bucket.Owner.String(),
+				Amount:    amount,
+			},
+			&action.TransactionLog{
+				Type:      iotextypes.TransactionLogType_DEPOSIT_TO_BUCKET,
+				Sender:    bucket.Owner.String(),
+				Recipient: address.StakingBucketPoolAddr,
+				Amount:    amount,
+			},
+		)
+	}
+	return logs, tLogs, nil
+}
+
+// rewardedAddrs returns the addresses rewarded in the reward logs, in the order of the logs
+func rewardedAddrs(rewardLogs []*action.Log) ([]address.Address, error) {
+	var (
+		addrs = make([]address.Address, 0, len(rewardLogs))
+		seen  = make(map[string]struct{}, len(rewardLogs))
+	)
+	for _, l := range rewardLogs {
+		var rewardLog rewardingpb.RewardLog
+		if err := proto.Unmarshal(l.Data, &rewardLog); err != nil {
+			return nil, err
+		}
+		if _, ok := seen[rewardLog.Addr]; ok {
+			continue
+		}
+		seen[rewardLog.Addr] = struct{}{}
+		addr, err := address.FromString(rewardLog.Addr)
+		if err != nil {
+			return nil, err
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs, nil
+}
+
+// compoundReward compounds the unclaimed reward of the owner into its auto-compound bucket, and returns the bucket
+// and the amount compounded. A nil bucket is returned if there is nothing to compound
+func (p *Protocol) compoundReward(ctx context.Context, sm protocol.StateManager, sp *staking.Protocol, owner address.Address) (*staking.VoteBucket, *big.Int, error) {
+	bucket, err := sp.AutoCompoundBucket(ctx, sm, owner)
+	if err != nil || bucket == nil {
+		return nil, nil, err
+	}
+	amount, _, err := p.UnclaimedBalance(ctx, sm, owner)
+	if err != nil {
+		return bucket, nil, err
+	}
+	if amount.Sign() == 0 {
+		return nil, nil, nil
+	}
+	if err := p.updateTotalBalance(ctx, sm, amount); err != nil {
+		return bucket, nil, err
+	}
+	if err := p.deductFromAccount(ctx, sm, owner, amount); err != nil {
+		return bucket, nil, err
+	}
+	if err := sp.CompoundReward(ctx, sm, bucket, amount); err != nil {
+		return bucket, nil, err
+	}
+	return bucket, amount, nil
+}
+
+func (p *Protocol) rewardCompoundedLog(ctx context.Context, bucket *staking.VoteBucket, amount *big.Int) (*action.Log, error) {
+	data, err := RewardCompoundedEvent.Inputs.NonIndexed().Pack(amount)
+	if err != nil {
+		return nil, err
+	}
+	return &action.Log{
+		Address: p.addr.String(),
+		Topics: action.Topics{
+			hash.Hash256(RewardCompoundedEvent.ID),
+			hash.BytesToHash256(byteutil.Uint64ToBytesBigEndian(bucket.Index)),
+			hash.BytesToHash256(bucket.Owner.Bytes()),
+		},
+		Data:        data,
+		BlockHeight: protocol.MustGetBlockCtx(ctx).BlockHeight,
+		ActionHash:  protocol.MustGetActionCtx(ctx).ActionHash,
+	}, nil
+}
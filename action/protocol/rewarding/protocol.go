@@ -250,7 +250,12 @@ func (p *Protocol) Handle(
 				log.L().Debug("Error when handling rewarding action", zap.Error(err))
 				return p.settleSystemAction(ctx, sm, elp, uint64(iotextypes.ReceiptStatus_Failure), si, nil)
 			}
-			return p.settleSystemAction(ctx, sm, elp, uint64(iotextypes.ReceiptStatus_Success), si, rewardLogs)
+			// a bucket failing to compound is skipped with the failure recorded on it, instead of failing the epoch reward
+			compoundLogs, tLogs, err := p.CompoundRewards(ctx, sm, rewardLogs)
+			if err != nil {
+				return nil, err
+			}
+			return p.settleSystemAction(ctx, sm, elp, uint64(iotextypes.ReceiptStatus_Success), si, append(rewardLogs, compoundLogs...), tLogs...)
 		}
 	}
 	return nil, nil
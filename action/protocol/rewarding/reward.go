@@ -382,6 +382,26 @@ func (p *Protocol) grantToAccount(ctx context.Context, sm protocol.StateManager,
 
 func (p *Protocol) claimFromAccount(ctx context.Context, sm protocol.StateManager, addr address.Address, amount *big.Int) error {
 	// Update reward account
+	if err := p.deductFromAccount(ctx, sm, addr, amount); err != nil {
+		return err
+	}
+	accountCreationOpts := []state.AccountCreationOption{}
+	if protocol.MustGetFeatureCtx(ctx).CreateLegacyNonceAccount {
+		accountCreationOpts = append(accountCreationOpts, state.LegacyNonceAccountTypeOption())
+	}
+	// Update primary account
+	primAcc, err := accountutil.LoadOrCreateAccount(sm, addr, accountCreationOpts...)
+	if err != nil {
+		return err
+	}
+	if err := primAcc.AddBalance(amount); err != nil {
+		return err
+	}
+	return accountutil.StoreAccount(sm, addr, primAcc)
+}
+
+// deductFromAccount deducts the amount from the unclaimed balance of the reward account
+func (p *Protocol) deductFromAccount(ctx context.Context, sm protocol.StateManager, addr address.Address, amount *big.Int) error {
 	acc := rewardAccount{}
 	accKey := append(_adminKey, addr.Bytes()...)
 	_, fromLegacy, err := p.stateCheckLegacy(ctx, sm, accKey, &acc)
@@ -402,19 +422,7 @@ func (p *Protocol) claimFromAccount(ctx context.Context, sm protocol.StateManage
 			return err
 		}
 	}
-	accountCreationOpts := []state.AccountCreationOption{}
-	if protocol.MustGetFeatureCtx(ctx).CreateLegacyNonceAccount {
-		accountCreationOpts = append(accountCreationOpts, state.LegacyNonceAccountTypeOption())
-	}
-	// Update primary account
-	primAcc, err := accountutil.LoadOrCreateAccount(sm, addr, accountCreationOpts...)
-	if err != nil {
-		return err
-	}
-	if err := primAcc.AddBalance(amount); err != nil {
-		return err
-	}
-	return accountutil.StoreAccount(sm, addr, primAcc)
+	return nil
 }
 
 func (p *Protocol) calculateTotalRewardAndTip(ctx context.Context, sm protocol.StateManager) (*big.Int, *big.Int, *big.Int, error) {
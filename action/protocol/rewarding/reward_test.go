@@ -11,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-election/test/mock/mock_committee"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
@@ -19,16 +20,19 @@ import (
 	"go.uber.org/mock/gomock"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/iotexproject/iotex-core/v2/action"
 	"github.com/iotexproject/iotex-core/v2/action/protocol"
 	"github.com/iotexproject/iotex-core/v2/action/protocol/account"
 	accountutil "github.com/iotexproject/iotex-core/v2/action/protocol/account/util"
 	"github.com/iotexproject/iotex-core/v2/action/protocol/poll"
 	"github.com/iotexproject/iotex-core/v2/action/protocol/rewarding/rewardingpb"
 	"github.com/iotexproject/iotex-core/v2/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/staking"
 	"github.com/iotexproject/iotex-core/v2/blockchain"
 	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/v2/db/batch"
 	"github.com/iotexproject/iotex-core/v2/pkg/unit"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
 	"github.com/iotexproject/iotex-core/v2/state"
 	"github.com/iotexproject/iotex-core/v2/test/identityset"
 	"github.com/iotexproject/iotex-core/v2/test/mock/mock_chainmanager"
@@ -603,3 +607,48 @@ func TestProtocol_CalculateReward(t *testing.T) {
 		}, false)
 	}
 }
+
+func TestProtocol_CompoundRewards(t *testing.T) {
+	testProtocol(t, func(t *testing.T, ctx context.Context, sm protocol.StateManager, p *Protocol) {
+		r := require.New(t)
+		// the staking protocol is not registered
+		g := genesis.MustExtractGenesisContext(ctx)
+		g.ToBeEnabledBlockHeight = 0
+		ctx = protocol.WithFeatureCtx(genesis.WithGenesisContext(ctx, g))
+		r.True(protocol.MustGetFeatureCtx(ctx).EnableRewardAutoCompound)
+		logs, tLogs, err := p.CompoundRewards(ctx, sm, nil)
+		r.NoError(err)
+		r.Empty(logs)
+		r.Empty(tLogs)
+
+		// the owners are the addresses rewarded in the epoch
+		var rewardLogs []*action.Log
+		for _, addr := range []address.Address{identityset.Address(28), identityset.Address(29), identityset.Address(28)} {
+			data, err := proto.Marshal(&rewardingpb.RewardLog{
+				Type:   rewardingpb.RewardLog_EPOCH_REWARD,
+				Addr:   addr.String(),
+				Amount: "100",
+			})
+			r.NoError(err)
+			rewardLogs = append(rewardLogs, &action.Log{Data: data})
+		}
+		owners, err := rewardedAddrs(rewardLogs)
+		r.NoError(err)
+		r.Equal([]address.Address{identityset.Address(28), identityset.Address(29)}, owners)
+
+		bucket := &staking.VoteBucket{
+			Index: 5,
+			Owner: identityset.Address(28),
+		}
+		log, err := p.rewardCompoundedLog(ctx, bucket, big.NewInt(100))
+		r.NoError(err)
+		r.Equal(ProtocolAddr().String(), log.Address)
+		r.Len(log.Topics, 3)
+		r.Equal(hash.Hash256(RewardCompoundedEvent.ID), log.Topics[0])
+		r.Equal(hash.BytesToHash256(byteutil.Uint64ToBytesBigEndian(5)), log.Topics[1])
+		r.Equal(hash.BytesToHash256(identityset.Address(28).Bytes()), log.Topics[2])
+		values, err := RewardCompoundedEvent.Inputs.NonIndexed().Unpack(log.Data)
+		r.NoError(err)
+		r.Equal(big.NewInt(100), values[0])
+	}, false)
+}
@@ -6,6 +6,7 @@
 package staking
 
 import (
+	"bytes"
 	"math/big"
 	"sort"
 	"strings"
@@ -31,6 +32,17 @@ type (
 		Votes              *big.Int
 		SelfStakeBucketIdx uint64
 		SelfStake          *big.Int
+		// PendingOperator is the announced operator, which takes over the operator at the start of the epoch before
+		// OperatorRotationEpoch, and PreviousOperator is the replaced operator, which is kept until the end of
+		// OperatorRotationEpoch. Both operators are honored by consensus during the two epochs
+		PendingOperator       address.Address
+		PreviousOperator      address.Address
+		OperatorRotationEpoch uint64
+		// BLSPublicKey is the BLS public key registered next to the operator, which is honored by consensus from
+		// BLSKeyEpoch, and PreviousBLSPublicKey is the replaced key, which is honored before BLSKeyEpoch
+		BLSPublicKey         []byte
+		PreviousBLSPublicKey []byte
+		BLSKeyEpoch          uint64
 	}
 
 	// CandidateList is a list of candidates which is sortable
@@ -46,14 +58,20 @@ type (
 // Clone returns a copy
 func (d *Candidate) Clone() *Candidate {
 	return &Candidate{
-		Owner:              d.Owner,
-		Operator:           d.Operator,
-		Reward:             d.Reward,
-		Identifier:         d.Identifier,
-		Name:               d.Name,
-		Votes:              new(big.Int).Set(d.Votes),
-		SelfStakeBucketIdx: d.SelfStakeBucketIdx,
-		SelfStake:          new(big.Int).Set(d.SelfStake),
+		Owner:                 d.Owner,
+		Operator:              d.Operator,
+		Reward:                d.Reward,
+		Identifier:            d.Identifier,
+		Name:                  d.Name,
+		Votes:                 new(big.Int).Set(d.Votes),
+		SelfStakeBucketIdx:    d.SelfStakeBucketIdx,
+		SelfStake:             new(big.Int).Set(d.SelfStake),
+		PendingOperator:       d.PendingOperator,
+		PreviousOperator:      d.PreviousOperator,
+		OperatorRotationEpoch: d.OperatorRotationEpoch,
+		BLSPublicKey:          d.BLSPublicKey,
+		PreviousBLSPublicKey:  d.PreviousBLSPublicKey,
+		BLSKeyEpoch:           d.BLSKeyEpoch,
 	}
 }
 
@@ -66,7 +84,13 @@ func (d *Candidate) Equal(c *Candidate) bool {
 		address.Equal(d.Reward, c.Reward) &&
 		address.Equal(d.Identifier, c.Identifier) &&
 		d.Votes.Cmp(c.Votes) == 0 &&
-		d.SelfStake.Cmp(c.SelfStake) == 0
+		d.SelfStake.Cmp(c.SelfStake) == 0 &&
+		address.Equal(d.PendingOperator, c.PendingOperator) &&
+		address.Equal(d.PreviousOperator, c.PreviousOperator) &&
+		d.OperatorRotationEpoch == c.OperatorRotationEpoch &&
+		bytes.Equal(d.BLSPublicKey, c.BLSPublicKey) &&
+		bytes.Equal(d.PreviousBLSPublicKey, c.PreviousBLSPublicKey) &&
+		d.BLSKeyEpoch == c.BLSKeyEpoch
 }
 
 // Validate does the sanity check
@@ -202,16 +226,29 @@ func (d *Candidate) toProto() (*stakingpb.Candidate, error) {
 	if d.Identifier != nil {
 		voter = d.Identifier.String()
 	}
+	pending, previous := "", ""
+	if d.PendingOperator != nil {
+		pending = d.PendingOperator.String()
+	}
+	if d.PreviousOperator != nil {
+		previous = d.PreviousOperator.String()
+	}
 
 	return &stakingpb.Candidate{
-		OwnerAddress:       d.Owner.String(),
-		OperatorAddress:    d.Operator.String(),
-		RewardAddress:      d.Reward.String(),
-		IdentifierAddress:  voter,
-		Name:               d.Name,
-		Votes:              d.Votes.String(),
-		SelfStakeBucketIdx: d.SelfStakeBucketIdx,
-		SelfStake:          d.SelfStake.String(),
+		OwnerAddress:            d.Owner.String(),
+		OperatorAddress:         d.Operator.String(),
+		RewardAddress:           d.Reward.String(),
+		IdentifierAddress:       voter,
+		Name:                    d.Name,
+		Votes:                   d.Votes.String(),
+		SelfStakeBucketIdx:      d.SelfStakeBucketIdx,
+		SelfStake:               d.SelfStake.String(),
+		PendingOperatorAddress:  pending,
+		PreviousOperatorAddress: previous,
+		OperatorRotationEpoch:   d.OperatorRotationEpoch,
+		BlsPublicKey:            d.BLSPublicKey,
+		PreviousBLSPublicKey:    d.PreviousBLSPublicKey,
+		BlsKeyEpoch:             d.BLSKeyEpoch,
 	}, nil
 }
 
@@ -255,9 +292,50 @@ func (d *Candidate) fromProto(pb *stakingpb.Candidate) error {
 	if !ok {
 		return action.ErrInvalidAmount
 	}
+
+	d.PendingOperator, d.PreviousOperator = nil, nil
+	if pending := pb.GetPendingOperatorAddress(); len(pending) > 0 {
+		d.PendingOperator, err = address.FromString(pending)
+		if err != nil {
+			return err
+		}
+	}
+	if previous := pb.GetPreviousOperatorAddress(); len(previous) > 0 {
+		d.PreviousOperator, err = address.FromString(previous)
+		if err != nil {
+			return err
+		}
+	}
+	d.OperatorRotationEpoch = pb.GetOperatorRotationEpoch()
+	d.BLSPublicKey = pb.GetBlsPublicKey()
+	d.PreviousBLSPublicKey = pb.GetPreviousBLSPublicKey()
+	d.BLSKeyEpoch = pb.GetBlsKeyEpoch()
 	return nil
 }
 
+// operatorAliases returns the old and the new operator of the rotation, which are both honored during the epoch
+func (d *Candidate) operatorAliases(epoch uint64) (address.Address, address.Address, bool) {
+	if d.OperatorRotationEpoch == 0 || epoch+1 < d.OperatorRotationEpoch || epoch > d.OperatorRotationEpoch {
+		return nil, nil, false
+	}
+	switch {
+	case d.PendingOperator != nil:
+		return d.Operator, d.PendingOperator, true
+	case d.PreviousOperator != nil:
+		return d.PreviousOperator, d.Operator, true
+	default:
+		return nil, nil, false
+	}
+}
+
+// blsPublicKey returns the BLS public key honored in the epoch, or nil if no key is registered
+func (d *Candidate) blsPublicKey(epoch uint64) []byte {
+	if epoch < d.BLSKeyEpoch {
+		return d.PreviousBLSPublicKey
+	}
+	return d.BLSPublicKey
+}
+
 func (d *Candidate) toIoTeXTypes() *iotextypes.CandidateV2 {
 	return &iotextypes.CandidateV2{
 		OwnerAddress:       d.Owner.String(),
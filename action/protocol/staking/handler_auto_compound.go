@@ -0,0 +1,272 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/abiregistry"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/v2/state"
+)
+
+const (
+	// HandleSetAutoCompound is the receipt log topic of setting the auto-compound option of a bucket
+	HandleSetAutoCompound = "setAutoCompound"
+
+	_setAutoCompoundABI = `[
+		{
+			"inputs": [
+				{"internalType": "uint64", "name": "bucketIndex", "type": "uint64"},
+				{"internalType": "bool", "name": "enabled", "type": "bool"}
+			],
+			"name": "setAutoCompound",
+			"outputs": [],
+			"stateMutability": "nonpayable",
+			"type": "function"
+		}
+	]`
+)
+
+var (
+	// AutoCompoundAddr is the address receiving the executions which opt the buckets in or out of compounding the
+	// epoch rewards of their owners automatically
+	AutoCompoundAddr = protocol.HashStringToAddress("stakeAutoCompound")
+
+	_setAutoCompoundMethod abi.Method
+)
+
+func init() {
+	compoundABI, err := abi.JSON(strings.NewReader(_setAutoCompoundABI))
+	if err != nil {
+		panic(err)
+	}
+	_setAutoCompoundMethod = compoundABI.Methods["setAutoCompound"]
+}
+
+// AutoCompoundABI returns the ABI of the auto-compound address as a system contract
+func AutoCompoundABI() string {
+	return abiregistry.MustJoinABI(_setAutoCompoundABI)
+}
+
+// PackSetAutoCompound packs the call data of setting the auto-compound option of the bucket
+func PackSetAutoCompound(bucketIndex uint64, enabled bool) ([]byte, error) {
+	args, err := _setAutoCompoundMethod.Inputs.Pack(bucketIndex, enabled)
+	if err != nil {
+		return nil, err
+	}
+	return append(_setAutoCompoundMethod.ID, args...), nil
+}
+
+func unpackSetAutoCompound(data []byte) (uint64, bool, error) {
+	if len(data) < 4 || !bytes.Equal(data[:4], _setAutoCompoundMethod.ID) {
+		return 0, false, errors.New("invalid set auto compound call data")
+	}
+	args, err := _setAutoCompoundMethod.Inputs.Unpack(data[4:])
+	if err != nil {
+		return 0, false, errors.Wrap(err, "failed to unpack set auto compound call data")
+	}
+	return args[0].(uint64), args[1].(bool), nil
+}
+
+func isSetAutoCompound(ctx context.Context, elp action.Envelope) (*action.Execution, bool) {
+	exec, ok := elp.Action().(*action.Execution)
+	if !ok || exec.Contract() != AutoCompoundAddr.String() {
+		return nil, false
+	}
+	return exec, protocol.MustGetFeatureCtx(ctx).EnableRewardAutoCompound
+}
+
+func (p *Protocol) validateSetAutoCompound(exec *action.Execution) error {
+	if exec.Amount().Sign() != 0 {
+		return errors.Wrap(action.ErrInvalidAct, "set auto compound cannot transfer value")
+	}
+	if _, _, err := unpackSetAutoCompound(exec.Data()); err != nil {
+		return errors.Wrap(action.ErrInvalidAct, err.Error())
+	}
+	return nil
+}
+
+// handleSetAutoCompound opts the bucket of the caller in or out of compounding the epoch rewards. The rewards of an
+// owner are compounded into one bucket only, so opting in a bucket opts out the other buckets of the owner
+func (p *Protocol) handleSetAutoCompound(ctx context.Context, exec *action.Execution, csm CandidateStateManager) (*receiptLog, error) {
+	actionCtx := protocol.MustGetActionCtx(ctx)
+	featureCtx := protocol.MustGetFeatureCtx(ctx)
+	log := newReceiptLog(p.addr.String(), HandleSetAutoCompound, featureCtx.NewStakingReceiptFormat)
+
+	index, enabled, err := unpackSetAutoCompound(exec.Data())
+	if err != nil {
+		return log, &handleError{
+			err:           err,
+			failureStatus: iotextypes.ReceiptStatus_Failure,
+		}
+	}
+	bucket, fetchErr := p.fetchBucketAndValidate(featureCtx, csm, actionCtx.Caller, index, true, true)
+	if fetchErr != nil {
+		return log, fetchErr
+	}
+	log.AddTopics(byteutil.Uint64ToBytesBigEndian(bucket.Index), bucket.Owner.Bytes(), bucket.Candidate.Bytes())
+	if !enabled {
+		if err := delAutoCompound(csm.SM(), index); err != nil {
+			return log, err
+		}
+		log.SetData([]byte{0})
+		return log, nil
+	}
+	if !isAutoCompoundEligible(bucket) {
+		return log, &handleError{
+			err:           errors.New("auto compound is only allowed on staked auto-stake bucket"),
+			failureStatus: iotextypes.ReceiptStatus_ErrInvalidBucketType,
+		}
+	}
+	indices, _, err := newCandidateStateReader(csm.SM()).voterBucketIndices(bucket.Owner)
+	switch errors.Cause(err) {
+	case nil:
+		for _, i := range *indices {
+			if i == index {
+				continue
+			}
+			if err := delAutoCompound(csm.SM(), i); err != nil {
+				return log, err
+			}
+		}
+	case state.ErrStateNotExist:
+	default:
+		return log, err
+	}
+	if err := putAutoCompound(csm.SM(), index, &autoCompound{}); err != nil {
+		return log, err
+	}
+	log.SetData([]byte{1})
+	return log, nil
+}
+
+// AutoCompoundBucket returns the bucket of the owner opted in to compound the epoch rewards, nil if there is none.
+// If the owner has several, e.g., after a bucket is transferred to it, the earliest one is taken
+func (p *Protocol) AutoCompoundBucket(ctx context.Context, sm protocol.StateManager, owner address.Address) (*VoteBucket, error) {
+	csm, err := NewCandidateStateManager(sm)
+	if err != nil {
+		return nil, err
+	}
+	indices, _, err := newCandidateStateReader(sm).voterBucketIndices(owner)
+	switch errors.Cause(err) {
+	case nil:
+	case state.ErrStateNotExist:
+		return nil, nil
+	default:
+		return nil, err
+	}
+	for _, i := range *indices {
+		if _, err := getAutoCompound(sm, i); err != nil {
+			if errors.Cause(err) == state.ErrStateNotExist {
+				continue
+			}
+			return nil, err
+		}
+		bucket, err := csm.getBucket(i)
+		if err != nil {
+			return nil, err
+		}
+		if !isAutoCompoundEligible(bucket) || csm.GetByIdentifier(bucket.Candidate) == nil {
+			continue
+		}
+		return bucket, nil
+	}
+	return nil, nil
+}
+
+// CompoundReward deposits the reward, which has been claimed from the rewarding fund, into the auto-compound bucket
+func (p *Protocol) CompoundReward(ctx context.Context, sm protocol.StateManager, bucket *VoteBucket, amount *big.Int) error {
+	csm, err := NewCandidateStateManager(sm)
+	if err != nil {
+		return err
+	}
+	view, err := sm.ReadView(_protocolID)
+	if err != nil {
+		return err
+	}
+	snapshot := view.Snapshot()
+	candidate := csm.GetByIdentifier(bucket.Candidate)
+	if candidate == nil {
+		err = errCandNotExist
+	} else {
+		err = p.depositToBucket(protocol.MustGetFeatureCtx(ctx), csm, bucket, candidate, amount)
+	}
+	if err != nil {
+		if rerr := view.Revert(snapshot); rerr != nil {
+			return errors.Wrap(rerr, "failed to revert view")
+		}
+		return errors.Wrapf(err, "failed to compound reward into bucket %d", bucket.Index)
+	}
+	return nil
+}
+
+// RecordCompoundFailure records on the auto-compound bucket the height the reward failed to compound into it
+func (p *Protocol) RecordCompoundFailure(ctx context.Context, sm protocol.StateManager, bucketIndex uint64) error {
+	return putAutoCompound(sm, bucketIndex, &autoCompound{
+		failedHeight: protocol.MustGetBlockCtx(ctx).BlockHeight,
+	})
+}
+
+func isAutoCompoundEligible(bucket *VoteBucket) bool {
+	return bucket.AutoStake && !bucket.isUnstaked()
+}
+
+// autoCompound is the auto-compound option of a bucket
+type autoCompound struct {
+	// failedHeight is the last height the reward failed to compound into the bucket, 0 if never
+	failedHeight uint64
+}
+
+// Serialize serializes the auto-compound option into bytes
+func (ac *autoCompound) Serialize() ([]byte, error) {
+	return byteutil.Uint64ToBytesBigEndian(ac.failedHeight), nil
+}
+
+// Deserialize deserializes bytes into the auto-compound option
+func (ac *autoCompound) Deserialize(data []byte) error {
+	if len(data) != 8 {
+		return errors.Errorf("invalid auto compound data length %d", len(data))
+	}
+	ac.failedHeight = byteutil.BytesToUint64BigEndian(data)
+	return nil
+}
+
+func autoCompoundKey(bucketIndex uint64) []byte {
+	key := []byte{_autoCompound}
+	return append(key, byteutil.Uint64ToBytesBigEndian(bucketIndex)...)
+}
+
+func getAutoCompound(sr protocol.StateReader, bucketIndex uint64) (*autoCompound, error) {
+	var ac autoCompound
+	if _, err := sr.State(&ac, protocol.NamespaceOption(_stakingNameSpace), protocol.KeyOption(autoCompoundKey(bucketIndex))); err != nil {
+		return nil, err
+	}
+	return &ac, nil
+}
+
+func putAutoCompound(sm protocol.StateManager, bucketIndex uint64, ac *autoCompound) error {
+	_, err := sm.PutState(ac, protocol.NamespaceOption(_stakingNameSpace), protocol.KeyOption(autoCompoundKey(bucketIndex)))
+	return err
+}
+
+func delAutoCompound(sm protocol.StateManager, bucketIndex uint64) error {
+	_, err := sm.DelState(protocol.NamespaceOption(_stakingNameSpace), protocol.KeyOption(autoCompoundKey(bucketIndex)))
+	if errors.Cause(err) == state.ErrStateNotExist {
+		return nil
+	}
+	return err
+}
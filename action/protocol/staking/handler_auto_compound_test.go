@@ -0,0 +1,151 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/state"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+func TestSetAutoCompoundCallData(t *testing.T) {
+	r := require.New(t)
+
+	data, err := PackSetAutoCompound(7, true)
+	r.NoError(err)
+	index, enabled, err := unpackSetAutoCompound(data)
+	r.NoError(err)
+	r.EqualValues(7, index)
+	r.True(enabled)
+
+	_, _, err = unpackSetAutoCompound(data[:3])
+	r.Error(err)
+	_, _, err = unpackSetAutoCompound(data[:20])
+	r.Error(err)
+
+	p := &Protocol{}
+	r.NoError(p.validateSetAutoCompound(action.NewExecution(AutoCompoundAddr.String(), big.NewInt(0), data)))
+	err = p.validateSetAutoCompound(action.NewExecution(AutoCompoundAddr.String(), big.NewInt(1), data))
+	r.Equal(action.ErrInvalidAct, errors.Cause(err))
+	err = p.validateSetAutoCompound(action.NewExecution(AutoCompoundAddr.String(), big.NewInt(0), []byte{1, 2, 3, 4}))
+	r.Equal(action.ErrInvalidAct, errors.Cause(err))
+}
+
+func TestProtocol_HandleSetAutoCompound(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm, p, candidate, _ := initAll(t, ctrl)
+	owner := identityset.Address(1)
+	// the buckets 0 and 1 are auto-stake, while the bucket 2 is not
+	for i, autoStake := range []bool{true, true, false} {
+		initCreateStake(t, sm, owner, 1000, big.NewInt(0), 10000, uint64(i+1), 1, time.Now(), 10000, p, candidate, "100000000000000000000", autoStake)
+	}
+	r.NoError(setupAccount(sm, identityset.Address(2), 1000))
+
+	g := genesis.TestDefault()
+	g.ToBeEnabledBlockHeight = 1
+	nonces := map[string]uint64{owner.String(): 3}
+	setAutoCompound := func(caller address.Address, index uint64, enabled bool) *action.Receipt {
+		data, err := PackSetAutoCompound(index, enabled)
+		r.NoError(err)
+		nonces[caller.String()]++
+		nonce := nonces[caller.String()]
+		elp := builder.SetNonce(nonce).SetGasLimit(100000).SetGasPrice(big.NewInt(0)).
+			SetAction(action.NewExecution(AutoCompoundAddr.String(), big.NewInt(0), data)).Build()
+		ctx := protocol.WithActionCtx(context.Background(), protocol.ActionCtx{
+			Caller:   caller,
+			GasPrice: big.NewInt(0),
+			Nonce:    nonce,
+		})
+		ctx = protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+			BlockHeight:    2,
+			BlockTimeStamp: time.Now(),
+			GasLimit:       10000000,
+		})
+		ctx = protocol.WithBlockchainCtx(ctx, protocol.BlockchainCtx{Tip: protocol.TipInfo{Height: 1}})
+		ctx = protocol.WithFeatureCtx(protocol.WithFeatureWithHeightCtx(genesis.WithGenesisContext(ctx, g)))
+		r.NoError(p.Validate(ctx, elp, sm))
+		receipt, err := p.Handle(ctx, elp, sm)
+		r.NoError(err)
+		r.NotNil(receipt)
+		return receipt
+	}
+	ctx := protocol.WithFeatureCtx(protocol.WithBlockCtx(genesis.WithGenesisContext(context.Background(), g), protocol.BlockCtx{BlockHeight: 2}))
+	autoCompoundBucket := func() *VoteBucket {
+		bucket, err := p.AutoCompoundBucket(ctx, sm, owner)
+		r.NoError(err)
+		return bucket
+	}
+	hasAutoCompound := func(index uint64) bool {
+		_, err := getAutoCompound(sm, index)
+		if errors.Cause(err) == state.ErrStateNotExist {
+			return false
+		}
+		r.NoError(err)
+		return true
+	}
+
+	receipt := setAutoCompound(owner, 0, true)
+	r.EqualValues(iotextypes.ReceiptStatus_Success, receipt.Status)
+	r.Len(receipt.Logs(), 1)
+	r.EqualValues(0, autoCompoundBucket().Index)
+	// an owner compounds into one bucket only
+	r.EqualValues(iotextypes.ReceiptStatus_Success, setAutoCompound(owner, 1, true).Status)
+	r.EqualValues(1, autoCompoundBucket().Index)
+	r.False(hasAutoCompound(0))
+	r.True(hasAutoCompound(1))
+	// the bucket is not auto-stake
+	r.EqualValues(iotextypes.ReceiptStatus_ErrInvalidBucketType, setAutoCompound(owner, 2, true).Status)
+	// the caller is not the owner
+	r.EqualValues(iotextypes.ReceiptStatus_ErrUnauthorizedOperator, setAutoCompound(identityset.Address(2), 1, false).Status)
+	// the bucket does not exist
+	r.EqualValues(iotextypes.ReceiptStatus_ErrInvalidBucketIndex, setAutoCompound(owner, 10, true).Status)
+	r.EqualValues(1, autoCompoundBucket().Index)
+	bucket, err := p.AutoCompoundBucket(ctx, sm, identityset.Address(2))
+	r.NoError(err)
+	r.Nil(bucket)
+
+	// compound the reward into the bucket
+	csm, err := NewCandidateStateManager(sm)
+	r.NoError(err)
+	bucket = autoCompoundBucket()
+	votes := new(big.Int).Set(csm.GetByIdentifier(bucket.Candidate).Votes)
+	reward := big.NewInt(1000)
+	r.NoError(p.CompoundReward(ctx, sm, bucket, reward))
+	csm, err = NewCandidateStateManager(sm)
+	r.NoError(err)
+	compounded, err := csm.getBucket(1)
+	r.NoError(err)
+	r.Equal("100000000000000001000", compounded.StakedAmount.String())
+	r.Equal(1, csm.GetByIdentifier(bucket.Candidate).Votes.Cmp(votes))
+
+	// the failure is recorded on the bucket, which stays opted in
+	r.NoError(p.RecordCompoundFailure(ctx, sm, 1))
+	ac, err := getAutoCompound(sm, 1)
+	r.NoError(err)
+	r.EqualValues(2, ac.failedHeight)
+	r.EqualValues(1, autoCompoundBucket().Index)
+	ac, err = getAutoCompound(sm, 0)
+	r.ErrorIs(err, state.ErrStateNotExist)
+	r.Nil(ac)
+
+	r.EqualValues(iotextypes.ReceiptStatus_Success, setAutoCompound(owner, 1, false).Status)
+	r.Nil(autoCompoundBucket())
+	r.False(hasAutoCompound(1))
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/abiregistry"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/v2/crypto/bls"
+)
+
+const (
+	// HandleRegisterBLSKey is the receipt log topic of registering the BLS key of a candidate
+	HandleRegisterBLSKey = "registerBLSKey"
+
+	_registerBLSKeyABI = `[
+		{
+			"inputs": [
+				{"internalType": "bytes", "name": "publicKey", "type": "bytes"},
+				{"internalType": "bytes", "name": "proof", "type": "bytes"}
+			],
+			"name": "registerBLSKey",
+			"outputs": [],
+			"stateMutability": "nonpayable",
+			"type": "function"
+		}
+	]`
+)
+
+var (
+	// BLSKeyAddr is the address receiving the executions which register the BLS keys of the candidates, with which
+	// the commit endorsements of the delegates are aggregated into one signature in the block footer
+	BLSKeyAddr = protocol.HashStringToAddress("stakeBLSKey")
+
+	_registerBLSKeyMethod abi.Method
+)
+
+func init() {
+	blsKeyABI, err := abi.JSON(strings.NewReader(_registerBLSKeyABI))
+	if err != nil {
+		panic(err)
+	}
+	_registerBLSKeyMethod = blsKeyABI.Methods["registerBLSKey"]
+}
+
+// BLSKeyABI returns the ABI of the BLS key address as a system contract
+func BLSKeyABI() string {
+	return abiregistry.MustJoinABI(_registerBLSKeyABI)
+}
+
+// PackRegisterBLSKey packs the call data of registering the BLS public key with its proof of possession
+func PackRegisterBLSKey(publicKey, proof []byte) ([]byte, error) {
+	args, err := _registerBLSKeyMethod.Inputs.Pack(publicKey, proof)
+	if err != nil {
+		return nil, err
+	}
+	return append(_registerBLSKeyMethod.ID, args...), nil
+}
+
+// unpackRegisterBLSKey unpacks the call data, and verifies the proof of possession of the BLS key, which prevents a
+// candidate from registering a rogue key to forge the aggregate signatures
+func unpackRegisterBLSKey(data []byte) (*bls.PublicKey, error) {
+	if len(data) < 4 || !bytes.Equal(data[:4], _registerBLSKeyMethod.ID) {
+		return nil, errors.New("invalid register BLS key call data")
+	}
+	args, err := _registerBLSKeyMethod.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unpack register BLS key call data")
+	}
+	pk, err := bls.BytesToPublicKey(args[0].([]byte))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid BLS public key")
+	}
+	if !pk.VerifyProofOfPossession(args[1].([]byte)) {
+		return nil, errors.New("invalid BLS proof of possession")
+	}
+	return pk, nil
+}
+
+func isRegisterBLSKey(ctx context.Context, elp action.Envelope) (*action.Execution, bool) {
+	exec, ok := elp.Action().(*action.Execution)
+	if !ok || exec.Contract() != BLSKeyAddr.String() {
+		return nil, false
+	}
+	return exec, protocol.MustGetFeatureCtx(ctx).EnableBLSKeyRegistration
+}
+
+func (p *Protocol) validateRegisterBLSKey(exec *action.Execution) error {
+	if exec.Amount().Sign() != 0 {
+		return errors.Wrap(action.ErrInvalidAct, "register BLS key cannot transfer value")
+	}
+	if _, err := unpackRegisterBLSKey(exec.Data()); err != nil {
+		return errors.Wrap(action.ErrInvalidAct, err.Error())
+	}
+	return nil
+}
+
+// handleRegisterBLSKey registers the BLS key of the candidate owned by the caller. The key is honored from the next
+// epoch, so that the endorsers of the current epoch are not changed, while the replaced key is honored until then
+func (p *Protocol) handleRegisterBLSKey(ctx context.Context, exec *action.Execution, csm CandidateStateManager) (*receiptLog, error) {
+	actCtx := protocol.MustGetActionCtx(ctx)
+	featureCtx := protocol.MustGetFeatureCtx(ctx)
+	log := newReceiptLog(p.addr.String(), HandleRegisterBLSKey, featureCtx.NewStakingReceiptFormat)
+
+	_, fetchErr := fetchCaller(ctx, csm, big.NewInt(0))
+	if fetchErr != nil {
+		return log, fetchErr
+	}
+	pk, err := unpackRegisterBLSKey(exec.Data())
+	if err != nil {
+		return log, &handleError{
+			err:           err,
+			failureStatus: iotextypes.ReceiptStatus_Failure,
+		}
+	}
+	// only owner can register the BLS key
+	c := csm.GetByOwner(actCtx.Caller)
+	if c == nil {
+		return log, errCandNotExist
+	}
+	key := pk.Bytes()
+	for _, cand := range csm.DirtyView().candCenter.All() {
+		if address.Equal(cand.GetIdentifier(), c.GetIdentifier()) {
+			continue
+		}
+		if bytes.Equal(cand.BLSPublicKey, key) || bytes.Equal(cand.PreviousBLSPublicKey, key) {
+			return log, &handleError{
+				err:           errors.New("BLS public key is registered by another candidate"),
+				failureStatus: iotextypes.ReceiptStatus_ErrCandidateConflict,
+			}
+		}
+	}
+	rp := rolldpos.FindProtocol(protocol.MustGetRegistry(ctx))
+	if rp == nil {
+		return log, &handleError{
+			err:           errors.New("rolldpos protocol is not registered"),
+			failureStatus: iotextypes.ReceiptStatus_Failure,
+		}
+	}
+	epoch := rp.GetEpochNum(protocol.MustGetBlockCtx(ctx).BlockHeight)
+	c.PreviousBLSPublicKey = c.blsPublicKey(epoch)
+	c.BLSPublicKey = key
+	c.BLSKeyEpoch = epoch + 1
+	log.AddTopics(c.GetIdentifier().Bytes())
+
+	if err := csm.Upsert(c); err != nil {
+		return log, csmErrorToHandleError(c.GetIdentifier().String(), err)
+	}
+	height, _ := csm.SM().Height()
+	if p.needToWriteCandsMap(ctx, height) {
+		csm.DirtyView().candCenter.base.recordOwner(c)
+	}
+
+	log.AddAddress(actCtx.Caller)
+	log.SetData(key)
+	return log, nil
+}
+
+// BLSEndorsers returns the addresses of the BLS keys honored in the epoch, mapped to the operators of the candidates
+// registered them, so that the commit endorsements signed with the BLS keys are honored for the operators
+func (p *Protocol) BLSEndorsers(ctx context.Context, sr protocol.StateReader, epoch uint64) (map[string]string, error) {
+	c, err := ConstructBaseView(sr)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get BLSEndorsers")
+	}
+	endorsers := make(map[string]string)
+	for _, cand := range c.AllCandidates() {
+		key := cand.blsPublicKey(epoch)
+		if len(key) == 0 {
+			continue
+		}
+		pk, err := bls.BytesToPublicKey(key)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid BLS public key of candidate %s", cand.GetIdentifier().String())
+		}
+		endorsers[pk.Address().String()] = cand.Operator.String()
+	}
+	return endorsers, nil
+}
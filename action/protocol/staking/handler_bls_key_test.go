@@ -0,0 +1,178 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/mohae/deepcopy"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/crypto/bls"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+	"github.com/iotexproject/iotex-core/v2/testutil/testdb"
+)
+
+func newBLSKey(t *testing.T) (*bls.PrivateKey, []byte) {
+	sk, err := bls.GenerateKey()
+	require.NoError(t, err)
+	proof, err := sk.ProofOfPossession()
+	require.NoError(t, err)
+	data, err := PackRegisterBLSKey(sk.BLSPublicKey().Bytes(), proof)
+	require.NoError(t, err)
+	return sk, data
+}
+
+func TestRegisterBLSKeyCallData(t *testing.T) {
+	r := require.New(t)
+
+	sk, data := newBLSKey(t)
+	pk, err := unpackRegisterBLSKey(data)
+	r.NoError(err)
+	r.True(sk.BLSPublicKey().Equal(pk))
+
+	_, err = unpackRegisterBLSKey(data[:3])
+	r.Error(err)
+	_, err = unpackRegisterBLSKey(data[:20])
+	r.Error(err)
+	// the proof of possession of another key is rejected
+	sk2, err := bls.GenerateKey()
+	r.NoError(err)
+	proof, err := sk2.ProofOfPossession()
+	r.NoError(err)
+	invalid, err := PackRegisterBLSKey(sk.BLSPublicKey().Bytes(), proof)
+	r.NoError(err)
+	_, err = unpackRegisterBLSKey(invalid)
+	r.ErrorContains(err, "invalid BLS proof of possession")
+
+	p := &Protocol{}
+	r.NoError(p.validateRegisterBLSKey(action.NewExecution(BLSKeyAddr.String(), big.NewInt(0), data)))
+	err = p.validateRegisterBLSKey(action.NewExecution(BLSKeyAddr.String(), big.NewInt(1), data))
+	r.Equal(action.ErrInvalidAct, errors.Cause(err))
+	err = p.validateRegisterBLSKey(action.NewExecution(BLSKeyAddr.String(), big.NewInt(0), invalid))
+	r.Equal(action.ErrInvalidAct, errors.Cause(err))
+}
+
+func TestProtocol_RegisterBLSKey(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm := testdb.NewMockStateManager(ctrl)
+	v, _, err := CreateBaseView(sm, false)
+	require.NoError(err)
+	sm.WriteView(_protocolID, v)
+	csm, err := NewCandidateStateManager(sm)
+	require.NoError(err)
+	g := deepcopy.Copy(genesis.TestDefault()).(genesis.Genesis)
+	g.ToBeEnabledBlockHeight = 1
+	p, err := NewProtocol(HelperCtx{
+		DepositGas:    depositGas,
+		BlockInterval: getBlockInterval,
+	}, &BuilderConfig{
+		Staking:                  g.Staking,
+		PersistStakingPatchBlock: math.MaxUint64,
+		Revise: ReviseConfig{
+			VoteWeight: g.Staking.VoteWeightCalConsts,
+		},
+	}, nil, nil, nil)
+	require.NoError(err)
+	for i, owner := range []address.Address{identityset.Address(1), identityset.Address(2)} {
+		require.NoError(csm.Upsert(&Candidate{
+			Owner:              owner,
+			Operator:           identityset.Address(7 + i),
+			Reward:             owner,
+			Name:               "test" + owner.String()[:4],
+			Votes:              big.NewInt(0),
+			SelfStakeBucketIdx: candidateNoSelfStakeBucketIndex,
+			SelfStake:          big.NewInt(0),
+		}))
+	}
+	require.NoError(csm.Commit(context.Background()))
+	for _, caller := range []address.Address{identityset.Address(1), identityset.Address(2), identityset.Address(3)} {
+		require.NoError(setupAccount(sm, caller, 1300000))
+	}
+
+	// epochs of 12 blocks
+	reg := protocol.NewRegistry()
+	require.NoError(reg.Register("rolldpos", rolldpos.NewProtocol(23, 4, 3)))
+	register := func(height uint64, caller address.Address, data []byte) error {
+		ctx := protocol.WithRegistry(context.Background(), reg)
+		ctx = protocol.WithActionCtx(ctx, protocol.ActionCtx{
+			Caller:   caller,
+			GasPrice: big.NewInt(0),
+		})
+		ctx = protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+			BlockHeight:    height,
+			BlockTimeStamp: timeBlock,
+		})
+		ctx = protocol.WithFeatureCtx(protocol.WithFeatureWithHeightCtx(genesis.WithGenesisContext(ctx, g)))
+		csm, err := NewCandidateStateManager(sm)
+		require.NoError(err)
+		_, err = p.handleRegisterBLSKey(ctx, action.NewExecution(BLSKeyAddr.String(), big.NewInt(0), data), csm)
+		if err == nil {
+			require.NoError(csm.Commit(ctx))
+		}
+		return err
+	}
+	endorsers := func(epoch uint64) map[string]string {
+		ret, err := p.BLSEndorsers(context.Background(), sm, epoch)
+		require.NoError(err)
+		return ret
+	}
+
+	// only the owner of a candidate can register the BLS key
+	sk1, data1 := newBLSKey(t)
+	require.Equal(errCandNotExist, register(5, identityset.Address(3), data1))
+	// the key is honored from the next epoch
+	require.NoError(register(5, identityset.Address(1), data1))
+	csm, err = NewCandidateStateManager(sm)
+	require.NoError(err)
+	c := csm.GetByOwner(identityset.Address(1))
+	require.Equal(sk1.BLSPublicKey().Bytes(), c.BLSPublicKey)
+	require.Nil(c.PreviousBLSPublicKey)
+	require.Equal(uint64(2), c.BLSKeyEpoch)
+	require.Empty(endorsers(1))
+	require.Equal(map[string]string{
+		sk1.PublicKey().Address().String(): identityset.Address(7).String(),
+	}, endorsers(2))
+
+	// the key of another candidate cannot be registered
+	err = register(6, identityset.Address(2), data1)
+	require.Error(err)
+	require.Equal(uint64(iotextypes.ReceiptStatus_ErrCandidateConflict), err.(*handleError).ReceiptStatus())
+
+	// the replaced key is honored until the new key is
+	sk2, data2 := newBLSKey(t)
+	require.NoError(register(14, identityset.Address(1), data2))
+	csm, err = NewCandidateStateManager(sm)
+	require.NoError(err)
+	c = csm.GetByOwner(identityset.Address(1))
+	require.Equal(sk1.BLSPublicKey().Bytes(), c.PreviousBLSPublicKey)
+	require.Equal(uint64(3), c.BLSKeyEpoch)
+	require.Equal(map[string]string{
+		sk1.PublicKey().Address().String(): identityset.Address(7).String(),
+	}, endorsers(2))
+	require.Equal(map[string]string{
+		sk2.PublicKey().Address().String(): identityset.Address(7).String(),
+	}, endorsers(3))
+
+	// the BLS keys are kept in the candidate
+	data, err := c.Serialize()
+	require.NoError(err)
+	d := &Candidate{}
+	require.NoError(d.Deserialize(data))
+	require.True(c.Equal(d))
+}
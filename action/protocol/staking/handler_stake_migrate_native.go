@@ -0,0 +1,305 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/abiregistry"
+	accountutil "github.com/iotexproject/iotex-core/v2/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+)
+
+const (
+	// HandleStakeMigrateToNative is the receipt log topic of migrating a contract bucket into a native bucket
+	HandleStakeMigrateToNative = "stakeMigrateToNative"
+
+	_migrateToNativeABI = `[
+		{
+			"inputs": [
+				{"internalType": "address", "name": "contractAddress", "type": "address"},
+				{"internalType": "uint256", "name": "tokenId", "type": "uint256"}
+			],
+			"name": "migrateToNative",
+			"outputs": [],
+			"stateMutability": "nonpayable",
+			"type": "function"
+		}
+	]`
+)
+
+var (
+	// StakeMigrationAddr is the address receiving the executions which migrate the contract staking buckets into
+	// native buckets. The migrated tokens are transferred to this address, which never moves them again, so the
+	// contract staking indexers delete the buckets transferred to it
+	StakeMigrationAddr = protocol.HashStringToAddress("stakeMigration")
+
+	_migrateToNativeMethod abi.Method
+)
+
+func init() {
+	migrateABI, err := abi.JSON(strings.NewReader(_migrateToNativeABI))
+	if err != nil {
+		panic(err)
+	}
+	_migrateToNativeMethod = migrateABI.Methods["migrateToNative"]
+}
+
+// StakeMigrationABI returns the ABI of the stake migration address as a system contract
+func StakeMigrationABI() string {
+	return abiregistry.MustJoinABI(_migrateToNativeABI)
+}
+
+// PackMigrateToNative packs the call data of migrating the contract bucket into a native bucket
+func PackMigrateToNative(contractAddress address.Address, tokenID uint64) ([]byte, error) {
+	args, err := _migrateToNativeMethod.Inputs.Pack(common.BytesToAddress(contractAddress.Bytes()), new(big.Int).SetUint64(tokenID))
+	if err != nil {
+		return nil, err
+	}
+	return append(_migrateToNativeMethod.ID, args...), nil
+}
+
+func unpackMigrateToNative(data []byte) (address.Address, uint64, error) {
+	if len(data) < 4 || !bytes.Equal(data[:4], _migrateToNativeMethod.ID) {
+		return nil, 0, errors.New("invalid migrate to native call data")
+	}
+	args, err := _migrateToNativeMethod.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to unpack migrate to native call data")
+	}
+	contractAddress, err := address.FromBytes(args[0].(common.Address).Bytes())
+	if err != nil {
+		return nil, 0, err
+	}
+	tokenID := args[1].(*big.Int)
+	if !tokenID.IsUint64() {
+		return nil, 0, errors.New("invalid token id")
+	}
+	return contractAddress, tokenID.Uint64(), nil
+}
+
+func isMigrateToNative(ctx context.Context, elp action.Envelope) (*action.Execution, bool) {
+	exec, ok := elp.Action().(*action.Execution)
+	if !ok || exec.Contract() != StakeMigrationAddr.String() {
+		return nil, false
+	}
+	return exec, protocol.MustGetFeatureCtx(ctx).MigrateContractStake
+}
+
+func (p *Protocol) validateMigrateToNative(exec *action.Execution) error {
+	if exec.Amount().Sign() != 0 {
+		return errors.Wrap(action.ErrInvalidAct, "migrate to native cannot transfer value")
+	}
+	if _, _, err := unpackMigrateToNative(exec.Data()); err != nil {
+		return errors.Wrap(action.ErrInvalidAct, err.Error())
+	}
+	return nil
+}
+
+// handleStakeMigrateToNative converts a locked bucket of the migrate staking contract into a native bucket with the
+// same amount, duration and candidate, so that the votes of the candidate are kept. The token is transferred to
+// StakeMigrationAddr by calling the contract on behalf of the owner, and the staked amount is moved from the contract
+// to the native bucket pool
+func (p *Protocol) handleStakeMigrateToNative(ctx context.Context, elp action.Envelope, exec *action.Execution, csm CandidateStateManager) ([]*action.Log, []*action.TransactionLog, uint64, uint64, error) {
+	var (
+		actionCtx       = protocol.MustGetActionCtx(ctx)
+		gasConsumed     = actionCtx.IntrinsicGas
+		gasToBeDeducted = gasConsumed
+	)
+	contractAddress, tokenID, err := unpackMigrateToNative(exec.Data())
+	if err != nil {
+		return nil, nil, gasConsumed, gasToBeDeducted, err
+	}
+	if _, rerr := fetchCaller(ctx, csm, big.NewInt(0)); rerr != nil {
+		return nil, nil, gasConsumed, gasToBeDeducted, errors.Wrap(rerr, "failed to fetch caller")
+	}
+	bucket, err := p.fetchContractBucket(ctx, csm, contractAddress, tokenID)
+	if err != nil {
+		return nil, nil, gasConsumed, gasToBeDeducted, err
+	}
+	candidate := csm.GetByIdentifier(bucket.Candidate)
+	if candidate == nil {
+		return nil, nil, gasConsumed, gasToBeDeducted, errCandNotExist
+	}
+	duration, err := validateMigrateToNative(ctx, bucket)
+	if err != nil {
+		return nil, nil, gasConsumed, gasToBeDeducted, err
+	}
+	data, err := StakingContractABI.Pack(
+		"transferFrom",
+		common.BytesToAddress(actionCtx.Caller.Bytes()),
+		common.BytesToAddress(StakeMigrationAddr.Bytes()),
+		new(big.Int).SetUint64(tokenID),
+	)
+	if err != nil {
+		return nil, nil, gasConsumed, gasToBeDeducted, errors.Wrap(err, "failed to pack data for contract call")
+	}
+	transfer := (&action.EnvelopeBuilder{}).SetAction(action.NewExecution(contractAddress.String(), big.NewInt(0), data)).
+		SetNonce(elp.Nonce()).SetGasLimit(elp.Gas()).SetGasPrice(elp.GasPrice()).Build()
+
+	// snapshot for sm in case of failure of hybrid protocol handling
+	si := csm.SM().Snapshot()
+	revertSM := func() {
+		if revertErr := csm.SM().Revert(si); revertErr != nil {
+			log.L().Panic("failed to revert state", zap.Error(revertErr))
+		}
+	}
+	// call staking contract to hand the token over
+	excReceipt, err := p.createNFTBucket(ctx, transfer, csm.SM())
+	if err != nil {
+		revertSM()
+		return nil, nil, gasConsumed, gasToBeDeducted, errors.Wrap(err, "failed to handle execution action")
+	}
+	gasConsumed += excReceipt.GasConsumed
+	if excReceipt.Status != uint64(iotextypes.ReceiptStatus_Success) {
+		revertSM()
+		gasToBeDeducted = gasConsumed
+		return nil, nil, gasConsumed, gasToBeDeducted, &handleError{
+			err:           errors.Errorf("staking contract failure: %s", excReceipt.ExecutionRevertMsg()),
+			failureStatus: iotextypes.ReceiptStatus(excReceipt.Status),
+		}
+	}
+	actLog, tLog, err := p.createNativeBucket(ctx, bucket, duration, candidate, csm)
+	if err != nil {
+		revertSM()
+		return nil, nil, gasConsumed, gasToBeDeducted, err
+	}
+	// add sub-receipts logs
+	actLogs := append([]*action.Log{}, excReceipt.Logs()...)
+	transferLogs := append([]*action.TransactionLog{}, excReceipt.TransactionLogs()...)
+	actLogs = append(actLogs, actLog.Build(ctx, nil))
+	transferLogs = append(transferLogs, tLog)
+	return actLogs, transferLogs, gasConsumed, gasToBeDeducted, nil
+}
+
+func (p *Protocol) fetchContractBucket(ctx context.Context, csm CandidateStateManager, contractAddress address.Address, tokenID uint64) (*VoteBucket, error) {
+	var (
+		featureCtx = protocol.MustGetFeatureCtx(ctx)
+		view       ContractStakeView
+	)
+	switch contractAddress.String() {
+	case p.config.MigrateContractAddress:
+		if p.contractStakingIndexerV2 != nil && !featureCtx.LimitedStakingContract {
+			view = csm.DirtyView().contractsStake.v2
+		}
+	case p.config.TimestampedMigrateContractAddress:
+		if p.contractStakingIndexerV3 != nil && featureCtx.TimestampedStakingContract {
+			view = csm.DirtyView().contractsStake.v3
+		}
+	}
+	if view == nil {
+		return nil, &handleError{
+			err:           errors.Errorf("cannot migrate bucket of contract %s", contractAddress.String()),
+			failureStatus: iotextypes.ReceiptStatus_ErrInvalidBucketType,
+		}
+	}
+	bkts, err := view.BucketsByIndices([]uint64{tokenID})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get bucket from contract staking view")
+	}
+	if len(bkts) == 0 || bkts[0] == nil {
+		return nil, &handleError{
+			err:           errors.Errorf("bucket %d of contract %s does not exist", tokenID, contractAddress.String()),
+			failureStatus: iotextypes.ReceiptStatus_ErrInvalidBucketIndex,
+		}
+	}
+	return bkts[0], nil
+}
+
+// validateMigrateToNative validates the contract bucket and returns the staked duration in days
+func validateMigrateToNative(ctx context.Context, bucket *VoteBucket) (uint32, error) {
+	if err := validateBucketOwner(bucket, protocol.MustGetActionCtx(ctx).Caller); err != nil {
+		return 0, err
+	}
+	if !bucket.AutoStake {
+		return 0, &handleError{
+			err:           errors.New("cannot migrate unlocked bucket"),
+			failureStatus: iotextypes.ReceiptStatus_ErrInvalidBucketType,
+		}
+	}
+	if bucket.isUnstaked() {
+		return 0, &handleError{
+			err:           errors.New("cannot migrate unstaked bucket"),
+			failureStatus: iotextypes.ReceiptStatus_ErrInvalidBucketType,
+		}
+	}
+	// native buckets are staked in days, the duration must be kept to keep the votes
+	const day = 24 * time.Hour
+	if bucket.StakedDuration%day != 0 {
+		return 0, &handleError{
+			err:           errors.Errorf("cannot migrate bucket staked for %s, which is not in whole days", bucket.StakedDuration),
+			failureStatus: iotextypes.ReceiptStatus_ErrInvalidBucketType,
+		}
+	}
+	return uint32(bucket.StakedDuration / day), nil
+}
+
+func (p *Protocol) createNativeBucket(ctx context.Context, contractBucket *VoteBucket, duration uint32, cand *Candidate, csm CandidateStateManager) (*receiptLog, *action.TransactionLog, error) {
+	var (
+		actionCtx = protocol.MustGetActionCtx(ctx)
+		blkCtx    = protocol.MustGetBlockCtx(ctx)
+		amount    = contractBucket.StakedAmount
+	)
+	contractAddress, err := address.FromString(contractBucket.ContractAddress)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "invalid contract address %s", contractBucket.ContractAddress)
+	}
+	// move the staked amount from the contract into the bucket pool
+	contract, err := accountutil.LoadAccount(csm.SM(), contractAddress)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to load account %s", contractBucket.ContractAddress)
+	}
+	if err := contract.SubBalance(amount); err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to update the balance of contract %s", contractBucket.ContractAddress)
+	}
+	if err := accountutil.StoreAccount(csm.SM(), contractAddress, contract); err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to store account %s", contractBucket.ContractAddress)
+	}
+	if err := csm.DebitBucketPool(amount, true); err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to update staking bucket pool %s", err.Error())
+	}
+	bucket := NewVoteBucket(cand.GetIdentifier(), actionCtx.Caller, amount, duration, blkCtx.BlockTimeStamp, true)
+	bucketIdx, err := csm.putBucketAndIndex(bucket)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := cand.AddVote(p.calculateVoteWeight(bucket, false)); err != nil {
+		return nil, nil, &handleError{
+			err:           errors.Wrapf(err, "failed to add vote for candidate %s", cand.GetIdentifier().String()),
+			failureStatus: iotextypes.ReceiptStatus_ErrInvalidBucketAmount,
+		}
+	}
+	if err := csm.Upsert(cand); err != nil {
+		return nil, nil, csmErrorToHandleError(cand.GetIdentifier().String(), err)
+	}
+	// create receipt log
+	actLog := newReceiptLog(p.addr.String(), HandleStakeMigrateToNative, protocol.MustGetFeatureCtx(ctx).NewStakingReceiptFormat)
+	actLog.AddTopics(byteutil.Uint64ToBytesBigEndian(bucketIdx), cand.GetIdentifier().Bytes())
+	actLog.AddAddress(contractAddress)
+	actLog.AddAddress(actionCtx.Caller)
+	actLog.SetData(byteutil.Uint64ToBytesBigEndian(contractBucket.Index))
+	return actLog, &action.TransactionLog{
+		Type:      iotextypes.TransactionLogType_CREATE_BUCKET,
+		Amount:    amount,
+		Sender:    contractBucket.ContractAddress,
+		Recipient: address.StakingBucketPoolAddr,
+	}, nil
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+func TestMigrateToNativeCallData(t *testing.T) {
+	r := require.New(t)
+
+	data, err := PackMigrateToNative(identityset.Address(10), 7)
+	r.NoError(err)
+	contract, tokenID, err := unpackMigrateToNative(data)
+	r.NoError(err)
+	r.Equal(identityset.Address(10).String(), contract.String())
+	r.EqualValues(7, tokenID)
+
+	_, _, err = unpackMigrateToNative(data[:3])
+	r.Error(err)
+	_, _, err = unpackMigrateToNative(data[:20])
+	r.Error(err)
+
+	p := &Protocol{}
+	r.NoError(p.validateMigrateToNative(action.NewExecution(StakeMigrationAddr.String(), big.NewInt(0), data)))
+	err = p.validateMigrateToNative(action.NewExecution(StakeMigrationAddr.String(), big.NewInt(1), data))
+	r.Equal(action.ErrInvalidAct, errors.Cause(err))
+	err = p.validateMigrateToNative(action.NewExecution(StakeMigrationAddr.String(), big.NewInt(0), []byte{1, 2, 3, 4}))
+	r.Equal(action.ErrInvalidAct, errors.Cause(err))
+}
+
+func TestValidateMigrateToNative(t *testing.T) {
+	r := require.New(t)
+
+	ctx := protocol.WithActionCtx(context.Background(), protocol.ActionCtx{Caller: identityset.Address(1)})
+	newBucket := func() *VoteBucket {
+		return &VoteBucket{
+			Owner:            identityset.Address(1),
+			StakedAmount:     big.NewInt(100),
+			StakedDuration:   91 * 24 * time.Hour,
+			AutoStake:        true,
+			StakeStartTime:   time.Unix(100, 0),
+			UnstakeStartTime: time.Unix(0, 0),
+			ContractAddress:  identityset.Address(10).String(),
+			Timestamped:      true,
+		}
+	}
+	duration, err := validateMigrateToNative(ctx, newBucket())
+	r.NoError(err)
+	r.EqualValues(91, duration)
+
+	for _, c := range []struct {
+		name   string
+		update func(*VoteBucket)
+		status iotextypes.ReceiptStatus
+	}{
+		{"not owner", func(b *VoteBucket) { b.Owner = identityset.Address(2) }, iotextypes.ReceiptStatus_ErrUnauthorizedOperator},
+		{"unlocked", func(b *VoteBucket) { b.AutoStake = false }, iotextypes.ReceiptStatus_ErrInvalidBucketType},
+		{"unstaked", func(b *VoteBucket) { b.UnstakeStartTime = time.Unix(1000, 0) }, iotextypes.ReceiptStatus_ErrInvalidBucketType},
+		{"not in days", func(b *VoteBucket) { b.StakedDuration += time.Hour }, iotextypes.ReceiptStatus_ErrInvalidBucketType},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			b := newBucket()
+			c.update(b)
+			_, err := validateMigrateToNative(ctx, b)
+			r.Error(err)
+			r.EqualValues(c.status, err.(ReceiptError).ReceiptStatus())
+		})
+	}
+}
@@ -515,75 +515,83 @@ func (p *Protocol) handleDepositToStake(ctx context.Context, act *action.Deposit
 			failureStatus: iotextypes.ReceiptStatus_ErrInvalidBucketType,
 		}
 	}
+	if err := p.depositToBucket(featureCtx, csm, bucket, candidate, act.Amount()); err != nil {
+		return log, nil, err
+	}
+
+	// update depositor balance
+	if err := depositor.SubBalance(act.Amount()); err != nil {
+		return log, nil, &handleError{
+			err:           errors.Wrapf(err, "failed to update the balance of depositor %s", actionCtx.Caller.String()),
+			failureStatus: iotextypes.ReceiptStatus_ErrNotEnoughBalance,
+		}
+	}
+	// put updated depositor's account state to trie
+	if err := accountutil.StoreAccount(csm.SM(), actionCtx.Caller, depositor); err != nil {
+		return log, nil, errors.Wrapf(err, "failed to store account %s", actionCtx.Caller.String())
+	}
+	log.AddAddress(actionCtx.Caller)
+
+	return log, []*action.TransactionLog{
+		{
+			Type:      iotextypes.TransactionLogType_DEPOSIT_TO_BUCKET,
+			Sender:    actionCtx.Caller.String(),
+			Recipient: address.StakingBucketPoolAddr,
+			Amount:    act.Amount(),
+		},
+	}, nil
+}
+
+// depositToBucket adds the amount to the auto-stake bucket, and updates the votes of the candidate and the bucket pool
+func (p *Protocol) depositToBucket(featureCtx protocol.FeatureCtx, csm CandidateStateManager, bucket *VoteBucket, candidate *Candidate, amount *big.Int) error {
 	selfStake, err := isSelfStakeBucket(featureCtx, csm, bucket)
 	if err != nil {
-		return log, nil, &handleError{
+		return &handleError{
 			err:           err,
 			failureStatus: iotextypes.ReceiptStatus_ErrUnknown,
 		}
 	}
 	prevWeightedVotes := p.calculateVoteWeight(bucket, selfStake)
 	// update bucket
-	bucket.StakedAmount.Add(bucket.StakedAmount, act.Amount())
-	if err := csm.updateBucket(act.BucketIndex(), bucket); err != nil {
-		return log, nil, errors.Wrapf(err, "failed to update bucket for voter %s", bucket.Owner.String())
+	bucket.StakedAmount.Add(bucket.StakedAmount, amount)
+	if err := csm.updateBucket(bucket.Index, bucket); err != nil {
+		return errors.Wrapf(err, "failed to update bucket for voter %s", bucket.Owner.String())
 	}
 
 	// update candidate
 	if err := candidate.SubVote(prevWeightedVotes); err != nil {
-		return log, nil, &handleError{
+		return &handleError{
 			err:           errors.Wrapf(err, "failed to subtract vote for candidate %s", bucket.Candidate.String()),
 			failureStatus: iotextypes.ReceiptStatus_ErrNotEnoughBalance,
 		}
 	}
 	weightedVotes := p.calculateVoteWeight(bucket, selfStake)
 	if err := candidate.AddVote(weightedVotes); err != nil {
-		return log, nil, &handleError{
+		return &handleError{
 			err:           errors.Wrapf(err, "failed to add vote for candidate %s", candidate.GetIdentifier().String()),
 			failureStatus: iotextypes.ReceiptStatus_ErrInvalidBucketAmount,
 		}
 	}
 	if selfStake {
-		if err := candidate.AddSelfStake(act.Amount()); err != nil {
-			return log, nil, &handleError{
+		if err := candidate.AddSelfStake(amount); err != nil {
+			return &handleError{
 				err:           errors.Wrapf(err, "failed to add self stake for candidate %s", candidate.GetIdentifier().String()),
 				failureStatus: iotextypes.ReceiptStatus_ErrInvalidBucketAmount,
 			}
 		}
 	}
 	if err := csm.Upsert(candidate); err != nil {
-		return log, nil, csmErrorToHandleError(candidate.GetIdentifier().String(), err)
+		return csmErrorToHandleError(candidate.GetIdentifier().String(), err)
 	}
 
 	// update bucket pool
-	if err := csm.DebitBucketPool(act.Amount(), false); err != nil {
-		return log, nil, &handleError{
+	if err := csm.DebitBucketPool(amount, false); err != nil {
+		return &handleError{
 			err:           errors.Wrapf(err, "failed to update staking bucket pool %s", err.Error()),
 			failureStatus: iotextypes.ReceiptStatus_ErrWriteAccount,
 		}
 	}
-
-	// update depositor balance
-	if err := depositor.SubBalance(act.Amount()); err != nil {
-		return log, nil, &handleError{
-			err:           errors.Wrapf(err, "failed to update the balance of depositor %s", actionCtx.Caller.String()),
-			failureStatus: iotextypes.ReceiptStatus_ErrNotEnoughBalance,
-		}
-	}
-	// put updated depositor's account state to trie
-	if err := accountutil.StoreAccount(csm.SM(), actionCtx.Caller, depositor); err != nil {
-		return log, nil, errors.Wrapf(err, "failed to store account %s", actionCtx.Caller.String())
-	}
-	log.AddAddress(actionCtx.Caller)
-
-	return log, []*action.TransactionLog{
-		{
-			Type:      iotextypes.TransactionLogType_DEPOSIT_TO_BUCKET,
-			Sender:    actionCtx.Caller.String(),
-			Recipient: address.StakingBucketPoolAddr,
-			Amount:    act.Amount(),
-		},
-	}, nil
+	return nil
 }
 
 func (p *Protocol) handleRestake(ctx context.Context, act *action.Restake, csm CandidateStateManager,
@@ -844,8 +852,15 @@ func (p *Protocol) handleCandidateUpdate(ctx context.Context, act *action.Candid
 		c.Name = act.Name()
 	}
 
-	if act.OperatorAddress() != nil {
-		c.Operator = act.OperatorAddress()
+	if operator := act.OperatorAddress(); operator != nil {
+		if featureCtx.DeferOperatorRotation && !address.Equal(operator, c.Operator) {
+			// the new operator takes effect in a future epoch, to rotate the operator without downtime
+			if err := announceOperatorRotation(ctx, csm, c, operator); err != nil {
+				return log, err
+			}
+		} else {
+			c.Operator = operator
+		}
 	}
 
 	if act.RewardAddress() != nil {
@@ -0,0 +1,124 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"context"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+// _operatorRotationDelay is the number of epochs from the announcement to the epoch when the delegates are elected
+// with the new operator. The new operator takes over the operator at the start of the epoch in between, so that it
+// is elected for the rotation epoch, while the old operator still produces blocks in the epoch in between
+const _operatorRotationDelay = 2
+
+var errOperatorRotationInProgress = &handleError{
+	err:           errors.New("operator rotation is in progress"),
+	failureStatus: iotextypes.ReceiptStatus_ErrCandidateConflict,
+}
+
+// announceOperatorRotation schedules the candidate to rotate to the new operator
+func announceOperatorRotation(ctx context.Context, csm CandidateStateManager, c *Candidate, operator address.Address) error {
+	if c.PendingOperator != nil || c.PreviousOperator != nil {
+		return errOperatorRotationInProgress
+	}
+	if csm.ContainsOperator(operator) || isRotatingOperator(csm, operator) {
+		return &handleError{
+			err:           ErrInvalidOperator,
+			failureStatus: iotextypes.ReceiptStatus_ErrCandidateConflict,
+		}
+	}
+	rp := rolldpos.FindProtocol(protocol.MustGetRegistry(ctx))
+	if rp == nil {
+		return &handleError{
+			err:           errors.New("rolldpos protocol is not registered"),
+			failureStatus: iotextypes.ReceiptStatus_Failure,
+		}
+	}
+	c.PendingOperator = operator
+	c.OperatorRotationEpoch = rp.GetEpochNum(protocol.MustGetBlockCtx(ctx).BlockHeight) + _operatorRotationDelay
+	return nil
+}
+
+// isRotatingOperator checks whether the operator is the pending or previous operator of a candidate
+func isRotatingOperator(csm CandidateStateManager, operator address.Address) bool {
+	for _, c := range csm.DirtyView().candCenter.All() {
+		if address.Equal(c.PendingOperator, operator) || address.Equal(c.PreviousOperator, operator) {
+			return true
+		}
+	}
+	return false
+}
+
+// rotateOperators switches the candidates to the pending operators at the start of the epoch before the rotation
+// epoch, and clears the previous operators once the rotation epoch ends
+func (p *Protocol) rotateOperators(ctx context.Context, sm protocol.StateManager) error {
+	rp := rolldpos.FindProtocol(protocol.MustGetRegistry(ctx))
+	if rp == nil {
+		return nil
+	}
+	height := protocol.MustGetBlockCtx(ctx).BlockHeight
+	epoch := rp.GetEpochNum(height)
+	if rp.GetEpochHeight(epoch) != height {
+		return nil
+	}
+	csm, err := NewCandidateStateManager(sm)
+	if err != nil {
+		return err
+	}
+	for _, c := range csm.DirtyView().candCenter.All() {
+		switch {
+		case c.PendingOperator != nil && epoch+1 >= c.OperatorRotationEpoch:
+			if csm.ContainsOperator(c.PendingOperator) {
+				// the operator is taken by another candidate since the announcement
+				log.L().Warn("operator rotation is canceled",
+					zap.String("candidate", c.GetIdentifier().String()),
+					zap.String("operator", c.PendingOperator.String()))
+				c.PendingOperator, c.OperatorRotationEpoch = nil, 0
+				break
+			}
+			c.PreviousOperator, c.Operator, c.PendingOperator = c.Operator, c.PendingOperator, nil
+		case c.PreviousOperator != nil && epoch > c.OperatorRotationEpoch:
+			c.PreviousOperator, c.OperatorRotationEpoch = nil, 0
+		default:
+			continue
+		}
+		if err := csm.Upsert(c); err != nil {
+			return err
+		}
+		if p.needToWriteCandsMap(ctx, height) {
+			csm.DirtyView().candCenter.base.recordOwner(c)
+		}
+	}
+	return nil
+}
+
+// OperatorAliases returns the pairs of the old and the new operators of the candidates rotating the operators in
+// the epoch, which are mapped to each other, so that either of them is honored for the elected one
+func (p *Protocol) OperatorAliases(ctx context.Context, sr protocol.StateReader, epoch uint64) (map[string]string, error) {
+	c, err := ConstructBaseView(sr)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get OperatorAliases")
+	}
+	aliases := make(map[string]string)
+	for _, cand := range c.AllCandidates() {
+		prev, next, ok := cand.operatorAliases(epoch)
+		if !ok {
+			continue
+		}
+		aliases[prev.String()] = next.String()
+		aliases[next.String()] = prev.String()
+	}
+	return aliases, nil
+}
@@ -0,0 +1,190 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/mohae/deepcopy"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+	"github.com/iotexproject/iotex-core/v2/testutil/testdb"
+)
+
+func TestCandidate_OperatorAliases(t *testing.T) {
+	require := require.New(t)
+	c := &Candidate{
+		Owner:                 identityset.Address(1),
+		Operator:              identityset.Address(2),
+		Reward:                identityset.Address(1),
+		Name:                  "test",
+		Votes:                 big.NewInt(0),
+		SelfStake:             big.NewInt(0),
+		PendingOperator:       identityset.Address(3),
+		OperatorRotationEpoch: 5,
+	}
+	data, err := c.Serialize()
+	require.NoError(err)
+	d := &Candidate{}
+	require.NoError(d.Deserialize(data))
+	require.True(c.Equal(d))
+
+	for _, v := range []struct {
+		epoch uint64
+		ok    bool
+	}{
+		{3, false}, {4, true}, {5, true}, {6, false},
+	} {
+		prev, next, ok := c.operatorAliases(v.epoch)
+		require.Equal(v.ok, ok)
+		if ok {
+			require.Equal(identityset.Address(2), prev)
+			require.Equal(identityset.Address(3), next)
+		}
+	}
+	c.PreviousOperator, c.Operator, c.PendingOperator = c.Operator, c.PendingOperator, nil
+	prev, next, ok := c.operatorAliases(5)
+	require.True(ok)
+	require.Equal(identityset.Address(2), prev)
+	require.Equal(identityset.Address(3), next)
+	_, _, ok = (&Candidate{}).operatorAliases(0)
+	require.False(ok)
+}
+
+func TestProtocol_OperatorRotation(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm := testdb.NewMockStateManager(ctrl)
+	v, _, err := CreateBaseView(sm, false)
+	require.NoError(err)
+	sm.WriteView(_protocolID, v)
+	csm, err := NewCandidateStateManager(sm)
+	require.NoError(err)
+	g := deepcopy.Copy(genesis.TestDefault()).(genesis.Genesis)
+	g.ToBeEnabledBlockHeight = 1
+	p, err := NewProtocol(HelperCtx{
+		DepositGas:    depositGas,
+		BlockInterval: getBlockInterval,
+	}, &BuilderConfig{
+		Staking:                  g.Staking,
+		PersistStakingPatchBlock: math.MaxUint64,
+		Revise: ReviseConfig{
+			VoteWeight: g.Staking.VoteWeightCalConsts,
+		},
+	}, nil, nil, nil)
+	require.NoError(err)
+	for i, owner := range []address.Address{identityset.Address(1), identityset.Address(2)} {
+		require.NoError(csm.Upsert(&Candidate{
+			Owner:              owner,
+			Operator:           identityset.Address(7 + i),
+			Reward:             owner,
+			Name:               "test" + owner.String()[:4],
+			Votes:              big.NewInt(0),
+			SelfStakeBucketIdx: candidateNoSelfStakeBucketIndex,
+			SelfStake:          big.NewInt(0),
+		}))
+	}
+	require.NoError(csm.Commit(context.Background()))
+	require.NoError(setupAccount(sm, identityset.Address(1), 1300000))
+
+	// epochs of 12 blocks
+	reg := protocol.NewRegistry()
+	require.NoError(reg.Register("rolldpos", rolldpos.NewProtocol(23, 4, 3)))
+	blockCtx := func(height uint64) context.Context {
+		ctx := protocol.WithRegistry(context.Background(), reg)
+		ctx = protocol.WithActionCtx(ctx, protocol.ActionCtx{
+			Caller:   identityset.Address(1),
+			GasPrice: big.NewInt(0),
+		})
+		ctx = protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+			BlockHeight:    height,
+			BlockTimeStamp: timeBlock,
+		})
+		ctx = genesis.WithGenesisContext(ctx, g)
+		return protocol.WithFeatureCtx(protocol.WithFeatureWithHeightCtx(ctx))
+	}
+	update := func(ctx context.Context, operator address.Address) error {
+		act, err := action.NewCandidateUpdate("", operator.String(), "")
+		require.NoError(err)
+		csm, err := NewCandidateStateManager(sm)
+		require.NoError(err)
+		_, err = p.handleCandidateUpdate(ctx, act, csm)
+		if err == nil {
+			require.NoError(csm.Commit(ctx))
+		}
+		return err
+	}
+	rotate := func(height uint64) *Candidate {
+		ctx := blockCtx(height)
+		require.NoError(p.rotateOperators(ctx, sm))
+		csm, err := NewCandidateStateManager(sm)
+		require.NoError(err)
+		require.NoError(csm.Commit(ctx))
+		return csm.GetByOwner(identityset.Address(1))
+	}
+
+	// the operator of another candidate cannot be announced
+	ctx := blockCtx(5)
+	err = update(ctx, identityset.Address(8))
+	require.Error(err)
+	require.Equal(uint64(iotextypes.ReceiptStatus_ErrCandidateConflict), err.(*handleError).ReceiptStatus())
+	require.NoError(update(ctx, identityset.Address(10)))
+	c := csm.GetByOwner(identityset.Address(1))
+	require.Equal(identityset.Address(7), c.Operator)
+	require.Equal(identityset.Address(10), c.PendingOperator)
+	require.Equal(uint64(3), c.OperatorRotationEpoch)
+	require.Equal(errOperatorRotationInProgress, update(ctx, identityset.Address(11)))
+
+	// nothing changes in the middle of an epoch
+	c = rotate(14)
+	require.Equal(identityset.Address(7), c.Operator)
+	aliases, err := p.OperatorAliases(ctx, sm, 1)
+	require.NoError(err)
+	require.Empty(aliases)
+	// the new operator takes over at the start of epoch 2, and both are honored in epoch 2 and 3
+	c = rotate(13)
+	require.Equal(identityset.Address(10), c.Operator)
+	require.Equal(identityset.Address(7), c.PreviousOperator)
+	require.Nil(c.PendingOperator)
+	for _, epoch := range []uint64{2, 3} {
+		aliases, err = p.OperatorAliases(ctx, sm, epoch)
+		require.NoError(err)
+		require.Equal(map[string]string{
+			identityset.Address(7).String():  identityset.Address(10).String(),
+			identityset.Address(10).String(): identityset.Address(7).String(),
+		}, aliases)
+	}
+	c = rotate(25)
+	require.Equal(identityset.Address(7), c.PreviousOperator)
+	// the previous operator is cleared after epoch 3
+	c = rotate(37)
+	require.Equal(identityset.Address(10), c.Operator)
+	require.Nil(c.PreviousOperator)
+	require.Zero(c.OperatorRotationEpoch)
+	aliases, err = p.OperatorAliases(ctx, sm, 3)
+	require.NoError(err)
+	require.Empty(aliases)
+
+	// the operator is changed immediately before the rotation is enabled
+	g.ToBeEnabledBlockHeight = math.MaxUint64
+	require.NoError(update(blockCtx(40), identityset.Address(11)))
+	csm, err = NewCandidateStateManager(sm)
+	require.NoError(err)
+	c = csm.GetByOwner(identityset.Address(1))
+	require.Equal(identityset.Address(11), c.Operator)
+	require.Nil(c.PendingOperator)
+}
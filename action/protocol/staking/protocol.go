@@ -52,6 +52,7 @@ const (
 	_voterIndex
 	_candIndex
 	_endorsement
+	_autoCompound
 )
 
 // Errors
@@ -359,6 +360,11 @@ func (p *Protocol) CreatePreStates(ctx context.Context, sm protocol.StateManager
 			return err
 		}
 	}
+	if featureCtx.DeferOperatorRotation {
+		if err := p.rotateOperators(ctx, sm); err != nil {
+			return err
+		}
+	}
 	// create pre-states for contract staking
 	v, err := sm.ReadView(_protocolID)
 	if err != nil {
@@ -530,6 +536,19 @@ func (p *Protocol) handle(ctx context.Context, elp action.Envelope, csm Candidat
 		if err == nil {
 			nonceUpdateOption = noUpdateNonce
 		}
+	case *action.Execution:
+		if exec, ok := isMigrateToNative(ctx, elp); ok {
+			logs, tLogs, gasConsumed, gasToBeDeducted, err = p.handleStakeMigrateToNative(ctx, elp, exec, csm)
+			if err == nil {
+				nonceUpdateOption = noUpdateNonce
+			}
+		} else if exec, ok := isSetAutoCompound(ctx, elp); ok {
+			rLog, err = p.handleSetAutoCompound(ctx, exec, csm)
+		} else if exec, ok := isRegisterBLSKey(ctx, elp); ok {
+			rLog, err = p.handleRegisterBLSKey(ctx, exec, csm)
+		} else {
+			return nil, nil
+		}
 	default:
 		return nil, nil
 	}
@@ -592,6 +611,16 @@ func (p *Protocol) Validate(ctx context.Context, elp action.Envelope, sr protoco
 		return p.validateCandidateTransferOwnershipAction(ctx, act)
 	case *action.MigrateStake:
 		return p.validateMigrateStake(ctx, act)
+	case *action.Execution:
+		if exec, ok := isMigrateToNative(ctx, elp); ok {
+			return p.validateMigrateToNative(exec)
+		}
+		if exec, ok := isSetAutoCompound(ctx, elp); ok {
+			return p.validateSetAutoCompound(exec)
+		}
+		if exec, ok := isRegisterBLSKey(ctx, elp); ok {
+			return p.validateRegisterBLSKey(exec)
+		}
 	}
 	return nil
 }
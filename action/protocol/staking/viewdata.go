@@ -24,6 +24,7 @@ type (
 		Handle(ctx context.Context, receipt *action.Receipt) error
 		Commit()
 		BucketsByCandidate(ownerAddr address.Address) ([]*VoteBucket, error)
+		BucketsByIndices(indices []uint64) ([]*VoteBucket, error)
 	}
 	// ViewData is the data that need to be stored in protocol's view
 	ViewData struct {
@@ -17,6 +17,7 @@ import (
 	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-core/v2/action/protocol"
 	"github.com/iotexproject/iotex-core/v2/action/protocol/vote"
+	"github.com/iotexproject/iotex-core/v2/crypto"
 	"github.com/iotexproject/iotex-core/v2/pkg/log"
 	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
 	"github.com/iotexproject/iotex-core/v2/state"
@@ -40,6 +41,9 @@ const NxtProbationKey = "NextKickoutKey."
 // UnproductiveDelegateKey is the key of unproductive Delegate struct
 const UnproductiveDelegateKey = "UnproductiveDelegateKey."
 
+// EpochCandidatesPrefix is the prefix of the key of the candidate list snapshot of an epoch
+const EpochCandidatesPrefix = "EpochCandidates."
+
 // CandidatesFromDB returns array of Candidates in candidate pool of a given height or current epoch
 func CandidatesFromDB(sr protocol.StateReader, height uint64, loadCandidatesLegacy bool, epochStartPoint bool) ([]*state.Candidate, uint64, error) {
 	var candidates state.CandidateList
@@ -133,6 +137,62 @@ func UnproductiveDelegateFromDB(sr protocol.StateReader) (*vote.UnproductiveDele
 	return nil, err
 }
 
+// EpochCandidatesFromDB returns the candidate list snapshot taken at the start of the epoch
+func EpochCandidatesFromDB(sr protocol.StateReader, epochNum uint64) (state.CandidateList, error) {
+	var candidates state.CandidateList
+	key := ConstructEpochKey(epochNum)
+	if _, err := sr.State(
+		&candidates,
+		protocol.KeyOption(key[:]),
+		protocol.NamespaceOption(protocol.SystemNamespace),
+	); err != nil {
+		return nil, errors.Wrapf(err, "failed to get candidates snapshot of epoch %d", epochNum)
+	}
+	return candidates, nil
+}
+
+// PutEpochCandidates persists the candidate list snapshot of the epoch
+func PutEpochCandidates(sm protocol.StateManager, epochNum uint64, candidates state.CandidateList) error {
+	key := ConstructEpochKey(epochNum)
+	_, err := sm.PutState(
+		&candidates,
+		protocol.KeyOption(key[:]),
+		protocol.NamespaceOption(protocol.SystemNamespace),
+	)
+	return errors.Wrapf(err, "failed to put candidates snapshot of epoch %d", epochNum)
+}
+
+// CandidateLeaves returns the merkle leaves of the candidate list, which are the hashes of the serialized candidates
+// in the order of the list
+func CandidateLeaves(candidates state.CandidateList) ([]hash.Hash256, error) {
+	leaves := make([]hash.Hash256, len(candidates))
+	for i, c := range candidates {
+		b, err := c.Serialize()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to serialize candidate %s", c.Address)
+		}
+		leaves[i] = hash.Hash256b(b)
+	}
+	return leaves, nil
+}
+
+// CandidatesRoot returns the merkle root of the candidate list, or zero hash for an empty list
+func CandidatesRoot(candidates state.CandidateList) (hash.Hash256, error) {
+	if len(candidates) == 0 {
+		return hash.ZeroHash256, nil
+	}
+	leaves, err := CandidateLeaves(candidates)
+	if err != nil {
+		return hash.ZeroHash256, err
+	}
+	return crypto.NewMerkleTree(leaves).HashTree(), nil
+}
+
+// ConstructEpochKey constructs the key of the candidate list snapshot of the epoch
+func ConstructEpochKey(epochNum uint64) hash.Hash256 {
+	return hash.Hash256b(append([]byte(EpochCandidatesPrefix), byteutil.Uint64ToBytesBigEndian(epochNum)...))
+}
+
 // ConstructLegacyKey constructs a key for candidates storage (deprecated version)
 func ConstructLegacyKey(height uint64) hash.Hash160 {
 	heightInBytes := byteutil.Uint64ToBytes(height)
@@ -122,3 +122,8 @@ func (upd *UnproductiveDelegate) Equal(upd2 *UnproductiveDelegate) bool {
 func (upd *UnproductiveDelegate) DelegateList() [][]string {
 	return upd.delegatelist
 }
+
+// ProbationPeriod returns the number of recent epochs kept
+func (upd *UnproductiveDelegate) ProbationPeriod() uint64 {
+	return upd.probationPeriod
+}
@@ -35,6 +35,20 @@ type (
 		logs               []*Log
 		transactionLogs    []*TransactionLog
 		executionRevertMsg string
+		gasBreakdown       *GasBreakdown
+	}
+
+	// GasBreakdown is the gas consumed by an action by phase, GasConsumed = Intrinsic + Execution - Refund. It is not
+	// part of the receipt stored in the block, thus not available once the receipt is serialized
+	GasBreakdown struct {
+		// Intrinsic is the gas charged for the action regardless of its execution
+		Intrinsic uint64
+		// Execution is the gas consumed by the execution before the refund
+		Execution uint64
+		// Refund is the gas refunded after the execution
+		Refund uint64
+		// Sidecar is the blob gas of the sidecar, which is charged apart from the gas
+		Sidecar uint64
 	}
 
 	// Log stores an evm contract event
@@ -133,6 +147,17 @@ func (receipt *Receipt) Hash() hash.Hash256 {
 	return hash.Hash256b(data)
 }
 
+// GasBreakdown returns the gas consumed by phase, nil if it is not available
+func (receipt *Receipt) GasBreakdown() *GasBreakdown {
+	return receipt.gasBreakdown
+}
+
+// SetGasBreakdown sets the gas consumed by phase
+func (receipt *Receipt) SetGasBreakdown(gb *GasBreakdown) *Receipt {
+	receipt.gasBreakdown = gb
+	return receipt
+}
+
 // Logs returns the list of logs stored in receipt
 func (receipt *Receipt) Logs() []*Log {
 	return receipt.logs
@@ -98,6 +98,15 @@ func TestSerDer(t *testing.T) {
 	receipt = receipt.SetExecutionRevertMsg("test")
 	hash2 := receipt.Hash()
 	require.NotEqual(oldHash, hex.EncodeToString(hash2[:]))
+
+	// the gas breakdown is not part of the receipt stored in the block
+	receipt.SetGasBreakdown(&GasBreakdown{Intrinsic: 1})
+	require.Equal(hash2, receipt.Hash())
+	ser, err = receipt.Serialize()
+	require.NoError(err)
+	receipt2 = &Receipt{}
+	require.NoError(receipt2.Deserialize(ser))
+	require.Nil(receipt2.GasBreakdown())
 }
 
 func TestUpdateIndex(t *testing.T) {
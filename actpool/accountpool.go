@@ -61,6 +61,7 @@ func (ap *accountPool) PutAction(
 	confirmedBalance *big.Int,
 	expiry time.Duration,
 	act *action.SealedEnvelope,
+	opts ...ActQueueOption,
 ) error {
 	account, ok := ap.accounts[addr]
 	if !ok {
@@ -69,7 +70,7 @@ func (ap *accountPool) PutAction(
 			addr,
 			pendingNonce,
 			confirmedBalance,
-			WithTimeOut(expiry),
+			append([]ActQueueOption{WithTimeOut(expiry)}, opts...)...,
 		)
 		if err := queue.Put(act); err != nil {
 			return err
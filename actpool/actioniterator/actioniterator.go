@@ -6,44 +6,34 @@
 package actioniterator
 
 import (
-	"bytes"
 	"container/heap"
 
 	"github.com/iotexproject/iotex-core/v2/action"
 )
 
-// ActionByPrice implements both the sort and the heap interface, making it useful
+// actionHeap implements both the sort and the heap interface, making it useful
 // for all at once sorting as well as individually adding and removing elements.
-// It's essentially a big root heap of actions
-type actionByPrice []*action.SealedEnvelope
-
-func (s actionByPrice) Len() int { return len(s) }
-func (s actionByPrice) Less(i, j int) bool {
-	switch s[i].GasPrice().Cmp(s[j].GasPrice()) {
-	case 1:
-		return true
-	case 0:
-		hi, _ := s[i].Hash()
-		hj, _ := s[j].Hash()
-		return bytes.Compare(hi[:], hj[:]) > 0
-	default:
-		return false
-	}
+// It's essentially a big root heap of actions ordered by the ordering strategy
+type actionHeap struct {
+	acts     []*action.SealedEnvelope
+	ordering OrderingStrategy
 }
 
-func (s actionByPrice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s *actionHeap) Len() int           { return len(s.acts) }
+func (s *actionHeap) Less(i, j int) bool { return s.ordering.Less(s.acts[i], s.acts[j]) }
+func (s *actionHeap) Swap(i, j int)      { s.acts[i], s.acts[j] = s.acts[j], s.acts[i] }
 
 // Push define the push function of heap
-func (s *actionByPrice) Push(x interface{}) {
-	*s = append(*s, x.(*action.SealedEnvelope))
+func (s *actionHeap) Push(x interface{}) {
+	s.acts = append(s.acts, x.(*action.SealedEnvelope))
 }
 
 // Pop define the pop function of heap
-func (s *actionByPrice) Pop() interface{} {
-	old := *s
+func (s *actionHeap) Pop() interface{} {
+	old := s.acts
 	n := len(old)
 	x := old[n-1]
-	*s = old[0 : n-1]
+	s.acts = old[0 : n-1]
 	return x
 }
 
@@ -53,58 +43,164 @@ type ActionIterator interface {
 	PopAccount()
 }
 
+// Option sets action iterator construction parameter
+type Option func(*actionIterator)
+
+// WithOrdering returns an option to order actions of different senders by the strategy,
+// actions are ordered by gas price by default
+func WithOrdering(ordering OrderingStrategy) Option {
+	return func(ai *actionIterator) {
+		ai.ordering = ordering
+	}
+}
+
+// WithPriorityLane returns an option to serve actions matching isPriority ahead of
+// all other actions regardless of gas price, up to maxNum actions. Once maxNum
+// actions have been served from the priority lane, the remaining priority actions
+// compete with other actions by gas price.
+func WithPriorityLane(isPriority func(*action.SealedEnvelope) bool, maxNum uint64) Option {
+	return func(ai *actionIterator) {
+		ai.isPriority = isPriority
+		ai.priorityCap = maxNum
+	}
+}
+
 type actionIterator struct {
-	accountActs map[string][]*action.SealedEnvelope
-	heads       actionByPrice
+	accountActs   map[string][]*action.SealedEnvelope
+	heads         *actionHeap
+	priorityHeads *actionHeap
+	ordering      OrderingStrategy
+	isPriority    func(*action.SealedEnvelope) bool
+	priorityCap   uint64
+	prioritized   uint64
+	// lastPriority is the sender of the last action if it was served from the priority lane
+	lastPriority string
 }
 
 // NewActionIterator return a new action iterator
-func NewActionIterator(accountActs map[string][]*action.SealedEnvelope) ActionIterator {
-	heads := make(actionByPrice, 0, len(accountActs))
+func NewActionIterator(accountActs map[string][]*action.SealedEnvelope, opts ...Option) ActionIterator {
+	ai := &actionIterator{
+		accountActs: accountActs,
+		ordering:    NewGasPriceOrdering(),
+	}
+	for _, opt := range opts {
+		opt(ai)
+	}
+	heads := make([]*action.SealedEnvelope, 0, len(accountActs))
+	priorityHeads := make([]*action.SealedEnvelope, 0)
 	for sender, accActs := range accountActs {
 		if len(accActs) == 0 {
 			continue
 		}
 
-		heads = append(heads, accActs[0])
+		if ai.priorityLaneOpen() && ai.isPriority(accActs[0]) {
+			priorityHeads = append(priorityHeads, accActs[0])
+		} else {
+			heads = append(heads, accActs[0])
+		}
 		if len(accActs) > 1 {
 			accountActs[sender] = accActs[1:]
 		} else {
 			accountActs[sender] = []*action.SealedEnvelope{}
 		}
 	}
-	heap.Init(&heads)
-	return &actionIterator{
-		accountActs: accountActs,
-		heads:       heads,
+	ai.heads = &actionHeap{acts: heads, ordering: ai.ordering}
+	ai.priorityHeads = &actionHeap{acts: priorityHeads, ordering: ai.ordering}
+	heap.Init(ai.heads)
+	heap.Init(ai.priorityHeads)
+	return ai
+}
+
+func (ai *actionIterator) priorityLaneOpen() bool {
+	return ai.isPriority != nil && ai.prioritized < ai.priorityCap
+}
+
+// closePriorityLane moves all remaining priority heads into the regular lane
+func (ai *actionIterator) closePriorityLane() {
+	for ai.priorityHeads.Len() > 0 {
+		heap.Push(ai.heads, heap.Pop(ai.priorityHeads))
 	}
 }
 
 // loadNextActionForTopAccount load next action of account of top action
 func (ai *actionIterator) loadNextActionForTopAccount() {
-	callerAddrStr := ai.heads[0].SenderAddress().String()
+	callerAddrStr := ai.heads.acts[0].SenderAddress().String()
 	if actions, ok := ai.accountActs[callerAddrStr]; ok && len(actions) > 0 {
-		ai.heads[0], ai.accountActs[callerAddrStr] = actions[0], actions[1:]
-		heap.Fix(&ai.heads, 0)
+		ai.heads.acts[0], ai.accountActs[callerAddrStr] = actions[0], actions[1:]
+		heap.Fix(ai.heads, 0)
 	} else {
-		heap.Pop(&ai.heads)
+		heap.Pop(ai.heads)
+	}
+}
+
+// loadNextActionForTopPriorityAccount load next action of account of top priority action,
+// the next action stays in the priority lane only if it is a priority action as well
+func (ai *actionIterator) loadNextActionForTopPriorityAccount() {
+	callerAddrStr := ai.priorityHeads.acts[0].SenderAddress().String()
+	actions, ok := ai.accountActs[callerAddrStr]
+	if !ok || len(actions) == 0 {
+		heap.Pop(ai.priorityHeads)
+		return
+	}
+	next := actions[0]
+	ai.accountActs[callerAddrStr] = actions[1:]
+	if ai.isPriority(next) {
+		ai.priorityHeads.acts[0] = next
+		heap.Fix(ai.priorityHeads, 0)
+		return
 	}
+	heap.Pop(ai.priorityHeads)
+	heap.Push(ai.heads, next)
 }
 
 // Next load next action of account of top action
 func (ai *actionIterator) Next() (*action.SealedEnvelope, bool) {
-	if len(ai.heads) == 0 {
+	if ai.priorityHeads.Len() > 0 {
+		if ai.priorityLaneOpen() {
+			headAction := ai.priorityHeads.acts[0]
+			ai.ordering.OnPicked(headAction)
+			ai.loadNextActionForTopPriorityAccount()
+			ai.prioritized++
+			ai.lastPriority = headAction.SenderAddress().String()
+			if !ai.priorityLaneOpen() {
+				ai.closePriorityLane()
+			}
+			return headAction, true
+		}
+		ai.closePriorityLane()
+	}
+	ai.lastPriority = ""
+	if ai.heads.Len() == 0 {
 		return nil, false
 	}
 
-	headAction := ai.heads[0]
+	headAction := ai.heads.acts[0]
+	ai.ordering.OnPicked(headAction)
 	ai.loadNextActionForTopAccount()
 	return headAction, true
 }
 
 // PopAccount will remove all actions related to this account
 func (ai *actionIterator) PopAccount() {
-	if len(ai.heads) != 0 {
-		heap.Pop(&ai.heads)
+	if ai.lastPriority != "" {
+		ai.popPriorityAccount(ai.lastPriority)
+		return
+	}
+	if ai.heads.Len() != 0 {
+		heap.Pop(ai.heads)
+	}
+}
+
+// popPriorityAccount removes all remaining actions of sender, whose next action
+// may have moved to either lane
+func (ai *actionIterator) popPriorityAccount(sender string) {
+	ai.accountActs[sender] = []*action.SealedEnvelope{}
+	for _, lane := range []*actionHeap{ai.priorityHeads, ai.heads} {
+		for i, act := range lane.acts {
+			if act.SenderAddress().String() == sender {
+				heap.Remove(lane, i)
+				return
+			}
+		}
 	}
 }
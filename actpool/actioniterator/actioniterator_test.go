@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -82,10 +83,130 @@ func TestActionIterator(t *testing.T) {
 	require.Equal(appliedActionList, []*action.SealedEnvelope{selp3, selp1, selp2, selp4, selp5, selp6})
 }
 
-func TestActionByPrice(t *testing.T) {
+// testAccountActions returns actions of 3 senders, and a function to generate the account actions map
+func testAccountActions(t *testing.T) ([]*action.SealedEnvelope, func() map[string][]*action.SealedEnvelope) {
+	var (
+		a     = identityset.Address(28)
+		b     = identityset.Address(29)
+		c     = identityset.Address(30)
+		selps = make([]*action.SealedEnvelope, 0, 6)
+	)
+	for _, v := range []struct {
+		key      int
+		nonce    uint64
+		gasPrice int64
+	}{
+		{28, 1, 13}, {28, 2, 30}, {29, 1, 15}, {29, 2, 10}, {29, 3, 20}, {30, 1, 5},
+	} {
+		elp := (&action.EnvelopeBuilder{}).SetNonce(v.nonce).SetGasPrice(big.NewInt(v.gasPrice)).
+			SetAction(action.NewTransfer(big.NewInt(100), a.String(), nil)).Build()
+		selp, err := action.Sign(elp, identityset.PrivateKey(v.key))
+		require.NoError(t, err)
+		selps = append(selps, selp)
+	}
+	return selps, func() map[string][]*action.SealedEnvelope {
+		return map[string][]*action.SealedEnvelope{
+			a.String(): {selps[0], selps[1]},
+			b.String(): {selps[2], selps[3], selps[4]},
+			c.String(): {selps[5]},
+		}
+	}
+}
+
+func drainActionIterator(ai ActionIterator) []*action.SealedEnvelope {
+	ret := make([]*action.SealedEnvelope, 0)
+	for {
+		selp, ok := ai.Next()
+		if !ok {
+			return ret
+		}
+		ret = append(ret, selp)
+	}
+}
+
+func TestActionIteratorOrdering(t *testing.T) {
+	require := require.New(t)
+	selps, accMap := testAccountActions(t)
+
+	t.Run("gas price", func(t *testing.T) {
+		ai := NewActionIterator(accMap(), WithOrdering(NewGasPriceOrdering()))
+		require.Equal([]*action.SealedEnvelope{selps[2], selps[0], selps[1], selps[3], selps[4], selps[5]}, drainActionIterator(ai))
+	})
+	t.Run("effective tip", func(t *testing.T) {
+		ai := NewActionIterator(accMap(), WithOrdering(NewEffectiveTipOrdering(big.NewInt(14))))
+		require.Equal(selps[2], drainActionIterator(ai)[0])
+		ai = NewActionIterator(accMap(), WithOrdering(NewEffectiveTipOrdering(big.NewInt(10))))
+		require.Equal([]*action.SealedEnvelope{selps[2], selps[0], selps[1], selps[3], selps[4], selps[5]}, drainActionIterator(ai))
+	})
+	t.Run("arrival time", func(t *testing.T) {
+		now := time.Now()
+		arrivals := map[*action.SealedEnvelope]time.Time{
+			selps[0]: now.Add(3 * time.Second),
+			selps[1]: now,
+			selps[2]: now.Add(2 * time.Second),
+			selps[3]: now.Add(time.Second),
+			selps[4]: now.Add(5 * time.Second),
+			selps[5]: now.Add(4 * time.Second),
+		}
+		ai := NewActionIterator(accMap(), WithOrdering(NewArrivalTimeOrdering(func(selp *action.SealedEnvelope) time.Time {
+			return arrivals[selp]
+		})))
+		require.Equal([]*action.SealedEnvelope{selps[2], selps[3], selps[0], selps[1], selps[5], selps[4]}, drainActionIterator(ai))
+	})
+	t.Run("round robin", func(t *testing.T) {
+		ai := NewActionIterator(accMap(), WithOrdering(NewRoundRobinOrdering()))
+		require.Equal([]*action.SealedEnvelope{selps[2], selps[0], selps[5], selps[1], selps[3], selps[4]}, drainActionIterator(ai))
+	})
+}
+
+func TestActionIteratorPriorityLane(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		a = identityset.Address(28)
+		b = identityset.Address(29)
+		c = identityset.Address(30)
+	)
+	selps, accMap := testAccountActions(t)
+	fromSenders := func(senders ...string) func(*action.SealedEnvelope) bool {
+		return func(selp *action.SealedEnvelope) bool {
+			for _, s := range senders {
+				if selp.SenderAddress().String() == s {
+					return true
+				}
+			}
+			return false
+		}
+	}
+
+	t.Run("priority served first", func(t *testing.T) {
+		ai := NewActionIterator(accMap(), WithPriorityLane(fromSenders(c.String()), 1))
+		require.Equal([]*action.SealedEnvelope{selps[5], selps[2], selps[0], selps[1], selps[3], selps[4]}, drainActionIterator(ai))
+	})
+	t.Run("cap reached", func(t *testing.T) {
+		ai := NewActionIterator(accMap(), WithPriorityLane(fromSenders(a.String()), 1))
+		require.Equal([]*action.SealedEnvelope{selps[0], selps[1], selps[2], selps[3], selps[4], selps[5]}, drainActionIterator(ai))
+		ai = NewActionIterator(accMap(), WithPriorityLane(fromSenders(a.String(), c.String()), 2))
+		require.Equal([]*action.SealedEnvelope{selps[0], selps[1], selps[2], selps[3], selps[4], selps[5]}, drainActionIterator(ai))
+	})
+	t.Run("zero cap", func(t *testing.T) {
+		ai := NewActionIterator(accMap(), WithPriorityLane(fromSenders(c.String()), 0))
+		require.Equal([]*action.SealedEnvelope{selps[2], selps[0], selps[1], selps[3], selps[4], selps[5]}, drainActionIterator(ai))
+	})
+	t.Run("pop priority account", func(t *testing.T) {
+		ai := NewActionIterator(accMap(), WithPriorityLane(fromSenders(b.String()), 3))
+		selp, ok := ai.Next()
+		require.True(ok)
+		require.Equal(selps[2], selp)
+		ai.PopAccount()
+		require.Equal([]*action.SealedEnvelope{selps[0], selps[1], selps[5]}, drainActionIterator(ai))
+	})
+}
+
+func TestActionHeap(t *testing.T) {
 	require := require.New(t)
 
-	s := &actionByPrice{}
+	s := &actionHeap{ordering: NewGasPriceOrdering()}
 	require.Equal(0, s.Len())
 
 	tsf1 := action.NewTransfer(big.NewInt(100), "100", nil)
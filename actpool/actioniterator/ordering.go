@@ -0,0 +1,122 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package actioniterator
+
+import (
+	"bytes"
+	"math/big"
+	"time"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+)
+
+// OrderingStrategy decides the order in which actions of different senders are picked,
+// actions of the same sender are always picked in nonce order
+type OrderingStrategy interface {
+	// Less returns true if action a should be picked before action b
+	Less(a, b *action.SealedEnvelope) bool
+	// OnPicked is called when an action is picked
+	OnPicked(*action.SealedEnvelope)
+}
+
+type (
+	gasPriceOrdering struct{}
+
+	effectiveTipOrdering struct {
+		baseFee *big.Int
+	}
+
+	arrivalTimeOrdering struct {
+		arrival func(*action.SealedEnvelope) time.Time
+	}
+
+	roundRobinOrdering struct {
+		picked map[string]uint64
+	}
+)
+
+// NewGasPriceOrdering returns a strategy which picks action of higher gas price first
+func NewGasPriceOrdering() OrderingStrategy {
+	return &gasPriceOrdering{}
+}
+
+// NewEffectiveTipOrdering returns a strategy which picks action of higher effective tip
+// first given the base fee of the block
+func NewEffectiveTipOrdering(baseFee *big.Int) OrderingStrategy {
+	return &effectiveTipOrdering{baseFee: baseFee}
+}
+
+// NewArrivalTimeOrdering returns a strategy which picks action arriving earlier first
+func NewArrivalTimeOrdering(arrival func(*action.SealedEnvelope) time.Time) OrderingStrategy {
+	return &arrivalTimeOrdering{arrival: arrival}
+}
+
+// NewRoundRobinOrdering returns a strategy which picks one action of each sender in turn,
+// senders in the same round are ordered by gas price
+func NewRoundRobinOrdering() OrderingStrategy {
+	return &roundRobinOrdering{picked: make(map[string]uint64)}
+}
+
+func (o *gasPriceOrdering) Less(a, b *action.SealedEnvelope) bool {
+	return lessByPrice(a.GasPrice(), b.GasPrice(), a, b)
+}
+
+func (o *gasPriceOrdering) OnPicked(*action.SealedEnvelope) {}
+
+func (o *effectiveTipOrdering) Less(a, b *action.SealedEnvelope) bool {
+	return lessByPrice(o.effectiveTip(a), o.effectiveTip(b), a, b)
+}
+
+func (o *effectiveTipOrdering) effectiveTip(selp *action.SealedEnvelope) *big.Int {
+	tip, err := action.EffectiveGasTip(selp, o.baseFee)
+	if err != nil {
+		// fee cap is lower than base fee, the action can't be included in the block
+		return new(big.Int).SetInt64(-1)
+	}
+	return tip
+}
+
+func (o *effectiveTipOrdering) OnPicked(*action.SealedEnvelope) {}
+
+func (o *arrivalTimeOrdering) Less(a, b *action.SealedEnvelope) bool {
+	ta, tb := o.arrival(a), o.arrival(b)
+	if ta.Equal(tb) {
+		return lessByHash(a, b)
+	}
+	return ta.Before(tb)
+}
+
+func (o *arrivalTimeOrdering) OnPicked(*action.SealedEnvelope) {}
+
+func (o *roundRobinOrdering) Less(a, b *action.SealedEnvelope) bool {
+	ra, rb := o.picked[a.SenderAddress().String()], o.picked[b.SenderAddress().String()]
+	if ra != rb {
+		return ra < rb
+	}
+	return lessByPrice(a.GasPrice(), b.GasPrice(), a, b)
+}
+
+func (o *roundRobinOrdering) OnPicked(selp *action.SealedEnvelope) {
+	o.picked[selp.SenderAddress().String()]++
+}
+
+// lessByPrice returns true if price a is higher, ties are broken by action hash to be deterministic
+func lessByPrice(pa, pb *big.Int, a, b *action.SealedEnvelope) bool {
+	switch pa.Cmp(pb) {
+	case 1:
+		return true
+	case 0:
+		return lessByHash(a, b)
+	default:
+		return false
+	}
+}
+
+func lessByHash(a, b *action.SealedEnvelope) bool {
+	ha, _ := a.Hash()
+	hb, _ := b.Hash()
+	return bytes.Compare(ha[:], hb[:]) > 0
+}
@@ -108,12 +108,12 @@ func (s *actionStore) Open(onData onAction) error {
 		act, err := s.decode(blob)
 		if err != nil {
 			fails = append(fails, id)
-			log.L().Warn("Failed to decode action", zap.Error(err))
+			log.Logger("actpool").Warn("Failed to decode action", zap.Error(err))
 			return
 		}
 		if err = onData(act); err != nil {
 			fails = append(fails, id)
-			log.L().Warn("Failed to process action", zap.Error(err))
+			log.Logger("actpool").Warn("Failed to process action", zap.Error(err))
 			return
 		}
 		s.stored += uint64(size)
@@ -128,7 +128,7 @@ func (s *actionStore) Open(onData onAction) error {
 	s.store = store
 
 	if len(fails) > 0 {
-		log.L().Warn("Dropping invalidated blob transactions", zap.Int("count", len(fails)))
+		log.Logger("actpool").Warn("Dropping invalidated blob transactions", zap.Int("count", len(fails)))
 
 		for _, id := range fails {
 			if err := s.store.Delete(id); err != nil {
@@ -8,6 +8,7 @@ package actpool
 import (
 	"context"
 	"encoding/hex"
+	"math/big"
 	"sort"
 	"sync"
 	"sync/atomic"
@@ -29,6 +30,7 @@ import (
 	"github.com/iotexproject/iotex-core/v2/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/v2/pkg/log"
 	"github.com/iotexproject/iotex-core/v2/pkg/prometheustimer"
+	"github.com/iotexproject/iotex-core/v2/pkg/routine"
 	"github.com/iotexproject/iotex-core/v2/pkg/tracer"
 )
 
@@ -44,6 +46,8 @@ var (
 	}, []string{"type"})
 	// ErrGasTooHigh error when the intrinsic gas of an action is too high
 	ErrGasTooHigh = errors.New("action gas is too high")
+	// ErrPaused error when the actpool is paused, e.g., the disk is running out of space
+	ErrPaused = errors.New("actpool is paused")
 )
 
 func init() {
@@ -60,6 +64,8 @@ type ActPool interface {
 	PendingActionMap() map[string][]*action.SealedEnvelope
 	// Add adds an action into the pool after passing validation
 	Add(ctx context.Context, act *action.SealedEnvelope) error
+	// CheckAction runs the validation of Add, without adding the action into the pool
+	CheckAction(ctx context.Context, act *action.SealedEnvelope) error
 	// GetPendingNonce returns pending nonce in pool given an account address
 	GetPendingNonce(addr string) (uint64, error)
 	// GetUnconfirmedActs returns unconfirmed actions in pool given an account address
@@ -74,6 +80,12 @@ type ActPool interface {
 	GetGasSize() uint64
 	// GetGasCapacity returns the act pool gas capacity
 	GetGasCapacity() uint64
+	// MinGasPrice returns the minimal gas price of actions accepted by the pool
+	MinGasPrice() *big.Int
+	// SetMinGasPrice sets the minimal gas price of actions accepted by the pool at runtime
+	SetMinGasPrice(*big.Int)
+	// SetLimits sets the capacities of the pool at runtime, the actions already in the pool are kept
+	SetLimits(Limits)
 	// DeleteAction deletes an invalid action from pool
 	DeleteAction(address.Address)
 	// ReceiveBlock will be called when a new block is committed
@@ -86,7 +98,7 @@ type ActPool interface {
 // Subscriber is the interface for actpool subscriber
 type Subscriber interface {
 	OnAdded(context.Context, *action.SealedEnvelope)
-	OnRemoved(*action.SealedEnvelope)
+	OnRemoved(*action.SealedEnvelope, EvictionReason)
 }
 
 // SortedActions is a slice of actions that implements sort.Interface to sort by Value.
@@ -99,6 +111,13 @@ func (p SortedActions) Less(i, j int) bool { return p[i].Nonce() < p[j].Nonce()
 // Option sets action pool construction parameter
 type Option func(pool *actPool) error
 
+// Limits are the capacities of the pool, which could be adjusted at runtime
+type Limits struct {
+	MaxNumActsPerPool  uint64
+	MaxGasLimitPerPool uint64
+	MaxNumActsPerAcct  uint64
+}
+
 // actPool implements ActPool interface
 type actPool struct {
 	cfg            Config
@@ -107,6 +126,9 @@ type actPool struct {
 	accountDesActs *destinationMap
 	allActions     *ttl.Cache
 	gasInPool      uint64
+	minGasPrice    atomic.Pointer[big.Int]
+	limits         atomic.Pointer[Limits]
+	paused         atomic.Bool
 	// actionEnvelopeValidators are the validators that are used in both actpool.Add and actpool.Validate
 	// TODO: can combine with privateValidators after NOT use actpool to call generic_validator in block validate
 	actionEnvelopeValidators []action.SealedEnvelopeValidator
@@ -119,6 +141,18 @@ type actPool struct {
 	worker            []*queueWorker
 	subs              []Subscriber
 	store             *actionStore // store is the persistent cache for actpool
+	sweepTask         *routine.RecurringTask
+	inclusion         *inclusionTracker
+	bundles           *bundlePool
+	registry          *protocol.Registry
+	// sponsors is the context to compute the cost of the actions at the next height, refreshed on reset
+	sponsors atomic.Pointer[sponsorCtx]
+}
+
+// sponsorCtx is the context of the next height with the gas sponsors active at the height
+type sponsorCtx struct {
+	ctx      context.Context
+	sponsors []protocol.GasSponsor
 }
 
 // NewActPool constructs a new actpool
@@ -126,6 +160,9 @@ func NewActPool(g genesis.Genesis, sf protocol.StateReader, cfg Config, opts ...
 	if sf == nil {
 		return nil, errors.New("Try to attach a nil state reader")
 	}
+	if err := validateOrderingPolicy(cfg.OrderingPolicy); err != nil {
+		return nil, err
+	}
 
 	senderBlackList := make(map[string]bool)
 	for _, bannedSender := range cfg.BlackList {
@@ -143,6 +180,12 @@ func NewActPool(g genesis.Genesis, sf protocol.StateReader, cfg Config, opts ...
 		jobQueue:        make([]chan workerJob, _numWorker),
 		worker:          make([]*queueWorker, _numWorker),
 	}
+	ap.minGasPrice.Store(cfg.MinGasPrice())
+	ap.limits.Store(&Limits{
+		MaxNumActsPerPool:  cfg.MaxNumActsPerPool,
+		MaxGasLimitPerPool: cfg.MaxGasLimitPerPool,
+		MaxNumActsPerAcct:  cfg.MaxNumActsPerAcct,
+	})
 	for _, opt := range opts {
 		if err := opt(ap); err != nil {
 			return nil, err
@@ -163,6 +206,15 @@ func NewActPool(g genesis.Genesis, sf protocol.StateReader, cfg Config, opts ...
 		return nil, err
 	}
 	ap.timerFactory = timerFactory
+	if cfg.InclusionLatencyWindow > 0 {
+		ap.inclusion = newInclusionTracker(cfg.InclusionLatencyWindow)
+	}
+	if cfg.MaxNumBundles > 0 {
+		ap.bundles = newBundlePool(cfg.MaxNumBundles, cfg.ActionExpiry)
+	}
+	if cfg.SweepInterval > 0 {
+		ap.sweepTask = routine.NewRecurringTask(ap.sweep, cfg.SweepInterval)
+	}
 	// TODO: move to Start
 	for i := 0; i < _numWorker; i++ {
 		ap.jobQueue[i] = make(chan workerJob, ap.cfg.WorkerBufferSize)
@@ -175,6 +227,11 @@ func NewActPool(g genesis.Genesis, sf protocol.StateReader, cfg Config, opts ...
 }
 
 func (ap *actPool) Start(ctx context.Context) error {
+	if ap.sweepTask != nil {
+		if err := ap.sweepTask.Start(ctx); err != nil {
+			return err
+		}
+	}
 	if ap.store == nil {
 		return nil
 	}
@@ -194,13 +251,18 @@ func (ap *actPool) Start(ctx context.Context) error {
 	sort.Sort(blobs)
 	for _, selp := range blobs {
 		if err := ap.add(ctx, selp); err != nil {
-			log.L().Info("Failed to load action from store", zap.Error(err))
+			log.Logger("actpool").Info("Failed to load action from store", zap.Error(err))
 		}
 	}
 	return nil
 }
 
 func (ap *actPool) Stop(ctx context.Context) error {
+	if ap.sweepTask != nil {
+		if err := ap.sweepTask.Stop(ctx); err != nil {
+			return err
+		}
+	}
 	for i := 0; i < _numWorker; i++ {
 		if err := ap.worker[i].Stop(); err != nil {
 			return err
@@ -233,6 +295,7 @@ func (ap *actPool) reset() {
 		wg  sync.WaitGroup
 		ctx = ap.context(context.Background())
 	)
+	ap.updateSponsors(ctx)
 	for i := range ap.worker {
 		wg.Add(1)
 		go func(worker *queueWorker) {
@@ -243,7 +306,9 @@ func (ap *actPool) reset() {
 	wg.Wait()
 }
 
-func (ap *actPool) ReceiveBlock(*block.Block) error {
+func (ap *actPool) ReceiveBlock(blk *block.Block) error {
+	ap.trackInclusion(blk)
+	ap.bundles.receiveBlock(blk)
 	ap.reset()
 	return nil
 }
@@ -284,6 +349,10 @@ func (ap *actPool) add(ctx context.Context, act *action.SealedEnvelope) error {
 	defer span.End()
 	ctx = ap.context(ctx)
 
+	if ap.paused.Load() {
+		return ErrPaused
+	}
+
 	// system action is only added by proposer when creating a block
 	if action.IsSystemAction(act) {
 		return action.ErrInvalidAct
@@ -301,7 +370,8 @@ func (ap *actPool) add(ctx context.Context, act *action.SealedEnvelope) error {
 	if err != nil {
 		return err
 	}
-	if intrinsicGas > ap.cfg.MaxGasLimitPerPool {
+	limits := ap.limits.Load()
+	if intrinsicGas > limits.MaxGasLimitPerPool {
 		_actpoolMtc.WithLabelValues("overMaxGasLimitPerPool").Inc()
 		return ErrGasTooHigh
 	}
@@ -309,11 +379,35 @@ func (ap *actPool) add(ctx context.Context, act *action.SealedEnvelope) error {
 	return ap.enqueue(
 		ctx,
 		act,
-		atomic.LoadUint64(&ap.gasInPool) > ap.cfg.MaxGasLimitPerPool-intrinsicGas ||
-			uint64(ap.allActions.Count()) >= ap.cfg.MaxNumActsPerPool,
+		atomic.LoadUint64(&ap.gasInPool) > limits.MaxGasLimitPerPool-intrinsicGas ||
+			uint64(ap.allActions.Count()) >= limits.MaxNumActsPerPool,
 	)
 }
 
+func (ap *actPool) CheckAction(ctx context.Context, act *action.SealedEnvelope) error {
+	ctx, span := tracer.NewSpan(ap.context(ctx), "actPool.CheckAction")
+	defer span.End()
+	ctx = ap.context(ctx)
+
+	if action.IsSystemAction(act) {
+		return action.ErrInvalidAct
+	}
+	if err := checkSelpData(act); err != nil {
+		return err
+	}
+	if err := ap.checkSelpWithoutState(ctx, act); err != nil {
+		return err
+	}
+	intrinsicGas, err := act.IntrinsicGas()
+	if err != nil {
+		return err
+	}
+	if intrinsicGas > ap.limits.Load().MaxGasLimitPerPool {
+		return ErrGasTooHigh
+	}
+	return ap.worker[ap.allocatedWorker(act.SenderAddress())].Check(ctx, act)
+}
+
 func checkSelpData(act *action.SealedEnvelope) error {
 	_, err := act.IntrinsicGas()
 	if err != nil {
@@ -346,10 +440,10 @@ func (ap *actPool) checkSelpWithoutState(ctx context.Context, selp *action.Seale
 	}
 
 	// Reject action if the gas price is lower than the threshold
-	if selp.Encoding() != uint32(iotextypes.Encoding_ETHEREUM_UNPROTECTED) && selp.GasFeeCap().Cmp(ap.cfg.MinGasPrice()) < 0 {
+	if selp.Encoding() != uint32(iotextypes.Encoding_ETHEREUM_UNPROTECTED) && selp.GasFeeCap().Cmp(ap.MinGasPrice()) < 0 {
 		_actpoolMtc.WithLabelValues("gasPriceLower").Inc()
 		actHash, _ := selp.Hash()
-		log.L().Debug("action rejected due to low gas price",
+		log.Logger("actpool").Debug("action rejected due to low gas price",
 			zap.String("actionHash", hex.EncodeToString(actHash[:])),
 			zap.String("GasFeeCap", selp.GasFeeCap().String()))
 		return action.ErrUnderpriced
@@ -430,7 +524,7 @@ func (ap *actPool) GetSize() uint64 {
 
 // GetCapacity returns the act pool capacity
 func (ap *actPool) GetCapacity() uint64 {
-	return ap.cfg.MaxNumActsPerPool
+	return ap.limits.Load().MaxNumActsPerPool
 }
 
 // GetGasSize returns the act pool gas size
@@ -440,7 +534,29 @@ func (ap *actPool) GetGasSize() uint64 {
 
 // GetGasCapacity returns the act pool gas capacity
 func (ap *actPool) GetGasCapacity() uint64 {
-	return ap.cfg.MaxGasLimitPerPool
+	return ap.limits.Load().MaxGasLimitPerPool
+}
+
+func (ap *actPool) MinGasPrice() *big.Int {
+	return new(big.Int).Set(ap.minGasPrice.Load())
+}
+
+func (ap *actPool) SetMinGasPrice(price *big.Int) {
+	ap.minGasPrice.Store(new(big.Int).Set(price))
+	log.Logger("actpool").Info("minimal gas price of actpool is set.", zap.String("minGasPrice", price.String()))
+}
+
+// Pause pauses or resumes accepting the actions
+func (ap *actPool) Pause(pause bool) {
+	ap.paused.Store(pause)
+}
+
+func (ap *actPool) SetLimits(limits Limits) {
+	ap.limits.Store(&limits)
+	log.Logger("actpool").Info("limits of actpool are set.",
+		zap.Uint64("maxNumActsPerPool", limits.MaxNumActsPerPool),
+		zap.Uint64("maxGasLimitPerPool", limits.MaxGasLimitPerPool),
+		zap.Uint64("maxNumActsPerAcct", limits.MaxNumActsPerAcct))
 }
 
 func (ap *actPool) Validate(ctx context.Context, selp *action.SealedEnvelope) error {
@@ -453,7 +569,7 @@ func (ap *actPool) DeleteAction(caller address.Address) {
 	}
 	worker := ap.worker[ap.allocatedWorker(caller)]
 	if pendingActs := worker.ResetAccount(caller); len(pendingActs) != 0 {
-		ap.removeInvalidActs(pendingActs)
+		ap.removeInvalidActs(pendingActs, EvictionInvalid)
 	}
 }
 
@@ -488,35 +604,84 @@ func (ap *actPool) validate(ctx context.Context, selp *action.SealedEnvelope) er
 	return nil
 }
 
-func (ap *actPool) removeInvalidActs(acts []*action.SealedEnvelope) {
+// sweep evicts the actions which have stayed in the pool longer than their lifetime
+func (ap *actPool) sweep() {
+	var wg sync.WaitGroup
+	for i := range ap.worker {
+		wg.Add(1)
+		go func(worker *queueWorker) {
+			defer wg.Done()
+			worker.Sweep()
+		}(ap.worker[i])
+	}
+	wg.Wait()
+}
+
+func (ap *actPool) removeInvalidActs(acts []*action.SealedEnvelope, reason EvictionReason) {
 	for _, act := range acts {
 		hash, err := act.Hash()
 		if err != nil {
-			log.L().Debug("Skipping action due to hash error", zap.Error(err))
+			log.Logger("actpool").Debug("Skipping action due to hash error", zap.Error(err))
 			continue
 		}
-		log.L().Debug("Removed invalidated action.", log.Hex("hash", hash[:]))
+		log.Logger("actpool").Debug("Removed invalidated action.", log.Hex("hash", hash[:]))
 		ap.allActions.Delete(hash)
 		intrinsicGas, _ := act.IntrinsicGas()
 		atomic.AddUint64(&ap.gasInPool, ^uint64(intrinsicGas-1))
 		ap.accountDesActs.delete(act)
 		if ap.store != nil {
 			if err = ap.store.Delete(hash); err != nil {
-				log.L().Warn("Failed to delete action from store", zap.Error(err), log.Hex("hash", hash[:]))
+				log.Logger("actpool").Warn("Failed to delete action from store", zap.Error(err), log.Hex("hash", hash[:]))
 			}
 		}
-		ap.onRemoved(act)
+		_actpoolEvictionMtc.WithLabelValues(string(reason)).Inc()
+		ap.onRemoved(act, reason)
 	}
 }
 
 func (ap *actPool) context(ctx context.Context) context.Context {
 	height, _ := ap.sf.Height()
+	if ap.registry != nil {
+		ctx = protocol.WithRegistry(ctx, ap.registry)
+	}
 	return protocol.WithFeatureCtx(protocol.WithBlockCtx(
 		genesis.WithGenesisContext(ctx, ap.g), protocol.BlockCtx{
 			BlockHeight: height + 1,
 		}))
 }
 
+// updateSponsors refreshes the gas sponsors active at the next height of the context
+func (ap *actPool) updateSponsors(ctx context.Context) *sponsorCtx {
+	if ap.registry == nil {
+		return nil
+	}
+	sc := &sponsorCtx{ctx: ctx, sponsors: protocol.GasSponsors(ctx)}
+	ap.sponsors.Store(sc)
+	return sc
+}
+
+// cost returns the cost of the action charged from the balance of the sender, which excludes the gas fee paid by
+// the gas sponsors active at the next height
+func (ap *actPool) cost(act *action.SealedEnvelope) (*big.Int, error) {
+	if ap == nil || ap.registry == nil {
+		return act.Cost()
+	}
+	sc := ap.sponsors.Load()
+	if sc == nil {
+		sc = ap.updateSponsors(ap.context(context.Background()))
+	}
+	if len(sc.sponsors) == 0 {
+		return act.Cost()
+	}
+	cost, err := protocol.CallerCost(sc.ctx, ap.sf, act, sc.sponsors)
+	if err != nil {
+		// the sender pays the full cost if the gas sponsors fail
+		log.L().Debug("failed to get the cost of action charged from the sender", zap.Error(err))
+		return act.Cost()
+	}
+	return cost, nil
+}
+
 func (ap *actPool) enqueue(ctx context.Context, act *action.SealedEnvelope, replace bool) error {
 	var errChan = make(chan error, 1) // unused errChan will be garbage-collected
 	ap.jobQueue[ap.allocatedWorker(act.SenderAddress())] <- workerJob{
@@ -553,9 +718,9 @@ func (ap *actPool) onAdded(ctx context.Context, act *action.SealedEnvelope) {
 	}
 }
 
-func (ap *actPool) onRemoved(act *action.SealedEnvelope) {
+func (ap *actPool) onRemoved(act *action.SealedEnvelope, reason EvictionReason) {
 	for _, sub := range ap.subs {
-		sub.OnRemoved(act)
+		sub.OnRemoved(act, reason)
 	}
 }
 
@@ -606,7 +771,7 @@ func (des *destinationMap) delete(act *action.SealedEnvelope) {
 	}
 	hash, err := act.Hash()
 	if err != nil {
-		log.L().Debug("Skipping action due to hash error", zap.Error(err))
+		log.Logger("actpool").Debug("Skipping action due to hash error", zap.Error(err))
 		return
 	}
 	dst, exist := des.acts[desAddress]
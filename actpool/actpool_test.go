@@ -19,6 +19,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/holiman/uint256"
+	"github.com/iotexproject/go-pkgs/crypto"
 	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"github.com/mohae/deepcopy"
@@ -39,6 +40,7 @@ import (
 	"github.com/iotexproject/iotex-core/v2/state"
 	"github.com/iotexproject/iotex-core/v2/test/identityset"
 	"github.com/iotexproject/iotex-core/v2/test/mock/mock_chainmanager"
+	"github.com/iotexproject/iotex-core/v2/test/mock/mock_poll"
 	"github.com/iotexproject/iotex-core/v2/test/mock/mock_sealed_envelope_validator"
 )
 
@@ -92,6 +94,89 @@ func TestActPool_NewActPool(t *testing.T) {
 
 	// test AddAction nil
 	require.NotPanics(func() { act.AddActionEnvelopeValidators(nil) }, "option is nil")
+
+	// error caused by invalid ordering policy
+	cfg := DefaultConfig
+	cfg.OrderingPolicy = "random"
+	_, err = NewActPool(g, sf, cfg)
+	require.ErrorIs(err, ErrInvalidOrderingPolicy)
+}
+
+func TestActPool_ActionIteratorOptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	require := require.New(t)
+	sf := mock_chainmanager.NewMockStateReader(ctrl)
+	ctx := protocol.WithBlockCtx(context.Background(), protocol.BlockCtx{BaseFee: big.NewInt(unit.Qev)})
+	pp := mock_poll.NewMockProtocol(ctrl)
+	pp.EXPECT().Delegates(gomock.Any(), gomock.Any()).Return(state.CandidateList{}, nil).AnyTimes()
+	re := protocol.NewRegistry()
+	require.NoError(re.Register("poll", pp))
+	for _, v := range []struct {
+		policy      string
+		maxPriority uint64
+		numOpts     int
+	}{
+		{OrderByGasPrice, 0, 0},
+		{OrderByGasPrice, 8, 1},
+		{OrderByEffectiveTip, 8, 2},
+		{OrderByArrivalTime, 0, 1},
+		{OrderByRoundRobin, 8, 2},
+	} {
+		cfg := DefaultConfig
+		cfg.OrderingPolicy = v.policy
+		cfg.MaxNumPriorityActsPerBlock = v.maxPriority
+		ap, err := NewActPool(genesis.TestDefault(), sf, cfg, WithRegistry(re))
+		require.NoError(err)
+		optioner, ok := ap.(ActionIteratorOptioner)
+		require.True(ok)
+		require.Len(optioner.ActionIteratorOptions(ctx), v.numOpts)
+	}
+	// no priority lane without the delegates
+	ap, err := NewActPool(genesis.TestDefault(), sf, DefaultConfig)
+	require.NoError(err)
+	require.Empty(ap.(ActionIteratorOptioner).ActionIteratorOptions(ctx))
+}
+
+func TestActPool_PriorityLane(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	require := require.New(t)
+	sf := mock_chainmanager.NewMockStateReader(ctrl)
+	pp := mock_poll.NewMockProtocol(ctrl)
+	re := protocol.NewRegistry()
+	require.NoError(re.Register("poll", pp))
+	Ap, err := NewActPool(genesis.TestDefault(), sf, DefaultConfig, WithRegistry(re))
+	require.NoError(err)
+	ap, ok := Ap.(*actPool)
+	require.True(ok)
+
+	claim := func(sk crypto.PrivateKey) *action.SealedEnvelope {
+		selp, err := action.SignedClaimReward(1, 100000, big.NewInt(0), sk, big.NewInt(1), nil, nil)
+		require.NoError(err)
+		return selp
+	}
+	operator, reward, other := identityset.PrivateKey(1), identityset.PrivateKey(2), identityset.PrivateKey(3)
+	tsf, err := action.SignedTransfer(_addr1, operator, 1, big.NewInt(1), nil, 0, big.NewInt(0))
+	require.NoError(err)
+
+	ctx := context.Background()
+	pp.EXPECT().Delegates(gomock.Any(), sf).Return(state.CandidateList{
+		{
+			Address:       operator.PublicKey().Address().String(),
+			RewardAddress: reward.PublicKey().Address().String(),
+		},
+	}, nil).Times(1)
+	isPriority := ap.priorityLane(ctx)
+	require.NotNil(isPriority)
+	require.True(isPriority(claim(operator)))
+	require.True(isPriority(claim(reward)))
+	// a claim from a non-delegate does not get priority
+	require.False(isPriority(claim(other)))
+	// neither does a delegate's non-governance action
+	require.False(isPriority(tsf))
+
+	// no priority lane if the delegates are unknown
+	pp.EXPECT().Delegates(gomock.Any(), sf).Return(nil, errors.New("mock error")).Times(1)
+	require.Nil(ap.priorityLane(ctx))
 }
 
 func TestValidate(t *testing.T) {
@@ -175,6 +260,10 @@ func TestActPool_AddActs(t *testing.T) {
 	require.NoError(err)
 
 	ctx := genesis.WithGenesisContext(context.Background(), genesis.TestDefault())
+	// the paused actpool rejects the actions
+	ap.Pause(true)
+	require.ErrorIs(ap.Add(ctx, tsf1), ErrPaused)
+	ap.Pause(false)
 	require.NoError(ap.Add(ctx, tsf1))
 	require.NoError(ap.Add(ctx, tsf2))
 	require.NoError(ap.Add(ctx, tsf3))
@@ -877,13 +966,60 @@ func TestActPool_removeInvalidActs(t *testing.T) {
 	require.True(exist1)
 	_, exist2 := ap.allActions.Get(hash2)
 	require.True(exist2)
-	ap.removeInvalidActs(acts)
+	ap.removeInvalidActs(acts, EvictionInvalid)
 	_, exist1 = ap.allActions.Get(hash1)
 	require.False(exist1)
 	_, exist2 = ap.allActions.Get(hash2)
 	require.False(exist2)
 }
 
+type evictionRecorder struct {
+	reasons []EvictionReason
+}
+
+func (r *evictionRecorder) OnAdded(context.Context, *action.SealedEnvelope) {}
+
+func (r *evictionRecorder) OnRemoved(_ *action.SealedEnvelope, reason EvictionReason) {
+	r.reasons = append(r.reasons, reason)
+}
+
+func TestActPool_Sweep(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	require := require.New(t)
+	sf := mock_chainmanager.NewMockStateReader(ctrl)
+	apConfig := getActPoolCfg()
+	apConfig.MaxActionLifetime = 10 * time.Millisecond
+	Ap, err := NewActPool(genesis.TestDefault(), sf, apConfig)
+	require.NoError(err)
+	ap, ok := Ap.(*actPool)
+	require.True(ok)
+	recorder := &evictionRecorder{}
+	ap.AddSubscriber(recorder)
+
+	tsf1, err := action.SignedTransfer(_addr1, _priKey1, uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf2, err := action.SignedTransfer(_addr1, _priKey1, uint64(2), big.NewInt(20), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	sf.EXPECT().State(gomock.Any(), gomock.Any()).DoAndReturn(func(account interface{}, opts ...protocol.StateOption) (uint64, error) {
+		acct, ok := account.(*state.Account)
+		require.True(ok)
+		require.NoError(acct.AddBalance(big.NewInt(100000000000000000)))
+		return 0, nil
+	}).Times(1)
+	sf.EXPECT().Height().Return(uint64(1), nil).AnyTimes()
+	ctx := genesis.WithGenesisContext(context.Background(), genesis.TestDefault())
+	require.NoError(ap.Add(ctx, tsf1))
+	require.NoError(ap.Add(ctx, tsf2))
+	ap.sweep()
+	require.Equal(uint64(2), ap.GetSize())
+	require.Empty(recorder.reasons)
+
+	time.Sleep(20 * time.Millisecond)
+	ap.sweep()
+	require.Zero(ap.GetSize())
+	require.Equal([]EvictionReason{EvictionExpired, EvictionExpired}, recorder.reasons)
+}
+
 func TestActPool_GetPendingNonce(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	require := require.New(t)
@@ -1029,6 +1165,11 @@ func TestActPool_GetCapacity(t *testing.T) {
 	require.True(ok)
 	require.Equal(uint64(_maxNumActsPerPool), ap.GetCapacity())
 	require.Equal(uint64(_maxGasLimitPerPool), ap.GetGasCapacity())
+
+	ap.SetLimits(Limits{MaxNumActsPerPool: 10, MaxGasLimitPerPool: 100000, MaxNumActsPerAcct: 1})
+	require.Equal(uint64(10), ap.GetCapacity())
+	require.Equal(uint64(100000), ap.GetGasCapacity())
+	require.Equal(uint64(1), ap.limits.Load().MaxNumActsPerAcct)
 }
 
 func TestActPool_GetSize(t *testing.T) {
@@ -1226,3 +1367,124 @@ func TestValidateMinGasPrice(t *testing.T) {
 	mgp := ap.MinGasPrice()
 	require.IsType(t, &big.Int{}, mgp)
 }
+
+func TestActPool_SetMinGasPrice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	require := require.New(t)
+	sf := mock_chainmanager.NewMockStateReader(ctrl)
+	Ap, err := NewActPool(genesis.TestDefault(), sf, getActPoolCfg())
+	require.NoError(err)
+	ap, ok := Ap.(*actPool)
+	require.True(ok)
+	require.Zero(ap.MinGasPrice().Sign())
+
+	price := big.NewInt(100)
+	ap.SetMinGasPrice(price)
+	// the pool keeps its own copy
+	price.SetInt64(1)
+	require.Equal(big.NewInt(100), ap.MinGasPrice())
+
+	tsf, err := action.SignedTransfer(_addr1, _priKey1, uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(10))
+	require.NoError(err)
+	require.ErrorIs(ap.checkSelpWithoutState(context.Background(), tsf), action.ErrUnderpriced)
+	ap.SetMinGasPrice(big.NewInt(10))
+	require.NoError(ap.checkSelpWithoutState(context.Background(), tsf))
+}
+
+func TestActPool_CheckAction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	require := require.New(t)
+	sf := mock_chainmanager.NewMockStateReader(ctrl)
+	sf.EXPECT().State(gomock.Any(), gomock.Any()).DoAndReturn(func(account interface{}, opts ...protocol.StateOption) (uint64, error) {
+		acct, ok := account.(*state.Account)
+		require.True(ok)
+		require.NoError(acct.AddBalance(big.NewInt(100)))
+		return 0, nil
+	}).AnyTimes()
+	sf.EXPECT().Height().Return(uint64(1), nil).AnyTimes()
+	apConfig := getActPoolCfg()
+	Ap, err := NewActPool(genesis.TestDefault(), sf, apConfig)
+	require.NoError(err)
+	ap, ok := Ap.(*actPool)
+	require.True(ok)
+	ap.AddActionEnvelopeValidators(protocol.NewGenericValidator(sf, accountutil.AccountState))
+	ctx := genesis.WithGenesisContext(context.Background(), genesis.TestDefault())
+
+	tsf1, err := action.SignedTransfer(_addr1, _priKey1, uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	require.NoError(ap.CheckAction(ctx, tsf1))
+	// the action is not added
+	require.Zero(ap.GetSize())
+	require.NoError(ap.Add(ctx, tsf1))
+	require.ErrorIs(ap.CheckAction(ctx, tsf1), action.ErrExistedInPool)
+
+	for _, c := range []struct {
+		nonce    uint64
+		amount   int64
+		gasLimit uint64
+		err      error
+	}{
+		{1, 20, 100000, action.ErrReplaceUnderpriced},
+		{2, 200, 100000, action.ErrInsufficientFunds},
+		{2, 10, 100, action.ErrIntrinsicGas},
+		{2 + apConfig.MaxNumActsPerAcct, 10, 100000, action.ErrNonceTooHigh},
+		{2, 10, 100000, nil},
+	} {
+		tsf, err := action.SignedTransfer(_addr1, _priKey1, c.nonce, big.NewInt(c.amount), []byte{}, c.gasLimit, big.NewInt(0))
+		require.NoError(err)
+		require.Equal(c.err, errors.Cause(ap.CheckAction(ctx, tsf)))
+	}
+	require.Equal(uint64(1), ap.GetSize())
+}
+
+type testSponsor struct {
+	protocol.Protocol
+	active bool
+	calls  int
+}
+
+func (s *testSponsor) SponsorsGas(context.Context) bool {
+	return s.active
+}
+
+func (s *testSponsor) SponsoredGasFee(context.Context, protocol.StateReader, *action.SealedEnvelope) (*big.Int, error) {
+	s.calls++
+	return big.NewInt(100), nil
+}
+
+func TestActPool_GasSponsor(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	sf := mock_chainmanager.NewMockStateReader(ctrl)
+
+	sponsor := &testSponsor{Protocol: account.NewProtocol(rewarding.DepositGas)}
+	re := protocol.NewRegistry()
+	require.NoError(re.Register("sponsor", sponsor))
+	pool, err := NewActPool(genesis.TestDefault(), sf, DefaultConfig, WithRegistry(re))
+	require.NoError(err)
+	ap := pool.(*actPool)
+	acts := make([]*action.SealedEnvelope, 3)
+	for i := range acts {
+		acts[i], err = action.SignedTransfer(_addr1, _priKey1, uint64(i+1), big.NewInt(10), nil, 10000, big.NewInt(1))
+		require.NoError(err)
+	}
+
+	// the sponsors are looked up once until the next reset, and skipped if not active
+	sf.EXPECT().Height().Return(uint64(1), nil).Times(1)
+	for _, act := range acts {
+		cost, err := ap.cost(act)
+		require.NoError(err)
+		require.Equal(big.NewInt(10010), cost)
+	}
+	require.Zero(sponsor.calls)
+
+	sponsor.active = true
+	sf.EXPECT().Height().Return(uint64(2), nil).Times(1)
+	ap.Reset()
+	for _, act := range acts {
+		cost, err := ap.cost(act)
+		require.NoError(err)
+		require.Equal(big.NewInt(9910), cost)
+	}
+	require.Equal(len(acts), sponsor.calls)
+}
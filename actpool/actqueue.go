@@ -27,6 +27,7 @@ import (
 // ActQueue is the interface of actQueue
 type ActQueue interface {
 	Put(*action.SealedEnvelope) error
+	Check(*action.SealedEnvelope) error
 	UpdateQueue() []*action.SealedEnvelope
 	UpdateAccountState(uint64, *big.Int) []*action.SealedEnvelope
 	AccountState() (uint64, *big.Int)
@@ -37,6 +38,7 @@ type ActQueue interface {
 	PendingActs(context.Context) []*action.SealedEnvelope
 	AllActs() []*action.SealedEnvelope
 	PopActionWithLargestNonce() *action.SealedEnvelope
+	ArrivalTime(uint64) (time.Time, bool)
 	Reset()
 }
 
@@ -59,7 +61,9 @@ type actQueue struct {
 	accountBalance *big.Int
 	clock          clock.Clock
 	ttl            time.Duration
-	mu             sync.RWMutex
+	// lifetime is the max time an action can stay in the queue, even if it is pending
+	lifetime time.Duration
+	mu       sync.RWMutex
 }
 
 // NewActQueue create a new action queue
@@ -96,51 +100,27 @@ func (q *actQueue) NextAction() (bool, *big.Int) {
 func (q *actQueue) Put(act *action.SealedEnvelope) error {
 	q.mu.Lock()
 	defer q.mu.Unlock()
-	nonce := act.Nonce()
-
-	if cost, _ := act.Cost(); q.getPendingBalanceAtNonce(nonce).Cmp(cost) < 0 {
-		return action.ErrInsufficientFunds
+	if err := q.check(act); err != nil {
+		return err
 	}
-
+	nonce := act.Nonce()
 	if actInPool, exist := q.items[nonce]; exist {
-		// act of higher gas price can cut in line
-		if nonce < q.pendingNonce && act.GasFeeCap().Cmp(actInPool.GasFeeCap()) != 1 {
-			return errors.Wrapf(action.ErrReplaceUnderpriced, "gas fee cap %s < %s", act.GasFeeCap(), actInPool.GasFeeCap())
-		}
-		// 2x bumps in gas price are allowed for blob tx
-		isPrevBlobTx, isBlobTx := len(actInPool.BlobHashes()) > 0, len(act.BlobHashes()) > 0
-		if isPrevBlobTx {
-			if !isBlobTx {
-				return errors.Wrap(action.ErrReplaceUnderpriced, "blob tx can only replace blob tx")
-			}
-			var (
-				priceBump        = big.NewInt(2)
-				minGasFeeCap     = new(big.Int).Mul(actInPool.GasFeeCap(), priceBump)
-				minGasTipCap     = new(big.Int).Mul(actInPool.GasTipCap(), priceBump)
-				minBlobGasFeeCap = new(big.Int).Mul(actInPool.BlobGasFeeCap(), priceBump)
-			)
-			switch {
-			case act.GasFeeCap().Cmp(minGasFeeCap) < 0:
-				return errors.Wrapf(action.ErrReplaceUnderpriced, "gas fee cap %s < %s", act.GasFeeCap(), minGasFeeCap)
-			case act.GasTipCap().Cmp(minGasTipCap) < 0:
-				return errors.Wrapf(action.ErrReplaceUnderpriced, "gas tip cap %s < %s", act.GasTipCap(), minGasTipCap)
-			case act.BlobGasFeeCap().Cmp(minBlobGasFeeCap) < 0:
-				return errors.Wrapf(action.ErrReplaceUnderpriced, "blob gas fee cap %s < %s", act.BlobGasFeeCap(), minBlobGasFeeCap)
-			}
-		}
 		// update action in q.items and q.index
 		q.items[nonce] = act
 		for i := range q.ascQueue {
 			if q.ascQueue[i].nonce == nonce {
-				q.ascQueue[i].deadline = q.clock.Now().Add(q.ttl)
+				now := q.clock.Now()
+				q.ascQueue[i].deadline = now.Add(q.ttl)
+				q.ascQueue[i].created = now
 				break
 			}
 		}
 		q.updateFromNonce(nonce)
-		q.ap.removeInvalidActs([]*action.SealedEnvelope{actInPool})
+		q.ap.removeInvalidActs([]*action.SealedEnvelope{actInPool}, EvictionReplaced)
 		return nil
 	}
-	nttl := &nonceWithTTL{nonce: nonce, deadline: q.clock.Now().Add(q.ttl)}
+	now := q.clock.Now()
+	nttl := &nonceWithTTL{nonce: nonce, deadline: now.Add(q.ttl), created: now}
 	heap.Push(&q.ascQueue, nttl)
 	heap.Push(&q.descQueue, nttl)
 	q.items[nonce] = act
@@ -150,6 +130,51 @@ func (q *actQueue) Put(act *action.SealedEnvelope) error {
 	return nil
 }
 
+// Check checks if the action could be put into the queue, without putting it
+func (q *actQueue) Check(act *action.SealedEnvelope) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.check(act)
+}
+
+func (q *actQueue) check(act *action.SealedEnvelope) error {
+	nonce := act.Nonce()
+	if cost, _ := q.ap.cost(act); q.getPendingBalanceAtNonce(nonce).Cmp(cost) < 0 {
+		return action.ErrInsufficientFunds
+	}
+	actInPool, exist := q.items[nonce]
+	if !exist {
+		return nil
+	}
+	// act of higher gas price can cut in line
+	if nonce < q.pendingNonce && act.GasFeeCap().Cmp(actInPool.GasFeeCap()) != 1 {
+		return errors.Wrapf(action.ErrReplaceUnderpriced, "gas fee cap %s < %s", act.GasFeeCap(), actInPool.GasFeeCap())
+	}
+	// 2x bumps in gas price are allowed for blob tx
+	isPrevBlobTx, isBlobTx := len(actInPool.BlobHashes()) > 0, len(act.BlobHashes()) > 0
+	if !isPrevBlobTx {
+		return nil
+	}
+	if !isBlobTx {
+		return errors.Wrap(action.ErrReplaceUnderpriced, "blob tx can only replace blob tx")
+	}
+	var (
+		priceBump        = big.NewInt(2)
+		minGasFeeCap     = new(big.Int).Mul(actInPool.GasFeeCap(), priceBump)
+		minGasTipCap     = new(big.Int).Mul(actInPool.GasTipCap(), priceBump)
+		minBlobGasFeeCap = new(big.Int).Mul(actInPool.BlobGasFeeCap(), priceBump)
+	)
+	switch {
+	case act.GasFeeCap().Cmp(minGasFeeCap) < 0:
+		return errors.Wrapf(action.ErrReplaceUnderpriced, "gas fee cap %s < %s", act.GasFeeCap(), minGasFeeCap)
+	case act.GasTipCap().Cmp(minGasTipCap) < 0:
+		return errors.Wrapf(action.ErrReplaceUnderpriced, "gas tip cap %s < %s", act.GasTipCap(), minGasTipCap)
+	case act.BlobGasFeeCap().Cmp(minBlobGasFeeCap) < 0:
+		return errors.Wrapf(action.ErrReplaceUnderpriced, "blob gas fee cap %s < %s", act.BlobGasFeeCap(), minBlobGasFeeCap)
+	}
+	return nil
+}
+
 func (q *actQueue) getPendingBalanceAtNonce(nonce uint64) *big.Int {
 	if nonce > q.pendingNonce {
 		return q.getPendingBalanceAtNonce(q.pendingNonce)
@@ -171,7 +196,7 @@ func (q *actQueue) updateFromNonce(start uint64) {
 			break
 		}
 
-		cost, _ := act.Cost()
+		cost, _ := q.ap.cost(act)
 		if balance.Cmp(cost) < 0 {
 			break
 		}
@@ -195,26 +220,43 @@ func (q *actQueue) UpdateQueue() []*action.SealedEnvelope {
 }
 
 func (q *actQueue) cleanTimeout() []*action.SealedEnvelope {
-	if q.ttl == 0 {
+	if q.ttl == 0 && q.lifetime == 0 {
 		return []*action.SealedEnvelope{}
 	}
 	var (
 		removedFromQueue = make([]*action.SealedEnvelope, 0)
 		timeNow          = q.clock.Now()
 		size             = len(q.ascQueue)
+		minOutlived      = q.pendingNonce
 	)
 	for i := 0; i < size; {
 		nonce := q.ascQueue[i].nonce
-		if timeNow.After(q.ascQueue[i].deadline) && nonce > q.pendingNonce {
+		timeout := q.ttl > 0 && timeNow.After(q.ascQueue[i].deadline) && nonce > q.pendingNonce
+		outlived := q.lifetime > 0 && timeNow.Sub(q.ascQueue[i].created) > q.lifetime
+		if timeout || outlived {
 			removedFromQueue = append(removedFromQueue, q.items[nonce])
 			delete(q.items, nonce)
-			delete(q.pendingBalance, nonce)
+			if nonce > q.pendingNonce {
+				delete(q.pendingBalance, nonce)
+			}
+			if nonce < minOutlived {
+				minOutlived = nonce
+			}
 			q.ascQueue[i] = q.ascQueue[size-1]
 			size--
 			continue
 		}
 		i++
 	}
+	// a pending action is removed, actions after it are no longer pending
+	if minOutlived < q.pendingNonce {
+		for nonce := range q.pendingBalance {
+			if nonce > minOutlived {
+				delete(q.pendingBalance, nonce)
+			}
+		}
+		q.pendingNonce = minOutlived
+	}
 	for i := 0; i < size; i++ {
 		q.descQueue[i] = q.ascQueue[i]
 		q.descQueue[i].ascIdx = i
@@ -297,13 +339,13 @@ func (q *actQueue) PendingActs(ctx context.Context) []*action.SealedEnvelope {
 	}
 	addr, err := address.FromString(q.address)
 	if err != nil {
-		log.L().Error("Error when getting the address", zap.String("address", q.address), zap.Error(err))
+		log.Logger("actpool").Error("Error when getting the address", zap.String("address", q.address), zap.Error(err))
 		return nil
 	}
 	// TODO: no need to refetch confirmed state, leave it to block builder to validate
 	confirmedState, err := accountutil.AccountState(ctx, q.ap.sf, addr)
 	if err != nil {
-		log.L().Error("Error when getting the nonce", zap.String("address", q.address), zap.Error(err))
+		log.Logger("actpool").Error("Error when getting the nonce", zap.String("address", q.address), zap.Error(err))
 		return nil
 	}
 
@@ -325,7 +367,7 @@ func (q *actQueue) PendingActs(ctx context.Context) []*action.SealedEnvelope {
 			break
 		}
 
-		cost, _ := act.Cost()
+		cost, _ := q.ap.cost(act)
 		if balance.Cmp(cost) < 0 {
 			break
 		}
@@ -363,3 +405,15 @@ func (q *actQueue) PopActionWithLargestNonce() *action.SealedEnvelope {
 	q.updateFromNonce(itemMeta.nonce)
 	return item
 }
+
+// ArrivalTime returns the time the action of nonce is put into the queue
+func (q *actQueue) ArrivalTime(nonce uint64) (time.Time, bool) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	for _, item := range q.ascQueue {
+		if item.nonce == nonce {
+			return item.created, true
+		}
+	}
+	return time.Time{}, false
+}
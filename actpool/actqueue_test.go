@@ -230,6 +230,33 @@ func TestActQueueCleanTimeout(t *testing.T) {
 	require.Equal(1, len(ret))
 }
 
+func TestActQueueLifetime(t *testing.T) {
+	require := require.New(t)
+	c := clock.NewMock()
+	q := NewActQueue(nil, "", 1, big.NewInt(maxBalance), WithClock(c), WithLifetime(5*time.Minute)).(*actQueue)
+	tsf1, err := action.SignedTransfer(_addr2, _priKey1, 1, big.NewInt(100), nil, uint64(0), big.NewInt(0))
+	require.NoError(err)
+	tsf2, err := action.SignedTransfer(_addr2, _priKey1, 2, big.NewInt(100), nil, uint64(0), big.NewInt(0))
+	require.NoError(err)
+	tsf3, err := action.SignedTransfer(_addr2, _priKey1, 3, big.NewInt(100), nil, uint64(0), big.NewInt(0))
+	require.NoError(err)
+
+	require.NoError(q.Put(tsf1))
+	c.Add(3 * time.Minute)
+	require.NoError(q.Put(tsf2))
+	require.NoError(q.Put(tsf3))
+	require.Equal(uint64(4), q.PendingNonce())
+	require.Empty(q.UpdateQueue())
+	// pending action is evicted once it outlives the lifetime
+	c.Add(3 * time.Minute)
+	require.Equal([]*action.SealedEnvelope{tsf1}, q.UpdateQueue())
+	require.Equal(2, q.Len())
+	require.Equal(uint64(1), q.PendingNonce())
+	c.Add(3 * time.Minute)
+	require.Len(q.UpdateQueue(), 2)
+	require.True(q.Empty())
+}
+
 // BenchmarkHeapInitAndRemove compare the heap re-establish performance between
 // using the heap.Init and the heap.Remove after remove some elements.
 // The bench result show that the performance of heap.Init is better than heap.Remove
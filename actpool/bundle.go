@@ -0,0 +1,226 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+)
+
+var (
+	// ErrInvalidBundle is the error of an invalid bundle
+	ErrInvalidBundle = errors.New("invalid bundle")
+	// ErrTooManyBundles is the error that the bundle pool is full
+	ErrTooManyBundles = errors.New("too many bundles")
+	// ErrBundleDisabled is the error that submitting the bundles is disabled
+	ErrBundleDisabled = errors.New("bundle is disabled")
+)
+
+type (
+	// Bundle is an ordered list of actions submitted to the local producer. The actions are kept out of the gossip
+	// and the pending actions of the pool, and are included in the order ahead of the other actions
+	Bundle struct {
+		Actions []*action.SealedEnvelope
+		// TargetHeight is the only height the bundle can be included at, 0 for any height
+		TargetHeight uint64
+		// AllOrNothing requires all the actions to succeed, otherwise none of them is included
+		AllOrNothing bool
+	}
+
+	// BundlePool is implemented by an actpool which keeps the private bundles for the local producer
+	BundlePool interface {
+		// SubmitBundle validates the bundle and keeps it until it is included or expired
+		SubmitBundle(context.Context, *Bundle) (hash.Hash256, error)
+		// PendingBundles returns the bundles to include at the height in the order of submission
+		PendingBundles(height uint64) []*Bundle
+	}
+
+	bundleEntry struct {
+		bundle    *Bundle
+		hash      hash.Hash256
+		submitted time.Time
+	}
+
+	bundlePool struct {
+		mutex   sync.Mutex
+		size    uint64
+		expiry  time.Duration
+		entries []*bundleEntry
+		// actions are the hashes of the actions in the bundles
+		actions map[hash.Hash256]hash.Hash256
+	}
+)
+
+// Hash returns the hash of the bundle, which is the hash of the hashes of the actions
+func (b *Bundle) Hash() (hash.Hash256, error) {
+	hashes := make([]byte, 0, len(b.Actions)*len(hash.ZeroHash256))
+	for _, selp := range b.Actions {
+		h, err := selp.Hash()
+		if err != nil {
+			return hash.ZeroHash256, err
+		}
+		hashes = append(hashes, h[:]...)
+	}
+	return hash.Hash256b(hashes), nil
+}
+
+func newBundlePool(size uint64, expiry time.Duration) *bundlePool {
+	return &bundlePool{
+		size:    size,
+		expiry:  expiry,
+		actions: make(map[hash.Hash256]hash.Hash256),
+	}
+}
+
+func (bp *bundlePool) add(bundle *Bundle) (hash.Hash256, error) {
+	bh, err := bundle.Hash()
+	if err != nil {
+		return hash.ZeroHash256, err
+	}
+	hashes := make([]hash.Hash256, len(bundle.Actions))
+	for i, selp := range bundle.Actions {
+		if hashes[i], err = selp.Hash(); err != nil {
+			return hash.ZeroHash256, err
+		}
+	}
+	bp.mutex.Lock()
+	defer bp.mutex.Unlock()
+	bp.evictExpired(time.Now())
+	if uint64(len(bp.entries)) >= bp.size {
+		return hash.ZeroHash256, errors.Wrapf(ErrTooManyBundles, "limit %d", bp.size)
+	}
+	for i, h := range hashes {
+		if _, ok := bp.actions[h]; ok {
+			return hash.ZeroHash256, errors.Wrapf(ErrInvalidBundle, "action %x is already in a bundle", h)
+		}
+		for _, prev := range hashes[:i] {
+			if prev == h {
+				return hash.ZeroHash256, errors.Wrapf(ErrInvalidBundle, "action %x is duplicated", h)
+			}
+		}
+	}
+	for _, h := range hashes {
+		bp.actions[h] = bh
+	}
+	bp.entries = append(bp.entries, &bundleEntry{bundle: bundle, hash: bh, submitted: time.Now()})
+	return bh, nil
+}
+
+func (bp *bundlePool) pending(height uint64) []*Bundle {
+	if bp == nil {
+		return nil
+	}
+	bp.mutex.Lock()
+	defer bp.mutex.Unlock()
+	bp.evictExpired(time.Now())
+	var ret []*Bundle
+	for _, e := range bp.entries {
+		if e.bundle.TargetHeight == 0 || e.bundle.TargetHeight == height {
+			ret = append(ret, e.bundle)
+		}
+	}
+	return ret
+}
+
+// receiveBlock removes the bundles of which an action is included in the block, or the target height is passed
+func (bp *bundlePool) receiveBlock(blk *block.Block) {
+	if bp == nil || blk == nil {
+		return
+	}
+	bp.mutex.Lock()
+	defer bp.mutex.Unlock()
+	included := make(map[hash.Hash256]struct{})
+	for _, selp := range blk.Actions {
+		h, err := selp.Hash()
+		if err != nil {
+			continue
+		}
+		if bh, ok := bp.actions[h]; ok {
+			included[bh] = struct{}{}
+		}
+	}
+	bp.filter(func(e *bundleEntry) bool {
+		if _, ok := included[e.hash]; ok {
+			return false
+		}
+		return e.bundle.TargetHeight == 0 || e.bundle.TargetHeight > blk.Height()
+	})
+}
+
+func (bp *bundlePool) evictExpired(now time.Time) {
+	if bp.expiry <= 0 {
+		return
+	}
+	bp.filter(func(e *bundleEntry) bool {
+		return now.Sub(e.submitted) < bp.expiry
+	})
+}
+
+// filter keeps the bundles for which keep returns true
+func (bp *bundlePool) filter(keep func(*bundleEntry) bool) {
+	kept := bp.entries[:0]
+	for _, e := range bp.entries {
+		if keep(e) {
+			kept = append(kept, e)
+			continue
+		}
+		for _, selp := range e.bundle.Actions {
+			if h, err := selp.Hash(); err == nil {
+				delete(bp.actions, h)
+			}
+		}
+	}
+	for i := len(kept); i < len(bp.entries); i++ {
+		bp.entries[i] = nil
+	}
+	bp.entries = kept
+}
+
+// SubmitBundle validates the actions of the bundle without the state, as the nonces and balances are checked when
+// the bundle is run on top of the state by the producer
+func (ap *actPool) SubmitBundle(ctx context.Context, bundle *Bundle) (hash.Hash256, error) {
+	if ap.bundles == nil {
+		return hash.ZeroHash256, ErrBundleDisabled
+	}
+	if len(bundle.Actions) == 0 {
+		return hash.ZeroHash256, errors.Wrap(ErrInvalidBundle, "no action in the bundle")
+	}
+	height, err := ap.sf.Height()
+	if err != nil {
+		return hash.ZeroHash256, err
+	}
+	if bundle.TargetHeight != 0 && bundle.TargetHeight <= height {
+		return hash.ZeroHash256, errors.Wrapf(ErrInvalidBundle, "target height %d is not higher than current height %d", bundle.TargetHeight, height)
+	}
+	ctx = ap.context(ctx)
+	for _, selp := range bundle.Actions {
+		if action.IsSystemAction(selp) {
+			return hash.ZeroHash256, errors.Wrap(ErrInvalidBundle, "system action in the bundle")
+		}
+		if len(selp.BlobHashes()) > 0 {
+			return hash.ZeroHash256, errors.Wrap(ErrInvalidBundle, "blob action in the bundle")
+		}
+		if err := checkSelpData(selp); err != nil {
+			return hash.ZeroHash256, err
+		}
+		if err := ap.checkSelpWithoutState(ctx, selp); err != nil {
+			return hash.ZeroHash256, err
+		}
+	}
+	return ap.bundles.add(bundle)
+}
+
+// PendingBundles returns the bundles to include at the height in the order of submission
+func (ap *actPool) PendingBundles(height uint64) []*Bundle {
+	return ap.bundles.pending(height)
+}
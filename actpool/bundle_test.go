@@ -0,0 +1,115 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+	"github.com/iotexproject/iotex-core/v2/test/mock/mock_chainmanager"
+	"github.com/iotexproject/iotex-core/v2/testutil"
+)
+
+func TestBundlePool(t *testing.T) {
+	r := require.New(t)
+	tsf1, err := action.SignedTransfer(_addr2, _priKey1, 1, big.NewInt(10), nil, testutil.TestGasLimit, big.NewInt(testutil.TestGasPriceInt64))
+	r.NoError(err)
+	tsf2, err := action.SignedTransfer(_addr1, _priKey2, 1, big.NewInt(10), nil, testutil.TestGasLimit, big.NewInt(testutil.TestGasPriceInt64))
+	r.NoError(err)
+	tsf3, err := action.SignedTransfer(_addr1, _priKey3, 1, big.NewInt(10), nil, testutil.TestGasLimit, big.NewInt(testutil.TestGasPriceInt64))
+	r.NoError(err)
+
+	bp := newBundlePool(2, time.Hour)
+	b1 := &Bundle{Actions: []*action.SealedEnvelope{tsf1, tsf2}, TargetHeight: 3}
+	h1, err := bp.add(b1)
+	r.NoError(err)
+	expected, err := b1.Hash()
+	r.NoError(err)
+	r.Equal(expected, h1)
+	// the actions can be in only one bundle
+	_, err = bp.add(&Bundle{Actions: []*action.SealedEnvelope{tsf2}})
+	r.ErrorIs(err, ErrInvalidBundle)
+	_, err = bp.add(&Bundle{Actions: []*action.SealedEnvelope{tsf3, tsf3}})
+	r.ErrorIs(err, ErrInvalidBundle)
+	b2 := &Bundle{Actions: []*action.SealedEnvelope{tsf3}}
+	_, err = bp.add(b2)
+	r.NoError(err)
+	_, err = bp.add(&Bundle{Actions: []*action.SealedEnvelope{tsf3}})
+	r.ErrorIs(err, ErrTooManyBundles)
+
+	r.Equal([]*Bundle{b2}, bp.pending(2))
+	r.Equal([]*Bundle{b1, b2}, bp.pending(3))
+
+	// the bundle is removed once an action of it is included
+	blk, err := block.NewTestingBuilder().SetHeight(2).SetTimeStamp(time.Now()).
+		AddActions(tsf3).SignAndBuild(identityset.PrivateKey(0))
+	r.NoError(err)
+	bp.receiveBlock(&blk)
+	r.Equal([]*Bundle{b1}, bp.pending(3))
+	r.Len(bp.actions, 2)
+	// the bundle is removed once the target height is passed
+	blk, err = block.NewTestingBuilder().SetHeight(3).SetTimeStamp(time.Now()).SignAndBuild(identityset.PrivateKey(0))
+	r.NoError(err)
+	bp.receiveBlock(&blk)
+	r.Empty(bp.pending(4))
+	r.Empty(bp.actions)
+
+	// the bundle is removed once expired
+	bp = newBundlePool(2, time.Millisecond)
+	_, err = bp.add(b2)
+	r.NoError(err)
+	time.Sleep(2 * time.Millisecond)
+	r.Empty(bp.pending(0))
+	r.Empty(bp.actions)
+}
+
+func TestActPool_SubmitBundle(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+	sf := mock_chainmanager.NewMockStateReader(ctrl)
+	sf.EXPECT().Height().Return(uint64(5), nil).AnyTimes()
+	tsf, err := action.SignedTransfer(_addr2, _priKey1, 1, big.NewInt(10), nil, testutil.TestGasLimit, big.NewInt(testutil.TestGasPriceInt64))
+	r.NoError(err)
+	ctx := context.Background()
+
+	cfg := getActPoolCfg()
+	ap, err := NewActPool(genesis.TestDefault(), sf, cfg)
+	r.NoError(err)
+	_, err = ap.(BundlePool).SubmitBundle(ctx, &Bundle{Actions: []*action.SealedEnvelope{tsf}})
+	r.Equal(ErrBundleDisabled, errors.Cause(err))
+
+	cfg.MaxNumBundles = 1
+	ap, err = NewActPool(genesis.TestDefault(), sf, cfg)
+	r.NoError(err)
+	bp := ap.(BundlePool)
+	_, err = bp.SubmitBundle(ctx, &Bundle{})
+	r.ErrorIs(err, ErrInvalidBundle)
+	_, err = bp.SubmitBundle(ctx, &Bundle{Actions: []*action.SealedEnvelope{tsf}, TargetHeight: 5})
+	r.ErrorIs(err, ErrInvalidBundle)
+	blackListed, err := action.SignedTransfer(_addr1, _priKey6, 1, big.NewInt(10), nil, testutil.TestGasLimit, big.NewInt(testutil.TestGasPriceInt64))
+	r.NoError(err)
+	_, err = bp.SubmitBundle(ctx, &Bundle{Actions: []*action.SealedEnvelope{tsf, blackListed}})
+	r.ErrorIs(err, action.ErrAddress)
+	r.Empty(bp.PendingBundles(6))
+
+	bundle := &Bundle{Actions: []*action.SealedEnvelope{tsf}, TargetHeight: 6}
+	_, err = bp.SubmitBundle(ctx, bundle)
+	r.NoError(err)
+	r.Equal([]*Bundle{bundle}, bp.PendingBundles(6))
+	r.Empty(bp.PendingBundles(7))
+	// the bundle actions are kept out of the pending actions
+	r.Empty(ap.PendingActionMap())
+}
@@ -11,14 +11,20 @@ import (
 var (
 	// DefaultConfig is the default config for actpool
 	DefaultConfig = Config{
-		MaxNumActsPerPool:  32000,
-		MaxGasLimitPerPool: 320000000,
-		MaxNumActsPerAcct:  2000,
-		WorkerBufferSize:   2000,
-		ActionExpiry:       10 * time.Minute,
-		MinGasPriceStr:     big.NewInt(unit.Qev).String(),
-		BlackList:          []string{},
-		MaxNumBlobsPerAcct: 16,
+		MaxNumActsPerPool:          32000,
+		MaxGasLimitPerPool:         320000000,
+		MaxNumActsPerAcct:          2000,
+		WorkerBufferSize:           2000,
+		ActionExpiry:               10 * time.Minute,
+		SweepInterval:              time.Minute,
+		MinGasPriceStr:             big.NewInt(unit.Qev).String(),
+		BlackList:                  []string{},
+		MaxNumBlobsPerAcct:         16,
+		MaxNumPriorityActsPerBlock: 8,
+		OrderingPolicy:             OrderByGasPrice,
+		InclusionLatencyWindow:     1000,
+		MaxNumBundles:              16,
+		SeenCacheTTL:               10 * time.Minute,
 		Store: &StoreConfig{
 			Datadir: "/var/data/actpool.cache",
 		},
@@ -37,6 +43,12 @@ type Config struct {
 	WorkerBufferSize uint64 `yaml:"bufferPerAcct"`
 	// ActionExpiry defines how long an action will be kept in action pool.
 	ActionExpiry time.Duration `yaml:"actionExpiry"`
+	// MaxActionLifetime defines the max time an action can be kept in action pool even if it is
+	// ready to be packed into a block, 0 means no limit
+	MaxActionLifetime time.Duration `yaml:"maxActionLifetime"`
+	// SweepInterval defines how often expired actions are evicted from action pool, 0 disables
+	// the background sweeper and expired actions are only evicted upon new blocks
+	SweepInterval time.Duration `yaml:"sweepInterval"`
 	// MinGasPriceStr defines the minimal gas price the delegate will accept for an action
 	MinGasPriceStr string `yaml:"minGasPrice"`
 	// BlackList lists the account address that are banned from initiating actions
@@ -45,6 +57,21 @@ type Config struct {
 	Store *StoreConfig `yaml:"store"`
 	// MaxNumBlobsPerAcct defines the maximum number of blob txs an account can have
 	MaxNumBlobsPerAcct uint64 `yaml:"maxNumBlobsPerAcct"`
+	// MaxNumPriorityActsPerBlock defines the maximum number of consensus-critical actions picked
+	// ahead of all other actions regardless of fee when minting a block, 0 disables the priority lane
+	MaxNumPriorityActsPerBlock uint64 `yaml:"maxNumPriorityActsPerBlock"`
+	// OrderingPolicy defines the order in which actions of different senders are picked when
+	// minting a block, one of "gasPrice", "effectiveTip", "arrivalTime" and "roundRobin"
+	OrderingPolicy string `yaml:"orderingPolicy"`
+	// InclusionLatencyWindow defines the number of the latest actions of each producer the inclusion latency
+	// distribution is calculated from, 0 disables tracking the inclusion latencies
+	InclusionLatencyWindow uint64 `yaml:"inclusionLatencyWindow"`
+	// MaxNumBundles defines the maximum number of the private action bundles kept for the local producer, 0 disables
+	// submitting the bundles
+	MaxNumBundles uint64 `yaml:"maxNumBundles"`
+	// SeenCacheTTL defines how long the signature verdict of an action is remembered since it is last seen, so the
+	// duplicated broadcasts are not verified again, 0 disables the cache
+	SeenCacheTTL time.Duration `yaml:"seenCacheTTL"`
 }
 
 // MinGasPrice returns the minimal gas price threshold
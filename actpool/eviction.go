@@ -0,0 +1,35 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// EvictionReason is the reason an action is removed from the actpool
+type EvictionReason string
+
+const (
+	// EvictionConfirmed means the action has been committed to a block
+	EvictionConfirmed EvictionReason = "confirmed"
+	// EvictionExpired means the action has stayed in the pool longer than its lifetime
+	EvictionExpired EvictionReason = "expired"
+	// EvictionReplaced means the action has been replaced by another action of the same nonce
+	EvictionReplaced EvictionReason = "replaced"
+	// EvictionOverflow means the action has been dropped to make room for a new action in a full pool
+	EvictionOverflow EvictionReason = "overflow"
+	// EvictionInvalid means the action is no longer valid, e.g., it failed to execute during minting
+	EvictionInvalid EvictionReason = "invalid"
+)
+
+var _actpoolEvictionMtc = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "iotex_actpool_eviction_metrics",
+	Help: "actpool eviction metrics by reason.",
+}, []string{"reason"})
+
+func init() {
+	prometheus.MustRegister(_actpoolEvictionMtc)
+}
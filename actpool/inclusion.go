@@ -0,0 +1,174 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+)
+
+var _inclusionLatencyMtc = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "iotex_actpool_inclusion_latency_seconds",
+	Help:    "Latency from an action arriving at the actpool to being included in a block, by producer.",
+	Buckets: []float64{1, 2.5, 5, 10, 15, 30, 60, 120, 300, 600},
+}, []string{"producer"})
+
+func init() {
+	prometheus.MustRegister(_inclusionLatencyMtc)
+}
+
+type (
+	// InclusionLatencyReporter is implemented by an actpool which tracks the latencies from the actions arriving at the
+	// pool to being included in the blocks, by the producers of the blocks
+	InclusionLatencyReporter interface {
+		// InclusionLatencies returns the latency distributions of the producers, nil if the tracking is disabled
+		InclusionLatencies() []*InclusionLatency
+	}
+
+	// InclusionLatency is the distribution of the latest latencies of the actions included by a producer
+	InclusionLatency struct {
+		Producer string
+		// Blocks and Actions are the numbers of the blocks and the actions of the producer observed so far
+		Blocks  uint64
+		Actions uint64
+		// Samples is the number of the latest latencies the distribution is calculated from
+		Samples int
+		Mean    time.Duration
+		P50     time.Duration
+		P90     time.Duration
+		P99     time.Duration
+		Max     time.Duration
+	}
+
+	inclusionTracker struct {
+		mu        sync.RWMutex
+		window    int
+		producers map[string]*producerLatencies
+	}
+
+	producerLatencies struct {
+		blocks    uint64
+		actions   uint64
+		latencies []time.Duration // ring buffer of the latest latencies
+		next      int
+	}
+)
+
+func newInclusionTracker(window uint64) *inclusionTracker {
+	return &inclusionTracker{
+		window:    int(window),
+		producers: make(map[string]*producerLatencies),
+	}
+}
+
+// observe records the latencies from the arrivals of the actions to the block time of the producer
+func (t *inclusionTracker) observe(producer string, blkTime time.Time, arrivals []time.Time) {
+	latencies := make([]time.Duration, len(arrivals))
+	for i, arrival := range arrivals {
+		latency := blkTime.Sub(arrival)
+		if latency < 0 {
+			// the clock of the producer is behind
+			latency = 0
+		}
+		latencies[i] = latency
+		_inclusionLatencyMtc.WithLabelValues(producer).Observe(latency.Seconds())
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	pl, ok := t.producers[producer]
+	if !ok {
+		pl = &producerLatencies{latencies: make([]time.Duration, 0, t.window)}
+		t.producers[producer] = pl
+	}
+	pl.blocks++
+	pl.actions += uint64(len(latencies))
+	for _, latency := range latencies {
+		if len(pl.latencies) < t.window {
+			pl.latencies = append(pl.latencies, latency)
+			continue
+		}
+		pl.latencies[pl.next] = latency
+		pl.next = (pl.next + 1) % t.window
+	}
+}
+
+func (t *inclusionTracker) distributions() []*InclusionLatency {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	ret := make([]*InclusionLatency, 0, len(t.producers))
+	for producer, pl := range t.producers {
+		il := &InclusionLatency{
+			Producer: producer,
+			Blocks:   pl.blocks,
+			Actions:  pl.actions,
+			Samples:  len(pl.latencies),
+		}
+		ret = append(ret, il)
+		if il.Samples == 0 {
+			continue
+		}
+		sorted := make([]time.Duration, il.Samples)
+		copy(sorted, pl.latencies)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+		var sum time.Duration
+		for _, latency := range sorted {
+			sum += latency
+		}
+		il.Mean = sum / time.Duration(il.Samples)
+		il.P50 = percentile(sorted, 50)
+		il.P90 = percentile(sorted, 90)
+		il.P99 = percentile(sorted, 99)
+		il.Max = sorted[il.Samples-1]
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Producer < ret[j].Producer })
+	return ret
+}
+
+// percentile returns the nearest-rank percentile of the sorted latencies
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (len(sorted)*p + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// InclusionLatencies returns the latency distributions of the producers, nil if the tracking is disabled
+func (ap *actPool) InclusionLatencies() []*InclusionLatency {
+	if ap.inclusion == nil {
+		return nil
+	}
+	return ap.inclusion.distributions()
+}
+
+// trackInclusion is called upon a new block before the included actions are removed from the pool, the actions
+// not seen by the pool, e.g., the system actions or the ones arrived while the node was syncing, are skipped
+func (ap *actPool) trackInclusion(blk *block.Block) {
+	if ap.inclusion == nil || blk == nil {
+		return
+	}
+	arrivals := make([]time.Time, 0, len(blk.Actions))
+	for _, selp := range blk.Actions {
+		h, err := selp.Hash()
+		if err != nil {
+			continue
+		}
+		if _, exist := ap.allActions.Get(h); !exist {
+			continue
+		}
+		sender := selp.SenderAddress()
+		if arrival, ok := ap.worker[ap.allocatedWorker(sender)].ArrivalTime(sender, selp.Nonce()); ok {
+			arrivals = append(arrivals, arrival)
+		}
+	}
+	ap.inclusion.observe(blk.ProducerAddress(), blk.Timestamp(), arrivals)
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+	"github.com/iotexproject/iotex-core/v2/test/mock/mock_chainmanager"
+)
+
+func TestInclusionTracker(t *testing.T) {
+	require := require.New(t)
+	var (
+		tracker = newInclusionTracker(10)
+		now     = time.Now()
+		p1      = identityset.Address(1).String()
+		p2      = identityset.Address(2).String()
+	)
+	arrivals := make([]time.Time, 0, 8)
+	for i := 1; i <= 8; i++ {
+		arrivals = append(arrivals, now.Add(-time.Duration(i)*time.Second))
+	}
+	tracker.observe(p1, now, arrivals)
+	// the latest latencies replace the oldest ones
+	tracker.observe(p1, now, []time.Time{now.Add(-time.Minute), now.Add(-time.Minute), now.Add(-time.Minute), now.Add(-time.Minute)})
+	// the clock of the producer is behind
+	tracker.observe(p2, now, []time.Time{now.Add(time.Second)})
+	tracker.observe(p2, now, nil)
+
+	ret := tracker.distributions()
+	require.Len(ret, 2)
+	if ret[0].Producer != p1 {
+		ret[0], ret[1] = ret[1], ret[0]
+	}
+	require.Equal(&InclusionLatency{
+		Producer: p1,
+		Blocks:   2,
+		Actions:  12,
+		Samples:  10,
+		Mean:     (3 + 4 + 5 + 6 + 7 + 8 + 60*4) * time.Second / 10,
+		P50:      7 * time.Second,
+		P90:      time.Minute,
+		P99:      time.Minute,
+		Max:      time.Minute,
+	}, ret[0])
+	require.Equal(&InclusionLatency{
+		Producer: p2,
+		Blocks:   2,
+		Actions:  1,
+		Samples:  1,
+	}, ret[1])
+}
+
+func TestActPool_InclusionLatencies(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	sf := mock_chainmanager.NewMockStateReader(ctrl)
+
+	ap, err := NewActPool(genesis.TestDefault(), sf, DefaultConfig)
+	require.NoError(err)
+	reporter, ok := ap.(InclusionLatencyReporter)
+	require.True(ok)
+	require.NotNil(reporter.InclusionLatencies())
+	require.Empty(reporter.InclusionLatencies())
+
+	cfg := DefaultConfig
+	cfg.InclusionLatencyWindow = 0
+	ap, err = NewActPool(genesis.TestDefault(), sf, cfg)
+	require.NoError(err)
+	require.Nil(ap.(InclusionLatencyReporter).InclusionLatencies())
+}
@@ -10,6 +10,8 @@ import (
 	"time"
 
 	"github.com/facebookgo/clock"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
 )
 
 // ActQueueOption is the option for actQueue.
@@ -35,6 +37,16 @@ func WithTimeOut(ttl time.Duration) interface{ ActQueueOption } {
 
 func (o *ttlOption) SetActQueueOption(aq *actQueue) { aq.ttl = o.ttl }
 
+type lifetimeOption struct{ lifetime time.Duration }
+
+// WithLifetime returns an option to overwrite the max lifetime of an action, regardless of
+// whether it is pending or not.
+func WithLifetime(lifetime time.Duration) interface{ ActQueueOption } {
+	return &lifetimeOption{lifetime}
+}
+
+func (o *lifetimeOption) SetActQueueOption(aq *actQueue) { aq.lifetime = o.lifetime }
+
 // WithStore is the option to set store encode and decode functions.
 func WithStore(cfg StoreConfig, encode encodeAction, decode decodeAction) func(*actPool) error {
 	return func(a *actPool) error {
@@ -51,3 +63,12 @@ func WithStore(cfg StoreConfig, encode encodeAction, decode decodeAction) func(*
 		return nil
 	}
 }
+
+// WithRegistry is the option to set the registry of the protocols, whose gas sponsors are taken into account when
+// checking the balance of the sender
+func WithRegistry(registry *protocol.Registry) func(*actPool) error {
+	return func(a *actPool) error {
+		a.registry = registry
+		return nil
+	}
+}
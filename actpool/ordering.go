@@ -0,0 +1,85 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/actpool/actioniterator"
+)
+
+// ordering policies of picking actions when minting a block
+const (
+	OrderByGasPrice     = "gasPrice"
+	OrderByEffectiveTip = "effectiveTip"
+	OrderByArrivalTime  = "arrivalTime"
+	OrderByRoundRobin   = "roundRobin"
+)
+
+// ErrInvalidOrderingPolicy is the error of unknown ordering policy
+var ErrInvalidOrderingPolicy = errors.New("invalid ordering policy")
+
+// ActionIteratorOptioner is implemented by an actpool which customizes the order
+// in which its pending actions are picked when minting a block
+type ActionIteratorOptioner interface {
+	ActionIteratorOptions(context.Context) []actioniterator.Option
+}
+
+func validateOrderingPolicy(policy string) error {
+	switch policy {
+	case "", OrderByGasPrice, OrderByEffectiveTip, OrderByArrivalTime, OrderByRoundRobin:
+		return nil
+	default:
+		return errors.Wrapf(ErrInvalidOrderingPolicy, "policy %s", policy)
+	}
+}
+
+// ActionIteratorOptions returns the options to iterate pending actions when minting a block
+func (ap *actPool) ActionIteratorOptions(ctx context.Context) []actioniterator.Option {
+	var opts []actioniterator.Option
+	if ap.cfg.MaxNumPriorityActsPerBlock > 0 {
+		if isPriority := ap.priorityLane(ctx); isPriority != nil {
+			opts = append(opts, actioniterator.WithPriorityLane(isPriority, ap.cfg.MaxNumPriorityActsPerBlock))
+		}
+	}
+	switch ap.cfg.OrderingPolicy {
+	case OrderByEffectiveTip:
+		var blkCtx protocol.BlockCtx
+		if ctx != nil {
+			blkCtx, _ = protocol.GetBlockCtx(ctx)
+		}
+		opts = append(opts, actioniterator.WithOrdering(actioniterator.NewEffectiveTipOrdering(blkCtx.BaseFee)))
+	case OrderByArrivalTime:
+		opts = append(opts, actioniterator.WithOrdering(actioniterator.NewArrivalTimeOrdering(ap.arrivalTimeFunc())))
+	case OrderByRoundRobin:
+		opts = append(opts, actioniterator.WithOrdering(actioniterator.NewRoundRobinOrdering()))
+	}
+	return opts
+}
+
+// arrivalTimeFunc returns a function to query the arrival time of a pooled action, the
+// result is memorized as the function is called many times when ordering actions
+func (ap *actPool) arrivalTimeFunc() func(*action.SealedEnvelope) time.Time {
+	arrivals := make(map[*action.SealedEnvelope]time.Time)
+	return func(selp *action.SealedEnvelope) time.Time {
+		if t, ok := arrivals[selp]; ok {
+			return t
+		}
+		sender := selp.SenderAddress()
+		// action removed from pool in the meantime is treated as the latest arrival
+		t, ok := ap.worker[ap.allocatedWorker(sender)].ArrivalTime(sender, selp.Nonce())
+		if !ok {
+			t = time.Unix(1<<62, 0)
+		}
+		arrivals[selp] = t
+		return t
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+	"github.com/iotexproject/iotex-core/v2/state"
+)
+
+// _pollProtocolID is the id of the poll protocol, which is found in the registry without importing the poll package,
+// as it would make an import cycle in the tests of the protocols
+const _pollProtocolID = "poll"
+
+// delegatesReader reads the delegates of the current epoch, which is implemented by the poll protocol
+type delegatesReader interface {
+	Delegates(context.Context, protocol.StateReader) (state.CandidateList, error)
+}
+
+// IsPriorityAction returns true if the action is a consensus-critical governance action,
+// such as a delegate claiming rewards or managing its candidate, which should not be
+// starved by user actions during fee spikes
+func IsPriorityAction(selp *action.SealedEnvelope) bool {
+	switch selp.Action().(type) {
+	case *action.ClaimFromRewardingFund,
+		*action.CandidateRegister,
+		*action.CandidateUpdate,
+		*action.CandidateActivate,
+		*action.CandidateEndorsement,
+		*action.CandidateTransferOwnership:
+		return true
+	default:
+		return false
+	}
+}
+
+// priorityLane returns the function checking if the action is a priority action sent by the operator or the reward
+// address of a delegate of the current epoch, so that other accounts cannot take the priority lane. It returns nil
+// if the delegates are unknown
+func (ap *actPool) priorityLane(ctx context.Context) func(*action.SealedEnvelope) bool {
+	if ap.registry == nil {
+		return nil
+	}
+	p, ok := ap.registry.Find(_pollProtocolID)
+	if !ok {
+		return nil
+	}
+	pp, ok := p.(delegatesReader)
+	if !ok {
+		return nil
+	}
+	delegates, err := pp.Delegates(ctx, ap.sf)
+	if err != nil {
+		log.L().Debug("failed to get the delegates for the priority lane", zap.Error(err))
+		return nil
+	}
+	senders := make(map[string]struct{}, 2*len(delegates))
+	for _, d := range delegates {
+		senders[d.Address] = struct{}{}
+		senders[d.RewardAddress] = struct{}{}
+	}
+	return func(selp *action.SealedEnvelope) bool {
+		if !IsPriorityAction(selp) {
+			return false
+		}
+		sender := selp.SenderAddress()
+		if sender == nil {
+			return false
+		}
+		_, ok := senders[sender.String()]
+		return ok
+	}
+}
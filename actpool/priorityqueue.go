@@ -13,6 +13,8 @@ type (
 		descIdx  int
 		nonce    uint64
 		deadline time.Time
+		// created is the time the action of nonce is put into the queue
+		created time.Time
 	}
 
 	ascNoncePriorityQueue  []*nonceWithTTL
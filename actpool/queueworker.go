@@ -8,6 +8,7 @@ import (
 	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/iotexproject/go-pkgs/cache/ttl"
 	"github.com/iotexproject/iotex-address/address"
@@ -109,13 +110,13 @@ func (worker *queueWorker) Handle(job workerJob) error {
 	isBlobTx := len(act.BlobHashes()) > 0 // only store blob tx
 	if worker.ap.store != nil && isBlobTx {
 		if err := worker.ap.store.Put(act); err != nil {
-			log.L().Warn("failed to store action", zap.Error(err), log.Hex("hash", actHash[:]))
+			log.Logger("actpool").Warn("failed to store action", zap.Error(err), log.Hex("hash", actHash[:]))
 		}
 	}
 
 	if desAddress, ok := act.Destination(); ok && !strings.EqualFold(sender, desAddress) {
 		if err := worker.ap.accountDesActs.addAction(act); err != nil {
-			log.L().Debug("fail to add destination map", zap.Error(err))
+			log.Logger("actpool").Debug("fail to add destination map", zap.Error(err))
 		}
 	}
 
@@ -127,10 +128,10 @@ func (worker *queueWorker) Handle(job workerJob) error {
 		// TODO: early return if sender is the account to pop and nonce is larger than largest in the queue
 		actToReplace := worker.accountActs.PopPeek()
 		if actToReplace == nil {
-			log.L().Warn("UNEXPECTED ERROR: action pool is full, but no action to drop")
+			log.Logger("actpool").Warn("UNEXPECTED ERROR: action pool is full, but no action to drop")
 			return nil
 		}
-		worker.ap.removeInvalidActs([]*action.SealedEnvelope{actToReplace})
+		worker.ap.removeInvalidActs([]*action.SealedEnvelope{actToReplace}, EvictionOverflow)
 		if actToReplace.SenderAddress().String() == sender && actToReplace.Nonce() == nonce {
 			err = action.ErrTxPoolOverflow
 			_actpoolMtc.WithLabelValues("overMaxNumActsPerPool").Inc()
@@ -142,6 +143,25 @@ func (worker *queueWorker) Handle(job workerJob) error {
 	return err
 }
 
+// Check checks the action against the account state and the actions of the account in pool, without adding it
+func (worker *queueWorker) Check(ctx context.Context, act *action.SealedEnvelope) error {
+	sender := act.SenderAddress()
+	nonce, balance, err := worker.getConfirmedState(ctx, sender)
+	if err != nil {
+		return err
+	}
+	if err := worker.checkSelpWithState(act, nonce, balance); err != nil {
+		return err
+	}
+	worker.mu.RLock()
+	queue := worker.accountActs.Account(sender.String())
+	worker.mu.RUnlock()
+	if queue == nil {
+		return nil
+	}
+	return queue.Check(act)
+}
+
 func (worker *queueWorker) getConfirmedState(ctx context.Context, sender address.Address) (uint64, *big.Int, error) {
 	worker.mu.RLock()
 	queue := worker.accountActs.Account(sender.String())
@@ -171,9 +191,9 @@ func (worker *queueWorker) checkSelpWithState(act *action.SealedEnvelope, pendin
 	}
 
 	// Nonce exceeds current range
-	if act.Nonce()-pendingNonce >= worker.ap.cfg.MaxNumActsPerAcct {
+	if act.Nonce()-pendingNonce >= worker.ap.limits.Load().MaxNumActsPerAcct {
 		hash, _ := act.Hash()
-		log.L().Debug("Rejecting action because nonce is too large.",
+		log.Logger("actpool").Debug("Rejecting action because nonce is too large.",
 			log.Hex("hash", hash[:]),
 			zap.Uint64("startNonce", pendingNonce),
 			zap.Uint64("actNonce", act.Nonce()))
@@ -193,11 +213,11 @@ func (worker *queueWorker) checkSelpWithState(act *action.SealedEnvelope, pendin
 		}
 	}
 
-	if cost, _ := act.Cost(); balance.Cmp(cost) < 0 {
+	if cost, _ := worker.ap.cost(act); balance.Cmp(cost) < 0 {
 		_actpoolMtc.WithLabelValues("insufficientBalance").Inc()
 		sender := act.SenderAddress().String()
 		actHash, _ := act.Hash()
-		log.L().Debug("insufficient balance for action",
+		log.Logger("actpool").Debug("insufficient balance for action",
 			zap.String("actionHash", hex.EncodeToString(actHash[:])),
 			zap.String("cost", cost.String()),
 			zap.String("balance", balance.String()),
@@ -217,12 +237,13 @@ func (worker *queueWorker) putAction(sender string, act *action.SealedEnvelope,
 		confirmedBalance,
 		worker.ap.cfg.ActionExpiry,
 		act,
+		WithLifetime(worker.ap.cfg.MaxActionLifetime),
 	)
 	worker.mu.Unlock()
 	if err != nil {
 		actHash, _ := act.Hash()
 		_actpoolMtc.WithLabelValues("failedPutActQueue").Inc()
-		log.L().Debug("failed put action into ActQueue",
+		log.Logger("actpool").Debug("failed put action into ActQueue",
 			zap.String("actionHash", hex.EncodeToString(actHash[:])),
 			zap.Error(err))
 		return err
@@ -252,7 +273,7 @@ func (worker *queueWorker) Reset(ctx context.Context) {
 		addr, _ := address.FromString(from)
 		confirmedState, err := accountutil.AccountState(ctx, worker.ap.sf, addr)
 		if err != nil {
-			log.L().Error("Error when removing confirmed actions", zap.Error(err))
+			log.Logger("actpool").Error("Error when removing confirmed actions", zap.Error(err))
 			queue.Reset()
 			worker.emptyAccounts.Set(from, struct{}{})
 			return
@@ -266,7 +287,8 @@ func (worker *queueWorker) Reset(ctx context.Context) {
 		// Remove all actions that are committed to new block
 		acts := queue.UpdateAccountState(pendingNonce, confirmedState.Balance)
 		acts2 := queue.UpdateQueue()
-		worker.ap.removeInvalidActs(append(acts, acts2...))
+		worker.ap.removeInvalidActs(acts, EvictionConfirmed)
+		worker.ap.removeInvalidActs(acts2, EvictionExpired)
 		// Delete the queue entry if it becomes empty
 		if queue.Empty() {
 			worker.emptyAccounts.Set(from, struct{}{})
@@ -274,6 +296,22 @@ func (worker *queueWorker) Reset(ctx context.Context) {
 	})
 }
 
+// Sweep evicts the actions which have stayed in the pool longer than their lifetime
+func (worker *queueWorker) Sweep() {
+	worker.mu.Lock()
+	defer worker.mu.Unlock()
+
+	worker.accountActs.Range(func(from string, queue ActQueue) {
+		if queue.Empty() {
+			return
+		}
+		worker.ap.removeInvalidActs(queue.UpdateQueue(), EvictionExpired)
+		if queue.Empty() {
+			worker.emptyAccounts.Set(from, struct{}{})
+		}
+	})
+}
+
 // PendingActions returns all accepted actions
 func (worker *queueWorker) PendingActions(ctx context.Context) []*pendingActions {
 	actionArr := make([]*pendingActions, 0)
@@ -286,7 +324,7 @@ func (worker *queueWorker) PendingActions(ctx context.Context) []*pendingActions
 		}
 		// Remove the actions that are already timeout
 		acts := queue.UpdateQueue()
-		worker.ap.removeInvalidActs(acts)
+		worker.ap.removeInvalidActs(acts, EvictionExpired)
 		pd := queue.PendingActs(ctx)
 		if len(pd) == 0 {
 			return
@@ -313,6 +351,16 @@ func (worker *queueWorker) AllActions(sender address.Address) ([]*action.SealedE
 	return nil, false
 }
 
+// ArrivalTime returns the time the action of sender and nonce is put into the pool
+func (worker *queueWorker) ArrivalTime(sender address.Address, nonce uint64) (time.Time, bool) {
+	worker.mu.RLock()
+	defer worker.mu.RUnlock()
+	if actQueue := worker.accountActs.Account(sender.String()); actQueue != nil {
+		return actQueue.ArrivalTime(nonce)
+	}
+	return time.Time{}, false
+}
+
 // PendingNonce returns the pending nonce of sender
 func (worker *queueWorker) PendingNonce(sender address.Address) (uint64, bool) {
 	worker.mu.RLock()
@@ -0,0 +1,108 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"context"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/cache/ttl"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+)
+
+const (
+	// ActionSourceAPI is the source of the actions sent to the API
+	ActionSourceAPI = "api"
+	// ActionSourceP2P is the source of the actions gossiped by the peers
+	ActionSourceP2P = "p2p"
+	// ActionSourceLocal is the source of the other actions, e.g., the ones reloaded or synced by the node itself
+	ActionSourceLocal = "local"
+)
+
+var _seenCacheMtc = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "iotex_actpool_seen_cache",
+	Help: "Signature verifications of the actions by source, of which the hits are the duplicated broadcasts.",
+}, []string{"source", "result"})
+
+func init() {
+	prometheus.MustRegister(_seenCacheMtc)
+}
+
+type (
+	actionSourceKey struct{}
+
+	// SeenCache remembers the signature verdicts of the recently seen actions, so that the duplicated broadcasts
+	// of an action coming from the API and the p2p gossip do not repeat the signature verification. It is keyed by
+	// the content of the action together with the sender public key, as the hash of an ethereum tx does not cover
+	// the public key, and an entry expires once the action is not seen for the ttl
+	SeenCache struct {
+		verdicts *ttl.Cache
+	}
+
+	seenVerdict struct {
+		err error
+	}
+)
+
+// WithActionSource sets the source of the action being added to the actpool
+func WithActionSource(ctx context.Context, source string) context.Context {
+	return context.WithValue(ctx, actionSourceKey{}, source)
+}
+
+func actionSource(ctx context.Context) string {
+	if source, ok := ctx.Value(actionSourceKey{}).(string); ok {
+		return source
+	}
+	return ActionSourceLocal
+}
+
+// NewSeenCache creates a seen cache of which the entries expire after the ttl, it returns nil if the ttl is 0
+func NewSeenCache(expiry time.Duration) (*SeenCache, error) {
+	if expiry <= 0 {
+		return nil, nil
+	}
+	verdicts, err := ttl.NewCache(ttl.AutoExpireOption(expiry))
+	if err != nil {
+		return nil, err
+	}
+	return &SeenCache{verdicts: verdicts}, nil
+}
+
+// VerifySignature returns the verdict of the action if it has been seen, otherwise verifies the signature and
+// remembers the verdict. A nil cache always verifies the signature
+func (c *SeenCache) VerifySignature(ctx context.Context, selp *action.SealedEnvelope) error {
+	if c == nil {
+		return selp.VerifySignature()
+	}
+	key, err := seenKey(selp)
+	if err != nil {
+		return err
+	}
+	source := actionSource(ctx)
+	if v, ok := c.verdicts.Get(key); ok {
+		_seenCacheMtc.WithLabelValues(source, "hit").Inc()
+		return v.(*seenVerdict).err
+	}
+	_seenCacheMtc.WithLabelValues(source, "miss").Inc()
+	err = selp.VerifySignature()
+	c.verdicts.Set(key, &seenVerdict{err: err})
+	return err
+}
+
+func seenKey(selp *action.SealedEnvelope) (hash.Hash256, error) {
+	h, err := selp.Hash()
+	if err != nil {
+		return hash.ZeroHash256, err
+	}
+	var pk []byte
+	if selp.SrcPubkey() != nil {
+		pk = selp.SrcPubkey().Bytes()
+	}
+	return hash.Hash256b(append(h[:], pk...)), nil
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+func TestSeenCache(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	tsf, err := action.SignedTransfer(_addr2, _priKey1, 1, big.NewInt(10), nil, 10000, big.NewInt(1))
+	r.NoError(err)
+	// the same action claimed to be signed by another public key
+	pb := tsf.Proto()
+	pb.SenderPubKey = identityset.PrivateKey(2).PublicKey().Bytes()
+	forged, err := (&action.Deserializer{}).ActionToSealedEnvelope(pb)
+	r.NoError(err)
+
+	var nilCache *SeenCache
+	r.NoError(nilCache.VerifySignature(ctx, tsf))
+	r.ErrorIs(nilCache.VerifySignature(ctx, forged), action.ErrInvalidSender)
+	c, err := NewSeenCache(0)
+	r.NoError(err)
+	r.Nil(c)
+
+	c, err = NewSeenCache(time.Hour)
+	r.NoError(err)
+	var (
+		p2pHit  = _seenCacheMtc.WithLabelValues(ActionSourceP2P, "hit")
+		apiHit  = _seenCacheMtc.WithLabelValues(ActionSourceAPI, "hit")
+		apiMiss = _seenCacheMtc.WithLabelValues(ActionSourceAPI, "miss")
+	)
+	p2pHits, apiHits, apiMisses := testutil.ToFloat64(p2pHit), testutil.ToFloat64(apiHit), testutil.ToFloat64(apiMiss)
+	apiCtx := WithActionSource(ctx, ActionSourceAPI)
+	r.NoError(c.VerifySignature(apiCtx, tsf))
+	r.ErrorIs(c.VerifySignature(apiCtx, forged), action.ErrInvalidSender)
+	r.Equal(apiMisses+2, testutil.ToFloat64(apiMiss))
+	r.Equal(2, c.verdicts.Count())
+
+	// the duplicated broadcasts get the remembered verdicts
+	p2pCtx := WithActionSource(ctx, ActionSourceP2P)
+	for i := 0; i < 3; i++ {
+		r.NoError(c.VerifySignature(p2pCtx, tsf))
+		r.ErrorIs(c.VerifySignature(p2pCtx, forged), action.ErrInvalidSender)
+	}
+	r.Equal(p2pHits+6, testutil.ToFloat64(p2pHit))
+	r.NoError(c.VerifySignature(apiCtx, tsf))
+	r.Equal(apiHits+1, testutil.ToFloat64(apiHit))
+	r.Equal(apiMisses+2, testutil.ToFloat64(apiMiss))
+}
@@ -0,0 +1,371 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package userop
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+// _validUntilMargin is the minimum remaining time of a user operation with an expiry to be admitted
+const _validUntilMargin = 30 * time.Second
+
+var (
+	// ErrUnsupportedEntryPoint indicates the entry point is not served by the pool
+	ErrUnsupportedEntryPoint = errors.New("unsupported entry point")
+	// ErrPoolFull indicates the pool reaches the limit of user operations
+	ErrPoolFull = errors.New("user operation pool is full")
+	// ErrUnderpriced indicates the user operation does not pay enough to replace the pending one
+	ErrUnderpriced = errors.New("replacement user operation underpriced")
+	// ErrEntityLimit indicates the sender or a throttled entity has too many user operations in the pool
+	ErrEntityLimit = errors.New("too many user operations of the entity")
+	// ErrBannedEntity indicates a paymaster or factory of the user operation is banned
+	ErrBannedEntity = errors.New("entity is banned")
+	// ErrValidationFailed indicates the simulated validation of the user operation fails
+	ErrValidationFailed = errors.New("user operation validation failed")
+)
+
+type (
+	// Config is the config of the user operation pool
+	Config struct {
+		// Enabled serves the ERC-4337 bundler rpc with the user operation pool
+		Enabled bool `yaml:"enabled"`
+		// EntryPoints are the addresses of the supported v0.6 entry points in hex string
+		EntryPoints []string `yaml:"entryPoints"`
+		// MaxOps is the maximum number of user operations in the pool
+		MaxOps int `yaml:"maxOps"`
+		// MaxOpsPerSender is the maximum number of user operations of a sender in the pool
+		MaxOpsPerSender int `yaml:"maxOpsPerSender"`
+		// ThrottledEntityOps is the maximum number of user operations of a throttled paymaster or factory in the pool
+		ThrottledEntityOps int `yaml:"throttledEntityOps"`
+		// ReputationDecayInterval is the interval to decay the reputation counters
+		ReputationDecayInterval time.Duration `yaml:"reputationDecayInterval"`
+	}
+
+	// SimulateFunc simulates calling the entry point with the data under the tracer, and returns the return or revert
+	// data of the call
+	SimulateFunc func(ctx context.Context, entryPoint common.Address, data []byte, tracer vm.EVMLogger) ([]byte, error)
+
+	// Pool is the pool of the ERC-4337 user operations waiting to be bundled. A user operation is admitted only if
+	// its validation simulated by the entry point succeeds without using the opcodes banned by ERC-7562, and its
+	// paymaster and factory are not banned by their reputation
+	Pool struct {
+		mu          sync.RWMutex
+		cfg         Config
+		chainID     *big.Int
+		simulate    SimulateFunc
+		entryPoints map[common.Address]struct{}
+		ops         map[common.Address]map[common.Hash]*pendingOp
+		reputation  *reputation
+		lastDecay   time.Time
+	}
+
+	// returnInfo is the result of the validation in ValidationResult
+	returnInfo struct {
+		PreOpGas         *big.Int
+		Prefund          *big.Int
+		SigFailed        bool
+		ValidAfter       *big.Int
+		ValidUntil       *big.Int
+		PaymasterContext []byte
+	}
+
+	pendingOp struct {
+		op      *UserOperation
+		hash    common.Hash
+		addedAt time.Time
+	}
+)
+
+// DefaultConfig is the default config of the user operation pool
+var DefaultConfig = Config{
+	Enabled:                 false,
+	EntryPoints:             []string{},
+	MaxOps:                  4096,
+	MaxOpsPerSender:         4,
+	ThrottledEntityOps:      4,
+	ReputationDecayInterval: time.Hour,
+}
+
+// NewPool creates a user operation pool
+func NewPool(cfg Config, chainID uint32, simulate SimulateFunc) (*Pool, error) {
+	entryPoints := make(map[common.Address]struct{}, len(cfg.EntryPoints))
+	for _, ep := range cfg.EntryPoints {
+		if !common.IsHexAddress(ep) {
+			return nil, errors.Errorf("invalid entry point address %s", ep)
+		}
+		entryPoints[common.HexToAddress(ep)] = struct{}{}
+	}
+	if len(entryPoints) == 0 {
+		return nil, errors.New("no entry point is configured")
+	}
+	ops := make(map[common.Address]map[common.Hash]*pendingOp, len(entryPoints))
+	for ep := range entryPoints {
+		ops[ep] = make(map[common.Hash]*pendingOp)
+	}
+	return &Pool{
+		cfg:         cfg,
+		chainID:     new(big.Int).SetUint64(uint64(chainID)),
+		simulate:    simulate,
+		entryPoints: entryPoints,
+		ops:         ops,
+		reputation:  newReputation(),
+	}, nil
+}
+
+// SupportedEntryPoints returns the entry points served by the pool
+func (p *Pool) SupportedEntryPoints() []common.Address {
+	ret := make([]common.Address, 0, len(p.entryPoints))
+	for ep := range p.entryPoints {
+		ret = append(ret, ep)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return bytes.Compare(ret[i][:], ret[j][:]) < 0
+	})
+	return ret
+}
+
+// Add validates the user operation and adds it into the pool, returns the user operation hash
+func (p *Pool) Add(ctx context.Context, op *UserOperation, entryPoint common.Address) (common.Hash, error) {
+	if _, ok := p.entryPoints[entryPoint]; !ok {
+		return common.Hash{}, errors.Wrap(ErrUnsupportedEntryPoint, entryPoint.Hex())
+	}
+	if err := op.sanityCheck(); err != nil {
+		return common.Hash{}, err
+	}
+	opHash, err := op.Hash(entryPoint, p.chainID)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	p.mu.RLock()
+	err = p.checkAdmission(op, entryPoint)
+	p.mu.RUnlock()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	// simulate without the lock, the admission is checked again before adding
+	if err := p.validate(ctx, op, entryPoint); err != nil {
+		return common.Hash{}, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err := p.checkAdmission(op, entryPoint); err != nil {
+		return common.Hash{}, err
+	}
+	ops := p.ops[entryPoint]
+	if replaced := p.findBySenderNonce(ops, op.Sender, op.Nonce); replaced != nil {
+		delete(ops, replaced.hash)
+	}
+	ops[opHash] = &pendingOp{op: op, hash: opHash, addedAt: time.Now()}
+	for _, entity := range []*common.Address{op.Factory(), op.Paymaster()} {
+		if entity != nil {
+			p.reputation.seen(*entity)
+		}
+	}
+	return opHash, nil
+}
+
+func (p *Pool) checkAdmission(op *UserOperation, entryPoint common.Address) error {
+	ops := p.ops[entryPoint]
+	var senderOps int
+	entityOps := make(map[common.Address]int)
+	for _, pending := range ops {
+		if pending.op.Sender == op.Sender {
+			senderOps++
+		}
+		for _, entity := range []*common.Address{pending.op.Factory(), pending.op.Paymaster()} {
+			if entity != nil {
+				entityOps[*entity]++
+			}
+		}
+	}
+	if replaced := p.findBySenderNonce(ops, op.Sender, op.Nonce); replaced != nil {
+		// the replacement pays at least 10% more on both fees
+		if !bumped(op.MaxFeePerGas, replaced.op.MaxFeePerGas) || !bumped(op.MaxPriorityFeePerGas, replaced.op.MaxPriorityFeePerGas) {
+			return ErrUnderpriced
+		}
+	} else {
+		if p.cfg.MaxOps > 0 && p.size() >= p.cfg.MaxOps {
+			return ErrPoolFull
+		}
+		if p.cfg.MaxOpsPerSender > 0 && senderOps >= p.cfg.MaxOpsPerSender {
+			return errors.Wrapf(ErrEntityLimit, "sender %s", op.Sender.Hex())
+		}
+	}
+	for _, entity := range []*common.Address{op.Factory(), op.Paymaster()} {
+		if entity == nil {
+			continue
+		}
+		switch p.reputation.status(*entity) {
+		case StatusBanned:
+			return errors.Wrap(ErrBannedEntity, entity.Hex())
+		case StatusThrottled:
+			if entityOps[*entity] >= p.cfg.ThrottledEntityOps {
+				return errors.Wrapf(ErrEntityLimit, "throttled entity %s", entity.Hex())
+			}
+		}
+	}
+	return nil
+}
+
+// validate simulates the validation of the user operation by the entry point
+func (p *Pool) validate(ctx context.Context, op *UserOperation, entryPoint common.Address) error {
+	data, err := _entryPoint.Pack("simulateValidation", op)
+	if err != nil {
+		return errors.Wrap(ErrInvalidUserOp, err.Error())
+	}
+	tracer := newOpcodeTracer(op)
+	ret, err := p.simulate(ctx, entryPoint, data, tracer)
+	if err != nil {
+		return errors.Wrap(err, "failed to simulate validation")
+	}
+	if tracer.err != nil {
+		return tracer.err
+	}
+	// simulateValidation always reverts with the result
+	if len(ret) < 4 {
+		return errors.Wrap(ErrValidationFailed, "unexpected result of simulateValidation")
+	}
+	selector, args := ret[:4], ret[4:]
+	switch {
+	case bytes.Equal(selector, _failedOp.ID[:4]):
+		values, err := _failedOp.Inputs.Unpack(args)
+		if err != nil {
+			return errors.Wrap(ErrValidationFailed, err.Error())
+		}
+		return errors.Wrap(ErrValidationFailed, values[1].(string))
+	case bytes.Equal(selector, _validationResult.ID[:4]):
+		values, err := _validationResult.Inputs.Unpack(args)
+		if err != nil {
+			return errors.Wrap(ErrValidationFailed, err.Error())
+		}
+		info := abi.ConvertType(values[0], new(returnInfo)).(*returnInfo)
+		if info.SigFailed {
+			return errors.Wrap(ErrValidationFailed, "invalid signature")
+		}
+		now := time.Now()
+		if info.ValidUntil.Sign() > 0 && info.ValidUntil.Int64() < now.Add(_validUntilMargin).Unix() {
+			return errors.Wrap(ErrValidationFailed, "user operation expires too soon")
+		}
+		if info.ValidAfter.Int64() > now.Unix() {
+			return errors.Wrap(ErrValidationFailed, "user operation is not valid yet")
+		}
+		return nil
+	default:
+		return errors.Wrap(ErrValidationFailed, "unexpected result of simulateValidation")
+	}
+}
+
+// Pending returns the user operations of the entry point, in the order of the priority fee
+func (p *Pool) Pending(entryPoint common.Address) []*UserOperation {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	pending := make([]*pendingOp, 0, len(p.ops[entryPoint]))
+	for _, op := range p.ops[entryPoint] {
+		pending = append(pending, op)
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		if c := pending[i].op.MaxPriorityFeePerGas.Cmp(pending[j].op.MaxPriorityFeePerGas); c != 0 {
+			return c > 0
+		}
+		return pending[i].addedAt.Before(pending[j].addedAt)
+	})
+	ret := make([]*UserOperation, len(pending))
+	for i := range pending {
+		ret[i] = pending[i].op
+	}
+	return ret
+}
+
+// Reputation returns the reputation of the paymasters and factories
+func (p *Pool) Reputation() []ReputationEntry {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.reputation.dump()
+}
+
+// ReceiveBlock removes the user operations included in the block and credits their paymasters and factories
+func (p *Pool) ReceiveBlock(blk *block.Block) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	event := _entryPoint.Events["UserOperationEvent"]
+	for _, r := range blk.Receipts {
+		for _, l := range r.Logs() {
+			if len(l.Topics) == 0 || common.Hash(l.Topics[0]) != event.ID {
+				continue
+			}
+			addr, err := address.FromString(l.Address)
+			if err != nil {
+				continue
+			}
+			ops, ok := p.ops[common.BytesToAddress(addr.Bytes())]
+			if !ok {
+				continue
+			}
+			opHash := common.Hash(l.Topics[1])
+			pending, ok := ops[opHash]
+			if !ok {
+				continue
+			}
+			delete(ops, opHash)
+			for _, entity := range []*common.Address{pending.op.Factory(), pending.op.Paymaster()} {
+				if entity != nil {
+					p.reputation.included(*entity)
+				}
+			}
+			log.L().Debug("user operation included", zap.String("hash", opHash.Hex()), zap.Uint64("height", blk.Height()))
+		}
+	}
+	ts := blk.Timestamp()
+	if p.lastDecay.IsZero() {
+		p.lastDecay = ts
+	}
+	if p.cfg.ReputationDecayInterval > 0 {
+		for ts.Sub(p.lastDecay) >= p.cfg.ReputationDecayInterval {
+			p.reputation.decay()
+			p.lastDecay = p.lastDecay.Add(p.cfg.ReputationDecayInterval)
+		}
+	}
+	return nil
+}
+
+func (p *Pool) size() int {
+	var size int
+	for _, ops := range p.ops {
+		size += len(ops)
+	}
+	return size
+}
+
+func (p *Pool) findBySenderNonce(ops map[common.Hash]*pendingOp, sender common.Address, nonce *big.Int) *pendingOp {
+	for _, pending := range ops {
+		if pending.op.Sender == sender && pending.op.Nonce.Cmp(nonce) == 0 {
+			return pending
+		}
+	}
+	return nil
+}
+
+// bumped checks whether the new fee is at least 10% higher than the old fee
+func bumped(newFee, oldFee *big.Int) bool {
+	threshold := new(big.Int).Mul(oldFee, big.NewInt(110))
+	return new(big.Int).Mul(newFee, big.NewInt(100)).Cmp(threshold) >= 0
+}
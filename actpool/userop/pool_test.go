@@ -0,0 +1,321 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package userop
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+const _testChainID = 4689
+
+var (
+	_testEntryPoint = common.HexToAddress("0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789")
+	_testPaymaster  = common.HexToAddress("0x00000000000000000000000000000000000000a1")
+)
+
+type stakeInfo struct {
+	Stake           *big.Int
+	UnstakeDelaySec *big.Int
+}
+
+func validationResult(t *testing.T, info returnInfo) []byte {
+	stake := stakeInfo{Stake: big.NewInt(0), UnstakeDelaySec: big.NewInt(0)}
+	args, err := _validationResult.Inputs.Pack(info, stake, stake, stake)
+	require.NoError(t, err)
+	return append(append([]byte{}, _validationResult.ID[:4]...), args...)
+}
+
+func failedOp(t *testing.T, reason string) []byte {
+	args, err := _failedOp.Inputs.Pack(big.NewInt(0), reason)
+	require.NoError(t, err)
+	return append(append([]byte{}, _failedOp.ID[:4]...), args...)
+}
+
+func validInfo() returnInfo {
+	return returnInfo{
+		PreOpGas:   big.NewInt(50000),
+		Prefund:    big.NewInt(1),
+		ValidAfter: big.NewInt(0),
+		ValidUntil: big.NewInt(0),
+	}
+}
+
+func newTestOp(sender byte, nonce int64, fee int64) *UserOperation {
+	return &UserOperation{
+		Sender:               common.BytesToAddress([]byte{sender}),
+		Nonce:                big.NewInt(nonce),
+		CallGasLimit:         big.NewInt(100000),
+		VerificationGasLimit: big.NewInt(100000),
+		PreVerificationGas:   big.NewInt(21000),
+		MaxFeePerGas:         big.NewInt(fee),
+		MaxPriorityFeePerGas: big.NewInt(fee),
+	}
+}
+
+func TestUserOperation(t *testing.T) {
+	require := require.New(t)
+
+	op := newTestOp(1, 2, 100)
+	op.PaymasterAndData = append(_testPaymaster.Bytes(), 1, 2, 3)
+	buf, err := json.Marshal(op)
+	require.NoError(err)
+	require.Contains(string(buf), `"maxFeePerGas":"0x64"`)
+	op2 := &UserOperation{}
+	require.NoError(json.Unmarshal(buf, op2))
+	require.Equal(op.Nonce, op2.Nonce)
+	require.Equal(op.PaymasterAndData, []byte(op2.PaymasterAndData))
+	require.ErrorIs(json.Unmarshal([]byte(`{"sender":"0x0000000000000000000000000000000000000001"}`), op2), ErrInvalidUserOp)
+
+	h1, err := op.Hash(_testEntryPoint, big.NewInt(_testChainID))
+	require.NoError(err)
+	h2, err := op.Hash(_testEntryPoint, big.NewInt(_testChainID+1))
+	require.NoError(err)
+	require.NotEqual(h1, h2)
+
+	require.Equal(_testPaymaster, *op.Paymaster())
+	require.Nil(op.Factory())
+	require.NoError(op.sanityCheck())
+	op.MaxPriorityFeePerGas = big.NewInt(101)
+	require.ErrorIs(op.sanityCheck(), ErrInvalidUserOp)
+	op.MaxPriorityFeePerGas = big.NewInt(100)
+	op.InitCode = []byte{1}
+	require.ErrorIs(op.sanityCheck(), ErrInvalidUserOp)
+}
+
+func TestReputation(t *testing.T) {
+	require := require.New(t)
+
+	r := newReputation()
+	addr := common.HexToAddress("0x01")
+	require.Equal(StatusOK, r.status(addr))
+	for i := 0; i < 109; i++ {
+		r.seen(addr)
+	}
+	require.Equal(StatusOK, r.status(addr))
+	// 110 seen and none included is throttled
+	r.seen(addr)
+	require.Equal(StatusThrottled, r.status(addr))
+	for i := 0; i < 510; i++ {
+		r.seen(addr)
+	}
+	require.Equal(StatusBanned, r.status(addr))
+	for i := 0; i < 20; i++ {
+		r.included(addr)
+	}
+	require.Equal(StatusThrottled, r.status(addr))
+
+	dump := r.dump()
+	require.Len(dump, 1)
+	require.EqualValues(620, dump[0].OpsSeen)
+	require.Equal(StatusThrottled, dump[0].Status)
+	r.decay()
+	require.EqualValues(594, r.entries[addr].OpsSeen)
+	require.EqualValues(19, r.entries[addr].OpsIncluded)
+	for i := 0; i < 200; i++ {
+		r.decay()
+	}
+	require.Empty(r.dump())
+}
+
+func TestOpcodeTracer(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		op      = newTestOp(1, 0, 100)
+		factory = common.HexToAddress("0x00000000000000000000000000000000000000f1")
+		other   = common.HexToAddress("0x00000000000000000000000000000000000000b1")
+	)
+	op.InitCode = factory.Bytes()
+	run := func(steps func(tr *opcodeTracer)) error {
+		tr := newOpcodeTracer(op)
+		tr.CaptureStart(nil, common.Address{}, _testEntryPoint, false, nil, 0, nil)
+		steps(tr)
+		return tr.err
+	}
+	state := func(tr *opcodeTracer, ops ...vm.OpCode) {
+		for _, o := range ops {
+			tr.CaptureState(0, o, 0, 0, nil, nil, 0, nil)
+		}
+	}
+
+	// the entry point itself is not checked
+	require.NoError(run(func(tr *opcodeTracer) {
+		state(tr, vm.TIMESTAMP, vm.GAS, vm.ADD)
+	}))
+	// the account could not read the timestamp, neither could the contracts it calls
+	require.ErrorIs(run(func(tr *opcodeTracer) {
+		tr.CaptureEnter(vm.CALL, _testEntryPoint, op.Sender, nil, 0, nil)
+		tr.CaptureEnter(vm.STATICCALL, op.Sender, other, nil, 0, nil)
+		state(tr, vm.TIMESTAMP)
+	}), ErrBannedOpcode)
+	require.NoError(run(func(tr *opcodeTracer) {
+		tr.CaptureEnter(vm.CALL, _testEntryPoint, op.Sender, nil, 0, nil)
+		tr.CaptureExit(nil, 0, nil)
+		state(tr, vm.TIMESTAMP)
+	}))
+	// GAS is allowed only right before a call
+	require.NoError(run(func(tr *opcodeTracer) {
+		tr.CaptureEnter(vm.CALL, _testEntryPoint, op.Sender, nil, 0, nil)
+		state(tr, vm.GAS, vm.CALL)
+	}))
+	require.ErrorIs(run(func(tr *opcodeTracer) {
+		tr.CaptureEnter(vm.CALL, _testEntryPoint, op.Sender, nil, 0, nil)
+		state(tr, vm.GAS, vm.POP)
+	}), ErrBannedOpcode)
+	// only the factory could use CREATE2, once
+	require.NoError(run(func(tr *opcodeTracer) {
+		tr.CaptureEnter(vm.CALL, _testEntryPoint, factory, nil, 0, nil)
+		state(tr, vm.CREATE2)
+	}))
+	require.ErrorIs(run(func(tr *opcodeTracer) {
+		tr.CaptureEnter(vm.CALL, _testEntryPoint, factory, nil, 0, nil)
+		state(tr, vm.CREATE2, vm.CREATE2)
+	}), ErrBannedOpcode)
+	require.ErrorIs(run(func(tr *opcodeTracer) {
+		tr.CaptureEnter(vm.CALL, _testEntryPoint, op.Sender, nil, 0, nil)
+		state(tr, vm.CREATE2)
+	}), ErrBannedOpcode)
+}
+
+func TestPool(t *testing.T) {
+	require := require.New(t)
+
+	cfg := DefaultConfig
+	cfg.Enabled = true
+	cfg.EntryPoints = []string{_testEntryPoint.Hex()}
+	cfg.MaxOps = 3
+	cfg.MaxOpsPerSender = 2
+	cfg.ThrottledEntityOps = 1
+	_, err := NewPool(DefaultConfig, _testChainID, nil)
+	require.Error(err)
+
+	var (
+		result   []byte
+		simCalls int
+	)
+	p, err := NewPool(cfg, _testChainID, func(_ context.Context, entryPoint common.Address, data []byte, tracer vm.EVMLogger) ([]byte, error) {
+		simCalls++
+		require.Equal(_testEntryPoint, entryPoint)
+		require.Equal(_entryPoint.Methods["simulateValidation"].ID, data[:4])
+		require.NotNil(tracer)
+		return result, nil
+	})
+	require.NoError(err)
+	require.Equal([]common.Address{_testEntryPoint}, p.SupportedEntryPoints())
+	ctx := context.Background()
+
+	t.Run("validation", func(t *testing.T) {
+		_, err := p.Add(ctx, newTestOp(1, 0, 100), common.HexToAddress("0x01"))
+		require.ErrorIs(err, ErrUnsupportedEntryPoint)
+		result = failedOp(t, "AA21 didn't pay prefund")
+		_, err = p.Add(ctx, newTestOp(1, 0, 100), _testEntryPoint)
+		require.ErrorIs(err, ErrValidationFailed)
+		require.Contains(err.Error(), "AA21")
+		info := validInfo()
+		info.SigFailed = true
+		result = validationResult(t, info)
+		_, err = p.Add(ctx, newTestOp(1, 0, 100), _testEntryPoint)
+		require.ErrorIs(err, ErrValidationFailed)
+		info = validInfo()
+		info.ValidUntil = big.NewInt(time.Now().Add(10 * time.Second).Unix())
+		result = validationResult(t, info)
+		_, err = p.Add(ctx, newTestOp(1, 0, 100), _testEntryPoint)
+		require.ErrorIs(err, ErrValidationFailed)
+		result = []byte{1, 2}
+		_, err = p.Add(ctx, newTestOp(1, 0, 100), _testEntryPoint)
+		require.ErrorIs(err, ErrValidationFailed)
+		require.Empty(p.Pending(_testEntryPoint))
+	})
+
+	result = validationResult(t, validInfo())
+	t.Run("admission", func(t *testing.T) {
+		h, err := p.Add(ctx, newTestOp(1, 0, 100), _testEntryPoint)
+		require.NoError(err)
+		expected, err := newTestOp(1, 0, 100).Hash(_testEntryPoint, big.NewInt(_testChainID))
+		require.NoError(err)
+		require.Equal(expected, h)
+		_, err = p.Add(ctx, newTestOp(1, 1, 200), _testEntryPoint)
+		require.NoError(err)
+		_, err = p.Add(ctx, newTestOp(1, 2, 100), _testEntryPoint)
+		require.ErrorIs(err, ErrEntityLimit)
+		// the replacement must bump the fees by 10%
+		_, err = p.Add(ctx, newTestOp(1, 0, 109), _testEntryPoint)
+		require.ErrorIs(err, ErrUnderpriced)
+		_, err = p.Add(ctx, newTestOp(1, 0, 110), _testEntryPoint)
+		require.NoError(err)
+		_, err = p.Add(ctx, newTestOp(2, 0, 150), _testEntryPoint)
+		require.NoError(err)
+		n := simCalls
+		_, err = p.Add(ctx, newTestOp(3, 0, 100), _testEntryPoint)
+		require.ErrorIs(err, ErrPoolFull)
+		// the admission is checked before the simulation
+		require.Equal(n, simCalls)
+
+		pending := p.Pending(_testEntryPoint)
+		require.Len(pending, 3)
+		for i, fee := range []int64{200, 150, 110} {
+			require.EqualValues(fee, pending[i].MaxPriorityFeePerGas.Int64())
+		}
+	})
+
+	t.Run("receiveBlock", func(t *testing.T) {
+		op := newTestOp(1, 1, 200)
+		h, err := op.Hash(_testEntryPoint, big.NewInt(_testChainID))
+		require.NoError(err)
+		epAddr, err := address.FromBytes(_testEntryPoint.Bytes())
+		require.NoError(err)
+		r := (&action.Receipt{}).AddLogs(&action.Log{
+			Address: epAddr.String(),
+			Topics:  []hash.Hash256{hash.Hash256(_entryPoint.Events["UserOperationEvent"].ID), hash.Hash256(h)},
+		})
+		blk, err := block.NewTestingBuilder().SetHeight(1).SetTimeStamp(time.Now()).
+			SetReceipts([]*action.Receipt{r}).SignAndBuild(identityset.PrivateKey(0))
+		require.NoError(err)
+		require.NoError(p.ReceiveBlock(&blk))
+		require.Len(p.Pending(_testEntryPoint), 2)
+	})
+
+	t.Run("reputation", func(t *testing.T) {
+		p, err := NewPool(cfg, _testChainID, func(context.Context, common.Address, []byte, vm.EVMLogger) ([]byte, error) {
+			return validationResult(t, validInfo()), nil
+		})
+		require.NoError(err)
+		for i := 0; i < 120; i++ {
+			p.reputation.seen(_testPaymaster)
+		}
+		op := newTestOp(1, 0, 100)
+		op.PaymasterAndData = _testPaymaster.Bytes()
+		_, err = p.Add(ctx, op, _testEntryPoint)
+		require.NoError(err)
+		// a throttled paymaster has limited user operations in the pool
+		op = newTestOp(2, 0, 100)
+		op.PaymasterAndData = _testPaymaster.Bytes()
+		_, err = p.Add(ctx, op, _testEntryPoint)
+		require.ErrorIs(err, ErrEntityLimit)
+		for i := 0; i < 500; i++ {
+			p.reputation.seen(_testPaymaster)
+		}
+		_, err = p.Add(ctx, op, _testEntryPoint)
+		require.ErrorIs(err, ErrBannedEntity)
+		rep := p.Reputation()
+		require.Len(rep, 1)
+		require.Equal(StatusBanned, rep[0].Status)
+	})
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package userop
+
+import (
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// the reputation parameters of ERC-7562
+const (
+	_minInclusionRateDenominator = 10
+	_throttlingSlack             = 10
+	_banSlack                    = 50
+)
+
+// ReputationStatus is the status of an entity
+type ReputationStatus string
+
+// the reputation statuses
+const (
+	StatusOK        ReputationStatus = "ok"
+	StatusThrottled ReputationStatus = "throttled"
+	StatusBanned    ReputationStatus = "banned"
+)
+
+type (
+	// ReputationEntry is the reputation of a paymaster or factory
+	ReputationEntry struct {
+		Address     common.Address   `json:"address"`
+		OpsSeen     uint64           `json:"opsSeen"`
+		OpsIncluded uint64           `json:"opsIncluded"`
+		Status      ReputationStatus `json:"status"`
+	}
+
+	// reputation tracks how many operations of an entity are seen in the pool and included on chain, an entity
+	// whose operations are seen much more often than included is throttled and then banned
+	reputation struct {
+		entries map[common.Address]*ReputationEntry
+	}
+)
+
+func newReputation() *reputation {
+	return &reputation{entries: make(map[common.Address]*ReputationEntry)}
+}
+
+func (r *reputation) entry(addr common.Address) *ReputationEntry {
+	e, ok := r.entries[addr]
+	if !ok {
+		e = &ReputationEntry{Address: addr}
+		r.entries[addr] = e
+	}
+	return e
+}
+
+func (r *reputation) seen(addr common.Address) {
+	r.entry(addr).OpsSeen++
+}
+
+func (r *reputation) included(addr common.Address) {
+	r.entry(addr).OpsIncluded++
+}
+
+func (r *reputation) status(addr common.Address) ReputationStatus {
+	e, ok := r.entries[addr]
+	if !ok {
+		return StatusOK
+	}
+	maxSeen := e.OpsSeen / _minInclusionRateDenominator
+	switch {
+	case maxSeen > e.OpsIncluded+_banSlack:
+		return StatusBanned
+	case maxSeen > e.OpsIncluded+_throttlingSlack:
+		return StatusThrottled
+	default:
+		return StatusOK
+	}
+}
+
+// decay scales the counters by 23/24, which is applied hourly, and drops the entries decayed to zero
+func (r *reputation) decay() {
+	for addr, e := range r.entries {
+		e.OpsSeen = e.OpsSeen * 23 / 24
+		e.OpsIncluded = e.OpsIncluded * 23 / 24
+		if e.OpsSeen == 0 && e.OpsIncluded == 0 {
+			delete(r.entries, addr)
+		}
+	}
+}
+
+func (r *reputation) dump() []ReputationEntry {
+	ret := make([]ReputationEntry, 0, len(r.entries))
+	for addr, e := range r.entries {
+		entry := *e
+		entry.Status = r.status(addr)
+		ret = append(ret, entry)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Address.Hex() < ret[j].Address.Hex()
+	})
+	return ret
+}
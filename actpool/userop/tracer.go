@@ -0,0 +1,143 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package userop
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/pkg/errors"
+)
+
+// ErrBannedOpcode indicates the validation of the user operation uses an opcode banned by ERC-7562
+var ErrBannedOpcode = errors.New("banned opcode in validation")
+
+// _bannedOpcodes are the opcodes the account, factory and paymaster cannot use during validation, since their result
+// differs between the simulation and the inclusion of the operation
+var _bannedOpcodes = map[vm.OpCode]struct{}{
+	vm.ORIGIN:       {},
+	vm.GASPRICE:     {},
+	vm.BLOCKHASH:    {},
+	vm.COINBASE:     {},
+	vm.TIMESTAMP:    {},
+	vm.NUMBER:       {},
+	vm.DIFFICULTY:   {},
+	vm.GASLIMIT:     {},
+	vm.SELFBALANCE:  {},
+	vm.BALANCE:      {},
+	vm.BASEFEE:      {},
+	vm.BLOBHASH:     {},
+	vm.BLOBBASEFEE:  {},
+	vm.CREATE:       {},
+	vm.SELFDESTRUCT: {},
+	vm.INVALID:      {},
+}
+
+// opcodeTracer checks the opcodes used by the entities during the simulation of the validation. The frames entered
+// into the sender, factory or paymaster are attributed to the entity, as well as the frames they call into; the
+// frames of the entry point itself are not checked
+type opcodeTracer struct {
+	sender    common.Address
+	factory   *common.Address
+	paymaster *common.Address
+	frames    []*common.Address
+	lastOp    vm.OpCode
+	create2   int
+	err       error
+}
+
+func newOpcodeTracer(op *UserOperation) *opcodeTracer {
+	return &opcodeTracer{
+		sender:    op.Sender,
+		factory:   op.Factory(),
+		paymaster: op.Paymaster(),
+	}
+}
+
+func (t *opcodeTracer) entity(addr common.Address) *common.Address {
+	switch {
+	case addr == t.sender:
+		return &t.sender
+	case t.factory != nil && addr == *t.factory:
+		return t.factory
+	case t.paymaster != nil && addr == *t.paymaster:
+		return t.paymaster
+	}
+	return nil
+}
+
+func (t *opcodeTracer) fail(entity *common.Address, op vm.OpCode) {
+	if t.err == nil {
+		t.err = errors.Wrapf(ErrBannedOpcode, "%s uses %s", entity.Hex(), op)
+	}
+}
+
+// CaptureTxStart implements vm.EVMLogger
+func (t *opcodeTracer) CaptureTxStart(uint64) {}
+
+// CaptureTxEnd implements vm.EVMLogger
+func (t *opcodeTracer) CaptureTxEnd(uint64) {}
+
+// CaptureStart implements vm.EVMLogger
+func (t *opcodeTracer) CaptureStart(_ *vm.EVM, _, _ common.Address, _ bool, _ []byte, _ uint64, _ *big.Int) {
+	t.frames = append(t.frames[:0], nil)
+}
+
+// CaptureEnd implements vm.EVMLogger
+func (t *opcodeTracer) CaptureEnd([]byte, uint64, error) {}
+
+// CaptureEnter implements vm.EVMLogger
+func (t *opcodeTracer) CaptureEnter(_ vm.OpCode, _, to common.Address, _ []byte, _ uint64, _ *big.Int) {
+	entity := t.entity(to)
+	if entity == nil && len(t.frames) > 0 {
+		entity = t.frames[len(t.frames)-1]
+	}
+	t.frames = append(t.frames, entity)
+}
+
+// CaptureExit implements vm.EVMLogger
+func (t *opcodeTracer) CaptureExit([]byte, uint64, error) {
+	if len(t.frames) > 0 {
+		t.frames = t.frames[:len(t.frames)-1]
+	}
+}
+
+// CaptureState implements vm.EVMLogger
+func (t *opcodeTracer) CaptureState(_ uint64, op vm.OpCode, _, _ uint64, _ *vm.ScopeContext, _ []byte, _ int, _ error) {
+	lastOp := t.lastOp
+	t.lastOp = op
+	if len(t.frames) == 0 {
+		return
+	}
+	entity := t.frames[len(t.frames)-1]
+	if entity == nil {
+		return
+	}
+	// GAS is allowed only to pass the gas to a call
+	if lastOp == vm.GAS {
+		switch op {
+		case vm.CALL, vm.DELEGATECALL, vm.CALLCODE, vm.STATICCALL:
+		default:
+			t.fail(entity, vm.GAS)
+		}
+	}
+	if _, ok := _bannedOpcodes[op]; ok {
+		t.fail(entity, op)
+		return
+	}
+	// the factory could deploy the account with CREATE2 once
+	if op == vm.CREATE2 {
+		t.create2++
+		if t.factory == nil || entity != t.factory || t.create2 > 1 {
+			t.fail(entity, op)
+		}
+	}
+}
+
+// CaptureFault implements vm.EVMLogger
+func (t *opcodeTracer) CaptureFault(uint64, vm.OpCode, uint64, uint64, *vm.ScopeContext, int, error) {
+}
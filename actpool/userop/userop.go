@@ -0,0 +1,287 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package userop
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+)
+
+// _entryPointABI is the part of the v0.6 entry point interface used by the pool
+const _entryPointABI = `[
+	{
+		"inputs": [
+			{
+				"components": [
+					{"internalType": "address", "name": "sender", "type": "address"},
+					{"internalType": "uint256", "name": "nonce", "type": "uint256"},
+					{"internalType": "bytes", "name": "initCode", "type": "bytes"},
+					{"internalType": "bytes", "name": "callData", "type": "bytes"},
+					{"internalType": "uint256", "name": "callGasLimit", "type": "uint256"},
+					{"internalType": "uint256", "name": "verificationGasLimit", "type": "uint256"},
+					{"internalType": "uint256", "name": "preVerificationGas", "type": "uint256"},
+					{"internalType": "uint256", "name": "maxFeePerGas", "type": "uint256"},
+					{"internalType": "uint256", "name": "maxPriorityFeePerGas", "type": "uint256"},
+					{"internalType": "bytes", "name": "paymasterAndData", "type": "bytes"},
+					{"internalType": "bytes", "name": "signature", "type": "bytes"}
+				],
+				"internalType": "struct UserOperation",
+				"name": "userOp",
+				"type": "tuple"
+			}
+		],
+		"name": "simulateValidation",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{"internalType": "uint256", "name": "opIndex", "type": "uint256"},
+			{"internalType": "string", "name": "reason", "type": "string"}
+		],
+		"name": "FailedOp",
+		"type": "error"
+	},
+	{
+		"inputs": [
+			{
+				"components": [
+					{"internalType": "uint256", "name": "preOpGas", "type": "uint256"},
+					{"internalType": "uint256", "name": "prefund", "type": "uint256"},
+					{"internalType": "bool", "name": "sigFailed", "type": "bool"},
+					{"internalType": "uint48", "name": "validAfter", "type": "uint48"},
+					{"internalType": "uint48", "name": "validUntil", "type": "uint48"},
+					{"internalType": "bytes", "name": "paymasterContext", "type": "bytes"}
+				],
+				"internalType": "struct IEntryPoint.ReturnInfo",
+				"name": "returnInfo",
+				"type": "tuple"
+			},
+			{
+				"components": [
+					{"internalType": "uint256", "name": "stake", "type": "uint256"},
+					{"internalType": "uint256", "name": "unstakeDelaySec", "type": "uint256"}
+				],
+				"internalType": "struct IStakeManager.StakeInfo",
+				"name": "senderInfo",
+				"type": "tuple"
+			},
+			{
+				"components": [
+					{"internalType": "uint256", "name": "stake", "type": "uint256"},
+					{"internalType": "uint256", "name": "unstakeDelaySec", "type": "uint256"}
+				],
+				"internalType": "struct IStakeManager.StakeInfo",
+				"name": "factoryInfo",
+				"type": "tuple"
+			},
+			{
+				"components": [
+					{"internalType": "uint256", "name": "stake", "type": "uint256"},
+					{"internalType": "uint256", "name": "unstakeDelaySec", "type": "uint256"}
+				],
+				"internalType": "struct IStakeManager.StakeInfo",
+				"name": "paymasterInfo",
+				"type": "tuple"
+			}
+		],
+		"name": "ValidationResult",
+		"type": "error"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "internalType": "bytes32", "name": "userOpHash", "type": "bytes32"},
+			{"indexed": true, "internalType": "address", "name": "sender", "type": "address"},
+			{"indexed": true, "internalType": "address", "name": "paymaster", "type": "address"},
+			{"indexed": false, "internalType": "uint256", "name": "nonce", "type": "uint256"},
+			{"indexed": false, "internalType": "bool", "name": "success", "type": "bool"},
+			{"indexed": false, "internalType": "uint256", "name": "actualGasCost", "type": "uint256"},
+			{"indexed": false, "internalType": "uint256", "name": "actualGasUsed", "type": "uint256"}
+		],
+		"name": "UserOperationEvent",
+		"type": "event"
+	}
+]`
+
+var (
+	_entryPoint       abi.ABI
+	_failedOp         abi.Error
+	_validationResult abi.Error
+	// _packedArgs is the layout of the user operation hashed into the user operation hash
+	_packedArgs abi.Arguments
+	_hashArgs   abi.Arguments
+
+	// ErrInvalidUserOp indicates the user operation is malformed
+	ErrInvalidUserOp = errors.New("invalid user operation")
+)
+
+func init() {
+	var err error
+	if _entryPoint, err = abi.JSON(strings.NewReader(_entryPointABI)); err != nil {
+		panic(err)
+	}
+	_failedOp = _entryPoint.Errors["FailedOp"]
+	_validationResult = _entryPoint.Errors["ValidationResult"]
+	newType := func(t string) abi.Type {
+		typ, err := abi.NewType(t, "", nil)
+		if err != nil {
+			panic(err)
+		}
+		return typ
+	}
+	for _, t := range []string{
+		"address", "uint256", "bytes32", "bytes32", "uint256", "uint256", "uint256", "uint256", "uint256", "bytes32",
+	} {
+		_packedArgs = append(_packedArgs, abi.Argument{Type: newType(t)})
+	}
+	_hashArgs = abi.Arguments{{Type: newType("bytes32")}, {Type: newType("address")}, {Type: newType("uint256")}}
+}
+
+// UserOperation is the v0.6 ERC-4337 user operation
+type UserOperation struct {
+	Sender               common.Address `abi:"sender"`
+	Nonce                *big.Int       `abi:"nonce"`
+	InitCode             []byte         `abi:"initCode"`
+	CallData             []byte         `abi:"callData"`
+	CallGasLimit         *big.Int       `abi:"callGasLimit"`
+	VerificationGasLimit *big.Int       `abi:"verificationGasLimit"`
+	PreVerificationGas   *big.Int       `abi:"preVerificationGas"`
+	MaxFeePerGas         *big.Int       `abi:"maxFeePerGas"`
+	MaxPriorityFeePerGas *big.Int       `abi:"maxPriorityFeePerGas"`
+	PaymasterAndData     []byte         `abi:"paymasterAndData"`
+	Signature            []byte         `abi:"signature"`
+}
+
+type userOperationJSON struct {
+	Sender               common.Address `json:"sender"`
+	Nonce                *hexutil.Big   `json:"nonce"`
+	InitCode             hexutil.Bytes  `json:"initCode"`
+	CallData             hexutil.Bytes  `json:"callData"`
+	CallGasLimit         *hexutil.Big   `json:"callGasLimit"`
+	VerificationGasLimit *hexutil.Big   `json:"verificationGasLimit"`
+	PreVerificationGas   *hexutil.Big   `json:"preVerificationGas"`
+	MaxFeePerGas         *hexutil.Big   `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big   `json:"maxPriorityFeePerGas"`
+	PaymasterAndData     hexutil.Bytes  `json:"paymasterAndData"`
+	Signature            hexutil.Bytes  `json:"signature"`
+}
+
+// MarshalJSON marshals the user operation in the format of the bundler rpc
+func (op *UserOperation) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&userOperationJSON{
+		Sender:               op.Sender,
+		Nonce:                (*hexutil.Big)(op.Nonce),
+		InitCode:             op.InitCode,
+		CallData:             op.CallData,
+		CallGasLimit:         (*hexutil.Big)(op.CallGasLimit),
+		VerificationGasLimit: (*hexutil.Big)(op.VerificationGasLimit),
+		PreVerificationGas:   (*hexutil.Big)(op.PreVerificationGas),
+		MaxFeePerGas:         (*hexutil.Big)(op.MaxFeePerGas),
+		MaxPriorityFeePerGas: (*hexutil.Big)(op.MaxPriorityFeePerGas),
+		PaymasterAndData:     op.PaymasterAndData,
+		Signature:            op.Signature,
+	})
+}
+
+// UnmarshalJSON unmarshals the user operation in the format of the bundler rpc
+func (op *UserOperation) UnmarshalJSON(data []byte) error {
+	var v userOperationJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	for _, b := range []*hexutil.Big{
+		v.Nonce, v.CallGasLimit, v.VerificationGasLimit, v.PreVerificationGas, v.MaxFeePerGas, v.MaxPriorityFeePerGas,
+	} {
+		if b == nil {
+			return errors.Wrap(ErrInvalidUserOp, "missing numeric field")
+		}
+	}
+	*op = UserOperation{
+		Sender:               v.Sender,
+		Nonce:                v.Nonce.ToInt(),
+		InitCode:             v.InitCode,
+		CallData:             v.CallData,
+		CallGasLimit:         v.CallGasLimit.ToInt(),
+		VerificationGasLimit: v.VerificationGasLimit.ToInt(),
+		PreVerificationGas:   v.PreVerificationGas.ToInt(),
+		MaxFeePerGas:         v.MaxFeePerGas.ToInt(),
+		MaxPriorityFeePerGas: v.MaxPriorityFeePerGas.ToInt(),
+		PaymasterAndData:     v.PaymasterAndData,
+		Signature:            v.Signature,
+	}
+	return nil
+}
+
+// Hash returns the user operation hash, which is signed by the account and emitted by the entry point
+func (op *UserOperation) Hash(entryPoint common.Address, chainID *big.Int) (common.Hash, error) {
+	packed, err := _packedArgs.Pack(
+		op.Sender,
+		op.Nonce,
+		crypto.Keccak256Hash(op.InitCode),
+		crypto.Keccak256Hash(op.CallData),
+		op.CallGasLimit,
+		op.VerificationGasLimit,
+		op.PreVerificationGas,
+		op.MaxFeePerGas,
+		op.MaxPriorityFeePerGas,
+		crypto.Keccak256Hash(op.PaymasterAndData),
+	)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	b, err := _hashArgs.Pack(crypto.Keccak256Hash(packed), entryPoint, chainID)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash(b), nil
+}
+
+// Factory returns the factory deploying the account, nil if the account is deployed
+func (op *UserOperation) Factory() *common.Address {
+	return entityOf(op.InitCode)
+}
+
+// Paymaster returns the paymaster paying for the operation, nil if the account pays by itself
+func (op *UserOperation) Paymaster() *common.Address {
+	return entityOf(op.PaymasterAndData)
+}
+
+func entityOf(b []byte) *common.Address {
+	if len(b) < common.AddressLength {
+		return nil
+	}
+	addr := common.BytesToAddress(b[:common.AddressLength])
+	return &addr
+}
+
+func (op *UserOperation) sanityCheck() error {
+	for _, b := range []*big.Int{
+		op.Nonce, op.CallGasLimit, op.VerificationGasLimit, op.PreVerificationGas, op.MaxFeePerGas, op.MaxPriorityFeePerGas,
+	} {
+		if b == nil || b.Sign() < 0 {
+			return errors.Wrap(ErrInvalidUserOp, "invalid numeric field")
+		}
+	}
+	if op.MaxPriorityFeePerGas.Cmp(op.MaxFeePerGas) > 0 {
+		return errors.Wrap(ErrInvalidUserOp, "maxPriorityFeePerGas is higher than maxFeePerGas")
+	}
+	if len(op.InitCode) > 0 && len(op.InitCode) < common.AddressLength {
+		return errors.Wrap(ErrInvalidUserOp, "initCode is too short")
+	}
+	if len(op.PaymasterAndData) > 0 && len(op.PaymasterAndData) < common.AddressLength {
+		return errors.Wrap(ErrInvalidUserOp, "paymasterAndData is too short")
+	}
+	return nil
+}
@@ -48,7 +48,7 @@ func (v *blobValidator) OnAdded(ctx context.Context, act *action.SealedEnvelope)
 	v.blobCntPerAcc[sender]++
 }
 
-func (v *blobValidator) OnRemoved(act *action.SealedEnvelope) {
+func (v *blobValidator) OnRemoved(act *action.SealedEnvelope, _ EvictionReason) {
 	if len(act.BlobHashes()) == 0 {
 		return
 	}
@@ -56,7 +56,7 @@ func (v *blobValidator) OnRemoved(act *action.SealedEnvelope) {
 	defer v.mutex.Unlock()
 	sender := act.SenderAddress().String()
 	if v.blobCntPerAcc[sender] == 0 {
-		log.L().Warn("blob count per account is already 0", zap.String("sender", sender))
+		log.Logger("actpool").Warn("blob count per account is already 0", zap.String("sender", sender))
 		return
 	}
 	v.blobCntPerAcc[sender]--
@@ -7,6 +7,10 @@ type (
 	Config struct {
 		Size     int           `yaml:"syncSize"`
 		Interval time.Duration `yaml:"syncInterval"`
+		// MempoolSyncInterval is the interval to announce pooled action hashes to peers, 0 disables mempool sync
+		MempoolSyncInterval time.Duration `yaml:"mempoolSyncInterval"`
+		// MempoolSyncSize is the max number of pooled action hashes announced to a peer in one round
+		MempoolSyncSize int `yaml:"mempoolSyncSize"`
 	}
 	// Helper is the helper for action syncer
 	Helper struct {
@@ -17,6 +21,8 @@ type (
 
 // DefaultConfig is the default configuration
 var DefaultConfig = Config{
-	Size:     1000,
-	Interval: 5 * time.Second,
+	Size:                1000,
+	Interval:            5 * time.Second,
+	MempoolSyncInterval: 30 * time.Second,
+	MempoolSyncSize:     500,
 }
@@ -0,0 +1,172 @@
+package actsync
+
+import (
+	"context"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotexrpc"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/pkg/fastrand"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+	"github.com/iotexproject/iotex-core/v2/pkg/routine"
+)
+
+// MempoolProtocol is the name of the p2p protocol announcing the hashes of the pooled actions
+const MempoolProtocol = "mempool"
+
+// _maxAnnounceHashes is the max number of the action hashes in an announcement, more hashes are split into several
+// announcements
+const _maxAnnounceHashes = 256
+
+type (
+	// PoolActionHashes returns the hashes of the actions in local action pool
+	PoolActionHashes func() []hash.Hash256
+	// AnnounceOutbound sends the announcement of the mempool protocol to the peer
+	AnnounceOutbound func(context.Context, peer.AddrInfo, []byte) error
+	// ActionHashHandler handles an action hash announced by the peer
+	ActionHashHandler func(context.Context, hash.Hash256, string) error
+
+	// MempoolHelper is the helper for mempool syncer
+	MempoolHelper struct {
+		P2PNeighbor      Neighbors
+		AnnounceOutbound AnnounceOutbound
+		HandleActionHash ActionHashHandler
+	}
+
+	// MempoolSync announces the hashes of pooled actions to peers when they get connected and
+	// periodically afterwards, a peer requests the actions it doesn't have via ActionSync, so
+	// that a restarted node repopulates its action pool quickly
+	MempoolSync struct {
+		cfg        Config
+		helper     *MempoolHelper
+		poolHashes PoolActionHashes
+		// knownPeers are the peers connected in last round, accessed by the recurring task only
+		knownPeers map[peer.ID]struct{}
+		task       *routine.RecurringTask
+	}
+)
+
+// NewMempoolSync creates a new mempool syncer
+func NewMempoolSync(cfg Config, helper *MempoolHelper, poolHashes PoolActionHashes) *MempoolSync {
+	ms := &MempoolSync{
+		cfg:        cfg,
+		helper:     helper,
+		poolHashes: poolHashes,
+		knownPeers: make(map[peer.ID]struct{}),
+	}
+	if cfg.MempoolSyncInterval > 0 {
+		ms.task = routine.NewRecurringTask(ms.announce, cfg.MempoolSyncInterval)
+	}
+	return ms
+}
+
+// Start starts the mempool syncer
+func (ms *MempoolSync) Start(ctx context.Context) error {
+	if ms.task == nil {
+		return nil
+	}
+	log.L().Info("starting mempool sync")
+	return ms.task.Start(ctx)
+}
+
+// Stop stops the mempool syncer
+func (ms *MempoolSync) Stop(ctx context.Context) error {
+	if ms.task == nil {
+		return nil
+	}
+	log.L().Info("stopping mempool sync")
+	return ms.task.Stop(ctx)
+}
+
+// announce sends the pooled action hashes to newly connected peers and a few random peers
+func (ms *MempoolSync) announce() {
+	neighbors, err := ms.helper.P2PNeighbor()
+	if err != nil {
+		log.L().Debug("Failed to get neighbors", zap.Error(err))
+		return
+	}
+	var (
+		connected = make(map[peer.ID]struct{}, len(neighbors))
+		targets   = make([]peer.AddrInfo, 0)
+		others    = make([]peer.AddrInfo, 0, len(neighbors))
+	)
+	for _, nb := range neighbors {
+		connected[nb.ID] = struct{}{}
+		if _, ok := ms.knownPeers[nb.ID]; ok {
+			others = append(others, nb)
+		} else {
+			targets = append(targets, nb)
+		}
+	}
+	ms.knownPeers = connected
+	for i := 0; i < batchPeerSize && len(others) > 0; i++ {
+		idx := fastrand.Uint32n(uint32(len(others)))
+		targets = append(targets, others[idx])
+		others[idx] = others[len(others)-1]
+		others = others[:len(others)-1]
+	}
+	if len(targets) == 0 {
+		return
+	}
+	hashes := ms.poolHashes()
+	if len(hashes) == 0 {
+		return
+	}
+	if ms.cfg.MempoolSyncSize > 0 && len(hashes) > ms.cfg.MempoolSyncSize {
+		hashes = hashes[:ms.cfg.MempoolSyncSize]
+	}
+	for _, target := range targets {
+		ms.announceTo(target, hashes)
+	}
+}
+
+// announceTo sends the hashes to the peer in as few announcements as possible
+func (ms *MempoolSync) announceTo(target peer.AddrInfo, hashes []hash.Hash256) {
+	ctx, cancel := context.WithTimeout(context.Background(), unicaseTimeout)
+	defer cancel()
+	for start := 0; start < len(hashes); start += _maxAnnounceHashes {
+		end := min(start+_maxAnnounceHashes, len(hashes))
+		msg := &iotexrpc.ActionSync{Hashes: make([][]byte, 0, end-start)}
+		for i := start; i < end; i++ {
+			msg.Hashes = append(msg.Hashes, hashes[i][:])
+		}
+		data, err := proto.Marshal(msg)
+		if err != nil {
+			log.L().Error("Failed to marshal action hashes", zap.Error(err))
+			return
+		}
+		if err := ms.helper.AnnounceOutbound(ctx, target, data); err != nil {
+			log.L().Debug("Failed to announce action hashes", zap.Error(err), zap.String("peer", target.String()))
+			counterMtc.WithLabelValues("mempool_announce_failed").Inc()
+			return
+		}
+		counterMtc.WithLabelValues("mempool_announce").Add(float64(end - start))
+	}
+}
+
+// HandleMessage handles the announcement of the mempool protocol from the peer. An error is returned only if the
+// announcement is malformed
+func (ms *MempoolSync) HandleMessage(ctx context.Context, from peer.AddrInfo, data []byte) error {
+	msg := &iotexrpc.ActionSync{}
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return errors.Wrap(err, "failed to parse mempool announcement")
+	}
+	if len(msg.Hashes) > _maxAnnounceHashes {
+		return errors.Errorf("too many action hashes announced: %d", len(msg.Hashes))
+	}
+	for _, h := range msg.Hashes {
+		if len(h) != len(hash.ZeroHash256) {
+			return errors.Errorf("invalid action hash length %d", len(h))
+		}
+	}
+	for _, h := range msg.Hashes {
+		if err := ms.helper.HandleActionHash(ctx, hash.BytesToHash256(h), from.ID.String()); err != nil {
+			log.L().Debug("Failed to handle action hash", zap.Error(err), log.Hex("hash", h))
+		}
+	}
+	return nil
+}
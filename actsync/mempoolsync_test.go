@@ -0,0 +1,121 @@
+package actsync
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotexrpc"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestMempoolSyncAnnounce(t *testing.T) {
+	r := require.New(t)
+	var (
+		mu        sync.Mutex
+		peers     = []peer.AddrInfo{{ID: "peer1"}, {ID: "peer2"}, {ID: "peer3"}, {ID: "peer4"}}
+		neighbors = peers[:3]
+		received  = make(map[peer.ID][]hash.Hash256)
+		messages  = make(map[peer.ID]int)
+		hashes    = []hash.Hash256{hash.Hash256b([]byte("1")), hash.Hash256b([]byte("2")), hash.Hash256b([]byte("3"))}
+	)
+	cfg := DefaultConfig
+	cfg.MempoolSyncSize = 2
+	ms := NewMempoolSync(cfg, &MempoolHelper{
+		P2PNeighbor: func() ([]peer.AddrInfo, error) {
+			return neighbors, nil
+		},
+		AnnounceOutbound: func(_ context.Context, p peer.AddrInfo, data []byte) error {
+			mu.Lock()
+			defer mu.Unlock()
+			msg := &iotexrpc.ActionSync{}
+			r.NoError(proto.Unmarshal(data, msg))
+			for _, h := range msg.Hashes {
+				received[p.ID] = append(received[p.ID], hash.BytesToHash256(h))
+			}
+			messages[p.ID]++
+			return nil
+		},
+	}, func() []hash.Hash256 {
+		return hashes
+	})
+	r.NoError(ms.Start(context.Background()))
+	defer func() {
+		r.NoError(ms.Stop(context.Background()))
+	}()
+
+	// all peers are newly connected
+	ms.announce()
+	r.Len(received, 3)
+	for _, p := range neighbors {
+		r.Equal(hashes[:2], received[p.ID])
+		// the hashes are announced in one message
+		r.Equal(1, messages[p.ID])
+	}
+
+	// newly connected peer and random known peers
+	received = make(map[peer.ID][]hash.Hash256)
+	neighbors = peers
+	ms.announce()
+	r.Len(received, 1+batchPeerSize)
+	r.Equal(hashes[:2], received["peer4"])
+
+	// nothing to announce
+	received = make(map[peer.ID][]hash.Hash256)
+	hashes = nil
+	ms.announce()
+	r.Empty(received)
+
+	// too many hashes are split into several announcements
+	received = make(map[peer.ID][]hash.Hash256)
+	messages = make(map[peer.ID]int)
+	hashes = make([]hash.Hash256, _maxAnnounceHashes+1)
+	for i := range hashes {
+		hashes[i] = hash.Hash256b([]byte{byte(i), byte(i >> 8)})
+	}
+	ms.announceTo(peers[0], hashes)
+	r.Equal(hashes, received["peer1"])
+	r.Equal(2, messages["peer1"])
+}
+
+func TestMempoolSyncHandleMessage(t *testing.T) {
+	r := require.New(t)
+	var handled []hash.Hash256
+	ms := NewMempoolSync(DefaultConfig, &MempoolHelper{
+		HandleActionHash: func(_ context.Context, h hash.Hash256, from string) error {
+			r.Equal(peer.ID("peer1").String(), from)
+			handled = append(handled, h)
+			return nil
+		},
+	}, nil)
+	from := peer.AddrInfo{ID: "peer1"}
+	hashes := []hash.Hash256{hash.Hash256b([]byte("1")), hash.Hash256b([]byte("2"))}
+	data, err := proto.Marshal(&iotexrpc.ActionSync{Hashes: [][]byte{hashes[0][:], hashes[1][:]}})
+	r.NoError(err)
+	r.NoError(ms.HandleMessage(context.Background(), from, data))
+	r.Equal(hashes, handled)
+
+	// malformed announcements
+	handled = nil
+	r.Error(ms.HandleMessage(context.Background(), from, []byte{0xff}))
+	data, err = proto.Marshal(&iotexrpc.ActionSync{Hashes: [][]byte{hashes[0][:], {1, 2, 3}}})
+	r.NoError(err)
+	r.Error(ms.HandleMessage(context.Background(), from, data))
+	data, err = proto.Marshal(&iotexrpc.ActionSync{Hashes: make([][]byte, _maxAnnounceHashes+1)})
+	r.NoError(err)
+	r.Error(ms.HandleMessage(context.Background(), from, data))
+	r.Empty(handled)
+}
+
+func TestMempoolSyncDisabled(t *testing.T) {
+	r := require.New(t)
+	cfg := DefaultConfig
+	cfg.MempoolSyncInterval = 0
+	ms := NewMempoolSync(cfg, &MempoolHelper{}, nil)
+	r.Nil(ms.task)
+	r.NoError(ms.Start(context.Background()))
+	r.NoError(ms.Stop(context.Background()))
+}
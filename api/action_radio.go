@@ -9,6 +9,7 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/actpool"
 	"github.com/iotexproject/iotex-core/v2/pkg/log"
 	batch "github.com/iotexproject/iotex-core/v2/pkg/messagebatcher"
 )
@@ -94,4 +95,4 @@ func (ar *ActionRadio) OnAdded(ctx context.Context, selp *action.SealedEnvelope)
 }
 
 // OnRemoved does nothing
-func (ar *ActionRadio) OnRemoved(act *action.SealedEnvelope) {}
+func (ar *ActionRadio) OnRemoved(act *action.SealedEnvelope, _ actpool.EvictionReason) {}
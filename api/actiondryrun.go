@@ -0,0 +1,104 @@
+package api
+
+import (
+	"context"
+	"encoding/hex"
+
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/actpool"
+	"github.com/iotexproject/iotex-core/v2/api/apipb"
+)
+
+// actionService serves the action submission checks
+type actionService struct {
+	coreService CoreService
+}
+
+// SendActionDryRun validates the action as SendAction does, without adding it into the actpool.
+// It returns the hash of the action, and the rejection error which the reason is told from
+func (core *coreService) SendActionDryRun(ctx context.Context, in *iotextypes.Action) (string, error) {
+	selp, err := (&action.Deserializer{}).SetEvmNetworkID(core.EVMNetworkID()).ActionToSealedEnvelope(in)
+	if err != nil {
+		return "", errors.Wrap(action.ErrInvalidProto, err.Error())
+	}
+	hash, err := selp.Hash()
+	if err != nil {
+		return "", errors.Wrap(action.ErrInvalidProto, err.Error())
+	}
+	actHash := hex.EncodeToString(hash[:])
+	if err := core.validateChainID(in.GetCore().GetChainID()); err != nil {
+		return actHash, errors.Wrap(action.ErrChainID, status.Convert(err).Message())
+	}
+	var (
+		g        = core.Genesis()
+		deployer = selp.SenderAddress()
+	)
+	if !selp.Protected() && !g.IsDeployerWhitelisted(deployer) {
+		return actHash, errors.Wrapf(action.ErrChainID, "replay deployer %v not whitelisted", deployer.Hex())
+	}
+	ctx = WithAPIContext(protocol.WithRegistry(ctx, core.registry))
+	return actHash, core.ap.CheckAction(ctx, selp)
+}
+
+func newActionService(core CoreService) *actionService {
+	return &actionService{
+		coreService: core,
+	}
+}
+
+// SubmitActionDryRun validates the action as it is submitted, without adding it into the actpool
+func (svr *actionService) SubmitActionDryRun(ctx context.Context, in *apipb.SubmitActionDryRunRequest) (*apipb.SubmitActionDryRunResponse, error) {
+	if in.GetAction() == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty action")
+	}
+	actHash, err := svr.coreService.SendActionDryRun(ctx, in.GetAction())
+	resp := &apipb.SubmitActionDryRunResponse{
+		ActionHash: actHash,
+		Reason:     rejectReason(err),
+	}
+	if err != nil {
+		resp.Message = err.Error()
+	}
+	return resp, nil
+}
+
+// rejectReason tells the reason of the rejection error of an action
+func rejectReason(err error) apipb.RejectReason {
+	if err == nil {
+		return apipb.RejectReason_ACCEPTED
+	}
+	switch errors.Cause(err) {
+	case action.ErrChainID:
+		return apipb.RejectReason_INVALID_CHAIN_ID
+	case action.ErrAddress, action.ErrInvalidSender:
+		return apipb.RejectReason_INVALID_SENDER
+	case action.ErrNonceTooLow:
+		return apipb.RejectReason_NONCE_TOO_LOW
+	case action.ErrNonceTooHigh:
+		return apipb.RejectReason_NONCE_TOO_HIGH
+	case action.ErrUnderpriced, action.ErrGasFeeCapTooLow:
+		return apipb.RejectReason_UNDERPRICED
+	case action.ErrReplaceUnderpriced:
+		return apipb.RejectReason_REPLACEMENT_UNDERPRICED
+	case action.ErrInsufficientFunds:
+		return apipb.RejectReason_INSUFFICIENT_BALANCE
+	case action.ErrIntrinsicGas:
+		return apipb.RejectReason_INTRINSIC_GAS
+	case action.ErrGasLimit, actpool.ErrGasTooHigh:
+		return apipb.RejectReason_GAS_LIMIT_EXCEEDED
+	case action.ErrOversizedData:
+		return apipb.RejectReason_OVERSIZED_DATA
+	case action.ErrExistedInPool:
+		return apipb.RejectReason_KNOWN_ACTION
+	case action.ErrTxPoolOverflow:
+		return apipb.RejectReason_POOL_FULL
+	default:
+		return apipb.RejectReason_INVALID_ACTION
+	}
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/actpool"
+	"github.com/iotexproject/iotex-core/v2/api/apipb"
+)
+
+func TestActionService_SubmitActionDryRun(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := NewMockCoreService(ctrl)
+	svr := newActionService(core)
+
+	_, err := svr.SubmitActionDryRun(context.Background(), &apipb.SubmitActionDryRunRequest{})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+
+	for _, c := range []struct {
+		err    error
+		reason apipb.RejectReason
+	}{
+		{nil, apipb.RejectReason_ACCEPTED},
+		{errors.Wrap(action.ErrChainID, "replay deployer not whitelisted"), apipb.RejectReason_INVALID_CHAIN_ID},
+		{action.ErrNonceTooLow, apipb.RejectReason_NONCE_TOO_LOW},
+		{errors.Wrap(action.ErrNonceTooHigh, "nonce 5 is larger than pending nonce 3"), apipb.RejectReason_NONCE_TOO_HIGH},
+		{action.ErrUnderpriced, apipb.RejectReason_UNDERPRICED},
+		{errors.Wrap(action.ErrReplaceUnderpriced, "gas fee cap 1 < 1"), apipb.RejectReason_REPLACEMENT_UNDERPRICED},
+		{action.ErrInsufficientFunds, apipb.RejectReason_INSUFFICIENT_BALANCE},
+		{action.ErrIntrinsicGas, apipb.RejectReason_INTRINSIC_GAS},
+		{actpool.ErrGasTooHigh, apipb.RejectReason_GAS_LIMIT_EXCEEDED},
+		{action.ErrExistedInPool, apipb.RejectReason_KNOWN_ACTION},
+		{errors.New("unknown"), apipb.RejectReason_INVALID_ACTION},
+	} {
+		core.EXPECT().SendActionDryRun(gomock.Any(), gomock.Any()).Return("0123", c.err).Times(1)
+		resp, err := svr.SubmitActionDryRun(context.Background(), &apipb.SubmitActionDryRunRequest{Action: &iotextypes.Action{}})
+		require.NoError(err)
+		require.Equal("0123", resp.GetActionHash())
+		require.Equal(c.reason, resp.GetReason())
+		if c.err != nil {
+			require.Equal(c.err.Error(), resp.GetMessage())
+		} else {
+			require.Empty(resp.GetMessage())
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/actpool"
+	apitypes "github.com/iotexproject/iotex-core/v2/api/types"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+type (
+	// pendingActionNotifier passes the actions accepted by actpool to the chain listener
+	pendingActionNotifier struct {
+		listener apitypes.Listener
+	}
+
+	web3PendingActionListener struct {
+		streamHandle streamHandler
+		assemble     func(*action.SealedEnvelope) (interface{}, error)
+	}
+)
+
+// OnAdded passes the new action to the listener
+func (n *pendingActionNotifier) OnAdded(_ context.Context, selp *action.SealedEnvelope) {
+	if err := n.listener.ReceiveAction(selp); err != nil {
+		log.L().Debug("failed to notify pending action", zap.Error(err))
+	}
+}
+
+// OnRemoved does nothing
+func (n *pendingActionNotifier) OnRemoved(*action.SealedEnvelope, actpool.EvictionReason) {}
+
+// NewWeb3PendingActionListener returns a new websocket pending action listener, assemble
+// converts the action into the result of subscription
+func NewWeb3PendingActionListener(handler streamHandler, assemble func(*action.SealedEnvelope) (interface{}, error)) apitypes.Responder {
+	return &web3PendingActionListener{
+		streamHandle: handler,
+		assemble:     assemble,
+	}
+}
+
+// Respond to new block, which is ignored
+func (pl *web3PendingActionListener) Respond(string, *block.Block) error {
+	return nil
+}
+
+// RespondAction to new pending action
+func (pl *web3PendingActionListener) RespondAction(id string, selp *action.SealedEnvelope) error {
+	result, err := pl.assemble(selp)
+	if err != nil {
+		return err
+	}
+	if _, err := pl.streamHandle(&streamResponse{
+		id:     id,
+		result: result,
+	}); err != nil {
+		h, _ := selp.Hash()
+		log.L().Info(
+			"Error when streaming the pending action",
+			log.Hex("actionHash", h[:]),
+			zap.Error(err),
+		)
+		return err
+	}
+	return nil
+}
+
+// Exit send to error channel
+func (pl *web3PendingActionListener) Exit() {}
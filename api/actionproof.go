@@ -0,0 +1,129 @@
+package api
+
+import (
+	"encoding/hex"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/go-pkgs/util"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/crypto"
+)
+
+// ActionProof is the merkle proof of an action against the tx root in the header of the block including it
+type ActionProof struct {
+	ActionHash string `json:"actionHash"`
+	Height     uint64 `json:"height"`
+	BlockHash  string `json:"blockHash"`
+	// Header is the serialized header of the block, whose signature can be verified by the client
+	Header string `json:"header"`
+	TxRoot string `json:"txRoot"`
+	// Index is the index of the action in the block, which is the index of the leaf in the merkle tree
+	Index uint64   `json:"index"`
+	Proof []string `json:"proof"`
+}
+
+// ActionProof returns the merkle proof of the action against the tx root of the block including it
+func (core *coreService) ActionProof(h hash.Hash256) (*ActionProof, error) {
+	_, blk, index, err := core.ActionByActionHash(h)
+	if err != nil {
+		if errors.Cause(err) == ErrNotFound {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, err
+	}
+	leaves := make([]hash.Hash256, len(blk.Actions))
+	for i, selp := range blk.Actions {
+		if leaves[i], err = selp.Hash(); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+	mk := crypto.NewMerkleTree(leaves)
+	root := blk.TxRoot()
+	if calculated := mk.HashTree(); calculated != root {
+		return nil, status.Errorf(codes.Internal, "tx root %x mismatches the root %x in header", calculated, root)
+	}
+	proof, err := mk.Proof(int(index))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	headerBytes, err := blk.Header.Serialize()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	blkHash := blk.HashBlock()
+	ret := &ActionProof{
+		ActionHash: hex.EncodeToString(h[:]),
+		Height:     blk.Height(),
+		BlockHash:  hex.EncodeToString(blkHash[:]),
+		Header:     hex.EncodeToString(headerBytes),
+		TxRoot:     hex.EncodeToString(root[:]),
+		Index:      uint64(index),
+		Proof:      make([]string, len(proof)),
+	}
+	for i := range proof {
+		ret.Proof[i] = hex.EncodeToString(proof[i][:])
+	}
+	return ret, nil
+}
+
+// VerifyActionProof verifies the action proof without trusting the node serving it, and returns the header of the
+// block including the action. It checks the signature of the header, the block hash and the tx root against the
+// header, and the merkle path from the action to the tx root. The caller should still check the producer of the
+// header is an active delegate and the block is finalized
+func VerifyActionProof(proof *ActionProof) (*block.Header, error) {
+	headerBytes, err := hex.DecodeString(proof.Header)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid header")
+	}
+	header := &block.Header{}
+	if err := header.Deserialize(headerBytes); err != nil {
+		return nil, errors.Wrap(err, "invalid header")
+	}
+	if !header.VerifySignature() {
+		return nil, errors.New("invalid signature of header")
+	}
+	if header.Height() != proof.Height {
+		return nil, errors.Errorf("height %d mismatches the height %d in header", proof.Height, header.Height())
+	}
+	blkHash := header.HashBlock()
+	if proof.BlockHash != hex.EncodeToString(blkHash[:]) {
+		return nil, errors.Errorf("block hash %s mismatches the hash %x of header", proof.BlockHash, blkHash)
+	}
+	root := header.TxRoot()
+	if proof.TxRoot != hex.EncodeToString(root[:]) {
+		return nil, errors.Errorf("tx root %s mismatches the root %x in header", proof.TxRoot, root)
+	}
+	actHash, err := hash.HexStringToHash256(proof.ActionHash)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid action hash")
+	}
+	path := make([]hash.Hash256, len(proof.Proof))
+	for i := range proof.Proof {
+		b, err := hex.DecodeString(proof.Proof[i])
+		if err != nil || len(b) != len(hash.ZeroHash256) {
+			return nil, errors.Errorf("invalid proof hash %s", proof.Proof[i])
+		}
+		path[i] = hash.BytesToHash256(b)
+	}
+	if !crypto.VerifyMerkleProof(root, actHash, proof.Index, path) {
+		return nil, errors.Errorf("action %s is not included in block %d", proof.ActionHash, proof.Height)
+	}
+	return header, nil
+}
+
+func (svr *web3Handler) getActionProof(in *gjson.Result) (interface{}, error) {
+	actHash := in.Get("params.0")
+	if !actHash.Exists() {
+		return nil, errInvalidFormat
+	}
+	h, err := hash.HexStringToHash256(util.Remove0xPrefix(actHash.String()))
+	if err != nil {
+		return nil, err
+	}
+	return svr.coreService.ActionProof(h)
+}
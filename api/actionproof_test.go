@@ -0,0 +1,57 @@
+package api
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/v2/testutil"
+)
+
+func TestCoreService_ActionProof(t *testing.T) {
+	require := require.New(t)
+	cfg := newConfig()
+	cfg.api.GRPCPort = testutil.RandomPort()
+	svr, _, _, _, _, _, bfIndexFile, err := createServerV2(cfg, false)
+	require.NoError(err)
+	defer func() {
+		testutil.CleanupPath(bfIndexFile)
+	}()
+
+	for _, test := range _getActionByActionHashTest {
+		proof, err := svr.core.ActionProof(test.h)
+		require.NoError(err)
+		header, err := VerifyActionProof(proof)
+		require.NoError(err)
+		require.Equal(proof.Height, header.Height())
+		_, blk, index, err := svr.core.ActionByActionHash(test.h)
+		require.NoError(err)
+		require.Equal(blk.HashBlock(), header.HashBlock())
+		require.EqualValues(index, proof.Index)
+
+		// the proof of another action in the block
+		p := *proof
+		p.Index ^= 1
+		_, err = VerifyActionProof(&p)
+		require.ErrorContains(err, "is not included in block")
+		// the proof against another block
+		p = *proof
+		p.BlockHash = hex.EncodeToString(hash.ZeroHash256[:])
+		_, err = VerifyActionProof(&p)
+		require.ErrorContains(err, "mismatches the hash")
+		// the header tampered
+		p = *proof
+		b, err := hex.DecodeString(p.Header)
+		require.NoError(err)
+		b[len(b)/2] ^= 1
+		p.Header = hex.EncodeToString(b)
+		_, err = VerifyActionProof(&p)
+		require.Error(err)
+	}
+	_, err = svr.core.ActionProof(hash.ZeroHash256)
+	require.Equal(codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"encoding/hex"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/go-pkgs/util"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/v2/blockindex"
+	"github.com/iotexproject/iotex-core/v2/db"
+)
+
+// ActionResult is whether an action succeeded, and the reason of the revert if it failed
+type ActionResult struct {
+	ActionHash string `json:"actionHash"`
+	Success    bool   `json:"success"`
+	Status     uint64 `json:"status"`
+	StatusName string `json:"statusName"`
+	// RevertReason is the reason decoded from the revert of the execution, empty if not available
+	RevertReason string `json:"revertReason"`
+}
+
+// ActionResult returns the result of the action from the index, or from the receipt if the block of the action was
+// indexed before the results are
+func (core *coreService) ActionResult(h hash.Hash256) (*ActionResult, error) {
+	if core.indexer == nil {
+		return nil, status.Error(codes.Unimplemented, blockindex.ErrActionIndexNA.Error())
+	}
+	var (
+		statusCode   uint64
+		revertReason string
+	)
+	r, err := core.indexer.GetActionResult(h[:])
+	switch errors.Cause(err) {
+	case nil:
+		statusCode, revertReason = r.Status(), r.RevertReason()
+	case db.ErrNotExist:
+		receipt, err := core.ReceiptByActionHash(h)
+		if err != nil {
+			if errors.Cause(err) == ErrNotFound {
+				return nil, status.Error(codes.NotFound, err.Error())
+			}
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		statusCode, revertReason = receipt.Status, receipt.ExecutionRevertMsg()
+	default:
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &ActionResult{
+		ActionHash:   hex.EncodeToString(h[:]),
+		Success:      statusCode == uint64(iotextypes.ReceiptStatus_Success),
+		Status:       statusCode,
+		StatusName:   iotextypes.ReceiptStatus_name[int32(statusCode)],
+		RevertReason: revertReason,
+	}, nil
+}
+
+func (svr *web3Handler) getActionResult(in *gjson.Result) (interface{}, error) {
+	hashParam := in.Get("params.0")
+	if !hashParam.Exists() {
+		return nil, errInvalidFormat
+	}
+	h, err := hash.HexStringToHash256(util.Remove0xPrefix(hashParam.String()))
+	if err != nil {
+		return nil, err
+	}
+	return svr.coreService.ActionResult(h)
+}
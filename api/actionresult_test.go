@@ -0,0 +1,87 @@
+package api
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/blockindex"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/v2/test/mock/mock_blockdao"
+	"github.com/iotexproject/iotex-core/v2/test/mock/mock_blockindex"
+)
+
+func TestCoreService_ActionResult(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	h := hash.Hash256b([]byte("action"))
+
+	core := &coreService{}
+	_, err := core.ActionResult(h)
+	require.Equal(codes.Unimplemented, status.Code(err))
+
+	indexer := mock_blockindex.NewMockIndexer(ctrl)
+	dao := mock_blockdao.NewMockBlockDAO(ctrl)
+	core = &coreService{indexer: indexer, dao: dao}
+	ar := &blockindex.ActionResult{}
+	require.NoError(ar.Deserialize(append(byteutil.Uint64ToBytesBigEndian(uint64(iotextypes.ReceiptStatus_ErrExecutionReverted)), "insufficient allowance"...)))
+	indexer.EXPECT().GetActionResult(h[:]).Return(ar, nil).Times(1)
+	ret, err := core.ActionResult(h)
+	require.NoError(err)
+	require.Equal(&ActionResult{
+		ActionHash:   hex.EncodeToString(h[:]),
+		Status:       uint64(iotextypes.ReceiptStatus_ErrExecutionReverted),
+		StatusName:   iotextypes.ReceiptStatus_ErrExecutionReverted.String(),
+		RevertReason: "insufficient allowance",
+	}, ret)
+
+	// the result of an action indexed before is read from the receipt
+	indexer.EXPECT().GetActionResult(h[:]).Return(nil, db.ErrNotExist).Times(2)
+	indexer.EXPECT().GetActionIndex(h[:]).Return(&blockindex.ActionIndex{}, nil).Times(1)
+	dao.EXPECT().GetReceipts(uint64(0)).Return([]*action.Receipt{
+		{Status: uint64(iotextypes.ReceiptStatus_Success), ActionHash: h},
+	}, nil).Times(1)
+	ret, err = core.ActionResult(h)
+	require.NoError(err)
+	require.True(ret.Success)
+	require.Equal(iotextypes.ReceiptStatus_Success.String(), ret.StatusName)
+	require.Empty(ret.RevertReason)
+	indexer.EXPECT().GetActionIndex(h[:]).Return(nil, db.ErrNotExist).Times(1)
+	_, err = core.ActionResult(h)
+	require.Equal(codes.NotFound, status.Code(err))
+
+	indexer.EXPECT().GetActionResult(h[:]).Return(nil, errors.New("db error")).Times(1)
+	_, err = core.ActionResult(h)
+	require.Equal(codes.Internal, status.Code(err))
+}
+
+func TestGetActionResult(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	core := NewMockCoreService(ctrl)
+	web3svr := &web3Handler{coreService: core, batchRequestLimit: _defaultBatchRequestLimit}
+
+	h := hash.Hash256b([]byte("action"))
+	core.EXPECT().ActionResult(h).Return(&ActionResult{Success: true}, nil).Times(1)
+	in := gjson.Parse(`{"params":["0x` + hex.EncodeToString(h[:]) + `"]}`)
+	ret, err := web3svr.getActionResult(&in)
+	require.NoError(err)
+	require.True(ret.(*ActionResult).Success)
+
+	in = gjson.Parse(`{"params":[]}`)
+	_, err = web3svr.getActionResult(&in)
+	require.ErrorIs(err, errInvalidFormat)
+	in = gjson.Parse(`{"params":["action"]}`)
+	_, err = web3svr.getActionResult(&in)
+	require.Error(err)
+}
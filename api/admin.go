@@ -0,0 +1,389 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/iotexproject/iotex-core/v2/api/apipb"
+	"github.com/iotexproject/iotex-core/v2/p2p"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+type (
+	// PeerManager manages the peers of the node
+	PeerManager interface {
+		// ConnectPeer connects the peer of the multiaddress
+		ConnectPeer(ctx context.Context, addr string) error
+		// BlockPeer disconnects and blocks the peer
+		BlockPeer(id string)
+		// PeerScores returns the scores of the peers misbehaved
+		PeerScores() []p2p.PeerScore
+		// AddTrustedPeer adds the peer of the id or the multiaddress as a trusted peer exempt from scoring and bans
+		AddTrustedPeer(ctx context.Context, addr string) error
+	}
+
+	// ConfigReloader reloads the config of the node at runtime
+	ConfigReloader interface {
+		// Reload re-reads the config files and applies the changes of the dynamic settings, returning the settings
+		// changed. The config is not reloaded if any immutable setting is changed
+		Reload() ([]string, error)
+	}
+
+	// adminService serves the admin grpc service, which requires client certificate
+	adminService struct {
+		coreService CoreService
+	}
+
+	// adminAuthHandler authorizes the admin namespace of web3 requests bearing the admin token
+	adminAuthHandler struct {
+		next  http.Handler
+		token string
+	}
+
+	adminContextKey struct{}
+)
+
+var errAdminNotAuthorized = errors.New("admin method is not authorized")
+
+// WithPeerManager is the option to manage the peers of the node through admin api
+func WithPeerManager(pm PeerManager) Option {
+	return func(svr *coreService) {
+		svr.peerManager = pm
+	}
+}
+
+// WithConfigReloader is the option to reload the config of the node through admin api
+func WithConfigReloader(r ConfigReloader) Option {
+	return func(svr *coreService) {
+		svr.configReloader = r
+	}
+}
+
+// AddPeer connects the peer of the multiaddress
+func (core *coreService) AddPeer(ctx context.Context, addr string) error {
+	if core.peerManager == nil {
+		return status.Error(codes.Unavailable, "peer management is not supported")
+	}
+	if err := core.peerManager.ConnectPeer(ctx, addr); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	log.L().Info("peer is added by admin.", zap.String("address", addr))
+	return nil
+}
+
+// RemovePeer disconnects and blocks the peer
+func (core *coreService) RemovePeer(id string) error {
+	if core.peerManager == nil {
+		return status.Error(codes.Unavailable, "peer management is not supported")
+	}
+	if _, err := peer.Decode(id); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid peer id %s: %v", id, err)
+	}
+	core.peerManager.BlockPeer(id)
+	log.L().Info("peer is removed by admin.", zap.String("id", id))
+	return nil
+}
+
+// PeerScores returns the scores of the peers misbehaved
+func (core *coreService) PeerScores() ([]p2p.PeerScore, error) {
+	if core.peerManager == nil {
+		return nil, status.Error(codes.Unavailable, "peer management is not supported")
+	}
+	return core.peerManager.PeerScores(), nil
+}
+
+// AddTrustedPeer adds the peer of the id or the multiaddress as a trusted peer exempt from scoring and bans
+func (core *coreService) AddTrustedPeer(ctx context.Context, addr string) error {
+	if core.peerManager == nil {
+		return status.Error(codes.Unavailable, "peer management is not supported")
+	}
+	if err := core.peerManager.AddTrustedPeer(ctx, addr); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	log.L().Info("trusted peer is added by admin.", zap.String("address", addr))
+	return nil
+}
+
+// SetMinGasPrice sets the minimal gas price of actions accepted by actpool
+func (core *coreService) SetMinGasPrice(price *big.Int) error {
+	if price == nil || price.Sign() < 0 {
+		return status.Error(codes.InvalidArgument, "invalid minimal gas price")
+	}
+	core.ap.SetMinGasPrice(price)
+	return nil
+}
+
+// PauseChain pauses or resumes committing blocks to the chain
+func (core *coreService) PauseChain(pause bool) {
+	core.bc.Pause(pause)
+	log.L().Info("chain is paused by admin.", zap.Bool("pause", pause))
+}
+
+// ReloadConfig re-reads the config files and applies the changes of the dynamic settings
+func (core *coreService) ReloadConfig() ([]string, error) {
+	if core.configReloader == nil {
+		return nil, status.Error(codes.Unavailable, "config reload is not supported")
+	}
+	changed, err := core.configReloader.Reload()
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	log.L().Info("config is reloaded by admin.", zap.Strings("changed", changed))
+	return changed, nil
+}
+
+func newAdminService(core CoreService) *adminService {
+	return &adminService{
+		coreService: core,
+	}
+}
+
+// AddPeer connects the peer of the multiaddress
+func (svr *adminService) AddPeer(ctx context.Context, in *apipb.AddPeerRequest) (*apipb.AddPeerResponse, error) {
+	if err := svr.coreService.AddPeer(ctx, in.GetAddress()); err != nil {
+		return nil, err
+	}
+	return &apipb.AddPeerResponse{}, nil
+}
+
+// RemovePeer disconnects and blocks the peer
+func (svr *adminService) RemovePeer(_ context.Context, in *apipb.RemovePeerRequest) (*apipb.RemovePeerResponse, error) {
+	if err := svr.coreService.RemovePeer(in.GetId()); err != nil {
+		return nil, err
+	}
+	return &apipb.RemovePeerResponse{}, nil
+}
+
+// AddTrustedPeer adds the peer of the id or the multiaddress as a trusted peer
+func (svr *adminService) AddTrustedPeer(ctx context.Context, in *apipb.AddTrustedPeerRequest) (*apipb.AddTrustedPeerResponse, error) {
+	if err := svr.coreService.AddTrustedPeer(ctx, in.GetAddress()); err != nil {
+		return nil, err
+	}
+	return &apipb.AddTrustedPeerResponse{}, nil
+}
+
+// GetPeerScores returns the scores of the peers misbehaved
+func (svr *adminService) GetPeerScores(context.Context, *apipb.GetPeerScoresRequest) (*apipb.GetPeerScoresResponse, error) {
+	scores, err := svr.coreService.PeerScores()
+	if err != nil {
+		return nil, err
+	}
+	res := &apipb.GetPeerScoresResponse{}
+	for _, s := range scores {
+		pb := &apipb.PeerScore{
+			Id:            s.ID,
+			Score:         s.Score,
+			InvalidBlocks: s.InvalidBlocks,
+			Timeouts:      s.Timeouts,
+			Violations:    s.Violations,
+		}
+		if !s.BannedUntil.IsZero() {
+			pb.BannedUntil = timestamppb.New(s.BannedUntil)
+		}
+		res.Scores = append(res.Scores, pb)
+	}
+	return res, nil
+}
+
+// SetMinGasPrice sets the minimal gas price of actions accepted by actpool
+func (svr *adminService) SetMinGasPrice(_ context.Context, in *apipb.SetMinGasPriceRequest) (*apipb.SetMinGasPriceResponse, error) {
+	price, ok := new(big.Int).SetString(in.GetMinGasPrice(), 10)
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid minimal gas price %s", in.GetMinGasPrice())
+	}
+	if err := svr.coreService.SetMinGasPrice(price); err != nil {
+		return nil, err
+	}
+	return &apipb.SetMinGasPriceResponse{}, nil
+}
+
+// PauseChain pauses committing blocks to the chain
+func (svr *adminService) PauseChain(context.Context, *apipb.PauseChainRequest) (*apipb.PauseChainResponse, error) {
+	svr.coreService.PauseChain(true)
+	return &apipb.PauseChainResponse{}, nil
+}
+
+// ResumeChain resumes committing blocks to the chain
+func (svr *adminService) ResumeChain(context.Context, *apipb.ResumeChainRequest) (*apipb.ResumeChainResponse, error) {
+	svr.coreService.PauseChain(false)
+	return &apipb.ResumeChainResponse{}, nil
+}
+
+// SetLogLevel changes the level of the logger at runtime
+func (svr *adminService) SetLogLevel(_ context.Context, in *apipb.SetLogLevelRequest) (*apipb.SetLogLevelResponse, error) {
+	if err := log.SetLevel(in.GetLogger(), in.GetLevel()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &apipb.SetLogLevelResponse{}, nil
+}
+
+// ReloadConfig re-reads the config files and applies the changes of the dynamic settings
+func (svr *adminService) ReloadConfig(context.Context, *apipb.ReloadConfigRequest) (*apipb.ReloadConfigResponse, error) {
+	changed, err := svr.coreService.ReloadConfig()
+	if err != nil {
+		return nil, err
+	}
+	return &apipb.ReloadConfigResponse{Changed: changed}, nil
+}
+
+// GetConsensusTimeouts returns the timeouts of the current consensus round
+func (svr *adminService) GetConsensusTimeouts(context.Context, *apipb.GetConsensusTimeoutsRequest) (*apipb.GetConsensusTimeoutsResponse, error) {
+	t, err := svr.coreService.ConsensusTimeouts()
+	if err != nil {
+		return nil, err
+	}
+	return &apipb.GetConsensusTimeoutsResponse{
+		Timeouts: &apipb.ConsensusTimeouts{
+			UnmatchedEventTTL:            durationpb.New(t.UnmatchedEventTTL),
+			UnmatchedEventInterval:       durationpb.New(t.UnmatchedEventInterval),
+			AcceptBlockTTL:               durationpb.New(t.AcceptBlockTTL),
+			AcceptProposalEndorsementTTL: durationpb.New(t.AcceptProposalEndorsementTTL),
+			AcceptLockEndorsementTTL:     durationpb.New(t.AcceptLockEndorsementTTL),
+			CommitTTL:                    durationpb.New(t.CommitTTL),
+		},
+	}, nil
+}
+
+// SetConsensusTimeouts adjusts the timeouts of the consensus rounds, the timeouts not set in the request are kept
+func (svr *adminService) SetConsensusTimeouts(_ context.Context, in *apipb.SetConsensusTimeoutsRequest) (*apipb.SetConsensusTimeoutsResponse, error) {
+	if in.GetReset_() {
+		if err := svr.coreService.SetConsensusTimeouts(nil); err != nil {
+			return nil, err
+		}
+		return &apipb.SetConsensusTimeoutsResponse{}, nil
+	}
+	t, err := svr.coreService.ConsensusTimeouts()
+	if err != nil {
+		return nil, err
+	}
+	pb := in.GetTimeouts()
+	for _, v := range []struct {
+		d  *durationpb.Duration
+		to *time.Duration
+	}{
+		{pb.GetUnmatchedEventTTL(), &t.UnmatchedEventTTL},
+		{pb.GetUnmatchedEventInterval(), &t.UnmatchedEventInterval},
+		{pb.GetAcceptBlockTTL(), &t.AcceptBlockTTL},
+		{pb.GetAcceptProposalEndorsementTTL(), &t.AcceptProposalEndorsementTTL},
+		{pb.GetAcceptLockEndorsementTTL(), &t.AcceptLockEndorsementTTL},
+		{pb.GetCommitTTL(), &t.CommitTTL},
+	} {
+		if v.d != nil {
+			*v.to = v.d.AsDuration()
+		}
+	}
+	if err := svr.coreService.SetConsensusTimeouts(t); err != nil {
+		return nil, err
+	}
+	return &apipb.SetConsensusTimeoutsResponse{}, nil
+}
+
+// newAdminAuthHandler wraps the handler to authorize the requests with header "Authorization: Bearer <token>"
+// to call the admin namespace, which is disabled if token is empty
+func newAdminAuthHandler(next http.Handler, token string) http.Handler {
+	if token == "" {
+		return next
+	}
+	return &adminAuthHandler{
+		next:  next,
+		token: token,
+	}
+}
+
+func (h *adminAuthHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if bearer, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer "); ok &&
+		subtle.ConstantTimeCompare([]byte(bearer), []byte(h.token)) == 1 {
+		req = req.WithContext(context.WithValue(req.Context(), adminContextKey{}, struct{}{}))
+	}
+	h.next.ServeHTTP(w, req)
+}
+
+func isAdminAuthorized(ctx context.Context) bool {
+	return ctx.Value(adminContextKey{}) != nil
+}
+
+// handleAdminReq handles the requests of the admin namespace
+func (svr *web3Handler) handleAdminReq(ctx context.Context, method string, in *gjson.Result) (interface{}, error) {
+	if !isAdminAuthorized(ctx) {
+		return nil, errAdminNotAuthorized
+	}
+	admin := newAdminService(svr.coreService)
+	var err error
+	switch method {
+	case "admin_addPeer":
+		_, err = admin.AddPeer(ctx, &apipb.AddPeerRequest{Address: in.Get("params.0").String()})
+	case "admin_addTrustedPeer":
+		_, err = admin.AddTrustedPeer(ctx, &apipb.AddTrustedPeerRequest{Address: in.Get("params.0").String()})
+	case "admin_removePeer":
+		_, err = admin.RemovePeer(ctx, &apipb.RemovePeerRequest{Id: in.Get("params.0").String()})
+	case "admin_setMinGasPrice":
+		// the price is either a hex quantity or a decimal string
+		price, ok := new(big.Int).SetString(in.Get("params.0").String(), 0)
+		if !ok {
+			return nil, errors.Wrapf(errUnkownType, "minGasPrice: %s", in.Get("params.0").String())
+		}
+		_, err = admin.SetMinGasPrice(ctx, &apipb.SetMinGasPriceRequest{MinGasPrice: price.String()})
+	case "admin_pauseChain":
+		_, err = admin.PauseChain(ctx, &apipb.PauseChainRequest{})
+	case "admin_resumeChain":
+		_, err = admin.ResumeChain(ctx, &apipb.ResumeChainRequest{})
+	case "admin_peerScores":
+		scores, err := svr.coreService.PeerScores()
+		if err != nil {
+			return nil, err
+		}
+		ret := make([]*peerScoreResult, 0, len(scores))
+		for _, s := range scores {
+			r := &peerScoreResult{
+				ID:            s.ID,
+				Score:         s.Score,
+				InvalidBlocks: s.InvalidBlocks,
+				Timeouts:      s.Timeouts,
+				Violations:    s.Violations,
+			}
+			if !s.BannedUntil.IsZero() {
+				r.BannedUntil = s.BannedUntil.UTC().Format(time.RFC3339)
+			}
+			ret = append(ret, r)
+		}
+		return ret, nil
+	case "admin_setLogLevel":
+		// params are [level] or [logger, level]
+		req := &apipb.SetLogLevelRequest{Level: in.Get("params.0").String()}
+		if in.Get("params.1").Exists() {
+			req.Logger, req.Level = in.Get("params.0").String(), in.Get("params.1").String()
+		}
+		_, err = admin.SetLogLevel(ctx, req)
+	case "admin_workingSetCache", "admin_pinWorkingSet", "admin_unpinWorkingSet", "admin_prewarmWorkingSet":
+		return svr.handleWorkingSetCacheReq(ctx, method, in)
+	case "admin_estimatePruning":
+		return svr.estimatePruning(in)
+	case "admin_sendBundle":
+		return svr.sendBundle(ctx, in)
+	case "admin_reloadConfig":
+		res, err := admin.ReloadConfig(ctx, &apipb.ReloadConfigRequest{})
+		if err != nil {
+			return nil, err
+		}
+		return res.Changed, nil
+	default:
+		return nil, errors.Wrapf(errors.New("web3 method not found"), "method: %s\n", method)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return true, nil
+}
@@ -0,0 +1,237 @@
+package api
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/iotexproject/iotex-core/v2/api/apipb"
+	"github.com/iotexproject/iotex-core/v2/consensus/consensusfsm"
+	"github.com/iotexproject/iotex-core/v2/p2p"
+	"github.com/iotexproject/iotex-core/v2/test/mock/mock_actpool"
+	"github.com/iotexproject/iotex-core/v2/test/mock/mock_blockchain"
+)
+
+type testPeerManager struct {
+	connected []string
+	blocked   []string
+	scores    []p2p.PeerScore
+	trusted   []string
+}
+
+func (pm *testPeerManager) ConnectPeer(_ context.Context, addr string) error {
+	if addr == "" {
+		return errors.New("empty address")
+	}
+	pm.connected = append(pm.connected, addr)
+	return nil
+}
+
+func (pm *testPeerManager) BlockPeer(id string) {
+	pm.blocked = append(pm.blocked, id)
+}
+
+func (pm *testPeerManager) PeerScores() []p2p.PeerScore {
+	return pm.scores
+}
+
+func (pm *testPeerManager) AddTrustedPeer(_ context.Context, addr string) error {
+	if addr == "" {
+		return errors.New("empty address")
+	}
+	pm.trusted = append(pm.trusted, addr)
+	return nil
+}
+
+type testConfigReloader struct {
+	changed []string
+	err     error
+}
+
+func (r *testConfigReloader) Reload() ([]string, error) {
+	return r.changed, r.err
+}
+
+func TestAdminAuthHandler(t *testing.T) {
+	require := require.New(t)
+	var authorized bool
+	next := http.HandlerFunc(func(_ http.ResponseWriter, req *http.Request) {
+		authorized = isAdminAuthorized(req.Context())
+	})
+	for _, c := range []struct {
+		token, header string
+		authorized    bool
+	}{
+		{"", "Bearer ", false},
+		{"secret", "", false},
+		{"secret", "Bearer wrong", false},
+		{"secret", "secret", false},
+		{"secret", "Bearer secret", true},
+	} {
+		req := httptest.NewRequest(http.MethodPost, "http://localhost/", nil)
+		if c.header != "" {
+			req.Header.Set("Authorization", c.header)
+		}
+		newAdminAuthHandler(next, c.token).ServeHTTP(httptest.NewRecorder(), req)
+		require.Equal(c.authorized, authorized)
+	}
+}
+
+func TestHandleAdminReq(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := NewMockCoreService(ctrl)
+	web3svr := &web3Handler{coreService: core, batchRequestLimit: _defaultBatchRequestLimit}
+	ctx := context.WithValue(context.Background(), adminContextKey{}, struct{}{})
+
+	in := gjson.Parse(`{"params":[]}`)
+	_, err := web3svr.handleAdminReq(context.Background(), "admin_pauseChain", &in)
+	require.ErrorIs(err, errAdminNotAuthorized)
+
+	core.EXPECT().PauseChain(true).Times(1)
+	ret, err := web3svr.handleAdminReq(ctx, "admin_pauseChain", &in)
+	require.NoError(err)
+	require.True(ret.(bool))
+	core.EXPECT().PauseChain(false).Times(1)
+	_, err = web3svr.handleAdminReq(ctx, "admin_resumeChain", &in)
+	require.NoError(err)
+
+	core.EXPECT().SetMinGasPrice(big.NewInt(1000000000000)).Return(nil).Times(2)
+	in = gjson.Parse(`{"params":["0xe8d4a51000"]}`)
+	_, err = web3svr.handleAdminReq(ctx, "admin_setMinGasPrice", &in)
+	require.NoError(err)
+	in = gjson.Parse(`{"params":["1000000000000"]}`)
+	_, err = web3svr.handleAdminReq(ctx, "admin_setMinGasPrice", &in)
+	require.NoError(err)
+	in = gjson.Parse(`{"params":["price"]}`)
+	_, err = web3svr.handleAdminReq(ctx, "admin_setMinGasPrice", &in)
+	require.ErrorIs(err, errUnkownType)
+
+	addr := "/ip4/127.0.0.1/tcp/4689/p2p/12D3KooWJwW6pUpTkxPTMv84RPLPMQVEAjZ6fvJuX4oZrvW5DAGQ"
+	core.EXPECT().AddPeer(gomock.Any(), addr).Return(nil).Times(1)
+	in = gjson.Parse(`{"params":["` + addr + `"]}`)
+	_, err = web3svr.handleAdminReq(ctx, "admin_addPeer", &in)
+	require.NoError(err)
+
+	in = gjson.Parse(`{"params":["api", "verbose"]}`)
+	_, err = web3svr.handleAdminReq(ctx, "admin_setLogLevel", &in)
+	require.Equal(codes.InvalidArgument, status.Code(err))
+	in = gjson.Parse(`{"params":["info"]}`)
+	_, err = web3svr.handleAdminReq(ctx, "admin_setLogLevel", &in)
+	require.NoError(err)
+
+	core.EXPECT().PeerScores().Return([]p2p.PeerScore{
+		{ID: "peer1", Score: -120, InvalidBlocks: 2, Violations: 1, BannedUntil: time.Unix(1700000000, 0)},
+		{ID: "peer2", Score: -10, Timeouts: 1},
+	}, nil).Times(1)
+	ret, err = web3svr.handleAdminReq(ctx, "admin_peerScores", &in)
+	require.NoError(err)
+	require.Equal([]*peerScoreResult{
+		{ID: "peer1", Score: -120, InvalidBlocks: 2, Violations: 1, BannedUntil: "2023-11-14T22:13:20Z"},
+		{ID: "peer2", Score: -10, Timeouts: 1},
+	}, ret)
+}
+
+func TestCoreServiceAdmin(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	ap := mock_actpool.NewMockActPool(ctrl)
+	pm := &testPeerManager{}
+	core := &coreService{bc: bc, ap: ap}
+
+	require.Equal(codes.Unavailable, status.Code(core.AddPeer(context.Background(), "addr")))
+	core.peerManager = pm
+	require.NoError(core.AddPeer(context.Background(), "addr"))
+	require.Equal(codes.InvalidArgument, status.Code(core.AddPeer(context.Background(), "")))
+	require.Equal([]string{"addr"}, pm.connected)
+	require.Equal(codes.InvalidArgument, status.Code(core.RemovePeer("peer")))
+	require.NoError(core.RemovePeer("12D3KooWJwW6pUpTkxPTMv84RPLPMQVEAjZ6fvJuX4oZrvW5DAGQ"))
+	require.Equal([]string{"12D3KooWJwW6pUpTkxPTMv84RPLPMQVEAjZ6fvJuX4oZrvW5DAGQ"}, pm.blocked)
+	_, err := newAdminService(core).AddTrustedPeer(context.Background(), &apipb.AddTrustedPeerRequest{Address: "peer"})
+	require.NoError(err)
+	require.Equal(codes.InvalidArgument, status.Code(core.AddTrustedPeer(context.Background(), "")))
+	require.Equal([]string{"peer"}, pm.trusted)
+
+	ap.EXPECT().SetMinGasPrice(big.NewInt(10)).Times(1)
+	require.NoError(core.SetMinGasPrice(big.NewInt(10)))
+	require.Equal(codes.InvalidArgument, status.Code(core.SetMinGasPrice(big.NewInt(-1))))
+
+	bc.EXPECT().Pause(true).Times(1)
+	core.PauseChain(true)
+
+	pm.scores = []p2p.PeerScore{{ID: "peer1", Score: -50, InvalidBlocks: 1, BannedUntil: time.Unix(1700000000, 0)}}
+	res, err := newAdminService(core).GetPeerScores(context.Background(), &apipb.GetPeerScoresRequest{})
+	require.NoError(err)
+	require.Len(res.Scores, 1)
+	require.Equal("peer1", res.Scores[0].Id)
+	require.Equal(-50.0, res.Scores[0].Score)
+	require.Equal(uint64(1), res.Scores[0].InvalidBlocks)
+	require.Equal(int64(1700000000), res.Scores[0].BannedUntil.GetSeconds())
+
+	_, err = newAdminService(core).SetMinGasPrice(context.Background(), &apipb.SetMinGasPriceRequest{MinGasPrice: "0x10"})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+
+	_, err = newAdminService(core).ReloadConfig(context.Background(), &apipb.ReloadConfigRequest{})
+	require.Equal(codes.Unavailable, status.Code(err))
+	reloader := &testConfigReloader{err: errors.New("immutable settings cannot be changed without restart: api.port")}
+	core.configReloader = reloader
+	_, err = newAdminService(core).ReloadConfig(context.Background(), &apipb.ReloadConfigRequest{})
+	require.Equal(codes.FailedPrecondition, status.Code(err))
+	reloader.changed, reloader.err = []string{"log.zap.level"}, nil
+	reload, err := newAdminService(core).ReloadConfig(context.Background(), &apipb.ReloadConfigRequest{})
+	require.NoError(err)
+	require.Equal([]string{"log.zap.level"}, reload.Changed)
+}
+
+func TestAdminService_ConsensusTimeouts(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := NewMockCoreService(ctrl)
+	svr := newAdminService(core)
+	timeouts := consensusfsm.Timeouts{
+		UnmatchedEventTTL:            2 * time.Second,
+		UnmatchedEventInterval:       100 * time.Millisecond,
+		AcceptBlockTTL:               time.Second,
+		AcceptProposalEndorsementTTL: 500 * time.Millisecond,
+		AcceptLockEndorsementTTL:     500 * time.Millisecond,
+		CommitTTL:                    500 * time.Millisecond,
+	}
+
+	core.EXPECT().ConsensusTimeouts().Return(&timeouts, nil).Times(2)
+	ret, err := svr.GetConsensusTimeouts(context.Background(), &apipb.GetConsensusTimeoutsRequest{})
+	require.NoError(err)
+	require.Equal(time.Second, ret.Timeouts.AcceptBlockTTL.AsDuration())
+	require.Equal(100*time.Millisecond, ret.Timeouts.UnmatchedEventInterval.AsDuration())
+
+	// the timeouts not in the request are kept
+	expected := timeouts
+	expected.AcceptBlockTTL = 800 * time.Millisecond
+	core.EXPECT().SetConsensusTimeouts(&expected).Return(nil).Times(1)
+	_, err = svr.SetConsensusTimeouts(context.Background(), &apipb.SetConsensusTimeoutsRequest{
+		Timeouts: &apipb.ConsensusTimeouts{AcceptBlockTTL: durationpb.New(800 * time.Millisecond)},
+	})
+	require.NoError(err)
+
+	core.EXPECT().SetConsensusTimeouts(nil).Return(nil).Times(1)
+	_, err = svr.SetConsensusTimeouts(context.Background(), &apipb.SetConsensusTimeoutsRequest{Reset_: true})
+	require.NoError(err)
+
+	core.EXPECT().ConsensusTimeouts().Return(nil, status.Error(codes.Unimplemented, "")).Times(1)
+	_, err = svr.SetConsensusTimeouts(context.Background(), &apipb.SetConsensusTimeoutsRequest{})
+	require.Equal(codes.Unimplemented, status.Code(err))
+}
@@ -0,0 +1,152 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	_protocolGRPC = "grpc"
+	_protocolWeb3 = "web3"
+
+	// _unknownMethod is the label of the web3 methods not supported, so that clients can't blow up the labels
+	_unknownMethod = "unknown"
+)
+
+type (
+	// apiCall is a call of an api method, which is recorded into the metrics and the span of the call
+	apiCall struct {
+		protocol string
+		method   string
+		start    time.Time
+		reqSize  int
+		respSize int
+	}
+
+	// metricsInterceptor records the metrics of the grpc methods
+	metricsInterceptor struct {
+		coreService CoreService
+	}
+
+	// metricsServerStream counts the sizes of the messages of a grpc stream
+	metricsServerStream struct {
+		grpc.ServerStream
+		reqSize  int
+		respSize int
+	}
+)
+
+var (
+	_apiMethodLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "iotex_api_method_latency_seconds",
+		Help:    "latency of the api methods",
+		Buckets: prometheus.ExponentialBuckets(0.001, 4, 8),
+	}, []string{"protocol", "method"})
+	_apiMethodCalls = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "iotex_api_method_calls",
+		Help: "calls of the api methods by result code",
+	}, []string{"protocol", "method", "code"})
+	_apiMethodPayload = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "iotex_api_method_payload_bytes",
+		Help:    "payload sizes of the requests and responses of the api methods",
+		Buckets: prometheus.ExponentialBuckets(64, 4, 10),
+	}, []string{"protocol", "method", "direction"})
+)
+
+func init() {
+	prometheus.MustRegister(_apiMethodLatency)
+	prometheus.MustRegister(_apiMethodCalls)
+	prometheus.MustRegister(_apiMethodPayload)
+}
+
+func newAPICall(protocol, method string) *apiCall {
+	return &apiCall{
+		protocol: protocol,
+		method:   method,
+		start:    time.Now(),
+	}
+}
+
+// finish records the call into the metrics, and tags the span of the call with the method, result, payload
+// sizes, client and the tip height the call is served at
+func (c *apiCall) finish(ctx context.Context, core CoreService, err error) {
+	code := status.Code(err).String()
+	_apiMethodLatency.WithLabelValues(c.protocol, c.method).Observe(time.Since(c.start).Seconds())
+	_apiMethodCalls.WithLabelValues(c.protocol, c.method, code).Inc()
+	_apiMethodPayload.WithLabelValues(c.protocol, c.method, "request").Observe(float64(c.reqSize))
+	_apiMethodPayload.WithLabelValues(c.protocol, c.method, "response").Observe(float64(c.respSize))
+
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+	span.SetAttributes(
+		attribute.String("api.method", c.method),
+		attribute.String("api.code", code),
+		attribute.Int("api.request_size", c.reqSize),
+		attribute.Int("api.response_size", c.respSize),
+		attribute.Int64("iotex.tip_height", int64(core.TipHeight())),
+	)
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		span.SetAttributes(attribute.String("api.client", p.Addr.String()))
+	}
+}
+
+func newMetricsInterceptor(core CoreService) *metricsInterceptor {
+	return &metricsInterceptor{
+		coreService: core,
+	}
+}
+
+func (i *metricsInterceptor) unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		call := newAPICall(_protocolGRPC, info.FullMethod)
+		call.reqSize = protoSize(req)
+		resp, err := handler(ctx, req)
+		call.respSize = protoSize(resp)
+		call.finish(ctx, i.coreService, err)
+		return resp, err
+	}
+}
+
+func (i *metricsInterceptor) stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		call := newAPICall(_protocolGRPC, info.FullMethod)
+		stream := &metricsServerStream{ServerStream: ss}
+		err := handler(srv, stream)
+		call.reqSize, call.respSize = stream.reqSize, stream.respSize
+		call.finish(ss.Context(), i.coreService, err)
+		return err
+	}
+}
+
+func (s *metricsServerStream) SendMsg(m interface{}) error {
+	if err := s.ServerStream.SendMsg(m); err != nil {
+		return err
+	}
+	s.respSize += protoSize(m)
+	return nil
+}
+
+func (s *metricsServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	s.reqSize += protoSize(m)
+	return nil
+}
+
+func protoSize(m interface{}) int {
+	if msg, ok := m.(proto.Message); ok {
+		return proto.Size(msg)
+	}
+	return 0
+}
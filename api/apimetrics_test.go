@@ -0,0 +1,102 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	apitypes "github.com/iotexproject/iotex-core/v2/api/types"
+)
+
+type testServerStream struct {
+	grpc.ServerStream
+	recv proto.Message
+}
+
+func (s *testServerStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *testServerStream) SendMsg(interface{}) error {
+	return nil
+}
+
+func (s *testServerStream) RecvMsg(m interface{}) error {
+	proto.Merge(m.(proto.Message), s.recv)
+	return nil
+}
+
+func TestMetricsInterceptor(t *testing.T) {
+	require := require.New(t)
+	metrics := newMetricsInterceptor(nil)
+
+	t.Run("unary", func(t *testing.T) {
+		const method = "/test.Service/Unary"
+		var (
+			req  = &iotexapi.GetAccountRequest{Address: "io1test"}
+			resp = &iotexapi.GetChainMetaResponse{}
+			info = &grpc.UnaryServerInfo{FullMethod: method}
+		)
+		ret, err := metrics.unary()(context.Background(), req, info, func(context.Context, interface{}) (interface{}, error) {
+			return resp, nil
+		})
+		require.NoError(err)
+		require.Equal(resp, ret)
+		_, err = metrics.unary()(context.Background(), req, info, func(context.Context, interface{}) (interface{}, error) {
+			return nil, status.Error(codes.NotFound, "not found")
+		})
+		require.Equal(codes.NotFound, status.Code(err))
+		require.Equal(1.0, testutil.ToFloat64(_apiMethodCalls.WithLabelValues(_protocolGRPC, method, codes.OK.String())))
+		require.Equal(1.0, testutil.ToFloat64(_apiMethodCalls.WithLabelValues(_protocolGRPC, method, codes.NotFound.String())))
+		require.Equal(1, testutil.CollectAndCount(_apiMethodLatency.MustCurryWith(prometheus.Labels{"protocol": _protocolGRPC, "method": method})))
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		const method = "/test.Service/Stream"
+		var (
+			in   = &iotexapi.GetAccountRequest{Address: "io1test"}
+			info = &grpc.StreamServerInfo{FullMethod: method}
+		)
+		err := metrics.stream()(nil, &testServerStream{recv: in}, info, func(srv interface{}, ss grpc.ServerStream) error {
+			stream, ok := ss.(*metricsServerStream)
+			require.True(ok)
+			req := &iotexapi.GetAccountRequest{}
+			require.NoError(ss.RecvMsg(req))
+			require.NoError(ss.SendMsg(in))
+			require.NoError(ss.SendMsg(in))
+			require.Equal(proto.Size(in), stream.reqSize)
+			require.Equal(2*proto.Size(in), stream.respSize)
+			return nil
+		})
+		require.NoError(err)
+		require.Equal(1.0, testutil.ToFloat64(_apiMethodCalls.WithLabelValues(_protocolGRPC, method, codes.OK.String())))
+	})
+}
+
+func TestWeb3MethodMetrics(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	core := NewMockCoreService(ctrl)
+	core.EXPECT().Track(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return().AnyTimes()
+	svr := &web3Handler{coreService: core, batchRequestLimit: _defaultBatchRequestLimit}
+
+	// the unsupported methods are recorded under a single label
+	for _, method := range []string{"eth_foo", "eth_bar"} {
+		in := gjson.Parse(`{"jsonrpc":"2.0","id":1,"method":"` + method + `","params":[]}`)
+		require.NoError(svr.handleWeb3Req(context.Background(), &in, apitypes.NewResponseWriter(func(interface{}) (int, error) {
+			return 0, nil
+		})))
+	}
+	require.Equal(2.0, testutil.ToFloat64(_apiMethodCalls.WithLabelValues(_protocolWeb3, _unknownMethod, codes.Unknown.String())))
+	require.False(_apiMethodCalls.DeleteLabelValues(_protocolWeb3, "eth_foo", codes.Unknown.String()))
+}
@@ -0,0 +1,328 @@
+// Copyright (c) 2025 IoTeX
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+// To compile the proto, run:
+//      protoc --go_out=plugins=grpc:. *.proto
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.4
+// 	protoc        v3.20.1
+// source: api/apipb/action.proto
+
+package apipb
+
+import (
+	iotextypes "github.com/iotexproject/iotex-proto/golang/iotextypes"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type RejectReason int32
+
+const (
+	RejectReason_ACCEPTED                RejectReason = 0
+	RejectReason_INVALID_ACTION          RejectReason = 1
+	RejectReason_INVALID_CHAIN_ID        RejectReason = 2
+	RejectReason_INVALID_SENDER          RejectReason = 3
+	RejectReason_NONCE_TOO_LOW           RejectReason = 4
+	RejectReason_NONCE_TOO_HIGH          RejectReason = 5
+	RejectReason_UNDERPRICED             RejectReason = 6
+	RejectReason_REPLACEMENT_UNDERPRICED RejectReason = 7
+	RejectReason_INSUFFICIENT_BALANCE    RejectReason = 8
+	RejectReason_INTRINSIC_GAS           RejectReason = 9
+	RejectReason_GAS_LIMIT_EXCEEDED      RejectReason = 10
+	RejectReason_OVERSIZED_DATA          RejectReason = 11
+	RejectReason_KNOWN_ACTION            RejectReason = 12
+	RejectReason_POOL_FULL               RejectReason = 13
+)
+
+// Enum value maps for RejectReason.
+var (
+	RejectReason_name = map[int32]string{
+		0:  "ACCEPTED",
+		1:  "INVALID_ACTION",
+		2:  "INVALID_CHAIN_ID",
+		3:  "INVALID_SENDER",
+		4:  "NONCE_TOO_LOW",
+		5:  "NONCE_TOO_HIGH",
+		6:  "UNDERPRICED",
+		7:  "REPLACEMENT_UNDERPRICED",
+		8:  "INSUFFICIENT_BALANCE",
+		9:  "INTRINSIC_GAS",
+		10: "GAS_LIMIT_EXCEEDED",
+		11: "OVERSIZED_DATA",
+		12: "KNOWN_ACTION",
+		13: "POOL_FULL",
+	}
+	RejectReason_value = map[string]int32{
+		"ACCEPTED":                0,
+		"INVALID_ACTION":          1,
+		"INVALID_CHAIN_ID":        2,
+		"INVALID_SENDER":          3,
+		"NONCE_TOO_LOW":           4,
+		"NONCE_TOO_HIGH":          5,
+		"UNDERPRICED":             6,
+		"REPLACEMENT_UNDERPRICED": 7,
+		"INSUFFICIENT_BALANCE":    8,
+		"INTRINSIC_GAS":           9,
+		"GAS_LIMIT_EXCEEDED":      10,
+		"OVERSIZED_DATA":          11,
+		"KNOWN_ACTION":            12,
+		"POOL_FULL":               13,
+	}
+)
+
+func (x RejectReason) Enum() *RejectReason {
+	p := new(RejectReason)
+	*p = x
+	return p
+}
+
+func (x RejectReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RejectReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_apipb_action_proto_enumTypes[0].Descriptor()
+}
+
+func (RejectReason) Type() protoreflect.EnumType {
+	return &file_api_apipb_action_proto_enumTypes[0]
+}
+
+func (x RejectReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RejectReason.Descriptor instead.
+func (RejectReason) EnumDescriptor() ([]byte, []int) {
+	return file_api_apipb_action_proto_rawDescGZIP(), []int{0}
+}
+
+type SubmitActionDryRunRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Action        *iotextypes.Action     `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitActionDryRunRequest) Reset() {
+	*x = SubmitActionDryRunRequest{}
+	mi := &file_api_apipb_action_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitActionDryRunRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitActionDryRunRequest) ProtoMessage() {}
+
+func (x *SubmitActionDryRunRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_apipb_action_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitActionDryRunRequest.ProtoReflect.Descriptor instead.
+func (*SubmitActionDryRunRequest) Descriptor() ([]byte, []int) {
+	return file_api_apipb_action_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SubmitActionDryRunRequest) GetAction() *iotextypes.Action {
+	if x != nil {
+		return x.Action
+	}
+	return nil
+}
+
+type SubmitActionDryRunResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// hash of the action, empty if the action cannot be decoded
+	ActionHash string `protobuf:"bytes,1,opt,name=actionHash,proto3" json:"actionHash,omitempty"`
+	// reason of the rejection, ACCEPTED if the action would be accepted
+	Reason RejectReason `protobuf:"varint,2,opt,name=reason,proto3,enum=apipb.RejectReason" json:"reason,omitempty"`
+	// detailed message of the rejection
+	Message       string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitActionDryRunResponse) Reset() {
+	*x = SubmitActionDryRunResponse{}
+	mi := &file_api_apipb_action_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitActionDryRunResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitActionDryRunResponse) ProtoMessage() {}
+
+func (x *SubmitActionDryRunResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_apipb_action_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitActionDryRunResponse.ProtoReflect.Descriptor instead.
+func (*SubmitActionDryRunResponse) Descriptor() ([]byte, []int) {
+	return file_api_apipb_action_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SubmitActionDryRunResponse) GetActionHash() string {
+	if x != nil {
+		return x.ActionHash
+	}
+	return ""
+}
+
+func (x *SubmitActionDryRunResponse) GetReason() RejectReason {
+	if x != nil {
+		return x.Reason
+	}
+	return RejectReason_ACCEPTED
+}
+
+func (x *SubmitActionDryRunResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+var File_api_apipb_action_proto protoreflect.FileDescriptor
+
+var file_api_apipb_action_proto_rawDesc = string([]byte{
+	0x0a, 0x16, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x70, 0x69, 0x70, 0x62, 0x2f, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x61, 0x70, 0x69, 0x70, 0x62, 0x1a,
+	0x18, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2f, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x47, 0x0a, 0x19, 0x53, 0x75, 0x62,
+	0x6d, 0x69, 0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x69, 0x6f, 0x74, 0x65, 0x78, 0x74, 0x79,
+	0x70, 0x65, 0x73, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x22, 0x83, 0x01, 0x0a, 0x1a, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x41, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x44, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x61, 0x73, 0x68, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x61, 0x73,
+	0x68, 0x12, 0x2b, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x13, 0x2e, 0x61, 0x70, 0x69, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74,
+	0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x18,
+	0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2a, 0xa9, 0x02, 0x0a, 0x0c, 0x52, 0x65, 0x6a,
+	0x65, 0x63, 0x74, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x0c, 0x0a, 0x08, 0x41, 0x43, 0x43,
+	0x45, 0x50, 0x54, 0x45, 0x44, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x49, 0x4e, 0x56, 0x41, 0x4c,
+	0x49, 0x44, 0x5f, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x49,
+	0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f, 0x43, 0x48, 0x41, 0x49, 0x4e, 0x5f, 0x49, 0x44, 0x10,
+	0x02, 0x12, 0x12, 0x0a, 0x0e, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f, 0x53, 0x45, 0x4e,
+	0x44, 0x45, 0x52, 0x10, 0x03, 0x12, 0x11, 0x0a, 0x0d, 0x4e, 0x4f, 0x4e, 0x43, 0x45, 0x5f, 0x54,
+	0x4f, 0x4f, 0x5f, 0x4c, 0x4f, 0x57, 0x10, 0x04, 0x12, 0x12, 0x0a, 0x0e, 0x4e, 0x4f, 0x4e, 0x43,
+	0x45, 0x5f, 0x54, 0x4f, 0x4f, 0x5f, 0x48, 0x49, 0x47, 0x48, 0x10, 0x05, 0x12, 0x0f, 0x0a, 0x0b,
+	0x55, 0x4e, 0x44, 0x45, 0x52, 0x50, 0x52, 0x49, 0x43, 0x45, 0x44, 0x10, 0x06, 0x12, 0x1b, 0x0a,
+	0x17, 0x52, 0x45, 0x50, 0x4c, 0x41, 0x43, 0x45, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x55, 0x4e, 0x44,
+	0x45, 0x52, 0x50, 0x52, 0x49, 0x43, 0x45, 0x44, 0x10, 0x07, 0x12, 0x18, 0x0a, 0x14, 0x49, 0x4e,
+	0x53, 0x55, 0x46, 0x46, 0x49, 0x43, 0x49, 0x45, 0x4e, 0x54, 0x5f, 0x42, 0x41, 0x4c, 0x41, 0x4e,
+	0x43, 0x45, 0x10, 0x08, 0x12, 0x11, 0x0a, 0x0d, 0x49, 0x4e, 0x54, 0x52, 0x49, 0x4e, 0x53, 0x49,
+	0x43, 0x5f, 0x47, 0x41, 0x53, 0x10, 0x09, 0x12, 0x16, 0x0a, 0x12, 0x47, 0x41, 0x53, 0x5f, 0x4c,
+	0x49, 0x4d, 0x49, 0x54, 0x5f, 0x45, 0x58, 0x43, 0x45, 0x45, 0x44, 0x45, 0x44, 0x10, 0x0a, 0x12,
+	0x12, 0x0a, 0x0e, 0x4f, 0x56, 0x45, 0x52, 0x53, 0x49, 0x5a, 0x45, 0x44, 0x5f, 0x44, 0x41, 0x54,
+	0x41, 0x10, 0x0b, 0x12, 0x10, 0x0a, 0x0c, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x41, 0x43, 0x54,
+	0x49, 0x4f, 0x4e, 0x10, 0x0c, 0x12, 0x0d, 0x0a, 0x09, 0x50, 0x4f, 0x4f, 0x4c, 0x5f, 0x46, 0x55,
+	0x4c, 0x4c, 0x10, 0x0d, 0x32, 0x6c, 0x0a, 0x0d, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x5b, 0x0a, 0x12, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x41,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x12, 0x20, 0x2e, 0x61, 0x70,
+	0x69, 0x70, 0x62, 0x2e, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x44, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e,
+	0x61, 0x70, 0x69, 0x70, 0x62, 0x2e, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x41, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x44, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x69, 0x6f, 0x74, 0x65, 0x78, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x2f, 0x69, 0x6f,
+	0x74, 0x65, 0x78, 0x2d, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x76, 0x32, 0x2f, 0x61, 0x70, 0x69, 0x2f,
+	0x61, 0x70, 0x69, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+})
+
+var (
+	file_api_apipb_action_proto_rawDescOnce sync.Once
+	file_api_apipb_action_proto_rawDescData []byte
+)
+
+func file_api_apipb_action_proto_rawDescGZIP() []byte {
+	file_api_apipb_action_proto_rawDescOnce.Do(func() {
+		file_api_apipb_action_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_apipb_action_proto_rawDesc), len(file_api_apipb_action_proto_rawDesc)))
+	})
+	return file_api_apipb_action_proto_rawDescData
+}
+
+var file_api_apipb_action_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_api_apipb_action_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_api_apipb_action_proto_goTypes = []any{
+	(RejectReason)(0),                  // 0: apipb.RejectReason
+	(*SubmitActionDryRunRequest)(nil),  // 1: apipb.SubmitActionDryRunRequest
+	(*SubmitActionDryRunResponse)(nil), // 2: apipb.SubmitActionDryRunResponse
+	(*iotextypes.Action)(nil),          // 3: iotextypes.Action
+}
+var file_api_apipb_action_proto_depIdxs = []int32{
+	3, // 0: apipb.SubmitActionDryRunRequest.action:type_name -> iotextypes.Action
+	0, // 1: apipb.SubmitActionDryRunResponse.reason:type_name -> apipb.RejectReason
+	1, // 2: apipb.ActionService.SubmitActionDryRun:input_type -> apipb.SubmitActionDryRunRequest
+	2, // 3: apipb.ActionService.SubmitActionDryRun:output_type -> apipb.SubmitActionDryRunResponse
+	3, // [3:4] is the sub-list for method output_type
+	2, // [2:3] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_api_apipb_action_proto_init() }
+func file_api_apipb_action_proto_init() {
+	if File_api_apipb_action_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_apipb_action_proto_rawDesc), len(file_api_apipb_action_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_apipb_action_proto_goTypes,
+		DependencyIndexes: file_api_apipb_action_proto_depIdxs,
+		EnumInfos:         file_api_apipb_action_proto_enumTypes,
+		MessageInfos:      file_api_apipb_action_proto_msgTypes,
+	}.Build()
+	File_api_apipb_action_proto = out.File
+	file_api_apipb_action_proto_goTypes = nil
+	file_api_apipb_action_proto_depIdxs = nil
+}
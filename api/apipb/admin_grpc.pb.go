@@ -0,0 +1,463 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.20.1
+// source: api/apipb/admin.proto
+
+package apipb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// AdminServiceClient is the client API for AdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AdminServiceClient interface {
+	AddPeer(ctx context.Context, in *AddPeerRequest, opts ...grpc.CallOption) (*AddPeerResponse, error)
+	RemovePeer(ctx context.Context, in *RemovePeerRequest, opts ...grpc.CallOption) (*RemovePeerResponse, error)
+	SetMinGasPrice(ctx context.Context, in *SetMinGasPriceRequest, opts ...grpc.CallOption) (*SetMinGasPriceResponse, error)
+	PauseChain(ctx context.Context, in *PauseChainRequest, opts ...grpc.CallOption) (*PauseChainResponse, error)
+	ResumeChain(ctx context.Context, in *ResumeChainRequest, opts ...grpc.CallOption) (*ResumeChainResponse, error)
+	SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelResponse, error)
+	GetConsensusTimeouts(ctx context.Context, in *GetConsensusTimeoutsRequest, opts ...grpc.CallOption) (*GetConsensusTimeoutsResponse, error)
+	SetConsensusTimeouts(ctx context.Context, in *SetConsensusTimeoutsRequest, opts ...grpc.CallOption) (*SetConsensusTimeoutsResponse, error)
+	GetPeerScores(ctx context.Context, in *GetPeerScoresRequest, opts ...grpc.CallOption) (*GetPeerScoresResponse, error)
+	AddTrustedPeer(ctx context.Context, in *AddTrustedPeerRequest, opts ...grpc.CallOption) (*AddTrustedPeerResponse, error)
+	ReloadConfig(ctx context.Context, in *ReloadConfigRequest, opts ...grpc.CallOption) (*ReloadConfigResponse, error)
+}
+
+type adminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminServiceClient(cc grpc.ClientConnInterface) AdminServiceClient {
+	return &adminServiceClient{cc}
+}
+
+func (c *adminServiceClient) AddPeer(ctx context.Context, in *AddPeerRequest, opts ...grpc.CallOption) (*AddPeerResponse, error) {
+	out := new(AddPeerResponse)
+	err := c.cc.Invoke(ctx, "/apipb.AdminService/AddPeer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) RemovePeer(ctx context.Context, in *RemovePeerRequest, opts ...grpc.CallOption) (*RemovePeerResponse, error) {
+	out := new(RemovePeerResponse)
+	err := c.cc.Invoke(ctx, "/apipb.AdminService/RemovePeer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SetMinGasPrice(ctx context.Context, in *SetMinGasPriceRequest, opts ...grpc.CallOption) (*SetMinGasPriceResponse, error) {
+	out := new(SetMinGasPriceResponse)
+	err := c.cc.Invoke(ctx, "/apipb.AdminService/SetMinGasPrice", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) PauseChain(ctx context.Context, in *PauseChainRequest, opts ...grpc.CallOption) (*PauseChainResponse, error) {
+	out := new(PauseChainResponse)
+	err := c.cc.Invoke(ctx, "/apipb.AdminService/PauseChain", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ResumeChain(ctx context.Context, in *ResumeChainRequest, opts ...grpc.CallOption) (*ResumeChainResponse, error) {
+	out := new(ResumeChainResponse)
+	err := c.cc.Invoke(ctx, "/apipb.AdminService/ResumeChain", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelResponse, error) {
+	out := new(SetLogLevelResponse)
+	err := c.cc.Invoke(ctx, "/apipb.AdminService/SetLogLevel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetConsensusTimeouts(ctx context.Context, in *GetConsensusTimeoutsRequest, opts ...grpc.CallOption) (*GetConsensusTimeoutsResponse, error) {
+	out := new(GetConsensusTimeoutsResponse)
+	err := c.cc.Invoke(ctx, "/apipb.AdminService/GetConsensusTimeouts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SetConsensusTimeouts(ctx context.Context, in *SetConsensusTimeoutsRequest, opts ...grpc.CallOption) (*SetConsensusTimeoutsResponse, error) {
+	out := new(SetConsensusTimeoutsResponse)
+	err := c.cc.Invoke(ctx, "/apipb.AdminService/SetConsensusTimeouts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetPeerScores(ctx context.Context, in *GetPeerScoresRequest, opts ...grpc.CallOption) (*GetPeerScoresResponse, error) {
+	out := new(GetPeerScoresResponse)
+	err := c.cc.Invoke(ctx, "/apipb.AdminService/GetPeerScores", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) AddTrustedPeer(ctx context.Context, in *AddTrustedPeerRequest, opts ...grpc.CallOption) (*AddTrustedPeerResponse, error) {
+	out := new(AddTrustedPeerResponse)
+	err := c.cc.Invoke(ctx, "/apipb.AdminService/AddTrustedPeer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ReloadConfig(ctx context.Context, in *ReloadConfigRequest, opts ...grpc.CallOption) (*ReloadConfigResponse, error) {
+	out := new(ReloadConfigResponse)
+	err := c.cc.Invoke(ctx, "/apipb.AdminService/ReloadConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServiceServer is the server API for AdminService service.
+// All implementations should embed UnimplementedAdminServiceServer
+// for forward compatibility
+type AdminServiceServer interface {
+	AddPeer(context.Context, *AddPeerRequest) (*AddPeerResponse, error)
+	RemovePeer(context.Context, *RemovePeerRequest) (*RemovePeerResponse, error)
+	SetMinGasPrice(context.Context, *SetMinGasPriceRequest) (*SetMinGasPriceResponse, error)
+	PauseChain(context.Context, *PauseChainRequest) (*PauseChainResponse, error)
+	ResumeChain(context.Context, *ResumeChainRequest) (*ResumeChainResponse, error)
+	SetLogLevel(context.Context, *SetLogLevelRequest) (*SetLogLevelResponse, error)
+	GetConsensusTimeouts(context.Context, *GetConsensusTimeoutsRequest) (*GetConsensusTimeoutsResponse, error)
+	SetConsensusTimeouts(context.Context, *SetConsensusTimeoutsRequest) (*SetConsensusTimeoutsResponse, error)
+	GetPeerScores(context.Context, *GetPeerScoresRequest) (*GetPeerScoresResponse, error)
+	AddTrustedPeer(context.Context, *AddTrustedPeerRequest) (*AddTrustedPeerResponse, error)
+	ReloadConfig(context.Context, *ReloadConfigRequest) (*ReloadConfigResponse, error)
+}
+
+// UnimplementedAdminServiceServer should be embedded to have forward compatible implementations.
+type UnimplementedAdminServiceServer struct {
+}
+
+func (UnimplementedAdminServiceServer) AddPeer(context.Context, *AddPeerRequest) (*AddPeerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddPeer not implemented")
+}
+func (UnimplementedAdminServiceServer) RemovePeer(context.Context, *RemovePeerRequest) (*RemovePeerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemovePeer not implemented")
+}
+func (UnimplementedAdminServiceServer) SetMinGasPrice(context.Context, *SetMinGasPriceRequest) (*SetMinGasPriceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMinGasPrice not implemented")
+}
+func (UnimplementedAdminServiceServer) PauseChain(context.Context, *PauseChainRequest) (*PauseChainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseChain not implemented")
+}
+func (UnimplementedAdminServiceServer) ResumeChain(context.Context, *ResumeChainRequest) (*ResumeChainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeChain not implemented")
+}
+func (UnimplementedAdminServiceServer) SetLogLevel(context.Context, *SetLogLevelRequest) (*SetLogLevelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLogLevel not implemented")
+}
+func (UnimplementedAdminServiceServer) GetConsensusTimeouts(context.Context, *GetConsensusTimeoutsRequest) (*GetConsensusTimeoutsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConsensusTimeouts not implemented")
+}
+func (UnimplementedAdminServiceServer) SetConsensusTimeouts(context.Context, *SetConsensusTimeoutsRequest) (*SetConsensusTimeoutsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetConsensusTimeouts not implemented")
+}
+func (UnimplementedAdminServiceServer) GetPeerScores(context.Context, *GetPeerScoresRequest) (*GetPeerScoresResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPeerScores not implemented")
+}
+func (UnimplementedAdminServiceServer) AddTrustedPeer(context.Context, *AddTrustedPeerRequest) (*AddTrustedPeerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddTrustedPeer not implemented")
+}
+func (UnimplementedAdminServiceServer) ReloadConfig(context.Context, *ReloadConfigRequest) (*ReloadConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReloadConfig not implemented")
+}
+
+// UnsafeAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminServiceServer will
+// result in compilation errors.
+type UnsafeAdminServiceServer interface {
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+func RegisterAdminServiceServer(s grpc.ServiceRegistrar, srv AdminServiceServer) {
+	s.RegisterService(&AdminService_ServiceDesc, srv)
+}
+
+func _AdminService_AddPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddPeerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).AddPeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apipb.AdminService/AddPeer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).AddPeer(ctx, req.(*AddPeerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_RemovePeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemovePeerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).RemovePeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apipb.AdminService/RemovePeer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).RemovePeer(ctx, req.(*RemovePeerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SetMinGasPrice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMinGasPriceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetMinGasPrice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apipb.AdminService/SetMinGasPrice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetMinGasPrice(ctx, req.(*SetMinGasPriceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_PauseChain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseChainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).PauseChain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apipb.AdminService/PauseChain",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).PauseChain(ctx, req.(*PauseChainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ResumeChain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeChainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ResumeChain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apipb.AdminService/ResumeChain",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ResumeChain(ctx, req.(*ResumeChainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SetLogLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLogLevelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetLogLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apipb.AdminService/SetLogLevel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetLogLevel(ctx, req.(*SetLogLevelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetConsensusTimeouts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConsensusTimeoutsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetConsensusTimeouts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apipb.AdminService/GetConsensusTimeouts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetConsensusTimeouts(ctx, req.(*GetConsensusTimeoutsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SetConsensusTimeouts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetConsensusTimeoutsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetConsensusTimeouts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apipb.AdminService/SetConsensusTimeouts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetConsensusTimeouts(ctx, req.(*SetConsensusTimeoutsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetPeerScores_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPeerScoresRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetPeerScores(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apipb.AdminService/GetPeerScores",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetPeerScores(ctx, req.(*GetPeerScoresRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_AddTrustedPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddTrustedPeerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).AddTrustedPeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apipb.AdminService/AddTrustedPeer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).AddTrustedPeer(ctx, req.(*AddTrustedPeerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ReloadConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReloadConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ReloadConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apipb.AdminService/ReloadConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ReloadConfig(ctx, req.(*ReloadConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AdminService_ServiceDesc is the grpc.ServiceDesc for AdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "apipb.AdminService",
+	HandlerType: (*AdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddPeer",
+			Handler:    _AdminService_AddPeer_Handler,
+		},
+		{
+			MethodName: "RemovePeer",
+			Handler:    _AdminService_RemovePeer_Handler,
+		},
+		{
+			MethodName: "SetMinGasPrice",
+			Handler:    _AdminService_SetMinGasPrice_Handler,
+		},
+		{
+			MethodName: "PauseChain",
+			Handler:    _AdminService_PauseChain_Handler,
+		},
+		{
+			MethodName: "ResumeChain",
+			Handler:    _AdminService_ResumeChain_Handler,
+		},
+		{
+			MethodName: "SetLogLevel",
+			Handler:    _AdminService_SetLogLevel_Handler,
+		},
+		{
+			MethodName: "GetConsensusTimeouts",
+			Handler:    _AdminService_GetConsensusTimeouts_Handler,
+		},
+		{
+			MethodName: "SetConsensusTimeouts",
+			Handler:    _AdminService_SetConsensusTimeouts_Handler,
+		},
+		{
+			MethodName: "GetPeerScores",
+			Handler:    _AdminService_GetPeerScores_Handler,
+		},
+		{
+			MethodName: "AddTrustedPeer",
+			Handler:    _AdminService_AddTrustedPeer_Handler,
+		},
+		{
+			MethodName: "ReloadConfig",
+			Handler:    _AdminService_ReloadConfig_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/apipb/admin.proto",
+}
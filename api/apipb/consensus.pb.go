@@ -0,0 +1,1196 @@
+// Copyright (c) 2025 IoTeX
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+// To compile the proto, run:
+//      protoc --go_out=plugins=grpc:. *.proto
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.4
+// 	protoc        v3.20.1
+// source: api/apipb/consensus.proto
+
+package apipb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetConsensusStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConsensusStatusRequest) Reset() {
+	*x = GetConsensusStatusRequest{}
+	mi := &file_api_apipb_consensus_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConsensusStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConsensusStatusRequest) ProtoMessage() {}
+
+func (x *GetConsensusStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_apipb_consensus_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConsensusStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetConsensusStatusRequest) Descriptor() ([]byte, []int) {
+	return file_api_apipb_consensus_proto_rawDescGZIP(), []int{0}
+}
+
+type DelegateStatus struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Address string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// number of the rounds in which the block proposal of the delegate is not received
+	MissedProposals uint64 `protobuf:"varint,2,opt,name=missedProposals,proto3" json:"missedProposals,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DelegateStatus) Reset() {
+	*x = DelegateStatus{}
+	mi := &file_api_apipb_consensus_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DelegateStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DelegateStatus) ProtoMessage() {}
+
+func (x *DelegateStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_api_apipb_consensus_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DelegateStatus.ProtoReflect.Descriptor instead.
+func (*DelegateStatus) Descriptor() ([]byte, []int) {
+	return file_api_apipb_consensus_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DelegateStatus) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *DelegateStatus) GetMissedProposals() uint64 {
+	if x != nil {
+		return x.MissedProposals
+	}
+	return 0
+}
+
+type GetConsensusStatusResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Height uint64                 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Round  uint32                 `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	Epoch  uint64                 `protobuf:"varint,3,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	// state of the consensus state machine
+	State          string                 `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`
+	Proposer       string                 `protobuf:"bytes,5,opt,name=proposer,proto3" json:"proposer,omitempty"`
+	RoundStartTime *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=roundStartTime,proto3" json:"roundStartTime,omitempty"`
+	// time from the round start to the receipt of the block proposal, empty if not received
+	ProposalReceiptTime *durationpb.Duration `protobuf:"bytes,7,opt,name=proposalReceiptTime,proto3" json:"proposalReceiptTime,omitempty"`
+	// times from the round start to the quorums of the endorsements, empty if not reached
+	ProposalQuorumTime *durationpb.Duration `protobuf:"bytes,8,opt,name=proposalQuorumTime,proto3" json:"proposalQuorumTime,omitempty"`
+	LockQuorumTime     *durationpb.Duration `protobuf:"bytes,9,opt,name=lockQuorumTime,proto3" json:"lockQuorumTime,omitempty"`
+	CommitQuorumTime   *durationpb.Duration `protobuf:"bytes,10,opt,name=commitQuorumTime,proto3" json:"commitQuorumTime,omitempty"`
+	// number of the rounds moving to the next round without committing a block
+	ViewChanges      uint64 `protobuf:"varint,11,opt,name=viewChanges,proto3" json:"viewChanges,omitempty"`
+	LastCommitHeight uint64 `protobuf:"varint,12,opt,name=lastCommitHeight,proto3" json:"lastCommitHeight,omitempty"`
+	// number of the rounds to commit the last block
+	LastCommitRounds uint32 `protobuf:"varint,13,opt,name=lastCommitRounds,proto3" json:"lastCommitRounds,omitempty"`
+	// delegates which missed the block proposals in the rounds the node takes part in
+	Delegates     []*DelegateStatus `protobuf:"bytes,14,rep,name=delegates,proto3" json:"delegates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConsensusStatusResponse) Reset() {
+	*x = GetConsensusStatusResponse{}
+	mi := &file_api_apipb_consensus_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConsensusStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConsensusStatusResponse) ProtoMessage() {}
+
+func (x *GetConsensusStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_apipb_consensus_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConsensusStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetConsensusStatusResponse) Descriptor() ([]byte, []int) {
+	return file_api_apipb_consensus_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetConsensusStatusResponse) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *GetConsensusStatusResponse) GetRound() uint32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *GetConsensusStatusResponse) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *GetConsensusStatusResponse) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *GetConsensusStatusResponse) GetProposer() string {
+	if x != nil {
+		return x.Proposer
+	}
+	return ""
+}
+
+func (x *GetConsensusStatusResponse) GetRoundStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RoundStartTime
+	}
+	return nil
+}
+
+func (x *GetConsensusStatusResponse) GetProposalReceiptTime() *durationpb.Duration {
+	if x != nil {
+		return x.ProposalReceiptTime
+	}
+	return nil
+}
+
+func (x *GetConsensusStatusResponse) GetProposalQuorumTime() *durationpb.Duration {
+	if x != nil {
+		return x.ProposalQuorumTime
+	}
+	return nil
+}
+
+func (x *GetConsensusStatusResponse) GetLockQuorumTime() *durationpb.Duration {
+	if x != nil {
+		return x.LockQuorumTime
+	}
+	return nil
+}
+
+func (x *GetConsensusStatusResponse) GetCommitQuorumTime() *durationpb.Duration {
+	if x != nil {
+		return x.CommitQuorumTime
+	}
+	return nil
+}
+
+func (x *GetConsensusStatusResponse) GetViewChanges() uint64 {
+	if x != nil {
+		return x.ViewChanges
+	}
+	return 0
+}
+
+func (x *GetConsensusStatusResponse) GetLastCommitHeight() uint64 {
+	if x != nil {
+		return x.LastCommitHeight
+	}
+	return 0
+}
+
+func (x *GetConsensusStatusResponse) GetLastCommitRounds() uint32 {
+	if x != nil {
+		return x.LastCommitRounds
+	}
+	return 0
+}
+
+func (x *GetConsensusStatusResponse) GetDelegates() []*DelegateStatus {
+	if x != nil {
+		return x.Delegates
+	}
+	return nil
+}
+
+type GetNextEpochPreviewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNextEpochPreviewRequest) Reset() {
+	*x = GetNextEpochPreviewRequest{}
+	mi := &file_api_apipb_consensus_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNextEpochPreviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNextEpochPreviewRequest) ProtoMessage() {}
+
+func (x *GetNextEpochPreviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_apipb_consensus_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNextEpochPreviewRequest.ProtoReflect.Descriptor instead.
+func (*GetNextEpochPreviewRequest) Descriptor() ([]byte, []int) {
+	return file_api_apipb_consensus_proto_rawDescGZIP(), []int{3}
+}
+
+type EpochDelegate struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Address string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// voting power, which is reduced if the delegate is on probation
+	Votes         string `protobuf:"bytes,2,opt,name=votes,proto3" json:"votes,omitempty"`
+	RewardAddress string `protobuf:"bytes,3,opt,name=rewardAddress,proto3" json:"rewardAddress,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EpochDelegate) Reset() {
+	*x = EpochDelegate{}
+	mi := &file_api_apipb_consensus_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EpochDelegate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EpochDelegate) ProtoMessage() {}
+
+func (x *EpochDelegate) ProtoReflect() protoreflect.Message {
+	mi := &file_api_apipb_consensus_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EpochDelegate.ProtoReflect.Descriptor instead.
+func (*EpochDelegate) Descriptor() ([]byte, []int) {
+	return file_api_apipb_consensus_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *EpochDelegate) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *EpochDelegate) GetVotes() string {
+	if x != nil {
+		return x.Votes
+	}
+	return ""
+}
+
+func (x *EpochDelegate) GetRewardAddress() string {
+	if x != nil {
+		return x.RewardAddress
+	}
+	return ""
+}
+
+type ProbationDelegate struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Address string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// number of the recent epochs in which the delegate is unproductive
+	Count         uint32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProbationDelegate) Reset() {
+	*x = ProbationDelegate{}
+	mi := &file_api_apipb_consensus_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProbationDelegate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProbationDelegate) ProtoMessage() {}
+
+func (x *ProbationDelegate) ProtoReflect() protoreflect.Message {
+	mi := &file_api_apipb_consensus_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProbationDelegate.ProtoReflect.Descriptor instead.
+func (*ProbationDelegate) Descriptor() ([]byte, []int) {
+	return file_api_apipb_consensus_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ProbationDelegate) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *ProbationDelegate) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type GetNextEpochPreviewResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Epoch uint64                 `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	// start height of the epoch
+	Height uint64 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	// height of the state the preview is computed from
+	StateHeight uint64 `protobuf:"varint,3,opt,name=stateHeight,proto3" json:"stateHeight,omitempty"`
+	// whether the delegates or the probation list are projected from the current state, which may change
+	// until the delegates are snapshotted in the middle of the epoch and the probation list is decided at the last block
+	Projected bool `protobuf:"varint,4,opt,name=projected,proto3" json:"projected,omitempty"`
+	// active delegates of the epoch
+	Delegates     []*EpochDelegate     `protobuf:"bytes,5,rep,name=delegates,proto3" json:"delegates,omitempty"`
+	ProbationList []*ProbationDelegate `protobuf:"bytes,6,rep,name=probationList,proto3" json:"probationList,omitempty"`
+	// percentage of the voting power reduced for the delegates on probation
+	ProbationIntensityRate uint32 `protobuf:"varint,7,opt,name=probationIntensityRate,proto3" json:"probationIntensityRate,omitempty"`
+	// proposers of the first round of the heights in turn, starting from the start height of the epoch
+	Proposers     []string `protobuf:"bytes,8,rep,name=proposers,proto3" json:"proposers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNextEpochPreviewResponse) Reset() {
+	*x = GetNextEpochPreviewResponse{}
+	mi := &file_api_apipb_consensus_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNextEpochPreviewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNextEpochPreviewResponse) ProtoMessage() {}
+
+func (x *GetNextEpochPreviewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_apipb_consensus_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNextEpochPreviewResponse.ProtoReflect.Descriptor instead.
+func (*GetNextEpochPreviewResponse) Descriptor() ([]byte, []int) {
+	return file_api_apipb_consensus_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetNextEpochPreviewResponse) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *GetNextEpochPreviewResponse) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *GetNextEpochPreviewResponse) GetStateHeight() uint64 {
+	if x != nil {
+		return x.StateHeight
+	}
+	return 0
+}
+
+func (x *GetNextEpochPreviewResponse) GetProjected() bool {
+	if x != nil {
+		return x.Projected
+	}
+	return false
+}
+
+func (x *GetNextEpochPreviewResponse) GetDelegates() []*EpochDelegate {
+	if x != nil {
+		return x.Delegates
+	}
+	return nil
+}
+
+func (x *GetNextEpochPreviewResponse) GetProbationList() []*ProbationDelegate {
+	if x != nil {
+		return x.ProbationList
+	}
+	return nil
+}
+
+func (x *GetNextEpochPreviewResponse) GetProbationIntensityRate() uint32 {
+	if x != nil {
+		return x.ProbationIntensityRate
+	}
+	return 0
+}
+
+func (x *GetNextEpochPreviewResponse) GetProposers() []string {
+	if x != nil {
+		return x.Proposers
+	}
+	return nil
+}
+
+type GetForksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetForksRequest) Reset() {
+	*x = GetForksRequest{}
+	mi := &file_api_apipb_consensus_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetForksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetForksRequest) ProtoMessage() {}
+
+func (x *GetForksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_apipb_consensus_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetForksRequest.ProtoReflect.Descriptor instead.
+func (*GetForksRequest) Descriptor() ([]byte, []int) {
+	return file_api_apipb_consensus_proto_rawDescGZIP(), []int{7}
+}
+
+type Fork struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// height of the first block of the branch
+	Height uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	// number of the heights of the branch
+	Depth uint64 `protobuf:"varint,2,opt,name=depth,proto3" json:"depth,omitempty"`
+	// hash of the first block of the branch
+	Root      string `protobuf:"bytes,3,opt,name=root,proto3" json:"root,omitempty"`
+	Tip       string `protobuf:"bytes,4,opt,name=tip,proto3" json:"tip,omitempty"`
+	TipHeight uint64 `protobuf:"varint,5,opt,name=tipHeight,proto3" json:"tipHeight,omitempty"`
+	// producers of the blocks in the branch
+	Producers []string `protobuf:"bytes,6,rep,name=producers,proto3" json:"producers,omitempty"`
+	// peers which the blocks in the branch are received from
+	Peers     []string               `protobuf:"bytes,7,rep,name=peers,proto3" json:"peers,omitempty"`
+	FirstSeen *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=firstSeen,proto3" json:"firstSeen,omitempty"`
+	LastSeen  *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=lastSeen,proto3" json:"lastSeen,omitempty"`
+	// whether the alert of the fork is fired
+	Alerted       bool `protobuf:"varint,10,opt,name=alerted,proto3" json:"alerted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Fork) Reset() {
+	*x = Fork{}
+	mi := &file_api_apipb_consensus_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Fork) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Fork) ProtoMessage() {}
+
+func (x *Fork) ProtoReflect() protoreflect.Message {
+	mi := &file_api_apipb_consensus_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Fork.ProtoReflect.Descriptor instead.
+func (*Fork) Descriptor() ([]byte, []int) {
+	return file_api_apipb_consensus_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Fork) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Fork) GetDepth() uint64 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *Fork) GetRoot() string {
+	if x != nil {
+		return x.Root
+	}
+	return ""
+}
+
+func (x *Fork) GetTip() string {
+	if x != nil {
+		return x.Tip
+	}
+	return ""
+}
+
+func (x *Fork) GetTipHeight() uint64 {
+	if x != nil {
+		return x.TipHeight
+	}
+	return 0
+}
+
+func (x *Fork) GetProducers() []string {
+	if x != nil {
+		return x.Producers
+	}
+	return nil
+}
+
+func (x *Fork) GetPeers() []string {
+	if x != nil {
+		return x.Peers
+	}
+	return nil
+}
+
+func (x *Fork) GetFirstSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FirstSeen
+	}
+	return nil
+}
+
+func (x *Fork) GetLastSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeen
+	}
+	return nil
+}
+
+func (x *Fork) GetAlerted() bool {
+	if x != nil {
+		return x.Alerted
+	}
+	return false
+}
+
+type GetForksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Forks         []*Fork                `protobuf:"bytes,1,rep,name=forks,proto3" json:"forks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetForksResponse) Reset() {
+	*x = GetForksResponse{}
+	mi := &file_api_apipb_consensus_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetForksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetForksResponse) ProtoMessage() {}
+
+func (x *GetForksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_apipb_consensus_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetForksResponse.ProtoReflect.Descriptor instead.
+func (*GetForksResponse) Descriptor() ([]byte, []int) {
+	return file_api_apipb_consensus_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetForksResponse) GetForks() []*Fork {
+	if x != nil {
+		return x.Forks
+	}
+	return nil
+}
+
+type GetHeartbeatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHeartbeatsRequest) Reset() {
+	*x = GetHeartbeatsRequest{}
+	mi := &file_api_apipb_consensus_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHeartbeatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHeartbeatsRequest) ProtoMessage() {}
+
+func (x *GetHeartbeatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_apipb_consensus_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHeartbeatsRequest.ProtoReflect.Descriptor instead.
+func (*GetHeartbeatsRequest) Descriptor() ([]byte, []int) {
+	return file_api_apipb_consensus_proto_rawDescGZIP(), []int{10}
+}
+
+type Heartbeat struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// address of the delegate which signed the heartbeat
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	// tip height of the delegate
+	Height    uint64                 `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Peer      string                 `protobuf:"bytes,5,opt,name=peer,proto3" json:"peer,omitempty"`
+	Epoch     uint64                 `protobuf:"varint,6,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	// times from the round start to the receipt of the block proposal and the quorum of the commit endorsements
+	// in the latest round of the delegate
+	ProposalLatency  *durationpb.Duration `protobuf:"bytes,7,opt,name=proposalLatency,proto3" json:"proposalLatency,omitempty"`
+	CommitLatency    *durationpb.Duration `protobuf:"bytes,8,opt,name=commitLatency,proto3" json:"commitLatency,omitempty"`
+	ViewChanges      uint64               `protobuf:"varint,9,opt,name=viewChanges,proto3" json:"viewChanges,omitempty"`
+	LastCommitRounds uint32               `protobuf:"varint,10,opt,name=lastCommitRounds,proto3" json:"lastCommitRounds,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Heartbeat) Reset() {
+	*x = Heartbeat{}
+	mi := &file_api_apipb_consensus_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Heartbeat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Heartbeat) ProtoMessage() {}
+
+func (x *Heartbeat) ProtoReflect() protoreflect.Message {
+	mi := &file_api_apipb_consensus_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Heartbeat.ProtoReflect.Descriptor instead.
+func (*Heartbeat) Descriptor() ([]byte, []int) {
+	return file_api_apipb_consensus_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Heartbeat) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Heartbeat) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Heartbeat) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Heartbeat) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *Heartbeat) GetPeer() string {
+	if x != nil {
+		return x.Peer
+	}
+	return ""
+}
+
+func (x *Heartbeat) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *Heartbeat) GetProposalLatency() *durationpb.Duration {
+	if x != nil {
+		return x.ProposalLatency
+	}
+	return nil
+}
+
+func (x *Heartbeat) GetCommitLatency() *durationpb.Duration {
+	if x != nil {
+		return x.CommitLatency
+	}
+	return nil
+}
+
+func (x *Heartbeat) GetViewChanges() uint64 {
+	if x != nil {
+		return x.ViewChanges
+	}
+	return 0
+}
+
+func (x *Heartbeat) GetLastCommitRounds() uint32 {
+	if x != nil {
+		return x.LastCommitRounds
+	}
+	return 0
+}
+
+type GetHeartbeatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Heartbeats    []*Heartbeat           `protobuf:"bytes,1,rep,name=heartbeats,proto3" json:"heartbeats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHeartbeatsResponse) Reset() {
+	*x = GetHeartbeatsResponse{}
+	mi := &file_api_apipb_consensus_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHeartbeatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHeartbeatsResponse) ProtoMessage() {}
+
+func (x *GetHeartbeatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_apipb_consensus_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHeartbeatsResponse.ProtoReflect.Descriptor instead.
+func (*GetHeartbeatsResponse) Descriptor() ([]byte, []int) {
+	return file_api_apipb_consensus_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetHeartbeatsResponse) GetHeartbeats() []*Heartbeat {
+	if x != nil {
+		return x.Heartbeats
+	}
+	return nil
+}
+
+var File_api_apipb_consensus_proto protoreflect.FileDescriptor
+
+var file_api_apipb_consensus_proto_rawDesc = string([]byte{
+	0x0a, 0x19, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x70, 0x69, 0x70, 0x62, 0x2f, 0x63, 0x6f, 0x6e, 0x73,
+	0x65, 0x6e, 0x73, 0x75, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x61, 0x70, 0x69,
+	0x70, 0x62, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x22, 0x1b, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e,
+	0x73, 0x75, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x22, 0x54, 0x0a, 0x0e, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x28, 0x0a, 0x0f,
+	0x6d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x6d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x50, 0x72, 0x6f,
+	0x70, 0x6f, 0x73, 0x61, 0x6c, 0x73, 0x22, 0xa7, 0x05, 0x0a, 0x1a, 0x47, 0x65, 0x74, 0x43, 0x6f,
+	0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x72, 0x6f,
+	0x75, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72, 0x12, 0x42, 0x0a, 0x0e, 0x72,
+	0x6f, 0x75, 0x6e, 0x64, 0x53, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x0e, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x53, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12,
+	0x4b, 0x0a, 0x13, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x52, 0x65, 0x63, 0x65, 0x69,
+	0x70, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x13, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61,
+	0x6c, 0x52, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x49, 0x0a, 0x12,
+	0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x54, 0x69,
+	0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x12, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x51, 0x75, 0x6f,
+	0x72, 0x75, 0x6d, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x41, 0x0a, 0x0e, 0x6c, 0x6f, 0x63, 0x6b, 0x51,
+	0x75, 0x6f, 0x72, 0x75, 0x6d, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0e, 0x6c, 0x6f, 0x63, 0x6b,
+	0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x45, 0x0a, 0x10, 0x63, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x10, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x54, 0x69, 0x6d,
+	0x65, 0x12, 0x20, 0x0a, 0x0b, 0x76, 0x69, 0x65, 0x77, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73,
+	0x18, 0x0b, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x76, 0x69, 0x65, 0x77, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x6c, 0x61, 0x73, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x6c,
+	0x61, 0x73, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12,
+	0x2a, 0x0a, 0x10, 0x6c, 0x61, 0x73, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x6f, 0x75,
+	0x6e, 0x64, 0x73, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x10, 0x6c, 0x61, 0x73, 0x74, 0x43,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x12, 0x33, 0x0a, 0x09, 0x64,
+	0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x65, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x61, 0x70, 0x69, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x09, 0x64, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x65, 0x73,
+	0x22, 0x1c, 0x0a, 0x1a, 0x47, 0x65, 0x74, 0x4e, 0x65, 0x78, 0x74, 0x45, 0x70, 0x6f, 0x63, 0x68,
+	0x50, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x65,
+	0x0a, 0x0d, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x6f, 0x74,
+	0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x6f, 0x74, 0x65, 0x73, 0x12,
+	0x24, 0x0a, 0x0d, 0x72, 0x65, 0x77, 0x61, 0x72, 0x64, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x72, 0x65, 0x77, 0x61, 0x72, 0x64, 0x41, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x43, 0x0a, 0x11, 0x50, 0x72, 0x6f, 0x62, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xd5, 0x02, 0x0a, 0x1b, 0x47,
+	0x65, 0x74, 0x4e, 0x65, 0x78, 0x74, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x50, 0x72, 0x65, 0x76, 0x69,
+	0x65, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70,
+	0x6f, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68,
+	0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72,
+	0x6f, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x70,
+	0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x32, 0x0a, 0x09, 0x64, 0x65, 0x6c, 0x65,
+	0x67, 0x61, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x61, 0x70,
+	0x69, 0x70, 0x62, 0x2e, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74,
+	0x65, 0x52, 0x09, 0x64, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x65, 0x73, 0x12, 0x3e, 0x0a, 0x0d,
+	0x70, 0x72, 0x6f, 0x62, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x18, 0x06, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x61, 0x70, 0x69, 0x70, 0x62, 0x2e, 0x50, 0x72, 0x6f, 0x62,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x65, 0x52, 0x0d, 0x70,
+	0x72, 0x6f, 0x62, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x36, 0x0a, 0x16,
+	0x70, 0x72, 0x6f, 0x62, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x74, 0x65, 0x6e, 0x73, 0x69,
+	0x74, 0x79, 0x52, 0x61, 0x74, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x16, 0x70, 0x72,
+	0x6f, 0x62, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x79,
+	0x52, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72,
+	0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65,
+	0x72, 0x73, 0x22, 0x11, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x46, 0x6f, 0x72, 0x6b, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xb8, 0x02, 0x0a, 0x04, 0x46, 0x6f, 0x72, 0x6b, 0x12, 0x16,
+	0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06,
+	0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x65, 0x70, 0x74, 0x68, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x64, 0x65, 0x70, 0x74, 0x68, 0x12, 0x12, 0x0a, 0x04,
+	0x72, 0x6f, 0x6f, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x6f, 0x6f, 0x74,
+	0x12, 0x10, 0x0a, 0x03, 0x74, 0x69, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74,
+	0x69, 0x70, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x70, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x74, 0x69, 0x70, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x65, 0x72, 0x73, 0x18, 0x06, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x65, 0x72, 0x73, 0x12, 0x14,
+	0x0a, 0x05, 0x70, 0x65, 0x65, 0x72, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x70,
+	0x65, 0x65, 0x72, 0x73, 0x12, 0x38, 0x0a, 0x09, 0x66, 0x69, 0x72, 0x73, 0x74, 0x53, 0x65, 0x65,
+	0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x09, 0x66, 0x69, 0x72, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x12, 0x36,
+	0x0a, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x6c, 0x61,
+	0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x6c, 0x65, 0x72, 0x74, 0x65,
+	0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x61, 0x6c, 0x65, 0x72, 0x74, 0x65, 0x64,
+	0x22, 0x35, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x46, 0x6f, 0x72, 0x6b, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a, 0x05, 0x66, 0x6f, 0x72, 0x6b, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x61, 0x70, 0x69, 0x70, 0x62, 0x2e, 0x46, 0x6f, 0x72, 0x6b,
+	0x52, 0x05, 0x66, 0x6f, 0x72, 0x6b, 0x73, 0x22, 0x16, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x48, 0x65,
+	0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22,
+	0x8f, 0x03, 0x0a, 0x09, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x12, 0x18, 0x0a,
+	0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x65, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x70, 0x65, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x43, 0x0a,
+	0x0f, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x0f, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x4c, 0x61, 0x74, 0x65, 0x6e,
+	0x63, 0x79, 0x12, 0x3f, 0x0a, 0x0d, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x4c, 0x61, 0x74, 0x65,
+	0x6e, 0x63, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x4c, 0x61, 0x74, 0x65,
+	0x6e, 0x63, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x76, 0x69, 0x65, 0x77, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x76, 0x69, 0x65, 0x77, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x6c, 0x61, 0x73, 0x74, 0x43, 0x6f, 0x6d,
+	0x6d, 0x69, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x10, 0x6c, 0x61, 0x73, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64,
+	0x73, 0x22, 0x49, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61,
+	0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a, 0x0a, 0x68, 0x65,
+	0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x61, 0x70, 0x69, 0x70, 0x62, 0x2e, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74,
+	0x52, 0x0a, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x73, 0x32, 0xdc, 0x02, 0x0a,
+	0x10, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x12, 0x5b, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75,
+	0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x20, 0x2e, 0x61, 0x70, 0x69, 0x70, 0x62, 0x2e,
+	0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x61, 0x70, 0x69, 0x70,
+	0x62, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5e,
+	0x0a, 0x13, 0x47, 0x65, 0x74, 0x4e, 0x65, 0x78, 0x74, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x50, 0x72,
+	0x65, 0x76, 0x69, 0x65, 0x77, 0x12, 0x21, 0x2e, 0x61, 0x70, 0x69, 0x70, 0x62, 0x2e, 0x47, 0x65,
+	0x74, 0x4e, 0x65, 0x78, 0x74, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x50, 0x72, 0x65, 0x76, 0x69, 0x65,
+	0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x61, 0x70, 0x69, 0x70, 0x62,
+	0x2e, 0x47, 0x65, 0x74, 0x4e, 0x65, 0x78, 0x74, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x50, 0x72, 0x65,
+	0x76, 0x69, 0x65, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3d,
+	0x0a, 0x08, 0x47, 0x65, 0x74, 0x46, 0x6f, 0x72, 0x6b, 0x73, 0x12, 0x16, 0x2e, 0x61, 0x70, 0x69,
+	0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x46, 0x6f, 0x72, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x17, 0x2e, 0x61, 0x70, 0x69, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x46, 0x6f,
+	0x72, 0x6b, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4c, 0x0a,
+	0x0d, 0x47, 0x65, 0x74, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x73, 0x12, 0x1b,
+	0x2e, 0x61, 0x70, 0x69, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62,
+	0x65, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x61, 0x70,
+	0x69, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x31, 0x5a, 0x2f, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x69, 0x6f, 0x74, 0x65, 0x78, 0x70,
+	0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x2f, 0x69, 0x6f, 0x74, 0x65, 0x78, 0x2d, 0x63, 0x6f, 0x72,
+	0x65, 0x2f, 0x76, 0x32, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x70, 0x69, 0x70, 0x62, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+})
+
+var (
+	file_api_apipb_consensus_proto_rawDescOnce sync.Once
+	file_api_apipb_consensus_proto_rawDescData []byte
+)
+
+func file_api_apipb_consensus_proto_rawDescGZIP() []byte {
+	file_api_apipb_consensus_proto_rawDescOnce.Do(func() {
+		file_api_apipb_consensus_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_apipb_consensus_proto_rawDesc), len(file_api_apipb_consensus_proto_rawDesc)))
+	})
+	return file_api_apipb_consensus_proto_rawDescData
+}
+
+var file_api_apipb_consensus_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_api_apipb_consensus_proto_goTypes = []any{
+	(*GetConsensusStatusRequest)(nil),   // 0: apipb.GetConsensusStatusRequest
+	(*DelegateStatus)(nil),              // 1: apipb.DelegateStatus
+	(*GetConsensusStatusResponse)(nil),  // 2: apipb.GetConsensusStatusResponse
+	(*GetNextEpochPreviewRequest)(nil),  // 3: apipb.GetNextEpochPreviewRequest
+	(*EpochDelegate)(nil),               // 4: apipb.EpochDelegate
+	(*ProbationDelegate)(nil),           // 5: apipb.ProbationDelegate
+	(*GetNextEpochPreviewResponse)(nil), // 6: apipb.GetNextEpochPreviewResponse
+	(*GetForksRequest)(nil),             // 7: apipb.GetForksRequest
+	(*Fork)(nil),                        // 8: apipb.Fork
+	(*GetForksResponse)(nil),            // 9: apipb.GetForksResponse
+	(*GetHeartbeatsRequest)(nil),        // 10: apipb.GetHeartbeatsRequest
+	(*Heartbeat)(nil),                   // 11: apipb.Heartbeat
+	(*GetHeartbeatsResponse)(nil),       // 12: apipb.GetHeartbeatsResponse
+	(*timestamppb.Timestamp)(nil),       // 13: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),         // 14: google.protobuf.Duration
+}
+var file_api_apipb_consensus_proto_depIdxs = []int32{
+	13, // 0: apipb.GetConsensusStatusResponse.roundStartTime:type_name -> google.protobuf.Timestamp
+	14, // 1: apipb.GetConsensusStatusResponse.proposalReceiptTime:type_name -> google.protobuf.Duration
+	14, // 2: apipb.GetConsensusStatusResponse.proposalQuorumTime:type_name -> google.protobuf.Duration
+	14, // 3: apipb.GetConsensusStatusResponse.lockQuorumTime:type_name -> google.protobuf.Duration
+	14, // 4: apipb.GetConsensusStatusResponse.commitQuorumTime:type_name -> google.protobuf.Duration
+	1,  // 5: apipb.GetConsensusStatusResponse.delegates:type_name -> apipb.DelegateStatus
+	4,  // 6: apipb.GetNextEpochPreviewResponse.delegates:type_name -> apipb.EpochDelegate
+	5,  // 7: apipb.GetNextEpochPreviewResponse.probationList:type_name -> apipb.ProbationDelegate
+	13, // 8: apipb.Fork.firstSeen:type_name -> google.protobuf.Timestamp
+	13, // 9: apipb.Fork.lastSeen:type_name -> google.protobuf.Timestamp
+	8,  // 10: apipb.GetForksResponse.forks:type_name -> apipb.Fork
+	13, // 11: apipb.Heartbeat.timestamp:type_name -> google.protobuf.Timestamp
+	14, // 12: apipb.Heartbeat.proposalLatency:type_name -> google.protobuf.Duration
+	14, // 13: apipb.Heartbeat.commitLatency:type_name -> google.protobuf.Duration
+	11, // 14: apipb.GetHeartbeatsResponse.heartbeats:type_name -> apipb.Heartbeat
+	0,  // 15: apipb.ConsensusService.GetConsensusStatus:input_type -> apipb.GetConsensusStatusRequest
+	3,  // 16: apipb.ConsensusService.GetNextEpochPreview:input_type -> apipb.GetNextEpochPreviewRequest
+	7,  // 17: apipb.ConsensusService.GetForks:input_type -> apipb.GetForksRequest
+	10, // 18: apipb.ConsensusService.GetHeartbeats:input_type -> apipb.GetHeartbeatsRequest
+	2,  // 19: apipb.ConsensusService.GetConsensusStatus:output_type -> apipb.GetConsensusStatusResponse
+	6,  // 20: apipb.ConsensusService.GetNextEpochPreview:output_type -> apipb.GetNextEpochPreviewResponse
+	9,  // 21: apipb.ConsensusService.GetForks:output_type -> apipb.GetForksResponse
+	12, // 22: apipb.ConsensusService.GetHeartbeats:output_type -> apipb.GetHeartbeatsResponse
+	19, // [19:23] is the sub-list for method output_type
+	15, // [15:19] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
+}
+
+func init() { file_api_apipb_consensus_proto_init() }
+func file_api_apipb_consensus_proto_init() {
+	if File_api_apipb_consensus_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_apipb_consensus_proto_rawDesc), len(file_api_apipb_consensus_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_apipb_consensus_proto_goTypes,
+		DependencyIndexes: file_api_apipb_consensus_proto_depIdxs,
+		MessageInfos:      file_api_apipb_consensus_proto_msgTypes,
+	}.Build()
+	File_api_apipb_consensus_proto = out.File
+	file_api_apipb_consensus_proto_goTypes = nil
+	file_api_apipb_consensus_proto_depIdxs = nil
+}
@@ -0,0 +1,393 @@
+// Copyright (c) 2025 IoTeX
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+// To compile the proto, run:
+//      protoc --go_out=plugins=grpc:. *.proto
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.4
+// 	protoc        v3.20.1
+// source: api/apipb/sync.proto
+
+package apipb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SyncStage int32
+
+const (
+	SyncStage_SYNCED        SyncStage = 0
+	SyncStage_HEADER_SYNC   SyncStage = 1
+	SyncStage_BODY_DOWNLOAD SyncStage = 2
+	SyncStage_STATE_SYNC    SyncStage = 3
+	SyncStage_INDEXING      SyncStage = 4
+)
+
+// Enum value maps for SyncStage.
+var (
+	SyncStage_name = map[int32]string{
+		0: "SYNCED",
+		1: "HEADER_SYNC",
+		2: "BODY_DOWNLOAD",
+		3: "STATE_SYNC",
+		4: "INDEXING",
+	}
+	SyncStage_value = map[string]int32{
+		"SYNCED":        0,
+		"HEADER_SYNC":   1,
+		"BODY_DOWNLOAD": 2,
+		"STATE_SYNC":    3,
+		"INDEXING":      4,
+	}
+)
+
+func (x SyncStage) Enum() *SyncStage {
+	p := new(SyncStage)
+	*p = x
+	return p
+}
+
+func (x SyncStage) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SyncStage) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_apipb_sync_proto_enumTypes[0].Descriptor()
+}
+
+func (SyncStage) Type() protoreflect.EnumType {
+	return &file_api_apipb_sync_proto_enumTypes[0]
+}
+
+func (x SyncStage) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SyncStage.Descriptor instead.
+func (SyncStage) EnumDescriptor() ([]byte, []int) {
+	return file_api_apipb_sync_proto_rawDescGZIP(), []int{0}
+}
+
+type GetSyncStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSyncStatusRequest) Reset() {
+	*x = GetSyncStatusRequest{}
+	mi := &file_api_apipb_sync_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSyncStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSyncStatusRequest) ProtoMessage() {}
+
+func (x *GetSyncStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_apipb_sync_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSyncStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetSyncStatusRequest) Descriptor() ([]byte, []int) {
+	return file_api_apipb_sync_proto_rawDescGZIP(), []int{0}
+}
+
+type SyncStageStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stage         SyncStage              `protobuf:"varint,1,opt,name=stage,proto3,enum=apipb.SyncStage" json:"stage,omitempty"`
+	StartHeight   uint64                 `protobuf:"varint,2,opt,name=startHeight,proto3" json:"startHeight,omitempty"`
+	CurrentHeight uint64                 `protobuf:"varint,3,opt,name=currentHeight,proto3" json:"currentHeight,omitempty"`
+	TargetHeight  uint64                 `protobuf:"varint,4,opt,name=targetHeight,proto3" json:"targetHeight,omitempty"`
+	// percentage of the stage completed
+	Progress float64 `protobuf:"fixed64,5,opt,name=progress,proto3" json:"progress,omitempty"`
+	// number of the blocks processed per second in the recent window
+	Rate float64 `protobuf:"fixed64,6,opt,name=rate,proto3" json:"rate,omitempty"`
+	// estimated time to complete the stage, empty if the stage is completed or the rate is unknown
+	Eta           *durationpb.Duration `protobuf:"bytes,7,opt,name=eta,proto3" json:"eta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncStageStatus) Reset() {
+	*x = SyncStageStatus{}
+	mi := &file_api_apipb_sync_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncStageStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncStageStatus) ProtoMessage() {}
+
+func (x *SyncStageStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_api_apipb_sync_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncStageStatus.ProtoReflect.Descriptor instead.
+func (*SyncStageStatus) Descriptor() ([]byte, []int) {
+	return file_api_apipb_sync_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SyncStageStatus) GetStage() SyncStage {
+	if x != nil {
+		return x.Stage
+	}
+	return SyncStage_SYNCED
+}
+
+func (x *SyncStageStatus) GetStartHeight() uint64 {
+	if x != nil {
+		return x.StartHeight
+	}
+	return 0
+}
+
+func (x *SyncStageStatus) GetCurrentHeight() uint64 {
+	if x != nil {
+		return x.CurrentHeight
+	}
+	return 0
+}
+
+func (x *SyncStageStatus) GetTargetHeight() uint64 {
+	if x != nil {
+		return x.TargetHeight
+	}
+	return 0
+}
+
+func (x *SyncStageStatus) GetProgress() float64 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+func (x *SyncStageStatus) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *SyncStageStatus) GetEta() *durationpb.Duration {
+	if x != nil {
+		return x.Eta
+	}
+	return nil
+}
+
+type GetSyncStatusResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// earliest stage not completed, SYNCED if all the stages are completed
+	Stage SyncStage `protobuf:"varint,1,opt,name=stage,proto3,enum=apipb.SyncStage" json:"stage,omitempty"`
+	// progress of the stages in order
+	Stages []*SyncStageStatus `protobuf:"bytes,2,rep,name=stages,proto3" json:"stages,omitempty"`
+	// estimated time to complete all the stages, empty if synced or unknown
+	Eta           *durationpb.Duration `protobuf:"bytes,3,opt,name=eta,proto3" json:"eta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSyncStatusResponse) Reset() {
+	*x = GetSyncStatusResponse{}
+	mi := &file_api_apipb_sync_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSyncStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSyncStatusResponse) ProtoMessage() {}
+
+func (x *GetSyncStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_apipb_sync_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSyncStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetSyncStatusResponse) Descriptor() ([]byte, []int) {
+	return file_api_apipb_sync_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetSyncStatusResponse) GetStage() SyncStage {
+	if x != nil {
+		return x.Stage
+	}
+	return SyncStage_SYNCED
+}
+
+func (x *GetSyncStatusResponse) GetStages() []*SyncStageStatus {
+	if x != nil {
+		return x.Stages
+	}
+	return nil
+}
+
+func (x *GetSyncStatusResponse) GetEta() *durationpb.Duration {
+	if x != nil {
+		return x.Eta
+	}
+	return nil
+}
+
+var File_api_apipb_sync_proto protoreflect.FileDescriptor
+
+var file_api_apipb_sync_proto_rawDesc = string([]byte{
+	0x0a, 0x14, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x70, 0x69, 0x70, 0x62, 0x2f, 0x73, 0x79, 0x6e, 0x63,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x61, 0x70, 0x69, 0x70, 0x62, 0x1a, 0x1e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x16, 0x0a,
+	0x14, 0x47, 0x65, 0x74, 0x53, 0x79, 0x6e, 0x63, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x82, 0x02, 0x0a, 0x0f, 0x53, 0x79, 0x6e, 0x63, 0x53, 0x74,
+	0x61, 0x67, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x26, 0x0a, 0x05, 0x73, 0x74, 0x61,
+	0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x61, 0x70, 0x69, 0x70, 0x62,
+	0x2e, 0x53, 0x79, 0x6e, 0x63, 0x53, 0x74, 0x61, 0x67, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x67,
+	0x65, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x72, 0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x73, 0x74, 0x61, 0x72, 0x74, 0x48, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x48, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x63, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x74, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0c, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1a, 0x0a,
+	0x08, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x08, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x74,
+	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x72, 0x61, 0x74, 0x65, 0x12, 0x2b, 0x0a,
+	0x03, 0x65, 0x74, 0x61, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x03, 0x65, 0x74, 0x61, 0x22, 0x9c, 0x01, 0x0a, 0x15, 0x47,
+	0x65, 0x74, 0x53, 0x79, 0x6e, 0x63, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x61, 0x70, 0x69, 0x70, 0x62, 0x2e, 0x53, 0x79, 0x6e, 0x63,
+	0x53, 0x74, 0x61, 0x67, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x12, 0x2e, 0x0a, 0x06,
+	0x73, 0x74, 0x61, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x61,
+	0x70, 0x69, 0x70, 0x62, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x53, 0x74, 0x61, 0x67, 0x65, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x67, 0x65, 0x73, 0x12, 0x2b, 0x0a, 0x03,
+	0x65, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x03, 0x65, 0x74, 0x61, 0x2a, 0x59, 0x0a, 0x09, 0x53, 0x79, 0x6e,
+	0x63, 0x53, 0x74, 0x61, 0x67, 0x65, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x59, 0x4e, 0x43, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x48, 0x45, 0x41, 0x44, 0x45, 0x52, 0x5f, 0x53, 0x59, 0x4e,
+	0x43, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d, 0x42, 0x4f, 0x44, 0x59, 0x5f, 0x44, 0x4f, 0x57, 0x4e,
+	0x4c, 0x4f, 0x41, 0x44, 0x10, 0x02, 0x12, 0x0e, 0x0a, 0x0a, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f,
+	0x53, 0x59, 0x4e, 0x43, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08, 0x49, 0x4e, 0x44, 0x45, 0x58, 0x49,
+	0x4e, 0x47, 0x10, 0x04, 0x32, 0x5b, 0x0a, 0x0b, 0x53, 0x79, 0x6e, 0x63, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x12, 0x4c, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x53, 0x79, 0x6e, 0x63, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x1b, 0x2e, 0x61, 0x70, 0x69, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74,
+	0x53, 0x79, 0x6e, 0x63, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1c, 0x2e, 0x61, 0x70, 0x69, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x79, 0x6e,
+	0x63, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x69, 0x6f, 0x74, 0x65, 0x78, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x2f, 0x69, 0x6f, 0x74,
+	0x65, 0x78, 0x2d, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x76, 0x32, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61,
+	0x70, 0x69, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+})
+
+var (
+	file_api_apipb_sync_proto_rawDescOnce sync.Once
+	file_api_apipb_sync_proto_rawDescData []byte
+)
+
+func file_api_apipb_sync_proto_rawDescGZIP() []byte {
+	file_api_apipb_sync_proto_rawDescOnce.Do(func() {
+		file_api_apipb_sync_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_apipb_sync_proto_rawDesc), len(file_api_apipb_sync_proto_rawDesc)))
+	})
+	return file_api_apipb_sync_proto_rawDescData
+}
+
+var file_api_apipb_sync_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_api_apipb_sync_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_api_apipb_sync_proto_goTypes = []any{
+	(SyncStage)(0),                // 0: apipb.SyncStage
+	(*GetSyncStatusRequest)(nil),  // 1: apipb.GetSyncStatusRequest
+	(*SyncStageStatus)(nil),       // 2: apipb.SyncStageStatus
+	(*GetSyncStatusResponse)(nil), // 3: apipb.GetSyncStatusResponse
+	(*durationpb.Duration)(nil),   // 4: google.protobuf.Duration
+}
+var file_api_apipb_sync_proto_depIdxs = []int32{
+	0, // 0: apipb.SyncStageStatus.stage:type_name -> apipb.SyncStage
+	4, // 1: apipb.SyncStageStatus.eta:type_name -> google.protobuf.Duration
+	0, // 2: apipb.GetSyncStatusResponse.stage:type_name -> apipb.SyncStage
+	2, // 3: apipb.GetSyncStatusResponse.stages:type_name -> apipb.SyncStageStatus
+	4, // 4: apipb.GetSyncStatusResponse.eta:type_name -> google.protobuf.Duration
+	1, // 5: apipb.SyncService.GetSyncStatus:input_type -> apipb.GetSyncStatusRequest
+	3, // 6: apipb.SyncService.GetSyncStatus:output_type -> apipb.GetSyncStatusResponse
+	6, // [6:7] is the sub-list for method output_type
+	5, // [5:6] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_api_apipb_sync_proto_init() }
+func file_api_apipb_sync_proto_init() {
+	if File_api_apipb_sync_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_apipb_sync_proto_rawDesc), len(file_api_apipb_sync_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_apipb_sync_proto_goTypes,
+		DependencyIndexes: file_api_apipb_sync_proto_depIdxs,
+		EnumInfos:         file_api_apipb_sync_proto_enumTypes,
+		MessageInfos:      file_api_apipb_sync_proto_msgTypes,
+	}.Build()
+	File_api_apipb_sync_proto = out.File
+	file_api_apipb_sync_proto_goTypes = nil
+	file_api_apipb_sync_proto_depIdxs = nil
+}
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"context"
+
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// _blockStreamWindow is the number of blocks read ahead of a slow client in GetBlockStream
+const _blockStreamWindow = 16
+
+type (
+	// BlockStreamServer is the server API of the block stream service
+	BlockStreamServer interface {
+		// GetBlockStream streams the blocks of [StartHeight, StartHeight+Count), or up to the tip if Count is 0
+		GetBlockStream(*iotexapi.GetRawBlocksRequest, BlockStreamSender) error
+	}
+
+	// BlockStreamSender is the server side of a block stream
+	BlockStreamSender interface {
+		Send(*iotexapi.BlockInfo) error
+		grpc.ServerStream
+	}
+
+	// BlockStreamReceiver is the client side of a block stream
+	BlockStreamReceiver interface {
+		Recv() (*iotexapi.BlockInfo, error)
+		grpc.ClientStream
+	}
+
+	blockStreamSender struct {
+		grpc.ServerStream
+	}
+
+	blockStreamReceiver struct {
+		grpc.ClientStream
+	}
+)
+
+// _blockStreamServiceDesc is registered alongside iotexapi.APIService, it has no proto definition
+// so the service is listed but not described by reflection
+var _blockStreamServiceDesc = grpc.ServiceDesc{
+	ServiceName: "iotexapi.BlockStreamService",
+	HandlerType: (*BlockStreamServer)(nil),
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetBlockStream",
+			Handler:       getBlockStreamHandler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api/blockstream.go",
+}
+
+func getBlockStreamHandler(srv interface{}, stream grpc.ServerStream) error {
+	in := new(iotexapi.GetRawBlocksRequest)
+	if err := stream.RecvMsg(in); err != nil {
+		return err
+	}
+	return srv.(BlockStreamServer).GetBlockStream(in, &blockStreamSender{stream})
+}
+
+func (s *blockStreamSender) Send(blk *iotexapi.BlockInfo) error {
+	return s.ServerStream.SendMsg(blk)
+}
+
+func (r *blockStreamReceiver) Recv() (*iotexapi.BlockInfo, error) {
+	blk := new(iotexapi.BlockInfo)
+	if err := r.ClientStream.RecvMsg(blk); err != nil {
+		return nil, err
+	}
+	return blk, nil
+}
+
+// GetBlockStream opens a block stream on the connection
+func GetBlockStream(ctx context.Context, cc grpc.ClientConnInterface, in *iotexapi.GetRawBlocksRequest, opts ...grpc.CallOption) (BlockStreamReceiver, error) {
+	stream, err := cc.NewStream(ctx, &_blockStreamServiceDesc.Streams[0], "/iotexapi.BlockStreamService/GetBlockStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return &blockStreamReceiver{stream}, nil
+}
+
+// GetBlockStream streams raw blocks, reading at most _blockStreamWindow blocks ahead of the client
+func (svr *gRPCHandler) GetBlockStream(in *iotexapi.GetRawBlocksRequest, stream BlockStreamSender) error {
+	tipHeight := svr.coreService.TipHeight()
+	if in.StartHeight > tipHeight {
+		return status.Error(codes.InvalidArgument, "start height should not exceed tip height")
+	}
+	endHeight := tipHeight
+	if in.Count > 0 && in.Count-1 < tipHeight-in.StartHeight {
+		endHeight = in.StartHeight + in.Count - 1
+	}
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	var (
+		blocks  = make(chan *iotexapi.BlockInfo, _blockStreamWindow)
+		errChan = make(chan error, 1)
+	)
+	go func() {
+		defer close(blocks)
+		for height := in.StartHeight; height <= endHeight; height++ {
+			blks, err := svr.coreService.RawBlocks(height, 1, in.WithReceipts, in.WithTransactionLogs)
+			if err != nil {
+				errChan <- err
+				return
+			}
+			select {
+			case blocks <- blks[0]:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	for blk := range blocks {
+		if err := stream.Send(blk); err != nil {
+			return status.Error(codes.Aborted, err.Error())
+		}
+	}
+	select {
+	case err := <-errChan:
+		return err
+	default:
+	}
+	if err := ctx.Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestGrpcServer_GetBlockStream(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := NewMockCoreService(ctrl)
+
+	lis := bufconn.Listen(1 << 20)
+	gSvr := grpc.NewServer()
+	gSvr.RegisterService(&_blockStreamServiceDesc, newGRPCHandler(core))
+	go gSvr.Serve(lis)
+	defer gSvr.Stop()
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(err)
+	defer conn.Close()
+
+	core.EXPECT().TipHeight().Return(uint64(100)).AnyTimes()
+	core.EXPECT().RawBlocks(gomock.Any(), uint64(1), true, false).DoAndReturn(
+		func(height, _ uint64, _, _ bool) ([]*iotexapi.BlockInfo, error) {
+			if height == 99 {
+				return nil, status.Error(codes.NotFound, "block not found")
+			}
+			return []*iotexapi.BlockInfo{{
+				Block: &iotextypes.Block{Header: &iotextypes.BlockHeader{Core: &iotextypes.BlockHeaderCore{Height: height}}},
+			}}, nil
+		}).AnyTimes()
+
+	recvAll := func(in *iotexapi.GetRawBlocksRequest) ([]uint64, error) {
+		stream, err := GetBlockStream(context.Background(), conn, in)
+		require.NoError(err)
+		var heights []uint64
+		for {
+			blk, err := stream.Recv()
+			if err == io.EOF {
+				return heights, nil
+			}
+			if err != nil {
+				return heights, err
+			}
+			heights = append(heights, blk.Block.Header.Core.Height)
+		}
+	}
+
+	t.Run("range", func(t *testing.T) {
+		heights, err := recvAll(&iotexapi.GetRawBlocksRequest{StartHeight: 10, Count: 3, WithReceipts: true})
+		require.NoError(err)
+		require.Equal([]uint64{10, 11, 12}, heights)
+	})
+	t.Run("up to tip", func(t *testing.T) {
+		heights, err := recvAll(&iotexapi.GetRawBlocksRequest{StartHeight: 100, WithReceipts: true})
+		require.NoError(err)
+		require.Equal([]uint64{100}, heights)
+		heights, err = recvAll(&iotexapi.GetRawBlocksRequest{StartHeight: 98, Count: 1000, WithReceipts: true})
+		require.Equal(codes.NotFound, status.Code(err))
+		require.Equal([]uint64{98}, heights)
+	})
+	t.Run("invalid start", func(t *testing.T) {
+		_, err := recvAll(&iotexapi.GetRawBlocksRequest{StartHeight: 101, WithReceipts: true})
+		require.Equal(codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("client cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		stream, err := GetBlockStream(ctx, conn, &iotexapi.GetRawBlocksRequest{StartHeight: 0, Count: 50, WithReceipts: true})
+		require.NoError(err)
+		blk, err := stream.Recv()
+		require.NoError(err)
+		require.Zero(blk.Block.Header.Core.Height)
+		cancel()
+		for err == nil {
+			_, err = stream.Recv()
+		}
+		// the remaining blocks may have been buffered by the client before cancel
+		require.True(err == io.EOF || status.Code(err) == codes.Canceled, err)
+	})
+}
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"context"
+	"encoding/hex"
+
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/actpool"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+// SendBundle submits the ordered actions to the local producer to include at the target height, 0 for any height.
+// Unlike SendAction, the actions are neither broadcast nor added to the pending actions
+func (core *coreService) SendBundle(ctx context.Context, acts []*iotextypes.Action, targetHeight uint64, allOrNothing bool) (string, error) {
+	bp, ok := core.ap.(actpool.BundlePool)
+	if !ok {
+		return "", status.Error(codes.Unimplemented, "actpool does not support bundle")
+	}
+	var (
+		g      = core.Genesis()
+		bundle = &actpool.Bundle{TargetHeight: targetHeight, AllOrNothing: allOrNothing}
+	)
+	for _, in := range acts {
+		selp, err := (&action.Deserializer{}).SetEvmNetworkID(core.EVMNetworkID()).ActionToSealedEnvelope(in)
+		if err != nil {
+			return "", status.Error(codes.InvalidArgument, err.Error())
+		}
+		if err := core.validateChainID(in.GetCore().GetChainID()); err != nil {
+			return "", err
+		}
+		if deployer := selp.SenderAddress(); !selp.Protected() && !g.IsDeployerWhitelisted(deployer) {
+			return "", status.Errorf(codes.InvalidArgument, "replay deployer %v not whitelisted", deployer.Hex())
+		}
+		bundle.Actions = append(bundle.Actions, selp)
+	}
+	h, err := bp.SubmitBundle(actpool.WithActionSource(WithAPIContext(ctx), actpool.ActionSourceAPI), bundle)
+	if err != nil {
+		switch errors.Cause(err) {
+		case actpool.ErrBundleDisabled:
+			return "", status.Error(codes.Unimplemented, err.Error())
+		case actpool.ErrTooManyBundles:
+			return "", status.Error(codes.ResourceExhausted, err.Error())
+		default:
+			return "", status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	log.L().Info("bundle is submitted by admin.", log.Hex("hash", h[:]),
+		zap.Int("actions", len(bundle.Actions)), zap.Uint64("targetHeight", targetHeight))
+	return hex.EncodeToString(h[:]), nil
+}
+
+// sendBundle takes the bundle of {"txs": [raw txs], "blockNumber": target height, "allOrNothing": bool}
+func (svr *web3Handler) sendBundle(ctx context.Context, in *gjson.Result) (interface{}, error) {
+	txs := in.Get("params.0.txs")
+	if !txs.IsArray() || len(txs.Array()) == 0 {
+		return nil, errInvalidFormat
+	}
+	acts := make([]*iotextypes.Action, 0, len(txs.Array()))
+	for _, tx := range txs.Array() {
+		req, err := svr.rawTxToAction(tx.String())
+		if err != nil {
+			return nil, err
+		}
+		acts = append(acts, req)
+	}
+	var targetHeight uint64
+	if bn := in.Get("params.0.blockNumber"); bn.Exists() {
+		var err error
+		if targetHeight, err = hexStringToNumber(bn.String()); err != nil {
+			return nil, errors.Wrapf(errUnkownType, "blockNumber: %s", bn.String())
+		}
+	}
+	h, err := svr.coreService.SendBundle(ctx, acts, targetHeight, in.Get("params.0.allOrNothing").Bool())
+	if err != nil {
+		return nil, err
+	}
+	return "0x" + h, nil
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/test/mock/mock_actpool"
+)
+
+func TestCoreService_SendBundle(t *testing.T) {
+	require := require.New(t)
+	core := &coreService{ap: mock_actpool.NewMockActPool(gomock.NewController(t))}
+	_, err := core.SendBundle(context.Background(), nil, 0, true)
+	require.Equal(codes.Unimplemented, status.Code(err))
+}
+
+func TestSendBundle(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	core := NewMockCoreService(ctrl)
+	web3svr := &web3Handler{coreService: core, batchRequestLimit: _defaultBatchRequestLimit}
+	core.EXPECT().Genesis().Return(genesis.TestDefault()).AnyTimes()
+	core.EXPECT().TipHeight().Return(uint64(0)).AnyTimes()
+	core.EXPECT().EVMNetworkID().Return(uint32(1)).AnyTimes()
+	core.EXPECT().ChainID().Return(uint32(1)).AnyTimes()
+	core.EXPECT().Account(gomock.Any()).Return(&iotextypes.AccountMeta{IsContract: true}, nil, nil).AnyTimes()
+	const rawTx = "f8600180830186a09412745fec82b585f239c01090882eb40702c32b04808025a0b0e1aab5b64d744ae01fc9f1c3e9919844a799e90c23129d611f7efe6aec8a29a0195e28d22d9b280e00d501ff63525bb76f5c87b8646c89d5d9c5485edcb1b498"
+
+	for _, v := range []string{
+		`{"params":[]}`,
+		`{"params":[{"txs":[]}]}`,
+		`{"params":[{"txs":["` + rawTx + `"],"blockNumber":"xyz"}]}`,
+	} {
+		in := gjson.Parse(v)
+		_, err := web3svr.sendBundle(context.Background(), &in)
+		require.Error(err)
+	}
+
+	core.EXPECT().SendBundle(gomock.Any(), gomock.Len(2), uint64(16), true).Return("1111", nil).Times(1)
+	in := gjson.Parse(`{"params":[{"txs":["` + rawTx + `","` + rawTx + `"],"blockNumber":"0x10","allOrNothing":true}]}`)
+	ret, err := web3svr.sendBundle(context.Background(), &in)
+	require.NoError(err)
+	require.Equal("0x1111", ret.(string))
+}
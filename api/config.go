@@ -8,6 +8,7 @@ package api
 import (
 	"time"
 
+	"github.com/iotexproject/iotex-core/v2/actpool/userop"
 	"github.com/iotexproject/iotex-core/v2/gasstation"
 	"github.com/iotexproject/iotex-core/v2/pkg/tracer"
 )
@@ -25,25 +26,90 @@ type Config struct {
 	Tracer          tracer.Config     `yaml:"tracer"`
 	// BatchRequestLimit is the maximum number of requests in a batch.
 	BatchRequestLimit int `yaml:"batchRequestLimit"`
+	// BatchGasLimit is the maximum total gas of the calls in a batch, 0 means no limit.
+	BatchGasLimit uint64 `yaml:"batchGasLimit"`
+	// BatchTimeout is the maximum time to handle a batch, 0 means no limit.
+	BatchTimeout time.Duration `yaml:"batchTimeout"`
 	// WebsocketRateLimit is the maximum number of messages per second per client.
 	WebsocketRateLimit int `yaml:"websocketRateLimit"`
 	// ListenerLimit is the maximum number of listeners.
 	ListenerLimit int `yaml:"listenerLimit"`
+	// WebsocketSubscriptionLimit is the maximum number of subscriptions per websocket connection, 0 means no limit.
+	WebsocketSubscriptionLimit int `yaml:"websocketSubscriptionLimit"`
+	// WebsocketSendQueueSize is the number of messages buffered for sending per websocket connection.
+	WebsocketSendQueueSize int `yaml:"websocketSendQueueSize"`
 	// ReadyDuration is the duration to wait for the server to be ready.
 	ReadyDuration time.Duration `yaml:"readyDuration"`
+	// ReadyMaxBlockLag is the maximum number of blocks the node or its indexers could fall behind to be ready.
+	ReadyMaxBlockLag uint64 `yaml:"readyMaxBlockLag"`
+	// ResponseCacheSize is the number of blocks and receipt lists of committed heights cached for api queries, 0 to disable
+	ResponseCacheSize int `yaml:"responseCacheSize"`
+	// ReadStateCacheSize is the number of read state results cached for the heights below the tip, and for the tip
+	// until the next block, 0 to disable
+	ReadStateCacheSize int `yaml:"readStateCacheSize"`
+	// TraceTimeout is the maximum amount of time to trace a single transaction, which is also the default timeout.
+	TraceTimeout time.Duration `yaml:"traceTimeout"`
+	// TraceResultSizeLimit is the maximum size in bytes of the result of tracing a single transaction, 0 means no limit.
+	TraceResultSizeLimit uint64 `yaml:"traceResultSizeLimit"`
+	// TraceBlockTimeout is the maximum amount of time to trace all transactions of a block.
+	TraceBlockTimeout time.Duration `yaml:"traceBlockTimeout"`
+	// TraceBlockResultSizeLimit is the maximum total size in bytes of the results of tracing all transactions of a
+	// block, 0 means no limit.
+	TraceBlockResultSizeLimit uint64 `yaml:"traceBlockResultSizeLimit"`
+	// LogsQueryBlockLimit is the maximum number of blocks scanned by a single eth_getLogs call, 0 means no limit.
+	LogsQueryBlockLimit uint64 `yaml:"logsQueryBlockLimit"`
+	// LogsQueryResultLimit is the maximum number of logs returned by a single eth_getLogs call, 0 means no limit.
+	LogsQueryResultLimit uint64 `yaml:"logsQueryResultLimit"`
+	// FilterTTL is the duration a filter of eth_newFilter is kept since it is last polled.
+	FilterTTL time.Duration `yaml:"filterTTL"`
+	// FilterLimit is the maximum number of filters kept in local cache, 0 means no limit.
+	FilterLimit int `yaml:"filterLimit"`
+	// Simulation is the concurrency, gas and time limits of the simulations of eth_call and eth_estimateGas.
+	Simulation SimulationConfig `yaml:"simulation"`
+	// RateLimit is the rate limiter of the web3 http endpoint.
+	RateLimit RateLimitConfig `yaml:"rateLimit"`
+	// CORS is the cross-origin resource sharing policy of the web3 http and websocket endpoints.
+	CORS CORSConfig `yaml:"cors"`
+	// TLS is the TLS of the grpc, web3 http and websocket endpoints.
+	TLS TLSConfig `yaml:"tls"`
+	// AdminToken authorizes the admin namespace of the web3 http endpoint with header "Authorization: Bearer <token>",
+	// which is disabled if empty. The admin grpc service is served only if TLS.ClientCAFile is set.
+	AdminToken string `yaml:"adminToken"`
+	// GasBreakdown adds the gas consumed by phase to the receipts of eth_getTransactionReceipt. The breakdown isn't
+	// stored with the receipts, so the execution of the receipts read from the db is reported net of the refund.
+	GasBreakdown bool `yaml:"gasBreakdown"`
+	// UserOpPool is the pool of the ERC-4337 user operations served to the bundlers.
+	UserOpPool userop.Config `yaml:"userOpPool"`
 }
 
 // DefaultConfig is the default config
 var DefaultConfig = Config{
-	UseRDS:             false,
-	GRPCPort:           14014,
-	HTTPPort:           15014,
-	WebSocketPort:      16014,
-	TpsWindow:          10,
-	GasStation:         gasstation.DefaultConfig,
-	RangeQueryLimit:    1000,
-	BatchRequestLimit:  _defaultBatchRequestLimit,
-	WebsocketRateLimit: 5,
-	ListenerLimit:      5000,
-	ReadyDuration:      time.Second * 30,
+	UseRDS:                     false,
+	GRPCPort:                   14014,
+	HTTPPort:                   15014,
+	WebSocketPort:              16014,
+	TpsWindow:                  10,
+	GasStation:                 gasstation.DefaultConfig,
+	RangeQueryLimit:            1000,
+	BatchRequestLimit:          _defaultBatchRequestLimit,
+	BatchGasLimit:              0,
+	BatchTimeout:               30 * time.Second,
+	WebsocketRateLimit:         5,
+	ListenerLimit:              5000,
+	WebsocketSubscriptionLimit: 100,
+	WebsocketSendQueueSize:     _defaultSendQueueSize,
+	ReadyDuration:              time.Second * 30,
+	ReadyMaxBlockLag:           5,
+	ResponseCacheSize:          1000,
+	ReadStateCacheSize:         10000,
+	TraceBlockResultSizeLimit:  64 << 20,
+	LogsQueryBlockLimit:        100000,
+	LogsQueryResultLimit:       10000,
+	FilterTTL:                  15 * time.Minute,
+	FilterLimit:                10000,
+	Simulation:                 DefaultSimulationConfig,
+	RateLimit:                  DefaultRateLimitConfig,
+	CORS:                       DefaultCORSConfig,
+	TLS:                        DefaultTLSConfig,
+	UserOpPool:                 userop.DefaultConfig,
 }
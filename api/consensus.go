@@ -0,0 +1,286 @@
+package api
+
+import (
+	"context"
+	"encoding/hex"
+	"sort"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol/poll"
+	"github.com/iotexproject/iotex-core/v2/api/apipb"
+	"github.com/iotexproject/iotex-core/v2/consensus/consensusfsm"
+	"github.com/iotexproject/iotex-core/v2/consensus/scheme"
+	"github.com/iotexproject/iotex-core/v2/forkmonitor"
+	"github.com/iotexproject/iotex-core/v2/nodeinfo"
+)
+
+type (
+	// Consensus reads the status of the consensus rounds, and adjusts the timeouts of them
+	Consensus interface {
+		Status() (scheme.ConsensusStatus, error)
+		Timeouts() (consensusfsm.Timeouts, error)
+		SetTimeouts(*consensusfsm.Timeouts) error
+	}
+
+	// ForkMonitor reads the forks seen from the peers
+	ForkMonitor interface {
+		Forks() []forkmonitor.Fork
+	}
+
+	// HeartbeatMonitor reads the heartbeats broadcast by the delegates
+	HeartbeatMonitor interface {
+		Heartbeats() []nodeinfo.Heartbeat
+	}
+
+	// consensusService serves the status of consensus
+	consensusService struct {
+		coreService CoreService
+	}
+)
+
+// WithConsensus is the option to return the consensus status through API
+func WithConsensus(cs Consensus) Option {
+	return func(svr *coreService) {
+		svr.consensus = cs
+	}
+}
+
+// WithForkMonitor is the option to return the forks seen from the peers through API
+func WithForkMonitor(fm ForkMonitor) Option {
+	return func(svr *coreService) {
+		svr.forkMonitor = fm
+	}
+}
+
+// WithHeartbeatMonitor is the option to return the heartbeats of the delegates through API
+func WithHeartbeatMonitor(hm HeartbeatMonitor) Option {
+	return func(svr *coreService) {
+		svr.heartbeats = hm
+	}
+}
+
+// ConsensusStatus returns the status of the consensus rounds
+func (core *coreService) ConsensusStatus() (*scheme.ConsensusStatus, error) {
+	if core.consensus == nil {
+		return nil, status.Error(codes.Unavailable, "consensus status is not supported")
+	}
+	cs, err := core.consensus.Status()
+	if err != nil {
+		return nil, consensusError(err)
+	}
+	return &cs, nil
+}
+
+// ConsensusTimeouts returns the timeouts of the current consensus round
+func (core *coreService) ConsensusTimeouts() (*consensusfsm.Timeouts, error) {
+	if core.consensus == nil {
+		return nil, status.Error(codes.Unavailable, "consensus timeouts are not supported")
+	}
+	t, err := core.consensus.Timeouts()
+	if err != nil {
+		return nil, consensusError(err)
+	}
+	return &t, nil
+}
+
+// SetConsensusTimeouts adjusts the timeouts of the consensus rounds, the configured ones are restored if t is nil
+func (core *coreService) SetConsensusTimeouts(t *consensusfsm.Timeouts) error {
+	if core.consensus == nil {
+		return status.Error(codes.Unavailable, "consensus timeouts are not supported")
+	}
+	if err := core.consensus.SetTimeouts(t); err != nil {
+		return consensusError(err)
+	}
+	return nil
+}
+
+// NextEpochPreview computes the delegates, the probation list of the next epoch ahead of the transition
+func (core *coreService) NextEpochPreview(ctx context.Context) (*poll.EpochPreview, error) {
+	previewer, ok := poll.FindProtocol(core.registry).(poll.NextEpochPreviewer)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "preview of the next epoch is not supported")
+	}
+	rc := core.newStateReadContext(ctx)
+	defer rc.close()
+	ctx, err := rc.context()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	preview, err := previewer.PreviewNextEpoch(ctx, core.sf)
+	if err != nil {
+		if errors.Cause(err) == poll.ErrPreviewNotSupported {
+			return nil, status.Error(codes.Unimplemented, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return preview, nil
+}
+
+// Forks returns the forks seen from the peers
+func (core *coreService) Forks() ([]forkmonitor.Fork, error) {
+	if core.forkMonitor == nil {
+		return nil, status.Error(codes.Unavailable, "fork monitor is not supported")
+	}
+	return core.forkMonitor.Forks(), nil
+}
+
+// Heartbeats returns the latest heartbeats of the delegates
+func (core *coreService) Heartbeats() ([]nodeinfo.Heartbeat, error) {
+	if core.heartbeats == nil {
+		return nil, status.Error(codes.Unavailable, "heartbeat is not supported")
+	}
+	return core.heartbeats.Heartbeats(), nil
+}
+
+func consensusError(err error) error {
+	switch errors.Cause(err) {
+	case scheme.ErrNotImplemented:
+		return status.Error(codes.Unimplemented, err.Error())
+	case consensusfsm.ErrInvalidTimeouts:
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+func newConsensusService(core CoreService) *consensusService {
+	return &consensusService{
+		coreService: core,
+	}
+}
+
+// GetConsensusStatus returns the status of the consensus rounds to diagnose the stalls
+func (svr *consensusService) GetConsensusStatus(context.Context, *apipb.GetConsensusStatusRequest) (*apipb.GetConsensusStatusResponse, error) {
+	cs, err := svr.coreService.ConsensusStatus()
+	if err != nil {
+		return nil, err
+	}
+	ret := &apipb.GetConsensusStatusResponse{
+		Height:           cs.Height,
+		Round:            cs.Round,
+		Epoch:            cs.Epoch,
+		State:            cs.State,
+		Proposer:         cs.Proposer,
+		ViewChanges:      cs.ViewChanges,
+		LastCommitHeight: cs.LastCommitHeight,
+		LastCommitRounds: cs.LastCommitRounds,
+	}
+	if !cs.RoundStartTime.IsZero() {
+		ret.RoundStartTime = timestamppb.New(cs.RoundStartTime)
+	}
+	if cs.ProposalReceived {
+		ret.ProposalReceiptTime = durationpb.New(cs.ProposalReceiptTime)
+	}
+	if d, ok := cs.QuorumTimes["proposal"]; ok {
+		ret.ProposalQuorumTime = durationpb.New(d)
+	}
+	if d, ok := cs.QuorumTimes["lock"]; ok {
+		ret.LockQuorumTime = durationpb.New(d)
+	}
+	if d, ok := cs.QuorumTimes["commit"]; ok {
+		ret.CommitQuorumTime = durationpb.New(d)
+	}
+	for addr, missed := range cs.MissedProposals {
+		ret.Delegates = append(ret.Delegates, &apipb.DelegateStatus{
+			Address:         addr,
+			MissedProposals: missed,
+		})
+	}
+	sort.Slice(ret.Delegates, func(i, j int) bool {
+		return ret.Delegates[i].Address < ret.Delegates[j].Address
+	})
+	return ret, nil
+}
+
+// GetNextEpochPreview computes the delegates of the next epoch ahead of the transition
+func (svr *consensusService) GetNextEpochPreview(ctx context.Context, _ *apipb.GetNextEpochPreviewRequest) (*apipb.GetNextEpochPreviewResponse, error) {
+	stateHeight := svr.coreService.TipHeight()
+	preview, err := svr.coreService.NextEpochPreview(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ret := &apipb.GetNextEpochPreviewResponse{
+		Epoch:       preview.EpochNum,
+		Height:      preview.Height,
+		StateHeight: stateHeight,
+		Projected:   preview.Projected,
+	}
+	for _, d := range preview.Delegates {
+		ret.Delegates = append(ret.Delegates, &apipb.EpochDelegate{
+			Address:       d.Address,
+			Votes:         d.Votes.String(),
+			RewardAddress: d.RewardAddress,
+		})
+	}
+	if pl := preview.ProbationList; pl != nil {
+		for addr, count := range pl.ProbationInfo {
+			ret.ProbationList = append(ret.ProbationList, &apipb.ProbationDelegate{
+				Address: addr,
+				Count:   count,
+			})
+		}
+		sort.Slice(ret.ProbationList, func(i, j int) bool {
+			return ret.ProbationList[i].Address < ret.ProbationList[j].Address
+		})
+		ret.ProbationIntensityRate = pl.IntensityRate
+	}
+	// the proposer of the first round at a height is the delegate at index height % len(delegates)
+	n := uint64(len(preview.Delegates))
+	for i := uint64(0); i < n; i++ {
+		ret.Proposers = append(ret.Proposers, preview.Delegates[(preview.Height+i)%n].Address)
+	}
+	return ret, nil
+}
+
+// GetForks returns the forks seen from the peers, from the latest to the earliest
+func (svr *consensusService) GetForks(context.Context, *apipb.GetForksRequest) (*apipb.GetForksResponse, error) {
+	forks, err := svr.coreService.Forks()
+	if err != nil {
+		return nil, err
+	}
+	ret := &apipb.GetForksResponse{}
+	for _, f := range forks {
+		ret.Forks = append(ret.Forks, &apipb.Fork{
+			Height:    f.Height,
+			Depth:     f.Depth,
+			Root:      hex.EncodeToString(f.Root[:]),
+			Tip:       hex.EncodeToString(f.Tip[:]),
+			TipHeight: f.TipHeight,
+			Producers: f.Producers,
+			Peers:     f.Peers,
+			FirstSeen: timestamppb.New(f.FirstSeen),
+			LastSeen:  timestamppb.New(f.LastSeen),
+			Alerted:   f.Alerted,
+		})
+	}
+	return ret, nil
+}
+
+// GetHeartbeats returns the latest heartbeats broadcast by the delegates, in the order of the addresses
+func (svr *consensusService) GetHeartbeats(context.Context, *apipb.GetHeartbeatsRequest) (*apipb.GetHeartbeatsResponse, error) {
+	heartbeats, err := svr.coreService.Heartbeats()
+	if err != nil {
+		return nil, err
+	}
+	ret := &apipb.GetHeartbeatsResponse{}
+	for _, hb := range heartbeats {
+		ret.Heartbeats = append(ret.Heartbeats, &apipb.Heartbeat{
+			Address:          hb.Address,
+			Version:          hb.Version,
+			Height:           hb.Height,
+			Timestamp:        timestamppb.New(hb.Timestamp),
+			Peer:             hb.PeerID,
+			Epoch:            hb.Epoch,
+			ProposalLatency:  durationpb.New(hb.ProposalLatency),
+			CommitLatency:    durationpb.New(hb.CommitLatency),
+			ViewChanges:      hb.ViewChanges,
+			LastCommitRounds: hb.LastCommitRounds,
+		})
+	}
+	return ret, nil
+}
@@ -0,0 +1,235 @@
+package api
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol/poll"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/vote"
+	"github.com/iotexproject/iotex-core/v2/api/apipb"
+	"github.com/iotexproject/iotex-core/v2/consensus/consensusfsm"
+	"github.com/iotexproject/iotex-core/v2/consensus/scheme"
+	"github.com/iotexproject/iotex-core/v2/forkmonitor"
+	"github.com/iotexproject/iotex-core/v2/nodeinfo"
+	"github.com/iotexproject/iotex-core/v2/state"
+	"github.com/iotexproject/iotex-core/v2/test/mock/mock_consensus"
+)
+
+func TestCoreService_ConsensusStatus(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	core := &coreService{}
+
+	_, err := core.ConsensusStatus()
+	require.Equal(codes.Unavailable, status.Code(err))
+
+	cs := mock_consensus.NewMockConsensus(ctrl)
+	WithConsensus(cs)(core)
+	cs.EXPECT().Status().Return(scheme.ConsensusStatus{}, errors.Wrap(scheme.ErrNotImplemented, "noop")).Times(1)
+	_, err = core.ConsensusStatus()
+	require.Equal(codes.Unimplemented, status.Code(err))
+	cs.EXPECT().Status().Return(scheme.ConsensusStatus{Height: 10}, nil).Times(1)
+	ret, err := core.ConsensusStatus()
+	require.NoError(err)
+	require.Equal(uint64(10), ret.Height)
+}
+
+func TestCoreService_ConsensusTimeouts(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	core := &coreService{}
+
+	_, err := core.ConsensusTimeouts()
+	require.Equal(codes.Unavailable, status.Code(err))
+	require.Equal(codes.Unavailable, status.Code(core.SetConsensusTimeouts(nil)))
+
+	cs := mock_consensus.NewMockConsensus(ctrl)
+	WithConsensus(cs)(core)
+	cs.EXPECT().Timeouts().Return(consensusfsm.Timeouts{CommitTTL: time.Second}, nil).Times(1)
+	ret, err := core.ConsensusTimeouts()
+	require.NoError(err)
+	require.Equal(time.Second, ret.CommitTTL)
+	cs.EXPECT().SetTimeouts(gomock.Any()).Return(errors.Wrap(consensusfsm.ErrInvalidTimeouts, "too long")).Times(1)
+	require.Equal(codes.InvalidArgument, status.Code(core.SetConsensusTimeouts(ret)))
+	cs.EXPECT().SetTimeouts(gomock.Any()).Return(errors.Wrap(scheme.ErrNotImplemented, "noop")).Times(1)
+	require.Equal(codes.Unimplemented, status.Code(core.SetConsensusTimeouts(ret)))
+}
+
+func TestConsensusService_GetConsensusStatus(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	core := NewMockCoreService(ctrl)
+	svr := newConsensusService(core)
+
+	core.EXPECT().ConsensusStatus().Return(nil, status.Error(codes.Unavailable, "")).Times(1)
+	_, err := svr.GetConsensusStatus(context.Background(), &apipb.GetConsensusStatusRequest{})
+	require.Equal(codes.Unavailable, status.Code(err))
+
+	start := time.Unix(1000, 0)
+	core.EXPECT().ConsensusStatus().Return(&scheme.ConsensusStatus{
+		Height:              10,
+		Round:               2,
+		Epoch:               1,
+		State:               "S_ACCEPT_LOCK_ENDORSEMENT",
+		Proposer:            "c",
+		RoundStartTime:      start,
+		ProposalReceived:    true,
+		ProposalReceiptTime: time.Second,
+		QuorumTimes:         map[string]time.Duration{"proposal": 2 * time.Second},
+		ViewChanges:         5,
+		LastCommitHeight:    9,
+		LastCommitRounds:    3,
+		MissedProposals:     map[string]uint64{"b": 1, "a": 2},
+	}, nil).Times(1)
+	ret, err := svr.GetConsensusStatus(context.Background(), &apipb.GetConsensusStatusRequest{})
+	require.NoError(err)
+	require.Equal(uint64(10), ret.Height)
+	require.Equal(uint32(2), ret.Round)
+	require.Equal("S_ACCEPT_LOCK_ENDORSEMENT", ret.State)
+	require.Equal(start, ret.RoundStartTime.AsTime().Local())
+	require.Equal(time.Second, ret.ProposalReceiptTime.AsDuration())
+	require.Equal(2*time.Second, ret.ProposalQuorumTime.AsDuration())
+	require.Nil(ret.LockQuorumTime)
+	require.Nil(ret.CommitQuorumTime)
+	require.Equal(uint64(5), ret.ViewChanges)
+	require.Equal(uint32(3), ret.LastCommitRounds)
+	require.Len(ret.Delegates, 2)
+	require.Equal("a", ret.Delegates[0].Address)
+	require.Equal(uint64(2), ret.Delegates[0].MissedProposals)
+}
+
+func TestConsensusService_GetNextEpochPreview(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	core := NewMockCoreService(ctrl)
+	svr := newConsensusService(core)
+
+	core.EXPECT().TipHeight().Return(uint64(700)).Times(2)
+	core.EXPECT().NextEpochPreview(gomock.Any()).Return(nil, status.Error(codes.Unimplemented, "")).Times(1)
+	_, err := svr.GetNextEpochPreview(context.Background(), &apipb.GetNextEpochPreviewRequest{})
+	require.Equal(codes.Unimplemented, status.Code(err))
+
+	core.EXPECT().NextEpochPreview(gomock.Any()).Return(&poll.EpochPreview{
+		EpochNum: 3,
+		Height:   721,
+		Delegates: state.CandidateList{
+			{Address: "a", Votes: big.NewInt(30), RewardAddress: "ra"},
+			{Address: "b", Votes: big.NewInt(20), RewardAddress: "rb"},
+			{Address: "c", Votes: big.NewInt(10), RewardAddress: "rc"},
+		},
+		ProbationList: &vote.ProbationList{
+			ProbationInfo: map[string]uint32{"e": 1, "d": 2},
+			IntensityRate: 90,
+		},
+		Projected: true,
+	}, nil).Times(1)
+	ret, err := svr.GetNextEpochPreview(context.Background(), &apipb.GetNextEpochPreviewRequest{})
+	require.NoError(err)
+	require.Equal(uint64(3), ret.Epoch)
+	require.Equal(uint64(721), ret.Height)
+	require.Equal(uint64(700), ret.StateHeight)
+	require.True(ret.Projected)
+	require.Len(ret.Delegates, 3)
+	require.Equal("30", ret.Delegates[0].Votes)
+	require.Equal("rb", ret.Delegates[1].RewardAddress)
+	require.Len(ret.ProbationList, 2)
+	require.Equal("d", ret.ProbationList[0].Address)
+	require.Equal(uint32(2), ret.ProbationList[0].Count)
+	require.Equal(uint32(90), ret.ProbationIntensityRate)
+	require.Equal([]string{"b", "c", "a"}, ret.Proposers)
+}
+
+func TestConsensusService_GetForks(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	core := NewMockCoreService(ctrl)
+	svr := newConsensusService(core)
+
+	core.EXPECT().Forks().Return(nil, status.Error(codes.Unavailable, "")).Times(1)
+	_, err := svr.GetForks(context.Background(), &apipb.GetForksRequest{})
+	require.Equal(codes.Unavailable, status.Code(err))
+
+	now := time.Now()
+	core.EXPECT().Forks().Return([]forkmonitor.Fork{
+		{
+			Height:    10,
+			Depth:     2,
+			Root:      hash.Hash256b([]byte("root")),
+			Tip:       hash.Hash256b([]byte("tip")),
+			TipHeight: 11,
+			Producers: []string{"a", "b"},
+			Peers:     []string{"p"},
+			FirstSeen: now,
+			LastSeen:  now.Add(time.Second),
+			Alerted:   true,
+		},
+	}, nil).Times(1)
+	ret, err := svr.GetForks(context.Background(), &apipb.GetForksRequest{})
+	require.NoError(err)
+	require.Len(ret.Forks, 1)
+	f := ret.Forks[0]
+	require.Equal(uint64(10), f.Height)
+	require.Equal(uint64(2), f.Depth)
+	root := hash.Hash256b([]byte("root"))
+	require.Equal(hex.EncodeToString(root[:]), f.Root)
+	require.Equal(uint64(11), f.TipHeight)
+	require.Equal([]string{"a", "b"}, f.Producers)
+	require.Equal([]string{"p"}, f.Peers)
+	require.Equal(now.Add(time.Second).UnixNano(), f.LastSeen.AsTime().UnixNano())
+	require.True(f.Alerted)
+}
+
+func TestConsensusService_GetHeartbeats(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	core := NewMockCoreService(ctrl)
+	svr := newConsensusService(core)
+
+	core.EXPECT().Heartbeats().Return(nil, status.Error(codes.Unavailable, "")).Times(1)
+	_, err := svr.GetHeartbeats(context.Background(), &apipb.GetHeartbeatsRequest{})
+	require.Equal(codes.Unavailable, status.Code(err))
+
+	now := time.Now()
+	core.EXPECT().Heartbeats().Return([]nodeinfo.Heartbeat{
+		{
+			Info: nodeinfo.Info{
+				Version:   "v2.2.0",
+				Height:    100,
+				Timestamp: now,
+				Address:   "a",
+				PeerID:    "p",
+			},
+			HeartbeatStats: nodeinfo.HeartbeatStats{
+				Epoch:            3,
+				ProposalLatency:  time.Second,
+				CommitLatency:    3 * time.Second,
+				ViewChanges:      1,
+				LastCommitRounds: 2,
+			},
+		},
+	}, nil).Times(1)
+	ret, err := svr.GetHeartbeats(context.Background(), &apipb.GetHeartbeatsRequest{})
+	require.NoError(err)
+	require.Len(ret.Heartbeats, 1)
+	hb := ret.Heartbeats[0]
+	require.Equal("a", hb.Address)
+	require.Equal("v2.2.0", hb.Version)
+	require.Equal(uint64(100), hb.Height)
+	require.Equal(now.UnixNano(), hb.Timestamp.AsTime().UnixNano())
+	require.Equal("p", hb.Peer)
+	require.Equal(uint64(3), hb.Epoch)
+	require.Equal(time.Second, hb.ProposalLatency.AsDuration())
+	require.Equal(3*time.Second, hb.CommitLatency.AsDuration())
+	require.Equal(uint64(1), hb.ViewChanges)
+	require.Equal(uint32(2), hb.LastCommitRounds)
+}
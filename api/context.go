@@ -3,23 +3,42 @@ package api
 import (
 	"context"
 	"sync"
+
+	"github.com/pkg/errors"
 )
 
+var errSubscriptionLimitReached = errors.New("subscription limit of the connection has been reached")
+
 type (
 	streamContextKey struct{}
 
 	StreamContext struct {
-		listenerIDs map[string]struct{}
-		mutex       sync.Mutex
+		listenerIDs   map[string]struct{}
+		listenerLimit int
+		mutex         sync.Mutex
 	}
 
+	// StreamContextOption sets the stream context
+	StreamContextOption func(*StreamContext)
+
 	apiContextKey struct{}
 )
 
-func (sc *StreamContext) AddListener(id string) {
+// WithListenerLimit limits the number of listeners of the stream, 0 means no limit
+func WithListenerLimit(limit int) StreamContextOption {
+	return func(sc *StreamContext) {
+		sc.listenerLimit = limit
+	}
+}
+
+func (sc *StreamContext) AddListener(id string) error {
 	sc.mutex.Lock()
 	defer sc.mutex.Unlock()
+	if sc.listenerLimit > 0 && len(sc.listenerIDs) >= sc.listenerLimit {
+		return errSubscriptionLimitReached
+	}
 	sc.listenerIDs[id] = struct{}{}
+	return nil
 }
 
 func (sc *StreamContext) RemoveListener(id string) {
@@ -38,10 +57,14 @@ func (sc *StreamContext) ListenerIDs() []string {
 	return ids
 }
 
-func WithStreamContext(ctx context.Context) context.Context {
-	return context.WithValue(ctx, streamContextKey{}, &StreamContext{
+func WithStreamContext(ctx context.Context, opts ...StreamContextOption) context.Context {
+	sc := &StreamContext{
 		listenerIDs: make(map[string]struct{}),
-	})
+	}
+	for _, opt := range opts {
+		opt(sc)
+	}
+	return context.WithValue(ctx, streamContextKey{}, sc)
 }
 
 func StreamFromContext(ctx context.Context) (*StreamContext, bool) {
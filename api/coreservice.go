@@ -9,9 +9,11 @@ import (
 	"bytes"
 	"context"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"math"
 	"math/big"
+	"reflect"
 	"strconv"
 	"time"
 
@@ -51,6 +53,7 @@ import (
 	"github.com/iotexproject/iotex-core/v2/action/protocol/rolldpos"
 	"github.com/iotexproject/iotex-core/v2/action/protocol/staking"
 	"github.com/iotexproject/iotex-core/v2/actpool"
+	"github.com/iotexproject/iotex-core/v2/actpool/userop"
 	logfilter "github.com/iotexproject/iotex-core/v2/api/logfilter"
 	apitypes "github.com/iotexproject/iotex-core/v2/api/types"
 	"github.com/iotexproject/iotex-core/v2/blockchain"
@@ -60,11 +63,16 @@ import (
 	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/v2/blockindex"
 	"github.com/iotexproject/iotex-core/v2/blocksync"
+	"github.com/iotexproject/iotex-core/v2/consensus/consensusfsm"
+	"github.com/iotexproject/iotex-core/v2/consensus/scheme"
 	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/forkmonitor"
 	"github.com/iotexproject/iotex-core/v2/gasstation"
+	"github.com/iotexproject/iotex-core/v2/nodeinfo"
+	"github.com/iotexproject/iotex-core/v2/p2p"
 	"github.com/iotexproject/iotex-core/v2/pkg/log"
+	"github.com/iotexproject/iotex-core/v2/pkg/routine"
 	"github.com/iotexproject/iotex-core/v2/pkg/tracer"
-	"github.com/iotexproject/iotex-core/v2/pkg/unit"
 	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
 	"github.com/iotexproject/iotex-core/v2/pkg/version"
 	"github.com/iotexproject/iotex-core/v2/server/itx/nodestats"
@@ -72,11 +80,19 @@ import (
 	"github.com/iotexproject/iotex-core/v2/state/factory"
 )
 
-const _workerNumbers int = 5
+const (
+	_workerNumbers int = 5
+	// _logsQueryBatchSize is the number of blocks to read logs from at a time in a cursor query
+	_logsQueryBatchSize = 100
+)
+
 const (
 	// defaultTraceTimeout is the amount of time a single transaction can execute
 	// by default before being forcefully aborted.
 	defaultTraceTimeout = 5 * time.Second
+	// defaultTraceBlockTimeout is the amount of time all transactions of a block can
+	// execute by default before the trace of the block is aborted.
+	defaultTraceBlockTimeout = 30 * time.Second
 )
 
 type (
@@ -91,14 +107,20 @@ type (
 		ServerMeta() (packageVersion string, packageCommitID string, gitStatus string, goVersion string, buildTime string)
 		// SendAction is the API to send an action to blockchain.
 		SendAction(ctx context.Context, in *iotextypes.Action) (string, error)
+		// SendActionDryRun validates the action as SendAction does, without adding it into the actpool
+		SendActionDryRun(ctx context.Context, in *iotextypes.Action) (string, error)
 		// ReadContract reads the state in a contract address specified by the slot
 		ReadContract(ctx context.Context, callerAddr address.Address, sc action.Envelope) (string, *iotextypes.Receipt, error)
 		// ReadState reads state on blockchain
 		ReadState(protocolID string, height string, methodName []byte, arguments [][]byte) (*iotexapi.ReadStateResponse, error)
+		// ReadStateBatch reads the states of the requests in a shared context
+		ReadStateBatch(ctx context.Context, reqs []*iotexapi.ReadStateRequest) ([]*iotexapi.ReadStateResponse, []error)
 		// SuggestGasPrice suggests gas price
 		SuggestGasPrice() (uint64, error)
 		// SuggestGasTipCap suggests gas tip cap
 		SuggestGasTipCap() (*big.Int, error)
+		// BlobBaseFee returns the blob base fee of the next block
+		BlobBaseFee() (*big.Int, error)
 		// FeeHistory returns the fee history
 		FeeHistory(ctx context.Context, blocks, lastBlock uint64, rewardPercentiles []float64) (uint64, [][]*big.Int, []*big.Int, []float64, []*big.Int, []float64, error)
 		// EstimateGasForAction estimates gas for action
@@ -129,6 +151,8 @@ type (
 		ActionsByAddress(addr address.Address, start uint64, count uint64) ([]*iotexapi.ActionInfo, error)
 		// ActionByActionHash returns action by action hash
 		ActionByActionHash(h hash.Hash256) (*action.SealedEnvelope, *block.Block, uint32, error)
+		// BlockByActionHash returns the action with the block containing it, its index in the block and its receipt
+		BlockByActionHash(h hash.Hash256) (*apitypes.ActionInBlock, error)
 		// PendingActionByActionHash returns action by action hash
 		PendingActionByActionHash(h hash.Hash256) (*action.SealedEnvelope, error)
 		// ActionsInActPool returns the all Transaction Identifiers in the actpool
@@ -151,6 +175,8 @@ type (
 		LogsInBlockByHash(filter *logfilter.LogFilter, blockHash hash.Hash256) ([]*action.Log, error)
 		// LogsInRange filter logs among [start, end] blocks
 		LogsInRange(filter *logfilter.LogFilter, start, end, paginationSize uint64) ([]*action.Log, []hash.Hash256, error)
+		// LogsInRangeWithCursor filter logs among [start, end] blocks within the query limits, and returns the cursor to resume from
+		LogsInRangeWithCursor(filter *logfilter.LogFilter, start, end uint64, cursor *LogsCursor) ([]*action.Log, []hash.Hash256, *LogsCursor, error)
 		// Genesis returns the genesis of the chain
 		Genesis() genesis.Genesis
 		// EVMNetworkID returns the network id of evm
@@ -165,6 +191,10 @@ type (
 		SimulateExecution(context.Context, address.Address, action.Envelope) ([]byte, *action.Receipt, error)
 		// SyncingProgress returns the syncing status of node
 		SyncingProgress() (uint64, uint64, uint64)
+		// SyncStatus returns the sync status of node and indexers
+		SyncStatus() (*SyncStatus, error)
+		// SyncProgress returns the progress of the sync broken down by the stages
+		SyncProgress() (*SyncProgress, error)
 		// TipHeight returns the tip of the chain
 		TipHeight() uint64
 		// PendingNonce returns the pending nonce of an account
@@ -175,6 +205,8 @@ type (
 		BlockHashByBlockHeight(blkHeight uint64) (hash.Hash256, error)
 		// TraceTransaction returns the trace result of a transaction
 		TraceTransaction(ctx context.Context, actHash string, config *tracers.TraceConfig) ([]byte, *action.Receipt, any, error)
+		// TraceBlock returns the trace results of the actions in the block at the height
+		TraceBlock(ctx context.Context, height uint64, config *tracers.TraceConfig) ([]*ActionTrace, error)
 		// TraceCall returns the trace result of a call
 		TraceCall(ctx context.Context,
 			callerAddr address.Address,
@@ -195,6 +227,70 @@ type (
 		BalanceAt(ctx context.Context, addr address.Address, height uint64) (string, error)
 		// PendingNonceAt returns the pending nonce of an account at a specific height
 		PendingNonceAt(ctx context.Context, addr address.Address, height uint64) (uint64, error)
+
+		// Admin methods
+		// AddPeer connects the peer of the multiaddress
+		AddPeer(ctx context.Context, addr string) error
+		// RemovePeer disconnects and blocks the peer
+		RemovePeer(id string) error
+		// PeerScores returns the scores of the peers misbehaved
+		PeerScores() ([]p2p.PeerScore, error)
+		// AddTrustedPeer adds the peer of the id or the multiaddress as a trusted peer exempt from scoring and bans
+		AddTrustedPeer(ctx context.Context, addr string) error
+		// SetMinGasPrice sets the minimal gas price of actions accepted by actpool
+		SetMinGasPrice(price *big.Int) error
+		// PauseChain pauses or resumes committing blocks to the chain
+		PauseChain(pause bool)
+		// ReloadConfig re-reads the config files and applies the changes of the dynamic settings
+		ReloadConfig() ([]string, error)
+		// ConsensusStatus returns the status of the consensus rounds
+		ConsensusStatus() (*scheme.ConsensusStatus, error)
+		// ConsensusTimeouts returns the timeouts of the current consensus round
+		ConsensusTimeouts() (*consensusfsm.Timeouts, error)
+		// SetConsensusTimeouts adjusts the timeouts of the consensus rounds at runtime
+		SetConsensusTimeouts(t *consensusfsm.Timeouts) error
+		// NextEpochPreview computes the delegates of the next epoch ahead of the transition
+		NextEpochPreview(ctx context.Context) (*poll.EpochPreview, error)
+		// Forks returns the forks seen from the peers
+		Forks() ([]forkmonitor.Fork, error)
+		// Heartbeats returns the latest heartbeats of the delegates
+		Heartbeats() ([]nodeinfo.Heartbeat, error)
+		// UserOpPool returns the pool of the ERC-4337 user operations, nil if the pool is not enabled
+		UserOpPool() *userop.Pool
+		// ProducerVersions aggregates the builds committed by the producers of the last count blocks
+		ProducerVersions(count uint64) ([]*ProducerVersion, error)
+		// EpochCandidateProof returns the candidate list snapshot of the epoch, with the merkle proofs against the root committed in the epoch start block
+		EpochCandidateProof(epochNum uint64) (*EpochCandidateProof, error)
+		// ActionProof returns the merkle proof of the action against the tx root in the header of the block including it
+		ActionProof(h hash.Hash256) (*ActionProof, error)
+		// ProbationParams returns the probation parameters effective at the tip and the ones scheduled on chain
+		ProbationParams() (*ProbationParams, error)
+		// ProbationHistory returns the probation status of the delegate in the count epochs from the start epoch
+		ProbationHistory(ctx context.Context, delegate string, startEpoch, count uint64) ([]*ProbationStatus, error)
+		// InclusionLatencies returns the latency distributions of the producers including the actions arrived at the actpool
+		InclusionLatencies() ([]*InclusionLatency, error)
+		// SystemContracts returns the system contracts registered in the ABI registry
+		SystemContracts() ([]*SystemContract, error)
+		// SystemContractABI returns the ABI of the system contract effective at the height, 0 for the tip
+		SystemContractABI(addr address.Address, height uint64) (*SystemContractABI, error)
+		// WorkingSetCache returns the working sets in the cache of the state factory
+		WorkingSetCache() ([]*WorkingSetCacheEntry, error)
+		// PinWorkingSet keeps the working set of the block in the cache until it is unpinned or the block height is committed
+		PinWorkingSet(blkHash hash.Hash256) error
+		// UnpinWorkingSet returns the working set of the block to the lru cache
+		UnpinWorkingSet(blkHash hash.Hash256) error
+		// PrewarmWorkingSet creates the working set of the next height ahead of validating or minting the block
+		PrewarmWorkingSet(ctx context.Context) error
+		// ActionResult returns whether the action succeeded, and the reason of the revert if it failed
+		ActionResult(h hash.Hash256) (*ActionResult, error)
+		// StateProof returns the merkle proofs of the account and its storage keys at the height, 0 for the tip
+		StateProof(addr address.Address, storageKeys []hash.Hash256, height uint64) (*factory.StateProof, error)
+		// EstimatePruning returns the space reclaimed by pruning the state history with each of the retentions
+		EstimatePruning(retentions []uint64) ([]*PruneEstimate, error)
+		// PendingBalance returns the balance of the account at the tip with the pending actions in the actpool applied
+		PendingBalance(ctx context.Context, addr address.Address) (string, error)
+		// SendBundle submits the ordered actions to the local producer, which are kept out of the gossip
+		SendBundle(ctx context.Context, acts []*iotextypes.Action, targetHeight uint64, allOrNothing bool) (string, error)
 	}
 
 	// coreService implements the CoreService interface
@@ -208,14 +304,25 @@ type (
 		ap                actpool.ActPool
 		gs                *gasstation.GasStation
 		broadcastHandler  BroadcastOutbound
+		peerManager       PeerManager
+		configReloader    ConfigReloader
+		consensus         Consensus
+		forkMonitor       ForkMonitor
+		heartbeats        HeartbeatMonitor
 		cfg               Config
 		archiveSupported  bool
 		registry          *protocol.Registry
 		chainListener     apitypes.Listener
 		electionCommittee committee.Committee
 		readCache         *ReadCache
+		respCache         *responseCache
+		readStateCache    *readStateCache
+		simLimiter        *simulationLimiter
+		userOpPool        *userop.Pool
 		actionRadio       *ActionRadio
 		apiStats          *nodestats.APILocalStats
+		syncTracker       *syncTracker
+		syncSampleTask    *routine.RecurringTask
 	}
 
 	// jobDesc provides a struct to get and store logs in core.LogsInRange
@@ -266,6 +373,7 @@ type intrinsicGasCalculator interface {
 var (
 	ErrNotFound            = errors.New("not found")
 	ErrArchiveNotSupported = errors.New("archive-mode not supported")
+	ErrTraceResultTooLarge = errors.New("trace result too large")
 )
 
 // newCoreService creates a api server that contains major blockchain components
@@ -281,7 +389,7 @@ func newCoreService(
 	registry *protocol.Registry,
 	opts ...Option,
 ) (CoreService, error) {
-	if cfg == (Config{}) {
+	if reflect.ValueOf(cfg).IsZero() {
 		log.L().Warn("API server is not configured.")
 		cfg = DefaultConfig
 	}
@@ -291,18 +399,22 @@ func newCoreService(
 	}
 
 	core := coreService{
-		bc:            chain,
-		bs:            bs,
-		sf:            sf,
-		dao:           dao,
-		indexer:       indexer,
-		bfIndexer:     bfIndexer,
-		ap:            actPool,
-		cfg:           cfg,
-		registry:      registry,
-		chainListener: NewChainListener(cfg.ListenerLimit),
-		gs:            gasstation.NewGasStation(chain, dao, cfg.GasStation),
-		readCache:     NewReadCache(),
+		bc:             chain,
+		bs:             bs,
+		sf:             sf,
+		dao:            dao,
+		indexer:        indexer,
+		bfIndexer:      bfIndexer,
+		ap:             actPool,
+		cfg:            cfg,
+		registry:       registry,
+		chainListener:  NewChainListener(cfg.ListenerLimit),
+		gs:             gasstation.NewGasStation(chain, dao, cfg.GasStation),
+		readCache:      NewReadCache(),
+		respCache:      newResponseCache(cfg.ResponseCacheSize),
+		readStateCache: newReadStateCache(cfg.ReadStateCacheSize),
+		simLimiter:     newSimulationLimiter(cfg.Simulation),
+		syncTracker:    newSyncTracker(_syncRateWindow),
 	}
 
 	for _, opt := range opts {
@@ -313,6 +425,17 @@ func newCoreService(
 		core.actionRadio = NewActionRadio(core.broadcastHandler, core.bc.ChainID(), WithMessageBatch())
 		actPool.AddSubscriber(core.actionRadio)
 	}
+	if actPool != nil {
+		actPool.AddSubscriber(&pendingActionNotifier{listener: core.chainListener})
+	}
+	core.syncSampleTask = routine.NewRecurringTask(core.sampleSyncStatus, _syncSampleInterval)
+	if cfg.UserOpPool.Enabled {
+		pool, err := userop.NewPool(cfg.UserOpPool, chain.EvmNetworkID(), core.simulateUserOp)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create user operation pool")
+		}
+		core.userOpPool = pool
+	}
 
 	return &core, nil
 }
@@ -321,6 +444,20 @@ func (core *coreService) WithHeight(height uint64) CoreServiceReaderWithHeight {
 	return newCoreServiceWithHeight(core, height)
 }
 
+// checkStateHeight returns error if the state at the height is not available, 0 means the latest state
+func (core *coreService) checkStateHeight(height uint64) error {
+	tipHeight := core.bc.TipHeight()
+	switch {
+	case height == 0 || height == tipHeight:
+		return nil
+	case height > tipHeight:
+		return status.Errorf(codes.NotFound, "height %d is higher than tip height %d", height, tipHeight)
+	case !core.archiveSupported:
+		return errors.Wrapf(ErrArchiveNotSupported, "state at height %d is not available, the earliest available height is %d", height, tipHeight)
+	}
+	return nil
+}
+
 // Account returns the metadata of an account
 func (core *coreService) Account(addr address.Address) (*iotextypes.AccountMeta, *iotextypes.BlockIdentifier, error) {
 	ctx, span := tracer.NewSpan(context.Background(), "coreService.Account")
@@ -346,6 +483,9 @@ func (core *coreService) Account(addr address.Address) (*iotextypes.AccountMeta,
 func (core *coreService) BalanceAt(ctx context.Context, addr address.Address, height uint64) (string, error) {
 	ctx, span := tracer.NewSpan(context.Background(), "coreService.BalanceAt")
 	defer span.End()
+	if err := core.checkStateHeight(height); err != nil {
+		return "", err
+	}
 	addrStr := addr.String()
 	ctx, err := core.bc.ContextAtHeight(ctx, height)
 	if err != nil {
@@ -525,7 +665,7 @@ func (core *coreService) SendAction(ctx context.Context, in *iotextypes.Action)
 		return "", err
 	}
 	l := log.T(ctx).Logger().With(zap.String("actionHash", hex.EncodeToString(hash[:])))
-	ctx = WithAPIContext(ctx)
+	ctx = actpool.WithActionSource(WithAPIContext(ctx), actpool.ActionSourceAPI)
 	if err = core.ap.Add(ctx, selp); err != nil {
 		txBytes, serErr := proto.Marshal(in)
 		if serErr != nil {
@@ -533,7 +673,12 @@ func (core *coreService) SendAction(ctx context.Context, in *iotextypes.Action)
 		} else {
 			l.With(zap.String("txBytes", hex.EncodeToString(txBytes))).Debug("Failed to accept action", zap.Error(err))
 		}
-		st := status.New(codes.Internal, err.Error())
+		code := codes.Internal
+		if errors.Is(err, actpool.ErrPaused) {
+			// the node rejects the action temporarily, e.g., the disk is running out of space
+			code = codes.Unavailable
+		}
+		st := status.New(code, err.Error())
 		br := &errdetails.BadRequest{
 			FieldViolations: []*errdetails.BadRequest_FieldViolation{
 				{
@@ -559,6 +704,9 @@ func (core *coreService) PendingNonceAt(ctx context.Context, addr address.Addres
 	if height == 0 {
 		return core.ap.GetPendingNonce(addr.String())
 	}
+	if err := core.checkStateHeight(height); err != nil {
+		return 0, err
+	}
 	ctx, err := core.bc.ContextAtHeight(ctx, height)
 	if err != nil {
 		return 0, status.Error(codes.Internal, err.Error())
@@ -619,16 +767,23 @@ func (core *coreService) readContract(
 			return res.Data, res.Receipt, nil
 		}
 	}
+	gasCap := core.simulationGasCap(height)
+	if elp.Gas() == 0 || gasCap < elp.Gas() {
+		elp.SetGas(gasCap)
+	}
 	var (
-		g             = core.bc.Genesis()
-		blockGasLimit = g.BlockGasLimitByHeight(height)
+		retval  []byte
+		receipt *action.Receipt
 	)
-	if elp.Gas() == 0 || blockGasLimit < elp.Gas() {
-		elp.SetGas(blockGasLimit)
-	}
-	retval, receipt, err := core.simulateExecution(ctx, height, archive, callerAddr, elp)
-	if err != nil {
-		return "", nil, status.Error(codes.Internal, err.Error())
+	if err := core.simLimiter.run(ctx, func(ctx context.Context) error {
+		var err error
+		retval, receipt, err = core.simulateExecution(ctx, height, archive, callerAddr, elp)
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		return nil
+	}); err != nil {
+		return "", nil, err
 	}
 	res := iotexapi.ReadContractResponse{
 		Data:    hex.EncodeToString(retval),
@@ -642,11 +797,17 @@ func (core *coreService) readContract(
 
 // ReadState reads state on blockchain
 func (core *coreService) ReadState(protocolID string, height string, methodName []byte, arguments [][]byte) (*iotexapi.ReadStateResponse, error) {
+	rc := core.newStateReadContext(context.Background())
+	defer rc.close()
+	return core.readStateResponse(rc, protocolID, height, methodName, arguments)
+}
+
+func (core *coreService) readStateResponse(rc *stateReadContext, protocolID string, height string, methodName []byte, arguments [][]byte) (*iotexapi.ReadStateResponse, error) {
 	p, ok := core.registry.Find(protocolID)
 	if !ok {
 		return nil, status.Errorf(codes.Internal, "protocol %s isn't registered", protocolID)
 	}
-	data, readStateHeight, err := core.readState(context.Background(), p, height, methodName, arguments...)
+	data, readStateHeight, err := core.readStateIn(rc, p, height, methodName, arguments...)
 	if err != nil {
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
@@ -671,26 +832,31 @@ func (core *coreService) SuggestGasPrice() (uint64, error) {
 	return core.gs.SuggestGasPrice()
 }
 
+// SuggestGasTipCap suggests gas tip cap from the effective tips of recent blocks
 func (core *coreService) SuggestGasTipCap() (*big.Int, error) {
-	sp, err := core.SuggestGasPrice()
-	if err != nil {
-		return nil, err
-	}
-	price := big.NewInt(0).SetUint64(sp)
 	header, err := core.bc.BlockHeaderByHeight(core.bc.TipHeight())
 	if err != nil {
 		return nil, err
 	}
 	if header.BaseFee() == nil {
 		// eip-1559 is not enabled yet
-		return price, nil
+		sp, err := core.SuggestGasPrice()
+		if err != nil {
+			return nil, err
+		}
+		return big.NewInt(0).SetUint64(sp), nil
 	}
-	fee := big.NewInt(0).Sub(price, header.BaseFee())
-	minFee := big.NewInt(unit.Qev) // TODO: use a better value
-	if fee.Cmp(minFee) < 0 {
-		fee = minFee
+	return core.gs.SuggestGasTipCap(context.Background())
+}
+
+// BlobBaseFee returns the blob base fee of the next block, by the blob fee market parameters in genesis
+func (core *coreService) BlobBaseFee() (*big.Int, error) {
+	header, err := core.bc.BlockHeaderByHeight(core.bc.TipHeight())
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
 	}
-	return fee, nil
+	g, height := core.bc.Genesis().Blockchain, header.Height()+1
+	return protocol.CalcBlobFee(g, height, protocol.CalcExcessBlobGas(g, height, header.ExcessBlobGas(), header.BlobGasUsed())), nil
 }
 
 // FeeHistory returns the fee history
@@ -816,7 +982,7 @@ func (core *coreService) RawBlocks(startHeight uint64, count uint64, withReceipt
 		}
 		var receiptsPb []*iotextypes.Receipt
 		if withReceipts && height > 0 {
-			receipts, err := core.dao.GetReceipts(height)
+			receipts, err := core.blockReceipts(height)
 			if err != nil {
 				return nil, status.Error(codes.NotFound, err.Error())
 			}
@@ -879,7 +1045,7 @@ func (core *coreService) ReceiptByActionHash(h hash.Hash256) (*action.Receipt, e
 		return nil, errors.Wrap(ErrNotFound, err.Error())
 	}
 
-	receipts, err := core.dao.GetReceipts(actIndex.BlockHeight())
+	receipts, err := core.blockReceipts(actIndex.BlockHeight())
 	if err != nil {
 		return nil, err
 	}
@@ -978,11 +1144,17 @@ func (core *coreService) Start(_ context.Context) error {
 			return errors.Wrap(err, "failed to start action radio")
 		}
 	}
+	if err := core.syncSampleTask.Start(context.Background()); err != nil {
+		return errors.Wrap(err, "failed to start sync status sampling")
+	}
 	return nil
 }
 
 // Stop stops the API server
 func (core *coreService) Stop(_ context.Context) error {
+	if err := core.syncSampleTask.Stop(context.Background()); err != nil {
+		return errors.Wrap(err, "failed to stop sync status sampling")
+	}
 	if core.actionRadio != nil {
 		if err := core.actionRadio.Stop(); err != nil {
 			return errors.Wrap(err, "failed to stop action radio")
@@ -992,72 +1164,9 @@ func (core *coreService) Stop(_ context.Context) error {
 }
 
 func (core *coreService) readState(ctx context.Context, p protocol.Protocol, height string, methodName []byte, arguments ...[]byte) ([]byte, uint64, error) {
-	key := ReadKey{
-		Name:   p.Name(),
-		Height: height,
-		Method: methodName,
-		Args:   arguments,
-	}
-	tipHeight := core.bc.TipHeight()
-	if height == "" {
-		key.Height = strconv.FormatUint(tipHeight, 10)
-	}
-	if d, ok := core.readCache.Get(key.Hash()); ok {
-		h := tipHeight
-		if height != "" {
-			h, _ = strconv.ParseUint(height, 0, 64)
-		}
-		return d, h, nil
-	}
-
-	// TODO: need to complete the context
-	ctx, err := core.bc.Context(ctx)
-	if err != nil {
-		return nil, 0, err
-	}
-	ctx = protocol.WithBlockCtx(ctx, protocol.BlockCtx{
-		BlockHeight: tipHeight,
-	})
-	ctx = genesis.WithGenesisContext(
-		protocol.WithRegistry(ctx, core.registry),
-		core.bc.Genesis(),
-	)
-	ctx = protocol.WithFeatureCtx(protocol.WithFeatureWithHeightCtx(ctx))
-
-	if height != "" {
-		inputHeight, err := strconv.ParseUint(height, 0, 64)
-		if err != nil {
-			return nil, 0, err
-		}
-		rp := rolldpos.FindProtocol(core.registry)
-		if rp != nil {
-			tipEpochNum := rp.GetEpochNum(tipHeight)
-			inputEpochNum := rp.GetEpochNum(inputHeight)
-			if inputEpochNum < tipEpochNum {
-				inputHeight = rp.GetEpochHeight(inputEpochNum)
-			}
-		}
-		if inputHeight < tipHeight {
-			// old data, wrap to history state reader
-			historySR, err := core.sf.WorkingSetAtHeight(ctx, inputHeight)
-			if err != nil {
-				return nil, 0, err
-			}
-			d, h, err := p.ReadState(ctx, historySR, methodName, arguments...)
-			if err == nil {
-				key.Height = strconv.FormatUint(h, 10)
-				core.readCache.Put(key.Hash(), d)
-			}
-			return d, h, err
-		}
-	}
-	// TODO: need to distinguish user error and system error
-	d, h, err := p.ReadState(ctx, core.sf, methodName, arguments...)
-	if err == nil {
-		key.Height = strconv.FormatUint(h, 10)
-		core.readCache.Put(key.Hash(), d)
-	}
-	return d, h, err
+	rc := core.newStateReadContext(ctx)
+	defer rc.close()
+	return core.readStateIn(rc, p, height, methodName, arguments...)
 }
 
 func (core *coreService) getActionsFromIndex(start, count uint64) ([]*iotexapi.ActionInfo, error) {
@@ -1213,6 +1322,62 @@ func (core *coreService) ActionByActionHash(h hash.Hash256) (*action.SealedEnvel
 	return selp, blk, index, nil
 }
 
+// BlockByActionHash returns the action with the block containing it, its index in the block and its receipt, which
+// are looked up by the action index. It returns ErrNotFound if the action or its receipt is not found, and passes the
+// other errors of the index and the block dao through. The lookup lives here rather than in the blockchain, as the
+// action index is held by the api instead of the blockchain
+func (core *coreService) BlockByActionHash(h hash.Hash256) (*apitypes.ActionInBlock, error) {
+	if err := core.checkActionIndex(); err != nil {
+		return nil, status.Error(codes.NotFound, blockindex.ErrActionIndexNA.Error())
+	}
+	actIndex, err := core.indexer.GetActionIndex(h[:])
+	if err != nil {
+		return nil, notFound(err)
+	}
+	blk, err := core.dao.GetBlockByHeight(actIndex.BlockHeight())
+	if err != nil {
+		return nil, notFound(err)
+	}
+	var (
+		selp  *action.SealedEnvelope
+		index uint32
+	)
+	if n := actIndex.TxNumber(); n > 0 {
+		if int(n) > len(blk.Actions) {
+			return nil, errors.Errorf("action index %d of %x is out of the range of block %d", n, h, blk.Height())
+		}
+		index = n - 1
+		selp = blk.Actions[index]
+	} else if selp, index, err = blk.ActionByHash(h); err != nil {
+		// the legacy index without the tx number points to a block not containing the action
+		return nil, errors.Wrap(ErrNotFound, err.Error())
+	}
+	receipts, err := core.blockReceipts(blk.Height())
+	if err != nil {
+		return nil, notFound(err)
+	}
+	var receipt *action.Receipt
+	if int(index) < len(receipts) && receipts[index].ActionHash == h {
+		receipt = receipts[index]
+	} else if receipt = filterReceipts(receipts, h); receipt == nil {
+		return nil, errors.Wrapf(ErrNotFound, "failed to find receipt for action %x", h)
+	}
+	return &apitypes.ActionInBlock{
+		Action:  selp,
+		Block:   blk,
+		Index:   index,
+		Receipt: receipt,
+	}, nil
+}
+
+// notFound wraps the error of a missing object as ErrNotFound
+func notFound(err error) error {
+	if cause := errors.Cause(err); cause == db.ErrNotExist || cause == db.ErrBucketNotExist {
+		return errors.Wrap(ErrNotFound, err.Error())
+	}
+	return err
+}
+
 // PendingActionByActionHash returns action by action hash
 func (core *coreService) PendingActionByActionHash(h hash.Hash256) (*action.SealedEnvelope, error) {
 	selp, err := core.ap.GetActionByHash(h)
@@ -1257,11 +1422,11 @@ func (core *coreService) BlockByHash(blkHash string) (*apitypes.BlockWithReceipt
 	if err != nil {
 		return nil, err
 	}
-	blk, err := core.dao.GetBlock(hash)
+	blk, err := core.blockByHash(hash)
 	if err != nil {
 		return nil, errors.Wrap(ErrNotFound, err.Error())
 	}
-	receipts, err := core.dao.GetReceipts(blk.Height())
+	receipts, err := core.blockReceipts(blk.Height())
 	if err != nil {
 		return nil, errors.Wrap(ErrNotFound, err.Error())
 	}
@@ -1271,6 +1436,30 @@ func (core *coreService) BlockByHash(blkHash string) (*apitypes.BlockWithReceipt
 	}, nil
 }
 
+func (core *coreService) blockByHash(h hash.Hash256) (*block.Block, error) {
+	if blk, ok := core.respCache.block(h); ok {
+		return blk, nil
+	}
+	blk, err := core.dao.GetBlock(h)
+	if err != nil {
+		return nil, err
+	}
+	core.respCache.putBlock(blk)
+	return blk, nil
+}
+
+func (core *coreService) blockReceipts(height uint64) ([]*action.Receipt, error) {
+	if receipts, ok := core.respCache.blockReceipts(height); ok {
+		return receipts, nil
+	}
+	receipts, err := core.dao.GetReceipts(height)
+	if err != nil {
+		return nil, err
+	}
+	core.respCache.putBlockReceipts(height, receipts)
+	return receipts, nil
+}
+
 // BlockByHeightRange returns blocks within the height range
 func (core *coreService) BlockByHeightRange(start uint64, count uint64) ([]*apitypes.BlockWithReceipts, error) {
 	if count == 0 {
@@ -1314,7 +1503,7 @@ func (core *coreService) getBlockByHeight(height uint64) (*apitypes.BlockWithRec
 	receipts := []*action.Receipt{}
 	if blk.Height() > 0 {
 		var err error
-		receipts, err = core.dao.GetReceipts(height)
+		receipts, err = core.blockReceipts(height)
 		if err != nil {
 			return nil, errors.Wrap(ErrNotFound, err.Error())
 		}
@@ -1402,7 +1591,7 @@ func (core *coreService) committedAction(selp *action.SealedEnvelope, blkHash ha
 		return nil, err
 	}
 	sender := selp.SenderAddress()
-	receipts, err := core.dao.GetReceipts(blkHeight)
+	receipts, err := core.blockReceipts(blkHeight)
 	if err != nil {
 		return nil, err
 	}
@@ -1506,7 +1695,7 @@ func (core *coreService) reverseActionsInBlock(blk *block.Block, reverseStart, c
 	}
 	end := size - 1 - reverseStart
 	res := make([]*iotexapi.ActionInfo, 0, start-end+1)
-	receipts, err := core.dao.GetReceipts(blkHeight)
+	receipts, err := core.blockReceipts(blkHeight)
 	if err != nil {
 		log.Logger("api").Debug("Skipping action due to failing to get receipt", zap.Error(err))
 		return nil
@@ -1561,7 +1750,7 @@ func (core *coreService) logsInBlock(filter *logfilter.LogFilter, blockNumber ui
 		return []*action.Log{}, nil
 	}
 
-	receipts, err := core.dao.GetReceipts(blockNumber)
+	receipts, err := core.blockReceipts(blockNumber)
 	if err != nil {
 		return nil, err
 	}
@@ -1581,15 +1770,139 @@ func (core *coreService) LogsInRange(filter *logfilter.LogFilter, start, end, pa
 		return nil, nil, err
 	}
 	var (
-		logs      = []*action.Log{}
-		hashes    = []hash.Hash256{}
+		logs   = []*action.Log{}
+		hashes = []hash.Hash256{}
+	)
+	logsInBlk, hashInBlk, err := core.logsInBlocks(filter, blockNumbers)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := 0; i < len(blockNumbers); i++ {
+		for j := range logsInBlk[i] {
+			logs = append(logs, logsInBlk[i][j])
+			hashes = append(hashes, hashInBlk[i])
+			if paginationSize > 0 && len(logs) >= int(paginationSize) {
+				return logs, hashes, nil
+			}
+		}
+	}
+
+	return logs, hashes, nil
+}
+
+// LogsInRangeWithCursor filter logs among [start, end] blocks within the query limits. The query
+// resumes from cursor if it is not nil, and the returned cursor is not nil if the limits are reached
+// before the whole range is scanned
+func (core *coreService) LogsInRangeWithCursor(filter *logfilter.LogFilter, start, end uint64, cursor *LogsCursor) ([]*action.Log, []hash.Hash256, *LogsCursor, error) {
+	start, end, err := core.correctQueryRange(start, end)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if cursor != nil {
+		if cursor.Height < start || cursor.Height > end {
+			return nil, nil, nil, errors.Wrapf(ErrInvalidLogsCursor, "cursor height %d is out of range [%d, %d]", cursor.Height, start, end)
+		}
+		start = cursor.Height
+	}
+	scanEnd := end
+	if limit := core.cfg.LogsQueryBlockLimit; limit > 0 && scanEnd-start >= limit {
+		scanEnd = start + limit - 1
+	}
+	blockNumbers, err := core.filterBlocksInShards(filter, start, scanEnd)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var (
+		logs        = []*action.Log{}
+		hashes      = []hash.Hash256{}
+		resultLimit = core.cfg.LogsQueryResultLimit
+	)
+	for len(blockNumbers) > 0 {
+		batch := blockNumbers
+		if len(batch) > _logsQueryBatchSize {
+			batch = batch[:_logsQueryBatchSize]
+		}
+		blockNumbers = blockNumbers[len(batch):]
+		logsInBlk, hashInBlk, err := core.logsInBlocks(filter, batch)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		for i, blkNum := range batch {
+			skip := 0
+			if cursor != nil && blkNum == cursor.Height {
+				skip = int(cursor.Index)
+			}
+			for j := skip; j < len(logsInBlk[i]); j++ {
+				if resultLimit > 0 && uint64(len(logs)) >= resultLimit {
+					return logs, hashes, &LogsCursor{Height: blkNum, Index: uint64(j)}, nil
+				}
+				logs = append(logs, logsInBlk[i][j])
+				hashes = append(hashes, hashInBlk[i])
+			}
+		}
+	}
+	if scanEnd < end {
+		return logs, hashes, &LogsCursor{Height: scanEnd + 1}, nil
+	}
+	return logs, hashes, nil, nil
+}
+
+// filterBlocksInShards splits [start, end] into shards of the range bloom filter size,
+// and filters the blocks of each shard in parallel
+func (core *coreService) filterBlocksInShards(filter *logfilter.LogFilter, start, end uint64) ([]uint64, error) {
+	shardSize := core.bfIndexer.RangeBloomFilterNumElements()
+	if shardSize == 0 {
+		return core.bfIndexer.FilterBlocksInRange(filter, start, end, 0)
+	}
+	type shard struct {
+		start, end uint64
+	}
+	shards := []shard{}
+	for s := start; s <= end; {
+		// align shards to the range size, so each shard mostly reads a single range bloom filter
+		e := ((s-1)/shardSize + 1) * shardSize
+		if e > end {
+			e = end
+		}
+		shards = append(shards, shard{s, e})
+		s = e + 1
+	}
+	var (
+		blkNums = make([][]uint64, len(shards))
+		eg      errgroup.Group
+	)
+	eg.SetLimit(_workerNumbers)
+	for i := range shards {
+		i := i
+		eg.Go(func() error {
+			blks, err := core.bfIndexer.FilterBlocksInRange(filter, shards[i].start, shards[i].end, 0)
+			if err != nil {
+				return err
+			}
+			blkNums[i] = blks
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	ret := []uint64{}
+	for i := range blkNums {
+		ret = append(ret, blkNums[i]...)
+	}
+	return ret, nil
+}
+
+// logsInBlocks filters the logs and fetches the hash of each block in parallel
+func (core *coreService) logsInBlocks(filter *logfilter.LogFilter, blockNumbers []uint64) ([][]*action.Log, []hash.Hash256, error) {
+	var (
 		logsInBlk = make([][]*action.Log, len(blockNumbers))
-		HashInBlk = make([]hash.Hash256, len(blockNumbers))
+		hashInBlk = make([]hash.Hash256, len(blockNumbers))
 		jobs      = make(chan jobDesc, len(blockNumbers))
 		eg, ctx   = errgroup.WithContext(context.Background())
 	)
 	if len(blockNumbers) == 0 {
-		return logs, hashes, nil
+		return logsInBlk, hashInBlk, nil
 	}
 
 	for i, v := range blockNumbers {
@@ -1616,7 +1929,7 @@ func (core *coreService) LogsInRange(filter *logfilter.LogFilter, start, end, pa
 						return err
 					}
 					logsInBlk[job.idx] = logsInBlock
-					HashInBlk[job.idx] = blkHash
+					hashInBlk[job.idx] = blkHash
 				}
 			}
 		})
@@ -1624,18 +1937,7 @@ func (core *coreService) LogsInRange(filter *logfilter.LogFilter, start, end, pa
 	if err := eg.Wait(); err != nil {
 		return nil, nil, err
 	}
-
-	for i := 0; i < len(blockNumbers); i++ {
-		for j := range logsInBlk[i] {
-			logs = append(logs, logsInBlk[i][j])
-			hashes = append(hashes, HashInBlk[i])
-			if paginationSize > 0 && len(logs) >= int(paginationSize) {
-				return logs, hashes, nil
-			}
-		}
-	}
-
-	return logs, hashes, nil
+	return logsInBlk, hashInBlk, nil
 }
 
 func (core *coreService) correctQueryRange(start, end uint64) (uint64, uint64, error) {
@@ -1692,7 +1994,7 @@ func (core *coreService) EstimateMigrateStakeGasConsumption(ctx context.Context,
 		GasLimit:       g.BlockGasLimitByHeight(header.Height() + 1),
 		Producer:       zeroAddr,
 		BaseFee:        protocol.CalcBaseFee(g.Blockchain, &tip),
-		ExcessBlobGas:  protocol.CalcExcessBlobGas(header.ExcessBlobGas(), header.BlobGasUsed()),
+		ExcessBlobGas:  protocol.CalcExcessBlobGas(g.Blockchain, header.Height()+1, header.ExcessBlobGas(), header.BlobGasUsed()),
 	})
 	ctx = protocol.WithFeatureCtx(ctx)
 	exec, err := staking.FindProtocol(core.registry).ConstructExecution(ctx, ms, 0, 0, new(big.Int), core.sf)
@@ -1723,10 +2025,20 @@ func (core *coreService) EstimateMigrateStakeGasConsumption(ctx context.Context,
 // EstimateExecutionGasConsumption estimate gas consumption for execution action
 func (core *coreService) EstimateExecutionGasConsumption(ctx context.Context, elp action.Envelope, callerAddr address.Address, opts ...protocol.SimulateOption) (uint64, []byte, error) {
 	var (
-		g             = core.bc.Genesis()
-		blockGasLimit = g.BlockGasLimitByHeight(core.bc.TipHeight())
+		estimatedGas uint64
+		retval       []byte
 	)
-	elp.SetGas(blockGasLimit)
+	err := core.simLimiter.run(ctx, func(ctx context.Context) error {
+		var err error
+		estimatedGas, retval, err = core.estimateExecutionGasConsumption(ctx, elp, callerAddr, opts...)
+		return err
+	})
+	return estimatedGas, retval, err
+}
+
+func (core *coreService) estimateExecutionGasConsumption(ctx context.Context, elp action.Envelope, callerAddr address.Address, opts ...protocol.SimulateOption) (uint64, []byte, error) {
+	gasCap := core.simulationGasCap(core.bc.TipHeight())
+	elp.SetGas(gasCap)
 	enough, receipt, retval, err := core.isGasLimitEnough(ctx, callerAddr, elp, opts...)
 	if err != nil {
 		return 0, nil, status.Error(codes.Internal, err.Error())
@@ -1747,7 +2059,7 @@ func (core *coreService) EstimateExecutionGasConsumption(ctx context.Context, el
 		return 0, nil, status.Error(codes.Internal, err.Error())
 	}
 	if !enough {
-		low, high := estimatedGas, blockGasLimit
+		low, high := estimatedGas, gasCap
 		estimatedGas = high
 		for low <= high {
 			mid := (low + high) / 2
@@ -1915,17 +2227,61 @@ func (core *coreService) ReadContractStorage(ctx context.Context, addr address.A
 
 func (core *coreService) ReceiveBlock(blk *block.Block) error {
 	core.readCache.Clear()
+	core.respCache.receiveBlock(blk)
+	core.readStateCache.receiveBlock(blk)
+	if core.userOpPool != nil {
+		if err := core.userOpPool.ReceiveBlock(blk); err != nil {
+			log.L().Warn("failed to update user operation pool", zap.Error(err))
+		}
+	}
 	return core.chainListener.ReceiveBlock(blk)
 }
 
 func (core *coreService) SimulateExecution(ctx context.Context, addr address.Address, elp action.Envelope) ([]byte, *action.Receipt, error) {
+	tipHeight := core.bc.TipHeight()
+	elp.SetGas(core.simulationGasCap(tipHeight))
 	var (
-		g             = core.bc.Genesis()
-		tipHeight     = core.bc.TipHeight()
-		blockGasLimit = g.BlockGasLimitByHeight(tipHeight)
+		retval  []byte
+		receipt *action.Receipt
 	)
-	elp.SetGas(blockGasLimit)
-	return core.simulateExecution(ctx, tipHeight, false, addr, elp)
+	err := core.simLimiter.run(ctx, func(ctx context.Context) error {
+		var err error
+		retval, receipt, err = core.simulateExecution(ctx, tipHeight, false, addr, elp)
+		return err
+	})
+	return retval, receipt, err
+}
+
+// UserOpPool returns the pool of the ERC-4337 user operations, nil if the pool is not enabled
+func (core *coreService) UserOpPool() *userop.Pool {
+	return core.userOpPool
+}
+
+// simulateUserOp calls the entry point from the zero address under the tracer, which is how the bundlers simulate
+// the validation of the user operations
+func (core *coreService) simulateUserOp(ctx context.Context, entryPoint common.Address, data []byte, tracer vm.EVMLogger) ([]byte, error) {
+	to, err := address.FromBytes(entryPoint.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	tipHeight := core.bc.TipHeight()
+	elp := (&action.EnvelopeBuilder{}).SetAction(action.NewExecution(to.String(), big.NewInt(0), data)).
+		SetGasLimit(core.simulationGasCap(tipHeight)).Build()
+	zeroAddr, err := address.FromString(address.ZeroAddress)
+	if err != nil {
+		return nil, err
+	}
+	var retval []byte
+	err = core.simLimiter.run(ctx, func(ctx context.Context) error {
+		var err error
+		ctx = protocol.WithVMConfigCtx(ctx, vm.Config{
+			Tracer:    tracer,
+			NoBaseFee: true,
+		})
+		retval, _, err = core.simulateExecution(ctx, tipHeight, false, zeroAddr, elp)
+		return err
+	})
+	return retval, err
 }
 
 // SyncingProgress returns the syncing status of node
@@ -1934,6 +2290,43 @@ func (core *coreService) SyncingProgress() (uint64, uint64, uint64) {
 	return startingHeight, currentHeight, targetHeight
 }
 
+// SyncStatus returns the sync status of node and indexers
+func (core *coreService) SyncStatus() (*SyncStatus, error) {
+	tipHeight := core.bc.TipHeight()
+	status := &SyncStatus{
+		CurrentHeight:  tipHeight,
+		HighestHeight:  tipHeight,
+		IndexerHeights: make(map[string]uint64),
+	}
+	if core.bs != nil {
+		startingHeight, _, targetHeight, _ := core.bs.SyncStatus()
+		headerHeight, headerHash := core.bs.HeaderTip()
+		status.StartingHeight = startingHeight
+		status.HeaderHeight = headerHeight
+		status.HeaderHash = hex.EncodeToString(headerHash[:])
+		status.HighestHeight = max(targetHeight, tipHeight, headerHeight)
+	}
+	stateHeight, err := core.sf.Height()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get state height")
+	}
+	status.StateHeight = stateHeight
+	for name, indexer := range map[string]interface{ Height() (uint64, error) }{
+		"index":       core.indexer,
+		"bloomfilter": core.bfIndexer,
+	} {
+		if indexer == nil || reflect.ValueOf(indexer).IsNil() {
+			continue
+		}
+		height, err := indexer.Height()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get height of %s indexer", name)
+		}
+		status.IndexerHeights[name] = height
+	}
+	return status, nil
+}
+
 // TraceTransaction returns the trace result of transaction
 func (core *coreService) TraceTransaction(ctx context.Context, actHash string, config *tracers.TraceConfig) ([]byte, *action.Receipt, any, error) {
 	actInfo, err := core.Action(util.Remove0xPrefix(actHash), false)
@@ -1944,12 +2337,87 @@ func (core *coreService) TraceTransaction(ctx context.Context, actHash string, c
 	if err != nil {
 		return nil, nil, nil, err
 	}
+	stateHeight, err := core.traceStateHeight(actInfo.BlkHeight)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return core.traceAction(ctx, stateHeight, act, config)
+}
+
+// TraceBlock returns the trace results of the actions in the block at the height, the failure of tracing a single
+// action is reported in its result. The whole block shares one deadline and one limit of the total result size
+func (core *coreService) TraceBlock(ctx context.Context, height uint64, config *tracers.TraceConfig) ([]*ActionTrace, error) {
+	if height > core.bc.TipHeight() {
+		return nil, ErrNotFound
+	}
+	stateHeight, err := core.traceStateHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	blk, err := core.dao.GetBlockByHeight(height)
+	if err != nil {
+		return nil, errors.Wrap(ErrNotFound, err.Error())
+	}
+	ctx, cancel := context.WithTimeout(ctx, core.traceBlockTimeout())
+	defer cancel()
+	var (
+		limit = core.cfg.TraceBlockResultSizeLimit
+		size  uint64
+		ret   = make([]*ActionTrace, 0, len(blk.Actions))
+	)
+	for _, selp := range blk.Actions {
+		actHash, err := selp.Hash()
+		if err != nil {
+			return nil, err
+		}
+		act, err := (&action.Deserializer{}).SetEvmNetworkID(core.EVMNetworkID()).ActionToSealedEnvelope(selp.Proto())
+		if err != nil {
+			return nil, err
+		}
+		res := &ActionTrace{ActHash: actHash}
+		res.Retval, res.Receipt, res.Tracer, res.Err = core.traceAction(ctx, stateHeight, act, config)
+		if err := ctx.Err(); err != nil {
+			return nil, errors.Wrapf(err, "failed to trace block %d", height)
+		}
+		if res.Err == nil && limit > 0 {
+			tracer, ok := res.Tracer.(tracers.Tracer)
+			if !ok {
+				return nil, errors.Errorf("unknown tracer type: %T", res.Tracer)
+			}
+			result, err := tracer.GetResult()
+			if err != nil {
+				return nil, err
+			}
+			if size += uint64(len(result)); size > limit {
+				return nil, errors.Wrapf(ErrTraceResultTooLarge, "result size of block %d exceeds the limit %d", height, limit)
+			}
+		}
+		ret = append(ret, res)
+	}
+	return ret, nil
+}
+
+// traceStateHeight returns the height of the state to trace the actions of the block at the height against. With
+// archive support, it is the state the block was built upon, otherwise only the tip block can be traced against the
+// tip state
+func (core *coreService) traceStateHeight(height uint64) (uint64, error) {
+	tipHeight := core.bc.TipHeight()
+	switch {
+	case core.archiveSupported:
+		return height - 1, nil
+	case height != tipHeight:
+		return 0, errors.Wrapf(ErrArchiveNotSupported, "cannot trace the actions at height %d, only the actions at the tip height %d can be traced", height, tipHeight)
+	}
+	return tipHeight, nil
+}
+
+// traceAction traces the execution against the state at the height
+func (core *coreService) traceAction(ctx context.Context, stateHeight uint64, act *action.SealedEnvelope, config *tracers.TraceConfig) ([]byte, *action.Receipt, any, error) {
 	if _, ok := act.Action().(*action.Execution); !ok {
 		return nil, nil, nil, errors.New("the type of action is not supported")
 	}
-	addr, _ := address.FromString(address.ZeroAddress)
 	return core.traceTx(ctx, new(tracers.Context), config, func(ctx context.Context) ([]byte, *action.Receipt, error) {
-		return core.simulateExecution(ctx, core.bc.TipHeight(), false, addr, act.Envelope)
+		return core.simulateExecution(ctx, stateHeight, core.archiveSupported, act.SenderAddress(), act.Envelope)
 	})
 }
 
@@ -1997,43 +2465,99 @@ func (core *coreService) Track(ctx context.Context, start time.Time, method stri
 
 func (core *coreService) traceTx(ctx context.Context, txctx *tracers.Context, config *tracers.TraceConfig, simulateFn func(ctx context.Context) ([]byte, *action.Receipt, error)) ([]byte, *action.Receipt, any, error) {
 	var (
-		tracer vm.EVMLogger
+		tracer tracers.Tracer
 		err    error
 	)
+	// Define a meaningful timeout of a single transaction trace
+	timeout := core.traceTimeout()
+	if config != nil && config.Timeout != nil {
+		requested, err := time.ParseDuration(*config.Timeout)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if requested < timeout {
+			timeout = requested
+		}
+	}
 	switch {
 	case config == nil:
 		tracer = logger.NewStructLogger(nil)
 	case config.Tracer != nil:
-		// Define a meaningful timeout of a single transaction trace
-		timeout := defaultTraceTimeout
-		if config.Timeout != nil {
-			if timeout, err = time.ParseDuration(*config.Timeout); err != nil {
-				return nil, nil, nil, err
-			}
-		}
-		t, err := tracers.DefaultDirectory.New(*config.Tracer, txctx, config.TracerConfig)
-		if err != nil {
+		if tracer, err = tracers.DefaultDirectory.New(*config.Tracer, txctx, config.TracerConfig); err != nil {
 			return nil, nil, nil, err
 		}
-		deadlineCtx, cancel := context.WithTimeout(ctx, timeout)
-		defer cancel()
-		go func() {
-			<-deadlineCtx.Done()
-			if errors.Is(deadlineCtx.Err(), context.DeadlineExceeded) {
-				t.Stop(errors.New("execution timeout"))
-			}
-		}()
-		tracer = t
-
 	default:
 		tracer = logger.NewStructLogger(config.Config)
 	}
+	deadlineCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	go func() {
+		<-deadlineCtx.Done()
+		if errors.Is(deadlineCtx.Err(), context.DeadlineExceeded) {
+			tracer.Stop(errors.New("execution timeout"))
+		}
+	}()
 	ctx = protocol.WithVMConfigCtx(ctx, vm.Config{
 		Tracer:    tracer,
 		NoBaseFee: true,
 	})
 	retval, receipt, err := simulateFn(ctx)
-	return retval, receipt, tracer, err
+	if err != nil {
+		return retval, receipt, tracer, err
+	}
+	if errors.Is(deadlineCtx.Err(), context.DeadlineExceeded) {
+		return nil, nil, nil, errors.Errorf("execution timeout after %s", timeout)
+	}
+	limit := core.cfg.TraceResultSizeLimit
+	if limit == 0 {
+		return retval, receipt, tracer, nil
+	}
+	result, err := tracer.GetResult()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if uint64(len(result)) > limit {
+		return nil, nil, nil, errors.Wrapf(ErrTraceResultTooLarge, "result size %d exceeds the limit %d", len(result), limit)
+	}
+	if _, ok := tracer.(*logger.StructLogger); ok {
+		return retval, receipt, tracer, nil
+	}
+	// avoid computing the result of the tracer again
+	return retval, receipt, &tracerWithResult{Tracer: tracer, result: result}, nil
+}
+
+func (core *coreService) traceTimeout() time.Duration {
+	if core.cfg.TraceTimeout > 0 {
+		return core.cfg.TraceTimeout
+	}
+	return defaultTraceTimeout
+}
+
+func (core *coreService) traceBlockTimeout() time.Duration {
+	if core.cfg.TraceBlockTimeout > 0 {
+		return core.cfg.TraceBlockTimeout
+	}
+	return defaultTraceBlockTimeout
+}
+
+// ActionTrace is the trace result of an action in a block, Err is the failure of tracing the action
+type ActionTrace struct {
+	ActHash hash.Hash256
+	Retval  []byte
+	Receipt *action.Receipt
+	Tracer  any
+	Err     error
+}
+
+// tracerWithResult is a tracer whose result has been computed
+type tracerWithResult struct {
+	tracers.Tracer
+	result json.RawMessage
+}
+
+// GetResult returns the computed result
+func (t *tracerWithResult) GetResult() (json.RawMessage, error) {
+	return t.result, nil
 }
 
 func (core *coreService) simulateExecution(
@@ -8,6 +8,7 @@ package api
 import (
 	"context"
 	"encoding/hex"
+	"fmt"
 	"math/big"
 	"strconv"
 	"testing"
@@ -19,6 +20,8 @@ import (
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
@@ -132,6 +135,111 @@ func TestLogsInRange(t *testing.T) {
 	})
 }
 
+func TestLogsInRangeWithCursor(t *testing.T) {
+	require := require.New(t)
+	svr, _, _, _, cleanCallback := setupTestCoreService()
+	defer cleanCallback()
+	core := svr.(*coreService)
+	filter := logfilter.NewLogFilter(&iotexapi.LogsFilter{})
+
+	t.Run("no limit", func(t *testing.T) {
+		core.cfg.LogsQueryBlockLimit, core.cfg.LogsQueryResultLimit = 0, 0
+		logs, hashes, cursor, err := svr.LogsInRangeWithCursor(filter, 1, 4, nil)
+		require.NoError(err)
+		require.Len(logs, 4)
+		require.Len(hashes, 4)
+		require.Nil(cursor)
+	})
+	t.Run("result limit", func(t *testing.T) {
+		core.cfg.LogsQueryBlockLimit, core.cfg.LogsQueryResultLimit = 0, 3
+		logs, hashes, cursor, err := svr.LogsInRangeWithCursor(filter, 1, 4, nil)
+		require.NoError(err)
+		require.Len(logs, 3)
+		require.Len(hashes, 3)
+		require.NotNil(cursor)
+		logs2, _, cursor, err := svr.LogsInRangeWithCursor(filter, 1, 4, cursor)
+		require.NoError(err)
+		require.Len(logs2, 1)
+		require.Nil(cursor)
+		require.Greater(logs2[0].BlockHeight, logs[2].BlockHeight)
+	})
+	t.Run("block limit", func(t *testing.T) {
+		core.cfg.LogsQueryBlockLimit, core.cfg.LogsQueryResultLimit = 2, 0
+		var (
+			all    []*action.Log
+			cursor *LogsCursor
+		)
+		for i := 0; ; i++ {
+			require.Less(i, 4)
+			logs, _, next, err := svr.LogsInRangeWithCursor(filter, 1, 4, cursor)
+			require.NoError(err)
+			for _, l := range logs {
+				require.GreaterOrEqual(l.BlockHeight, uint64(1+2*i))
+				require.LessOrEqual(l.BlockHeight, uint64(2+2*i))
+			}
+			all = append(all, logs...)
+			if next == nil {
+				break
+			}
+			require.Equal(uint64(3+2*i), next.Height)
+			cursor = next
+		}
+		require.Len(all, 4)
+	})
+	t.Run("cursor out of range", func(t *testing.T) {
+		_, _, _, err := svr.LogsInRangeWithCursor(filter, 2, 4, &LogsCursor{Height: 1})
+		require.ErrorIs(err, ErrInvalidLogsCursor)
+	})
+}
+
+func TestLogsCursor(t *testing.T) {
+	require := require.New(t)
+	c := &LogsCursor{Height: 12345, Index: 6}
+	parsed, err := ParseLogsCursor(c.String())
+	require.NoError(err)
+	require.Equal(c, parsed)
+	for _, s := range []string{"", "0xzz", "0x1234", (&LogsCursor{}).String()} {
+		_, err = ParseLogsCursor(s)
+		require.ErrorIs(err, ErrInvalidLogsCursor)
+	}
+}
+
+func TestTraceTxLimits(t *testing.T) {
+	require := require.New(t)
+	receipt := &action.Receipt{Status: uint64(iotextypes.ReceiptStatus_Success)}
+	simulate := func(delay time.Duration) func(context.Context) ([]byte, *action.Receipt, error) {
+		return func(context.Context) ([]byte, *action.Receipt, error) {
+			time.Sleep(delay)
+			return []byte{1}, receipt, nil
+		}
+	}
+	core := &coreService{cfg: DefaultConfig}
+
+	t.Run("default", func(t *testing.T) {
+		_, _, tracer, err := core.traceTx(context.Background(), new(tracers.Context), nil, simulate(0))
+		require.NoError(err)
+		_, ok := tracer.(*logger.StructLogger)
+		require.True(ok)
+	})
+	t.Run("timeout", func(t *testing.T) {
+		timeout := "10ms"
+		_, _, _, err := core.traceTx(context.Background(), new(tracers.Context), &tracers.TraceConfig{Timeout: &timeout}, simulate(100*time.Millisecond))
+		require.ErrorContains(err, "execution timeout")
+		// requested timeout cannot exceed the configured one
+		core := &coreService{cfg: DefaultConfig}
+		core.cfg.TraceTimeout = 10 * time.Millisecond
+		timeout = "1h"
+		_, _, _, err = core.traceTx(context.Background(), new(tracers.Context), &tracers.TraceConfig{Timeout: &timeout}, simulate(100*time.Millisecond))
+		require.ErrorContains(err, "execution timeout")
+	})
+	t.Run("result size", func(t *testing.T) {
+		core := &coreService{cfg: DefaultConfig}
+		core.cfg.TraceResultSizeLimit = 1
+		_, _, _, err := core.traceTx(context.Background(), new(tracers.Context), nil, simulate(0))
+		require.ErrorIs(err, ErrTraceResultTooLarge)
+	})
+}
+
 func BenchmarkLogsInRange(b *testing.B) {
 	svr, _, _, _, cleanCallback := setupTestCoreService()
 	defer cleanCallback()
@@ -641,6 +749,63 @@ func TestTraceTransaction(t *testing.T) {
 	require.Equal(0, len(traces.(*logger.StructLogger).StructLogs()))
 }
 
+func TestTraceBlock(t *testing.T) {
+	require := require.New(t)
+	svr, bc, _, ap, cleanCallback := setupTestCoreService()
+	defer cleanCallback()
+	core, ok := svr.(*coreService)
+	require.True(ok)
+	ctx := context.Background()
+	exec, err := action.SignedExecution(identityset.Address(29).String(),
+		identityset.PrivateKey(29), 1, big.NewInt(0), testutil.TestGasLimit,
+		big.NewInt(testutil.TestGasPriceInt64), []byte{})
+	require.NoError(err)
+	execHash, err := exec.Hash()
+	require.NoError(err)
+	require.NoError(ap.Add(ctx, exec))
+	blk, err := bc.MintNewBlock(testutil.TimestampNow())
+	require.NoError(err)
+	require.NoError(bc.CommitBlock(blk))
+	height := blk.Height()
+
+	_, err = svr.TraceBlock(ctx, height+1, nil)
+	require.ErrorIs(err, ErrNotFound)
+
+	traces, err := svr.TraceBlock(ctx, height, nil)
+	require.NoError(err)
+	require.Len(traces, len(blk.Actions))
+	require.Equal(execHash, traces[0].ActHash)
+	require.NoError(traces[0].Err)
+	require.Equal(uint64(1), traces[0].Receipt.Status)
+	// the system actions cannot be traced
+	for _, trace := range traces[1:] {
+		require.Error(trace.Err)
+	}
+
+	t.Run("block result size", func(t *testing.T) {
+		core.cfg.TraceBlockResultSizeLimit = 1
+		defer func() { core.cfg.TraceBlockResultSizeLimit = DefaultConfig.TraceBlockResultSizeLimit }()
+		_, err := svr.TraceBlock(ctx, height, nil)
+		require.ErrorIs(err, ErrTraceResultTooLarge)
+	})
+	t.Run("block timeout", func(t *testing.T) {
+		core.cfg.TraceBlockTimeout = time.Nanosecond
+		defer func() { core.cfg.TraceBlockTimeout = 0 }()
+		_, err := svr.TraceBlock(ctx, height, nil)
+		require.ErrorIs(err, context.DeadlineExceeded)
+	})
+	t.Run("archive not supported", func(t *testing.T) {
+		blk, err := bc.MintNewBlock(testutil.TimestampNow())
+		require.NoError(err)
+		require.NoError(bc.CommitBlock(blk))
+		// the state the block was built upon is not available
+		_, err = svr.TraceBlock(ctx, height, nil)
+		require.ErrorIs(err, ErrArchiveNotSupported)
+		_, _, _, err = svr.TraceTransaction(ctx, hex.EncodeToString(execHash[:]), nil)
+		require.ErrorIs(err, ErrArchiveNotSupported)
+	})
+}
+
 func TestTraceCall(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
@@ -1194,3 +1359,120 @@ func TestTraceTx(t *testing.T) {
 		require.Empty(tracer)
 	})
 }
+
+func TestStateAtHeight(t *testing.T) {
+	require := require.New(t)
+	svr, bc, _, _, cleanCallback := setupTestCoreService()
+	defer cleanCallback()
+	core := svr.(*coreService)
+	tip := bc.TipHeight()
+	require.Greater(tip, uint64(1))
+	addr := identityset.Address(27)
+
+	// latest and tip state are always available
+	for _, height := range []uint64{0, tip} {
+		_, err := core.BalanceAt(context.Background(), addr, height)
+		require.NoError(err)
+		_, err = core.WithHeight(height).ReadContractStorage(context.Background(), addr, []byte("key"))
+		require.NoError(err)
+	}
+	_, err := core.PendingNonceAt(context.Background(), addr, tip)
+	require.NoError(err)
+
+	// past state requires archive
+	_, err = core.BalanceAt(context.Background(), addr, 1)
+	require.ErrorIs(err, ErrArchiveNotSupported)
+	require.Contains(err.Error(), fmt.Sprintf("the earliest available height is %d", tip))
+	_, err = core.PendingNonceAt(context.Background(), addr, 1)
+	require.ErrorIs(err, ErrArchiveNotSupported)
+	_, err = core.WithHeight(1).ReadContractStorage(context.Background(), addr, []byte("key"))
+	require.ErrorIs(err, ErrArchiveNotSupported)
+	_, _, err = core.WithHeight(1).Account(addr)
+	require.ErrorIs(err, ErrArchiveNotSupported)
+
+	// future state
+	_, err = core.BalanceAt(context.Background(), addr, tip+1)
+	require.Equal(codes.NotFound, status.Code(err))
+
+	core.archiveSupported = true
+	require.NoError(core.checkStateHeight(1))
+}
+
+func TestBlockByActionHash(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	var (
+		blkDAO  = mock_blockdao.NewMockBlockDAO(ctrl)
+		indexer = mock_blockindex.NewMockIndexer(ctrl)
+		cs      = &coreService{
+			dao:     blkDAO,
+			indexer: indexer,
+		}
+		ioErr = errors.New("io error")
+	)
+	tsf, err := action.SignedTransfer(identityset.Address(2).String(), identityset.PrivateKey(1), 1, big.NewInt(1), nil, 10000, big.NewInt(1))
+	require.NoError(err)
+	h, err := tsf.Hash()
+	require.NoError(err)
+	blk, err := block.NewTestingBuilder().SetHeight(1).AddActions(tsf).SignAndBuild(identityset.PrivateKey(0))
+	require.NoError(err)
+
+	t.Run("IndexerIsNil", func(t *testing.T) {
+		_, err := (&coreService{}).BlockByActionHash(h)
+		require.Equal(codes.NotFound, status.Code(err))
+	})
+
+	t.Run("ActionNotIndexed", func(t *testing.T) {
+		indexer.EXPECT().GetActionIndex(gomock.Any()).Return(nil, errors.Wrap(db.ErrNotExist, "action")).Times(1)
+		_, err := cs.BlockByActionHash(h)
+		require.ErrorIs(err, ErrNotFound)
+	})
+
+	t.Run("FailedToGetActionIndex", func(t *testing.T) {
+		indexer.EXPECT().GetActionIndex(gomock.Any()).Return(nil, ioErr).Times(1)
+		_, err := cs.BlockByActionHash(h)
+		require.ErrorIs(err, ioErr)
+		require.NotErrorIs(err, ErrNotFound)
+	})
+
+	indexer.EXPECT().GetActionIndex(gomock.Any()).Return(&blockindex.ActionIndex{}, nil).AnyTimes()
+
+	t.Run("FailedToGetBlock", func(t *testing.T) {
+		blkDAO.EXPECT().GetBlockByHeight(gomock.Any()).Return(nil, ioErr).Times(1)
+		_, err := cs.BlockByActionHash(h)
+		require.ErrorIs(err, ioErr)
+		require.NotErrorIs(err, ErrNotFound)
+		blkDAO.EXPECT().GetBlockByHeight(gomock.Any()).Return(nil, db.ErrNotExist).Times(1)
+		_, err = cs.BlockByActionHash(h)
+		require.ErrorIs(err, ErrNotFound)
+	})
+
+	t.Run("ActionNotInBlock", func(t *testing.T) {
+		blkDAO.EXPECT().GetBlockByHeight(gomock.Any()).Return(&block.Block{}, nil).Times(1)
+		_, err := cs.BlockByActionHash(h)
+		require.ErrorIs(err, ErrNotFound)
+	})
+
+	blkDAO.EXPECT().GetBlockByHeight(gomock.Any()).Return(&blk, nil).AnyTimes()
+
+	t.Run("FailedToGetReceipts", func(t *testing.T) {
+		blkDAO.EXPECT().GetReceipts(gomock.Any()).Return(nil, ioErr).Times(1)
+		_, err := cs.BlockByActionHash(h)
+		require.ErrorIs(err, ioErr)
+		require.NotErrorIs(err, ErrNotFound)
+		blkDAO.EXPECT().GetReceipts(gomock.Any()).Return(nil, nil).Times(1)
+		_, err = cs.BlockByActionHash(h)
+		require.ErrorIs(err, ErrNotFound)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		receipt := &action.Receipt{ActionHash: h, BlockHeight: 1}
+		blkDAO.EXPECT().GetReceipts(gomock.Any()).Return([]*action.Receipt{receipt}, nil).Times(1)
+		act, err := cs.BlockByActionHash(h)
+		require.NoError(err)
+		require.Equal(tsf, act.Action)
+		require.Zero(act.Index)
+		require.Equal(receipt, act.Receipt)
+	})
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/iotexproject/iotex-core/v2/action"
 	accountutil "github.com/iotexproject/iotex-core/v2/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/execution/evm"
 	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/v2/pkg/log"
 	"github.com/iotexproject/iotex-core/v2/pkg/tracer"
@@ -23,6 +24,7 @@ type (
 	CoreServiceReaderWithHeight interface {
 		Account(address.Address) (*iotextypes.AccountMeta, *iotextypes.BlockIdentifier, error)
 		ReadContract(context.Context, address.Address, action.Envelope) (string, *iotextypes.Receipt, error)
+		ReadContractStorage(context.Context, address.Address, []byte) ([]byte, error)
 	}
 
 	coreServiceReaderWithHeight struct {
@@ -39,8 +41,8 @@ func newCoreServiceWithHeight(cs *coreService, height uint64) *coreServiceReader
 }
 
 func (core *coreServiceReaderWithHeight) Account(addr address.Address) (*iotextypes.AccountMeta, *iotextypes.BlockIdentifier, error) {
-	if !core.cs.archiveSupported {
-		return nil, nil, ErrArchiveNotSupported
+	if err := core.cs.checkStateHeight(core.height); err != nil {
+		return nil, nil, err
 	}
 	ctx, span := tracer.NewSpan(context.Background(), "coreServiceReaderWithHeight.Account")
 	defer span.End()
@@ -78,8 +80,8 @@ func (core *coreServiceReaderWithHeight) stateAndNonce(addr address.Address) (*s
 }
 
 func (core *coreServiceReaderWithHeight) ReadContract(ctx context.Context, callerAddr address.Address, elp action.Envelope) (string, *iotextypes.Receipt, error) {
-	if !core.cs.archiveSupported {
-		return "", nil, ErrArchiveNotSupported
+	if err := core.cs.checkStateHeight(core.height); err != nil {
+		return "", nil, err
 	}
 	log.Logger("api").Debug("receive read smart contract request")
 	exec, ok := elp.Action().(*action.Execution)
@@ -92,3 +94,19 @@ func (core *coreServiceReaderWithHeight) ReadContract(ctx context.Context, calle
 	)
 	return core.cs.readContract(ctx, key, core.height, true, callerAddr, elp)
 }
+
+func (core *coreServiceReaderWithHeight) ReadContractStorage(ctx context.Context, addr address.Address, key []byte) ([]byte, error) {
+	if err := core.cs.checkStateHeight(core.height); err != nil {
+		return nil, err
+	}
+	ctx, err := core.cs.bc.ContextAtHeight(ctx, core.height)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	ws, err := core.cs.sf.WorkingSetAtHeight(ctx, core.height)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	defer ws.Close()
+	return evm.ReadContractStorage(ctx, ws, addr, key)
+}
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	// CORSConfig is the config of cross-origin resource sharing of the web3 http and websocket endpoints
+	CORSConfig struct {
+		// AllowedOrigins are the origins allowed to access the endpoints, "*" allows any origin, and a leading
+		// wildcard in host, e.g., "https://*.iotex.io", allows any subdomain
+		AllowedOrigins []string `yaml:"allowedOrigins"`
+		// AllowedHeaders are the request headers allowed in cross-origin requests
+		AllowedHeaders []string `yaml:"allowedHeaders"`
+		// MaxAge is the duration the result of a preflight request could be cached by browsers
+		MaxAge time.Duration `yaml:"maxAge"`
+	}
+
+	corsHandler struct {
+		next http.Handler
+		cfg  CORSConfig
+	}
+)
+
+// DefaultCORSConfig is the default config of cross-origin resource sharing, which allows any origin
+var DefaultCORSConfig = CORSConfig{
+	AllowedOrigins: []string{"*"},
+	AllowedHeaders: []string{"Content-Type", "X-API-Key"},
+	MaxAge:         10 * time.Minute,
+}
+
+// newCORSHandler wraps the handler with the cross-origin resource sharing policy
+func newCORSHandler(next http.Handler, cfg CORSConfig) http.Handler {
+	return &corsHandler{
+		next: next,
+		cfg:  cfg,
+	}
+}
+
+func (h *corsHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	origin := req.Header.Get("Origin")
+	preflight := req.Method == http.MethodOptions && req.Header.Get("Access-Control-Request-Method") != ""
+	if origin == "" {
+		h.next.ServeHTTP(w, req)
+		return
+	}
+	w.Header().Add("Vary", "Origin")
+	if !h.cfg.allowOrigin(origin) {
+		if preflight {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		// browsers reject the response without allowed origin
+		h.next.ServeHTTP(w, req)
+		return
+	}
+	if h.cfg.allowAnyOrigin() {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+	} else {
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+	}
+	if !preflight {
+		h.next.ServeHTTP(w, req)
+		return
+	}
+	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+	if len(h.cfg.AllowedHeaders) > 0 {
+		w.Header().Set("Access-Control-Allow-Headers", strings.Join(h.cfg.AllowedHeaders, ", "))
+	}
+	if h.cfg.MaxAge > 0 {
+		w.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(h.cfg.MaxAge.Seconds())))
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (cfg CORSConfig) allowAnyOrigin() bool {
+	for _, allowed := range cfg.AllowedOrigins {
+		if allowed == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// allowOrigin returns true if the origin matches one of the allowed origins
+func (cfg CORSConfig) allowOrigin(origin string) bool {
+	origin = strings.ToLower(origin)
+	for _, allowed := range cfg.AllowedOrigins {
+		allowed = strings.ToLower(allowed)
+		if allowed == "*" || allowed == origin {
+			return true
+		}
+		scheme, host, ok := strings.Cut(allowed, "://*.")
+		if !ok {
+			continue
+		}
+		if strings.HasPrefix(origin, scheme+"://") && strings.HasSuffix(origin, "."+host) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkOrigin returns true if the websocket request is from the same host or an allowed origin
+func (cfg CORSConfig) checkOrigin(req *http.Request) bool {
+	origin := req.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	if _, host, ok := strings.Cut(origin, "://"); ok && strings.EqualFold(host, req.Host) {
+		return true
+	}
+	return cfg.allowOrigin(origin)
+}
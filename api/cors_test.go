@@ -0,0 +1,81 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCORSHandler(t *testing.T) {
+	require := require.New(t)
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte("ok"))
+	})
+	handler := newCORSHandler(next, CORSConfig{
+		AllowedOrigins: []string{"https://app.iotex.io", "https://*.example.com"},
+		AllowedHeaders: []string{"Content-Type"},
+		MaxAge:         DefaultCORSConfig.MaxAge,
+	})
+	serve := func(method, origin string, preflight bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "http://localhost/", nil)
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		if preflight {
+			req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("NoOrigin", func(t *testing.T) {
+		w := serve(http.MethodPost, "", false)
+		require.Equal("ok", w.Body.String())
+		require.Empty(w.Header().Get("Access-Control-Allow-Origin"))
+	})
+	t.Run("AllowedOrigin", func(t *testing.T) {
+		w := serve(http.MethodPost, "https://app.iotex.io", false)
+		require.Equal("ok", w.Body.String())
+		require.Equal("https://app.iotex.io", w.Header().Get("Access-Control-Allow-Origin"))
+		w = serve(http.MethodPost, "https://wallet.example.com", false)
+		require.Equal("https://wallet.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	})
+	t.Run("DisallowedOrigin", func(t *testing.T) {
+		for _, origin := range []string{"https://evil.io", "http://wallet.example.com", "https://example.com"} {
+			w := serve(http.MethodPost, origin, false)
+			require.Empty(w.Header().Get("Access-Control-Allow-Origin"))
+			w = serve(http.MethodOptions, origin, true)
+			require.Equal(http.StatusForbidden, w.Code)
+		}
+	})
+	t.Run("Preflight", func(t *testing.T) {
+		w := serve(http.MethodOptions, "https://app.iotex.io", true)
+		require.Equal(http.StatusNoContent, w.Code)
+		require.Empty(w.Body.String())
+		require.Equal("Content-Type", w.Header().Get("Access-Control-Allow-Headers"))
+		require.Equal("600", w.Header().Get("Access-Control-Max-Age"))
+	})
+	t.Run("AnyOrigin", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "http://localhost/", nil)
+		req.Header.Set("Origin", "https://evil.io")
+		newCORSHandler(next, DefaultCORSConfig).ServeHTTP(w, req)
+		require.Equal("*", w.Header().Get("Access-Control-Allow-Origin"))
+	})
+}
+
+func TestCORSCheckOrigin(t *testing.T) {
+	require := require.New(t)
+	cfg := CORSConfig{AllowedOrigins: []string{"https://app.iotex.io"}}
+	req := httptest.NewRequest(http.MethodGet, "http://node.iotex.io/", nil)
+	require.True(cfg.checkOrigin(req))
+	req.Header.Set("Origin", "https://node.iotex.io")
+	require.True(cfg.checkOrigin(req))
+	req.Header.Set("Origin", "https://app.iotex.io")
+	require.True(cfg.checkOrigin(req))
+	req.Header.Set("Origin", "https://evil.io")
+	require.False(cfg.checkOrigin(req))
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"encoding/hex"
+
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/vote/candidatesutil"
+	"github.com/iotexproject/iotex-core/v2/crypto"
+	"github.com/iotexproject/iotex-core/v2/state"
+)
+
+type (
+	// EpochCandidateProof is the candidate list snapshot of an epoch, along with the header of the epoch start block
+	// committing the root of the snapshot, and the merkle proof of each candidate against the root
+	EpochCandidateProof struct {
+		Epoch     uint64 `json:"epoch"`
+		Height    uint64 `json:"height"`
+		BlockHash string `json:"blockHash"`
+		// Header is the serialized header of the epoch start block, whose signature can be verified by the light client
+		Header     string                `json:"header"`
+		Root       string                `json:"root"`
+		Candidates []*CandidateWithProof `json:"candidates"`
+	}
+
+	// CandidateWithProof is a candidate in the snapshot and its merkle proof
+	CandidateWithProof struct {
+		Address       string `json:"address"`
+		Votes         string `json:"votes"`
+		RewardAddress string `json:"rewardAddress"`
+		CanName       string `json:"canName"`
+		Index         uint64 `json:"index"`
+		// Data is the serialized candidate, whose hash is the leaf of the merkle tree
+		Data  string   `json:"data"`
+		Proof []string `json:"proof"`
+	}
+)
+
+// EpochCandidateProof returns the candidate list snapshot of the epoch and its proofs
+func (core *coreService) EpochCandidateProof(epochNum uint64) (*EpochCandidateProof, error) {
+	rp := rolldpos.FindProtocol(core.registry)
+	if rp == nil {
+		return nil, status.Error(codes.Unimplemented, "rolldpos protocol is not registered")
+	}
+	if epochNum < 1 {
+		return nil, status.Error(codes.InvalidArgument, "epoch number cannot be less than one")
+	}
+	epochHeight := rp.GetEpochHeight(epochNum)
+	if epochHeight > core.bc.TipHeight() {
+		return nil, status.Errorf(codes.NotFound, "epoch %d has not started", epochNum)
+	}
+	candidates, err := candidatesutil.EpochCandidatesFromDB(core.sf, epochNum)
+	if err != nil {
+		if errors.Cause(err) == state.ErrStateNotExist {
+			return nil, status.Errorf(codes.NotFound, "no candidates snapshot of epoch %d", epochNum)
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	header, err := core.bc.BlockHeaderByHeight(epochHeight)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	root := header.CandidatesRoot()
+	calculated, err := candidatesutil.CandidatesRoot(candidates)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if calculated != root {
+		return nil, status.Errorf(codes.Internal, "candidates root %x mismatches the root %x in header", calculated, root)
+	}
+	headerBytes, err := header.Serialize()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	leaves, err := candidatesutil.CandidateLeaves(candidates)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	blkHash := header.HashBlock()
+	ret := &EpochCandidateProof{
+		Epoch:      epochNum,
+		Height:     epochHeight,
+		BlockHash:  hex.EncodeToString(blkHash[:]),
+		Header:     hex.EncodeToString(headerBytes),
+		Root:       hex.EncodeToString(root[:]),
+		Candidates: make([]*CandidateWithProof, len(candidates)),
+	}
+	var mk *crypto.Merkle
+	if len(leaves) > 0 {
+		mk = crypto.NewMerkleTree(leaves)
+	}
+	for i, c := range candidates {
+		data, err := c.Serialize()
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		proof, err := mk.Proof(i)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		cp := &CandidateWithProof{
+			Address:       c.Address,
+			Votes:         c.Votes.String(),
+			RewardAddress: c.RewardAddress,
+			CanName:       hex.EncodeToString(c.CanName),
+			Index:         uint64(i),
+			Data:          hex.EncodeToString(data),
+			Proof:         make([]string, len(proof)),
+		}
+		for j := range proof {
+			cp.Proof[j] = hex.EncodeToString(proof[j][:])
+		}
+		ret.Candidates[i] = cp
+	}
+	return ret, nil
+}
+
+func (svr *web3Handler) getEpochCandidateProof(in *gjson.Result) (interface{}, error) {
+	epoch := in.Get("params.0")
+	if !epoch.Exists() || epoch.Type != gjson.Number {
+		return nil, errInvalidFormat
+	}
+	return svr.coreService.EpochCandidateProof(epoch.Uint())
+}
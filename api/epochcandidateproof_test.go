@@ -0,0 +1,110 @@
+package api
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/vote/candidatesutil"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/crypto"
+	"github.com/iotexproject/iotex-core/v2/state"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+	"github.com/iotexproject/iotex-core/v2/test/mock/mock_blockchain"
+	"github.com/iotexproject/iotex-core/v2/test/mock/mock_factory"
+	"github.com/iotexproject/iotex-core/v2/testutil"
+)
+
+func TestCoreService_EpochCandidateProof(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	sf := mock_factory.NewMockFactory(ctrl)
+	registry := protocol.NewRegistry()
+	rp := rolldpos.NewProtocol(24, 24, 1)
+	require.NoError(rp.Register(registry))
+	core := &coreService{bc: bc, sf: sf, registry: registry}
+
+	candidates := state.CandidateList{}
+	for i := 1; i <= 3; i++ {
+		candidates = append(candidates, &state.Candidate{
+			Address:       identityset.Address(i).String(),
+			Votes:         big.NewInt(int64(100 * i)),
+			RewardAddress: identityset.Address(i + 10).String(),
+			CanName:       []byte{byte(i)},
+		})
+	}
+	root, err := candidatesutil.CandidatesRoot(candidates)
+	require.NoError(err)
+	epochHeight := rp.GetEpochHeight(2)
+	blk, err := block.NewBuilder(block.NewRunnableActionsBuilder().Build()).
+		SetHeight(epochHeight).
+		SetTimestamp(testutil.TimestampNow()).
+		SetPrevBlockHash(hash.ZeroHash256).
+		SetCandidatesRoot(root).
+		SignAndBuild(identityset.PrivateKey(1))
+	require.NoError(err)
+	bc.EXPECT().TipHeight().Return(epochHeight).AnyTimes()
+	bc.EXPECT().BlockHeaderByHeight(epochHeight).Return(&blk.Header, nil).AnyTimes()
+	key := candidatesutil.ConstructEpochKey(2)
+	sf.EXPECT().State(gomock.Any(), gomock.Any()).DoAndReturn(func(s interface{}, opts ...protocol.StateOption) (uint64, error) {
+		cfg, err := protocol.CreateStateConfig(opts...)
+		if err != nil {
+			return 0, err
+		}
+		if string(cfg.Key) != string(key[:]) {
+			return 0, state.ErrStateNotExist
+		}
+		*s.(*state.CandidateList) = candidates
+		return epochHeight, nil
+	}).AnyTimes()
+
+	_, err = core.EpochCandidateProof(0)
+	require.Equal(codes.InvalidArgument, status.Code(err))
+	_, err = core.EpochCandidateProof(3)
+	require.Equal(codes.NotFound, status.Code(err))
+	_, err = core.EpochCandidateProof(1)
+	require.Equal(codes.NotFound, status.Code(err))
+
+	ret, err := core.EpochCandidateProof(2)
+	require.NoError(err)
+	require.EqualValues(2, ret.Epoch)
+	require.Equal(epochHeight, ret.Height)
+	require.Equal(hex.EncodeToString(root[:]), ret.Root)
+	// the header can be verified by the light client
+	headerBytes, err := hex.DecodeString(ret.Header)
+	require.NoError(err)
+	header := &block.Header{}
+	require.NoError(header.Deserialize(headerBytes))
+	require.True(header.VerifySignature())
+	require.Equal(root, header.CandidatesRoot())
+	// each candidate is verified against the root in the header
+	require.Len(ret.Candidates, len(candidates))
+	for i, c := range ret.Candidates {
+		require.Equal(candidates[i].Address, c.Address)
+		require.Equal(candidates[i].Votes.String(), c.Votes)
+		data, err := hex.DecodeString(c.Data)
+		require.NoError(err)
+		proof := make([]hash.Hash256, len(c.Proof))
+		for j := range c.Proof {
+			b, err := hex.DecodeString(c.Proof[j])
+			require.NoError(err)
+			proof[j] = hash.BytesToHash256(b)
+		}
+		require.True(crypto.VerifyMerkleProof(header.CandidatesRoot(), hash.Hash256b(data), c.Index, proof))
+		require.False(crypto.VerifyMerkleProof(header.CandidatesRoot(), hash.Hash256b(data[1:]), c.Index, proof))
+	}
+
+	// the snapshot mismatching the root in header
+	candidates[0].Votes = big.NewInt(1)
+	_, err = core.EpochCandidateProof(2)
+	require.Equal(codes.Internal, status.Code(err))
+}
@@ -7,6 +7,7 @@ package api
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/hex"
 	"fmt"
 	"math"
@@ -30,6 +31,7 @@ import (
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/keepalive"
@@ -38,6 +40,7 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/api/apipb"
 	"github.com/iotexproject/iotex-core/v2/api/logfilter"
 	apitypes "github.com/iotexproject/iotex-core/v2/api/types"
 	"github.com/iotexproject/iotex-core/v2/blockchain/block"
@@ -54,6 +57,14 @@ type (
 		svr  *grpc.Server
 	}
 
+	// GRPCServerOption sets the grpc server
+	GRPCServerOption func(*grpcServerConfig)
+
+	grpcServerConfig struct {
+		tlsConfig     *tls.Config
+		adminServices []string
+	}
+
 	// GRPCHandler contains the pointer to api coreservice
 	gRPCHandler struct {
 		coreService CoreService
@@ -81,33 +92,73 @@ func RecoveryInterceptor() grpc_recovery.Option {
 	})
 }
 
+// WithGRPCTLS serves the grpc server over TLS, and the admin services require verified client certificate
+// if the tls config verifies client certificates
+func WithGRPCTLS(tlsConfig *tls.Config, adminServices []string) GRPCServerOption {
+	return func(cfg *grpcServerConfig) {
+		cfg.tlsConfig = tlsConfig
+		cfg.adminServices = adminServices
+	}
+}
+
 // NewGRPCServer creates a new grpc server
-func NewGRPCServer(core CoreService, bds *blockDAOService, grpcPort int) *GRPCServer {
+func NewGRPCServer(core CoreService, bds *blockDAOService, grpcPort int, opts ...GRPCServerOption) *GRPCServer {
 	if grpcPort == 0 {
 		return nil
 	}
-
-	gSvr := grpc.NewServer(
-		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(
-			grpc_prometheus.StreamServerInterceptor,
-			otelgrpc.StreamServerInterceptor(),
-			grpc_recovery.StreamServerInterceptor(RecoveryInterceptor()),
-		)),
-		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
-			grpc_prometheus.UnaryServerInterceptor,
-			otelgrpc.UnaryServerInterceptor(),
-			grpc_recovery.UnaryServerInterceptor(RecoveryInterceptor()),
-		)),
+	cfg := grpcServerConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	metrics := newMetricsInterceptor(core)
+	streamInterceptors := []grpc.StreamServerInterceptor{
+		grpc_prometheus.StreamServerInterceptor,
+		otelgrpc.StreamServerInterceptor(),
+		metrics.stream(),
+		grpc_recovery.StreamServerInterceptor(RecoveryInterceptor()),
+	}
+	unaryInterceptors := []grpc.UnaryServerInterceptor{
+		grpc_prometheus.UnaryServerInterceptor,
+		otelgrpc.UnaryServerInterceptor(),
+		metrics.unary(),
+		grpc_recovery.UnaryServerInterceptor(RecoveryInterceptor()),
+	}
+	serverOpts := []grpc.ServerOption{
 		grpc.KeepaliveEnforcementPolicy(kaep),
 		grpc.KeepaliveParams(kasp),
+	}
+	if cfg.tlsConfig != nil {
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(cfg.tlsConfig)))
+		if cfg.tlsConfig.ClientCAs != nil {
+			auth := &clientAuthInterceptor{services: cfg.adminServices}
+			streamInterceptors = append(streamInterceptors, auth.stream())
+			unaryInterceptors = append(unaryInterceptors, auth.unary())
+		}
+	}
+	serverOpts = append(serverOpts,
+		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(streamInterceptors...)),
+		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(unaryInterceptors...)),
 	)
+	gSvr := grpc.NewServer(serverOpts...)
 
 	//serviceName: grpc.health.v1.Health
 	grpc_health_v1.RegisterHealthServer(gSvr, health.NewServer())
-	iotexapi.RegisterAPIServiceServer(gSvr, newGRPCHandler(core))
+	handler := newGRPCHandler(core)
+	iotexapi.RegisterAPIServiceServer(gSvr, handler)
+	gSvr.RegisterService(&_blockStreamServiceDesc, handler)
+	apipb.RegisterStakingServiceServer(gSvr, newStakingService(core))
+	apipb.RegisterLogStreamServiceServer(gSvr, newLogStreamService(core))
+	apipb.RegisterActionServiceServer(gSvr, newActionService(core))
+	apipb.RegisterStateServiceServer(gSvr, newStateService(core))
+	apipb.RegisterConsensusServiceServer(gSvr, newConsensusService(core))
+	apipb.RegisterSyncServiceServer(gSvr, newSyncService(core))
 	if bds != nil {
 		blockdaopb.RegisterBlockDAOServiceServer(gSvr, bds)
 	}
+	if cfg.tlsConfig != nil && cfg.tlsConfig.ClientCAs != nil {
+		// the admin service is only served to the clients with verified certificate
+		apipb.RegisterAdminServiceServer(gSvr, newAdminService(core))
+	}
 	grpc_prometheus.EnableHandlingTimeHistogram()
 	grpc_prometheus.Register(gSvr)
 	reflection.Register(gSvr)
@@ -340,18 +391,15 @@ func (svr *gRPCHandler) GetReceiptByAction(ctx context.Context, in *iotexapi.Get
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
-	receipt, err := svr.coreService.ReceiptByActionHash(actHash)
-	if err != nil {
-		return nil, status.Error(codes.NotFound, err.Error())
-	}
-	blkHash, err := svr.coreService.BlockHashByBlockHeight(receipt.BlockHeight)
+	act, err := svr.coreService.BlockByActionHash(actHash)
 	if err != nil {
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
+	blkHash := act.Block.HashBlock()
 
 	return &iotexapi.GetReceiptByActionResponse{
 		ReceiptInfo: &iotexapi.ReceiptInfo{
-			Receipt: receipt.ConvertToReceiptPb(),
+			Receipt: act.Receipt.ConvertToReceiptPb(),
 			BlkHash: hex.EncodeToString(blkHash[:]),
 		},
 	}, nil
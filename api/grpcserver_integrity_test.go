@@ -2150,6 +2150,7 @@ func TestGrpcServer_GetEpochMetaIntegrity(t *testing.T) {
 		coreService, ok := svr.core.(*coreService)
 		require.True(ok)
 		coreService.readCache.Clear()
+		coreService.readStateCache.clear()
 		res, err := grpcHandler.GetEpochMeta(context.Background(), &iotexapi.GetEpochMetaRequest{EpochNumber: test.EpochNumber})
 		require.NoError(err)
 		require.Equal(test.epochData.Num, res.EpochData.Num)
@@ -2342,6 +2343,30 @@ func TestGrpcServer_GetActionByActionHashIntegrity(t *testing.T) {
 	}
 }
 
+func TestGrpcServer_BlockByActionHashIntegrity(t *testing.T) {
+	require := require.New(t)
+	cfg := newConfig()
+	cfg.api.GRPCPort = testutil.RandomPort()
+	svr, _, _, _, _, _, bfIndexFile, err := createServerV2(cfg, false)
+	require.NoError(err)
+	defer func() {
+		testutil.CleanupPath(bfIndexFile)
+	}()
+
+	for _, test := range _getActionByActionHashTest {
+		act, err := svr.core.BlockByActionHash(test.h)
+		require.NoError(err)
+		require.Equal(test.expectedNounce, act.Action.Envelope.Nonce())
+		h, err := act.Block.Actions[act.Index].Hash()
+		require.NoError(err)
+		require.Equal(test.h, h)
+		require.Equal(test.h, act.Receipt.ActionHash)
+		require.Equal(act.Block.Height(), act.Receipt.BlockHeight)
+	}
+	_, err = svr.core.BlockByActionHash(hash.ZeroHash256)
+	require.ErrorIs(err, ErrNotFound)
+}
+
 func TestGrpcServer_GetTransactionLogByActionHashIntegrity(t *testing.T) {
 	require := require.New(t)
 	cfg := newConfig()
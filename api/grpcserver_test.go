@@ -417,8 +417,7 @@ func TestGrpcServer_GetReceiptByAction(t *testing.T) {
 	}
 
 	t.Run("get receipt by action", func(t *testing.T) {
-		core.EXPECT().ReceiptByActionHash(gomock.Any()).Return(receipt, nil)
-		core.EXPECT().BlockHashByBlockHeight(gomock.Any()).Return(hash.ZeroHash256, nil)
+		core.EXPECT().BlockByActionHash(gomock.Any()).Return(&apitypes.ActionInBlock{Block: &block.Block{}, Receipt: receipt}, nil)
 
 		res, err := grpcSvr.GetReceiptByAction(context.Background(), &iotexapi.GetReceiptByActionRequest{})
 		require.NoError(err)
@@ -428,21 +427,13 @@ func TestGrpcServer_GetReceiptByAction(t *testing.T) {
 		require.Equal(receipt.GasConsumed, res.ReceiptInfo.Receipt.GasConsumed)
 		require.Equal(receipt.ContractAddress, res.ReceiptInfo.Receipt.ContractAddress)
 		require.Equal(receipt.TxIndex, res.ReceiptInfo.Receipt.TxIndex)
-		require.Equal(hex.EncodeToString(hash.ZeroHash256[:]), res.ReceiptInfo.BlkHash)
-	})
-
-	t.Run("failed to get block hash by block height", func(t *testing.T) {
-		expectedErr := errors.New("failed to get block hash by block height")
-		core.EXPECT().ReceiptByActionHash(gomock.Any()).Return(receipt, nil)
-		core.EXPECT().BlockHashByBlockHeight(gomock.Any()).Return(hash.ZeroHash256, expectedErr)
-
-		_, err := grpcSvr.GetReceiptByAction(context.Background(), &iotexapi.GetReceiptByActionRequest{})
-		require.Contains(err.Error(), expectedErr.Error())
+		blkHash := (&block.Block{}).HashBlock()
+		require.Equal(hex.EncodeToString(blkHash[:]), res.ReceiptInfo.BlkHash)
 	})
 
 	t.Run("failed to get receipt by action hash", func(t *testing.T) {
 		expectedErr := errors.New("failed to get receipt by action hash")
-		core.EXPECT().ReceiptByActionHash(gomock.Any()).Return(receipt, expectedErr)
+		core.EXPECT().BlockByActionHash(gomock.Any()).Return(nil, expectedErr)
 
 		_, err := grpcSvr.GetReceiptByAction(context.Background(), &iotexapi.GetReceiptByActionRequest{})
 		require.Contains(err.Error(), expectedErr.Error())
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+type (
+	// SyncStatus is the sync status of the node and of the indexers serving the api
+	SyncStatus struct {
+		StartingHeight uint64 `json:"startingHeight"`
+		CurrentHeight  uint64 `json:"currentHeight"`
+		HighestHeight  uint64 `json:"highestHeight"`
+		StateHeight    uint64 `json:"stateHeight"`
+		// HeaderHeight and HeaderHash are the tip of the headers synced ahead of the blocks in header-first sync mode
+		HeaderHeight uint64 `json:"headerHeight"`
+		HeaderHash   string `json:"headerHash,omitempty"`
+		// IndexerHeights are the heights of the indexers by name
+		IndexerHeights map[string]uint64 `json:"indexerHeights"`
+	}
+
+	// healthHandler serves the health and readiness of the api server for load balancers
+	healthHandler struct {
+		coreService CoreService
+		maxBlockLag uint64
+	}
+
+	healthResult struct {
+		Status string `json:"status"`
+		*SyncStatus
+		Error string `json:"error,omitempty"`
+	}
+)
+
+// BlockLag returns the number of blocks the node falls behind its peers
+func (s *SyncStatus) BlockLag() uint64 {
+	if s.CurrentHeight >= s.HighestHeight {
+		return 0
+	}
+	return s.HighestHeight - s.CurrentHeight
+}
+
+// IndexerLag returns the number of blocks the state or the slowest indexer falls behind the chain
+func (s *SyncStatus) IndexerLag() uint64 {
+	var lag uint64
+	for _, height := range append([]uint64{s.StateHeight}, mapValues(s.IndexerHeights)...) {
+		if height < s.CurrentHeight {
+			lag = max(lag, s.CurrentHeight-height)
+		}
+	}
+	return lag
+}
+
+func mapValues(m map[string]uint64) []uint64 {
+	values := make([]uint64, 0, len(m))
+	for _, v := range m {
+		values = append(values, v)
+	}
+	return values
+}
+
+func newHealthHandler(coreService CoreService, maxBlockLag uint64) *healthHandler {
+	return &healthHandler{
+		coreService: coreService,
+		maxBlockLag: maxBlockLag,
+	}
+}
+
+// handler returns the http handler serving /health and /ready alongside the api handler
+func (h *healthHandler) handler(apiHandler http.Handler) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", h.health)
+	mux.HandleFunc("/ready", h.ready)
+	mux.Handle("/", apiHandler)
+	return mux
+}
+
+// health reports whether the node is able to serve, regardless of its sync status
+func (h *healthHandler) health(w http.ResponseWriter, _ *http.Request) {
+	status, err := h.coreService.SyncStatus()
+	if err != nil {
+		writeHealth(w, http.StatusServiceUnavailable, &healthResult{Status: "unhealthy", Error: err.Error()})
+		return
+	}
+	writeHealth(w, http.StatusOK, &healthResult{Status: "ok", SyncStatus: status})
+}
+
+// ready reports whether the node and its indexers catch up with the chain, so requests could be routed to it
+func (h *healthHandler) ready(w http.ResponseWriter, _ *http.Request) {
+	status, err := h.coreService.SyncStatus()
+	if err != nil {
+		writeHealth(w, http.StatusServiceUnavailable, &healthResult{Status: "unhealthy", Error: err.Error()})
+		return
+	}
+	if status.BlockLag() > h.maxBlockLag || status.IndexerLag() > h.maxBlockLag {
+		writeHealth(w, http.StatusServiceUnavailable, &healthResult{Status: "syncing", SyncStatus: status})
+		return
+	}
+	writeHealth(w, http.StatusOK, &healthResult{Status: "ok", SyncStatus: status})
+}
+
+func writeHealth(w http.ResponseWriter, code int, res *healthResult) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(res); err != nil {
+		log.Logger("api").Warn("failed to write health response.", zap.Error(err))
+	}
+}
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHealthHandler(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := NewMockCoreService(ctrl)
+	var served bool
+	h := newHealthHandler(core, 2).handler(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		served = true
+	}))
+	get := func(path string) (int, *healthResult) {
+		resp := httptest.NewRecorder()
+		h.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "http://url.com"+path, nil))
+		res := &healthResult{}
+		require.NoError(json.Unmarshal(resp.Body.Bytes(), res))
+		return resp.Code, res
+	}
+
+	t.Run("synced", func(t *testing.T) {
+		core.EXPECT().SyncStatus().Return(&SyncStatus{
+			CurrentHeight:  10,
+			HighestHeight:  11,
+			StateHeight:    10,
+			IndexerHeights: map[string]uint64{"index": 9},
+		}, nil).Times(2)
+		for _, path := range []string{"/health", "/ready"} {
+			code, res := get(path)
+			require.Equal(http.StatusOK, code)
+			require.Equal("ok", res.Status)
+			require.Equal(uint64(11), res.HighestHeight)
+			require.Equal(uint64(9), res.IndexerHeights["index"])
+		}
+	})
+
+	t.Run("syncing", func(t *testing.T) {
+		core.EXPECT().SyncStatus().Return(&SyncStatus{
+			CurrentHeight: 10,
+			HighestHeight: 20,
+			StateHeight:   10,
+		}, nil).Times(2)
+		code, res := get("/health")
+		require.Equal(http.StatusOK, code)
+		require.Equal("ok", res.Status)
+		code, res = get("/ready")
+		require.Equal(http.StatusServiceUnavailable, code)
+		require.Equal("syncing", res.Status)
+	})
+
+	t.Run("indexer lagging", func(t *testing.T) {
+		core.EXPECT().SyncStatus().Return(&SyncStatus{
+			CurrentHeight:  10,
+			HighestHeight:  10,
+			StateHeight:    10,
+			IndexerHeights: map[string]uint64{"index": 10, "bloomfilter": 7},
+		}, nil)
+		code, res := get("/ready")
+		require.Equal(http.StatusServiceUnavailable, code)
+		require.Equal("syncing", res.Status)
+	})
+
+	t.Run("unhealthy", func(t *testing.T) {
+		core.EXPECT().SyncStatus().Return(nil, errors.New("db closed")).Times(2)
+		for _, path := range []string{"/health", "/ready"} {
+			code, res := get(path)
+			require.Equal(http.StatusServiceUnavailable, code)
+			require.Equal("unhealthy", res.Status)
+			require.Equal("db closed", res.Error)
+		}
+	})
+
+	t.Run("api", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		h.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "http://url.com/", nil))
+		require.True(served)
+	})
+}
+
+func TestSyncStatusLag(t *testing.T) {
+	require := require.New(t)
+	s := &SyncStatus{
+		CurrentHeight:  10,
+		HighestHeight:  8,
+		StateHeight:    9,
+		IndexerHeights: map[string]uint64{"index": 6, "bloomfilter": 11},
+	}
+	require.Zero(s.BlockLag())
+	require.Equal(uint64(4), s.IndexerLag())
+	s.HighestHeight = 15
+	require.Equal(uint64(5), s.BlockLag())
+}
@@ -2,11 +2,13 @@ package api
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"net/http"
 	"strconv"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 
 	apitypes "github.com/iotexproject/iotex-core/v2/api/types"
@@ -21,14 +23,24 @@ type (
 		svr *http.Server
 	}
 
+	// HTTPServerOption sets the http server
+	HTTPServerOption func(*HTTPServer)
+
 	// hTTPHandler handles requests from http protocol
 	hTTPHandler struct {
 		msgHandler Web3Handler
 	}
 )
 
+// WithHTTPTLS serves the http server over TLS
+func WithHTTPTLS(tlsConfig *tls.Config) HTTPServerOption {
+	return func(hSvr *HTTPServer) {
+		hSvr.svr.TLSConfig = tlsConfig
+	}
+}
+
 // NewHTTPServer creates a new http server
-func NewHTTPServer(route string, port int, handler http.Handler) *HTTPServer {
+func NewHTTPServer(route string, port int, handler http.Handler, opts ...HTTPServerOption) *HTTPServer {
 	if port == 0 {
 		return nil
 	}
@@ -36,15 +48,26 @@ func NewHTTPServer(route string, port int, handler http.Handler) *HTTPServer {
 	mux.Handle("/"+route, handler)
 
 	svr := httputil.NewServer(":"+strconv.Itoa(port), mux, httputil.ReadHeaderTimeout(10*time.Second))
-	return &HTTPServer{
+	hSvr := &HTTPServer{
 		svr: &svr,
 	}
+	for _, opt := range opts {
+		opt(hSvr)
+	}
+	return hSvr
 }
 
 // Start starts the http server
 func (hSvr *HTTPServer) Start(_ context.Context) error {
 	go func() {
-		if err := hSvr.svr.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		var err error
+		if hSvr.svr.TLSConfig != nil {
+			// the certificate is provided by TLSConfig
+			err = hSvr.svr.ListenAndServeTLS("", "")
+		} else {
+			err = hSvr.svr.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
 			log.L().Fatal("Node failed to serve.", zap.Error(err))
 		}
 	}()
@@ -71,10 +94,10 @@ func (handler *hTTPHandler) ServeHTTP(w http.ResponseWriter, req *http.Request)
 
 	ctx, span := tracer.NewSpan(req.Context(), "http")
 	defer span.End()
+	span.SetAttributes(attribute.String("api.client", req.RemoteAddr))
 	if err := handler.msgHandler.HandlePOSTReq(ctx, req.Body,
 		apitypes.NewResponseWriter(
 			func(resp interface{}) (int, error) {
-				w.Header().Set("Access-Control-Allow-Origin", "*")
 				w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 				raw, err := json.Marshal(resp)
 				if err != nil {
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/v2/actpool"
+)
+
+// InclusionLatency is the distribution of the latencies from the actions arriving at the actpool of the node to
+// being included in the blocks of a producer, in milliseconds
+type InclusionLatency struct {
+	Producer string `json:"producer"`
+	// Blocks and Actions are the numbers of the blocks and the actions of the producer observed by the node
+	Blocks  uint64 `json:"blocks"`
+	Actions uint64 `json:"actions"`
+	// Samples is the number of the latest actions the distribution is calculated from
+	Samples int   `json:"samples"`
+	Mean    int64 `json:"mean"`
+	P50     int64 `json:"p50"`
+	P90     int64 `json:"p90"`
+	P99     int64 `json:"p99"`
+	Max     int64 `json:"max"`
+}
+
+// InclusionLatencies returns the inclusion latency distributions of the producers observed by the actpool, a producer
+// censoring or delaying the actions of the users stands out with a long tail
+func (core *coreService) InclusionLatencies() ([]*InclusionLatency, error) {
+	reporter, ok := core.ap.(actpool.InclusionLatencyReporter)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "actpool does not track inclusion latencies")
+	}
+	latencies := reporter.InclusionLatencies()
+	if latencies == nil {
+		return nil, status.Error(codes.Unavailable, "tracking inclusion latencies is disabled")
+	}
+	ret := make([]*InclusionLatency, len(latencies))
+	for i, l := range latencies {
+		ret[i] = &InclusionLatency{
+			Producer: l.Producer,
+			Blocks:   l.Blocks,
+			Actions:  l.Actions,
+			Samples:  l.Samples,
+			Mean:     l.Mean.Milliseconds(),
+			P50:      l.P50.Milliseconds(),
+			P90:      l.P90.Milliseconds(),
+			P99:      l.P99.Milliseconds(),
+			Max:      l.Max.Milliseconds(),
+		}
+	}
+	return ret, nil
+}
+
+func (svr *web3Handler) inclusionLatencies() (interface{}, error) {
+	return svr.coreService.InclusionLatencies()
+}
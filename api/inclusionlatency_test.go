@@ -0,0 +1,40 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/v2/actpool"
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/test/mock/mock_actpool"
+	"github.com/iotexproject/iotex-core/v2/test/mock/mock_chainmanager"
+)
+
+func TestCoreService_InclusionLatencies(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	core := &coreService{ap: mock_actpool.NewMockActPool(ctrl)}
+	_, err := core.InclusionLatencies()
+	require.Equal(codes.Unimplemented, status.Code(err))
+
+	sf := mock_chainmanager.NewMockStateReader(ctrl)
+	cfg := actpool.DefaultConfig
+	cfg.InclusionLatencyWindow = 0
+	ap, err := actpool.NewActPool(genesis.TestDefault(), sf, cfg)
+	require.NoError(err)
+	core.ap = ap
+	_, err = core.InclusionLatencies()
+	require.Equal(codes.Unavailable, status.Code(err))
+
+	ap, err = actpool.NewActPool(genesis.TestDefault(), sf, actpool.DefaultConfig)
+	require.NoError(err)
+	core.ap = ap
+	ret, err := core.InclusionLatencies()
+	require.NoError(err)
+	require.Empty(ret)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
+	"github.com/iotexproject/iotex-core/v2/action"
 	apitypes "github.com/iotexproject/iotex-core/v2/api/types"
 	"github.com/iotexproject/iotex-core/v2/blockchain/block"
 	"github.com/iotexproject/iotex-core/v2/pkg/fastrand"
@@ -85,6 +86,23 @@ func (cl *chainListener) ReceiveBlock(blk *block.Block) error {
 	return nil
 }
 
+// ReceiveAction handles the pending action
+func (cl *chainListener) ReceiveAction(selp *action.SealedEnvelope) error {
+	// pass the action to every responder interested in pending actions
+	cl.streamMap.Range(func(key, value interface{}) error {
+		r, ok := value.(apitypes.ActionResponder)
+		if !ok {
+			return nil
+		}
+		err := r.RespondAction(key.(string), selp)
+		if err != nil {
+			log.L().Error("responder failed to process action", zap.Error(err))
+		}
+		return err
+	})
+	return nil
+}
+
 // AddResponder adds a new responder
 func (cl *chainListener) AddResponder(responder apitypes.Responder) (string, error) {
 	cl.mu.Lock()
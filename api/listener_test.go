@@ -6,9 +6,13 @@
 package api
 
 import (
+	"encoding/hex"
+	"math/big"
 	"testing"
 
+	"github.com/iotexproject/iotex-core/v2/action"
 	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
 	mock_apitypes "github.com/iotexproject/iotex-core/v2/test/mock/mock_apiresponder"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -59,6 +63,43 @@ func TestChainListener(t *testing.T) {
 	})
 }
 
+func TestChainListenerReceiveAction(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	listener := NewChainListener(2)
+	// block responders are not notified of pending actions
+	responder := mock_apitypes.NewMockResponder(ctrl)
+	responder.EXPECT().Respond(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	_, err := listener.AddResponder(responder)
+	r.NoError(err)
+	var received []interface{}
+	id, err := listener.AddResponder(NewWeb3PendingActionListener(
+		func(in interface{}) (int, error) {
+			received = append(received, in)
+			return 0, nil
+		},
+		func(selp *action.SealedEnvelope) (interface{}, error) {
+			h, err := selp.Hash()
+			return hex.EncodeToString(h[:]), err
+		},
+	))
+	r.NoError(err)
+
+	selp, err := action.SignedTransfer(identityset.Address(1).String(), identityset.PrivateKey(1), 1, big.NewInt(1), nil, 10000, big.NewInt(1))
+	r.NoError(err)
+	r.NoError(listener.ReceiveAction(selp))
+	r.Len(received, 1)
+	res, ok := received[0].(*streamResponse)
+	r.True(ok)
+	r.Equal(id, res.id)
+	h, _ := selp.Hash()
+	r.Equal(hex.EncodeToString(h[:]), res.result)
+	// pending action responders ignore new blocks
+	r.NoError(listener.ReceiveBlock(&block.Block{}))
+	r.Len(received, 1)
+}
+
 func TestRandID(t *testing.T) {
 	require := require.New(t)
 
@@ -0,0 +1,60 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"encoding/hex"
+
+	"github.com/iotexproject/go-pkgs/util"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+)
+
+// _logsCursorLen is the length of an encoded logs cursor, 8 bytes of height followed by 8 bytes of index
+const _logsCursorLen = 16
+
+var (
+	// ErrInvalidLogsCursor indicates the logs cursor is malformed or out of the query range
+	ErrInvalidLogsCursor = errors.New("invalid logs cursor")
+	// ErrLogsQueryLimitExceeded indicates the logs query cannot finish within the query limits
+	ErrLogsQueryLimitExceeded = errors.New("logs query exceeds limit")
+)
+
+// LogsCursor is the position to resume a logs query from
+type LogsCursor struct {
+	// Height is the height of the block to resume from
+	Height uint64
+	// Index is the number of matched logs in the block which have been returned
+	Index uint64
+}
+
+// String encodes the cursor into a continuation token
+func (c *LogsCursor) String() string {
+	b := make([]byte, 0, _logsCursorLen)
+	b = append(b, byteutil.Uint64ToBytesBigEndian(c.Height)...)
+	b = append(b, byteutil.Uint64ToBytesBigEndian(c.Index)...)
+	return "0x" + hex.EncodeToString(b)
+}
+
+// ParseLogsCursor decodes a continuation token into a cursor
+func ParseLogsCursor(s string) (*LogsCursor, error) {
+	b, err := hex.DecodeString(util.Remove0xPrefix(s))
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidLogsCursor, err.Error())
+	}
+	if len(b) != _logsCursorLen {
+		return nil, errors.Wrapf(ErrInvalidLogsCursor, "invalid length %d", len(b))
+	}
+	c := &LogsCursor{
+		Height: byteutil.BytesToUint64BigEndian(b[:8]),
+		Index:  byteutil.BytesToUint64BigEndian(b[8:]),
+	}
+	if c.Height == 0 {
+		return nil, errors.Wrap(ErrInvalidLogsCursor, "zero height")
+	}
+	return c, nil
+}
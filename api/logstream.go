@@ -0,0 +1,237 @@
+package api
+
+import (
+	"io"
+	"sync"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/api/apipb"
+	"github.com/iotexproject/iotex-core/v2/api/logfilter"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+)
+
+const (
+	_defaultLogStreamWindow = 64
+	_maxLogStreamWindow     = 1024
+)
+
+// errLogStreamClosed indicates the client closes the sending side of the stream, which ends the stream
+var errLogStreamClosed = errors.New("log stream is closed by client")
+
+type (
+	// logStreamService streams the logs from a past height, and keeps streaming the logs of new blocks
+	logStreamService struct {
+		coreService CoreService
+	}
+
+	// blockNotifier is notified of new blocks by the chain listener, it doesn't carry the block so
+	// a slow stream never blocks the listener
+	blockNotifier struct {
+		notify   chan struct{}
+		exit     chan struct{}
+		exitOnce sync.Once
+	}
+
+	// logStream sends the logs, and waits for the acknowledgment once window responses are in flight
+	logStream struct {
+		stream  apipb.LogStreamService_StreamLogsServer
+		window  uint64
+		sent    uint64
+		acked   uint64
+		acks    chan uint64
+		recvErr chan error
+	}
+)
+
+func newLogStreamService(core CoreService) *logStreamService {
+	return &logStreamService{
+		coreService: core,
+	}
+}
+
+func newBlockNotifier() *blockNotifier {
+	return &blockNotifier{
+		notify: make(chan struct{}, 1),
+		exit:   make(chan struct{}),
+	}
+}
+
+// Respond to new block
+func (n *blockNotifier) Respond(string, *block.Block) error {
+	select {
+	case n.notify <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Exit closes the exit channel
+func (n *blockNotifier) Exit() {
+	n.exitOnce.Do(func() {
+		close(n.exit)
+	})
+}
+
+// StreamLogs replays the matched logs from the height, then streams the logs of new blocks. The first request
+// carries the filter, and the following requests acknowledge the responses processed by the client
+func (service *logStreamService) StreamLogs(stream apipb.LogStreamService_StreamLogsServer) error {
+	in, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	if in.GetFilter() == nil {
+		return status.Error(codes.InvalidArgument, "empty filter")
+	}
+	window := uint64(in.GetWindow())
+	switch {
+	case window == 0:
+		window = _defaultLogStreamWindow
+	case window > _maxLogStreamWindow:
+		window = _maxLogStreamWindow
+	}
+	// subscribe before reading the tip, so no block is missed between the replay and the live logs
+	notifier := newBlockNotifier()
+	chainListener := service.coreService.ChainListener()
+	id, err := chainListener.AddResponder(notifier)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	defer chainListener.RemoveResponder(id)
+
+	var (
+		filter    = logfilter.NewLogFilter(in.GetFilter())
+		liveStart = service.coreService.TipHeight() + 1
+		next      = in.GetFromHeight()
+	)
+	if next == 0 {
+		next = liveStart
+	} else if next > liveStart {
+		return status.Errorf(codes.InvalidArgument, "from height %d exceeds tip height %d", next, liveStart-1)
+	}
+	ls := &logStream{
+		stream:  stream,
+		window:  window,
+		acks:    make(chan uint64),
+		recvErr: make(chan error, 1),
+	}
+	go ls.receiveAcks()
+	if err := service.streamLogs(ls, notifier, filter, next, liveStart); err != nil && !errors.Is(err, errLogStreamClosed) {
+		return err
+	}
+	return nil
+}
+
+func (service *logStreamService) streamLogs(ls *logStream, notifier *blockNotifier, filter *logfilter.LogFilter, next, liveStart uint64) error {
+	for {
+		tip := service.coreService.TipHeight()
+		for next <= tip {
+			var (
+				logs   []*action.Log
+				hashes []hash.Hash256
+				cursor *LogsCursor
+				err    error
+			)
+			for {
+				logs, hashes, cursor, err = service.coreService.LogsInRangeWithCursor(filter, next, tip, cursor)
+				if err != nil {
+					return status.Error(codes.Internal, err.Error())
+				}
+				for i, l := range logs {
+					if err := ls.send(&apipb.LogStreamResponse{
+						Log:  toLogPb(l, hashes[i]),
+						Live: l.BlockHeight >= liveStart,
+					}); err != nil {
+						return err
+					}
+				}
+				if cursor == nil {
+					break
+				}
+			}
+			next = tip + 1
+		}
+		if err := ls.wait(notifier); err != nil {
+			return err
+		}
+	}
+}
+
+func (ls *logStream) receiveAcks() {
+	ctx := ls.stream.Context()
+	for {
+		in, err := ls.stream.Recv()
+		if err != nil {
+			ls.recvErr <- err
+			return
+		}
+		select {
+		case ls.acks <- in.GetAck():
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (ls *logStream) ack(acked uint64) error {
+	if acked > ls.sent {
+		return status.Errorf(codes.InvalidArgument, "ack %d exceeds the number of sent responses %d", acked, ls.sent)
+	}
+	if acked > ls.acked {
+		ls.acked = acked
+	}
+	return nil
+}
+
+func (ls *logStream) handleRecvErr(err error) error {
+	if errors.Is(err, io.EOF) {
+		return errLogStreamClosed
+	}
+	return err
+}
+
+func (ls *logStream) send(resp *apipb.LogStreamResponse) error {
+	ctx := ls.stream.Context()
+	for ls.sent-ls.acked >= ls.window {
+		select {
+		case acked := <-ls.acks:
+			if err := ls.ack(acked); err != nil {
+				return err
+			}
+		case err := <-ls.recvErr:
+			return ls.handleRecvErr(err)
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		}
+	}
+	if err := ls.stream.Send(resp); err != nil {
+		return status.Error(codes.Aborted, err.Error())
+	}
+	ls.sent++
+	return nil
+}
+
+// wait waits for the next block, and handles the acknowledgments meanwhile
+func (ls *logStream) wait(notifier *blockNotifier) error {
+	ctx := ls.stream.Context()
+	for {
+		select {
+		case <-notifier.notify:
+			return nil
+		case acked := <-ls.acks:
+			if err := ls.ack(acked); err != nil {
+				return err
+			}
+		case err := <-ls.recvErr:
+			return ls.handleRecvErr(err)
+		case <-notifier.exit:
+			return status.Error(codes.Unavailable, "chain listener is stopped")
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		}
+	}
+}
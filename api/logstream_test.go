@@ -0,0 +1,155 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/api/apipb"
+	"github.com/iotexproject/iotex-core/v2/api/logfilter"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+)
+
+func TestLogStreamService_StreamLogs(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := NewMockCoreService(ctrl)
+	listener := NewChainListener(10)
+	require.NoError(listener.Start())
+	defer listener.Stop()
+
+	lis := bufconn.Listen(1 << 20)
+	gSvr := grpc.NewServer()
+	apipb.RegisterLogStreamServiceServer(gSvr, newLogStreamService(core))
+	go gSvr.Serve(lis)
+	defer gSvr.Stop()
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(err)
+	defer conn.Close()
+	client := apipb.NewLogStreamServiceClient(conn)
+
+	var tip atomic.Uint64
+	tip.Store(5)
+	core.EXPECT().TipHeight().DoAndReturn(tip.Load).AnyTimes()
+	core.EXPECT().ChainListener().Return(listener).AnyTimes()
+	// one log per block, at most 2 logs per query
+	core.EXPECT().LogsInRangeWithCursor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ *logfilter.LogFilter, start, end uint64, cursor *LogsCursor) ([]*action.Log, []hash.Hash256, *LogsCursor, error) {
+			if cursor != nil {
+				start = cursor.Height
+			}
+			var (
+				logs   []*action.Log
+				hashes []hash.Hash256
+			)
+			for h := start; h <= end; h++ {
+				if len(logs) == 2 {
+					return logs, hashes, &LogsCursor{Height: h}, nil
+				}
+				logs = append(logs, &action.Log{BlockHeight: h})
+				hashes = append(hashes, hash.Hash256b([]byte{byte(h)}))
+			}
+			return logs, hashes, nil, nil
+		}).AnyTimes()
+	filter := &iotexapi.LogsFilter{Address: []string{"io1"}}
+
+	t.Run("invalid request", func(t *testing.T) {
+		for _, in := range []*apipb.LogStreamRequest{
+			{},
+			{Filter: filter, FromHeight: 7},
+		} {
+			stream, err := client.StreamLogs(context.Background())
+			require.NoError(err)
+			require.NoError(stream.Send(in))
+			_, err = stream.Recv()
+			require.Equal(codes.InvalidArgument, status.Code(err))
+		}
+	})
+
+	t.Run("replay and live", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		stream, err := client.StreamLogs(ctx)
+		require.NoError(err)
+		require.NoError(stream.Send(&apipb.LogStreamRequest{Filter: filter, FromHeight: 2, Window: 2}))
+		recv := make(chan *apipb.LogStreamResponse)
+		go func() {
+			for {
+				resp, err := stream.Recv()
+				if err != nil {
+					close(recv)
+					return
+				}
+				recv <- resp
+			}
+		}()
+		expect := func(height uint64, live bool) {
+			select {
+			case resp := <-recv:
+				require.Equal(height, resp.GetLog().GetBlkHeight())
+				require.Equal(live, resp.GetLive())
+			case <-time.After(5 * time.Second):
+				require.FailNow("no log received")
+			}
+		}
+		expectNone := func() {
+			select {
+			case resp := <-recv:
+				require.FailNowf("unexpected log", "height %d", resp.GetLog().GetBlkHeight())
+			case <-time.After(100 * time.Millisecond):
+			}
+		}
+		expect(2, false)
+		expect(3, false)
+		// the window is full
+		expectNone()
+		require.NoError(stream.Send(&apipb.LogStreamRequest{Ack: 1}))
+		expect(4, false)
+		expectNone()
+		require.NoError(stream.Send(&apipb.LogStreamRequest{Ack: 3}))
+		expect(5, false)
+		require.NoError(stream.Send(&apipb.LogStreamRequest{Ack: 4}))
+		expectNone()
+
+		// switch to the new blocks
+		tip.Store(7)
+		require.NoError(listener.ReceiveBlock(&block.Block{}))
+		expect(6, true)
+		require.NoError(stream.Send(&apipb.LogStreamRequest{Ack: 5}))
+		expect(7, true)
+
+		// ack more than sent
+		require.NoError(stream.Send(&apipb.LogStreamRequest{Ack: 10}))
+		_, ok := <-recv
+		require.False(ok)
+		_, err = stream.Recv()
+		require.Equal(codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("closed by client", func(t *testing.T) {
+		stream, err := client.StreamLogs(context.Background())
+		require.NoError(err)
+		require.NoError(stream.Send(&apipb.LogStreamRequest{Filter: filter}))
+		require.NoError(stream.CloseSend())
+		_, err = stream.Recv()
+		require.Equal(io.EOF, err)
+	})
+}
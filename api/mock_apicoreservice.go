@@ -20,10 +20,18 @@ import (
 	address "github.com/iotexproject/iotex-address/address"
 	action "github.com/iotexproject/iotex-core/v2/action"
 	protocol "github.com/iotexproject/iotex-core/v2/action/protocol"
+	poll "github.com/iotexproject/iotex-core/v2/action/protocol/poll"
+	userop "github.com/iotexproject/iotex-core/v2/actpool/userop"
 	logfilter "github.com/iotexproject/iotex-core/v2/api/logfilter"
 	apitypes "github.com/iotexproject/iotex-core/v2/api/types"
 	block "github.com/iotexproject/iotex-core/v2/blockchain/block"
 	genesis "github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	consensusfsm "github.com/iotexproject/iotex-core/v2/consensus/consensusfsm"
+	scheme "github.com/iotexproject/iotex-core/v2/consensus/scheme"
+	forkmonitor "github.com/iotexproject/iotex-core/v2/forkmonitor"
+	nodeinfo "github.com/iotexproject/iotex-core/v2/nodeinfo"
+	p2p "github.com/iotexproject/iotex-core/v2/p2p"
+	factory "github.com/iotexproject/iotex-core/v2/state/factory"
 	iotexapi "github.com/iotexproject/iotex-proto/golang/iotexapi"
 	iotextypes "github.com/iotexproject/iotex-proto/golang/iotextypes"
 	gomock "go.uber.org/mock/gomock"
@@ -101,6 +109,36 @@ func (mr *MockCoreServiceMockRecorder) ActionByActionHash(h any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionByActionHash", reflect.TypeOf((*MockCoreService)(nil).ActionByActionHash), h)
 }
 
+// ActionProof mocks base method.
+func (m *MockCoreService) ActionProof(h hash.Hash256) (*ActionProof, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActionProof", h)
+	ret0, _ := ret[0].(*ActionProof)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActionProof indicates an expected call of ActionProof.
+func (mr *MockCoreServiceMockRecorder) ActionProof(h any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionProof", reflect.TypeOf((*MockCoreService)(nil).ActionProof), h)
+}
+
+// ActionResult mocks base method.
+func (m *MockCoreService) ActionResult(h hash.Hash256) (*ActionResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActionResult", h)
+	ret0, _ := ret[0].(*ActionResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActionResult indicates an expected call of ActionResult.
+func (mr *MockCoreServiceMockRecorder) ActionResult(h any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionResult", reflect.TypeOf((*MockCoreService)(nil).ActionResult), h)
+}
+
 // Actions mocks base method.
 func (m *MockCoreService) Actions(start, count uint64) ([]*iotexapi.ActionInfo, error) {
 	m.ctrl.T.Helper()
@@ -146,6 +184,34 @@ func (mr *MockCoreServiceMockRecorder) ActionsInActPool(actHashes any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionsInActPool", reflect.TypeOf((*MockCoreService)(nil).ActionsInActPool), actHashes)
 }
 
+// AddPeer mocks base method.
+func (m *MockCoreService) AddPeer(ctx context.Context, addr string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPeer", ctx, addr)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddPeer indicates an expected call of AddPeer.
+func (mr *MockCoreServiceMockRecorder) AddPeer(ctx, addr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPeer", reflect.TypeOf((*MockCoreService)(nil).AddPeer), ctx, addr)
+}
+
+// AddTrustedPeer mocks base method.
+func (m *MockCoreService) AddTrustedPeer(ctx context.Context, addr string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTrustedPeer", ctx, addr)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTrustedPeer indicates an expected call of AddTrustedPeer.
+func (mr *MockCoreServiceMockRecorder) AddTrustedPeer(ctx, addr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTrustedPeer", reflect.TypeOf((*MockCoreService)(nil).AddTrustedPeer), ctx, addr)
+}
+
 // BalanceAt mocks base method.
 func (m *MockCoreService) BalanceAt(ctx context.Context, addr address.Address, height uint64) (string, error) {
 	m.ctrl.T.Helper()
@@ -161,6 +227,21 @@ func (mr *MockCoreServiceMockRecorder) BalanceAt(ctx, addr, height interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BalanceAt", reflect.TypeOf((*MockCoreService)(nil).BalanceAt), ctx, addr, height)
 }
 
+// BlobBaseFee mocks base method.
+func (m *MockCoreService) BlobBaseFee() (*big.Int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlobBaseFee")
+	ret0, _ := ret[0].(*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BlobBaseFee indicates an expected call of BlobBaseFee.
+func (mr *MockCoreServiceMockRecorder) BlobBaseFee() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlobBaseFee", reflect.TypeOf((*MockCoreService)(nil).BlobBaseFee))
+}
+
 // BlobSidecarsByHeight mocks base method.
 func (m *MockCoreService) BlobSidecarsByHeight(height uint64) ([]*apitypes.BlobSidecarResult, error) {
 	m.ctrl.T.Helper()
@@ -176,6 +257,21 @@ func (mr *MockCoreServiceMockRecorder) BlobSidecarsByHeight(height any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlobSidecarsByHeight", reflect.TypeOf((*MockCoreService)(nil).BlobSidecarsByHeight), height)
 }
 
+// BlockByActionHash mocks base method.
+func (m *MockCoreService) BlockByActionHash(h hash.Hash256) (*apitypes.ActionInBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockByActionHash", h)
+	ret0, _ := ret[0].(*apitypes.ActionInBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BlockByActionHash indicates an expected call of BlockByActionHash.
+func (mr *MockCoreServiceMockRecorder) BlockByActionHash(h any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockByActionHash", reflect.TypeOf((*MockCoreService)(nil).BlockByActionHash), h)
+}
+
 // BlockByHash mocks base method.
 func (m *MockCoreService) BlockByHash(arg0 string) (*apitypes.BlockWithReceipts, error) {
 	m.ctrl.T.Helper()
@@ -280,6 +376,36 @@ func (mr *MockCoreServiceMockRecorder) ChainMeta() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainMeta", reflect.TypeOf((*MockCoreService)(nil).ChainMeta))
 }
 
+// ConsensusStatus mocks base method.
+func (m *MockCoreService) ConsensusStatus() (*scheme.ConsensusStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsensusStatus")
+	ret0, _ := ret[0].(*scheme.ConsensusStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsensusStatus indicates an expected call of ConsensusStatus.
+func (mr *MockCoreServiceMockRecorder) ConsensusStatus() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsensusStatus", reflect.TypeOf((*MockCoreService)(nil).ConsensusStatus))
+}
+
+// ConsensusTimeouts mocks base method.
+func (m *MockCoreService) ConsensusTimeouts() (*consensusfsm.Timeouts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsensusTimeouts")
+	ret0, _ := ret[0].(*consensusfsm.Timeouts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsensusTimeouts indicates an expected call of ConsensusTimeouts.
+func (mr *MockCoreServiceMockRecorder) ConsensusTimeouts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsensusTimeouts", reflect.TypeOf((*MockCoreService)(nil).ConsensusTimeouts))
+}
+
 // EVMNetworkID mocks base method.
 func (m *MockCoreService) EVMNetworkID() uint32 {
 	m.ctrl.T.Helper()
@@ -309,6 +435,21 @@ func (mr *MockCoreServiceMockRecorder) ElectionBuckets(epochNum any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ElectionBuckets", reflect.TypeOf((*MockCoreService)(nil).ElectionBuckets), epochNum)
 }
 
+// EpochCandidateProof mocks base method.
+func (m *MockCoreService) EpochCandidateProof(epochNum uint64) (*EpochCandidateProof, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EpochCandidateProof", epochNum)
+	ret0, _ := ret[0].(*EpochCandidateProof)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EpochCandidateProof indicates an expected call of EpochCandidateProof.
+func (mr *MockCoreServiceMockRecorder) EpochCandidateProof(epochNum any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EpochCandidateProof", reflect.TypeOf((*MockCoreService)(nil).EpochCandidateProof), epochNum)
+}
+
 // EpochMeta mocks base method.
 func (m *MockCoreService) EpochMeta(epochNum uint64) (*iotextypes.EpochData, uint64, []*iotexapi.BlockProducerInfo, error) {
 	m.ctrl.T.Helper()
@@ -393,6 +534,21 @@ func (mr *MockCoreServiceMockRecorder) EstimateMigrateStakeGasConsumption(arg0,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimateMigrateStakeGasConsumption", reflect.TypeOf((*MockCoreService)(nil).EstimateMigrateStakeGasConsumption), arg0, arg1, arg2)
 }
 
+// EstimatePruning mocks base method.
+func (m *MockCoreService) EstimatePruning(retentions []uint64) ([]*PruneEstimate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EstimatePruning", retentions)
+	ret0, _ := ret[0].([]*PruneEstimate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EstimatePruning indicates an expected call of EstimatePruning.
+func (mr *MockCoreServiceMockRecorder) EstimatePruning(retentions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimatePruning", reflect.TypeOf((*MockCoreService)(nil).EstimatePruning), retentions)
+}
+
 // FeeHistory mocks base method.
 func (m *MockCoreService) FeeHistory(ctx context.Context, blocks, lastBlock uint64, rewardPercentiles []float64) (uint64, [][]*big.Int, []*big.Int, []float64, []*big.Int, []float64, error) {
 	m.ctrl.T.Helper()
@@ -413,6 +569,21 @@ func (mr *MockCoreServiceMockRecorder) FeeHistory(ctx, blocks, lastBlock, reward
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FeeHistory", reflect.TypeOf((*MockCoreService)(nil).FeeHistory), ctx, blocks, lastBlock, rewardPercentiles)
 }
 
+// Forks mocks base method.
+func (m *MockCoreService) Forks() ([]forkmonitor.Fork, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Forks")
+	ret0, _ := ret[0].([]forkmonitor.Fork)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Forks indicates an expected call of Forks.
+func (mr *MockCoreServiceMockRecorder) Forks() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Forks", reflect.TypeOf((*MockCoreService)(nil).Forks))
+}
+
 // Genesis mocks base method.
 func (m *MockCoreService) Genesis() genesis.Genesis {
 	m.ctrl.T.Helper()
@@ -427,6 +598,36 @@ func (mr *MockCoreServiceMockRecorder) Genesis() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Genesis", reflect.TypeOf((*MockCoreService)(nil).Genesis))
 }
 
+// Heartbeats mocks base method.
+func (m *MockCoreService) Heartbeats() ([]nodeinfo.Heartbeat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Heartbeats")
+	ret0, _ := ret[0].([]nodeinfo.Heartbeat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Heartbeats indicates an expected call of Heartbeats.
+func (mr *MockCoreServiceMockRecorder) Heartbeats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Heartbeats", reflect.TypeOf((*MockCoreService)(nil).Heartbeats))
+}
+
+// InclusionLatencies mocks base method.
+func (m *MockCoreService) InclusionLatencies() ([]*InclusionLatency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InclusionLatencies")
+	ret0, _ := ret[0].([]*InclusionLatency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InclusionLatencies indicates an expected call of InclusionLatencies.
+func (mr *MockCoreServiceMockRecorder) InclusionLatencies() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InclusionLatencies", reflect.TypeOf((*MockCoreService)(nil).InclusionLatencies))
+}
+
 // LogsInBlockByHash mocks base method.
 func (m *MockCoreService) LogsInBlockByHash(filter *logfilter.LogFilter, blockHash hash.Hash256) ([]*action.Log, error) {
 	m.ctrl.T.Helper()
@@ -458,6 +659,65 @@ func (mr *MockCoreServiceMockRecorder) LogsInRange(filter, start, end, paginatio
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogsInRange", reflect.TypeOf((*MockCoreService)(nil).LogsInRange), filter, start, end, paginationSize)
 }
 
+// LogsInRangeWithCursor mocks base method.
+func (m *MockCoreService) LogsInRangeWithCursor(filter *logfilter.LogFilter, start, end uint64, cursor *LogsCursor) ([]*action.Log, []hash.Hash256, *LogsCursor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogsInRangeWithCursor", filter, start, end, cursor)
+	ret0, _ := ret[0].([]*action.Log)
+	ret1, _ := ret[1].([]hash.Hash256)
+	ret2, _ := ret[2].(*LogsCursor)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// LogsInRangeWithCursor indicates an expected call of LogsInRangeWithCursor.
+func (mr *MockCoreServiceMockRecorder) LogsInRangeWithCursor(filter, start, end, cursor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogsInRangeWithCursor", reflect.TypeOf((*MockCoreService)(nil).LogsInRangeWithCursor), filter, start, end, cursor)
+}
+
+// NextEpochPreview mocks base method.
+func (m *MockCoreService) NextEpochPreview(ctx context.Context) (*poll.EpochPreview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextEpochPreview", ctx)
+	ret0, _ := ret[0].(*poll.EpochPreview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NextEpochPreview indicates an expected call of NextEpochPreview.
+func (mr *MockCoreServiceMockRecorder) NextEpochPreview(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextEpochPreview", reflect.TypeOf((*MockCoreService)(nil).NextEpochPreview), ctx)
+}
+
+// PauseChain mocks base method.
+func (m *MockCoreService) PauseChain(pause bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PauseChain", pause)
+}
+
+// PauseChain indicates an expected call of PauseChain.
+func (mr *MockCoreServiceMockRecorder) PauseChain(pause any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseChain", reflect.TypeOf((*MockCoreService)(nil).PauseChain), pause)
+}
+
+// PeerScores mocks base method.
+func (m *MockCoreService) PeerScores() ([]p2p.PeerScore, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PeerScores")
+	ret0, _ := ret[0].([]p2p.PeerScore)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PeerScores indicates an expected call of PeerScores.
+func (mr *MockCoreServiceMockRecorder) PeerScores() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PeerScores", reflect.TypeOf((*MockCoreService)(nil).PeerScores))
+}
+
 // PendingActionByActionHash mocks base method.
 func (m *MockCoreService) PendingActionByActionHash(h hash.Hash256) (*action.SealedEnvelope, error) {
 	m.ctrl.T.Helper()
@@ -473,6 +733,21 @@ func (mr *MockCoreServiceMockRecorder) PendingActionByActionHash(h any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingActionByActionHash", reflect.TypeOf((*MockCoreService)(nil).PendingActionByActionHash), h)
 }
 
+// PendingBalance mocks base method.
+func (m *MockCoreService) PendingBalance(ctx context.Context, addr address.Address) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingBalance", ctx, addr)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PendingBalance indicates an expected call of PendingBalance.
+func (mr *MockCoreServiceMockRecorder) PendingBalance(ctx, addr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingBalance", reflect.TypeOf((*MockCoreService)(nil).PendingBalance), ctx, addr)
+}
+
 // PendingNonce mocks base method.
 func (m *MockCoreService) PendingNonce(arg0 address.Address) (uint64, error) {
 	m.ctrl.T.Helper()
@@ -503,6 +778,79 @@ func (mr *MockCoreServiceMockRecorder) PendingNonceAt(ctx, addr, height interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingNonceAt", reflect.TypeOf((*MockCoreService)(nil).PendingNonceAt), ctx, addr, height)
 }
 
+// PinWorkingSet mocks base method.
+func (m *MockCoreService) PinWorkingSet(blkHash hash.Hash256) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PinWorkingSet", blkHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PinWorkingSet indicates an expected call of PinWorkingSet.
+func (mr *MockCoreServiceMockRecorder) PinWorkingSet(blkHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PinWorkingSet", reflect.TypeOf((*MockCoreService)(nil).PinWorkingSet), blkHash)
+}
+
+// PrewarmWorkingSet mocks base method.
+func (m *MockCoreService) PrewarmWorkingSet(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PrewarmWorkingSet", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PrewarmWorkingSet indicates an expected call of PrewarmWorkingSet.
+func (mr *MockCoreServiceMockRecorder) PrewarmWorkingSet(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrewarmWorkingSet", reflect.TypeOf((*MockCoreService)(nil).PrewarmWorkingSet), ctx)
+}
+
+// ProbationHistory mocks base method.
+func (m *MockCoreService) ProbationHistory(ctx context.Context, delegate string, startEpoch, count uint64) ([]*ProbationStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProbationHistory", ctx, delegate, startEpoch, count)
+	ret0, _ := ret[0].([]*ProbationStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProbationHistory indicates an expected call of ProbationHistory.
+func (mr *MockCoreServiceMockRecorder) ProbationHistory(ctx, delegate, startEpoch, count any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProbationHistory", reflect.TypeOf((*MockCoreService)(nil).ProbationHistory), ctx, delegate, startEpoch, count)
+}
+
+// ProbationParams mocks base method.
+func (m *MockCoreService) ProbationParams() (*ProbationParams, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProbationParams")
+	ret0, _ := ret[0].(*ProbationParams)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProbationParams indicates an expected call of ProbationParams.
+func (mr *MockCoreServiceMockRecorder) ProbationParams() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProbationParams", reflect.TypeOf((*MockCoreService)(nil).ProbationParams))
+}
+
+// ProducerVersions mocks base method.
+func (m *MockCoreService) ProducerVersions(count uint64) ([]*ProducerVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProducerVersions", count)
+	ret0, _ := ret[0].([]*ProducerVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProducerVersions indicates an expected call of ProducerVersions.
+func (mr *MockCoreServiceMockRecorder) ProducerVersions(count any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProducerVersions", reflect.TypeOf((*MockCoreService)(nil).ProducerVersions), count)
+}
+
 // RawBlocks mocks base method.
 func (m *MockCoreService) RawBlocks(startHeight, count uint64, withReceipts, withTransactionLogs bool) ([]*iotexapi.BlockInfo, error) {
 	m.ctrl.T.Helper()
@@ -564,6 +912,21 @@ func (mr *MockCoreServiceMockRecorder) ReadState(protocolID, height, methodName,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadState", reflect.TypeOf((*MockCoreService)(nil).ReadState), protocolID, height, methodName, arguments)
 }
 
+// ReadStateBatch mocks base method.
+func (m *MockCoreService) ReadStateBatch(ctx context.Context, reqs []*iotexapi.ReadStateRequest) ([]*iotexapi.ReadStateResponse, []error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadStateBatch", ctx, reqs)
+	ret0, _ := ret[0].([]*iotexapi.ReadStateResponse)
+	ret1, _ := ret[1].([]error)
+	return ret0, ret1
+}
+
+// ReadStateBatch indicates an expected call of ReadStateBatch.
+func (mr *MockCoreServiceMockRecorder) ReadStateBatch(ctx, reqs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadStateBatch", reflect.TypeOf((*MockCoreService)(nil).ReadStateBatch), ctx, reqs)
+}
+
 // ReceiptByActionHash mocks base method.
 func (m *MockCoreService) ReceiptByActionHash(h hash.Hash256) (*action.Receipt, error) {
 	m.ctrl.T.Helper()
@@ -593,6 +956,35 @@ func (mr *MockCoreServiceMockRecorder) ReceiveBlock(blk any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiveBlock", reflect.TypeOf((*MockCoreService)(nil).ReceiveBlock), blk)
 }
 
+// ReloadConfig mocks base method.
+func (m *MockCoreService) ReloadConfig() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReloadConfig")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReloadConfig indicates an expected call of ReloadConfig.
+func (mr *MockCoreServiceMockRecorder) ReloadConfig() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReloadConfig", reflect.TypeOf((*MockCoreService)(nil).ReloadConfig))
+}
+
+// RemovePeer mocks base method.
+func (m *MockCoreService) RemovePeer(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemovePeer", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemovePeer indicates an expected call of RemovePeer.
+func (mr *MockCoreServiceMockRecorder) RemovePeer(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePeer", reflect.TypeOf((*MockCoreService)(nil).RemovePeer), id)
+}
+
 // SendAction mocks base method.
 func (m *MockCoreService) SendAction(ctx context.Context, in *iotextypes.Action) (string, error) {
 	m.ctrl.T.Helper()
@@ -608,6 +1000,36 @@ func (mr *MockCoreServiceMockRecorder) SendAction(ctx, in any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendAction", reflect.TypeOf((*MockCoreService)(nil).SendAction), ctx, in)
 }
 
+// SendActionDryRun mocks base method.
+func (m *MockCoreService) SendActionDryRun(ctx context.Context, in *iotextypes.Action) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendActionDryRun", ctx, in)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendActionDryRun indicates an expected call of SendActionDryRun.
+func (mr *MockCoreServiceMockRecorder) SendActionDryRun(ctx, in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendActionDryRun", reflect.TypeOf((*MockCoreService)(nil).SendActionDryRun), ctx, in)
+}
+
+// SendBundle mocks base method.
+func (m *MockCoreService) SendBundle(ctx context.Context, acts []*iotextypes.Action, targetHeight uint64, allOrNothing bool) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendBundle", ctx, acts, targetHeight, allOrNothing)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendBundle indicates an expected call of SendBundle.
+func (mr *MockCoreServiceMockRecorder) SendBundle(ctx, acts, targetHeight, allOrNothing any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendBundle", reflect.TypeOf((*MockCoreService)(nil).SendBundle), ctx, acts, targetHeight, allOrNothing)
+}
+
 // ServerMeta mocks base method.
 func (m *MockCoreService) ServerMeta() (string, string, string, string, string) {
 	m.ctrl.T.Helper()
@@ -626,6 +1048,34 @@ func (mr *MockCoreServiceMockRecorder) ServerMeta() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServerMeta", reflect.TypeOf((*MockCoreService)(nil).ServerMeta))
 }
 
+// SetConsensusTimeouts mocks base method.
+func (m *MockCoreService) SetConsensusTimeouts(t *consensusfsm.Timeouts) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetConsensusTimeouts", t)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetConsensusTimeouts indicates an expected call of SetConsensusTimeouts.
+func (mr *MockCoreServiceMockRecorder) SetConsensusTimeouts(t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetConsensusTimeouts", reflect.TypeOf((*MockCoreService)(nil).SetConsensusTimeouts), t)
+}
+
+// SetMinGasPrice mocks base method.
+func (m *MockCoreService) SetMinGasPrice(price *big.Int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMinGasPrice", price)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMinGasPrice indicates an expected call of SetMinGasPrice.
+func (mr *MockCoreServiceMockRecorder) SetMinGasPrice(price any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMinGasPrice", reflect.TypeOf((*MockCoreService)(nil).SetMinGasPrice), price)
+}
+
 // SimulateExecution mocks base method.
 func (m *MockCoreService) SimulateExecution(arg0 context.Context, arg1 address.Address, arg2 action.Envelope) ([]byte, *action.Receipt, error) {
 	m.ctrl.T.Helper()
@@ -656,6 +1106,21 @@ func (mr *MockCoreServiceMockRecorder) Start(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockCoreService)(nil).Start), ctx)
 }
 
+// StateProof mocks base method.
+func (m *MockCoreService) StateProof(addr address.Address, storageKeys []hash.Hash256, height uint64) (*factory.StateProof, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateProof", addr, storageKeys, height)
+	ret0, _ := ret[0].(*factory.StateProof)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateProof indicates an expected call of StateProof.
+func (mr *MockCoreServiceMockRecorder) StateProof(addr, storageKeys, height any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateProof", reflect.TypeOf((*MockCoreService)(nil).StateProof), addr, storageKeys, height)
+}
+
 // Stop mocks base method.
 func (m *MockCoreService) Stop(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -700,6 +1165,36 @@ func (mr *MockCoreServiceMockRecorder) SuggestGasTipCap() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuggestGasTipCap", reflect.TypeOf((*MockCoreService)(nil).SuggestGasTipCap))
 }
 
+// SyncProgress mocks base method.
+func (m *MockCoreService) SyncProgress() (*SyncProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncProgress")
+	ret0, _ := ret[0].(*SyncProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SyncProgress indicates an expected call of SyncProgress.
+func (mr *MockCoreServiceMockRecorder) SyncProgress() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncProgress", reflect.TypeOf((*MockCoreService)(nil).SyncProgress))
+}
+
+// SyncStatus mocks base method.
+func (m *MockCoreService) SyncStatus() (*SyncStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncStatus")
+	ret0, _ := ret[0].(*SyncStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SyncStatus indicates an expected call of SyncStatus.
+func (mr *MockCoreServiceMockRecorder) SyncStatus() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncStatus", reflect.TypeOf((*MockCoreService)(nil).SyncStatus))
+}
+
 // SyncingProgress mocks base method.
 func (m *MockCoreService) SyncingProgress() (uint64, uint64, uint64) {
 	m.ctrl.T.Helper()
@@ -716,6 +1211,36 @@ func (mr *MockCoreServiceMockRecorder) SyncingProgress() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncingProgress", reflect.TypeOf((*MockCoreService)(nil).SyncingProgress))
 }
 
+// SystemContractABI mocks base method.
+func (m *MockCoreService) SystemContractABI(addr address.Address, height uint64) (*SystemContractABI, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SystemContractABI", addr, height)
+	ret0, _ := ret[0].(*SystemContractABI)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SystemContractABI indicates an expected call of SystemContractABI.
+func (mr *MockCoreServiceMockRecorder) SystemContractABI(addr, height any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SystemContractABI", reflect.TypeOf((*MockCoreService)(nil).SystemContractABI), addr, height)
+}
+
+// SystemContracts mocks base method.
+func (m *MockCoreService) SystemContracts() ([]*SystemContract, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SystemContracts")
+	ret0, _ := ret[0].([]*SystemContract)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SystemContracts indicates an expected call of SystemContracts.
+func (mr *MockCoreServiceMockRecorder) SystemContracts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SystemContracts", reflect.TypeOf((*MockCoreService)(nil).SystemContracts))
+}
+
 // TipHeight mocks base method.
 func (m *MockCoreService) TipHeight() uint64 {
 	m.ctrl.T.Helper()
@@ -730,6 +1255,21 @@ func (mr *MockCoreServiceMockRecorder) TipHeight() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TipHeight", reflect.TypeOf((*MockCoreService)(nil).TipHeight))
 }
 
+// TraceBlock mocks base method.
+func (m *MockCoreService) TraceBlock(ctx context.Context, height uint64, config *tracers.TraceConfig) ([]*ActionTrace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TraceBlock", ctx, height, config)
+	ret0, _ := ret[0].([]*ActionTrace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TraceBlock indicates an expected call of TraceBlock.
+func (mr *MockCoreServiceMockRecorder) TraceBlock(ctx, height, config any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TraceBlock", reflect.TypeOf((*MockCoreService)(nil).TraceBlock), ctx, height, config)
+}
+
 // TraceCall mocks base method.
 func (m *MockCoreService) TraceCall(ctx context.Context, callerAddr address.Address, blkNumOrHash any, contractAddress string, nonce uint64, amount *big.Int, gasLimit uint64, data []byte, config *tracers.TraceConfig) ([]byte, *action.Receipt, any, error) {
 	m.ctrl.T.Helper()
@@ -822,6 +1362,34 @@ func (mr *MockCoreServiceMockRecorder) UnconfirmedActionsByAddress(arg0, start,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnconfirmedActionsByAddress", reflect.TypeOf((*MockCoreService)(nil).UnconfirmedActionsByAddress), arg0, start, count)
 }
 
+// UnpinWorkingSet mocks base method.
+func (m *MockCoreService) UnpinWorkingSet(blkHash hash.Hash256) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnpinWorkingSet", blkHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnpinWorkingSet indicates an expected call of UnpinWorkingSet.
+func (mr *MockCoreServiceMockRecorder) UnpinWorkingSet(blkHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnpinWorkingSet", reflect.TypeOf((*MockCoreService)(nil).UnpinWorkingSet), blkHash)
+}
+
+// UserOpPool mocks base method.
+func (m *MockCoreService) UserOpPool() *userop.Pool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserOpPool")
+	ret0, _ := ret[0].(*userop.Pool)
+	return ret0
+}
+
+// UserOpPool indicates an expected call of UserOpPool.
+func (mr *MockCoreServiceMockRecorder) UserOpPool() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserOpPool", reflect.TypeOf((*MockCoreService)(nil).UserOpPool))
+}
+
 // WithHeight mocks base method.
 func (m *MockCoreService) WithHeight(arg0 uint64) CoreServiceReaderWithHeight {
 	m.ctrl.T.Helper()
@@ -836,6 +1404,21 @@ func (mr *MockCoreServiceMockRecorder) WithHeight(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithHeight", reflect.TypeOf((*MockCoreService)(nil).WithHeight), arg0)
 }
 
+// WorkingSetCache mocks base method.
+func (m *MockCoreService) WorkingSetCache() ([]*WorkingSetCacheEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkingSetCache")
+	ret0, _ := ret[0].([]*WorkingSetCacheEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkingSetCache indicates an expected call of WorkingSetCache.
+func (mr *MockCoreServiceMockRecorder) WorkingSetCache() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkingSetCache", reflect.TypeOf((*MockCoreService)(nil).WorkingSetCache))
+}
+
 // MockintrinsicGasCalculator is a mock of intrinsicGasCalculator interface.
 type MockintrinsicGasCalculator struct {
 	ctrl     *gomock.Controller
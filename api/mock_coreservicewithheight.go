@@ -74,3 +74,18 @@ func (mr *MockCoreServiceReaderWithHeightMockRecorder) ReadContract(arg0, arg1,
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadContract", reflect.TypeOf((*MockCoreServiceReaderWithHeight)(nil).ReadContract), arg0, arg1, arg2)
 }
+
+// ReadContractStorage mocks base method.
+func (m *MockCoreServiceReaderWithHeight) ReadContractStorage(arg0 context.Context, arg1 address.Address, arg2 []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadContractStorage", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadContractStorage indicates an expected call of ReadContractStorage.
+func (mr *MockCoreServiceReaderWithHeightMockRecorder) ReadContractStorage(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadContractStorage", reflect.TypeOf((*MockCoreServiceReaderWithHeight)(nil).ReadContractStorage), arg0, arg1, arg2)
+}
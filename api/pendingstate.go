@@ -0,0 +1,92 @@
+package api
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/v2/actpool"
+)
+
+// PendingBalance returns the balance of the account at the tip with the pending actions in the actpool applied, as
+// the balance of the "pending" block
+func (core *coreService) PendingBalance(ctx context.Context, addr address.Address) (string, error) {
+	confirmed, err := core.BalanceAt(ctx, addr, 0)
+	if err != nil {
+		return "", err
+	}
+	addrStr := addr.String()
+	if addrStr == address.RewardingPoolAddr || addrStr == address.StakingBucketPoolAddr {
+		return confirmed, nil
+	}
+	balance, ok := new(big.Int).SetString(confirmed, 10)
+	if !ok {
+		return "", status.Errorf(codes.Internal, "invalid balance %s of %s", confirmed, addrStr)
+	}
+	delta, err := pendingBalanceDelta(core.ap, addr)
+	if err != nil {
+		return "", status.Error(codes.Internal, err.Error())
+	}
+	if balance.Add(balance, delta).Sign() < 0 {
+		balance.SetInt64(0)
+	}
+	return balance.String(), nil
+}
+
+// pendingBalanceDelta returns the change of the balance by the executable actions in the actpool, which are the ones
+// below the pending nonce of the sender. The cost of the actions sent by the account is deducted, in which the gas is
+// charged at the gas limit, and the amount of the transfers and executions to the account is added
+func pendingBalanceDelta(ap actpool.ActPool, addr address.Address) (*big.Int, error) {
+	var (
+		addrStr      = addr.String()
+		delta        = new(big.Int)
+		seen         = make(map[hash.Hash256]struct{})
+		pendingNonce = make(map[string]uint64)
+	)
+	executable := func(sender string, nonce uint64) (bool, error) {
+		n, ok := pendingNonce[sender]
+		if !ok {
+			var err error
+			if n, err = ap.GetPendingNonce(sender); err != nil {
+				return false, err
+			}
+			pendingNonce[sender] = n
+		}
+		return nonce < n, nil
+	}
+	for _, selp := range ap.GetUnconfirmedActs(addrStr) {
+		h, err := selp.Hash()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := seen[h]; ok {
+			continue
+		}
+		seen[h] = struct{}{}
+		sender := selp.SenderAddress().String()
+		ok, err := executable(sender, selp.Nonce())
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		if sender == addrStr {
+			cost, err := selp.Cost()
+			if err != nil {
+				return nil, err
+			}
+			delta.Sub(delta, cost)
+		}
+		if dest, ok := selp.Destination(); ok && dest == addrStr {
+			if a, ok := selp.Action().(interface{ Amount() *big.Int }); ok && a.Amount() != nil {
+				delta.Add(delta, a.Amount())
+			}
+		}
+	}
+	return delta, nil
+}
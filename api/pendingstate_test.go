@@ -0,0 +1,46 @@
+package api
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+	"github.com/iotexproject/iotex-core/v2/test/mock/mock_actpool"
+)
+
+func TestPendingBalanceDelta(t *testing.T) {
+	require := require.New(t)
+	ap := mock_actpool.NewMockActPool(gomock.NewController(t))
+	addr, other := identityset.Address(1), identityset.Address(2)
+	gasPrice := big.NewInt(1)
+
+	// the actions sent by the account, in which the one of nonce 3 is not executable
+	out1, err := action.SignedTransfer(other.String(), identityset.PrivateKey(1), 1, big.NewInt(100), nil, 10000, gasPrice)
+	require.NoError(err)
+	out3, err := action.SignedTransfer(other.String(), identityset.PrivateKey(1), 3, big.NewInt(100), nil, 10000, gasPrice)
+	require.NoError(err)
+	// the self transfer is returned both as sent and received
+	self, err := action.SignedTransfer(addr.String(), identityset.PrivateKey(1), 2, big.NewInt(30), nil, 10000, gasPrice)
+	require.NoError(err)
+	// the actions to the account, in which the one of nonce 5 is not executable
+	in1, err := action.SignedExecution(addr.String(), identityset.PrivateKey(2), 1, big.NewInt(1000), 20000, gasPrice, nil)
+	require.NoError(err)
+	in5, err := action.SignedTransfer(addr.String(), identityset.PrivateKey(2), 5, big.NewInt(1000), nil, 10000, gasPrice)
+	require.NoError(err)
+	ap.EXPECT().GetUnconfirmedActs(addr.String()).Return([]*action.SealedEnvelope{out1, self, out3, self, in1, in5}).Times(1)
+	ap.EXPECT().GetPendingNonce(addr.String()).Return(uint64(3), nil).Times(1)
+	ap.EXPECT().GetPendingNonce(other.String()).Return(uint64(2), nil).Times(1)
+
+	delta, err := pendingBalanceDelta(ap, addr)
+	require.NoError(err)
+	cost1, err := out1.Cost()
+	require.NoError(err)
+	costSelf, err := self.Cost()
+	require.NoError(err)
+	expected := new(big.Int).Sub(big.NewInt(30+1000), cost1)
+	require.Equal(expected.Sub(expected, costSelf), delta)
+}
@@ -0,0 +1,151 @@
+package api
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/tidwall/gjson"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol/governance"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/poll"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/vote"
+)
+
+const (
+	_probationParamsFromGenesis    = "genesis"
+	_probationParamsFromGovernance = "governance"
+)
+
+type (
+	// ProbationParams are the probation parameters effective at the tip, along with the ones scheduled on chain
+	ProbationParams struct {
+		Height  uint64                  `json:"height"`
+		Current *ProbationParamsEntry   `json:"current"`
+		Pending []*ProbationParamsEntry `json:"pending"`
+		// History are the parameters scheduled on chain which have been activated, in the order of activation
+		History []*ProbationParamsEntry `json:"history"`
+	}
+
+	// ProbationParamsEntry is a set of probation parameters and where they come from
+	ProbationParamsEntry struct {
+		ActivationHeight      uint64 `json:"activationHeight"`
+		ProductivityThreshold uint64 `json:"productivityThreshold"`
+		ProbationEpochPeriod  uint64 `json:"probationEpochPeriod"`
+		IntensityRate         uint32 `json:"intensityRate"`
+		Source                string `json:"source"`
+	}
+
+	// ProbationStatus is the probation status of a delegate in an epoch
+	ProbationStatus struct {
+		Epoch       uint64 `json:"epoch"`
+		Height      uint64 `json:"height"`
+		OnProbation bool   `json:"onProbation"`
+		// Count is the number of recent epochs the delegate is unproductive in
+		Count         uint32 `json:"count"`
+		IntensityRate uint32 `json:"intensityRate"`
+	}
+)
+
+// ProbationParams returns the probation parameters effective at the tip, which are the genesis ones until the ones
+// scheduled by the governors are activated
+func (core *coreService) ProbationParams() (*ProbationParams, error) {
+	schedule, _, err := governance.ReadProbationSchedule(core.sf)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	g := core.bc.Genesis()
+	ret := &ProbationParams{
+		Height: core.bc.TipHeight(),
+		Current: &ProbationParamsEntry{
+			ActivationHeight:      g.EasterBlockHeight,
+			ProductivityThreshold: g.ProductivityThreshold,
+			ProbationEpochPeriod:  g.ProbationEpochPeriod,
+			IntensityRate:         g.ProbationIntensityRate,
+			Source:                _probationParamsFromGenesis,
+		},
+		Pending: []*ProbationParamsEntry{},
+		History: []*ProbationParamsEntry{},
+	}
+	for _, p := range schedule {
+		entry := &ProbationParamsEntry{
+			ActivationHeight:      p.ActivationHeight,
+			ProductivityThreshold: p.ProductivityThreshold,
+			ProbationEpochPeriod:  p.ProbationEpochPeriod,
+			IntensityRate:         p.IntensityRate,
+			Source:                _probationParamsFromGovernance,
+		}
+		if p.ActivationHeight > ret.Height {
+			ret.Pending = append(ret.Pending, entry)
+			continue
+		}
+		ret.History = append(ret.History, entry)
+		ret.Current = entry
+	}
+	return ret, nil
+}
+
+// ProbationHistory returns the probation status of the delegate in the count epochs from the start epoch
+func (core *coreService) ProbationHistory(ctx context.Context, delegate string, startEpoch, count uint64) ([]*ProbationStatus, error) {
+	rp := rolldpos.FindProtocol(core.registry)
+	pp := poll.FindProtocol(core.registry)
+	if rp == nil || pp == nil {
+		return nil, status.Error(codes.Unimplemented, "rolldpos protocol is not registered")
+	}
+	if _, err := address.FromString(delegate); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if startEpoch < 1 {
+		return nil, status.Error(codes.InvalidArgument, "epoch number cannot be less than one")
+	}
+	if count == 0 || count > core.cfg.RangeQueryLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "count must be in (0, %d]", core.cfg.RangeQueryLimit)
+	}
+	tipEpoch := rp.GetEpochNum(core.bc.TipHeight())
+	if startEpoch > tipEpoch {
+		return nil, status.Errorf(codes.NotFound, "epoch %d has not started", startEpoch)
+	}
+	if startEpoch+count > tipEpoch+1 {
+		count = tipEpoch + 1 - startEpoch
+	}
+	easterHeight := core.bc.Genesis().EasterBlockHeight
+	ret := make([]*ProbationStatus, 0, count)
+	for epoch := startEpoch; epoch < startEpoch+count; epoch++ {
+		ps := &ProbationStatus{
+			Epoch:  epoch,
+			Height: rp.GetEpochHeight(epoch),
+		}
+		ret = append(ret, ps)
+		if ps.Height < easterHeight {
+			// there is no probation before Easter
+			continue
+		}
+		data, _, err := core.readState(ctx, pp, "", []byte("ProbationListByEpoch"), []byte(strconv.FormatUint(epoch, 10)))
+		if err != nil {
+			return nil, status.Errorf(codes.NotFound, "failed to read probation list of epoch %d: %v", epoch, err)
+		}
+		pl := &vote.ProbationList{}
+		if err := pl.Deserialize(data); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		ps.IntensityRate = pl.IntensityRate
+		ps.Count = pl.ProbationInfo[delegate]
+		ps.OnProbation = ps.Count > 0
+	}
+	return ret, nil
+}
+
+func (svr *web3Handler) getProbationParams() (interface{}, error) {
+	return svr.coreService.ProbationParams()
+}
+
+func (svr *web3Handler) getProbationHistory(ctx context.Context, in *gjson.Result) (interface{}, error) {
+	delegate, startEpoch, count := in.Get("params.0"), in.Get("params.1"), in.Get("params.2")
+	if !delegate.Exists() || !startEpoch.Exists() || !count.Exists() || startEpoch.Type != gjson.Number || count.Type != gjson.Number {
+		return nil, errInvalidFormat
+	}
+	return svr.coreService.ProbationHistory(ctx, delegate.String(), startEpoch.Uint(), count.Uint())
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/governance"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/poll"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+	"github.com/iotexproject/iotex-core/v2/test/mock/mock_blockchain"
+	"github.com/iotexproject/iotex-core/v2/test/mock/mock_factory"
+)
+
+func TestCoreService_ProbationParams(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	sf := mock_factory.NewMockFactory(ctrl)
+	core := &coreService{bc: bc, sf: sf, registry: protocol.NewRegistry()}
+
+	g := genesis.TestDefault()
+	bc.EXPECT().Genesis().Return(g).AnyTimes()
+	bc.EXPECT().TipHeight().Return(uint64(150)).AnyTimes()
+	schedule := governance.ProbationSchedule{
+		{ActivationHeight: 100, ProductivityThreshold: 80, ProbationEpochPeriod: 3, IntensityRate: 50},
+		{ActivationHeight: 200, ProductivityThreshold: 85, ProbationEpochPeriod: 4, IntensityRate: 90},
+	}
+	sf.EXPECT().State(gomock.Any(), gomock.Any()).DoAndReturn(func(s interface{}, opts ...protocol.StateOption) (uint64, error) {
+		*s.(*governance.ProbationSchedule) = schedule
+		return 150, nil
+	}).Times(1)
+
+	ret, err := core.ProbationParams()
+	require.NoError(err)
+	require.EqualValues(150, ret.Height)
+	require.Equal(&ProbationParamsEntry{
+		ActivationHeight:      100,
+		ProductivityThreshold: 80,
+		ProbationEpochPeriod:  3,
+		IntensityRate:         50,
+		Source:                _probationParamsFromGovernance,
+	}, ret.Current)
+	require.Len(ret.History, 1)
+	require.Len(ret.Pending, 1)
+	require.EqualValues(200, ret.Pending[0].ActivationHeight)
+
+	// the genesis parameters are effective without any scheduled on chain
+	schedule = nil
+	sf.EXPECT().State(gomock.Any(), gomock.Any()).Return(uint64(150), nil).Times(1)
+	ret, err = core.ProbationParams()
+	require.NoError(err)
+	require.Equal(_probationParamsFromGenesis, ret.Current.Source)
+	require.Equal(g.ProductivityThreshold, ret.Current.ProductivityThreshold)
+	require.Empty(ret.History)
+	require.Empty(ret.Pending)
+}
+
+func TestCoreService_ProbationHistory(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	registry := protocol.NewRegistry()
+	core := &coreService{bc: bc, registry: registry, cfg: DefaultConfig}
+
+	delegate := identityset.Address(1).String()
+	_, err := core.ProbationHistory(context.Background(), delegate, 1, 1)
+	require.Equal(codes.Unimplemented, status.Code(err))
+
+	rp := rolldpos.NewProtocol(24, 24, 1)
+	require.NoError(rp.Register(registry))
+	require.NoError(poll.NewLifeLongDelegatesProtocol(genesis.TestDefault().Delegates).Register(registry))
+	bc.EXPECT().TipHeight().Return(rp.GetEpochHeight(2)).AnyTimes()
+	for _, c := range []struct {
+		delegate          string
+		startEpoch, count uint64
+		code              codes.Code
+	}{
+		{"invalid", 1, 1, codes.InvalidArgument},
+		{delegate, 0, 1, codes.InvalidArgument},
+		{delegate, 1, 0, codes.InvalidArgument},
+		{delegate, 1, DefaultConfig.RangeQueryLimit + 1, codes.InvalidArgument},
+		{delegate, 3, 1, codes.NotFound},
+	} {
+		_, err := core.ProbationHistory(context.Background(), c.delegate, c.startEpoch, c.count)
+		require.Equal(c.code, status.Code(err))
+	}
+}
@@ -0,0 +1,113 @@
+package api
+
+import (
+	"encoding/hex"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+)
+
+const (
+	// _defaultProducerVersionBlocks is the number of recent blocks scanned for the producer versions by default,
+	// which covers a couple of epochs
+	_defaultProducerVersionBlocks = 720
+	// _maxProducerVersionBlocks is the maximum number of recent blocks scanned for the producer versions
+	_maxProducerVersionBlocks = 10000
+
+	// _unknownVersion is the version of the producers not committing the build info
+	_unknownVersion = "unknown"
+)
+
+// ProducerVersion is a build of the node run by the recent producers
+type ProducerVersion struct {
+	Version     string `json:"version"`
+	FeatureHash string `json:"featureHash,omitempty"`
+	// Producers are the producers whose latest block in the range is minted by the build
+	Producers []string `json:"producers"`
+	// Blocks is the number of blocks in the range minted by the build
+	Blocks uint64 `json:"blocks"`
+}
+
+// ProducerVersions aggregates the builds committed in the last count blocks, by the latest build of each producer,
+// to monitor the readiness of the network before activating a hard fork
+func (core *coreService) ProducerVersions(count uint64) ([]*ProducerVersion, error) {
+	if count == 0 || count > _maxProducerVersionBlocks {
+		return nil, status.Errorf(codes.InvalidArgument, "count must be in (0, %d]", _maxProducerVersionBlocks)
+	}
+	tipHeight := core.bc.TipHeight()
+	if count > tipHeight {
+		count = tipHeight
+	}
+	type build struct {
+		version     string
+		featureHash string
+	}
+	var (
+		latest = make(map[string]build)
+		blocks = make(map[build]uint64)
+	)
+	// scan from the tip, so the first block seen of a producer is its latest one
+	for height := tipHeight; height > tipHeight-count; height-- {
+		header, err := core.bc.BlockHeaderByHeight(height)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		b := build{version: _unknownVersion}
+		bi, err := block.DecodeBuildInfo(header.ExtraData())
+		switch errors.Cause(err) {
+		case nil:
+			b.version, b.featureHash = bi.Version, hex.EncodeToString(bi.FeatureHash[:])
+		case block.ErrNoBuildInfo:
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		blocks[b]++
+		if _, ok := latest[header.ProducerAddress()]; !ok {
+			latest[header.ProducerAddress()] = b
+		}
+	}
+	versions := make(map[build]*ProducerVersion)
+	for producer, b := range latest {
+		pv, ok := versions[b]
+		if !ok {
+			pv = &ProducerVersion{
+				Version:     b.version,
+				FeatureHash: b.featureHash,
+				Blocks:      blocks[b],
+			}
+			versions[b] = pv
+		}
+		pv.Producers = append(pv.Producers, producer)
+	}
+	ret := make([]*ProducerVersion, 0, len(versions))
+	for _, pv := range versions {
+		sort.Strings(pv.Producers)
+		ret = append(ret, pv)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if len(ret[i].Producers) != len(ret[j].Producers) {
+			return len(ret[i].Producers) > len(ret[j].Producers)
+		}
+		if ret[i].Version != ret[j].Version {
+			return ret[i].Version < ret[j].Version
+		}
+		return ret[i].FeatureHash < ret[j].FeatureHash
+	})
+	return ret, nil
+}
+
+func (svr *web3Handler) producerVersions(in *gjson.Result) (interface{}, error) {
+	count := uint64(_defaultProducerVersionBlocks)
+	if param := in.Get("params.0"); param.Exists() {
+		if param.Type != gjson.Number {
+			return nil, errInvalidFormat
+		}
+		count = param.Uint()
+	}
+	return svr.coreService.ProducerVersions(count)
+}
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"encoding/hex"
+	"sort"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+	"github.com/iotexproject/iotex-core/v2/test/mock/mock_blockchain"
+	"github.com/iotexproject/iotex-core/v2/testutil"
+)
+
+func TestCoreService_ProducerVersions(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	core := &coreService{bc: bc}
+
+	var (
+		featureHash = hash.Hash256b([]byte("features"))
+		v1          = block.NewBuildInfo("v2.1.0", featureHash).Encode()
+		v2          = block.NewBuildInfo("v2.2.0", featureHash).Encode()
+		// the producer and the build info of the blocks at height 1 to 6
+		blocks = []struct {
+			producer int
+			extra    []byte
+		}{
+			{1, nil}, {2, v1}, {3, v1}, {1, v2}, {2, v2}, {4, v1},
+		}
+		headers = make(map[uint64]*block.Header)
+	)
+	newHeader := func(height uint64, producer int, extra []byte) *block.Header {
+		blk, err := block.NewBuilder(block.NewRunnableActionsBuilder().Build()).
+			SetHeight(height).
+			SetTimestamp(testutil.TimestampNow()).
+			SetPrevBlockHash(hash.ZeroHash256).
+			SetExtraData(extra).
+			SignAndBuild(identityset.PrivateKey(producer))
+		require.NoError(err)
+		return &blk.Header
+	}
+	for i, b := range blocks {
+		headers[uint64(i+1)] = newHeader(uint64(i+1), b.producer, b.extra)
+	}
+	bc.EXPECT().TipHeight().Return(uint64(len(blocks))).AnyTimes()
+	bc.EXPECT().BlockHeaderByHeight(gomock.Any()).DoAndReturn(func(height uint64) (*block.Header, error) {
+		return headers[height], nil
+	}).AnyTimes()
+
+	for _, count := range []uint64{0, _maxProducerVersionBlocks + 1} {
+		_, err := core.ProducerVersions(count)
+		require.Equal(codes.InvalidArgument, status.Code(err))
+	}
+
+	// the producers 1 and 2 have upgraded, while the producers 3 and 4 have not
+	ret, err := core.ProducerVersions(100)
+	require.NoError(err)
+	require.Equal([]*ProducerVersion{
+		{
+			Version:     "v2.1.0",
+			FeatureHash: hex.EncodeToString(featureHash[:]),
+			Producers:   sortedAddresses(3, 4),
+			Blocks:      3,
+		},
+		{
+			Version:     "v2.2.0",
+			FeatureHash: hex.EncodeToString(featureHash[:]),
+			Producers:   sortedAddresses(1, 2),
+			Blocks:      2,
+		},
+	}, ret)
+
+	ret, err = core.ProducerVersions(3)
+	require.NoError(err)
+	require.Len(ret, 2)
+	require.Equal("v2.2.0", ret[0].Version)
+	require.Equal(sortedAddresses(1, 2), ret[0].Producers)
+	require.Equal([]string{identityset.Address(4).String()}, ret[1].Producers)
+
+	// the producer 5 does not commit the build info
+	headers[1] = newHeader(1, 5, nil)
+	ret, err = core.ProducerVersions(6)
+	require.NoError(err)
+	require.Len(ret, 3)
+	require.Equal(_unknownVersion, ret[2].Version)
+	require.Empty(ret[2].FeatureHash)
+	require.Equal([]string{identityset.Address(5).String()}, ret[2].Producers)
+	require.EqualValues(1, ret[2].Blocks)
+}
+
+func sortedAddresses(ids ...int) []string {
+	ret := make([]string, len(ids))
+	for i, id := range ids {
+		ret[i] = identityset.Address(id).String()
+	}
+	sort.Strings(ret)
+	return ret
+}
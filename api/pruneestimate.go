@@ -0,0 +1,81 @@
+package api
+
+import (
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/v2/state/factory"
+)
+
+// PruneEstimate is the space reclaimed by pruning the state history below a height, which keeps the history of the
+// number of the most recent heights
+type PruneEstimate struct {
+	Retention  uint64 `json:"retention"`
+	PruneBelow uint64 `json:"pruneBelow"`
+	// Roots and Nodes are the number of the reclaimed roots and nodes of the account trie, Bytes is the size of them
+	Roots      uint64 `json:"roots"`
+	Nodes      uint64 `json:"nodes"`
+	Bytes      uint64 `json:"bytes"`
+	TotalNodes uint64 `json:"totalNodes"`
+	TotalBytes uint64 `json:"totalBytes"`
+}
+
+// EstimatePruning returns the space reclaimed by pruning the state history with each of the retentions, nothing is
+// pruned by the estimate
+func (core *coreService) EstimatePruning(retentions []uint64) ([]*PruneEstimate, error) {
+	estimator, ok := core.sf.(factory.PruneEstimator)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "state factory does not support pruning estimate")
+	}
+	if len(retentions) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no retention is specified")
+	}
+	for _, r := range retentions {
+		if r == 0 {
+			return nil, status.Error(codes.InvalidArgument, "retention should be positive")
+		}
+	}
+	estimates, err := estimator.EstimatePruning(retentions)
+	if err != nil {
+		if errors.Cause(err) == factory.ErrNotSupported {
+			return nil, status.Error(codes.Unimplemented, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	ret := make([]*PruneEstimate, len(estimates))
+	for i, e := range estimates {
+		ret[i] = &PruneEstimate{
+			Retention:  e.Retention,
+			PruneBelow: e.PruneBelow,
+			Roots:      e.Roots,
+			Nodes:      e.Nodes,
+			Bytes:      e.Bytes,
+			TotalNodes: e.TotalNodes,
+			TotalBytes: e.TotalBytes,
+		}
+	}
+	return ret, nil
+}
+
+// estimatePruning takes the retentions in either decimal numbers or hex quantities
+func (svr *web3Handler) estimatePruning(in *gjson.Result) (interface{}, error) {
+	param := in.Get("params.0")
+	if !param.IsArray() {
+		return nil, errInvalidFormat
+	}
+	var retentions []uint64
+	for _, r := range param.Array() {
+		if r.Type == gjson.Number {
+			retentions = append(retentions, r.Uint())
+			continue
+		}
+		n, err := hexStringToNumber(r.String())
+		if err != nil {
+			return nil, errors.Wrapf(errUnkownType, "retention: %s", r.String())
+		}
+		retentions = append(retentions, n)
+	}
+	return svr.coreService.EstimatePruning(retentions)
+}
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/v2/test/mock/mock_factory"
+)
+
+func TestCoreService_EstimatePruning(t *testing.T) {
+	require := require.New(t)
+	core := &coreService{sf: mock_factory.NewMockFactory(gomock.NewController(t))}
+	_, err := core.EstimatePruning([]uint64{1})
+	require.Equal(codes.Unimplemented, status.Code(err))
+}
+
+func TestEstimatePruning(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	core := NewMockCoreService(ctrl)
+	web3svr := &web3Handler{coreService: core, batchRequestLimit: _defaultBatchRequestLimit}
+
+	estimates := []*PruneEstimate{{Retention: 100, PruneBelow: 1}, {Retention: 16, PruneBelow: 85}}
+	core.EXPECT().EstimatePruning([]uint64{100, 16}).Return(estimates, nil).Times(1)
+	in := gjson.Parse(`{"params":[[100, "0x10"]]}`)
+	ret, err := web3svr.estimatePruning(&in)
+	require.NoError(err)
+	require.Equal(estimates, ret)
+
+	for _, params := range []string{`[]`, `[100]`, `[["xyz"]]`} {
+		in = gjson.Parse(`{"params":` + params + `}`)
+		_, err = web3svr.estimatePruning(&in)
+		require.Error(err)
+	}
+}
@@ -0,0 +1,211 @@
+package api
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/tidwall/gjson"
+	"golang.org/x/time/rate"
+)
+
+type (
+	// RateLimitConfig is the config of the rate limiter of the web3 http endpoint
+	RateLimitConfig struct {
+		// Rate is the number of request weights per second allowed for a client identified by ip, 0 means no limit
+		Rate float64 `yaml:"rate"`
+		// Burst is the maximum weight a client could spend at once, which is at least the rate of the client
+		Burst int `yaml:"burst"`
+		// APIKeyHeader is the http header carrying the api key
+		APIKeyHeader string `yaml:"apiKeyHeader"`
+		// APIKeys are the rates per second granted to api keys, clients with unknown keys are limited by ip
+		APIKeys map[string]float64 `yaml:"apiKeys"`
+		// MethodWeights are the weights of expensive methods, other methods weigh 1
+		MethodWeights map[string]int `yaml:"methodWeights"`
+		// TrustForwardedFor uses the X-Forwarded-For header as client ip, enable it only behind a trusted proxy
+		TrustForwardedFor bool `yaml:"trustForwardedFor"`
+		// IdleTimeout is the duration after which the bucket of an idle client is dropped
+		IdleTimeout time.Duration `yaml:"idleTimeout"`
+	}
+
+	rateLimitHandler struct {
+		next    http.Handler
+		cfg     RateLimitConfig
+		mu      sync.Mutex
+		buckets map[string]*clientBucket
+		swept   time.Time
+	}
+
+	// reloadableRateLimitHandler serves the requests with the rate limiter of the latest config, the buckets of the
+	// clients are reset when the config is changed
+	reloadableRateLimitHandler struct {
+		next    http.Handler
+		current atomic.Pointer[http.Handler]
+	}
+
+	clientBucket struct {
+		limiter  *rate.Limiter
+		lastSeen time.Time
+	}
+)
+
+// DefaultRateLimitConfig is the default config of the rate limiter, which is disabled
+var DefaultRateLimitConfig = RateLimitConfig{
+	Rate:         0,
+	Burst:        100,
+	APIKeyHeader: "X-API-Key",
+	APIKeys:      map[string]float64{},
+	MethodWeights: map[string]int{
+		"eth_getLogs":              10,
+		"eth_call":                 5,
+		"eth_estimateGas":          5,
+		"debug_traceCall":          20,
+		"debug_traceTransaction":   20,
+		"debug_traceBlockByNumber": 50,
+	},
+	IdleTimeout: 10 * time.Minute,
+}
+
+var _rateLimitMtc = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "iotex_api_rate_limit",
+	Help: "web3 requests allowed or limited by the rate limiter",
+}, []string{"client", "result"})
+
+func init() {
+	prometheus.MustRegister(_rateLimitMtc)
+}
+
+// newRateLimitHandler wraps the handler with a token bucket rate limiter per client
+func newRateLimitHandler(next http.Handler, cfg RateLimitConfig) http.Handler {
+	if cfg.Rate <= 0 && len(cfg.APIKeys) == 0 {
+		return next
+	}
+	if cfg.Burst <= 0 {
+		cfg.Burst = 1
+	}
+	return &rateLimitHandler{
+		next:    next,
+		cfg:     cfg,
+		buckets: make(map[string]*clientBucket),
+	}
+}
+
+func newReloadableRateLimitHandler(next http.Handler, cfg RateLimitConfig) *reloadableRateLimitHandler {
+	h := &reloadableRateLimitHandler{next: next}
+	h.SetConfig(cfg)
+	return h
+}
+
+// SetConfig replaces the rate limiter with the one of the config
+func (h *reloadableRateLimitHandler) SetConfig(cfg RateLimitConfig) {
+	limiter := newRateLimitHandler(h.next, cfg)
+	h.current.Store(&limiter)
+}
+
+func (h *reloadableRateLimitHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	(*h.current.Load()).ServeHTTP(w, req)
+}
+
+func (h *rateLimitHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		h.next.ServeHTTP(w, req)
+		return
+	}
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	clientType, key, limit := h.client(req)
+	if limit > 0 {
+		if ok, retryAfter := h.allow(key, limit, h.weight(body)); !ok {
+			_rateLimitMtc.WithLabelValues(clientType, "limited").Inc()
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"jsonrpc":"2.0","id":null,"error":{"code":-32005,"message":"rate limit exceeded"}}`))
+			return
+		}
+	}
+	_rateLimitMtc.WithLabelValues(clientType, "allowed").Inc()
+	h.next.ServeHTTP(w, req)
+}
+
+// client returns the type, the bucket key and the rate of the client of the request
+func (h *rateLimitHandler) client(req *http.Request) (string, string, float64) {
+	if key := req.Header.Get(h.cfg.APIKeyHeader); key != "" {
+		if limit, ok := h.cfg.APIKeys[key]; ok {
+			return "apikey", "key:" + key, limit
+		}
+	}
+	ip := req.RemoteAddr
+	if h.cfg.TrustForwardedFor {
+		if fwd := req.Header.Get("X-Forwarded-For"); fwd != "" {
+			ip, _, _ = strings.Cut(fwd, ",")
+			ip = strings.TrimSpace(ip)
+		}
+	}
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+	return "ip", "ip:" + ip, h.cfg.Rate
+}
+
+// weight sums up the weights of the methods of a single or batch request
+func (h *rateLimitHandler) weight(body []byte) int {
+	reqs := gjson.ParseBytes(body)
+	if !reqs.IsArray() {
+		return h.methodWeight(reqs.Get("method").String())
+	}
+	weight := 0
+	for _, req := range reqs.Array() {
+		weight += h.methodWeight(req.Get("method").String())
+	}
+	return max(weight, 1)
+}
+
+func (h *rateLimitHandler) methodWeight(method string) int {
+	if weight, ok := h.cfg.MethodWeights[method]; ok {
+		return weight
+	}
+	return 1
+}
+
+// allow takes the weight from the bucket of the client, or returns the time to wait until it is available
+func (h *rateLimitHandler) allow(key string, limit float64, weight int) (bool, time.Duration) {
+	now := time.Now()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.cfg.IdleTimeout > 0 && now.Sub(h.swept) > h.cfg.IdleTimeout {
+		for k, b := range h.buckets {
+			if now.Sub(b.lastSeen) > h.cfg.IdleTimeout {
+				delete(h.buckets, k)
+			}
+		}
+		h.swept = now
+	}
+	b, ok := h.buckets[key]
+	if !ok {
+		b = &clientBucket{limiter: rate.NewLimiter(rate.Limit(limit), max(h.cfg.Burst, int(limit)))}
+		h.buckets[key] = b
+	}
+	b.lastSeen = now
+	// a request heavier than the burst is charged the whole burst, so it is still served once the bucket is full
+	weight = min(weight, b.limiter.Burst())
+	r := b.limiter.ReserveN(now, weight)
+	if delay := r.DelayFrom(now); delay > 0 {
+		r.CancelAt(now)
+		return false, delay
+	}
+	return true, 0
+}
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitHandler(t *testing.T) {
+	require := require.New(t)
+	var served []string
+	next := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		served = append(served, string(body))
+	})
+	post := func(h http.Handler, body string, header map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "http://url.com", strings.NewReader(body))
+		req.RemoteAddr = "1.2.3.4:5678"
+		for k, v := range header {
+			req.Header.Set(k, v)
+		}
+		resp := httptest.NewRecorder()
+		h.ServeHTTP(resp, req)
+		return resp
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		_, ok := newRateLimitHandler(next, DefaultRateLimitConfig).(*rateLimitHandler)
+		require.False(ok)
+	})
+
+	cfg := DefaultRateLimitConfig
+	cfg.Rate = 1
+	cfg.Burst = 10
+	cfg.APIKeys = map[string]float64{"vip": 1000}
+	cfg.TrustForwardedFor = true
+
+	t.Run("weights", func(t *testing.T) {
+		h := newRateLimitHandler(next, cfg).(*rateLimitHandler)
+		require.Equal(1, h.weight([]byte(`{"method":"eth_blockNumber"}`)))
+		require.Equal(10, h.weight([]byte(`{"method":"eth_getLogs"}`)))
+		require.Equal(11, h.weight([]byte(`[{"method":"eth_getLogs"},{"method":"eth_chainId"}]`)))
+		require.Equal(1, h.weight([]byte(`[]`)))
+	})
+
+	t.Run("limited by ip", func(t *testing.T) {
+		served = nil
+		h := newRateLimitHandler(next, cfg)
+		// a request heavier than the burst drains the bucket
+		resp := post(h, `{"method":"debug_traceCall"}`, nil)
+		require.Equal(http.StatusOK, resp.Code)
+		resp = post(h, `{"method":"eth_chainId"}`, nil)
+		require.Equal(http.StatusTooManyRequests, resp.Code)
+		require.Equal("1", resp.Header().Get("Retry-After"))
+		require.Contains(resp.Body.String(), "rate limit exceeded")
+		require.Equal([]string{`{"method":"debug_traceCall"}`}, served)
+
+		// other ip has its own bucket
+		resp = post(h, `{"method":"eth_chainId"}`, map[string]string{"X-Forwarded-For": "5.6.7.8, 1.2.3.4"})
+		require.Equal(http.StatusOK, resp.Code)
+		// unknown api key is limited by ip
+		resp = post(h, `{"method":"eth_chainId"}`, map[string]string{"X-API-Key": "unknown"})
+		require.Equal(http.StatusTooManyRequests, resp.Code)
+		// api key has its own rate
+		for range 20 {
+			resp = post(h, `{"method":"eth_getLogs"}`, map[string]string{"X-API-Key": "vip"})
+			require.Equal(http.StatusOK, resp.Code)
+		}
+	})
+
+	t.Run("retry after", func(t *testing.T) {
+		h := newRateLimitHandler(next, cfg)
+		require.Equal(http.StatusOK, post(h, `{"method":"eth_getLogs"}`, nil).Code)
+		resp := post(h, `{"method":"eth_getLogs"}`, nil)
+		require.Equal(http.StatusTooManyRequests, resp.Code)
+		retryAfter, err := strconv.Atoi(resp.Header().Get("Retry-After"))
+		require.NoError(err)
+		require.InDelta(10, retryAfter, 1)
+	})
+
+	t.Run("idle buckets dropped", func(t *testing.T) {
+		c := cfg
+		c.IdleTimeout = time.Millisecond
+		h := newRateLimitHandler(next, c).(*rateLimitHandler)
+		ok, _ := h.allow("ip:a", 1, 1)
+		require.True(ok)
+		time.Sleep(2 * time.Millisecond)
+		ok, _ = h.allow("ip:b", 1, 1)
+		require.True(ok)
+		require.Len(h.buckets, 1)
+	})
+
+	t.Run("non post passes through", func(t *testing.T) {
+		served = nil
+		h := newRateLimitHandler(next, cfg)
+		for range 20 {
+			resp := httptest.NewRecorder()
+			h.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "http://url.com", nil))
+			require.Equal(http.StatusOK, resp.Code)
+		}
+	})
+	t.Run("reload", func(t *testing.T) {
+		h := newReloadableRateLimitHandler(next, DefaultRateLimitConfig)
+		for range 5 {
+			require.Equal(http.StatusOK, post(h, `{"method":"eth_getLogs"}`, nil).Code)
+		}
+		h.SetConfig(cfg)
+		require.Equal(http.StatusOK, post(h, `{"method":"eth_getLogs"}`, nil).Code)
+		require.Equal(http.StatusTooManyRequests, post(h, `{"method":"eth_getLogs"}`, nil).Code)
+		h.SetConfig(DefaultRateLimitConfig)
+		require.Equal(http.StatusOK, post(h, `{"method":"eth_getLogs"}`, nil).Code)
+	})
+}
@@ -0,0 +1,198 @@
+package api
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/v2/api/apipb"
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+)
+
+// _maxReadStateBatchSize is the maximum number of requests in a batch of read state
+const _maxReadStateBatchSize = 100
+
+type (
+	// stateReadContext is the context shared by the reads of states in a round trip. The reads without height are
+	// read at the same tip height, and the reads at the same history height share the history state reader
+	stateReadContext struct {
+		core      *coreService
+		ctx       context.Context
+		tipHeight uint64
+		readCtx   context.Context
+		readers   map[uint64]protocol.StateManagerWithCloser
+	}
+
+	// stateService serves the reads of states
+	stateService struct {
+		coreService CoreService
+	}
+)
+
+func (core *coreService) newStateReadContext(ctx context.Context) *stateReadContext {
+	return &stateReadContext{
+		core:      core,
+		ctx:       ctx,
+		tipHeight: core.bc.TipHeight(),
+		readers:   make(map[uint64]protocol.StateManagerWithCloser),
+	}
+}
+
+// context returns the context to read the states, which is built on the first read
+func (rc *stateReadContext) context() (context.Context, error) {
+	if rc.readCtx != nil {
+		return rc.readCtx, nil
+	}
+	// TODO: need to complete the context
+	ctx, err := rc.core.bc.Context(rc.ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx = protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+		BlockHeight: rc.tipHeight,
+	})
+	ctx = genesis.WithGenesisContext(
+		protocol.WithRegistry(ctx, rc.core.registry),
+		rc.core.bc.Genesis(),
+	)
+	rc.readCtx = protocol.WithFeatureCtx(protocol.WithFeatureWithHeightCtx(ctx))
+	return rc.readCtx, nil
+}
+
+// historyReader returns the state reader at the history height
+func (rc *stateReadContext) historyReader(ctx context.Context, height uint64) (protocol.StateReader, error) {
+	if sr, ok := rc.readers[height]; ok {
+		return sr, nil
+	}
+	sr, err := rc.core.sf.WorkingSetAtHeight(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	rc.readers[height] = sr
+	return sr, nil
+}
+
+func (rc *stateReadContext) close() {
+	for _, sr := range rc.readers {
+		sr.Close()
+	}
+	rc.readers = nil
+}
+
+func (core *coreService) readStateIn(rc *stateReadContext, p protocol.Protocol, height string, methodName []byte, arguments ...[]byte) ([]byte, uint64, error) {
+	var (
+		tipHeight   = rc.tipHeight
+		inputHeight = tipHeight
+		err         error
+	)
+	if height != "" {
+		if inputHeight, err = strconv.ParseUint(height, 0, 64); err != nil {
+			return nil, 0, err
+		}
+	}
+	// the states at and above the tip height are the latest states, which share the results read at the tip
+	latest := inputHeight >= tipHeight
+	key := ReadKey{
+		Name:   p.Name(),
+		Height: height,
+		Method: methodName,
+		Args:   arguments,
+	}
+	if latest {
+		key.Height = strconv.FormatUint(tipHeight, 10)
+	}
+	keyHash := key.Hash()
+	if d, h, ok := core.readStateCache.get(keyHash, latest); ok {
+		return d, h, nil
+	}
+
+	ctx, err := rc.context()
+	if err != nil {
+		return nil, 0, err
+	}
+	if !latest {
+		rp := rolldpos.FindProtocol(core.registry)
+		if rp != nil {
+			tipEpochNum := rp.GetEpochNum(tipHeight)
+			inputEpochNum := rp.GetEpochNum(inputHeight)
+			if inputEpochNum < tipEpochNum {
+				inputHeight = rp.GetEpochHeight(inputEpochNum)
+			}
+		}
+		// old data, wrap to history state reader
+		historySR, err := rc.historyReader(ctx, inputHeight)
+		if err != nil {
+			return nil, 0, err
+		}
+		d, h, err := p.ReadState(ctx, historySR, methodName, arguments...)
+		if err == nil {
+			core.readStateCache.put(keyHash, false, d, h)
+		}
+		return d, h, err
+	}
+	// TODO: need to distinguish user error and system error
+	d, h, err := p.ReadState(ctx, core.sf, methodName, arguments...)
+	if err == nil {
+		core.readStateCache.put(keyHash, true, d, h)
+	}
+	return d, h, err
+}
+
+// ReadStateBatch reads the states of the requests in a shared context, the requests without height are read at
+// the same tip height. It returns the responses and the errors in the order of the requests
+func (core *coreService) ReadStateBatch(ctx context.Context, reqs []*iotexapi.ReadStateRequest) ([]*iotexapi.ReadStateResponse, []error) {
+	var (
+		rc    = core.newStateReadContext(ctx)
+		resps = make([]*iotexapi.ReadStateResponse, len(reqs))
+		errs  = make([]error, len(reqs))
+	)
+	defer rc.close()
+	for i, req := range reqs {
+		if err := ctx.Err(); err != nil {
+			errs[i] = status.FromContextError(err).Err()
+			continue
+		}
+		resps[i], errs[i] = core.readStateResponse(rc, string(req.GetProtocolID()), req.GetHeight(), req.GetMethodName(), req.GetArguments())
+	}
+	return resps, errs
+}
+
+func newStateService(core CoreService) *stateService {
+	return &stateService{
+		coreService: core,
+	}
+}
+
+// ReadStateBatch reads the states of the requests in a single round trip
+func (svr *stateService) ReadStateBatch(ctx context.Context, in *apipb.ReadStateBatchRequest) (*apipb.ReadStateBatchResponse, error) {
+	reqs := in.GetRequests()
+	if len(reqs) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "empty requests")
+	}
+	if len(reqs) > _maxReadStateBatchSize {
+		return nil, status.Errorf(codes.InvalidArgument, "number of requests %d exceeds the limit %d", len(reqs), _maxReadStateBatchSize)
+	}
+	resps, errs := svr.coreService.ReadStateBatch(ctx, reqs)
+	ret := &apipb.ReadStateBatchResponse{
+		Results: make([]*apipb.ReadStateResult, len(reqs)),
+	}
+	for i := range reqs {
+		if errs[i] != nil {
+			st := status.Convert(errs[i])
+			ret.Results[i] = &apipb.ReadStateResult{
+				Code:    uint32(st.Code()),
+				Message: st.Message(),
+			}
+			continue
+		}
+		ret.Results[i] = &apipb.ReadStateResult{
+			Response: resps[i],
+		}
+	}
+	return ret, nil
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"sync"
+
+	"github.com/iotexproject/go-pkgs/cache"
+	"github.com/iotexproject/go-pkgs/hash"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+)
+
+type (
+	// readStateCache caches the results of the read state queries. The results of the queries at the heights below
+	// the tip are immutable, and kept until evicted or the chain is reorganized, while the results of the queries of
+	// the latest states are dropped on every new block. The cached values are shared and must not be modified.
+	readStateCache struct {
+		mutex     sync.RWMutex
+		tipHeight uint64
+		finalized cache.LRUCache // read key hash -> *readStateEntry
+		latest    cache.LRUCache // read key hash at the tip height -> *readStateEntry
+	}
+
+	readStateEntry struct {
+		data []byte
+		// height is the height of the state read, which could be below the height queried, e.g., the epoch start
+		height uint64
+	}
+)
+
+// newReadStateCache returns a read state cache holding up to size results of each kind, nil is returned if size is
+// 0, which disables the cache
+func newReadStateCache(size int) *readStateCache {
+	if size <= 0 {
+		return nil
+	}
+	return &readStateCache{
+		finalized: cache.NewThreadSafeLruCache(size),
+		latest:    cache.NewThreadSafeLruCache(size),
+	}
+}
+
+func (rc *readStateCache) get(key hash.Hash160, latest bool) ([]byte, uint64, bool) {
+	if rc == nil {
+		return nil, 0, false
+	}
+	rc.mutex.RLock()
+	defer rc.mutex.RUnlock()
+	c, typ := rc.finalized, "readstate_finalized"
+	if latest {
+		c, typ = rc.latest, "readstate_latest"
+	}
+	v, ok := c.Get(key)
+	recordResponseCache(typ, ok)
+	if !ok {
+		return nil, 0, false
+	}
+	e := v.(*readStateEntry)
+	return e.data, e.height, true
+}
+
+func (rc *readStateCache) put(key hash.Hash160, latest bool, data []byte, height uint64) {
+	if rc == nil {
+		return
+	}
+	rc.mutex.RLock()
+	defer rc.mutex.RUnlock()
+	e := &readStateEntry{data: data, height: height}
+	if latest {
+		rc.latest.Add(key, e)
+		return
+	}
+	rc.finalized.Add(key, e)
+}
+
+// receiveBlock drops the results of the latest states, and the results at and above the height of blk if the height
+// has been seen before, i.e., the chain has been reorganized
+func (rc *readStateCache) receiveBlock(blk *block.Block) {
+	if rc == nil {
+		return
+	}
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+	rc.latest.Clear()
+	height := blk.Height()
+	if height > rc.tipHeight {
+		rc.tipHeight = height
+		return
+	}
+	rc.tipHeight = height
+	removeFromCache(rc.finalized, func(_ cache.Key, v interface{}) bool {
+		return v.(*readStateEntry).height >= height
+	})
+}
+
+func (rc *readStateCache) clear() {
+	if rc == nil {
+		return
+	}
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+	rc.latest.Clear()
+	rc.finalized.Clear()
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+func TestReadStateCache(t *testing.T) {
+	r := require.New(t)
+
+	r.Nil(newReadStateCache(0))
+	var disabled *readStateCache
+	disabled.put(hash.ZeroHash160, true, []byte("data"), 1)
+	_, _, ok := disabled.get(hash.ZeroHash160, true)
+	r.False(ok)
+	disabled.receiveBlock(nil)
+	disabled.clear()
+
+	newBlock := func(height uint64) *block.Block {
+		blk, err := block.NewTestingBuilder().
+			SetHeight(height).
+			SetPrevBlockHash(hash.ZeroHash256).
+			SetTimeStamp(time.Now()).
+			SignAndBuild(identityset.PrivateKey(0))
+		r.NoError(err)
+		return &blk
+	}
+	keyAt := func(height string) hash.Hash160 {
+		return (&ReadKey{Name: "staking", Height: height, Method: []byte("TotalStakingAmount")}).Hash()
+	}
+
+	c := newReadStateCache(2)
+	c.receiveBlock(newBlock(10))
+	for _, h := range []uint64{4, 6, 8} {
+		c.put(keyAt(string(rune('0'+h))), false, []byte{byte(h)}, h)
+	}
+	c.put(keyAt("10"), true, []byte{10}, 10)
+	// the oldest entries are evicted
+	_, _, ok = c.get(keyAt("4"), false)
+	r.False(ok)
+	d, h, ok := c.get(keyAt("6"), false)
+	r.True(ok)
+	r.Equal([]byte{6}, d)
+	r.EqualValues(6, h)
+	// the latest and the finalized results are apart
+	_, _, ok = c.get(keyAt("10"), false)
+	r.False(ok)
+	d, h, ok = c.get(keyAt("10"), true)
+	r.True(ok)
+	r.Equal([]byte{10}, d)
+	r.EqualValues(10, h)
+
+	// a new block drops the latest results only
+	c.receiveBlock(newBlock(11))
+	_, _, ok = c.get(keyAt("10"), true)
+	r.False(ok)
+	_, _, ok = c.get(keyAt("8"), false)
+	r.True(ok)
+
+	// a reorg drops the results at and above the reorg height
+	c.receiveBlock(newBlock(7))
+	_, _, ok = c.get(keyAt("6"), false)
+	r.True(ok)
+	_, _, ok = c.get(keyAt("8"), false)
+	r.False(ok)
+
+	c.clear()
+	_, _, ok = c.get(keyAt("6"), false)
+	r.False(ok)
+}
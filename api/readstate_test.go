@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/v2/api/apipb"
+	"github.com/iotexproject/iotex-core/v2/pkg/unit"
+	"github.com/iotexproject/iotex-core/v2/testutil"
+)
+
+func TestReadStateBatch(t *testing.T) {
+	require := require.New(t)
+	cfg := newConfig()
+	cfg.api.GRPCPort = testutil.RandomPort()
+	svr, _, _, _, _, _, bfIndexFile, err := createServerV2(cfg, false)
+	require.NoError(err)
+	defer func() {
+		testutil.CleanupPath(bfIndexFile)
+	}()
+
+	reqs := []*iotexapi.ReadStateRequest{
+		{ProtocolID: []byte("rewarding"), MethodName: []byte("TotalBalance")},
+		{ProtocolID: []byte("unknown"), MethodName: []byte("TotalBalance")},
+		{ProtocolID: []byte("rewarding"), MethodName: []byte("AvailableBalance")},
+	}
+	resps, errs := svr.core.ReadStateBatch(context.Background(), reqs)
+	require.Len(resps, 3)
+	require.Len(errs, 3)
+	require.NoError(errs[0])
+	require.Equal(codes.Internal, status.Code(errs[1]))
+	require.NoError(errs[2])
+	val, ok := new(big.Int).SetString(string(resps[0].Data), 10)
+	require.True(ok)
+	require.Equal(unit.ConvertIotxToRau(200000000), val)
+	val, ok = new(big.Int).SetString(string(resps[2].Data), 10)
+	require.True(ok)
+	require.Equal(unit.ConvertIotxToRau(199999936), val)
+	// the requests are read at the same height
+	require.Equal(resps[0].BlockIdentifier, resps[2].BlockIdentifier)
+	// same as reading one by one
+	out, err := svr.core.ReadState("rewarding", "", []byte("TotalBalance"), nil)
+	require.NoError(err)
+	require.Equal(out.Data, resps[0].Data)
+
+	// canceled context
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, errs = svr.core.ReadStateBatch(ctx, reqs[:1])
+	require.Equal(codes.Canceled, status.Code(errs[0]))
+}
+
+func TestStateService_ReadStateBatch(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	core := NewMockCoreService(ctrl)
+	svr := newStateService(core)
+
+	_, err := svr.ReadStateBatch(context.Background(), &apipb.ReadStateBatchRequest{})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+	_, err = svr.ReadStateBatch(context.Background(), &apipb.ReadStateBatchRequest{
+		Requests: make([]*iotexapi.ReadStateRequest, _maxReadStateBatchSize+1),
+	})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+
+	resp := &iotexapi.ReadStateResponse{Data: []byte("10")}
+	core.EXPECT().ReadStateBatch(gomock.Any(), gomock.Any()).Return(
+		[]*iotexapi.ReadStateResponse{resp, nil, nil},
+		[]error{nil, status.Error(codes.NotFound, "not found"), errors.New("failure")},
+	).Times(1)
+	ret, err := svr.ReadStateBatch(context.Background(), &apipb.ReadStateBatchRequest{
+		Requests: []*iotexapi.ReadStateRequest{{}, {}, {}},
+	})
+	require.NoError(err)
+	require.Len(ret.Results, 3)
+	require.Equal(resp.Data, ret.Results[0].Response.Data)
+	require.Zero(ret.Results[0].Code)
+	require.Nil(ret.Results[1].Response)
+	require.Equal(uint32(codes.NotFound), ret.Results[1].Code)
+	require.Equal("not found", ret.Results[1].Message)
+	require.Equal(uint32(codes.Unknown), ret.Results[2].Code)
+	require.Equal("failure", ret.Results[2].Message)
+}
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"sync"
+
+	"github.com/iotexproject/go-pkgs/cache"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+)
+
+var _responseCacheMtc = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "iotex_api_response_cache",
+	Help: "api response cache hits and misses.",
+}, []string{"type", "result"})
+
+func init() {
+	prometheus.MustRegister(_responseCacheMtc)
+}
+
+// responseCache caches deserialized blocks and receipts of committed heights, which are immutable
+// unless the chain is reorganized. The cached values are shared and must not be modified by callers.
+type responseCache struct {
+	mutex     sync.RWMutex
+	tipHeight uint64
+	blocks    cache.LRUCache // block hash -> *block.Block
+	receipts  cache.LRUCache // block height -> []*action.Receipt
+}
+
+// newResponseCache returns a response cache holding up to size blocks and size receipt lists,
+// nil is returned if size is 0, which disables the cache
+func newResponseCache(size int) *responseCache {
+	if size <= 0 {
+		return nil
+	}
+	return &responseCache{
+		blocks:   cache.NewThreadSafeLruCache(size),
+		receipts: cache.NewThreadSafeLruCache(size),
+	}
+}
+
+func (rc *responseCache) block(h hash.Hash256) (*block.Block, bool) {
+	if rc == nil {
+		return nil, false
+	}
+	rc.mutex.RLock()
+	defer rc.mutex.RUnlock()
+	v, ok := rc.blocks.Get(h)
+	recordResponseCache("block", ok)
+	if !ok {
+		return nil, false
+	}
+	return v.(*block.Block), true
+}
+
+func (rc *responseCache) putBlock(blk *block.Block) {
+	if rc == nil {
+		return
+	}
+	rc.mutex.RLock()
+	defer rc.mutex.RUnlock()
+	rc.blocks.Add(blk.HashBlock(), blk)
+}
+
+func (rc *responseCache) blockReceipts(height uint64) ([]*action.Receipt, bool) {
+	if rc == nil {
+		return nil, false
+	}
+	rc.mutex.RLock()
+	defer rc.mutex.RUnlock()
+	v, ok := rc.receipts.Get(height)
+	recordResponseCache("receipts", ok)
+	if !ok {
+		return nil, false
+	}
+	return v.([]*action.Receipt), true
+}
+
+func (rc *responseCache) putBlockReceipts(height uint64, receipts []*action.Receipt) {
+	if rc == nil {
+		return
+	}
+	rc.mutex.RLock()
+	defer rc.mutex.RUnlock()
+	rc.receipts.Add(height, receipts)
+}
+
+// receiveBlock invalidates the cached entries at and above the height of blk if the height has been
+// seen before, i.e., the chain has been reorganized
+func (rc *responseCache) receiveBlock(blk *block.Block) {
+	if rc == nil {
+		return
+	}
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+	height := blk.Height()
+	if height > rc.tipHeight {
+		rc.tipHeight = height
+		return
+	}
+	rc.tipHeight = height
+	removeFromCache(rc.blocks, func(_ cache.Key, v interface{}) bool {
+		return v.(*block.Block).Height() >= height
+	})
+	removeFromCache(rc.receipts, func(k cache.Key, _ interface{}) bool {
+		return k.(uint64) >= height
+	})
+}
+
+func removeFromCache(c cache.LRUCache, match func(cache.Key, interface{}) bool) {
+	var keys []cache.Key
+	c.Range(func(k cache.Key, v interface{}) bool {
+		if match(k, v) {
+			keys = append(keys, k)
+		}
+		return true
+	})
+	for _, k := range keys {
+		c.Remove(k)
+	}
+}
+
+func recordResponseCache(typ string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	_responseCacheMtc.WithLabelValues(typ, result).Inc()
+}
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+func TestResponseCache(t *testing.T) {
+	r := require.New(t)
+
+	r.Nil(newResponseCache(0))
+	var disabled *responseCache
+	disabled.putBlockReceipts(1, []*action.Receipt{})
+	_, ok := disabled.blockReceipts(1)
+	r.False(ok)
+	disabled.receiveBlock(nil)
+
+	blks := make([]*block.Block, 4)
+	for i := range blks {
+		blk, err := block.NewTestingBuilder().
+			SetHeight(uint64(i + 1)).
+			SetPrevBlockHash(hash.ZeroHash256).
+			SetTimeStamp(time.Now()).
+			SignAndBuild(identityset.PrivateKey(0))
+		r.NoError(err)
+		blks[i] = &blk
+	}
+	c := newResponseCache(3)
+	for _, blk := range blks {
+		c.receiveBlock(blk)
+		c.putBlock(blk)
+		c.putBlockReceipts(blk.Height(), []*action.Receipt{{BlockHeight: blk.Height()}})
+	}
+	// the oldest entries are evicted
+	_, ok = c.block(blks[0].HashBlock())
+	r.False(ok)
+	_, ok = c.blockReceipts(1)
+	r.False(ok)
+	for _, blk := range blks[1:] {
+		cached, ok := c.block(blk.HashBlock())
+		r.True(ok)
+		r.Equal(blk, cached)
+		receipts, ok := c.blockReceipts(blk.Height())
+		r.True(ok)
+		r.Equal(blk.Height(), receipts[0].BlockHeight)
+	}
+
+	// a new block does not invalidate the cache
+	blk5, err := block.NewTestingBuilder().
+		SetHeight(5).
+		SetPrevBlockHash(hash.ZeroHash256).
+		SetTimeStamp(time.Now()).
+		SignAndBuild(identityset.PrivateKey(0))
+	r.NoError(err)
+	c.receiveBlock(&blk5)
+	_, ok = c.block(blks[3].HashBlock())
+	r.True(ok)
+
+	// a reorg invalidates the entries at and above the reorg height
+	c.receiveBlock(blks[2])
+	_, ok = c.block(blks[1].HashBlock())
+	r.True(ok)
+	_, ok = c.blockReceipts(2)
+	r.True(ok)
+	for _, blk := range blks[2:] {
+		_, ok = c.block(blk.HashBlock())
+		r.False(ok)
+		_, ok = c.blockReceipts(blk.Height())
+		r.False(ok)
+	}
+}
@@ -32,6 +32,7 @@ type ServerV2 struct {
 	httpSvr      *HTTPServer
 	websocketSvr *HTTPServer
 	tracer       *tracesdk.TracerProvider
+	rateLimiter  *reloadableRateLimitHandler
 }
 
 // NewServerV2 creates a new server with coreService and GRPC Server
@@ -52,7 +53,15 @@ func NewServerV2(
 	if err != nil {
 		return nil, err
 	}
-	web3Handler := NewWeb3Handler(coreAPI, cfg.RedisCacheURL, cfg.BatchRequestLimit)
+	web3Handler := NewWeb3Handler(
+		coreAPI,
+		cfg.RedisCacheURL,
+		cfg.BatchRequestLimit,
+		WithBatchGasLimit(cfg.BatchGasLimit),
+		WithBatchTimeout(cfg.BatchTimeout),
+		WithFilterCache(cfg.FilterTTL, cfg.FilterLimit),
+		WithGasBreakdown(cfg.GasBreakdown),
+	)
 
 	tp, err := tracer.NewProvider(
 		tracer.WithServiceName(cfg.Tracer.ServiceName),
@@ -64,17 +73,39 @@ func NewServerV2(
 		return nil, errors.Wrapf(err, "cannot config tracer provider")
 	}
 
-	wrappedWeb3Handler := otelhttp.NewHandler(newHTTPHandler(web3Handler), "web3.jsonrpc")
+	tlsConfig, err := cfg.TLS.serverTLSConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid tls config")
+	}
+	var (
+		grpcOpts []GRPCServerOption
+		httpOpts []HTTPServerOption
+	)
+	if tlsConfig != nil {
+		grpcOpts = append(grpcOpts, WithGRPCTLS(tlsConfig, cfg.TLS.AdminServices))
+		httpOpts = append(httpOpts, WithHTTPTLS(tlsConfig))
+	}
+
+	rateLimiter := newReloadableRateLimitHandler(newHTTPHandler(web3Handler), cfg.RateLimit)
+	wrappedWeb3Handler := otelhttp.NewHandler(newCORSHandler(newAdminAuthHandler(rateLimiter, cfg.AdminToken), cfg.CORS), "web3.jsonrpc")
 
 	limiter := rate.NewLimiter(rate.Limit(cfg.WebsocketRateLimit), 1)
-	wrappedWebsocketHandler := otelhttp.NewHandler(NewWebsocketHandler(coreAPI, web3Handler, limiter), "web3.websocket")
+	wrappedWebsocketHandler := otelhttp.NewHandler(NewWebsocketHandler(
+		coreAPI,
+		web3Handler,
+		limiter,
+		WithSubscriptionLimit(cfg.WebsocketSubscriptionLimit),
+		WithSendQueueSize(cfg.WebsocketSendQueueSize),
+		WithCheckOrigin(cfg.CORS.checkOrigin),
+	), "web3.websocket")
 
 	return &ServerV2{
 		core:         coreAPI,
-		grpcServer:   NewGRPCServer(coreAPI, newBlockDAOService(dao), cfg.GRPCPort),
-		httpSvr:      NewHTTPServer("", cfg.HTTPPort, wrappedWeb3Handler),
-		websocketSvr: NewHTTPServer("", cfg.WebSocketPort, wrappedWebsocketHandler),
+		grpcServer:   NewGRPCServer(coreAPI, newBlockDAOService(dao), cfg.GRPCPort, grpcOpts...),
+		httpSvr:      NewHTTPServer("", cfg.HTTPPort, newHealthHandler(coreAPI, cfg.ReadyMaxBlockLag).handler(wrappedWeb3Handler), httpOpts...),
+		websocketSvr: NewHTTPServer("", cfg.WebSocketPort, wrappedWebsocketHandler, httpOpts...),
 		tracer:       tp,
+		rateLimiter:  rateLimiter,
 	}, nil
 }
 
@@ -138,3 +169,8 @@ func (svr *ServerV2) ReceiveBlock(blk *block.Block) error {
 func (svr *ServerV2) CoreService() CoreService {
 	return svr.core
 }
+
+// SetRateLimit replaces the rate limiter of the web3 http endpoint at runtime
+func (svr *ServerV2) SetRateLimit(cfg RateLimitConfig) {
+	svr.rateLimiter.SetConfig(cfg)
+}
@@ -0,0 +1,165 @@
+package api
+
+import (
+	"context"
+	"math"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// _evmWordSize is the size in bytes of a word of the EVM memory
+const _evmWordSize = 32
+
+type (
+	// SimulationConfig is the config of the simulations of eth_call and eth_estimateGas
+	SimulationConfig struct {
+		// Concurrency is the maximum number of simulations running at the same time, 0 means no limit
+		Concurrency int `yaml:"concurrency"`
+		// QueueSize is the maximum number of simulations waiting for a running one to finish, the others are rejected
+		QueueSize int `yaml:"queueSize"`
+		// GasCap is the maximum gas of a simulation, 0 means the block gas limit
+		GasCap uint64 `yaml:"gasCap"`
+		// Timeout is the maximum time of a simulation, 0 means no limit
+		Timeout time.Duration `yaml:"timeout"`
+		// MemoryLimit is the maximum EVM memory in bytes of a simulation, which further caps the gas of the simulation
+		// by the gas to expand the memory to the limit, 0 means no limit
+		MemoryLimit uint64 `yaml:"memoryLimit"`
+	}
+
+	// simulationLimiter runs the simulations in a bounded number of workers
+	simulationLimiter struct {
+		workers   chan struct{}
+		queued    atomic.Int64
+		queueSize int64
+		timeout   time.Duration
+	}
+)
+
+// DefaultSimulationConfig is the default config of the simulations
+var DefaultSimulationConfig = SimulationConfig{
+	Concurrency: 16,
+	QueueSize:   256,
+	GasCap:      0,
+	Timeout:     5 * time.Second,
+	MemoryLimit: 32 << 20,
+}
+
+var (
+	// ErrSimulationBusy indicates too many simulations are running or waiting
+	ErrSimulationBusy = errors.New("too many simulations")
+
+	_simulationQueueMtc = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "iotex_api_simulation_queue",
+		Help: "number of simulations waiting for a worker",
+	})
+	_simulationMtc = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "iotex_api_simulation",
+		Help: "simulations by result",
+	}, []string{"result"})
+)
+
+func init() {
+	prometheus.MustRegister(_simulationQueueMtc)
+	prometheus.MustRegister(_simulationMtc)
+}
+
+func newSimulationLimiter(cfg SimulationConfig) *simulationLimiter {
+	l := &simulationLimiter{
+		queueSize: int64(cfg.QueueSize),
+		timeout:   cfg.Timeout,
+	}
+	if cfg.Concurrency > 0 {
+		l.workers = make(chan struct{}, cfg.Concurrency)
+	}
+	return l
+}
+
+// run runs the simulation once a worker is available, which is canceled if it doesn't finish in time.
+// A nil limiter runs the simulation without limits
+func (l *simulationLimiter) run(ctx context.Context, simulate func(context.Context) error) error {
+	if l == nil {
+		return simulate(ctx)
+	}
+	if l.workers != nil {
+		if err := l.acquire(ctx); err != nil {
+			return err
+		}
+		defer func() {
+			<-l.workers
+		}()
+	}
+	if l.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, l.timeout)
+		defer cancel()
+	}
+	err := simulate(ctx)
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		_simulationMtc.WithLabelValues("timeout").Inc()
+		return status.Errorf(codes.DeadlineExceeded, "execution timeout after %s", l.timeout)
+	case err != nil:
+		_simulationMtc.WithLabelValues("failure").Inc()
+	default:
+		_simulationMtc.WithLabelValues("success").Inc()
+	}
+	return err
+}
+
+func (l *simulationLimiter) acquire(ctx context.Context) error {
+	select {
+	case l.workers <- struct{}{}:
+		return nil
+	default:
+	}
+	if l.queued.Add(1) > l.queueSize {
+		l.queued.Add(-1)
+		_simulationMtc.WithLabelValues("rejected").Inc()
+		return status.Error(codes.ResourceExhausted, ErrSimulationBusy.Error())
+	}
+	_simulationQueueMtc.Inc()
+	defer func() {
+		l.queued.Add(-1)
+		_simulationQueueMtc.Dec()
+	}()
+	select {
+	case l.workers <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return status.FromContextError(ctx.Err()).Err()
+	}
+}
+
+// gasCap returns the maximum gas of a simulation at the block gas limit
+func (cfg SimulationConfig) gasCap(blockGasLimit uint64) uint64 {
+	gasCap := blockGasLimit
+	if cfg.GasCap > 0 && cfg.GasCap < gasCap {
+		gasCap = cfg.GasCap
+	}
+	if cfg.MemoryLimit > 0 {
+		if memGas := memoryGas(cfg.MemoryLimit); memGas < gasCap {
+			gasCap = memGas
+		}
+	}
+	return gasCap
+}
+
+// memoryGas returns the gas to expand the EVM memory to the size, which is 3 per word plus the square of words / 512
+func memoryGas(size uint64) uint64 {
+	words := (size + _evmWordSize - 1) / _evmWordSize
+	if words > math.MaxUint32 {
+		return math.MaxUint64
+	}
+	return 3*words + words*words/512
+}
+
+// simulationGasCap returns the maximum gas of a simulation at the height
+func (core *coreService) simulationGasCap(height uint64) uint64 {
+	g := core.bc.Genesis()
+	return core.cfg.Simulation.gasCap(g.BlockGasLimitByHeight(height))
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSimulationLimiter(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("concurrency and queue", func(t *testing.T) {
+		l := newSimulationLimiter(SimulationConfig{Concurrency: 1, QueueSize: 2})
+		var (
+			running = make(chan struct{})
+			release = make(chan struct{})
+			wg      sync.WaitGroup
+		)
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			require.NoError(l.run(context.Background(), func(context.Context) error {
+				close(running)
+				<-release
+				return nil
+			}))
+		}()
+		<-running
+		go func() {
+			defer wg.Done()
+			require.NoError(l.run(context.Background(), func(context.Context) error {
+				return nil
+			}))
+		}()
+		// the caller gives up waiting
+		ctx, cancel := context.WithCancel(context.Background())
+		canceled := make(chan error)
+		go func() {
+			canceled <- l.run(ctx, func(context.Context) error {
+				return nil
+			})
+		}()
+		require.Eventually(func() bool {
+			return l.queued.Load() == 2
+		}, time.Second, 10*time.Millisecond)
+		// the queue is full
+		err := l.run(context.Background(), func(context.Context) error {
+			return nil
+		})
+		require.Equal(codes.ResourceExhausted, status.Code(err))
+		cancel()
+		require.Equal(codes.Canceled, status.Code(<-canceled))
+		close(release)
+		wg.Wait()
+		require.Zero(l.queued.Load())
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		l := newSimulationLimiter(SimulationConfig{Timeout: 10 * time.Millisecond})
+		err := l.run(context.Background(), func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		require.Equal(codes.DeadlineExceeded, status.Code(err))
+		require.NoError(l.run(context.Background(), func(context.Context) error {
+			return nil
+		}))
+	})
+}
+
+func TestSimulationGasCap(t *testing.T) {
+	require := require.New(t)
+	require.Equal(uint64(0), memoryGas(0))
+	require.Equal(uint64(3), memoryGas(1))
+	// 1024 words
+	require.Equal(uint64(3*1024+1024*1024/512), memoryGas(32*1024))
+
+	require.Equal(uint64(30000000), SimulationConfig{}.gasCap(30000000))
+	require.Equal(uint64(10000000), SimulationConfig{GasCap: 10000000}.gasCap(30000000))
+	require.Equal(uint64(30000000), SimulationConfig{GasCap: 50000000}.gasCap(30000000))
+	require.Equal(uint64(30000000), DefaultSimulationConfig.gasCap(30000000))
+	require.Equal(memoryGas(1<<20), SimulationConfig{MemoryLimit: 1 << 20}.gasCap(30000000))
+}
@@ -0,0 +1,243 @@
+package api
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/api/apipb"
+)
+
+const (
+	_defaultStakingPageSize = 100
+	_maxStakingPageSize     = 1000
+)
+
+// stakingService serves the staking queries of native and contract staking at an explicit height
+type stakingService struct {
+	coreService CoreService
+}
+
+func newStakingService(core CoreService) *stakingService {
+	return &stakingService{
+		coreService: core,
+	}
+}
+
+// GetBuckets returns a page of buckets, filtered by voter, candidate or indexes
+func (service *stakingService) GetBuckets(_ context.Context, in *apipb.GetBucketsRequest) (*apipb.GetBucketsResponse, error) {
+	var (
+		method   iotexapi.ReadStakingDataMethod_Name
+		req      = &iotexapi.ReadStakingDataRequest{}
+		paginate = true
+	)
+	offset, limit, err := stakingPage(in.GetPagination())
+	if err != nil {
+		return nil, err
+	}
+	// query one more bucket to tell if there are more pages
+	pagination := &iotexapi.PaginationParam{Offset: offset, Limit: limit + 1}
+	switch filter := in.GetFilter().(type) {
+	case nil:
+		method = iotexapi.ReadStakingDataMethod_BUCKETS
+		req.Request = &iotexapi.ReadStakingDataRequest_Buckets{
+			Buckets: &iotexapi.ReadStakingDataRequest_VoteBuckets{Pagination: pagination},
+		}
+	case *apipb.GetBucketsRequest_VoterAddress:
+		method = iotexapi.ReadStakingDataMethod_BUCKETS_BY_VOTER
+		req.Request = &iotexapi.ReadStakingDataRequest_BucketsByVoter{
+			BucketsByVoter: &iotexapi.ReadStakingDataRequest_VoteBucketsByVoter{VoterAddress: filter.VoterAddress, Pagination: pagination},
+		}
+	case *apipb.GetBucketsRequest_CandidateName:
+		method = iotexapi.ReadStakingDataMethod_BUCKETS_BY_CANDIDATE
+		req.Request = &iotexapi.ReadStakingDataRequest_BucketsByCandidate{
+			BucketsByCandidate: &iotexapi.ReadStakingDataRequest_VoteBucketsByCandidate{CandName: filter.CandidateName, Pagination: pagination},
+		}
+	case *apipb.GetBucketsRequest_Indexes:
+		method = iotexapi.ReadStakingDataMethod_BUCKETS_BY_INDEXES
+		req.Request = &iotexapi.ReadStakingDataRequest_BucketsByIndexes{
+			BucketsByIndexes: &iotexapi.ReadStakingDataRequest_VoteBucketsByIndexes{Index: filter.Indexes.GetIndex()},
+		}
+		paginate = false
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported filter %T", filter)
+	}
+	if in.GetIncludeContractStaking() {
+		method = compositeStakingMethod(method)
+	}
+	buckets := &iotextypes.VoteBucketList{}
+	height, err := service.readStakingData(in.GetHeight(), method, req, buckets)
+	if err != nil {
+		return nil, err
+	}
+	resp := &apipb.GetBucketsResponse{
+		Buckets:    buckets,
+		Pagination: &apipb.PaginationResult{},
+		Height:     height,
+	}
+	if !paginate {
+		return resp, nil
+	}
+	if len(buckets.Buckets) > int(limit) {
+		buckets.Buckets = buckets.Buckets[:limit]
+		resp.Pagination.HasMore = true
+	}
+	resp.Pagination.NextOffset = offset + uint32(len(buckets.Buckets))
+	if in.GetFilter() == nil {
+		// read the total at the height the buckets are read from
+		method := iotexapi.ReadStakingDataMethod_BUCKETS_COUNT
+		if in.GetIncludeContractStaking() {
+			method = iotexapi.ReadStakingDataMethod_COMPOSITE_BUCKETS_COUNT
+		}
+		count := &iotextypes.BucketsCount{}
+		if _, err := service.readStakingData(height, method, &iotexapi.ReadStakingDataRequest{
+			Request: &iotexapi.ReadStakingDataRequest_BucketsCount_{
+				BucketsCount: &iotexapi.ReadStakingDataRequest_BucketsCount{},
+			},
+		}, count); err != nil {
+			return nil, err
+		}
+		resp.Pagination.Total = count.GetTotal()
+	}
+	return resp, nil
+}
+
+// GetCandidates returns a page of candidates, with the votes of both native and contract staking
+func (service *stakingService) GetCandidates(_ context.Context, in *apipb.GetCandidatesRequest) (*apipb.GetCandidatesResponse, error) {
+	offset, limit, err := stakingPage(in.GetPagination())
+	if err != nil {
+		return nil, err
+	}
+	candidates := &iotextypes.CandidateListV2{}
+	height, err := service.readStakingData(in.GetHeight(), iotexapi.ReadStakingDataMethod_CANDIDATES, &iotexapi.ReadStakingDataRequest{
+		Request: &iotexapi.ReadStakingDataRequest_Candidates_{
+			Candidates: &iotexapi.ReadStakingDataRequest_Candidates{
+				Pagination: &iotexapi.PaginationParam{Offset: offset, Limit: limit + 1},
+			},
+		},
+	}, candidates)
+	if err != nil {
+		return nil, err
+	}
+	resp := &apipb.GetCandidatesResponse{
+		Candidates: candidates,
+		Pagination: &apipb.PaginationResult{},
+		Height:     height,
+	}
+	if len(candidates.Candidates) > int(limit) {
+		candidates.Candidates = candidates.Candidates[:limit]
+		resp.Pagination.HasMore = true
+	}
+	resp.Pagination.NextOffset = offset + uint32(len(candidates.Candidates))
+	return resp, nil
+}
+
+// GetCandidate returns the candidate by name or owner address
+func (service *stakingService) GetCandidate(_ context.Context, in *apipb.GetCandidateRequest) (*apipb.GetCandidateResponse, error) {
+	var (
+		method iotexapi.ReadStakingDataMethod_Name
+		req    = &iotexapi.ReadStakingDataRequest{}
+	)
+	switch id := in.GetId().(type) {
+	case *apipb.GetCandidateRequest_Name:
+		method = iotexapi.ReadStakingDataMethod_CANDIDATE_BY_NAME
+		req.Request = &iotexapi.ReadStakingDataRequest_CandidateByName_{
+			CandidateByName: &iotexapi.ReadStakingDataRequest_CandidateByName{CandName: id.Name},
+		}
+	case *apipb.GetCandidateRequest_OwnerAddress:
+		method = iotexapi.ReadStakingDataMethod_CANDIDATE_BY_ADDRESS
+		req.Request = &iotexapi.ReadStakingDataRequest_CandidateByAddress_{
+			CandidateByAddress: &iotexapi.ReadStakingDataRequest_CandidateByAddress{OwnerAddr: id.OwnerAddress},
+		}
+	default:
+		return nil, status.Error(codes.InvalidArgument, "candidate name or owner address is required")
+	}
+	candidate := &iotextypes.CandidateV2{}
+	height, err := service.readStakingData(in.GetHeight(), method, req, candidate)
+	if err != nil {
+		return nil, err
+	}
+	if candidate.GetName() == "" {
+		return nil, status.Errorf(codes.NotFound, "candidate %s is not found at height %d", in.GetId(), height)
+	}
+	return &apipb.GetCandidateResponse{
+		Candidate: candidate,
+		Height:    height,
+	}, nil
+}
+
+// GetBucketTypes returns the bucket types of the staking contract
+func (service *stakingService) GetBucketTypes(_ context.Context, in *apipb.GetBucketTypesRequest) (*apipb.GetBucketTypesResponse, error) {
+	bucketTypes := &iotextypes.ContractStakingBucketTypeList{}
+	height, err := service.readStakingData(in.GetHeight(), iotexapi.ReadStakingDataMethod_CONTRACT_STAKING_BUCKET_TYPES, &iotexapi.ReadStakingDataRequest{
+		Request: &iotexapi.ReadStakingDataRequest_ContractStakingBucketTypes_{
+			ContractStakingBucketTypes: &iotexapi.ReadStakingDataRequest_ContractStakingBucketTypes{ContractAddress: in.GetContractAddress()},
+		},
+	}, bucketTypes)
+	if err != nil {
+		return nil, err
+	}
+	return &apipb.GetBucketTypesResponse{
+		BucketTypes: bucketTypes,
+		Height:      height,
+	}, nil
+}
+
+// readStakingData reads the staking state at height into resp, and returns the height the state is read from,
+// which is the start height of the epoch if height is in a past epoch
+func (service *stakingService) readStakingData(height uint64, method iotexapi.ReadStakingDataMethod_Name, req *iotexapi.ReadStakingDataRequest, resp proto.Message) (uint64, error) {
+	var heightStr string
+	if height > 0 {
+		if tip := service.coreService.TipHeight(); height > tip {
+			return 0, status.Errorf(codes.InvalidArgument, "height %d is higher than the tip height %d", height, tip)
+		}
+		heightStr = strconv.FormatUint(height, 10)
+	}
+	methodName, err := proto.Marshal(&iotexapi.ReadStakingDataMethod{Method: method})
+	if err != nil {
+		return 0, status.Error(codes.Internal, err.Error())
+	}
+	arg, err := proto.Marshal(req)
+	if err != nil {
+		return 0, status.Error(codes.InvalidArgument, err.Error())
+	}
+	out, err := service.coreService.ReadState("staking", heightStr, methodName, [][]byte{arg})
+	if err != nil {
+		return 0, err
+	}
+	if err := proto.Unmarshal(out.GetData(), resp); err != nil {
+		return 0, status.Error(codes.Internal, err.Error())
+	}
+	return out.GetBlockIdentifier().GetHeight(), nil
+}
+
+func stakingPage(in *iotexapi.PaginationParam) (uint32, uint32, error) {
+	limit := in.GetLimit()
+	switch {
+	case limit == 0:
+		limit = _defaultStakingPageSize
+	case limit > _maxStakingPageSize:
+		return 0, 0, status.Errorf(codes.InvalidArgument, "limit %d exceeds the maximum %d", limit, _maxStakingPageSize)
+	}
+	return in.GetOffset(), limit, nil
+}
+
+func compositeStakingMethod(method iotexapi.ReadStakingDataMethod_Name) iotexapi.ReadStakingDataMethod_Name {
+	switch method {
+	case iotexapi.ReadStakingDataMethod_BUCKETS:
+		return iotexapi.ReadStakingDataMethod_COMPOSITE_BUCKETS
+	case iotexapi.ReadStakingDataMethod_BUCKETS_BY_VOTER:
+		return iotexapi.ReadStakingDataMethod_COMPOSITE_BUCKETS_BY_VOTER
+	case iotexapi.ReadStakingDataMethod_BUCKETS_BY_CANDIDATE:
+		return iotexapi.ReadStakingDataMethod_COMPOSITE_BUCKETS_BY_CANDIDATE
+	case iotexapi.ReadStakingDataMethod_BUCKETS_BY_INDEXES:
+		return iotexapi.ReadStakingDataMethod_COMPOSITE_BUCKETS_BY_INDEXES
+	default:
+		return method
+	}
+}
@@ -0,0 +1,134 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/api/apipb"
+)
+
+func stakingStateResponse(t *testing.T, height uint64, data proto.Message) *iotexapi.ReadStateResponse {
+	b, err := proto.Marshal(data)
+	require.NoError(t, err)
+	return &iotexapi.ReadStateResponse{
+		Data:            b,
+		BlockIdentifier: &iotextypes.BlockIdentifier{Height: height},
+	}
+}
+
+func expectStakingMethod(t *testing.T, methodName []byte, expected iotexapi.ReadStakingDataMethod_Name) {
+	method := &iotexapi.ReadStakingDataMethod{}
+	require.NoError(t, proto.Unmarshal(methodName, method))
+	require.Equal(t, expected, method.GetMethod())
+}
+
+func TestStakingService_GetBuckets(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := NewMockCoreService(ctrl)
+	svc := newStakingService(core)
+
+	t.Run("AllBucketsWithTotal", func(t *testing.T) {
+		core.EXPECT().TipHeight().Return(uint64(100)).Times(2)
+		core.EXPECT().ReadState("staking", "50", gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ string, _ string, methodName []byte, args [][]byte) (*iotexapi.ReadStateResponse, error) {
+				expectStakingMethod(t, methodName, iotexapi.ReadStakingDataMethod_COMPOSITE_BUCKETS)
+				req := &iotexapi.ReadStakingDataRequest{}
+				require.NoError(proto.Unmarshal(args[0], req))
+				require.Equal(uint32(10), req.GetBuckets().GetPagination().GetOffset())
+				require.Equal(uint32(3), req.GetBuckets().GetPagination().GetLimit())
+				return stakingStateResponse(t, 50, &iotextypes.VoteBucketList{
+					Buckets: []*iotextypes.VoteBucket{{Index: 10}, {Index: 11}, {Index: 12}},
+				}), nil
+			})
+		core.EXPECT().ReadState("staking", "50", gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ string, _ string, methodName []byte, _ [][]byte) (*iotexapi.ReadStateResponse, error) {
+				expectStakingMethod(t, methodName, iotexapi.ReadStakingDataMethod_COMPOSITE_BUCKETS_COUNT)
+				return stakingStateResponse(t, 50, &iotextypes.BucketsCount{Total: 20, Active: 18}), nil
+			})
+		resp, err := svc.GetBuckets(context.Background(), &apipb.GetBucketsRequest{
+			Height:                 50,
+			Pagination:             &iotexapi.PaginationParam{Offset: 10, Limit: 2},
+			IncludeContractStaking: true,
+		})
+		require.NoError(err)
+		require.Equal(uint64(50), resp.GetHeight())
+		require.Len(resp.GetBuckets().GetBuckets(), 2)
+		require.True(resp.GetPagination().GetHasMore())
+		require.Equal(uint32(12), resp.GetPagination().GetNextOffset())
+		require.Equal(uint64(20), resp.GetPagination().GetTotal())
+	})
+	t.Run("BucketsByVoterAtTip", func(t *testing.T) {
+		core.EXPECT().ReadState("staking", "", gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ string, _ string, methodName []byte, args [][]byte) (*iotexapi.ReadStateResponse, error) {
+				expectStakingMethod(t, methodName, iotexapi.ReadStakingDataMethod_BUCKETS_BY_VOTER)
+				req := &iotexapi.ReadStakingDataRequest{}
+				require.NoError(proto.Unmarshal(args[0], req))
+				require.Equal("voter", req.GetBucketsByVoter().GetVoterAddress())
+				require.Equal(uint32(_defaultStakingPageSize+1), req.GetBucketsByVoter().GetPagination().GetLimit())
+				return stakingStateResponse(t, 100, &iotextypes.VoteBucketList{
+					Buckets: []*iotextypes.VoteBucket{{Index: 1}},
+				}), nil
+			})
+		resp, err := svc.GetBuckets(context.Background(), &apipb.GetBucketsRequest{
+			Filter: &apipb.GetBucketsRequest_VoterAddress{VoterAddress: "voter"},
+		})
+		require.NoError(err)
+		require.Equal(uint64(100), resp.GetHeight())
+		require.Len(resp.GetBuckets().GetBuckets(), 1)
+		require.False(resp.GetPagination().GetHasMore())
+		require.Equal(uint32(1), resp.GetPagination().GetNextOffset())
+		require.Zero(resp.GetPagination().GetTotal())
+	})
+	t.Run("InvalidArgument", func(t *testing.T) {
+		_, err := svc.GetBuckets(context.Background(), &apipb.GetBucketsRequest{
+			Pagination: &iotexapi.PaginationParam{Limit: _maxStakingPageSize + 1},
+		})
+		require.Equal(codes.InvalidArgument, status.Code(err))
+		core.EXPECT().TipHeight().Return(uint64(100)).Times(1)
+		_, err = svc.GetBuckets(context.Background(), &apipb.GetBucketsRequest{Height: 101})
+		require.Equal(codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestStakingService_GetCandidate(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := NewMockCoreService(ctrl)
+	svc := newStakingService(core)
+
+	core.EXPECT().ReadState("staking", "", gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ string, _ string, methodName []byte, args [][]byte) (*iotexapi.ReadStateResponse, error) {
+			expectStakingMethod(t, methodName, iotexapi.ReadStakingDataMethod_CANDIDATE_BY_ADDRESS)
+			req := &iotexapi.ReadStakingDataRequest{}
+			require.NoError(proto.Unmarshal(args[0], req))
+			require.Equal("owner", req.GetCandidateByAddress().GetOwnerAddr())
+			return stakingStateResponse(t, 100, &iotextypes.CandidateV2{Name: "cand", OwnerAddress: "owner"}), nil
+		})
+	resp, err := svc.GetCandidate(context.Background(), &apipb.GetCandidateRequest{
+		Id: &apipb.GetCandidateRequest_OwnerAddress{OwnerAddress: "owner"},
+	})
+	require.NoError(err)
+	require.Equal("cand", resp.GetCandidate().GetName())
+	require.Equal(uint64(100), resp.GetHeight())
+
+	core.EXPECT().ReadState("staking", "", gomock.Any(), gomock.Any()).Return(
+		stakingStateResponse(t, 100, &iotextypes.CandidateV2{}), nil)
+	_, err = svc.GetCandidate(context.Background(), &apipb.GetCandidateRequest{
+		Id: &apipb.GetCandidateRequest_Name{Name: "unknown"},
+	})
+	require.Equal(codes.NotFound, status.Code(err))
+
+	_, err = svc.GetCandidate(context.Background(), &apipb.GetCandidateRequest{})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"encoding/hex"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/v2/state/factory"
+)
+
+type (
+	getProofResult struct {
+		Address      string               `json:"address"`
+		AccountProof []string             `json:"accountProof"`
+		Balance      *hexutil.Big         `json:"balance"`
+		CodeHash     string               `json:"codeHash"`
+		Nonce        hexutil.Uint64       `json:"nonce"`
+		StorageHash  string               `json:"storageHash"`
+		StorageProof []storageProofResult `json:"storageProof"`
+		// StateRoot is the root of the account trie which the account proof is against, as the block header does not
+		// commit to the state root
+		StateRoot string `json:"stateRoot"`
+	}
+
+	storageProofResult struct {
+		Key   string   `json:"key"`
+		Value string   `json:"value"`
+		Proof []string `json:"proof"`
+	}
+)
+
+// StateProof returns the merkle proof of the account at the height, 0 for the tip, and the proofs of the storage keys
+// if the account is a contract
+func (core *coreService) StateProof(addr address.Address, storageKeys []hash.Hash256, height uint64) (*factory.StateProof, error) {
+	prover, ok := core.sf.(factory.StateProver)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "state factory does not support state proof")
+	}
+	if height == 0 {
+		height = core.bc.TipHeight()
+	}
+	sp, err := prover.StateProof(height, hash.BytesToHash160(addr.Bytes()), storageKeys)
+	if err != nil {
+		switch errors.Cause(err) {
+		case factory.ErrNotSupported:
+			return nil, status.Error(codes.Unimplemented, err.Error())
+		case factory.ErrNoArchiveData:
+			return nil, status.Error(codes.NotFound, err.Error())
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+	return sp, nil
+}
+
+func (svr *web3Handler) getProof(in *gjson.Result) (interface{}, error) {
+	ethAddr, keysParam := in.Get("params.0"), in.Get("params.1")
+	if !ethAddr.Exists() || !keysParam.IsArray() {
+		return nil, errInvalidFormat
+	}
+	addr, err := address.FromHex(ethAddr.String())
+	if err != nil {
+		return nil, err
+	}
+	var storageKeys []hash.Hash256
+	for _, k := range keysParam.Array() {
+		b, err := hexToBytes(k.String())
+		if err != nil {
+			return nil, err
+		}
+		if len(b) > len(hash.Hash256{}) {
+			return nil, errors.Wrapf(errInvalidFormat, "invalid storage key %s", k.String())
+		}
+		storageKeys = append(storageKeys, hash.BytesToHash256(b))
+	}
+	var bn = rpc.LatestBlockNumber
+	if bnParam := in.Get("params.2"); bnParam.Exists() {
+		if err := bn.UnmarshalJSON([]byte(bnParam.String())); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal height %s", bnParam.String())
+		}
+	}
+	height, _, err := svr.blockNumberOrHashToHeight(rpc.BlockNumberOrHashWithNumber(bn))
+	if err != nil {
+		return nil, err
+	}
+	sp, err := svr.coreService.StateProof(addr, storageKeys, height)
+	if err != nil {
+		return nil, err
+	}
+	ret := &getProofResult{
+		Address:      ethAddr.String(),
+		AccountProof: encodeProof(sp.AccountProof),
+		Balance:      (*hexutil.Big)(big.NewInt(0)),
+		CodeHash:     "0x" + hex.EncodeToString(hash.ZeroHash256[:]),
+		StorageHash:  "0x" + hex.EncodeToString(hash.ZeroHash256[:]),
+		StorageProof: make([]storageProofResult, 0, len(sp.StorageProofs)),
+		StateRoot:    "0x" + hex.EncodeToString(sp.Root),
+	}
+	if acct := sp.Account; acct != nil {
+		ret.Balance = (*hexutil.Big)(acct.Balance)
+		ret.Nonce = hexutil.Uint64(acct.PendingNonce())
+		if len(acct.CodeHash) > 0 {
+			ret.CodeHash = "0x" + hex.EncodeToString(acct.CodeHash)
+		}
+		ret.StorageHash = "0x" + hex.EncodeToString(acct.Root[:])
+	}
+	for _, p := range sp.StorageProofs {
+		ret.StorageProof = append(ret.StorageProof, storageProofResult{
+			Key:   "0x" + hex.EncodeToString(p.Key[:]),
+			Value: "0x" + hex.EncodeToString(p.Value),
+			Proof: encodeProof(p.Proof),
+		})
+	}
+	return ret, nil
+}
+
+func encodeProof(proof [][]byte) []string {
+	ret := make([]string, len(proof))
+	for i, p := range proof {
+		ret[i] = "0x" + hex.EncodeToString(p)
+	}
+	return ret
+}
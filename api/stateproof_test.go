@@ -0,0 +1,82 @@
+package api
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/v2/state"
+	"github.com/iotexproject/iotex-core/v2/state/factory"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+	"github.com/iotexproject/iotex-core/v2/test/mock/mock_factory"
+)
+
+func TestCoreService_StateProof(t *testing.T) {
+	require := require.New(t)
+	core := &coreService{sf: mock_factory.NewMockFactory(gomock.NewController(t))}
+	_, err := core.StateProof(identityset.Address(1), nil, 1)
+	require.Equal(codes.Unimplemented, status.Code(err))
+}
+
+func TestGetProof(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	core := NewMockCoreService(ctrl)
+	web3svr := &web3Handler{coreService: core, batchRequestLimit: _defaultBatchRequestLimit}
+
+	addr := identityset.Address(1)
+	acct, err := state.NewAccount()
+	require.NoError(err)
+	require.NoError(acct.AddBalance(big.NewInt(10)))
+	acct.Root = hash.Hash256b([]byte("root"))
+	acct.CodeHash = []byte("code")
+	key := hash.BytesToHash256([]byte{1})
+	core.EXPECT().StateProof(addr, []hash.Hash256{key}, uint64(5)).Return(&factory.StateProof{
+		Height:        5,
+		Root:          []byte{1, 2},
+		Account:       acct,
+		AccountProof:  [][]byte{{3}, {4}},
+		StorageProofs: []*factory.StorageProof{{Key: key, Value: []byte{5}, Proof: [][]byte{{6}}}},
+	}, nil).Times(1)
+	in := gjson.Parse(`{"params":["` + addr.Hex() + `", ["0x01"], "0x5"]}`)
+	ret, err := web3svr.getProof(&in)
+	require.NoError(err)
+	res := ret.(*getProofResult)
+	require.Equal([]string{"0x03", "0x04"}, res.AccountProof)
+	require.Equal("0xa", res.Balance.String())
+	require.EqualValues(acct.PendingNonce(), res.Nonce)
+	require.Equal("0x"+hex.EncodeToString([]byte("code")), res.CodeHash)
+	require.Equal("0x"+hex.EncodeToString(acct.Root[:]), res.StorageHash)
+	require.Equal("0x0102", res.StateRoot)
+	require.Equal([]storageProofResult{{
+		Key:   "0x" + hex.EncodeToString(key[:]),
+		Value: "0x05",
+		Proof: []string{"0x06"},
+	}}, res.StorageProof)
+
+	// the absent account
+	core.EXPECT().StateProof(addr, nil, uint64(0)).Return(&factory.StateProof{AccountProof: [][]byte{{3}}}, nil).Times(1)
+	in = gjson.Parse(`{"params":["` + addr.Hex() + `", []]}`)
+	ret, err = web3svr.getProof(&in)
+	require.NoError(err)
+	res = ret.(*getProofResult)
+	require.Equal("0x0", res.Balance.String())
+	require.Empty(res.StorageProof)
+
+	for _, params := range []string{
+		`[]`,
+		`["` + addr.Hex() + `"]`,
+		`["` + addr.Hex() + `", ["0x` + hex.EncodeToString(make([]byte, 33)) + `"]]`,
+	} {
+		in = gjson.Parse(`{"params":` + params + `}`)
+		_, err = web3svr.getProof(&in)
+		require.Error(err)
+	}
+}
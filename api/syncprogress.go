@@ -0,0 +1,230 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/iotexproject/iotex-core/v2/api/apipb"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+// SyncStage is the stage of the sync, a node passes the stages in order
+type SyncStage int
+
+const (
+	// SyncStageSynced means all the stages are completed
+	SyncStageSynced SyncStage = iota
+	// SyncStageHeader syncs the headers ahead of the blocks in header-first sync mode
+	SyncStageHeader
+	// SyncStageBody downloads and commits the blocks
+	SyncStageBody
+	// SyncStageState builds the state of the blocks committed
+	SyncStageState
+	// SyncStageIndexing indexes the blocks committed
+	SyncStageIndexing
+)
+
+const (
+	// _syncSampleInterval is the interval to sample the heights of the stages
+	_syncSampleInterval = 10 * time.Second
+	// _syncRateWindow is the window of the samples the rates are computed from
+	_syncRateWindow = 5 * time.Minute
+)
+
+type (
+	// StageProgress is the progress of a sync stage
+	StageProgress struct {
+		Stage         SyncStage
+		StartHeight   uint64
+		CurrentHeight uint64
+		TargetHeight  uint64
+		// Progress is the percentage of the stage completed
+		Progress float64
+		// Rate is the number of blocks processed per second in the recent window
+		Rate float64
+		// ETA is the estimated time to complete the stage, 0 if the stage is completed or the rate is unknown
+		ETA time.Duration
+	}
+
+	// SyncProgress is the progress of the sync broken down by the stages
+	SyncProgress struct {
+		// Stage is the earliest stage not completed
+		Stage  SyncStage
+		Stages []StageProgress
+		// ETA is the estimated time to complete all the stages, 0 if synced or unknown
+		ETA time.Duration
+	}
+
+	syncSample struct {
+		at      time.Time
+		heights []uint64
+	}
+
+	// syncTracker keeps the samples of the stage heights in a window to compute the rates of the stages
+	syncTracker struct {
+		window  time.Duration
+		mu      sync.Mutex
+		samples []syncSample
+	}
+
+	// syncService serves the sync progress of the node
+	syncService struct {
+		coreService CoreService
+	}
+)
+
+var _syncStages = []SyncStage{SyncStageHeader, SyncStageBody, SyncStageState, SyncStageIndexing}
+
+func (s SyncStage) String() string {
+	return apipb.SyncStage(s).String()
+}
+
+func newSyncTracker(window time.Duration) *syncTracker {
+	return &syncTracker{window: window}
+}
+
+// stageHeights returns the heights the stages reach in order of _syncStages
+func stageHeights(s *SyncStatus) []uint64 {
+	indexed := s.CurrentHeight
+	for _, h := range s.IndexerHeights {
+		indexed = min(indexed, h)
+	}
+	return []uint64{max(s.HeaderHeight, s.CurrentHeight), s.CurrentHeight, s.StateHeight, indexed}
+}
+
+// Sample records the heights of the stages, the samples out of the window are dropped
+func (t *syncTracker) Sample(now time.Time, s *SyncStatus) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.prune(now)
+	if n := len(t.samples); n > 0 && now.Sub(t.samples[n-1].at) < time.Second {
+		return
+	}
+	t.samples = append(t.samples, syncSample{at: now, heights: stageHeights(s)})
+}
+
+func (t *syncTracker) prune(now time.Time) {
+	i := 0
+	for i < len(t.samples) && now.Sub(t.samples[i].at) > t.window {
+		i++
+	}
+	t.samples = t.samples[i:]
+}
+
+// Progress computes the progress of the stages, the rates are measured from the oldest sample in the window
+func (t *syncTracker) Progress(now time.Time, s *SyncStatus) *SyncProgress {
+	t.mu.Lock()
+	t.prune(now)
+	var oldest *syncSample
+	if len(t.samples) > 0 {
+		oldest = &t.samples[0]
+	}
+	t.mu.Unlock()
+
+	var (
+		heights  = stageHeights(s)
+		start    = min(s.StartingHeight, s.CurrentHeight)
+		target   = max(s.HighestHeight, s.CurrentHeight)
+		progress = &SyncProgress{Stage: SyncStageSynced}
+		unknown  bool
+	)
+	for i, stage := range _syncStages {
+		sp := StageProgress{
+			Stage:         stage,
+			StartHeight:   min(start, heights[i]),
+			CurrentHeight: heights[i],
+			TargetHeight:  target,
+			Progress:      100,
+		}
+		if oldest != nil {
+			if elapsed := now.Sub(oldest.at).Seconds(); elapsed >= 1 && sp.CurrentHeight > oldest.heights[i] {
+				sp.Rate = float64(sp.CurrentHeight-oldest.heights[i]) / elapsed
+			}
+		}
+		if sp.CurrentHeight < sp.TargetHeight {
+			sp.Progress = 100 * float64(sp.CurrentHeight-sp.StartHeight) / float64(sp.TargetHeight-sp.StartHeight)
+			if progress.Stage == SyncStageSynced {
+				progress.Stage = stage
+			}
+			if sp.Rate > 0 {
+				sp.ETA = time.Duration(math.Ceil(float64(sp.TargetHeight-sp.CurrentHeight)/sp.Rate)) * time.Second
+				progress.ETA = max(progress.ETA, sp.ETA)
+			} else {
+				unknown = true
+			}
+		}
+		progress.Stages = append(progress.Stages, sp)
+	}
+	if unknown {
+		progress.ETA = 0
+	}
+	return progress
+}
+
+// SyncProgress returns the progress of the sync broken down by the stages
+func (core *coreService) SyncProgress() (*SyncProgress, error) {
+	syncStatus, err := core.SyncStatus()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	now := time.Now()
+	progress := core.syncTracker.Progress(now, syncStatus)
+	core.syncTracker.Sample(now, syncStatus)
+	return progress, nil
+}
+
+func (core *coreService) sampleSyncStatus() {
+	syncStatus, err := core.SyncStatus()
+	if err != nil {
+		log.Logger("api").Debug("failed to sample sync status.", zap.Error(err))
+		return
+	}
+	core.syncTracker.Sample(time.Now(), syncStatus)
+}
+
+func newSyncService(core CoreService) *syncService {
+	return &syncService{
+		coreService: core,
+	}
+}
+
+// GetSyncStatus returns the current stage of the sync, the progress, the rate and the ETA of each stage
+func (svr *syncService) GetSyncStatus(context.Context, *apipb.GetSyncStatusRequest) (*apipb.GetSyncStatusResponse, error) {
+	progress, err := svr.coreService.SyncProgress()
+	if err != nil {
+		return nil, err
+	}
+	ret := &apipb.GetSyncStatusResponse{
+		Stage: apipb.SyncStage(progress.Stage),
+	}
+	if progress.ETA > 0 {
+		ret.Eta = durationpb.New(progress.ETA)
+	}
+	for _, sp := range progress.Stages {
+		stage := &apipb.SyncStageStatus{
+			Stage:         apipb.SyncStage(sp.Stage),
+			StartHeight:   sp.StartHeight,
+			CurrentHeight: sp.CurrentHeight,
+			TargetHeight:  sp.TargetHeight,
+			Progress:      sp.Progress,
+			Rate:          sp.Rate,
+		}
+		if sp.ETA > 0 {
+			stage.Eta = durationpb.New(sp.ETA)
+		}
+		ret.Stages = append(ret.Stages, stage)
+	}
+	return ret, nil
+}
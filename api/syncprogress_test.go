@@ -0,0 +1,123 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/iotexproject/iotex-core/v2/api/apipb"
+)
+
+func TestSyncTracker(t *testing.T) {
+	require := require.New(t)
+	tracker := newSyncTracker(time.Minute)
+	now := time.Now()
+	status := &SyncStatus{
+		StartingHeight: 100,
+		CurrentHeight:  200,
+		HighestHeight:  1100,
+		HeaderHeight:   600,
+		StateHeight:    200,
+		IndexerHeights: map[string]uint64{"index": 150, "bloomfilter": 200},
+	}
+
+	// the rates are unknown without samples
+	progress := tracker.Progress(now, status)
+	require.Equal(SyncStageHeader, progress.Stage)
+	require.Zero(progress.ETA)
+	require.Len(progress.Stages, 4)
+	for _, sp := range progress.Stages {
+		require.Zero(sp.Rate)
+		require.Zero(sp.ETA)
+	}
+	tracker.Sample(now, status)
+
+	status = &SyncStatus{
+		StartingHeight: 100,
+		CurrentHeight:  300,
+		HighestHeight:  1100,
+		HeaderHeight:   1100,
+		StateHeight:    300,
+		IndexerHeights: map[string]uint64{"index": 250, "bloomfilter": 300},
+	}
+	now = now.Add(10 * time.Second)
+	progress = tracker.Progress(now, status)
+	require.Equal(SyncStageBody, progress.Stage)
+	header, body, state, indexing := progress.Stages[0], progress.Stages[1], progress.Stages[2], progress.Stages[3]
+	require.Equal(SyncStageHeader, header.Stage)
+	require.Equal(float64(100), header.Progress)
+	require.Zero(header.ETA)
+	require.Equal(StageProgress{
+		Stage:         SyncStageBody,
+		StartHeight:   100,
+		CurrentHeight: 300,
+		TargetHeight:  1100,
+		Progress:      20,
+		Rate:          10,
+		ETA:           80 * time.Second,
+	}, body)
+	require.Equal(SyncStageState, state.Stage)
+	require.Equal(80*time.Second, state.ETA)
+	require.Equal(SyncStageIndexing, indexing.Stage)
+	require.Equal(uint64(250), indexing.CurrentHeight)
+	require.Equal(float64(10), indexing.Rate)
+	require.Equal(85*time.Second, indexing.ETA)
+	require.Equal(85*time.Second, progress.ETA)
+
+	// the samples out of the window are dropped
+	tracker.Sample(now, status)
+	now = now.Add(time.Minute + time.Second)
+	status.HighestHeight = 300
+	status.IndexerHeights["index"] = 300
+	progress = tracker.Progress(now, status)
+	require.Equal(SyncStageSynced, progress.Stage)
+	require.Zero(progress.ETA)
+	for _, sp := range progress.Stages {
+		require.Equal(float64(100), sp.Progress)
+		require.Zero(sp.Rate)
+	}
+	require.Empty(tracker.samples)
+
+	// the overall ETA is unknown if any stage not completed doesn't progress
+	status.HighestHeight = 400
+	tracker.Sample(now, status)
+	status.StateHeight = 310
+	progress = tracker.Progress(now.Add(10*time.Second), status)
+	require.Equal(SyncStageBody, progress.Stage)
+	require.Equal(float64(1), progress.Stages[2].Rate)
+	require.Zero(progress.ETA)
+}
+
+func TestSyncService_GetSyncStatus(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	core := NewMockCoreService(ctrl)
+	svr := newSyncService(core)
+
+	core.EXPECT().SyncProgress().Return(nil, errors.New("db closed")).Times(1)
+	_, err := svr.GetSyncStatus(context.Background(), &apipb.GetSyncStatusRequest{})
+	require.Error(err)
+
+	core.EXPECT().SyncProgress().Return(&SyncProgress{
+		Stage: SyncStageBody,
+		Stages: []StageProgress{
+			{Stage: SyncStageHeader, StartHeight: 1, CurrentHeight: 10, TargetHeight: 10, Progress: 100},
+			{Stage: SyncStageBody, StartHeight: 1, CurrentHeight: 5, TargetHeight: 10, Progress: 50, Rate: 1, ETA: 5 * time.Second},
+		},
+		ETA: 5 * time.Second,
+	}, nil).Times(1)
+	res, err := svr.GetSyncStatus(context.Background(), &apipb.GetSyncStatusRequest{})
+	require.NoError(err)
+	require.Equal(apipb.SyncStage_BODY_DOWNLOAD, res.Stage)
+	require.Equal(5*time.Second, res.Eta.AsDuration())
+	require.Len(res.Stages, 2)
+	require.Equal(apipb.SyncStage_HEADER_SYNC, res.Stages[0].Stage)
+	require.Nil(res.Stages[0].Eta)
+	require.Equal(float64(50), res.Stages[1].Progress)
+	require.Equal(5*time.Second, res.Stages[1].Eta.AsDuration())
+	require.Equal("BODY_DOWNLOAD", SyncStageBody.String())
+}
@@ -0,0 +1,127 @@
+package api
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol/abiregistry"
+	"github.com/iotexproject/iotex-core/v2/state"
+)
+
+type (
+	// SystemContract is a system contract registered in the ABI registry, with the versions of its ABI
+	SystemContract struct {
+		Address    string                   `json:"address"`
+		EthAddress string                   `json:"ethAddress"`
+		Name       string                   `json:"name"`
+		Versions   []*SystemContractVersion `json:"versions"`
+	}
+
+	// SystemContractVersion is a version of the ABI of a system contract
+	SystemContractVersion struct {
+		Version          uint32 `json:"version"`
+		ActivationHeight uint64 `json:"activationHeight"`
+	}
+
+	// SystemContractABI is the ABI of a system contract effective at a height
+	SystemContractABI struct {
+		Address          string          `json:"address"`
+		Name             string          `json:"name"`
+		Height           uint64          `json:"height"`
+		Version          uint32          `json:"version"`
+		ActivationHeight uint64          `json:"activationHeight"`
+		ABI              json.RawMessage `json:"abi"`
+	}
+)
+
+// SystemContracts returns the system contracts registered in the ABI registry
+func (core *coreService) SystemContracts() ([]*SystemContract, error) {
+	l, _, err := abiregistry.ReadContractList(core.sf)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	ret := make([]*SystemContract, 0, len(l))
+	for _, addr := range l {
+		abis, _, err := abiregistry.ReadContractABIs(core.sf, addr)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		sc := &SystemContract{
+			Address:    addr.String(),
+			EthAddress: addr.Hex(),
+			Name:       abis.Name,
+			Versions:   make([]*SystemContractVersion, len(abis.Versions)),
+		}
+		for i, v := range abis.Versions {
+			sc.Versions[i] = &SystemContractVersion{
+				Version:          v.Version,
+				ActivationHeight: v.ActivationHeight,
+			}
+		}
+		ret = append(ret, sc)
+	}
+	return ret, nil
+}
+
+// SystemContractABI returns the ABI of the system contract effective at the height, 0 for the tip
+func (core *coreService) SystemContractABI(addr address.Address, height uint64) (*SystemContractABI, error) {
+	tipHeight := core.bc.TipHeight()
+	if height == 0 {
+		height = tipHeight
+	}
+	if height > tipHeight {
+		return nil, status.Errorf(codes.InvalidArgument, "height %d is higher than the tip height %d", height, tipHeight)
+	}
+	name, v, _, err := abiregistry.ReadContractABI(core.sf, addr, height)
+	if err != nil {
+		if errors.Cause(err) == state.ErrStateNotExist {
+			return nil, status.Errorf(codes.NotFound, "%s is not a system contract at height %d", addr.String(), height)
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &SystemContractABI{
+		Address:          addr.String(),
+		Name:             name,
+		Height:           height,
+		Version:          v.Version,
+		ActivationHeight: v.ActivationHeight,
+		ABI:              json.RawMessage(v.ABI),
+	}, nil
+}
+
+func (svr *web3Handler) getSystemContracts() (interface{}, error) {
+	return svr.coreService.SystemContracts()
+}
+
+func (svr *web3Handler) getSystemContractABI(in *gjson.Result) (interface{}, error) {
+	addrParam := in.Get("params.0")
+	if !addrParam.Exists() {
+		return nil, errInvalidFormat
+	}
+	var (
+		addr address.Address
+		err  error
+	)
+	if strings.HasPrefix(addrParam.String(), "0x") {
+		addr, err = ethAddrToIoAddr(addrParam.String())
+	} else {
+		addr, err = address.FromString(addrParam.String())
+	}
+	if err != nil {
+		return nil, err
+	}
+	var height uint64
+	if param := in.Get("params.1"); param.Exists() {
+		if param.Type != gjson.Number {
+			return nil, errInvalidFormat
+		}
+		height = param.Uint()
+	}
+	return svr.coreService.SystemContractABI(addr, height)
+}
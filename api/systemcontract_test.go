@@ -0,0 +1,61 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/abiregistry"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/anchor"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/governance"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+	"github.com/iotexproject/iotex-core/v2/test/mock/mock_blockchain"
+	"github.com/iotexproject/iotex-core/v2/test/mock/mock_factory"
+	"github.com/iotexproject/iotex-core/v2/testutil/testdb"
+)
+
+func TestCoreService_SystemContracts(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	sf := mock_factory.NewMockFactory(ctrl)
+	sm := testdb.NewMockStateManager(ctrl)
+	core := &coreService{bc: bc, sf: sf}
+
+	p, err := abiregistry.NewProtocol(
+		&abiregistry.Contract{Name: "anchor", Address: anchor.ProtocolAddr(), ABI: anchor.ContractABI(), ActivationHeight: 5},
+		&abiregistry.Contract{Name: "governance", Address: governance.ProtocolAddr(), ABI: governance.ContractABI(), ActivationHeight: 8},
+	)
+	require.NoError(err)
+	for height := uint64(1); height <= 10; height++ {
+		require.NoError(p.CreatePreStates(protocol.WithBlockCtx(context.Background(), protocol.BlockCtx{BlockHeight: height}), sm))
+	}
+	sf.EXPECT().State(gomock.Any(), gomock.Any()).DoAndReturn(sm.State).AnyTimes()
+	bc.EXPECT().TipHeight().Return(uint64(10)).AnyTimes()
+
+	contracts, err := core.SystemContracts()
+	require.NoError(err)
+	require.Len(contracts, 2)
+	require.Equal("anchor", contracts[0].Name)
+	require.Equal(anchor.ProtocolAddr().String(), contracts[0].Address)
+	require.Equal([]*SystemContractVersion{{Version: 1, ActivationHeight: 5}}, contracts[0].Versions)
+	require.Equal("governance", contracts[1].Name)
+
+	ret, err := core.SystemContractABI(governance.ProtocolAddr(), 0)
+	require.NoError(err)
+	require.EqualValues(10, ret.Height)
+	require.EqualValues(1, ret.Version)
+	require.EqualValues(8, ret.ActivationHeight)
+	require.Equal(governance.ContractABI(), string(ret.ABI))
+	_, err = core.SystemContractABI(governance.ProtocolAddr(), 7)
+	require.Equal(codes.NotFound, status.Code(err))
+	_, err = core.SystemContractABI(identityset.Address(1), 10)
+	require.Equal(codes.NotFound, status.Code(err))
+	_, err = core.SystemContractABI(anchor.ProtocolAddr(), 11)
+	require.Equal(codes.InvalidArgument, status.Code(err))
+}
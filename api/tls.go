@@ -0,0 +1,187 @@
+package api
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+const _certCheckInterval = 10 * time.Second
+
+type (
+	// TLSConfig is the config of TLS of the api servers
+	TLSConfig struct {
+		// CertFile and KeyFile are the PEM-encoded certificate and key of the servers, TLS is disabled if empty.
+		// They are reloaded on change without restarting the node
+		CertFile string `yaml:"certFile"`
+		KeyFile  string `yaml:"keyFile"`
+		// ClientCAFile is the PEM-encoded CAs to verify the client certificates of the admin grpc services,
+		// which are open to any client if empty
+		ClientCAFile string `yaml:"clientCAFile"`
+		// AdminServices are the grpc services requiring client certificate if ClientCAFile is set
+		AdminServices []string `yaml:"adminServices"`
+	}
+
+	// certReloader loads the key pair, and reloads it once the files are modified
+	certReloader struct {
+		certFile string
+		keyFile  string
+		mu       sync.Mutex
+		cert     *tls.Certificate
+		modTime  time.Time
+		checked  time.Time
+	}
+)
+
+// DefaultTLSConfig is the default config of TLS, which is disabled
+var DefaultTLSConfig = TLSConfig{
+	AdminServices: []string{"blockdaopb.BlockDAOService", "apipb.AdminService"},
+}
+
+// serverTLSConfig returns the tls config of the servers, nil if TLS is disabled
+func (cfg TLSConfig) serverTLSConfig() (*tls.Config, error) {
+	if cfg.CertFile == "" && cfg.KeyFile == "" {
+		if cfg.ClientCAFile != "" {
+			return nil, errors.New("client CA is set without server certificate")
+		}
+		return nil, nil
+	}
+	reloader, err := newCertReloader(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	tlsCfg := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: reloader.getCertificate,
+	}
+	if cfg.ClientCAFile != "" {
+		pem, err := os.ReadFile(cfg.ClientCAFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read client CA file %s", cfg.ClientCAFile)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.Errorf("no certificate in client CA file %s", cfg.ClientCAFile)
+		}
+		tlsCfg.ClientCAs = pool
+		// only the admin services require client certificate, which is checked by clientAuthInterceptor
+		tlsCfg.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+	return tlsCfg, nil
+}
+
+func newCertReloader(certFile, keyFile string) (*certReloader, error) {
+	r := &certReloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+	}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *certReloader) reload() error {
+	modTime, err := r.lastModified()
+	if err != nil {
+		return err
+	}
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return errors.Wrap(err, "failed to load key pair")
+	}
+	r.cert, r.modTime = &cert, modTime
+	return nil
+}
+
+func (r *certReloader) lastModified() (time.Time, error) {
+	var last time.Time
+	for _, file := range []string{r.certFile, r.keyFile} {
+		info, err := os.Stat(file)
+		if err != nil {
+			return time.Time{}, errors.Wrapf(err, "failed to stat %s", file)
+		}
+		if info.ModTime().After(last) {
+			last = info.ModTime()
+		}
+	}
+	return last, nil
+}
+
+func (r *certReloader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if now := time.Now(); now.Sub(r.checked) >= _certCheckInterval {
+		r.checked = now
+		if modTime, err := r.lastModified(); err == nil && !modTime.Equal(r.modTime) {
+			// keep serving the current certificate if the new one is not ready yet
+			if err := r.reload(); err != nil {
+				log.L().Warn("failed to reload certificate.", zap.Error(err))
+			} else {
+				log.L().Info("certificate is reloaded.", zap.String("certFile", r.certFile))
+			}
+		}
+	}
+	return r.cert, nil
+}
+
+// clientAuthInterceptor rejects the calls to the admin services without verified client certificate
+type clientAuthInterceptor struct {
+	services []string
+}
+
+func (i *clientAuthInterceptor) requireAuth(fullMethod string) bool {
+	for _, svc := range i.services {
+		if strings.HasPrefix(fullMethod, "/"+svc+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func (i *clientAuthInterceptor) authorize(ctx context.Context, fullMethod string) error {
+	if !i.requireAuth(fullMethod) {
+		return nil
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "unknown peer")
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 {
+		return status.Errorf(codes.Unauthenticated, "client certificate is required for %s", fullMethod)
+	}
+	return nil
+}
+
+func (i *clientAuthInterceptor) unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := i.authorize(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (i *clientAuthInterceptor) stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := i.authorize(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
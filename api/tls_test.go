@@ -0,0 +1,126 @@
+package api
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func writeTestKeyPair(t *testing.T, dir, name string) (string, string) {
+	require := require.New(t)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		DNSNames:              []string{name},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(err)
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	require.NoError(os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+	return certFile, keyFile
+}
+
+func TestServerTLSConfig(t *testing.T) {
+	require := require.New(t)
+	dir := t.TempDir()
+
+	tlsCfg, err := DefaultTLSConfig.serverTLSConfig()
+	require.NoError(err)
+	require.Nil(tlsCfg)
+	_, err = TLSConfig{ClientCAFile: "ca.pem"}.serverTLSConfig()
+	require.Error(err)
+	_, err = TLSConfig{CertFile: filepath.Join(dir, "none.pem"), KeyFile: filepath.Join(dir, "none.pem")}.serverTLSConfig()
+	require.Error(err)
+
+	certFile, keyFile := writeTestKeyPair(t, dir, "node1")
+	tlsCfg, err = TLSConfig{CertFile: certFile, KeyFile: keyFile, ClientCAFile: certFile}.serverTLSConfig()
+	require.NoError(err)
+	require.Equal(tls.VerifyClientCertIfGiven, tlsCfg.ClientAuth)
+	require.NotNil(tlsCfg.ClientCAs)
+}
+
+func TestCertReloader(t *testing.T) {
+	require := require.New(t)
+	dir := t.TempDir()
+	certFile, keyFile := writeTestKeyPair(t, dir, "node1")
+	reloader, err := newCertReloader(certFile, keyFile)
+	require.NoError(err)
+	cert, err := reloader.getCertificate(nil)
+	require.NoError(err)
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(err)
+	require.Equal("node1", leaf.Subject.CommonName)
+
+	// renew the certificate
+	writeTestKeyPair(t, dir, "node2")
+	future := time.Now().Add(time.Minute)
+	require.NoError(os.Chtimes(certFile, future, future))
+	cert, err = reloader.getCertificate(nil)
+	require.NoError(err)
+	leaf, err = x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(err)
+	// not reloaded until the check interval passes
+	require.Equal("node1", leaf.Subject.CommonName)
+	reloader.checked = time.Time{}
+	cert, err = reloader.getCertificate(nil)
+	require.NoError(err)
+	leaf, err = x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(err)
+	require.Equal("node2", leaf.Subject.CommonName)
+
+	// keep the current certificate if the new one is broken
+	require.NoError(os.WriteFile(keyFile, []byte("broken"), 0600))
+	future = future.Add(time.Minute)
+	require.NoError(os.Chtimes(keyFile, future, future))
+	reloader.checked = time.Time{}
+	cert, err = reloader.getCertificate(nil)
+	require.NoError(err)
+	leaf, err = x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(err)
+	require.Equal("node2", leaf.Subject.CommonName)
+}
+
+func TestClientAuthInterceptor(t *testing.T) {
+	require := require.New(t)
+	auth := &clientAuthInterceptor{services: DefaultTLSConfig.AdminServices}
+	const adminMethod = "/blockdaopb.BlockDAOService/Height"
+
+	require.NoError(auth.authorize(context.Background(), "/iotexapi.APIService/GetChainMeta"))
+	err := auth.authorize(context.Background(), adminMethod)
+	require.Equal(codes.Unauthenticated, status.Code(err))
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{}})
+	err = auth.authorize(ctx, adminMethod)
+	require.Equal(codes.Unauthenticated, status.Code(err))
+
+	ctx = peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{
+		State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{{}}}},
+	}})
+	require.NoError(auth.authorize(ctx, adminMethod))
+}
@@ -26,11 +26,17 @@ type (
 		Exit()
 	}
 
+	// ActionResponder responds to new pending action, it is optional for a Responder
+	ActionResponder interface {
+		RespondAction(string, *action.SealedEnvelope) error
+	}
+
 	// Listener pass new block to all responders
 	Listener interface {
 		Start() error
 		Stop() error
 		ReceiveBlock(*block.Block) error
+		ReceiveAction(*action.SealedEnvelope) error
 		AddResponder(Responder) (string, error)
 		RemoveResponder(string) (bool, error)
 	}
@@ -40,6 +46,13 @@ type (
 		Block    *block.Block
 		Receipts []*action.Receipt
 	}
+	// ActionInBlock is the action located by the action index, with the block containing it and its receipt
+	ActionInBlock struct {
+		Action  *action.SealedEnvelope
+		Block   *block.Block
+		Index   uint32
+		Receipt *action.Receipt
+	}
 	// BlobSidecarResult is the result of get blob sidecar
 	BlobSidecarResult struct {
 		BlobSidecar *types.BlobTxSidecar `json:"blobSidecar"`
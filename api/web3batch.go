@@ -0,0 +1,65 @@
+package api
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+)
+
+// batchBudget tracks the gas and time spent by the requests of a batch
+type batchBudget struct {
+	gasLimit uint64
+	gasUsed  uint64
+	cancel   context.CancelFunc
+	// defaultGas returns the gas of a call which does not specify gas
+	defaultGas func() uint64
+}
+
+func (svr *web3Handler) newBatchBudget(ctx context.Context) (context.Context, *batchBudget) {
+	budget := &batchBudget{
+		gasLimit: svr.batchGasLimit,
+		cancel:   func() {},
+		defaultGas: func() uint64 {
+			g := svr.coreService.Genesis()
+			return g.BlockGasLimitByHeight(svr.coreService.TipHeight())
+		},
+	}
+	if svr.batchTimeout > 0 {
+		ctx, budget.cancel = context.WithTimeout(ctx, svr.batchTimeout)
+	}
+	return ctx, budget
+}
+
+// consume charges the budget for the request, and returns error if the budget is exhausted
+func (b *batchBudget) consume(ctx context.Context, web3Req *gjson.Result) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return errBatchTimeout
+	}
+	if b.gasLimit == 0 {
+		return nil
+	}
+	switch web3Req.Get("method").String() {
+	case "eth_call", "eth_estimateGas", "debug_traceCall":
+	default:
+		return nil
+	}
+	var gas uint64
+	if gasStr := web3Req.Get("params.0.gas").String(); gasStr != "" {
+		var err error
+		if gas, err = hexStringToNumber(gasStr); err != nil {
+			return errors.Wrapf(errUnkownType, "gas: %s", gasStr)
+		}
+	} else {
+		gas = b.defaultGas()
+	}
+	if gas > b.gasLimit-b.gasUsed {
+		return errors.Wrapf(errBatchGasExceeded, "gas %d exceeds the remaining %d of the batch", gas, b.gasLimit-b.gasUsed)
+	}
+	b.gasUsed += gas
+	return nil
+}
+
+func (b *batchBudget) release() {
+	b.cancel()
+}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"math/big"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/core/types"
@@ -27,7 +28,6 @@ import (
 	"google.golang.org/grpc/status"
 
 	"github.com/iotexproject/iotex-core/v2/action"
-	"github.com/iotexproject/iotex-core/v2/action/protocol"
 	rewardingabi "github.com/iotexproject/iotex-core/v2/action/protocol/rewarding/ethabi"
 	stakingabi "github.com/iotexproject/iotex-core/v2/action/protocol/staking/ethabi"
 	apitypes "github.com/iotexproject/iotex-core/v2/api/types"
@@ -51,7 +51,15 @@ type (
 		coreService       CoreService
 		cache             apiCache
 		batchRequestLimit int
+		batchGasLimit     uint64
+		batchTimeout      time.Duration
+		filterTTL         time.Duration
+		filterLimit       int
+		gasBreakdown      bool
 	}
+
+	// Web3HandlerOption sets the web3 handler
+	Web3HandlerOption func(*web3Handler)
 )
 
 type (
@@ -62,6 +70,8 @@ type (
 		ToBlock    string     `json:"toBlock,omitempty"`
 		Address    []string   `json:"address,omitempty"`
 		Topics     [][]string `json:"topics,omitempty"`
+		// Cursor is the continuation token of eth_getLogs, nil if the query is not paginated
+		Cursor *string `json:"cursor,omitempty"`
 	}
 )
 
@@ -81,10 +91,13 @@ var (
 	errInvalidFormat     = errors.New("invalid format of request")
 	errNotImplemented    = errors.New("method not implemented")
 	errInvalidFilterID   = errors.New("filter not found")
+	errFilterLimit       = errors.New("too many filters")
 	errInvalidEvmChainID = errors.New("invalid EVM chain ID")
 	errInvalidBlock      = errors.New("invalid block")
 	errUnsupportedAction = errors.New("the type of action is not supported")
 	errMsgBatchTooLarge  = errors.New("batch too large")
+	errBatchGasExceeded  = errors.New("batch gas limit exceeded")
+	errBatchTimeout      = errors.New("batch timeout")
 	errHTTPNotSupported  = errors.New("http not supported")
 	errPanic             = errors.New("panic")
 
@@ -98,13 +111,47 @@ func init() {
 	prometheus.MustRegister(_web3ServerLatency)
 }
 
+// WithBatchGasLimit limits the total gas of the calls in a batch request, 0 means no limit
+func WithBatchGasLimit(limit uint64) Web3HandlerOption {
+	return func(svr *web3Handler) {
+		svr.batchGasLimit = limit
+	}
+}
+
+// WithBatchTimeout limits the time to handle a batch request, 0 means no limit
+func WithBatchTimeout(timeout time.Duration) Web3HandlerOption {
+	return func(svr *web3Handler) {
+		svr.batchTimeout = timeout
+	}
+}
+
+// WithFilterCache sets how long an idle filter is kept, and the maximum number of filters kept in local cache
+func WithFilterCache(ttl time.Duration, limit int) Web3HandlerOption {
+	return func(svr *web3Handler) {
+		svr.filterTTL = ttl
+		svr.filterLimit = limit
+	}
+}
+
+// WithGasBreakdown adds the gas consumed by phase to the receipts
+func WithGasBreakdown(enabled bool) Web3HandlerOption {
+	return func(svr *web3Handler) {
+		svr.gasBreakdown = enabled
+	}
+}
+
 // NewWeb3Handler creates a handle to process web3 requests
-func NewWeb3Handler(core CoreService, cacheURL string, batchRequestLimit int) Web3Handler {
-	return &web3Handler{
+func NewWeb3Handler(core CoreService, cacheURL string, batchRequestLimit int, opts ...Web3HandlerOption) Web3Handler {
+	svr := &web3Handler{
 		coreService:       core,
-		cache:             newAPICache(15*time.Minute, cacheURL),
 		batchRequestLimit: batchRequestLimit,
+		filterTTL:         15 * time.Minute,
+	}
+	for _, opt := range opts {
+		opt(svr)
 	}
+	svr.cache = newAPICache(svr.filterTTL, cacheURL, svr.filterLimit)
+	return svr
 }
 
 // HandlePOSTReq handles web3 request
@@ -143,7 +190,17 @@ func (svr *web3Handler) HandlePOSTReq(ctx context.Context, reader io.Reader, wri
 		return err
 	}
 	batchWriter := apitypes.NewBatchWriter(writer)
+	ctx, budget := svr.newBatchBudget(ctx)
+	defer budget.release()
 	for i := range web3ReqArr {
+		// requests beyond the budget are rejected individually, so the rest of the batch is still answered
+		if err := budget.consume(ctx, &web3ReqArr[i]); err != nil {
+			_web3ServerMtc.WithLabelValues("batch_budget_exceeded").Inc()
+			if err := svr.rejectWeb3Req(&web3ReqArr[i], err, batchWriter); err != nil {
+				return err
+			}
+			continue
+		}
 		if err := svr.handleWeb3Req(ctx, &web3ReqArr[i], batchWriter); err != nil {
 			return err
 		}
@@ -157,8 +214,16 @@ func (svr *web3Handler) handleWeb3Req(ctx context.Context, web3Req *gjson.Result
 		err, err1 error
 		method    = web3Req.Get("method").Value()
 		size      int
+		call      = newAPICall(_protocolWeb3, method.(string))
 	)
 	defer func(start time.Time) { svr.coreService.Track(ctx, start, method.(string), int64(size), err == nil) }(time.Now())
+	ctx, span := tracer.NewSpan(ctx, "svr.handleWeb3Req")
+	defer span.End()
+	call.reqSize = len(web3Req.Raw)
+	defer func() {
+		call.respSize = size
+		call.finish(ctx, svr.coreService, err)
+	}()
 
 	log.T(ctx).Debug("handleWeb3Req", zap.String("method", method.(string)), zap.String("requestParams", fmt.Sprintf("%+v", web3Req)))
 	_web3ServerMtc.WithLabelValues(method.(string)).Inc()
@@ -230,6 +295,8 @@ func (svr *web3Handler) handleWeb3Req(ctx context.Context, web3Req *gjson.Result
 		res, err = svr.getTransactionReceipt(web3Req)
 	case "eth_getStorageAt":
 		res, err = svr.getStorageAt(web3Req)
+	case "eth_getProof":
+		res, err = svr.getProof(web3Req)
 	case "eth_getFilterLogs":
 		res, err = svr.getFilterLogs(web3Req)
 	case "eth_getFilterChanges":
@@ -252,18 +319,54 @@ func (svr *web3Handler) handleWeb3Req(ctx context.Context, web3Req *gjson.Result
 		res, err = svr.subscribe(sc, web3Req, writer)
 	case "eth_unsubscribe":
 		res, err = svr.unsubscribe(web3Req)
+		if sc, ok := StreamFromContext(ctx); ok && err == nil {
+			sc.RemoveListener(web3Req.Get("params.0").String())
+		}
 	case "eth_getBlobSidecars":
 		res, err = svr.getBlobSidecars(web3Req)
-	//TODO: enable debug api after archive mode is supported
-	// case "debug_traceTransaction":
-	// 	res, err = svr.traceTransaction(ctx, web3Req)
-	// case "debug_traceCall":
-	// 	res, err = svr.traceCall(ctx, web3Req)
+	case "debug_traceTransaction":
+		res, err = svr.traceTransaction(ctx, web3Req)
+	case "debug_traceCall":
+		res, err = svr.traceCall(ctx, web3Req)
+	case "debug_traceBlockByNumber":
+		res, err = svr.traceBlockByNumber(ctx, web3Req)
+	case "debug_producerVersions":
+		res, err = svr.producerVersions(web3Req)
+	case "debug_inclusionLatencies":
+		res, err = svr.inclusionLatencies()
+	case "iotex_getEpochCandidateProof":
+		res, err = svr.getEpochCandidateProof(web3Req)
+	case "iotex_getActionProof":
+		res, err = svr.getActionProof(web3Req)
+	case "iotex_getProbationParams":
+		res, err = svr.getProbationParams()
+	case "iotex_getProbationHistory":
+		res, err = svr.getProbationHistory(ctx, web3Req)
+	case "iotex_getSystemContracts":
+		res, err = svr.getSystemContracts()
+	case "iotex_getSystemContractABI":
+		res, err = svr.getSystemContractABI(web3Req)
+	case "iotex_getActionResult":
+		res, err = svr.getActionResult(web3Req)
+	case "eth_sendUserOperation":
+		res, err = svr.sendUserOperation(ctx, web3Req)
+	case "eth_supportedEntryPoints":
+		res, err = svr.supportedEntryPoints()
+	case "debug_bundler_dumpMempool":
+		res, err = svr.dumpUserOpMempool(web3Req)
+	case "debug_bundler_dumpReputation":
+		res, err = svr.dumpUserOpReputation()
+	case "admin_addPeer", "admin_addTrustedPeer", "admin_removePeer", "admin_setMinGasPrice", "admin_pauseChain",
+		"admin_resumeChain", "admin_peerScores", "admin_setLogLevel", "admin_reloadConfig", "admin_workingSetCache",
+		"admin_pinWorkingSet", "admin_unpinWorkingSet", "admin_prewarmWorkingSet", "admin_estimatePruning",
+		"admin_sendBundle":
+		res, err = svr.handleAdminReq(ctx, method.(string), web3Req)
 	case "eth_coinbase", "eth_getUncleCountByBlockHash", "eth_getUncleCountByBlockNumber",
 		"eth_sign", "eth_signTransaction", "eth_sendTransaction", "eth_getUncleByBlockHashAndIndex",
 		"eth_getUncleByBlockNumberAndIndex", "eth_pendingTransactions":
 		res, err = svr.unimplemented()
 	default:
+		call.method = _unknownMethod
 		res, err = nil, errors.Wrapf(errors.New("web3 method not found"), "method: %s\n", web3Req.Get("method"))
 	}
 	if err != nil {
@@ -273,16 +376,9 @@ func (svr *web3Handler) handleWeb3Req(ctx context.Context, web3Req *gjson.Result
 	} else {
 		log.Logger("api").Debug("web3Debug", zap.String("response", fmt.Sprintf("%+v", res)))
 	}
-	var id any
-	reqID := web3Req.Get("id")
-	switch reqID.Type {
-	case gjson.String:
-		id = reqID.String()
-	case gjson.Number:
-		id = reqID.Int()
-	default:
-		id = 0
-		res, err = nil, errors.New("invalid id type")
+	id, idErr := parseWeb3ReqID(web3Req)
+	if idErr != nil {
+		res, err = nil, idErr
 	}
 	size, err1 = writer.Write(&web3Response{
 		id:     id,
@@ -292,6 +388,31 @@ func (svr *web3Handler) handleWeb3Req(ctx context.Context, web3Req *gjson.Result
 	return err1
 }
 
+// rejectWeb3Req responds to the request with the error without handling it
+func (svr *web3Handler) rejectWeb3Req(web3Req *gjson.Result, err error, writer apitypes.Web3ResponseWriter) error {
+	id, idErr := parseWeb3ReqID(web3Req)
+	if idErr != nil {
+		err = idErr
+	}
+	_, err = writer.Write(&web3Response{
+		id:  id,
+		err: err,
+	})
+	return err
+}
+
+func parseWeb3ReqID(web3Req *gjson.Result) (any, error) {
+	reqID := web3Req.Get("id")
+	switch reqID.Type {
+	case gjson.String:
+		return reqID.String(), nil
+	case gjson.Number:
+		return reqID.Int(), nil
+	default:
+		return 0, errors.New("invalid id type")
+	}
+}
+
 func parseWeb3Reqs(reader io.Reader) (gjson.Result, error) {
 	data, err := io.ReadAll(reader)
 	if err != nil {
@@ -329,7 +450,7 @@ func (svr *web3Handler) maxPriorityFee() (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
-	return uint64ToHex(ret.Uint64()), nil
+	return bigIntToHex(ret), nil
 }
 
 func (svr *web3Handler) feeHistory(ctx context.Context, in *gjson.Result) (interface{}, error) {
@@ -337,9 +458,18 @@ func (svr *web3Handler) feeHistory(ctx context.Context, in *gjson.Result) (inter
 	if !blkCnt.Exists() || !newestBlk.Exists() {
 		return nil, errInvalidFormat
 	}
-	blocks, err := strconv.ParseUint(blkCnt.String(), 10, 64)
+	// block count is either a hex quantity or a decimal number
+	var (
+		blocks uint64
+		err    error
+	)
+	if cnt := blkCnt.String(); strings.HasPrefix(cnt, "0x") {
+		blocks, err = hexStringToNumber(cnt)
+	} else {
+		blocks, err = strconv.ParseUint(cnt, 10, 64)
+	}
 	if err != nil {
-		return nil, err
+		return nil, errors.Wrapf(errUnkownType, "blockCount: %s", blkCnt.String())
 	}
 	lastBlock, err := svr.parseBlockNumber(newestBlk.String())
 	if err != nil {
@@ -373,11 +503,11 @@ func (svr *web3Handler) getChainID() (interface{}, error) {
 }
 
 func (svr *web3Handler) blobBaseFee() (interface{}, error) {
-	blk, err := svr.coreService.BlockByHeight(svr.coreService.TipHeight())
+	fee, err := svr.coreService.BlobBaseFee()
 	if err != nil {
 		return nil, err
 	}
-	return bigIntToHex(protocol.CalcBlobFee(protocol.CalcExcessBlobGas(blk.Block.ExcessBlobGas(), blk.Block.BlobGasUsed()))), nil
+	return bigIntToHex(fee), nil
 }
 
 func (svr *web3Handler) getBlockNumber() (interface{}, error) {
@@ -418,6 +548,13 @@ func (svr *web3Handler) getBalance(in *gjson.Result) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	if bn == rpc.PendingBlockNumber {
+		balance, err := svr.coreService.PendingBalance(context.Background(), ioAddr)
+		if err != nil {
+			return nil, err
+		}
+		return intStrToHex(balance)
+	}
 	height, _, err := svr.blockNumberOrHashToHeight(rpc.BlockNumberOrHashWithNumber(bn))
 	if err != nil {
 		return nil, err
@@ -566,16 +703,27 @@ func (svr *web3Handler) sendRawTransaction(ctx context.Context, in *gjson.Result
 	if !dataStr.Exists() {
 		return nil, errInvalidFormat
 	}
-	// parse raw data string from json request
+	req, err := svr.rawTxToAction(dataStr.String())
+	if err != nil {
+		return nil, err
+	}
+	actionHash, err := svr.coreService.SendAction(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return "0x" + actionHash, nil
+}
+
+// rawTxToAction parses the raw data string of the transaction from json request
+func (svr *web3Handler) rawTxToAction(rawString string) (*iotextypes.Action, error) {
 	var (
-		cs        = svr.coreService
-		rawString = dataStr.String()
-		tx        *types.Transaction
-		encoding  iotextypes.Encoding
-		sig       []byte
-		pubkey    crypto.PublicKey
-		err       error
-		req       *iotextypes.Action
+		cs       = svr.coreService
+		tx       *types.Transaction
+		encoding iotextypes.Encoding
+		sig      []byte
+		pubkey   crypto.PublicKey
+		err      error
+		req      *iotextypes.Action
 	)
 	tx, err = action.DecodeEtherTx(rawString)
 	if err != nil {
@@ -625,11 +773,7 @@ func (svr *web3Handler) sendRawTransaction(ctx context.Context, in *gjson.Result
 			Encoding:     encoding,
 		}
 	}
-	actionHash, err := cs.SendAction(ctx, req)
-	if err != nil {
-		return nil, err
-	}
-	return "0x" + actionHash, nil
+	return req, nil
 }
 
 func (svr *web3Handler) getCode(in *gjson.Result) (interface{}, error) {
@@ -670,14 +814,25 @@ func (svr *web3Handler) getProtocolVersion() (interface{}, error) {
 }
 
 func (svr *web3Handler) isSyncing() (interface{}, error) {
-	start, curr, highest := svr.coreService.SyncingProgress()
-	if curr >= highest {
+	status, err := svr.coreService.SyncStatus()
+	if err != nil {
+		return nil, err
+	}
+	// the node is still syncing until the state and indexers catch up with the chain
+	if status.BlockLag() == 0 && status.IndexerLag() == 0 {
 		return false, nil
 	}
+	indexerBlocks := make(map[string]string, len(status.IndexerHeights))
+	for name, height := range status.IndexerHeights {
+		indexerBlocks[name] = uint64ToHex(height)
+	}
 	return &getSyncingResult{
-		StartingBlock: uint64ToHex(start),
-		CurrentBlock:  uint64ToHex(curr),
-		HighestBlock:  uint64ToHex(highest),
+		StartingBlock: uint64ToHex(status.StartingHeight),
+		CurrentBlock:  uint64ToHex(status.CurrentHeight),
+		HighestBlock:  uint64ToHex(status.HighestHeight),
+		StateBlock:    uint64ToHex(status.StateHeight),
+		HeaderBlock:   uint64ToHex(status.HeaderHeight),
+		IndexerBlocks: indexerBlocks,
 	}, nil
 }
 
@@ -726,19 +881,12 @@ func (svr *web3Handler) getTransactionByHash(in *gjson.Result) (interface{}, err
 		return nil, err
 	}
 
-	selp, blk, _, err := svr.coreService.ActionByActionHash(actHash)
+	act, err := svr.coreService.BlockByActionHash(actHash)
 	if err == nil {
-		receipt, err := svr.coreService.ReceiptByActionHash(actHash)
-		if err == nil {
-			return svr.assembleConfirmedTransaction(blk.HashBlock(), selp, receipt)
-		}
-		if errors.Cause(err) == ErrNotFound {
-			return nil, nil
-		}
-		return nil, err
+		return svr.assembleConfirmedTransaction(act.Block.HashBlock(), act.Action, act.Receipt)
 	}
 	if errors.Cause(err) == ErrNotFound {
-		selp, err = svr.coreService.PendingActionByActionHash(actHash)
+		selp, err := svr.coreService.PendingActionByActionHash(actHash)
 		if err == nil {
 			return svr.assemblePendingTransaction(selp)
 		}
@@ -755,7 +903,35 @@ func (svr *web3Handler) getLogs(filter *filterObject) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
-	return svr.getLogsWithFilter(from, to, filter.Address, filter.Topics)
+	logFilter, err := newLogFilterFrom(filter.Address, filter.Topics)
+	if err != nil {
+		return nil, err
+	}
+	var cursor *LogsCursor
+	if filter.Cursor != nil && *filter.Cursor != "" {
+		if cursor, err = ParseLogsCursor(*filter.Cursor); err != nil {
+			return nil, err
+		}
+	}
+	logs, hashes, next, err := svr.coreService.LogsInRangeWithCursor(logFilter, from, to, cursor)
+	if err != nil {
+		return nil, err
+	}
+	if filter.Cursor == nil && next != nil {
+		return nil, errors.Wrapf(ErrLogsQueryLimitExceeded, "set cursor in the filter to query logs by pages, next cursor: %s", next)
+	}
+	ret := make([]*getLogsResult, 0, len(logs))
+	for i := range logs {
+		ret = append(ret, &getLogsResult{hashes[i], logs[i]})
+	}
+	if filter.Cursor == nil {
+		return ret, nil
+	}
+	page := &getLogsPageResult{Logs: ret}
+	if next != nil {
+		page.Cursor = next.String()
+	}
+	return page, nil
 }
 
 func (svr *web3Handler) getTransactionReceipt(in *gjson.Result) (interface{}, error) {
@@ -770,24 +946,18 @@ func (svr *web3Handler) getTransactionReceipt(in *gjson.Result) (interface{}, er
 	}
 
 	// acquire action receipt by action hash
-	selp, blk, _, err := svr.coreService.ActionByActionHash(actHash)
+	act, err := svr.coreService.BlockByActionHash(actHash)
 	if err != nil {
 		if errors.Cause(err) == ErrNotFound {
 			return nil, nil
 		}
 		return nil, err
 	}
-	tx, err := selp.ToEthTx()
+	selp, blk, receipt := act.Action, act.Block, act.Receipt
+	tx, err := toEthTx(selp)
 	if err != nil {
 		return nil, err
 	}
-	receipt, err := svr.coreService.ReceiptByActionHash(actHash)
-	if err != nil {
-		if errors.Cause(err) == ErrNotFound {
-			return nil, nil
-		}
-		return nil, err
-	}
 	to, contractAddr, err := getRecipientAndContractAddrFromAction(selp, receipt)
 	if err != nil {
 		return nil, err
@@ -798,6 +968,18 @@ func (svr *web3Handler) getTransactionReceipt(in *gjson.Result) (interface{}, er
 	if logsBloom := blk.LogsBloomfilter(); logsBloom != nil {
 		logsBloomStr = hex.EncodeToString(logsBloom.Bytes())
 	}
+	var nativeLogs []*action.Log
+	if isNativeAction(selp) {
+		if nativeLogs, err = svr.nativeTransferLogs(blk, actHash); err != nil {
+			return nil, err
+		}
+	}
+	var gasBreakdown *action.GasBreakdown
+	if svr.gasBreakdown {
+		if gasBreakdown, err = receiptGasBreakdown(selp, receipt); err != nil {
+			return nil, err
+		}
+	}
 	return &getReceiptResult{
 		blockHash:       blk.HashBlock(),
 		from:            selp.SenderAddress(),
@@ -805,9 +987,10 @@ func (svr *web3Handler) getTransactionReceipt(in *gjson.Result) (interface{}, er
 		contractAddress: contractAddr,
 		logsBloom:       logsBloomStr,
 		receipt:         receipt,
+		nativeLogs:      nativeLogs,
 		txType:          uint(tx.Type()),
+		gasBreakdown:    gasBreakdown,
 	}, nil
-
 }
 
 func (svr *web3Handler) getBlockTransactionCountByNumber(in *gjson.Result) (interface{}, error) {
@@ -889,7 +1072,22 @@ func (svr *web3Handler) getStorageAt(in *gjson.Result) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
-	val, err := svr.coreService.ReadContractStorage(context.Background(), contractAddr, pos)
+	var bn = rpc.LatestBlockNumber
+	if bnParam := in.Get("params.2"); bnParam.Exists() {
+		if err := bn.UnmarshalJSON([]byte(bnParam.String())); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal height %s", bnParam.String())
+		}
+	}
+	height, archive, err := svr.blockNumberOrHashToHeight(rpc.BlockNumberOrHashWithNumber(bn))
+	if err != nil {
+		return nil, err
+	}
+	var val []byte
+	if !archive {
+		val, err = svr.coreService.ReadContractStorage(context.Background(), contractAddr, pos)
+	} else {
+		val, err = svr.coreService.WithHeight(height).ReadContractStorage(context.Background(), contractAddr, pos)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -920,28 +1118,27 @@ func (svr *web3Handler) newFilter(filter *filterObject) (interface{}, error) {
 		}
 	}
 
-	// cache filter and return hash value of the filter as filter id
 	filter.FilterType = "log"
-	objInByte, _ := json.Marshal(*filter)
-	keyHash := hash.Hash256b(objInByte)
-	filterID := hex.EncodeToString(keyHash[:])
-	err := svr.cache.Set(filterID, objInByte)
-	if err != nil {
-		return nil, err
-	}
-	return "0x" + filterID, nil
+	return svr.installFilter(filter)
 }
 
 func (svr *web3Handler) newBlockFilter() (interface{}, error) {
-	filterObj := filterObject{
+	return svr.installFilter(&filterObject{
 		FilterType: "block",
 		LogHeight:  svr.coreService.TipHeight(),
-	}
-	objInByte, _ := json.Marshal(filterObj)
+	})
+}
+
+// installFilter caches the filter and returns hash value of the filter as filter id. A filter installed
+// again, e.g., by a client reconnecting, keeps its progress so that no changes are lost or repeated
+func (svr *web3Handler) installFilter(filter *filterObject) (interface{}, error) {
+	objInByte, _ := json.Marshal(*filter)
 	keyHash := hash.Hash256b(objInByte)
 	filterID := hex.EncodeToString(keyHash[:])
-	err := svr.cache.Set(filterID, objInByte)
-	if err != nil {
+	if _, ok := svr.cache.Get(filterID); ok {
+		return "0x" + filterID, nil
+	}
+	if err := svr.cache.Set(filterID, objInByte); err != nil {
 		return nil, err
 	}
 	return "0x" + filterID, nil
@@ -1049,19 +1246,15 @@ func (svr *web3Handler) subscribe(ctx *StreamContext, in *gjson.Result, writer a
 			return nil, err
 		}
 		return svr.streamLogs(ctx, filter, writer)
+	case "newPendingTransactions":
+		return svr.streamPendingActions(ctx, in.Get("params.1").Bool(), writer)
 	default:
 		return nil, errInvalidFormat
 	}
 }
 
 func (svr *web3Handler) streamBlocks(ctx *StreamContext, writer apitypes.Web3ResponseWriter) (interface{}, error) {
-	chainListener := svr.coreService.ChainListener()
-	streamID, err := chainListener.AddResponder(NewWeb3BlockListener(writer.Write))
-	if err != nil {
-		return nil, err
-	}
-	ctx.AddListener(streamID)
-	return streamID, nil
+	return svr.addStream(ctx, NewWeb3BlockListener(writer.Write))
 }
 
 func (svr *web3Handler) streamLogs(ctx *StreamContext, filterObj *filterObject, writer apitypes.Web3ResponseWriter) (interface{}, error) {
@@ -1069,12 +1262,38 @@ func (svr *web3Handler) streamLogs(ctx *StreamContext, filterObj *filterObject,
 	if err != nil {
 		return nil, err
 	}
+	return svr.addStream(ctx, NewWeb3LogListener(filter, writer.Write))
+}
+
+func (svr *web3Handler) streamPendingActions(ctx *StreamContext, fullTx bool, writer apitypes.Web3ResponseWriter) (interface{}, error) {
+	assemble := func(selp *action.SealedEnvelope) (interface{}, error) {
+		h, err := selp.Hash()
+		if err != nil {
+			return nil, err
+		}
+		return "0x" + hex.EncodeToString(h[:]), nil
+	}
+	if fullTx {
+		assemble = func(selp *action.SealedEnvelope) (interface{}, error) {
+			return svr.assemblePendingTransaction(selp)
+		}
+	}
+	return svr.addStream(ctx, NewWeb3PendingActionListener(writer.Write, assemble))
+}
+
+// addStream adds the responder to the chain listener, and binds it with the stream
+func (svr *web3Handler) addStream(ctx *StreamContext, responder apitypes.Responder) (interface{}, error) {
 	chainListener := svr.coreService.ChainListener()
-	streamID, err := chainListener.AddResponder(NewWeb3LogListener(filter, writer.Write))
+	streamID, err := chainListener.AddResponder(responder)
 	if err != nil {
 		return nil, err
 	}
-	ctx.AddListener(streamID)
+	if err := ctx.AddListener(streamID); err != nil {
+		if _, err := chainListener.RemoveResponder(streamID); err != nil {
+			log.Logger("api").Warn("failed to remove responder", zap.String("streamID", streamID), zap.Error(err))
+		}
+		return nil, err
+	}
 	return streamID, nil
 }
 
@@ -1112,48 +1331,43 @@ func (svr *web3Handler) traceTransaction(ctx context.Context, in *gjson.Result)
 	if !actHash.Exists() {
 		return nil, errInvalidFormat
 	}
-	var (
-		enableMemory, disableStack, disableStorage, enableReturnData bool
-	)
-	if options.Exists() {
-		enableMemory = options.Get("enableMemory").Bool()
-		disableStack = options.Get("disableStack").Bool()
-		disableStorage = options.Get("disableStorage").Bool()
-		enableReturnData = options.Get("enableReturnData").Bool()
+	retval, receipt, tracer, err := svr.coreService.TraceTransaction(ctx, actHash.String(), parseTraceConfig(options))
+	if err != nil {
+		return nil, err
 	}
-	cfg := &tracers.TraceConfig{
-		Config: &logger.Config{
-			EnableMemory:     enableMemory,
-			DisableStack:     disableStack,
-			DisableStorage:   disableStorage,
-			EnableReturnData: enableReturnData,
-		},
+	return assembleTraceResult(retval, receipt, tracer)
+}
+
+func (svr *web3Handler) traceBlockByNumber(ctx context.Context, in *gjson.Result) (interface{}, error) {
+	blkNum, options := in.Get("params.0"), in.Get("params.1")
+	if !blkNum.Exists() {
+		return nil, errInvalidFormat
 	}
-	if tracer := options.Get("tracer"); tracer.Exists() {
-		cfg.Tracer = new(string)
-		*cfg.Tracer = tracer.String()
-		if tracerConfig := options.Get("tracerConfig"); tracerConfig.Exists() {
-			cfg.TracerConfig = json.RawMessage(tracerConfig.Raw)
-		}
+	num, err := svr.parseBlockNumber(blkNum.String())
+	if err != nil {
+		return nil, err
 	}
-	retval, receipt, tracer, err := svr.coreService.TraceTransaction(ctx, actHash.String(), cfg)
+	traces, err := svr.coreService.TraceBlock(ctx, num, parseTraceConfig(options))
 	if err != nil {
+		if errors.Cause(err) == ErrNotFound {
+			return nil, errors.Wrapf(errInvalidBlock, "block %d not found", num)
+		}
 		return nil, err
 	}
-	switch tracer := tracer.(type) {
-	case *logger.StructLogger:
-		return &debugTraceTransactionResult{
-			Failed:      receipt.Status != uint64(iotextypes.ReceiptStatus_Success),
-			Revert:      receipt.ExecutionRevertMsg(),
-			ReturnValue: byteToHex(retval),
-			StructLogs:  fromLoggerStructLogs(tracer.StructLogs()),
-			Gas:         receipt.GasConsumed,
-		}, nil
-	case tracers.Tracer:
-		return tracer.GetResult()
-	default:
-		return nil, fmt.Errorf("unknown tracer type: %T", tracer)
+	ret := make([]*debugTraceBlockResult, 0, len(traces))
+	for _, trace := range traces {
+		res := &debugTraceBlockResult{TxHash: "0x" + hex.EncodeToString(trace.ActHash[:])}
+		// failure of a single action is reported in its result, instead of failing the whole block
+		err := trace.Err
+		if err == nil {
+			res.Result, err = assembleTraceResult(trace.Retval, trace.Receipt, trace.Tracer)
+		}
+		if err != nil {
+			res.Error = err.Error()
+		}
+		ret = append(ret, res)
 	}
+	return ret, nil
 }
 
 func (svr *web3Handler) traceCall(ctx context.Context, in *gjson.Result) (interface{}, error) {
@@ -1176,29 +1390,25 @@ func (svr *web3Handler) traceCall(ctx context.Context, in *gjson.Result) (interf
 		}
 	}
 
+	retval, receipt, tracer, err := svr.coreService.TraceCall(ctx, callMsg.From, blkNumOrHash, callMsg.To, 0, callMsg.Value, callMsg.Gas, callMsg.Data, parseTraceConfig(options))
+	if err != nil {
+		return nil, err
+	}
+	return assembleTraceResult(retval, receipt, tracer)
+}
+
+// parseTraceConfig parses the tracer options of debug methods
+func parseTraceConfig(options gjson.Result) *tracers.TraceConfig {
 	var (
 		enableMemory, disableStack, disableStorage, enableReturnData bool
-		tracerJs, tracerTimeout                                      *string
 	)
 	if options.Exists() {
 		enableMemory = options.Get("enableMemory").Bool()
 		disableStack = options.Get("disableStack").Bool()
 		disableStorage = options.Get("disableStorage").Bool()
 		enableReturnData = options.Get("enableReturnData").Bool()
-		trace := options.Get("tracer")
-		if trace.Exists() {
-			tracerJs = new(string)
-			*tracerJs = trace.String()
-		}
-		traceTimeout := options.Get("timeout")
-		if traceTimeout.Exists() {
-			tracerTimeout = new(string)
-			*tracerTimeout = traceTimeout.String()
-		}
 	}
 	cfg := &tracers.TraceConfig{
-		Tracer:  tracerJs,
-		Timeout: tracerTimeout,
 		Config: &logger.Config{
 			EnableMemory:     enableMemory,
 			DisableStack:     disableStack,
@@ -1206,11 +1416,21 @@ func (svr *web3Handler) traceCall(ctx context.Context, in *gjson.Result) (interf
 			EnableReturnData: enableReturnData,
 		},
 	}
-
-	retval, receipt, tracer, err := svr.coreService.TraceCall(ctx, callMsg.From, blkNumOrHash, callMsg.To, 0, callMsg.Value, callMsg.Gas, callMsg.Data, cfg)
-	if err != nil {
-		return nil, err
+	if tracer := options.Get("tracer"); tracer.Exists() {
+		cfg.Tracer = new(string)
+		*cfg.Tracer = tracer.String()
+		if tracerConfig := options.Get("tracerConfig"); tracerConfig.Exists() {
+			cfg.TracerConfig = json.RawMessage(tracerConfig.Raw)
+		}
 	}
+	if timeout := options.Get("timeout"); timeout.Exists() {
+		cfg.Timeout = new(string)
+		*cfg.Timeout = timeout.String()
+	}
+	return cfg
+}
+
+func assembleTraceResult(retval []byte, receipt *action.Receipt, tracer any) (interface{}, error) {
 	switch tracer := tracer.(type) {
 	case *logger.StructLogger:
 		return &debugTraceTransactionResult{
@@ -66,7 +66,18 @@ type (
 		contractAddress *string
 		logsBloom       string
 		receipt         *action.Receipt
-		txType          uint
+		// nativeLogs are the synthesized logs of native token transfers of native actions
+		nativeLogs []*action.Log
+		txType     uint
+		// gasBreakdown is the gas consumed by phase, nil if it isn't requested
+		gasBreakdown *action.GasBreakdown
+	}
+
+	gasBreakdownResult struct {
+		Intrinsic hexutil.Uint64 `json:"intrinsic"`
+		Execution hexutil.Uint64 `json:"execution"`
+		Refund    hexutil.Uint64 `json:"refund"`
+		Sidecar   hexutil.Uint64 `json:"sidecar"`
 	}
 
 	getLogsResult struct {
@@ -74,10 +85,27 @@ type (
 		log       *action.Log
 	}
 
+	getLogsPageResult struct {
+		Logs   []*getLogsResult `json:"logs"`
+		Cursor string           `json:"cursor,omitempty"`
+	}
+
 	getSyncingResult struct {
-		StartingBlock string `json:"startingBlock"`
-		CurrentBlock  string `json:"currentBlock"`
-		HighestBlock  string `json:"highestBlock"`
+		StartingBlock string            `json:"startingBlock"`
+		CurrentBlock  string            `json:"currentBlock"`
+		HighestBlock  string            `json:"highestBlock"`
+		StateBlock    string            `json:"stateBlock,omitempty"`
+		HeaderBlock   string            `json:"headerBlock,omitempty"`
+		IndexerBlocks map[string]string `json:"indexerBlocks,omitempty"`
+	}
+
+	peerScoreResult struct {
+		ID            string  `json:"id"`
+		Score         float64 `json:"score"`
+		InvalidBlocks uint64  `json:"invalidBlocks"`
+		Timeouts      uint64  `json:"timeouts"`
+		Violations    uint64  `json:"violations"`
+		BannedUntil   string  `json:"bannedUntil,omitempty"`
 	}
 
 	debugTraceTransactionResult struct {
@@ -88,6 +116,12 @@ type (
 		StructLogs  []apitypes.StructLog `json:"structLogs"`
 	}
 
+	debugTraceBlockResult struct {
+		TxHash string      `json:"txHash"`
+		Result interface{} `json:"result,omitempty"`
+		Error  string      `json:"error,omitempty"`
+	}
+
 	feeHistoryResult struct {
 		OldestBlock       string     `json:"oldestBlock"`
 		BaseFeePerGas     []string   `json:"baseFeePerGas"`
@@ -364,28 +398,41 @@ func (obj *getReceiptResult) MarshalJSON() ([]byte, error) {
 	if obj.receipt == nil {
 		return nil, errInvalidObject
 	}
-	logs := make([]*getLogsResult, 0, len(obj.receipt.Logs()))
+	logs := make([]*getLogsResult, 0, len(obj.receipt.Logs())+len(obj.nativeLogs))
 	for _, v := range obj.receipt.Logs() {
 		logs = append(logs, &getLogsResult{obj.blockHash, v})
 	}
+	for _, v := range obj.nativeLogs {
+		logs = append(logs, &getLogsResult{obj.blockHash, v})
+	}
+	var gasBreakdown *gasBreakdownResult
+	if gb := obj.gasBreakdown; gb != nil {
+		gasBreakdown = &gasBreakdownResult{
+			Intrinsic: hexutil.Uint64(gb.Intrinsic),
+			Execution: hexutil.Uint64(gb.Execution),
+			Refund:    hexutil.Uint64(gb.Refund),
+			Sidecar:   hexutil.Uint64(gb.Sidecar),
+		}
+	}
 
 	return json.Marshal(&struct {
-		TransactionIndex  string           `json:"transactionIndex"`
-		TransactionHash   string           `json:"transactionHash"`
-		BlockHash         string           `json:"blockHash"`
-		BlockNumber       string           `json:"blockNumber"`
-		From              string           `json:"from"`
-		To                *string          `json:"to"`
-		CumulativeGasUsed string           `json:"cumulativeGasUsed"`
-		GasUsed           string           `json:"gasUsed"`
-		ContractAddress   *string          `json:"contractAddress"`
-		LogsBloom         string           `json:"logsBloom"`
-		Logs              []*getLogsResult `json:"logs"`
-		Status            string           `json:"status"`
-		Type              hexutil.Uint     `json:"type"`
-		EffectiveGasPrice *hexutil.Big     `json:"effectiveGasPrice"`
-		BlobGasUsed       hexutil.Uint64   `json:"blobGasUsed,omitempty"`
-		BlobGasPrice      *hexutil.Big     `json:"blobGasPrice,omitempty"`
+		TransactionIndex  string              `json:"transactionIndex"`
+		TransactionHash   string              `json:"transactionHash"`
+		BlockHash         string              `json:"blockHash"`
+		BlockNumber       string              `json:"blockNumber"`
+		From              string              `json:"from"`
+		To                *string             `json:"to"`
+		CumulativeGasUsed string              `json:"cumulativeGasUsed"`
+		GasUsed           string              `json:"gasUsed"`
+		ContractAddress   *string             `json:"contractAddress"`
+		LogsBloom         string              `json:"logsBloom"`
+		Logs              []*getLogsResult    `json:"logs"`
+		Status            string              `json:"status"`
+		Type              hexutil.Uint        `json:"type"`
+		EffectiveGasPrice *hexutil.Big        `json:"effectiveGasPrice"`
+		BlobGasUsed       hexutil.Uint64      `json:"blobGasUsed,omitempty"`
+		BlobGasPrice      *hexutil.Big        `json:"blobGasPrice,omitempty"`
+		GasBreakdown      *gasBreakdownResult `json:"gasBreakdown,omitempty"`
 	}{
 		TransactionIndex:  uint64ToHex(uint64(obj.receipt.TxIndex)),
 		TransactionHash:   "0x" + hex.EncodeToString(obj.receipt.ActionHash[:]),
@@ -403,6 +450,7 @@ func (obj *getReceiptResult) MarshalJSON() ([]byte, error) {
 		EffectiveGasPrice: (*hexutil.Big)(obj.receipt.EffectiveGasPrice),
 		BlobGasUsed:       hexutil.Uint64(obj.receipt.BlobGasUsed),
 		BlobGasPrice:      (*hexutil.Big)(obj.receipt.BlobGasPrice),
+		GasBreakdown:      gasBreakdown,
 	})
 }
 
@@ -17,6 +17,7 @@ import (
 	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
 
 	"github.com/iotexproject/iotex-core/v2/action"
 	apitypes "github.com/iotexproject/iotex-core/v2/api/types"
@@ -699,6 +700,25 @@ func TestReceiptObjectMarshal(t *testing.T) {
 		 }
 		`, string(res))
 	})
+
+	t.Run("GasBreakdown", func(t *testing.T) {
+		res, err := json.Marshal(&getReceiptResult{
+			blockHash:    _testBlkHash,
+			from:         _testSenderIoAddr,
+			receipt:      receipt,
+			gasBreakdown: &action.GasBreakdown{Intrinsic: 21000, Execution: 100, Refund: 100, Sidecar: 2},
+		})
+		require.NoError(err)
+		require.Equal(`{"intrinsic":"0x5208","execution":"0x64","refund":"0x64","sidecar":"0x2"}`, gjson.GetBytes(res, "gasBreakdown").Raw)
+
+		res, err = json.Marshal(&getReceiptResult{
+			blockHash: _testBlkHash,
+			from:      _testSenderIoAddr,
+			receipt:   receipt,
+		})
+		require.NoError(err)
+		require.False(gjson.GetBytes(res, "gasBreakdown").Exists())
+	})
 }
 
 func TestLogsObjectMarshal(t *testing.T) {
@@ -0,0 +1,188 @@
+package api
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/poll"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/addrutil"
+)
+
+// Native actions are presented to web3 clients as follows, so that generic EVM indexers see every
+// action of a block:
+//
+//   - staking and rewarding actions are txs sent to the staking protocol address
+//     0x04c22afae6a03438b8fed74cb1cf441168df3f12 and the rewarding protocol address
+//     0xa576c141e5659137ddda4223d209d4744b2106be, with their ABI-encoded calls as data
+//   - put poll result actions are txs sent to the poll protocol address
+//     0x166b743c2c1a57c93c2e2bc3e169d28bbb9f6da3, with the protobuf-encoded action core as data
+//   - the native token moved by a native action, e.g., staked into a bucket or claimed from the
+//     rewarding fund, is reported in its receipt as ERC20-like logs emitted from _nativeTokenEthAddr:
+//     event Transfer(address indexed from, address indexed to, uint256 value)
+//     where the staking bucket pool and the rewarding pool are represented by the staking and
+//     rewarding protocol addresses. These logs are indexed after all logs of the block, and are
+//     neither included in the logs bloom nor returned by eth_getLogs
+var (
+	_pollProtocolEthAddr  = common.BytesToAddress(poll.ProtocolAddr().Bytes())
+	_nativeTokenEthAddr   = common.HexToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE")
+	_transferEventTopic   = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+	_nativeTokenIoAddr, _ = address.FromBytes(_nativeTokenEthAddr.Bytes())
+)
+
+// toEthTx converts the action to eth tx, including the native actions without ethereum representation
+func toEthTx(selp *action.SealedEnvelope) (*types.Transaction, error) {
+	tx, err := selp.ToEthTx()
+	if errors.Cause(err) != action.ErrInvalidAct {
+		return tx, err
+	}
+	if _, ok := selp.Action().(*action.PutPollResult); !ok {
+		return nil, err
+	}
+	data, err := proto.Marshal(selp.Envelope.Proto())
+	if err != nil {
+		return nil, err
+	}
+	return types.NewTx(&types.LegacyTx{
+		Nonce:    selp.Nonce(),
+		GasPrice: selp.GasPrice(),
+		Gas:      selp.Gas(),
+		To:       &_pollProtocolEthAddr,
+		Value:    big.NewInt(0),
+		Data:     data,
+	}), nil
+}
+
+// isNativeAction returns true if the action is neither a contract execution nor a container of eth tx
+func isNativeAction(selp *action.SealedEnvelope) bool {
+	if _, ok := selp.Envelope.(action.TxContainer); ok {
+		return false
+	}
+	_, ok := selp.Action().(*action.Execution)
+	return !ok
+}
+
+// isReportedNativeTransfer returns true if the native token transfer should be reported as log, fees are
+// implied by gas used and gas price, and native transfers are shown as value of the tx
+func isReportedNativeTransfer(typ iotextypes.TransactionLogType) bool {
+	switch typ {
+	case iotextypes.TransactionLogType_GAS_FEE,
+		iotextypes.TransactionLogType_PRIORITY_FEE,
+		iotextypes.TransactionLogType_BLOB_FEE,
+		iotextypes.TransactionLogType_NATIVE_TRANSFER:
+		return false
+	default:
+		return true
+	}
+}
+
+// nativeTransferLogs returns the synthesized logs of native token transfers of the action in the block,
+// nil is returned if the node does not keep transaction logs
+func (svr *web3Handler) nativeTransferLogs(blk *block.Block, actHash hash.Hash256) ([]*action.Log, error) {
+	_, txLogs, err := svr.coreService.TransactionLogByBlockHeight(blk.Height())
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if txLogs == nil {
+		return nil, nil
+	}
+	blkWithReceipts, err := svr.coreService.BlockByHeight(blk.Height())
+	if err != nil {
+		return nil, err
+	}
+	var (
+		logIndex uint32
+		txIndex  = make(map[hash.Hash256]uint32, len(blk.Actions))
+		native   = make(map[hash.Hash256]bool, len(blk.Actions))
+		logs     []*action.Log
+	)
+	for _, receipt := range blkWithReceipts.Receipts {
+		logIndex += uint32(len(receipt.Logs()))
+	}
+	for i, selp := range blk.Actions {
+		h, err := selp.Hash()
+		if err != nil {
+			return nil, err
+		}
+		txIndex[h] = uint32(i)
+		native[h] = isNativeAction(selp)
+	}
+	for _, txLog := range txLogs.Logs {
+		h := hash.BytesToHash256(txLog.ActionHash)
+		if !native[h] {
+			continue
+		}
+		for _, tx := range txLog.Transactions {
+			if !isReportedNativeTransfer(tx.Type) {
+				continue
+			}
+			if h == actHash {
+				log, err := newNativeTransferLog(tx, blk.Height(), h, logIndex, txIndex[h])
+				if err != nil {
+					return nil, err
+				}
+				logs = append(logs, log)
+			}
+			logIndex++
+		}
+	}
+	return logs, nil
+}
+
+func newNativeTransferLog(tx *iotextypes.TransactionLog_Transaction, height uint64, actHash hash.Hash256, index, txIndex uint32) (*action.Log, error) {
+	from, err := nativeEthAddr(tx.Sender)
+	if err != nil {
+		return nil, err
+	}
+	to, err := nativeEthAddr(tx.Recipient)
+	if err != nil {
+		return nil, err
+	}
+	amount, ok := new(big.Int).SetString(tx.Amount, 10)
+	if !ok {
+		return nil, errors.Errorf("invalid amount %s of transaction log", tx.Amount)
+	}
+	return &action.Log{
+		Address: _nativeTokenIoAddr.String(),
+		Topics: action.Topics{
+			hash.Hash256(_transferEventTopic),
+			hash.BytesToHash256(from.Bytes()),
+			hash.BytesToHash256(to.Bytes()),
+		},
+		Data:        common.LeftPadBytes(amount.Bytes(), 32),
+		BlockHeight: height,
+		ActionHash:  actHash,
+		Index:       index,
+		TxIndex:     txIndex,
+	}, nil
+}
+
+// nativeEthAddr converts the address in transaction log to eth address, where the pools are represented
+// by the protocol addresses
+func nativeEthAddr(ioAddr string) (common.Address, error) {
+	switch ioAddr {
+	case address.StakingBucketPoolAddr:
+		return common.BytesToAddress(address.StakingProtocolAddrHash[:]), nil
+	case address.RewardingPoolAddr:
+		return common.BytesToAddress(address.RewardingProtocolAddrHash[:]), nil
+	}
+	addr, err := addrutil.IoAddrToEvmAddr(ioAddr)
+	if err != nil {
+		return common.Address{}, errors.Wrapf(err, "invalid address %s of transaction log", ioAddr)
+	}
+	return addr, nil
+}
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	apitypes "github.com/iotexproject/iotex-core/v2/api/types"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/state"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+func TestToEthTx(t *testing.T) {
+	require := require.New(t)
+
+	tsf, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), 1, big.NewInt(10), []byte{}, 100000, big.NewInt(0))
+	require.NoError(err)
+	tx, err := toEthTx(tsf)
+	require.NoError(err)
+	require.Equal(identityset.Address(28).Bytes(), tx.To().Bytes())
+
+	candidates := state.CandidateList{{
+		Address: identityset.Address(1).String(),
+		Votes:   big.NewInt(1000),
+	}}
+	elp := (&action.EnvelopeBuilder{}).SetNonce(2).SetGasLimit(100000).
+		SetAction(action.NewPutPollResult(10001, candidates)).Build()
+	poll, err := action.Sign(elp, identityset.PrivateKey(27))
+	require.NoError(err)
+	_, err = poll.ToEthTx()
+	require.ErrorIs(err, action.ErrInvalidAct)
+	tx, err = toEthTx(poll)
+	require.NoError(err)
+	require.Equal("0x166B743C2C1a57C93c2E2Bc3e169D28BBb9f6dA3", tx.To().Hex())
+	require.Equal(uint64(2), tx.Nonce())
+	require.Zero(tx.Value().Sign())
+	core := &iotextypes.ActionCore{}
+	require.NoError(proto.Unmarshal(tx.Data(), core))
+	require.Equal(uint64(10001), core.GetPutPollResult().GetHeight())
+}
+
+func TestNativeTransferLogs(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := NewMockCoreService(ctrl)
+	web3svr := &web3Handler{coreService: core, batchRequestLimit: _defaultBatchRequestLimit}
+
+	exec, err := action.SignedExecution(identityset.Address(29).String(), identityset.PrivateKey(27), 1, big.NewInt(1), 100000, big.NewInt(0), nil)
+	require.NoError(err)
+	stake1, err := action.SignedCreateStake(2, "cand", "100", 1, false, nil, 100000, big.NewInt(0), identityset.PrivateKey(27))
+	require.NoError(err)
+	stake2, err := action.SignedCreateStake(3, "cand", "200", 1, false, nil, 100000, big.NewInt(0), identityset.PrivateKey(27))
+	require.NoError(err)
+	var hashes []hash.Hash256
+	for _, selp := range []*action.SealedEnvelope{exec, stake1, stake2} {
+		h, err := selp.Hash()
+		require.NoError(err)
+		hashes = append(hashes, h)
+	}
+	blk, err := block.NewTestingBuilder().
+		SetHeight(5).
+		SetPrevBlockHash(hash.ZeroHash256).
+		SetTimeStamp(time.Now()).
+		AddActions(exec, stake1, stake2).
+		SignAndBuild(identityset.PrivateKey(0))
+	require.NoError(err)
+	receipts := []*action.Receipt{
+		(&action.Receipt{ActionHash: hashes[0]}).AddLogs(&action.Log{Index: 0}),
+		{ActionHash: hashes[1]},
+		{ActionHash: hashes[2]},
+	}
+	staker := identityset.Address(27).String()
+	txLogs := &iotextypes.TransactionLogs{Logs: []*iotextypes.TransactionLog{
+		{ActionHash: hashes[0][:], Transactions: []*iotextypes.TransactionLog_Transaction{
+			{Type: iotextypes.TransactionLogType_IN_CONTRACT_TRANSFER, Amount: "1", Sender: staker, Recipient: identityset.Address(29).String()},
+		}},
+		{ActionHash: hashes[1][:], Transactions: []*iotextypes.TransactionLog_Transaction{
+			{Type: iotextypes.TransactionLogType_GAS_FEE, Amount: "1", Sender: staker, Recipient: address.RewardingPoolAddr},
+			{Type: iotextypes.TransactionLogType_CREATE_BUCKET, Amount: "100", Sender: staker, Recipient: address.StakingBucketPoolAddr},
+		}},
+		{ActionHash: hashes[2][:], Transactions: []*iotextypes.TransactionLog_Transaction{
+			{Type: iotextypes.TransactionLogType_CREATE_BUCKET, Amount: "200", Sender: staker, Recipient: address.StakingBucketPoolAddr},
+		}},
+	}}
+	core.EXPECT().TransactionLogByBlockHeight(uint64(5)).Return(nil, txLogs, nil).Times(2)
+	core.EXPECT().BlockByHeight(uint64(5)).Return(&apitypes.BlockWithReceipts{Block: &blk, Receipts: receipts}, nil).Times(2)
+
+	logs, err := web3svr.nativeTransferLogs(&blk, hashes[2])
+	require.NoError(err)
+	require.Len(logs, 1)
+	log := logs[0]
+	// indexed after the log of execution and the log of the first stake
+	require.Equal(uint32(2), log.Index)
+	require.Equal(uint32(2), log.TxIndex)
+	require.Equal(uint64(5), log.BlockHeight)
+	require.Equal(hashes[2], log.ActionHash)
+	addr, err := ioAddrToEthAddr(log.Address)
+	require.NoError(err)
+	require.Equal("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE", addr)
+	require.Equal(_transferEventTopic[:], log.Topics[0][:])
+	require.Equal(identityset.Address(27).Bytes(), log.Topics[1][12:])
+	require.Equal(address.StakingProtocolAddrHash[:], log.Topics[2][12:])
+	require.Equal(common.LeftPadBytes(big.NewInt(200).Bytes(), 32), log.Data)
+
+	// execution is not a native action
+	logs, err = web3svr.nativeTransferLogs(&blk, hashes[0])
+	require.NoError(err)
+	require.Empty(logs)
+
+	// marshal into receipt after the logs of receipt
+	res, err := (&getReceiptResult{
+		blockHash:  blk.HashBlock(),
+		from:       identityset.Address(27),
+		receipt:    receipts[2],
+		nativeLogs: []*action.Log{log},
+	}).MarshalJSON()
+	require.NoError(err)
+	require.Contains(string(res), hex.EncodeToString(_transferEventTopic[:]))
+}
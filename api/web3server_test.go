@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
 	"math/big"
@@ -19,6 +20,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/tidwall/gjson"
 	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/iotexproject/go-pkgs/hash"
@@ -27,6 +30,7 @@ import (
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 
 	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/actpool/userop"
 	apitypes "github.com/iotexproject/iotex-core/v2/api/types"
 	"github.com/iotexproject/iotex-core/v2/blockchain/block"
 	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
@@ -165,12 +169,76 @@ func TestHandlePost(t *testing.T) {
 	require.Contains(string(bodyBytes9), errMsgBatchTooLarge.Error())
 }
 
+func TestHandlePostBatchBudget(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := NewMockCoreService(ctrl)
+	core.EXPECT().Track(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return().AnyTimes()
+	svr := newHTTPHandler(NewWeb3Handler(core, "", _defaultBatchRequestLimit, WithBatchGasLimit(100000), WithBatchTimeout(time.Minute)))
+
+	req, _ := http.NewRequest(http.MethodPost, "http://url.com", strings.NewReader(`[{"jsonrpc":"2.0","method":"eth_call","params":[{"to":"0x0000000000000000000000000000000000000001","gas":"0x30000"}],"id":1}, {"jsonrpc":"2.0","method":"eth_mining","params":[],"id":2}]`))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	svr.ServeHTTP(resp, req)
+	body, _ := io.ReadAll(resp.Body)
+	require.True(gjson.Valid(string(body)))
+	results := gjson.Parse(string(body)).Array()
+	require.Len(results, 2)
+	require.Equal(int64(1), results[0].Get("id").Int())
+	require.Contains(results[0].Get("error.message").String(), errBatchGasExceeded.Error())
+	require.Equal(int64(2), results[1].Get("id").Int())
+	require.False(results[1].Get("error").Exists())
+}
+
+func TestBatchBudget(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := NewMockCoreService(ctrl)
+	g := genesis.TestDefault()
+	core.EXPECT().Genesis().Return(g).AnyTimes()
+	core.EXPECT().TipHeight().Return(uint64(1)).AnyTimes()
+	defaultGas := g.BlockGasLimitByHeight(1)
+
+	svr := &web3Handler{coreService: core, batchGasLimit: defaultGas + 1000}
+	ctx, budget := svr.newBatchBudget(context.Background())
+	defer budget.release()
+	for _, c := range []struct {
+		req string
+		err error
+	}{
+		{`{"method":"eth_mining"}`, nil},
+		{`{"method":"eth_call","params":[{"gas":"0x3e8"}]}`, nil},
+		{`{"method":"eth_call","params":[{"gas":"0xzz"}]}`, errUnkownType},
+		// call without gas is charged by the block gas limit
+		{`{"method":"debug_traceCall","params":[{}]}`, nil},
+		{`{"method":"eth_estimateGas","params":[{"gas":"0x1"}]}`, errBatchGasExceeded},
+		{`{"method":"eth_mining"}`, nil},
+	} {
+		req := gjson.Parse(c.req)
+		err := budget.consume(ctx, &req)
+		if c.err == nil {
+			require.NoError(err)
+		} else {
+			require.ErrorIs(err, c.err)
+		}
+	}
+
+	svr = &web3Handler{coreService: core, batchTimeout: time.Nanosecond}
+	ctx, budget = svr.newBatchBudget(context.Background())
+	defer budget.release()
+	<-ctx.Done()
+	req := gjson.Parse(`{"method":"eth_mining"}`)
+	require.ErrorIs(budget.consume(ctx, &req), errBatchTimeout)
+}
+
 func TestGasPrice(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{coreService: core, batchRequestLimit: _defaultBatchRequestLimit}
 	core.EXPECT().SuggestGasPrice().Return(uint64(1), nil)
 	ret, err := web3svr.gasPrice()
 	require.NoError(err)
@@ -186,7 +254,7 @@ func TestGetChainID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{coreService: core, batchRequestLimit: _defaultBatchRequestLimit}
 	core.EXPECT().EVMNetworkID().Return(uint32(1))
 	ret, err := web3svr.getChainID()
 	require.NoError(err)
@@ -198,7 +266,7 @@ func TestGetBlockNumber(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{coreService: core, batchRequestLimit: _defaultBatchRequestLimit}
 	core.EXPECT().TipHeight().Return(uint64(1))
 	ret, err := web3svr.getBlockNumber()
 	require.NoError(err)
@@ -210,7 +278,7 @@ func TestGetBlockByNumber(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{coreService: core, batchRequestLimit: _defaultBatchRequestLimit}
 
 	tsf, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
@@ -261,7 +329,7 @@ func TestGetBalance(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{coreService: core, batchRequestLimit: _defaultBatchRequestLimit}
 	balance := "111111111111111111"
 	core.EXPECT().BalanceAt(gomock.Any(), gomock.Any(), gomock.Any()).Return(balance, nil)
 
@@ -271,6 +339,13 @@ func TestGetBalance(t *testing.T) {
 	ans, ok := new(big.Int).SetString(balance, 10)
 	require.True(ok)
 	require.Equal("0x"+fmt.Sprintf("%x", ans), ret.(string))
+
+	// the balance of the pending block
+	core.EXPECT().PendingBalance(gomock.Any(), gomock.Any()).Return("10", nil)
+	in = gjson.Parse(`{"params":["0xDa7e12Ef57c236a06117c5e0d04a228e7181CF36", "pending"]}`)
+	ret, err = web3svr.getBalance(&in)
+	require.NoError(err)
+	require.Equal("0xa", ret.(string))
 }
 
 func TestGetTransactionCount(t *testing.T) {
@@ -278,7 +353,7 @@ func TestGetTransactionCount(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{coreService: core, batchRequestLimit: _defaultBatchRequestLimit}
 	core.EXPECT().PendingNonceAt(gomock.Any(), gomock.Any(), gomock.Any()).Return(uint64(2), nil)
 
 	inNil := gjson.Parse(`{"params":[]}`)
@@ -296,7 +371,7 @@ func TestCall(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{coreService: core, batchRequestLimit: _defaultBatchRequestLimit}
 
 	t.Run("to is StakingProtocol addr", func(t *testing.T) {
 		meta := &iotextypes.AccountMeta{
@@ -387,7 +462,7 @@ func TestEstimateGas(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{coreService: core, batchRequestLimit: _defaultBatchRequestLimit}
 	core.EXPECT().ChainID().Return(uint32(1)).Times(2)
 	core.EXPECT().EVMNetworkID().Return(uint32(0)).Times(2)
 
@@ -431,7 +506,7 @@ func TestSendRawTransaction(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{coreService: core, batchRequestLimit: _defaultBatchRequestLimit}
 	core.EXPECT().Genesis().Return(genesis.TestDefault())
 	core.EXPECT().TipHeight().Return(uint64(0))
 	core.EXPECT().EVMNetworkID().Return(uint32(1))
@@ -458,7 +533,7 @@ func TestGetCode(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{coreService: core, batchRequestLimit: _defaultBatchRequestLimit}
 	code := "608060405234801561001057600080fd5b50610150806100206contractbytecode"
 	data, _ := hex.DecodeString(code)
 	core.EXPECT().Account(gomock.Any()).Return(&iotextypes.AccountMeta{ContractByteCode: data}, nil, nil)
@@ -482,7 +557,7 @@ func TestGetNodeInfo(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{coreService: core, batchRequestLimit: _defaultBatchRequestLimit}
 	core.EXPECT().ServerMeta().Return("111", "", "", "222", "")
 	ret, err := web3svr.getNodeInfo()
 	require.NoError(err)
@@ -494,7 +569,7 @@ func TestGetNetworkID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{coreService: core, batchRequestLimit: _defaultBatchRequestLimit}
 	core.EXPECT().EVMNetworkID().Return(uint32(123))
 	ret, err := web3svr.getNetworkID()
 	require.NoError(err)
@@ -506,8 +581,15 @@ func TestIsSyncing(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
-	core.EXPECT().SyncingProgress().Return(uint64(1), uint64(2), uint64(3))
+	web3svr := &web3Handler{coreService: core, batchRequestLimit: _defaultBatchRequestLimit}
+	core.EXPECT().SyncStatus().Return(&SyncStatus{
+		StartingHeight: 1,
+		CurrentHeight:  2,
+		HighestHeight:  3,
+		StateHeight:    2,
+		HeaderHeight:   3,
+		IndexerHeights: map[string]uint64{"index": 1},
+	}, nil)
 	ret, err := web3svr.isSyncing()
 	require.NoError(err)
 	rlt, ok := ret.(*getSyncingResult)
@@ -515,6 +597,34 @@ func TestIsSyncing(t *testing.T) {
 	require.Equal("0x1", rlt.StartingBlock)
 	require.Equal("0x2", rlt.CurrentBlock)
 	require.Equal("0x3", rlt.HighestBlock)
+	require.Equal("0x2", rlt.StateBlock)
+	require.Equal("0x3", rlt.HeaderBlock)
+	require.Equal(map[string]string{"index": "0x1"}, rlt.IndexerBlocks)
+
+	t.Run("indexer lagging", func(t *testing.T) {
+		core.EXPECT().SyncStatus().Return(&SyncStatus{
+			CurrentHeight:  3,
+			HighestHeight:  3,
+			StateHeight:    3,
+			IndexerHeights: map[string]uint64{"index": 2},
+		}, nil)
+		ret, err := web3svr.isSyncing()
+		require.NoError(err)
+		_, ok := ret.(*getSyncingResult)
+		require.True(ok)
+	})
+
+	t.Run("synced", func(t *testing.T) {
+		core.EXPECT().SyncStatus().Return(&SyncStatus{
+			CurrentHeight:  3,
+			HighestHeight:  3,
+			StateHeight:    3,
+			IndexerHeights: map[string]uint64{"index": 3},
+		}, nil)
+		ret, err := web3svr.isSyncing()
+		require.NoError(err)
+		require.Equal(false, ret)
+	})
 }
 
 func TestGetBlockTransactionCountByHash(t *testing.T) {
@@ -522,7 +632,7 @@ func TestGetBlockTransactionCountByHash(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{coreService: core, batchRequestLimit: _defaultBatchRequestLimit}
 
 	tsf, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
@@ -558,7 +668,7 @@ func TestGetBlockByHash(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{coreService: core, batchRequestLimit: _defaultBatchRequestLimit}
 
 	tsf, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
@@ -610,7 +720,7 @@ func TestGetTransactionByHash(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{coreService: core, batchRequestLimit: _defaultBatchRequestLimit}
 
 	selp, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
@@ -632,8 +742,7 @@ func TestGetTransactionByHash(t *testing.T) {
 		AddActions(selp).
 		SignAndBuild(identityset.PrivateKey(0))
 	require.NoError(err)
-	core.EXPECT().ActionByActionHash(gomock.Any()).Return(selp, &blk, uint32(0), nil)
-	core.EXPECT().ReceiptByActionHash(gomock.Any()).Return(receipt, nil)
+	core.EXPECT().BlockByActionHash(gomock.Any()).Return(&apitypes.ActionInBlock{Action: selp, Block: &blk, Receipt: receipt}, nil)
 	core.EXPECT().EVMNetworkID().Return(uint32(0))
 
 	inNil := gjson.Parse(`{"params":[]}`)
@@ -648,7 +757,7 @@ func TestGetTransactionByHash(t *testing.T) {
 	require.Equal(receipt, rlt.receipt)
 
 	// get pending transaction
-	core.EXPECT().ActionByActionHash(gomock.Any()).Return(nil, nil, uint32(0), ErrNotFound)
+	core.EXPECT().BlockByActionHash(gomock.Any()).Return(nil, ErrNotFound)
 	core.EXPECT().PendingActionByActionHash(gomock.Any()).Return(selp, nil)
 	core.EXPECT().EVMNetworkID().Return(uint32(0))
 	ret, err = web3svr.getTransactionByHash(&in)
@@ -663,7 +772,7 @@ func TestGetTransactionByHash(t *testing.T) {
 	require.NoError(err)
 	txHash, err = selp.Hash()
 	require.NoError(err)
-	core.EXPECT().ActionByActionHash(gomock.Any()).Return(nil, nil, uint32(0), ErrNotFound)
+	core.EXPECT().BlockByActionHash(gomock.Any()).Return(nil, ErrNotFound)
 	core.EXPECT().PendingActionByActionHash(gomock.Any()).Return(selp, nil)
 	core.EXPECT().EVMNetworkID().Return(uint32(0))
 	ret, err = web3svr.getTransactionByHash(&in)
@@ -680,7 +789,7 @@ func TestGetLogs(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{coreService: core, batchRequestLimit: _defaultBatchRequestLimit}
 
 	logs := []*action.Log{
 		{
@@ -706,7 +815,7 @@ func TestGetLogs(t *testing.T) {
 		blkHash1,
 		blkHash2,
 	}
-	core.EXPECT().LogsInRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(logs, hashes, nil)
+	core.EXPECT().LogsInRangeWithCursor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Nil()).Return(logs, hashes, nil, nil)
 
 	ret, err := web3svr.getLogs(&filterObject{
 		FromBlock: "1",
@@ -722,6 +831,37 @@ func TestGetLogs(t *testing.T) {
 	require.Equal(blkHash1, rlt[0].blockHash)
 	require.Equal("_topic2", rlt[1].log.Address)
 	require.Equal(blkHash2, rlt[1].blockHash)
+
+	t.Run("limit exceeded without cursor", func(t *testing.T) {
+		core.EXPECT().LogsInRangeWithCursor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Nil()).Return(logs, hashes, &LogsCursor{Height: 3}, nil)
+		_, err := web3svr.getLogs(&filterObject{FromBlock: "1", ToBlock: "5"})
+		require.ErrorIs(err, ErrLogsQueryLimitExceeded)
+	})
+	t.Run("paginated", func(t *testing.T) {
+		first := ""
+		core.EXPECT().LogsInRangeWithCursor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Nil()).Return(logs[:1], hashes[:1], &LogsCursor{Height: 2}, nil)
+		ret, err := web3svr.getLogs(&filterObject{FromBlock: "1", ToBlock: "2", Cursor: &first})
+		require.NoError(err)
+		page, ok := ret.(*getLogsPageResult)
+		require.True(ok)
+		require.Len(page.Logs, 1)
+		require.Equal(blkHash1, page.Logs[0].blockHash)
+		require.Equal((&LogsCursor{Height: 2}).String(), page.Cursor)
+
+		core.EXPECT().LogsInRangeWithCursor(gomock.Any(), gomock.Any(), gomock.Any(), &LogsCursor{Height: 2}).Return(logs[1:], hashes[1:], nil, nil)
+		ret, err = web3svr.getLogs(&filterObject{FromBlock: "1", ToBlock: "2", Cursor: &page.Cursor})
+		require.NoError(err)
+		page, ok = ret.(*getLogsPageResult)
+		require.True(ok)
+		require.Len(page.Logs, 1)
+		require.Equal(blkHash2, page.Logs[0].blockHash)
+		require.Empty(page.Cursor)
+	})
+	t.Run("invalid cursor", func(t *testing.T) {
+		cursor := "0x1234"
+		_, err := web3svr.getLogs(&filterObject{FromBlock: "1", ToBlock: "2", Cursor: &cursor})
+		require.ErrorIs(err, ErrInvalidLogsCursor)
+	})
 }
 
 func TestGetTransactionReceipt(t *testing.T) {
@@ -729,7 +869,7 @@ func TestGetTransactionReceipt(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{coreService: core, batchRequestLimit: _defaultBatchRequestLimit}
 
 	selp, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
@@ -751,8 +891,8 @@ func TestGetTransactionReceipt(t *testing.T) {
 		AddActions(selp).
 		SignAndBuild(identityset.PrivateKey(0))
 	require.NoError(err)
-	core.EXPECT().ActionByActionHash(gomock.Any()).Return(selp, &blk, uint32(0), nil)
-	core.EXPECT().ReceiptByActionHash(gomock.Any()).Return(receipt, nil)
+	core.EXPECT().BlockByActionHash(gomock.Any()).Return(&apitypes.ActionInBlock{Action: selp, Block: &blk, Receipt: receipt}, nil)
+	core.EXPECT().TransactionLogByBlockHeight(uint64(1)).Return(nil, nil, status.Error(codes.Unimplemented, "not supported"))
 
 	t.Run("nil params", func(t *testing.T) {
 		inNil := gjson.Parse(`{"params":[]}`)
@@ -767,6 +907,7 @@ func TestGetTransactionReceipt(t *testing.T) {
 		rlt, ok := ret.(*getReceiptResult)
 		require.True(ok)
 		require.Equal(receipt, rlt.receipt)
+		require.Nil(rlt.nativeLogs)
 		require.Equal("", rlt.logsBloom)
 		require.Nil(blk.Header.LogsBloomfilter())
 	})
@@ -777,7 +918,7 @@ func TestGetBlockTransactionCountByNumber(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{coreService: core, batchRequestLimit: _defaultBatchRequestLimit}
 
 	tsf, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
@@ -812,7 +953,7 @@ func TestGetTransactionByBlockHashAndIndex(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{coreService: core, batchRequestLimit: _defaultBatchRequestLimit}
 
 	tsf, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
@@ -859,7 +1000,7 @@ func TestGetTransactionByBlockNumberAndIndex(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{coreService: core, batchRequestLimit: _defaultBatchRequestLimit}
 
 	tsf, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
@@ -905,7 +1046,7 @@ func TestGetStorageAt(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{coreService: core, batchRequestLimit: _defaultBatchRequestLimit}
 	val := []byte("test")
 	core.EXPECT().ReadContractStorage(gomock.Any(), gomock.Any(), gomock.Any()).Return(val, nil)
 
@@ -913,6 +1054,21 @@ func TestGetStorageAt(t *testing.T) {
 	ret, err := web3svr.getStorageAt(&in)
 	require.NoError(err)
 	require.Equal("0x"+hex.EncodeToString(val), ret.(string))
+
+	t.Run("at height", func(t *testing.T) {
+		coreWithHeight := NewMockCoreServiceReaderWithHeight(ctrl)
+		core.EXPECT().WithHeight(uint64(10)).Return(coreWithHeight).Times(1)
+		coreWithHeight.EXPECT().ReadContractStorage(gomock.Any(), gomock.Any(), gomock.Any()).Return(val, nil)
+		in := gjson.Parse(`{"params":["0x123456789abc", "0", "0xa"]}`)
+		ret, err := web3svr.getStorageAt(&in)
+		require.NoError(err)
+		require.Equal("0x"+hex.EncodeToString(val), ret.(string))
+
+		core.EXPECT().ReadContractStorage(gomock.Any(), gomock.Any(), gomock.Any()).Return(val, nil)
+		in = gjson.Parse(`{"params":["0x123456789abc", "0", "latest"]}`)
+		_, err = web3svr.getStorageAt(&in)
+		require.NoError(err)
+	})
 }
 
 func TestNewfilter(t *testing.T) {
@@ -920,7 +1076,7 @@ func TestNewfilter(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, newAPICache(1*time.Second, ""), _defaultBatchRequestLimit}
+	web3svr := &web3Handler{coreService: core, cache: newAPICache(1*time.Second, "", 0), batchRequestLimit: _defaultBatchRequestLimit}
 
 	ret, err := web3svr.newFilter(&filterObject{
 		FromBlock: "1",
@@ -932,12 +1088,57 @@ func TestNewfilter(t *testing.T) {
 	require.Equal("0x6e86c450ba48d23a459b74581736ca033ed60ef2a3d5ae09c316f77f67d7fad7", ret.(string))
 }
 
+func TestInstallFilter(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := NewMockCoreService(ctrl)
+	web3svr := &web3Handler{coreService: core, cache: newAPICache(1*time.Second, "", 2), batchRequestLimit: _defaultBatchRequestLimit}
+	core.EXPECT().TipHeight().Return(uint64(100)).AnyTimes()
+
+	t.Run("reinstall keeps progress", func(t *testing.T) {
+		id, err := web3svr.newFilter(&filterObject{FromBlock: "0x1"})
+		require.NoError(err)
+		filterID := util.Remove0xPrefix(id.(string))
+		filterObj, err := loadFilterFromCache(web3svr.cache, filterID)
+		require.NoError(err)
+		filterObj.LogHeight = 10
+		objInByte, _ := json.Marshal(filterObj)
+		require.NoError(web3svr.cache.Set(filterID, objInByte))
+
+		id2, err := web3svr.newFilter(&filterObject{FromBlock: "0x1"})
+		require.NoError(err)
+		require.Equal(id, id2)
+		filterObj, err = loadFilterFromCache(web3svr.cache, filterID)
+		require.NoError(err)
+		require.Equal(uint64(10), filterObj.LogHeight)
+	})
+
+	t.Run("limit of filters", func(t *testing.T) {
+		_, err := web3svr.newFilter(&filterObject{FromBlock: "0x2"})
+		require.NoError(err)
+		_, err = web3svr.newFilter(&filterObject{FromBlock: "0x3"})
+		require.ErrorIs(err, errFilterLimit)
+	})
+
+	t.Run("uninstall frees the slot", func(t *testing.T) {
+		id, err := web3svr.newFilter(&filterObject{FromBlock: "0x2"})
+		require.NoError(err)
+		in := gjson.Parse(fmt.Sprintf(`{"params":["%s"]}`, id))
+		ret, err := web3svr.uninstallFilter(&in)
+		require.NoError(err)
+		require.True(ret.(bool))
+		_, err = web3svr.newFilter(&filterObject{FromBlock: "0x3"})
+		require.NoError(err)
+	})
+}
+
 func TestNewBlockFilter(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, newAPICache(1*time.Second, ""), _defaultBatchRequestLimit}
+	web3svr := &web3Handler{coreService: core, cache: newAPICache(1*time.Second, "", 0), batchRequestLimit: _defaultBatchRequestLimit}
 	core.EXPECT().TipHeight().Return(uint64(123))
 
 	ret, err := web3svr.newBlockFilter()
@@ -950,7 +1151,7 @@ func TestUninstallFilter(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, newAPICache(1*time.Second, ""), _defaultBatchRequestLimit}
+	web3svr := &web3Handler{coreService: core, cache: newAPICache(1*time.Second, "", 0), batchRequestLimit: _defaultBatchRequestLimit}
 
 	require.NoError(web3svr.cache.Set("123456789abc", []byte("test")))
 
@@ -973,7 +1174,7 @@ func TestGetFilterChanges(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, newAPICache(1*time.Second, ""), _defaultBatchRequestLimit}
+	web3svr := &web3Handler{coreService: core, cache: newAPICache(1*time.Second, "", 0), batchRequestLimit: _defaultBatchRequestLimit}
 	core.EXPECT().TipHeight().Return(uint64(0)).Times(3)
 
 	t.Run("log filterType", func(t *testing.T) {
@@ -1058,7 +1259,7 @@ func TestGetFilterLogs(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, newAPICache(1*time.Second, ""), _defaultBatchRequestLimit}
+	web3svr := &web3Handler{coreService: core, cache: newAPICache(1*time.Second, "", 0), batchRequestLimit: _defaultBatchRequestLimit}
 
 	logs := []*action.Log{
 		{
@@ -1114,7 +1315,7 @@ func TestSubscribe(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{coreService: core, batchRequestLimit: _defaultBatchRequestLimit}
 
 	listener := mock_apitypes.NewMockListener(ctrl)
 	listener.EXPECT().AddResponder(gomock.Any()).Return("streamid_1", nil).Times(3)
@@ -1160,12 +1361,41 @@ func TestSubscribe(t *testing.T) {
 	})
 }
 
+func TestSubscribePendingTransactions(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := NewMockCoreService(ctrl)
+	web3svr := &web3Handler{coreService: core, batchRequestLimit: _defaultBatchRequestLimit}
+
+	listener := mock_apitypes.NewMockListener(ctrl)
+	core.EXPECT().ChainListener().Return(listener).AnyTimes()
+	writer := mock_apitypes.NewMockWeb3ResponseWriter(ctrl)
+	sc, _ := StreamFromContext(WithStreamContext(context.Background(), WithListenerLimit(1)))
+
+	in := gjson.Parse(`{"params":["newPendingTransactions"]}`)
+	listener.EXPECT().AddResponder(gomock.Any()).Return("streamid_1", nil).Times(1)
+	ret, err := web3svr.subscribe(sc, &in, writer)
+	require.NoError(err)
+	require.Equal("streamid_1", ret.(string))
+	require.Equal([]string{"streamid_1"}, sc.ListenerIDs())
+
+	t.Run("subscription limit", func(t *testing.T) {
+		in := gjson.Parse(`{"params":["newPendingTransactions", true]}`)
+		listener.EXPECT().AddResponder(gomock.Any()).Return("streamid_2", nil).Times(1)
+		listener.EXPECT().RemoveResponder("streamid_2").Return(true, nil).Times(1)
+		_, err := web3svr.subscribe(sc, &in, writer)
+		require.ErrorIs(err, errSubscriptionLimitReached)
+		require.Equal([]string{"streamid_1"}, sc.ListenerIDs())
+	})
+}
+
 func TestUnsubscribe(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{coreService: core, batchRequestLimit: _defaultBatchRequestLimit}
 
 	listener := mock_apitypes.NewMockListener(ctrl)
 	listener.EXPECT().RemoveResponder(gomock.Any()).Return(true, nil)
@@ -1188,7 +1418,7 @@ func TestUnsubscribe(t *testing.T) {
 func TestLocalAPICache(t *testing.T) {
 	require := require.New(t)
 	testKey, testData := strconv.Itoa(rand.Int()), []byte(strconv.Itoa(rand.Int()))
-	cacheLocal := newAPICache(1*time.Second, "")
+	cacheLocal := newAPICache(1*time.Second, "", 0)
 	_, exist := cacheLocal.Get(testKey)
 	require.False(exist)
 	err := cacheLocal.Set(testKey, testData)
@@ -1205,7 +1435,7 @@ func TestDebugTraceTransaction(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{coreService: core, batchRequestLimit: _defaultBatchRequestLimit}
 
 	ctx := context.Background()
 	tsf, err := action.SignedExecution(identityset.Address(29).String(),
@@ -1239,12 +1469,89 @@ func TestDebugTraceTransaction(t *testing.T) {
 	})
 }
 
+func TestDebugTraceBlockByNumber(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := NewMockCoreService(ctrl)
+	web3svr := &web3Handler{coreService: core, batchRequestLimit: _defaultBatchRequestLimit}
+
+	ctx := context.Background()
+	exec, err := action.SignedExecution(identityset.Address(29).String(),
+		identityset.PrivateKey(29), 1, big.NewInt(0), testutil.TestGasLimit,
+		big.NewInt(testutil.TestGasPriceInt64), []byte{})
+	require.NoError(err)
+	execHash, err := exec.Hash()
+	require.NoError(err)
+	tsf, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(29), 2, big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsfHash, err := tsf.Hash()
+	require.NoError(err)
+	receipt := &action.Receipt{Status: 1, BlockHeight: 1, ActionHash: execHash, GasConsumed: 100000}
+
+	t.Run("nil params", func(t *testing.T) {
+		inNil := gjson.Parse(`{"params":[]}`)
+		_, err := web3svr.traceBlockByNumber(ctx, &inNil)
+		require.EqualError(err, errInvalidFormat.Error())
+	})
+
+	t.Run("block not found", func(t *testing.T) {
+		core.EXPECT().TraceBlock(ctx, uint64(2), gomock.Any()).Return(nil, ErrNotFound)
+		in := gjson.Parse(`{"params":["0x2"]}`)
+		_, err := web3svr.traceBlockByNumber(ctx, &in)
+		require.ErrorIs(err, errInvalidBlock)
+	})
+
+	t.Run("archive not supported", func(t *testing.T) {
+		core.EXPECT().TraceBlock(ctx, uint64(1), gomock.Any()).Return(nil, ErrArchiveNotSupported)
+		in := gjson.Parse(`{"params":["0x1"]}`)
+		_, err := web3svr.traceBlockByNumber(ctx, &in)
+		require.ErrorIs(err, ErrArchiveNotSupported)
+	})
+
+	t.Run("trace block", func(t *testing.T) {
+		core.EXPECT().TraceBlock(ctx, uint64(1), gomock.Any()).Return([]*ActionTrace{
+			{ActHash: execHash, Retval: []byte{0x01}, Receipt: receipt, Tracer: &logger.StructLogger{}},
+			{ActHash: tsfHash, Err: errUnsupportedAction},
+		}, nil)
+		in := gjson.Parse(`{"params":["0x1", {"timeout":"1s"}]}`)
+		ret, err := web3svr.traceBlockByNumber(ctx, &in)
+		require.NoError(err)
+		rlt, ok := ret.([]*debugTraceBlockResult)
+		require.True(ok)
+		require.Len(rlt, 2)
+		require.Equal("0x"+hex.EncodeToString(execHash[:]), rlt[0].TxHash)
+		require.Empty(rlt[0].Error)
+		res, ok := rlt[0].Result.(*debugTraceTransactionResult)
+		require.True(ok)
+		require.Equal("0x01", res.ReturnValue)
+		require.Equal("0x"+hex.EncodeToString(tsfHash[:]), rlt[1].TxHash)
+		require.Nil(rlt[1].Result)
+		require.Equal(errUnsupportedAction.Error(), rlt[1].Error)
+	})
+}
+
+func TestParseTraceConfig(t *testing.T) {
+	require := require.New(t)
+
+	cfg := parseTraceConfig(gjson.Parse(`{"enableMemory":true,"tracer":"callTracer","tracerConfig":{"onlyTopCall":true},"timeout":"10s"}`))
+	require.True(cfg.Config.EnableMemory)
+	require.Equal("callTracer", *cfg.Tracer)
+	require.JSONEq(`{"onlyTopCall":true}`, string(cfg.TracerConfig))
+	require.Equal("10s", *cfg.Timeout)
+
+	cfg = parseTraceConfig(gjson.Result{})
+	require.Nil(cfg.Tracer)
+	require.Nil(cfg.Timeout)
+	require.NotNil(cfg.Config)
+}
+
 func TestDebugTraceCall(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{coreService: core, batchRequestLimit: _defaultBatchRequestLimit}
 
 	ctx := context.Background()
 	tsf, err := action.SignedExecution(identityset.Address(29).String(),
@@ -1303,3 +1610,40 @@ func TestResponseIDMatchTypeWithRequest(t *testing.T) {
 		require.Contains(string(bodyBytes), tt.sub)
 	}
 }
+
+func TestUserOperationRPC(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := NewMockCoreService(ctrl)
+	web3svr := &web3Handler{coreService: core, batchRequestLimit: _defaultBatchRequestLimit}
+
+	core.EXPECT().UserOpPool().Return(nil)
+	_, err := web3svr.supportedEntryPoints()
+	require.ErrorIs(err, errUserOpPoolDisabled)
+
+	entryPoint := "0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789"
+	cfg := userop.DefaultConfig
+	cfg.Enabled = true
+	cfg.EntryPoints = []string{entryPoint}
+	pool, err := userop.NewPool(cfg, 4689, nil)
+	require.NoError(err)
+	core.EXPECT().UserOpPool().Return(pool).AnyTimes()
+	ret, err := web3svr.supportedEntryPoints()
+	require.NoError(err)
+	require.Equal([]string{entryPoint}, ret)
+
+	in := gjson.Parse(`{"params":["0x01"]}`)
+	_, err = web3svr.dumpUserOpMempool(&in)
+	require.ErrorIs(err, errInvalidFormat)
+	in = gjson.Parse(fmt.Sprintf(`{"params":["%s"]}`, entryPoint))
+	ret, err = web3svr.dumpUserOpMempool(&in)
+	require.NoError(err)
+	require.Empty(ret)
+	in = gjson.Parse(fmt.Sprintf(`{"params":[{"sender":"0x0000000000000000000000000000000000000001"},"%s"]}`, entryPoint))
+	_, err = web3svr.sendUserOperation(context.Background(), &in)
+	require.ErrorIs(err, errInvalidFormat)
+	ret, err = web3svr.dumpUserOpReputation()
+	require.NoError(err)
+	require.Empty(ret)
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+
+	"github.com/iotexproject/iotex-core/v2/actpool/userop"
+)
+
+// errUserOpPoolDisabled indicates the node does not serve the bundler rpc
+var errUserOpPoolDisabled = errors.New("user operation pool is disabled")
+
+func (svr *web3Handler) userOpPool() (*userop.Pool, error) {
+	pool := svr.coreService.UserOpPool()
+	if pool == nil {
+		return nil, errUserOpPoolDisabled
+	}
+	return pool, nil
+}
+
+func parseEntryPoint(in gjson.Result) (common.Address, error) {
+	if !in.Exists() || !common.IsHexAddress(in.String()) {
+		return common.Address{}, errInvalidFormat
+	}
+	return common.HexToAddress(in.String()), nil
+}
+
+func (svr *web3Handler) sendUserOperation(ctx context.Context, in *gjson.Result) (interface{}, error) {
+	pool, err := svr.userOpPool()
+	if err != nil {
+		return nil, err
+	}
+	opJSON := in.Get("params.0")
+	if !opJSON.Exists() {
+		return nil, errInvalidFormat
+	}
+	entryPoint, err := parseEntryPoint(in.Get("params.1"))
+	if err != nil {
+		return nil, err
+	}
+	op := &userop.UserOperation{}
+	if err := json.Unmarshal([]byte(opJSON.Raw), op); err != nil {
+		return nil, errors.Wrap(errInvalidFormat, err.Error())
+	}
+	h, err := pool.Add(ctx, op, entryPoint)
+	if err != nil {
+		return nil, err
+	}
+	return h.Hex(), nil
+}
+
+func (svr *web3Handler) supportedEntryPoints() (interface{}, error) {
+	pool, err := svr.userOpPool()
+	if err != nil {
+		return nil, err
+	}
+	entryPoints := pool.SupportedEntryPoints()
+	ret := make([]string, len(entryPoints))
+	for i, ep := range entryPoints {
+		ret[i] = ep.Hex()
+	}
+	return ret, nil
+}
+
+func (svr *web3Handler) dumpUserOpMempool(in *gjson.Result) (interface{}, error) {
+	pool, err := svr.userOpPool()
+	if err != nil {
+		return nil, err
+	}
+	entryPoint, err := parseEntryPoint(in.Get("params.0"))
+	if err != nil {
+		return nil, err
+	}
+	return pool.Pending(entryPoint), nil
+}
+
+func (svr *web3Handler) dumpUserOpReputation() (interface{}, error) {
+	pool, err := svr.userOpPool()
+	if err != nil {
+		return nil, err
+	}
+	return pool.Reputation(), nil
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"math/big"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -129,6 +130,24 @@ func (svr *web3Handler) assemblePendingTransaction(selp *action.SealedEnvelope)
 	return newGetTransactionResult(nil, selp, nil, svr.coreService.EVMNetworkID())
 }
 
+// receiptGasBreakdown returns the gas consumed by phase of the receipt. The breakdown isn't stored with the receipts,
+// for a receipt read from the db it is derived from the action, with the execution net of the refund
+func receiptGasBreakdown(selp *action.SealedEnvelope, receipt *action.Receipt) (*action.GasBreakdown, error) {
+	if gb := receipt.GasBreakdown(); gb != nil {
+		return gb, nil
+	}
+	intrinsic, err := selp.IntrinsicGas()
+	if err != nil {
+		return nil, err
+	}
+	intrinsic = min(intrinsic, receipt.GasConsumed)
+	return &action.GasBreakdown{
+		Intrinsic: intrinsic,
+		Execution: receipt.GasConsumed - intrinsic,
+		Sidecar:   receipt.BlobGasUsed,
+	}, nil
+}
+
 func getRecipientAndContractAddrFromAction(selp *action.SealedEnvelope, receipt *action.Receipt) (*string, *string, error) {
 	// recipient is empty when contract is created
 	if exec, ok := selp.Action().(*action.Execution); ok && len(exec.Contract()) == 0 {
@@ -138,7 +157,7 @@ func getRecipientAndContractAddrFromAction(selp *action.SealedEnvelope, receipt
 		}
 		return nil, &addr, nil
 	}
-	ethTx, err := selp.ToEthTx()
+	ethTx, err := toEthTx(selp)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -265,6 +284,10 @@ func parseLogRequest(in gjson.Result) (*filterObject, error) {
 		req := in.Array()[0]
 		logReq.FromBlock = req.Get("fromBlock").String()
 		logReq.ToBlock = req.Get("toBlock").String()
+		if cursor := req.Get("cursor"); cursor.Exists() {
+			str := cursor.String()
+			logReq.Cursor = &str
+		}
 		for _, addr := range req.Get("address").Array() {
 			logReq.Address = append(logReq.Address, addr.String())
 		}
@@ -504,7 +527,10 @@ func loadFilterFromCache(c apiCache, filterID string) (filterObject, error) {
 	return filterObj, nil
 }
 
-func newAPICache(expireTime time.Duration, remoteURL string) apiCache {
+// newAPICache creates the cache of filters, in redis if it is reachable at remoteURL so that filters
+// are shared by nodes behind a load balancer and survive node restarts, otherwise in local memory
+// bounded by limit
+func newAPICache(expireTime time.Duration, remoteURL string, limit int) apiCache {
 	redisClient := redis.NewClient(&redis.Options{
 		Addr:     remoteURL,
 		Password: "", // no password set
@@ -515,6 +541,7 @@ func newAPICache(expireTime time.Duration, remoteURL string) apiCache {
 		filterCache, _ := ttl.NewCache(ttl.AutoExpireOption(expireTime))
 		return &localCache{
 			ttlCache: filterCache,
+			limit:    limit,
 		}
 	}
 	log.L().Info("remote cache is used as API cache")
@@ -532,12 +559,21 @@ type apiCache interface {
 
 type localCache struct {
 	ttlCache *ttl.Cache
+	limit    int
+	mutex    sync.Mutex
 }
 
 func (c *localCache) Set(key string, data []byte) error {
 	if c.ttlCache == nil {
 		return errNullPointer
 	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.limit > 0 && c.ttlCache.Count() >= c.limit {
+		if _, exist := c.ttlCache.Get(key); !exist {
+			return errFilterLimit
+		}
+	}
 	c.ttlCache.Set(key, data)
 	return nil
 }
@@ -624,7 +660,7 @@ func newGetTransactionResult(
 	receipt *action.Receipt,
 	evmChainID uint32,
 ) (*getTransactionResult, error) {
-	ethTx, err := selp.ToEthTx()
+	ethTx, err := toEthTx(selp)
 	if err != nil {
 		return nil, err
 	}
@@ -152,7 +152,7 @@ func TestParseBlockNumber(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{coreService: core, batchRequestLimit: _defaultBatchRequestLimit}
 
 	t.Run("earliest block number", func(t *testing.T) {
 		num, _ := web3svr.parseBlockNumber("earliest")
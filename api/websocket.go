@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 	"golang.org/x/time/rate"
 
@@ -28,13 +30,48 @@ const (
 
 	// Maximum message size allowed from peer.
 	maxMessageSize = 15 * 1024 * 1024
+
+	// Default number of messages buffered for sending to peer.
+	_defaultSendQueueSize = 1024
 )
 
+var errSendQueueFull = errors.New("send queue of the connection is full")
+
 // WebsocketHandler handles requests from websocket protocol
 type WebsocketHandler struct {
-	coreService CoreService
-	msgHandler  Web3Handler
-	limiter     *rate.Limiter
+	coreService       CoreService
+	msgHandler        Web3Handler
+	limiter           *rate.Limiter
+	subscriptionLimit int
+	sendQueueSize     int
+	checkOrigin       func(*http.Request) bool
+}
+
+// WebsocketHandlerOption sets the websocket handler
+type WebsocketHandlerOption func(*WebsocketHandler)
+
+// WithSubscriptionLimit limits the number of subscriptions per connection, 0 means no limit
+func WithSubscriptionLimit(limit int) WebsocketHandlerOption {
+	return func(wsSvr *WebsocketHandler) {
+		wsSvr.subscriptionLimit = limit
+	}
+}
+
+// WithCheckOrigin sets the check of the origin of websocket requests, which allows any origin by default
+func WithCheckOrigin(checkOrigin func(*http.Request) bool) WebsocketHandlerOption {
+	return func(wsSvr *WebsocketHandler) {
+		wsSvr.checkOrigin = checkOrigin
+	}
+}
+
+// WithSendQueueSize sets the number of messages buffered for sending per connection,
+// a connection falling behind by more subscription messages is closed
+func WithSendQueueSize(size int) WebsocketHandlerOption {
+	return func(wsSvr *WebsocketHandler) {
+		if size > 0 {
+			wsSvr.sendQueueSize = size
+		}
+	}
 }
 
 var upgrader = websocket.Upgrader{
@@ -76,24 +113,88 @@ func (c *safeWebsocketConn) SetWriteDeadline(t time.Time) error {
 	return c.ws.SetWriteDeadline(t)
 }
 
+// sendQueue sends messages to the connection in order. Responses wait for room in the
+// queue, while subscription messages are rejected and the connection is closed if the
+// queue is full, so that a slow peer cannot block the notification of other peers
+type sendQueue struct {
+	ws     *safeWebsocketConn
+	queue  chan interface{}
+	cancel context.CancelFunc
+}
+
+func newSendQueue(ws *safeWebsocketConn, size int, cancel context.CancelFunc) *sendQueue {
+	return &sendQueue{
+		ws:     ws,
+		queue:  make(chan interface{}, size),
+		cancel: cancel,
+	}
+}
+
+func (q *sendQueue) write(ctx context.Context, msg interface{}) (int, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+	if _, ok := msg.(*streamResponse); !ok {
+		select {
+		case q.queue <- msg:
+			return 0, nil
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}
+	select {
+	case q.queue <- msg:
+		return 0, nil
+	default:
+		_web3ServerMtc.WithLabelValues("websocket_send_queue_full").Inc()
+		q.cancel()
+		return 0, errSendQueueFull
+	}
+}
+
+func (q *sendQueue) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg := <-q.queue:
+			if err := q.ws.SetWriteDeadline(time.Now().Add(writeWait)); err != nil {
+				log.Logger("api").Warn("failed to set write deadline timeout.", zap.Error(err))
+			}
+			if err := q.ws.WriteJSON(msg); err != nil {
+				log.Logger("api").Warn("fail to write message.", zap.Error(err))
+				q.cancel()
+				return
+			}
+		}
+	}
+}
+
 // NewWebsocketHandler creates a new websocket handler
-func NewWebsocketHandler(coreService CoreService, web3Handler Web3Handler, limiter *rate.Limiter) *WebsocketHandler {
+func NewWebsocketHandler(coreService CoreService, web3Handler Web3Handler, limiter *rate.Limiter, opts ...WebsocketHandlerOption) *WebsocketHandler {
 	if limiter == nil {
 		// set the limiter to the maximum possible rate
 		limiter = rate.NewLimiter(rate.Limit(math.MaxFloat64), 1)
 	}
-	return &WebsocketHandler{
-		msgHandler:  web3Handler,
-		limiter:     limiter,
-		coreService: coreService,
+	wsSvr := &WebsocketHandler{
+		msgHandler:    web3Handler,
+		limiter:       limiter,
+		coreService:   coreService,
+		sendQueueSize: _defaultSendQueueSize,
+		checkOrigin:   func(_ *http.Request) bool { return true },
+	}
+	for _, opt := range opts {
+		opt(wsSvr)
 	}
+	return wsSvr
 }
 
 func (wsSvr *WebsocketHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
-	upgrader.CheckOrigin = func(_ *http.Request) bool { return true }
+	wsUpgrader := upgrader
+	wsUpgrader.CheckOrigin = wsSvr.checkOrigin
 
 	// upgrade this connection to a WebSocket connection
-	ws, err := upgrader.Upgrade(w, req, nil)
+	ws, err := wsUpgrader.Upgrade(w, req, nil)
 	if err != nil {
 		log.Logger("api").Warn("failed to upgrade http server to websocket", zap.Error(err))
 		return
@@ -115,9 +216,11 @@ func (wsSvr *WebsocketHandler) handleConnection(ctx context.Context, ws *websock
 		return nil
 	})
 
-	ctx, cancel := context.WithCancel(WithStreamContext(ctx))
+	ctx, cancel := context.WithCancel(WithStreamContext(ctx, WithListenerLimit(wsSvr.subscriptionLimit)))
 	safeWs := &safeWebsocketConn{ws: ws}
+	sender := newSendQueue(safeWs, wsSvr.sendQueueSize, cancel)
 	go ping(ctx, safeWs, cancel)
+	go sender.run(ctx)
 
 	defer func() {
 		// clean up the stream context
@@ -145,13 +248,11 @@ func (wsSvr *WebsocketHandler) handleConnection(ctx context.Context, ws *websock
 			func() {
 				wsCtx, span := tracer.NewSpan(ctx, "wss")
 				defer span.End()
+				span.SetAttributes(attribute.String("api.client", ws.RemoteAddr().String()))
 				err = wsSvr.msgHandler.HandlePOSTReq(wsCtx, reader,
 					apitypes.NewResponseWriter(
 						func(resp interface{}) (int, error) {
-							if err = safeWs.SetWriteDeadline(time.Now().Add(writeWait)); err != nil {
-								log.T(wsCtx).Warn("failed to set write deadline timeout.", zap.Error(err))
-							}
-							return 0, safeWs.WriteJSON(resp)
+							return sender.write(ctx, resp)
 						}),
 				)
 				if err != nil {
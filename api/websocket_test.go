@@ -0,0 +1,27 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendQueue(t *testing.T) {
+	require := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	q := newSendQueue(nil, 2, cancel)
+
+	_, err := q.write(ctx, &web3Response{id: 1})
+	require.NoError(err)
+	_, err = q.write(ctx, &streamResponse{id: "0x1"})
+	require.NoError(err)
+	require.NoError(ctx.Err())
+	// a slow connection is closed instead of blocking the subscription
+	_, err = q.write(ctx, &streamResponse{id: "0x1"})
+	require.ErrorIs(err, errSendQueueFull)
+	require.Error(ctx.Err())
+	_, err = q.write(ctx, &web3Response{id: 2})
+	require.ErrorIs(err, context.Canceled)
+}
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"context"
+	"encoding/hex"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/go-pkgs/util"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+	"github.com/iotexproject/iotex-core/v2/state/factory"
+)
+
+// WorkingSetCacheEntry is a working set in the cache of the state factory
+type WorkingSetCacheEntry struct {
+	// Hash is the hash of the block of the working set, empty for the one prewarmed for the next height
+	Hash      string `json:"hash"`
+	Height    uint64 `json:"height"`
+	Pinned    bool   `json:"pinned"`
+	Prewarmed bool   `json:"prewarmed"`
+	// Writes and Bytes are the number and the size of the writes buffered by the working set
+	Writes int    `json:"writes"`
+	Bytes  uint64 `json:"bytes"`
+}
+
+func (core *coreService) workingSetCacheManager() (factory.WorkingSetCacheManager, error) {
+	m, ok := core.sf.(factory.WorkingSetCacheManager)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "state factory does not support managing working set cache")
+	}
+	return m, nil
+}
+
+// WorkingSetCache returns the working sets in the cache of the state factory in the ascending order of heights
+func (core *coreService) WorkingSetCache() ([]*WorkingSetCacheEntry, error) {
+	m, err := core.workingSetCacheManager()
+	if err != nil {
+		return nil, err
+	}
+	entries := m.WorkingSetCacheEntries()
+	ret := make([]*WorkingSetCacheEntry, len(entries))
+	for i, e := range entries {
+		ret[i] = &WorkingSetCacheEntry{
+			Height:    e.Height,
+			Pinned:    e.Pinned,
+			Prewarmed: e.Prewarmed,
+			Writes:    e.Writes,
+			Bytes:     e.Bytes,
+		}
+		if !e.Prewarmed {
+			ret[i].Hash = hex.EncodeToString(e.Hash[:])
+		}
+	}
+	return ret, nil
+}
+
+// PinWorkingSet keeps the working set of the block in the cache until it is unpinned or the block height is committed
+func (core *coreService) PinWorkingSet(blkHash hash.Hash256) error {
+	m, err := core.workingSetCacheManager()
+	if err != nil {
+		return err
+	}
+	if err := m.PinWorkingSet(blkHash); err != nil {
+		switch errors.Cause(err) {
+		case factory.ErrWorkingSetNotCached:
+			return status.Error(codes.NotFound, err.Error())
+		case factory.ErrTooManyPinnedWorkingSets:
+			return status.Error(codes.ResourceExhausted, err.Error())
+		default:
+			return status.Error(codes.Internal, err.Error())
+		}
+	}
+	log.L().Info("working set is pinned by admin.", log.Hex("hash", blkHash[:]))
+	return nil
+}
+
+// UnpinWorkingSet returns the working set of the block to the lru cache
+func (core *coreService) UnpinWorkingSet(blkHash hash.Hash256) error {
+	m, err := core.workingSetCacheManager()
+	if err != nil {
+		return err
+	}
+	if !m.UnpinWorkingSet(blkHash) {
+		return status.Errorf(codes.NotFound, "working set of block %x is not pinned", blkHash[:])
+	}
+	log.L().Info("working set is unpinned by admin.", log.Hex("hash", blkHash[:]))
+	return nil
+}
+
+// PrewarmWorkingSet creates the working set of the next height ahead of validating or minting the block
+func (core *coreService) PrewarmWorkingSet(ctx context.Context) error {
+	m, err := core.workingSetCacheManager()
+	if err != nil {
+		return err
+	}
+	ctx, err = core.bc.Context(ctx)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	if err := m.PrewarmWorkingSet(ctx); err != nil {
+		log.L().Error("failed to prewarm working set.", zap.Error(err))
+		return status.Error(codes.Internal, err.Error())
+	}
+	return nil
+}
+
+func (svr *web3Handler) handleWorkingSetCacheReq(ctx context.Context, method string, in *gjson.Result) (interface{}, error) {
+	switch method {
+	case "admin_workingSetCache":
+		return svr.coreService.WorkingSetCache()
+	case "admin_prewarmWorkingSet":
+		if err := svr.coreService.PrewarmWorkingSet(ctx); err != nil {
+			return nil, err
+		}
+		return true, nil
+	}
+	blkHash, err := hash.HexStringToHash256(util.Remove0xPrefix(in.Get("params.0").String()))
+	if err != nil {
+		return nil, errors.Wrapf(errUnkownType, "hash: %s", in.Get("params.0").String())
+	}
+	if method == "admin_pinWorkingSet" {
+		err = svr.coreService.PinWorkingSet(blkHash)
+	} else {
+		err = svr.coreService.UnpinWorkingSet(blkHash)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return true, nil
+}
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/state/factory"
+	"github.com/iotexproject/iotex-core/v2/test/mock/mock_blockchain"
+	"github.com/iotexproject/iotex-core/v2/test/mock/mock_factory"
+)
+
+func TestCoreService_WorkingSetCache(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	core := &coreService{sf: mock_factory.NewMockFactory(ctrl)}
+	_, err := core.WorkingSetCache()
+	require.Equal(codes.Unimplemented, status.Code(err))
+
+	sf, err := factory.NewStateDB(factory.DefaultConfig, db.NewMemKVStore())
+	require.NoError(err)
+	ctx := genesis.WithGenesisContext(context.Background(), genesis.TestDefault())
+	require.NoError(sf.Start(ctx))
+	defer func() {
+		require.NoError(sf.Stop(ctx))
+	}()
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	bc.EXPECT().Context(gomock.Any()).Return(ctx, nil).Times(1)
+	core = &coreService{sf: sf, bc: bc}
+
+	entries, err := core.WorkingSetCache()
+	require.NoError(err)
+	require.Empty(entries)
+	require.Equal(codes.NotFound, status.Code(core.PinWorkingSet(hash.ZeroHash256)))
+	require.Equal(codes.NotFound, status.Code(core.UnpinWorkingSet(hash.ZeroHash256)))
+
+	require.NoError(core.PrewarmWorkingSet(context.Background()))
+	entries, err = core.WorkingSetCache()
+	require.NoError(err)
+	require.Equal([]*WorkingSetCacheEntry{{Height: 1, Prewarmed: true}}, entries)
+}
+
+func TestHandleWorkingSetCacheReq(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	core := NewMockCoreService(ctrl)
+	web3svr := &web3Handler{coreService: core, batchRequestLimit: _defaultBatchRequestLimit}
+	ctx := context.WithValue(context.Background(), adminContextKey{}, struct{}{})
+
+	in := gjson.Parse(`{"params":[]}`)
+	_, err := web3svr.handleAdminReq(context.Background(), "admin_workingSetCache", &in)
+	require.ErrorIs(err, errAdminNotAuthorized)
+	entries := []*WorkingSetCacheEntry{{Hash: "01", Height: 5, Pinned: true, Writes: 2, Bytes: 64}}
+	core.EXPECT().WorkingSetCache().Return(entries, nil).Times(1)
+	ret, err := web3svr.handleAdminReq(ctx, "admin_workingSetCache", &in)
+	require.NoError(err)
+	require.Equal(entries, ret)
+	core.EXPECT().PrewarmWorkingSet(gomock.Any()).Return(nil).Times(1)
+	ret, err = web3svr.handleAdminReq(ctx, "admin_prewarmWorkingSet", &in)
+	require.NoError(err)
+	require.True(ret.(bool))
+
+	blkHash := hash.Hash256b([]byte("block"))
+	in = gjson.Parse(`{"params":["0x` + hex.EncodeToString(blkHash[:]) + `"]}`)
+	core.EXPECT().PinWorkingSet(blkHash).Return(nil).Times(1)
+	ret, err = web3svr.handleAdminReq(ctx, "admin_pinWorkingSet", &in)
+	require.NoError(err)
+	require.True(ret.(bool))
+	core.EXPECT().UnpinWorkingSet(blkHash).Return(status.Error(codes.NotFound, "not pinned")).Times(1)
+	_, err = web3svr.handleAdminReq(ctx, "admin_unpinWorkingSet", &in)
+	require.Equal(codes.NotFound, status.Code(err))
+	in = gjson.Parse(`{"params":["block"]}`)
+	_, err = web3svr.handleAdminReq(ctx, "admin_pinWorkingSet", &in)
+	require.ErrorIs(err, errUnkownType)
+}
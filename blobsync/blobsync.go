@@ -0,0 +1,476 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+// Package blobsync announces, requests and serves the blob sidecars by the versioned hashes over p2p, decoupled from
+// the block bodies. The nodes storing the blobs serve the recent ones within the retention window, and the nodes
+// receiving the blocks without the sidecars, e.g., synced from the peers which have pruned them, fetch the missing
+// sidecars.
+//
+// The sidecars are only accepted if they are requested, and each sidecar is verified against the versioned hashes of
+// its transaction in the block committed locally and the kzg proofs before it is stored.
+package blobsync
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/blobsync/blobsyncpb"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+// Protocol is the name of the p2p protocol of the blob sync
+const Protocol = "blobsync"
+
+type (
+	// Neighbors acquires the peers to fetch the sidecars from
+	Neighbors func() ([]peer.AddrInfo, error)
+	// UnicastOutbound sends the message of the blob sync protocol to the peer
+	UnicastOutbound func(context.Context, peer.AddrInfo, []byte) error
+	// BlobHashes returns the versioned blob hashes of the blob transactions in the block committed at the height,
+	// keyed by the action hash
+	BlobHashes func(uint64) (map[hash.Hash256][]common.Hash, error)
+	// PeerReporter reports the peer serving a sidecar failing the verification
+	PeerReporter func(string)
+
+	// Store stores the sidecars within the retention window
+	Store interface {
+		GetBlobByVersionedHash(common.Hash) (uint64, *types.BlobTxSidecar, string, error)
+		PutBlobs(uint64, []*types.BlobTxSidecar, []hash.Hash256) error
+	}
+
+	// Helper is the helper of the blob sync
+	Helper struct {
+		P2PNeighbor     Neighbors
+		UnicastOutbound UnicastOutbound
+		BlobHashes      BlobHashes
+		ReportPeer      PeerReporter
+	}
+
+	// BlobSync announces the blobs of the blocks committed, serves them to the peers, and fetches the missing ones
+	BlobSync struct {
+		cfg       Config
+		retention time.Duration
+		store     Store
+		helper    *Helper
+
+		mu      sync.Mutex
+		pending map[common.Hash]*pendingBlob
+	}
+
+	// pendingBlob is the blob requested, done is closed once it is stored
+	pendingBlob struct {
+		height uint64
+		done   chan struct{}
+	}
+)
+
+var _blobSyncCounter = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "iotex_blobsync_counter",
+		Help: "blob sidecars announced, served and fetched",
+	},
+	[]string{"type"},
+)
+
+func init() {
+	prometheus.MustRegister(_blobSyncCounter)
+}
+
+// NewBlobSync creates the blob sync, the blobs of the blocks older than the retention are neither announced nor
+// fetched
+func NewBlobSync(cfg Config, retention time.Duration, store Store, helper *Helper) *BlobSync {
+	return &BlobSync{
+		cfg:       cfg,
+		retention: retention,
+		store:     store,
+		helper:    helper,
+		pending:   map[common.Hash]*pendingBlob{},
+	}
+}
+
+// Start starts the blob sync
+func (bs *BlobSync) Start(_ context.Context) error {
+	return nil
+}
+
+// Stop stops the blob sync
+func (bs *BlobSync) Stop(_ context.Context) error {
+	return nil
+}
+
+// ReceiveBlock announces the blobs of the block committed, and fetches the sidecars missing from the block in the
+// background
+func (bs *BlobSync) ReceiveBlock(blk *block.Block) error {
+	if time.Since(blk.Timestamp()) > bs.retention {
+		return nil
+	}
+	var stored, missing []common.Hash
+	for _, act := range blk.Actions {
+		hashes := act.BlobHashes()
+		if len(hashes) == 0 {
+			continue
+		}
+		if act.BlobTxSidecar() != nil {
+			stored = append(stored, hashes...)
+		} else {
+			missing = append(missing, hashes...)
+		}
+	}
+	height := blk.Height()
+	if bs.cfg.Announce && len(stored) > 0 {
+		go bs.announce(height, stored)
+	}
+	if len(missing) > 0 {
+		go func() {
+			if err := bs.Fetch(context.Background(), height, missing); err != nil {
+				log.L().Warn("failed to fetch blob sidecars", zap.Uint64("height", height), zap.Error(err))
+			}
+		}()
+	}
+	return nil
+}
+
+// Fetch fetches the sidecars of the versioned hashes in the block of the height from the peers one after another,
+// until all of them are stored
+func (bs *BlobSync) Fetch(ctx context.Context, height uint64, hashes []common.Hash) error {
+	peers, err := bs.helper.P2PNeighbor()
+	if err != nil {
+		return err
+	}
+	return bs.fetch(ctx, height, hashes, peers)
+}
+
+func (bs *BlobSync) fetch(ctx context.Context, height uint64, hashes []common.Hash, peers []peer.AddrInfo) error {
+	waits, release := bs.request(height, hashes)
+	defer release()
+	for _, p := range peers {
+		remaining := unresolved(waits)
+		if len(remaining) == 0 {
+			return nil
+		}
+		for i := 0; i < len(remaining); i += bs.cfg.MaxBlobs {
+			batch := remaining[i:min(i+bs.cfg.MaxBlobs, len(remaining))]
+			req := &blobsyncpb.BlobRequest{BlobHashes: make([][]byte, len(batch))}
+			for j, h := range batch {
+				req.BlobHashes[j] = h.Bytes()
+			}
+			bs.send(ctx, p, &blobsyncpb.Message{Msg: &blobsyncpb.Message_Request{Request: req}})
+		}
+		if err := wait(ctx, waits, bs.cfg.RequestTimeout); err != nil {
+			return err
+		}
+	}
+	if remaining := unresolved(waits); len(remaining) > 0 {
+		return errors.Errorf("failed to fetch %d blobs out of %d", len(remaining), len(hashes))
+	}
+	return nil
+}
+
+// request marks the blobs not stored as pending, and returns the blobs to wait for and the function releasing the
+// blobs marked
+func (bs *BlobSync) request(height uint64, hashes []common.Hash) (map[common.Hash]chan struct{}, func()) {
+	var (
+		waits = make(map[common.Hash]chan struct{}, len(hashes))
+		added []common.Hash
+	)
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	for _, h := range hashes {
+		if p, ok := bs.pending[h]; ok {
+			waits[h] = p.done
+			continue
+		}
+		if _, _, _, err := bs.store.GetBlobByVersionedHash(h); err == nil {
+			continue
+		}
+		p := &pendingBlob{height: height, done: make(chan struct{})}
+		bs.pending[h] = p
+		waits[h] = p.done
+		added = append(added, h)
+	}
+	return waits, func() {
+		bs.mu.Lock()
+		defer bs.mu.Unlock()
+		for _, h := range added {
+			if p, ok := bs.pending[h]; ok && p.done == waits[h] {
+				delete(bs.pending, h)
+			}
+		}
+	}
+}
+
+// HandleMessage handles the message of the blob sync protocol from the peer. An error is returned only if the
+// message is malformed
+func (bs *BlobSync) HandleMessage(ctx context.Context, from peer.AddrInfo, data []byte) error {
+	msg := &blobsyncpb.Message{}
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return errors.Wrap(err, "failed to parse blob sync message")
+	}
+	switch {
+	case msg.GetAnnounce() != nil:
+		return bs.handleAnnounce(from, msg.GetAnnounce())
+	case msg.GetRequest() != nil:
+		return bs.handleRequest(ctx, from, msg.GetRequest())
+	case msg.GetSidecars() != nil:
+		return bs.handleSidecars(from, msg.GetSidecars())
+	default:
+		return errors.New("unknown blob sync message")
+	}
+}
+
+// handleAnnounce fetches the blobs announced which are in the block committed locally but not stored
+func (bs *BlobSync) handleAnnounce(from peer.AddrInfo, ann *blobsyncpb.BlobAnnounce) error {
+	hashes, err := toHashes(ann.GetBlobHashes())
+	if err != nil {
+		return err
+	}
+	blobHashes, err := bs.helper.BlobHashes(ann.GetHeight())
+	if err != nil {
+		// the block is not committed yet
+		return nil
+	}
+	inBlock := map[common.Hash]struct{}{}
+	for _, hs := range blobHashes {
+		for _, h := range hs {
+			inBlock[h] = struct{}{}
+		}
+	}
+	var wanted []common.Hash
+	for _, h := range hashes {
+		if _, ok := inBlock[h]; !ok {
+			continue
+		}
+		if _, _, _, err := bs.store.GetBlobByVersionedHash(h); err == nil {
+			continue
+		}
+		wanted = append(wanted, h)
+	}
+	if len(wanted) == 0 {
+		return nil
+	}
+	_blobSyncCounter.WithLabelValues("announced").Inc()
+	go func() {
+		if err := bs.fetch(context.Background(), ann.GetHeight(), wanted, []peer.AddrInfo{from}); err != nil {
+			log.L().Debug("failed to fetch blobs announced", zap.String("peer", from.ID.String()), zap.Error(err))
+		}
+	}()
+	return nil
+}
+
+// handleRequest serves the sidecars of the blobs stored, grouped by the transactions
+func (bs *BlobSync) handleRequest(ctx context.Context, from peer.AddrInfo, req *blobsyncpb.BlobRequest) error {
+	hashes, err := toHashes(req.GetBlobHashes())
+	if err != nil {
+		return err
+	}
+	var (
+		resp   = &blobsyncpb.BlobSidecars{}
+		served = map[string]struct{}{}
+		blobs  int
+	)
+	for _, h := range hashes {
+		if blobs >= bs.cfg.MaxBlobs {
+			break
+		}
+		height, sidecar, txHash, err := bs.store.GetBlobByVersionedHash(h)
+		if err != nil {
+			continue
+		}
+		if _, ok := served[txHash]; ok {
+			continue
+		}
+		served[txHash] = struct{}{}
+		blobs += len(sidecar.Blobs)
+		resp.Sidecars = append(resp.Sidecars, &blobsyncpb.BlobSidecar{
+			Height:  height,
+			TxHash:  common.FromHex(txHash),
+			Sidecar: action.ToProtoSideCar(sidecar),
+		})
+	}
+	if len(resp.Sidecars) == 0 {
+		return nil
+	}
+	_blobSyncCounter.WithLabelValues("serve").Add(float64(blobs))
+	bs.send(ctx, from, &blobsyncpb.Message{Msg: &blobsyncpb.Message_Sidecars{Sidecars: resp}})
+	return nil
+}
+
+// handleSidecars verifies the sidecars requested, and stores them
+func (bs *BlobSync) handleSidecars(from peer.AddrInfo, msg *blobsyncpb.BlobSidecars) error {
+	type blobs struct {
+		sidecars []*types.BlobTxSidecar
+		txHashes []hash.Hash256
+		hashes   []common.Hash
+	}
+	var (
+		byHeight   = map[uint64]*blobs{}
+		blockBlobs = map[uint64]map[hash.Hash256][]common.Hash{}
+	)
+	for _, sc := range msg.GetSidecars() {
+		height := sc.GetHeight()
+		if _, ok := blockBlobs[height]; !ok {
+			blobHashes, err := bs.helper.BlobHashes(height)
+			if err != nil {
+				log.L().Debug("failed to get blob hashes", zap.Uint64("height", height), zap.Error(err))
+			}
+			blockBlobs[height] = blobHashes
+		}
+		txHash := hash.BytesToHash256(sc.GetTxHash())
+		expected, ok := blockBlobs[height][txHash]
+		if !ok || !bs.isPending(height, expected) {
+			// the sidecar isn't requested
+			continue
+		}
+		sidecar, err := action.FromProtoBlobTxSideCar(sc.GetSidecar())
+		if err == nil {
+			err = action.VerifySidecar(sidecar, expected)
+		}
+		if err != nil {
+			log.L().Warn("invalid blob sidecar", zap.String("peer", from.ID.String()), zap.Error(err))
+			bs.reportPeer(from.ID)
+			continue
+		}
+		b, ok := byHeight[height]
+		if !ok {
+			b = &blobs{}
+			byHeight[height] = b
+		}
+		b.sidecars = append(b.sidecars, sidecar)
+		b.txHashes = append(b.txHashes, txHash)
+		b.hashes = append(b.hashes, expected...)
+	}
+	for height, b := range byHeight {
+		if err := bs.store.PutBlobs(height, b.sidecars, b.txHashes); err != nil {
+			log.L().Warn("failed to store blob sidecars", zap.Uint64("height", height), zap.Error(err))
+			continue
+		}
+		_blobSyncCounter.WithLabelValues("fetch").Add(float64(len(b.hashes)))
+		bs.resolve(b.hashes)
+	}
+	return nil
+}
+
+func (bs *BlobSync) isPending(height uint64, hashes []common.Hash) bool {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	for _, h := range hashes {
+		if p, ok := bs.pending[h]; ok && p.height == height {
+			return true
+		}
+	}
+	return false
+}
+
+func (bs *BlobSync) resolve(hashes []common.Hash) {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	for _, h := range hashes {
+		if p, ok := bs.pending[h]; ok {
+			close(p.done)
+			delete(bs.pending, h)
+		}
+	}
+}
+
+func (bs *BlobSync) announce(height uint64, hashes []common.Hash) {
+	peers, err := bs.helper.P2PNeighbor()
+	if err != nil {
+		log.L().Debug("failed to get neighbors", zap.Error(err))
+		return
+	}
+	ann := &blobsyncpb.BlobAnnounce{Height: height, BlobHashes: make([][]byte, len(hashes))}
+	for i, h := range hashes {
+		ann.BlobHashes[i] = h.Bytes()
+	}
+	msg := &blobsyncpb.Message{Msg: &blobsyncpb.Message_Announce{Announce: ann}}
+	for _, p := range peers {
+		bs.send(context.Background(), p, msg)
+	}
+}
+
+func (bs *BlobSync) send(ctx context.Context, p peer.AddrInfo, msg *blobsyncpb.Message) {
+	data, err := proto.Marshal(msg)
+	if err == nil {
+		err = bs.helper.UnicastOutbound(ctx, p, data)
+	}
+	if err != nil {
+		log.L().Debug("failed to send blob sync message", zap.String("peer", p.ID.String()), zap.Error(err))
+	}
+}
+
+func (bs *BlobSync) reportPeer(id peer.ID) {
+	if bs.helper.ReportPeer != nil {
+		bs.helper.ReportPeer(id.String())
+	}
+}
+
+// BlockBlobHashes returns the versioned blob hashes of the blob transactions in the block, keyed by the action hash
+func BlockBlobHashes(blk *block.Block) (map[hash.Hash256][]common.Hash, error) {
+	blobHashes := map[hash.Hash256][]common.Hash{}
+	for _, act := range blk.Actions {
+		hashes := act.BlobHashes()
+		if len(hashes) == 0 {
+			continue
+		}
+		h, err := act.Hash()
+		if err != nil {
+			return nil, err
+		}
+		blobHashes[h] = hashes
+	}
+	return blobHashes, nil
+}
+
+func toHashes(b [][]byte) ([]common.Hash, error) {
+	hashes := make([]common.Hash, len(b))
+	for i := range b {
+		if len(b[i]) != common.HashLength {
+			return nil, errors.Errorf("invalid versioned hash %x", b[i])
+		}
+		hashes[i] = common.BytesToHash(b[i])
+	}
+	return hashes, nil
+}
+
+// unresolved returns the blobs not stored yet
+func unresolved(waits map[common.Hash]chan struct{}) []common.Hash {
+	var hashes []common.Hash
+	for h, done := range waits {
+		select {
+		case <-done:
+		default:
+			hashes = append(hashes, h)
+		}
+	}
+	return hashes
+}
+
+// wait waits until all the blobs are stored or the timeout
+func wait(ctx context.Context, waits map[common.Hash]chan struct{}, timeout time.Duration) error {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for _, done := range waits {
+		select {
+		case <-done:
+		case <-timer.C:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,302 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blobsync
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/blobsync/blobsyncpb"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/db"
+)
+
+var _testPeerIDs = []string{
+	"12D3KooWJwW6pUpTkxPTMv84RPLPMQVEAjZ6fvJuX4oZrvW5DAGQ",
+	"12D3KooWHt2yPeWLgWmCVe5xWbCJCCDfQYVaNkfJvrAaJqqbjcoe",
+	"12D3KooWL2mUXJjLqz7h4bbQ3LeZdmV2Ta4xsG1xm8CLTKYBZyvj",
+}
+
+type testBlob struct {
+	height  uint64
+	sidecar *types.BlobTxSidecar
+	txHash  hash.Hash256
+}
+
+// testStore stores the blobs in memory, the sidecars are swapped if it is corrupted
+type testStore struct {
+	mu        sync.Mutex
+	blobs     map[common.Hash]testBlob
+	corrupted bool
+}
+
+func newTestStore() *testStore {
+	return &testStore{blobs: map[common.Hash]testBlob{}}
+}
+
+func (s *testStore) GetBlobByVersionedHash(h common.Hash) (uint64, *types.BlobTxSidecar, string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, ok := s.blobs[h]
+	if !ok {
+		return 0, nil, "", db.ErrNotExist
+	}
+	if s.corrupted {
+		for _, other := range s.blobs {
+			if other.txHash != b.txHash {
+				b.sidecar = other.sidecar
+				break
+			}
+		}
+	}
+	return b.height, b.sidecar, "0x" + common.Bytes2Hex(b.txHash[:]), nil
+}
+
+func (s *testStore) PutBlobs(height uint64, sidecars []*types.BlobTxSidecar, txHashes []hash.Hash256) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, sc := range sidecars {
+		for _, h := range sc.BlobHashes() {
+			s.blobs[h] = testBlob{height: height, sidecar: sc, txHash: txHashes[i]}
+		}
+	}
+	return nil
+}
+
+func (s *testStore) len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.blobs)
+}
+
+// testNetwork delivers the messages between the blob syncs synchronously
+type testNetwork struct {
+	mu    sync.Mutex
+	nodes map[peer.ID]*BlobSync
+}
+
+func (n *testNetwork) add(t *testing.T, i int, cfg Config, store Store, helper *Helper) (*BlobSync, peer.AddrInfo) {
+	id, err := peer.Decode(_testPeerIDs[i])
+	require.NoError(t, err)
+	self := peer.AddrInfo{ID: id}
+	helper.UnicastOutbound = func(ctx context.Context, to peer.AddrInfo, data []byte) error {
+		n.mu.Lock()
+		node, ok := n.nodes[to.ID]
+		n.mu.Unlock()
+		if !ok {
+			return errors.New("peer not found")
+		}
+		return node.HandleMessage(ctx, self, data)
+	}
+	bs := NewBlobSync(cfg, time.Hour, store, helper)
+	n.mu.Lock()
+	n.nodes[id] = bs
+	n.mu.Unlock()
+	return bs, self
+}
+
+func testBlocks(t *testing.T) ([]*block.Block, []*block.Block, BlobHashes) {
+	blks, err := block.CreateTestBlockWithBlob(1, 2)
+	require.NoError(t, err)
+	stripped := make([]*block.Block, len(blks))
+	for i, blk := range blks {
+		stripped[i], err = block.NewDeserializer(0).BlockFromBlockStoreProto((&block.Store{Block: blk}).ToProtoWithoutSidecar())
+		require.NoError(t, err)
+		require.False(t, stripped[i].HasBlob())
+	}
+	return blks, stripped, func(height uint64) (map[hash.Hash256][]common.Hash, error) {
+		if height == 0 || height > uint64(len(blks)) {
+			return nil, db.ErrNotExist
+		}
+		return BlockBlobHashes(blks[height-1])
+	}
+}
+
+func storeBlocks(t *testing.T, store Store, blks []*block.Block) {
+	for _, blk := range blks {
+		var (
+			sidecars []*types.BlobTxSidecar
+			txHashes []hash.Hash256
+		)
+		for _, act := range blk.Actions {
+			if sc := act.BlobTxSidecar(); sc != nil {
+				h, err := act.Hash()
+				require.NoError(t, err)
+				sidecars = append(sidecars, sc)
+				txHashes = append(txHashes, h)
+			}
+		}
+		require.NoError(t, store.PutBlobs(blk.Height(), sidecars, txHashes))
+	}
+}
+
+func TestBlobSyncFetch(t *testing.T) {
+	require := require.New(t)
+	blks, stripped, blobHashes := testBlocks(t)
+	cfg := DefaultConfig
+	cfg.RequestTimeout = 100 * time.Millisecond
+	cfg.MaxBlobs = 1
+
+	network := &testNetwork{nodes: map[peer.ID]*BlobSync{}}
+	var servers []peer.AddrInfo
+	for i := 0; i < 2; i++ {
+		store := newTestStore()
+		storeBlocks(t, store, blks)
+		// the first peer serves the sidecars of other transactions
+		store.corrupted = i == 0
+		_, p := network.add(t, i, cfg, store, &Helper{BlobHashes: blobHashes})
+		servers = append(servers, p)
+	}
+	var (
+		reportMu sync.Mutex
+		reported []string
+		store    = newTestStore()
+	)
+	fetcher, _ := network.add(t, 2, cfg, store, &Helper{
+		P2PNeighbor: func() ([]peer.AddrInfo, error) { return servers, nil },
+		BlobHashes:  blobHashes,
+		ReportPeer: func(id string) {
+			reportMu.Lock()
+			defer reportMu.Unlock()
+			reported = append(reported, id)
+		},
+	})
+
+	// the sidecars missing from the block are fetched once it is received
+	require.NoError(fetcher.ReceiveBlock(stripped[0]))
+	require.Eventually(func() bool { return store.len() == 2 }, 3*time.Second, 10*time.Millisecond)
+	for _, act := range blks[0].Actions {
+		for _, h := range act.BlobHashes() {
+			height, sc, _, err := store.GetBlobByVersionedHash(h)
+			require.NoError(err)
+			require.EqualValues(1, height)
+			require.Equal(act.BlobTxSidecar(), sc)
+		}
+	}
+	// only the peer serving the corrupted sidecars is reported
+	reportMu.Lock()
+	require.NotEmpty(reported)
+	for _, id := range reported {
+		require.Equal(_testPeerIDs[0], id)
+	}
+	reportMu.Unlock()
+
+	// the blobs stored are not fetched again
+	require.NoError(fetcher.Fetch(context.Background(), 1, blks[0].Actions[1].BlobHashes()))
+
+	// the blobs can't be fetched once no peer stores them
+	network.nodes[servers[1].ID].store = newTestStore()
+	require.ErrorContains(fetcher.Fetch(context.Background(), 2, blks[1].Actions[1].BlobHashes()), "failed to fetch 1 blobs out of 1")
+
+	// the blobs of the blocks older than the retention are not fetched
+	fetcher.retention = 0
+	require.NoError(fetcher.ReceiveBlock(stripped[1]))
+	time.Sleep(50 * time.Millisecond)
+	require.Equal(2, store.len())
+}
+
+func TestBlobSyncAnnounce(t *testing.T) {
+	require := require.New(t)
+	blks, stripped, blobHashes := testBlocks(t)
+	network := &testNetwork{nodes: map[peer.ID]*BlobSync{}}
+	var (
+		server, receiver = newTestStore(), newTestStore()
+		peers            []peer.AddrInfo
+	)
+	storeBlocks(t, server, blks[:1])
+	announcer, p := network.add(t, 0, DefaultConfig, server, &Helper{
+		P2PNeighbor: func() ([]peer.AddrInfo, error) { return peers, nil },
+		BlobHashes:  blobHashes,
+	})
+	receiverCfg := DefaultConfig
+	receiverCfg.Announce = false
+	_, q := network.add(t, 1, receiverCfg, receiver, &Helper{
+		P2PNeighbor: func() ([]peer.AddrInfo, error) { return []peer.AddrInfo{p}, nil },
+		BlobHashes:  blobHashes,
+	})
+	peers = append(peers, q)
+
+	// the receiver fetches the blobs announced which are missing
+	require.NoError(announcer.ReceiveBlock(blks[0]))
+	require.Eventually(func() bool { return receiver.len() == 2 }, 3*time.Second, 10*time.Millisecond)
+
+	// the blobs announced not in the block committed are not fetched
+	msg, err := proto.Marshal(&blobsyncpb.Message{Msg: &blobsyncpb.Message_Announce{Announce: &blobsyncpb.BlobAnnounce{
+		Height:     1,
+		BlobHashes: [][]byte{blks[1].Actions[1].BlobHashes()[0].Bytes()},
+	}}})
+	require.NoError(err)
+	require.NoError(network.nodes[q.ID].HandleMessage(context.Background(), p, msg))
+	require.NoError(announcer.ReceiveBlock(stripped[1]))
+	time.Sleep(50 * time.Millisecond)
+	require.Equal(2, receiver.len())
+}
+
+func TestBlobSyncHandleMessage(t *testing.T) {
+	require := require.New(t)
+	blks, _, blobHashes := testBlocks(t)
+	store := newTestStore()
+	var sent []*blobsyncpb.Message
+	bs := NewBlobSync(DefaultConfig, time.Hour, store, &Helper{
+		UnicastOutbound: func(_ context.Context, _ peer.AddrInfo, data []byte) error {
+			msg := &blobsyncpb.Message{}
+			require.NoError(proto.Unmarshal(data, msg))
+			sent = append(sent, msg)
+			return nil
+		},
+		BlobHashes: blobHashes,
+	})
+	storeBlocks(t, store, blks[:1])
+
+	// the sidecars are served grouped by the transactions
+	hashes := blks[0].Actions[1].BlobHashes()
+	req, err := proto.Marshal(&blobsyncpb.Message{Msg: &blobsyncpb.Message_Request{Request: &blobsyncpb.BlobRequest{
+		BlobHashes: [][]byte{hashes[0].Bytes(), hashes[0].Bytes(), blks[1].Actions[1].BlobHashes()[0].Bytes()},
+	}}})
+	require.NoError(err)
+	require.NoError(bs.HandleMessage(context.Background(), peer.AddrInfo{}, req))
+	require.Len(sent, 1)
+	require.Len(sent[0].GetSidecars().GetSidecars(), 1)
+	sc := sent[0].GetSidecars().GetSidecars()[0]
+	require.EqualValues(1, sc.GetHeight())
+	txHash, err := blks[0].Actions[1].Hash()
+	require.NoError(err)
+	require.Equal(txHash[:], sc.GetTxHash())
+
+	// the blobs not stored are not served
+	req, err = proto.Marshal(&blobsyncpb.Message{Msg: &blobsyncpb.Message_Request{Request: &blobsyncpb.BlobRequest{
+		BlobHashes: [][]byte{blks[1].Actions[1].BlobHashes()[0].Bytes()},
+	}}})
+	require.NoError(err)
+	require.NoError(bs.HandleMessage(context.Background(), peer.AddrInfo{}, req))
+	require.Len(sent, 1)
+
+	// the sidecars unsolicited are ignored
+	sc.Height = 2
+	resp, err := proto.Marshal(&blobsyncpb.Message{Msg: &blobsyncpb.Message_Sidecars{Sidecars: sent[0].GetSidecars()}})
+	require.NoError(err)
+	require.NoError(bs.HandleMessage(context.Background(), peer.AddrInfo{}, resp))
+	require.Equal(2, store.len())
+
+	// the malformed messages are rejected
+	require.Error(bs.HandleMessage(context.Background(), peer.AddrInfo{}, []byte{0xff}))
+	require.Error(bs.HandleMessage(context.Background(), peer.AddrInfo{}, nil))
+	req, err = proto.Marshal(&blobsyncpb.Message{Msg: &blobsyncpb.Message_Request{Request: &blobsyncpb.BlobRequest{
+		BlobHashes: [][]byte{{1, 2, 3}},
+	}}})
+	require.NoError(err)
+	require.ErrorContains(bs.HandleMessage(context.Background(), peer.AddrInfo{}, req), "invalid versioned hash")
+}
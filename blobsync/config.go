@@ -0,0 +1,26 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blobsync
+
+import "time"
+
+// Config is the config of the blob sync
+type Config struct {
+	// Announce enables announcing the versioned hashes of the blobs in the blocks committed to the peers
+	Announce bool `yaml:"announce"`
+	// RequestTimeout is the time to wait for the sidecars requested from a peer
+	RequestTimeout time.Duration `yaml:"requestTimeout"`
+	// MaxBlobs is the maximal number of the blobs in a request or a response, each blob is 128KB so that it must
+	// be well below the maximal message size of p2p
+	MaxBlobs int `yaml:"maxBlobs"`
+}
+
+// DefaultConfig is the default config
+var DefaultConfig = Config{
+	Announce:       true,
+	RequestTimeout: 5 * time.Second,
+	MaxBlobs:       32,
+}
@@ -108,6 +108,30 @@ func (b *Builder) SetExcessBlobGas(g uint64) *Builder {
 	return b
 }
 
+// SetExtraData sets the extra data committed by the producer
+func (b *Builder) SetExtraData(extra []byte) *Builder {
+	b.blk.Header.extraData = extra
+	return b
+}
+
+// SetCandidatesRoot sets the merkle root of the candidate list snapshot of the epoch
+func (b *Builder) SetCandidatesRoot(root hash.Hash256) *Builder {
+	b.blk.Header.candidatesRoot = root
+	return b
+}
+
+// SetBeacon sets the random beacon after the block is executed
+func (b *Builder) SetBeacon(beacon hash.Hash256) *Builder {
+	b.blk.Header.beacon = beacon
+	return b
+}
+
+// SetOutboundRoot sets the root of the bridge outbound queue after the block is executed
+func (b *Builder) SetOutboundRoot(root hash.Hash256) *Builder {
+	b.blk.Header.outboundRoot = root
+	return b
+}
+
 // SignAndBuild signs and then builds a block.
 func (b *Builder) SignAndBuild(signerPrvKey crypto.PrivateKey) (Block, error) {
 	b.blk.Header.pubkey = signerPrvKey.PublicKey()
@@ -0,0 +1,77 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package block
+
+import (
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+)
+
+const (
+	// _extraDataFieldNum is the proto field number of the extra data in BlockHeaderCore, which is far beyond the
+	// fields defined, so that it does not collide with the fields added to BlockHeaderCore later
+	_extraDataFieldNum = 100
+	// _candidatesRootFieldNum is the proto field number of the candidates root in BlockHeaderCore
+	_candidatesRootFieldNum = 101
+	// _beaconFieldNum is the proto field number of the random beacon in BlockHeaderCore
+	_beaconFieldNum = 102
+	// _outboundRootFieldNum is the proto field number of the bridge outbound root in BlockHeaderCore
+	_outboundRootFieldNum = 103
+	// MaxExtraDataSize is the maximum size of the extra data in a block header
+	MaxExtraDataSize = 64
+
+	_buildInfoType = byte(1)
+	// MaxBuildVersionLength is the maximum length of the node version in the build info, the longer version is
+	// truncated
+	MaxBuildVersionLength = MaxExtraDataSize - 1 - len(hash.ZeroHash256)
+)
+
+var (
+	// ErrInvalidExtraData indicates the extra data in the block header is invalid
+	ErrInvalidExtraData = errors.New("invalid extra data")
+	// ErrNoBuildInfo indicates the extra data does not carry a build info
+	ErrNoBuildInfo = errors.New("no build info in extra data")
+)
+
+// BuildInfo is the build of the producer's node, committed in the extra data of the blocks it mints. The network
+// readiness of a hard fork could be monitored by the versions of the recent producers, and a producer running a
+// different set of features is told by the feature hash
+type BuildInfo struct {
+	Version     string
+	FeatureHash hash.Hash256
+}
+
+// NewBuildInfo creates a build info, the version is truncated to MaxBuildVersionLength
+func NewBuildInfo(version string, featureHash hash.Hash256) *BuildInfo {
+	if len(version) > MaxBuildVersionLength {
+		version = version[:MaxBuildVersionLength]
+	}
+	return &BuildInfo{
+		Version:     version,
+		FeatureHash: featureHash,
+	}
+}
+
+// Encode encodes the build info into the extra data, which is a type byte followed by the feature hash and the version
+func (bi *BuildInfo) Encode() []byte {
+	buf := make([]byte, 0, 1+len(bi.FeatureHash)+len(bi.Version))
+	buf = append(buf, _buildInfoType)
+	buf = append(buf, bi.FeatureHash[:]...)
+	return append(buf, bi.Version...)
+}
+
+// DecodeBuildInfo decodes the build info from the extra data
+func DecodeBuildInfo(extra []byte) (*BuildInfo, error) {
+	if len(extra) == 0 || extra[0] != _buildInfoType {
+		return nil, ErrNoBuildInfo
+	}
+	if len(extra) < 1+len(hash.ZeroHash256) || len(extra) > MaxExtraDataSize {
+		return nil, errors.Wrapf(ErrInvalidExtraData, "invalid build info length %d", len(extra))
+	}
+	bi := &BuildInfo{Version: string(extra[1+len(hash.ZeroHash256):])}
+	copy(bi.FeatureHash[:], extra[1:])
+	return bi, nil
+}
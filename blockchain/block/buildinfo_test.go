@@ -0,0 +1,185 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package block
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+	"github.com/iotexproject/iotex-core/v2/testutil"
+)
+
+func TestBuildInfo(t *testing.T) {
+	require := require.New(t)
+
+	featureHash := hash.Hash256b([]byte("features"))
+	bi := NewBuildInfo("v2.2.0", featureHash)
+	extra := bi.Encode()
+	require.LessOrEqual(len(extra), MaxExtraDataSize)
+	bi2, err := DecodeBuildInfo(extra)
+	require.NoError(err)
+	require.Equal(bi, bi2)
+
+	// the long version is truncated to fit in the extra data
+	bi = NewBuildInfo(strings.Repeat("v", 100), featureHash)
+	require.Len(bi.Version, MaxBuildVersionLength)
+	require.Len(bi.Encode(), MaxExtraDataSize)
+
+	_, err = DecodeBuildInfo(nil)
+	require.Equal(ErrNoBuildInfo, err)
+	_, err = DecodeBuildInfo([]byte{2, 3})
+	require.Equal(ErrNoBuildInfo, err)
+	_, err = DecodeBuildInfo(extra[:10])
+	require.Equal(ErrInvalidExtraData, errors.Cause(err))
+}
+
+func TestHeaderExtraData(t *testing.T) {
+	require := require.New(t)
+
+	extra := NewBuildInfo("v2.2.0", hash.Hash256b([]byte("features"))).Encode()
+	build := func(extra []byte) *Block {
+		blk, err := NewBuilder(NewRunnableActionsBuilder().Build()).
+			SetHeight(1).
+			SetTimestamp(testutil.TimestampNow()).
+			SetPrevBlockHash(hash.ZeroHash256).
+			SetExtraData(extra).
+			SignAndBuild(identityset.PrivateKey(29))
+		require.NoError(err)
+		return &blk
+	}
+	blk := build(extra)
+	require.True(blk.VerifySignature())
+	require.Equal(extra, blk.ExtraData())
+	// the extra data is committed in the hash of the header
+	require.NotEqual(build(nil).HashHeaderCore(), blk.HashHeaderCore())
+
+	ser, err := blk.Header.Serialize()
+	require.NoError(err)
+	header := &Header{}
+	require.NoError(header.Deserialize(ser))
+	require.Equal(extra, header.ExtraData())
+	require.Equal(blk.HashBlock(), header.HashBlock())
+	require.True(header.VerifySignature())
+
+	// the header without extra data is serialized as before
+	header = getHeader(true)
+	require.Nil(header.BlockHeaderCoreProto().ProtoReflect().GetUnknown())
+	ser, err = header.Serialize()
+	require.NoError(err)
+	header2 := &Header{}
+	require.NoError(header2.Deserialize(ser))
+	require.Nil(header2.ExtraData())
+}
+
+func TestHeaderCandidatesRoot(t *testing.T) {
+	require := require.New(t)
+
+	root := hash.Hash256b([]byte("candidates"))
+	extra := NewBuildInfo("v2.2.0", hash.Hash256b([]byte("features"))).Encode()
+	blk, err := NewBuilder(NewRunnableActionsBuilder().Build()).
+		SetHeight(1).
+		SetTimestamp(testutil.TimestampNow()).
+		SetPrevBlockHash(hash.ZeroHash256).
+		SetExtraData(extra).
+		SetCandidatesRoot(root).
+		SignAndBuild(identityset.PrivateKey(29))
+	require.NoError(err)
+	require.True(blk.VerifySignature())
+	require.Equal(root, blk.CandidatesRoot())
+
+	ser, err := blk.Header.Serialize()
+	require.NoError(err)
+	header := &Header{}
+	require.NoError(header.Deserialize(ser))
+	require.Equal(root, header.CandidatesRoot())
+	require.Equal(extra, header.ExtraData())
+	require.Equal(blk.HashBlock(), header.HashBlock())
+	require.True(header.VerifySignature())
+
+	// the candidates root is committed in the hash of the header
+	blk2, err := NewBuilder(NewRunnableActionsBuilder().Build()).
+		SetHeight(1).
+		SetTimestamp(blk.Timestamp()).
+		SetPrevBlockHash(hash.ZeroHash256).
+		SetExtraData(extra).
+		SignAndBuild(identityset.PrivateKey(29))
+	require.NoError(err)
+	require.Equal(hash.ZeroHash256, blk2.CandidatesRoot())
+	require.NotEqual(blk2.HashHeaderCore(), blk.HashHeaderCore())
+
+	// the root of invalid length is rejected
+	pb := blk.Header.BlockHeaderCoreProto()
+	pb.ProtoReflect().SetUnknown(protowire.AppendBytes(protowire.AppendTag(nil, _candidatesRootFieldNum, protowire.BytesType), root[:20]))
+	require.ErrorContains(header.loadFromBlockHeaderCoreProto(pb), "invalid candidates root length")
+}
+
+func TestHeaderBeacon(t *testing.T) {
+	require := require.New(t)
+
+	beacon := hash.Hash256b([]byte("beacon"))
+	root := hash.Hash256b([]byte("candidates"))
+	blk, err := NewBuilder(NewRunnableActionsBuilder().Build()).
+		SetHeight(1).
+		SetTimestamp(testutil.TimestampNow()).
+		SetPrevBlockHash(hash.ZeroHash256).
+		SetCandidatesRoot(root).
+		SetBeacon(beacon).
+		SignAndBuild(identityset.PrivateKey(29))
+	require.NoError(err)
+	require.True(blk.VerifySignature())
+	require.Equal(beacon, blk.Beacon())
+
+	ser, err := blk.Header.Serialize()
+	require.NoError(err)
+	header := &Header{}
+	require.NoError(header.Deserialize(ser))
+	require.Equal(beacon, header.Beacon())
+	require.Equal(root, header.CandidatesRoot())
+	require.Equal(blk.HashBlock(), header.HashBlock())
+	require.True(header.VerifySignature())
+
+	// the beacon of invalid length is rejected
+	pb := blk.Header.BlockHeaderCoreProto()
+	pb.ProtoReflect().SetUnknown(protowire.AppendBytes(protowire.AppendTag(nil, _beaconFieldNum, protowire.BytesType), beacon[:20]))
+	require.ErrorContains(header.loadFromBlockHeaderCoreProto(pb), "invalid beacon length")
+}
+
+func TestHeaderOutboundRoot(t *testing.T) {
+	require := require.New(t)
+
+	root := hash.Hash256b([]byte("outbound"))
+	beacon := hash.Hash256b([]byte("beacon"))
+	blk, err := NewBuilder(NewRunnableActionsBuilder().Build()).
+		SetHeight(1).
+		SetTimestamp(testutil.TimestampNow()).
+		SetPrevBlockHash(hash.ZeroHash256).
+		SetBeacon(beacon).
+		SetOutboundRoot(root).
+		SignAndBuild(identityset.PrivateKey(29))
+	require.NoError(err)
+	require.True(blk.VerifySignature())
+	require.Equal(root, blk.OutboundRoot())
+
+	ser, err := blk.Header.Serialize()
+	require.NoError(err)
+	header := &Header{}
+	require.NoError(header.Deserialize(ser))
+	require.Equal(root, header.OutboundRoot())
+	require.Equal(beacon, header.Beacon())
+	require.Equal(blk.HashBlock(), header.HashBlock())
+	require.True(header.VerifySignature())
+
+	// the outbound root of invalid length is rejected
+	pb := blk.Header.BlockHeaderCoreProto()
+	pb.ProtoReflect().SetUnknown(protowire.AppendBytes(protowire.AppendTag(nil, _outboundRootFieldNum, protowire.BytesType), root[:20]))
+	require.ErrorContains(header.loadFromBlockHeaderCoreProto(pb), "invalid outbound root length")
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protowire"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
@@ -41,6 +42,15 @@ type Header struct {
 	// added by EIP-4844 and is ignored in legacy headers.
 	blobGasUsed   uint64
 	excessBlobGas uint64
+
+	// extraData is the optional data committed by the producer, see ExtraData
+	extraData []byte
+	// candidatesRoot is the merkle root of the candidate list snapshot, see CandidatesRoot
+	candidatesRoot hash.Hash256
+	// beacon is the random beacon after the block is executed, see Beacon
+	beacon hash.Hash256
+	// outboundRoot is the root of the bridge outbound queue after the block is executed, see OutboundRoot
+	outboundRoot hash.Hash256
 }
 
 // Errors
@@ -48,6 +58,12 @@ var (
 	ErrTxRootMismatch      = errors.New("transaction merkle root does not match")
 	ErrDeltaStateMismatch  = errors.New("delta state digest doesn't match")
 	ErrReceiptRootMismatch = errors.New("receipt root hash does not match")
+	// ErrCandidatesRootMismatch indicates the candidates root in the header does not match the snapshot
+	ErrCandidatesRootMismatch = errors.New("candidates root does not match")
+	// ErrBeaconMismatch indicates the random beacon in the header does not match the state
+	ErrBeaconMismatch = errors.New("random beacon does not match")
+	// ErrOutboundRootMismatch indicates the bridge outbound root in the header does not match the state
+	ErrOutboundRootMismatch = errors.New("bridge outbound root does not match")
 )
 
 // Version returns the version of this block.
@@ -101,6 +117,32 @@ func (h *Header) ExcessBlobGas() uint64 {
 	return h.excessBlobGas
 }
 
+// ExtraData returns the extra data committed by the producer. There is no field for it in BlockHeaderCore, so it is
+// carried as the field _extraDataFieldNum unknown to the proto, which is hashed and signed with the header core
+func (h *Header) ExtraData() []byte {
+	return h.extraData
+}
+
+// CandidatesRoot returns the merkle root of the candidate list snapshot taken at the start of the epoch, which is
+// only committed in the first block of an epoch. Like the extra data, it is carried as the field
+// _candidatesRootFieldNum unknown to BlockHeaderCore
+func (h *Header) CandidatesRoot() hash.Hash256 {
+	return h.candidatesRoot
+}
+
+// Beacon returns the random beacon mixed with the hash of the previous block, which seeds the proposer ordering of
+// the epochs later. It is carried as the field _beaconFieldNum unknown to BlockHeaderCore
+func (h *Header) Beacon() hash.Hash256 {
+	return h.beacon
+}
+
+// OutboundRoot returns the root of the bridge outbound queue, which accumulates the hashes of all outbound messages
+// sent, so that the counterpart chain verifies the messages against the headers. It is carried as the field
+// _outboundRootFieldNum unknown to BlockHeaderCore
+func (h *Header) OutboundRoot() hash.Hash256 {
+	return h.outboundRoot
+}
+
 // Proto returns BlockHeader proto.
 func (h *Header) Proto() *iotextypes.BlockHeader {
 	header := iotextypes.BlockHeader{
@@ -135,6 +177,26 @@ func (h *Header) BlockHeaderCoreProto() *iotextypes.BlockHeaderCore {
 	if h.baseFee != nil {
 		header.BaseFee = h.baseFee.Bytes()
 	}
+	var unknown []byte
+	if len(h.extraData) > 0 {
+		unknown = protowire.AppendBytes(
+			protowire.AppendTag(unknown, _extraDataFieldNum, protowire.BytesType), h.extraData)
+	}
+	if h.candidatesRoot != hash.ZeroHash256 {
+		unknown = protowire.AppendBytes(
+			protowire.AppendTag(unknown, _candidatesRootFieldNum, protowire.BytesType), h.candidatesRoot[:])
+	}
+	if h.beacon != hash.ZeroHash256 {
+		unknown = protowire.AppendBytes(
+			protowire.AppendTag(unknown, _beaconFieldNum, protowire.BytesType), h.beacon[:])
+	}
+	if h.outboundRoot != hash.ZeroHash256 {
+		unknown = protowire.AppendBytes(
+			protowire.AppendTag(unknown, _outboundRootFieldNum, protowire.BytesType), h.outboundRoot[:])
+	}
+	if len(unknown) > 0 {
+		header.ProtoReflect().SetUnknown(unknown)
+	}
 	return &header
 }
 
@@ -182,7 +244,56 @@ func (h *Header) loadFromBlockHeaderCoreProto(pb *iotextypes.BlockHeaderCore) er
 	}
 	h.blobGasUsed = pb.GetBlobGasUsed()
 	h.excessBlobGas = pb.GetExcessBlobGas()
-	return err
+	return h.loadUnknownFields(pb.ProtoReflect().GetUnknown())
+}
+
+// loadUnknownFields parses the extra data, the candidates root, the beacon and the bridge outbound root out of the
+// fields unknown to BlockHeaderCore
+func (h *Header) loadUnknownFields(b []byte) error {
+	h.extraData = nil
+	h.candidatesRoot = hash.ZeroHash256
+	h.beacon = hash.ZeroHash256
+	h.outboundRoot = hash.ZeroHash256
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+		if (num == _extraDataFieldNum || num == _candidatesRootFieldNum || num == _beaconFieldNum || num == _outboundRootFieldNum) && typ == protowire.BytesType {
+			v, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			switch num {
+			case _extraDataFieldNum:
+				h.extraData = append([]byte{}, v...)
+			case _candidatesRootFieldNum:
+				if len(v) != len(h.candidatesRoot) {
+					return errors.Errorf("invalid candidates root length %d", len(v))
+				}
+				copy(h.candidatesRoot[:], v)
+			case _beaconFieldNum:
+				if len(v) != len(h.beacon) {
+					return errors.Errorf("invalid beacon length %d", len(v))
+				}
+				copy(h.beacon[:], v)
+			default:
+				if len(v) != len(h.outboundRoot) {
+					return errors.Errorf("invalid outbound root length %d", len(v))
+				}
+				copy(h.outboundRoot[:], v)
+			}
+			b = b[n:]
+			continue
+		}
+		n = protowire.ConsumeFieldValue(num, typ, b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
 }
 
 // SerializeCore returns byte stream for header core.
@@ -220,11 +220,11 @@ func NewBlockchain(cfg Config, g genesis.Genesis, dao blockdao.BlockDAO, bbf Blo
 		[]string{"default", strconv.FormatUint(uint64(cfg.ID), 10)},
 	)
 	if err != nil {
-		log.L().Panic("Failed to generate prometheus timer factory.", zap.Error(err))
+		log.Logger("blockchain").Panic("Failed to generate prometheus timer factory.", zap.Error(err))
 	}
 	chain.timerFactory = timerFactory
 	if chain.dao == nil {
-		log.L().Panic("blockdao is nil")
+		log.Logger("blockchain").Panic("blockdao is nil")
 	}
 	chain.lifecycle.Add(chain.dao)
 	chain.lifecycle.Add(chain.pubSubManager)
@@ -304,7 +304,7 @@ func (bc *blockchain) TipHash() hash.Hash256 {
 func (bc *blockchain) TipHeight() uint64 {
 	tipHeight, err := bc.dao.Height()
 	if err != nil {
-		log.L().Panic("failed to get tip height", zap.Error(err))
+		log.Logger("blockchain").Panic("failed to get tip height", zap.Error(err))
 	}
 	return tipHeight
 }
@@ -337,7 +337,7 @@ func (bc *blockchain) ValidateBlock(blk *block.Block, opts ...BlockValidationOpt
 	}
 	// verify new block has correctly linked to current tip
 	if blk.PrevHash() != tip.Hash {
-		blk.HeaderLogger(log.L()).Error("Previous block hash doesn't match.",
+		blk.HeaderLogger(log.Logger("blockchain")).Error("Previous block hash doesn't match.",
 			log.Hex("expectedBlockHash", tip.Hash[:]))
 		return errors.Wrapf(
 			ErrInvalidBlock,
@@ -467,8 +467,9 @@ func (bc *blockchain) MintNewBlock(timestamp time.Time, opts ...MintOption) (*bl
 		producerPrivateKey = privateKeys[0]
 	}
 	minterAddress := producerPrivateKey.PublicKey().Address()
-	log.L().Info("Minting a new block.", zap.Uint64("height", newblockHeight), zap.String("minter", minterAddress.String()))
-	ctx = bc.contextWithBlock(ctx, minterAddress, newblockHeight, timestamp, protocol.CalcBaseFee(genesis.MustExtractGenesisContext(ctx).Blockchain, &tip), protocol.CalcExcessBlobGas(tip.ExcessBlobGas, tip.BlobGasUsed))
+	log.Logger("blockchain").Info("Minting a new block.", zap.Uint64("height", newblockHeight), zap.String("minter", minterAddress.String()))
+	g := genesis.MustExtractGenesisContext(ctx).Blockchain
+	ctx = bc.contextWithBlock(ctx, minterAddress, newblockHeight, timestamp, protocol.CalcBaseFee(g, &tip), protocol.CalcExcessBlobGas(g, newblockHeight, tip.ExcessBlobGas, tip.BlobGasUsed))
 	ctx = protocol.WithFeatureCtx(ctx)
 	// run execution and update state trie root hash
 	blk, err := bc.bbf.Mint(ctx, producerPrivateKey)
@@ -493,7 +494,7 @@ func (bc *blockchain) CommitBlock(blk *block.Block) error {
 }
 
 func (bc *blockchain) AddSubscriber(s BlockCreationSubscriber) error {
-	log.L().Info("Add a subscriber.")
+	log.Logger("blockchain").Info("Add a subscriber.")
 	if s == nil {
 		return errors.New("subscriber could not be nil")
 	}
@@ -567,7 +568,7 @@ func (bc *blockchain) commitBlock(blk *block.Block) error {
 	}
 	blkHash := blk.HashBlock()
 	if blk.Height()%100 == 0 {
-		blk.HeaderLogger(log.L()).Info("Committed a block.", log.Hex("tipHash", blkHash[:]))
+		blk.HeaderLogger(log.Logger("blockchain")).Info("Committed a block.", log.Hex("tipHash", blkHash[:]))
 	}
 	_blockMtc.WithLabelValues("numActions").Set(float64(len(blk.Actions)))
 	if blk.BaseFee() != nil {
@@ -0,0 +1,319 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockdao
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/iotexproject/go-pkgs/byteutil"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/db/batch"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+const (
+	// _blobExportInterval is the interval to retry the export if no block is stored
+	_blobExportInterval = time.Minute
+	_blobArchiveTimeout = 30 * time.Second
+)
+
+type (
+	// BlobArchive is the object store keeping the blobs exported before they are deleted by the retention
+	BlobArchive interface {
+		// Put writes the object of the key, overwriting the existing one
+		Put(ctx context.Context, key string, data []byte) error
+		// Get returns the object of the key, or db.ErrNotExist if it is not found
+		Get(ctx context.Context, key string) ([]byte, error)
+	}
+
+	// blobManifest is the index of the blobs of a height in the archive, which is uploaded along with the blobs, so
+	// that the archive could be served without the blob store
+	blobManifest struct {
+		Height   uint64                `json:"height"`
+		Object   string                `json:"object"`
+		Checksum hexutil.Bytes         `json:"checksum"`
+		Actions  []*blobManifestAction `json:"actions"`
+	}
+
+	blobManifestAction struct {
+		ActionHash common.Hash   `json:"actionHash"`
+		BlobHashes []common.Hash `json:"blobHashes"`
+	}
+
+	fileBlobArchive struct {
+		dir string
+	}
+
+	httpBlobArchive struct {
+		endpoint string
+		client   *http.Client
+	}
+)
+
+// NewBlobArchive returns the blob archive of the uri, which is a local directory of the scheme file, or an object store
+// accepting the HTTP PUT and GET requests of the scheme http or https, e.g., a bucket behind an authenticating gateway
+func NewBlobArchive(uri string) (BlobArchive, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse blob archive uri %s", uri)
+	}
+	switch u.Scheme {
+	case "file", "":
+		if u.Path == "" {
+			return nil, errors.Errorf("empty path of blob archive uri %s", uri)
+		}
+		return &fileBlobArchive{dir: u.Path}, nil
+	case "http", "https":
+		return &httpBlobArchive{
+			endpoint: strings.TrimSuffix(uri, "/"),
+			client:   &http.Client{Timeout: _blobArchiveTimeout},
+		}, nil
+	default:
+		return nil, errors.Errorf("unsupported blob archive scheme %s", u.Scheme)
+	}
+}
+
+func (a *fileBlobArchive) Put(_ context.Context, key string, data []byte) error {
+	path := filepath.Join(a.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return errors.Wrapf(err, "failed to create directory of %s", path)
+	}
+	// write to a temporary file first, so that a partially written object is never read
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return errors.Wrapf(err, "failed to write %s", tmp)
+	}
+	return errors.Wrapf(os.Rename(tmp, path), "failed to rename %s", tmp)
+}
+
+func (a *fileBlobArchive) Get(_ context.Context, key string) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Join(a.dir, filepath.FromSlash(key)))
+	if os.IsNotExist(err) {
+		return nil, errors.Wrapf(db.ErrNotExist, "object %s is not archived", key)
+	}
+	return data, err
+}
+
+func (a *httpBlobArchive) Put(ctx context.Context, key string, data []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, a.endpoint+"/"+key, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to upload object %s", key)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return errors.Errorf("failed to upload object %s, status %s", key, resp.Status)
+	}
+	return nil
+}
+
+func (a *httpBlobArchive) Get(ctx context.Context, key string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.endpoint+"/"+key, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to download object %s", key)
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, errors.Wrapf(db.ErrNotExist, "object %s is not archived", key)
+	case resp.StatusCode/100 != 2:
+		return nil, errors.Errorf("failed to download object %s, status %s", key, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func blobObjectKey(height uint64) string {
+	return fmt.Sprintf("blobs/%020d", height)
+}
+
+func blobManifestKey(height uint64) string {
+	return fmt.Sprintf("manifests/%020d.json", height)
+}
+
+// exportLoop exports the blobs reaching the end of the retention window, until the context is canceled
+func (bs *blobStore) exportLoop(ctx context.Context) {
+	ticker := time.NewTicker(_blobExportInterval)
+	defer ticker.Stop()
+	for {
+		if err := bs.exportBlobs(ctx); err != nil && ctx.Err() == nil {
+			log.L().Warn("Failed to export blobs.", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-bs.exportCh:
+		case <-ticker.C:
+		}
+	}
+}
+
+// exportBlobs uploads the blobs out of the retention window to the archive, and deletes them from the blob store
+func (bs *blobStore) exportBlobs(ctx context.Context) error {
+	tip := atomic.LoadUint64(&bs.currWriteBlock)
+	if tip < bs.totalBlocks {
+		return nil
+	}
+	var (
+		target = tip - bs.totalBlocks
+		from   = atomic.LoadUint64(&bs.archiveHeight)
+	)
+	if from >= target {
+		return nil
+	}
+	ek, ev, err := bs.kvStore.Filter(_heightIndexNS, func(k, v []byte) bool {
+		return true
+	}, keyForBlock(from+1), keyForBlock(target))
+	switch errors.Cause(err) {
+	case nil, db.ErrNotExist, db.ErrBucketNotExist:
+	default:
+		return err
+	}
+	for i, key := range ek {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := bs.exportBlob(ctx, key, ev[i]); err != nil {
+			return errors.Wrapf(err, "failed to export blobs at height %d", byteutil.BytesToUint64BigEndian(key))
+		}
+	}
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	b := batch.NewBatch()
+	b.Put(_hashHeightNS, _archiveHeight, keyForBlock(target), "failed to put archive height")
+	if err := bs.kvStore.WriteBatch(b); err != nil {
+		return err
+	}
+	atomic.StoreUint64(&bs.archiveHeight, target)
+	return nil
+}
+
+// exportBlob uploads the blobs of the height and the manifest, then moves the height into the archived index
+func (bs *blobStore) exportBlob(ctx context.Context, key, index []byte) error {
+	height := byteutil.BytesToUint64BigEndian(key)
+	raw, err := bs.kvStore.Get(_blobDataNS, key)
+	if err != nil {
+		return err
+	}
+	manifest, err := newBlobManifest(height, raw)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	if err := bs.putArchive(ctx, manifest.Object, raw); err != nil {
+		return err
+	}
+	if err := bs.putArchive(ctx, blobManifestKey(height), data); err != nil {
+		return err
+	}
+
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	// the blobs could be changed while uploading if the tip is reset
+	current, err := bs.kvStore.Get(_blobDataNS, key)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(current, raw) {
+		return errors.New("blobs are changed while exporting")
+	}
+	b := batch.NewBatch()
+	if err := bs.deleteBlob(key, index, b); err != nil {
+		return err
+	}
+	for _, act := range manifest.Actions {
+		b.Put(_archivedHashNS, act.ActionHash[:], key, "failed to put archived hash to height mapping")
+		for _, h := range act.BlobHashes {
+			b.Put(_archivedBlobHashNS, h[:], act.ActionHash[:], "failed to put archived blob hash to action hash mapping")
+		}
+	}
+	b.Put(_archivedHeightNS, key, manifest.Checksum, "failed to put archived height")
+	b.Put(_hashHeightNS, _archiveHeight, key, "failed to put archive height")
+	if err := bs.kvStore.WriteBatch(b); err != nil {
+		return err
+	}
+	atomic.StoreUint64(&bs.archiveHeight, height)
+	return nil
+}
+
+func (bs *blobStore) putArchive(ctx context.Context, key string, data []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, _blobArchiveTimeout)
+	defer cancel()
+	return bs.archive.Put(ctx, key, data)
+}
+
+// getArchivedBlobs downloads the blobs of the height from the archive
+func (bs *blobStore) getArchivedBlobs(height uint64) ([]byte, error) {
+	checksum, err := bs.kvStore.Get(_archivedHeightNS, keyForBlock(height))
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), _blobArchiveTimeout)
+	defer cancel()
+	raw, err := bs.archive.Get(ctx, blobObjectKey(height))
+	if err != nil {
+		return nil, err
+	}
+	if h := sha256.Sum256(raw); !bytes.Equal(h[:], checksum) {
+		return nil, errors.Errorf("checksum mismatch of archived blobs at height %d", height)
+	}
+	return raw, nil
+}
+
+func newBlobManifest(height uint64, raw []byte) (*blobManifest, error) {
+	pb := iotextypes.BlobTxSidecars{}
+	if err := proto.Unmarshal(raw, &pb); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode blobs at height %d", height)
+	}
+	checksum := sha256.Sum256(raw)
+	manifest := &blobManifest{
+		Height:   height,
+		Object:   blobObjectKey(height),
+		Checksum: checksum[:],
+		Actions:  make([]*blobManifestAction, len(pb.GetSidecars())),
+	}
+	for i, sc := range pb.GetSidecars() {
+		act := &blobManifestAction{ActionHash: common.BytesToHash(pb.GetTxHash()[i])}
+		hasher := sha256.New()
+		for j := range sc.GetCommitments() {
+			var commitment kzg4844.Commitment
+			copy(commitment[:], sc.GetCommitments()[j])
+			act.BlobHashes = append(act.BlobHashes, kzg4844.CalcBlobHashV1(hasher, &commitment))
+		}
+		manifest.Actions[i] = act
+	}
+	return manifest, nil
+}
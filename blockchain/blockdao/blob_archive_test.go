@@ -0,0 +1,147 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockdao
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/db"
+	. "github.com/iotexproject/iotex-core/v2/pkg/util/assertions"
+	"github.com/iotexproject/iotex-core/v2/testutil"
+)
+
+func TestNewBlobArchive(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+
+	_, err := NewBlobArchive("s3://bucket")
+	r.ErrorContains(err, "unsupported blob archive scheme")
+	_, err = NewBlobArchive("file://")
+	r.Error(err)
+
+	objects := map[string][]byte{}
+	var mu sync.Mutex
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		key := strings.TrimPrefix(req.URL.Path, "/archive/")
+		switch req.Method {
+		case http.MethodPut:
+			data, _ := io.ReadAll(req.Body)
+			objects[key] = data
+		case http.MethodGet:
+			data, ok := objects[key]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(data)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	defer srv.Close()
+
+	for _, uri := range []string{"file://" + t.TempDir(), srv.URL + "/archive/"} {
+		archive, err := NewBlobArchive(uri)
+		r.NoError(err)
+		_, err = archive.Get(ctx, blobObjectKey(1))
+		r.ErrorIs(err, db.ErrNotExist)
+		r.NoError(archive.Put(ctx, blobObjectKey(1), []byte{1}))
+		r.NoError(archive.Put(ctx, blobObjectKey(1), []byte{1, 2}))
+		data, err := archive.Get(ctx, blobObjectKey(1))
+		r.NoError(err)
+		r.Equal([]byte{1, 2}, data)
+	}
+}
+
+func TestBlobStoreExport(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	testPath, err := testutil.PathOfTempFile("test-blob-store")
+	r.NoError(err)
+	defer func() {
+		testutil.CleanupPath(testPath)
+	}()
+	cfg := db.DefaultConfig
+	cfg.DbPath = testPath
+	archive, err := NewBlobArchive(t.TempDir())
+	r.NoError(err)
+	bs := NewBlobStore(db.NewBoltDB(cfg), 4, WithBlobArchive(archive))
+	r.NoError(bs.Start(ctx))
+	// stop the exporter to export manually
+	bs.cancel()
+	<-bs.done
+
+	blks, err := block.CreateTestBlockWithBlob(1, 8)
+	r.NoError(err)
+	for _, blk := range blks {
+		r.NoError(bs.PutBlock(blk))
+	}
+	// the expired blobs are kept until exported
+	_, _, err = bs.GetBlobsByHeight(1)
+	r.NoError(err)
+	r.NoError(bs.exportBlobs(ctx))
+	r.EqualValues(4, bs.archiveHeight)
+	for _, blk := range blks {
+		for _, i := range []int{1, 3} {
+			act := blk.Actions[i]
+			h := MustNoErrorV(act.Hash())
+			sc, _, err := bs.GetBlob(h)
+			r.NoError(err)
+			r.Equal(act.BlobTxSidecar(), sc)
+			height, sc, _, err := bs.GetBlobByVersionedHash(act.BlobHashes()[0])
+			r.NoError(err)
+			r.Equal(blk.Height(), height)
+			r.Equal(act.BlobTxSidecar(), sc)
+		}
+		scs, _, err := bs.GetBlobsByHeight(blk.Height())
+		r.NoError(err)
+		r.Len(scs, 2)
+		// the exported blobs are deleted from the blob store
+		_, err = bs.kvStore.Get(_blobDataNS, keyForBlock(blk.Height()))
+		if blk.Height() <= 4 {
+			r.ErrorIs(err, db.ErrNotExist)
+		} else {
+			r.NoError(err)
+		}
+	}
+	_, _, err = bs.GetBlobsByHeight(9)
+	r.ErrorIs(err, db.ErrNotExist)
+
+	// the manifest indexes the archived blobs
+	data, err := archive.Get(ctx, blobManifestKey(2))
+	r.NoError(err)
+	manifest := &blobManifest{}
+	r.NoError(json.Unmarshal(data, manifest))
+	r.EqualValues(2, manifest.Height)
+	r.Equal(blobObjectKey(2), manifest.Object)
+	r.Len(manifest.Actions, 2)
+	r.Equal(MustNoErrorV(blks[1].Actions[1].Hash()), hash.Hash256(manifest.Actions[0].ActionHash))
+	r.Equal(blks[1].Actions[1].BlobHashes(), manifest.Actions[0].BlobHashes)
+
+	// corrupted archive is detected
+	r.NoError(archive.Put(ctx, blobObjectKey(2), []byte{1}))
+	_, _, err = bs.GetBlobsByHeight(2)
+	r.ErrorContains(err, "checksum mismatch")
+
+	// the exporter resumes from the archive height after restart
+	r.NoError(bs.Stop(ctx))
+	r.NoError(bs.Start(ctx))
+	r.EqualValues(4, bs.archiveHeight)
+	r.NoError(bs.Stop(ctx))
+}
@@ -7,10 +7,13 @@ package blockdao
 
 import (
 	"context"
+	"crypto/sha256"
+	"sync"
 	"sync/atomic"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/iotexproject/go-pkgs/byteutil"
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
@@ -29,6 +32,13 @@ var (
 	_blobDataNS    = "blb"
 	_heightIndexNS = "hin" // mapping from blob height to index
 	_hashHeightNS  = "shn" // mapping from action hash to blob height
+	_blobHashNS    = "bhn" // mapping from versioned blob hash to action hash
+	_heightBlobNS  = "hbn" // mapping from blob height to versioned blob hashes
+
+	_archiveHeight      = []byte("ah")
+	_archivedHeightNS   = "ahn" // mapping from archived blob height to the checksum of the blobs
+	_archivedHashNS     = "ahh" // mapping from action hash to archived blob height
+	_archivedBlobHashNS = "abh" // mapping from versioned blob hash to action hash of archived blobs
 )
 
 type (
@@ -38,7 +48,14 @@ type (
 		Stop(context.Context) error
 		GetBlob(hash.Hash256) (*types.BlobTxSidecar, string, error)
 		GetBlobsByHeight(uint64) ([]*types.BlobTxSidecar, []string, error)
+		// GetBlobByVersionedHash returns the height, the sidecar and the action hash of the blob
+		GetBlobByVersionedHash(common.Hash) (uint64, *types.BlobTxSidecar, string, error)
 		PutBlock(*block.Block) error
+		// PutBlobs adds the sidecars of the actions in the block of the height within the retention window, which are
+		// missing from the block when it is stored
+		PutBlobs(uint64, []*types.BlobTxSidecar, []hash.Hash256) error
+		// SetTip deletes the blobs of the blocks above the height
+		SetTip(uint64) error
 	}
 
 	// storage for past N-day's blobs, structured as blow:
@@ -59,29 +76,71 @@ type (
 	// 3. The maximum number of blobs is 6 for each block, so maximum data size
 	//    stored by a key is 131kB x 6 = 786kB. The maximum total size of the
 	//    entire blob storage is 786kB x 311040 = 245GB.
+	// 4. If an archive is configured, the expired blobs are uploaded to the
+	//    archive along with a manifest before they are deleted, and only the
+	//    mappings to the archived heights are kept, so that the archived blobs
+	//    are still served by downloading them from the archive.
 	//
 	blobStore struct {
+		mu             sync.Mutex
 		kvStore        db.KVStore
 		totalBlocks    uint64
 		currWriteBlock uint64
+		archive        BlobArchive
+		archiveHeight  uint64
+		exportCh       chan struct{}
+		cancel         context.CancelFunc
+		done           chan struct{}
 	}
+
+	// BlobStoreOption is the option of the blob store
+	BlobStoreOption func(*blobStore)
 )
 
-func NewBlobStore(kv db.KVStore, size uint64) *blobStore {
-	return &blobStore{
+// WithBlobArchive exports the blobs to the archive before they are deleted by the retention
+func WithBlobArchive(archive BlobArchive) BlobStoreOption {
+	return func(bs *blobStore) {
+		bs.archive = archive
+	}
+}
+
+func NewBlobStore(kv db.KVStore, size uint64, opts ...BlobStoreOption) *blobStore {
+	bs := &blobStore{
 		kvStore:     kv,
 		totalBlocks: size,
 	}
+	for _, opt := range opts {
+		opt(bs)
+	}
+	return bs
 }
 
 func (bs *blobStore) Start(ctx context.Context) error {
 	if err := bs.kvStore.Start(ctx); err != nil {
 		return err
 	}
-	return bs.checkDB()
+	if err := bs.checkDB(); err != nil {
+		return err
+	}
+	if bs.archive != nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		bs.cancel = cancel
+		bs.exportCh = make(chan struct{}, 1)
+		bs.done = make(chan struct{})
+		go func() {
+			defer close(bs.done)
+			bs.exportLoop(ctx)
+		}()
+	}
+	return nil
 }
 
 func (bs *blobStore) Stop(ctx context.Context) error {
+	if bs.cancel != nil {
+		bs.cancel()
+		<-bs.done
+		bs.cancel = nil
+	}
 	return bs.kvStore.Stop(ctx)
 }
 
@@ -91,12 +150,20 @@ func (bs *blobStore) checkDB() error {
 	if err != nil && errors.Cause(err) != db.ErrNotExist {
 		return err
 	}
+	if bs.archive != nil {
+		bs.archiveHeight, err = bs.getHeightByHash(_archiveHeight)
+		if err != nil && errors.Cause(err) != db.ErrNotExist {
+			return err
+		}
+		// the expired blobs are deleted after exported
+		return nil
+	}
 	// in case the retention window size has shrunk, do a one-time purge
 	return bs.expireBlob(bs.currWriteBlock)
 }
 
 func (bs *blobStore) GetBlob(h hash.Hash256) (*types.BlobTxSidecar, string, error) {
-	height, err := bs.getHeightByHash(h[:])
+	height, err := bs.blobHeight(h[:])
 	if err != nil {
 		return nil, "", err
 	}
@@ -113,12 +180,35 @@ func (bs *blobStore) GetBlob(h hash.Hash256) (*types.BlobTxSidecar, string, erro
 	return nil, "", errors.Errorf("data inconsistency: cannot find blob hash = %s", target)
 }
 
+func (bs *blobStore) GetBlobByVersionedHash(h common.Hash) (uint64, *types.BlobTxSidecar, string, error) {
+	actHash, err := bs.kvStore.Get(_blobHashNS, h[:])
+	if errors.Cause(err) == db.ErrNotExist && bs.archive != nil {
+		actHash, err = bs.kvStore.Get(_archivedBlobHashNS, h[:])
+	}
+	if err != nil {
+		return 0, nil, "", err
+	}
+	height, err := bs.blobHeight(actHash)
+	if err != nil {
+		return 0, nil, "", err
+	}
+	blob, txHash, err := bs.GetBlob(hash.BytesToHash256(actHash))
+	if err != nil {
+		return 0, nil, "", err
+	}
+	return height, blob, txHash, nil
+}
+
 func (bs *blobStore) GetBlobsByHeight(height uint64) ([]*types.BlobTxSidecar, []string, error) {
 	return bs.getBlobs(height)
 }
 
 func (bs *blobStore) getBlobs(height uint64) ([]*types.BlobTxSidecar, []string, error) {
 	raw, err := bs.kvStore.Get(_blobDataNS, keyForBlock(height))
+	if errors.Cause(err) == db.ErrNotExist && bs.archive != nil {
+		// the slower path of the blobs exported to the archive
+		raw, err = bs.getArchivedBlobs(height)
+	}
 	if err != nil {
 		return nil, nil, errors.Wrapf(err, "failed to get blobs at height %d", height)
 	}
@@ -130,6 +220,8 @@ func (bs *blobStore) getBlobs(height uint64) ([]*types.BlobTxSidecar, []string,
 }
 
 func (bs *blobStore) PutBlock(blk *block.Block) error {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
 	height := blk.Height()
 	if height <= atomic.LoadUint64(&bs.currWriteBlock) {
 		return errors.Errorf("block height %d is less than current tip height", height)
@@ -161,8 +253,15 @@ func (bs *blobStore) PutBlock(blk *block.Block) error {
 	b := batch.NewBatch()
 	if raw != nil {
 		bs.putBlob(raw, height, pb.TxHash, b)
+		bs.putBlobHashes(height, &pb, b)
 	}
-	if height >= bs.totalBlocks {
+	if bs.archive != nil {
+		// notify the exporter to export and delete the expired blobs
+		select {
+		case bs.exportCh <- struct{}{}:
+		default:
+		}
+	} else if height >= bs.totalBlocks {
 		k := keyForBlock(height - bs.totalBlocks)
 		v, err := bs.kvStore.Get(_heightIndexNS, k)
 		if err != nil {
@@ -183,7 +282,111 @@ func (bs *blobStore) PutBlock(blk *block.Block) error {
 	return nil
 }
 
+func (bs *blobStore) PutBlobs(height uint64, sidecars []*types.BlobTxSidecar, txHashes []hash.Hash256) error {
+	if len(sidecars) != len(txHashes) {
+		return errors.New("number of sidecars and action hashes mismatch")
+	}
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	tip := atomic.LoadUint64(&bs.currWriteBlock)
+	if height > tip || height+bs.totalBlocks <= tip {
+		return errors.Errorf("block height %d is out of the retention window at tip height %d", height, tip)
+	}
+	pb := iotextypes.BlobTxSidecars{}
+	raw, err := bs.kvStore.Get(_blobDataNS, keyForBlock(height))
+	switch errors.Cause(err) {
+	case nil:
+		if err := proto.Unmarshal(raw, &pb); err != nil {
+			return errors.Wrapf(err, "failed to decode blobs at height %d", height)
+		}
+	case db.ErrNotExist:
+	default:
+		return err
+	}
+	stored := make(map[hash.Hash256]struct{}, len(pb.TxHash))
+	for _, h := range pb.TxHash {
+		stored[hash.BytesToHash256(h)] = struct{}{}
+	}
+	added := false
+	for i, sc := range sidecars {
+		if _, ok := stored[txHashes[i]]; ok {
+			continue
+		}
+		stored[txHashes[i]] = struct{}{}
+		pb.TxHash = append(pb.TxHash, txHashes[i][:])
+		pb.Sidecars = append(pb.Sidecars, action.ToProtoSideCar(sc))
+		added = true
+	}
+	if !added {
+		return nil
+	}
+	if raw, err = proto.Marshal(&pb); err != nil {
+		return errors.Wrapf(err, "failed to put blobs at height %d", height)
+	}
+	b := batch.NewBatch()
+	bs.putBlobData(raw, height, pb.TxHash, b)
+	bs.putBlobHashes(height, &pb, b)
+	return bs.kvStore.WriteBatch(b)
+}
+
+func (bs *blobStore) SetTip(height uint64) error {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	if height >= atomic.LoadUint64(&bs.currWriteBlock) {
+		return nil
+	}
+	b := batch.NewBatch()
+	ek, ev, err := bs.kvStore.Filter(_heightIndexNS, func(k, v []byte) bool {
+		return byteutil.BytesToUint64BigEndian(k) > height
+	}, nil, nil)
+	switch errors.Cause(err) {
+	case nil:
+		for i, key := range ek {
+			if err := bs.deleteBlob(key, ev[i], b); err != nil {
+				return errors.Wrapf(err, "failed to delete blob")
+			}
+		}
+	case db.ErrNotExist:
+	default:
+		return err
+	}
+	b.Put(_hashHeightNS, _writeHeight, keyForBlock(height), "failed to put write height")
+	// the blobs above the height are exported again once expired
+	archiveHeight := atomic.LoadUint64(&bs.archiveHeight)
+	if height < archiveHeight {
+		archiveHeight = height
+		b.Put(_hashHeightNS, _archiveHeight, keyForBlock(height), "failed to put archive height")
+	}
+	if err := bs.kvStore.WriteBatch(b); err != nil {
+		return errors.Wrapf(err, "failed to write batch")
+	}
+	atomic.StoreUint64(&bs.currWriteBlock, height)
+	atomic.StoreUint64(&bs.archiveHeight, archiveHeight)
+	return nil
+}
+
+// putBlobHashes writes the mappings from the versioned blob hashes to the action hashes
+func (bs *blobStore) putBlobHashes(height uint64, pb *iotextypes.BlobTxSidecars, b batch.KVStoreBatch) {
+	var all []byte
+	for i, sc := range pb.GetSidecars() {
+		hasher := sha256.New()
+		for j := range sc.GetCommitments() {
+			var commitment kzg4844.Commitment
+			copy(commitment[:], sc.GetCommitments()[j])
+			h := kzg4844.CalcBlobHashV1(hasher, &commitment)
+			b.Put(_blobHashNS, h[:], pb.GetTxHash()[i], "failed to put blob hash to action hash mapping")
+			all = append(all, h[:]...)
+		}
+	}
+	b.Put(_heightBlobNS, keyForBlock(height), all, "failed to put height to blob hashes mapping")
+}
+
 func (bs *blobStore) putBlob(blob []byte, height uint64, txHash [][]byte, b batch.KVStoreBatch) {
+	bs.putBlobData(blob, height, txHash, b)
+	b.Put(_hashHeightNS, _writeHeight, keyForBlock(height), "failed to put write height")
+}
+
+func (bs *blobStore) putBlobData(blob []byte, height uint64, txHash [][]byte, b batch.KVStoreBatch) {
 	// write blob index
 	var (
 		key   = keyForBlock(height)
@@ -194,9 +397,8 @@ func (bs *blobStore) putBlob(blob []byte, height uint64, txHash [][]byte, b batc
 	for i := range txHash {
 		b.Put(_hashHeightNS, txHash[i], key, "failed to put hash to height mapping")
 	}
-	// write the blob data and height
+	// write the blob data
 	b.Put(_blobDataNS, key, blob, "failed to put blob")
-	b.Put(_hashHeightNS, _writeHeight, key, "failed to put write height")
 }
 
 func (bs *blobStore) deleteBlob(k []byte, v []byte, b batch.KVStoreBatch) error {
@@ -210,6 +412,18 @@ func (bs *blobStore) deleteBlob(k []byte, v []byte, b batch.KVStoreBatch) error
 	}
 	b.Delete(_heightIndexNS, k, "failed to delete index")
 	b.Delete(_blobDataNS, k, "failed to delete blob")
+	// delete versioned hash of expired blobs
+	blobHashes, err := bs.kvStore.Get(_heightBlobNS, k)
+	switch errors.Cause(err) {
+	case nil:
+		for i := 0; i+len(common.Hash{}) <= len(blobHashes); i += len(common.Hash{}) {
+			b.Delete(_blobHashNS, blobHashes[i:i+len(common.Hash{})], "failed to delete blob hash")
+		}
+		b.Delete(_heightBlobNS, k, "failed to delete blob hashes")
+	case db.ErrNotExist:
+	default:
+		return err
+	}
 
 	return nil
 }
@@ -263,6 +477,19 @@ func decodeBlob(raw []byte) ([]*types.BlobTxSidecar, []string, error) {
 	return blobs, hashes, nil
 }
 
+// blobHeight returns the height of the blobs of the action, which could have been archived
+func (bs *blobStore) blobHeight(actHash []byte) (uint64, error) {
+	height, err := bs.getHeightByHash(actHash)
+	if errors.Cause(err) != db.ErrNotExist || bs.archive == nil {
+		return height, err
+	}
+	v, err := bs.kvStore.Get(_archivedHashNS, actHash)
+	if err != nil {
+		return 0, err
+	}
+	return byteutil.BytesToUint64BigEndian(v), nil
+}
+
 func (bs *blobStore) getHeightByHash(h []byte) (uint64, error) {
 	height, err := bs.kvStore.Get(_hashHeightNS, h)
 	if err != nil {
@@ -10,6 +10,7 @@ import (
 	"encoding/hex"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/iotexproject/go-pkgs/crypto"
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/stretchr/testify/require"
@@ -158,6 +159,107 @@ func TestBlobStore(t *testing.T) {
 			r.Equal(h, hash)
 		}
 	})
+	t.Run("PutBlobs", func(t *testing.T) {
+		ctx := context.Background()
+		testPath, err := testutil.PathOfTempFile("test-blob-store")
+		r.NoError(err)
+		defer func() {
+			testutil.CleanupPath(testPath)
+		}()
+		cfg := db.DefaultConfig
+		cfg.DbPath = testPath
+		bs := NewBlobStore(db.NewBoltDB(cfg), 4)
+		r.NoError(bs.Start(ctx))
+		defer func() {
+			r.NoError(bs.Stop(ctx))
+		}()
+		blks, err := block.CreateTestBlockWithBlob(1, 6)
+		r.NoError(err)
+		// the blobs of block 2 are missing when it is stored
+		for i, blk := range blks[:3] {
+			if i == 1 {
+				r.NoError(bs.PutBlock(&block.Block{Header: blk.Header}))
+				continue
+			}
+			r.NoError(bs.PutBlock(blk))
+		}
+		vh := blks[0].Actions[3].BlobHashes()[0]
+		height, sc, txHash, err := bs.GetBlobByVersionedHash(vh)
+		r.NoError(err)
+		r.EqualValues(1, height)
+		r.Equal(blks[0].Actions[3].BlobTxSidecar(), sc)
+		h := MustNoErrorV(blks[0].Actions[3].Hash())
+		r.Equal(hex.EncodeToString(h[:]), txHash[2:])
+
+		missing := blks[1].Actions[1]
+		_, _, _, err = bs.GetBlobByVersionedHash(missing.BlobHashes()[0])
+		r.ErrorIs(err, db.ErrNotExist)
+		h = MustNoErrorV(missing.Hash())
+		r.ErrorContains(bs.PutBlobs(2, []*types.BlobTxSidecar{missing.BlobTxSidecar()}, nil), "mismatch")
+		r.NoError(bs.PutBlobs(2, []*types.BlobTxSidecar{missing.BlobTxSidecar()}, []hash.Hash256{h}))
+		// the blobs stored are not duplicated
+		r.NoError(bs.PutBlobs(2, []*types.BlobTxSidecar{missing.BlobTxSidecar()}, []hash.Hash256{h}))
+		height, sc, _, err = bs.GetBlobByVersionedHash(missing.BlobHashes()[0])
+		r.NoError(err)
+		r.EqualValues(2, height)
+		r.Equal(missing.BlobTxSidecar(), sc)
+		scs, _, err := bs.GetBlobsByHeight(2)
+		r.NoError(err)
+		r.Len(scs, 1)
+		// the write height is kept
+		r.EqualValues(3, bs.currWriteBlock)
+		r.ErrorContains(bs.PutBlobs(4, []*types.BlobTxSidecar{missing.BlobTxSidecar()}, []hash.Hash256{h}), "out of the retention window")
+
+		// the versioned hashes of the expired blobs are deleted
+		for _, blk := range blks[3:] {
+			r.NoError(bs.PutBlock(blk))
+		}
+		_, _, _, err = bs.GetBlobByVersionedHash(vh)
+		r.ErrorIs(err, db.ErrNotExist)
+		_, _, _, err = bs.GetBlobByVersionedHash(missing.BlobHashes()[0])
+		r.ErrorIs(err, db.ErrNotExist)
+		r.ErrorContains(bs.PutBlobs(2, []*types.BlobTxSidecar{missing.BlobTxSidecar()}, []hash.Hash256{h}), "out of the retention window")
+		_, _, _, err = bs.GetBlobByVersionedHash(blks[5].Actions[1].BlobHashes()[0])
+		r.NoError(err)
+	})
+	t.Run("SetTip", func(t *testing.T) {
+		ctx := context.Background()
+		testPath, err := testutil.PathOfTempFile("test-blob-store")
+		r.NoError(err)
+		defer func() {
+			testutil.CleanupPath(testPath)
+		}()
+		cfg := db.DefaultConfig
+		cfg.DbPath = testPath
+		bs := NewBlobStore(db.NewBoltDB(cfg), 24)
+		r.NoError(bs.Start(ctx))
+		blks, err := block.CreateTestBlockWithBlob(1, 5)
+		r.NoError(err)
+		for _, blk := range blks {
+			r.NoError(bs.PutBlock(blk))
+		}
+		r.NoError(bs.SetTip(2))
+		r.EqualValues(2, bs.currWriteBlock)
+		for _, blk := range blks {
+			scs, _, err := bs.GetBlobsByHeight(blk.Height())
+			if blk.Height() <= 2 {
+				r.NoError(err)
+				r.Len(scs, 2)
+				continue
+			}
+			r.ErrorIs(err, db.ErrNotExist)
+			_, _, _, err = bs.GetBlobByVersionedHash(blk.Actions[1].BlobHashes()[0])
+			r.ErrorIs(err, db.ErrNotExist)
+		}
+		// the write height is kept after restart
+		r.NoError(bs.Stop(ctx))
+		r.NoError(bs.Start(ctx))
+		r.EqualValues(2, bs.currWriteBlock)
+		for _, blk := range blks[2:] {
+			r.NoError(bs.PutBlock(blk))
+		}
+		r.NoError(bs.Stop(ctx))
+	})
 }
 
 func createTestHash(i int, height uint64) [][]byte {
@@ -34,6 +34,9 @@ var (
 		},
 		[]string{"result"},
 	)
+
+	// ErrNotRevertible indicates the indexer cannot be reverted to a lower height
+	ErrNotRevertible = errors.New("indexer cannot be reverted")
 )
 
 type (
@@ -61,6 +64,15 @@ type (
 		FooterByHeight(uint64) (*block.Footer, error)
 	}
 
+	// TipSetter sets the tip of the chain to a lower height
+	TipSetter interface {
+		SetTip(context.Context, uint64) error
+	}
+
+	tipDeleter interface {
+		DeleteTipBlock() error
+	}
+
 	blockDAO struct {
 		blockStore   BlockStore
 		blobStore    BlobStore
@@ -73,6 +85,8 @@ type (
 		blockCache   cache.LRUCache
 		txLogCache   cache.LRUCache
 		tipHeight    uint64
+		// cleanShutdownHeight is the tip height at which the node was shut down cleanly
+		cleanShutdownHeight uint64
 	}
 )
 
@@ -84,6 +98,14 @@ func WithBlobStore(bs BlobStore) Option {
 	}
 }
 
+// WithCleanShutdownHeight skips the catch-up of the indexers at start if the tip height is the height at which the
+// node was shut down cleanly, in which case the indexers are already up to date
+func WithCleanShutdownHeight(height uint64) Option {
+	return func(dao *blockDAO) {
+		dao.cleanShutdownHeight = height
+	}
+}
+
 // NewBlockDAOWithIndexersAndCache returns a BlockDAO with indexers which will consume blocks appended, and
 // caches which will speed up reading
 func NewBlockDAOWithIndexersAndCache(blkStore BlockStore, indexers []BlockIndexer, cacheSize int, opts ...Option) BlockDAO {
@@ -138,6 +160,10 @@ func (dao *blockDAO) Start(ctx context.Context) error {
 		return err
 	}
 	atomic.StoreUint64(&dao.tipHeight, tipHeight)
+	if dao.cleanShutdownHeight > 0 && dao.cleanShutdownHeight == tipHeight {
+		log.L().Info("Skip checking the indexers after the clean shutdown.", zap.Uint64("height", tipHeight))
+		return nil
+	}
 	return dao.checkIndexers(ctx)
 }
 
@@ -355,6 +381,68 @@ func (dao *blockDAO) PutBlock(ctx context.Context, blk *block.Block) error {
 	return nil
 }
 
+// SetTip truncates the blocks above the height, and reverts the indexers and the blob store to the height. It is an
+// offline operation, no block can be put at the same time. It fails before deleting any block if an indexer above the
+// height cannot be reverted, such an indexer has to be removed from the dao and rebuilt from the blocks afterwards
+func (dao *blockDAO) SetTip(ctx context.Context, height uint64) error {
+	store, ok := dao.blockStore.(tipDeleter)
+	if !ok {
+		return errors.New("block store does not support deleting blocks")
+	}
+	tip, err := dao.blockStore.Height()
+	if err != nil {
+		return err
+	}
+	if height > tip {
+		return errors.Errorf("height %d is higher than the tip height %d", height, tip)
+	}
+	for i, indexer := range dao.indexers {
+		indexerHeight, err := indexer.Height()
+		if err != nil {
+			return err
+		}
+		if _, ok := indexer.(BlockIndexerWithDeleteTip); !ok && indexerHeight > height {
+			return errors.Wrapf(ErrNotRevertible, "indexer %d is at height %d", i, indexerHeight)
+		}
+	}
+	for h := tip; h > height; h-- {
+		blk, err := dao.blockStore.GetBlockByHeight(h)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get block %d", h)
+		}
+		// revert the indexers in the reverse order of putting the block
+		for i := len(dao.indexers) - 1; i >= 0; i-- {
+			indexer := dao.indexers[i]
+			indexerHeight, err := indexer.Height()
+			if err != nil {
+				return err
+			}
+			if indexerHeight != h {
+				continue
+			}
+			if err := indexer.(BlockIndexerWithDeleteTip).DeleteTipBlock(ctx, blk); err != nil {
+				return errors.Wrapf(err, "failed to revert indexer %d at height %d", i, h)
+			}
+		}
+		if err := store.DeleteTipBlock(); err != nil {
+			return errors.Wrapf(err, "failed to delete block %d", h)
+		}
+		atomic.StoreUint64(&dao.tipHeight, h-1)
+		log.L().Info("Deleted tip block.", zap.Uint64("height", h))
+	}
+	if dao.blobStore != nil {
+		if err := dao.blobStore.SetTip(height); err != nil {
+			return errors.Wrap(err, "failed to delete blobs")
+		}
+	}
+	for _, c := range []cache.LRUCache{dao.headerCache, dao.footerCache, dao.receiptCache, dao.blockCache, dao.txLogCache} {
+		if c != nil {
+			c.Clear()
+		}
+	}
+	return nil
+}
+
 func (dao *blockDAO) GetBlob(h hash.Hash256) (*types.BlobTxSidecar, string, error) {
 	if dao.blobStore == nil {
 		return nil, "", errors.Wrap(db.ErrNotExist, "blob store is not available")
@@ -114,6 +114,21 @@ func Test_blockDAO_Start(t *testing.T) {
 		r.NoError(err)
 		r.Equal(blockdao.tipHeight, expectedHeight)
 	})
+	t.Run("CleanShutdown", func(t *testing.T) {
+		p := gomonkey.NewPatches()
+		defer p.Reset()
+
+		p.ApplyMethodReturn(&lifecycle.Lifecycle{}, "OnStart", nil)
+		p.ApplyPrivateMethod(&blockDAO{}, "checkIndexers", func(*blockDAO, context.Context) error { return errors.New(t.Name()) })
+		blockdao.cleanShutdownHeight = 2
+		defer func() { blockdao.cleanShutdownHeight = 0 }()
+
+		// the indexers are checked if the tip is not at the height of the shutdown
+		mockblockdao.EXPECT().Height().Return(uint64(3), nil).Times(1)
+		r.ErrorContains(blockdao.Start(context.Background()), t.Name())
+		mockblockdao.EXPECT().Height().Return(uint64(2), nil).Times(1)
+		r.NoError(blockdao.Start(context.Background()))
+	})
 }
 
 func Test_blockDAO_checkIndexers(t *testing.T) {
@@ -575,6 +590,92 @@ func Test_blockDAO_PutBlock(t *testing.T) {
 	})
 }
 
+type testRevertibleIndexer struct {
+	height uint64
+}
+
+func (ti *testRevertibleIndexer) Start(context.Context) error { return nil }
+
+func (ti *testRevertibleIndexer) Stop(context.Context) error { return nil }
+
+func (ti *testRevertibleIndexer) Height() (uint64, error) { return ti.height, nil }
+
+func (ti *testRevertibleIndexer) PutBlock(_ context.Context, blk *block.Block) error {
+	ti.height = blk.Height()
+	return nil
+}
+
+func (ti *testRevertibleIndexer) DeleteTipBlock(_ context.Context, blk *block.Block) error {
+	if blk.Height() != ti.height {
+		return errors.Errorf("block %d is not the tip %d", blk.Height(), ti.height)
+	}
+	ti.height--
+	return nil
+}
+
+func Test_blockDAO_SetTip(t *testing.T) {
+	r := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := protocol.WithBlockchainCtx(
+		genesis.WithGenesisContext(context.Background(), genesis.TestDefault()),
+		protocol.BlockchainCtx{ChainID: 1},
+	)
+	blks := getTestBlocks(t)
+	fd, err := filedao.NewFileDAOInMemForTest()
+	r.NoError(err)
+	indexer := &testRevertibleIndexer{}
+	dao := NewBlockDAOWithIndexersAndCache(fd, []BlockIndexer{indexer}, 16)
+	r.NoError(dao.Start(ctx))
+	defer func() {
+		r.NoError(dao.Stop(ctx))
+	}()
+	for _, blk := range blks {
+		r.NoError(dao.PutBlock(ctx, blk))
+	}
+	setter, ok := dao.(TipSetter)
+	r.True(ok)
+
+	t.Run("HigherThanTip", func(t *testing.T) {
+		r.ErrorContains(setter.SetTip(ctx, 4), "higher than the tip height")
+	})
+	t.Run("IndexerNotRevertible", func(t *testing.T) {
+		other := mock_blockdao.NewMockBlockIndexer(ctrl)
+		other.EXPECT().Height().Return(uint64(3), nil).Times(1)
+		d := &blockDAO{
+			blockStore: fd,
+			indexers:   []BlockIndexer{indexer, other},
+		}
+		r.ErrorIs(d.SetTip(ctx, 1), ErrNotRevertible)
+		height, err := dao.Height()
+		r.NoError(err)
+		r.EqualValues(3, height)
+	})
+	t.Run("Success", func(t *testing.T) {
+		// read the blocks into the cache
+		for _, blk := range blks {
+			_, err := dao.GetBlockByHeight(blk.Height())
+			r.NoError(err)
+		}
+		r.NoError(setter.SetTip(ctx, 1))
+		height, err := dao.Height()
+		r.NoError(err)
+		r.EqualValues(1, height)
+		r.EqualValues(1, indexer.height)
+		for _, blk := range blks[1:] {
+			_, err := dao.GetBlockByHeight(blk.Height())
+			r.Error(err)
+		}
+		// the deleted blocks can be put again
+		for _, blk := range blks[1:] {
+			r.NoError(dao.PutBlock(ctx, blk))
+		}
+		r.EqualValues(3, indexer.height)
+	})
+}
+
 func Test_lruCache(t *testing.T) {
 	r := require.New(t)
 
@@ -34,6 +34,13 @@ type (
 		StartHeight() uint64
 	}
 
+	// BlockIndexerWithDeleteTip defines an interface of block indexer which can be reverted block by block
+	BlockIndexerWithDeleteTip interface {
+		BlockIndexer
+		// DeleteTipBlock deletes the index of the block at the tip of the indexer
+		DeleteTipBlock(context.Context, *block.Block) error
+	}
+
 	// BlockIndexerChecker defines a checker of block indexer
 	BlockIndexerChecker struct {
 		dao BlockDAO
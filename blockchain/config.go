@@ -8,6 +8,7 @@ package blockchain
 import (
 	"crypto/ecdsa"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -39,6 +40,7 @@ type (
 		ContractStakingIndexDBPath string           `yaml:"contractStakingIndexDBPath"`
 		BlobStoreDBPath            string           `yaml:"blobStoreDBPath"`
 		BlobStoreRetentionDays     uint32           `yaml:"blobStoreRetentionDays"`
+		BlobStoreArchiveURI        string           `yaml:"blobStoreArchiveURI"`
 		HistoryIndexPath           string           `yaml:"historyIndexPath"`
 		ID                         uint32           `yaml:"id"`
 		EVMNetworkID               uint32           `yaml:"evmNetworkID"`
@@ -51,6 +53,13 @@ type (
 		GravityChainDB             db.Config        `yaml:"gravityChainDB"`
 		Committee                  committee.Config `yaml:"committee"`
 
+		// ProducerExternalKeys are the producer keys held outside of the node, i.e., in the KMS or the HSM, which are
+		// loaded by the schema awsKMS, gcpKMS or pkcs11
+		ProducerExternalKeys []crypto.PrivateKey `yaml:"-"`
+		// ProducerKeystorePassphraseFile is the file of the passphrase decrypting the keystores of the schema keystore,
+		// which is read if the env IOTEX_PRODUCER_KEYSTORE_PASSPHRASE is not set
+		ProducerKeystorePassphraseFile string `yaml:"producerKeystorePassphraseFile"`
+
 		// EnableTrielessStateDB enables trieless state db (deprecated)
 		EnableTrielessStateDB bool `yaml:"enableTrielessStateDB"`
 		// EnableStateDBCaching enables cachedStateDBOption
@@ -76,6 +85,14 @@ type (
 		StateDBCacheSize int `yaml:"stateDBCacheSize"`
 		// WorkingSetCacheSize is the max size of workingset cache in state factory
 		WorkingSetCacheSize uint64 `yaml:"workingSetCacheSize"`
+		// WorkingSetPinLimit is the max number of workingsets pinned in the cache, which are exempt from eviction
+		WorkingSetPinLimit uint64 `yaml:"workingSetPinLimit"`
+		// TrieNodeCacheSize is the max number of the decoded trie nodes cached and shared by all the working sets, 0
+		// disables the cache
+		TrieNodeCacheSize int `yaml:"trieNodeCacheSize"`
+		// EnableAccountTrie maintains a merkle patricia trie of the accounts in state factory to serve the proofs of
+		// the accounts, the proofs are available at the heights since the trie is enabled
+		EnableAccountTrie bool `yaml:"enableAccountTrie"`
 		// StreamingBlockBufferSize
 		StreamingBlockBufferSize uint64 `yaml:"streamingBlockBufferSize"`
 		// PersistStakingPatchBlock is the block to persist staking patch
@@ -84,6 +101,9 @@ type (
 		FixAliasForNonStopHeight uint64 `yaml:"fixAliasForNonStopHeight"`
 		// FactoryDBType is the type of factory db
 		FactoryDBType string `yaml:"factoryDBType"`
+		// CommitBuildInfo commits the node version and the hash of the enabled features in the extra data of the
+		// minted blocks, once the block extra data is enabled
+		CommitBuildInfo bool `yaml:"commitBuildInfo"`
 		// MintTimeout is the timeout for minting
 		MintTimeout time.Duration `yaml:"-"`
 	}
@@ -126,6 +146,8 @@ var (
 		PollInitialCandidatesInterval: 10 * time.Second,
 		StateDBCacheSize:              1000,
 		WorkingSetCacheSize:           20,
+		WorkingSetPinLimit:            8,
+		TrieNodeCacheSize:             50000,
 		StreamingBlockBufferSize:      200,
 		PersistStakingPatchBlock:      19778037,
 		FixAliasForNonStopHeight:      19778036,
@@ -144,7 +166,7 @@ func (cfg *Config) ProducerAddress() []address.Address {
 	for _, sk := range privateKeys {
 		addr := sk.PublicKey().Address()
 		if addr == nil {
-			log.L().Panic("Error when constructing producer address")
+			log.Logger("blockchain").Panic("Error when constructing producer address")
 		}
 		addrs = append(addrs, addr)
 	}
@@ -153,54 +175,73 @@ func (cfg *Config) ProducerAddress() []address.Address {
 
 // ProducerPrivateKeys returns the configured private keys
 func (cfg *Config) ProducerPrivateKeys() []crypto.PrivateKey {
-	pks := strings.Split(cfg.ProducerPrivKey, ",")
-	if len(pks) == 0 {
-		log.L().Panic("Error when decoding private key")
+	privateKeys, err := cfg.producerPrivateKeys()
+	if err != nil {
+		log.Logger("blockchain").Panic("Error when loading producer private keys", zap.Error(err))
 	}
-	privateKeys := make([]crypto.PrivateKey, 0, len(pks))
-	for _, pk := range pks {
-		sk, err := crypto.HexStringToPrivateKey(pk)
-		if err != nil {
-			log.L().Panic(
-				"Error when decoding private key",
-				zap.Error(err),
-			)
-		}
+	return privateKeys
+}
 
-		if !cfg.whitelistSignatureScheme(sk) {
-			log.L().Panic("The private key's signature scheme is not whitelisted")
+// ValidateProducerPrivateKeys validates the producer private keys, their signature schemes and the range
+func (cfg *Config) ValidateProducerPrivateKeys() error {
+	_, err := cfg.producerPrivateKeys()
+	return err
+}
+
+func (cfg *Config) producerPrivateKeys() ([]crypto.PrivateKey, error) {
+	privateKeys := cfg.ProducerExternalKeys
+	if len(privateKeys) == 0 {
+		var err error
+		if privateKeys, err = cfg.producerHexKeys(); err != nil {
+			return nil, err
 		}
-		privateKeys = append(privateKeys, sk)
 	}
 
 	if cfg.ProducerPrivKeyRange == "" {
-		return privateKeys
+		return privateKeys, nil
 	}
 	// Expecting format "[$start:$end]"
 	r := strings.Trim(cfg.ProducerPrivKeyRange, "[]")
 	parts := strings.Split(r, ":")
 	if len(parts) != 2 {
-		log.L().Panic("invalid format", zap.String("ProducerPrivKeyRange", cfg.ProducerPrivKeyRange))
+		return nil, errors.Wrapf(ErrConfig, "invalid format of producer private key range %s", cfg.ProducerPrivKeyRange)
 	}
 	start, end := 0, len(privateKeys)
 	var err error
 	if parts[0] != "" {
 		start, err = strconv.Atoi(parts[0])
 		if err != nil {
-			log.L().Panic("invalid start", zap.String("start", parts[0]), zap.Error(err))
+			return nil, errors.Wrapf(ErrConfig, "invalid start %s of producer private key range", parts[0])
 		}
 	}
 	if parts[1] != "" {
 		end, err = strconv.Atoi(parts[1])
 		if err != nil {
-			log.L().Panic("invalid end", zap.String("end", parts[1]), zap.Error(err))
+			return nil, errors.Wrapf(ErrConfig, "invalid end %s of producer private key range", parts[1])
 		}
 	}
 	if start < 0 || end > len(privateKeys) || start > end {
-		log.L().Panic("ProducerPrivKeyRange out of bounds", zap.Int("start", start), zap.Int("end", end), zap.Int("len", len(privateKeys)))
+		return nil, errors.Wrapf(ErrConfig, "producer private key range [%d:%d] out of bounds of %d keys", start, end, len(privateKeys))
 	}
 
-	return privateKeys[start:end]
+	return privateKeys[start:end], nil
+}
+
+func (cfg *Config) producerHexKeys() ([]crypto.PrivateKey, error) {
+	pks := strings.Split(cfg.ProducerPrivKey, ",")
+	privateKeys := make([]crypto.PrivateKey, 0, len(pks))
+	for i, pk := range pks {
+		sk, err := crypto.HexStringToPrivateKey(pk)
+		if err != nil {
+			return nil, errors.Wrapf(ErrConfig, "failed to decode producer private key #%d: %v", i, err)
+		}
+
+		if !cfg.whitelistSignatureScheme(sk) {
+			return nil, errors.Wrapf(ErrConfig, "signature scheme of producer private key #%d is not whitelisted", i)
+		}
+		privateKeys = append(privateKeys, sk)
+	}
+	return privateKeys, nil
 }
 
 // SetProducerPrivKey set producer privKey by PrivKeyConfigFile info
@@ -227,6 +268,28 @@ func (cfg *Config) SetProducerPrivKey() error {
 			return errors.Wrap(err, "failed to load producer private key")
 		}
 		cfg.ProducerPrivKey = key
+	case "keystore":
+		// the producer private keys are the comma separated paths of the encrypted keystores
+		key, err := loadKeystoreKeys(strings.Split(cfg.ProducerPrivKey, ","), cfg.ProducerKeystorePassphraseFile)
+		if err != nil {
+			return errors.Wrap(err, "failed to load producer private key from keystore")
+		}
+		cfg.ProducerPrivKey = key
+	case "awsKMS", "gcpKMS", "pkcs11":
+		// the signing requests are forwarded to the KMS or the HSM, so the keys are secp256k1 keys never loaded into
+		// memory
+		if !slices.Contains(cfg.SignatureScheme, SigP256k1) {
+			return errors.Wrap(ErrConfig, "secp256k1 signature scheme is not whitelisted")
+		}
+		yaml, err := config.NewYAML(config.Expand(os.LookupEnv), config.File(cfg.ProducerPrivKey))
+		if err != nil {
+			return errors.Wrap(err, "failed to init private key config")
+		}
+		keys, err := loadExternalKeys(cfg.ProducerPrivKeySchema, yaml.Get(config.Root))
+		if err != nil {
+			return errors.Wrap(err, "failed to load producer external keys")
+		}
+		cfg.ProducerExternalKeys = keys
 	default:
 		return errors.Wrap(ErrConfig, "invalid private key schema")
 	}
@@ -6,16 +6,51 @@
 package blockchain
 
 import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/hashicorp/vault/api"
+	"github.com/iotexproject/go-pkgs/crypto"
 	"github.com/pkg/errors"
+	"go.uber.org/config"
+	"golang.org/x/term"
+
+	"github.com/iotexproject/iotex-core/v2/crypto/hsm"
+	"github.com/iotexproject/iotex-core/v2/crypto/kms"
 )
 
-const defaultHTTPTimeout = 10 * time.Second
+const (
+	defaultHTTPTimeout = 10 * time.Second
+
+	// _keystorePassphraseEnv is the env of the passphrase decrypting the producer keystores
+	_keystorePassphraseEnv = "IOTEX_PRODUCER_KEYSTORE_PASSPHRASE"
+)
 
-// ErrVault vault error
-var ErrVault = errors.New("vault error")
+var (
+	// ErrVault vault error
+	ErrVault = errors.New("vault error")
+
+	// _readPassphrase prompts for the passphrase on the terminal
+	_readPassphrase = func(prompt string) (string, error) {
+		fd := int(os.Stdin.Fd())
+		if !term.IsTerminal(fd) {
+			return "", errors.Wrapf(ErrConfig, "passphrase is required, set env %s or the passphrase file", _keystorePassphraseEnv)
+		}
+		fmt.Fprint(os.Stderr, prompt)
+		b, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to read passphrase")
+		}
+		return string(b), nil
+	}
+)
 
 type (
 	hashiCorpVault struct {
@@ -74,3 +109,89 @@ func newVaultPrivKeyLoader(cfg *hashiCorpVault) (*vaultPrivKeyLoader, error) {
 		cfg:         cfg,
 	}, nil
 }
+
+// loadExternalKeys loads the keys held in the KMS or the HSM of the schema, whose config is the value
+func loadExternalKeys(schema string, value config.Value) ([]crypto.PrivateKey, error) {
+	var clients []kms.Client
+	switch schema {
+	case "awsKMS":
+		cfg := kms.AWSConfig{}
+		if err := value.Populate(&cfg); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal YAML config to aws kms config")
+		}
+		for _, id := range cfg.KeyIDs {
+			cli, err := kms.NewAWSClient(cfg, id)
+			if err != nil {
+				return nil, err
+			}
+			clients = append(clients, cli)
+		}
+	case "gcpKMS":
+		cfg := kms.GCPConfig{}
+		if err := value.Populate(&cfg); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal YAML config to gcp kms config")
+		}
+		var err error
+		clients, err = kms.NewGCPClients(cfg)
+		if err != nil {
+			return nil, err
+		}
+	case "pkcs11":
+		cfg := hsm.Config{}
+		if err := value.Populate(&cfg); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal YAML config to pkcs11 config")
+		}
+		return hsm.NewPrivateKeys(cfg)
+	default:
+		return nil, errors.Wrapf(ErrConfig, "invalid kms schema %s", schema)
+	}
+	if len(clients) == 0 {
+		return nil, errors.Wrap(ErrConfig, "no kms key is set")
+	}
+	keys := make([]crypto.PrivateKey, 0, len(clients))
+	for _, cli := range clients {
+		sk, err := kms.NewPrivateKey(cli)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, sk)
+	}
+	return keys, nil
+}
+
+// loadKeystoreKeys decrypts the Ethereum-style encrypted JSON keystores with the same passphrase, and returns the
+// comma separated hex of the keys
+func loadKeystoreKeys(paths []string, passphraseFile string) (string, error) {
+	passphrase, err := keystorePassphrase(passphraseFile)
+	if err != nil {
+		return "", err
+	}
+	keys := make([]string, 0, len(paths))
+	for _, path := range paths {
+		keyJSON, err := os.ReadFile(filepath.Clean(strings.TrimSpace(path)))
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to read keystore %s", path)
+		}
+		key, err := keystore.DecryptKey(keyJSON, passphrase)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to decrypt keystore %s", path)
+		}
+		keys = append(keys, hex.EncodeToString(ethcrypto.FromECDSA(key.PrivateKey)))
+	}
+	return strings.Join(keys, ","), nil
+}
+
+// keystorePassphrase reads the passphrase from the env, the file or the terminal in order
+func keystorePassphrase(passphraseFile string) (string, error) {
+	if passphrase, ok := os.LookupEnv(_keystorePassphraseEnv); ok {
+		return passphrase, nil
+	}
+	if passphraseFile != "" {
+		b, err := os.ReadFile(filepath.Clean(passphraseFile))
+		if err != nil {
+			return "", errors.Wrap(err, "failed to read passphrase file")
+		}
+		return strings.TrimRight(string(b), "\r\n"), nil
+	}
+	return _readPassphrase("Enter the passphrase of the producer keystore: ")
+}
@@ -6,10 +6,26 @@
 package blockchain
 
 import (
+	"crypto/ecdsa"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"hash/crc32"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strconv"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/hashicorp/vault/api"
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
@@ -116,4 +132,137 @@ func TestSetProducerPrivKey(t *testing.T) {
 		err = cfg.SetProducerPrivKey()
 		r.Contains(err.Error(), "dial tcp 127.0.0.1:8200: connect: connection refused")
 	})
+	t.Run("PrivateConfigFileHasKMS", func(t *testing.T) {
+		sk, err := crypto.GenerateKey()
+		r.NoError(err)
+		name := "projects/p/locations/global/keyRings/r/cryptoKeys/k/cryptoKeyVersions/1"
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			switch req.URL.Path {
+			case "/v1/" + name + "/publicKey":
+				params, _ := asn1.Marshal(asn1.ObjectIdentifier{1, 3, 132, 0, 10})
+				spki, _ := asn1.Marshal(struct {
+					Algorithm pkix.AlgorithmIdentifier
+					PublicKey asn1.BitString
+				}{
+					Algorithm: pkix.AlgorithmIdentifier{
+						Algorithm:  asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1},
+						Parameters: asn1.RawValue{FullBytes: params},
+					},
+					PublicKey: asn1.BitString{Bytes: sk.PublicKey().Bytes(), BitLength: 520},
+				})
+				b := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: spki})
+				r.NoError(json.NewEncoder(w).Encode(map[string]string{
+					"pem":       string(b),
+					"algorithm": "EC_SIGN_SECP256K1_SHA256",
+					"pemCrc32c": crc32c(b),
+				}))
+			case "/v1/" + name + ":asymmetricSign":
+				var body struct {
+					Digest struct {
+						Sha256 []byte `json:"sha256"`
+					} `json:"digest"`
+				}
+				r.NoError(json.NewDecoder(req.Body).Decode(&body))
+				sig, err := ethcrypto.Sign(body.Digest.Sha256, sk.EcdsaPrivateKey().(*ecdsa.PrivateKey))
+				r.NoError(err)
+				der, _ := asn1.Marshal(struct{ R, S *big.Int }{new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:64])})
+				r.NoError(json.NewEncoder(w).Encode(map[string]any{
+					"signature":            der,
+					"signatureCrc32c":      crc32c(der),
+					"verifiedDigestCrc32c": true,
+					"name":                 name,
+				}))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		defer srv.Close()
+
+		cfg := DefaultConfig
+		tmp, err := os.CreateTemp("", testfile)
+		r.NoError(err)
+		defer os.Remove(tmp.Name())
+		_, err = fmt.Fprintf(tmp, "accessToken: token\nendpoint: %s\nkeyVersions:\n  - %s\n", srv.URL, name)
+		r.NoError(err)
+		r.NoError(tmp.Close())
+		cfg.ProducerPrivKey = tmp.Name()
+		cfg.ProducerPrivKeySchema = "gcpKMS"
+		r.NoError(cfg.SetProducerPrivKey())
+		r.Equal(tmp.Name(), cfg.ProducerPrivKey)
+		keys := cfg.ProducerPrivateKeys()
+		r.Len(keys, 1)
+		r.Equal(sk.PublicKey().Address().String(), cfg.ProducerAddress()[0].String())
+		h := hash.Hash256b([]byte("block"))
+		sig, err := keys[0].Sign(h[:])
+		r.NoError(err)
+		r.True(sk.PublicKey().Verify(h[:], sig))
+
+		cfg = DefaultConfig
+		r.NoError(os.WriteFile(tmp.Name(), []byte("keyIDs:\n  - alias/producer\n"), 0600))
+		cfg.ProducerPrivKey = tmp.Name()
+		cfg.ProducerPrivKeySchema = "awsKMS"
+		t.Setenv("AWS_REGION", "")
+		t.Setenv("AWS_DEFAULT_REGION", "")
+		t.Setenv("AWS_CONFIG_FILE", filepath.Join(t.TempDir(), "config"))
+		r.ErrorContains(cfg.SetProducerPrivKey(), "aws region is not set")
+	})
+	t.Run("PrivateConfigFileHasKeystore", func(t *testing.T) {
+		dir := t.TempDir()
+		ks := keystore.NewKeyStore(dir, keystore.LightScryptN, keystore.LightScryptP)
+		var (
+			paths []string
+			sks   []crypto.PrivateKey
+		)
+		for i := 0; i < 2; i++ {
+			sk, err := crypto.GenerateKey()
+			r.NoError(err)
+			acc, err := ks.ImportECDSA(sk.EcdsaPrivateKey().(*ecdsa.PrivateKey), "passphrase")
+			r.NoError(err)
+			paths = append(paths, acc.URL.Path)
+			sks = append(sks, sk)
+		}
+		passphraseFile := filepath.Join(dir, "passphrase")
+		r.NoError(os.WriteFile(passphraseFile, []byte("passphrase\n"), 0600))
+
+		// passphrase from the file
+		cfg := DefaultConfig
+		cfg.ProducerPrivKey = paths[0] + "," + paths[1]
+		cfg.ProducerPrivKeySchema = "keystore"
+		cfg.ProducerKeystorePassphraseFile = passphraseFile
+		r.NoError(cfg.SetProducerPrivKey())
+		r.Equal(sks[0].HexString()+","+sks[1].HexString(), cfg.ProducerPrivKey)
+		r.Len(cfg.ProducerPrivateKeys(), 2)
+
+		// passphrase from the env takes precedence
+		t.Setenv(_keystorePassphraseEnv, "wrong")
+		cfg = DefaultConfig
+		cfg.ProducerPrivKey = paths[0]
+		cfg.ProducerPrivKeySchema = "keystore"
+		cfg.ProducerKeystorePassphraseFile = passphraseFile
+		r.ErrorIs(cfg.SetProducerPrivKey(), keystore.ErrDecrypt)
+		t.Setenv(_keystorePassphraseEnv, "passphrase")
+		r.NoError(cfg.SetProducerPrivKey())
+		r.Equal(sks[0].HexString(), cfg.ProducerPrivKey)
+		r.NoError(os.Unsetenv(_keystorePassphraseEnv))
+
+		// passphrase from the prompt
+		readPassphrase := _readPassphrase
+		defer func() { _readPassphrase = readPassphrase }()
+		_readPassphrase = func(string) (string, error) { return "passphrase", nil }
+		cfg = DefaultConfig
+		cfg.ProducerPrivKey = paths[1]
+		cfg.ProducerPrivKeySchema = "keystore"
+		r.NoError(cfg.SetProducerPrivKey())
+		r.Equal(sks[1].HexString(), cfg.ProducerPrivKey)
+
+		cfg = DefaultConfig
+		cfg.ProducerPrivKey = filepath.Join(dir, "nonexistent")
+		cfg.ProducerPrivKeySchema = "keystore"
+		r.ErrorContains(cfg.SetProducerPrivKey(), "failed to read keystore")
+	})
+}
+
+// crc32c returns the CRC32C checksum in the decimal string encoded by Cloud KMS
+func crc32c(b []byte) string {
+	return strconv.FormatUint(uint64(crc32.Checksum(b, crc32.MakeTable(crc32.Castagnoli))), 10)
 }
@@ -0,0 +1,207 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package genesis
+
+import (
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-address/address"
+)
+
+// _lifeLongPollMode is the poll mode in which the delegates are fixed to the ones in the genesis
+const _lifeLongPollMode = "lifeLong"
+
+type (
+	// Builder builds the genesis config of a new network programmatically, e.g., a local devnet. The network starts
+	// with the delegates registered in the genesis rather than reading the votes from the gravity chain, e.g.,
+	//
+	//	g, err := NewBuilder().
+	//		FundAccount(addr, amount).
+	//		AddDelegate(operator, reward, votes).
+	//		ActivateAllAt(1).
+	//		Build()
+	Builder struct {
+		g   Genesis
+		err error
+	}
+
+	featureHeight struct {
+		name   string
+		height *uint64
+	}
+)
+
+// NewBuilder creates a genesis builder starting from the default genesis, without any initial balance and delegate
+func NewBuilder() *Builder {
+	g := defaultConfig()
+	g.InitBalanceMap = map[string]string{}
+	g.Delegates = []Delegate{}
+	g.PollMode = _lifeLongPollMode
+	g.EnableGravityChainVoting = false
+	return &Builder{g: g}
+}
+
+// FundAccount adds the amount in rau to the initial balance of the account
+func (b *Builder) FundAccount(addr string, amount *big.Int) *Builder {
+	if b.err != nil {
+		return b
+	}
+	if _, err := address.FromString(addr); err != nil {
+		b.err = errors.Wrapf(err, "invalid account %s", addr)
+		return b
+	}
+	if amount == nil || amount.Sign() < 0 {
+		b.err = errors.Errorf("invalid balance %v of account %s", amount, addr)
+		return b
+	}
+	balance := new(big.Int).Set(amount)
+	if prev, ok := b.g.InitBalanceMap[addr]; ok {
+		p, _ := new(big.Int).SetString(prev, 10)
+		balance.Add(balance, p)
+	}
+	b.g.InitBalanceMap[addr] = balance.String()
+	return b
+}
+
+// AddDelegate registers a delegate producing the blocks from the genesis, with the votes to rank the delegates
+func (b *Builder) AddDelegate(operator, reward string, votes *big.Int) *Builder {
+	if b.err != nil {
+		return b
+	}
+	for _, addr := range []string{operator, reward} {
+		if _, err := address.FromString(addr); err != nil {
+			b.err = errors.Wrapf(err, "invalid delegate address %s", addr)
+			return b
+		}
+	}
+	if votes == nil || votes.Sign() <= 0 {
+		b.err = errors.Errorf("invalid votes %v of delegate %s", votes, operator)
+		return b
+	}
+	for _, d := range b.g.Delegates {
+		if d.OperatorAddrStr == operator {
+			b.err = errors.Errorf("delegate %s is registered twice", operator)
+			return b
+		}
+	}
+	b.g.Delegates = append(b.g.Delegates, Delegate{
+		OperatorAddrStr: operator,
+		RewardAddrStr:   reward,
+		VotesStr:        votes.String(),
+	})
+	return b
+}
+
+// SetNumDelegates sets the number of the delegates producing the blocks in an epoch
+func (b *Builder) SetNumDelegates(n uint64) *Builder {
+	b.g.NumDelegates = n
+	b.g.NumCandidateDelegates = n
+	return b
+}
+
+// SetNumSubEpochs sets the number of the sub epochs in an epoch, before and after the upgrades changing it
+func (b *Builder) SetNumSubEpochs(n uint64) *Builder {
+	b.g.NumSubEpochs = n
+	b.g.DardanellesNumSubEpochs = n
+	b.g.WakeNumSubEpochs = n
+	return b
+}
+
+// SetBlockInterval sets the block interval before the Dardanelles height, the interval after the upgrades is set by
+// the consensus config of the nodes
+func (b *Builder) SetBlockInterval(interval time.Duration) *Builder {
+	b.g.BlockInterval = interval
+	return b
+}
+
+// SetTimestamp sets the timestamp of the genesis block
+func (b *Builder) SetTimestamp(ts int64) *Builder {
+	b.g.Timestamp = ts
+	return b
+}
+
+// SetFeatureHeight sets the start height of the feature by its name, e.g., "wake" for WakeBlockHeight
+func (b *Builder) SetFeatureHeight(name string, height uint64) *Builder {
+	if b.err != nil {
+		return b
+	}
+	for _, f := range featureHeights(&b.g.Blockchain) {
+		if strings.EqualFold(f.name, name) {
+			*f.height = height
+			return b
+		}
+	}
+	b.err = errors.Errorf("unknown feature %s", name)
+	return b
+}
+
+// ActivateAllAt sets the start height of all the released features to the height
+func (b *Builder) ActivateAllAt(height uint64) *Builder {
+	for _, f := range featureHeights(&b.g.Blockchain) {
+		*f.height = height
+	}
+	return b
+}
+
+// Build validates and returns the genesis config
+func (b *Builder) Build() (Genesis, error) {
+	if b.err != nil {
+		return Genesis{}, b.err
+	}
+	if uint64(len(b.g.Delegates)) < b.g.NumDelegates {
+		return Genesis{}, errors.Errorf("%d delegates are registered, fewer than %d", len(b.g.Delegates), b.g.NumDelegates)
+	}
+	heights := featureHeights(&b.g.Blockchain)
+	for i := 1; i < len(heights); i++ {
+		if *heights[i-1].height > *heights[i].height {
+			return Genesis{}, errors.Errorf("%s height %d is higher than %s height %d",
+				heights[i-1].name, *heights[i-1].height, heights[i].name, *heights[i].height)
+		}
+	}
+	g := b.g
+	g.InitBalanceMap = make(map[string]string, len(b.g.InitBalanceMap))
+	for addr, balance := range b.g.InitBalanceMap {
+		g.InitBalanceMap[addr] = balance
+	}
+	g.Delegates = append([]Delegate{}, b.g.Delegates...)
+	return g, nil
+}
+
+// featureHeights returns the start heights of the released features in the order of the upgrades
+func featureHeights(bc *Blockchain) []featureHeight {
+	return []featureHeight{
+		{"pacific", &bc.PacificBlockHeight},
+		{"aleutian", &bc.AleutianBlockHeight},
+		{"bering", &bc.BeringBlockHeight},
+		{"cook", &bc.CookBlockHeight},
+		{"dardanelles", &bc.DardanellesBlockHeight},
+		{"daytona", &bc.DaytonaBlockHeight},
+		{"easter", &bc.EasterBlockHeight},
+		{"fbkMigration", &bc.FbkMigrationBlockHeight},
+		{"fairbank", &bc.FairbankBlockHeight},
+		{"greenland", &bc.GreenlandBlockHeight},
+		{"hawaii", &bc.HawaiiBlockHeight},
+		{"iceland", &bc.IcelandBlockHeight},
+		{"jutland", &bc.JutlandBlockHeight},
+		{"kamchatka", &bc.KamchatkaBlockHeight},
+		{"lordHowe", &bc.LordHoweBlockHeight},
+		{"midway", &bc.MidwayBlockHeight},
+		{"newfoundland", &bc.NewfoundlandBlockHeight},
+		{"okhotsk", &bc.OkhotskBlockHeight},
+		{"palau", &bc.PalauBlockHeight},
+		{"quebec", &bc.QuebecBlockHeight},
+		{"redsea", &bc.RedseaBlockHeight},
+		{"sumatra", &bc.SumatraBlockHeight},
+		{"tsunami", &bc.TsunamiBlockHeight},
+		{"upernavik", &bc.UpernavikBlockHeight},
+		{"vanuatu", &bc.VanuatuBlockHeight},
+		{"wake", &bc.WakeBlockHeight},
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package genesis
+
+import (
+	"math"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+func TestBuilder(t *testing.T) {
+	r := require.New(t)
+	addr0, addr1 := identityset.Address(0).String(), identityset.Address(1).String()
+
+	t.Run("Build", func(t *testing.T) {
+		g, err := NewBuilder().
+			FundAccount(addr0, big.NewInt(100)).
+			FundAccount(addr0, big.NewInt(20)).
+			FundAccount(addr1, big.NewInt(1)).
+			AddDelegate(addr0, addr1, big.NewInt(10)).
+			SetNumDelegates(1).
+			SetNumSubEpochs(2).
+			SetBlockInterval(time.Second).
+			ActivateAllAt(1).
+			SetFeatureHeight("Wake", 5).
+			Build()
+		r.NoError(err)
+		r.Equal(map[string]string{addr0: "120", addr1: "1"}, g.InitBalanceMap)
+		r.Equal([]Delegate{{addr0, addr1, "10"}}, g.Delegates)
+		r.Equal("lifeLong", g.PollMode)
+		r.False(g.EnableGravityChainVoting)
+		r.Equal(uint64(1), g.NumDelegates)
+		r.Equal(uint64(2), g.WakeNumSubEpochs)
+		r.Equal(time.Second, g.BlockInterval)
+		r.Equal(uint64(1), g.PacificBlockHeight)
+		r.Equal(uint64(1), g.VanuatuBlockHeight)
+		r.Equal(uint64(5), g.WakeBlockHeight)
+		r.Equal(uint64(math.MaxUint64), g.ToBeEnabledBlockHeight)
+
+		// the genesis written into the yaml is loaded as is
+		b, err := yaml.Marshal(&g)
+		r.NoError(err)
+		path := filepath.Join(t.TempDir(), "genesis.yaml")
+		r.NoError(os.WriteFile(path, b, 0600))
+		loaded, err := New(path)
+		r.NoError(err)
+		r.Equal(g.Hash(), loaded.Hash())
+		r.Equal(g.InitBalanceMap, loaded.InitBalanceMap)
+		r.Equal(g.BlockInterval, loaded.BlockInterval)
+		r.Equal(g.WakeBlockHeight, loaded.WakeBlockHeight)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		for _, b := range []*Builder{
+			NewBuilder().FundAccount("io1invalid", big.NewInt(1)),
+			NewBuilder().FundAccount(addr0, big.NewInt(-1)),
+			NewBuilder().AddDelegate(addr0, addr0, big.NewInt(0)),
+			NewBuilder().AddDelegate(addr0, addr0, big.NewInt(1)).AddDelegate(addr0, addr1, big.NewInt(1)),
+			NewBuilder().SetFeatureHeight("atlantis", 1),
+			NewBuilder().AddDelegate(addr0, addr0, big.NewInt(1)).SetNumDelegates(2),
+			NewBuilder().SetNumDelegates(0).ActivateAllAt(1).SetFeatureHeight("pacific", 2),
+		} {
+			_, err := b.Build()
+			r.Error(err)
+		}
+	})
+}
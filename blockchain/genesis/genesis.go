@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/pkg/errors"
 	"go.uber.org/config"
 	"go.uber.org/zap"
@@ -51,7 +52,11 @@ func defaultConfig() Genesis {
 			NumDelegates:              24,
 			NumCandidateDelegates:     36,
 			TimeBasedRotation:         true,
+			StandbyDelay:              300 * time.Millisecond,
 			MinBlocksForBlobRetention: 345600,
+			TargetBlobsPerBlock:       3,
+			MaxBlobsPerBlock:          6,
+			BlobBaseFeeUpdateFraction: 3338477,
 			PacificBlockHeight:        432001,
 			AleutianBlockHeight:       864001,
 			BeringBlockHeight:         1512001,
@@ -175,6 +180,15 @@ func defaultConfig() Genesis {
 			BootstrapCandidates:              []BootstrapCandidate{},
 			EndorsementWithdrawWaitingBlocks: 24 * 60 * 60 / 5,
 		},
+		Bridge: Bridge{
+			BridgeRelayers: []string{},
+		},
+		Governance: Governance{
+			Governors: []string{},
+		},
+		FeeToken: FeeToken{
+			FeeTokens: []string{},
+		},
 	}
 }
 
@@ -246,6 +260,9 @@ type (
 		Poll       `yaml:"poll"`
 		Rewarding  `yaml:"rewarding"`
 		Staking    `yaml:"staking"`
+		Bridge     `yaml:"bridge"`
+		Governance `yaml:"governance"`
+		FeeToken   `yaml:"feeToken"`
 	}
 	// Blockchain contains blockchain level configs
 	Blockchain struct {
@@ -273,8 +290,21 @@ type (
 		NumCandidateDelegates uint64 `yaml:"numCandidateDelegates"`
 		// TimeBasedRotation is the flag to enable rotating delegates' time slots on a block height
 		TimeBasedRotation bool `yaml:"timeBasedRotation"`
+		// StandbyProposers is the number of the proposers following the proposer of a round, which propose in turn
+		// after StandbyDelay each if the proposer misses its slot, at or after ToBeEnabledBlockHeight. It is disabled
+		// if it is 0
+		StandbyProposers uint64        `yaml:"standbyProposers"`
+		StandbyDelay     time.Duration `yaml:"standbyDelay"`
 		// MinBlocksForBlobRetention is the minimum number of blocks for blob retention
 		MinBlocksForBlobRetention uint64 `yaml:"minBlocksForBlobRetention"`
+		// TargetBlobsPerBlock is the target number of blobs per block, the blob base fee rises when the blobs in
+		// the parent block exceed the target, and falls otherwise
+		TargetBlobsPerBlock uint64 `yaml:"targetBlobsPerBlock"`
+		// MaxBlobsPerBlock is the maximum number of blobs per block
+		MaxBlobsPerBlock uint64 `yaml:"maxBlobsPerBlock"`
+		// BlobBaseFeeUpdateFraction controls the rate of change of the blob base fee, independent of the base fee of
+		// the execution gas
+		BlobBaseFeeUpdateFraction uint64 `yaml:"blobBaseFeeUpdateFraction"`
 		// PacificBlockHeight is the start height of using the logic of Pacific version
 		// TODO: PacificBlockHeight is not added into protobuf definition for backward compatibility
 		PacificBlockHeight uint64 `yaml:"pacificHeight"`
@@ -482,6 +512,36 @@ type (
 		EndorsementWithdrawWaitingBlocks uint64               `yaml:"endorsementWithdrawWaitingBlocks"`
 	}
 
+	// Bridge contains the configs for the cross-chain bridge protocol, which is disabled if no counterpart chain is
+	// configured
+	Bridge struct {
+		// CounterpartChainID is the EVM chain ID of the counterpart chain
+		CounterpartChainID uint64 `yaml:"counterpartChainID"`
+		// CounterpartBridgeAddress is the address of the bridge contract on the counterpart chain in hex string
+		CounterpartBridgeAddress string `yaml:"counterpartBridgeAddress"`
+		// BridgeRelayers are the addresses allowed to submit the headers of the counterpart chain
+		BridgeRelayers []string `yaml:"bridgeRelayers"`
+		// BridgeConfirmations is the number of counterpart headers on top of a header before its messages are accepted
+		BridgeConfirmations uint64 `yaml:"bridgeConfirmations"`
+	}
+
+	// Governance contains the configs for the governance parameter store, which holds the on-chain parameters
+	// overriding the genesis ones from their activation heights
+	Governance struct {
+		// Governors are the addresses allowed to schedule the on-chain parameters
+		Governors []string `yaml:"governors"`
+	}
+
+	// FeeToken contains the configs for paying the gas in the whitelisted wrapped assets, which is experimental and
+	// disabled if no asset is whitelisted
+	FeeToken struct {
+		// FeeTokens are the addresses of the XRC20 wrapped assets allowed to pay the gas
+		FeeTokens []string `yaml:"feeTokens"`
+		// FeeTokenOracle is the address of the oracle contract returning the conversion rates of the assets, in Rau per
+		// 10^18 of the smallest unit of an asset
+		FeeTokenOracle string `yaml:"feeTokenOracle"`
+	}
+
 	// VoteWeightCalConsts contains the configs for calculating vote weight
 	VoteWeightCalConsts struct {
 		DurationLg float64 `yaml:"durationLg"`
@@ -527,6 +587,10 @@ func New(genesisPath string) (Genesis, error) {
 	if len(genesis.InitBalanceMap) == 0 {
 		genesis.InitBalanceMap = defaultInitBalanceMap()
 	}
+	// the fraction is the denominator of the blob base fee
+	if genesis.BlobBaseFeeUpdateFraction == 0 {
+		return Genesis{}, errors.New("blob base fee update fraction should be greater than 0")
+	}
 	return genesis, nil
 }
 
@@ -764,6 +828,33 @@ func (g *Blockchain) BlockGasLimitByHeight(height uint64) uint64 {
 	return g.BlockGasLimit
 }
 
+// TargetBlobGasPerBlock returns the target blob gas per block by height, the target configured takes effect after
+// toBeEnabled height
+func (g *Blockchain) TargetBlobGasPerBlock(height uint64) uint64 {
+	if g.IsToBeEnabled(height) {
+		return g.TargetBlobsPerBlock * params.BlobTxBlobGasPerBlob
+	}
+	return params.BlobTxTargetBlobGasPerBlock
+}
+
+// MaxBlobGasPerBlock returns the maximum blob gas per block by height, the maximum configured takes effect after
+// toBeEnabled height
+func (g *Blockchain) MaxBlobGasPerBlock(height uint64) uint64 {
+	if g.IsToBeEnabled(height) {
+		return g.MaxBlobsPerBlock * params.BlobTxBlobGasPerBlob
+	}
+	return params.MaxBlobGasPerBlock
+}
+
+// BlobBaseFeeUpdateFractionByHeight returns the blob base fee update fraction by height, the fraction configured
+// takes effect after toBeEnabled height
+func (g *Blockchain) BlobBaseFeeUpdateFractionByHeight(height uint64) uint64 {
+	if g.IsToBeEnabled(height) {
+		return g.BlobBaseFeeUpdateFraction
+	}
+	return params.BlobTxBlobGaspriceUpdateFraction
+}
+
 // IsDeployerWhitelisted returns if the replay deployer is whitelisted
 func (a *Account) IsDeployerWhitelisted(deployer address.Address) bool {
 	for _, v := range a.ReplayDeployerWhitelist {
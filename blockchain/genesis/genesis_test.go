@@ -7,8 +7,11 @@ package genesis
 
 import (
 	"encoding/hex"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -79,6 +82,27 @@ func TestTsunamiBlockGasLimit(t *testing.T) {
 	}
 }
 
+func TestBlobMarket(t *testing.T) {
+	r := require.New(t)
+
+	cfg := Default.Blockchain
+	cfg.ToBeEnabledBlockHeight = 10
+	cfg.TargetBlobsPerBlock, cfg.MaxBlobsPerBlock, cfg.BlobBaseFeeUpdateFraction = 2, 4, 1000
+	// the parameters configured take effect after the fork
+	r.EqualValues(params.BlobTxTargetBlobGasPerBlock, cfg.TargetBlobGasPerBlock(9))
+	r.EqualValues(params.MaxBlobGasPerBlock, cfg.MaxBlobGasPerBlock(9))
+	r.EqualValues(params.BlobTxBlobGaspriceUpdateFraction, cfg.BlobBaseFeeUpdateFractionByHeight(9))
+	r.EqualValues(2*params.BlobTxBlobGasPerBlob, cfg.TargetBlobGasPerBlock(10))
+	r.EqualValues(4*params.BlobTxBlobGasPerBlob, cfg.MaxBlobGasPerBlock(10))
+	r.EqualValues(1000, cfg.BlobBaseFeeUpdateFractionByHeight(10))
+
+	// the update fraction is the denominator of the blob base fee
+	path := filepath.Join(t.TempDir(), "genesis.yaml")
+	r.NoError(os.WriteFile(path, []byte("blockchain:\n  blobBaseFeeUpdateFraction: 0\n"), 0600))
+	_, err := New(path)
+	r.ErrorContains(err, "blob base fee update fraction should be greater than 0")
+}
+
 func TestDeployerWhitelist(t *testing.T) {
 	r := require.New(t)
 
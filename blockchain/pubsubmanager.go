@@ -83,7 +83,7 @@ func (ps *pubSub) RemoveBlockListener(s BlockCreationSubscriber) error {
 			close(elem.cancel)
 			ps.blocklisteners[i] = nil
 			ps.blocklisteners = append(ps.blocklisteners[:i], ps.blocklisteners[i+1:]...)
-			log.L().Info("Successfully unsubscribe block creation.")
+			log.Logger("blockchain").Info("Successfully unsubscribe block creation.")
 			return nil
 		}
 	}
@@ -105,7 +105,7 @@ func (ps *pubSub) Stop(_ context.Context) error {
 	defer ps.lock.Unlock()
 	for i, elem := range ps.blocklisteners {
 		close(elem.cancel)
-		log.L().Info("Successfully unsubscribe block creation.", zap.Int("listener", i))
+		log.Logger("blockchain").Info("Successfully unsubscribe block creation.", zap.Int("listener", i))
 	}
 	ps.blocklisteners = nil
 	return nil
@@ -118,7 +118,7 @@ func (ps *pubSub) handler(sub *pubSubElem) {
 			return
 		case blk := <-sub.pendingBlksBuffer:
 			if err := sub.listener.ReceiveBlock(blk); err != nil {
-				log.L().Error("Failed to handle new block.", zap.Error(err))
+				log.Logger("blockchain").Error("Failed to handle new block.", zap.Error(err))
 			}
 		}
 	}
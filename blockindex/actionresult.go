@@ -0,0 +1,52 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+)
+
+// ActionResult is the result of an action, so the reason of a failed action is served without re-running it
+type ActionResult struct {
+	status uint64
+	// revertReason is the reason decoded from the revert of the execution, empty if not available
+	revertReason string
+}
+
+func newActionResult(r *action.Receipt) *ActionResult {
+	return &ActionResult{
+		status:       r.Status,
+		revertReason: r.ExecutionRevertMsg(),
+	}
+}
+
+// Status returns the receipt status of the action
+func (r *ActionResult) Status() uint64 {
+	return r.status
+}
+
+// RevertReason returns the reason of the revert of the execution, empty if not available
+func (r *ActionResult) RevertReason() string {
+	return r.revertReason
+}
+
+// Serialize into byte stream
+func (r *ActionResult) Serialize() []byte {
+	return append(byteutil.Uint64ToBytesBigEndian(r.status), r.revertReason...)
+}
+
+// Deserialize from byte stream
+func (r *ActionResult) Deserialize(buf []byte) error {
+	if len(buf) < 8 {
+		return errors.Errorf("invalid action result length %d", len(buf))
+	}
+	r.status = byteutil.BytesToUint64BigEndian(buf[:8])
+	r.revertReason = string(buf[8:])
+	return nil
+}
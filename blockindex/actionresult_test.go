@@ -0,0 +1,76 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"context"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/db"
+)
+
+func TestActionResult(t *testing.T) {
+	require := require.New(t)
+
+	r := &ActionResult{}
+	require.Error(r.Deserialize([]byte{1, 2, 3}))
+	for _, ar := range []*ActionResult{
+		{status: uint64(iotextypes.ReceiptStatus_Success)},
+		{status: uint64(iotextypes.ReceiptStatus_ErrExecutionReverted), revertReason: "insufficient allowance"},
+	} {
+		require.NoError(r.Deserialize(ar.Serialize()))
+		require.Equal(ar, r)
+	}
+
+	ctx := genesis.WithGenesisContext(context.Background(), genesis.TestDefault())
+	indexer, err := NewIndexer(db.NewMemKVStore(), hash.ZeroHash256)
+	require.NoError(err)
+	require.NoError(indexer.Start(ctx))
+	defer func() {
+		require.NoError(indexer.Stop(ctx))
+	}()
+
+	blks := getTestBlocks(t)
+	for _, selp := range blks[0].Actions {
+		h, err := selp.Hash()
+		require.NoError(err)
+		r := &action.Receipt{Status: uint64(iotextypes.ReceiptStatus_Success), ActionHash: h}
+		if _, ok := selp.Action().(*action.Execution); ok {
+			r.Status = uint64(iotextypes.ReceiptStatus_ErrExecutionReverted)
+			r.SetExecutionRevertMsg("insufficient allowance")
+		}
+		blks[0].Receipts = append(blks[0].Receipts, r)
+	}
+	// the second block comes without the receipts
+	require.NoError(indexer.PutBlocks(ctx, blks[:2]))
+
+	t1Hash, _ := blks[0].Actions[0].Hash()
+	e1Hash, _ := blks[0].Actions[2].Hash()
+	t2Hash, _ := blks[1].Actions[0].Hash()
+	r, err = indexer.GetActionResult(t1Hash[:])
+	require.NoError(err)
+	require.EqualValues(iotextypes.ReceiptStatus_Success, r.Status())
+	require.Empty(r.RevertReason())
+	r, err = indexer.GetActionResult(e1Hash[:])
+	require.NoError(err)
+	require.EqualValues(iotextypes.ReceiptStatus_ErrExecutionReverted, r.Status())
+	require.Equal("insufficient allowance", r.RevertReason())
+	_, err = indexer.GetActionResult(t2Hash[:])
+	require.Equal(db.ErrNotExist, errors.Cause(err))
+
+	x := indexer.(*blockIndexer)
+	require.NoError(x.DeleteTipBlock(ctx, blks[1]))
+	require.NoError(x.DeleteTipBlock(ctx, blks[0]))
+	_, err = indexer.GetActionResult(e1Hash[:])
+	require.Equal(db.ErrNotExist, errors.Cause(err))
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/iotexproject/go-pkgs/bloom"
 	"github.com/pkg/errors"
+	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/iotexproject/iotex-core/v2/action"
@@ -20,6 +21,7 @@ import (
 	"github.com/iotexproject/iotex-core/v2/blockchain/blockdao"
 	"github.com/iotexproject/iotex-core/v2/db"
 	"github.com/iotexproject/iotex-core/v2/db/batch"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
 	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
 )
 
@@ -30,6 +32,8 @@ const (
 	RangeBloomFilterNamespace = "RangeBloomFilters"
 	// CurrentHeightKey indicates the key of current bf indexer height in underlying DB
 	CurrentHeightKey = "CurrentHeight"
+	// BloomFilterParamsNamespace indicates the kvstore namespace to store the parameters of the range BloomFilters
+	BloomFilterParamsNamespace = "BloomFilterParams"
 )
 
 const (
@@ -58,16 +62,23 @@ type (
 		FilterBlocksInRange(*filter.LogFilter, uint64, uint64, uint64) ([]uint64, error)
 	}
 
+	// ReceiptsReader reads the receipts of the block at the height
+	ReceiptsReader func(uint64) ([]*action.Receipt, error)
+
+	// BloomFilterIndexerOption is the option to create the bloomfilter indexer
+	BloomFilterIndexerOption func(*bloomfilterIndexer)
+
 	// bloomfilterIndexer is a struct for bloomfilter indexer
 	bloomfilterIndexer struct {
-		mutex               sync.RWMutex // mutex for curRangeBloomfilter
+		mutex               sync.RWMutex // mutex for curRangeBloomfilter, params and totalRange
 		kvStore             db.KVStore
-		rangeSize           uint64
-		bfSize              uint64
-		bfNumHash           uint64
+		target              bloomParams
+		params              bloomParams
 		currRangeBfKey      []byte
 		curRangeBloomfilter *bloomRange
 		totalRange          db.RangeIndex
+		readReceipts        ReceiptsReader
+		migration           *bloomMigration
 	}
 
 	jobDesc struct {
@@ -76,18 +87,32 @@ type (
 	}
 )
 
+// WithReceiptsReader sets the reader of the receipts, with which the range bloomfilters are rebuilt in the background
+// once the parameters of the config differ from the ones the index is built with
+func WithReceiptsReader(readReceipts ReceiptsReader) BloomFilterIndexerOption {
+	return func(bfx *bloomfilterIndexer) {
+		bfx.readReceipts = readReceipts
+	}
+}
+
 // NewBloomfilterIndexer creates a new bloomfilterindexer struct by given kvstore and rangebloomfilter size
-func NewBloomfilterIndexer(kv db.KVStore, cfg Config) (BloomFilterIndexer, error) {
+func NewBloomfilterIndexer(kv db.KVStore, cfg Config, opts ...BloomFilterIndexerOption) (BloomFilterIndexer, error) {
 	if kv == nil {
 		return nil, errors.New("empty kvStore")
 	}
 
-	return &bloomfilterIndexer{
-		kvStore:   kv,
-		rangeSize: cfg.RangeBloomFilterNumElements,
-		bfSize:    cfg.RangeBloomFilterSize,
-		bfNumHash: cfg.RangeBloomFilterNumHash,
-	}, nil
+	bfx := &bloomfilterIndexer{
+		kvStore: kv,
+		target: bloomParams{
+			RangeSize: cfg.RangeBloomFilterNumElements,
+			BfSize:    cfg.RangeBloomFilterSize,
+			BfNumHash: cfg.RangeBloomFilterNumHash,
+		},
+	}
+	for _, opt := range opts {
+		opt(bfx)
+	}
+	return bfx, nil
 }
 
 // Start starts the bloomfilter indexer
@@ -98,19 +123,64 @@ func (bfx *bloomfilterIndexer) Start(ctx context.Context) error {
 
 	bfx.mutex.Lock()
 	defer bfx.mutex.Unlock()
+	var tipHeight uint64
 	tipHeightData, err := bfx.kvStore.Get(RangeBloomFilterNamespace, []byte(CurrentHeightKey))
 	switch errors.Cause(err) {
 	case nil:
-		tipHeight := byteutil.BytesToUint64BigEndian(tipHeightData)
-		return bfx.initRangeBloomFilter(tipHeight)
+		tipHeight = byteutil.BytesToUint64BigEndian(tipHeightData)
 	case db.ErrNotExist:
 		if err = bfx.kvStore.Put(RangeBloomFilterNamespace, []byte(CurrentHeightKey), byteutil.Uint64ToBytes(0)); err != nil {
 			return err
 		}
-		return bfx.initRangeBloomFilter(0)
 	default:
 		return err
 	}
+	if bfx.params, err = bfx.loadParams(tipHeight); err != nil {
+		return err
+	}
+	if err := bfx.initRangeBloomFilter(tipHeight); err != nil {
+		return err
+	}
+	if bfx.params.sameAs(bfx.target) {
+		return nil
+	}
+	if bfx.readReceipts == nil {
+		log.L().Warn("The bloomfilter parameters differ from the ones the index is built with, which is kept using.",
+			zap.Object("params", &bfx.params), zap.Object("config", &bfx.target))
+		return nil
+	}
+	bfx.migration = bfx.startMigration(nil)
+	return nil
+}
+
+// loadParams loads the parameters the range bloomfilters are built with, the index built before the parameters are
+// stored is assumed to be built with the config, as well as an empty index
+func (bfx *bloomfilterIndexer) loadParams(tipHeight uint64) (bloomParams, error) {
+	data, err := bfx.kvStore.Get(BloomFilterParamsNamespace, _activeParamsKey)
+	switch errors.Cause(err) {
+	case nil:
+		params := bloomParams{}
+		if err := params.Deserialize(data); err != nil {
+			return params, err
+		}
+		if tipHeight > 0 || params.sameAs(bfx.target) {
+			return params, nil
+		}
+		// nothing is indexed yet, so the config is used without rebuilding
+		params.RangeSize, params.BfSize, params.BfNumHash = bfx.target.RangeSize, bfx.target.BfSize, bfx.target.BfNumHash
+		if err := bfx.kvStore.Put(BloomFilterParamsNamespace, _activeParamsKey, params.Serialize()); err != nil {
+			return params, err
+		}
+		return params, nil
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		params := bfx.target
+		if err := bfx.kvStore.Put(BloomFilterParamsNamespace, _activeParamsKey, params.Serialize()); err != nil {
+			return params, err
+		}
+		return params, nil
+	default:
+		return bloomParams{}, err
+	}
 }
 
 func (bfx *bloomfilterIndexer) initRangeBloomFilter(height uint64) error {
@@ -118,11 +188,11 @@ func (bfx *bloomfilterIndexer) initRangeBloomFilter(height uint64) error {
 		err        error
 		zero8Bytes = make([]byte, 8)
 	)
-	bfx.totalRange, err = db.NewRangeIndex(bfx.kvStore, TotalBloomFilterNamespace, zero8Bytes)
+	bfx.totalRange, err = db.NewRangeIndex(bfx.kvStore, bfx.params.totalNamespace(), zero8Bytes)
 	if err != nil {
 		return err
 	}
-	if bfx.curRangeBloomfilter, err = newBloomRange(bfx.bfSize, bfx.bfNumHash); err != nil {
+	if bfx.curRangeBloomfilter, err = newBloomRange(bfx.params.BfSize, bfx.params.BfNumHash); err != nil {
 		return err
 	}
 	if height > 0 {
@@ -130,7 +200,7 @@ func (bfx *bloomfilterIndexer) initRangeBloomFilter(height uint64) error {
 		if err != nil {
 			return err
 		}
-		if err := bfx.loadBloomRangeFromDB(bfx.curRangeBloomfilter, bfx.currRangeBfKey); err != nil {
+		if err := bfx.loadBloomRangeFromDB(bfx.curRangeBloomfilter, bfx.params.rangeNamespace(), bfx.currRangeBfKey); err != nil {
 			return err
 		}
 	} else {
@@ -142,6 +212,13 @@ func (bfx *bloomfilterIndexer) initRangeBloomFilter(height uint64) error {
 
 // Stop stops the bloomfilter indexer
 func (bfx *bloomfilterIndexer) Stop(ctx context.Context) error {
+	bfx.mutex.Lock()
+	m := bfx.migration
+	bfx.migration = nil
+	bfx.mutex.Unlock()
+	if m != nil {
+		m.stop()
+	}
 	bfx.totalRange.Close()
 	return bfx.kvStore.Stop(ctx)
 }
@@ -159,18 +236,18 @@ func (bfx *bloomfilterIndexer) Height() (uint64, error) {
 func (bfx *bloomfilterIndexer) PutBlock(ctx context.Context, blk *block.Block) (err error) {
 	bfx.mutex.Lock()
 	defer bfx.mutex.Unlock()
-	bfx.addLogsToRangeBloomFilter(ctx, blk.Height(), blk.Receipts)
+	addLogsToBloomRange(bfx.curRangeBloomfilter, blk.Height(), blk.Receipts)
 	// commit into DB and update tipHeight
 	if err := bfx.commit(blk.Height(), bfx.calculateBlockBloomFilter(ctx, blk.Receipts)); err != nil {
 		return err
 	}
-	if bfx.curRangeBloomfilter.NumElements() >= bfx.rangeSize {
+	if bfx.curRangeBloomfilter.NumElements() >= bfx.params.RangeSize {
 		nextIndex := byteutil.BytesToUint64BigEndian(bfx.currRangeBfKey) + 1
 		bfx.currRangeBfKey = byteutil.Uint64ToBytesBigEndian(nextIndex)
 		if err := bfx.totalRange.Insert(blk.Height()+1, bfx.currRangeBfKey); err != nil {
 			return errors.Wrapf(err, "failed to write next bloomfilter index")
 		}
-		if bfx.curRangeBloomfilter, err = newBloomRange(bfx.bfSize, bfx.bfNumHash); err != nil {
+		if bfx.curRangeBloomfilter, err = newBloomRange(bfx.params.BfSize, bfx.params.BfNumHash); err != nil {
 			return err
 		}
 		bfx.curRangeBloomfilter.SetStart(blk.Height() + 1)
@@ -178,23 +255,47 @@ func (bfx *bloomfilterIndexer) PutBlock(ctx context.Context, blk *block.Block) (
 	return nil
 }
 
-// DeleteTipBlock deletes tip height from underlying DB if necessary
+// DeleteTipBlock deletes the block bloom filter of the tip, the logs of the block are kept in the range bloom filter
+// it belongs to, which only adds false positives to the range
 func (bfx *bloomfilterIndexer) DeleteTipBlock(_ context.Context, blk *block.Block) (err error) {
 	bfx.mutex.Lock()
 	defer bfx.mutex.Unlock()
 	height := blk.Height()
-	if err := bfx.kvStore.Delete(BlockBloomFilterNamespace, byteutil.Uint64ToBytesBigEndian(height)); err != nil {
+	tipHeight, err := bfx.Height()
+	if err != nil {
 		return err
 	}
-	bfx.curRangeBloomfilter = nil
-	return nil
+	if height == 0 || height != tipHeight {
+		return errors.Wrapf(db.ErrInvalid, "wrong block height %d, expecting %d", height, tipHeight)
+	}
+	b := batch.NewBatch()
+	b.Delete(BlockBloomFilterNamespace, byteutil.Uint64ToBytesBigEndian(height), "failed to delete block bloom filter")
+	b.Put(RangeBloomFilterNamespace, []byte(CurrentHeightKey), byteutil.Uint64ToBytesBigEndian(height-1), "failed to put current height")
+	firstInRange := height > 1 && bfx.curRangeBloomfilter.Start() == height
+	if firstInRange {
+		b.Delete(bfx.params.rangeNamespace(), bfx.currRangeBfKey, "failed to delete range bloom filter")
+	}
+	if err := bfx.kvStore.WriteBatch(b); err != nil {
+		return err
+	}
+	if firstInRange {
+		// the block is the only one in the current range, the range starting at it is deleted
+		if err := bfx.totalRange.Delete(height); err != nil {
+			return errors.Wrapf(err, "failed to delete bloomfilter index")
+		}
+	}
+	if bfx.migration != nil && !bfx.migration.switched {
+		// the block may have been indexed by the migration, which is restarted from scratch
+		bfx.migration = bfx.startMigration(bfx.migration)
+	}
+	return bfx.initRangeBloomFilter(height - 1)
 }
 
 // RangeBloomFilterNumElements returns the number of elements that each rangeBloomfilter indexes
 func (bfx *bloomfilterIndexer) RangeBloomFilterNumElements() uint64 {
 	bfx.mutex.RLock()
 	defer bfx.mutex.RUnlock()
-	return bfx.rangeSize
+	return bfx.params.RangeSize
 }
 
 // BlockFilterByHeight returns the block-level bloomfilter which includes not only topic but also address of logs info by given block height
@@ -203,7 +304,10 @@ func (bfx *bloomfilterIndexer) BlockFilterByHeight(height uint64) (bloom.BloomFi
 	if err != nil {
 		return nil, err
 	}
-	bf, err := bloom.NewBloomFilter(bfx.bfSize, bfx.bfNumHash)
+	bfx.mutex.RLock()
+	params := bfx.params
+	bfx.mutex.RUnlock()
+	bf, err := bloom.NewBloomFilter(params.BfSize, params.BfNumHash)
 	if err != nil {
 		return nil, err
 	}
@@ -222,14 +326,19 @@ func (bfx *bloomfilterIndexer) FilterBlocksInRange(l *filter.LogFilter, start, e
 	if end-start > _maxBlockRange {
 		return nil, errRangeTooLarge
 	}
+	// the range bloomfilters of the previous parameters are kept for a while after switched by the migration, so
+	// that the query keeps reading the ones of the parameters at the time it starts
+	bfx.mutex.RLock()
+	params, totalRange := bfx.params, bfx.totalRange
+	bfx.mutex.RUnlock()
 	var (
 		startIndex, endIndex uint64
 		err                  error
 	)
-	if startIndex, err = bfx.getIndexByHeight(start); err != nil {
+	if startIndex, err = getIndexByHeight(totalRange, start); err != nil {
 		return nil, err
 	}
-	if endIndex, err = bfx.getIndexByHeight(end); err != nil {
+	if endIndex, err = getIndexByHeight(totalRange, end); err != nil {
 		return nil, err
 	}
 
@@ -244,12 +353,12 @@ func (bfx *bloomfilterIndexer) FilterBlocksInRange(l *filter.LogFilter, start, e
 	eg, ctx = errgroup.WithContext(ctx)
 
 	// create pool for BloomRange object reusing
-	if _, err := newBloomRange(bfx.bfSize, bfx.bfNumHash); err != nil {
+	if _, err := newBloomRange(params.BfSize, params.BfNumHash); err != nil {
 		return nil, err
 	}
 	bufPool = sync.Pool{
 		New: func() interface{} {
-			br, _ := newBloomRange(bfx.bfSize, bfx.bfNumHash)
+			br, _ := newBloomRange(params.BfSize, params.BfNumHash)
 			return br
 		},
 	}
@@ -265,7 +374,7 @@ func (bfx *bloomfilterIndexer) FilterBlocksInRange(l *filter.LogFilter, start, e
 						return nil
 					}
 					br := bufPool.Get().(*bloomRange)
-					if err := bfx.loadBloomRangeFromDB(br, job.key); err != nil {
+					if err := bfx.loadBloomRangeFromDB(br, params.rangeNamespace(), job.key); err != nil {
 						bufPool.Put(br)
 						return err
 					}
@@ -316,10 +425,10 @@ func (bfx *bloomfilterIndexer) commit(blockNumber uint64, blkBloomfilter bloom.B
 		return err
 	}
 	b := batch.NewBatch()
-	b.Put(RangeBloomFilterNamespace, bfx.currRangeBfKey, bfBytes, "failed to put range bloom filter")
+	b.Put(bfx.params.rangeNamespace(), bfx.currRangeBfKey, bfBytes, "failed to put range bloom filter")
 	b.Put(BlockBloomFilterNamespace, byteutil.Uint64ToBytesBigEndian(blockNumber), blkBloomfilter.Bytes(), "failed to put block bloom filter")
 	b.Put(RangeBloomFilterNamespace, []byte(CurrentHeightKey), byteutil.Uint64ToBytesBigEndian(blockNumber), "failed to put current height")
-	b.AddFillPercent(RangeBloomFilterNamespace, 1.0)
+	b.AddFillPercent(bfx.params.rangeNamespace(), 1.0)
 	b.AddFillPercent(BlockBloomFilterNamespace, 1.0)
 	return bfx.kvStore.WriteBatch(b)
 }
@@ -339,34 +448,34 @@ func (bfx *bloomfilterIndexer) calculateBlockBloomFilter(ctx context.Context, re
 }
 
 // TODO: improve performance
-func (bfx *bloomfilterIndexer) addLogsToRangeBloomFilter(ctx context.Context, blockNumber uint64, receipts []*action.Receipt) {
+func addLogsToBloomRange(br *bloomRange, blockNumber uint64, receipts []*action.Receipt) {
 	Heightkey := append([]byte(filter.BlockHeightPrefix), byteutil.Uint64ToBytes(blockNumber)...)
 
 	for _, receipt := range receipts {
 		for _, l := range receipt.Logs() {
-			bfx.curRangeBloomfilter.Add([]byte(l.Address))
-			bfx.curRangeBloomfilter.Add(append(Heightkey, []byte(l.Address)...)) // concatenate with block number
+			br.Add([]byte(l.Address))
+			br.Add(append(Heightkey, []byte(l.Address)...)) // concatenate with block number
 			for i, topic := range l.Topics {
-				bfx.curRangeBloomfilter.Add(append(byteutil.Uint64ToBytes(uint64(i)), topic[:]...)) //position-sensitive
-				bfx.curRangeBloomfilter.Add(append(Heightkey, topic[:]...))                         // concatenate with block number
+				br.Add(append(byteutil.Uint64ToBytes(uint64(i)), topic[:]...)) //position-sensitive
+				br.Add(append(Heightkey, topic[:]...))                         // concatenate with block number
 			}
 		}
 	}
 }
 
-func (bfx *bloomfilterIndexer) loadBloomRangeFromDB(br *bloomRange, bfKey []byte) error {
+func (bfx *bloomfilterIndexer) loadBloomRangeFromDB(br *bloomRange, ns string, bfKey []byte) error {
 	if br == nil {
 		return errors.New("bloomRange is empty")
 	}
-	bfBytes, err := bfx.kvStore.Get(RangeBloomFilterNamespace, bfKey)
+	bfBytes, err := bfx.kvStore.Get(ns, bfKey)
 	if err != nil {
 		return err
 	}
 	return br.FromBytes(bfBytes)
 }
 
-func (bfx *bloomfilterIndexer) getIndexByHeight(height uint64) (uint64, error) {
-	val, err := bfx.totalRange.Get(height)
+func getIndexByHeight(totalRange db.RangeIndex, height uint64) (uint64, error) {
+	val, err := totalRange.Get(height)
 	if err != nil {
 		return 0, err
 	}
@@ -12,7 +12,9 @@ import (
 	"runtime"
 	"sync"
 	"testing"
+	"time"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/go-pkgs/hash"
@@ -260,6 +262,46 @@ func TestBloomfilterIndexer(t *testing.T) {
 		}
 	}
 
+	testDelete := func(kvStore db.KVStore, t *testing.T) {
+		ctx := context.Background()
+		cfg := DefaultConfig
+		cfg.RangeBloomFilterNumElements = 2
+		cfg.RangeBloomFilterSize = 4096
+		cfg.RangeBloomFilterNumHash = 4
+
+		indexer, err := NewBloomfilterIndexer(kvStore, cfg)
+		require.NoError(err)
+		require.NoError(indexer.Start(ctx))
+		defer func() {
+			require.NoError(indexer.Stop(ctx))
+		}()
+		for i := 0; i < len(blks); i++ {
+			require.NoError(indexer.PutBlock(ctx, blks[i]))
+		}
+		bfx := indexer.(*bloomfilterIndexer)
+		// only the tip can be deleted
+		err = bfx.DeleteTipBlock(ctx, blks[1])
+		require.Equal(db.ErrInvalid, errors.Cause(err))
+		for i := len(blks) - 1; i >= 2; i-- {
+			require.NoError(bfx.DeleteTipBlock(ctx, blks[i]))
+			height, err := indexer.Height()
+			require.NoError(err)
+			require.Equal(blks[i].Height()-1, height)
+			_, err = indexer.BlockFilterByHeight(blks[i].Height())
+			require.Error(err)
+		}
+
+		// the deleted blocks can be indexed again
+		for i := 2; i < len(blks); i++ {
+			require.NoError(indexer.PutBlock(ctx, blks[i]))
+		}
+		for i, l := range testFilter {
+			res, err := indexer.FilterBlocksInRange(logfilter.NewLogFilter(l), 1, 5, 0)
+			require.NoError(err)
+			require.Equal(expectedRes2[i], res)
+		}
+	}
+
 	t.Run("Bolt DB indexer", func(t *testing.T) {
 		testPath, err := testutil.PathOfTempFile("test-indexer")
 		require.NoError(err)
@@ -269,6 +311,100 @@ func TestBloomfilterIndexer(t *testing.T) {
 
 		testIndexer(db.NewBoltDB(cfg), t)
 	})
+	t.Run("Bolt DB delete", func(t *testing.T) {
+		testPath, err := testutil.PathOfTempFile("test-indexer")
+		require.NoError(err)
+		defer testutil.CleanupPath(testPath)
+		cfg := db.DefaultConfig
+		cfg.DbPath = testPath
+
+		testDelete(db.NewBoltDB(cfg), t)
+	})
+}
+
+func TestBloomfilterIndexerMigration(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	blks := getTestLogBlocks(t)
+	testPath, err := testutil.PathOfTempFile("test-indexer")
+	require.NoError(err)
+	defer testutil.CleanupPath(testPath)
+	dbCfg := db.DefaultConfig
+	dbCfg.DbPath = testPath
+	cleanupDelay := _cleanupDelay
+	_cleanupDelay = 0
+	defer func() { _cleanupDelay = cleanupDelay }()
+
+	filters := []*logfilter.LogFilter{
+		logfilter.NewLogFilter(&iotexapi.LogsFilter{
+			Address: []string{identityset.Address(28).String()},
+			Topics:  []*iotexapi.Topics{{Topic: [][]byte{_data1[:], _data2[:]}}},
+		}),
+		logfilter.NewLogFilter(&iotexapi.LogsFilter{
+			Address: []string{identityset.Address(18).String()},
+			Topics:  []*iotexapi.Topics{{Topic: [][]byte{_data1[:]}}},
+		}),
+	}
+	checkFilters := func(indexer BloomFilterIndexer, end uint64, expected ...[]uint64) {
+		for i, lf := range filters {
+			res, err := indexer.FilterBlocksInRange(lf, 1, end, 0)
+			require.NoError(err)
+			require.Equal(expected[i], res)
+		}
+	}
+	cfg := DefaultConfig
+	cfg.RangeBloomFilterNumElements = 2
+	cfg.RangeBloomFilterSize = 4096
+	cfg.RangeBloomFilterNumHash = 4
+	indexer, err := NewBloomfilterIndexer(db.NewBoltDB(dbCfg), cfg)
+	require.NoError(err)
+	require.NoError(indexer.Start(ctx))
+	for i := 0; i < 3; i++ {
+		require.NoError(indexer.PutBlock(ctx, blks[i]))
+	}
+	require.NoError(indexer.Stop(ctx))
+
+	// the range bloomfilters are rebuilt with the new parameters in the background
+	newCfg := cfg
+	newCfg.RangeBloomFilterNumElements = 3
+	newCfg.RangeBloomFilterSize = 8192
+	newCfg.RangeBloomFilterNumHash = 5
+	release := make(chan struct{})
+	indexer, err = NewBloomfilterIndexer(db.NewBoltDB(dbCfg), newCfg, WithReceiptsReader(func(height uint64) ([]*action.Receipt, error) {
+		<-release
+		return blks[height-1].Receipts, nil
+	}))
+	require.NoError(err)
+	require.NoError(indexer.Start(ctx))
+	bfx := indexer.(*bloomfilterIndexer)
+	require.NotNil(bfx.migration)
+	// the index keeps serving with the previous parameters during the migration
+	require.Equal(cfg.RangeBloomFilterNumElements, indexer.RangeBloomFilterNumElements())
+	checkFilters(indexer, 3, []uint64{1, 2}, []uint64{3})
+	require.NoError(indexer.PutBlock(ctx, blks[3]))
+	close(release)
+	require.Eventually(func() bool {
+		return indexer.RangeBloomFilterNumElements() == newCfg.RangeBloomFilterNumElements
+	}, 5*time.Second, 10*time.Millisecond)
+	<-bfx.migration.done
+	require.NoError(indexer.PutBlock(ctx, blks[4]))
+	checkFilters(indexer, 5, []uint64{1, 2, 5}, []uint64{3})
+	// the range bloomfilters of the previous parameters are deleted
+	_, err = bfx.kvStore.Get(RangeBloomFilterNamespace, make([]byte, 8))
+	require.ErrorIs(err, db.ErrNotExist)
+	height, err := indexer.Height()
+	require.NoError(err)
+	require.EqualValues(5, height)
+	require.NoError(indexer.Stop(ctx))
+
+	// the index is kept built with the migrated parameters without the receipts reader
+	indexer, err = NewBloomfilterIndexer(db.NewBoltDB(dbCfg), cfg)
+	require.NoError(err)
+	require.NoError(indexer.Start(ctx))
+	require.Nil(indexer.(*bloomfilterIndexer).migration)
+	require.Equal(newCfg.RangeBloomFilterNumElements, indexer.RangeBloomFilterNumElements())
+	checkFilters(indexer, 5, []uint64{1, 2, 5}, []uint64{3})
+	require.NoError(indexer.Stop(ctx))
 }
 
 func BenchmarkBloomfilterIndexer(b *testing.B) {
@@ -0,0 +1,297 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/db/batch"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+)
+
+const (
+	_bloomParamsLength = 32
+	// _migrationLogInterval is the number of blocks between the progress logs of the migration
+	_migrationLogInterval = 100000
+	// _cleanupBatchSize is the number of the range bloomfilters of the previous parameters deleted in a batch
+	_cleanupBatchSize = 100
+)
+
+var (
+	_activeParamsKey = []byte("active")
+
+	// _cleanupDelay is the delay to delete the range bloomfilters of the previous parameters after switched, which
+	// are read by the queries started before the switch
+	_cleanupDelay = _queryTimeout
+)
+
+type (
+	// bloomParams is the parameters of the range bloomfilters, the range bloomfilters of each generation of the
+	// parameters are stored in their own namespaces
+	bloomParams struct {
+		Generation uint64
+		RangeSize  uint64
+		BfSize     uint64
+		BfNumHash  uint64
+	}
+
+	// bloomMigration rebuilds the range bloomfilters with the parameters of a new generation in the background, and
+	// switches the indexer to them once caught up with the tip of the indexer
+	bloomMigration struct {
+		bfx        *bloomfilterIndexer
+		params     bloomParams
+		cancel     context.CancelFunc
+		done       chan struct{}
+		totalRange db.RangeIndex
+		cur        *bloomRange
+		curKey     []byte
+		height     uint64
+		switched   bool
+	}
+)
+
+// Serialize serializes the parameters into bytes
+func (p *bloomParams) Serialize() []byte {
+	buf := make([]byte, 0, _bloomParamsLength)
+	for _, v := range []uint64{p.Generation, p.RangeSize, p.BfSize, p.BfNumHash} {
+		buf = append(buf, byteutil.Uint64ToBytesBigEndian(v)...)
+	}
+	return buf
+}
+
+// Deserialize deserializes bytes into the parameters
+func (p *bloomParams) Deserialize(buf []byte) error {
+	if len(buf) != _bloomParamsLength {
+		return errors.Errorf("invalid bloomfilter params length %d", len(buf))
+	}
+	p.Generation = byteutil.BytesToUint64BigEndian(buf[:8])
+	p.RangeSize = byteutil.BytesToUint64BigEndian(buf[8:16])
+	p.BfSize = byteutil.BytesToUint64BigEndian(buf[16:24])
+	p.BfNumHash = byteutil.BytesToUint64BigEndian(buf[24:])
+	return nil
+}
+
+// MarshalLogObject marshals the parameters into the log
+func (p *bloomParams) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddUint64("generation", p.Generation)
+	enc.AddUint64("rangeSize", p.RangeSize)
+	enc.AddUint64("bfSize", p.BfSize)
+	enc.AddUint64("bfNumHash", p.BfNumHash)
+	return nil
+}
+
+func (p *bloomParams) sameAs(o bloomParams) bool {
+	return p.RangeSize == o.RangeSize && p.BfSize == o.BfSize && p.BfNumHash == o.BfNumHash
+}
+
+// rangeNamespace returns the namespace of the range bloomfilters, the generation 0 uses the original namespace
+func (p *bloomParams) rangeNamespace() string {
+	if p.Generation == 0 {
+		return RangeBloomFilterNamespace
+	}
+	return fmt.Sprintf("%s-%d", RangeBloomFilterNamespace, p.Generation)
+}
+
+// totalNamespace returns the namespace of the index from the heights to the range bloomfilters
+func (p *bloomParams) totalNamespace() []byte {
+	if p.Generation == 0 {
+		return TotalBloomFilterNamespace
+	}
+	return []byte(fmt.Sprintf("%s-%d", TotalBloomFilterNamespace, p.Generation))
+}
+
+// startMigration starts the migration to the parameters of the config after the previous migration exits, which is
+// canceled. It must be called with the mutex of the indexer locked
+func (bfx *bloomfilterIndexer) startMigration(prev *bloomMigration) *bloomMigration {
+	ctx, cancel := context.WithCancel(context.Background())
+	params := bfx.target
+	params.Generation = bfx.params.Generation + 1
+	m := &bloomMigration{
+		bfx:    bfx,
+		params: params,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	if prev != nil {
+		prev.cancel()
+	}
+	go func() {
+		defer close(m.done)
+		if prev != nil {
+			<-prev.done
+		}
+		if err := m.run(ctx); err != nil && errors.Cause(err) != context.Canceled {
+			log.L().Error("Failed to migrate bloomfilter index.", zap.Object("params", &m.params), zap.Error(err))
+		}
+	}()
+	return m
+}
+
+func (m *bloomMigration) stop() {
+	m.cancel()
+	<-m.done
+}
+
+func (m *bloomMigration) run(ctx context.Context) error {
+	kv := m.bfx.kvStore
+	// clean up the leftover of an interrupted migration
+	for _, ns := range []string{m.params.rangeNamespace(), string(m.params.totalNamespace())} {
+		if err := kv.Delete(ns, nil); err != nil {
+			return err
+		}
+	}
+	zero8Bytes := make([]byte, 8)
+	totalRange, err := db.NewRangeIndex(kv, m.params.totalNamespace(), zero8Bytes)
+	if err != nil {
+		return err
+	}
+	m.totalRange = totalRange
+	if m.cur, err = newBloomRange(m.params.BfSize, m.params.BfNumHash); err != nil {
+		return err
+	}
+	m.cur.SetStart(1)
+	m.curKey = zero8Bytes
+	log.L().Info("Start migrating bloomfilter index.", zap.Object("params", &m.params))
+	for {
+		tip, err := m.bfx.Height()
+		if err != nil {
+			return err
+		}
+		for m.height < tip {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := m.putBlock(m.height + 1); err != nil {
+				return err
+			}
+			if m.height%_migrationLogInterval == 0 {
+				log.L().Info("Migrating bloomfilter index.", zap.Uint64("height", m.height), zap.Uint64("tip", tip))
+			}
+		}
+		prev, switched, err := m.switchIfCaughtUp(ctx)
+		if err != nil {
+			return err
+		}
+		if switched {
+			log.L().Info("Switched to the migrated bloomfilter index.", zap.Object("params", &m.params), zap.Uint64("height", m.height))
+			return m.cleanup(ctx, prev)
+		}
+	}
+}
+
+// putBlock adds the logs of the block into the range bloomfilter being built, which is written once it is full
+func (m *bloomMigration) putBlock(height uint64) error {
+	receipts, err := m.bfx.readReceipts(height)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read receipts at height %d", height)
+	}
+	addLogsToBloomRange(m.cur, height, receipts)
+	m.height = height
+	if m.cur.NumElements() < m.params.RangeSize {
+		return nil
+	}
+	if err := m.writeRange(batch.NewBatch()); err != nil {
+		return err
+	}
+	m.curKey = byteutil.Uint64ToBytesBigEndian(byteutil.BytesToUint64BigEndian(m.curKey) + 1)
+	if err := m.totalRange.Insert(height+1, m.curKey); err != nil {
+		return errors.Wrapf(err, "failed to write next bloomfilter index")
+	}
+	if m.cur, err = newBloomRange(m.params.BfSize, m.params.BfNumHash); err != nil {
+		return err
+	}
+	m.cur.SetStart(height + 1)
+	return nil
+}
+
+// writeRange writes the range bloomfilter being built along with the batch
+func (m *bloomMigration) writeRange(b batch.KVStoreBatch) error {
+	if m.cur.Start() <= m.height {
+		m.cur.SetEnd(m.height)
+		bfBytes, err := m.cur.Bytes()
+		if err != nil {
+			return err
+		}
+		b.Put(m.params.rangeNamespace(), m.curKey, bfBytes, "failed to put range bloom filter")
+		b.AddFillPercent(m.params.rangeNamespace(), 1.0)
+	}
+	return m.bfx.kvStore.WriteBatch(b)
+}
+
+// switchIfCaughtUp switches the indexer to the migrated range bloomfilters if no block is indexed after the height
+// the migration has built, and returns the parameters switched from
+func (m *bloomMigration) switchIfCaughtUp(ctx context.Context) (bloomParams, bool, error) {
+	bfx := m.bfx
+	bfx.mutex.Lock()
+	defer bfx.mutex.Unlock()
+	tip, err := bfx.Height()
+	if err != nil {
+		return bloomParams{}, false, err
+	}
+	// a tip block deleted after the migration has built it cancels the migration
+	if err := ctx.Err(); err != nil {
+		return bloomParams{}, false, err
+	}
+	if tip != m.height {
+		return bloomParams{}, false, nil
+	}
+	b := batch.NewBatch()
+	b.Put(BloomFilterParamsNamespace, _activeParamsKey, m.params.Serialize(), "failed to put bloomfilter params")
+	if err := m.writeRange(b); err != nil {
+		return bloomParams{}, false, err
+	}
+	prev := bfx.params
+	bfx.params = m.params
+	bfx.totalRange = m.totalRange
+	bfx.curRangeBloomfilter = m.cur
+	bfx.currRangeBfKey = m.curKey
+	m.switched = true
+	return prev, true, nil
+}
+
+// cleanup deletes the range bloomfilters of the previous parameters, after the queries reading them are finished
+func (m *bloomMigration) cleanup(ctx context.Context, prev bloomParams) error {
+	select {
+	case <-ctx.Done():
+		// the leftover is deleted by the next migration to the generation
+		return ctx.Err()
+	case <-time.After(_cleanupDelay):
+	}
+	kv := m.bfx.kvStore
+	totalRange, err := db.NewRangeIndex(kv, prev.totalNamespace(), make([]byte, 8))
+	if err != nil {
+		return err
+	}
+	lastKey, err := getIndexByHeight(totalRange, m.height)
+	totalRange.Close()
+	if err != nil {
+		return err
+	}
+	b := batch.NewBatch()
+	for idx := uint64(0); idx <= lastKey; idx++ {
+		b.Delete(prev.rangeNamespace(), byteutil.Uint64ToBytesBigEndian(idx), "failed to delete range bloom filter")
+		if b.Size() >= _cleanupBatchSize || idx == lastKey {
+			if err := kv.WriteBatch(b); err != nil {
+				return err
+			}
+			b = batch.NewBatch()
+		}
+	}
+	if prev.Generation > 0 {
+		if err := kv.Delete(prev.rangeNamespace(), nil); err != nil {
+			return err
+		}
+	}
+	return kv.Delete(string(prev.totalNamespace()), nil)
+}
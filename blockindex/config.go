@@ -5,7 +5,12 @@
 
 package blockindex
 
-// Config is the config for indexer
+import (
+	"github.com/iotexproject/iotex-core/v2/blockindex/sqlindexer"
+)
+
+// Config is the config for indexer. Changing the parameters of the rangeBloomfilter rebuilds the rangeBloomfilters in
+// the background, the log queries are served by the ones of the previous parameters until the rebuilding finishes
 type Config struct {
 	// RangeBloomFilterNumElements is the number of elements each rangeBloomfilter will store in bloomfilterIndexer
 	RangeBloomFilterNumElements uint64 `yaml:"rangeBloomFilterNumElements"`
@@ -13,6 +18,8 @@ type Config struct {
 	RangeBloomFilterSize uint64 `yaml:"rangeBloomFilterSize"`
 	// RangeBloomFilterNumHash is the number of hash functions of rangeBloomfilter
 	RangeBloomFilterNumHash uint64 `yaml:"rangeBloomFilterNumHash"`
+	// SQL is the config of the indexer exporting the blocks to a sql database for the analytics
+	SQL sqlindexer.Config `yaml:"sql"`
 }
 
 // DefaultConfig is the default config of indexer
@@ -20,4 +27,5 @@ var DefaultConfig = Config{
 	RangeBloomFilterNumElements: 100000,
 	RangeBloomFilterSize:        1200000,
 	RangeBloomFilterNumHash:     8,
+	SQL:                         sqlindexer.DefaultConfig,
 }
@@ -170,6 +170,10 @@ func (s *contractStakingCache) BucketsByIndices(indices []uint64, height uint64)
 	if err := s.validateHeight(height); err != nil {
 		return nil, err
 	}
+	return s.bucketsByIndices(indices, height)
+}
+
+func (s *contractStakingCache) bucketsByIndices(indices []uint64, height uint64) ([]*Bucket, error) {
 	vbs := make([]*Bucket, 0, len(indices))
 	for _, id := range indices {
 		vb, ok := s.getBucket(id, height)
@@ -44,6 +44,15 @@ func (s *stakeView) BucketsByCandidate(candidate address.Address) ([]*Bucket, er
 	return s.clean.bucketsByCandidate(candidate, s.height)
 }
 
+func (s *stakeView) BucketsByIndices(indices []uint64) ([]*Bucket, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.dirty != nil {
+		return s.dirty.bucketsByIndices(indices, s.height)
+	}
+	return s.clean.bucketsByIndices(indices, s.height)
+}
+
 func (s *stakeView) CreatePreStates(ctx context.Context) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -143,6 +143,9 @@ func (ib *IndexBuilder) init(ctx context.Context) error {
 		if err != nil {
 			return err
 		}
+		if blk.Receipts, err = ib.dao.GetReceipts(startHeight); err != nil {
+			return err
+		}
 		blks = append(blks, blk)
 		// commit once every 100 blocks
 		if startHeight%100 == 0 || startHeight == tipHeight {
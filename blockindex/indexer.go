@@ -31,6 +31,7 @@ const (
 	_hashOffset          = 12
 	_blockHashToHeightNS = "hh"
 	_actionToBlockHashNS = "ab"
+	_actionResultNS      = "ar"
 )
 
 var (
@@ -54,6 +55,7 @@ type (
 		GetBlockHeight(hash hash.Hash256) (uint64, error)
 		GetBlockIndex(uint64) (*BlockIndex, error)
 		GetActionIndex([]byte) (*ActionIndex, error)
+		GetActionResult([]byte) (*ActionResult, error)
 		GetTotalActions() (uint64, error)
 		GetActionHashFromIndex(uint64, uint64) ([][]byte, error)
 		GetActionCountByAddress(hash.Hash160) (uint64, error)
@@ -142,6 +144,59 @@ func (x *blockIndexer) PutBlock(ctx context.Context, blk *block.Block) error {
 	return x.commit()
 }
 
+// DeleteTipBlock deletes the index of the block at the tip
+func (x *blockIndexer) DeleteTipBlock(ctx context.Context, blk *block.Block) error {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+
+	height := blk.Height()
+	if height == 0 || height != x.tbk.Size()-1 {
+		return errors.Wrapf(db.ErrInvalid, "wrong block height %d, expecting %d", height, x.tbk.Size()-1)
+	}
+	fCtx := protocol.MustGetFeatureCtx(protocol.WithFeatureCtx(protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+		BlockHeight: height,
+	})))
+	var (
+		b         = batch.NewBatch()
+		blkHash   = blk.HashBlock()
+		addrCount = make(map[hash.Hash160]uint64)
+	)
+	b.Delete(_blockHashToHeightNS, blkHash[_hashOffset:], "failed to delete hash -> height mapping")
+	for _, selp := range blk.Actions {
+		actHash, err := selp.Hash()
+		if err != nil {
+			return err
+		}
+		b.Delete(_actionToBlockHashNS, actHash[_hashOffset:], fmt.Sprintf("failed to delete action hash %x", actHash))
+		b.Delete(_actionResultNS, actHash[_hashOffset:], fmt.Sprintf("failed to delete result of action %x", actHash))
+		addrs, err := actionAddresses(selp, fCtx.TolerateLegacyAddress)
+		if err != nil {
+			return err
+		}
+		for _, addr := range addrs {
+			addrCount[hash.BytesToHash160(addr)]++
+		}
+	}
+	if err := x.kvStore.WriteBatch(b); err != nil {
+		return err
+	}
+	for addr, count := range addrCount {
+		indexer, err := db.NewCountingIndexNX(x.kvStore, addr[:])
+		if err != nil {
+			return err
+		}
+		if err := indexer.Revert(count); err != nil {
+			return errors.Wrapf(err, "failed to revert index of address %x", addr)
+		}
+	}
+	if len(blk.Actions) > 0 {
+		if err := x.tac.Revert(uint64(len(blk.Actions))); err != nil {
+			return errors.Wrap(err, "failed to revert total action index")
+		}
+	}
+	return x.tbk.Revert(1)
+}
+
 // Height return the blockchain height
 func (x *blockIndexer) Height() (uint64, error) {
 	x.mutex.RLock()
@@ -205,6 +260,23 @@ func (x *blockIndexer) GetActionIndex(h []byte) (*ActionIndex, error) {
 	return a, nil
 }
 
+// GetActionResult returns the result of the action, it returns db.ErrNotExist if the block of the action is indexed
+// without the receipts
+func (x *blockIndexer) GetActionResult(h []byte) (*ActionResult, error) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+
+	v, err := x.kvStore.Get(_actionResultNS, h[_hashOffset:])
+	if err != nil {
+		return nil, err
+	}
+	r := &ActionResult{}
+	if err := r.Deserialize(v); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
 // GetTotalActions return total number of all actions
 func (x *blockIndexer) GetTotalActions() (uint64, error) {
 	x.mutex.RLock()
@@ -300,6 +372,11 @@ func (x *blockIndexer) putBlock(ctx context.Context, blk *block.Block) error {
 			return err
 		}
 	}
+	// index the results of the actions, if the block comes with the receipts
+	for _, r := range blk.Receipts {
+		ar := newActionResult(r).Serialize()
+		x.batch.Put(_actionResultNS, r.ActionHash[_hashOffset:], ar, fmt.Sprintf("failed to put result of action %x", r.ActionHash))
+	}
 	return nil
 }
 
@@ -353,41 +430,47 @@ func (x *blockIndexer) getIndexerForAddr(addr []byte) (db.CountingIndex, error)
 
 // indexAction builds index for an action
 func (x *blockIndexer) indexAction(actHash hash.Hash256, elp *action.SealedEnvelope, tolerateLegacyAddress bool) error {
-	// add to sender's index
-	callerAddrBytes := elp.SrcPubkey().Hash()
-	sender, err := x.getIndexerForAddr(callerAddrBytes)
+	addrs, err := actionAddresses(elp, tolerateLegacyAddress)
 	if err != nil {
 		return err
 	}
-	if err = sender.Add(actHash[:], true); err != nil {
-		return err
+	for _, addr := range addrs {
+		indexer, err := x.getIndexerForAddr(addr)
+		if err != nil {
+			return err
+		}
+		if err := indexer.Add(actHash[:], true); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
+// actionAddresses returns the addresses whose index the action is added to, i.e., the sender and the recipient
+func actionAddresses(elp *action.SealedEnvelope, tolerateLegacyAddress bool) ([][]byte, error) {
+	callerAddrBytes := elp.SrcPubkey().Hash()
 	dst, ok := elp.Destination()
 	if !ok || dst == "" {
-		return nil
+		return [][]byte{callerAddrBytes}, nil
 	}
 
-	var dstAddr address.Address
+	var (
+		dstAddr address.Address
+		err     error
+	)
 	if tolerateLegacyAddress {
 		dstAddr, err = address.FromStringLegacy(dst)
 	} else {
 		dstAddr, err = address.FromString(dst)
 	}
 	if err != nil {
-		return err
+		return nil, err
 	}
 	dstAddrBytes := dstAddr.Bytes()
 
 	if bytes.Equal(dstAddrBytes, callerAddrBytes) {
 		// recipient is same as sender
-		return nil
-	}
-
-	// add to recipient's index
-	recipient, err := x.getIndexerForAddr(dstAddrBytes)
-	if err != nil {
-		return err
+		return [][]byte{callerAddrBytes}, nil
 	}
-	return recipient.Add(actHash[:], true)
+	return [][]byte{callerAddrBytes, dstAddrBytes}, nil
 }
@@ -258,6 +258,53 @@ func TestIndexer(t *testing.T) {
 			require.NoError(err)
 			require.EqualValues(len(indexTests[0].actions[i].hashes), actionCount)
 		}
+
+		x := indexer.(*blockIndexer)
+		// only the tip can be deleted
+		err = x.DeleteTipBlock(ctx, blks[1])
+		require.Equal(db.ErrInvalid, errors.Cause(err))
+		for i := 1; i < len(indexTests); i++ {
+			tipBlk := blks[len(blks)-i]
+			require.NoError(x.DeleteTipBlock(ctx, tipBlk))
+			height, err := indexer.Height()
+			require.NoError(err)
+			require.Equal(tipBlk.Height()-1, height)
+			_, err = indexer.GetBlockHeight(tipBlk.HashBlock())
+			require.Error(err)
+			for _, selp := range tipBlk.Actions {
+				h, err := selp.Hash()
+				require.NoError(err)
+				_, err = indexer.GetActionIndex(h[:])
+				require.Error(err)
+			}
+
+			total, err := indexer.GetTotalActions()
+			require.NoError(err)
+			require.EqualValues(indexTests[i].total, total)
+			if total > 0 {
+				actions, err := indexer.GetActionHashFromIndex(0, total)
+				require.NoError(err)
+				require.Equal(indexTests[i].hashTotal, actions)
+			}
+			for _, index := range indexTests[i].actions {
+				actionCount, err := indexer.GetActionCountByAddress(index.addr)
+				require.NoError(err)
+				require.EqualValues(len(index.hashes), actionCount)
+				if actionCount > 0 {
+					actions, err := indexer.GetActionsByAddress(index.addr, 0, actionCount)
+					require.NoError(err)
+					require.Equal(index.hashes, actions)
+				}
+			}
+		}
+
+		// the deleted blocks can be indexed again
+		for i := 0; i < 3; i++ {
+			require.NoError(indexer.PutBlock(ctx, blks[i]))
+		}
+		total, err := indexer.GetTotalActions()
+		require.NoError(err)
+		require.EqualValues(indexTests[0].total, total)
 	}
 
 	t.Run("In-memory KV indexer", func(t *testing.T) {
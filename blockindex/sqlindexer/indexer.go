@@ -0,0 +1,474 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+// Package sqlindexer exports the blocks, the actions, the receipts, the logs and the staking events to a sql database
+// for the analytics. The rows of a block and the height indexed are committed in one transaction, so the indexer
+// resumes from the height committed after a restart. The schema is created at start if not exists, which is written
+// for postgres
+package sqlindexer
+
+import (
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+	"sync/atomic"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	_ "github.com/lib/pq" // postgres driver
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+)
+
+type (
+	table struct {
+		name    string
+		columns []string
+		key     string
+	}
+
+	// Indexer writes the blocks to a sql database
+	Indexer struct {
+		cfg    Config
+		db     *sql.DB
+		height uint64
+	}
+)
+
+var (
+	_blocks = table{
+		name:    "blocks",
+		columns: []string{"height", "hash", "prev_hash", "block_time", "producer", "num_actions", "gas_used", "base_fee"},
+		key:     "height",
+	}
+	_actions = table{
+		name:    "actions",
+		columns: []string{"hash", "height", "idx", "type", "sender", "recipient", "nonce", "amount", "gas_limit", "gas_price", "data"},
+		key:     "hash",
+	}
+	_receipts = table{
+		name:    "receipts",
+		columns: []string{"action_hash", "height", "idx", "status", "gas_consumed", "effective_gas_price", "contract_address", "revert_msg"},
+		key:     "action_hash",
+	}
+	_logs = table{
+		name:    "logs",
+		columns: []string{"height", "idx", "action_hash", "tx_idx", "address", "topic0", "topic1", "topic2", "topic3", "data"},
+		key:     "height, idx",
+	}
+	_stakingEvents = table{
+		name:    "staking_events",
+		columns: []string{"action_hash", "height", "type", "sender", "bucket_index", "candidate", "amount", "duration", "auto_stake"},
+		key:     "action_hash",
+	}
+
+	_schema = []string{
+		`CREATE TABLE IF NOT EXISTS blocks (
+			height BIGINT PRIMARY KEY,
+			hash TEXT NOT NULL,
+			prev_hash TEXT NOT NULL,
+			block_time TIMESTAMPTZ NOT NULL,
+			producer TEXT NOT NULL,
+			num_actions INTEGER NOT NULL,
+			gas_used BIGINT NOT NULL,
+			base_fee NUMERIC
+		)`,
+		`CREATE TABLE IF NOT EXISTS actions (
+			hash TEXT PRIMARY KEY,
+			height BIGINT NOT NULL,
+			idx INTEGER NOT NULL,
+			type TEXT NOT NULL,
+			sender TEXT NOT NULL,
+			recipient TEXT,
+			nonce BIGINT NOT NULL,
+			amount NUMERIC,
+			gas_limit BIGINT NOT NULL,
+			gas_price NUMERIC,
+			data BYTEA
+		)`,
+		`CREATE INDEX IF NOT EXISTS actions_height ON actions (height)`,
+		`CREATE INDEX IF NOT EXISTS actions_sender ON actions (sender, height)`,
+		`CREATE INDEX IF NOT EXISTS actions_recipient ON actions (recipient, height)`,
+		`CREATE TABLE IF NOT EXISTS receipts (
+			action_hash TEXT PRIMARY KEY,
+			height BIGINT NOT NULL,
+			idx INTEGER NOT NULL,
+			status BIGINT NOT NULL,
+			gas_consumed BIGINT NOT NULL,
+			effective_gas_price NUMERIC,
+			contract_address TEXT,
+			revert_msg TEXT
+		)`,
+		`CREATE INDEX IF NOT EXISTS receipts_height ON receipts (height)`,
+		`CREATE TABLE IF NOT EXISTS logs (
+			height BIGINT NOT NULL,
+			idx INTEGER NOT NULL,
+			action_hash TEXT NOT NULL,
+			tx_idx INTEGER NOT NULL,
+			address TEXT NOT NULL,
+			topic0 TEXT,
+			topic1 TEXT,
+			topic2 TEXT,
+			topic3 TEXT,
+			data BYTEA,
+			PRIMARY KEY (height, idx)
+		)`,
+		`CREATE INDEX IF NOT EXISTS logs_address ON logs (address, topic0, height)`,
+		`CREATE TABLE IF NOT EXISTS staking_events (
+			action_hash TEXT PRIMARY KEY,
+			height BIGINT NOT NULL,
+			type TEXT NOT NULL,
+			sender TEXT NOT NULL,
+			bucket_index BIGINT,
+			candidate TEXT,
+			amount NUMERIC,
+			duration BIGINT,
+			auto_stake BOOLEAN
+		)`,
+		`CREATE INDEX IF NOT EXISTS staking_events_height ON staking_events (height)`,
+		`CREATE INDEX IF NOT EXISTS staking_events_bucket ON staking_events (bucket_index, height)`,
+		`CREATE TABLE IF NOT EXISTS sync_state (
+			id INTEGER PRIMARY KEY,
+			height BIGINT NOT NULL
+		)`,
+	}
+
+	// the tables with the rows of a block, the rows are deleted in the order when the block is reverted
+	_tables = []table{_stakingEvents, _logs, _receipts, _actions, _blocks}
+)
+
+// NewIndexer creates a sql indexer
+func NewIndexer(cfg Config) *Indexer {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = DefaultConfig.BatchSize
+	}
+	return &Indexer{cfg: cfg}
+}
+
+// Start connects to the database, creates the schema and loads the height indexed
+func (x *Indexer) Start(ctx context.Context) error {
+	db, err := sql.Open(x.cfg.Driver, x.cfg.DSN)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open %s database", x.cfg.Driver)
+	}
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return errors.Wrapf(err, "failed to connect to %s database", x.cfg.Driver)
+	}
+	for _, stmt := range _schema {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			db.Close()
+			return errors.Wrap(err, "failed to create the schema")
+		}
+	}
+	var height uint64
+	switch err := db.QueryRowContext(ctx, "SELECT height FROM sync_state WHERE id = 1").Scan(&height); err {
+	case nil, sql.ErrNoRows:
+	default:
+		db.Close()
+		return errors.Wrap(err, "failed to read the height indexed")
+	}
+	x.db = db
+	atomic.StoreUint64(&x.height, height)
+	return nil
+}
+
+// Stop closes the database
+func (x *Indexer) Stop(_ context.Context) error {
+	if x.db == nil {
+		return nil
+	}
+	err := x.db.Close()
+	x.db = nil
+	return err
+}
+
+// Height returns the height committed
+func (x *Indexer) Height() (uint64, error) {
+	return atomic.LoadUint64(&x.height), nil
+}
+
+// StartHeight returns the start height of the indexer
+func (x *Indexer) StartHeight() uint64 {
+	return x.cfg.StartHeight
+}
+
+// PutBlock writes the rows of the block and the height in one transaction
+func (x *Indexer) PutBlock(ctx context.Context, blk *block.Block) error {
+	height := atomic.LoadUint64(&x.height)
+	expectHeight := height + 1
+	if expectHeight < x.cfg.StartHeight {
+		expectHeight = x.cfg.StartHeight
+	}
+	if blk.Height() < expectHeight {
+		return nil
+	}
+	if blk.Height() > expectHeight {
+		return errors.Errorf("invalid block height %d, expect %d", blk.Height(), expectHeight)
+	}
+	rows, err := blockRows(blk)
+	if err != nil {
+		return err
+	}
+	tx, err := x.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin the transaction")
+	}
+	defer tx.Rollback()
+	for _, t := range []table{_blocks, _actions, _receipts, _logs, _stakingEvents} {
+		if err := x.insert(ctx, tx, t, rows[t.name]); err != nil {
+			return err
+		}
+	}
+	if err := putHeight(ctx, tx, blk.Height()); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return errors.Wrapf(err, "failed to commit block %d", blk.Height())
+	}
+	atomic.StoreUint64(&x.height, blk.Height())
+	return nil
+}
+
+// DeleteTipBlock deletes the rows of the block at the height indexed
+func (x *Indexer) DeleteTipBlock(ctx context.Context, blk *block.Block) error {
+	height := atomic.LoadUint64(&x.height)
+	if blk.Height() != height {
+		return errors.Errorf("invalid block height %d, expect %d", blk.Height(), height)
+	}
+	tx, err := x.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin the transaction")
+	}
+	defer tx.Rollback()
+	for _, t := range _tables {
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE height = $1", t.name), int64(height)); err != nil {
+			return errors.Wrapf(err, "failed to delete from %s", t.name)
+		}
+	}
+	if err := putHeight(ctx, tx, height-1); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return errors.Wrapf(err, "failed to delete block %d", height)
+	}
+	atomic.StoreUint64(&x.height, height-1)
+	return nil
+}
+
+// insert inserts the rows by the statements of at most BatchSize rows, the existing rows are kept
+func (x *Indexer) insert(ctx context.Context, tx *sql.Tx, t table, rows [][]any) error {
+	for len(rows) > 0 {
+		n := len(rows)
+		if n > x.cfg.BatchSize {
+			n = x.cfg.BatchSize
+		}
+		var (
+			sb   strings.Builder
+			args = make([]any, 0, n*len(t.columns))
+		)
+		fmt.Fprintf(&sb, "INSERT INTO %s (%s) VALUES ", t.name, strings.Join(t.columns, ", "))
+		for i, row := range rows[:n] {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteByte('(')
+			for j := range row {
+				if j > 0 {
+					sb.WriteString(", ")
+				}
+				args = append(args, row[j])
+				fmt.Fprintf(&sb, "$%d", len(args))
+			}
+			sb.WriteByte(')')
+		}
+		fmt.Fprintf(&sb, " ON CONFLICT (%s) DO NOTHING", t.key)
+		if _, err := tx.ExecContext(ctx, sb.String(), args...); err != nil {
+			return errors.Wrapf(err, "failed to insert into %s", t.name)
+		}
+		rows = rows[n:]
+	}
+	return nil
+}
+
+func putHeight(ctx context.Context, tx *sql.Tx, height uint64) error {
+	if _, err := tx.ExecContext(ctx,
+		"INSERT INTO sync_state (id, height) VALUES (1, $1) ON CONFLICT (id) DO UPDATE SET height = excluded.height",
+		int64(height),
+	); err != nil {
+		return errors.Wrap(err, "failed to update the height indexed")
+	}
+	return nil
+}
+
+// blockRows returns the rows of the block by the table name
+func blockRows(blk *block.Block) (map[string][][]any, error) {
+	var (
+		height   = int64(blk.Height())
+		blkHash  = blk.HashBlock()
+		prevHash = blk.PrevHash()
+		receipts = make(map[hash.Hash256]*action.Receipt, len(blk.Receipts))
+		rows     = make(map[string][][]any)
+	)
+	rows[_blocks.name] = [][]any{{
+		height,
+		hexHash(blkHash),
+		hexHash(prevHash),
+		blk.Timestamp().UTC(),
+		blk.ProducerAddress(),
+		len(blk.Actions),
+		int64(blk.GasUsed()),
+		bigString(blk.BaseFee()),
+	}}
+	for _, r := range blk.Receipts {
+		receipts[r.ActionHash] = r
+		rows[_receipts.name] = append(rows[_receipts.name], []any{
+			hexHash(r.ActionHash),
+			height,
+			int64(r.TxIndex),
+			int64(r.Status),
+			int64(r.GasConsumed),
+			bigString(r.EffectiveGasPrice),
+			nullString(r.ContractAddress),
+			nullString(r.ExecutionRevertMsg()),
+		})
+		for _, l := range r.Logs() {
+			row := []any{height, int64(l.Index), hexHash(r.ActionHash), int64(l.TxIndex), l.Address, nil, nil, nil, nil, l.Data}
+			for i, topic := range l.Topics {
+				if i < 4 {
+					row[5+i] = hexHash(topic)
+				}
+			}
+			rows[_logs.name] = append(rows[_logs.name], row)
+		}
+	}
+	for i, selp := range blk.Actions {
+		actHash, err := selp.Hash()
+		if err != nil {
+			return nil, err
+		}
+		var (
+			typ    = actionType(selp.Action())
+			sender = selp.SenderAddress().String()
+			to     any
+		)
+		if dest, ok := selp.Destination(); ok {
+			to = nullString(dest)
+		}
+		rows[_actions.name] = append(rows[_actions.name], []any{
+			hexHash(actHash),
+			height,
+			i,
+			typ,
+			sender,
+			to,
+			int64(selp.Nonce()),
+			bigString(selp.Value()),
+			int64(selp.Gas()),
+			bigString(selp.GasPrice()),
+			selp.Data(),
+		})
+		if r, ok := receipts[actHash]; ok && r.Status == uint64(iotextypes.ReceiptStatus_Success) {
+			if row := stakingEventRow(selp.Action(), r); row != nil {
+				rows[_stakingEvents.name] = append(rows[_stakingEvents.name], append([]any{hexHash(actHash), height, typ, sender}, row...))
+			}
+		}
+	}
+	return rows, nil
+}
+
+// stakingEventRow returns the bucket index, the candidate, the amount, the duration and the auto stake of a staking
+// action, or nil if it is not a staking action
+func stakingEventRow(act action.Action, r *action.Receipt) []any {
+	switch act.(type) {
+	case *action.CreateStake, *action.Unstake, *action.WithdrawStake, *action.ChangeCandidate, *action.TransferStake,
+		*action.DepositToStake, *action.Restake, *action.MigrateStake, *action.CandidateRegister, *action.CandidateUpdate,
+		*action.CandidateActivate, *action.CandidateEndorsement, *action.CandidateTransferOwnership:
+	default:
+		return nil
+	}
+	row := make([]any, 5)
+	switch v := act.(type) {
+	case interface{ BucketIndex() uint64 }:
+		row[0] = int64(v.BucketIndex())
+	case *action.CandidateActivate:
+		row[0] = int64(v.BucketID())
+	case *action.CreateStake, *action.CandidateRegister:
+		// the index of the bucket created is in the log of the staking protocol
+		if index, ok := createdBucketIndex(r); ok {
+			row[0] = int64(index)
+		}
+	}
+	switch v := act.(type) {
+	case interface{ Candidate() string }:
+		row[1] = nullString(v.Candidate())
+	case interface{ Name() string }:
+		row[1] = nullString(v.Name())
+	}
+	if v, ok := act.(interface{ Amount() *big.Int }); ok {
+		row[2] = bigString(v.Amount())
+	}
+	if v, ok := act.(interface{ Duration() uint32 }); ok {
+		row[3] = int64(v.Duration())
+	}
+	if v, ok := act.(interface{ AutoStake() bool }); ok {
+		row[4] = v.AutoStake()
+	}
+	return row
+}
+
+// createdBucketIndex returns the index of the bucket created, which is the data of the log before the fairbank
+// migration, and the second topic after it
+func createdBucketIndex(r *action.Receipt) (uint64, bool) {
+	for _, l := range r.Logs() {
+		if l.Address != address.StakingProtocolAddr {
+			continue
+		}
+		if len(l.Data) == 8 {
+			return binary.BigEndian.Uint64(l.Data), true
+		}
+		if len(l.Topics) >= 2 {
+			return binary.BigEndian.Uint64(l.Topics[1][24:]), true
+		}
+	}
+	return 0, false
+}
+
+func actionType(act action.Action) string {
+	t := reflect.TypeOf(act)
+	if t == nil {
+		return ""
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Name()
+}
+
+func hexHash(h hash.Hash256) string {
+	return hex.EncodeToString(h[:])
+}
+
+func bigString(v *big.Int) any {
+	if v == nil {
+		return nil
+	}
+	return v.String()
+}
+
+func nullString(s string) any {
+	if len(s) == 0 {
+		return nil
+	}
+	return s
+}
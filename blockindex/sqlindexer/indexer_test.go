@@ -0,0 +1,195 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package sqlindexer
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+func newTestBlock(t *testing.T, height uint64, acts []*action.SealedEnvelope, receipts []*action.Receipt) *block.Block {
+	blk, err := block.NewTestingBuilder().
+		SetHeight(height).
+		SetTimeStamp(time.Unix(int64(height), 0)).
+		AddActions(acts...).
+		SignAndBuild(identityset.PrivateKey(0))
+	require.NoError(t, err)
+	for i, r := range receipts {
+		r.BlockHeight = height
+		r.ActionHash, err = acts[i].Hash()
+		require.NoError(t, err)
+		r.TxIndex = uint32(i)
+		for _, l := range r.Logs() {
+			l.BlockHeight = height
+			l.ActionHash = r.ActionHash
+			l.TxIndex = r.TxIndex
+		}
+	}
+	blk.Receipts = receipts
+	return &blk
+}
+
+func TestIndexer(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	sk := identityset.PrivateKey(1)
+
+	exec, err := action.SignedExecution(identityset.Address(2).String(), sk, 1, big.NewInt(10), 100000, big.NewInt(1), []byte{1, 2})
+	r.NoError(err)
+	r1 := &action.Receipt{Status: 1, GasConsumed: 21000}
+	r1.AddLogs(&action.Log{
+		Address: identityset.Address(2).String(),
+		Topics:  action.Topics{hash.Hash256b([]byte("topic"))},
+		Data:    []byte{3},
+	})
+	blk1 := newTestBlock(t, 1, []*action.SealedEnvelope{exec}, []*action.Receipt{r1})
+
+	create, err := action.SignedCreateStake(2, "cand", "100", 7, true, nil, 100000, big.NewInt(1), sk)
+	r.NoError(err)
+	r2 := &action.Receipt{Status: 1, GasConsumed: 10000}
+	r2.AddLogs(&action.Log{
+		Address: address.StakingProtocolAddr,
+		Topics: action.Topics{
+			hash.BytesToHash256([]byte("createStake")),
+			hash.BytesToHash256(byteutil.Uint64ToBytesBigEndian(5)),
+		},
+	})
+	deposit, err := action.SignedDepositToStake(3, 5, "10", nil, 100000, big.NewInt(1), sk)
+	r.NoError(err)
+	blk2 := newTestBlock(t, 2, []*action.SealedEnvelope{create, deposit}, []*action.Receipt{r2, {Status: 0, GasConsumed: 10000}})
+
+	unstake, err := action.SignedReclaimStake(false, 4, 5, nil, 100000, big.NewInt(1), sk)
+	r.NoError(err)
+	blk3 := newTestBlock(t, 3, []*action.SealedEnvelope{unstake}, []*action.Receipt{{Status: 1, GasConsumed: 10000}})
+
+	cfg := DefaultConfig
+	cfg.Driver = "sqlite"
+	cfg.DSN = filepath.Join(t.TempDir(), "index.db")
+	// the rows are inserted one by one
+	cfg.BatchSize = 1
+	x := NewIndexer(cfg)
+	r.NoError(x.Start(ctx))
+	height, err := x.Height()
+	r.NoError(err)
+	r.Zero(height)
+	r.NoError(x.PutBlock(ctx, blk1))
+	r.ErrorContains(x.PutBlock(ctx, blk3), "invalid block height 3, expect 2")
+	r.NoError(x.PutBlock(ctx, blk2))
+	r.NoError(x.PutBlock(ctx, blk3))
+
+	count := func(table string) int {
+		var n int
+		r.NoError(x.db.QueryRow("SELECT COUNT(*) FROM " + table).Scan(&n))
+		return n
+	}
+	r.Equal(3, count("blocks"))
+	r.Equal(4, count("actions"))
+	r.Equal(4, count("receipts"))
+	r.Equal(2, count("logs"))
+	// the failed deposit is not a staking event
+	r.Equal(2, count("staking_events"))
+
+	var (
+		typ, sender, recipient, amount string
+		data                           []byte
+	)
+	execHash, err := exec.Hash()
+	r.NoError(err)
+	r.NoError(x.db.QueryRow(
+		"SELECT type, sender, recipient, CAST(amount AS TEXT), data FROM actions WHERE hash = $1", hexHash(execHash),
+	).Scan(&typ, &sender, &recipient, &amount, &data))
+	r.Equal("Execution", typ)
+	r.Equal(identityset.Address(1).String(), sender)
+	r.Equal(identityset.Address(2).String(), recipient)
+	r.Equal("10", amount)
+	r.Equal([]byte{1, 2}, data)
+
+	var (
+		topic0 string
+		topic1 *string
+	)
+	r.NoError(x.db.QueryRow("SELECT topic0, topic1, data FROM logs WHERE height = 1 AND idx = 0").Scan(&topic0, &topic1, &data))
+	r.Equal(hexHash(hash.Hash256b([]byte("topic"))), topic0)
+	r.Nil(topic1)
+	r.Equal([]byte{3}, data)
+
+	var (
+		bucket    int64
+		candidate string
+		duration  int64
+		autoStake bool
+	)
+	createHash, err := create.Hash()
+	r.NoError(err)
+	r.NoError(x.db.QueryRow(
+		"SELECT type, bucket_index, candidate, CAST(amount AS TEXT), duration, auto_stake FROM staking_events WHERE action_hash = $1",
+		hexHash(createHash),
+	).Scan(&typ, &bucket, &candidate, &amount, &duration, &autoStake))
+	r.Equal("CreateStake", typ)
+	r.EqualValues(5, bucket)
+	r.Equal("cand", candidate)
+	r.Equal("100", amount)
+	r.EqualValues(7, duration)
+	r.True(autoStake)
+	r.NoError(x.db.QueryRow("SELECT type, bucket_index FROM staking_events WHERE height = 3").Scan(&typ, &bucket))
+	r.Equal("Unstake", typ)
+	r.EqualValues(5, bucket)
+
+	// revert the tip block
+	r.ErrorContains(x.DeleteTipBlock(ctx, blk2), "invalid block height 2, expect 3")
+	r.NoError(x.DeleteTipBlock(ctx, blk3))
+	r.Equal(2, count("blocks"))
+	r.Equal(3, count("actions"))
+	r.Equal(1, count("staking_events"))
+	r.NoError(x.Stop(ctx))
+
+	// resume from the height committed
+	x = NewIndexer(cfg)
+	r.NoError(x.Start(ctx))
+	height, err = x.Height()
+	r.NoError(err)
+	r.EqualValues(2, height)
+	r.NoError(x.PutBlock(ctx, blk2))
+	r.NoError(x.PutBlock(ctx, blk3))
+	r.Equal(3, count("blocks"))
+	r.Equal(2, count("staking_events"))
+	r.NoError(x.Stop(ctx))
+}
+
+func TestIndexerStartHeight(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	cfg := DefaultConfig
+	cfg.Driver = "sqlite"
+	cfg.DSN = filepath.Join(t.TempDir(), "index.db")
+	cfg.StartHeight = 2
+	x := NewIndexer(cfg)
+	r.NoError(x.Start(ctx))
+	defer x.Stop(ctx)
+	r.EqualValues(2, x.StartHeight())
+	// the blocks before the start height are skipped
+	r.NoError(x.PutBlock(ctx, newTestBlock(t, 1, nil, nil)))
+	height, err := x.Height()
+	r.NoError(err)
+	r.Zero(height)
+	r.NoError(x.PutBlock(ctx, newTestBlock(t, 2, nil, nil)))
+	height, err = x.Height()
+	r.NoError(err)
+	r.EqualValues(2, height)
+}
@@ -0,0 +1,210 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/blocksync/blocksyncpb"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+	"github.com/iotexproject/iotex-core/v2/pkg/routine"
+)
+
+// AvailabilityProtocol is the name of the p2p protocol advertising the height ranges of the data served by the nodes
+const AvailabilityProtocol = "blockavail"
+
+// _availabilityTTLFactor is the number of the advertise intervals after which an advertisement expires
+const _availabilityTTLFactor = 3
+
+type (
+	// HeightRange is the range of the heights [Start, End]
+	HeightRange struct {
+		Start uint64
+		End   uint64
+	}
+
+	// Availability is the height ranges of the blocks, the receipts and the states a node serves
+	Availability struct {
+		Blocks   HeightRange
+		Receipts HeightRange
+		States   HeightRange
+	}
+
+	// LocalAvailability returns the availability of the node itself
+	LocalAvailability func() Availability
+	// AvailabilityOutbound sends the message of the availability protocol to the peer
+	AvailabilityOutbound func(context.Context, peer.AddrInfo, []byte) error
+
+	// Advertiser advertises the height ranges of the data served by the node to the peers, and keeps the ranges
+	// advertised by the peers, so that the pruned nodes and the archive nodes coexist and the range requests are
+	// routed to the peers actually having the data
+	Advertiser struct {
+		interval  time.Duration
+		local     LocalAvailability
+		neighbors Neighbors
+		outbound  AvailabilityOutbound
+		task      *routine.RecurringTask
+
+		mu    sync.RWMutex
+		peers map[string]peerAvailability
+	}
+
+	peerAvailability struct {
+		Availability
+		updated time.Time
+	}
+)
+
+// Contains returns true if the height is in the range
+func (r HeightRange) Contains(height uint64) bool {
+	return r.Start <= height && height <= r.End
+}
+
+func (r HeightRange) toProto() *blocksyncpb.HeightRange {
+	return &blocksyncpb.HeightRange{Start: r.Start, End: r.End}
+}
+
+func heightRangeFromProto(r *blocksyncpb.HeightRange) HeightRange {
+	return HeightRange{Start: r.GetStart(), End: r.GetEnd()}
+}
+
+// NewAdvertiser creates the advertiser advertising the local availability to the neighbors every interval, the
+// availability is not advertised if the interval is 0
+func NewAdvertiser(interval time.Duration, local LocalAvailability, neighbors Neighbors, outbound AvailabilityOutbound) *Advertiser {
+	a := &Advertiser{
+		interval:  interval,
+		local:     local,
+		neighbors: neighbors,
+		outbound:  outbound,
+		peers:     map[string]peerAvailability{},
+	}
+	if interval > 0 {
+		a.task = routine.NewRecurringTask(func() { a.Advertise(context.Background()) }, interval)
+	}
+	return a
+}
+
+// Start starts advertising the availability periodically
+func (a *Advertiser) Start(ctx context.Context) error {
+	if a.task == nil {
+		return nil
+	}
+	return a.task.Start(ctx)
+}
+
+// Stop stops advertising the availability
+func (a *Advertiser) Stop(ctx context.Context) error {
+	if a.task == nil {
+		return nil
+	}
+	return a.task.Stop(ctx)
+}
+
+// Advertise sends the local availability to the neighbors, and drops the advertisements expired
+func (a *Advertiser) Advertise(ctx context.Context) {
+	a.expire(time.Now())
+	peers, err := a.neighbors()
+	if err != nil {
+		log.Logger("blocksync").Error("failed to get neighbours", zap.Error(err))
+		return
+	}
+	local := a.local()
+	data, err := proto.Marshal(&blocksyncpb.Availability{
+		Blocks:   local.Blocks.toProto(),
+		Receipts: local.Receipts.toProto(),
+		States:   local.States.toProto(),
+	})
+	if err != nil {
+		log.Logger("blocksync").Error("failed to serialize availability", zap.Error(err))
+		return
+	}
+	for _, p := range peers {
+		if err := a.outbound(ctx, p, data); err != nil {
+			log.Logger("blocksync").Debug("failed to advertise availability", zap.String("peer", p.ID.String()), zap.Error(err))
+		}
+	}
+}
+
+// HandleMessage keeps the availability advertised by the peer. An error is returned only if the message is malformed
+func (a *Advertiser) HandleMessage(_ context.Context, from peer.AddrInfo, data []byte) error {
+	msg := &blocksyncpb.Availability{}
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return errors.Wrap(err, "failed to parse availability")
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.peers[from.ID.String()] = peerAvailability{
+		Availability: Availability{
+			Blocks:   heightRangeFromProto(msg.GetBlocks()),
+			Receipts: heightRangeFromProto(msg.GetReceipts()),
+			States:   heightRangeFromProto(msg.GetStates()),
+		},
+		updated: time.Now(),
+	}
+	return nil
+}
+
+func (a *Advertiser) expire(now time.Time) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for pid, pa := range a.peers {
+		if a.expired(pa, now) {
+			delete(a.peers, pid)
+		}
+	}
+}
+
+func (a *Advertiser) expired(pa peerAvailability, now time.Time) bool {
+	return a.interval > 0 && now.Sub(pa.updated) > _availabilityTTLFactor*a.interval
+}
+
+// Availability returns the availability advertised by the peer, false if the peer hasn't advertised or the
+// advertisement expired
+func (a *Advertiser) Availability(pid string) (Availability, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	pa, ok := a.peers[pid]
+	if !ok || a.expired(pa, time.Now()) {
+		return Availability{}, false
+	}
+	return pa.Availability, true
+}
+
+// ServesBlocks returns whether the peer serves the blocks starting from the height, known is false if the
+// availability of the peer is unknown. Only the start height is checked against the range advertised, since the tip
+// of the peer moves on after the advertisement
+func (a *Advertiser) ServesBlocks(pid string, start uint64) (serves bool, known bool) {
+	av, ok := a.Availability(pid)
+	if !ok {
+		return false, false
+	}
+	return av.Blocks.Contains(start), true
+}
+
+// PeersServingBlocks returns the peers advertising the blocks starting from the height, or the peers of unknown
+// availability if none advertises them. The peers advertising not having the blocks are never returned
+func (a *Advertiser) PeersServingBlocks(peers []peer.AddrInfo, start uint64) []peer.AddrInfo {
+	var serving, unknown []peer.AddrInfo
+	for _, p := range peers {
+		switch serves, known := a.ServesBlocks(p.ID.String(), start); {
+		case serves:
+			serving = append(serving, p)
+		case !known:
+			unknown = append(unknown, p)
+		}
+	}
+	if len(serving) > 0 {
+		return serving
+	}
+	return unknown
+}
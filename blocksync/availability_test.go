@@ -0,0 +1,87 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdvertiser(t *testing.T) {
+	require := require.New(t)
+	var (
+		ctx     = context.Background()
+		pruned  = peer.AddrInfo{ID: peer.ID("pruned")}
+		archive = peer.AddrInfo{ID: peer.ID("archive")}
+		unknown = peer.AddrInfo{ID: peer.ID("unknown")}
+		peers   = []peer.AddrInfo{pruned, archive, unknown}
+		local   = Availability{
+			Blocks:   HeightRange{Start: 1, End: 200},
+			Receipts: HeightRange{Start: 1, End: 200},
+			States:   HeightRange{Start: 200, End: 200},
+		}
+		sent = map[peer.ID][]byte{}
+	)
+	a := NewAdvertiser(time.Minute, func() Availability { return local },
+		func() ([]peer.AddrInfo, error) { return peers, nil },
+		func(_ context.Context, p peer.AddrInfo, data []byte) error {
+			sent[p.ID] = data
+			return nil
+		},
+	)
+	a.Advertise(ctx)
+	require.Len(sent, 3)
+
+	// the availability advertised is kept
+	require.NoError(a.HandleMessage(ctx, archive, sent[archive.ID]))
+	av, ok := a.Availability(archive.ID.String())
+	require.True(ok)
+	require.Equal(local, av)
+	local.Blocks.Start, local.Receipts.Start = 101, 101
+	a.Advertise(ctx)
+	require.NoError(a.HandleMessage(ctx, pruned, sent[pruned.ID]))
+	require.Error(a.HandleMessage(ctx, unknown, []byte{0xff}))
+	_, ok = a.Availability(unknown.ID.String())
+	require.False(ok)
+
+	for _, c := range []struct {
+		pid    peer.ID
+		start  uint64
+		serves bool
+		known  bool
+	}{
+		{archive.ID, 1, true, true},
+		{pruned.ID, 1, false, true},
+		{pruned.ID, 150, true, true},
+		{pruned.ID, 201, false, true},
+		{unknown.ID, 1, false, false},
+	} {
+		serves, known := a.ServesBlocks(c.pid.String(), c.start)
+		require.Equal(c.serves, serves, c)
+		require.Equal(c.known, known, c)
+	}
+
+	// the peers advertising the blocks are preferred, and the peers advertising not having them are excluded
+	require.Equal([]peer.AddrInfo{archive}, a.PeersServingBlocks(peers, 1))
+	require.Equal([]peer.AddrInfo{pruned, archive}, a.PeersServingBlocks(peers, 150))
+	require.Equal([]peer.AddrInfo{unknown}, a.PeersServingBlocks(peers, 300))
+	require.Empty(a.PeersServingBlocks(peers[:1], 1))
+
+	// the advertisements expire
+	a.mu.Lock()
+	pa := a.peers[archive.ID.String()]
+	pa.updated = time.Now().Add(-4 * time.Minute)
+	a.peers[archive.ID.String()] = pa
+	a.mu.Unlock()
+	_, ok = a.Availability(archive.ID.String())
+	require.False(ok)
+	a.Advertise(ctx)
+	require.Len(a.peers, 1)
+}
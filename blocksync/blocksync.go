@@ -12,6 +12,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-proto/golang/iotexrpc"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/pkg/errors"
@@ -35,6 +36,10 @@ type (
 	UniCastOutbound func(context.Context, peer.AddrInfo, proto.Message) error
 	// BlockPeer adds the peer into blacklist in p2p layer
 	BlockPeer func(string)
+	// PeerTimeout reports the peer not responding to the request in time
+	PeerTimeout func(string)
+	// Option is the option of the block syncer
+	Option func(*blockSyncer)
 	// TipHeight returns the tip height of blockchain
 	TipHeight func() uint64
 	// BlockByHeight returns the block of a given height
@@ -54,14 +59,18 @@ type (
 		ProcessBlock(context.Context, string, *block.Block) error
 		// SyncStatus report block sync status
 		SyncStatus() (startingHeight uint64, currentHeight uint64, targetHeight uint64, syncSpeedDesc string)
+		// HeaderTip returns the height and the hash of the header tip synced ahead of the blocks committed
+		HeaderTip() (uint64, hash.Hash256)
 	}
 
 	dummyBlockSync struct{}
 
 	// blockSyncer implements BlockSync interface
 	blockSyncer struct {
-		cfg Config
-		buf *blockBuffer
+		cfg       Config
+		buf       *blockBuffer
+		scheduler *rangeScheduler
+		headers   *headerChain
 
 		tipHeightHandler     TipHeight
 		blockByHeightHandler BlockByHeight
@@ -69,6 +78,8 @@ type (
 		p2pNeighbor          Neighbors
 		unicastOutbound      UniCastOutbound
 		blockP2pPeer         BlockPeer
+		peerTimeout          PeerTimeout
+		advertiser           *Advertiser
 
 		syncTask      *routine.RecurringTask
 		syncStageTask *routine.RecurringTask
@@ -125,10 +136,28 @@ func (*dummyBlockSync) SyncStatus() (uint64, uint64, uint64, string) {
 	return 0, 0, 0, ""
 }
 
+func (*dummyBlockSync) HeaderTip() (uint64, hash.Hash256) {
+	return 0, hash.ZeroHash256
+}
+
 func (*dummyBlockSync) BuildReport() string {
 	return ""
 }
 
+// WithPeerTimeout sets the handler of the peers not completing the ranges requested in time
+func WithPeerTimeout(f PeerTimeout) Option {
+	return func(bs *blockSyncer) {
+		bs.peerTimeout = f
+	}
+}
+
+// WithAdvertiser routes the range requests to the peers by the height ranges they advertise
+func WithAdvertiser(a *Advertiser) Option {
+	return func(bs *blockSyncer) {
+		bs.advertiser = a
+	}
+}
+
 // NewBlockSyncer returns a new block syncer instance
 func NewBlockSyncer(
 	cfg Config,
@@ -138,6 +167,7 @@ func NewBlockSyncer(
 	p2pNeighbor Neighbors,
 	uniCastHandler UniCastOutbound,
 	blockP2pPeer BlockPeer,
+	opts ...Option,
 ) (BlockSync, error) {
 	bs := &blockSyncer{
 		cfg:                  cfg,
@@ -151,6 +181,15 @@ func NewBlockSyncer(
 		blockP2pPeer:         blockP2pPeer,
 		targetHeight:         0,
 	}
+	for _, opt := range opts {
+		opt(bs)
+	}
+	if bs.cfg.ParallelPeers > 0 {
+		bs.scheduler = newRangeScheduler(bs.cfg.ParallelPeers, bs.cfg.RangeTimeout)
+	}
+	if bs.cfg.HeaderFirst {
+		bs.headers = newHeaderChain(bs.cfg.HeaderBufferSize)
+	}
 	if bs.cfg.Interval != 0 {
 		bs.syncTask = routine.NewRecurringTask(bs.sync, bs.cfg.Interval)
 		bs.syncStageTask = routine.NewRecurringTask(bs.syncStageChecker, bs.cfg.Interval)
@@ -167,14 +206,17 @@ func (bs *blockSyncer) commitBlocks(blks []*peerBlock) bool {
 		err := bs.commitBlockHandler(blk.block)
 		switch errors.Cause(err) {
 		case nil:
+			if bs.headers != nil {
+				bs.headers.Commit(blk.block.Height(), blk.block.HashBlock())
+			}
 			return true
 		case blockdao.ErrRemoteHeightTooLow:
-			log.L().Info("remote height too low", zap.Uint64("height", blk.block.Height()))
+			log.Logger("blocksync").Info("remote height too low", zap.Uint64("height", blk.block.Height()))
 		case blockchain.ErrPaused:
-			log.L().Info("blockchain is paused, skip committing block", zap.Uint64("height", blk.block.Height()))
+			log.Logger("blocksync").Info("blockchain is paused, skip committing block", zap.Uint64("height", blk.block.Height()))
 		default:
 			bs.blockP2pPeer(blk.pid)
-			log.L().Error("failed to commit block", zap.Error(err), zap.Uint64("height", blk.block.Height()), zap.String("peer", blk.pid))
+			log.Logger("blocksync").Error("failed to commit block", zap.Error(err), zap.Uint64("height", blk.block.Height()), zap.String("peer", blk.pid))
 		}
 	}
 	return false
@@ -188,18 +230,22 @@ func (bs *blockSyncer) flushInfo() (time.Time, uint64) {
 }
 
 func (bs *blockSyncer) sync() {
+	if bs.scheduler != nil {
+		bs.dispatch(context.Background())
+		return
+	}
 	updateTime, targetHeight := bs.flushInfo()
 	if updateTime.Add(bs.cfg.Interval).After(time.Now()) {
 		return
 	}
-	intervals := bs.buf.GetBlocksIntervalsToSync(bs.tipHeightHandler(), targetHeight)
+	intervals := bs.intervalsToSync(bs.tipHeightHandler(), targetHeight)
 	// no sync
 	if len(intervals) == 0 {
 		return
 	}
 	// start syncing
 	bs.startingHeight = bs.tipHeightHandler()
-	log.L().Info("block sync intervals.",
+	log.Logger("blocksync").Info("block sync intervals.",
 		zap.Any("intervals", intervals),
 		zap.Uint64("targetHeight", targetHeight))
 	for i, interval := range intervals {
@@ -207,16 +253,80 @@ func (bs *blockSyncer) sync() {
 	}
 }
 
+// intervalsToSync returns the intervals of the blocks missing from the buffer, followed by the intervals of the
+// headers missing beyond the buffer in header-first sync mode
+func (bs *blockSyncer) intervalsToSync(tip, targetHeight uint64) []syncBlocksInterval {
+	intervals := bs.buf.GetBlocksIntervalsToSync(tip, targetHeight)
+	if bs.headers == nil {
+		return intervals
+	}
+	return append(intervals, bs.headers.IntervalsToSync(tip+bs.cfg.BufferSize+1, targetHeight, bs.cfg.IntervalSize)...)
+}
+
+// dispatch requests the missing ranges from the idle peers, each peer downloads a range disjoint from the others
+func (bs *blockSyncer) dispatch(ctx context.Context) {
+	_, targetHeight := bs.flushInfo()
+	tip := bs.tipHeightHandler()
+	intervals := bs.intervalsToSync(tip, targetHeight)
+	if len(intervals) == 0 {
+		return
+	}
+	peers, err := bs.p2pNeighbor()
+	if err != nil {
+		log.Logger("blocksync").Error("failed to get neighbours", zap.Error(err))
+		return
+	}
+	if len(peers) == 0 {
+		log.Logger("blocksync").Error("no peers")
+		return
+	}
+	now := time.Now()
+	for _, pid := range bs.scheduler.Expire(tip, now) {
+		if bs.peerTimeout != nil {
+			bs.peerTimeout(pid)
+		}
+	}
+	var serves func(string, uint64) (bool, bool)
+	if bs.advertiser != nil {
+		serves = bs.advertiser.ServesBlocks
+	}
+	assigned := bs.scheduler.Assign(intervals, peers, now, serves)
+	if len(assigned) == 0 {
+		return
+	}
+	bs.mu.Lock()
+	if bs.startingHeight == 0 {
+		bs.startingHeight = tip
+	}
+	bs.mu.Unlock()
+	for _, a := range assigned {
+		log.Logger("blocksync").Debug("request block range",
+			zap.String("peer", a.peer.ID.String()),
+			zap.Uint64("start", a.Start),
+			zap.Uint64("end", a.End),
+			zap.Float64("score", bs.scheduler.Score(a.peer.ID.String())))
+		if err := bs.unicastOutbound(ctx, a.peer, &iotexrpc.BlockSync{Start: a.Start, End: a.End}); err != nil {
+			log.Logger("blocksync").Error("failed to request blocks", zap.Error(err), zap.String("peer", a.peer.ID.String()), zap.Uint64("start", a.Start), zap.Uint64("end", a.End))
+		}
+	}
+}
+
 func (bs *blockSyncer) requestBlock(ctx context.Context, start uint64, end uint64, repeat int) {
 	peers, err := bs.p2pNeighbor()
 	if err != nil {
-		log.L().Error("failed to get neighbours", zap.Error(err))
+		log.Logger("blocksync").Error("failed to get neighbours", zap.Error(err))
 		return
 	}
 	if len(peers) == 0 {
-		log.L().Error("no peers")
+		log.Logger("blocksync").Error("no peers")
 		return
 	}
+	if bs.advertiser != nil {
+		if peers = bs.advertiser.PeersServingBlocks(peers, start); len(peers) == 0 {
+			log.Logger("blocksync").Warn("no peers serving blocks", zap.Uint64("start", start))
+			return
+		}
+	}
 	if repeat < 2 {
 		repeat = 2
 	}
@@ -230,11 +340,30 @@ func (bs *blockSyncer) requestBlock(ctx context.Context, start uint64, end uint6
 			peer,
 			&iotexrpc.BlockSync{Start: start, End: end},
 		); err != nil {
-			log.L().Error("failed to request blocks", zap.Error(err), zap.String("peer", peer.ID.String()), zap.Uint64("start", start), zap.Uint64("end", end))
+			log.Logger("blocksync").Error("failed to request blocks", zap.Error(err), zap.String("peer", peer.ID.String()), zap.Uint64("start", start), zap.Uint64("end", end))
 		}
 	}
 }
 
+// HeaderTip returns the height and the hash of the header tip, which is the tip of the chain if the header-first
+// sync mode is disabled
+func (bs *blockSyncer) HeaderTip() (uint64, hash.Hash256) {
+	tip := bs.tipHeightHandler()
+	if bs.headers != nil {
+		if height, h := bs.headers.Tip(); height > tip {
+			return height, h
+		}
+	}
+	if tip == 0 {
+		return 0, block.GenesisHash()
+	}
+	blk, err := bs.blockByHeightHandler(tip)
+	if err != nil {
+		return tip, hash.ZeroHash256
+	}
+	return tip, blk.HashBlock()
+}
+
 func (bs *blockSyncer) TargetHeight() uint64 {
 	bs.mu.RLock()
 	defer bs.mu.RUnlock()
@@ -243,7 +372,19 @@ func (bs *blockSyncer) TargetHeight() uint64 {
 
 // Start starts a block syncer
 func (bs *blockSyncer) Start(ctx context.Context) error {
-	log.L().Debug("Starting block syncer.")
+	log.Logger("blocksync").Debug("Starting block syncer.")
+	if bs.headers != nil {
+		tip := bs.tipHeightHandler()
+		tipHash := block.GenesisHash()
+		if tip > 0 {
+			blk, err := bs.blockByHeightHandler(tip)
+			if err != nil {
+				return errors.Wrapf(err, "failed to get tip block %d", tip)
+			}
+			tipHash = blk.HashBlock()
+		}
+		bs.headers.Commit(tip, tipHash)
+	}
 	if bs.syncTask != nil {
 		if err := bs.syncTask.Start(ctx); err != nil {
 			return err
@@ -257,7 +398,7 @@ func (bs *blockSyncer) Start(ctx context.Context) error {
 
 // Stop stops a block syncer
 func (bs *blockSyncer) Stop(ctx context.Context) error {
-	log.L().Debug("Stopping block syncer.")
+	log.Logger("blocksync").Debug("Stopping block syncer.")
 	if bs.syncStageTask != nil {
 		if err := bs.syncStageTask.Stop(ctx); err != nil {
 			return err
@@ -275,7 +416,30 @@ func (bs *blockSyncer) ProcessBlock(ctx context.Context, peer string, blk *block
 	if blk == nil {
 		return errors.New("block is nil")
 	}
+	// the blocks are verified as they arrive from the peers concurrently, ahead of the commit in order
+	if tip := bs.tipHeightHandler(); blk.Height() > tip && (bs.headers != nil || blk.Height() <= tip+bs.cfg.BufferSize) {
+		if err := verifyBlock(blk); err != nil {
+			bs.blockP2pPeer(peer)
+			return errors.Wrapf(err, "invalid block %d from peer %s", blk.Height(), peer)
+		}
+		if bs.headers != nil {
+			bs.headers.Add(&blk.Header)
+			bs.mu.Lock()
+			bs.targetHeight = max(bs.targetHeight, blk.Height())
+			bs.mu.Unlock()
+		}
+	}
+	if err := bs.flush(peer, blk); err != nil {
+		return err
+	}
+	if bs.scheduler != nil && bs.scheduler.Received(peer, blk.Height(), time.Now()) {
+		// the peer is idle, request the next range
+		bs.dispatch(ctx)
+	}
+	return nil
+}
 
+func (bs *blockSyncer) flush(peer string, blk *block.Block) error {
 	tip := bs.tipHeightHandler()
 	added, targetHeight := bs.buf.AddBlock(tip, newPeerBlock(peer, blk))
 	bs.mu.Lock()
@@ -293,7 +457,7 @@ func (bs *blockSyncer) ProcessBlock(ctx context.Context, peer string, blk *block
 		}
 		syncedHeight++
 	}
-	log.L().Debug("flush blocks", zap.Uint64("start", tip), zap.Uint64("end", syncedHeight))
+	log.Logger("blocksync").Debug("flush blocks", zap.Uint64("start", tip), zap.Uint64("end", syncedHeight))
 	if syncedHeight > bs.lastTip {
 		bs.lastTip = syncedHeight
 		bs.lastTipUpdateTime = time.Now()
@@ -301,10 +465,20 @@ func (bs *blockSyncer) ProcessBlock(ctx context.Context, peer string, blk *block
 	return nil
 }
 
+// verifyBlock verifies the signature of the header and the transaction root of the body
+func verifyBlock(blk *block.Block) error {
+	if !blk.VerifySignature() {
+		return errors.New("failed to verify block signature")
+	}
+	return blk.VerifyTxRoot()
+}
+
 func (bs *blockSyncer) ProcessSyncRequest(ctx context.Context, peer peer.AddrInfo, start uint64, end uint64) error {
+	// the blocks below the height served are pruned or not synced
+	start = max(start, bs.cfg.ServeFromHeight)
 	tip := bs.tipHeightHandler()
 	if end > tip {
-		log.L().Debug(
+		log.Logger("blocksync").Debug(
 			"Do not have requested blocks",
 			zap.Uint64("start", start),
 			zap.Uint64("end", end),
@@ -351,11 +525,15 @@ func (bs *blockSyncer) SyncStatus() (uint64, uint64, uint64, string) {
 // BuildReport builds a report of block syncer
 func (bs *blockSyncer) BuildReport() string {
 	startingHeight, tipHeight, targetHeight, syncSpeedDesc := bs.SyncStatus()
-	return fmt.Sprintf(
+	report := fmt.Sprintf(
 		"BlockSync startingHeight: %d, tipHeight: %d, targetHeight: %d, %s",
 		startingHeight,
 		tipHeight,
 		targetHeight,
 		syncSpeedDesc,
 	)
+	if bs.scheduler != nil {
+		report += fmt.Sprintf(", ranges in flight: %d", bs.scheduler.Inflight())
+	}
+	return report
 }
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotexrpc"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
@@ -180,6 +181,13 @@ func TestBlockSyncerProcessSyncRequestError(t *testing.T) {
 	require.NoError(err)
 
 	require.Error(bs.ProcessSyncRequest(context.Background(), peer.AddrInfo{}, 1, 5))
+
+	// the blocks below the height served are not read
+	cfg.BlockSync.ServeFromHeight = 6
+	chain.EXPECT().TipHeight().Return(uint64(5)).Times(1)
+	bs, err = newBlockSyncerForTest(cfg.BlockSync, chain, dao, cs)
+	require.NoError(err)
+	require.NoError(bs.ProcessSyncRequest(context.Background(), peer.AddrInfo{}, 1, 5))
 }
 
 func TestBlockSyncerProcessBlockTipHeight(t *testing.T) {
@@ -507,6 +515,148 @@ func TestBlockSyncerSync(t *testing.T) {
 	time.Sleep(time.Millisecond << 7)
 }
 
+func TestBlockSyncerParallelSync(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	var (
+		tip       uint64
+		committed []uint64
+		blocked   []string
+		requested = map[peer.ID]*iotexrpc.BlockSync{}
+		peers     = []peer.AddrInfo{{ID: peer.ID("peer1")}, {ID: peer.ID("peer2")}, {ID: peer.ID("peer3")}}
+		p1, p2    = peers[0].ID.String(), peers[1].ID.String()
+	)
+	cfg := DefaultConfig
+	cfg.Interval = 0
+	cfg.IntervalSize = 2
+	cfg.BufferSize = 4
+	cfg.ParallelPeers = 2
+	bs, err := NewBlockSyncer(cfg,
+		func() uint64 { return tip },
+		nil,
+		func(blk *block.Block) error {
+			committed = append(committed, blk.Height())
+			tip = blk.Height()
+			return nil
+		},
+		func() ([]peer.AddrInfo, error) { return peers, nil },
+		func(_ context.Context, p peer.AddrInfo, msg proto.Message) error {
+			requested[p.ID] = msg.(*iotexrpc.BlockSync)
+			return nil
+		},
+		func(pid string) { blocked = append(blocked, pid) },
+	)
+	require.NoError(err)
+	syncer := bs.(*blockSyncer)
+	blks := make([]*block.Block, 6)
+	for i := range blks {
+		blk, err := block.NewTestingBuilder().
+			SetHeight(uint64(i + 1)).
+			SetPrevBlockHash(hash.ZeroHash256).
+			SetTimeStamp(testutil.TimestampNow()).
+			SignAndBuild(identityset.PrivateKey(27))
+		require.NoError(err)
+		blks[i] = &blk
+	}
+
+	// disjoint ranges are requested from the peers
+	syncer.targetHeight = 6
+	syncer.sync()
+	require.Len(requested, 2)
+	require.Equal(uint64(1), requested[peers[0].ID].Start)
+	require.Equal(uint64(2), requested[peers[0].ID].End)
+	require.Equal(uint64(3), requested[peers[1].ID].Start)
+	require.Equal(uint64(4), requested[peers[1].ID].End)
+
+	// the blocks are committed in order, and the next range within the buffer is requested once a peer is idle
+	require.NoError(bs.ProcessBlock(ctx, p2, blks[2]))
+	require.NoError(bs.ProcessBlock(ctx, p2, blks[3]))
+	require.Empty(committed)
+	require.Len(requested, 2)
+	require.NoError(bs.ProcessBlock(ctx, p1, blks[0]))
+	require.NoError(bs.ProcessBlock(ctx, p1, blks[1]))
+	require.Equal([]uint64{1, 2, 3, 4}, committed)
+	require.Equal(uint64(5), requested[peers[2].ID].Start)
+	require.Equal(uint64(6), requested[peers[2].ID].End)
+
+	// the block failing the verification is rejected and the peer is blocked
+	invalid := block.NewBlockDeprecated(1, 5, hash.ZeroHash256, testutil.TimestampNow(), identityset.PrivateKey(27).PublicKey(), nil)
+	require.Error(bs.ProcessBlock(ctx, p2, invalid))
+	require.Equal([]string{p2}, blocked)
+	require.Equal([]uint64{1, 2, 3, 4}, committed)
+}
+
+func TestBlockSyncerHeaderFirst(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	var (
+		tip       uint64
+		committed []uint64
+		blks      []*block.Block
+		prevHash  = block.GenesisHash()
+	)
+	for i := 1; i <= 6; i++ {
+		blk, err := block.NewTestingBuilder().
+			SetHeight(uint64(i)).
+			SetPrevBlockHash(prevHash).
+			SetTimeStamp(testutil.TimestampNow()).
+			SignAndBuild(identityset.PrivateKey(27))
+		require.NoError(err)
+		blks = append(blks, &blk)
+		prevHash = blk.HashBlock()
+	}
+	cfg := DefaultConfig
+	cfg.Interval = 0
+	cfg.IntervalSize = 2
+	cfg.BufferSize = 2
+	cfg.HeaderFirst = true
+	bs, err := NewBlockSyncer(cfg,
+		func() uint64 { return tip },
+		func(h uint64) (*block.Block, error) { return blks[h-1], nil },
+		func(blk *block.Block) error {
+			committed = append(committed, blk.Height())
+			tip = blk.Height()
+			return nil
+		},
+		func() ([]peer.AddrInfo, error) { return nil, nil },
+		func(context.Context, peer.AddrInfo, proto.Message) error { return nil },
+		func(string) {},
+	)
+	require.NoError(err)
+	require.NoError(bs.Start(ctx))
+	syncer := bs.(*blockSyncer)
+
+	// the block at the network tip sets the target, and the headers beyond the buffer are requested
+	require.NoError(bs.ProcessBlock(ctx, "peer", blks[5]))
+	require.Equal(uint64(6), bs.TargetHeight())
+	require.Equal([]syncBlocksInterval{{1, 2}, {3, 4}, {5, 5}}, syncer.intervalsToSync(tip, 6))
+	for _, blk := range blks[2:5] {
+		require.NoError(bs.ProcessBlock(ctx, "peer", blk))
+	}
+	height, _ := bs.HeaderTip()
+	require.Zero(height)
+	require.Empty(committed)
+
+	// the header tip reaches the network tip ahead of the blocks committed
+	require.NoError(bs.ProcessBlock(ctx, "peer", blks[0]))
+	require.NoError(bs.ProcessBlock(ctx, "peer", blks[1]))
+	require.Equal([]uint64{1, 2}, committed)
+	height, h := bs.HeaderTip()
+	require.Equal(uint64(6), height)
+	require.Equal(blks[5].HashBlock(), h)
+	// the bodies are backfilled
+	require.Equal([]syncBlocksInterval{{3, 4}}, syncer.intervalsToSync(tip, 6))
+	for _, blk := range blks[2:] {
+		require.NoError(bs.ProcessBlock(ctx, "peer", blk))
+	}
+	require.Equal([]uint64{1, 2, 3, 4, 5, 6}, committed)
+	height, h = bs.HeaderTip()
+	require.Equal(uint64(6), height)
+	require.Equal(blks[5].HashBlock(), h)
+}
+
 func newTestConfig() (testConfig, error) {
 	testTriePath, err := testutil.PathOfTempFile("trie")
 	if err != nil {
@@ -17,6 +17,22 @@ type Config struct {
 	MaxRepeat int `yaml:"maxRepeat"`
 	// RepeatDecayStep is the step for repeat number decreasing by 1
 	RepeatDecayStep int `yaml:"repeatDecayStep"`
+	// ParallelPeers is the maximal number of peers downloading disjoint ranges concurrently, the ranges are
+	// requested from random peers repeatedly if it is 0
+	ParallelPeers int `yaml:"parallelPeers"`
+	// RangeTimeout is the duration after which a range not completed by the peer is requested from another peer
+	RangeTimeout time.Duration `yaml:"rangeTimeout"`
+	// HeaderFirst enables syncing the headers to the network tip ahead of the blocks committed. The blocks beyond
+	// the buffer are dropped once their headers are verified, and downloaded again as the commit catches up
+	HeaderFirst bool `yaml:"headerFirst"`
+	// HeaderBufferSize is the maximal number of headers synced ahead of the header tip
+	HeaderBufferSize uint64 `yaml:"headerBufferSize"`
+	// ServeFromHeight is the lowest height of the blocks and the receipts served to the peers, for the node whose
+	// blocks below it are pruned or not synced. All the blocks are served if it is 0
+	ServeFromHeight uint64 `yaml:"serveFromHeight"`
+	// AdvertiseInterval is the interval to advertise the height ranges of the blocks, the receipts and the states
+	// served to the peers, the ranges are not advertised if it is 0
+	AdvertiseInterval time.Duration `yaml:"advertiseInterval"`
 }
 
 // DefaultConfig is the default config
@@ -27,4 +43,10 @@ var DefaultConfig = Config{
 	IntervalSize:          20,
 	MaxRepeat:             3,
 	RepeatDecayStep:       1,
+	ParallelPeers:         8,
+	RangeTimeout:          10 * time.Second,
+	HeaderFirst:           false,
+	HeaderBufferSize:      2000,
+	ServeFromHeight:       0,
+	AdvertiseInterval:     time.Minute,
 }
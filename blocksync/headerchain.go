@@ -0,0 +1,127 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"sync"
+
+	"github.com/iotexproject/go-pkgs/hash"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+)
+
+// headerChain tracks the tip of the headers synced ahead of the blocks committed in header-first sync mode. A header
+// extends the tip if it is signed by its producer and links to the tip by the previous hash, the headers arriving
+// out of order are kept pending until the headers in between arrive. The endorsements can't be validated before the
+// state catches up with the epoch of the header, so the header chain is provisional and is reset to the committed
+// block once they diverge
+type headerChain struct {
+	mu      sync.RWMutex
+	size    uint64
+	height  uint64
+	hash    hash.Hash256
+	pending map[uint64]*block.Header
+}
+
+func newHeaderChain(size uint64) *headerChain {
+	return &headerChain{
+		size:    size,
+		pending: map[uint64]*block.Header{},
+	}
+}
+
+// Tip returns the height and the hash of the header tip
+func (hc *headerChain) Tip() (uint64, hash.Hash256) {
+	hc.mu.RLock()
+	defer hc.mu.RUnlock()
+	return hc.height, hc.hash
+}
+
+// Add adds the header verified, and returns true if the header tip is extended
+func (hc *headerChain) Add(h *block.Header) bool {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	height := h.Height()
+	if height <= hc.height || height > hc.height+hc.size {
+		return false
+	}
+	hc.pending[height] = h
+	return hc.link()
+}
+
+// Commit moves the header tip to the block committed if the block is above the tip, or if the block diverges from
+// the header tip
+func (hc *headerChain) Commit(height uint64, blkHash hash.Hash256) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	if height < hc.height || (height == hc.height && blkHash == hc.hash) {
+		return
+	}
+	hc.height, hc.hash = height, blkHash
+	for h := range hc.pending {
+		if h <= height || h > height+hc.size {
+			delete(hc.pending, h)
+		}
+	}
+	hc.link()
+}
+
+// link extends the tip with the pending headers linked to it
+func (hc *headerChain) link() bool {
+	extended := false
+	for {
+		next, ok := hc.pending[hc.height+1]
+		if !ok {
+			break
+		}
+		delete(hc.pending, hc.height+1)
+		if next.PrevHash() != hc.hash {
+			// the header on another branch is dropped, and downloaded again
+			break
+		}
+		hc.height, hc.hash = next.Height(), next.HashBlock()
+		extended = true
+	}
+	return extended
+}
+
+// IntervalsToSync returns the intervals of the headers missing from the start to the end, upto the size of the
+// header buffer ahead of the tip
+func (hc *headerChain) IntervalsToSync(start, end, intervalSize uint64) []syncBlocksInterval {
+	hc.mu.RLock()
+	defer hc.mu.RUnlock()
+	if start <= hc.height {
+		start = hc.height + 1
+	}
+	if end > hc.height+hc.size {
+		end = hc.height + hc.size
+	}
+	var (
+		bi       []syncBlocksInterval
+		interval *syncBlocksInterval
+	)
+	for h := start; h <= end; h++ {
+		if _, ok := hc.pending[h]; ok {
+			if interval != nil {
+				bi = append(bi, *interval)
+				interval = nil
+			}
+			continue
+		}
+		if interval == nil {
+			interval = &syncBlocksInterval{Start: h}
+		}
+		interval.End = h
+		if interval.End-interval.Start+1 >= intervalSize {
+			bi = append(bi, *interval)
+			interval = nil
+		}
+	}
+	if interval != nil {
+		bi = append(bi, *interval)
+	}
+	return bi
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+	"github.com/iotexproject/iotex-core/v2/testutil"
+)
+
+func testHeaders(t *testing.T, prevHash hash.Hash256, start uint64, n int) []*block.Header {
+	headers := make([]*block.Header, n)
+	for i := range headers {
+		blk, err := block.NewTestingBuilder().
+			SetHeight(start + uint64(i)).
+			SetPrevBlockHash(prevHash).
+			SetTimeStamp(testutil.TimestampNow()).
+			SignAndBuild(identityset.PrivateKey(27))
+		require.NoError(t, err)
+		headers[i] = &blk.Header
+		prevHash = blk.HashBlock()
+	}
+	return headers
+}
+
+func TestHeaderChain(t *testing.T) {
+	require := require.New(t)
+	genesis := hash.Hash256b([]byte("genesis"))
+	headers := testHeaders(t, genesis, 1, 6)
+	hc := newHeaderChain(5)
+	hc.Commit(0, genesis)
+
+	// the headers out of order are kept pending until linked to the tip
+	require.False(hc.Add(headers[1]))
+	require.False(hc.Add(headers[4]))
+	require.Equal([]syncBlocksInterval{{1, 1}, {3, 4}}, hc.IntervalsToSync(1, 10, 2))
+	require.True(hc.Add(headers[0]))
+	height, h := hc.Tip()
+	require.Equal(uint64(2), height)
+	require.Equal(headers[1].HashBlock(), h)
+	require.Equal([]syncBlocksInterval{{3, 4}, {6, 7}}, hc.IntervalsToSync(1, 10, 2))
+
+	// the header on another branch is dropped
+	fork := testHeaders(t, hash.ZeroHash256, 3, 1)
+	require.False(hc.Add(fork[0]))
+	height, _ = hc.Tip()
+	require.Equal(uint64(2), height)
+	require.True(hc.Add(headers[2]))
+	require.True(hc.Add(headers[3]))
+	height, _ = hc.Tip()
+	require.Equal(uint64(5), height)
+	// the header too far ahead of the tip is ignored
+	require.False(hc.Add(testHeaders(t, hash.ZeroHash256, 11, 1)[0]))
+
+	// the committed block below the tip doesn't move the tip, the one diverging from the tip resets it
+	hc.Commit(3, headers[2].HashBlock())
+	height, _ = hc.Tip()
+	require.Equal(uint64(5), height)
+	hc.Commit(5, headers[4].HashBlock())
+	height, h = hc.Tip()
+	require.Equal(uint64(5), height)
+	require.Equal(headers[4].HashBlock(), h)
+	hc.Commit(5, fork[0].HashBlock())
+	height, h = hc.Tip()
+	require.Equal(uint64(5), height)
+	require.Equal(fork[0].HashBlock(), h)
+	require.False(hc.Add(headers[5]))
+}
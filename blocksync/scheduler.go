@@ -0,0 +1,200 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// _scoreDecay is the weight of the latest throughput sample in the score of a peer
+const _scoreDecay = 0.3
+
+type (
+	// rangeScheduler assigns disjoint height ranges to the peers, at most one range per peer at a time, and scores
+	// the peers by the throughput of the ranges downloaded from them
+	rangeScheduler struct {
+		mu          sync.Mutex
+		maxPeers    int
+		timeout     time.Duration
+		scores      map[string]float64
+		assignments map[string]*rangeTask
+	}
+
+	rangeTask struct {
+		syncBlocksInterval
+		peer      peer.AddrInfo
+		requested time.Time
+		received  map[uint64]struct{}
+	}
+
+	rangeAssignment struct {
+		syncBlocksInterval
+		peer peer.AddrInfo
+	}
+)
+
+func newRangeScheduler(maxPeers int, timeout time.Duration) *rangeScheduler {
+	return &rangeScheduler{
+		maxPeers:    maxPeers,
+		timeout:     timeout,
+		scores:      map[string]float64{},
+		assignments: map[string]*rangeTask{},
+	}
+}
+
+func (t *rangeTask) size() uint64 {
+	return t.End - t.Start + 1
+}
+
+func (t *rangeTask) overlaps(interval syncBlocksInterval) bool {
+	return t.Start <= interval.End && interval.Start <= t.End
+}
+
+// Expire releases the ranges below the tip, and the ranges timed out whose peers are penalized. It returns the peers
+// timed out
+func (s *rangeScheduler) Expire(tip uint64, now time.Time) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var timedOut []string
+	for pid, task := range s.assignments {
+		switch {
+		case task.End <= tip:
+			delete(s.assignments, pid)
+		case now.Sub(task.requested) >= s.timeout:
+			s.updateScore(pid, float64(len(task.received))/now.Sub(task.requested).Seconds())
+			delete(s.assignments, pid)
+			timedOut = append(timedOut, pid)
+		}
+	}
+	sort.Strings(timedOut)
+	return timedOut
+}
+
+// Assign assigns the intervals to the idle peers in the order of their scores, so that the lowest ranges, which
+// block the commit, go to the fastest peers. If serves is not nil, an interval goes to the peers advertising its
+// blocks first, then to the peers of unknown availability, and never to the peers advertising not having them
+func (s *rangeScheduler) Assign(intervals []syncBlocksInterval, peers []peer.AddrInfo, now time.Time, serves func(pid string, start uint64) (bool, bool)) []rangeAssignment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	idle := make([]peer.AddrInfo, 0, len(peers))
+	for _, p := range peers {
+		if _, ok := s.assignments[p.ID.String()]; !ok {
+			idle = append(idle, p)
+		}
+	}
+	sort.SliceStable(idle, func(i, j int) bool {
+		return s.score(idle[i].ID.String()) > s.score(idle[j].ID.String())
+	})
+	var assigned []rangeAssignment
+	for _, interval := range intervals {
+		if len(idle) == 0 || len(s.assignments) >= s.maxPeers {
+			break
+		}
+		if s.inflight(interval) {
+			continue
+		}
+		i := pickPeer(idle, interval, serves)
+		if i < 0 {
+			continue
+		}
+		p := idle[i]
+		idle = append(idle[:i], idle[i+1:]...)
+		s.assignments[p.ID.String()] = &rangeTask{
+			syncBlocksInterval: interval,
+			peer:               p,
+			requested:          now,
+			received:           map[uint64]struct{}{},
+		}
+		assigned = append(assigned, rangeAssignment{syncBlocksInterval: interval, peer: p})
+	}
+	return assigned
+}
+
+// pickPeer returns the index of the first peer serving the interval, or of the first peer of unknown availability,
+// -1 if no peer is eligible
+func pickPeer(peers []peer.AddrInfo, interval syncBlocksInterval, serves func(string, uint64) (bool, bool)) int {
+	if serves == nil {
+		return 0
+	}
+	unknown := -1
+	for i, p := range peers {
+		ok, known := serves(p.ID.String(), interval.Start)
+		if ok {
+			return i
+		}
+		if !known && unknown < 0 {
+			unknown = i
+		}
+	}
+	return unknown
+}
+
+// Received records the block of the height received from the peer, and returns true if the range assigned to the
+// peer is completed
+func (s *rangeScheduler) Received(pid string, height uint64, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	task, ok := s.assignments[pid]
+	if !ok || height < task.Start || height > task.End {
+		return false
+	}
+	task.received[height] = struct{}{}
+	if uint64(len(task.received)) < task.size() {
+		return false
+	}
+	elapsed := now.Sub(task.requested).Seconds()
+	if elapsed <= 0 {
+		elapsed = time.Millisecond.Seconds()
+	}
+	s.updateScore(pid, float64(task.size())/elapsed)
+	delete(s.assignments, pid)
+	return true
+}
+
+// Inflight returns the number of ranges being downloaded
+func (s *rangeScheduler) Inflight() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.assignments)
+}
+
+// Score returns the throughput score of the peer in blocks per second
+func (s *rangeScheduler) Score(pid string) float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.score(pid)
+}
+
+// score returns the score of the peer, the peers never tried are preferred so that they get explored
+func (s *rangeScheduler) score(pid string) float64 {
+	if score, ok := s.scores[pid]; ok {
+		return score
+	}
+	return math.Inf(1)
+}
+
+func (s *rangeScheduler) updateScore(pid string, throughput float64) {
+	score, ok := s.scores[pid]
+	if !ok {
+		s.scores[pid] = throughput
+		return
+	}
+	s.scores[pid] = _scoreDecay*throughput + (1-_scoreDecay)*score
+}
+
+func (s *rangeScheduler) inflight(interval syncBlocksInterval) bool {
+	for _, task := range s.assignments {
+		if task.overlaps(interval) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRangeScheduler(t *testing.T) {
+	require := require.New(t)
+	var (
+		now       = time.Unix(1000, 0)
+		p1        = peer.AddrInfo{ID: peer.ID("peer1")}
+		p2        = peer.AddrInfo{ID: peer.ID("peer2")}
+		p3        = peer.AddrInfo{ID: peer.ID("peer3")}
+		intervals = []syncBlocksInterval{{1, 10}, {11, 20}, {21, 30}, {31, 40}}
+	)
+	s := newRangeScheduler(2, 10*time.Second)
+
+	// disjoint ranges are assigned to at most 2 peers
+	assigned := s.Assign(intervals, []peer.AddrInfo{p1, p2, p3}, now, nil)
+	require.Equal([]rangeAssignment{
+		{syncBlocksInterval{1, 10}, p1},
+		{syncBlocksInterval{11, 20}, p2},
+	}, assigned)
+	require.Equal(2, s.Inflight())
+	require.Empty(s.Assign(intervals, []peer.AddrInfo{p1, p2, p3}, now, nil))
+
+	// peer1 completes the range in 1 second
+	for h := uint64(1); h < 10; h++ {
+		require.False(s.Received(p1.ID.String(), h, now.Add(time.Second)))
+	}
+	require.False(s.Received(p1.ID.String(), 11, now.Add(time.Second)))
+	require.False(s.Received(p2.ID.String(), 10, now.Add(time.Second)))
+	require.True(s.Received(p1.ID.String(), 10, now.Add(time.Second)))
+	require.Equal(10.0, s.Score(p1.ID.String()))
+
+	// the range in flight is skipped, and the peer never tried is preferred
+	assigned = s.Assign(intervals[1:], []peer.AddrInfo{p1, p2, p3}, now.Add(time.Second), nil)
+	require.Equal([]rangeAssignment{{syncBlocksInterval{21, 30}, p3}}, assigned)
+
+	// the ranges timed out are reassigned, and the peers are penalized
+	now = now.Add(10500 * time.Millisecond)
+	require.Equal([]string{p2.ID.String()}, s.Expire(0, now))
+	assigned = s.Assign(intervals[1:], []peer.AddrInfo{p1, p2, p3}, now, nil)
+	require.Equal([]rangeAssignment{{syncBlocksInterval{11, 20}, p1}}, assigned)
+	require.Zero(s.Score(p2.ID.String()))
+
+	// the ranges below the tip are released without penalty
+	require.Empty(s.Expire(20, now))
+	require.Equal(1, s.Inflight())
+	require.Equal(10.0, s.Score(p1.ID.String()))
+}
+
+func TestRangeSchedulerAvailability(t *testing.T) {
+	require := require.New(t)
+	var (
+		now       = time.Unix(1000, 0)
+		pruned    = peer.AddrInfo{ID: peer.ID("pruned")}
+		archive   = peer.AddrInfo{ID: peer.ID("archive")}
+		unknown   = peer.AddrInfo{ID: peer.ID("unknown")}
+		intervals = []syncBlocksInterval{{1, 10}, {101, 110}, {111, 120}}
+	)
+	serves := func(pid string, start uint64) (bool, bool) {
+		switch pid {
+		case pruned.ID.String():
+			return start > 100, true
+		case archive.ID.String():
+			return true, true
+		default:
+			return false, false
+		}
+	}
+	s := newRangeScheduler(3, 10*time.Second)
+	// the pruned peer is never assigned the blocks it doesn't have, and the peer of unknown availability is the
+	// last resort
+	assigned := s.Assign(intervals, []peer.AddrInfo{pruned, unknown, archive}, now, serves)
+	require.Equal([]rangeAssignment{
+		{syncBlocksInterval{1, 10}, archive},
+		{syncBlocksInterval{101, 110}, pruned},
+		{syncBlocksInterval{111, 120}, unknown},
+	}, assigned)
+
+	s = newRangeScheduler(3, 10*time.Second)
+	assigned = s.Assign(intervals[:1], []peer.AddrInfo{pruned}, now, serves)
+	require.Empty(assigned)
+}
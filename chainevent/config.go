@@ -0,0 +1,46 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package chainevent
+
+import (
+	"time"
+
+	"github.com/iotexproject/iotex-core/v2/pkg/publisher"
+)
+
+// Config is the config of the chain event notifier
+type Config struct {
+	// Enable enables publishing the chain events to the sink
+	Enable    bool             `yaml:"enable"`
+	Publisher publisher.Config `yaml:"publisher"`
+	// Topic is the topic, or the subject of nats, the events are published to
+	Topic string `yaml:"topic"`
+	// FinalityDepth is the number of the blocks on top of a block before it is announced as finalized. The blocks of
+	// the roll-dpos consensus are final once committed, so it is 0 by default
+	FinalityDepth uint64 `yaml:"finalityDepth"`
+	// QueueSize is the max number of the events waiting to be published. The events are dropped instead of blocking
+	// the chain when the queue is full, and the number of the dropped events is carried by the next event published
+	QueueSize int `yaml:"queueSize"`
+	// MaxRetries is the max number of the retries of an event before it is dropped
+	MaxRetries int `yaml:"maxRetries"`
+	// RetryInterval is the interval before the first retry, which is doubled for each retry up to a minute
+	RetryInterval time.Duration `yaml:"retryInterval"`
+}
+
+// DefaultConfig is the default config
+var DefaultConfig = Config{
+	Enable: false,
+	Publisher: publisher.Config{
+		Sink:    publisher.SinkHTTP,
+		URL:     "",
+		Timeout: 5 * time.Second,
+	},
+	Topic:         "iotex.chain",
+	FinalityDepth: 0,
+	QueueSize:     1000,
+	MaxRetries:    5,
+	RetryInterval: time.Second,
+}
@@ -0,0 +1,287 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+// Package chainevent announces the new blocks, the reorganizations and the finality of the chain to an external sink,
+// so that the off-chain services could follow the chain head without holding a gRPC stream
+package chainevent
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+	"github.com/iotexproject/iotex-core/v2/pkg/publisher"
+)
+
+// the types of the events
+const (
+	EventBlock     = "block"
+	EventReorg     = "reorg"
+	EventFinalized = "finalized"
+)
+
+const _maxRetryInterval = time.Minute
+
+type (
+	chain interface {
+		TipHeight() uint64
+		BlockHeaderByHeight(uint64) (*block.Header, error)
+		AddSubscriber(blockchain.BlockCreationSubscriber) error
+		RemoveSubscriber(blockchain.BlockCreationSubscriber) error
+	}
+
+	// Event is the json event published to the sink
+	Event struct {
+		Type    string `json:"type"`
+		ChainID uint32 `json:"chainID"`
+		Height  uint64 `json:"height"`
+		Hash    string `json:"hash"`
+		// the fields of the block events
+		PrevHash   string     `json:"prevHash,omitempty"`
+		Timestamp  *time.Time `json:"timestamp,omitempty"`
+		Producer   string     `json:"producer,omitempty"`
+		NumActions int        `json:"numActions,omitempty"`
+		// the fields of the reorg events, the blocks from the height to the old tip are replaced
+		OldHash      string `json:"oldHash,omitempty"`
+		OldTipHeight uint64 `json:"oldTipHeight,omitempty"`
+		// Dropped is the number of the events dropped since the last event published, the consumer should catch up
+		// with the api if it is not 0
+		Dropped uint64 `json:"dropped,omitempty"`
+	}
+
+	// Notifier publishes the events of the blocks received from the chain
+	Notifier struct {
+		cfg       Config
+		chainID   uint32
+		chain     chain
+		publisher publisher.Publisher
+		queue     chan *Event
+		dropped   uint64
+		cancel    context.CancelFunc
+		done      chan struct{}
+
+		mutex     sync.Mutex
+		tip       uint64
+		finalized uint64
+		hashes    map[uint64]hash.Hash256 // hashes of the blocks above the finalized height
+	}
+)
+
+var _chainEventMtc = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "iotex_chain_events",
+		Help: "Chain events published to the sink by the type and the result",
+	},
+	[]string{"type", "result"},
+)
+
+func init() {
+	prometheus.MustRegister(_chainEventMtc)
+}
+
+// NewNotifier creates a chain event notifier
+func NewNotifier(cfg Config, chainID uint32, ch chain) *Notifier {
+	return &Notifier{
+		cfg:     cfg,
+		chainID: chainID,
+		chain:   ch,
+		hashes:  make(map[uint64]hash.Hash256),
+	}
+}
+
+// Start starts publishing the events if the notifier is enabled
+func (n *Notifier) Start(_ context.Context) error {
+	if !n.cfg.Enable {
+		return nil
+	}
+	if err := publisher.ValidateTopic(n.cfg.Topic); err != nil {
+		return err
+	}
+	pub, err := publisher.New(n.cfg.Publisher)
+	if err != nil {
+		return errors.Wrap(err, "failed to create the publisher of chain events")
+	}
+	n.publisher = pub
+	n.tip = n.chain.TipHeight()
+	if n.tip > n.cfg.FinalityDepth {
+		n.finalized = n.tip - n.cfg.FinalityDepth
+	}
+	n.queue = make(chan *Event, n.cfg.QueueSize)
+	ctx, cancel := context.WithCancel(context.Background())
+	n.cancel = cancel
+	n.done = make(chan struct{})
+	go n.run(ctx)
+	log.L().Info("Chain events are published.", zap.String("sink", n.cfg.Publisher.Sink), zap.String("topic", n.cfg.Topic))
+	return n.chain.AddSubscriber(n)
+}
+
+// Stop stops publishing the events, the events in the queue are dropped
+func (n *Notifier) Stop(_ context.Context) error {
+	if n.cancel == nil {
+		return nil
+	}
+	if err := n.chain.RemoveSubscriber(n); err != nil {
+		log.L().Warn("Failed to unsubscribe chain events.", zap.Error(err))
+	}
+	n.cancel()
+	<-n.done
+	n.cancel = nil
+	return n.publisher.Close()
+}
+
+// ReceiveBlock enqueues the events of the block, which never blocks the chain
+func (n *Notifier) ReceiveBlock(blk *block.Block) error {
+	for _, ev := range n.events(blk) {
+		select {
+		case n.queue <- ev:
+		default:
+			atomic.AddUint64(&n.dropped, 1)
+			_chainEventMtc.WithLabelValues(ev.Type, "dropped").Inc()
+		}
+	}
+	return nil
+}
+
+// events returns the events of the block, a block at or below the height of the last block replaces the blocks
+// from its height
+func (n *Notifier) events(blk *block.Block) []*Event {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	var (
+		height  = blk.Height()
+		blkHash = blk.HashBlock()
+		prev    = blk.PrevHash()
+		ts      = blk.Timestamp()
+		events  []*Event
+	)
+	if height <= n.tip {
+		ev := &Event{
+			Type:         EventReorg,
+			ChainID:      n.chainID,
+			Height:       height,
+			Hash:         hex.EncodeToString(blkHash[:]),
+			OldTipHeight: n.tip,
+		}
+		if old, ok := n.hashes[height]; ok {
+			ev.OldHash = hex.EncodeToString(old[:])
+		}
+		events = append(events, ev)
+		for h := range n.hashes {
+			if h >= height {
+				delete(n.hashes, h)
+			}
+		}
+		if n.finalized >= height {
+			n.finalized = height - 1
+		}
+	}
+	n.tip = height
+	n.hashes[height] = blkHash
+	events = append(events, &Event{
+		Type:       EventBlock,
+		ChainID:    n.chainID,
+		Height:     height,
+		Hash:       hex.EncodeToString(blkHash[:]),
+		PrevHash:   hex.EncodeToString(prev[:]),
+		Timestamp:  &ts,
+		Producer:   blk.ProducerAddress(),
+		NumActions: len(blk.Actions),
+	})
+	if height <= n.cfg.FinalityDepth {
+		return events
+	}
+	if finalized := height - n.cfg.FinalityDepth; finalized > n.finalized {
+		if ev, err := n.finalizedEvent(finalized); err != nil {
+			log.L().Warn("Failed to get the finalized block.", zap.Uint64("height", finalized), zap.Error(err))
+		} else {
+			events = append(events, ev)
+		}
+		n.finalized = finalized
+		for h := range n.hashes {
+			if h <= finalized {
+				delete(n.hashes, h)
+			}
+		}
+	}
+	return events
+}
+
+func (n *Notifier) finalizedEvent(height uint64) (*Event, error) {
+	h, ok := n.hashes[height]
+	if !ok {
+		header, err := n.chain.BlockHeaderByHeight(height)
+		if err != nil {
+			return nil, err
+		}
+		h = header.HashBlock()
+	}
+	return &Event{
+		Type:    EventFinalized,
+		ChainID: n.chainID,
+		Height:  height,
+		Hash:    hex.EncodeToString(h[:]),
+	}, nil
+}
+
+func (n *Notifier) run(ctx context.Context) {
+	defer close(n.done)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ev := <-n.queue:
+			ev.Dropped = atomic.SwapUint64(&n.dropped, 0)
+			if err := n.publish(ctx, ev); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				atomic.AddUint64(&n.dropped, ev.Dropped+1)
+				_chainEventMtc.WithLabelValues(ev.Type, "failure").Inc()
+				log.L().Error("Failed to publish chain event.", zap.String("type", ev.Type), zap.Uint64("height", ev.Height), zap.Error(err))
+				continue
+			}
+			_chainEventMtc.WithLabelValues(ev.Type, "success").Inc()
+		}
+	}
+}
+
+// publish publishes the event, and retries with the backoff on failure
+func (n *Notifier) publish(ctx context.Context, ev *Event) error {
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	key := strconv.FormatUint(ev.Height, 10)
+	interval := n.cfg.RetryInterval
+	for i := 0; ; i++ {
+		if err = n.publisher.Publish(ctx, n.cfg.Topic, key, data); err == nil {
+			err = n.publisher.Flush(ctx)
+		}
+		if err == nil || i >= n.cfg.MaxRetries {
+			return err
+		}
+		log.L().Debug("Retry publishing chain event.", zap.Int("retry", i+1), zap.Error(err))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+		if interval *= 2; interval > _maxRetryInterval {
+			interval = _maxRetryInterval
+		}
+	}
+}
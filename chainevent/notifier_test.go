@@ -0,0 +1,159 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package chainevent
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/pkg/publisher"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+type testChain struct {
+	headers     []*block.Header
+	subscribers int
+}
+
+func (c *testChain) TipHeight() uint64 {
+	return uint64(len(c.headers))
+}
+
+func (c *testChain) BlockHeaderByHeight(height uint64) (*block.Header, error) {
+	if height == 0 || height > uint64(len(c.headers)) {
+		return nil, errors.Errorf("block %d not exist", height)
+	}
+	return c.headers[height-1], nil
+}
+
+func (c *testChain) AddSubscriber(blockchain.BlockCreationSubscriber) error {
+	c.subscribers++
+	return nil
+}
+
+func (c *testChain) RemoveSubscriber(blockchain.BlockCreationSubscriber) error {
+	c.subscribers--
+	return nil
+}
+
+func newBlock(t *testing.T, height uint64, prev hash.Hash256, producer int) *block.Block {
+	blk, err := block.NewTestingBuilder().
+		SetHeight(height).
+		SetPrevBlockHash(prev).
+		SetTimeStamp(time.Unix(int64(height), 0)).
+		SignAndBuild(identityset.PrivateKey(producer))
+	require.NoError(t, err)
+	return &blk
+}
+
+func hexHash(h hash.Hash256) string {
+	return hex.EncodeToString(h[:])
+}
+
+func TestNotifier(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	var failures int32
+	events := make(chan *Event, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if atomic.AddInt32(&failures, -1) >= 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		ev := &Event{}
+		r.NoError(json.NewDecoder(req.Body).Decode(ev))
+		r.Equal("iotex.chain", req.Header.Get("X-Iotex-Topic"))
+		events <- ev
+	}))
+	defer srv.Close()
+
+	blk1 := newBlock(t, 1, hash.ZeroHash256, 1)
+	chain := &testChain{headers: []*block.Header{&blk1.Header}}
+	// disabled by default
+	n := NewNotifier(DefaultConfig, 4689, chain)
+	r.NoError(n.Start(ctx))
+	r.Zero(chain.subscribers)
+	r.NoError(n.Stop(ctx))
+
+	cfg := DefaultConfig
+	cfg.Enable = true
+	cfg.Publisher = publisher.Config{Sink: publisher.SinkHTTP, URL: srv.URL, Timeout: time.Second}
+	cfg.Topic = "iotex chain"
+	r.ErrorIs(NewNotifier(cfg, 4689, chain).Start(ctx), publisher.ErrInvalidTopic)
+	r.Zero(chain.subscribers)
+	cfg.Topic = DefaultConfig.Topic
+	cfg.FinalityDepth = 1
+	cfg.RetryInterval = 10 * time.Millisecond
+	n = NewNotifier(cfg, 4689, chain)
+	r.NoError(n.Start(ctx))
+	r.Equal(1, chain.subscribers)
+
+	// the sink recovers after retries
+	atomic.StoreInt32(&failures, 2)
+	blk2 := newBlock(t, 2, blk1.HashBlock(), 2)
+	r.NoError(n.ReceiveBlock(blk2))
+	ev := <-events
+	r.Equal(EventBlock, ev.Type)
+	r.EqualValues(4689, ev.ChainID)
+	r.EqualValues(2, ev.Height)
+	r.Equal(hexHash(blk2.HashBlock()), ev.Hash)
+	r.Equal(hexHash(blk1.HashBlock()), ev.PrevHash)
+	r.Equal(blk2.ProducerAddress(), ev.Producer)
+	r.True(blk2.Timestamp().Equal(*ev.Timestamp))
+	// the hash of the finalized block is read from the chain
+	ev = <-events
+	r.Equal(&Event{Type: EventFinalized, ChainID: 4689, Height: 1, Hash: hexHash(blk1.HashBlock())}, ev)
+
+	blk3 := newBlock(t, 3, blk2.HashBlock(), 3)
+	r.NoError(n.ReceiveBlock(blk3))
+	r.Equal(EventBlock, (<-events).Type)
+	r.Equal(&Event{Type: EventFinalized, ChainID: 4689, Height: 2, Hash: hexHash(blk2.HashBlock())}, <-events)
+
+	// a different block at the height of the tip replaces it
+	blk3b := newBlock(t, 3, blk2.HashBlock(), 4)
+	r.NoError(n.ReceiveBlock(blk3b))
+	r.Equal(&Event{
+		Type:         EventReorg,
+		ChainID:      4689,
+		Height:       3,
+		Hash:         hexHash(blk3b.HashBlock()),
+		OldHash:      hexHash(blk3.HashBlock()),
+		OldTipHeight: 3,
+	}, <-events)
+	ev = <-events
+	r.Equal(EventBlock, ev.Type)
+	r.Equal(hexHash(blk3b.HashBlock()), ev.Hash)
+
+	r.NoError(n.Stop(ctx))
+	r.Zero(chain.subscribers)
+	r.Empty(events)
+}
+
+func TestNotifierBackpressure(t *testing.T) {
+	r := require.New(t)
+	cfg := DefaultConfig
+	cfg.Enable = true
+	blk1 := newBlock(t, 1, hash.ZeroHash256, 1)
+	n := NewNotifier(cfg, 4689, &testChain{})
+	// the events are not published, so that the queue is full
+	n.queue = make(chan *Event, 3)
+	r.NoError(n.ReceiveBlock(blk1))
+	r.NoError(n.ReceiveBlock(newBlock(t, 2, blk1.HashBlock(), 2)))
+	r.Len(n.queue, 3)
+	r.EqualValues(1, n.dropped)
+}
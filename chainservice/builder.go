@@ -9,21 +9,31 @@ import (
 	"context"
 	"math/big"
 	"net/url"
+	"slices"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/facebookgo/clock"
+	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-election/committee"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/iotexproject/iotex-core/v2/action"
 	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/abiregistry"
 	"github.com/iotexproject/iotex-core/v2/action/protocol/account"
 	accountutil "github.com/iotexproject/iotex-core/v2/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/anchor"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/bridge"
 	"github.com/iotexproject/iotex-core/v2/action/protocol/execution"
 	"github.com/iotexproject/iotex-core/v2/action/protocol/execution/evm"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/feetoken"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/governance"
 	"github.com/iotexproject/iotex-core/v2/action/protocol/poll"
 	"github.com/iotexproject/iotex-core/v2/action/protocol/rewarding"
 	"github.com/iotexproject/iotex-core/v2/action/protocol/rolldpos"
@@ -31,6 +41,7 @@ import (
 	"github.com/iotexproject/iotex-core/v2/action/protocol/vote/candidatesutil"
 	"github.com/iotexproject/iotex-core/v2/actpool"
 	"github.com/iotexproject/iotex-core/v2/actsync"
+	"github.com/iotexproject/iotex-core/v2/blobsync"
 	"github.com/iotexproject/iotex-core/v2/blockchain"
 	"github.com/iotexproject/iotex-core/v2/blockchain/block"
 	"github.com/iotexproject/iotex-core/v2/blockchain/blockdao"
@@ -38,17 +49,20 @@ import (
 	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/v2/blockindex"
 	"github.com/iotexproject/iotex-core/v2/blockindex/contractstaking"
+	"github.com/iotexproject/iotex-core/v2/blockindex/sqlindexer"
 	"github.com/iotexproject/iotex-core/v2/blocksync"
 	"github.com/iotexproject/iotex-core/v2/config"
 	"github.com/iotexproject/iotex-core/v2/consensus"
 	"github.com/iotexproject/iotex-core/v2/consensus/consensusfsm"
 	rp "github.com/iotexproject/iotex-core/v2/consensus/scheme/rolldpos"
 	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/forkmonitor"
 	"github.com/iotexproject/iotex-core/v2/nodeinfo"
 	"github.com/iotexproject/iotex-core/v2/p2p"
 	"github.com/iotexproject/iotex-core/v2/pkg/log"
 	"github.com/iotexproject/iotex-core/v2/server/itx/nodestats"
 	"github.com/iotexproject/iotex-core/v2/state/factory"
+	"github.com/iotexproject/iotex-core/v2/statesync"
 	"github.com/iotexproject/iotex-core/v2/systemcontractindex/stakingindex"
 )
 
@@ -56,6 +70,10 @@ import (
 type Builder struct {
 	cfg config.Config
 	cs  *ChainService
+	// cleanShutdownHeight is the tip height at which the node was shut down cleanly, 0 if unknown
+	cleanShutdownHeight uint64
+	// clock drives the blockchain and the consensus, the system clock if nil
+	clock clock.Clock
 }
 
 // NewBuilder creates a new chainservice builder
@@ -122,6 +140,19 @@ func (builder *Builder) SetBlockSync(bs blocksync.BlockSync) *Builder {
 	return builder
 }
 
+// SetCleanShutdownHeight sets the tip height at which the node was shut down cleanly, so that the recovery of the
+// indexers is skipped at start if the tip is still at the height
+func (builder *Builder) SetCleanShutdownHeight(height uint64) *Builder {
+	builder.cleanShutdownHeight = height
+	return builder
+}
+
+// SetClock sets the clock driving the blockchain and the consensus, e.g., a mock clock to simulate the network
+func (builder *Builder) SetClock(clk clock.Clock) *Builder {
+	builder.clock = clk
+	return builder
+}
+
 // BuildForTest builds a chainservice for test purpose
 func (builder *Builder) BuildForTest() (*ChainService, error) {
 	builder.createInstance()
@@ -231,7 +262,7 @@ func (builder *Builder) createElectionCommittee() (committee.Committee, error) {
 
 func (builder *Builder) buildActionPool() error {
 	if builder.cs.actpool == nil {
-		options := []actpool.Option{}
+		options := []actpool.Option{actpool.WithRegistry(builder.cs.registry)}
 		if builder.cfg.ActPool.Store != nil {
 			d := &action.Deserializer{}
 			d.SetEvmNetworkID(builder.cfg.Chain.EVMNetworkID)
@@ -258,9 +289,14 @@ func (builder *Builder) buildActionPool() error {
 		}
 		builder.cs.actpool = ac
 	}
+	seen, err := actpool.NewSeenCache(builder.cfg.ActPool.SeenCacheTTL)
+	if err != nil {
+		return errors.Wrap(err, "failed to create seen cache")
+	}
 	// Add action validators
 	builder.cs.actpool.AddActionEnvelopeValidators(
-		protocol.NewGenericValidator(builder.cs.factory, accountutil.AccountState),
+		protocol.NewGenericValidator(builder.cs.factory, accountutil.AccountState,
+			protocol.WithSignatureVerifier(seen.VerifySignature)),
 	)
 
 	return nil
@@ -297,6 +333,9 @@ func (builder *Builder) buildBlockDAO(forTest bool) error {
 	if builder.cs.bfIndexer != nil {
 		indexers = append(indexers, builder.cs.bfIndexer)
 	}
+	if sqlCfg := builder.cfg.Indexer.SQL; len(sqlCfg.DSN) > 0 && !forTest {
+		indexers = append(indexers, sqlindexer.NewIndexer(sqlCfg))
+	}
 	var (
 		cfg       = builder.cfg
 		err       error
@@ -326,16 +365,29 @@ func (builder *Builder) buildBlockDAO(forTest bool) error {
 		if bsPath := cfg.Chain.BlobStoreDBPath; len(bsPath) > 0 {
 			blocksPerHour := time.Hour / cfg.WakeUpgrade.BlockInterval
 			dbConfig.DbPath = bsPath
+			var bsOpts []blockdao.BlobStoreOption
+			if uri := cfg.Chain.BlobStoreArchiveURI; len(uri) > 0 {
+				archive, err := blockdao.NewBlobArchive(uri)
+				if err != nil {
+					return err
+				}
+				bsOpts = append(bsOpts, blockdao.WithBlobArchive(archive))
+			}
 			blobStore = blockdao.NewBlobStore(
 				db.NewBoltDB(dbConfig),
 				uint64(blocksPerHour)*uint64(cfg.Chain.BlobStoreRetentionDays)*24,
+				bsOpts...,
 			)
 			opts = append(opts, blockdao.WithBlobStore(blobStore))
+			builder.cs.blobStore = blobStore
 		}
 	}
 	if err != nil {
 		return err
 	}
+	if builder.cleanShutdownHeight > 0 {
+		opts = append(opts, blockdao.WithCleanShutdownHeight(builder.cleanShutdownHeight))
+	}
 	builder.cs.blockdao = blockdao.NewBlockDAOWithIndexersAndCache(
 		store, indexers, cfg.DB.MaxCacheSize, opts...)
 
@@ -355,7 +407,10 @@ func (builder *Builder) buildContractStakingIndexer(forTest bool) error {
 	cfg := builder.cfg
 	dbConfig := builder.cfg.DB
 	dbConfig.DbPath = builder.cfg.Chain.ContractStakingIndexDBPath
-	kvstore := db.NewBoltDB(dbConfig)
+	// the indexers share one bolt file, each of them tracks its own height in its own namespaces. The namespaces of
+	// the existing indexers are kept as is, as the indexer v1 uses the plain namespaces and the others prefix the
+	// namespaces with the contract address
+	kvstore := db.NewSharedKVStore(db.NewBoltDB(dbConfig))
 	blockDurationFn := func(start uint64, end uint64, viewAt uint64) time.Duration {
 		if viewAt < cfg.Genesis.WakeBlockHeight {
 			return time.Duration(end-start) * cfg.DardanellesUpgrade.BlockInterval
@@ -366,7 +421,7 @@ func (builder *Builder) buildContractStakingIndexer(forTest bool) error {
 	if builder.cs.contractStakingIndexer == nil && len(builder.cfg.Genesis.SystemStakingContractAddress) > 0 {
 		voteCalcConsts := builder.cfg.Genesis.VoteWeightCalConsts
 		indexer, err := contractstaking.NewContractStakingIndexer(
-			kvstore,
+			kvstore.Tenant(""),
 			contractstaking.Config{
 				ContractAddress:      builder.cfg.Genesis.SystemStakingContractAddress,
 				ContractDeployHeight: builder.cfg.Genesis.SystemStakingContractHeight,
@@ -383,7 +438,7 @@ func (builder *Builder) buildContractStakingIndexer(forTest bool) error {
 	// build contract staking indexer v2
 	if builder.cs.contractStakingIndexerV2 == nil && len(builder.cfg.Genesis.SystemStakingContractV2Address) > 0 {
 		indexer := stakingindex.NewIndexer(
-			kvstore,
+			kvstore.Tenant(""),
 			builder.cfg.Genesis.SystemStakingContractV2Address,
 			builder.cfg.Genesis.SystemStakingContractV2Height,
 			blockDurationFn,
@@ -394,7 +449,7 @@ func (builder *Builder) buildContractStakingIndexer(forTest bool) error {
 	// build contract staking indexer v3
 	if builder.cs.contractStakingIndexerV3 == nil && len(builder.cfg.Genesis.SystemStakingContractV3Address) > 0 {
 		indexer := stakingindex.NewIndexer(
-			kvstore,
+			kvstore.Tenant(""),
 			builder.cfg.Genesis.SystemStakingContractV3Address,
 			builder.cfg.Genesis.SystemStakingContractV3Height,
 			blockDurationFn,
@@ -463,7 +518,11 @@ func (builder *Builder) createGateWayComponents(forTest bool) (
 
 	// create bloomfilter indexer
 	dbConfig.DbPath = builder.cfg.Chain.BloomfilterIndexDBPath
-	bfIndexer, err = blockindex.NewBloomfilterIndexer(db.NewBoltDB(dbConfig), builder.cfg.Indexer)
+	bfIndexer, err = blockindex.NewBloomfilterIndexer(db.NewBoltDB(dbConfig), builder.cfg.Indexer, blockindex.WithReceiptsReader(
+		func(height uint64) ([]*action.Receipt, error) {
+			return builder.cs.blockdao.GetReceipts(height)
+		},
+	))
 	if err != nil {
 		return
 	}
@@ -522,6 +581,9 @@ func (builder *Builder) createBlockchain(forSubChain, forTest bool) blockchain.B
 	} else {
 		chainOpts = append(chainOpts, blockchain.BlockValidatorOption(builder.cs.factory))
 	}
+	if builder.clock != nil {
+		chainOpts = append(chainOpts, blockchain.ClockOption(builder.clock))
+	}
 	var mintOpts []factory.MintOption
 	if builder.cfg.Consensus.Scheme == config.RollDPoSScheme {
 		mintOpts = append(mintOpts, factory.WithTimeoutOption(builder.cfg.Chain.MintTimeout))
@@ -557,6 +619,20 @@ func (builder *Builder) buildNodeInfoManager() error {
 			}
 			return whiteList
 		}, builder.cfg.Chain.ProducerPrivateKeys()...)
+		if rp := rolldpos.FindProtocol(cs.registry); rp != nil && cs.consensus != nil {
+			dm.SetHeartbeatSource(rp.GetEpochNum, func() (nodeinfo.HeartbeatStats, error) {
+				status, err := cs.consensus.Status()
+				if err != nil {
+					return nodeinfo.HeartbeatStats{}, err
+				}
+				return nodeinfo.HeartbeatStats{
+					ProposalLatency:  status.ProposalReceiptTime,
+					CommitLatency:    status.QuorumTimes["commit"],
+					ViewChanges:      status.ViewChanges,
+					LastCommitRounds: status.LastCommitRounds,
+				}, nil
+			})
+		}
 	} else {
 		dm = nodeinfo.NewInfoManager(&builder.cfg.NodeInfo, cs.p2pAgent, cs.chain, nil)
 	}
@@ -580,6 +656,27 @@ func (builder *Builder) buildBlockSyncer() error {
 	dao := builder.cs.blockdao
 	cfg := builder.cfg
 
+	advertiser := blocksync.NewAdvertiser(
+		cfg.BlockSync.AdvertiseInterval,
+		func() blocksync.Availability {
+			tip := chain.TipHeight()
+			blocks := blocksync.HeightRange{Start: max(cfg.BlockSync.ServeFromHeight, 1), End: tip}
+			states := blocksync.HeightRange{Start: tip, End: tip}
+			if len(cfg.Chain.HistoryIndexPath) > 0 {
+				// the archive node serves the states of all the heights of the blocks
+				states.Start = blocks.Start
+			}
+			return blocksync.Availability{Blocks: blocks, Receipts: blocks, States: states}
+		},
+		p2pAgent.ConnectedPeers,
+		func(ctx context.Context, p peer.AddrInfo, data []byte) error {
+			return p2pAgent.UnicastProtocol(ctx, p, blocksync.AvailabilityProtocol, data)
+		},
+	)
+	if err := p2pAgent.AddProtocol(blocksync.AvailabilityProtocol, advertiser.HandleMessage); err != nil {
+		return errors.Wrap(err, "failed to add availability protocol")
+	}
+
 	blocksync, err := blocksync.NewBlockSyncer(
 		builder.cfg.BlockSync,
 		chain.TipHeight,
@@ -651,14 +748,102 @@ func (builder *Builder) buildBlockSyncer() error {
 		},
 		p2pAgent.ConnectedPeers,
 		p2pAgent.UnicastOutbound,
-		p2pAgent.BlockPeer,
+		func(id string) {
+			p2pAgent.ReportPeer(id, p2p.PeerInvalidBlock)
+		},
+		blocksync.WithPeerTimeout(func(id string) {
+			p2pAgent.ReportPeer(id, p2p.PeerTimeout)
+		}),
+		blocksync.WithAdvertiser(advertiser),
 	)
 	if err != nil {
 		return errors.Wrap(err, "failed to create block syncer")
 	}
 	builder.cs.blocksync = blocksync
 	builder.cs.lifecycle.Add(blocksync)
+	builder.cs.lifecycle.Add(advertiser)
+
+	return nil
+}
 
+func (builder *Builder) buildForkMonitor() {
+	if builder.cs.forkMonitor != nil {
+		return
+	}
+	builder.cs.forkMonitor = forkmonitor.NewMonitor(builder.cfg.ForkMonitor, builder.cs.chain)
+}
+
+func (builder *Builder) buildStateSync() error {
+	if builder.cs.stateSync != nil {
+		return nil
+	}
+	p2pAgent := builder.cs.p2pAgent
+	helper := &statesync.Helper{
+		P2PNeighbor: p2pAgent.ConnectedPeers,
+		UnicastOutbound: func(ctx context.Context, p peer.AddrInfo, data []byte) error {
+			return p2pAgent.UnicastProtocol(ctx, p, statesync.Protocol, data)
+		},
+		BlockHash: builder.cs.blockdao.GetBlockHash,
+		ReportPeer: func(id string) {
+			p2pAgent.ReportPeer(id, p2p.PeerProtocolViolation)
+		},
+	}
+	cfg := builder.cfg.StateSync
+	if iter, ok := builder.cs.factory.(factory.StateIterator); ok {
+		helper.IterateStates = iter.ForEachState
+	} else if cfg.Serve {
+		log.L().Warn("state factory does not support iterating states, skip serving state snapshots")
+		cfg.Serve = false
+	}
+	stateSync := statesync.NewStateSync(cfg, helper)
+	if err := p2pAgent.AddProtocol(statesync.Protocol, stateSync.HandleMessage); err != nil {
+		return errors.Wrap(err, "failed to add state sync protocol")
+	}
+	if cfg.Serve {
+		if err := builder.cs.chain.AddSubscriber(stateSync); err != nil {
+			return errors.Wrap(err, "failed to add state sync as subscriber")
+		}
+	}
+	builder.cs.stateSync = stateSync
+	builder.cs.lifecycle.Add(stateSync)
+	return nil
+}
+
+func (builder *Builder) buildBlobSync() error {
+	if builder.cs.blobSync != nil || builder.cs.blobStore == nil {
+		return nil
+	}
+	p2pAgent := builder.cs.p2pAgent
+	dao := builder.cs.blockdao
+	blobSync := blobsync.NewBlobSync(
+		builder.cfg.BlobSync,
+		time.Duration(builder.cfg.Chain.BlobStoreRetentionDays)*24*time.Hour,
+		builder.cs.blobStore,
+		&blobsync.Helper{
+			P2PNeighbor: p2pAgent.ConnectedPeers,
+			UnicastOutbound: func(ctx context.Context, p peer.AddrInfo, data []byte) error {
+				return p2pAgent.UnicastProtocol(ctx, p, blobsync.Protocol, data)
+			},
+			BlobHashes: func(height uint64) (map[hash.Hash256][]common.Hash, error) {
+				blk, err := dao.GetBlockByHeight(height)
+				if err != nil {
+					return nil, err
+				}
+				return blobsync.BlockBlobHashes(blk)
+			},
+			ReportPeer: func(id string) {
+				p2pAgent.ReportPeer(id, p2p.PeerProtocolViolation)
+			},
+		},
+	)
+	if err := p2pAgent.AddProtocol(blobsync.Protocol, blobSync.HandleMessage); err != nil {
+		return errors.Wrap(err, "failed to add blob sync protocol")
+	}
+	if err := builder.cs.chain.AddSubscriber(blobSync); err != nil {
+		return errors.Wrap(err, "failed to add blob sync as subscriber")
+	}
+	builder.cs.blobSync = blobSync
+	builder.cs.lifecycle.Add(blobSync)
 	return nil
 }
 
@@ -673,6 +858,30 @@ func (builder *Builder) buildActionSyncer() error {
 	})
 	builder.cs.actionsync = actionsync
 	builder.cs.lifecycle.Add(actionsync)
+	ap := builder.cs.actpool
+	mempoolSync := actsync.NewMempoolSync(builder.cfg.ActionSync, &actsync.MempoolHelper{
+		P2PNeighbor: p2pAgent.ConnectedPeers,
+		AnnounceOutbound: func(ctx context.Context, p peer.AddrInfo, data []byte) error {
+			return p2pAgent.UnicastProtocol(ctx, p, actsync.MempoolProtocol, data)
+		},
+		HandleActionHash: builder.cs.HandleActionHash,
+	}, func() []hash.Hash256 {
+		hashes := make([]hash.Hash256, 0)
+		for _, acts := range ap.PendingActionMap() {
+			for _, act := range acts {
+				h, err := act.Hash()
+				if err != nil {
+					continue
+				}
+				hashes = append(hashes, h)
+			}
+		}
+		return hashes
+	})
+	if err := p2pAgent.AddProtocol(actsync.MempoolProtocol, mempoolSync.HandleMessage); err != nil {
+		return errors.Wrap(err, "failed to add mempool protocol")
+	}
+	builder.cs.lifecycle.Add(mempoolSync)
 	return nil
 }
 
@@ -685,6 +894,11 @@ func (builder *Builder) registerStakingProtocol() error {
 	if builder.cs.contractStakingIndexerV3 != nil {
 		opts = append(opts, staking.WithContractStakingIndexerV3(builder.cs.contractStakingIndexerV3))
 	}
+	// avoid passing a typed nil, which the protocol takes as an indexer
+	var contractStakingIndexer staking.ContractStakingIndexerWithBucketType
+	if builder.cs.contractStakingIndexer != nil {
+		contractStakingIndexer = builder.cs.contractStakingIndexer
+	}
 	stakingProtocol, err := staking.NewProtocol(
 		staking.HelperCtx{
 			DepositGas:    rewarding.DepositGas,
@@ -704,7 +918,7 @@ func (builder *Builder) registerStakingProtocol() error {
 			},
 		},
 		builder.cs.candBucketsIndexer,
-		builder.cs.contractStakingIndexer,
+		contractStakingIndexer,
 		builder.cs.contractStakingIndexerV2,
 		opts...,
 	)
@@ -724,6 +938,67 @@ func (builder *Builder) registerAccountProtocol() error {
 	return account.NewProtocol(rewarding.DepositGas).Register(builder.cs.registry)
 }
 
+func (builder *Builder) registerBridgeProtocol() error {
+	if builder.cfg.Genesis.CounterpartChainID == 0 {
+		return nil
+	}
+	bridgeProtocol, err := bridge.NewProtocol(builder.cfg.Genesis.Bridge, rewarding.DepositGas)
+	if err != nil {
+		return err
+	}
+	return bridgeProtocol.Register(builder.cs.registry)
+}
+
+func (builder *Builder) registerAnchorProtocol() error {
+	return anchor.NewProtocol(rewarding.DepositGas).Register(builder.cs.registry)
+}
+
+func (builder *Builder) registerGovernanceProtocol() error {
+	governanceProtocol, err := governance.NewProtocol(builder.cfg.Genesis.Governance, rewarding.DepositGas)
+	if err != nil {
+		return err
+	}
+	return governanceProtocol.Register(builder.cs.registry)
+}
+
+func (builder *Builder) registerFeeTokenProtocol() error {
+	if len(builder.cfg.Genesis.FeeTokens) == 0 {
+		return nil
+	}
+	feeTokenProtocol, err := feetoken.NewProtocol(builder.cfg.Genesis.FeeToken, rewarding.DepositGas)
+	if err != nil {
+		return err
+	}
+	return feeTokenProtocol.Register(builder.cs.registry)
+}
+
+func (builder *Builder) registerABIRegistryProtocol() error {
+	height := builder.cfg.Genesis.ToBeEnabledBlockHeight
+	contracts := []*abiregistry.Contract{
+		{Name: "anchor", Address: anchor.ProtocolAddr(), ABI: anchor.ContractABI(), ActivationHeight: height},
+		{Name: "governance", Address: governance.ProtocolAddr(), ABI: governance.ContractABI(), ActivationHeight: height},
+		{Name: "stakeAutoCompound", Address: staking.AutoCompoundAddr, ABI: staking.AutoCompoundABI(), ActivationHeight: height},
+		{Name: "stakeBLSKey", Address: staking.BLSKeyAddr, ABI: staking.BLSKeyABI(), ActivationHeight: height},
+		{Name: "stakeMigration", Address: staking.StakeMigrationAddr, ABI: staking.StakeMigrationABI(), ActivationHeight: height},
+		{Name: "rewarding", Address: rewarding.ProtocolAddr(), ABI: rewarding.CompoundABI(), ActivationHeight: height},
+	}
+	if builder.cfg.Genesis.CounterpartChainID != 0 {
+		contracts = append(contracts, &abiregistry.Contract{
+			Name: "bridge", Address: bridge.ProtocolAddr(), ABI: bridge.ContractABI(), ActivationHeight: height,
+		})
+	}
+	if len(builder.cfg.Genesis.FeeTokens) != 0 {
+		contracts = append(contracts, &abiregistry.Contract{
+			Name: "feeToken", Address: feetoken.ProtocolAddr(), ABI: feetoken.ContractABI(), ActivationHeight: height,
+		})
+	}
+	abiRegistryProtocol, err := abiregistry.NewProtocol(contracts...)
+	if err != nil {
+		return err
+	}
+	return abiRegistryProtocol.Register(builder.cs.registry)
+}
+
 func (builder *Builder) registerExecutionProtocol() error {
 	return execution.NewProtocol(nil, rewarding.DepositGas, nil).Register(builder.cs.registry)
 }
@@ -792,11 +1067,16 @@ func (builder *Builder) registerRollDPoSProtocol() error {
 }
 
 func (builder *Builder) buildConsensusComponent() error {
+	cs := builder.cs
 	p2pAgent := builder.cs.p2pAgent
 	copts := []consensus.Option{
 		consensus.WithBroadcast(func(msg proto.Message) error {
 			return p2pAgent.BroadcastOutbound(context.Background(), msg)
 		}),
+		// the node info manager is built after the consensus, which is looked up when the block is pushed
+		consensus.WithBlockPusher(func(blk *iotextypes.Block, proposers []string) {
+			pushBlock(p2pAgent, cs.nodeInfoManager, blk, proposers)
+		}),
 	}
 	if rDPoSProtocol := rolldpos.FindProtocol(builder.cs.registry); rDPoSProtocol != nil {
 		copts = append(copts, consensus.WithRollDPoSProtocol(rDPoSProtocol))
@@ -805,6 +1085,12 @@ func (builder *Builder) buildConsensusComponent() error {
 	if pollProtocol := poll.FindProtocol(builder.cs.registry); pollProtocol != nil {
 		copts = append(copts, consensus.WithPollProtocol(pollProtocol))
 	}
+	if stakingProtocol := staking.FindProtocol(builder.cs.registry); stakingProtocol != nil {
+		copts = append(copts, consensus.WithStakingProtocol(stakingProtocol))
+	}
+	if builder.clock != nil {
+		copts = append(copts, consensus.WithClock(builder.clock))
+	}
 
 	// TODO: explorer dependency deleted at #1085, need to revive by migrating to api
 	builderCfg := rp.BuilderConfig{
@@ -827,6 +1113,33 @@ func (builder *Builder) buildConsensusComponent() error {
 	return nil
 }
 
+// pushBlock sends the block to the connected peers of the proposers, which are learned from the node infos
+func pushBlock(p2pAgent p2p.Agent, dm *nodeinfo.InfoManager, blk *iotextypes.Block, proposers []string) {
+	if dm == nil {
+		return
+	}
+	peers, err := p2pAgent.ConnectedPeers()
+	if err != nil {
+		log.L().Debug("failed to get connected peers", zap.Error(err))
+		return
+	}
+	for _, addr := range proposers {
+		info, ok := dm.GetNodeInfo(addr)
+		if !ok {
+			continue
+		}
+		idx := slices.IndexFunc(peers, func(p peer.AddrInfo) bool {
+			return p.ID.String() == info.PeerID
+		})
+		if idx < 0 {
+			continue
+		}
+		if err := p2pAgent.UnicastOutbound(context.Background(), peers[idx], blk); err != nil {
+			log.L().Debug("failed to push block to proposer", zap.String("proposer", addr), zap.Error(err))
+		}
+	}
+}
+
 func (builder *Builder) build(forSubChain, forTest bool) (*ChainService, error) {
 	builder.cs.registry = protocol.NewRegistry()
 	if builder.cs.p2pAgent == nil {
@@ -863,12 +1176,29 @@ func (builder *Builder) build(forSubChain, forTest bool) (*ChainService, error)
 	if err := builder.registerRollDPoSProtocol(); err != nil {
 		return nil, errors.Wrap(err, "failed to register roll dpos related protocols")
 	}
+	// the bridge, anchor, governance and fee token protocols handle the executions calling their addresses, so they are registered
+	// before the execution protocol
+	if err := builder.registerBridgeProtocol(); err != nil {
+		return nil, errors.Wrap(err, "failed to register bridge protocol")
+	}
+	if err := builder.registerAnchorProtocol(); err != nil {
+		return nil, errors.Wrap(err, "failed to register anchor protocol")
+	}
+	if err := builder.registerGovernanceProtocol(); err != nil {
+		return nil, errors.Wrap(err, "failed to register governance protocol")
+	}
+	if err := builder.registerFeeTokenProtocol(); err != nil {
+		return nil, errors.Wrap(err, "failed to register fee token protocol")
+	}
 	if err := builder.registerExecutionProtocol(); err != nil {
 		return nil, errors.Wrap(err, "failed to register execution protocol")
 	}
 	if err := builder.registerRewardingProtocol(); err != nil {
 		return nil, errors.Wrap(err, "failed to register rewarding protocol")
 	}
+	if err := builder.registerABIRegistryProtocol(); err != nil {
+		return nil, errors.Wrap(err, "failed to register abi registry protocol")
+	}
 	if err := builder.buildConsensusComponent(); err != nil {
 		return nil, err
 	}
@@ -881,6 +1211,13 @@ func (builder *Builder) build(forSubChain, forTest bool) (*ChainService, error)
 	if err := builder.buildActionSyncer(); err != nil {
 		return nil, err
 	}
+	if err := builder.buildStateSync(); err != nil {
+		return nil, err
+	}
+	if err := builder.buildBlobSync(); err != nil {
+		return nil, err
+	}
+	builder.buildForkMonitor()
 	cs := builder.cs
 	builder.cs = nil
 
@@ -1,6 +1,7 @@
 package chainservice
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -8,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/v2/config"
 	"github.com/iotexproject/iotex-core/v2/test/identityset"
 )
@@ -104,3 +106,18 @@ func TestBlockDistanceAt(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildWithoutContractStakingIndexer(t *testing.T) {
+	r := require.New(t)
+	cfg := deepcopy.Copy(config.Default).(config.Config)
+	cfg.Genesis = genesis.TestDefault()
+	cfg.Genesis.SystemStakingContractAddress = ""
+	cfg.Genesis.SystemStakingContractV2Address = ""
+	cfg.Genesis.SystemStakingContractV3Address = ""
+	cs, err := NewBuilder(cfg).BuildForTest()
+	r.NoError(err)
+	// the staking protocol starts without the contract staking indexer
+	ctx := context.Background()
+	r.NoError(cs.Blockchain().Start(ctx))
+	r.NoError(cs.Blockchain().Stop(ctx))
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/iotexproject/iotex-core/v2/actpool"
 	"github.com/iotexproject/iotex-core/v2/actsync"
 	"github.com/iotexproject/iotex-core/v2/api"
+	"github.com/iotexproject/iotex-core/v2/blobsync"
 	"github.com/iotexproject/iotex-core/v2/blockchain"
 	"github.com/iotexproject/iotex-core/v2/blockchain/block"
 	"github.com/iotexproject/iotex-core/v2/blockchain/blockdao"
@@ -35,12 +36,14 @@ import (
 	"github.com/iotexproject/iotex-core/v2/blockindex/contractstaking"
 	"github.com/iotexproject/iotex-core/v2/blocksync"
 	"github.com/iotexproject/iotex-core/v2/consensus"
+	"github.com/iotexproject/iotex-core/v2/forkmonitor"
 	"github.com/iotexproject/iotex-core/v2/nodeinfo"
 	"github.com/iotexproject/iotex-core/v2/p2p"
 	"github.com/iotexproject/iotex-core/v2/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/v2/pkg/log"
 	"github.com/iotexproject/iotex-core/v2/server/itx/nodestats"
 	"github.com/iotexproject/iotex-core/v2/state/factory"
+	"github.com/iotexproject/iotex-core/v2/statesync"
 	"github.com/iotexproject/iotex-core/v2/systemcontractindex/stakingindex"
 )
 
@@ -81,6 +84,10 @@ type ChainService struct {
 	nodeInfoManager          *nodeinfo.InfoManager
 	apiStats                 *nodestats.APILocalStats
 	actionsync               *actsync.ActionSync
+	forkMonitor              *forkmonitor.Monitor
+	stateSync                *statesync.StateSync
+	blobStore                blockdao.BlobStore
+	blobSync                 *blobsync.BlobSync
 	minter                   *factory.Minter
 
 	lastReceivedBlockHeight uint64
@@ -169,7 +176,7 @@ func (cs *ChainService) HandleAction(ctx context.Context, actPb *iotextypes.Acti
 	if err != nil {
 		return err
 	}
-	ctx = protocol.WithRegistry(ctx, cs.registry)
+	ctx = actpool.WithActionSource(protocol.WithRegistry(ctx, cs.registry), actpool.ActionSourceP2P)
 	err = cs.actpool.Add(ctx, act)
 	if err != nil {
 		log.L().Debug(err.Error())
@@ -217,6 +224,7 @@ func (cs *ChainService) HandleBlock(ctx context.Context, peer string, pbBlock *i
 	if err != nil {
 		return err
 	}
+	cs.forkMonitor.Observe(peer, &blk.Header)
 	return cs.blocksync.ProcessBlock(ctx, peer, blk)
 }
 
@@ -259,6 +267,11 @@ func (cs *ChainService) BlockDAO() blockdao.BlockDAO {
 	return cs.blockdao
 }
 
+// StateSync returns the state sync, nil if it is disabled
+func (cs *ChainService) StateSync() *statesync.StateSync {
+	return cs.stateSync
+}
+
 // ActionPool returns the Action pool
 func (cs *ChainService) ActionPool() actpool.ActPool {
 	return cs.actpool
@@ -283,7 +296,7 @@ func (cs *ChainService) NodeInfoManager() *nodeinfo.InfoManager {
 func (cs *ChainService) Registry() *protocol.Registry { return cs.registry }
 
 // NewAPIServer creates a new api server
-func (cs *ChainService) NewAPIServer(cfg api.Config, archive bool) (*api.ServerV2, error) {
+func (cs *ChainService) NewAPIServer(cfg api.Config, archive bool, opts ...api.Option) (*api.ServerV2, error) {
 	if cfg.GRPCPort == 0 && cfg.HTTPPort == 0 {
 		return nil, nil
 	}
@@ -294,10 +307,15 @@ func (cs *ChainService) NewAPIServer(cfg api.Config, archive bool) (*api.ServerV
 		}),
 		api.WithNativeElection(cs.electionCommittee),
 		api.WithAPIStats(cs.apiStats),
+		api.WithPeerManager(p2pAgent),
+		api.WithConsensus(cs.consensus),
+		api.WithForkMonitor(cs.forkMonitor),
+		api.WithHeartbeatMonitor(cs.nodeInfoManager),
 	}
 	if archive {
 		apiServerOptions = append(apiServerOptions, api.WithArchiveSupport())
 	}
+	apiServerOptions = append(apiServerOptions, opts...)
 
 	svr, err := api.NewServerV2(
 		cfg,
@@ -12,21 +12,28 @@ import (
 
 	"github.com/pkg/errors"
 	uconfig "go.uber.org/config"
+	"go.uber.org/zap/zapcore"
 
 	"github.com/iotexproject/iotex-core/v2/actpool"
 	"github.com/iotexproject/iotex-core/v2/actsync"
 	"github.com/iotexproject/iotex-core/v2/api"
+	"github.com/iotexproject/iotex-core/v2/blobsync"
 	"github.com/iotexproject/iotex-core/v2/blockchain"
 	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/v2/blockindex"
 	"github.com/iotexproject/iotex-core/v2/blocksync"
+	"github.com/iotexproject/iotex-core/v2/chainevent"
 	"github.com/iotexproject/iotex-core/v2/consensus"
 	"github.com/iotexproject/iotex-core/v2/consensus/consensusfsm"
 	"github.com/iotexproject/iotex-core/v2/db"
 	"github.com/iotexproject/iotex-core/v2/dispatcher"
+	"github.com/iotexproject/iotex-core/v2/forkmonitor"
 	"github.com/iotexproject/iotex-core/v2/nodeinfo"
 	"github.com/iotexproject/iotex-core/v2/p2p"
 	"github.com/iotexproject/iotex-core/v2/pkg/log"
+	"github.com/iotexproject/iotex-core/v2/statesync"
+	"github.com/iotexproject/iotex-core/v2/streamsink"
+	"github.com/iotexproject/iotex-core/v2/telemetry"
 )
 
 // IMPORTANT: to define a config, add a field or a new config type to the existing config types. In addition, provide
@@ -63,6 +70,7 @@ var (
 	Default = Config{
 		Plugins:            make(map[int]interface{}),
 		SubLogs:            make(map[string]log.GlobalConfig),
+		Log:                log.GlobalConfig{ModuleLevels: make(map[string]zapcore.Level)},
 		Network:            p2p.DefaultConfig,
 		Chain:              blockchain.DefaultConfig,
 		ActPool:            actpool.DefaultConfig,
@@ -73,18 +81,29 @@ var (
 		Dispatcher:         dispatcher.DefaultConfig,
 		API:                api.DefaultConfig,
 		System: System{
-			Active:                true,
-			HeartbeatInterval:     10 * time.Second,
-			HTTPStatsPort:         8080,
-			HTTPAdminPort:         0,
-			StartSubChainInterval: 10 * time.Second,
-			SystemLogDBPath:       "/var/log",
+			Active:                 true,
+			HeartbeatInterval:      10 * time.Second,
+			HTTPStatsPort:          8080,
+			HTTPAdminPort:          0,
+			StartSubChainInterval:  10 * time.Second,
+			SystemLogDBPath:        "/var/log",
+			DiskMonitorInterval:    time.Minute,
+			WarnFreeDiskSpace:      10 << 30,
+			MinFreeDiskSpace:       1 << 30,
+			RuntimeMonitorInterval: 30 * time.Second,
+			WarnMemoryRatio:        0.9,
 		},
-		DB:         db.DefaultConfig,
-		Indexer:    blockindex.DefaultConfig,
-		Genesis:    genesis.Default,
-		NodeInfo:   nodeinfo.DefaultConfig,
-		ActionSync: actsync.DefaultConfig,
+		DB:          db.DefaultConfig,
+		Indexer:     blockindex.DefaultConfig,
+		Genesis:     genesis.Default,
+		NodeInfo:    nodeinfo.DefaultConfig,
+		ActionSync:  actsync.DefaultConfig,
+		ForkMonitor: forkmonitor.DefaultConfig,
+		StateSync:   statesync.DefaultConfig,
+		BlobSync:    blobsync.DefaultConfig,
+		Telemetry:   telemetry.DefaultConfig,
+		ChainEvent:  chainevent.DefaultConfig,
+		StreamSink:  streamsink.DefaultConfig,
 	}
 
 	// ErrInvalidCfg indicates the invalid config value
@@ -97,6 +116,9 @@ var (
 		ValidateAPI,
 		ValidateActPool,
 		ValidateForkHeights,
+		ValidateBlobMarket,
+		ValidateNetwork,
+		ValidateTelemetry,
 	}
 )
 
@@ -115,6 +137,26 @@ type (
 		StartSubChainInterval time.Duration `yaml:"startSubChainInterval"`
 		SystemLogDBPath       string        `yaml:"systemLogDBPath"`
 		MptrieLogPath         string        `yaml:"mptrieLogPath"`
+		// DataDir is the root of the data, under which the db paths left as default are derived, e.g., chain.chainDBPath
+		// is DataDir/chain.db unless it is set explicitly
+		DataDir string `yaml:"dataDir"`
+
+		// DiskMonitorInterval is the interval of checking the free space of the db volumes, 0 disables the monitor
+		DiskMonitorInterval time.Duration `yaml:"diskMonitorInterval"`
+		// WarnFreeDiskSpace is the free space in bytes below which the warnings are logged
+		WarnFreeDiskSpace uint64 `yaml:"warnFreeDiskSpace"`
+		// MinFreeDiskSpace is the free space in bytes below which the node enters the safe mode, pausing the commits
+		// of blocks and rejecting the actions, rather than corrupting the db when the disk is full
+		MinFreeDiskSpace uint64 `yaml:"minFreeDiskSpace"`
+
+		// RuntimeMonitorInterval is the interval of exporting the metrics of the heap, the GC and the goroutines, 0
+		// disables the monitor
+		RuntimeMonitorInterval time.Duration `yaml:"runtimeMonitorInterval"`
+		// MemoryLimit is the soft memory limit in bytes of the runtime, under which the GC runs more often as the heap
+		// grows close to the limit, 0 leaves the limit unchanged, which is also set by the env GOMEMLIMIT
+		MemoryLimit uint64 `yaml:"memoryLimit"`
+		// WarnMemoryRatio is the ratio of the memory limit above which the warnings are logged
+		WarnMemoryRatio float64 `yaml:"warnMemoryRatio"`
 	}
 
 	// Config is the root config struct, each package's config should be put as its sub struct
@@ -137,6 +179,12 @@ type (
 		Genesis            genesis.Genesis                 `yaml:"genesis"`
 		NodeInfo           nodeinfo.Config                 `yaml:"nodeinfo"`
 		ActionSync         actsync.Config                  `yaml:"actionSync"`
+		ForkMonitor        forkmonitor.Config              `yaml:"forkMonitor"`
+		StateSync          statesync.Config                `yaml:"stateSync"`
+		BlobSync           blobsync.Config                 `yaml:"blobSync"`
+		Telemetry          telemetry.Config                `yaml:"telemetry"`
+		ChainEvent         chainevent.Config               `yaml:"chainEvent"`
+		StreamSink         streamsink.Config               `yaml:"streamSink"`
 	}
 
 	// Validate is the interface of validating the config
@@ -155,6 +203,14 @@ func New(configPaths []string, _plugins []string, validates ...Validate) (Config
 			opts = append(opts, uconfig.File(path))
 		}
 	}
+	// the env overrides the config files
+	overrides, err := envOverrides(os.LookupEnv)
+	if err != nil {
+		return Config{}, errors.Wrap(err, "failed to read env overrides")
+	}
+	if len(overrides) > 0 {
+		opts = append(opts, uconfig.Static(overrides))
+	}
 	yaml, err := uconfig.NewYAML(opts...)
 	if err != nil {
 		return Config{}, errors.Wrap(err, "failed to init config")
@@ -165,6 +221,7 @@ func New(configPaths []string, _plugins []string, validates ...Validate) (Config
 		return Config{}, errors.Wrap(err, "failed to unmarshal YAML config to struct")
 	}
 
+	applyDataDir(&cfg)
 	if err := cfg.Chain.SetProducerPrivKey(); err != nil {
 		return Config{}, errors.Wrap(err, "failed to set producer private key")
 	}
@@ -174,11 +231,15 @@ func New(configPaths []string, _plugins []string, validates ...Validate) (Config
 	// set network master key to private key
 	if cfg.Network.MasterKey == "" {
 		if cfg.System.Active {
+			if err := cfg.Chain.ValidateProducerPrivateKeys(); err != nil {
+				return Config{}, errors.Wrap(err, "invalid producer private keys")
+			}
 			pks := cfg.Chain.ProducerPrivateKeys()
 			if len(pks) > 0 {
 				cfg.Network.MasterKey = pks[0].HexString()
 			}
 		}
+		// the keys held in the KMS or the HSM can't be exported, for which a random key is generated as well
 		if cfg.Network.MasterKey == "" {
 			cfg.Network.MasterKey = blockchain.GenerateRandomKey(blockchain.SigP256k1)
 		}
@@ -216,6 +277,14 @@ func NewSub(configPaths []string, validates ...Validate) (Config, error) {
 			opts = append(opts, uconfig.File(path))
 		}
 	}
+	// the env overrides the config files
+	overrides, err := envOverrides(os.LookupEnv)
+	if err != nil {
+		return Config{}, errors.Wrap(err, "failed to read env overrides")
+	}
+	if len(overrides) > 0 {
+		opts = append(opts, uconfig.Static(overrides))
+	}
 	yaml, err := uconfig.NewYAML(opts...)
 	if err != nil {
 		return Config{}, errors.Wrap(err, "failed to init config")
@@ -225,6 +294,7 @@ func NewSub(configPaths []string, validates ...Validate) (Config, error) {
 	if err := yaml.Get(uconfig.Root).Populate(&cfg); err != nil {
 		return Config{}, errors.Wrap(err, "failed to unmarshal YAML config to struct")
 	}
+	applyDataDir(&cfg)
 
 	// By default, the config needs to pass all the validation
 	if len(validates) == 0 {
@@ -260,6 +330,20 @@ func ValidateRollDPoS(cfg Config) error {
 	if fsm.EventChanSize <= 0 {
 		return errors.Wrap(ErrInvalidCfg, "roll-DPoS event chan size should be greater than 0")
 	}
+	// the timeouts of each network profile should fit in its block interval
+	for _, profile := range []struct {
+		name          string
+		timeouts      consensusfsm.Timeouts
+		blockInterval time.Duration
+	}{
+		{"roll-DPoS fsm", fsm.Timeouts(), cfg.Genesis.Blockchain.BlockInterval},
+		{"dardanelles upgrade", cfg.DardanellesUpgrade.Timeouts(), cfg.DardanellesUpgrade.BlockInterval},
+		{"wake upgrade", cfg.WakeUpgrade.Timeouts(), cfg.WakeUpgrade.BlockInterval},
+	} {
+		if err := profile.timeouts.Validate(profile.blockInterval); err != nil {
+			return errors.Wrapf(ErrInvalidCfg, "%s: %v", profile.name, err)
+		}
+	}
 	return nil
 }
 
@@ -271,6 +355,37 @@ func ValidateAPI(cfg Config) error {
 	return nil
 }
 
+// ValidateNetwork validates the network configs
+func ValidateNetwork(cfg Config) error {
+	switch cfg.Network.Compression.Algorithm {
+	case p2p.CompressionNone, p2p.CompressionSnappy, p2p.CompressionZstd:
+	default:
+		return errors.Wrapf(ErrInvalidCfg, "unsupported compression algorithm %s", cfg.Network.Compression.Algorithm)
+	}
+	bw := cfg.Network.Bandwidth
+	if bw.MaxUpload < 0 || bw.MaxDownload < 0 || bw.MaxPeerUpload < 0 || bw.MaxPeerDownload < 0 {
+		return errors.Wrap(ErrInvalidCfg, "bandwidth caps should not be negative")
+	}
+	if d := cfg.Network.Diversity; d.MaxPeersPerGroup < 0 || d.AnchorPeers < 0 || d.RotationFraction < 0 || d.RotationFraction > 1 {
+		return errors.Wrap(ErrInvalidCfg, "invalid peer diversity config")
+	}
+	return nil
+}
+
+// ValidateTelemetry validates the telemetry configs
+func ValidateTelemetry(cfg Config) error {
+	if !cfg.Telemetry.Enable {
+		return nil
+	}
+	if cfg.Telemetry.Endpoint == "" {
+		return errors.Wrap(ErrInvalidCfg, "telemetry endpoint is not set when the telemetry is enabled")
+	}
+	if cfg.Telemetry.Interval <= 0 {
+		return errors.Wrap(ErrInvalidCfg, "telemetry interval is not positive when the telemetry is enabled")
+	}
+	return nil
+}
+
 // ValidateActPool validates the given config
 func ValidateActPool(cfg Config) error {
 	maxNumActPerPool := cfg.ActPool.MaxNumActsPerPool
@@ -346,5 +461,19 @@ func ValidateForkHeights(cfg Config) error {
 	return nil
 }
 
+// ValidateBlobMarket validates the parameters of the blob fee market
+func ValidateBlobMarket(cfg Config) error {
+	g := cfg.Genesis
+	switch {
+	case g.TargetBlobsPerBlock == 0:
+		return errors.Wrap(ErrInvalidCfg, "target blobs per block should be greater than 0")
+	case g.TargetBlobsPerBlock > g.MaxBlobsPerBlock:
+		return errors.Wrap(ErrInvalidCfg, "target blobs per block is greater than the maximum")
+	case g.BlobBaseFeeUpdateFraction == 0:
+		return errors.Wrap(ErrInvalidCfg, "blob base fee update fraction should be greater than 0")
+	}
+	return nil
+}
+
 // DoNotValidate validates the given config
 func DoNotValidate(cfg Config) error { return nil }
@@ -18,6 +18,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/p2p"
 )
 
 const (
@@ -239,6 +240,28 @@ func TestValidateDispatcher(t *testing.T) {
 	)
 }
 
+func TestValidateNetwork(t *testing.T) {
+	require := require.New(t)
+	cfg := Default
+	require.NoError(ValidateNetwork(cfg))
+	cfg.Network.Compression.Algorithm = p2p.CompressionZstd
+	cfg.Network.Bandwidth.MaxPeerUpload = 1 << 20
+	require.NoError(ValidateNetwork(cfg))
+	cfg.Network.Compression.Algorithm = "lz4"
+	err := ValidateNetwork(cfg)
+	require.Equal(ErrInvalidCfg, errors.Cause(err))
+	require.Contains(err.Error(), "unsupported compression algorithm lz4")
+	cfg.Network.Compression.Algorithm = p2p.CompressionSnappy
+	cfg.Network.Bandwidth.MaxDownload = -1
+	err = ValidateNetwork(cfg)
+	require.Equal(ErrInvalidCfg, errors.Cause(err))
+	cfg.Network.Bandwidth.MaxDownload = 0
+	cfg.Network.Diversity.RotationFraction = 1.5
+	err = ValidateNetwork(cfg)
+	require.Equal(ErrInvalidCfg, errors.Cause(err))
+	require.Contains(err.Error(), "invalid peer diversity config")
+}
+
 func TestValidateRollDPoS(t *testing.T) {
 	cfg := Default
 	cfg.Consensus.Scheme = RollDPoSScheme
@@ -251,6 +274,20 @@ func TestValidateRollDPoS(t *testing.T) {
 		t,
 		strings.Contains(err.Error(), "roll-DPoS event chan size should be greater than 0"),
 	)
+
+	cfg = Default
+	cfg.Consensus.Scheme = RollDPoSScheme
+	require.NoError(t, ValidateRollDPoS(cfg))
+	cfg.WakeUpgrade.CommitTTL = time.Second
+	err = ValidateRollDPoS(cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.Contains(t, err.Error(), "wake upgrade: sum of ttls 3.05s exceeds block interval 2.5s")
+	cfg = Default
+	cfg.Consensus.Scheme = RollDPoSScheme
+	cfg.DardanellesUpgrade.UnmatchedEventInterval = 3 * time.Second
+	err = ValidateRollDPoS(cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.Contains(t, err.Error(), "dardanelles upgrade: unmatched event interval 3s")
 }
 
 func TestValidateActPool(t *testing.T) {
@@ -301,6 +338,25 @@ func TestBlobConfig(t *testing.T) {
 	r.EqualValues(cfg.Genesis.MinBlocksForBlobRetention, blocks)
 }
 
+func TestValidateBlobMarket(t *testing.T) {
+	r := require.New(t)
+	r.NoError(ValidateBlobMarket(Default))
+	for _, c := range []struct {
+		update func(*Config)
+		msg    string
+	}{
+		{func(cfg *Config) { cfg.Genesis.TargetBlobsPerBlock = 0 }, "target blobs per block should be greater than 0"},
+		{func(cfg *Config) { cfg.Genesis.MaxBlobsPerBlock = 2 }, "target blobs per block is greater than the maximum"},
+		{func(cfg *Config) { cfg.Genesis.BlobBaseFeeUpdateFraction = 0 }, "blob base fee update fraction should be greater than 0"},
+	} {
+		cfg := Default
+		c.update(&cfg)
+		err := ValidateBlobMarket(cfg)
+		r.Equal(ErrInvalidCfg, errors.Cause(err))
+		r.Contains(err.Error(), c.msg)
+	}
+}
+
 func TestValidateForkHeights(t *testing.T) {
 	r := require.New(t)
 
@@ -0,0 +1,134 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// _defaultDataDir is the root of the default db paths
+	_defaultDataDir = "/var/data"
+	// _chainIDFile is the file in the data dir recording the chain id of the data
+	_chainIDFile = "CHAINID"
+)
+
+// ErrDataDir indicates the data dir holds the data of another chain
+var ErrDataDir = errors.New("invalid data dir")
+
+type dataPath struct {
+	path  string
+	value *string
+}
+
+// _dataPaths are the paths derived from the data dir
+var _dataPaths = func(cfg *Config) []dataPath {
+	paths := []dataPath{
+		{"chain.chainDBPath", &cfg.Chain.ChainDBPath},
+		{"chain.trieDBPatchFile", &cfg.Chain.TrieDBPatchFile},
+		{"chain.trieDBPath", &cfg.Chain.TrieDBPath},
+		{"chain.stakingPatchDir", &cfg.Chain.StakingPatchDir},
+		{"chain.indexDBPath", &cfg.Chain.IndexDBPath},
+		{"chain.bloomfilterIndexDBPath", &cfg.Chain.BloomfilterIndexDBPath},
+		{"chain.candidateIndexDBPath", &cfg.Chain.CandidateIndexDBPath},
+		{"chain.stakingIndexDBPath", &cfg.Chain.StakingIndexDBPath},
+		{"chain.contractStakingIndexDBPath", &cfg.Chain.ContractStakingIndexDBPath},
+		{"chain.blobStoreDBPath", &cfg.Chain.BlobStoreDBPath},
+		{"chain.gravityChainDB.dbPath", &cfg.Chain.GravityChainDB.DbPath},
+		{"consensus.rollDPoS.consensusDBPath", &cfg.Consensus.RollDPoS.ConsensusDBPath},
+		{"stateSync.snapshotDir", &cfg.StateSync.SnapshotDir},
+		{"streamSink.cursorDBPath", &cfg.StreamSink.CursorDBPath},
+	}
+	if cfg.ActPool.Store != nil {
+		paths = append(paths, dataPath{"actPool.store.datadir", &cfg.ActPool.Store.Datadir})
+	}
+	return paths
+}
+
+// applyDataDir derives the paths left as default from the data dir, the paths set explicitly are kept
+func applyDataDir(cfg *Config) {
+	if cfg.System.DataDir == "" {
+		return
+	}
+	if cfg.ActPool.Store != nil {
+		// the store is shared with the default
+		store := *cfg.ActPool.Store
+		cfg.ActPool.Store = &store
+	}
+	defaults := Default
+	defaultPaths := map[string]string{}
+	for _, p := range _dataPaths(&defaults) {
+		defaultPaths[p.path] = *p.value
+	}
+	for _, p := range _dataPaths(cfg) {
+		def, ok := defaultPaths[p.path]
+		if !ok || *p.value != def {
+			continue
+		}
+		rel, err := filepath.Rel(_defaultDataDir, def)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		*p.value = filepath.Join(cfg.System.DataDir, rel)
+	}
+}
+
+// DBPaths returns the paths of the dbs and the data files, the unset ones are skipped
+func (cfg Config) DBPaths() []string {
+	var paths []string
+	for _, p := range _dataPaths(&cfg) {
+		if *p.value != "" {
+			paths = append(paths, *p.value)
+		}
+	}
+	if cfg.Chain.HistoryIndexPath != "" {
+		paths = append(paths, cfg.Chain.HistoryIndexPath)
+	}
+	return paths
+}
+
+// InitDataDir creates the data dir and records the chain id in it at the first start, which fails later if the chain
+// id of the config is different from the one recorded, to avoid mixing the data of multiple chains
+func InitDataDir(cfg Config) error {
+	if cfg.System.DataDir == "" {
+		return nil
+	}
+	id, ok, err := dataDirChainID(cfg.System.DataDir)
+	if err != nil {
+		return err
+	}
+	if ok {
+		if id != cfg.Chain.ID {
+			return errors.Wrapf(ErrDataDir, "data dir %s holds the data of chain %d, not chain %d", cfg.System.DataDir, id, cfg.Chain.ID)
+		}
+		return nil
+	}
+	if err := os.MkdirAll(cfg.System.DataDir, 0750); err != nil {
+		return errors.Wrapf(err, "failed to create data dir %s", cfg.System.DataDir)
+	}
+	return os.WriteFile(filepath.Join(cfg.System.DataDir, _chainIDFile), []byte(strconv.FormatUint(uint64(cfg.Chain.ID), 10)), 0600)
+}
+
+// dataDirChainID returns the chain id recorded in the data dir, false if it isn't recorded yet
+func dataDirChainID(dir string) (uint32, bool, error) {
+	b, err := os.ReadFile(filepath.Clean(filepath.Join(dir, _chainIDFile)))
+	if os.IsNotExist(err) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, errors.Wrap(err, "failed to read the chain id of data dir")
+	}
+	id, err := strconv.ParseUint(strings.TrimSpace(string(b)), 10, 32)
+	if err != nil {
+		return 0, false, errors.Wrapf(ErrDataDir, "invalid chain id %s recorded in data dir %s", b, dir)
+	}
+	return uint32(id), true, nil
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataDir(t *testing.T) {
+	r := require.New(t)
+	dir := filepath.Join(t.TempDir(), "data")
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	r.NoError(os.WriteFile(path, []byte(`
+system:
+  dataDir: `+dir+`
+chain:
+  id: 4690
+  trieDBPath: /mnt/ssd/trie.db
+`), 0600))
+
+	cfg, err := New([]string{path}, nil)
+	r.NoError(err)
+	r.Equal(filepath.Join(dir, "chain.db"), cfg.Chain.ChainDBPath)
+	r.Equal(filepath.Join(dir, "bloomfilter.index.db"), cfg.Chain.BloomfilterIndexDBPath)
+	r.Equal(filepath.Join(dir, "blob.db"), cfg.Chain.BlobStoreDBPath)
+	r.Equal(filepath.Join(dir, "poll.db"), cfg.Chain.GravityChainDB.DbPath)
+	r.Equal(filepath.Join(dir, "consensus.db"), cfg.Consensus.RollDPoS.ConsensusDBPath)
+	r.Equal(filepath.Join(dir, "snapshot"), cfg.StateSync.SnapshotDir)
+	r.Equal(filepath.Join(dir, "actpool.cache"), cfg.ActPool.Store.Datadir)
+	r.Equal(dir, cfg.Chain.StakingPatchDir)
+	// the deprecated path is not derived
+	r.Equal(Default.Chain.SGDIndexDBPath, cfg.Chain.SGDIndexDBPath)
+	// the path set explicitly is kept
+	r.Equal("/mnt/ssd/trie.db", cfg.Chain.TrieDBPath)
+	// the default is not changed
+	r.Equal("/var/data/actpool.cache", Default.ActPool.Store.Datadir)
+
+	// the chain id is recorded at the first start
+	r.NoError(InitDataDir(cfg))
+	r.NoError(InitDataDir(cfg))
+	r.False(Diagnose(cfg).HasError())
+	cfg.Chain.ID = 4689
+	r.ErrorIs(InitDataDir(cfg), ErrDataDir)
+	rp := Diagnose(cfg)
+	r.True(rp.HasError())
+	r.Equal("system.dataDir", rp.Diagnostics[len(rp.Diagnostics)-1].Path)
+
+	// no data dir
+	cfg, err = New(nil, nil)
+	r.NoError(err)
+	r.Equal(Default.Chain.ChainDBPath, cfg.Chain.ChainDBPath)
+	r.NoError(InitDataDir(cfg))
+}
@@ -0,0 +1,269 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain"
+)
+
+// Severities of the diagnostics
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+type (
+	// Severity is the severity of a diagnostic, the node doesn't start if any error is found
+	Severity string
+
+	// Diagnostic is an issue found in the config
+	Diagnostic struct {
+		Severity Severity `json:"severity"`
+		// Path is the yaml path of the setting, e.g., "chain.trieDBPath"
+		Path    string `json:"path"`
+		Message string `json:"message"`
+		// Hint is the action to fix the issue
+		Hint string `json:"hint"`
+	}
+
+	// Report is the report of the config diagnostics
+	Report struct {
+		Diagnostics []Diagnostic `json:"diagnostics"`
+	}
+
+	namedSetting struct {
+		path  string
+		value string
+	}
+
+	namedPort struct {
+		path string
+		port int
+	}
+
+	deprecatedSetting struct {
+		path  string
+		value func(Config) any
+		hint  string
+	}
+)
+
+var (
+	// the stores of the node, each of which requires a distinct path
+	_storePaths = func(cfg Config) []namedSetting {
+		return []namedSetting{
+			{"chain.chainDBPath", cfg.Chain.ChainDBPath},
+			{"chain.trieDBPath", cfg.Chain.TrieDBPath},
+			{"chain.indexDBPath", cfg.Chain.IndexDBPath},
+			{"chain.bloomfilterIndexDBPath", cfg.Chain.BloomfilterIndexDBPath},
+			{"chain.candidateIndexDBPath", cfg.Chain.CandidateIndexDBPath},
+			{"chain.stakingIndexDBPath", cfg.Chain.StakingIndexDBPath},
+			{"chain.contractStakingIndexDBPath", cfg.Chain.ContractStakingIndexDBPath},
+			{"chain.blobStoreDBPath", cfg.Chain.BlobStoreDBPath},
+			{"chain.historyIndexPath", cfg.Chain.HistoryIndexPath},
+			{"chain.gravityChainDB.dbPath", cfg.Chain.GravityChainDB.DbPath},
+			{"consensus.rollDPoS.consensusDBPath", cfg.Consensus.RollDPoS.ConsensusDBPath},
+			{"network.peerStorePath", cfg.Network.PeerStorePath},
+			{"stateSync.snapshotDir", cfg.StateSync.SnapshotDir},
+			{"streamSink.cursorDBPath", cfg.StreamSink.CursorDBPath},
+		}
+	}
+
+	// the ports listened by the node
+	_listenPorts = func(cfg Config) []namedPort {
+		return []namedPort{
+			{"network.port", cfg.Network.Port},
+			{"api.port", cfg.API.GRPCPort},
+			{"api.web3port", cfg.API.HTTPPort},
+			{"api.webSocketPort", cfg.API.WebSocketPort},
+			{"system.httpAdminPort", cfg.System.HTTPAdminPort},
+			{"system.httpStatsPort", cfg.System.HTTPStatsPort},
+		}
+	}
+
+	// the deprecated settings are reported if they are different from the default
+	_deprecatedSettings = []deprecatedSetting{
+		{"chain.sgdIndexDBPath", func(cfg Config) any { return cfg.Chain.SGDIndexDBPath }, "remove it, the sgd indexer is removed"},
+		{"chain.enableTrielessStateDB", func(cfg Config) any { return cfg.Chain.EnableTrielessStateDB }, "remove it, the state db is always trieless"},
+		{"chain.enableArchiveMode", func(cfg Config) any { return cfg.Chain.EnableArchiveMode }, "set chain.historyIndexPath to store the archived state"},
+		{"chain.enableSystemLog", func(cfg Config) any { return cfg.Chain.EnableSystemLogIndexer }, "remove it, the system log indexer is removed"},
+	}
+)
+
+// Diagnose checks the config thoroughly, including the validations, the collisions of the store paths and the
+// listen ports, the deprecated settings, the inconsistent feature flags and the producer keys. Unlike the validations
+// failing at the first error, all the issues are reported with the hints to fix them
+func Diagnose(cfg Config) *Report {
+	r := &Report{}
+	for _, validate := range Validates {
+		if err := validate(cfg); err != nil {
+			r.add(SeverityError, "", err.Error(), "fix the invalid setting")
+		}
+	}
+	r.checkStorePaths(cfg)
+	r.checkPorts(cfg)
+	r.checkDeprecated(cfg)
+	r.checkFeatureFlags(cfg)
+	r.checkProducerKeys(cfg)
+	r.checkDataDir(cfg)
+	return r
+}
+
+// HasError returns true if any error is found
+func (r *Report) HasError() bool {
+	return slices.ContainsFunc(r.Diagnostics, func(d Diagnostic) bool {
+		return d.Severity == SeverityError
+	})
+}
+
+// String returns the report in lines, one per diagnostic
+func (r *Report) String() string {
+	if len(r.Diagnostics) == 0 {
+		return "config is valid"
+	}
+	var (
+		lines          = make([]string, 0, len(r.Diagnostics)+1)
+		errs, warnings int
+	)
+	for _, d := range r.Diagnostics {
+		if d.Severity == SeverityError {
+			errs++
+		} else {
+			warnings++
+		}
+		path := d.Path
+		if path == "" {
+			path = "config"
+		}
+		lines = append(lines, fmt.Sprintf("%-7s %s: %s (hint: %s)", strings.ToUpper(string(d.Severity)), path, d.Message, d.Hint))
+	}
+	lines = append(lines, fmt.Sprintf("%d error(s), %d warning(s)", errs, warnings))
+	return strings.Join(lines, "\n")
+}
+
+func (r *Report) add(severity Severity, path, message, hint string) {
+	r.Diagnostics = append(r.Diagnostics, Diagnostic{
+		Severity: severity,
+		Path:     path,
+		Message:  message,
+		Hint:     hint,
+	})
+}
+
+// checkStorePaths checks that no store shares its path with, or is nested in, another store
+func (r *Report) checkStorePaths(cfg Config) {
+	stores := _storePaths(cfg)
+	for i, a := range stores {
+		if a.value == "" {
+			continue
+		}
+		pa := filepath.Clean(a.value)
+		for _, b := range stores[:i] {
+			if b.value == "" {
+				continue
+			}
+			pb := filepath.Clean(b.value)
+			switch {
+			case pa == pb:
+				r.add(SeverityError, a.path, fmt.Sprintf("path %s collides with %s", a.value, b.path), "set a distinct path for each store")
+			case isNested(pa, pb) || isNested(pb, pa):
+				r.add(SeverityError, a.path, fmt.Sprintf("path %s overlaps with %s (%s)", a.value, b.path, b.value), "set a distinct path for each store")
+			}
+		}
+	}
+}
+
+func isNested(path, dir string) bool {
+	return strings.HasPrefix(path, dir+string(filepath.Separator))
+}
+
+// checkPorts checks that the ports are valid and not listened by multiple services
+func (r *Report) checkPorts(cfg Config) {
+	ports := _listenPorts(cfg)
+	for i, a := range ports {
+		// the port 0 disables the service
+		if a.port == 0 {
+			continue
+		}
+		if a.port < 0 || a.port > 65535 {
+			r.add(SeverityError, a.path, fmt.Sprintf("port %d is out of range", a.port), "set a port in [1, 65535], or 0 to disable it")
+			continue
+		}
+		for _, b := range ports[:i] {
+			if a.port == b.port {
+				r.add(SeverityError, a.path, fmt.Sprintf("port %d conflicts with %s", a.port, b.path), "set a distinct port for each service")
+			}
+		}
+	}
+}
+
+func (r *Report) checkDeprecated(cfg Config) {
+	for _, s := range _deprecatedSettings {
+		if !reflect.DeepEqual(s.value(cfg), s.value(Default)) {
+			r.add(SeverityWarning, s.path, "setting is deprecated and has no effect", s.hint)
+		}
+	}
+}
+
+// checkFeatureFlags checks the feature flags which conflict with or depend on each other
+func (r *Report) checkFeatureFlags(cfg Config) {
+	if cfg.Chain.HistoryIndexPath != "" && cfg.BlockSync.ServeFromHeight > 0 {
+		r.add(SeverityError, "blockSync.serveFromHeight", "archive node serves the blocks above the height only, as if the blocks below are pruned",
+			"set blockSync.serveFromHeight to 0 for the archive node, which keeps all the blocks")
+	}
+	if cfg.Chain.EnableStakingIndexer && !cfg.Chain.EnableStakingProtocol {
+		r.add(SeverityError, "chain.enableStakingIndexer", "staking indexer is enabled without the staking protocol",
+			"set chain.enableStakingProtocol to true, or disable the staking indexer")
+	}
+	if tls := cfg.API.TLS; (tls.CertFile == "") != (tls.KeyFile == "") {
+		r.add(SeverityError, "api.tls", "certFile and keyFile are required together", "set both api.tls.certFile and api.tls.keyFile, or neither")
+	}
+	if cfg.DB.ReadOnly && cfg.System.Active {
+		r.add(SeverityError, "db.readOnly", "active node can't commit blocks to the read-only db", "set db.readOnly to false, or system.active to false")
+	}
+}
+
+// checkDataDir checks that the data dir holds the data of the chain
+func (r *Report) checkDataDir(cfg Config) {
+	if cfg.System.DataDir == "" {
+		return
+	}
+	id, ok, err := dataDirChainID(cfg.System.DataDir)
+	switch {
+	case err != nil:
+		r.add(SeverityError, "system.dataDir", err.Error(), "check the "+_chainIDFile+" file in the data dir")
+	case ok && id != cfg.Chain.ID:
+		r.add(SeverityError, "system.dataDir", fmt.Sprintf("data dir holds the data of chain %d, not chain %d", id, cfg.Chain.ID),
+			"set system.dataDir to the data dir of the chain, or chain.id to the chain of the data")
+	}
+}
+
+// checkProducerKeys checks the producer key schema, the signature schemes and the producer keys
+func (r *Report) checkProducerKeys(cfg Config) {
+	switch cfg.Chain.ProducerPrivKeySchema {
+	case "", "hex", "hashiCorpVault", "keystore", "awsKMS", "gcpKMS", "pkcs11":
+	default:
+		r.add(SeverityError, "chain.producerPrivKeySchema", fmt.Sprintf("unknown schema %s", cfg.Chain.ProducerPrivKeySchema),
+			"set one of hex, hashiCorpVault, keystore, awsKMS, gcpKMS and pkcs11")
+		return
+	}
+	for _, scheme := range cfg.Chain.SignatureScheme {
+		if scheme != blockchain.SigP256k1 && scheme != blockchain.SigP256sm2 {
+			r.add(SeverityError, "chain.signatureScheme", fmt.Sprintf("unknown signature scheme %s", scheme),
+				fmt.Sprintf("set %s or %s", blockchain.SigP256k1, blockchain.SigP256sm2))
+		}
+	}
+	if err := cfg.Chain.ValidateProducerPrivateKeys(); err != nil {
+		r.add(SeverityError, "chain.producerPrivKey", err.Error(),
+			"check chain.producerPrivKey, chain.producerPrivKeyRange and chain.signatureScheme")
+	}
+}
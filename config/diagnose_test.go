@@ -0,0 +1,94 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package config
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiagnose(t *testing.T) {
+	r := require.New(t)
+	paths := func(rp *Report, severity Severity) []string {
+		var ps []string
+		for _, d := range rp.Diagnostics {
+			if d.Severity == severity {
+				ps = append(ps, d.Path)
+			}
+		}
+		return ps
+	}
+
+	t.Run("Default", func(t *testing.T) {
+		rp := Diagnose(Default)
+		r.False(rp.HasError(), rp.String())
+		r.Empty(paths(rp, SeverityWarning))
+	})
+	t.Run("StorePaths", func(t *testing.T) {
+		cfg := Default
+		cfg.Chain.TrieDBPath = cfg.Chain.ChainDBPath
+		cfg.Network.PeerStorePath = "./data"
+		cfg.StateSync.SnapshotDir = "./data/snapshot"
+		rp := Diagnose(cfg)
+		r.True(rp.HasError())
+		r.Equal([]string{"chain.trieDBPath", "stateSync.snapshotDir"}, paths(rp, SeverityError))
+	})
+	t.Run("Ports", func(t *testing.T) {
+		cfg := Default
+		cfg.API.HTTPPort = cfg.API.GRPCPort
+		cfg.System.HTTPStatsPort = 70000
+		cfg.System.HTTPAdminPort = 0
+		rp := Diagnose(cfg)
+		r.Equal([]string{"api.web3port", "system.httpStatsPort"}, paths(rp, SeverityError))
+	})
+	t.Run("Deprecated", func(t *testing.T) {
+		cfg := Default
+		cfg.Chain.EnableArchiveMode = !Default.Chain.EnableArchiveMode
+		rp := Diagnose(cfg)
+		r.False(rp.HasError())
+		r.Equal([]string{"chain.enableArchiveMode"}, paths(rp, SeverityWarning))
+	})
+	t.Run("FeatureFlags", func(t *testing.T) {
+		cfg := Default
+		cfg.Chain.HistoryIndexPath = "/var/data/history.db"
+		cfg.BlockSync.ServeFromHeight = 100
+		cfg.Chain.EnableStakingIndexer = true
+		cfg.Chain.EnableStakingProtocol = false
+		cfg.API.TLS.CertFile = "cert.pem"
+		cfg.API.TLS.KeyFile = ""
+		rp := Diagnose(cfg)
+		r.Equal([]string{"blockSync.serveFromHeight", "chain.enableStakingIndexer", "api.tls"}, paths(rp, SeverityError))
+	})
+	t.Run("ProducerKeys", func(t *testing.T) {
+		cfg := Default
+		cfg.Chain.ProducerPrivKeySchema = "unknown"
+		rp := Diagnose(cfg)
+		r.Equal([]string{"chain.producerPrivKeySchema"}, paths(rp, SeverityError))
+
+		cfg = Default
+		cfg.Chain.ProducerPrivKey = "invalid"
+		rp = Diagnose(cfg)
+		r.Contains(paths(rp, SeverityError), "chain.producerPrivKey")
+	})
+	t.Run("Report", func(t *testing.T) {
+		r.Equal("config is valid", (&Report{}).String())
+		rp := &Report{}
+		rp.add(SeverityError, "api.port", "port 1 conflicts with network.port", "set a distinct port for each service")
+		rp.add(SeverityWarning, "", "setting is deprecated", "remove it")
+		lines := strings.Split(rp.String(), "\n")
+		r.Equal([]string{
+			"ERROR   api.port: port 1 conflicts with network.port (hint: set a distinct port for each service)",
+			"WARNING config: setting is deprecated (hint: remove it)",
+			"1 error(s), 1 warning(s)",
+		}, lines)
+		b, err := json.Marshal(rp)
+		r.NoError(err)
+		r.Contains(string(b), `"severity":"error","path":"api.port"`)
+	})
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package config
+
+import (
+	"encoding"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// EnvPrefix is the prefix of the env overriding the config settings.
+//
+// Each setting is bound to the env named by its yaml path, upper cased and joined by "_", e.g., chain.chainDBPath is
+// overridden by IOTEX_CHAIN_CHAINDBPATH, and api.port by IOTEX_API_PORT. The settings are layered with the precedence
+// from low to high:
+//   - the default values
+//   - the config files, in the order given
+//   - the env with the prefix
+//
+// The value of a string setting is taken as is, and the others are parsed as yaml, e.g., IOTEX_NETWORK_BOOTSTRAPNODES
+// is set to "[/dns4/a/tcp/4689, /dns4/b/tcp/4689]", and IOTEX_SYSTEM_HEARTBEATINTERVAL to "10s"
+const EnvPrefix = "IOTEX"
+
+var (
+	_textUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	_yamlUnmarshaler = reflect.TypeOf((*yaml.Unmarshaler)(nil)).Elem()
+)
+
+type envBinding struct {
+	// path is the yaml keys of the setting
+	path []string
+	kind reflect.Kind
+}
+
+// EnvNames returns the env bound to the settings, keyed by the env name with the value of the yaml path
+func EnvNames() map[string]string {
+	names := map[string]string{}
+	for name, b := range envBindings() {
+		names[name] = strings.Join(b.path, ".")
+	}
+	return names
+}
+
+// envBindings returns the bindings of all the settings of the config, generated from the yaml tags
+func envBindings() map[string]envBinding {
+	bindings := map[string]envBinding{}
+	bindEnv(reflect.TypeOf(Config{}), nil, bindings)
+	return bindings
+}
+
+func bindEnv(t reflect.Type, path []string, bindings map[string]envBinding) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || isEnvLeaf(t) {
+		if len(path) == 0 {
+			return
+		}
+		name := EnvPrefix + "_" + strings.ToUpper(strings.Join(path, "_"))
+		bindings[name] = envBinding{path: path, kind: t.Kind()}
+		return
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		key, opts, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		if key == "-" {
+			continue
+		}
+		if strings.Contains(opts, "inline") {
+			bindEnv(f.Type, path, bindings)
+			continue
+		}
+		if key == "" {
+			// same as the yaml encoding
+			key = strings.ToLower(f.Name)
+		}
+		bindEnv(f.Type, append(append([]string{}, path...), key), bindings)
+	}
+}
+
+// isEnvLeaf returns true if the struct is decoded from a scalar, e.g., time.Time
+func isEnvLeaf(t reflect.Type) bool {
+	pt := reflect.PointerTo(t)
+	return pt.Implements(_textUnmarshaler) || pt.Implements(_yamlUnmarshaler)
+}
+
+// envOverrides returns the settings overridden by the env, in the tree of the yaml keys
+func envOverrides(lookup func(string) (string, bool)) (map[string]any, error) {
+	overrides := map[string]any{}
+	for name, b := range envBindings() {
+		value, ok := lookup(name)
+		if !ok {
+			continue
+		}
+		var v any = value
+		if b.kind != reflect.String {
+			if err := yaml.Unmarshal([]byte(value), &v); err != nil {
+				return nil, errors.Wrapf(err, "failed to parse env %s", name)
+			}
+		}
+		node := overrides
+		for _, key := range b.path[:len(b.path)-1] {
+			child, ok := node[key].(map[string]any)
+			if !ok {
+				child = map[string]any{}
+				node[key] = child
+			}
+			node = child
+		}
+		node[b.path[len(b.path)-1]] = v
+	}
+	return overrides, nil
+}
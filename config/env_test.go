@@ -0,0 +1,74 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvNames(t *testing.T) {
+	r := require.New(t)
+	names := EnvNames()
+	for name, path := range map[string]string{
+		"IOTEX_CHAIN_ID":                     "chain.id",
+		"IOTEX_CHAIN_CHAINDBPATH":            "chain.chainDBPath",
+		"IOTEX_API_PORT":                     "api.port",
+		"IOTEX_NETWORK_BOOTSTRAPNODES":       "network.bootstrapNodes",
+		"IOTEX_SYSTEM_HEARTBEATINTERVAL":     "system.heartbeatInterval",
+		"IOTEX_GENESIS_BLOCKCHAIN_TIMESTAMP": "genesis.blockchain.timestamp",
+	} {
+		r.Equal(path, names[name], name)
+	}
+	// the settings not in the yaml are not bound
+	r.NotContains(names, "IOTEX_CHAIN_PRODUCEREXTERNALKEYS")
+}
+
+func TestNewConfigWithEnvOverrides(t *testing.T) {
+	r := require.New(t)
+	sk, err := crypto.GenerateKey()
+	r.NoError(err)
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	r.NoError(os.WriteFile(path, []byte(`
+chain:
+  id: 2
+  chainDBPath: /var/data/chain.db
+api:
+  port: 14014
+`), 0600))
+
+	t.Setenv("IOTEX_CHAIN_ID", "4690")
+	t.Setenv("IOTEX_CHAIN_PRODUCERPRIVKEY", sk.HexString())
+	t.Setenv("IOTEX_NETWORK_BOOTSTRAPNODES", "[/dns4/a/tcp/4689, /dns4/b/tcp/4689]")
+	t.Setenv("IOTEX_SYSTEM_HEARTBEATINTERVAL", "3s")
+	// the string setting is taken as is
+	t.Setenv("IOTEX_CHAIN_TRIEDBPATH", "1234")
+	cfg, err := New([]string{path}, nil)
+	r.NoError(err)
+	// the env overrides the config file
+	r.EqualValues(4690, cfg.Chain.ID)
+	// the config file overrides the default
+	r.Equal("/var/data/chain.db", cfg.Chain.ChainDBPath)
+	r.Equal(14014, cfg.API.GRPCPort)
+	// the env overrides the default
+	r.Equal(sk.HexString(), cfg.Chain.ProducerPrivKey)
+	r.Equal([]string{"/dns4/a/tcp/4689", "/dns4/b/tcp/4689"}, cfg.Network.BootstrapNodes)
+	r.Equal(3*time.Second, cfg.System.HeartbeatInterval)
+	r.Equal("1234", cfg.Chain.TrieDBPath)
+
+	cfg, err = NewSub([]string{path})
+	r.NoError(err)
+	r.EqualValues(4690, cfg.Chain.ID)
+
+	t.Setenv("IOTEX_API_PORT", "[invalid")
+	_, err = New([]string{path}, nil)
+	r.ErrorContains(err, "IOTEX_API_PORT")
+}
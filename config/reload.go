@@ -0,0 +1,233 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+// ErrImmutableConfig indicates the settings changed cannot be applied without restart
+var ErrImmutableConfig = errors.New("immutable settings cannot be changed without restart")
+
+type (
+	// ApplyFunc applies the dynamic setting of the config reloaded
+	ApplyFunc func(Config) error
+
+	// Reloader re-reads the config files on SIGHUP or on demand, and applies the changes of the dynamic settings at
+	// runtime. The dynamic settings are registered by their yaml paths, e.g., "actPool.maxNumActsPerPool", where
+	// "*" matches any key of a map. A path covers the settings under it
+	Reloader struct {
+		paths   []string
+		plugins []string
+
+		mu       sync.Mutex
+		current  Config
+		settings map[string]ApplyFunc
+		signals  chan os.Signal
+		done     chan struct{}
+	}
+)
+
+// NewReloader creates the reloader of the config loaded from the paths and the plugins
+func NewReloader(cfg Config, paths []string, plugins []string) *Reloader {
+	return &Reloader{
+		paths:    paths,
+		plugins:  plugins,
+		current:  cfg,
+		settings: map[string]ApplyFunc{},
+	}
+}
+
+// Register registers the dynamic setting of the path, which is applied by the function once it is changed
+func (r *Reloader) Register(path string, apply ApplyFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.settings[path] = apply
+}
+
+// Start reloads the config on SIGHUP
+func (r *Reloader) Start(context.Context) error {
+	r.signals = make(chan os.Signal, 1)
+	r.done = make(chan struct{})
+	signal.Notify(r.signals, syscall.SIGHUP)
+	go func() {
+		for {
+			select {
+			case <-r.done:
+				return
+			case <-r.signals:
+				changed, err := r.Reload()
+				if err != nil {
+					log.L().Error("failed to reload config.", zap.Error(err))
+					continue
+				}
+				log.L().Info("config is reloaded.", zap.Strings("changed", changed))
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop stops reloading the config on SIGHUP
+func (r *Reloader) Stop(context.Context) error {
+	if r.signals == nil {
+		return nil
+	}
+	signal.Stop(r.signals)
+	close(r.done)
+	return nil
+}
+
+// Reload re-reads the config files and applies the changes of the dynamic settings, returning the settings changed.
+// Nothing is applied if any immutable setting is changed
+func (r *Reloader) Reload() ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cfg, err := New(r.paths, r.plugins)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load config")
+	}
+	// the genesis is loaded from its own file, and the master key is generated randomly if it isn't set, neither of
+	// which is reloaded
+	cfg.Genesis = r.current.Genesis
+	cfg.Network.MasterKey = r.current.Network.MasterKey
+
+	changed := changedSettings(reflect.ValueOf(r.current), reflect.ValueOf(cfg), "")
+	var immutable []string
+	applies := map[string]struct{}{}
+	for _, path := range changed {
+		setting, ok := r.setting(path)
+		if !ok {
+			immutable = append(immutable, path)
+			continue
+		}
+		applies[setting] = struct{}{}
+	}
+	if len(immutable) > 0 {
+		return nil, errors.Wrap(ErrImmutableConfig, strings.Join(immutable, ", "))
+	}
+	settings := make([]string, 0, len(applies))
+	for setting := range applies {
+		settings = append(settings, setting)
+	}
+	sort.Strings(settings)
+	for _, setting := range settings {
+		if err := r.settings[setting](cfg); err != nil {
+			return nil, errors.Wrapf(err, "failed to apply %s", setting)
+		}
+	}
+	r.current = cfg
+	return changed, nil
+}
+
+// setting returns the dynamic setting covering the path
+func (r *Reloader) setting(path string) (string, bool) {
+	for setting := range r.settings {
+		if matchSetting(setting, path) {
+			return setting, true
+		}
+	}
+	return "", false
+}
+
+func matchSetting(setting, path string) bool {
+	patterns, keys := strings.Split(setting, "."), strings.Split(path, ".")
+	if len(keys) < len(patterns) {
+		return false
+	}
+	for i, p := range patterns {
+		if p != "*" && p != keys[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// changedSettings returns the yaml paths of the settings different in the configs. The structs and the maps of the
+// same keys are compared field by field, and the functions and the fields not in yaml are skipped
+func changedSettings(a, b reflect.Value, path string) []string {
+	switch a.Kind() {
+	case reflect.Func:
+		return nil
+	case reflect.Ptr, reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				return []string{path}
+			}
+			return nil
+		}
+		if a.Kind() == reflect.Interface && a.Elem().Type() != b.Elem().Type() {
+			return []string{path}
+		}
+		return changedSettings(a.Elem(), b.Elem(), path)
+	case reflect.Struct:
+		if !exportedOnly(a.Type()) {
+			break
+		}
+		var changed []string
+		for i := 0; i < a.NumField(); i++ {
+			if a.Type().Field(i).Tag.Get("yaml") == "-" {
+				continue
+			}
+			changed = append(changed, changedSettings(a.Field(i), b.Field(i), join(path, settingName(a.Type().Field(i))))...)
+		}
+		return changed
+	case reflect.Map:
+		if a.Len() != b.Len() {
+			return []string{path}
+		}
+		var changed []string
+		for _, k := range a.MapKeys() {
+			v := b.MapIndex(k)
+			if !v.IsValid() {
+				return []string{path}
+			}
+			changed = append(changed, changedSettings(a.MapIndex(k), v, join(path, fmt.Sprint(k.Interface())))...)
+		}
+		sort.Strings(changed)
+		return changed
+	}
+	if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+		return []string{path}
+	}
+	return nil
+}
+
+func exportedOnly(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if !t.Field(i).IsExported() {
+			return false
+		}
+	}
+	return true
+}
+
+func settingName(f reflect.StructField) string {
+	if name, _, _ := strings.Cut(f.Tag.Get("yaml"), ","); name != "" {
+		return name
+	}
+	return f.Name
+}
+
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
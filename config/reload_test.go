@@ -0,0 +1,112 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReloader(t *testing.T) {
+	require := require.New(t)
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	write := func(s string) {
+		require.NoError(os.WriteFile(path, []byte(s), 0666))
+	}
+	write(`
+actPool:
+  maxNumActsPerPool: 10000
+log:
+  zap:
+    level: info
+`)
+	cfg, err := New([]string{path}, nil)
+	require.NoError(err)
+	r := NewReloader(cfg, []string{path}, nil)
+	var applied []Config
+	apply := func(cfg Config) error {
+		applied = append(applied, cfg)
+		return nil
+	}
+	r.Register("actPool.maxNumActsPerPool", apply)
+	r.Register("log.zap.level", apply)
+	r.Register("subLogs.*.zap.level", apply)
+
+	// nothing is changed
+	changed, err := r.Reload()
+	require.NoError(err)
+	require.Empty(changed)
+	require.Empty(applied)
+
+	// the dynamic settings are applied
+	write(`
+actPool:
+  maxNumActsPerPool: 20000
+log:
+  zap:
+    level: debug
+`)
+	changed, err = r.Reload()
+	require.NoError(err)
+	require.Equal([]string{"actPool.maxNumActsPerPool", "log.zap.level"}, changed)
+	require.Len(applied, 2)
+	require.Equal(uint64(20000), applied[1].ActPool.MaxNumActsPerPool)
+
+	// the config is not reloaded if any immutable setting is changed
+	applied = nil
+	write(`
+actPool:
+  maxNumActsPerPool: 30000
+api:
+  port: 12345
+`)
+	_, err = r.Reload()
+	require.Equal(ErrImmutableConfig, errors.Cause(err))
+	require.Contains(err.Error(), "api.port")
+	require.Empty(applied)
+	require.Equal(uint64(20000), r.current.ActPool.MaxNumActsPerPool)
+
+	// the failure to apply is returned
+	write(`
+actPool:
+  maxNumActsPerPool: 30000
+log:
+  zap:
+    level: debug
+`)
+	r.Register("actPool.maxNumActsPerPool", func(Config) error {
+		return errors.New("failed")
+	})
+	_, err = r.Reload()
+	require.ErrorContains(err, "failed to apply actPool.maxNumActsPerPool")
+}
+
+func TestMatchSetting(t *testing.T) {
+	require := require.New(t)
+	require.True(matchSetting("api.rateLimit", "api.rateLimit.apiKeys.abc"))
+	require.True(matchSetting("subLogs.*.zap.level", "subLogs.api.zap.level"))
+	require.False(matchSetting("subLogs.*.zap.level", "subLogs.api.zap.encoding"))
+	require.False(matchSetting("api.rateLimit", "api"))
+	require.False(matchSetting("api.rateLimit", "api.rateLimitX"))
+}
+
+func TestChangedSettings(t *testing.T) {
+	require := require.New(t)
+	a, b := Default, Default
+	sk, err := crypto.GenerateKey()
+	require.NoError(err)
+	// the fields not in yaml are skipped
+	b.Chain.ProducerExternalKeys = []crypto.PrivateKey{sk}
+	require.Empty(changedSettings(reflect.ValueOf(a), reflect.ValueOf(b), ""))
+	b.Chain.ProducerPrivKeyRange = "[0:1]"
+	require.Equal([]string{"chain.producerPrivKeyRange"}, changedSettings(reflect.ValueOf(a), reflect.ValueOf(b), ""))
+}
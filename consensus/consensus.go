@@ -17,9 +17,11 @@ import (
 	"github.com/iotexproject/iotex-core/v2/action/protocol"
 	"github.com/iotexproject/iotex-core/v2/action/protocol/poll"
 	rp "github.com/iotexproject/iotex-core/v2/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/staking"
 	"github.com/iotexproject/iotex-core/v2/blockchain"
 	"github.com/iotexproject/iotex-core/v2/blockchain/block"
 	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/consensus/consensusfsm"
 	"github.com/iotexproject/iotex-core/v2/consensus/scheme"
 	"github.com/iotexproject/iotex-core/v2/consensus/scheme/rolldpos"
 	"github.com/iotexproject/iotex-core/v2/pkg/lifecycle"
@@ -35,6 +37,9 @@ type Consensus interface {
 	Calibrate(uint64)
 	ValidateBlockFooter(*block.Block) error
 	Metrics() (scheme.ConsensusMetrics, error)
+	Status() (scheme.ConsensusStatus, error)
+	Timeouts() (consensusfsm.Timeouts, error)
+	SetTimeouts(*consensusfsm.Timeouts) error
 	Activate(bool)
 	Active() bool
 }
@@ -49,7 +54,11 @@ type optionParams struct {
 	broadcastHandler scheme.Broadcast
 	pp               poll.Protocol
 	rp               *rp.Protocol
+	sp               *staking.Protocol
 	bbf              rolldpos.BlockBuilderFactory
+	evidenceHandler  rolldpos.EvidenceHandler
+	blockPusher      rolldpos.BlockPusher
+	clock            clock.Clock
 }
 
 // Option sets Consensus construction parameter.
@@ -79,6 +88,15 @@ func WithPollProtocol(pp poll.Protocol) Option {
 	}
 }
 
+// WithStakingProtocol is an option to register staking protocol, to honor both operators of the delegates rotating
+// the operators
+func WithStakingProtocol(sp *staking.Protocol) Option {
+	return func(ops *optionParams) error {
+		ops.sp = sp
+		return nil
+	}
+}
+
 // WithBlockBuilderFactory is an option to set block builder factory
 func WithBlockBuilderFactory(bbf rolldpos.BlockBuilderFactory) Option {
 	return func(ops *optionParams) error {
@@ -87,6 +105,30 @@ func WithBlockBuilderFactory(bbf rolldpos.BlockBuilderFactory) Option {
 	}
 }
 
+// WithEvidenceHandler is an option to handle the evidences of double signing, e.g., to slash the offender
+func WithEvidenceHandler(handler rolldpos.EvidenceHandler) Option {
+	return func(ops *optionParams) error {
+		ops.evidenceHandler = handler
+		return nil
+	}
+}
+
+// WithBlockPusher is an option to send the blocks produced to the next proposers directly in addition to the broadcast
+func WithBlockPusher(pusher rolldpos.BlockPusher) Option {
+	return func(ops *optionParams) error {
+		ops.blockPusher = pusher
+		return nil
+	}
+}
+
+// WithClock is an option to drive the consensus by the clock, e.g., a mock clock in the simulation
+func WithClock(clk clock.Clock) Option {
+	return func(ops *optionParams) error {
+		ops.clock = clk
+		return nil
+	}
+}
+
 // NewConsensus creates a IotxConsensus struct.
 func NewConsensus(
 	cfg rolldpos.BuilderConfig,
@@ -101,7 +143,10 @@ func NewConsensus(
 		}
 	}
 
-	clock := clock.New()
+	clk := ops.clock
+	if clk == nil {
+		clk = clock.New()
+	}
 	cs := &IotxConsensus{cfg: Config{
 		Scheme:   cfg.Scheme,
 		RollDPoS: cfg.Consensus,
@@ -152,15 +197,47 @@ func NewConsensus(
 			return addrs, nil
 		}
 		proposersByEpochFunc := delegatesByEpochFunc
+		var (
+			aliasesByEpochFunc      rolldpos.OperatorAliasesByEpochFunc
+			blsEndorsersByEpochFunc rolldpos.BLSEndorsersByEpochFunc
+		)
+		if ops.sp != nil {
+			aliasesByEpochFunc = func(epochNum uint64, prevHash []byte) (map[string]string, error) {
+				fork, err := chainMgr.Fork(hash.Hash256(prevHash))
+				if err != nil {
+					return nil, err
+				}
+				forkSF, err := fork.StateReader()
+				if err != nil {
+					return nil, err
+				}
+				return ops.sp.OperatorAliases(context.Background(), forkSF, epochNum)
+			}
+			blsEndorsersByEpochFunc = func(epochNum uint64, prevHash []byte) (map[string]string, error) {
+				fork, err := chainMgr.Fork(hash.Hash256(prevHash))
+				if err != nil {
+					return nil, err
+				}
+				forkSF, err := fork.StateReader()
+				if err != nil {
+					return nil, err
+				}
+				return ops.sp.BLSEndorsers(context.Background(), forkSF, epochNum)
+			}
+		}
 		bd := rolldpos.NewRollDPoSBuilder().
 			SetPriKey(cfg.Chain.ProducerPrivateKeys()...).
 			SetConfig(cfg).
 			SetChainManager(chainMgr).
 			SetBlockDeserializer(block.NewDeserializer(bc.EvmNetworkID())).
-			SetClock(clock).
+			SetClock(clk).
 			SetBroadcast(ops.broadcastHandler).
 			SetDelegatesByEpochFunc(delegatesByEpochFunc).
 			SetProposersByEpochFunc(proposersByEpochFunc).
+			SetEvidenceHandler(ops.evidenceHandler).
+			SetOperatorAliasesByEpochFunc(aliasesByEpochFunc).
+			SetBLSEndorsersByEpochFunc(blsEndorsersByEpochFunc).
+			SetBlockPusher(ops.blockPusher).
 			RegisterProtocol(ops.rp)
 		// TODO: explorer dependency deleted here at #1085, need to revive by migrating to api
 		cs.scheme, err = bd.Build()
@@ -171,7 +248,7 @@ func NewConsensus(
 		cs.scheme = scheme.NewNoop()
 	case StandaloneScheme:
 		mintBlockCB := func() (*block.Block, error) {
-			blk, err := bc.MintNewBlock(clock.Now())
+			blk, err := bc.MintNewBlock(clk.Now())
 			if err != nil {
 				log.Logger("consensus").Error("Failed to mint a block.", zap.Error(err))
 				return nil, err
@@ -235,6 +312,21 @@ func (c *IotxConsensus) Metrics() (scheme.ConsensusMetrics, error) {
 	return c.scheme.Metrics()
 }
 
+// Status returns the status of the consensus rounds
+func (c *IotxConsensus) Status() (scheme.ConsensusStatus, error) {
+	return c.scheme.Status()
+}
+
+// Timeouts returns the timeouts of the consensus rounds
+func (c *IotxConsensus) Timeouts() (consensusfsm.Timeouts, error) {
+	return c.scheme.Timeouts()
+}
+
+// SetTimeouts adjusts the timeouts of the consensus rounds at runtime
+func (c *IotxConsensus) SetTimeouts(t *consensusfsm.Timeouts) error {
+	return c.scheme.SetTimeouts(t)
+}
+
 // HandleConsensusMsg handles consensus messages
 func (c *IotxConsensus) HandleConsensusMsg(msg *iotextypes.ConsensusMessage) error {
 	return c.scheme.HandleConsensusMsg(msg)
@@ -6,11 +6,20 @@
 package consensusfsm
 
 import (
+	"sync"
 	"time"
 
+	"github.com/pkg/errors"
+
 	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
 )
 
+// _minTimeout is the lower bound of the timeouts adjusted at runtime
+const _minTimeout = 100 * time.Millisecond
+
+// ErrInvalidTimeouts indicates that the timeouts of the consensus round are invalid
+var ErrInvalidTimeouts = errors.New("invalid consensus timeouts")
+
 var (
 	// DefaultDardanellesUpgradeConfig is the default config for dardanelles upgrade
 	DefaultDardanellesUpgradeConfig = DardanellesUpgrade{
@@ -69,6 +78,16 @@ type (
 		CommitTTL                    time.Duration `yaml:"commitTTL"`
 	}
 
+	// Timeouts are the timeouts of a consensus round
+	Timeouts struct {
+		UnmatchedEventTTL            time.Duration
+		UnmatchedEventInterval       time.Duration
+		AcceptBlockTTL               time.Duration
+		AcceptProposalEndorsementTTL time.Duration
+		AcceptLockEndorsementTTL     time.Duration
+		CommitTTL                    time.Duration
+	}
+
 	// ConsensusConfig defines a set of time durations used in fsm
 	ConsensusConfig interface {
 		EventChanSize() uint
@@ -80,6 +99,8 @@ type (
 		CommitTTL(uint64) time.Duration
 		BlockInterval(uint64) time.Duration
 		Delay(uint64) time.Duration
+		Timeouts(uint64) Timeouts
+		SetTimeouts(uint64, *Timeouts) error
 	}
 
 	// config implements ConsensusConfig
@@ -92,9 +113,65 @@ type (
 		dardanellesHeight uint64
 		wake              WakeUpgrade
 		wakeHeight        uint64
+
+		mutex     sync.RWMutex
+		overrides *Timeouts
 	}
 )
 
+// Timeouts returns the timeouts of the consensus round
+func (t *ConsensusTiming) Timeouts() Timeouts {
+	return Timeouts{
+		UnmatchedEventTTL:            t.UnmatchedEventTTL,
+		UnmatchedEventInterval:       t.UnmatchedEventInterval,
+		AcceptBlockTTL:               t.AcceptBlockTTL,
+		AcceptProposalEndorsementTTL: t.AcceptProposalEndorsementTTL,
+		AcceptLockEndorsementTTL:     t.AcceptLockEndorsementTTL,
+		CommitTTL:                    t.CommitTTL,
+	}
+}
+
+// Timeouts returns the timeouts of the consensus round
+func (d *DardanellesUpgrade) Timeouts() Timeouts {
+	return Timeouts{
+		UnmatchedEventTTL:            d.UnmatchedEventTTL,
+		UnmatchedEventInterval:       d.UnmatchedEventInterval,
+		AcceptBlockTTL:               d.AcceptBlockTTL,
+		AcceptProposalEndorsementTTL: d.AcceptProposalEndorsementTTL,
+		AcceptLockEndorsementTTL:     d.AcceptLockEndorsementTTL,
+		CommitTTL:                    d.CommitTTL,
+	}
+}
+
+// Timeouts returns the timeouts of the consensus round
+func (w *WakeUpgrade) Timeouts() Timeouts {
+	return Timeouts{
+		UnmatchedEventTTL:            w.UnmatchedEventTTL,
+		UnmatchedEventInterval:       w.UnmatchedEventInterval,
+		AcceptBlockTTL:               w.AcceptBlockTTL,
+		AcceptProposalEndorsementTTL: w.AcceptProposalEndorsementTTL,
+		AcceptLockEndorsementTTL:     w.AcceptLockEndorsementTTL,
+		CommitTTL:                    w.CommitTTL,
+	}
+}
+
+// Validate validates the timeouts in a round of the block interval. The ttls of the round should be positive and
+// fit in the block interval, and the unmatched events should be checked within their ttl
+func (t *Timeouts) Validate(blockInterval time.Duration) error {
+	sum := t.AcceptBlockTTL + t.AcceptProposalEndorsementTTL + t.AcceptLockEndorsementTTL + t.CommitTTL
+	switch {
+	case t.AcceptBlockTTL <= 0 || t.AcceptProposalEndorsementTTL <= 0 || t.AcceptLockEndorsementTTL <= 0 || t.CommitTTL <= 0:
+		return errors.Wrap(ErrInvalidTimeouts, "ttls should be positive")
+	case sum > blockInterval:
+		return errors.Wrapf(ErrInvalidTimeouts, "sum of ttls %s exceeds block interval %s", sum, blockInterval)
+	case t.UnmatchedEventTTL <= 0 || t.UnmatchedEventTTL > blockInterval:
+		return errors.Wrapf(ErrInvalidTimeouts, "unmatched event ttl %s should be positive and within block interval %s", t.UnmatchedEventTTL, blockInterval)
+	case t.UnmatchedEventInterval <= 0 || t.UnmatchedEventInterval > t.UnmatchedEventTTL:
+		return errors.Wrapf(ErrInvalidTimeouts, "unmatched event interval %s should be positive and within unmatched event ttl", t.UnmatchedEventInterval)
+	}
+	return nil
+}
+
 // NewConsensusConfig creates a ConsensusConfig out of config.
 func NewConsensusConfig(timing ConsensusTiming, dardanelles DardanellesUpgrade, wake WakeUpgrade, g genesis.Genesis, delay time.Duration) ConsensusConfig {
 	return &consensusCfg{
@@ -121,6 +198,9 @@ func (c *consensusCfg) EventChanSize() uint {
 }
 
 func (c *consensusCfg) UnmatchedEventTTL(height uint64) time.Duration {
+	if t := c.overridden(); t != nil {
+		return t.UnmatchedEventTTL
+	}
 	if c.isWake(height) {
 		return c.wake.UnmatchedEventTTL
 	}
@@ -131,6 +211,9 @@ func (c *consensusCfg) UnmatchedEventTTL(height uint64) time.Duration {
 }
 
 func (c *consensusCfg) UnmatchedEventInterval(height uint64) time.Duration {
+	if t := c.overridden(); t != nil {
+		return t.UnmatchedEventInterval
+	}
 	if c.isWake(height) {
 		return c.wake.UnmatchedEventInterval
 	}
@@ -141,6 +224,9 @@ func (c *consensusCfg) UnmatchedEventInterval(height uint64) time.Duration {
 }
 
 func (c *consensusCfg) AcceptBlockTTL(height uint64) time.Duration {
+	if t := c.overridden(); t != nil {
+		return t.AcceptBlockTTL
+	}
 	if c.isWake(height) {
 		return c.wake.AcceptBlockTTL
 	}
@@ -151,6 +237,9 @@ func (c *consensusCfg) AcceptBlockTTL(height uint64) time.Duration {
 }
 
 func (c *consensusCfg) AcceptProposalEndorsementTTL(height uint64) time.Duration {
+	if t := c.overridden(); t != nil {
+		return t.AcceptProposalEndorsementTTL
+	}
 	if c.isWake(height) {
 		return c.wake.AcceptProposalEndorsementTTL
 	}
@@ -161,6 +250,9 @@ func (c *consensusCfg) AcceptProposalEndorsementTTL(height uint64) time.Duration
 }
 
 func (c *consensusCfg) AcceptLockEndorsementTTL(height uint64) time.Duration {
+	if t := c.overridden(); t != nil {
+		return t.AcceptLockEndorsementTTL
+	}
 	if c.isWake(height) {
 		return c.wake.AcceptLockEndorsementTTL
 	}
@@ -171,6 +263,9 @@ func (c *consensusCfg) AcceptLockEndorsementTTL(height uint64) time.Duration {
 }
 
 func (c *consensusCfg) CommitTTL(height uint64) time.Duration {
+	if t := c.overridden(); t != nil {
+		return t.CommitTTL
+	}
 	if c.isWake(height) {
 		return c.wake.CommitTTL
 	}
@@ -196,3 +291,49 @@ func (c *consensusCfg) Delay(height uint64) time.Duration {
 	}
 	return c.delay
 }
+
+func (c *consensusCfg) overridden() *Timeouts {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.overrides
+}
+
+// Timeouts returns the timeouts of the round at the height
+func (c *consensusCfg) Timeouts(height uint64) Timeouts {
+	return Timeouts{
+		UnmatchedEventTTL:            c.UnmatchedEventTTL(height),
+		UnmatchedEventInterval:       c.UnmatchedEventInterval(height),
+		AcceptBlockTTL:               c.AcceptBlockTTL(height),
+		AcceptProposalEndorsementTTL: c.AcceptProposalEndorsementTTL(height),
+		AcceptLockEndorsementTTL:     c.AcceptLockEndorsementTTL(height),
+		CommitTTL:                    c.CommitTTL(height),
+	}
+}
+
+// SetTimeouts overrides the timeouts of the rounds at runtime, which are validated against the block interval at the
+// height, and each of them should be no less than 100ms. The configured timeouts are restored if t is nil
+func (c *consensusCfg) SetTimeouts(height uint64, t *Timeouts) error {
+	if t != nil {
+		for _, d := range []time.Duration{
+			t.UnmatchedEventTTL,
+			t.UnmatchedEventInterval,
+			t.AcceptBlockTTL,
+			t.AcceptProposalEndorsementTTL,
+			t.AcceptLockEndorsementTTL,
+			t.CommitTTL,
+		} {
+			if d < _minTimeout {
+				return errors.Wrapf(ErrInvalidTimeouts, "timeout %s is less than %s", d, _minTimeout)
+			}
+		}
+		if err := t.Validate(c.BlockInterval(height)); err != nil {
+			return err
+		}
+		copied := *t
+		t = &copied
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.overrides = t
+	return nil
+}
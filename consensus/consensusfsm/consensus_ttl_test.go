@@ -51,3 +51,30 @@ func TestConsensusConfig_BlockInterval(t *testing.T) {
 		})
 	}
 }
+
+func TestConsensusConfig_SetTimeouts(t *testing.T) {
+	require := require.New(t)
+	cfg := config.Default
+	ccfg := consensusfsm.NewConsensusConfig(cfg.Consensus.RollDPoS.FSM, cfg.DardanellesUpgrade, cfg.WakeUpgrade, cfg.Genesis, 2*time.Second)
+	height := cfg.Genesis.WakeBlockHeight
+	require.Equal(cfg.WakeUpgrade.Timeouts(), ccfg.Timeouts(height))
+
+	timeouts := cfg.WakeUpgrade.Timeouts()
+	timeouts.AcceptBlockTTL = 50 * time.Millisecond
+	require.ErrorIs(ccfg.SetTimeouts(height, &timeouts), consensusfsm.ErrInvalidTimeouts)
+	timeouts.AcceptBlockTTL = 2 * time.Second
+	require.ErrorIs(ccfg.SetTimeouts(height, &timeouts), consensusfsm.ErrInvalidTimeouts)
+	require.Equal(cfg.WakeUpgrade.Timeouts(), ccfg.Timeouts(height))
+
+	timeouts.AcceptBlockTTL = 500 * time.Millisecond
+	require.NoError(ccfg.SetTimeouts(height, &timeouts))
+	// the overrides apply to all heights, and are not affected by the later changes of the argument
+	timeouts.AcceptBlockTTL = time.Second
+	require.Equal(500*time.Millisecond, ccfg.AcceptBlockTTL(height))
+	require.Equal(500*time.Millisecond, ccfg.AcceptBlockTTL(cfg.Genesis.DardanellesBlockHeight))
+	require.Equal(cfg.WakeUpgrade.CommitTTL, ccfg.CommitTTL(cfg.Genesis.DardanellesBlockHeight))
+
+	require.NoError(ccfg.SetTimeouts(height, nil))
+	require.Equal(cfg.WakeUpgrade.AcceptBlockTTL, ccfg.AcceptBlockTTL(height))
+	require.Equal(cfg.DardanellesUpgrade.AcceptBlockTTL, ccfg.AcceptBlockTTL(cfg.Genesis.DardanellesBlockHeight))
+}
@@ -34,6 +34,8 @@ type Context interface {
 	Prepare() error
 	HasDelegate() bool
 	Proposal() (interface{}, error)
+	StandbyDelay() (time.Duration, bool)
+	StandbyProposal() (interface{}, error)
 	WaitUntilRoundStart() time.Duration
 	PreCommitEndorsement() interface{}
 	NewProposalEndorsement(interface{}) (interface{}, error)
@@ -56,6 +56,7 @@ const (
 	ePrepare                           fsm.EventType = "E_PREPARE"
 	eReceiveBlock                      fsm.EventType = "E_RECEIVE_BLOCK"
 	eFailedToReceiveBlock              fsm.EventType = "E_FAILED_TO_RECEIVE_BLOCK"
+	eProposeStandbyBlock               fsm.EventType = "E_PROPOSE_STANDBY_BLOCK"
 	eReceiveProposalEndorsement        fsm.EventType = "E_RECEIVE_PROPOSAL_ENDORSEMENT"
 	eStopReceivingProposalEndorsement  fsm.EventType = "E_STOP_RECEIVING_PROPOSAL_ENDORSEMENT"
 	eReceiveLockEndorsement            fsm.EventType = "E_RECEIVE_LOCK_ENDORSEMENT"
@@ -132,6 +133,13 @@ func NewConsensusFSM(ctx Context, clock clock.Clock) (*ConsensusFSM, error) {
 				sAcceptBlockProposal,       // proposed block invalid
 				sAcceptProposalEndorsement, // receive valid block, jump to next step
 			}).
+		AddTransition(
+			sAcceptBlockProposal,
+			eProposeStandbyBlock,
+			cm.onProposeStandbyBlock,
+			[]fsm.State{
+				sAcceptBlockProposal, // propose a block as a standby proposer, and wait for it
+			}).
 		AddTransition(
 			sAcceptBlockProposal,
 			eFailedToReceiveBlock,
@@ -329,9 +337,12 @@ func (m *ConsensusFSM) produceOne(evt *ConsensusEvent, delay time.Duration) {
 	if delay > 0 {
 		m.wg.Add(1)
 		go func() {
+			// stop the timer on close, the mock clock blocks on the timer nobody waits for
+			timer := m.clock.Timer(delay)
 			select {
 			case <-m.close:
-			case <-m.clock.After(delay):
+				timer.Stop()
+			case <-timer.C:
 				m.evtq <- evt
 			}
 			m.wg.Done()
@@ -454,6 +465,11 @@ func (m *ConsensusFSM) prepare(evt fsm.Event) (fsm.State, error) {
 		m.produceConsensusEvent(eStopReceivingPreCommitEndorsement, ttl)
 		return sAcceptPreCommitEndorsement, nil
 	}
+	if proposal == nil {
+		if delay, ok := m.ctx.StandbyDelay(); ok {
+			m.produceConsensusEvent(eProposeStandbyBlock, delay-overtime)
+		}
+	}
 	m.produceConsensusEvent(eFailedToReceiveBlock, ttl)
 	ttl += m.ctx.AcceptProposalEndorsementTTL(h)
 	m.produceConsensusEvent(eStopReceivingProposalEndorsement, ttl)
@@ -478,6 +494,20 @@ func (m *ConsensusFSM) onReceiveBlock(evt fsm.Event) (fsm.State, error) {
 	return sAcceptProposalEndorsement, nil
 }
 
+func (m *ConsensusFSM) onProposeStandbyBlock(evt fsm.Event) (fsm.State, error) {
+	m.ctx.Logger().Warn("didn't receive the proposed block before the slot of standby proposer")
+	proposal, err := m.ctx.StandbyProposal()
+	if err != nil {
+		m.ctx.Logger().Error("failed to generate standby block proposal", zap.Error(err))
+		return sAcceptBlockProposal, nil
+	}
+	if proposal != nil {
+		m.ctx.Broadcast(proposal)
+		m.ProduceReceiveBlockEvent(proposal)
+	}
+	return sAcceptBlockProposal, nil
+}
+
 func (m *ConsensusFSM) processBlock(block interface{}) error {
 	en, err := m.ctx.NewProposalEndorsement(block)
 	if err != nil {
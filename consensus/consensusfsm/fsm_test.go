@@ -137,6 +137,7 @@ func TestStateTransitionFunctions(t *testing.T) {
 					mockCtx.EXPECT().Proposal().Return(nil, nil).Times(1)
 					mockCtx.EXPECT().WaitUntilRoundStart().Return(time.Duration(0)).Times(1)
 					mockCtx.EXPECT().PreCommitEndorsement().Return(nil).Times(1)
+					mockCtx.EXPECT().StandbyDelay().Return(time.Duration(0), false).Times(1)
 					mockCtx.EXPECT().Height().Return(uint64(0)).Times(2)
 					state, err := cfsm.prepare(evt)
 					require.NoError(err)
@@ -156,6 +157,34 @@ func TestStateTransitionFunctions(t *testing.T) {
 					evt = <-cfsm.evtq
 					require.Equal(eStopReceivingPreCommitEndorsement, evt.Type())
 				})
+				t.Run("standby-proposer", func(t *testing.T) {
+					mockCtx.EXPECT().Prepare().Return(nil).Times(1)
+					mockCtx.EXPECT().Proposal().Return(nil, nil).Times(1)
+					mockCtx.EXPECT().WaitUntilRoundStart().Return(time.Duration(0)).Times(1)
+					mockCtx.EXPECT().PreCommitEndorsement().Return(nil).Times(1)
+					mockCtx.EXPECT().StandbyDelay().Return(time.Second, true).Times(1)
+					mockCtx.EXPECT().Height().Return(uint64(0)).Times(1)
+					state, err := cfsm.prepare(evt)
+					require.NoError(err)
+					require.Equal(sAcceptBlockProposal, state)
+					time.Sleep(100 * time.Millisecond)
+					mockClock.Add(time.Second)
+					evt := <-cfsm.evtq
+					require.Equal(eProposeStandbyBlock, evt.Type())
+					// garbage collection
+					mockClock.Add(cfsm.ctx.AcceptBlockTTL(0) - time.Second)
+					evt = <-cfsm.evtq
+					require.Equal(eFailedToReceiveBlock, evt.Type())
+					mockClock.Add(cfsm.ctx.AcceptProposalEndorsementTTL(0))
+					evt = <-cfsm.evtq
+					require.Equal(eStopReceivingProposalEndorsement, evt.Type())
+					mockClock.Add(cfsm.ctx.AcceptLockEndorsementTTL(0))
+					evt = <-cfsm.evtq
+					require.Equal(eStopReceivingLockEndorsement, evt.Type())
+					mockClock.Add(cfsm.ctx.CommitTTL(0))
+					evt = <-cfsm.evtq
+					require.Equal(eStopReceivingPreCommitEndorsement, evt.Type())
+				})
 				t.Run("ready-to-commit", func(t *testing.T) {
 					mockEndorsement := NewMockEndorsement(ctrl)
 					mockCtx.EXPECT().Prepare().Return(nil).Times(1)
@@ -247,6 +276,23 @@ func TestStateTransitionFunctions(t *testing.T) {
 			require.Equal(eReceiveProposalEndorsement, evt.Type())
 		})
 	})
+	t.Run("onProposeStandbyBlock", func(t *testing.T) {
+		t.Run("fail-to-mint", func(t *testing.T) {
+			mockCtx.EXPECT().StandbyProposal().Return(nil, errors.New("some error")).Times(1)
+			state, err := cfsm.onProposeStandbyBlock(nil)
+			require.NoError(err)
+			require.Equal(sAcceptBlockProposal, state)
+		})
+		t.Run("success", func(t *testing.T) {
+			mockCtx.EXPECT().StandbyProposal().Return(NewMockEndorsement(ctrl), nil).Times(1)
+			mockCtx.EXPECT().Broadcast(gomock.Any()).Return().Times(1)
+			state, err := cfsm.onProposeStandbyBlock(nil)
+			require.NoError(err)
+			require.Equal(sAcceptBlockProposal, state)
+			evt := <-cfsm.evtq
+			require.Equal(eReceiveBlock, evt.Type())
+		})
+	})
 	t.Run("onFailedToReceiveBlock", func(t *testing.T) {
 		mockCtx.EXPECT().NewProposalEndorsement(nil).Return(NewMockEndorsement(ctrl), nil).Times(1)
 		mockCtx.EXPECT().Broadcast(gomock.Any()).Return().Times(1)
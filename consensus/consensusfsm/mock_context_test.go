@@ -394,6 +394,50 @@ func (mr *MockContextMockRecorder) Proposal() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Proposal", reflect.TypeOf((*MockContext)(nil).Proposal))
 }
 
+// SetTimeouts mocks base method.
+func (m *MockContext) SetTimeouts(arg0 uint64, arg1 *Timeouts) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTimeouts", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTimeouts indicates an expected call of SetTimeouts.
+func (mr *MockContextMockRecorder) SetTimeouts(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTimeouts", reflect.TypeOf((*MockContext)(nil).SetTimeouts), arg0, arg1)
+}
+
+// StandbyDelay mocks base method.
+func (m *MockContext) StandbyDelay() (time.Duration, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StandbyDelay")
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// StandbyDelay indicates an expected call of StandbyDelay.
+func (mr *MockContextMockRecorder) StandbyDelay() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StandbyDelay", reflect.TypeOf((*MockContext)(nil).StandbyDelay))
+}
+
+// StandbyProposal mocks base method.
+func (m *MockContext) StandbyProposal() (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StandbyProposal")
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StandbyProposal indicates an expected call of StandbyProposal.
+func (mr *MockContextMockRecorder) StandbyProposal() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StandbyProposal", reflect.TypeOf((*MockContext)(nil).StandbyProposal))
+}
+
 // Start mocks base method.
 func (m *MockContext) Start(arg0 context.Context) error {
 	m.ctrl.T.Helper()
@@ -422,6 +466,20 @@ func (mr *MockContextMockRecorder) Stop(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockContext)(nil).Stop), arg0)
 }
 
+// Timeouts mocks base method.
+func (m *MockContext) Timeouts(arg0 uint64) Timeouts {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Timeouts", arg0)
+	ret0, _ := ret[0].(Timeouts)
+	return ret0
+}
+
+// Timeouts indicates an expected call of Timeouts.
+func (mr *MockContextMockRecorder) Timeouts(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Timeouts", reflect.TypeOf((*MockContext)(nil).Timeouts), arg0)
+}
+
 // UnmatchedEventInterval mocks base method.
 func (m *MockContext) UnmatchedEventInterval(arg0 uint64) time.Duration {
 	m.ctrl.T.Helper()
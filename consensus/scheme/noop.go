@@ -11,6 +11,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/consensus/consensusfsm"
 	"github.com/iotexproject/iotex-core/v2/pkg/log"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 )
@@ -53,6 +54,30 @@ func (n *Noop) Metrics() (ConsensusMetrics, error) {
 	)
 }
 
+// Status is not implemented for noop scheme
+func (n *Noop) Status() (ConsensusStatus, error) {
+	return ConsensusStatus{}, errors.Wrapf(
+		ErrNotImplemented,
+		"noop scheme does not support status",
+	)
+}
+
+// Timeouts is not implemented for noop scheme
+func (n *Noop) Timeouts() (consensusfsm.Timeouts, error) {
+	return consensusfsm.Timeouts{}, errors.Wrapf(
+		ErrNotImplemented,
+		"noop scheme does not support timeouts",
+	)
+}
+
+// SetTimeouts is not implemented for noop scheme
+func (n *Noop) SetTimeouts(*consensusfsm.Timeouts) error {
+	return errors.Wrapf(
+		ErrNotImplemented,
+		"noop scheme does not support timeouts",
+	)
+}
+
 // Activate is not implemented for noop scheme
 func (n *Noop) Activate(_ bool) {
 	log.S().Warn("Noop scheme could not support activate")
@@ -0,0 +1,78 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"github.com/iotexproject/go-pkgs/cache"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+)
+
+var _attestationCacheMtc = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "iotex_consensus_attestation_cache",
+		Help: "Lookups of the verified block footers by the result",
+	},
+	[]string{"result"},
+)
+
+func init() {
+	prometheus.MustRegister(_attestationCacheMtc)
+}
+
+// attestationCache caches the digests of the block footers whose endorsements have been verified, keyed by the block
+// hash, such that a block endorsed during consensus or validated once during block sync is not verified again when
+// it is received from the other peers. A different footer of the same block is still verified
+type attestationCache struct {
+	footers cache.LRUCache
+}
+
+func newAttestationCache(size int) *attestationCache {
+	if size <= 0 {
+		return nil
+	}
+	return &attestationCache{
+		footers: cache.NewThreadSafeLruCache(size),
+	}
+}
+
+// Verified returns whether the footer of the block has been verified. It always returns false if the cache is nil
+func (c *attestationCache) Verified(blk *block.Block) bool {
+	if c == nil {
+		return false
+	}
+	digest, ok := footerDigest(blk)
+	if !ok {
+		return false
+	}
+	v, ok := c.footers.Get(blk.HashBlock())
+	if !ok || v.(hash.Hash256) != digest {
+		_attestationCacheMtc.WithLabelValues("miss").Inc()
+		return false
+	}
+	_attestationCacheMtc.WithLabelValues("hit").Inc()
+	return true
+}
+
+// Add records the footer of the block as verified
+func (c *attestationCache) Add(blk *block.Block) {
+	if c == nil {
+		return
+	}
+	if digest, ok := footerDigest(blk); ok {
+		c.footers.Add(blk.HashBlock(), digest)
+	}
+}
+
+func footerDigest(blk *block.Block) (hash.Hash256, bool) {
+	data, err := blk.Footer.Serialize()
+	if err != nil {
+		return hash.ZeroHash256, false
+	}
+	return hash.Hash256b(data), true
+}
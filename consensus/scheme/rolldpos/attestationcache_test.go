@@ -0,0 +1,34 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttestationCache(t *testing.T) {
+	require := require.New(t)
+
+	var disabled *attestationCache
+	require.Nil(newAttestationCache(0))
+	blk := makeBlock(t, 1, 4, false, 9)
+	disabled.Add(blk)
+	require.False(disabled.Verified(blk))
+
+	c := newAttestationCache(1)
+	require.False(c.Verified(blk))
+	c.Add(blk)
+	require.True(c.Verified(blk))
+	// the same block with another footer is not verified
+	require.False(c.Verified(makeBlock(t, 1, 3, false, 9)))
+	// the least recently used block is evicted
+	blk2 := makeBlock(t, 2, 4, false, 9)
+	c.Add(blk2)
+	require.True(c.Verified(blk2))
+	require.False(c.Verified(blk))
+}
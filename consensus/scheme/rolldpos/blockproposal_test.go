@@ -10,6 +10,7 @@ import (
 
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/iotexproject/iotex-core/v2/blockchain/block"
@@ -41,7 +42,7 @@ func TestNewBlockProposal(t *testing.T) {
 	require.NoError(bp3.LoadProto(pro, block.NewDeserializer(0)))
 	pro3, err := bp3.Proto()
 	require.NoError(err)
-	require.EqualValues(pro, pro3)
+	require.True(proto.Equal(pro, pro3))
 }
 func getBlock(t *testing.T) block.Block {
 	require := require.New(t)
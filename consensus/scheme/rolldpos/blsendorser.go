@@ -0,0 +1,31 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"strings"
+
+	"github.com/iotexproject/go-pkgs/crypto"
+
+	"github.com/iotexproject/iotex-core/v2/crypto/bls"
+)
+
+// blsEndorserKeys decodes the comma separated BLS private keys of the operators on this node, which are honored once
+// their public keys are registered on chain
+func blsEndorserKeys(keys string) ([]crypto.PrivateKey, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	var ret []crypto.PrivateKey
+	for _, k := range strings.Split(keys, ",") {
+		sk, err := bls.HexStringToPrivateKey(strings.TrimSpace(k))
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, sk)
+	}
+	return ret, nil
+}
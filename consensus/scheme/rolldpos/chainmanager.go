@@ -389,7 +389,7 @@ func (cm *chainManager) mintContext(
 			Producer:       producer,
 			GasLimit:       g.BlockGasLimitByHeight(height),
 			BaseFee:        protocol.CalcBaseFee(g.Blockchain, tip),
-			ExcessBlobGas:  protocol.CalcExcessBlobGas(tip.ExcessBlobGas, tip.BlobGasUsed),
+			ExcessBlobGas:  protocol.CalcExcessBlobGas(g.Blockchain, height, tip.ExcessBlobGas, tip.BlobGasUsed),
 		})
 	return protocol.WithFeatureCtx(ctx)
 }
@@ -25,6 +25,20 @@ const (
 	COMMIT ConsensusVoteTopic = 2
 )
 
+// String returns the name of the topic
+func (t ConsensusVoteTopic) String() string {
+	switch t {
+	case PROPOSAL:
+		return "proposal"
+	case LOCK:
+		return "lock"
+	case COMMIT:
+		return "commit"
+	default:
+		return "unknown"
+	}
+}
+
 // ConsensusVote is a vote on a given topic for a block on a specific height
 type ConsensusVote struct {
 	blkHash []byte
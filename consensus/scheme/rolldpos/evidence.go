@@ -0,0 +1,367 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/consensus/scheme"
+	"github.com/iotexproject/iotex-core/v2/consensus/scheme/rolldpos/endorsementpb"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/endorsement"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+)
+
+const (
+	_evidenceNS = "evd"
+)
+
+var (
+	// ErrInvalidEvidence indicates that the evidence doesn't prove a double signing
+	ErrInvalidEvidence = errors.New("invalid evidence")
+
+	_evidenceMtc = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iotex_consensus_double_sign_evidence",
+			Help: "Evidences of double signing by the kind of the signed messages",
+		},
+		[]string{"kind"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(_evidenceMtc)
+}
+
+type (
+	// Evidence is the proof that a delegate endorsed two different blocks in the consensus messages of the same
+	// kind, at the same height and round
+	Evidence struct {
+		first  *EndorsedConsensusMessage
+		second *EndorsedConsensusMessage
+	}
+
+	// EvidenceHandler handles a new evidence of double signing, e.g., a slashing protocol penalizes the offender
+	EvidenceHandler func(*Evidence) error
+
+	// signKey identifies the consensus message which a delegate signs only once in a round
+	signKey struct {
+		height    uint64
+		endorser  string
+		kind      string
+		timestamp int64
+	}
+
+	// evidencePool detects the conflicting consensus messages signed by the same delegate, and keeps the evidences
+	evidencePool struct {
+		mutex     sync.RWMutex
+		kvStore   db.KVStore
+		broadcast scheme.Broadcast
+		handler   EvidenceHandler
+		signed    map[signKey]*EndorsedConsensusMessage
+		evidences map[hash.Hash256]*Evidence
+	}
+)
+
+// signedBlock returns the kind of the consensus message and the hash of the block endorsed by it, the messages
+// without a block are not counted, as they don't conflict with others
+func signedBlock(msg *EndorsedConsensusMessage) (string, []byte) {
+	switch doc := msg.Document().(type) {
+	case *blockProposal:
+		blkHash := doc.block.HashBlock()
+		return "block", blkHash[:]
+	case *ConsensusVote:
+		if len(doc.BlockHash()) == 0 {
+			return "", nil
+		}
+		switch doc.Topic() {
+		case PROPOSAL:
+			return "proposal", doc.BlockHash()
+		case LOCK:
+			return "lock", doc.BlockHash()
+		case COMMIT:
+			return "commit", doc.BlockHash()
+		}
+	}
+	return "", nil
+}
+
+func newEvidence(first, second *EndorsedConsensusMessage) (*Evidence, error) {
+	if first.Height() != second.Height() {
+		return nil, errors.Wrap(ErrInvalidEvidence, "different heights")
+	}
+	en1, en2 := first.Endorsement(), second.Endorsement()
+	if !bytes.Equal(en1.Endorser().Bytes(), en2.Endorser().Bytes()) {
+		return nil, errors.Wrap(ErrInvalidEvidence, "different endorsers")
+	}
+	if !en1.Timestamp().Equal(en2.Timestamp()) {
+		return nil, errors.Wrap(ErrInvalidEvidence, "different rounds")
+	}
+	kind1, blk1 := signedBlock(first)
+	kind2, blk2 := signedBlock(second)
+	if kind1 == "" || kind1 != kind2 {
+		return nil, errors.Wrap(ErrInvalidEvidence, "different kinds of messages")
+	}
+	switch bytes.Compare(blk1, blk2) {
+	case 0:
+		return nil, errors.Wrap(ErrInvalidEvidence, "same block")
+	case 1:
+		// the evidence is in the order of the block hashes, so that it is the same whichever message comes first
+		first, second = second, first
+	}
+	if !endorsement.VerifyEndorsedDocument(first) || !endorsement.VerifyEndorsedDocument(second) {
+		return nil, errors.Wrap(ErrInvalidEvidence, "invalid signature")
+	}
+	return &Evidence{
+		first:  first,
+		second: second,
+	}, nil
+}
+
+// Height returns the height of the conflicting messages
+func (e *Evidence) Height() uint64 {
+	return e.first.Height()
+}
+
+// Kind returns the kind of the conflicting messages
+func (e *Evidence) Kind() string {
+	kind, _ := signedBlock(e.first)
+	return kind
+}
+
+// Offender returns the address of the delegate who signed the conflicting messages
+func (e *Evidence) Offender() address.Address {
+	return e.first.Endorsement().Endorser().Address()
+}
+
+// Messages returns the conflicting messages
+func (e *Evidence) Messages() (*EndorsedConsensusMessage, *EndorsedConsensusMessage) {
+	return e.first, e.second
+}
+
+// Hash returns the hash of the evidence
+func (e *Evidence) Hash() (hash.Hash256, error) {
+	pb, err := e.Proto()
+	if err != nil {
+		return hash.ZeroHash256, err
+	}
+	return hash.Hash256b(byteutil.Must(proto.Marshal(pb))), nil
+}
+
+// Proto converts the evidence to protobuf message
+func (e *Evidence) Proto() (*endorsementpb.Evidence, error) {
+	first, err := e.first.Proto()
+	if err != nil {
+		return nil, err
+	}
+	second, err := e.second.Proto()
+	if err != nil {
+		return nil, err
+	}
+	return &endorsementpb.Evidence{
+		First:  first,
+		Second: second,
+	}, nil
+}
+
+// LoadProto loads the evidence from protobuf message, and verifies it
+func (e *Evidence) LoadProto(pb *endorsementpb.Evidence, deserializer *block.Deserializer) error {
+	first := &EndorsedConsensusMessage{}
+	if err := first.LoadProto(pb.GetFirst(), deserializer); err != nil {
+		return err
+	}
+	second := &EndorsedConsensusMessage{}
+	if err := second.LoadProto(pb.GetSecond(), deserializer); err != nil {
+		return err
+	}
+	evidence, err := newEvidence(first, second)
+	if err != nil {
+		return err
+	}
+	*e = *evidence
+	return nil
+}
+
+func newEvidencePool(kvStore db.KVStore, broadcast scheme.Broadcast) *evidencePool {
+	return &evidencePool{
+		kvStore:   kvStore,
+		broadcast: broadcast,
+		signed:    map[signKey]*EndorsedConsensusMessage{},
+		evidences: map[hash.Hash256]*Evidence{},
+	}
+}
+
+// Load loads the evidences from the db
+func (p *evidencePool) Load(deserializer *block.Deserializer) error {
+	if p.kvStore == nil {
+		return nil
+	}
+	_, values, err := p.kvStore.Filter(_evidenceNS, func(k, v []byte) bool { return true }, nil, nil)
+	switch errors.Cause(err) {
+	case nil:
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		return nil
+	default:
+		return err
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	for _, v := range values {
+		pb := &endorsementpb.Evidence{}
+		if err := proto.Unmarshal(v, pb); err != nil {
+			return err
+		}
+		evidence := &Evidence{}
+		if err := evidence.LoadProto(pb, deserializer); err != nil {
+			return err
+		}
+		h, err := evidence.Hash()
+		if err != nil {
+			return err
+		}
+		p.evidences[h] = evidence
+	}
+	return nil
+}
+
+// SetHandler sets the handler of the new evidences
+func (p *evidencePool) SetHandler(handler EvidenceHandler) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.handler = handler
+}
+
+// Add adds a verified consensus message, and returns the evidence if the message conflicts with the one signed
+// before by the same delegate
+func (p *evidencePool) Add(msg *EndorsedConsensusMessage) (*Evidence, error) {
+	kind, _ := signedBlock(msg)
+	if kind == "" {
+		return nil, nil
+	}
+	endorser := msg.Endorsement().Endorser().Address()
+	if endorser == nil {
+		return nil, errors.New("failed to get address")
+	}
+	key := signKey{
+		height:    msg.Height(),
+		endorser:  endorser.String(),
+		kind:      kind,
+		timestamp: msg.Endorsement().Timestamp().UnixNano(),
+	}
+	p.mutex.Lock()
+	signed, ok := p.signed[key]
+	if !ok {
+		p.signed[key] = msg
+		p.mutex.Unlock()
+		return nil, nil
+	}
+	evidence, err := newEvidence(signed, msg)
+	if err != nil {
+		p.mutex.Unlock()
+		if errors.Cause(err) == ErrInvalidEvidence {
+			// the same message received again
+			return nil, nil
+		}
+		return nil, err
+	}
+	h, err := evidence.Hash()
+	if err != nil {
+		p.mutex.Unlock()
+		return nil, err
+	}
+	if _, ok := p.evidences[h]; ok {
+		p.mutex.Unlock()
+		return nil, nil
+	}
+	if err := p.put(h, evidence); err != nil {
+		p.mutex.Unlock()
+		return nil, err
+	}
+	p.evidences[h] = evidence
+	handler := p.handler
+	p.mutex.Unlock()
+
+	_evidenceMtc.WithLabelValues(kind).Inc()
+	log.Logger("consensus").Warn("double signing detected",
+		zap.String("offender", key.endorser),
+		zap.Uint64("height", key.height),
+		zap.String("kind", kind),
+		zap.Time("timestamp", msg.Endorsement().Timestamp()),
+	)
+	p.gossip(evidence)
+	if handler != nil {
+		if err := handler(evidence); err != nil {
+			log.Logger("consensus").Error("failed to handle evidence", zap.Error(err))
+		}
+	}
+	return evidence, nil
+}
+
+// Evidences returns the evidences in the pool
+func (p *evidencePool) Evidences() []*Evidence {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	evidences := make([]*Evidence, 0, len(p.evidences))
+	for _, e := range p.evidences {
+		evidences = append(evidences, e)
+	}
+	return evidences
+}
+
+// Prune removes the signed messages below the height, which are no longer accepted by consensus
+func (p *evidencePool) Prune(height uint64) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	for k := range p.signed {
+		if k.height < height {
+			delete(p.signed, k)
+		}
+	}
+}
+
+func (p *evidencePool) put(h hash.Hash256, evidence *Evidence) error {
+	if p.kvStore == nil {
+		return nil
+	}
+	pb, err := evidence.Proto()
+	if err != nil {
+		return err
+	}
+	value, err := proto.Marshal(pb)
+	if err != nil {
+		return err
+	}
+	// keys are ordered by height
+	key := append(byteutil.Uint64ToBytesBigEndian(evidence.Height()), h[:]...)
+	return p.kvStore.Put(_evidenceNS, key, value)
+}
+
+// gossip relays both conflicting messages, so that the peers which received only one of them detect the double
+// signing as well
+func (p *evidencePool) gossip(evidence *Evidence) {
+	if p.broadcast == nil {
+		return
+	}
+	for _, msg := range []*EndorsedConsensusMessage{evidence.first, evidence.second} {
+		pb, err := msg.Proto()
+		if err == nil {
+			err = p.broadcast(pb)
+		}
+		if err != nil {
+			log.Logger("consensus").Error("failed to gossip evidence", zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/endorsement"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+	"github.com/iotexproject/iotex-core/v2/testutil"
+)
+
+func signedVote(t *testing.T, sk crypto.PrivateKey, height uint64, blkHash []byte, topic ConsensusVoteTopic, ts time.Time) *EndorsedConsensusMessage {
+	vote := NewConsensusVote(blkHash, topic)
+	ens, err := endorsement.Endorse(vote, ts, sk)
+	require.NoError(t, err)
+	return NewEndorsedConsensusMessage(height, vote, ens[0])
+}
+
+func TestEvidencePool(t *testing.T) {
+	require := require.New(t)
+	testDBPath, err := testutil.PathOfTempFile("consensus.db")
+	require.NoError(err)
+	defer testutil.CleanupPath(testDBPath)
+	cfg := db.DefaultConfig
+	cfg.DbPath = testDBPath
+	kvStore := db.NewBoltDB(cfg)
+	require.NoError(kvStore.Start(context.Background()))
+	defer kvStore.Stop(context.Background())
+
+	var (
+		sk           = identityset.PrivateKey(1)
+		ts           = time.Unix(1000, 0)
+		blk1         = hash.Hash256b([]byte("block1"))
+		blk2         = hash.Hash256b([]byte("block2"))
+		gossiped     []proto.Message
+		handled      []*Evidence
+		deserializer = block.NewDeserializer(0)
+	)
+	pool := newEvidencePool(kvStore, func(msg proto.Message) error {
+		gossiped = append(gossiped, msg)
+		return nil
+	})
+	pool.SetHandler(func(e *Evidence) error {
+		handled = append(handled, e)
+		return nil
+	})
+
+	// no conflict
+	for _, msg := range []*EndorsedConsensusMessage{
+		signedVote(t, sk, 10, blk1[:], PROPOSAL, ts),
+		signedVote(t, sk, 10, blk1[:], PROPOSAL, ts),
+		signedVote(t, sk, 10, blk1[:], LOCK, ts),
+		signedVote(t, sk, 10, blk2[:], PROPOSAL, ts.Add(time.Second)),
+		signedVote(t, sk, 11, blk2[:], PROPOSAL, ts),
+		signedVote(t, identityset.PrivateKey(2), 10, blk2[:], PROPOSAL, ts),
+		signedVote(t, sk, 10, nil, PROPOSAL, ts),
+	} {
+		evidence, err := pool.Add(msg)
+		require.NoError(err)
+		require.Nil(evidence)
+	}
+	require.Empty(pool.Evidences())
+
+	// conflicting votes
+	conflict := signedVote(t, sk, 10, blk2[:], PROPOSAL, ts)
+	evidence, err := pool.Add(conflict)
+	require.NoError(err)
+	require.NotNil(evidence)
+	require.Equal(uint64(10), evidence.Height())
+	require.Equal("proposal", evidence.Kind())
+	require.Equal(identityset.Address(1).String(), evidence.Offender().String())
+	require.Len(gossiped, 2)
+	require.Len(handled, 1)
+	require.Len(pool.Evidences(), 1)
+	// reported once
+	evidence, err = pool.Add(conflict)
+	require.NoError(err)
+	require.Nil(evidence)
+	require.Len(handled, 1)
+
+	// evidence is verified on loading
+	pb, err := pool.Evidences()[0].Proto()
+	require.NoError(err)
+	loaded := &Evidence{}
+	require.NoError(loaded.LoadProto(pb, deserializer))
+	pb.Second = pb.First
+	require.ErrorIs(loaded.LoadProto(pb, deserializer), ErrInvalidEvidence)
+
+	// evidence is persisted
+	pool2 := newEvidencePool(kvStore, nil)
+	require.NoError(pool2.Load(deserializer))
+	require.Len(pool2.Evidences(), 1)
+	h1, err := pool.Evidences()[0].Hash()
+	require.NoError(err)
+	h2, err := pool2.Evidences()[0].Hash()
+	require.NoError(err)
+	require.Equal(h1, h2)
+
+	// pruned messages are not checked
+	pool.Prune(11)
+	evidence, err = pool.Add(signedVote(t, sk, 10, blk2[:], COMMIT, ts))
+	require.NoError(err)
+	require.Nil(evidence)
+	evidence, err = pool.Add(signedVote(t, sk, 11, blk1[:], PROPOSAL, ts))
+	require.NoError(err)
+	require.NotNil(evidence)
+}
+
+func TestNewEvidence(t *testing.T) {
+	require := require.New(t)
+	var (
+		sk   = identityset.PrivateKey(1)
+		ts   = time.Unix(1000, 0)
+		blk1 = hash.Hash256b([]byte("block1"))
+		blk2 = hash.Hash256b([]byte("block2"))
+	)
+	vote1 := signedVote(t, sk, 10, blk1[:], COMMIT, ts)
+	vote2 := signedVote(t, sk, 10, blk2[:], COMMIT, ts)
+	e1, err := newEvidence(vote1, vote2)
+	require.NoError(err)
+	e2, err := newEvidence(vote2, vote1)
+	require.NoError(err)
+	h1, err := e1.Hash()
+	require.NoError(err)
+	h2, err := e2.Hash()
+	require.NoError(err)
+	require.Equal(h1, h2)
+
+	for _, msg := range []*EndorsedConsensusMessage{
+		signedVote(t, sk, 11, blk2[:], COMMIT, ts),
+		signedVote(t, identityset.PrivateKey(2), 10, blk2[:], COMMIT, ts),
+		signedVote(t, sk, 10, blk2[:], COMMIT, ts.Add(time.Second)),
+		signedVote(t, sk, 10, blk2[:], LOCK, ts),
+		signedVote(t, sk, 10, blk1[:], COMMIT, ts),
+	} {
+		_, err := newEvidence(vote1, msg)
+		require.ErrorIs(err, ErrInvalidEvidence)
+	}
+}
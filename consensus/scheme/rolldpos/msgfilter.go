@@ -0,0 +1,70 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"sync"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/cache"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+)
+
+const (
+	_dropDecodeFailure    = "decodeFailure"
+	_dropInvalidSignature = "invalidSignature"
+	_dropRateLimit        = "rateLimit"
+	_dropInvalidEndorser  = "invalidEndorser"
+)
+
+var _droppedMsgMtc = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "iotex_consensus_dropped_msg",
+		Help: "Consensus messages dropped before handled by the FSM by the reason",
+	},
+	[]string{"reason"},
+)
+
+func init() {
+	prometheus.MustRegister(_droppedMsgMtc)
+}
+
+// endorserLimiter limits the rate of the consensus messages signed by an endorser, which is checked after the
+// signature is verified, such that a delegate cannot be throttled by the messages signed by the others
+type endorserLimiter struct {
+	mutex    sync.Mutex
+	limiters cache.LRUCache
+	r        rate.Limit
+	b        int
+}
+
+func newEndorserLimiter(size int, limit uint) *endorserLimiter {
+	if limit == 0 {
+		return nil
+	}
+	return &endorserLimiter{
+		limiters: cache.NewThreadSafeLruCache(size),
+		r:        rate.Limit(limit),
+		b:        int(limit),
+	}
+}
+
+// Allow returns whether a message of the endorser is allowed at the time of the consensus clock, so that the replay
+// on a mock clock is deterministic. It always returns true if the limiter is nil
+func (l *endorserLimiter) Allow(endorser string, now time.Time) bool {
+	if l == nil {
+		return true
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	limiter, ok := l.limiters.Get(endorser)
+	if !ok {
+		limiter = rate.NewLimiter(l.r, l.b)
+		l.limiters.Add(endorser, limiter)
+	}
+	return limiter.(*rate.Limiter).AllowN(now, 1)
+}
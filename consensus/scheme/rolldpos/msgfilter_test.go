@@ -0,0 +1,35 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndorserLimiter(t *testing.T) {
+	require := require.New(t)
+	now := time.Unix(1000, 0)
+	// disabled
+	var l *endorserLimiter
+	require.Nil(newEndorserLimiter(10, 0))
+	for i := 0; i < 100; i++ {
+		require.True(l.Allow("a", now))
+	}
+
+	l = newEndorserLimiter(10, 2)
+	require.True(l.Allow("a", now))
+	require.True(l.Allow("a", now))
+	require.False(l.Allow("a", now))
+	// the messages of another endorser are not throttled
+	require.True(l.Allow("b", now))
+	// the tokens are refilled by the time passed
+	require.False(l.Allow("a", now.Add(100*time.Millisecond)))
+	require.True(l.Allow("a", now.Add(500*time.Millisecond)))
+	require.False(l.Allow("a", now.Add(500*time.Millisecond)))
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+// _maxRecordSize is the maximum size of a recorded consensus message, which carries at most a block
+const _maxRecordSize = 64 << 20
+
+type (
+	// RecordedMessage is a consensus message in the message log, with the time when it was received
+	RecordedMessage struct {
+		Timestamp time.Time
+		Message   *iotextypes.ConsensusMessage
+	}
+
+	// messageRecorder appends the received consensus messages to the message log. Each record is the receipt time
+	// in unix nanoseconds and the size of the message, both in big endian, followed by the serialized message
+	messageRecorder struct {
+		path   string
+		mutex  sync.Mutex
+		file   *os.File
+		writer *bufio.Writer
+	}
+)
+
+func newMessageRecorder(path string) *messageRecorder {
+	return &messageRecorder{
+		path: path,
+	}
+}
+
+// Start opens the message log, the new messages are appended to the existing ones
+func (r *messageRecorder) Start(_ context.Context) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	file, err := os.OpenFile(r.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open consensus message log %s", r.path)
+	}
+	r.file = file
+	r.writer = bufio.NewWriter(file)
+	return nil
+}
+
+// Stop closes the message log
+func (r *messageRecorder) Stop(_ context.Context) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.file == nil {
+		return nil
+	}
+	err := r.writer.Flush()
+	if cerr := r.file.Close(); err == nil {
+		err = cerr
+	}
+	r.file, r.writer = nil, nil
+	return err
+}
+
+// Record appends the message received at the time to the message log
+func (r *messageRecorder) Record(ts time.Time, msg *iotextypes.ConsensusMessage) error {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	var header [12]byte
+	binary.BigEndian.PutUint64(header[:8], uint64(ts.UnixNano()))
+	binary.BigEndian.PutUint32(header[8:], uint32(len(data)))
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.writer == nil {
+		return errors.New("consensus message log is not open")
+	}
+	if _, err := r.writer.Write(header[:]); err != nil {
+		return err
+	}
+	if _, err := r.writer.Write(data); err != nil {
+		return err
+	}
+	// flush every record, so that the log is complete when the node gets stuck or crashes
+	return r.writer.Flush()
+}
+
+// ReadMessageLog reads the consensus messages in the message log, in the order of receipt. A truncated record at
+// the end of the log, which is left by a crash, is ignored
+func ReadMessageLog(path string) ([]*RecordedMessage, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open consensus message log %s", path)
+	}
+	defer file.Close()
+	return readMessages(bufio.NewReader(file))
+}
+
+func readMessages(reader io.Reader) ([]*RecordedMessage, error) {
+	var (
+		msgs   []*RecordedMessage
+		header [12]byte
+	)
+	for {
+		if _, err := io.ReadFull(reader, header[:]); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return msgs, nil
+			}
+			return nil, err
+		}
+		size := binary.BigEndian.Uint32(header[8:])
+		if size > _maxRecordSize {
+			return nil, errors.Errorf("invalid size %d of record %d", size, len(msgs))
+		}
+		data := make([]byte, size)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return msgs, nil
+			}
+			return nil, err
+		}
+		msg := &iotextypes.ConsensusMessage{}
+		if err := proto.Unmarshal(data, msg); err != nil {
+			return nil, errors.Wrapf(err, "failed to decode record %d", len(msgs))
+		}
+		msgs = append(msgs, &RecordedMessage{
+			Timestamp: time.Unix(0, int64(binary.BigEndian.Uint64(header[:8]))),
+			Message:   msg,
+		})
+	}
+}
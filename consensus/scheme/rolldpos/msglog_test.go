@@ -0,0 +1,69 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+	"github.com/iotexproject/iotex-core/v2/testutil"
+)
+
+func TestMessageRecorder(t *testing.T) {
+	require := require.New(t)
+	path, err := testutil.PathOfTempFile("consensus.log")
+	require.NoError(err)
+	defer testutil.CleanupPath(path)
+
+	var (
+		ctx  = context.Background()
+		ts   = time.Unix(1000, 0)
+		blk  = hash.Hash256b([]byte("block"))
+		msgs []*iotextypes.ConsensusMessage
+	)
+	for i := 0; i < 3; i++ {
+		msg, err := signedVote(t, identityset.PrivateKey(i), 10, blk[:], PROPOSAL, ts).Proto()
+		require.NoError(err)
+		msgs = append(msgs, msg)
+	}
+	recorder := newMessageRecorder(path)
+	require.Error(recorder.Record(ts, msgs[0]))
+	require.NoError(recorder.Start(ctx))
+	require.NoError(recorder.Record(ts, msgs[0]))
+	require.NoError(recorder.Record(ts.Add(time.Second), msgs[1]))
+	require.NoError(recorder.Stop(ctx))
+	// the messages are appended after restart
+	require.NoError(recorder.Start(ctx))
+	require.NoError(recorder.Record(ts.Add(2*time.Second), msgs[2]))
+	require.NoError(recorder.Stop(ctx))
+	require.NoError(recorder.Stop(ctx))
+
+	recorded, err := ReadMessageLog(path)
+	require.NoError(err)
+	require.Len(recorded, 3)
+	for i, r := range recorded {
+		require.True(ts.Add(time.Duration(i) * time.Second).Equal(r.Timestamp))
+		require.True(proto.Equal(msgs[i], r.Message))
+	}
+
+	// the truncated record is ignored
+	data, err := os.ReadFile(path)
+	require.NoError(err)
+	require.NoError(os.WriteFile(path, data[:len(data)-1], 0600))
+	recorded, err = ReadMessageLog(path)
+	require.NoError(err)
+	require.Len(recorded, 2)
+	_, err = ReadMessageLog(path + ".notexist")
+	require.Error(err)
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"context"
+	"time"
+
+	"github.com/facebookgo/clock"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/pkg/lifecycle"
+)
+
+// _replaySettleTime is the time to wait for the FSM to finish the event being handled, which is not counted in the
+// pending events
+const _replaySettleTime = 5 * time.Millisecond
+
+type (
+	// ReplayConsensus is the consensus which the recorded messages are replayed into
+	ReplayConsensus interface {
+		lifecycle.StartStopper
+		HandleConsensusMsg(*iotextypes.ConsensusMessage) error
+		NumPendingEvts() int
+	}
+
+	// Replayer feeds the recorded consensus messages back into the consensus, to reproduce the consensus stalls. The
+	// consensus runs on a mock clock, which is moved forward tick by tick, and the FSM handles all the pending events
+	// before the clock moves on, so that the messages and the timeouts are handled in the same order in every replay
+	Replayer struct {
+		consensus ReplayConsensus
+		clock     *clock.Mock
+		tick      time.Duration
+	}
+)
+
+// NewReplayer creates a replayer of the consensus built on the mock clock
+func NewReplayer(consensus ReplayConsensus, clk *clock.Mock, tick time.Duration) (*Replayer, error) {
+	if consensus == nil {
+		return nil, errors.New("consensus cannot be nil")
+	}
+	if clk == nil {
+		return nil, errors.New("clock cannot be nil")
+	}
+	if tick <= 0 {
+		return nil, errors.Errorf("invalid tick %s", tick)
+	}
+	return &Replayer{
+		consensus: consensus,
+		clock:     clk,
+		tick:      tick,
+	}, nil
+}
+
+// Replay starts the consensus at the receipt time of the first message, feeds the messages at the time they were
+// received, and keeps the consensus running until the tail after the last message. It returns the errors of handling
+// the messages in the order of the messages
+func (r *Replayer) Replay(ctx context.Context, msgs []*RecordedMessage, tail time.Duration) ([]error, error) {
+	if len(msgs) == 0 {
+		return nil, nil
+	}
+	if now := r.clock.Now(); now.Before(msgs[0].Timestamp) {
+		r.clock.Add(msgs[0].Timestamp.Sub(now))
+	}
+	if err := r.consensus.Start(ctx); err != nil {
+		return nil, errors.Wrap(err, "failed to start consensus")
+	}
+	errs := make([]error, len(msgs))
+	err := r.feed(ctx, msgs, tail, errs)
+	if stopErr := r.consensus.Stop(context.Background()); stopErr != nil && err == nil {
+		err = errors.Wrap(stopErr, "failed to stop consensus")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return errs, nil
+}
+
+func (r *Replayer) feed(ctx context.Context, msgs []*RecordedMessage, tail time.Duration, errs []error) error {
+	for i, msg := range msgs {
+		if err := r.advance(ctx, msg.Timestamp); err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		errs[i] = r.consensus.HandleConsensusMsg(msg.Message)
+		r.waitIdle()
+	}
+	return r.advance(ctx, msgs[len(msgs)-1].Timestamp.Add(tail))
+}
+
+// advance moves the clock forward to the time tick by tick
+func (r *Replayer) advance(ctx context.Context, to time.Time) error {
+	for now := r.clock.Now(); now.Before(to); now = r.clock.Now() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		d := to.Sub(now)
+		if d > r.tick {
+			d = r.tick
+		}
+		r.clock.Add(d)
+		r.waitIdle()
+	}
+	return nil
+}
+
+func (r *Replayer) waitIdle() {
+	for r.consensus.NumPendingEvts() > 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(_replaySettleTime)
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/facebookgo/clock"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+)
+
+type replayEvent struct {
+	name string
+	ts   time.Time
+}
+
+// fakeReplayConsensus schedules a timeout on start, and records the events in the order they are handled
+type fakeReplayConsensus struct {
+	clock  *clock.Mock
+	events []replayEvent
+}
+
+func (c *fakeReplayConsensus) Start(context.Context) error {
+	c.events = append(c.events, replayEvent{"start", c.clock.Now()})
+	c.clock.AfterFunc(1500*time.Millisecond, func() {
+		c.events = append(c.events, replayEvent{"timeout", c.clock.Now()})
+	})
+	return nil
+}
+
+func (c *fakeReplayConsensus) Stop(context.Context) error {
+	c.events = append(c.events, replayEvent{"stop", c.clock.Now()})
+	return nil
+}
+
+func (c *fakeReplayConsensus) HandleConsensusMsg(msg *iotextypes.ConsensusMessage) error {
+	c.events = append(c.events, replayEvent{"msg", c.clock.Now()})
+	if msg.Height == 0 {
+		return errors.New("invalid height")
+	}
+	return nil
+}
+
+func (c *fakeReplayConsensus) NumPendingEvts() int { return 0 }
+
+func TestReplayer(t *testing.T) {
+	require := require.New(t)
+	clk := clock.NewMock()
+	cs := &fakeReplayConsensus{clock: clk}
+	_, err := NewReplayer(cs, clk, 0)
+	require.Error(err)
+	replayer, err := NewReplayer(cs, clk, time.Second)
+	require.NoError(err)
+
+	ts := time.Unix(1000, 0)
+	msgs := []*RecordedMessage{
+		{Timestamp: ts, Message: &iotextypes.ConsensusMessage{Height: 1}},
+		{Timestamp: ts.Add(time.Second), Message: &iotextypes.ConsensusMessage{}},
+		{Timestamp: ts.Add(3 * time.Second), Message: &iotextypes.ConsensusMessage{Height: 1}},
+	}
+	errs, err := replayer.Replay(context.Background(), msgs, 2*time.Second)
+	require.NoError(err)
+	require.Len(errs, 3)
+	require.NoError(errs[0])
+	require.Error(errs[1])
+	require.NoError(errs[2])
+	require.Equal([]replayEvent{
+		{"start", ts},
+		{"msg", ts},
+		{"msg", ts.Add(time.Second)},
+		{"timeout", ts.Add(1500 * time.Millisecond)},
+		{"msg", ts.Add(3 * time.Second)},
+		{"stop", ts.Add(5 * time.Second)},
+	}, cs.events)
+
+	// canceled replay stops the consensus
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	cs.events = nil
+	_, err = replayer.Replay(ctx, []*RecordedMessage{{Timestamp: ts.Add(10 * time.Second)}}, 0)
+	require.ErrorIs(err, context.Canceled)
+	require.Equal("stop", cs.events[len(cs.events)-1].name)
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/v2/consensus/consensusfsm"
 	"github.com/iotexproject/iotex-core/v2/consensus/scheme"
+	"github.com/iotexproject/iotex-core/v2/crypto/bls"
 	"github.com/iotexproject/iotex-core/v2/db"
 	"github.com/iotexproject/iotex-core/v2/endorsement"
 	"github.com/iotexproject/iotex-core/v2/pkg/log"
@@ -44,6 +45,24 @@ type (
 		ToleratedOvertime time.Duration                `yaml:"toleratedOvertime"`
 		Delay             time.Duration                `yaml:"delay"`
 		ConsensusDBPath   string                       `yaml:"consensusDBPath"`
+		// MessageLogPath is the path of the log recording the received consensus messages, which can be replayed to
+		// reproduce the consensus stalls, the messages are not recorded if it is empty
+		MessageLogPath string `yaml:"messageLogPath"`
+		// BLSPrivateKeys are the comma separated BLS private keys of the operators, whose public keys are registered
+		// on chain next to the operator keys, to sign the commit endorsements which are aggregated in the block footer
+		BLSPrivateKeys string `yaml:"blsPrivateKeys"`
+		// EndorserRateLimit is the number of consensus messages per second accepted from an endorser, the messages
+		// exceeding the limit are dropped before handled by the FSM. It is disabled if it is 0
+		EndorserRateLimit uint `yaml:"endorserRateLimit"`
+		// PushProposers is the number of the next heights, whose proposers the block producer sends the block
+		// committed to directly in addition to the broadcast. It is disabled if it is 0
+		PushProposers uint64 `yaml:"pushProposers"`
+		// AttestationCacheSize is the number of the latest blocks whose verified footers are cached, so the footer of
+		// a block is not verified again when the block is received from the other peers. It is disabled if it is 0
+		AttestationCacheSize int `yaml:"attestationCacheSize"`
+		// PipelinedProposal makes the next proposer start minting the next block on the proposed block while the
+		// endorsements of it are being collected, the draft is discarded if another block is committed
+		PipelinedProposal bool `yaml:"pipelinedProposal"`
 	}
 )
 
@@ -61,18 +80,31 @@ var DefaultConfig = Config{
 	ToleratedOvertime: 2 * time.Second,
 	Delay:             5 * time.Second,
 	ConsensusDBPath:   "/var/data/consensus.db",
+	EndorserRateLimit: 50,
+	PushProposers:     2,
+	// the blocks of a couple of epochs
+	AttestationCacheSize: 1024,
+	PipelinedProposal:    true,
 }
 
 // RollDPoS is Roll-DPoS consensus main entrance
 type RollDPoS struct {
 	cfsm       *consensusfsm.ConsensusFSM
 	ctx        RDPoSCtx
+	recorder   *messageRecorder
+	limiter    *endorserLimiter
+	attests    *attestationCache
 	startDelay time.Duration
 	ready      chan interface{}
 }
 
 // Start starts RollDPoS consensus
 func (r *RollDPoS) Start(ctx context.Context) error {
+	if r.recorder != nil {
+		if err := r.recorder.Start(ctx); err != nil {
+			return err
+		}
+	}
 	if err := r.ctx.Start(ctx); err != nil {
 		return errors.Wrap(err, "error when starting the roll dpos context")
 	}
@@ -91,6 +123,11 @@ func (r *RollDPoS) Stop(ctx context.Context) error {
 	if err := r.cfsm.Stop(ctx); err != nil {
 		return errors.Wrap(err, "error when stopping the consensus FSM")
 	}
+	if r.recorder != nil {
+		if err := r.recorder.Stop(ctx); err != nil {
+			log.Logger("consensus").Error("failed to close consensus message log", zap.Error(err))
+		}
+	}
 	return errors.Wrap(r.ctx.Stop(ctx), "error when stopping the roll dpos context")
 }
 
@@ -101,6 +138,11 @@ func (r *RollDPoS) HandleConsensusMsg(msg *iotextypes.ConsensusMessage) error {
 		return nil
 	}
 	<-r.ready
+	if r.recorder != nil {
+		if err := r.recorder.Record(r.ctx.Clock().Now(), msg); err != nil {
+			log.Logger("consensus").Error("failed to record consensus message", zap.Error(err))
+		}
+	}
 	consensusHeight := r.ctx.Height()
 	switch {
 	case consensusHeight == 0:
@@ -123,17 +165,29 @@ func (r *RollDPoS) HandleConsensusMsg(msg *iotextypes.ConsensusMessage) error {
 	}
 	endorsedMessage := &EndorsedConsensusMessage{}
 	if err := endorsedMessage.LoadProto(msg, r.ctx.BlockDeserializer()); err != nil {
+		_droppedMsgMtc.WithLabelValues(_dropDecodeFailure).Inc()
 		return errors.Wrapf(err, "failed to decode endorsed consensus message")
 	}
 	if !endorsement.VerifyEndorsedDocument(endorsedMessage) {
+		_droppedMsgMtc.WithLabelValues(_dropInvalidSignature).Inc()
 		return errors.New("failed to verify signature in endorsement")
 	}
 	en := endorsedMessage.Endorsement()
+	if !r.limiter.Allow(en.Endorser().HexString(), r.ctx.Clock().Now()) {
+		_droppedMsgMtc.WithLabelValues(_dropRateLimit).Inc()
+		log.Logger("consensus").Debug(
+			"consensus message dropped due to endorser rate limit",
+			zap.String("endorser", en.Endorser().HexString()),
+		)
+		return nil
+	}
 	switch consensusMessage := endorsedMessage.Document().(type) {
 	case *blockProposal:
 		if err := r.ctx.CheckBlockProposer(endorsedMessage.Height(), consensusMessage, en); err != nil {
+			_droppedMsgMtc.WithLabelValues(_dropInvalidEndorser).Inc()
 			return errors.Wrap(err, "failed to verify block proposal")
 		}
+		r.checkDoubleSign(endorsedMessage)
 		r.cfsm.ProduceReceiveBlockEvent(endorsedMessage)
 		return nil
 	case *ConsensusVote:
@@ -145,8 +199,10 @@ func (r *RollDPoS) HandleConsensusMsg(msg *iotextypes.ConsensusMessage) error {
 				log.L().Debug("failed to verify vote", zap.Error(err))
 				return nil
 			}
+			_droppedMsgMtc.WithLabelValues(_dropInvalidEndorser).Inc()
 			return errors.Wrapf(err, "failed to verify vote")
 		}
+		r.checkDoubleSign(endorsedMessage)
 		switch consensusMessage.Topic() {
 		case PROPOSAL:
 			r.cfsm.ProduceReceiveProposalEndorsementEvent(endorsedMessage)
@@ -162,13 +218,36 @@ func (r *RollDPoS) HandleConsensusMsg(msg *iotextypes.ConsensusMessage) error {
 	}
 }
 
+// checkDoubleSign records the verified message to detect double signing, the message is handled anyway
+func (r *RollDPoS) checkDoubleSign(msg *EndorsedConsensusMessage) {
+	if _, err := r.ctx.CheckDoubleSign(msg); err != nil {
+		log.Logger("consensus").Error("failed to check double signing", zap.Error(err))
+	}
+}
+
+// Evidences returns the evidences of double signing detected
+func (r *RollDPoS) Evidences() []*Evidence {
+	return r.ctx.Evidences()
+}
+
 // Calibrate called on receive a new block not via consensus
 func (r *RollDPoS) Calibrate(height uint64) {
 	r.cfsm.Calibrate(height)
 }
 
-// ValidateBlockFooter validates the signatures in the block footer
+// ValidateBlockFooter validates the signatures in the block footer, the footers verified already are skipped
 func (r *RollDPoS) ValidateBlockFooter(blk *block.Block) error {
+	if r.attests.Verified(blk) {
+		return nil
+	}
+	if err := r.validateBlockFooter(blk); err != nil {
+		return err
+	}
+	r.attests.Add(blk)
+	return nil
+}
+
+func (r *RollDPoS) validateBlockFooter(blk *block.Block) error {
 	height := blk.Height()
 	roundCalc := r.ctx.RoundCalculator().Fork(r.ctx.Chain())
 	round, err := roundCalc.NewRound(height, r.ctx.BlockInterval(height), blk.Timestamp(), nil)
@@ -186,7 +265,11 @@ func (r *RollDPoS) ValidateBlockFooter(blk *block.Block) error {
 	}
 	blkHash := blk.HashBlock()
 	for _, en := range blk.Endorsements() {
-		if err := round.AddVoteEndorsement(
+		add := round.AddVoteEndorsement
+		if _, ok := en.Endorser().(*bls.AggregatePublicKey); ok {
+			add = round.AddAggregateVoteEndorsement
+		}
+		if err := add(
 			NewConsensusVote(blkHash[:], COMMIT),
 			en,
 		); err != nil {
@@ -217,6 +300,28 @@ func (r *RollDPoS) Metrics() (scheme.ConsensusMetrics, error) {
 	}, nil
 }
 
+// Status returns the status of the consensus rounds
+func (r *RollDPoS) Status() (scheme.ConsensusStatus, error) {
+	status := r.ctx.Status()
+	status.State = string(r.cfsm.CurrentState())
+	return status, nil
+}
+
+// Timeouts returns the timeouts of the current consensus round
+func (r *RollDPoS) Timeouts() (consensusfsm.Timeouts, error) {
+	return r.ctx.Timeouts(r.ctx.Height()), nil
+}
+
+// SetTimeouts adjusts the timeouts of the consensus rounds at runtime, which take effect from the next round, and
+// the configured timeouts are restored if t is nil
+func (r *RollDPoS) SetTimeouts(t *consensusfsm.Timeouts) error {
+	if err := r.ctx.SetTimeouts(r.ctx.Height(), t); err != nil {
+		return err
+	}
+	log.Logger("consensus").Info("consensus timeouts are adjusted", zap.Any("timeouts", t))
+	return nil
+}
+
 // NumPendingEvts returns the number of pending events
 func (r *RollDPoS) NumPendingEvts() int {
 	return r.cfsm.NumPendingEvents()
@@ -269,6 +374,10 @@ type (
 		rp                   *rolldpos.Protocol
 		delegatesByEpochFunc NodesSelectionByEpochFunc
 		proposersByEpochFunc NodesSelectionByEpochFunc
+		evidenceHandler      EvidenceHandler
+		aliasesByEpochFunc   OperatorAliasesByEpochFunc
+		blsEndorsersFunc     BLSEndorsersByEpochFunc
+		blockPusher          BlockPusher
 	}
 )
 
@@ -329,6 +438,30 @@ func (b *Builder) SetProposersByEpochFunc(
 	return b
 }
 
+// SetEvidenceHandler sets the handler of the evidences of double signing
+func (b *Builder) SetEvidenceHandler(handler EvidenceHandler) *Builder {
+	b.evidenceHandler = handler
+	return b
+}
+
+// SetOperatorAliasesByEpochFunc sets the function to honor both operators of the delegates rotating the operators
+func (b *Builder) SetOperatorAliasesByEpochFunc(fn OperatorAliasesByEpochFunc) *Builder {
+	b.aliasesByEpochFunc = fn
+	return b
+}
+
+// SetBLSEndorsersByEpochFunc sets the function to honor the BLS keys registered by the delegates on chain
+func (b *Builder) SetBLSEndorsersByEpochFunc(fn BLSEndorsersByEpochFunc) *Builder {
+	b.blsEndorsersFunc = fn
+	return b
+}
+
+// SetBlockPusher sets the pusher sending the blocks produced to the next proposers directly
+func (b *Builder) SetBlockPusher(pusher BlockPusher) *Builder {
+	b.blockPusher = pusher
+	return b
+}
+
 // RegisterProtocol sets the rolldpos protocol
 func (b *Builder) RegisterProtocol(rp *rolldpos.Protocol) *Builder {
 	b.rp = rp
@@ -343,6 +476,9 @@ func (b *Builder) Build() (*RollDPoS, error) {
 	if b.broadcastHandler == nil {
 		return nil, errors.Wrap(ErrNewRollDPoS, "broadcast callback is nil")
 	}
+	if b.cfg.Genesis.StandbyProposers > 0 && b.cfg.Genesis.StandbyDelay <= 0 {
+		return nil, errors.Wrap(ErrNewRollDPoS, "standby delay should be positive")
+	}
 	if b.clock == nil {
 		b.clock = clock.New()
 	}
@@ -366,13 +502,42 @@ func (b *Builder) Build() (*RollDPoS, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "error when constructing consensus context")
 	}
+	if b.evidenceHandler != nil {
+		ctx.SetEvidenceHandler(b.evidenceHandler)
+	}
+	if b.aliasesByEpochFunc != nil {
+		ctx.SetOperatorAliasesByEpochFunc(b.aliasesByEpochFunc)
+	}
+	if b.cfg.Genesis.StandbyProposers > 0 {
+		ctx.SetStandbyProposers(b.cfg.Genesis.Blockchain)
+	}
+	if b.blockPusher != nil && b.cfg.Consensus.PushProposers > 0 {
+		ctx.SetBlockPusher(b.cfg.Consensus.PushProposers, b.blockPusher)
+	}
+	ctx.SetPipelinedProposal(b.cfg.Consensus.PipelinedProposal)
+	attests := newAttestationCache(b.cfg.Consensus.AttestationCacheSize)
+	ctx.SetAttestationCache(attests)
+	blsKeys, err := blsEndorserKeys(b.cfg.Consensus.BLSPrivateKeys)
+	if err != nil {
+		return nil, errors.Wrap(err, "error when loading the BLS private keys")
+	}
+	if b.blsEndorsersFunc != nil {
+		ctx.SetBLSEndorsers(b.blsEndorsersFunc, blsKeys)
+	}
 	cfsm, err := consensusfsm.NewConsensusFSM(ctx, b.clock)
 	if err != nil {
 		return nil, errors.Wrap(err, "error when constructing the consensus FSM")
 	}
+	var recorder *messageRecorder
+	if len(b.cfg.Consensus.MessageLogPath) > 0 {
+		recorder = newMessageRecorder(b.cfg.Consensus.MessageLogPath)
+	}
 	return &RollDPoS{
 		cfsm:       cfsm,
 		ctx:        ctx,
+		recorder:   recorder,
+		limiter:    newEndorserLimiter(1000, b.cfg.Consensus.EndorserRateLimit),
+		attests:    attests,
 		startDelay: b.cfg.Consensus.Delay,
 		ready:      make(chan interface{}),
 	}, nil
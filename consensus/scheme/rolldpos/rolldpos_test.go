@@ -38,6 +38,7 @@ import (
 	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/v2/consensus/consensusfsm"
 	cp "github.com/iotexproject/iotex-core/v2/crypto"
+	"github.com/iotexproject/iotex-core/v2/crypto/bls"
 	"github.com/iotexproject/iotex-core/v2/db"
 	"github.com/iotexproject/iotex-core/v2/endorsement"
 	"github.com/iotexproject/iotex-core/v2/p2p/node"
@@ -265,6 +266,8 @@ func TestValidateBlockFooter(t *testing.T) {
 	blk := makeBlock(t, 1, 4, false, 9)
 	err = r.ValidateBlockFooter(blk)
 	require.NoError(t, err)
+	require.True(t, r.attests.Verified(blk))
+	require.NoError(t, r.ValidateBlockFooter(blk))
 
 	// Proposer is wrong
 	blk = makeBlock(t, 4, 4, false, 9)
@@ -287,6 +290,118 @@ func TestValidateBlockFooter(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestValidateBlockFooterWithBLSEndorsers(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	candidates := make([]string, 4)
+	for i := 0; i < len(candidates); i++ {
+		candidates[i] = identityset.Address(i).String()
+	}
+	blsKeys := make([]crypto.PrivateKey, 3)
+	endorsers := make(map[string]string, len(blsKeys))
+	for i := range blsKeys {
+		sk, err := bls.GenerateKey()
+		require.NoError(err)
+		blsKeys[i] = sk
+		endorsers[sk.PublicKey().Address().String()] = candidates[i]
+	}
+	// the BLS keys are registered on chain, and honored from epoch 2
+	blsEndorsersByEpoch := func(epochNum uint64, _ []byte) (map[string]string, error) {
+		if epochNum < 2 {
+			return nil, nil
+		}
+		return endorsers, nil
+	}
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	bc.EXPECT().ChainID().Return(uint32(1)).AnyTimes()
+	bc.EXPECT().TipHeight().Return(uint64(8)).AnyTimes()
+	bc.EXPECT().TipHash().Return(hash.ZeroHash256).AnyTimes()
+	bc.EXPECT().AddSubscriber(gomock.Any()).Return(nil).AnyTimes()
+	bc.EXPECT().BlockFooterByHeight(uint64(8)).Return(&block.Footer{}, nil).AnyTimes()
+	bc.EXPECT().BlockHeaderByHeight(uint64(8)).Return(&block.Header{}, nil).AnyTimes()
+	sf := mock_factory.NewMockFactory(ctrl)
+	sf.EXPECT().StateReaderAt(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	delegatesByEpoch := func(uint64, []byte) ([]string, error) {
+		return candidates, nil
+	}
+	g := genesis.TestDefault()
+	g.NumDelegates = 4
+	g.NumSubEpochs = 1
+	g.BlockInterval = 10 * time.Second
+	g.Timestamp = int64(1500000000)
+	bc.EXPECT().Genesis().Return(g).AnyTimes()
+	newRollDPoS := func(blsEndorsersByEpoch BLSEndorsersByEpochFunc, blsPrivateKeys string) (*RollDPoS, error) {
+		builderCfg := BuilderConfig{
+			Chain:              blockchain.DefaultConfig,
+			Consensus:          DefaultConfig,
+			DardanellesUpgrade: consensusfsm.DefaultDardanellesUpgradeConfig,
+			DB:                 db.DefaultConfig,
+			Genesis:            g,
+			SystemActive:       true,
+			WakeUpgrade:        consensusfsm.DefaultWakeUpgradeConfig,
+		}
+		builderCfg.Consensus.ConsensusDBPath = ""
+		builderCfg.Consensus.BLSPrivateKeys = blsPrivateKeys
+		return NewRollDPoSBuilder().
+			SetConfig(builderCfg).
+			SetPriKey(identityset.PrivateKey(1)).
+			SetChainManager(NewChainManager(bc, sf, &dummyBlockBuildFactory{})).
+			SetBroadcast(func(_ proto.Message) error {
+				return nil
+			}).
+			SetDelegatesByEpochFunc(delegatesByEpoch).
+			SetProposersByEpochFunc(delegatesByEpoch).
+			SetBLSEndorsersByEpochFunc(blsEndorsersByEpoch).
+			SetClock(clock.NewMock()).
+			RegisterProtocol(rolldpos.NewProtocol(g.NumCandidateDelegates, g.NumDelegates, g.NumSubEpochs)).
+			Build()
+	}
+	withFooter := func(blk *block.Block, ens []*endorsement.Endorsement) *block.Block {
+		footer := iotextypes.BlockFooter{Timestamp: timestamppb.New(time.Unix(1500000000, 0))}
+		for _, en := range ens {
+			footer.Endorsements = append(footer.Endorsements, en.Proto())
+		}
+		require.NoError(blk.Footer.ConvertFromBlockFooterPb(&footer))
+		return blk
+	}
+	blk := makeBlock(t, 1, 0, false, 9)
+	blkHash := blk.HashBlock()
+	ens, err := endorsement.Endorse(NewConsensusVote(blkHash[:], COMMIT), time.Unix(1500000000, 0), blsKeys...)
+	require.NoError(err)
+	aggregated, err := endorsement.Aggregate(ens)
+	require.NoError(err)
+	require.Len(aggregated, 1)
+
+	r, err := newRollDPoS(blsEndorsersByEpoch, blsKeys[1].HexString())
+	require.NoError(err)
+	require.NoError(r.ValidateBlockFooter(withFooter(blk, aggregated)))
+	require.NoError(r.ValidateBlockFooter(withFooter(blk, ens)))
+	// not enough endorsers in the aggregate endorsement
+	aggregated2, err := endorsement.Aggregate(ens[:2])
+	require.NoError(err)
+	require.ErrorIs(r.ValidateBlockFooter(withFooter(blk, aggregated2)), ErrInsufficientEndorsements)
+	// the aggregate endorsement of another vote
+	invalid, err := endorsement.Endorse(NewConsensusVote(blkHash[:], LOCK), time.Unix(1500000000, 0), blsKeys...)
+	require.NoError(err)
+	invalid, err = endorsement.Aggregate(invalid)
+	require.NoError(err)
+	require.Error(r.ValidateBlockFooter(withFooter(blk, invalid)))
+
+	// the BLS keys are not honored before the epoch they are registered for
+	r, err = newRollDPoS(func(epochNum uint64, _ []byte) (map[string]string, error) {
+		if epochNum < 10 {
+			return nil, nil
+		}
+		return endorsers, nil
+	}, "")
+	require.NoError(err)
+	require.Error(r.ValidateBlockFooter(withFooter(blk, aggregated)))
+
+	// invalid BLS private key
+	_, err = newRollDPoS(blsEndorsersByEpoch, "invalid")
+	require.Error(err)
+}
+
 func TestRollDPoS_Metrics(t *testing.T) {
 	t.Parallel()
 
@@ -310,8 +425,11 @@ func TestRollDPoS_Metrics(t *testing.T) {
 	bc.EXPECT().AddSubscriber(gomock.Any()).Return(nil).AnyTimes()
 
 	sk1 := identityset.PrivateKey(1)
+	testDBPath, err := testutil.PathOfTempFile("consensus.db")
+	require.NoError(t, err)
+	defer testutil.CleanupPath(testDBPath)
 	cfg := DefaultConfig
-	cfg.ConsensusDBPath = "consensus.db"
+	cfg.ConsensusDBPath = testDBPath
 	g := genesis.TestDefault()
 	g.NumDelegates = 4
 	g.NumSubEpochs = 1
@@ -6,6 +6,7 @@
 package rolldpos
 
 import (
+	"bytes"
 	"context"
 	"sync"
 	"time"
@@ -14,6 +15,7 @@ import (
 	fsm "github.com/iotexproject/go-fsm"
 	"github.com/iotexproject/go-pkgs/crypto"
 	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
@@ -23,6 +25,7 @@ import (
 	"github.com/iotexproject/iotex-core/v2/action/protocol/rolldpos"
 	"github.com/iotexproject/iotex-core/v2/blockchain"
 	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/v2/consensus/consensusfsm"
 	"github.com/iotexproject/iotex-core/v2/consensus/scheme"
 	"github.com/iotexproject/iotex-core/v2/db"
@@ -75,6 +78,23 @@ type (
 	// NodesSelectionByEpochFunc defines a function to select nodes
 	NodesSelectionByEpochFunc func(uint64, []byte) ([]string, error)
 
+	// OperatorAliasesByEpochFunc defines a function to map the old and the new operators of the delegates rotating
+	// the operators to each other
+	OperatorAliasesByEpochFunc func(uint64, []byte) (map[string]string, error)
+
+	// BLSEndorsersByEpochFunc defines a function to map the addresses of the BLS keys registered by the delegates to
+	// the operators
+	BLSEndorsersByEpochFunc func(uint64, []byte) (map[string]string, error)
+
+	// BlockPusher sends the block directly to the proposers of the next heights
+	BlockPusher func(*iotextypes.Block, []string)
+
+	// healthChecker is implemented by the private keys held in the hardware, e.g., HSM, which can't sign while the
+	// hardware is unavailable
+	healthChecker interface {
+		Healthy() error
+	}
+
 	// RDPoSCtx is the context of RollDPoS
 	RDPoSCtx interface {
 		consensusfsm.Context
@@ -84,6 +104,16 @@ type (
 		Clock() clock.Clock
 		CheckBlockProposer(uint64, *blockProposal, *endorsement.Endorsement) error
 		CheckVoteEndorser(uint64, *ConsensusVote, *endorsement.Endorsement) error
+		CheckDoubleSign(*EndorsedConsensusMessage) (*Evidence, error)
+		SetEvidenceHandler(EvidenceHandler)
+		SetOperatorAliasesByEpochFunc(OperatorAliasesByEpochFunc)
+		SetStandbyProposers(genesis.Blockchain)
+		SetBLSEndorsers(BLSEndorsersByEpochFunc, []crypto.PrivateKey)
+		SetBlockPusher(uint64, BlockPusher)
+		SetPipelinedProposal(bool)
+		SetAttestationCache(*attestationCache)
+		Evidences() []*Evidence
+		Status() scheme.ConsensusStatus
 	}
 
 	rollDPoSCtx struct {
@@ -95,7 +125,16 @@ type (
 		broadcastHandler  scheme.Broadcast
 		roundCalc         *roundCalculator
 		eManagerDB        db.KVStore
+		evidences         *evidencePool
+		rounds            *roundStore
+		tracker           *roundTracker
 		toleratedOvertime time.Duration
+		standbyDelay      time.Duration
+		blsKeys           []crypto.PrivateKey
+		blockPusher       BlockPusher
+		pushProposers     uint64
+		attests           *attestationCache
+		pipelined         bool
 
 		encodedAddrs []string
 		priKeys      []crypto.PrivateKey
@@ -175,7 +214,11 @@ func NewRollDPoSCtx(
 		clock:             clock,
 		roundCalc:         roundCalc,
 		eManagerDB:        eManagerDB,
+		evidences:         newEvidencePool(eManagerDB, broadcastHandler),
+		rounds:            newRoundStore(eManagerDB),
+		tracker:           newRoundTracker(),
 		toleratedOvertime: toleratedOvertime,
+		pipelined:         true,
 	}, nil
 }
 
@@ -183,7 +226,10 @@ func (ctx *rollDPoSCtx) Start(c context.Context) (err error) {
 	if err := ctx.chain.Start(c); err != nil {
 		return errors.Wrap(err, "Error when starting the chain")
 	}
-	var eManager *endorsementManager
+	var (
+		eManager *endorsementManager
+		state    *roundState
+	)
 	if ctx.eManagerDB != nil {
 		if err := ctx.eManagerDB.Start(c); err != nil {
 			return errors.Wrap(err, "Error when starting the collectionDB")
@@ -192,10 +238,20 @@ func (ctx *rollDPoSCtx) Start(c context.Context) (err error) {
 		if err != nil {
 			return errors.Wrap(err, "Error when creating the endorsement manager")
 		}
+		if err := ctx.evidences.Load(ctx.blockDeserializer); err != nil {
+			return errors.Wrap(err, "Error when loading the evidences")
+		}
+		if state, err = ctx.rounds.Load(); err != nil {
+			return errors.Wrap(err, "Error when loading the round state")
+		}
 	}
 	ctx.round, err = ctx.roundCalc.NewRoundWithToleration(0, ctx.BlockInterval(0), ctx.clock.Now(), eManager, ctx.toleratedOvertime)
+	if err != nil {
+		return err
+	}
+	ctx.recoverRound(state)
 
-	return err
+	return nil
 }
 
 func (ctx *rollDPoSCtx) Stop(c context.Context) error {
@@ -270,15 +326,17 @@ func (ctx *rollDPoSCtx) CheckBlockProposer(
 		return errors.Wrapf(err, "failed to get fork at block %d, hash %x", proposal.block.Height(), prevHash[:])
 	}
 	roundCalc := ctx.roundCalc.Fork(fork)
-	if proposer := roundCalc.Proposer(height, ctx.BlockInterval(height), en.Timestamp()); proposer != endorserAddr.String() {
+	if !roundCalc.IsProposer(endorserAddr.String(), height, ctx.BlockInterval(height), en.Timestamp()) &&
+		roundCalc.StandbyRank(endorserAddr.String(), height, ctx.BlockInterval(height), en.Timestamp()) == 0 {
 		return errors.Errorf(
 			"%s is not proposer of the corresponding round, %s expected",
 			endorserAddr.String(),
-			proposer,
+			roundCalc.Proposer(height, ctx.BlockInterval(height), en.Timestamp()),
 		)
 	}
 	proposerAddr := proposal.ProposerAddress()
-	if roundCalc.Proposer(height, ctx.BlockInterval(height), proposal.block.Timestamp()) != proposerAddr {
+	if !roundCalc.IsProposer(proposerAddr, height, ctx.BlockInterval(height), proposal.block.Timestamp()) &&
+		roundCalc.StandbyRank(proposerAddr, height, ctx.BlockInterval(height), proposal.block.Timestamp()) == 0 {
 		return errors.Errorf("%s is not proposer of the corresponding round", proposerAddr)
 	}
 	if !proposal.block.VerifySignature() {
@@ -314,6 +372,77 @@ func (ctx *rollDPoSCtx) CheckBlockProposer(
 	return nil
 }
 
+// CheckDoubleSign checks if the verified message conflicts with the one signed before by the same delegate,
+// and returns the evidence of double signing
+func (ctx *rollDPoSCtx) CheckDoubleSign(msg *EndorsedConsensusMessage) (*Evidence, error) {
+	return ctx.evidences.Add(msg)
+}
+
+// SetEvidenceHandler sets the handler of the new evidences of double signing
+func (ctx *rollDPoSCtx) SetEvidenceHandler(handler EvidenceHandler) {
+	ctx.evidences.SetHandler(handler)
+}
+
+// SetOperatorAliasesByEpochFunc sets the function to honor both operators of the delegates rotating the operators
+func (ctx *rollDPoSCtx) SetOperatorAliasesByEpochFunc(fn OperatorAliasesByEpochFunc) {
+	ctx.mutex.Lock()
+	defer ctx.mutex.Unlock()
+	ctx.roundCalc.aliasesByEpochFunc = fn
+}
+
+// SetBLSEndorsers sets the function to get the BLS keys registered by the delegates on chain, and the BLS keys of the
+// operators on this node
+func (ctx *rollDPoSCtx) SetBLSEndorsers(fn BLSEndorsersByEpochFunc, keys []crypto.PrivateKey) {
+	ctx.mutex.Lock()
+	defer ctx.mutex.Unlock()
+	ctx.roundCalc.blsEndorsersByEpochFunc = fn
+	ctx.blsKeys = keys
+}
+
+// SetStandbyProposers sets the number of the standby proposers of a round, and the delay between their slots, in
+// genesis. The standby proposers propose in turn if no block is received after their delays since the start of the
+// round, at or after the height they are enabled
+func (ctx *rollDPoSCtx) SetStandbyProposers(g genesis.Blockchain) {
+	ctx.mutex.Lock()
+	defer ctx.mutex.Unlock()
+	ctx.roundCalc.standbyProposers = g.StandbyProposers
+	ctx.roundCalc.standbyEnabled = g.IsToBeEnabled
+	ctx.standbyDelay = g.StandbyDelay
+}
+
+// SetPipelinedProposal sets whether the next proposer mints the next block on the proposed block ahead of its round
+func (ctx *rollDPoSCtx) SetPipelinedProposal(enable bool) {
+	ctx.mutex.Lock()
+	defer ctx.mutex.Unlock()
+	ctx.pipelined = enable
+}
+
+// SetBlockPusher sets the pusher sending the block committed to the proposers of the next num heights directly, in
+// addition to the broadcast, if the block is produced by the current node
+func (ctx *rollDPoSCtx) SetBlockPusher(num uint64, pusher BlockPusher) {
+	ctx.mutex.Lock()
+	defer ctx.mutex.Unlock()
+	ctx.pushProposers = num
+	ctx.blockPusher = pusher
+}
+
+// SetAttestationCache sets the cache recording the footers of the blocks committed by consensus as verified
+func (ctx *rollDPoSCtx) SetAttestationCache(attests *attestationCache) {
+	ctx.mutex.Lock()
+	defer ctx.mutex.Unlock()
+	ctx.attests = attests
+}
+
+// Evidences returns the evidences of double signing
+func (ctx *rollDPoSCtx) Evidences() []*Evidence {
+	return ctx.evidences.Evidences()
+}
+
+// Status returns the status of the consensus rounds
+func (ctx *rollDPoSCtx) Status() scheme.ConsensusStatus {
+	return ctx.tracker.Status()
+}
+
 func (ctx *rollDPoSCtx) RoundCalc() *roundCalculator {
 	return ctx.roundCalc
 }
@@ -366,6 +495,12 @@ func (ctx *rollDPoSCtx) Prepare() error {
 		zap.String("roundStartTime", newRound.roundStartTime.String()),
 	)
 	ctx.round = newRound
+	ctx.evidences.Prune(newRound.height)
+	if err := ctx.rounds.Prune(newRound.height); err != nil {
+		ctx.logger().Warn("failed to prune the signed blocks", zap.Error(err))
+	}
+	ctx.saveRound()
+	ctx.tracker.NewRound(newRound, ctx.active && slices.ContainsFunc(ctx.encodedAddrs, newRound.IsDelegate))
 	_consensusHeightMtc.WithLabelValues().Set(float64(ctx.round.height))
 	_timeSlotMtc.WithLabelValues().Set(float64(ctx.round.roundNum))
 	return nil
@@ -392,21 +527,51 @@ func (ctx *rollDPoSCtx) Proposal() (interface{}, error) {
 				privateKey = ctx.priKeys[i]
 				break
 			}
+			// the other operator of the proposer during a rotation, if the elected one is not configured
+			if privateKey == nil && ctx.round.IsProposer(addr) {
+				privateKey = ctx.priKeys[i]
+			}
 		}
 	}
-	if privateKey == nil {
+	if privateKey == nil || !ctx.signerHealthy(privateKey) {
 		return nil, nil
 	}
-	if ctx.round.IsLocked() {
-		return ctx.endorseBlockProposal(newBlockProposal(
-			ctx.round.Block(ctx.round.HashOfBlockInLock()),
-			ctx.round.ProofOfLock(),
-		), privateKey)
+	return ctx.propose(privateKey)
+}
+
+// StandbyDelay returns the delay since the start of the round, after which the current node proposes as a standby
+// proposer if no block is received, and false if the current node is not a standby proposer of the round
+func (ctx *rollDPoSCtx) StandbyDelay() (time.Duration, bool) {
+	ctx.mutex.RLock()
+	defer ctx.mutex.RUnlock()
+	_, rank := ctx.standbyKey()
+	if rank == 0 {
+		return 0, false
 	}
-	return ctx.mintNewBlock(privateKey)
+	delay := time.Duration(rank) * ctx.standbyDelay
+	if delay >= ctx.AcceptBlockTTL(ctx.round.height) {
+		// the slot is beyond the time to accept block
+		return 0, false
+	}
+	return delay, true
+}
+
+// StandbyProposal returns the block proposal of the current node as a standby proposer
+func (ctx *rollDPoSCtx) StandbyProposal() (interface{}, error) {
+	ctx.mutex.RLock()
+	defer ctx.mutex.RUnlock()
+	privateKey, rank := ctx.standbyKey()
+	if rank == 0 || !ctx.signerHealthy(privateKey) {
+		return nil, nil
+	}
+	ctx.logger().Info("propose as standby proposer", zap.Int("rank", rank))
+	return ctx.propose(privateKey)
 }
 
 func (ctx *rollDPoSCtx) prepareNextProposal(prevHeight uint64, prevHash hash.Hash256) error {
+	if !ctx.pipelined {
+		return nil
+	}
 	var (
 		height    = prevHeight + 1
 		interval  = ctx.BlockInterval(height)
@@ -425,6 +590,9 @@ func (ctx *rollDPoSCtx) prepareNextProposal(prevHeight uint64, prevHash hash.Has
 		return nil
 	}
 	privateKey := ctx.priKeys[idx]
+	if !ctx.signerHealthy(privateKey) {
+		return nil
+	}
 	ctx.logger().Debug("prepare next proposal", log.Hex("prevHash", prevHash[:]), zap.Uint64("height", ctx.round.height+1), zap.Time("timestamp", startTime), zap.String("nextproposer", nextProposer))
 	go func() {
 		blk, err := fork.MintNewBlock(startTime, privateKey, prevHash)
@@ -443,12 +611,12 @@ func (ctx *rollDPoSCtx) WaitUntilRoundStart() time.Duration {
 	now := ctx.clock.Now()
 	startTime := ctx.round.StartTime()
 	if now.Before(startTime) {
-		time.Sleep(startTime.Sub(now))
+		ctx.clock.Sleep(startTime.Sub(now))
 		return 0
 	}
 	overTime := now.Sub(startTime)
 	if !ctx.hasDelegate() && ctx.toleratedOvertime > overTime {
-		time.Sleep(ctx.toleratedOvertime - overTime)
+		ctx.clock.Sleep(ctx.toleratedOvertime - overTime)
 		return 0
 	}
 	return overTime
@@ -478,6 +646,9 @@ func (ctx *rollDPoSCtx) NewProposalEndorsement(msg interface{}) (interface{}, er
 		if !ok {
 			return nil, errors.New("invalid endorsed block")
 		}
+		if err := ctx.checkStandbySlot(ecm.Endorsement()); err != nil {
+			return nil, err
+		}
 		blkHash := proposal.block.HashBlock()
 		blockHash = blkHash[:]
 		if err := ctx.chain.ValidateBlock(proposal.block); err != nil {
@@ -486,6 +657,7 @@ func (ctx *rollDPoSCtx) NewProposalEndorsement(msg interface{}) (interface{}, er
 		if err := ctx.round.AddBlock(proposal.block); err != nil {
 			return nil, err
 		}
+		ctx.tracker.ProposalReceived(ctx.clock.Now())
 		if err := ctx.prepareNextProposal(proposal.block.Height(), blkHash); err != nil {
 			ctx.loggerWithStats().Warn("failed to prepare next proposal", zap.Error(err), zap.Uint64("prevHeight", proposal.block.Height()))
 		}
@@ -520,6 +692,7 @@ func (ctx *rollDPoSCtx) NewLockEndorsement(
 		return nil, nil
 	case nil:
 		if len(blkHash) != 0 {
+			ctx.tracker.QuorumReached(PROPOSAL, ctx.clock.Now())
 			ctx.loggerWithStats().Debug("Locked", log.Hex("block", blkHash))
 			return ctx.newEndorsement(
 				blkHash,
@@ -547,6 +720,7 @@ func (ctx *rollDPoSCtx) NewPreCommitEndorsement(
 	case ErrInsufficientEndorsements:
 		return nil, nil
 	case nil:
+		ctx.tracker.QuorumReached(LOCK, ctx.clock.Now())
 		ctx.loggerWithStats().Debug("Ready to pre-commit")
 		return ctx.newEndorsement(
 			blkHash,
@@ -580,15 +754,23 @@ func (ctx *rollDPoSCtx) Commit(msg interface{}) (bool, error) {
 	if ctx.round.Height()%100 == 0 {
 		ctx.logger().Info("consensus reached", zap.Uint64("blockHeight", ctx.round.Height()))
 	}
+	// the commit endorsements signed with the BLS keys are aggregated to shrink the footer
+	endorsements, err := endorsement.Aggregate(ctx.round.Endorsements(blkHash, []ConsensusVoteTopic{COMMIT}))
+	if err != nil {
+		return false, errors.Wrap(err, "failed to aggregate endorsements")
+	}
 	if err := pendingBlock.Finalize(
-		ctx.round.Endorsements(blkHash, []ConsensusVoteTopic{COMMIT}),
+		endorsements,
 		ctx.round.StartTime().Add(
 			ctx.AcceptBlockTTL(ctx.round.height)+ctx.AcceptProposalEndorsementTTL(ctx.round.height)+ctx.AcceptLockEndorsementTTL(ctx.round.height),
 		),
 	); err != nil {
 		return false, errors.Wrap(err, "failed to add endorsements to block")
 	}
+	// the endorsements in the footer have been verified when received
+	ctx.attests.Add(pendingBlock)
 
+	ctx.tracker.QuorumReached(COMMIT, ctx.clock.Now())
 	// Commit and broadcast the pending block
 	switch err := ctx.chain.CommitBlock(pendingBlock); errors.Cause(err) {
 	case blockchain.ErrInvalidTipHeight:
@@ -602,6 +784,7 @@ func (ctx *rollDPoSCtx) Commit(msg interface{}) (bool, error) {
 		log.L().Error("error when committing the block", zap.Error(err))
 		return false, errors.Wrap(err, "error when committing a block")
 	}
+	ctx.tracker.Committed(pendingBlock.Height())
 	// Broadcast the committed block to the network
 	if blkProto := pendingBlock.ConvertToBlockPb(); blkProto != nil {
 		if err := ctx.broadcastHandler(blkProto); err != nil {
@@ -611,6 +794,7 @@ func (ctx *rollDPoSCtx) Commit(msg interface{}) (bool, error) {
 				zap.Uint64("block", pendingBlock.Height()),
 			)
 		}
+		ctx.pushBlock(pendingBlock, blkProto)
 		// putblock to parent chain if the current node is proposer and current chain is a sub chain
 		// TODO: explorer dependency deleted at #1085, need to call putblock related method
 	} else {
@@ -634,6 +818,22 @@ func (ctx *rollDPoSCtx) Commit(msg interface{}) (bool, error) {
 	return true, nil
 }
 
+// pushBlock sends the block produced by the current node to the next proposers directly, so that they receive the
+// block without waiting for the gossip, which eats into the proposal window of the next round
+func (ctx *rollDPoSCtx) pushBlock(blk *block.Block, blkProto *iotextypes.Block) {
+	if ctx.blockPusher == nil || ctx.pushProposers == 0 || !slices.Contains(ctx.encodedAddrs, blk.ProducerAddress()) {
+		return
+	}
+	proposers := ctx.roundCalc.NextProposers(blk.Height(), ctx.pushProposers)
+	proposers = slices.DeleteFunc(proposers, func(addr string) bool {
+		return slices.Contains(ctx.encodedAddrs, addr)
+	})
+	if len(proposers) == 0 {
+		return
+	}
+	go ctx.blockPusher(blkProto, proposers)
+}
+
 func (ctx *rollDPoSCtx) encodeAndBroadcast(ecm *EndorsedConsensusMessage) error {
 	msg, err := ecm.Proto()
 	if err != nil {
@@ -707,6 +907,67 @@ func (ctx *rollDPoSCtx) Active() bool {
 // private functions
 ///////////////////////////////////////////
 
+func (ctx *rollDPoSCtx) propose(privateKey crypto.PrivateKey) (*EndorsedConsensusMessage, error) {
+	if ctx.round.IsLocked() {
+		return ctx.endorseBlockProposal(newBlockProposal(
+			ctx.round.Block(ctx.round.HashOfBlockInLock()),
+			ctx.round.ProofOfLock(),
+		), privateKey)
+	}
+	return ctx.mintNewBlock(privateKey)
+}
+
+// signerHealthy checks the private key is available for signing, the minting is paused otherwise
+func (ctx *rollDPoSCtx) signerHealthy(privateKey crypto.PrivateKey) bool {
+	hc, ok := privateKey.(healthChecker)
+	if !ok {
+		return true
+	}
+	if err := hc.Healthy(); err != nil {
+		ctx.logger().Warn("minting is paused as the signer is unavailable", zap.Error(err))
+		return false
+	}
+	return true
+}
+
+// standbyKey returns the private key of the highest ranked standby proposer of the round on the current node
+func (ctx *rollDPoSCtx) standbyKey() (crypto.PrivateKey, int) {
+	var (
+		privateKey crypto.PrivateKey
+		rank       int
+	)
+	if !ctx.active || ctx.standbyDelay <= 0 {
+		return nil, 0
+	}
+	for i, addr := range ctx.encodedAddrs {
+		if r := ctx.round.StandbyRank(addr); r > 0 && (rank == 0 || r < rank) {
+			privateKey, rank = ctx.priKeys[i], r
+		}
+	}
+	return privateKey, rank
+}
+
+// checkStandbySlot checks that the block proposed by a standby proposer is received no earlier than its slot. Half
+// of the delay is tolerated for the clock drift between the nodes
+func (ctx *rollDPoSCtx) checkStandbySlot(en *endorsement.Endorsement) error {
+	addr := en.Endorser().Address()
+	if addr == nil {
+		return errors.New("failed to get address")
+	}
+	if ctx.round.IsProposer(addr.String()) {
+		return nil
+	}
+	rank := ctx.round.StandbyRank(addr.String())
+	if rank == 0 {
+		return nil
+	}
+	slot := ctx.round.StartTime().Add(time.Duration(rank)*ctx.standbyDelay - ctx.standbyDelay/2)
+	if ctx.standbyDelay <= 0 || ctx.clock.Now().Before(slot) {
+		return errors.Errorf("block proposed by standby proposer %s before its slot", addr.String())
+	}
+	return nil
+}
+
 func (ctx *rollDPoSCtx) mintNewBlock(privateKey crypto.PrivateKey) (*EndorsedConsensusMessage, error) {
 	var err error
 	blk := ctx.round.CachedMintedBlock()
@@ -744,8 +1005,12 @@ func (ctx *rollDPoSCtx) endorseBlockProposal(proposal *blockProposal, privateKey
 	if len(ens) != 1 {
 		return nil, errors.New("invalid number of endorsements")
 	}
+	msg := NewEndorsedConsensusMessage(ctx.round.Height(), proposal, ens[0])
+	if err := ctx.rounds.Sign(msg); err != nil {
+		return nil, err
+	}
 
-	return NewEndorsedConsensusMessage(ctx.round.Height(), proposal, ens[0]), nil
+	return msg, nil
 }
 
 func (ctx *rollDPoSCtx) logger() *zap.Logger {
@@ -818,9 +1083,13 @@ func (ctx *rollDPoSCtx) verifyVote(
 	}
 	blkHash := vote.BlockHash()
 	endorsement := consensusMsg.Endorsement()
+	status, blockInLock := ctx.round.status, ctx.round.blockInLock
 	if err := ctx.round.AddVoteEndorsement(vote, endorsement); err != nil {
 		return blkHash, err
 	}
+	if status != ctx.round.status || !bytes.Equal(blockInLock, ctx.round.blockInLock) {
+		ctx.saveRound()
+	}
 	ctx.loggerWithStats().Debug(
 		"verified consensus vote",
 		log.Hex("block", blkHash),
@@ -842,21 +1111,82 @@ func (ctx *rollDPoSCtx) newEndorsement(
 		blkHash,
 		topic,
 	)
-	privKeys := make([]crypto.PrivateKey, 0, len(ctx.priKeys))
+	// endorse once per delegate, with the elected operator if both operators of a rotation are configured
+	var (
+		privKeys  = make([]crypto.PrivateKey, 0, len(ctx.priKeys))
+		endorsers = make(map[string]int, len(ctx.priKeys))
+	)
 	for i, addr := range ctx.encodedAddrs {
-		if !ctx.round.IsDelegate(addr) {
+		delegate := ctx.round.delegateOf(addr)
+		if delegate == "" {
+			continue
+		}
+		if j, ok := endorsers[delegate]; ok {
+			if addr == delegate {
+				privKeys[j] = ctx.priKeys[i]
+			}
 			continue
 		}
+		endorsers[delegate] = len(privKeys)
 		privKeys = append(privKeys, ctx.priKeys[i])
 	}
+	if topic == COMMIT {
+		// endorse with the BLS key once it is honored, so that the commit endorsements are aggregated
+		for i, pk := range privKeys {
+			delegate := ctx.round.delegateOf(pk.PublicKey().Address().String())
+			for _, blsKey := range ctx.blsKeys {
+				if ctx.round.delegateOf(blsKey.PublicKey().Address().String()) == delegate {
+					privKeys[i] = blsKey
+					break
+				}
+			}
+		}
+	}
 	ens, err := endorsement.Endorse(vote, timestamp, privKeys...)
 	if err != nil {
 		return nil, err
 	}
 	msgs := make([]*EndorsedConsensusMessage, 0, len(ens))
 	for _, en := range ens {
-		msgs = append(msgs, NewEndorsedConsensusMessage(ctx.round.Height(), vote, en))
+		msg := NewEndorsedConsensusMessage(ctx.round.Height(), vote, en)
+		if err := ctx.rounds.Sign(msg); err != nil {
+			if errors.Cause(err) != ErrConflictingSignature {
+				return nil, err
+			}
+			ctx.logger().Error("refuse to sign conflicting endorsement", zap.String("endorser", en.Endorser().HexString()), zap.Error(err))
+			continue
+		}
+		msgs = append(msgs, msg)
 	}
 
 	return msgs, nil
 }
+
+// recoverRound resumes the round persisted before the restart, if the chain is still at the height of the round
+func (ctx *rollDPoSCtx) recoverRound(state *roundState) {
+	if state == nil || state.height != ctx.chain.TipHeight()+1 {
+		return
+	}
+	round, err := ctx.roundCalc.NewRoundWithToleration(state.height, ctx.BlockInterval(state.height), ctx.clock.Now(), ctx.round.eManager, ctx.toleratedOvertime)
+	if err != nil {
+		log.Logger("consensus").Warn("failed to recover the round", zap.Uint64("height", state.height), zap.Error(err))
+		return
+	}
+	if !round.restore(state) {
+		log.Logger("consensus").Warn("the block in lock is missing, recover the round unlocked", zap.Uint64("height", state.height))
+	}
+	ctx.round = round
+	ctx.logger().Info(
+		"recovered the round",
+		zap.Uint32("persistedRound", state.roundNum),
+		zap.Bool("locked", round.IsLocked()),
+		log.Hex("blockInLock", round.HashOfBlockInLock()),
+	)
+}
+
+// saveRound persists the snapshot of the round, the failure doesn't stop the consensus
+func (ctx *rollDPoSCtx) saveRound() {
+	if err := ctx.rounds.Save(ctx.round.snapshot()); err != nil {
+		ctx.logger().Warn("failed to persist the round", zap.Error(err))
+	}
+}
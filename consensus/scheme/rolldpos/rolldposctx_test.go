@@ -271,6 +271,66 @@ func TestCheckBlockProposer(t *testing.T) {
 	require.NoError(rctx.CheckBlockProposer(51, bp, en))
 }
 
+func TestStandbyProposer(t *testing.T) {
+	require := require.New(t)
+	g := genesis.TestDefault()
+	cfg := consensusfsm.NewConsensusConfig(DefaultConfig.FSM, consensusfsm.DefaultDardanellesUpgradeConfig, consensusfsm.DefaultWakeUpgradeConfig, g, DefaultConfig.Delay)
+	mockClock := clock.NewMock()
+	startTime := mockClock.Now()
+	rctx := &rollDPoSCtx{
+		ConsensusConfig: cfg,
+		active:          true,
+		encodedAddrs:    []string{identityset.Address(2).String(), identityset.Address(3).String()},
+		priKeys:         []crypto.PrivateKey{identityset.PrivateKey(2), identityset.PrivateKey(3)},
+		clock:           mockClock,
+		round: &roundCtx{
+			height:         10,
+			proposer:       identityset.Address(1).String(),
+			standbys:       []string{identityset.Address(3).String(), identityset.Address(2).String()},
+			roundStartTime: startTime,
+		},
+	}
+	// standby proposers are disabled
+	_, ok := rctx.StandbyDelay()
+	require.False(ok)
+
+	// the highest ranked standby proposer on the node proposes
+	rctx.standbyDelay = 200 * time.Millisecond
+	delay, ok := rctx.StandbyDelay()
+	require.True(ok)
+	require.Equal(200*time.Millisecond, delay)
+	key, rank := rctx.standbyKey()
+	require.Equal(1, rank)
+	require.Equal(identityset.PrivateKey(3), key)
+	rctx.standbyDelay = cfg.AcceptBlockTTL(10)
+	_, ok = rctx.StandbyDelay()
+	require.False(ok)
+
+	// the block of a standby proposer is accepted after its slot
+	rctx.standbyDelay = 200 * time.Millisecond
+	en := endorsement.NewEndorsement(startTime, identityset.PrivateKey(2).PublicKey(), nil)
+	require.Error(rctx.checkStandbySlot(en))
+	mockClock.Add(300 * time.Millisecond)
+	require.NoError(rctx.checkStandbySlot(en))
+	mockClock.Add(-300 * time.Millisecond)
+	en = endorsement.NewEndorsement(startTime, identityset.PrivateKey(1).PublicKey(), nil)
+	require.NoError(rctx.checkStandbySlot(en))
+
+	// the minting is paused if the signer is unavailable
+	rctx.priKeys[1] = &unhealthyKey{identityset.PrivateKey(3)}
+	proposal, err := rctx.StandbyProposal()
+	require.NoError(err)
+	require.Nil(proposal)
+}
+
+type unhealthyKey struct {
+	crypto.PrivateKey
+}
+
+func (k *unhealthyKey) Healthy() error {
+	return errors.New("token is not present")
+}
+
 func TestNotProducingMultipleBlocks(t *testing.T) {
 	require := require.New(t)
 	b, sf, _, rp, pp := makeChain(t)
@@ -352,6 +412,39 @@ func TestNotProducingMultipleBlocks(t *testing.T) {
 	require.Equal(height1, height2)
 }
 
+type forkRecorder struct {
+	ChainManager
+	forks []hash.Hash256
+}
+
+func (fr *forkRecorder) Fork(prevHash hash.Hash256) (ForkChain, error) {
+	fr.forks = append(fr.forks, prevHash)
+	return nil, errors.New("no fork")
+}
+
+func TestPipelinedProposal(t *testing.T) {
+	require := require.New(t)
+	chain := &forkRecorder{}
+	rctx := &rollDPoSCtx{
+		ConsensusConfig: consensusfsm.NewConsensusConfig(DefaultConfig.FSM, consensusfsm.DefaultDardanellesUpgradeConfig, consensusfsm.DefaultWakeUpgradeConfig, genesis.TestDefault(), DefaultConfig.Delay),
+		chain:           chain,
+		pipelined:       true,
+		round: &roundCtx{
+			height:         10,
+			roundStartTime: time.Now(),
+		},
+	}
+	prevHash := hash.Hash256b([]byte("proposal"))
+	// the next block is minted on the proposed block
+	require.ErrorContains(rctx.prepareNextProposal(10, prevHash), "failed to check fork at block 10")
+	require.Equal([]hash.Hash256{prevHash}, chain.forks)
+
+	// no draft is minted ahead of the round if the pipelining is disabled
+	rctx.SetPipelinedProposal(false)
+	require.NoError(rctx.prepareNextProposal(10, prevHash))
+	require.Len(chain.forks, 1)
+}
+
 func getBlockforctx(t *testing.T, i int, sign bool, prevHash hash.Hash256) block.Block {
 	require := require.New(t)
 	ts := &timestamppb.Timestamp{Seconds: 1596329600, Nanos: 10}
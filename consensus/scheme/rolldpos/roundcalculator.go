@@ -6,6 +6,7 @@
 package rolldpos
 
 import (
+	"slices"
 	"time"
 
 	"github.com/pkg/errors"
@@ -24,7 +25,14 @@ type roundCalculator struct {
 	rp                   *rolldpos.Protocol
 	delegatesByEpochFunc NodesSelectionByEpochFunc
 	proposersByEpochFunc NodesSelectionByEpochFunc
+	aliasesByEpochFunc   OperatorAliasesByEpochFunc
 	beringHeight         uint64
+	// standbyProposers is the number of the proposers following the proposer of the round, which propose in turn
+	// if the proposer misses its slot, at the heights standbyEnabled returns true
+	standbyProposers uint64
+	standbyEnabled   func(uint64) bool
+	// blsEndorsersByEpochFunc maps the addresses of the BLS keys registered by the delegates to the operators
+	blsEndorsersByEpochFunc BLSEndorsersByEpochFunc
 }
 
 // UpdateRound updates previous roundCtx
@@ -33,6 +41,7 @@ func (c *roundCalculator) UpdateRound(round *roundCtx, height uint64, blockInter
 	epochStartHeight := round.EpochStartHeight()
 	delegates := round.Delegates()
 	proposers := round.Proposers()
+	aliases := round.aliases
 	switch {
 	case height < round.Height():
 		return nil, errors.New("cannot update to a lower height")
@@ -52,6 +61,9 @@ func (c *roundCalculator) UpdateRound(round *roundCtx, height uint64, blockInter
 			if proposers, err = c.Proposers(height); err != nil {
 				return nil, err
 			}
+			if aliases, err = c.Aliases(height); err != nil {
+				return nil, err
+			}
 		}
 	}
 	roundNum, roundStartTime, err := c.roundInfo(height, blockInterval, now, toleratedOvertime)
@@ -87,11 +99,13 @@ func (c *roundCalculator) UpdateRound(round *roundCtx, height uint64, blockInter
 		delegates:            delegates,
 		numOfDelegates:       round.numOfDelegates,
 		proposers:            proposers,
+		aliases:              aliases,
 
 		height:             height,
 		roundNum:           roundNum,
 		prevHash:           prevHash,
 		proposer:           proposer,
+		standbys:           c.calculateStandbys(height, roundNum, proposers),
 		roundStartTime:     roundStartTime,
 		nextRoundStartTime: roundStartTime.Add(blockInterval),
 		eManager:           round.eManager,
@@ -112,14 +126,45 @@ func (c *roundCalculator) Proposer(height uint64, blockInterval time.Duration, r
 	return round.Proposer()
 }
 
+// IsProposer checks whether the address is the block producer of the round, or the other operator of it during a
+// rotation
+func (c *roundCalculator) IsProposer(addr string, height uint64, blockInterval time.Duration, roundStartTime time.Time) bool {
+	round, err := c.newRound(height, blockInterval, roundStartTime, nil, 0)
+	if err != nil {
+		log.L().Warn("Failed to get proposer", zap.Error(err))
+		return false
+	}
+
+	return round.IsProposer(addr)
+}
+
+// StandbyRank returns the rank of the address in the standby proposers of the round, see roundCtx.StandbyRank
+func (c *roundCalculator) StandbyRank(addr string, height uint64, blockInterval time.Duration, roundStartTime time.Time) int {
+	if !c.isStandbyEnabled(height) {
+		return 0
+	}
+	round, err := c.newRound(height, blockInterval, roundStartTime, nil, 0)
+	if err != nil {
+		log.L().Warn("Failed to get standby proposers", zap.Error(err))
+		return 0
+	}
+
+	return round.StandbyRank(addr)
+}
+
 func (c *roundCalculator) IsDelegate(addr string, height uint64) bool {
 	delegates, err := c.Delegates(height)
 	if err != nil {
 		log.L().Warn("Failed to get delegates", zap.Error(err))
 		return false
 	}
+	aliases, err := c.Aliases(height)
+	if err != nil {
+		log.L().Warn("Failed to get operator aliases", zap.Error(err))
+		return false
+	}
 	for _, d := range delegates {
-		if addr == d {
+		if addr == d || aliases[addr] == d {
 			return true
 		}
 	}
@@ -191,6 +236,40 @@ func (c *roundCalculator) Delegates(height uint64) ([]string, error) {
 	return c.delegatesByEpochFunc(epochNum, prevHash[:])
 }
 
+// Aliases returns the operators of the delegates rotating the operators at given height, mapped to each other, along
+// with the BLS keys of the delegates mapped to the operators
+func (c *roundCalculator) Aliases(height uint64) (map[string]string, error) {
+	var (
+		aliases  map[string]string
+		epochNum = c.rp.GetEpochNum(height)
+	)
+	prevHash := c.chain.TipHash()
+	if c.aliasesByEpochFunc != nil {
+		var err error
+		if aliases, err = c.aliasesByEpochFunc(epochNum, prevHash[:]); err != nil {
+			return nil, err
+		}
+	}
+	if c.blsEndorsersByEpochFunc == nil {
+		return aliases, nil
+	}
+	endorsers, err := c.blsEndorsersByEpochFunc(epochNum, prevHash[:])
+	if err != nil {
+		return nil, err
+	}
+	if len(endorsers) == 0 {
+		return aliases, nil
+	}
+	merged := make(map[string]string, len(aliases)+len(endorsers))
+	for k, v := range aliases {
+		merged[k] = v
+	}
+	for k, v := range endorsers {
+		merged[k] = v
+	}
+	return merged, nil
+}
+
 // Proposers returns list of candidate proposers at given height
 func (c *roundCalculator) Proposers(height uint64) ([]string, error) {
 	epochNum := c.rp.GetEpochNum(height)
@@ -198,6 +277,24 @@ func (c *roundCalculator) Proposers(height uint64) ([]string, error) {
 	return c.proposersByEpochFunc(epochNum, prevHash[:])
 }
 
+// NextProposers returns the distinct proposers of the first rounds of the num heights following the height. The
+// heights whose proposers cannot be determined yet, e.g., in the next epoch, are skipped
+func (c *roundCalculator) NextProposers(height uint64, num uint64) []string {
+	var next []string
+	for h := height + 1; h <= height+num; h++ {
+		proposers, err := c.Proposers(h)
+		if err != nil {
+			continue
+		}
+		proposer, err := c.calculateProposer(h, 0, proposers)
+		if err != nil || slices.Contains(next, proposer) {
+			continue
+		}
+		next = append(next, proposer)
+	}
+	return next
+}
+
 // NewRoundWithToleration starts new round with tolerated over time
 func (c *roundCalculator) NewRoundWithToleration(
 	height uint64,
@@ -229,8 +326,10 @@ func (c *roundCalculator) newRound(
 	epochNum := uint64(0)
 	epochStartHeight := uint64(0)
 	var delegates, proposers []string
+	var aliases map[string]string
 	var roundNum uint32
 	var proposer string
+	var standbys []string
 	var roundStartTime time.Time
 	if height != 0 {
 		epochNum = c.rp.GetEpochNum(height)
@@ -241,12 +340,16 @@ func (c *roundCalculator) newRound(
 		if proposers, err = c.Proposers(height); err != nil {
 			return
 		}
+		if aliases, err = c.Aliases(height); err != nil {
+			return
+		}
 		if roundNum, roundStartTime, err = c.roundInfo(height, blockInterval, now, toleratedOvertime); err != nil {
 			return
 		}
 		if proposer, err = c.calculateProposer(height, roundNum, proposers); err != nil {
 			return
 		}
+		standbys = c.calculateStandbys(height, roundNum, proposers)
 	}
 	if eManager == nil {
 		if eManager, err = newEndorsementManager(nil, nil); err != nil {
@@ -261,11 +364,13 @@ func (c *roundCalculator) newRound(
 		numOfDelegates:       c.rp.NumDelegates(),
 		delegates:            delegates,
 		proposers:            proposers,
+		aliases:              aliases,
 
 		height:             height,
 		roundNum:           roundNum,
 		prevHash:           prevHash,
 		proposer:           proposer,
+		standbys:           standbys,
 		eManager:           eManager,
 		roundStartTime:     roundStartTime,
 		nextRoundStartTime: roundStartTime.Add(blockInterval),
@@ -296,13 +401,47 @@ func (c *roundCalculator) calculateProposer(
 	return
 }
 
+// calculateStandbys returns the proposers following the proposer of the round in order, which is called after
+// calculateProposer validates the proposer list
+func (c *roundCalculator) calculateStandbys(
+	height uint64,
+	round uint32,
+	proposers []string,
+) []string {
+	numProposers := uint64(len(proposers))
+	num := c.standbyProposers
+	if !c.isStandbyEnabled(height) || numProposers == 0 {
+		return nil
+	}
+	if num >= numProposers {
+		num = numProposers - 1
+	}
+	idx := height
+	if c.timeBasedRotation {
+		idx += uint64(round)
+	}
+	standbys := make([]string, 0, num)
+	for i := uint64(1); i <= num; i++ {
+		standbys = append(standbys, proposers[(idx+i)%numProposers])
+	}
+	return standbys
+}
+
+func (c *roundCalculator) isStandbyEnabled(height uint64) bool {
+	return c.standbyProposers > 0 && c.standbyEnabled != nil && c.standbyEnabled(height)
+}
+
 func (c *roundCalculator) Fork(fork ForkChain) *roundCalculator {
 	return &roundCalculator{
-		chain:                fork,
-		timeBasedRotation:    c.timeBasedRotation,
-		rp:                   c.rp,
-		delegatesByEpochFunc: c.delegatesByEpochFunc,
-		proposersByEpochFunc: c.proposersByEpochFunc,
-		beringHeight:         c.beringHeight,
+		chain:                   fork,
+		timeBasedRotation:       c.timeBasedRotation,
+		rp:                      c.rp,
+		delegatesByEpochFunc:    c.delegatesByEpochFunc,
+		proposersByEpochFunc:    c.proposersByEpochFunc,
+		aliasesByEpochFunc:      c.aliasesByEpochFunc,
+		beringHeight:            c.beringHeight,
+		standbyProposers:        c.standbyProposers,
+		standbyEnabled:          c.standbyEnabled,
+		blsEndorsersByEpochFunc: c.blsEndorsersByEpochFunc,
 	}
 }
@@ -7,6 +7,7 @@ package rolldpos
 
 import (
 	"context"
+	"slices"
 	"testing"
 	"time"
 
@@ -92,6 +93,57 @@ func TestNewRound(t *testing.T) {
 	require.Equal(identityset.Address(12).String(), ra.proposer)
 }
 
+func TestStandbyProposers(t *testing.T) {
+	require := require.New(t)
+	rc := makeRoundCalculator(t)
+	var validDelegates [24]string
+	for i := 0; i < 24; i++ {
+		validDelegates[i] = identityset.Address(i).String()
+	}
+	require.Empty(rc.calculateStandbys(5, 1, validDelegates[:]))
+
+	// standby proposers are not enabled before the hard fork
+	rc.standbyProposers = 2
+	rc.standbyEnabled = func(height uint64) bool { return height >= 10 }
+	require.Empty(rc.calculateStandbys(5, 1, validDelegates[:]))
+	require.Zero(rc.StandbyRank(validDelegates[7], 5, time.Second, time.Unix(1562382592, 0)))
+
+	rc.standbyEnabled = func(uint64) bool { return true }
+	require.Equal(validDelegates[7:9], rc.calculateStandbys(5, 1, validDelegates[:]))
+	require.Equal(validDelegates[:2], rc.calculateStandbys(22, 1, validDelegates[:]))
+	rc.standbyProposers = 30
+	require.Len(rc.calculateStandbys(5, 1, validDelegates[:]), 23)
+
+	rc.standbyProposers = 2
+	ra, err := rc.NewRound(51, time.Second, time.Unix(1562382592, 0), nil)
+	require.NoError(err)
+	require.Len(ra.standbys, 2)
+	require.Zero(ra.StandbyRank(ra.proposer))
+	for i, addr := range ra.standbys {
+		require.Equal(i+1, ra.StandbyRank(addr))
+		require.Equal(i+1, rc.StandbyRank(addr, 51, time.Second, time.Unix(1562382592, 0)))
+	}
+}
+
+func TestNextProposers(t *testing.T) {
+	require := require.New(t)
+	rc := makeRoundCalculator(t)
+	require.Empty(rc.NextProposers(51, 0))
+
+	next := rc.NextProposers(51, 2)
+	require.Len(next, 2)
+	for i, addr := range next {
+		height := uint64(52 + i)
+		proposers, err := rc.Proposers(height)
+		require.NoError(err)
+		require.Equal(proposers[height%rc.rp.NumDelegates()], addr)
+	}
+	// the proposers are distinct
+	next = rc.NextProposers(51, 2*rc.rp.NumDelegates())
+	require.LessOrEqual(len(next), int(rc.rp.NumDelegates()))
+	require.Len(slices.Compact(slices.Sorted(slices.Values(next))), len(next))
+}
+
 func TestDelegates(t *testing.T) {
 	require := require.New(t)
 	rc := makeRoundCalculator(t)
@@ -270,6 +322,10 @@ func makeRoundCalculator(t *testing.T) *roundCalculator {
 		rp,
 		delegatesByEpoch,
 		delegatesByEpoch,
+		nil,
+		0,
 		0,
+		nil,
+		nil,
 	}
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/iotexproject/go-pkgs/hash"
 
 	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/crypto/bls"
 	"github.com/iotexproject/iotex-core/v2/endorsement"
 	"github.com/iotexproject/iotex-core/v2/pkg/log"
 )
@@ -37,11 +38,14 @@ type roundCtx struct {
 	numOfDelegates       uint64
 	delegates            []string
 	proposers            []string
+	// aliases maps the old and the new operators of the delegates rotating the operators to each other
+	aliases map[string]string
 
 	height             uint64
 	roundNum           uint32
 	prevHash           hash.Hash256
 	proposer           string
+	standbys           []string
 	roundStartTime     time.Time
 	nextRoundStartTime time.Time
 
@@ -110,13 +114,37 @@ func (ctx *roundCtx) Proposers() []string {
 }
 
 func (ctx *roundCtx) IsDelegate(addr string) bool {
+	return ctx.delegateOf(addr) != ""
+}
+
+// IsProposer checks whether the address is the proposer, or the other operator of the proposer during a rotation
+func (ctx *roundCtx) IsProposer(addr string) bool {
+	return addr == ctx.proposer || ctx.aliases[addr] == ctx.proposer && ctx.proposer != ""
+}
+
+// StandbyRank returns the rank of the address in the standby proposers of the round starting from 1, or 0 if it is
+// not a standby proposer
+func (ctx *roundCtx) StandbyRank(addr string) int {
+	alias := ctx.aliases[addr]
+	for i, s := range ctx.standbys {
+		if addr == s || alias == s {
+			return i + 1
+		}
+	}
+
+	return 0
+}
+
+// delegateOf returns the delegate of the address, which is either the delegate or the other operator of it
+func (ctx *roundCtx) delegateOf(addr string) string {
+	alias := ctx.aliases[addr]
 	for _, d := range ctx.delegates {
-		if addr == d {
-			return true
+		if addr == d || alias == d {
+			return d
 		}
 	}
 
-	return false
+	return ""
 }
 
 func (ctx *roundCtx) Block(blkHash []byte) *block.Block {
@@ -216,6 +244,39 @@ func (ctx *roundCtx) AddVoteEndorsement(
 	if !endorsement.VerifyEndorsement(vote, en) {
 		return errors.New("invalid endorsement for the vote")
 	}
+	return ctx.addVoteEndorsement(vote, en)
+}
+
+// AddAggregateVoteEndorsement verifies the endorsement aggregated from the BLS endorsements once, and adds the votes
+// of the endorsers of it, which is used to validate the endorsements in the block footer
+func (ctx *roundCtx) AddAggregateVoteEndorsement(
+	vote *ConsensusVote,
+	en *endorsement.Endorsement,
+) error {
+	if _, ok := en.Endorser().(*bls.AggregatePublicKey); !ok {
+		return errors.New("not an aggregate endorsement")
+	}
+	if !endorsement.VerifyEndorsement(vote, en) {
+		return errors.New("invalid endorsement for the vote")
+	}
+	endorsers := make(map[string]struct{})
+	for _, e := range endorsement.Expand(en) {
+		endorser := e.Endorser().HexString()
+		if _, ok := endorsers[endorser]; ok {
+			return errors.New("duplicate endorser in the aggregate endorsement")
+		}
+		endorsers[endorser] = struct{}{}
+		if err := ctx.addVoteEndorsement(vote, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ctx *roundCtx) addVoteEndorsement(
+	vote *ConsensusVote,
+	en *endorsement.Endorsement,
+) error {
 	if addr := en.Endorser().Address(); addr == nil || !ctx.IsDelegate(addr.String()) {
 		return errors.New("invalid endorser")
 	}
@@ -275,7 +336,17 @@ func (ctx *roundCtx) endorsedByMajority(blockHash []byte, topics []ConsensusVote
 }
 
 func (ctx *roundCtx) isMajority(endorsements []*endorsement.Endorsement) bool {
-	return 3*len(endorsements) > 2*int(ctx.numOfDelegates)
+	if len(ctx.aliases) == 0 {
+		return 3*len(endorsements) > 2*int(ctx.numOfDelegates)
+	}
+	// a delegate endorsing with both operators during a rotation is counted once
+	endorsers := make(map[string]struct{}, len(endorsements))
+	for _, en := range endorsements {
+		if addr := en.Endorser().Address(); addr != nil {
+			endorsers[ctx.delegateOf(addr.String())] = struct{}{}
+		}
+	}
+	return 3*len(endorsers) > 2*int(ctx.numOfDelegates)
 }
 
 func (ctx *roundCtx) block(blkHash []byte) *block.Block {
@@ -13,6 +13,7 @@ import (
 	"go.uber.org/mock/gomock"
 
 	"github.com/iotexproject/iotex-core/v2/endorsement"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
 )
 
 func TestRoundCtx(t *testing.T) {
@@ -93,3 +94,44 @@ func TestRoundCtx(t *testing.T) {
 	})
 	// TODO: add more unit tests
 }
+
+func TestRoundCtx_OperatorAliases(t *testing.T) {
+	require := require.New(t)
+	delegates := make([]string, 4)
+	for i := range delegates {
+		delegates[i] = identityset.Address(i).String()
+	}
+	alias := identityset.Address(10).String()
+	round := &roundCtx{
+		numOfDelegates: 4,
+		delegates:      delegates,
+		proposer:       delegates[0],
+		aliases: map[string]string{
+			alias:        delegates[0],
+			delegates[0]: alias,
+		},
+	}
+	require.True(round.IsDelegate(alias))
+	require.True(round.IsProposer(alias))
+	require.True(round.IsProposer(delegates[0]))
+	require.False(round.IsProposer(delegates[1]))
+	require.Equal(delegates[0], round.delegateOf(alias))
+	require.False(round.IsDelegate(identityset.Address(11).String()))
+	round.standbys = []string{delegates[0], delegates[1]}
+	require.Equal(1, round.StandbyRank(alias))
+	require.Equal(2, round.StandbyRank(delegates[1]))
+	require.Zero(round.StandbyRank(delegates[2]))
+	round.standbys = nil
+
+	// the endorsements of both operators of a delegate are counted once
+	endorse := func(keys ...int) []*endorsement.Endorsement {
+		ens := make([]*endorsement.Endorsement, 0, len(keys))
+		for _, k := range keys {
+			ens = append(ens, endorsement.NewEndorsement(time.Now(), identityset.PrivateKey(k).PublicKey(), nil))
+		}
+		return ens
+	}
+	require.False(round.isMajority(endorse(0, 10, 1)))
+	require.True(round.isMajority(endorse(0, 1, 2)))
+	require.True(round.isMajority(endorse(10, 1, 2)))
+}
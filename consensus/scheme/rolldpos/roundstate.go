@@ -0,0 +1,236 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/consensus/scheme/rolldpos/endorsementpb"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/endorsement"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+)
+
+const (
+	_roundStateNS = "rds"
+	_signedNS     = "sgn"
+)
+
+var (
+	// ErrConflictingSignature indicates that the message conflicts with the one signed before by the same delegate
+	ErrConflictingSignature = errors.New("the message conflicts with the one signed before")
+	_roundStateKey          = []byte("round")
+)
+
+type (
+	// roundState is the snapshot of the round, with which a restarted delegate resumes the round of the same height
+	roundState struct {
+		height      uint64
+		roundNum    uint32
+		status      status
+		blockInLock []byte
+		proofOfLock []*endorsement.Endorsement
+	}
+
+	// roundStore persists the snapshot of the current round, and the blocks signed by the delegates of the node in
+	// the consensus db, so that a restarted delegate never signs a message conflicting with the one signed before
+	roundStore struct {
+		mutex   sync.Mutex
+		kvStore db.KVStore
+		signed  map[signKey][]byte
+	}
+)
+
+func (s *roundState) toProto() *endorsementpb.RoundState {
+	pb := &endorsementpb.RoundState{
+		Height:      s.height,
+		Round:       s.roundNum,
+		Status:      uint32(s.status),
+		BlockInLock: s.blockInLock,
+	}
+	for _, en := range s.proofOfLock {
+		pb.ProofOfLock = append(pb.ProofOfLock, en.Proto())
+	}
+	return pb
+}
+
+func (s *roundState) fromProto(pb *endorsementpb.RoundState) error {
+	s.height = pb.Height
+	s.roundNum = pb.Round
+	s.status = status(pb.Status)
+	s.blockInLock = pb.BlockInLock
+	s.proofOfLock = make([]*endorsement.Endorsement, 0, len(pb.ProofOfLock))
+	for _, enPb := range pb.ProofOfLock {
+		en := &endorsement.Endorsement{}
+		if err := en.LoadProto(enPb); err != nil {
+			return err
+		}
+		s.proofOfLock = append(s.proofOfLock, en)
+	}
+	return nil
+}
+
+func newRoundStore(kvStore db.KVStore) *roundStore {
+	return &roundStore{
+		kvStore: kvStore,
+		signed:  map[signKey][]byte{},
+	}
+}
+
+// Load loads the signed blocks, and returns the snapshot of the round persisted, or nil if there is none
+func (s *roundStore) Load() (*roundState, error) {
+	if s.kvStore == nil {
+		return nil, nil
+	}
+	keys, values, err := s.kvStore.Filter(_signedNS, func(k, v []byte) bool { return true }, nil, nil)
+	switch errors.Cause(err) {
+	case nil:
+	case db.ErrNotExist, db.ErrBucketNotExist:
+	default:
+		return nil, err
+	}
+	s.mutex.Lock()
+	for i, k := range keys {
+		key, err := decodeSignKey(k)
+		if err != nil {
+			s.mutex.Unlock()
+			return nil, err
+		}
+		s.signed[key] = values[i]
+	}
+	s.mutex.Unlock()
+	value, err := s.kvStore.Get(_roundStateNS, _roundStateKey)
+	switch errors.Cause(err) {
+	case nil:
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		return nil, nil
+	default:
+		return nil, err
+	}
+	pb := &endorsementpb.RoundState{}
+	if err := proto.Unmarshal(value, pb); err != nil {
+		return nil, err
+	}
+	state := &roundState{}
+	if err := state.fromProto(pb); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// Save persists the snapshot of the round
+func (s *roundStore) Save(state *roundState) error {
+	if s.kvStore == nil {
+		return nil
+	}
+	value, err := proto.Marshal(state.toProto())
+	if err != nil {
+		return err
+	}
+	return s.kvStore.Put(_roundStateNS, _roundStateKey, value)
+}
+
+// Sign records the block signed in the message before it is sent, and returns ErrConflictingSignature if the
+// delegate has signed another block in the same kind of message of the round
+func (s *roundStore) Sign(msg *EndorsedConsensusMessage) error {
+	kind, blkHash := signedBlock(msg)
+	if kind == "" {
+		return nil
+	}
+	endorser := msg.Endorsement().Endorser().Address()
+	if endorser == nil {
+		return errors.New("failed to get address")
+	}
+	key := signKey{
+		height:    msg.Height(),
+		endorser:  endorser.String(),
+		kind:      kind,
+		timestamp: msg.Endorsement().Timestamp().UnixNano(),
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if signed, ok := s.signed[key]; ok {
+		if !bytes.Equal(signed, blkHash) {
+			return errors.Wrapf(ErrConflictingSignature, "%s of height %d at %s", kind, key.height, msg.Endorsement().Timestamp())
+		}
+		return nil
+	}
+	if s.kvStore != nil {
+		if err := s.kvStore.Put(_signedNS, encodeSignKey(key), blkHash); err != nil {
+			return err
+		}
+	}
+	s.signed[key] = blkHash
+	return nil
+}
+
+// Prune removes the blocks signed below the height
+func (s *roundStore) Prune(height uint64) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for k := range s.signed {
+		if k.height >= height {
+			continue
+		}
+		delete(s.signed, k)
+		if s.kvStore != nil {
+			if err := s.kvStore.Delete(_signedNS, encodeSignKey(k)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// encodeSignKey encodes the key in the order of height, timestamp, kind and endorser
+func encodeSignKey(key signKey) []byte {
+	buf := append(byteutil.Uint64ToBytesBigEndian(key.height), byteutil.Uint64ToBytesBigEndian(uint64(key.timestamp))...)
+	buf = append(buf, byte(len(key.kind)))
+	buf = append(buf, key.kind...)
+	return append(buf, key.endorser...)
+}
+
+func decodeSignKey(buf []byte) (signKey, error) {
+	if len(buf) < 17 || len(buf) < 17+int(buf[16]) {
+		return signKey{}, errors.Errorf("invalid sign key %x", buf)
+	}
+	kindLen := int(buf[16])
+	return signKey{
+		height:    byteutil.BytesToUint64BigEndian(buf[:8]),
+		timestamp: int64(byteutil.BytesToUint64BigEndian(buf[8:16])),
+		kind:      string(buf[17 : 17+kindLen]),
+		endorser:  string(buf[17+kindLen:]),
+	}, nil
+}
+
+func (ctx *roundCtx) snapshot() *roundState {
+	return &roundState{
+		height:      ctx.height,
+		roundNum:    ctx.roundNum,
+		status:      ctx.status,
+		blockInLock: ctx.blockInLock,
+		proofOfLock: ctx.proofOfLock,
+	}
+}
+
+// restore restores the lock of the round from the snapshot of the same height. The lock is dropped if the block in
+// lock is not kept by the endorsement manager
+func (ctx *roundCtx) restore(state *roundState) bool {
+	if state.height != ctx.height {
+		return false
+	}
+	if state.status == _locked && ctx.block(state.blockInLock) == nil {
+		return false
+	}
+	ctx.status = state.status
+	ctx.blockInLock = state.blockInLock
+	ctx.proofOfLock = state.proofOfLock
+	return true
+}
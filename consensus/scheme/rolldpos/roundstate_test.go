@@ -0,0 +1,113 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/endorsement"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+	"github.com/iotexproject/iotex-core/v2/testutil"
+)
+
+func TestRoundStore(t *testing.T) {
+	require := require.New(t)
+	testDBPath, err := testutil.PathOfTempFile("consensus.db")
+	require.NoError(err)
+	defer testutil.CleanupPath(testDBPath)
+	cfg := db.DefaultConfig
+	cfg.DbPath = testDBPath
+	kvStore := db.NewBoltDB(cfg)
+	require.NoError(kvStore.Start(context.Background()))
+
+	var (
+		sk   = identityset.PrivateKey(1)
+		ts   = time.Unix(1000, 0)
+		blk1 = hash.Hash256b([]byte("block1"))
+		blk2 = hash.Hash256b([]byte("block2"))
+	)
+	store := newRoundStore(kvStore)
+	state, err := store.Load()
+	require.NoError(err)
+	require.Nil(state)
+
+	// the same block is signed again, and the messages of other kinds, rounds or heights don't conflict
+	for _, msg := range []*EndorsedConsensusMessage{
+		signedVote(t, sk, 10, blk1[:], PROPOSAL, ts),
+		signedVote(t, sk, 10, blk1[:], PROPOSAL, ts),
+		signedVote(t, sk, 10, blk2[:], LOCK, ts),
+		signedVote(t, sk, 10, blk2[:], PROPOSAL, ts.Add(time.Second)),
+		signedVote(t, sk, 11, blk2[:], PROPOSAL, ts),
+		signedVote(t, identityset.PrivateKey(2), 10, blk2[:], PROPOSAL, ts),
+		signedVote(t, sk, 10, nil, PROPOSAL, ts),
+	} {
+		require.NoError(store.Sign(msg))
+	}
+	require.ErrorIs(store.Sign(signedVote(t, sk, 10, blk2[:], PROPOSAL, ts)), ErrConflictingSignature)
+
+	en, err := endorsement.Endorse(NewConsensusVote(blk1[:], PROPOSAL), ts, sk)
+	require.NoError(err)
+	require.NoError(store.Save(&roundState{
+		height:      10,
+		roundNum:    2,
+		status:      _locked,
+		blockInLock: blk1[:],
+		proofOfLock: en,
+	}))
+
+	// the signed blocks and the round are recovered after a restart
+	require.NoError(kvStore.Stop(context.Background()))
+	kvStore = db.NewBoltDB(cfg)
+	require.NoError(kvStore.Start(context.Background()))
+	defer kvStore.Stop(context.Background())
+	store = newRoundStore(kvStore)
+	state, err = store.Load()
+	require.NoError(err)
+	require.Equal(uint64(10), state.height)
+	require.Equal(uint32(2), state.roundNum)
+	require.Equal(_locked, state.status)
+	require.Equal(blk1[:], state.blockInLock)
+	require.Len(state.proofOfLock, 1)
+	require.Equal(en[0].Proto(), state.proofOfLock[0].Proto())
+	require.ErrorIs(store.Sign(signedVote(t, sk, 10, blk2[:], PROPOSAL, ts)), ErrConflictingSignature)
+	require.ErrorIs(store.Sign(signedVote(t, sk, 11, blk1[:], PROPOSAL, ts)), ErrConflictingSignature)
+	require.NoError(store.Sign(signedVote(t, sk, 10, blk2[:], LOCK, ts)))
+
+	// the blocks signed below the height are pruned
+	require.NoError(store.Prune(11))
+	require.NoError(store.Sign(signedVote(t, sk, 10, blk2[:], PROPOSAL, ts)))
+	require.ErrorIs(store.Sign(signedVote(t, sk, 11, blk1[:], PROPOSAL, ts)), ErrConflictingSignature)
+	store = newRoundStore(kvStore)
+	_, err = store.Load()
+	require.NoError(err)
+	require.Len(store.signed, 2)
+}
+
+func TestRoundCtxRestore(t *testing.T) {
+	require := require.New(t)
+	blk := getBlockforctx(t, 1, true, hash.ZeroHash256)
+	blkHash := blk.HashBlock()
+	eManager, err := newEndorsementManager(nil, nil)
+	require.NoError(err)
+	round := &roundCtx{height: 51, eManager: eManager, status: _open}
+	state := &roundState{height: 51, status: _locked, blockInLock: blkHash[:]}
+
+	// the lock is dropped without the block
+	require.False(round.restore(state))
+	require.False(round.IsLocked())
+	require.NoError(round.AddBlock(&blk))
+	require.False(round.restore(&roundState{height: 52, status: _locked, blockInLock: blkHash[:]}))
+	require.True(round.restore(state))
+	require.True(round.IsLocked())
+	require.Equal(blkHash[:], round.HashOfBlockInLock())
+	require.Equal(state, round.snapshot())
+}
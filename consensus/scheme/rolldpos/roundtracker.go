@@ -0,0 +1,194 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/consensus/scheme"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+var (
+	_roundsPerHeightMtc = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "iotex_consensus_rounds_per_height",
+			Help:    "Number of consensus rounds to commit a block",
+			Buckets: []float64{1, 2, 3, 4, 6, 8, 12, 16},
+		},
+	)
+
+	_proposalReceiptMtc = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "iotex_consensus_proposal_receipt_seconds",
+			Help:    "Time from the round start to the receipt of the block proposal",
+			Buckets: prometheus.LinearBuckets(0.25, 0.25, 20),
+		},
+	)
+
+	_quorumTimeMtc = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "iotex_consensus_quorum_seconds",
+			Help:    "Time from the round start to the quorum of the endorsements",
+			Buckets: prometheus.LinearBuckets(0.25, 0.25, 20),
+		},
+		[]string{"topic"},
+	)
+
+	_viewChangeMtc = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "iotex_consensus_view_changes",
+			Help: "Number of consensus rounds moving to the next round without committing a block",
+		},
+	)
+
+	_missedProposalMtc = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iotex_consensus_missed_proposals",
+			Help: "Number of consensus rounds in which the block proposal of the delegate is not received",
+		},
+		[]string{"delegate"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(_roundsPerHeightMtc)
+	prometheus.MustRegister(_proposalReceiptMtc)
+	prometheus.MustRegister(_quorumTimeMtc)
+	prometheus.MustRegister(_viewChangeMtc)
+	prometheus.MustRegister(_missedProposalMtc)
+}
+
+// roundTracker traces the progress of the consensus rounds, to diagnose the stalls of consensus
+type roundTracker struct {
+	mutex           sync.RWMutex
+	height          uint64
+	round           uint32
+	epoch           uint64
+	proposer        string
+	startTime       time.Time
+	participating   bool
+	rounds          uint32
+	proposed        bool
+	proposalReceipt time.Duration
+	quorums         map[ConsensusVoteTopic]time.Duration
+	viewChanges     uint64
+	lastHeight      uint64
+	lastRounds      uint32
+	missed          map[string]uint64
+}
+
+func newRoundTracker() *roundTracker {
+	return &roundTracker{
+		quorums: map[ConsensusVoteTopic]time.Duration{},
+		missed:  map[string]uint64{},
+	}
+}
+
+// NewRound starts tracking a new round, participating is true if the node is an active delegate of the round
+func (t *roundTracker) NewRound(round *roundCtx, participating bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if round.Height() == t.height && round.Number() == t.round {
+		return
+	}
+	// the proposal of the previous round is counted only if the node took part in it
+	if t.participating && !t.proposed && t.proposer != "" {
+		t.missed[t.proposer]++
+		_missedProposalMtc.WithLabelValues(t.proposer).Inc()
+	}
+	switch {
+	case round.Height() == t.height:
+		t.viewChanges++
+		_viewChangeMtc.Inc()
+		log.Logger("consensus").Info("view change",
+			zap.Uint64("height", t.height),
+			zap.Uint32("round", t.round),
+			zap.Uint32("nextRound", round.Number()),
+			zap.String("proposer", t.proposer),
+			zap.Bool("proposalReceived", t.proposed),
+		)
+	default:
+		t.rounds = 0
+	}
+	t.rounds++
+	t.height = round.Height()
+	t.round = round.Number()
+	t.epoch = round.EpochNum()
+	t.proposer = round.Proposer()
+	t.startTime = round.StartTime()
+	t.participating = participating
+	t.proposed = false
+	t.proposalReceipt = 0
+	t.quorums = map[ConsensusVoteTopic]time.Duration{}
+}
+
+// ProposalReceived records the receipt of the block proposal of the current round
+func (t *roundTracker) ProposalReceived(now time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.proposed {
+		return
+	}
+	t.proposed = true
+	t.proposalReceipt = now.Sub(t.startTime)
+	_proposalReceiptMtc.Observe(t.proposalReceipt.Seconds())
+}
+
+// QuorumReached records the quorum of the endorsements of the topic in the current round
+func (t *roundTracker) QuorumReached(topic ConsensusVoteTopic, now time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if _, ok := t.quorums[topic]; ok {
+		return
+	}
+	d := now.Sub(t.startTime)
+	t.quorums[topic] = d
+	_quorumTimeMtc.WithLabelValues(topic.String()).Observe(d.Seconds())
+}
+
+// Committed records the block committed in the current round
+func (t *roundTracker) Committed(height uint64) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if height != t.height {
+		return
+	}
+	t.lastHeight = height
+	t.lastRounds = t.rounds
+	_roundsPerHeightMtc.Observe(float64(t.rounds))
+}
+
+// Status returns the status of the tracked rounds
+func (t *roundTracker) Status() scheme.ConsensusStatus {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	status := scheme.ConsensusStatus{
+		Height:              t.height,
+		Round:               t.round,
+		Epoch:               t.epoch,
+		Proposer:            t.proposer,
+		RoundStartTime:      t.startTime,
+		ProposalReceived:    t.proposed,
+		ProposalReceiptTime: t.proposalReceipt,
+		QuorumTimes:         make(map[string]time.Duration, len(t.quorums)),
+		ViewChanges:         t.viewChanges,
+		LastCommitHeight:    t.lastHeight,
+		LastCommitRounds:    t.lastRounds,
+		MissedProposals:     make(map[string]uint64, len(t.missed)),
+	}
+	for topic, d := range t.quorums {
+		status.QuorumTimes[topic.String()] = d
+	}
+	for delegate, n := range t.missed {
+		status.MissedProposals[delegate] = n
+	}
+	return status
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundTracker(t *testing.T) {
+	require := require.New(t)
+	var (
+		tracker  = newRoundTracker()
+		start    = time.Unix(1000, 0)
+		newRound = func(height uint64, num uint32, proposer string) *roundCtx {
+			return &roundCtx{
+				epochNum:       1,
+				height:         height,
+				roundNum:       num,
+				proposer:       proposer,
+				roundStartTime: start.Add(time.Duration(num) * 5 * time.Second),
+			}
+		}
+	)
+
+	// round 0 at height 10, proposal missed
+	tracker.NewRound(newRound(10, 0, "a"), true)
+	tracker.QuorumReached(PROPOSAL, start.Add(3*time.Second))
+	status := tracker.Status()
+	require.Equal(uint64(10), status.Height)
+	require.Equal("a", status.Proposer)
+	require.False(status.ProposalReceived)
+	require.Equal(3*time.Second, status.QuorumTimes["proposal"])
+
+	// preparing the same round again is ignored
+	tracker.NewRound(newRound(10, 0, "a"), true)
+	require.Equal(status, tracker.Status())
+
+	// view change to round 1, the proposal is received and the block is committed
+	tracker.NewRound(newRound(10, 1, "b"), true)
+	status = tracker.Status()
+	require.Equal(uint32(1), status.Round)
+	require.Equal(uint64(1), status.ViewChanges)
+	require.Equal(map[string]uint64{"a": 1}, status.MissedProposals)
+	require.Empty(status.QuorumTimes)
+	tracker.ProposalReceived(start.Add(6 * time.Second))
+	tracker.ProposalReceived(start.Add(7 * time.Second))
+	tracker.QuorumReached(PROPOSAL, start.Add(7*time.Second))
+	tracker.QuorumReached(LOCK, start.Add(8*time.Second))
+	tracker.QuorumReached(COMMIT, start.Add(9*time.Second))
+	tracker.Committed(10)
+	status = tracker.Status()
+	require.True(status.ProposalReceived)
+	require.Equal(time.Second, status.ProposalReceiptTime)
+	require.Equal(map[string]time.Duration{
+		"proposal": 2 * time.Second,
+		"lock":     3 * time.Second,
+		"commit":   4 * time.Second,
+	}, status.QuorumTimes)
+	require.Equal(uint64(10), status.LastCommitHeight)
+	require.Equal(uint32(2), status.LastCommitRounds)
+
+	// next height, the missed proposals are not counted if the node doesn't take part in the round
+	tracker.NewRound(newRound(11, 0, "c"), false)
+	tracker.NewRound(newRound(11, 1, "a"), true)
+	status = tracker.Status()
+	require.Equal(uint64(2), status.ViewChanges)
+	require.Equal(map[string]uint64{"a": 1}, status.MissedProposals)
+	tracker.Committed(10)
+	require.Equal(uint32(2), tracker.Status().LastCommitRounds)
+}
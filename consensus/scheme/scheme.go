@@ -6,9 +6,12 @@
 package scheme
 
 import (
+	"time"
+
 	"google.golang.org/protobuf/proto"
 
 	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/consensus/consensusfsm"
 	"github.com/iotexproject/iotex-core/v2/pkg/lifecycle"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 )
@@ -36,6 +39,9 @@ type Scheme interface {
 	Calibrate(uint64)
 	ValidateBlockFooter(*block.Block) error
 	Metrics() (ConsensusMetrics, error)
+	Status() (ConsensusStatus, error)
+	Timeouts() (consensusfsm.Timeouts, error)
+	SetTimeouts(*consensusfsm.Timeouts) error
 	Activate(bool)
 	Active() bool
 }
@@ -47,3 +53,25 @@ type ConsensusMetrics struct {
 	LatestDelegates     []string
 	LatestBlockProducer string
 }
+
+// ConsensusStatus contains the status of the consensus rounds to diagnose the liveness
+type ConsensusStatus struct {
+	Height         uint64
+	Round          uint32
+	Epoch          uint64
+	State          string
+	Proposer       string
+	RoundStartTime time.Time
+	// ProposalReceived indicates whether the block proposal of the round is received
+	ProposalReceived bool
+	// ProposalReceiptTime is the time from the round start to the receipt of the block proposal
+	ProposalReceiptTime time.Duration
+	// QuorumTimes are the times from the round start to the quorums of the endorsements by topic
+	QuorumTimes map[string]time.Duration
+	// ViewChanges is the number of rounds moving to the next one without committing a block
+	ViewChanges      uint64
+	LastCommitHeight uint64
+	LastCommitRounds uint32
+	// MissedProposals is the number of the rounds in which the block proposal of the delegate is not received
+	MissedProposals map[string]uint64
+}
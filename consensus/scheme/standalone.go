@@ -16,6 +16,7 @@ import (
 
 	"github.com/iotexproject/iotex-core/v2/blockchain"
 	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/consensus/consensusfsm"
 	"github.com/iotexproject/iotex-core/v2/pkg/log"
 	"github.com/iotexproject/iotex-core/v2/pkg/routine"
 )
@@ -99,6 +100,30 @@ func (s *Standalone) Metrics() (ConsensusMetrics, error) {
 	)
 }
 
+// Status is not implemented for standalone scheme
+func (s *Standalone) Status() (ConsensusStatus, error) {
+	return ConsensusStatus{}, errors.Wrapf(
+		ErrNotImplemented,
+		"standalone scheme does not support status",
+	)
+}
+
+// Timeouts is not implemented for standalone scheme
+func (s *Standalone) Timeouts() (consensusfsm.Timeouts, error) {
+	return consensusfsm.Timeouts{}, errors.Wrapf(
+		ErrNotImplemented,
+		"standalone scheme does not support timeouts",
+	)
+}
+
+// SetTimeouts is not implemented for standalone scheme
+func (s *Standalone) SetTimeouts(*consensusfsm.Timeouts) error {
+	return errors.Wrapf(
+		ErrNotImplemented,
+		"standalone scheme does not support timeouts",
+	)
+}
+
 // Activate is not implemented for standalone scheme
 func (s *Standalone) Activate(_ bool) {
 	log.S().Warn("Standalone scheme could not support activate")
@@ -0,0 +1,346 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+// Package bls implements the BLS signatures on the BLS12-381 curve, with the public keys in G1 and the signatures in
+// G2. The signatures of the same message can be aggregated into one signature, which is verified against the
+// aggregate of the public keys with a constant number of pairings. The public keys are expected to be registered with
+// the proofs of possession of the private keys to prevent the rogue key attack.
+package bls
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/go-pkgs/util"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+)
+
+const (
+	// PrivateKeySize is the size of a private key in bytes
+	PrivateKeySize = fr.Bytes
+	// PublicKeySize is the size of a compressed public key in bytes
+	PublicKeySize = bls12381.SizeOfG1AffineCompressed
+	// SignatureSize is the size of a compressed signature in bytes
+	SignatureSize = bls12381.SizeOfG2AffineCompressed
+)
+
+var (
+	// the domain separation tags of the proof of possession scheme, defined in draft-irtf-cfrg-bls-signature
+	_signatureDST = []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_")
+	_proofDST     = []byte("BLS_POP_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_")
+
+	// ErrInvalidKey is the error that the key is invalid
+	ErrInvalidKey = errors.New("invalid BLS key")
+	// ErrInvalidSignature is the error that the signature is invalid
+	ErrInvalidSignature = errors.New("invalid BLS signature")
+)
+
+type (
+	// PrivateKey is a BLS private key
+	PrivateKey struct {
+		sk fr.Element
+	}
+
+	// PublicKey is a BLS public key
+	PublicKey struct {
+		pk bls12381.G1Affine
+	}
+
+	// AggregatePublicKey is the public keys of the signers of an aggregate signature
+	AggregatePublicKey struct {
+		pks []*PublicKey
+	}
+)
+
+// GenerateKey generates a random BLS private key
+func GenerateKey() (*PrivateKey, error) {
+	sk := &PrivateKey{}
+	for sk.sk.IsZero() {
+		if _, err := sk.sk.SetRandom(); err != nil {
+			return nil, err
+		}
+	}
+	return sk, nil
+}
+
+// BytesToPrivateKey decodes a BLS private key
+func BytesToPrivateKey(b []byte) (*PrivateKey, error) {
+	if len(b) != PrivateKeySize {
+		return nil, errors.Wrapf(ErrInvalidKey, "invalid private key length %d", len(b))
+	}
+	sk := &PrivateKey{}
+	if err := sk.sk.SetBytesCanonical(b); err != nil {
+		return nil, errors.Wrap(ErrInvalidKey, err.Error())
+	}
+	if sk.sk.IsZero() {
+		return nil, errors.Wrap(ErrInvalidKey, "zero private key")
+	}
+	return sk, nil
+}
+
+// HexStringToPrivateKey decodes a BLS private key in hex string
+func HexStringToPrivateKey(s string) (*PrivateKey, error) {
+	b, err := hex.DecodeString(util.Remove0xPrefix(s))
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidKey, err.Error())
+	}
+	return BytesToPrivateKey(b)
+}
+
+// Bytes returns the private key in bytes
+func (k *PrivateKey) Bytes() []byte {
+	b := k.sk.Bytes()
+	return b[:]
+}
+
+// HexString returns the private key in hex string
+func (k *PrivateKey) HexString() string {
+	return hex.EncodeToString(k.Bytes())
+}
+
+// EcdsaPrivateKey returns nil, as a BLS private key is not an ECDSA key
+func (k *PrivateKey) EcdsaPrivateKey() interface{} {
+	return nil
+}
+
+// PublicKey returns the public key of the private key
+func (k *PrivateKey) PublicKey() crypto.PublicKey {
+	return k.BLSPublicKey()
+}
+
+// BLSPublicKey returns the BLS public key of the private key
+func (k *PrivateKey) BLSPublicKey() *PublicKey {
+	pk := &PublicKey{}
+	pk.pk.ScalarMultiplicationBase(k.scalar())
+	return pk
+}
+
+// Sign signs the message
+func (k *PrivateKey) Sign(msg []byte) ([]byte, error) {
+	return k.sign(msg, _signatureDST)
+}
+
+// ProofOfPossession signs the public key to prove the possession of the private key
+func (k *PrivateKey) ProofOfPossession() ([]byte, error) {
+	return k.sign(k.BLSPublicKey().Bytes(), _proofDST)
+}
+
+// Zero clears the private key
+func (k *PrivateKey) Zero() {
+	k.sk.SetZero()
+}
+
+func (k *PrivateKey) scalar() *big.Int {
+	return k.sk.BigInt(new(big.Int))
+}
+
+func (k *PrivateKey) sign(msg, dst []byte) ([]byte, error) {
+	h, err := bls12381.HashToG2(msg, dst)
+	if err != nil {
+		return nil, err
+	}
+	var sig bls12381.G2Affine
+	sig.ScalarMultiplication(&h, k.scalar())
+	b := sig.Bytes()
+	return b[:], nil
+}
+
+// BytesToPublicKey decodes a compressed BLS public key
+func BytesToPublicKey(b []byte) (*PublicKey, error) {
+	if len(b) != PublicKeySize {
+		return nil, errors.Wrapf(ErrInvalidKey, "invalid public key length %d", len(b))
+	}
+	pk := &PublicKey{}
+	if _, err := pk.pk.SetBytes(b); err != nil {
+		return nil, errors.Wrap(ErrInvalidKey, err.Error())
+	}
+	if pk.pk.IsInfinity() {
+		return nil, errors.Wrap(ErrInvalidKey, "public key at infinity")
+	}
+	return pk, nil
+}
+
+// HexStringToPublicKey decodes a compressed BLS public key in hex string
+func HexStringToPublicKey(s string) (*PublicKey, error) {
+	b, err := hex.DecodeString(util.Remove0xPrefix(s))
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidKey, err.Error())
+	}
+	return BytesToPublicKey(b)
+}
+
+// Bytes returns the compressed public key
+func (k *PublicKey) Bytes() []byte {
+	b := k.pk.Bytes()
+	return b[:]
+}
+
+// HexString returns the compressed public key in hex string
+func (k *PublicKey) HexString() string {
+	return hex.EncodeToString(k.Bytes())
+}
+
+// EcdsaPublicKey returns nil, as a BLS public key is not an ECDSA key
+func (k *PublicKey) EcdsaPublicKey() interface{} {
+	return nil
+}
+
+// Hash returns the hash of the public key
+func (k *PublicKey) Hash() []byte {
+	h := hash.Hash160b(k.Bytes())
+	return h[:]
+}
+
+// Verify verifies the signature of the message
+func (k *PublicKey) Verify(msg, sig []byte) bool {
+	return verify(&k.pk, msg, sig, _signatureDST)
+}
+
+// VerifyProofOfPossession verifies the proof of possession of the private key
+func (k *PublicKey) VerifyProofOfPossession(proof []byte) bool {
+	return verify(&k.pk, k.Bytes(), proof, _proofDST)
+}
+
+// Address returns the address derived from the public key, which is used to identify the BLS key of a delegate
+func (k *PublicKey) Address() address.Address {
+	addr, err := address.FromBytes(k.Hash())
+	if err != nil {
+		return nil
+	}
+	return addr
+}
+
+// Equal checks whether the public keys are the same
+func (k *PublicKey) Equal(pk *PublicKey) bool {
+	return k.pk.Equal(&pk.pk)
+}
+
+// NewAggregatePublicKey returns the aggregate public key of the signers
+func NewAggregatePublicKey(pks []*PublicKey) *AggregatePublicKey {
+	return &AggregatePublicKey{pks: pks}
+}
+
+// BytesToAggregatePublicKey decodes the concatenated public keys of the signers
+func BytesToAggregatePublicKey(b []byte) (*AggregatePublicKey, error) {
+	if len(b) == 0 || len(b)%PublicKeySize != 0 {
+		return nil, errors.Wrapf(ErrInvalidKey, "invalid aggregate public key length %d", len(b))
+	}
+	pks := make([]*PublicKey, 0, len(b)/PublicKeySize)
+	for i := 0; i < len(b); i += PublicKeySize {
+		pk, err := BytesToPublicKey(b[i : i+PublicKeySize])
+		if err != nil {
+			return nil, err
+		}
+		pks = append(pks, pk)
+	}
+	return NewAggregatePublicKey(pks), nil
+}
+
+// PublicKeys returns the public keys of the signers
+func (k *AggregatePublicKey) PublicKeys() []*PublicKey {
+	return k.pks
+}
+
+// Bytes returns the concatenated public keys of the signers
+func (k *AggregatePublicKey) Bytes() []byte {
+	var b bytes.Buffer
+	for _, pk := range k.pks {
+		b.Write(pk.Bytes())
+	}
+	return b.Bytes()
+}
+
+// HexString returns the concatenated public keys of the signers in hex string
+func (k *AggregatePublicKey) HexString() string {
+	return hex.EncodeToString(k.Bytes())
+}
+
+// EcdsaPublicKey returns nil, as a BLS public key is not an ECDSA key
+func (k *AggregatePublicKey) EcdsaPublicKey() interface{} {
+	return nil
+}
+
+// Hash returns the hash of the concatenated public keys
+func (k *AggregatePublicKey) Hash() []byte {
+	h := hash.Hash160b(k.Bytes())
+	return h[:]
+}
+
+// Verify verifies the aggregate signature of the message signed by all the signers
+func (k *AggregatePublicKey) Verify(msg, sig []byte) bool {
+	if len(k.pks) == 0 {
+		return false
+	}
+	var sum bls12381.G1Jac
+	sum.FromAffine(&k.pks[0].pk)
+	for _, pk := range k.pks[1:] {
+		sum.AddMixed(&pk.pk)
+	}
+	var apk bls12381.G1Affine
+	apk.FromJacobian(&sum)
+	return verify(&apk, msg, sig, _signatureDST)
+}
+
+// Address returns nil, as an aggregate public key does not stand for a single signer
+func (k *AggregatePublicKey) Address() address.Address {
+	return nil
+}
+
+// AggregateSignatures aggregates the signatures into one signature
+func AggregateSignatures(sigs [][]byte) ([]byte, error) {
+	if len(sigs) == 0 {
+		return nil, errors.Wrap(ErrInvalidSignature, "no signature to aggregate")
+	}
+	var sum bls12381.G2Jac
+	for i, b := range sigs {
+		sig, err := bytesToSignature(b)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			sum.FromAffine(sig)
+			continue
+		}
+		sum.AddMixed(sig)
+	}
+	var agg bls12381.G2Affine
+	agg.FromJacobian(&sum)
+	b := agg.Bytes()
+	return b[:], nil
+}
+
+func bytesToSignature(b []byte) (*bls12381.G2Affine, error) {
+	if len(b) != SignatureSize {
+		return nil, errors.Wrapf(ErrInvalidSignature, "invalid signature length %d", len(b))
+	}
+	sig := &bls12381.G2Affine{}
+	if _, err := sig.SetBytes(b); err != nil {
+		return nil, errors.Wrap(ErrInvalidSignature, err.Error())
+	}
+	return sig, nil
+}
+
+// verify checks e(pk, H(msg)) == e(g1, sig)
+func verify(pk *bls12381.G1Affine, msg, b, dst []byte) bool {
+	sig, err := bytesToSignature(b)
+	if err != nil {
+		return false
+	}
+	h, err := bls12381.HashToG2(msg, dst)
+	if err != nil {
+		return false
+	}
+	_, _, g1, _ := bls12381.Generators()
+	var negG1 bls12381.G1Affine
+	negG1.Neg(&g1)
+	ok, err := bls12381.PairingCheck([]bls12381.G1Affine{*pk, negG1}, []bls12381.G2Affine{h, *sig})
+	return err == nil && ok
+}
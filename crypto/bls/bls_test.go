@@ -0,0 +1,92 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package bls
+
+import (
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	require := require.New(t)
+	sk, err := GenerateKey()
+	require.NoError(err)
+	var _ crypto.PrivateKey = sk
+	msg := hash.Hash256b([]byte("block"))
+	sig, err := sk.Sign(msg[:])
+	require.NoError(err)
+	require.Len(sig, SignatureSize)
+	pk := sk.BLSPublicKey()
+	require.True(pk.Verify(msg[:], sig))
+	other := hash.Hash256b([]byte("other block"))
+	require.False(pk.Verify(other[:], sig))
+	require.False(pk.Verify(msg[:], sig[1:]))
+	require.NotNil(pk.Address())
+
+	// encoding
+	sk2, err := HexStringToPrivateKey(sk.HexString())
+	require.NoError(err)
+	require.True(sk2.BLSPublicKey().Equal(pk))
+	pk2, err := HexStringToPublicKey(pk.HexString())
+	require.NoError(err)
+	require.True(pk2.Equal(pk))
+	require.Equal(pk.Address().String(), pk2.Address().String())
+	_, err = BytesToPublicKey(pk.Bytes()[1:])
+	require.ErrorIs(err, ErrInvalidKey)
+	_, err = BytesToPrivateKey(make([]byte, PrivateKeySize))
+	require.ErrorIs(err, ErrInvalidKey)
+
+	// proof of possession
+	proof, err := sk.ProofOfPossession()
+	require.NoError(err)
+	require.True(pk.VerifyProofOfPossession(proof))
+	require.False(pk.Verify(pk.Bytes(), proof))
+	sk3, err := GenerateKey()
+	require.NoError(err)
+	require.False(sk3.BLSPublicKey().VerifyProofOfPossession(proof))
+}
+
+func TestAggregate(t *testing.T) {
+	require := require.New(t)
+	msg := hash.Hash256b([]byte("block"))
+	var (
+		pks  []*PublicKey
+		sigs [][]byte
+	)
+	for i := 0; i < 4; i++ {
+		sk, err := GenerateKey()
+		require.NoError(err)
+		sig, err := sk.Sign(msg[:])
+		require.NoError(err)
+		pks = append(pks, sk.BLSPublicKey())
+		sigs = append(sigs, sig)
+	}
+	agg, err := AggregateSignatures(sigs)
+	require.NoError(err)
+	require.Len(agg, SignatureSize)
+	apk := NewAggregatePublicKey(pks)
+	require.True(apk.Verify(msg[:], agg))
+	require.Nil(apk.Address())
+
+	// missing a signer
+	require.False(NewAggregatePublicKey(pks[:3]).Verify(msg[:], agg))
+	// different message
+	other := hash.Hash256b([]byte("other block"))
+	require.False(apk.Verify(other[:], agg))
+
+	// encoding
+	apk2, err := BytesToAggregatePublicKey(apk.Bytes())
+	require.NoError(err)
+	require.Len(apk2.PublicKeys(), 4)
+	require.True(apk2.Verify(msg[:], agg))
+	_, err = BytesToAggregatePublicKey(apk.Bytes()[1:])
+	require.ErrorIs(err, ErrInvalidKey)
+	_, err = AggregateSignatures(nil)
+	require.ErrorIs(err, ErrInvalidSignature)
+}
@@ -0,0 +1,255 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+// Package hsm implements the secp256k1 private keys held in the hardware security modules, e.g., HSM or YubiHSM,
+// through PKCS#11. The keys never leave the token, and the token is health checked so that the minting is paused if
+// the token disappears
+package hsm
+
+import (
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	cp "github.com/iotexproject/iotex-core/v2/crypto"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+const _defaultHealthCheckInterval = 5 * time.Second
+
+var (
+	_oidSecp256k1 = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+
+	// ErrHSM is the error of the HSM
+	ErrHSM = errors.New("hsm error")
+
+	// the PKCS#11 module is initialized once in the process, so that the token is shared by the configs of the same
+	// library and slot
+	_tokensMu sync.Mutex
+	_tokens   = map[string]*token{}
+)
+
+type (
+	// Config is the config of the keys held in the PKCS#11 token
+	Config struct {
+		// Library is the path of the PKCS#11 module, e.g., /usr/lib/softhsm/libsofthsm2.so
+		Library string `yaml:"library"`
+		// Slot is the id of the slot of the token, which is ignored if TokenLabel is set
+		Slot uint `yaml:"slot"`
+		// TokenLabel selects the slot by the label of the token
+		TokenLabel string `yaml:"tokenLabel"`
+		// Pin is the user pin of the token
+		Pin string `yaml:"pin"`
+		// KeyLabels are the labels of the secp256k1 key pairs
+		KeyLabels []string `yaml:"keyLabels"`
+		// HealthCheckInterval is the minimum interval between the health checks of the token
+		HealthCheckInterval time.Duration `yaml:"healthCheckInterval"`
+	}
+
+	// session is a logged-in session of the PKCS#11 token
+	session interface {
+		// Open opens the session and logs in
+		Open() error
+		// Close closes the session
+		Close()
+		// Alive checks the session is alive
+		Alive() error
+		// PublicKey returns CKA_EC_PARAMS and CKA_EC_POINT of the public key of the label
+		PublicKey(label string) ([]byte, []byte, error)
+		// Sign signs the digest with the private key of the label, returning R || S
+		Sign(label string, digest []byte) ([]byte, error)
+	}
+
+	// token serializes the use of the session, which supports a single operation at a time, and checks its health
+	token struct {
+		mu        sync.Mutex
+		session   session
+		interval  time.Duration
+		checkedAt time.Time
+		err       error
+	}
+
+	// PrivateKey is a secp256k1 private key held in the PKCS#11 token, which implements crypto.PrivateKey without
+	// exposing the key itself
+	PrivateKey struct {
+		token *token
+		label string
+		pub   crypto.PublicKey
+	}
+)
+
+// NewPrivateKeys creates the private keys of the labels in the token
+func NewPrivateKeys(cfg Config) ([]crypto.PrivateKey, error) {
+	if len(cfg.KeyLabels) == 0 {
+		return nil, errors.Wrap(ErrHSM, "no key label is set")
+	}
+	t, err := loadToken(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return newPrivateKeys(t, cfg.KeyLabels)
+}
+
+func loadToken(cfg Config) (*token, error) {
+	_tokensMu.Lock()
+	defer _tokensMu.Unlock()
+	id := fmt.Sprintf("%s:%d:%s", cfg.Library, cfg.Slot, cfg.TokenLabel)
+	if t, ok := _tokens[id]; ok {
+		return t, nil
+	}
+	s, err := newPKCS11Session(cfg)
+	if err != nil {
+		return nil, err
+	}
+	t, err := newToken(s, cfg.HealthCheckInterval)
+	if err != nil {
+		return nil, err
+	}
+	_tokens[id] = t
+	return t, nil
+}
+
+func newToken(s session, interval time.Duration) (*token, error) {
+	if err := s.Open(); err != nil {
+		return nil, errors.Wrap(err, "failed to open session")
+	}
+	if interval <= 0 {
+		interval = _defaultHealthCheckInterval
+	}
+	return &token{
+		session:   s,
+		interval:  interval,
+		checkedAt: time.Now(),
+	}, nil
+}
+
+func newPrivateKeys(t *token, labels []string) ([]crypto.PrivateKey, error) {
+	keys := make([]crypto.PrivateKey, 0, len(labels))
+	for _, label := range labels {
+		t.mu.Lock()
+		params, point, err := t.session.PublicKey(label)
+		t.mu.Unlock()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get public key %s", label)
+		}
+		pub, err := parsePublicKey(params, point)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid public key %s", label)
+		}
+		keys = append(keys, &PrivateKey{
+			token: t,
+			label: label,
+			pub:   pub,
+		})
+	}
+	return keys, nil
+}
+
+// healthy returns the error if the token is unavailable. The session is checked at most once per interval, and
+// re-opened if the token is back after it disappeared
+func (t *token) healthy() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := time.Now()
+	if now.Sub(t.checkedAt) < t.interval {
+		return t.err
+	}
+	t.checkedAt = now
+	err := t.session.Alive()
+	if err != nil {
+		t.session.Close()
+		err = t.session.Open()
+	}
+	switch {
+	case err != nil && t.err == nil:
+		log.L().Error("hsm token is unavailable.", zap.Error(err))
+	case err == nil && t.err != nil:
+		log.L().Info("hsm token is available again.")
+	}
+	t.err = err
+	return err
+}
+
+func (t *token) sign(label string, digest []byte) ([]byte, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	sig, err := t.session.Sign(label, digest)
+	if err != nil {
+		// check the session in the next health check
+		t.checkedAt = time.Time{}
+		return nil, err
+	}
+	return sig, nil
+}
+
+// Bytes returns nil, as the private key never leaves the token
+func (k *PrivateKey) Bytes() []byte {
+	return nil
+}
+
+// HexString returns an empty string, as the private key never leaves the token
+func (k *PrivateKey) HexString() string {
+	return ""
+}
+
+// EcdsaPrivateKey returns nil, as the private key never leaves the token
+func (k *PrivateKey) EcdsaPrivateKey() interface{} {
+	return nil
+}
+
+// PublicKey returns the public key
+func (k *PrivateKey) PublicKey() crypto.PublicKey {
+	return k.pub
+}
+
+// Sign signs the 32-byte hash in the token, returning the signature in [R || S || V] format
+func (k *PrivateKey) Sign(hash []byte) ([]byte, error) {
+	if len(hash) != 32 {
+		return nil, errors.Errorf("hash is required to be exactly 32 bytes (%d)", len(hash))
+	}
+	sig, err := k.token.sign(k.label, hash)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign in hsm")
+	}
+	if len(sig) != 64 {
+		return nil, errors.Wrapf(ErrHSM, "invalid signature length %d", len(sig))
+	}
+	rsv, err := cp.RecoverableSignature(hash, new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:]), k.pub.Bytes())
+	if err != nil {
+		return nil, errors.Wrap(ErrHSM, err.Error())
+	}
+	return rsv, nil
+}
+
+// Zero does nothing, as the private key never leaves the token
+func (k *PrivateKey) Zero() {}
+
+// Healthy returns the error if the token is unavailable for signing
+func (k *PrivateKey) Healthy() error {
+	return k.token.healthy()
+}
+
+// parsePublicKey parses the secp256k1 public key of CKA_EC_PARAMS and CKA_EC_POINT. CKA_EC_POINT is the DER encoded
+// octet string of the uncompressed point, while some tokens return the raw point
+func parsePublicKey(params, point []byte) (crypto.PublicKey, error) {
+	var curve asn1.ObjectIdentifier
+	if _, err := asn1.Unmarshal(params, &curve); err != nil || !curve.Equal(_oidSecp256k1) {
+		return nil, errors.Wrap(ErrHSM, "public key is not a secp256k1 key")
+	}
+	if len(point) != 65 {
+		var raw []byte
+		if _, err := asn1.Unmarshal(point, &raw); err != nil {
+			return nil, errors.Wrap(ErrHSM, "failed to parse ec point")
+		}
+		point = raw
+	}
+	return crypto.BytesToPublicKey(point)
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package hsm
+
+import (
+	"crypto/ecdsa"
+	"encoding/asn1"
+	"math/big"
+	"testing"
+	"time"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSession holds the keys in memory, which is unavailable if removed is set
+type fakeSession struct {
+	keys    map[string]crypto.PrivateKey
+	open    bool
+	removed bool
+	opens   int
+	rawEC   bool
+}
+
+func (s *fakeSession) Open() error {
+	if s.removed {
+		return errors.Wrap(ErrHSM, "token is not present")
+	}
+	s.open = true
+	s.opens++
+	return nil
+}
+
+func (s *fakeSession) Close() {
+	s.open = false
+}
+
+func (s *fakeSession) Alive() error {
+	if !s.open || s.removed {
+		return errors.Wrap(ErrHSM, "session is closed")
+	}
+	return nil
+}
+
+func (s *fakeSession) PublicKey(label string) ([]byte, []byte, error) {
+	sk, ok := s.keys[label]
+	if !ok {
+		return nil, nil, errors.Wrapf(ErrHSM, "key %s is not found", label)
+	}
+	params, err := asn1.Marshal(_oidSecp256k1)
+	if err != nil {
+		return nil, nil, err
+	}
+	if s.rawEC {
+		return params, sk.PublicKey().Bytes(), nil
+	}
+	point, err := asn1.Marshal(sk.PublicKey().Bytes())
+	if err != nil {
+		return nil, nil, err
+	}
+	return params, point, nil
+}
+
+func (s *fakeSession) Sign(label string, digest []byte) ([]byte, error) {
+	if err := s.Alive(); err != nil {
+		return nil, err
+	}
+	sig, err := ethcrypto.Sign(digest, s.keys[label].EcdsaPrivateKey().(*ecdsa.PrivateKey))
+	if err != nil {
+		return nil, err
+	}
+	// the token may return the signature of high S
+	ss := new(big.Int).Sub(ethcrypto.S256().Params().N, new(big.Int).SetBytes(sig[32:64]))
+	return append(sig[:32:32], ss.FillBytes(make([]byte, 32))...), nil
+}
+
+func TestPrivateKey(t *testing.T) {
+	r := require.New(t)
+	sk, err := crypto.GenerateKey()
+	r.NoError(err)
+	h := hash.Hash256b([]byte("block"))
+
+	for _, rawEC := range []bool{false, true} {
+		s := &fakeSession{keys: map[string]crypto.PrivateKey{"producer": sk}, rawEC: rawEC}
+		tk, err := newToken(s, time.Hour)
+		r.NoError(err)
+		keys, err := newPrivateKeys(tk, []string{"producer"})
+		r.NoError(err)
+		r.Len(keys, 1)
+		key := keys[0]
+		r.Equal(sk.PublicKey().HexString(), key.PublicKey().HexString())
+		r.Nil(key.Bytes())
+		r.Empty(key.HexString())
+		r.Nil(key.EcdsaPrivateKey())
+		sig, err := key.Sign(h[:])
+		r.NoError(err)
+		expected, err := sk.Sign(h[:])
+		r.NoError(err)
+		r.Equal(expected, sig)
+		r.True(sk.PublicKey().Verify(h[:], sig))
+		_, err = key.Sign(h[:31])
+		r.Error(err)
+
+		_, err = newPrivateKeys(tk, []string{"unknown"})
+		r.ErrorIs(err, ErrHSM)
+	}
+
+	// P-256 key is rejected
+	params, err := asn1.Marshal(asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7})
+	r.NoError(err)
+	_, err = parsePublicKey(params, sk.PublicKey().Bytes())
+	r.ErrorIs(err, ErrHSM)
+
+	_, err = NewPrivateKeys(Config{Library: "/nonexistent/libpkcs11.so"})
+	r.ErrorIs(err, ErrHSM)
+	_, err = NewPrivateKeys(Config{Library: "/nonexistent/libpkcs11.so", KeyLabels: []string{"producer"}})
+	r.ErrorIs(err, ErrHSM)
+}
+
+func TestHealthy(t *testing.T) {
+	r := require.New(t)
+	sk, err := crypto.GenerateKey()
+	r.NoError(err)
+	h := hash.Hash256b([]byte("block"))
+	s := &fakeSession{keys: map[string]crypto.PrivateKey{"producer": sk}}
+	tk, err := newToken(s, time.Millisecond)
+	r.NoError(err)
+	keys, err := newPrivateKeys(tk, []string{"producer"})
+	r.NoError(err)
+	key := keys[0].(*PrivateKey)
+	time.Sleep(2 * time.Millisecond)
+	r.NoError(key.Healthy())
+
+	// the token disappears
+	s.removed = true
+	_, err = key.Sign(h[:])
+	r.ErrorIs(err, ErrHSM)
+	r.ErrorIs(key.Healthy(), ErrHSM)
+	r.False(s.open)
+
+	// the token is back, the session is re-opened
+	s.removed = false
+	time.Sleep(2 * time.Millisecond)
+	r.NoError(key.Healthy())
+	r.True(s.open)
+	r.Equal(2, s.opens)
+	_, err = key.Sign(h[:])
+	r.NoError(err)
+
+	// the result is cached within the interval
+	tk.interval = time.Hour
+	s.removed = true
+	r.NoError(key.Healthy())
+}
+
+func TestNewPrivateKeys(t *testing.T) {
+	r := require.New(t)
+	_, err := NewPrivateKeys(Config{Library: "/nonexistent/libpkcs11.so"})
+	r.ErrorIs(err, ErrHSM)
+	_, err = NewPrivateKeys(Config{Library: "/nonexistent/libpkcs11.so", KeyLabels: []string{"producer"}})
+	r.ErrorIs(err, ErrHSM)
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+//go:build cgo
+
+package hsm
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/miekg/pkcs11"
+	"github.com/pkg/errors"
+)
+
+var (
+	// the contexts of the modules loaded, as a module is initialized once in the process
+	_modulesMu sync.Mutex
+	_modules   = map[string]*pkcs11.Ctx{}
+)
+
+type pkcs11Session struct {
+	ctx    *pkcs11.Ctx
+	cfg    Config
+	handle pkcs11.SessionHandle
+	// the handles of the private keys found in the session
+	keys map[string]pkcs11.ObjectHandle
+}
+
+func newPKCS11Session(cfg Config) (session, error) {
+	ctx, err := loadModule(cfg.Library)
+	if err != nil {
+		return nil, err
+	}
+	return &pkcs11Session{
+		ctx: ctx,
+		cfg: cfg,
+	}, nil
+}
+
+func loadModule(path string) (*pkcs11.Ctx, error) {
+	_modulesMu.Lock()
+	defer _modulesMu.Unlock()
+	if ctx, ok := _modules[path]; ok {
+		return ctx, nil
+	}
+	ctx := pkcs11.New(path)
+	if ctx == nil {
+		return nil, errors.Wrapf(ErrHSM, "failed to load pkcs11 module %s", path)
+	}
+	if err := ctx.Initialize(); err != nil && !errors.Is(err, pkcs11.Error(pkcs11.CKR_CRYPTOKI_ALREADY_INITIALIZED)) {
+		ctx.Destroy()
+		return nil, errors.Wrapf(ckError(err), "failed to initialize pkcs11 module %s", path)
+	}
+	_modules[path] = ctx
+	return ctx, nil
+}
+
+func (s *pkcs11Session) Open() error {
+	slot, err := s.slot()
+	if err != nil {
+		return err
+	}
+	handle, err := s.ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION)
+	if err != nil {
+		return errors.Wrap(ckError(err), "failed to open pkcs11 session")
+	}
+	if err := s.ctx.Login(handle, pkcs11.CKU_USER, s.cfg.Pin); err != nil && !errors.Is(err, pkcs11.Error(pkcs11.CKR_USER_ALREADY_LOGGED_IN)) {
+		s.ctx.CloseSession(handle)
+		return errors.Wrap(ckError(err), "failed to log in pkcs11 session")
+	}
+	s.handle, s.keys = handle, map[string]pkcs11.ObjectHandle{}
+	return nil
+}
+
+func (s *pkcs11Session) Close() {
+	if s.keys == nil {
+		return
+	}
+	s.ctx.CloseSession(s.handle)
+	s.keys = nil
+}
+
+func (s *pkcs11Session) Alive() error {
+	if s.keys == nil {
+		return errors.Wrap(ErrHSM, "session is closed")
+	}
+	_, err := s.ctx.GetSessionInfo(s.handle)
+	return ckError(err)
+}
+
+func (s *pkcs11Session) PublicKey(label string) ([]byte, []byte, error) {
+	obj, err := s.find(pkcs11.CKO_PUBLIC_KEY, label)
+	if err != nil {
+		return nil, nil, err
+	}
+	attrs, err := s.ctx.GetAttributeValue(s.handle, obj, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_EC_PARAMS, nil),
+		pkcs11.NewAttribute(pkcs11.CKA_EC_POINT, nil),
+	})
+	if err != nil {
+		return nil, nil, errors.Wrapf(ckError(err), "failed to get public key %s", label)
+	}
+	if len(attrs) != 2 {
+		return nil, nil, errors.Wrapf(ErrHSM, "public key %s has %d attributes", label, len(attrs))
+	}
+	return attrs[0].Value, attrs[1].Value, nil
+}
+
+func (s *pkcs11Session) Sign(label string, digest []byte) ([]byte, error) {
+	obj, ok := s.keys[label]
+	if !ok {
+		var err error
+		if obj, err = s.find(pkcs11.CKO_PRIVATE_KEY, label); err != nil {
+			return nil, err
+		}
+		s.keys[label] = obj
+	}
+	if err := s.ctx.SignInit(s.handle, []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_ECDSA, nil)}, obj); err != nil {
+		return nil, ckError(err)
+	}
+	sig, err := s.ctx.Sign(s.handle, digest)
+	if err != nil {
+		return nil, ckError(err)
+	}
+	return sig, nil
+}
+
+// slot returns the slot of the token label if it is set, otherwise the slot configured
+func (s *pkcs11Session) slot() (uint, error) {
+	if s.cfg.TokenLabel == "" {
+		return s.cfg.Slot, nil
+	}
+	slots, err := s.ctx.GetSlotList(true)
+	if err != nil {
+		return 0, errors.Wrap(ckError(err), "failed to get slots")
+	}
+	if len(slots) == 0 {
+		return 0, errors.Wrap(ErrHSM, "no token is present")
+	}
+	for _, slot := range slots {
+		info, err := s.ctx.GetTokenInfo(slot)
+		if err != nil {
+			continue
+		}
+		// the label is padded with blank characters
+		if strings.TrimRight(info.Label, " \x00") == s.cfg.TokenLabel {
+			return slot, nil
+		}
+	}
+	return 0, errors.Wrapf(ErrHSM, "token %s is not present", s.cfg.TokenLabel)
+}
+
+func (s *pkcs11Session) find(class uint, label string) (pkcs11.ObjectHandle, error) {
+	if s.keys == nil {
+		return 0, errors.Wrap(ErrHSM, "session is closed")
+	}
+	if err := s.ctx.FindObjectsInit(s.handle, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, class),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, label),
+	}); err != nil {
+		return 0, errors.Wrapf(ckError(err), "failed to find key %s", label)
+	}
+	objs, _, err := s.ctx.FindObjects(s.handle, 1)
+	if finalErr := s.ctx.FindObjectsFinal(s.handle); err == nil {
+		err = finalErr
+	}
+	if err != nil {
+		return 0, errors.Wrapf(ckError(err), "failed to find key %s", label)
+	}
+	if len(objs) == 0 {
+		return 0, errors.Wrapf(ErrHSM, "key %s is not found", label)
+	}
+	return objs[0], nil
+}
+
+func ckError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return errors.Wrap(ErrHSM, err.Error())
+}
@@ -0,0 +1,14 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+//go:build !cgo
+
+package hsm
+
+import "github.com/pkg/errors"
+
+func newPKCS11Session(Config) (session, error) {
+	return nil, errors.Wrap(ErrHSM, "pkcs11 requires cgo")
+}
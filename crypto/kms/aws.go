@@ -0,0 +1,94 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package kms
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	awskms "github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/kms/types"
+	"github.com/pkg/errors"
+)
+
+type (
+	// AWSConfig is the config of the keys held in AWS KMS. The credentials and the region fall back to the default
+	// credential chain of the AWS SDK, e.g., the environment variables, the shared config or the instance role, if
+	// they are not set
+	AWSConfig struct {
+		Region          string `yaml:"region"`
+		AccessKeyID     string `yaml:"accessKeyID"`
+		SecretAccessKey string `yaml:"secretAccessKey"`
+		SessionToken    string `yaml:"sessionToken"`
+		// Endpoint overrides the default endpoint https://kms.<region>.amazonaws.com, e.g., a VPC endpoint
+		Endpoint string `yaml:"endpoint"`
+		// KeyIDs are the ids or the arns of the keys, whose key spec is ECC_SECG_P256K1
+		KeyIDs []string `yaml:"keyIDs"`
+	}
+
+	awsClient struct {
+		keyID  string
+		client *awskms.Client
+	}
+)
+
+// NewAWSClient creates the client of the key in AWS KMS
+func NewAWSClient(cfg AWSConfig, keyID string) (Client, error) {
+	opts := []func(*awsconfig.LoadOptions) error{
+		awsconfig.WithRegion(cfg.Region),
+		awsconfig.WithHTTPClient(awshttp.NewBuildableClient().WithTimeout(_requestTimeout)),
+	}
+	if cfg.AccessKeyID != "" {
+		opts = append(opts, awsconfig.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(cfg.AccessKeyID, cfg.SecretAccessKey, cfg.SessionToken)))
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), _requestTimeout)
+	defer cancel()
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load aws config")
+	}
+	if awsCfg.Region == "" {
+		return nil, errors.Wrap(ErrKMS, "aws region is not set")
+	}
+	return &awsClient{
+		keyID: keyID,
+		client: awskms.NewFromConfig(awsCfg, func(o *awskms.Options) {
+			if cfg.Endpoint != "" {
+				o.BaseEndpoint = aws.String(cfg.Endpoint)
+			}
+		}),
+	}, nil
+}
+
+func (c *awsClient) PublicKey(ctx context.Context) ([]byte, error) {
+	res, err := c.client.GetPublicKey(ctx, &awskms.GetPublicKeyInput{
+		KeyId: aws.String(c.keyID),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to call aws kms")
+	}
+	if res.KeySpec != types.KeySpecEccSecgP256k1 {
+		return nil, errors.Wrapf(ErrKMS, "key spec %s is not %s", res.KeySpec, types.KeySpecEccSecgP256k1)
+	}
+	return res.PublicKey, nil
+}
+
+func (c *awsClient) SignDigest(ctx context.Context, digest []byte) ([]byte, error) {
+	res, err := c.client.Sign(ctx, &awskms.SignInput{
+		KeyId:            aws.String(c.keyID),
+		Message:          digest,
+		MessageType:      types.MessageTypeDigest,
+		SigningAlgorithm: types.SigningAlgorithmSpecEcdsaSha256,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to call aws kms")
+	}
+	return res.Signature, nil
+}
@@ -0,0 +1,185 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package kms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"hash/crc32"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+const (
+	_gcpEndpoint  = "https://cloudkms.googleapis.com"
+	_gcpScope     = "https://www.googleapis.com/auth/cloudkms"
+	_gcpAlgorithm = "EC_SIGN_SECP256K1_SHA256"
+)
+
+var _crc32c = crc32.MakeTable(crc32.Castagnoli)
+
+type (
+	// GCPConfig is the config of the keys held in GCP Cloud KMS. The application default credentials, e.g., the
+	// service account of the instance, are used if the access token is not set
+	GCPConfig struct {
+		AccessToken string `yaml:"accessToken"`
+		// Endpoint overrides the default endpoint https://cloudkms.googleapis.com
+		Endpoint string `yaml:"endpoint"`
+		// KeyVersions are the resource names of the key versions, whose algorithm is EC_SIGN_SECP256K1_SHA256, e.g.,
+		// projects/<project>/locations/<location>/keyRings/<ring>/cryptoKeys/<key>/cryptoKeyVersions/<version>
+		KeyVersions []string `yaml:"keyVersions"`
+	}
+
+	gcpClient struct {
+		endpoint string
+		name     string
+		client   *http.Client
+	}
+
+	// gcpChecksum is the CRC32C checksum, which is encoded as a decimal string in JSON
+	gcpChecksum int64
+)
+
+// NewGCPClients creates the clients of the key versions in GCP Cloud KMS, which share the token source
+func NewGCPClients(cfg GCPConfig) ([]Client, error) {
+	var tokens oauth2.TokenSource
+	if cfg.AccessToken != "" {
+		tokens = oauth2.StaticTokenSource(&oauth2.Token{AccessToken: cfg.AccessToken})
+	} else {
+		ctx, cancel := context.WithTimeout(context.Background(), _requestTimeout)
+		defer cancel()
+		var err error
+		if tokens, err = google.DefaultTokenSource(ctx, _gcpScope); err != nil {
+			return nil, errors.Wrap(err, "failed to find gcp default credentials")
+		}
+	}
+	return newGCPClients(cfg, tokens), nil
+}
+
+func newGCPClients(cfg GCPConfig, tokens oauth2.TokenSource) []Client {
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = _gcpEndpoint
+	}
+	client := &http.Client{
+		Timeout: _requestTimeout,
+		Transport: &oauth2.Transport{
+			Source: oauth2.ReuseTokenSource(nil, tokens),
+			Base:   http.DefaultTransport,
+		},
+	}
+	clients := make([]Client, 0, len(cfg.KeyVersions))
+	for _, name := range cfg.KeyVersions {
+		clients = append(clients, &gcpClient{
+			endpoint: cfg.Endpoint,
+			name:     name,
+			client:   client,
+		})
+	}
+	return clients
+}
+
+func (c *gcpClient) PublicKey(ctx context.Context) ([]byte, error) {
+	var res struct {
+		Pem       string       `json:"pem"`
+		Algorithm string       `json:"algorithm"`
+		PemCrc32c *gcpChecksum `json:"pemCrc32c"`
+	}
+	if err := c.call(ctx, http.MethodGet, c.endpoint+"/v1/"+c.name+"/publicKey", nil, &res); err != nil {
+		return nil, err
+	}
+	if res.Algorithm != _gcpAlgorithm {
+		return nil, errors.Wrapf(ErrKMS, "algorithm %s is not %s", res.Algorithm, _gcpAlgorithm)
+	}
+	// the checksums guard against the corruption in transit, as recommended by Cloud KMS
+	if res.PemCrc32c == nil || *res.PemCrc32c != checksum([]byte(res.Pem)) {
+		return nil, errors.Wrap(ErrKMS, "public key checksum mismatch")
+	}
+	return []byte(res.Pem), nil
+}
+
+func (c *gcpClient) SignDigest(ctx context.Context, digest []byte) ([]byte, error) {
+	var res struct {
+		Signature            []byte       `json:"signature"`
+		SignatureCrc32c      *gcpChecksum `json:"signatureCrc32c"`
+		VerifiedDigestCrc32c bool         `json:"verifiedDigestCrc32c"`
+		Name                 string       `json:"name"`
+	}
+	req := map[string]any{
+		"digest":       map[string][]byte{"sha256": digest},
+		"digestCrc32c": checksum(digest),
+	}
+	if err := c.call(ctx, http.MethodPost, c.endpoint+"/v1/"+c.name+":asymmetricSign", req, &res); err != nil {
+		return nil, err
+	}
+	if !res.VerifiedDigestCrc32c {
+		return nil, errors.Wrap(ErrKMS, "digest checksum is not verified")
+	}
+	if res.Name != c.name {
+		return nil, errors.Wrapf(ErrKMS, "signed by key %s", res.Name)
+	}
+	if res.SignatureCrc32c == nil || *res.SignatureCrc32c != checksum(res.Signature) {
+		return nil, errors.Wrap(ErrKMS, "signature checksum mismatch")
+	}
+	return res.Signature, nil
+}
+
+func (c *gcpClient) call(ctx context.Context, method, url string, req, res any) error {
+	var body io.Reader
+	if req != nil {
+		b, err := json.Marshal(req)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return err
+	}
+	if req != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	httpRes, err := c.client.Do(httpReq)
+	if err != nil {
+		return errors.Wrap(err, "failed to call gcp kms")
+	}
+	defer httpRes.Body.Close()
+	b, err := io.ReadAll(httpRes.Body)
+	if err != nil {
+		return err
+	}
+	if httpRes.StatusCode != http.StatusOK {
+		return errors.Wrapf(ErrKMS, "%s returns %d: %s", httpReq.URL.Path, httpRes.StatusCode, b)
+	}
+	return json.Unmarshal(b, res)
+}
+
+func (c gcpChecksum) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strconv.FormatInt(int64(c), 10))
+}
+
+func (c *gcpChecksum) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return err
+	}
+	*c = gcpChecksum(v)
+	return nil
+}
+
+func checksum(b []byte) gcpChecksum {
+	return gcpChecksum(crc32.Checksum(b, _crc32c))
+}
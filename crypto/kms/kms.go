@@ -0,0 +1,146 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+// Package kms implements the secp256k1 private keys held in the cloud key management services, the signing requests
+// are forwarded to the KMS so that the keys never enter the memory of the node. The DER signatures returned by the KMS
+// are adapted to the 65-byte [R || S || V] format with the recovery id
+package kms
+
+import (
+	"context"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"math/big"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/pkg/errors"
+
+	cp "github.com/iotexproject/iotex-core/v2/crypto"
+)
+
+const _requestTimeout = 10 * time.Second
+
+var (
+	_oidECPublicKey = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	_oidSecp256k1   = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+
+	// ErrKMS is the error of the KMS
+	ErrKMS = errors.New("kms error")
+)
+
+type (
+	// Client signs the digests with a secp256k1 key held in a KMS
+	Client interface {
+		// PublicKey returns the public key in DER or PEM encoded SubjectPublicKeyInfo
+		PublicKey(ctx context.Context) ([]byte, error)
+		// SignDigest signs the 32-byte digest, returning the DER encoded ECDSA signature
+		SignDigest(ctx context.Context, digest []byte) ([]byte, error)
+	}
+
+	// PrivateKey is a secp256k1 private key held in a KMS, which implements crypto.PrivateKey without exposing the
+	// key itself
+	PrivateKey struct {
+		client Client
+		pub    crypto.PublicKey
+	}
+
+	subjectPublicKeyInfo struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+
+	ecdsaSignature struct {
+		R, S *big.Int
+	}
+)
+
+// NewPrivateKey creates the private key of the client, whose public key is fetched from the KMS
+func NewPrivateKey(client Client) (*PrivateKey, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), _requestTimeout)
+	defer cancel()
+	b, err := client.PublicKey(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get public key from kms")
+	}
+	pub, err := parsePublicKey(b)
+	if err != nil {
+		return nil, err
+	}
+	return &PrivateKey{
+		client: client,
+		pub:    pub,
+	}, nil
+}
+
+// Bytes returns nil, as the private key never leaves the KMS
+func (k *PrivateKey) Bytes() []byte {
+	return nil
+}
+
+// HexString returns an empty string, as the private key never leaves the KMS
+func (k *PrivateKey) HexString() string {
+	return ""
+}
+
+// EcdsaPrivateKey returns nil, as the private key never leaves the KMS
+func (k *PrivateKey) EcdsaPrivateKey() interface{} {
+	return nil
+}
+
+// PublicKey returns the public key
+func (k *PrivateKey) PublicKey() crypto.PublicKey {
+	return k.pub
+}
+
+// Sign signs the 32-byte hash in the KMS, returning the signature in [R || S || V] format
+func (k *PrivateKey) Sign(hash []byte) ([]byte, error) {
+	if len(hash) != 32 {
+		return nil, errors.Errorf("hash is required to be exactly 32 bytes (%d)", len(hash))
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), _requestTimeout)
+	defer cancel()
+	der, err := k.client.SignDigest(ctx, hash)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign in kms")
+	}
+	return recoverableSignature(hash, der, k.pub.Bytes())
+}
+
+// Zero does nothing, as the private key never leaves the KMS
+func (k *PrivateKey) Zero() {}
+
+// parsePublicKey parses the DER or PEM encoded SubjectPublicKeyInfo of a secp256k1 public key
+func parsePublicKey(b []byte) (crypto.PublicKey, error) {
+	if block, _ := pem.Decode(b); block != nil {
+		b = block.Bytes
+	}
+	var spki subjectPublicKeyInfo
+	if _, err := asn1.Unmarshal(b, &spki); err != nil {
+		return nil, errors.Wrap(ErrKMS, "failed to parse public key")
+	}
+	var curve asn1.ObjectIdentifier
+	if !spki.Algorithm.Algorithm.Equal(_oidECPublicKey) {
+		return nil, errors.Wrap(ErrKMS, "public key is not an EC key")
+	}
+	if _, err := asn1.Unmarshal(spki.Algorithm.Parameters.FullBytes, &curve); err != nil || !curve.Equal(_oidSecp256k1) {
+		return nil, errors.Wrap(ErrKMS, "public key is not a secp256k1 key")
+	}
+	return crypto.BytesToPublicKey(spki.PublicKey.RightAlign())
+}
+
+// recoverableSignature converts the DER signature to [R || S || V]
+func recoverableSignature(hash, der, pub []byte) ([]byte, error) {
+	var sig ecdsaSignature
+	if rest, err := asn1.Unmarshal(der, &sig); err != nil || len(rest) > 0 {
+		return nil, errors.Wrap(ErrKMS, "failed to parse signature")
+	}
+	rsv, err := cp.RecoverableSignature(hash, sig.R, sig.S, pub)
+	if err != nil {
+		return nil, errors.Wrap(ErrKMS, err.Error())
+	}
+	return rsv, nil
+}
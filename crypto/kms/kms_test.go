@@ -0,0 +1,264 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package kms
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClient signs with a local key, returning the DER signature of high S if highS is set
+type fakeClient struct {
+	sk    crypto.PrivateKey
+	highS bool
+}
+
+func (c *fakeClient) PublicKey(context.Context) ([]byte, error) {
+	return marshalPublicKey(c.sk.PublicKey())
+}
+
+func (c *fakeClient) SignDigest(_ context.Context, digest []byte) ([]byte, error) {
+	return signDER(c.sk, digest, c.highS)
+}
+
+func marshalPublicKey(pk crypto.PublicKey) ([]byte, error) {
+	params, err := asn1.Marshal(_oidSecp256k1)
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(subjectPublicKeyInfo{
+		Algorithm: pkix.AlgorithmIdentifier{
+			Algorithm:  _oidECPublicKey,
+			Parameters: asn1.RawValue{FullBytes: params},
+		},
+		PublicKey: asn1.BitString{Bytes: pk.Bytes(), BitLength: 8 * len(pk.Bytes())},
+	})
+}
+
+func signDER(sk crypto.PrivateKey, digest []byte, highS bool) ([]byte, error) {
+	sig, err := ethcrypto.Sign(digest, sk.EcdsaPrivateKey().(*ecdsa.PrivateKey))
+	if err != nil {
+		return nil, err
+	}
+	r, s := new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:64])
+	if highS {
+		s.Sub(ethcrypto.S256().Params().N, s)
+	}
+	return asn1.Marshal(ecdsaSignature{R: r, S: s})
+}
+
+func TestPrivateKey(t *testing.T) {
+	r := require.New(t)
+	sk, err := crypto.GenerateKey()
+	r.NoError(err)
+	h := hash.Hash256b([]byte("block"))
+
+	for _, highS := range []bool{false, true} {
+		key, err := NewPrivateKey(&fakeClient{sk: sk, highS: highS})
+		r.NoError(err)
+		r.Equal(sk.PublicKey().HexString(), key.PublicKey().HexString())
+		r.Nil(key.Bytes())
+		r.Empty(key.HexString())
+		r.Nil(key.EcdsaPrivateKey())
+
+		sig, err := key.Sign(h[:])
+		r.NoError(err)
+		r.Len(sig, 65)
+		r.True(key.PublicKey().Verify(h[:], sig))
+		recovered, err := crypto.RecoverPubkey(h[:], sig)
+		r.NoError(err)
+		r.Equal(sk.PublicKey().HexString(), recovered.HexString())
+		expected, err := sk.Sign(h[:])
+		r.NoError(err)
+		r.Equal(expected, sig)
+	}
+
+	key, err := NewPrivateKey(&fakeClient{sk: sk})
+	r.NoError(err)
+	_, err = key.Sign(h[:31])
+	r.Error(err)
+
+	// the signature of another key is rejected
+	other, err := crypto.GenerateKey()
+	r.NoError(err)
+	der, err := signDER(other, h[:], false)
+	r.NoError(err)
+	_, err = recoverableSignature(h[:], der, sk.PublicKey().Bytes())
+	r.ErrorIs(err, ErrKMS)
+	_, err = recoverableSignature(h[:], []byte{1, 2, 3}, sk.PublicKey().Bytes())
+	r.ErrorIs(err, ErrKMS)
+}
+
+func TestParsePublicKey(t *testing.T) {
+	r := require.New(t)
+	sk, err := crypto.GenerateKey()
+	r.NoError(err)
+	der, err := marshalPublicKey(sk.PublicKey())
+	r.NoError(err)
+	pk, err := parsePublicKey(der)
+	r.NoError(err)
+	r.Equal(sk.PublicKey().HexString(), pk.HexString())
+	pk, err = parsePublicKey(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	r.NoError(err)
+	r.Equal(sk.PublicKey().HexString(), pk.HexString())
+
+	// P-256 key is rejected
+	params, err := asn1.Marshal(asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7})
+	r.NoError(err)
+	p256, err := asn1.Marshal(subjectPublicKeyInfo{
+		Algorithm: pkix.AlgorithmIdentifier{Algorithm: _oidECPublicKey, Parameters: asn1.RawValue{FullBytes: params}},
+		PublicKey: asn1.BitString{Bytes: sk.PublicKey().Bytes(), BitLength: 520},
+	})
+	r.NoError(err)
+	_, err = parsePublicKey(p256)
+	r.ErrorIs(err, ErrKMS)
+	_, err = parsePublicKey([]byte("invalid"))
+	r.ErrorIs(err, ErrKMS)
+}
+
+func TestAWSClient(t *testing.T) {
+	r := require.New(t)
+	sk, err := crypto.GenerateKey()
+	r.NoError(err)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !strings.HasPrefix(req.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=akid/") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		var body map[string]string
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil || body["KeyId"] != "alias/producer" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var res any
+		switch req.Header.Get("X-Amz-Target") {
+		case "TrentService.GetPublicKey":
+			pub, _ := marshalPublicKey(sk.PublicKey())
+			res = map[string]any{"PublicKey": pub, "KeySpec": "ECC_SECG_P256K1"}
+		case "TrentService.Sign":
+			if body["MessageType"] != "DIGEST" || body["SigningAlgorithm"] != "ECDSA_SHA_256" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			digest, _ := base64.StdEncoding.DecodeString(body["Message"])
+			sig, _ := signDER(sk, digest, true)
+			res = map[string]any{"Signature": sig}
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		r.NoError(json.NewEncoder(w).Encode(res))
+	}))
+	defer srv.Close()
+
+	// the region is not found in the environment
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_DEFAULT_REGION", "")
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(t.TempDir(), "config"))
+	_, err = NewAWSClient(AWSConfig{AccessKeyID: "akid", SecretAccessKey: "secret"}, "alias/producer")
+	r.ErrorIs(err, ErrKMS)
+	cli, err := NewAWSClient(AWSConfig{
+		Region:          "us-east-1",
+		AccessKeyID:     "akid",
+		SecretAccessKey: "secret",
+		Endpoint:        srv.URL,
+	}, "alias/producer")
+	r.NoError(err)
+	key, err := NewPrivateKey(cli)
+	r.NoError(err)
+	r.Equal(sk.PublicKey().HexString(), key.PublicKey().HexString())
+	h := hash.Hash256b([]byte("block"))
+	sig, err := key.Sign(h[:])
+	r.NoError(err)
+	r.True(sk.PublicKey().Verify(h[:], sig))
+}
+
+func TestGCPClient(t *testing.T) {
+	r := require.New(t)
+	sk, err := crypto.GenerateKey()
+	r.NoError(err)
+	name := "projects/p/locations/global/keyRings/r/cryptoKeys/k/cryptoKeyVersions/1"
+	corrupt := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var res any
+		switch req.URL.Path {
+		case "/v1/" + name + "/publicKey":
+			pub, _ := marshalPublicKey(sk.PublicKey())
+			b := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pub})
+			res = map[string]any{
+				"pem":       string(b),
+				"algorithm": _gcpAlgorithm,
+				"pemCrc32c": checksum(b),
+			}
+		case "/v1/" + name + ":asymmetricSign":
+			var body struct {
+				Digest struct {
+					Sha256 []byte `json:"sha256"`
+				} `json:"digest"`
+				DigestCrc32c gcpChecksum `json:"digestCrc32c"`
+			}
+			r.NoError(json.NewDecoder(req.Body).Decode(&body))
+			sig, _ := signDER(sk, body.Digest.Sha256, false)
+			sum := checksum(sig)
+			if corrupt {
+				sig[len(sig)-1] ^= 1
+			}
+			res = map[string]any{
+				"signature":            sig,
+				"signatureCrc32c":      sum,
+				"verifiedDigestCrc32c": body.DigestCrc32c == checksum(body.Digest.Sha256),
+				"name":                 name,
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		r.NoError(json.NewEncoder(w).Encode(res))
+	}))
+	defer srv.Close()
+
+	h := hash.Hash256b([]byte("block"))
+	clients, err := NewGCPClients(GCPConfig{AccessToken: "token", Endpoint: srv.URL, KeyVersions: []string{name}})
+	r.NoError(err)
+	r.Len(clients, 1)
+	key, err := NewPrivateKey(clients[0])
+	r.NoError(err)
+	r.Equal(sk.PublicKey().HexString(), key.PublicKey().HexString())
+	sig, err := key.Sign(h[:])
+	r.NoError(err)
+	r.True(sk.PublicKey().Verify(h[:], sig))
+
+	// the signature corrupted in transit is rejected
+	corrupt = true
+	_, err = key.Sign(h[:])
+	r.ErrorIs(err, ErrKMS)
+
+	clients, err = NewGCPClients(GCPConfig{AccessToken: "token", Endpoint: srv.URL, KeyVersions: []string{name + "0"}})
+	r.NoError(err)
+	_, err = NewPrivateKey(clients[0])
+	r.ErrorIs(err, ErrKMS)
+}
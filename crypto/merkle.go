@@ -7,6 +7,7 @@ package crypto
 
 import (
 	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
 )
 
 // Merkle tree struct
@@ -78,3 +79,44 @@ func (mk *Merkle) HashTree() hash.Hash256 {
 	mk.root = merkle[0]
 	return mk.root
 }
+
+// Proof returns the hashes of the siblings on the path from the leaf at index to the root
+func (mk *Merkle) Proof(index int) ([]hash.Hash256, error) {
+	if index < 0 || index >= mk.size {
+		return nil, errors.Errorf("leaf index %d out of range [0, %d)", index, mk.size)
+	}
+	if mk.size == 1 {
+		return nil, nil
+	}
+	var (
+		level = append([]hash.Hash256{}, mk.leaf[:mk.size]...)
+		proof []hash.Hash256
+	)
+	for len(level) > 1 {
+		if len(level)&1 != 0 {
+			level = append(level, level[len(level)-1])
+		}
+		proof = append(proof, level[index^1])
+		next := make([]hash.Hash256, len(level)>>1)
+		for i := range next {
+			next[i] = hash.Hash256b(append(level[i<<1][:], level[i<<1+1][:]...))
+		}
+		level = next
+		index >>= 1
+	}
+	return proof, nil
+}
+
+// VerifyMerkleProof verifies the leaf at index is in the merkle tree of the root with the proof
+func VerifyMerkleProof(root, leaf hash.Hash256, index uint64, proof []hash.Hash256) bool {
+	h := leaf
+	for _, sibling := range proof {
+		if index&1 == 0 {
+			h = hash.Hash256b(append(h[:], sibling[:]...))
+		} else {
+			h = hash.Hash256b(append(sibling[:], h[:]...))
+		}
+		index >>= 1
+	}
+	return index == 0 && h == root
+}
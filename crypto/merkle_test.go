@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/go-pkgs/hash"
 )
@@ -40,3 +41,28 @@ func TestMerkleTree(t *testing.T) {
 	rootHashHex := hex.EncodeToString(rootHash[:])
 	assert.Equal(t, "4de26a6d1d6618f7bfeb3d168e37ef645db94c2d558bf8c3546d1311877ddffa", rootHashHex)
 }
+
+func TestMerkleProof(t *testing.T) {
+	require := require.New(t)
+
+	for size := 1; size <= 9; size++ {
+		leaves := make([]hash.Hash256, size)
+		for i := range leaves {
+			leaves[i] = hash.Hash256b([]byte{byte(i)})
+		}
+		m := NewMerkleTree(leaves)
+		root := m.HashTree()
+		for i := range leaves {
+			proof, err := m.Proof(i)
+			require.NoError(err)
+			require.True(VerifyMerkleProof(root, leaves[i], uint64(i), proof), "size %d, index %d", size, i)
+			// the proof is bound to the leaf and its index
+			require.False(VerifyMerkleProof(root, hash.Hash256b([]byte("other")), uint64(i), proof))
+			if size > 1 {
+				require.False(VerifyMerkleProof(root, leaves[i], uint64(i)+uint64(len(m.leaf)), proof))
+			}
+		}
+		_, err := m.Proof(len(m.leaf) + 1)
+		require.Error(err)
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package crypto
+
+import (
+	"bytes"
+	"math/big"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+)
+
+// ErrInvalidSignature indicates the signature is invalid or not signed by the key
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// RecoverableSignature converts the secp256k1 signature (r, s) of the hash, signed by the key outside of the node,
+// e.g., in the KMS or the HSM, to [R || S || V]. S is normalized to the lower half of the order as required by the
+// signature verification, and V is the recovery id which recovers the uncompressed public key
+func RecoverableSignature(hash []byte, r, s *big.Int, pub []byte) ([]byte, error) {
+	n := ethcrypto.S256().Params().N
+	if r.Sign() <= 0 || s.Sign() <= 0 || r.Cmp(n) >= 0 || s.Cmp(n) >= 0 {
+		return nil, ErrInvalidSignature
+	}
+	if s.Cmp(new(big.Int).Rsh(n, 1)) > 0 {
+		s = new(big.Int).Sub(n, s)
+	}
+	rsv := make([]byte, 65)
+	r.FillBytes(rsv[:32])
+	s.FillBytes(rsv[32:64])
+	for v := byte(0); v < 2; v++ {
+		rsv[64] = v
+		if recovered, err := ethcrypto.Ecrecover(hash, rsv); err == nil && bytes.Equal(recovered, pub) {
+			return rsv, nil
+		}
+	}
+	return nil, errors.Wrap(ErrInvalidSignature, "signature is not signed by the key")
+}
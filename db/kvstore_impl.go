@@ -6,7 +6,10 @@
 package db
 
 import (
+	"bytes"
 	"context"
+	"sort"
+	"strings"
 
 	"github.com/pkg/errors"
 
@@ -114,10 +117,51 @@ func (m *memKVStore) Delete(namespace string, key []byte) error {
 	return nil
 }
 
-// Filter returns <k, v> pair in a bucket that meet the condition
+// Filter returns <k, v> pair in a bucket that meet the condition, in the order of the keys as the BoltDB does
 func (m *memKVStore) Filter(namespace string, c Condition, minKey, maxKey []byte) ([][]byte, [][]byte, error) {
-	// TODO: implement filter for memKVStore
-	return nil, nil, errors.New("in-memory KVStore does not support Filter()")
+	if _, ok := m.bucket.Get(namespace); !ok {
+		return nil, nil, errors.Wrapf(ErrBucketNotExist, "bucket = %x doesn't exist", []byte(namespace))
+	}
+	var (
+		prefix = namespace + _keyDelimiter
+		fk, fv [][]byte
+	)
+	m.data.Range(func(key cache.Key, value interface{}) bool {
+		k, ok := key.(string)
+		if !ok || !strings.HasPrefix(k, prefix) {
+			return true
+		}
+		kb, vb := []byte(k[len(prefix):]), value.([]byte)
+		if len(minKey) > 0 && bytes.Compare(kb, minKey) < 0 {
+			return true
+		}
+		if len(maxKey) > 0 && bytes.Compare(kb, maxKey) > 0 {
+			return true
+		}
+		if c(kb, vb) {
+			fk = append(fk, kb)
+			fv = append(fv, append([]byte{}, vb...))
+		}
+		return true
+	})
+	if len(fk) == 0 {
+		return nil, nil, errors.Wrap(ErrNotExist, "filter returns no match")
+	}
+	sort.Sort(&keyValueSorter{fk, fv})
+	return fk, fv, nil
+}
+
+type keyValueSorter struct {
+	keys, values [][]byte
+}
+
+func (s *keyValueSorter) Len() int { return len(s.keys) }
+
+func (s *keyValueSorter) Less(i, j int) bool { return bytes.Compare(s.keys[i], s.keys[j]) < 0 }
+
+func (s *keyValueSorter) Swap(i, j int) {
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+	s.values[i], s.values[j] = s.values[j], s.values[i]
 }
 
 // WriteBatch commits a batch
@@ -164,3 +164,32 @@ func TestKVStoreImpl(t *testing.T) {
 	require.NoError(err)
 	require.Equal(v, _v4)
 }
+
+func TestKVStoreImplFilter(t *testing.T) {
+	require := require.New(t)
+
+	s := NewMemKVStore()
+	_, _, err := s.Filter(_namespace, func(k, v []byte) bool { return true }, nil, nil)
+	require.ErrorIs(err, ErrBucketNotExist)
+
+	for _, kv := range [][2][]byte{{_k3, _v3}, {_k1, _v1}, {_k4, _v4}, {_k2, _v2}} {
+		require.NoError(s.Put(_namespace, kv[0], kv[1]))
+	}
+	require.NoError(s.Put("other", _k1, _v1))
+
+	// the pairs are returned in the order of the keys
+	keys, values, err := s.Filter(_namespace, func(k, v []byte) bool { return true }, nil, nil)
+	require.NoError(err)
+	require.Equal([][]byte{_k1, _k2, _k3, _k4}, keys)
+	require.Equal([][]byte{_v1, _v2, _v3, _v4}, values)
+
+	keys, values, err = s.Filter(_namespace, func(k, v []byte) bool {
+		return string(v) != string(_v3)
+	}, _k2, _k3)
+	require.NoError(err)
+	require.Equal([][]byte{_k2}, keys)
+	require.Equal([][]byte{_v2}, values)
+
+	_, _, err = s.Filter(_namespace, func(k, v []byte) bool { return false }, nil, nil)
+	require.ErrorIs(err, ErrNotExist)
+}
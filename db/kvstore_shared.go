@@ -0,0 +1,105 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package db
+
+import (
+	"context"
+	"sync"
+
+	"github.com/iotexproject/iotex-core/v2/db/batch"
+)
+
+type (
+	// SharedKVStore shares one physical kvstore among the tenants, each of which sees the store with its namespaces
+	// prefixed by the tenant prefix, so the tenants using the same namespaces are isolated from each other. The store
+	// is started when the first tenant starts, and stopped after the last tenant stops
+	SharedKVStore struct {
+		mutex   sync.Mutex
+		kvstore KVStore
+		started int
+	}
+
+	tenantKVStore struct {
+		shared  *SharedKVStore
+		prefix  string
+		started bool
+	}
+)
+
+// NewSharedKVStore creates a kvstore shared by the tenants
+func NewSharedKVStore(kvstore KVStore) *SharedKVStore {
+	return &SharedKVStore{kvstore: kvstore}
+}
+
+// Tenant returns the view of the tenant of the prefix, the empty prefix keeps the namespaces as is
+func (s *SharedKVStore) Tenant(prefix string) KVStore {
+	return &tenantKVStore{shared: s, prefix: prefix}
+}
+
+func (s *SharedKVStore) start(ctx context.Context, t *tenantKVStore) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if t.started {
+		return nil
+	}
+	if s.started == 0 {
+		if err := s.kvstore.Start(ctx); err != nil {
+			return err
+		}
+	}
+	s.started++
+	t.started = true
+	return nil
+}
+
+func (s *SharedKVStore) stop(ctx context.Context, t *tenantKVStore) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if !t.started {
+		return nil
+	}
+	if s.started == 1 {
+		if err := s.kvstore.Stop(ctx); err != nil {
+			return err
+		}
+	}
+	s.started--
+	t.started = false
+	return nil
+}
+
+func (t *tenantKVStore) Start(ctx context.Context) error {
+	return t.shared.start(ctx, t)
+}
+
+func (t *tenantKVStore) Stop(ctx context.Context) error {
+	return t.shared.stop(ctx, t)
+}
+
+func (t *tenantKVStore) Put(ns string, key, value []byte) error {
+	return t.shared.kvstore.Put(t.prefix+ns, key, value)
+}
+
+func (t *tenantKVStore) Get(ns string, key []byte) ([]byte, error) {
+	return t.shared.kvstore.Get(t.prefix+ns, key)
+}
+
+func (t *tenantKVStore) Delete(ns string, key []byte) error {
+	return t.shared.kvstore.Delete(t.prefix+ns, key)
+}
+
+func (t *tenantKVStore) Filter(ns string, cond Condition, minKey, maxKey []byte) ([][]byte, [][]byte, error) {
+	return t.shared.kvstore.Filter(t.prefix+ns, cond, minKey, maxKey)
+}
+
+func (t *tenantKVStore) WriteBatch(b batch.KVStoreBatch) error {
+	if t.prefix == "" {
+		return t.shared.kvstore.WriteBatch(b)
+	}
+	return t.shared.kvstore.WriteBatch(b.Translate(func(wi *batch.WriteInfo) *batch.WriteInfo {
+		return batch.NewWriteInfo(wi.WriteType(), t.prefix+wi.Namespace(), wi.Key(), wi.Value(), wi.Error())
+	}))
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/db/batch"
+	"github.com/iotexproject/iotex-core/v2/testutil"
+)
+
+func TestSharedKVStore(t *testing.T) {
+	r := require.New(t)
+	testPath, err := testutil.PathOfTempFile("test-shared")
+	r.NoError(err)
+	defer testutil.CleanupPath(testPath)
+
+	cfg := DefaultConfig
+	cfg.DbPath = testPath
+	kv := NewBoltDB(cfg)
+	shared := NewSharedKVStore(kv)
+	legacy, t1, t2 := shared.Tenant(""), shared.Tenant("t1#"), shared.Tenant("t2#")
+	ctx := context.Background()
+	for _, tenant := range []KVStore{legacy, t1, t2, t1} {
+		r.NoError(tenant.Start(ctx))
+	}
+	r.True(kv.IsReady())
+
+	// the tenants are isolated in the same namespace
+	r.NoError(legacy.Put(_namespace, _k1, _v1))
+	r.NoError(t1.Put(_namespace, _k1, _v2))
+	b := batch.NewBatch()
+	b.Put(_namespace, _k1, _v3, "")
+	b.Put(_namespace, _k2, _v4, "")
+	r.NoError(t2.WriteBatch(b))
+	for _, e := range []struct {
+		tenant KVStore
+		value  []byte
+	}{
+		{legacy, _v1},
+		{t1, _v2},
+		{t2, _v3},
+	} {
+		v, err := e.tenant.Get(_namespace, _k1)
+		r.NoError(err)
+		r.Equal(e.value, v)
+	}
+	v, err := kv.Get("t2#"+_namespace, _k2)
+	r.NoError(err)
+	r.Equal(_v4, v)
+	keys, _, err := t2.Filter(_namespace, func([]byte, []byte) bool { return true }, nil, nil)
+	r.NoError(err)
+	r.Equal([][]byte{_k1, _k2}, keys)
+	_, err = t1.Get(_namespace, _k2)
+	r.Equal(ErrNotExist, errors.Cause(err))
+	r.NoError(t1.Delete(_namespace, _k1))
+	_, err = t1.Get(_namespace, _k1)
+	r.Equal(ErrNotExist, errors.Cause(err))
+	v, err = legacy.Get(_namespace, _k1)
+	r.NoError(err)
+	r.Equal(_v1, v)
+
+	// the store is stopped after the last tenant stops
+	for _, tenant := range []KVStore{t1, t1, legacy} {
+		r.NoError(tenant.Stop(ctx))
+		r.True(kv.IsReady())
+	}
+	r.NoError(t2.Stop(ctx))
+	r.False(kv.IsReady())
+	r.NoError(t2.Start(ctx))
+	r.True(kv.IsReady())
+	r.NoError(t2.Stop(ctx))
+}
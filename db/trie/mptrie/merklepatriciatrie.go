@@ -32,6 +32,7 @@ type (
 		hashFunc      HashFunc
 		async         bool
 		emptyRootHash []byte
+		nodeCache     *NodeCache
 	}
 )
 
@@ -88,6 +89,14 @@ func AsyncOption() Option {
 	}
 }
 
+// NodeCacheOption sets the cache of the clean nodes, which could be shared with other tries
+func NodeCacheOption(nc *NodeCache) Option {
+	return func(mpt *merklePatriciaTrie) error {
+		mpt.nodeCache = nc
+		return nil
+	}
+}
+
 // New creates a trie with DB filename
 func New(options ...Option) (trie.Trie, error) {
 	t := &merklePatriciaTrie{
@@ -302,13 +311,17 @@ func (mpt *merklePatriciaTrie) putNode(key []byte, value []byte) error {
 }
 
 func (mpt *merklePatriciaTrie) loadNode(key []byte) (node, error) {
-	s, err := mpt.kvStore.Get(key)
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed to get key %x", key)
-	}
-	pb := triepb.NodePb{}
-	if err := proto.Unmarshal(s, &pb); err != nil {
-		return nil, err
+	pb, ok := mpt.nodeCache.get(key)
+	if !ok {
+		s, err := mpt.kvStore.Get(key)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get key %x", key)
+		}
+		pb = &triepb.NodePb{}
+		if err := proto.Unmarshal(s, pb); err != nil {
+			return nil, err
+		}
+		mpt.nodeCache.put(key, pb)
 	}
 	if pbBranch := pb.GetBranch(); pbBranch != nil {
 		return newBranchNodeFromProtoPb(pbBranch, key), nil
@@ -344,5 +357,6 @@ func (mpt *merklePatriciaTrie) Clone(kvStore trie.KVStore) (trie.Trie, error) {
 		hashFunc:      mpt.hashFunc,
 		async:         mpt.async,
 		emptyRootHash: erh,
+		nodeCache:     mpt.nodeCache,
 	}, nil
 }
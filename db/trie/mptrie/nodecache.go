@@ -0,0 +1,105 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package mptrie
+
+import (
+	"bytes"
+
+	"github.com/iotexproject/go-pkgs/cache"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/iotexproject/iotex-core/v2/db/trie/triepb"
+)
+
+var _nodeCacheMtc = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "iotex_trie_node_cache",
+		Help: "IoTeX trie node cache",
+	},
+	[]string{"result"},
+)
+
+func init() {
+	prometheus.MustRegister(_nodeCacheMtc)
+}
+
+// NodeCache is a size-bounded cache of the decoded clean trie nodes keyed by the node hash. A node is immutable once
+// hashed, so the cache can be shared by the tries of all the working sets, and serves a node no matter which store
+// it is read from
+type NodeCache struct {
+	lru cache.LRUCache
+}
+
+// NewNodeCache returns a node cache holding up to size nodes, nil is returned if size is 0, which disables the cache
+func NewNodeCache(size int) *NodeCache {
+	if size <= 0 {
+		return nil
+	}
+	return &NodeCache{lru: cache.NewThreadSafeLruCache(size)}
+}
+
+// Len returns the number of nodes in the cache
+func (nc *NodeCache) Len() int {
+	if nc == nil {
+		return 0
+	}
+	return nc.lru.Len()
+}
+
+// Clear removes all the nodes from the cache
+func (nc *NodeCache) Clear() {
+	if nc == nil {
+		return
+	}
+	nc.lru.Clear()
+}
+
+// get returns a copy of the cached node, which is free to be taken by the trie
+func (nc *NodeCache) get(key []byte) (*triepb.NodePb, bool) {
+	if nc == nil {
+		return nil, false
+	}
+	v, ok := nc.lru.Get(string(key))
+	if !ok {
+		_nodeCacheMtc.WithLabelValues("miss").Inc()
+		return nil, false
+	}
+	_nodeCacheMtc.WithLabelValues("hit").Inc()
+	return cloneNodePb(v.(*triepb.NodePb)), true
+}
+
+// put caches a copy of the node
+func (nc *NodeCache) put(key []byte, pb *triepb.NodePb) {
+	if nc == nil {
+		return
+	}
+	nc.lru.Add(string(key), cloneNodePb(pb))
+}
+
+// cloneNodePb deep copies the node, as the nodes built from it hold and modify the slices in it
+func cloneNodePb(pb *triepb.NodePb) *triepb.NodePb {
+	switch {
+	case pb.GetBranch() != nil:
+		branches := make([]*triepb.BranchNodePb, len(pb.GetBranch().Branches))
+		for i, b := range pb.GetBranch().Branches {
+			branches[i] = &triepb.BranchNodePb{Index: b.Index, Path: bytes.Clone(b.Path)}
+		}
+		return &triepb.NodePb{Node: &triepb.NodePb_Branch{Branch: &triepb.BranchPb{Branches: branches}}}
+	case pb.GetLeaf() != nil:
+		return &triepb.NodePb{Node: &triepb.NodePb_Leaf{Leaf: &triepb.LeafPb{
+			Ext:   pb.GetLeaf().Ext,
+			Path:  bytes.Clone(pb.GetLeaf().Path),
+			Value: bytes.Clone(pb.GetLeaf().Value),
+		}}}
+	case pb.GetExtend() != nil:
+		return &triepb.NodePb{Node: &triepb.NodePb_Extend{Extend: &triepb.ExtendPb{
+			Path:  bytes.Clone(pb.GetExtend().Path),
+			Value: bytes.Clone(pb.GetExtend().Value),
+		}}}
+	default:
+		return pb
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package mptrie
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/db/trie"
+)
+
+func TestNodeCache(t *testing.T) {
+	require := require.New(t)
+
+	require.Nil(NewNodeCache(0))
+	var disabled *NodeCache
+	require.Zero(disabled.Len())
+	disabled.Clear()
+
+	keys := [][]byte{ham, car, cat, dog, egg, fox}
+	nc := NewNodeCache(100)
+	newTrie := func(kvStore trie.KVStore, root []byte) trie.Trie {
+		tr, err := New(KVStoreOption(kvStore), KeyLengthOption(8), RootHashOption(root), NodeCacheOption(nc))
+		require.NoError(err)
+		require.NoError(tr.Start(context.Background()))
+		return tr
+	}
+	tr := newTrie(trie.NewMemKVStore(), nil)
+	for i, k := range keys {
+		require.NoError(tr.Upsert(k, testV[i]))
+	}
+	root, err := tr.RootHash()
+	require.NoError(err)
+	require.Zero(nc.Len())
+	tr = newTrie(tr.(*merklePatriciaTrie).kvStore, root)
+	for i, k := range keys {
+		v, err := tr.Get(k)
+		require.NoError(err)
+		require.Equal(testV[i], v)
+	}
+	require.NotZero(nc.Len())
+
+	// the nodes are served by the cache regardless of the store
+	tr = newTrie(trie.NewMemKVStore(), root)
+	for i, k := range keys {
+		v, err := tr.Get(k)
+		require.NoError(err)
+		require.Equal(testV[i], v)
+	}
+	// updating the trie does not change the cached nodes
+	v, err := tr.Get(cat)
+	require.NoError(err)
+	v[0] = 'b'
+	require.NoError(tr.Delete(car))
+	require.NoError(tr.Delete(egg))
+	require.NoError(tr.Upsert(rat, testV[6]))
+	tr = newTrie(trie.NewMemKVStore(), root)
+	for i, k := range keys {
+		v, err := tr.Get(k)
+		require.NoError(err)
+		require.Equal(testV[i], v)
+	}
+
+	nc.Clear()
+	require.Zero(nc.Len())
+	tr, err = New(KVStoreOption(trie.NewMemKVStore()), KeyLengthOption(8), RootHashOption(root), NodeCacheOption(nc))
+	require.NoError(err)
+	require.Error(tr.Start(context.Background()))
+}
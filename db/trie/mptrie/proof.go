@@ -0,0 +1,140 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package mptrie
+
+import (
+	"bytes"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/db/trie"
+	"github.com/iotexproject/iotex-core/v2/db/trie/triepb"
+)
+
+// Prove returns the value of the key, and the serialized nodes on the path from the root to the key, which prove the
+// existence of the key, or the absence of the key if the value is nil
+func (mpt *merklePatriciaTrie) Prove(key []byte) ([]byte, [][]byte, error) {
+	mpt.mutex.RLock()
+	defer mpt.mutex.RUnlock()
+
+	kt, err := mpt.checkKeyType(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	var (
+		n      node = mpt.root
+		offset uint8
+		proof  [][]byte
+	)
+	for {
+		if hn, ok := n.(*hashNode); ok {
+			if n, err = hn.LoadNode(mpt); err != nil {
+				return nil, nil, err
+			}
+		}
+		sn, ok := n.(serializable)
+		if !ok {
+			return nil, nil, errors.Wrapf(trie.ErrInvalidTrie, "unexpected node type %T", n)
+		}
+		pb, err := sn.proto(mpt, false)
+		if err != nil {
+			return nil, nil, err
+		}
+		ser, err := proto.Marshal(pb)
+		if err != nil {
+			return nil, nil, err
+		}
+		proof = append(proof, ser)
+		switch node := n.(type) {
+		case *branchNode:
+			child, err := node.child(kt[offset])
+			if err != nil {
+				return nil, proof, nil
+			}
+			n = child
+			offset++
+		case *extensionNode:
+			matched := node.commonPrefixLength(kt[offset:])
+			if matched != uint8(len(node.path)) {
+				return nil, proof, nil
+			}
+			n = node.child
+			offset += matched
+		case *leafNode:
+			if !bytes.Equal(node.key, kt) {
+				return nil, proof, nil
+			}
+			return node.value, proof, nil
+		default:
+			return nil, nil, errors.Wrapf(trie.ErrInvalidTrie, "unexpected node type %T", n)
+		}
+	}
+}
+
+// VerifyProof verifies the proof generated by Prove against the root hash, and returns the value of the key, or nil
+// if the proof shows the absence of the key. The hash func should be the same as the one of the trie, nil for the
+// default hash func
+func VerifyProof(rootHash []byte, key []byte, proof [][]byte, hashFunc HashFunc) ([]byte, error) {
+	if hashFunc == nil {
+		hashFunc = DefaultHashFunc
+	}
+	var (
+		expected = rootHash
+		offset   int
+	)
+	for i, ser := range proof {
+		if !bytes.Equal(hashFunc(ser), expected) {
+			return nil, errors.Wrapf(trie.ErrInvalidProof, "hash mismatch of node %d", i)
+		}
+		last := i == len(proof)-1
+		pb := triepb.NodePb{}
+		if err := proto.Unmarshal(ser, &pb); err != nil {
+			return nil, errors.Wrapf(trie.ErrInvalidProof, "failed to deserialize node %d: %v", i, err)
+		}
+		switch {
+		case pb.GetBranch() != nil:
+			if offset >= len(key) {
+				return nil, errors.Wrapf(trie.ErrInvalidProof, "key %x is too short", key)
+			}
+			expected = nil
+			for _, b := range pb.GetBranch().Branches {
+				if b.Index == uint32(key[offset]) {
+					expected = b.Path
+					break
+				}
+			}
+			if expected == nil {
+				if !last {
+					return nil, errors.Wrap(trie.ErrInvalidProof, "redundant nodes after absence")
+				}
+				return nil, nil
+			}
+			offset++
+		case pb.GetExtend() != nil:
+			path := pb.GetExtend().Path
+			if !bytes.HasPrefix(key[offset:], path) {
+				if !last {
+					return nil, errors.Wrap(trie.ErrInvalidProof, "redundant nodes after absence")
+				}
+				return nil, nil
+			}
+			expected = pb.GetExtend().Value
+			offset += len(path)
+		case pb.GetLeaf() != nil:
+			if !last {
+				return nil, errors.Wrap(trie.ErrInvalidProof, "redundant nodes after leaf")
+			}
+			if !bytes.Equal(pb.GetLeaf().Path, key) {
+				return nil, nil
+			}
+			return pb.GetLeaf().Value, nil
+		default:
+			return nil, errors.Wrapf(trie.ErrInvalidProof, "invalid type of node %d", i)
+		}
+	}
+	return nil, errors.Wrap(trie.ErrInvalidProof, "incomplete proof")
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package mptrie
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/db/trie"
+)
+
+func TestProof(t *testing.T) {
+	require := require.New(t)
+
+	for _, async := range []bool{false, true} {
+		opts := []Option{KVStoreOption(trie.NewMemKVStore()), KeyLengthOption(8)}
+		if async {
+			opts = append(opts, AsyncOption())
+		}
+		tr, err := New(opts...)
+		require.NoError(err)
+		require.NoError(tr.Start(context.Background()))
+		prover, ok := tr.(trie.Prover)
+		require.True(ok)
+
+		// the proof of the empty trie
+		root, err := tr.RootHash()
+		require.NoError(err)
+		v, proof, err := prover.Prove(cat)
+		require.NoError(err)
+		require.Nil(v)
+		require.Len(proof, 1)
+		v, err = VerifyProof(root, cat, proof, nil)
+		require.NoError(err)
+		require.Nil(v)
+
+		for i, k := range [][]byte{ham, car, cat, dog, egg, fox} {
+			require.NoError(tr.Upsert(k, testV[i]))
+		}
+		root, err = tr.RootHash()
+		require.NoError(err)
+		for i, k := range [][]byte{ham, car, cat, dog, egg, fox} {
+			v, proof, err := prover.Prove(k)
+			require.NoError(err)
+			require.Equal(testV[i], v)
+			v, err = VerifyProof(root, k, proof, nil)
+			require.NoError(err)
+			require.Equal(testV[i], v)
+			// the proof does not hold for other keys or roots
+			_, err = VerifyProof(root, ant, proof, nil)
+			require.Equal(trie.ErrInvalidProof, errors.Cause(err))
+			_, err = VerifyProof(emptyTrieRootHash, k, proof, nil)
+			require.Equal(trie.ErrInvalidProof, errors.Cause(err))
+			_, err = VerifyProof(root, k, proof[:len(proof)-1], nil)
+			require.Equal(trie.ErrInvalidProof, errors.Cause(err))
+		}
+		// the proofs of the absence of the keys diverging at a branch, an extension, and a leaf
+		for _, k := range [][]byte{ant, cow, rat} {
+			v, proof, err := prover.Prove(k)
+			require.NoError(err)
+			require.Nil(v)
+			v, err = VerifyProof(root, k, proof, nil)
+			require.NoError(err)
+			require.Nil(v)
+		}
+		_, _, err = prover.Prove([]byte{1, 2, 3})
+		require.Error(err)
+		require.NoError(tr.Stop(context.Background()))
+	}
+}
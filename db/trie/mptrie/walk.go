@@ -0,0 +1,63 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package mptrie
+
+import (
+	"bytes"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/db/trie"
+	"github.com/iotexproject/iotex-core/v2/db/trie/triepb"
+)
+
+// Walk visits the stored nodes of the trie of the root hash in depth first order, in which the children of a branch
+// are visited in the order of the indices. visit is called with the hash and the serialized node, and the children of
+// the node are skipped if it returns false. The hash func should be the same as the one of the trie, nil for the
+// default hash func
+func Walk(kvStore trie.KVStore, rootHash []byte, hashFunc HashFunc, visit func(hash, node []byte) bool) error {
+	if hashFunc == nil {
+		hashFunc = DefaultHashFunc
+	}
+	// the root of the empty trie is not stored
+	empty, err := proto.Marshal(&triepb.NodePb{Node: &triepb.NodePb_Branch{Branch: &triepb.BranchPb{}}})
+	if err != nil {
+		return err
+	}
+	if len(rootHash) == 0 || bytes.Equal(rootHash, hashFunc(empty)) {
+		return nil
+	}
+	stack := [][]byte{rootHash}
+	for len(stack) > 0 {
+		key := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		ser, err := kvStore.Get(key)
+		if err != nil {
+			return errors.Wrapf(err, "failed to load node %x", key)
+		}
+		if !visit(key, ser) {
+			continue
+		}
+		pb := triepb.NodePb{}
+		if err := proto.Unmarshal(ser, &pb); err != nil {
+			return errors.Wrapf(trie.ErrInvalidTrie, "failed to deserialize node %x: %v", key, err)
+		}
+		switch {
+		case pb.GetBranch() != nil:
+			branches := pb.GetBranch().Branches
+			for i := len(branches) - 1; i >= 0; i-- {
+				stack = append(stack, branches[i].Path)
+			}
+		case pb.GetExtend() != nil:
+			stack = append(stack, pb.GetExtend().Value)
+		case pb.GetLeaf() != nil:
+		default:
+			return errors.Wrapf(trie.ErrInvalidTrie, "invalid type of node %x", key)
+		}
+	}
+	return nil
+}
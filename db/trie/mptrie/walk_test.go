@@ -0,0 +1,72 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package mptrie
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/db/trie"
+	"github.com/iotexproject/iotex-core/v2/db/trie/triepb"
+)
+
+func TestWalk(t *testing.T) {
+	require := require.New(t)
+
+	kvStore := trie.NewMemKVStore()
+	tr, err := New(KVStoreOption(kvStore), KeyLengthOption(8))
+	require.NoError(err)
+	require.NoError(tr.Start(context.Background()))
+	root, err := tr.RootHash()
+	require.NoError(err)
+	walk := func(root []byte, visit func(hash, node []byte) bool) ([][]byte, error) {
+		var leaves [][]byte
+		err := Walk(kvStore, root, nil, func(hash, node []byte) bool {
+			require.Equal(DefaultHashFunc(node), hash)
+			pb := triepb.NodePb{}
+			require.NoError(proto.Unmarshal(node, &pb))
+			if pb.GetLeaf() != nil {
+				leaves = append(leaves, pb.GetLeaf().Value)
+			}
+			return visit(hash, node)
+		})
+		return leaves, err
+	}
+	all := func([]byte, []byte) bool { return true }
+
+	// the empty trie
+	leaves, err := walk(root, all)
+	require.NoError(err)
+	require.Empty(leaves)
+
+	keys := [][]byte{ham, car, cat, dog, egg, fox}
+	for i, k := range keys {
+		require.NoError(tr.Upsert(k, testV[i]))
+	}
+	root, err = tr.RootHash()
+	require.NoError(err)
+	// the leaves are visited in the order of the keys
+	leaves, err = walk(root, all)
+	require.NoError(err)
+	require.Equal([][]byte{testV[0], testV[1], testV[2], testV[4], testV[3], testV[5]}, leaves)
+
+	// the children are skipped
+	nodes := 0
+	leaves, err = walk(root, func([]byte, []byte) bool {
+		nodes++
+		return false
+	})
+	require.NoError(err)
+	require.Empty(leaves)
+	require.Equal(1, nodes)
+
+	// the missing node
+	_, err = walk([]byte("missing"), all)
+	require.Error(err)
+}
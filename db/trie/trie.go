@@ -20,6 +20,9 @@ var (
 
 	// ErrEndOfIterator defines an error which will be returned
 	ErrEndOfIterator = errors.New("hit the end of the iterator, no more item")
+
+	// ErrInvalidProof indicates the merkle proof does not match the trie root
+	ErrInvalidProof = errors.New("invalid merkle proof")
 )
 
 type (
@@ -49,6 +52,11 @@ type (
 		// Clone clones a trie with a new kvstore
 		Clone(KVStore) (Trie, error)
 	}
+	// Prover is a trie generating the merkle proofs of its keys
+	Prover interface {
+		// Prove returns the value of the key and the proof, the value is nil if the proof is of the absence
+		Prove([]byte) ([]byte, [][]byte, error)
+	}
 	// TwoLayerTrie is a trie data structure with two layers
 	TwoLayerTrie interface {
 		// Start starts the layer one trie
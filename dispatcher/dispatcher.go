@@ -35,6 +35,9 @@ type (
 		MiscChanSize               uint          `yaml:"miscChanSize"`
 		ProcessSyncRequestInterval time.Duration `yaml:"processSyncRequestInterval"`
 		AccountRateLimit           uint          `yaml:"accountRateLimit"`
+		// ConsensusPeerRateLimit is the number of consensus messages per second accepted from a peer, the messages
+		// exceeding the limit are dropped before queued. It is disabled if it is 0
+		ConsensusPeerRateLimit uint `yaml:"consensusPeerRateLimit"`
 		// TODO: explorer dependency deleted at #1085, need to revive by migrating to api
 	}
 )
@@ -50,6 +53,7 @@ var (
 		AccountRateLimit:  100,
 
 		ProcessSyncRequestInterval: 0 * time.Second,
+		ConsensusPeerRateLimit:     300,
 	}
 )
 
@@ -77,10 +81,18 @@ var (
 		},
 		[]string{"method", "succeed"},
 	)
+	droppedConsensusMsgMtc = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iotex_dispatch_dropped_consensus_msg",
+			Help: "Dispatcher dropped consensus message counter.",
+		},
+		[]string{"reason"},
+	)
 )
 
 func init() {
 	prometheus.MustRegister(requestMtc)
+	prometheus.MustRegister(droppedConsensusMsgMtc)
 }
 
 type (
@@ -104,6 +116,8 @@ type (
 		peerSyncLock     sync.RWMutex
 		ratelimiter      *RateLimiter
 		verificationFunc VerificationFunc
+		// consensusLimiter limits the consensus messages from a peer, it is nil if disabled
+		consensusLimiter *RateLimiter
 	}
 
 	message struct {
@@ -126,6 +140,9 @@ func NewDispatcher(cfg Config, verificationFunc VerificationFunc) (Dispatcher, e
 		ratelimiter:      NewRateLimiter(100000, rate.Limit(cfg.AccountRateLimit), int(cfg.AccountRateLimit)),
 		verificationFunc: verificationFunc,
 	}
+	if cfg.ConsensusPeerRateLimit > 0 {
+		d.consensusLimiter = NewRateLimiter(10000, rate.Limit(cfg.ConsensusPeerRateLimit), int(cfg.ConsensusPeerRateLimit))
+	}
 	queueMgr := newMsgQueueMgr(msgQueueConfig{
 		actionChanSize: cfg.ActionChanSize,
 		blockChanSize:  cfg.BlockChanSize,
@@ -276,6 +293,10 @@ func (d *IotxDispatcher) HandleBroadcast(ctx context.Context, chainID uint32, pe
 		return
 	case *iotextypes.Action:
 		d.ratelimiter.Wait(string(actions.SenderPubKey))
+	case *iotextypes.ConsensusMessage:
+		if !d.allowConsensusMsg(peer) {
+			return
+		}
 	}
 	msg := &message{
 		ctx:     ctx,
@@ -296,6 +317,9 @@ func (d *IotxDispatcher) HandleTell(ctx context.Context, chainID uint32, peer pe
 	if err != nil {
 		log.L().Warn("Unexpected message handled by HandleTell.", zap.Error(err))
 	}
+	if _, ok := msgProto.(*iotextypes.ConsensusMessage); ok && !d.allowConsensusMsg(peer.ID.String()) {
+		return
+	}
 	cp := peer
 	msg := &message{
 		ctx:      ctx,
@@ -308,6 +332,17 @@ func (d *IotxDispatcher) HandleTell(ctx context.Context, chainID uint32, peer pe
 	d.queueMessage(msg)
 }
 
+// allowConsensusMsg drops the consensus messages from a peer flooding the node, so that they don't delay the
+// messages of the other peers in the queue
+func (d *IotxDispatcher) allowConsensusMsg(peer string) bool {
+	if d.consensusLimiter == nil || d.consensusLimiter.Allow(peer) {
+		return true
+	}
+	droppedConsensusMsgMtc.WithLabelValues("peerRateLimit").Inc()
+	log.L().Debug("Consensus message dropped due to peer rate limit.", zap.String("peer", peer))
+	return false
+}
+
 func (d *IotxDispatcher) updateEventAudit(t iotexrpc.MessageType) {
 	d.eventAuditLock.Lock()
 	defer d.eventAuditLock.Unlock()
@@ -345,6 +380,25 @@ func (d *IotxDispatcher) dispatchMsg(message *message) {
 		log.L().Warn("chainID has not been registered in dispatcher.", zap.Uint32("chainID", message.chainID))
 		return
 	}
+	dispatchTo(subscriber, message)
+}
+
+// DispatchMessage hands the message from the peer to the subscriber synchronously, bypassing the queues and the rate
+// limits of the dispatcher, e.g., in a simulated network. The peer info is only passed for the unicast messages, to
+// which the subscriber may reply
+func DispatchMessage(ctx context.Context, subscriber Subscriber, peer peer.AddrInfo, unicast bool, msg proto.Message) {
+	message := &message{
+		ctx:  ctx,
+		msg:  msg,
+		peer: peer.ID.String(),
+	}
+	if unicast {
+		message.peerInfo = &peer
+	}
+	dispatchTo(subscriber, message)
+}
+
+func dispatchTo(subscriber Subscriber, message *message) {
 	switch msg := message.msg.(type) {
 	case *iotextypes.ConsensusMessage:
 		if err := subscriber.HandleConsensusMsg(msg); err != nil {
@@ -122,6 +122,28 @@ func TestDispatcherV2(t *testing.T) {
 		}))
 		r.Equal(int32(2), sub.blockSync.Load())
 	})
+	t.Run("limitConsensusPeer", func(t *testing.T) {
+		cfg := DefaultConfig
+		cfg.ConsensusPeerRateLimit = 2
+		dsp, err := NewDispatcher(cfg, dummyVerificationFunc)
+		r.NoError(err)
+		r.NoError(dsp.Start(context.Background()))
+		defer func() {
+			r.NoError(dsp.Stop(context.Background()))
+		}()
+		sub := &counterSubscriber{}
+		dsp.AddSubscriber(defaultChainID, sub)
+		peer1 := peer.AddrInfo{ID: "peer1"}
+		for i := 0; i < 5; i++ {
+			dsp.HandleBroadcast(context.Background(), defaultChainID, peer1.ID.String(), &iotextypes.ConsensusMessage{})
+		}
+		dsp.HandleTell(context.Background(), defaultChainID, peer1, &iotextypes.ConsensusMessage{})
+		dsp.HandleBroadcast(context.Background(), defaultChainID, "peer2", &iotextypes.ConsensusMessage{})
+		r.NoError(testutil.WaitUntil(100*time.Millisecond, time.Second, func() (bool, error) {
+			return dispatcherIsClean(dsp.(*IotxDispatcher)), nil
+		}))
+		r.Equal(int32(3), sub.consensus.Load())
+	})
 	t.Run("broadcast", func(t *testing.T) {
 		dsp, err := NewDispatcher(DefaultConfig, dummyVerificationFunc)
 		r.NoError(err)
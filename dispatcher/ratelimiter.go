@@ -47,3 +47,8 @@ func (rl *RateLimiter) Remainings(key string) int {
 func (rl *RateLimiter) Wait(key string) {
 	rl.getLimiter(key).Wait(context.Background())
 }
+
+// Allow reports whether 1 token is available for the given key, and consumes it if so.
+func (rl *RateLimiter) Allow(key string) bool {
+	return rl.getLimiter(key).Allow()
+}
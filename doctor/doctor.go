@@ -0,0 +1,367 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+// Package doctor checks the integrity of the dbs of a stopped node, i.e., the consistency of the heights of chain.db,
+// trie.db and the index dbs, the blocks and the receipts missing at the tip and the orphan files left in the data
+// directories, and reports the issues with the commands to repair them.
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/blockchain/filedao"
+	"github.com/iotexproject/iotex-core/v2/blockindex"
+	"github.com/iotexproject/iotex-core/v2/config"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/v2/state/factory"
+)
+
+// Statuses of the checks
+const (
+	StatusOK      Status = "ok"
+	StatusWarning Status = "warning"
+	StatusError   Status = "error"
+)
+
+// _openTimeout is the time to wait for opening a db, which is blocked while the db is locked by a running node
+var _openTimeout = 10 * time.Second
+
+type (
+	// Status is the status of a check
+	Status string
+
+	// Check is the result of an integrity check
+	Check struct {
+		Name    string `json:"name"`
+		Path    string `json:"path,omitempty"`
+		Status  Status `json:"status"`
+		Message string `json:"message"`
+		// Hint is the command or the step to repair the issue
+		Hint string `json:"hint,omitempty"`
+	}
+
+	// Report is the report of the integrity checks
+	Report struct {
+		// Height is the tip height of chain.db
+		Height uint64  `json:"height"`
+		Checks []Check `json:"checks"`
+	}
+
+	// Option sets the parameter of the checks
+	Option func(*options)
+
+	options struct {
+		depth uint64
+	}
+
+	// starter is a db opened by Start
+	starter interface {
+		Start(context.Context) error
+		Stop(context.Context) error
+	}
+)
+
+// WithDepth sets the number of the blocks below the tip whose blocks and receipts are checked
+func WithDepth(depth uint64) Option {
+	return func(o *options) {
+		o.depth = depth
+	}
+}
+
+// Run runs the integrity checks on the dbs of the config, the node must be stopped
+func Run(ctx context.Context, cfg config.Config, opts ...Option) *Report {
+	o := options{depth: 100}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	r := &Report{}
+	height, ok := r.checkChainDB(ctx, cfg, o.depth)
+	if ok {
+		r.Height = height
+		r.checkStateDB(ctx, cfg, height)
+		r.checkIndexDBs(ctx, cfg, height)
+	}
+	r.checkOrphans(cfg)
+	return r
+}
+
+// HasError returns true if any check fails
+func (r *Report) HasError() bool {
+	return slices.ContainsFunc(r.Checks, func(c Check) bool {
+		return c.Status == StatusError
+	})
+}
+
+// String returns the report in lines, one per check
+func (r *Report) String() string {
+	var (
+		lines          = make([]string, 0, len(r.Checks)+1)
+		errs, warnings int
+	)
+	for _, c := range r.Checks {
+		switch c.Status {
+		case StatusError:
+			errs++
+		case StatusWarning:
+			warnings++
+		}
+		line := fmt.Sprintf("%-7s %s: %s", strings.ToUpper(string(c.Status)), c.Name, c.Message)
+		if c.Hint != "" {
+			line += fmt.Sprintf(" (hint: %s)", c.Hint)
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, fmt.Sprintf("%d error(s), %d warning(s)", errs, warnings))
+	return strings.Join(lines, "\n")
+}
+
+func (r *Report) add(status Status, name, path, message, hint string) {
+	r.Checks = append(r.Checks, Check{
+		Name:    name,
+		Path:    path,
+		Status:  status,
+		Message: message,
+		Hint:    hint,
+	})
+}
+
+// checkChainDB checks the blocks and the receipts of the tip, and returns the tip height
+func (r *Report) checkChainDB(ctx context.Context, cfg config.Config, depth uint64) (uint64, bool) {
+	const name = "chain.db"
+	path := cfg.Chain.ChainDBPath
+	if uri, err := url.Parse(path); err == nil && uri.Scheme != "" && uri.Scheme != "file" {
+		r.add(StatusWarning, name, path, "remote chain db isn't checked", "")
+		return 0, false
+	} else if err == nil && uri.Scheme == "file" {
+		path = uri.Path
+	}
+	if !fileExists(path) {
+		r.add(StatusError, name, path, "chain db doesn't exist",
+			"restore chain.db from a backup, or remove all the dbs to sync from the genesis")
+		return 0, false
+	}
+	dbCfg := cfg.DB
+	dbCfg.DbPath = path
+	dbCfg.ReadOnly = true
+	var dao filedao.FileDAO
+	err := func() (err error) {
+		defer func() {
+			// the file dao panics on the corrupted file version
+			if e := recover(); e != nil {
+				err = errors.Errorf("%v", e)
+			}
+		}()
+		dao, err = filedao.NewFileDAO(dbCfg, block.NewDeserializer(cfg.Chain.EVMNetworkID))
+		return err
+	}()
+	if err == nil {
+		err = start(ctx, dao)
+	}
+	if err != nil {
+		r.add(StatusError, name, path, fmt.Sprintf("failed to open chain db: %v", err),
+			"stop the node if it is running, otherwise restore chain.db from a backup")
+		return 0, false
+	}
+	defer dao.Stop(ctx)
+	height, err := dao.Height()
+	if err != nil {
+		r.add(StatusError, name, path, fmt.Sprintf("failed to read the tip height: %v", err),
+			"restore chain.db from a backup")
+		return 0, false
+	}
+	bottom := uint64(1)
+	if height > depth {
+		bottom = height - depth + 1
+	}
+	for h := height; h >= bottom && h > 0; h-- {
+		if _, err := dao.GetBlockByHeight(h); err != nil {
+			r.add(StatusError, name, path, fmt.Sprintf("block %d is unreadable: %v", h, err),
+				fmt.Sprintf("restore chain.db from a backup at height %d or above", height))
+			return height, false
+		}
+		if _, err := dao.GetReceipts(h); err != nil {
+			r.add(StatusError, name, path, fmt.Sprintf("receipts of block %d are missing: %v", h, err),
+				fmt.Sprintf("restore chain.db from a backup at height %d or above", height))
+			return height, false
+		}
+	}
+	if height == 0 {
+		r.add(StatusOK, name, path, "tip height 0", "")
+	} else {
+		r.add(StatusOK, name, path, fmt.Sprintf("tip height %d, blocks and receipts of %d to %d are intact", height, bottom, height), "")
+	}
+	return height, true
+}
+
+// checkStateDB checks the height of the states against the tip height of the blocks
+func (r *Report) checkStateDB(ctx context.Context, cfg config.Config, height uint64) {
+	const name = "trie.db"
+	path := cfg.Chain.TrieDBPath
+	if !fileExists(path) {
+		r.add(StatusWarning, name, path, "state db doesn't exist, the states are built from the genesis at the next start", "")
+		return
+	}
+	dbCfg := cfg.DB
+	dbCfg.DBType = cfg.Chain.FactoryDBType
+	dbCfg.ReadOnly = true
+	kv, err := db.CreateKVStore(dbCfg, path)
+	if err == nil {
+		err = start(ctx, kv)
+	}
+	if err != nil {
+		r.add(StatusError, name, path, fmt.Sprintf("failed to open state db: %v", err),
+			"stop the node if it is running, otherwise remove the state db to rebuild it from the genesis: rm -r "+path)
+		return
+	}
+	defer kv.Stop(ctx)
+	h, err := kv.Get(factory.AccountKVNamespace, []byte(factory.CurrentHeightKey))
+	if err != nil {
+		r.add(StatusError, name, path, fmt.Sprintf("failed to read the state height: %v", err),
+			"remove the state db to rebuild it from the genesis: rm -r "+path)
+		return
+	}
+	stateHeight := byteutil.BytesToUint64(h)
+	switch {
+	case stateHeight > height:
+		r.add(StatusError, name, path, fmt.Sprintf("state height %d is ahead of the tip height %d", stateHeight, height),
+			fmt.Sprintf("restore chain.db from a backup at height %d or above, or remove the state db to rebuild it from the genesis: rm -r %s", stateHeight, path))
+	case stateHeight < height:
+		r.add(StatusWarning, name, path, fmt.Sprintf("state height %d lags behind the tip height %d, the blocks are replayed at the next start", stateHeight, height), "")
+	default:
+		r.add(StatusOK, name, path, fmt.Sprintf("state height %d", stateHeight), "")
+	}
+}
+
+// checkIndexDBs checks the height of the index dbs against the tip height of the blocks
+func (r *Report) checkIndexDBs(ctx context.Context, cfg config.Config, height uint64) {
+	type indexDB struct {
+		name   string
+		path   string
+		height func(db.KVStore) (uint64, error)
+	}
+	indexes := []indexDB{
+		{"index.db", cfg.Chain.IndexDBPath, func(kv db.KVStore) (uint64, error) {
+			indexer, err := blockindex.NewIndexer(kv, hash.ZeroHash256)
+			if err != nil {
+				return 0, err
+			}
+			if err := indexer.Start(ctx); err != nil {
+				return 0, err
+			}
+			return indexer.Height()
+		}},
+		{"bloomfilter.index.db", cfg.Chain.BloomfilterIndexDBPath, func(kv db.KVStore) (uint64, error) {
+			indexer, err := blockindex.NewBloomfilterIndexer(kv, cfg.Indexer)
+			if err != nil {
+				return 0, err
+			}
+			if err := indexer.Start(ctx); err != nil {
+				return 0, err
+			}
+			return indexer.Height()
+		}},
+	}
+	for _, index := range indexes {
+		rebuild := "remove the index db to rebuild it from chain.db at the next start: rm " + index.path
+		if index.path == "" {
+			continue
+		}
+		if !fileExists(index.path) {
+			r.add(StatusWarning, index.name, index.path, "index db doesn't exist, it is built from chain.db at the next start", "")
+			continue
+		}
+		dbCfg := cfg.DB
+		dbCfg.DbPath = index.path
+		dbCfg.ReadOnly = true
+		kv := db.NewBoltDB(dbCfg)
+		indexHeight, err := func() (uint64, error) {
+			if err := start(ctx, kv); err != nil {
+				return 0, err
+			}
+			defer kv.Stop(ctx)
+			return index.height(kv)
+		}()
+		switch {
+		case err != nil:
+			r.add(StatusError, index.name, index.path, fmt.Sprintf("failed to read the index height: %v", err),
+				"stop the node if it is running, otherwise "+rebuild)
+		case indexHeight > height:
+			r.add(StatusError, index.name, index.path, fmt.Sprintf("index height %d is ahead of the tip height %d", indexHeight, height), rebuild)
+		case indexHeight < height:
+			r.add(StatusWarning, index.name, index.path, fmt.Sprintf("index lags behind the tip by %d blocks, it catches up at the next start", height-indexHeight), "")
+		default:
+			r.add(StatusOK, index.name, index.path, fmt.Sprintf("index height %d", indexHeight), "")
+		}
+	}
+}
+
+// _auxChainDB matches the auxiliary files of chain.db, e.g., chain-00000001.db
+var _auxChainDB = regexp.MustCompile(`-\d{8}$`)
+
+// checkOrphans reports the db files in the directories of the dbs which aren't used by the config, e.g., the dbs left
+// after the paths are changed, and the temporary files of the interrupted writes
+func (r *Report) checkOrphans(cfg config.Config) {
+	used := map[string]bool{}
+	dirs := []string{}
+	for _, path := range cfg.DBPaths() {
+		path = filepath.Clean(path)
+		used[path] = true
+		if dir := filepath.Dir(path); !slices.Contains(dirs, dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+	chainDB := filepath.Clean(cfg.Chain.ChainDBPath)
+	chainExt := filepath.Ext(chainDB)
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			path := filepath.Join(dir, entry.Name())
+			ext := filepath.Ext(path)
+			if used[path] || (ext != ".db" && ext != ".tmp") {
+				continue
+			}
+			if ext == chainExt && strings.HasPrefix(path, strings.TrimSuffix(chainDB, chainExt)) &&
+				_auxChainDB.MatchString(strings.TrimSuffix(path, ext)) {
+				continue
+			}
+			r.add(StatusWarning, "orphan", path, "file isn't used by the node", "remove it if it isn't used by another node: rm -r "+path)
+		}
+	}
+}
+
+// start starts the db, which fails if the db is locked by a running node rather than waiting for the lock
+func start(ctx context.Context, s starter) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Start(ctx)
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(_openTimeout):
+		return errors.New("db is locked, the node may be running")
+	}
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package doctor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/blockchain/filedao"
+	"github.com/iotexproject/iotex-core/v2/config"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/v2/state/factory"
+)
+
+func TestRun(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	dir := t.TempDir()
+	cfg := config.Default
+	cfg.System.DataDir = ""
+	cfg.Chain.ChainDBPath = filepath.Join(dir, "chain.db")
+	cfg.Chain.TrieDBPath = filepath.Join(dir, "trie.db")
+	cfg.Chain.IndexDBPath = filepath.Join(dir, "index.db")
+	cfg.Chain.BloomfilterIndexDBPath = filepath.Join(dir, "bloomfilter.index.db")
+
+	checks := func(report *Report) map[string]Status {
+		statuses := map[string]Status{}
+		for _, c := range report.Checks {
+			statuses[c.Name] = c.Status
+		}
+		return statuses
+	}
+
+	t.Run("MissingChainDB", func(t *testing.T) {
+		report := Run(ctx, cfg)
+		r.True(report.HasError())
+		r.Equal(StatusError, checks(report)["chain.db"])
+	})
+
+	// create an empty chain db
+	dbCfg := cfg.DB
+	dbCfg.DbPath = cfg.Chain.ChainDBPath
+	dao, err := filedao.NewFileDAO(dbCfg, block.NewDeserializer(cfg.Chain.EVMNetworkID))
+	r.NoError(err)
+	r.NoError(dao.Start(ctx))
+	r.NoError(dao.Stop(ctx))
+
+	t.Run("Empty", func(t *testing.T) {
+		report := Run(ctx, cfg)
+		r.False(report.HasError(), report.String())
+		statuses := checks(report)
+		r.Equal(StatusOK, statuses["chain.db"])
+		r.Equal(StatusWarning, statuses["trie.db"])
+		r.Equal(StatusWarning, statuses["index.db"])
+	})
+
+	t.Run("StateAhead", func(t *testing.T) {
+		kv := db.NewBoltDB(db.Config{DbPath: cfg.Chain.TrieDBPath, NumRetries: 3})
+		r.NoError(kv.Start(ctx))
+		r.NoError(kv.Put(factory.AccountKVNamespace, []byte(factory.CurrentHeightKey), byteutil.Uint64ToBytes(5)))
+		r.NoError(kv.Stop(ctx))
+		report := Run(ctx, cfg)
+		r.True(report.HasError())
+		r.Equal(StatusError, checks(report)["trie.db"])
+		r.Contains(report.String(), "state height 5 is ahead of the tip height 0")
+	})
+
+	t.Run("Orphans", func(t *testing.T) {
+		orphan := filepath.Join(dir, "old.index.db")
+		r.NoError(os.WriteFile(orphan, nil, 0600))
+		r.NoError(os.WriteFile(filepath.Join(dir, "chain-00000002.db"), nil, 0600))
+		r.NoError(os.WriteFile(filepath.Join(dir, "notes.txt"), nil, 0600))
+		report := Run(ctx, cfg)
+		var orphans []string
+		for _, c := range report.Checks {
+			if c.Name == "orphan" {
+				orphans = append(orphans, c.Path)
+			}
+		}
+		r.Equal([]string{orphan}, orphans)
+	})
+}
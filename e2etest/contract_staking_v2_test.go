@@ -1192,6 +1192,87 @@ func TestMigrateStake(t *testing.T) {
 			},
 		})
 	})
+	t.Run("migrate_to_native", func(t *testing.T) {
+		contractAddress := "io1dkqh5mu9djfas3xyrmzdv9frsmmytel4mp7a64"
+		cfg := initCfg(require)
+		cfg.Genesis.SystemStakingContractV2Address = address.ZeroAddress
+		cfg.Genesis.SystemStakingContractV2Height = 1
+		cfg.Genesis.SystemStakingContractV3Address = contractAddress
+		cfg.Genesis.SystemStakingContractV3Height = 1
+		cfg.Genesis.VanuatuBlockHeight = 1
+		cfg.Genesis.WakeBlockHeight = 1
+		cfg.Genesis.ToBeEnabledBlockHeight = 1
+		testutil.NormalizeGenesisHeights(&cfg.Genesis.Blockchain)
+		cfg.DardanellesUpgrade.BlockInterval = time.Second * 8640
+		cfg.Plugins[config.GatewayPlugin] = nil
+		test := newE2ETest(t, cfg)
+		defer test.teardown()
+
+		chainID := test.cfg.Chain.ID
+		stakerID := 1
+		contractCreator := 1
+		candOwnerID := 2
+		stakeAmount, _ := big.NewInt(0).SetString("10000000000000000000000", 10)
+		stakeDurationDays := uint32(1)
+		stakeTime := time.Now()
+		minAmount, _ := big.NewInt(0).SetString("1000000000000000000000", 10)
+		bytecode, err := hex.DecodeString(stakingContractV3Bytecode)
+		require.NoError(err)
+		mustCallData := func(m string, args ...any) []byte {
+			data, err := abiCall(stakingContractV3ABI, m, args...)
+			require.NoError(err)
+			return data
+		}
+		contractV2AddressEth := common.BytesToAddress(mustNoErr(address.FromString(address.ZeroAddress)).Bytes())
+		migrateData := mustNoErr(staking.PackMigrateToNative(mustNoErr(address.FromString(contractAddress)), 1))
+		candidate := &iotextypes.CandidateV2{Name: "cand1", Id: identityset.Address(candOwnerID).String(), OperatorAddress: identityset.Address(1).String(), RewardAddress: identityset.Address(1).String(), TotalWeightedVotes: "1256001586604779503009155", SelfStakingTokens: registerAmount.String(), OwnerAddress: identityset.Address(candOwnerID).String(), SelfStakeBucketIdx: 0}
+		test.run([]*testcase{
+			{
+				name: "migrate native bucket to contract",
+				preActs: []*actionWithTime{
+					{mustNoErr(action.SignedExecution("", identityset.PrivateKey(contractCreator), test.nonceMgr.pop(identityset.Address(contractCreator).String()), big.NewInt(0), gasLimit, gasPrice, append(bytecode, mustCallData("", minAmount, contractV2AddressEth)...), action.WithChainID(chainID))), stakeTime},
+					{mustNoErr(action.SignedCandidateRegister(test.nonceMgr.pop(identityset.Address(candOwnerID).String()), "cand1", identityset.Address(1).String(), identityset.Address(1).String(), identityset.Address(candOwnerID).String(), registerAmount.String(), 1, true, nil, gasLimit, gasPrice, identityset.PrivateKey(candOwnerID), action.WithChainID(chainID))), stakeTime},
+					{mustNoErr(action.SignedCreateStake(test.nonceMgr.pop(identityset.Address(stakerID).String()), "cand1", stakeAmount.String(), stakeDurationDays, true, nil, gasLimit, gasPrice, identityset.PrivateKey(stakerID), action.WithChainID(chainID))), stakeTime},
+				},
+				act: &actionWithTime{mustNoErr(action.SignedMigrateStake(test.nonceMgr.pop(identityset.Address(stakerID).String()), 1, gasLimit, gasPrice, identityset.PrivateKey(stakerID), action.WithChainID(chainID))), stakeTime},
+				expect: []actionExpect{
+					successExpect,
+					&bucketExpect{&iotextypes.VoteBucket{Index: 1, CandidateAddress: identityset.Address(candOwnerID).String(), StakedAmount: stakeAmount.String(), AutoStake: true, StakedDuration: stakeDurationDays, CreateTime: timestamppb.New(time.Unix(stakeTime.Unix(), 0)), StakeStartTime: timestamppb.New(time.Unix(stakeTime.Unix(), 0)), UnstakeStartTime: timestamppb.New(time.Unix(0, 0)), Owner: identityset.Address(stakerID).String(), ContractAddress: contractAddress}},
+					&candidateExpect{"cand1", candidate},
+				},
+			},
+			{
+				name: "non-owner cannot migrate contract bucket",
+				act:  &actionWithTime{mustNoErr(action.SignedExecution(staking.StakeMigrationAddr.String(), identityset.PrivateKey(candOwnerID), test.nonceMgr.pop(identityset.Address(candOwnerID).String()), big.NewInt(0), gasLimit, gasPrice, migrateData, action.WithChainID(chainID))), stakeTime},
+				expect: []actionExpect{
+					&basicActionExpect{nil, uint64(iotextypes.ReceiptStatus_ErrUnauthorizedOperator), ""},
+				},
+			},
+			{
+				name: "migrate contract bucket to native",
+				act:  &actionWithTime{mustNoErr(action.SignedExecution(staking.StakeMigrationAddr.String(), identityset.PrivateKey(stakerID), test.nonceMgr.pop(identityset.Address(stakerID).String()), big.NewInt(0), gasLimit, gasPrice, migrateData, action.WithChainID(chainID))), stakeTime},
+				expect: []actionExpect{
+					successExpect,
+					&noBucketExpect{1, contractAddress},
+					&bucketExpect{&iotextypes.VoteBucket{Index: 2, CandidateAddress: identityset.Address(candOwnerID).String(), StakedAmount: stakeAmount.String(), AutoStake: true, StakedDuration: stakeDurationDays, Owner: identityset.Address(stakerID).String(), CreateTime: timestamppb.New(stakeTime), StakeStartTime: timestamppb.New(stakeTime), UnstakeStartTime: &timestamppb.Timestamp{}}},
+					// the votes of the candidate are kept
+					&candidateExpect{"cand1", candidate},
+					&functionExpect{func(test *e2etest, act *action.SealedEnvelope, receipt *action.Receipt, err error) {
+						resp, err := test.api.GetAccount(context.Background(), &iotexapi.GetAccountRequest{Address: contractAddress})
+						require.NoError(err)
+						require.Equal("0", resp.GetAccountMeta().GetBalance())
+					}},
+				},
+			},
+			{
+				name: "migrated bucket cannot be migrated again",
+				act:  &actionWithTime{mustNoErr(action.SignedExecution(staking.StakeMigrationAddr.String(), identityset.PrivateKey(stakerID), test.nonceMgr.pop(identityset.Address(stakerID).String()), big.NewInt(0), gasLimit, gasPrice, migrateData, action.WithChainID(chainID))), stakeTime},
+				expect: []actionExpect{
+					&basicActionExpect{nil, uint64(iotextypes.ReceiptStatus_ErrInvalidBucketIndex), ""},
+				},
+			},
+		})
+	})
 }
 
 func methodSignToID(sign string) []byte {
@@ -0,0 +1,434 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+// Package simnet runs a network of the nodes in the process, to integration-test the protocol changes without the
+// docker clusters. The nodes keep the data in the memory and exchange the messages through a simulated network, and
+// the consensus and the network run on a mock clock, which the test moves forward to drive the rounds, e.g.,
+//
+//	c, err := simnet.NewCluster(4, simnet.WithLatency(100*time.Millisecond, 50*time.Millisecond))
+//	...
+//	c.Partition([]int{0, 1, 2}, []int{3})
+//	err = c.WaitHeight(5, time.Minute)
+package simnet
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"time"
+
+	"github.com/facebookgo/clock"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-address/address"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	accountutil "github.com/iotexproject/iotex-core/v2/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/chainservice"
+	"github.com/iotexproject/iotex-core/v2/config"
+	"github.com/iotexproject/iotex-core/v2/consensus"
+	"github.com/iotexproject/iotex-core/v2/pkg/unit"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+const (
+	// _settleTime is the time for the nodes to handle the messages and the events triggered by a tick
+	_settleTime = 10 * time.Millisecond
+	// _maxIdleWait is the max time to wait for the nodes to handle the pending events of a tick
+	_maxIdleWait = 50 * time.Millisecond
+	// _defaultTick is the default step to move the mock clock forward
+	_defaultTick = 100 * time.Millisecond
+)
+
+type (
+	// Option is the option of the cluster
+	Option func(*clusterConfig) error
+
+	clusterConfig struct {
+		seed           int64
+		latency        time.Duration
+		jitter         time.Duration
+		tick           time.Duration
+		buildGenesis   func(*genesis.Builder)
+		overrideConfig func(int, *config.Config)
+	}
+
+	// Node is a node of the cluster
+	Node struct {
+		index  int
+		clock  *clock.Mock
+		tick   time.Duration
+		agent  *agent
+		cs     *chainservice.ChainService
+		cancel context.CancelFunc
+	}
+
+	// Cluster is a network of the block producers running in the process
+	Cluster struct {
+		clock   *clock.Mock
+		network *Network
+		tick    time.Duration
+		genesis genesis.Genesis
+		nodes   []*Node
+	}
+)
+
+// WithSeed sets the seed of the random source, from which the peer ids and the network jitters are drawn
+func WithSeed(seed int64) Option {
+	return func(cfg *clusterConfig) error {
+		cfg.seed = seed
+		return nil
+	}
+}
+
+// WithLatency sets the latency of the network, each message is delayed by an extra random duration within the jitter
+func WithLatency(latency, jitter time.Duration) Option {
+	return func(cfg *clusterConfig) error {
+		if latency < 0 || jitter < 0 {
+			return errors.Errorf("invalid latency %s and jitter %s", latency, jitter)
+		}
+		cfg.latency, cfg.jitter = latency, jitter
+		return nil
+	}
+}
+
+// WithTick sets the step to move the mock clock forward
+func WithTick(tick time.Duration) Option {
+	return func(cfg *clusterConfig) error {
+		if tick <= 0 {
+			return errors.Errorf("invalid tick %s", tick)
+		}
+		cfg.tick = tick
+		return nil
+	}
+}
+
+// WithGenesis customizes the genesis, after the nodes are funded and registered as the delegates
+func WithGenesis(f func(*genesis.Builder)) Option {
+	return func(cfg *clusterConfig) error {
+		cfg.buildGenesis = f
+		return nil
+	}
+}
+
+// WithConfig customizes the config of each node by its index
+func WithConfig(f func(int, *config.Config)) Option {
+	return func(cfg *clusterConfig) error {
+		cfg.overrideConfig = f
+		return nil
+	}
+}
+
+// NewCluster creates a cluster of n nodes, the i-th node produces the blocks with the i-th key of the identity set.
+// All the features are activated from the first block
+func NewCluster(n int, opts ...Option) (*Cluster, error) {
+	if n <= 0 || n > identityset.Size() {
+		return nil, errors.Errorf("invalid number of nodes %d", n)
+	}
+	ccfg := clusterConfig{tick: _defaultTick}
+	for _, opt := range opts {
+		if err := opt(&ccfg); err != nil {
+			return nil, err
+		}
+	}
+	b := genesis.NewBuilder().
+		SetNumDelegates(uint64(n)).
+		SetNumSubEpochs(2).
+		ActivateAllAt(1)
+	for i := 0; i < n; i++ {
+		addr := identityset.Address(i).String()
+		b.FundAccount(addr, unit.ConvertIotxToRau(100000000)).
+			AddDelegate(addr, addr, unit.ConvertIotxToRau(1000000))
+	}
+	if ccfg.buildGenesis != nil {
+		ccfg.buildGenesis(b)
+	}
+	g, err := b.Build()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build genesis")
+	}
+	// the genesis hash is global, as the server loads it at the startup
+	block.LoadGenesisHash(&g)
+	clk := clock.NewMock()
+	clk.Add(time.Unix(g.Timestamp, 0).Sub(clk.Now()))
+	c := &Cluster{
+		clock:   clk,
+		network: NewNetwork(clk, ccfg.seed),
+		tick:    ccfg.tick,
+		genesis: g,
+	}
+	c.network.SetLatency(ccfg.latency, ccfg.jitter)
+	for i := 0; i < n; i++ {
+		cfg := nodeConfig(g, i)
+		if ccfg.overrideConfig != nil {
+			ccfg.overrideConfig(i, &cfg)
+		}
+		a, err := c.network.newAgent()
+		if err != nil {
+			return nil, err
+		}
+		cs, err := chainservice.NewBuilder(cfg).SetP2PAgent(a).SetClock(clk).BuildForTest()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to build node %d", i)
+		}
+		a.setHandler(cs)
+		c.nodes = append(c.nodes, &Node{index: i, clock: clk, tick: c.tick, agent: a, cs: cs})
+	}
+	return c, nil
+}
+
+func nodeConfig(g genesis.Genesis, i int) config.Config {
+	cfg := config.Default
+	cfg.Genesis = g
+	cfg.Chain.ID = config.Default.Chain.ID
+	cfg.Chain.ProducerPrivKey = identityset.PrivateKey(i).HexString()
+	cfg.Chain.TrieDBPatchFile = ""
+	cfg.Consensus.Scheme = config.RollDPoSScheme
+	cfg.Consensus.RollDPoS.ConsensusDBPath = ""
+	cfg.ActPool.MinGasPriceStr = big.NewInt(0).String()
+	cfg.ActPool.Store = nil
+	// the minting deadline is checked on the real clock, against the block time on the mock clock
+	cfg.Chain.MintTimeout = 0
+	// the lagging nodes catch up by the block sync, which runs on the real clock
+	cfg.BlockSync.Interval = 200 * time.Millisecond
+	return cfg
+}
+
+// Genesis returns the genesis of the cluster
+func (c *Cluster) Genesis() genesis.Genesis {
+	return c.genesis
+}
+
+// Clock returns the mock clock of the cluster
+func (c *Cluster) Clock() *clock.Mock {
+	return c.clock
+}
+
+// Network returns the simulated network of the cluster
+func (c *Cluster) Network() *Network {
+	return c.network
+}
+
+// Size returns the number of the nodes
+func (c *Cluster) Size() int {
+	return len(c.nodes)
+}
+
+// Node returns the i-th node
+func (c *Cluster) Node(i int) *Node {
+	return c.nodes[i]
+}
+
+// Start starts all the nodes
+func (c *Cluster) Start(ctx context.Context) error {
+	for _, n := range c.nodes {
+		if err := n.Start(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Stop stops all the running nodes
+func (c *Cluster) Stop(ctx context.Context) error {
+	for _, n := range c.nodes {
+		if n.cancel == nil {
+			continue
+		}
+		if err := n.Stop(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Partition splits the nodes into the groups of the indexes, see Network.Partition
+func (c *Cluster) Partition(groups ...[]int) {
+	c.network.Partition(groups...)
+}
+
+// Heal removes the partitions
+func (c *Cluster) Heal() {
+	c.network.Heal()
+}
+
+// Advance moves the mock clock forward by the duration tick by tick, the nodes handle the messages and the events
+// triggered by a tick before the next one
+func (c *Cluster) Advance(d time.Duration) {
+	for end := c.clock.Now().Add(d); c.clock.Now().Before(end); {
+		c.step(end.Sub(c.clock.Now()))
+	}
+}
+
+// WaitHeight moves the mock clock forward until all the running nodes reach the height, or returns an error if they
+// don't within the duration
+func (c *Cluster) WaitHeight(height uint64, d time.Duration) error {
+	end := c.clock.Now().Add(d)
+	for {
+		reached := true
+		for _, n := range c.nodes {
+			if n.Running() && n.TipHeight() < height {
+				reached = false
+				break
+			}
+		}
+		if reached {
+			return nil
+		}
+		if !c.clock.Now().Before(end) {
+			return errors.Errorf("nodes are at heights %v, lower than %d after %s", c.TipHeights(), height, d)
+		}
+		c.step(end.Sub(c.clock.Now()))
+	}
+}
+
+func (c *Cluster) step(left time.Duration) {
+	tick := c.tick
+	if left < tick {
+		tick = left
+	}
+	c.clock.Add(tick)
+	c.waitIdle()
+}
+
+// waitIdle waits for the nodes to handle the pending events, a node waiting on the mock clock, e.g., for the round to
+// start, moves on at the next tick
+func (c *Cluster) waitIdle() {
+	deadline := time.Now().Add(_maxIdleWait)
+	for _, n := range c.nodes {
+		for n.Running() && n.numPendingEvts() > 0 && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+	}
+	time.Sleep(_settleTime)
+}
+
+// TipHeights returns the tip heights of the nodes
+func (c *Cluster) TipHeights() []uint64 {
+	heights := make([]uint64, len(c.nodes))
+	for i, n := range c.nodes {
+		heights[i] = n.TipHeight()
+	}
+	return heights
+}
+
+// CheckConsistency checks that all the nodes at or above the height have the same block at the height
+func (c *Cluster) CheckConsistency(height uint64) error {
+	var (
+		expected string
+		from     int
+	)
+	for i, n := range c.nodes {
+		if n.TipHeight() < height {
+			continue
+		}
+		hash, err := n.cs.BlockDAO().GetBlockHash(height)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get block %d of node %d", height, i)
+		}
+		h := hex.EncodeToString(hash[:])
+		if expected == "" {
+			expected, from = h, i
+			continue
+		}
+		if h != expected {
+			return errors.Errorf("block %d of node %d is %s, different from %s of node %d", height, i, h, expected, from)
+		}
+	}
+	return nil
+}
+
+// Start starts the node
+func (n *Node) Start(ctx context.Context) error {
+	if n.cancel != nil {
+		return errors.Errorf("node %d is already started", n.index)
+	}
+	cctx, cancel := context.WithCancel(ctx)
+	if err := n.cs.Start(cctx); err != nil {
+		cancel()
+		return errors.Wrapf(err, "failed to start node %d", n.index)
+	}
+	if err := n.agent.Start(cctx); err != nil {
+		cancel()
+		return errors.Wrapf(err, "failed to start the agent of node %d", n.index)
+	}
+	n.cancel = cancel
+	return nil
+}
+
+// Stop stops the node, the messages to and from the node are dropped since then
+func (n *Node) Stop(ctx context.Context) error {
+	if n.cancel == nil {
+		return errors.Errorf("node %d is not started", n.index)
+	}
+	if err := n.agent.Stop(ctx); err != nil {
+		return err
+	}
+	n.cancel()
+	n.cancel = nil
+	// the consensus may be waiting on the mock clock for the round to start, keep the clock ticking until it stops
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case <-time.After(time.Millisecond):
+				n.clock.Add(n.tick)
+			}
+		}
+	}()
+	return n.cs.Stop(ctx)
+}
+
+// Running returns whether the node is running
+func (n *Node) Running() bool {
+	return n.cancel != nil
+}
+
+// ChainService returns the chain service of the node
+func (n *Node) ChainService() *chainservice.ChainService {
+	return n.cs
+}
+
+// TipHeight returns the tip height of the node
+func (n *Node) TipHeight() uint64 {
+	return n.cs.Blockchain().TipHeight()
+}
+
+// SendAction adds the action into the actpool of the node and broadcasts it to the peers, as the api does
+func (n *Node) SendAction(ctx context.Context, selp *action.SealedEnvelope) error {
+	ctx = protocol.WithRegistry(ctx, n.cs.Registry())
+	if err := n.cs.ActionPool().Add(ctx, selp); err != nil {
+		return errors.Wrapf(err, "node %d rejects the action", n.index)
+	}
+	return n.agent.BroadcastOutbound(ctx, selp.Proto())
+}
+
+// Balance returns the confirmed balance of the account on the node
+func (n *Node) Balance(addr address.Address) (*big.Int, error) {
+	ctx := genesis.WithGenesisContext(context.Background(), n.cs.Blockchain().Genesis())
+	acct, err := accountutil.AccountState(ctx, n.cs.StateFactory(), addr)
+	if err != nil {
+		return nil, err
+	}
+	return acct.Balance, nil
+}
+
+func (n *Node) numPendingEvts() int {
+	c, ok := n.cs.Consensus().(*consensus.IotxConsensus)
+	if !ok {
+		return 0
+	}
+	s, ok := c.Scheme().(interface{ NumPendingEvts() int })
+	if !ok {
+		return 0
+	}
+	return s.NumPendingEvts()
+}
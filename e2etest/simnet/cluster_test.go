@@ -0,0 +1,78 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package simnet
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+func TestCluster(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	c, err := NewCluster(4, WithSeed(1), WithLatency(50*time.Millisecond, 50*time.Millisecond))
+	r.NoError(err)
+	r.NoError(c.Start(ctx))
+	defer func() {
+		r.NoError(c.Stop(ctx))
+	}()
+
+	r.NoError(c.WaitHeight(3, time.Minute))
+	for h := uint64(1); h <= 3; h++ {
+		r.NoError(c.CheckConsistency(h))
+	}
+
+	t.Run("Transfer", func(t *testing.T) {
+		r := require.New(t)
+		amount := big.NewInt(100)
+		recipient := identityset.Address(27)
+		nonce, err := c.Node(0).ChainService().ActionPool().GetPendingNonce(identityset.Address(0).String())
+		r.NoError(err)
+		elp := (&action.EnvelopeBuilder{}).
+			SetNonce(nonce).
+			SetGasLimit(action.TransferBaseIntrinsicGas).
+			SetGasPrice(new(big.Int).SetUint64(action.InitialBaseFee)).
+			SetChainID(c.Node(0).ChainService().ChainID()).
+			SetAction(action.NewTransfer(amount, recipient.String(), nil)).
+			Build()
+		selp, err := action.Sign(elp, identityset.PrivateKey(0))
+		r.NoError(err)
+		r.NoError(c.Node(0).SendAction(ctx, selp))
+		height := c.Node(0).TipHeight() + 3
+		r.NoError(c.WaitHeight(height, time.Minute))
+		for i := 0; i < c.Size(); i++ {
+			balance, err := c.Node(i).Balance(recipient)
+			r.NoError(err)
+			r.Equal(amount, balance)
+		}
+	})
+	t.Run("Partition", func(t *testing.T) {
+		r := require.New(t)
+		// the minority can't reach the consensus while the majority keeps producing the blocks
+		c.Partition([]int{0, 1, 2}, []int{3})
+		stuck, height := c.Node(3).TipHeight(), c.Node(0).TipHeight()
+		c.Advance(30 * time.Second)
+		r.Equal(stuck, c.Node(3).TipHeight())
+		for i := 0; i < 3; i++ {
+			r.Greater(c.Node(i).TipHeight(), height)
+		}
+
+		// the minority catches up after the partition is healed
+		c.Heal()
+		height = c.Node(0).TipHeight() + 2
+		r.NoError(c.WaitHeight(height, 2*time.Minute))
+		for h := uint64(1); h <= height; h++ {
+			r.NoError(c.CheckConsistency(h))
+		}
+	})
+}
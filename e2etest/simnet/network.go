@@ -0,0 +1,284 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package simnet
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/facebookgo/clock"
+	p2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/dispatcher"
+	"github.com/iotexproject/iotex-core/v2/p2p"
+)
+
+type (
+	// Network is the simulated network connecting the nodes in the process. A message is delivered on the mock clock
+	// after the latency, with the jitter drawn from the seeded random source, and dropped if the sender and the
+	// receiver are in different partitions at the delivery
+	Network struct {
+		clock *clock.Mock
+
+		mu        sync.Mutex
+		rand      *rand.Rand
+		latency   time.Duration
+		jitter    time.Duration
+		agents    []*agent
+		partition map[int]int
+		inflight  atomic.Int64
+	}
+
+	// agent is the p2p agent of a node in the simulated network
+	agent struct {
+		index     int
+		network   *Network
+		info      peer.AddrInfo
+		running   atomic.Bool
+		mu        sync.RWMutex
+		handler   dispatcher.Subscriber
+		protocols map[string]p2p.HandleProtocolInbound
+	}
+)
+
+// NewNetwork creates a simulated network on the mock clock, the same seed gives the same jitters
+func NewNetwork(clk *clock.Mock, seed int64) *Network {
+	return &Network{
+		clock:     clk,
+		rand:      rand.New(rand.NewSource(seed)),
+		partition: map[int]int{},
+	}
+}
+
+// SetLatency sets the latency of the messages, each of which is delayed by an extra random duration within the jitter
+func (n *Network) SetLatency(latency, jitter time.Duration) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.latency, n.jitter = latency, jitter
+}
+
+// Partition splits the nodes into the groups of the indexes, the messages between the groups are dropped. The nodes
+// not in any group are isolated from the others
+func (n *Network) Partition(groups ...[]int) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.partition = map[int]int{}
+	for i := range n.agents {
+		// a group of its own
+		n.partition[i] = -1 - i
+	}
+	for g, group := range groups {
+		for _, i := range group {
+			n.partition[i] = g
+		}
+	}
+}
+
+// Heal removes the partitions
+func (n *Network) Heal() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.partition = map[int]int{}
+}
+
+// Inflight returns the number of the messages sent but not delivered yet
+func (n *Network) Inflight() int {
+	return int(n.inflight.Load())
+}
+
+// newAgent adds a node to the network, whose identity is derived from the random source
+func (n *Network) newAgent() (*agent, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	sk, _, err := p2pcrypto.GenerateEd25519Key(n.rand)
+	if err != nil {
+		return nil, err
+	}
+	id, err := peer.IDFromPrivateKey(sk)
+	if err != nil {
+		return nil, err
+	}
+	a := &agent{
+		index:     len(n.agents),
+		network:   n,
+		protocols: map[string]p2p.HandleProtocolInbound{},
+	}
+	addr, err := multiaddr.NewMultiaddr(fmt.Sprintf("/ip4/127.0.0.1/tcp/%d", 4689+a.index))
+	if err != nil {
+		return nil, err
+	}
+	a.info = peer.AddrInfo{ID: id, Addrs: []multiaddr.Multiaddr{addr}}
+	n.agents = append(n.agents, a)
+	return a, nil
+}
+
+func (n *Network) connected(from, to *agent) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return from.running.Load() && to.running.Load() && n.partition[from.index] == n.partition[to.index]
+}
+
+func (n *Network) peers(from *agent) []*agent {
+	n.mu.Lock()
+	agents := append([]*agent{}, n.agents...)
+	n.mu.Unlock()
+	peers := make([]*agent, 0, len(agents))
+	for _, a := range agents {
+		if a != from && n.connected(from, a) {
+			peers = append(peers, a)
+		}
+	}
+	return peers
+}
+
+func (n *Network) find(id peer.ID) *agent {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for _, a := range n.agents {
+		if a.info.ID == id {
+			return a
+		}
+	}
+	return nil
+}
+
+// send schedules the delivery on the mock clock, the connectivity is checked again at the delivery
+func (n *Network) send(from, to *agent, deliver func()) {
+	if !n.connected(from, to) {
+		return
+	}
+	n.mu.Lock()
+	delay := n.latency
+	if n.jitter > 0 {
+		delay += time.Duration(n.rand.Int63n(int64(n.jitter)))
+	}
+	n.mu.Unlock()
+	n.inflight.Add(1)
+	n.clock.AfterFunc(delay, func() {
+		defer n.inflight.Add(-1)
+		if n.connected(from, to) {
+			deliver()
+		}
+	})
+}
+
+func (a *agent) setHandler(handler dispatcher.Subscriber) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.handler = handler
+}
+
+func (a *agent) deliver(from *agent, unicast bool, msg proto.Message) {
+	a.mu.RLock()
+	handler := a.handler
+	a.mu.RUnlock()
+	if handler == nil {
+		return
+	}
+	dispatcher.DispatchMessage(context.Background(), handler, from.info, unicast, msg)
+}
+
+func (a *agent) Start(context.Context) error {
+	a.running.Store(true)
+	return nil
+}
+
+func (a *agent) Stop(context.Context) error {
+	a.running.Store(false)
+	return nil
+}
+
+func (a *agent) BroadcastOutbound(_ context.Context, msg proto.Message) error {
+	for _, to := range a.network.peers(a) {
+		// each node receives a copy of its own as from the wire
+		m := proto.Clone(msg)
+		a.network.send(a, to, func() { to.deliver(a, false, m) })
+	}
+	return nil
+}
+
+func (a *agent) UnicastOutbound(_ context.Context, target peer.AddrInfo, msg proto.Message) error {
+	to := a.network.find(target.ID)
+	if to == nil {
+		return errors.Errorf("unknown peer %s", target.ID)
+	}
+	m := proto.Clone(msg)
+	a.network.send(a, to, func() { to.deliver(a, true, m) })
+	return nil
+}
+
+func (a *agent) Info() (peer.AddrInfo, error) {
+	return a.info, nil
+}
+
+func (a *agent) Self() ([]multiaddr.Multiaddr, error) {
+	return a.info.Addrs, nil
+}
+
+func (a *agent) ConnectedPeers() ([]peer.AddrInfo, error) {
+	peers := a.network.peers(a)
+	infos := make([]peer.AddrInfo, 0, len(peers))
+	for _, p := range peers {
+		infos = append(infos, p.info)
+	}
+	return infos, nil
+}
+
+func (a *agent) BlockPeer(string) {}
+
+func (a *agent) ConnectPeer(context.Context, string) error {
+	return nil
+}
+
+func (a *agent) ReportPeer(string, p2p.PeerEvent) {}
+
+func (a *agent) PeerScores() []p2p.PeerScore {
+	return nil
+}
+
+func (a *agent) AddTrustedPeer(context.Context, string) error {
+	return nil
+}
+
+func (a *agent) SetMaxPeersPerGroup(int) {}
+
+func (a *agent) AddProtocol(name string, handler p2p.HandleProtocolInbound) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, ok := a.protocols[name]; ok {
+		return errors.Errorf("protocol %s is already added", name)
+	}
+	a.protocols[name] = handler
+	return nil
+}
+
+func (a *agent) UnicastProtocol(_ context.Context, target peer.AddrInfo, name string, data []byte) error {
+	to := a.network.find(target.ID)
+	if to == nil {
+		return errors.Errorf("unknown peer %s", target.ID)
+	}
+	to.mu.RLock()
+	handler, ok := to.protocols[name]
+	to.mu.RUnlock()
+	if !ok {
+		return errors.Errorf("peer %s doesn't support protocol %s", target.ID, name)
+	}
+	b := append([]byte{}, data...)
+	a.network.send(a, to, func() { _ = handler(context.Background(), a.info, b) })
+	return nil
+}
+
+func (a *agent) BuildReport() string {
+	return ""
+}
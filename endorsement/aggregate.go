@@ -0,0 +1,71 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package endorsement
+
+import (
+	"sort"
+
+	"github.com/iotexproject/iotex-core/v2/crypto/bls"
+)
+
+// Aggregate aggregates the BLS endorsements of the same timestamp, which sign the same hash of a document, into one
+// endorsement, while the other endorsements are returned as they are
+func Aggregate(ens []*Endorsement) ([]*Endorsement, error) {
+	var (
+		ret    = make([]*Endorsement, 0, len(ens))
+		groups = make(map[int64][]*Endorsement)
+	)
+	for _, en := range ens {
+		if _, ok := en.Endorser().(*bls.PublicKey); !ok {
+			ret = append(ret, en)
+			continue
+		}
+		ts := en.Timestamp().UnixNano()
+		groups[ts] = append(groups[ts], en)
+	}
+	timestamps := make([]int64, 0, len(groups))
+	for ts := range groups {
+		timestamps = append(timestamps, ts)
+	}
+	sort.Slice(timestamps, func(i, j int) bool {
+		return timestamps[i] < timestamps[j]
+	})
+	for _, ts := range timestamps {
+		group := groups[ts]
+		if len(group) == 1 {
+			ret = append(ret, group[0])
+			continue
+		}
+		var (
+			pks  = make([]*bls.PublicKey, 0, len(group))
+			sigs = make([][]byte, 0, len(group))
+		)
+		for _, en := range group {
+			pks = append(pks, en.Endorser().(*bls.PublicKey))
+			sigs = append(sigs, en.signature)
+		}
+		sig, err := bls.AggregateSignatures(sigs)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, NewEndorsement(group[0].Timestamp(), bls.NewAggregatePublicKey(pks), sig))
+	}
+	return ret, nil
+}
+
+// Expand returns the endorsements of the signers of an aggregate endorsement, which are used to count the endorsers
+// after the aggregate endorsement is verified, as they carry no signatures of their own
+func Expand(en *Endorsement) []*Endorsement {
+	apk, ok := en.Endorser().(*bls.AggregatePublicKey)
+	if !ok {
+		return []*Endorsement{en}
+	}
+	ens := make([]*Endorsement, 0, len(apk.PublicKeys()))
+	for _, pk := range apk.PublicKeys() {
+		ens = append(ens, NewEndorsement(en.Timestamp(), pk, nil))
+	}
+	return ens
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package endorsement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/crypto/bls"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+type testDoc []byte
+
+func (d testDoc) Hash() ([]byte, error) {
+	return d, nil
+}
+
+func TestAggregate(t *testing.T) {
+	require := require.New(t)
+	doc := testDoc("block")
+	ts := time.Unix(1000, 0)
+	var signers []crypto.PrivateKey
+	for i := 0; i < 3; i++ {
+		sk, err := bls.GenerateKey()
+		require.NoError(err)
+		signers = append(signers, sk)
+	}
+	ens, err := Endorse(doc, ts, signers...)
+	require.NoError(err)
+	// an endorsement of another timestamp, and an ECDSA endorsement are not aggregated
+	late, err := Endorse(doc, ts.Add(time.Second), signers[0])
+	require.NoError(err)
+	ecdsa, err := Endorse(doc, ts, identityset.PrivateKey(1))
+	require.NoError(err)
+	ens = append(append(ens, late...), ecdsa...)
+
+	aggregated, err := Aggregate(ens)
+	require.NoError(err)
+	require.Len(aggregated, 3)
+	require.Equal(ecdsa[0], aggregated[0])
+	apk, ok := aggregated[1].Endorser().(*bls.AggregatePublicKey)
+	require.True(ok)
+	require.Len(apk.PublicKeys(), 3)
+	require.Equal(late[0], aggregated[2])
+	for _, en := range aggregated {
+		require.True(VerifyEndorsement(doc, en))
+	}
+	require.False(VerifyEndorsement(testDoc("other block"), aggregated[1]))
+
+	// encoding
+	for _, en := range aggregated {
+		loaded := &Endorsement{}
+		require.NoError(loaded.LoadProto(en.Proto()))
+		require.Equal(en.Endorser().Bytes(), loaded.Endorser().Bytes())
+		require.True(VerifyEndorsement(doc, loaded))
+	}
+
+	expanded := Expand(aggregated[1])
+	require.Len(expanded, 3)
+	for i, en := range expanded {
+		require.Equal(signers[i].PublicKey().Bytes(), en.Endorser().Bytes())
+		require.Equal(ts.UTC(), en.Timestamp())
+	}
+	require.Equal([]*Endorsement{aggregated[0]}, Expand(aggregated[0]))
+}
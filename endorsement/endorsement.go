@@ -13,6 +13,7 @@ import (
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/iotexproject/iotex-core/v2/crypto/bls"
 	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
 )
 
@@ -134,7 +135,7 @@ func (en *Endorsement) LoadProto(ePb *iotextypes.Endorsement) (err error) {
 	en.ts = ePb.Timestamp.AsTime()
 	eb := make([]byte, len(ePb.Endorser))
 	copy(eb, ePb.Endorser)
-	if en.endorser, err = crypto.BytesToPublicKey(eb); err != nil {
+	if en.endorser, err = bytesToEndorser(eb); err != nil {
 		return err
 	}
 	en.signature = make([]byte, len(ePb.Signature))
@@ -142,3 +143,16 @@ func (en *Endorsement) LoadProto(ePb *iotextypes.Endorsement) (err error) {
 
 	return nil
 }
+
+// bytesToEndorser decodes the public key of the endorser, which is a BLS public key, or the concatenated BLS public
+// keys of an aggregate endorsement, if the length is a multiple of the BLS public key size
+func bytesToEndorser(b []byte) (crypto.PublicKey, error) {
+	switch {
+	case len(b) == bls.PublicKeySize:
+		return bls.BytesToPublicKey(b)
+	case len(b) > 0 && len(b)%bls.PublicKeySize == 0:
+		return bls.BytesToAggregatePublicKey(b)
+	default:
+		return crypto.BytesToPublicKey(b)
+	}
+}
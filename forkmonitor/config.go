@@ -0,0 +1,31 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package forkmonitor
+
+import "time"
+
+// Config is the config of the fork monitor
+type Config struct {
+	// MaxForks is the max number of the forks tracked, the least recently seen one is dropped when exceeded. The
+	// monitor is disabled if it is 0
+	MaxForks int `yaml:"maxForks"`
+	// Retention is the number of the heights a fork is kept after the tip of the fork
+	Retention uint64 `yaml:"retention"`
+	// AlertDepth is the depth of a fork which fires the webhook, once per fork
+	AlertDepth uint64 `yaml:"alertDepth"`
+	// WebhookURL is the url the alert is posted to in json, no alert is sent if it is empty
+	WebhookURL     string        `yaml:"webhookURL"`
+	WebhookTimeout time.Duration `yaml:"webhookTimeout"`
+}
+
+// DefaultConfig is the default config
+var DefaultConfig = Config{
+	MaxForks:       100,
+	Retention:      720,
+	AlertDepth:     3,
+	WebhookURL:     "",
+	WebhookTimeout: 5 * time.Second,
+}
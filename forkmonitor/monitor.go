@@ -0,0 +1,325 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package forkmonitor
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+type (
+	chain interface {
+		TipHeight() uint64
+		BlockHeaderByHeight(uint64) (*block.Header, error)
+	}
+
+	// Fork is a branch of the blocks received from the peers, which competes with the chain of the node
+	Fork struct {
+		// Height is the height of the first block of the branch
+		Height uint64
+		// Depth is the number of the heights of the branch
+		Depth     uint64
+		Root      hash.Hash256
+		Tip       hash.Hash256
+		TipHeight uint64
+		// Producers are the producers of the blocks in the branch
+		Producers []string
+		// Peers are the peers which the blocks in the branch are received from
+		Peers     []string
+		FirstSeen time.Time
+		LastSeen  time.Time
+		Alerted   bool
+	}
+
+	// Monitor tracks the forks seen from the peers, even if the blocks are never committed, and fires the webhook
+	// when a fork is deeper than the alert depth
+	Monitor struct {
+		mutex  sync.RWMutex
+		cfg    Config
+		chain  chain
+		forks  map[hash.Hash256]*fork
+		blocks map[hash.Hash256]*fork
+		client *http.Client
+	}
+
+	fork struct {
+		Fork
+		producers map[string]struct{}
+		peers     map[string]struct{}
+	}
+
+	alertMessage struct {
+		Height    uint64    `json:"height"`
+		Depth     uint64    `json:"depth"`
+		Root      string    `json:"root"`
+		Tip       string    `json:"tip"`
+		TipHeight uint64    `json:"tipHeight"`
+		Producers []string  `json:"producers"`
+		Peers     []string  `json:"peers"`
+		FirstSeen time.Time `json:"firstSeen"`
+	}
+)
+
+var (
+	_forkBlockMtc = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "iotex_fork_blocks",
+		Help: "Number of the blocks received from the peers which compete with the chain",
+	})
+	_forkDepthMtc = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "iotex_fork_max_depth",
+		Help: "Max depth of the forks tracked",
+	})
+	_forkNumMtc = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "iotex_fork_count",
+		Help: "Number of the forks tracked",
+	})
+	_forkAlertMtc = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iotex_fork_alerts",
+			Help: "Fork alerts posted to the webhook by the result",
+		},
+		[]string{"result"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(_forkBlockMtc, _forkDepthMtc, _forkNumMtc, _forkAlertMtc)
+}
+
+// NewMonitor creates a fork monitor comparing the blocks from the peers with the chain
+func NewMonitor(cfg Config, chain chain) *Monitor {
+	return &Monitor{
+		cfg:    cfg,
+		chain:  chain,
+		forks:  make(map[hash.Hash256]*fork),
+		blocks: make(map[hash.Hash256]*fork),
+		client: &http.Client{Timeout: cfg.WebhookTimeout},
+	}
+}
+
+// Observe checks the block header received from the peer. The block is on a fork if it is different from the block
+// of the chain at the same height, or it is the next block not built on the tip of the chain
+func (m *Monitor) Observe(peer string, header *block.Header) {
+	if m == nil || m.cfg.MaxForks <= 0 || header == nil {
+		return
+	}
+	height := header.Height()
+	tip := m.chain.TipHeight()
+	if height == 0 || height > tip+1 {
+		return
+	}
+	blkHash := header.HashBlock()
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if f, ok := m.blocks[blkHash]; ok {
+		f.peers[peer] = struct{}{}
+		f.LastSeen = time.Now()
+		return
+	}
+	if !m.competes(header, tip) {
+		return
+	}
+	if !header.VerifySignature() {
+		log.L().Debug("block of invalid signature is not tracked", zap.Uint64("height", height), zap.String("peer", peer))
+		return
+	}
+	m.prune(tip)
+	now := time.Now()
+	f, ok := m.blocks[header.PrevHash()]
+	if !ok {
+		f = &fork{
+			Fork: Fork{
+				Height:    height,
+				Depth:     1,
+				Root:      blkHash,
+				Tip:       blkHash,
+				TipHeight: height,
+				FirstSeen: now,
+			},
+			producers: make(map[string]struct{}),
+			peers:     make(map[string]struct{}),
+		}
+		m.forks[blkHash] = f
+	} else if depth := height - f.Height + 1; depth > f.Depth {
+		f.Depth = depth
+		f.Tip = blkHash
+		f.TipHeight = height
+	}
+	f.LastSeen = now
+	f.producers[header.ProducerAddress()] = struct{}{}
+	f.peers[peer] = struct{}{}
+	m.blocks[blkHash] = f
+	_forkBlockMtc.Inc()
+	m.updateMetrics()
+	log.L().Warn("block on a fork is received",
+		zap.Uint64("height", height),
+		zap.String("hash", hex.EncodeToString(blkHash[:])),
+		zap.Uint64("forkHeight", f.Height),
+		zap.Uint64("forkDepth", f.Depth),
+		zap.String("producer", header.ProducerAddress()),
+		zap.String("peer", peer),
+	)
+	if m.cfg.AlertDepth > 0 && f.Depth >= m.cfg.AlertDepth && !f.Alerted {
+		f.Alerted = true
+		go m.alert(f.snapshot())
+	}
+}
+
+// Forks returns the forks tracked, from the latest to the earliest
+func (m *Monitor) Forks() []Fork {
+	if m == nil {
+		return nil
+	}
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	forks := make([]Fork, 0, len(m.forks))
+	for _, f := range m.forks {
+		forks = append(forks, f.snapshot())
+	}
+	sort.Slice(forks, func(i, j int) bool {
+		if forks[i].Height != forks[j].Height {
+			return forks[i].Height > forks[j].Height
+		}
+		return forks[i].FirstSeen.After(forks[j].FirstSeen)
+	})
+	return forks
+}
+
+func (m *Monitor) competes(header *block.Header, tip uint64) bool {
+	height := header.Height()
+	if height <= tip {
+		h, err := m.chain.BlockHeaderByHeight(height)
+		if err != nil {
+			log.L().Debug("failed to get block header", zap.Uint64("height", height), zap.Error(err))
+			return false
+		}
+		return h.HashBlock() != header.HashBlock()
+	}
+	if tip == 0 {
+		return false
+	}
+	h, err := m.chain.BlockHeaderByHeight(tip)
+	if err != nil {
+		log.L().Debug("failed to get block header", zap.Uint64("height", tip), zap.Error(err))
+		return false
+	}
+	return h.HashBlock() != header.PrevHash()
+}
+
+// prune drops the forks out of the retention, and the least recently seen ones beyond the max number
+func (m *Monitor) prune(tip uint64) {
+	for root, f := range m.forks {
+		if f.TipHeight+m.cfg.Retention < tip {
+			m.drop(root, f)
+		}
+	}
+	for len(m.forks) >= m.cfg.MaxForks {
+		var (
+			oldest *fork
+			root   hash.Hash256
+		)
+		for h, f := range m.forks {
+			if oldest == nil || f.LastSeen.Before(oldest.LastSeen) {
+				oldest, root = f, h
+			}
+		}
+		m.drop(root, oldest)
+	}
+}
+
+func (m *Monitor) drop(root hash.Hash256, f *fork) {
+	delete(m.forks, root)
+	for h, bf := range m.blocks {
+		if bf == f {
+			delete(m.blocks, h)
+		}
+	}
+}
+
+func (m *Monitor) updateMetrics() {
+	var depth uint64
+	for _, f := range m.forks {
+		if f.Depth > depth {
+			depth = f.Depth
+		}
+	}
+	_forkDepthMtc.Set(float64(depth))
+	_forkNumMtc.Set(float64(len(m.forks)))
+}
+
+func (m *Monitor) alert(f Fork) {
+	if len(m.cfg.WebhookURL) == 0 {
+		return
+	}
+	if err := m.post(f); err != nil {
+		_forkAlertMtc.WithLabelValues("failure").Inc()
+		log.L().Error("failed to post fork alert", zap.Error(err))
+		return
+	}
+	_forkAlertMtc.WithLabelValues("success").Inc()
+}
+
+func (m *Monitor) post(f Fork) error {
+	body, err := json.Marshal(&alertMessage{
+		Height:    f.Height,
+		Depth:     f.Depth,
+		Root:      hex.EncodeToString(f.Root[:]),
+		Tip:       hex.EncodeToString(f.Tip[:]),
+		TipHeight: f.TipHeight,
+		Producers: f.Producers,
+		Peers:     f.Peers,
+		FirstSeen: f.FirstSeen,
+	})
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), m.cfg.WebhookTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.cfg.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (f *fork) snapshot() Fork {
+	ret := f.Fork
+	ret.Producers = sortedKeys(f.producers)
+	ret.Peers = sortedKeys(f.peers)
+	return ret
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
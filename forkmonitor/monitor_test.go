@@ -0,0 +1,149 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package forkmonitor
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+type testChain []*block.Header
+
+func (c *testChain) TipHeight() uint64 {
+	return uint64(len(*c))
+}
+
+func (c *testChain) BlockHeaderByHeight(height uint64) (*block.Header, error) {
+	if height == 0 || height > uint64(len(*c)) {
+		return nil, errors.Errorf("block %d not exist", height)
+	}
+	return (*c)[height-1], nil
+}
+
+func newHeader(t *testing.T, height uint64, prev hash.Hash256, producer int) *block.Header {
+	blk, err := block.NewTestingBuilder().
+		SetHeight(height).
+		SetPrevBlockHash(prev).
+		SetTimeStamp(time.Unix(int64(height), 0)).
+		SignAndBuild(identityset.PrivateKey(producer))
+	require.NoError(t, err)
+	return &blk.Header
+}
+
+func TestMonitor(t *testing.T) {
+	require := require.New(t)
+	alerts := make(chan alertMessage, 1)
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		msg := alertMessage{}
+		require.NoError(json.NewDecoder(r.Body).Decode(&msg))
+		alerts <- msg
+	}))
+	defer svr.Close()
+
+	chain := &testChain{}
+	prev := hash.ZeroHash256
+	for i := uint64(1); i <= 5; i++ {
+		h := newHeader(t, i, prev, 1)
+		*chain = append(*chain, h)
+		prev = h.HashBlock()
+	}
+	cfg := DefaultConfig
+	cfg.WebhookURL = svr.URL
+	m := NewMonitor(cfg, chain)
+
+	// the blocks of the chain, and the next block on the tip are not on a fork
+	m.Observe("p1", (*chain)[2])
+	m.Observe("p1", newHeader(t, 6, prev, 1))
+	// the block too far ahead of the tip is not judged
+	m.Observe("p1", newHeader(t, 8, hash.ZeroHash256, 2))
+	require.Empty(m.Forks())
+
+	// a branch starting at height 4
+	b4 := newHeader(t, 4, (*chain)[2].HashBlock(), 2)
+	m.Observe("p1", b4)
+	m.Observe("p2", b4)
+	b5 := newHeader(t, 5, b4.HashBlock(), 3)
+	m.Observe("p2", b5)
+	forks := m.Forks()
+	require.Len(forks, 1)
+	require.Equal(uint64(4), forks[0].Height)
+	require.Equal(uint64(2), forks[0].Depth)
+	require.Equal(b4.HashBlock(), forks[0].Root)
+	require.Equal(b5.HashBlock(), forks[0].Tip)
+	require.Equal([]string{identityset.Address(2).String(), identityset.Address(3).String()}, forks[0].Producers)
+	require.Equal([]string{"p1", "p2"}, forks[0].Peers)
+	require.False(forks[0].Alerted)
+
+	// the next block of the branch, which is not built on the tip, fires the alert at depth 3
+	b6 := newHeader(t, 6, b5.HashBlock(), 2)
+	m.Observe("p3", b6)
+	select {
+	case msg := <-alerts:
+		require.Equal(uint64(4), msg.Height)
+		require.Equal(uint64(3), msg.Depth)
+		require.Equal(uint64(6), msg.TipHeight)
+		require.Equal([]string{"p1", "p2", "p3"}, msg.Peers)
+	case <-time.After(5 * time.Second):
+		require.FailNow("alert is not posted")
+	}
+	forks = m.Forks()
+	require.Len(forks, 1)
+	require.True(forks[0].Alerted)
+
+	// another branch at height 2
+	m.Observe("p4", newHeader(t, 2, (*chain)[0].HashBlock(), 4))
+	forks = m.Forks()
+	require.Len(forks, 2)
+	require.Equal(uint64(4), forks[0].Height)
+	require.Equal(uint64(2), forks[1].Height)
+	require.Equal(uint64(1), forks[1].Depth)
+
+	// the block of invalid signature is not tracked
+	invalid := newHeader(t, 3, hash.ZeroHash256, 5)
+	invalidPb := invalid.Proto()
+	invalidPb.Signature = []byte("invalid")
+	require.NoError(invalid.LoadFromBlockHeaderProto(invalidPb))
+	m.Observe("p5", invalid)
+	require.Len(m.Forks(), 2)
+
+	// the least recently seen fork is dropped beyond the max number
+	m.cfg.MaxForks = 2
+	m.Observe("p4", newHeader(t, 3, hash.ZeroHash256, 4))
+	forks = m.Forks()
+	require.Len(forks, 2)
+	require.Equal(uint64(3), forks[0].Height)
+	require.Equal(uint64(2), forks[1].Height)
+
+	// the forks out of the retention are dropped
+	m.cfg.Retention = 1
+	for i := uint64(6); i <= 10; i++ {
+		h := newHeader(t, i, prev, 1)
+		*chain = append(*chain, h)
+		prev = h.HashBlock()
+	}
+	m.Observe("p6", newHeader(t, 10, (*chain)[8].HashBlock(), 6))
+	forks = m.Forks()
+	require.Len(forks, 1)
+	require.Equal(uint64(10), forks[0].Height)
+
+	// disabled
+	m = NewMonitor(Config{}, chain)
+	m.Observe("p6", newHeader(t, 10, (*chain)[8].HashBlock(), 6))
+	require.Empty(m.Forks())
+	var nilMonitor *Monitor
+	nilMonitor.Observe("p6", b4)
+	require.Empty(nilMonitor.Forks())
+}
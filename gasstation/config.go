@@ -13,6 +13,8 @@ type Config struct {
 	DefaultGas          uint64 `yaml:"defaultGas"`
 	Percentile          int    `yaml:"Percentile"`
 	FeeHistoryCacheSize int    `yaml:"feeHistoryCacheSize"`
+	// TipPercentile is the percentile of the tips sampled from recent blocks to suggest the priority fee
+	TipPercentile int `yaml:"tipPercentile"`
 }
 
 // DefaultConfig is the default config
@@ -21,4 +23,5 @@ var DefaultConfig = Config{
 	DefaultGas:          uint64(unit.Qev),
 	Percentile:          60,
 	FeeHistoryCacheSize: 1024,
+	TipPercentile:       60,
 }
@@ -10,10 +10,10 @@ import (
 	"math/big"
 	"sort"
 
-	"github.com/ethereum/go-ethereum/params"
 	"github.com/iotexproject/go-pkgs/cache"
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -124,9 +124,13 @@ type blockFee struct {
 }
 
 type blockPercents struct {
-	ascEffectivePriorityFees []*big.Int
+	ascTips []*big.Int
+	gasUsed []uint64
 }
 
+// _tipSamplesPerBlock is the number of the lowest tips sampled from each block to suggest the tip
+const _tipSamplesPerBlock = 3
+
 // FeeHistory returns fee history over a series of blocks
 func (gs *GasStation) FeeHistory(ctx context.Context, blocks, lastBlock uint64, rewardPercentiles []float64) (uint64, [][]*big.Int, []*big.Int, []float64, []*big.Int, []float64, error) {
 	if blocks < 1 {
@@ -180,8 +184,8 @@ func (gs *GasStation) FeeHistory(ctx context.Context, blocks, lastBlock uint64,
 			}
 			baseFees[i] = blk.BaseFee()
 			gasUsedRatios[i] = float64(blk.GasUsed()) / float64(g.BlockGasLimitByHeight(blk.Height()))
-			blobBaseFees[i] = protocol.CalcBlobFee(blk.ExcessBlobGas())
-			blobGasUsedRatios[i] = float64(blk.BlobGasUsed()) / float64(params.MaxBlobGasPerBlock)
+			blobBaseFees[i] = protocol.CalcBlobFee(g.Blockchain, blk.Height(), blk.ExcessBlobGas())
+			blobGasUsedRatios[i] = float64(blk.BlobGasUsed()) / float64(g.MaxBlobGasPerBlock(blk.Height()))
 			gs.feeCache.Add(height, &blockFee{
 				baseFee:      baseFees[i],
 				gasUsedRatio: gasUsedRatios[i],
@@ -191,31 +195,11 @@ func (gs *GasStation) FeeHistory(ctx context.Context, blocks, lastBlock uint64,
 		}
 		// block priority fee percentiles
 		if len(rewardPercentiles) > 0 {
-			if blkPercents, ok := gs.percentileCache.Get(height); ok {
-				log.T(ctx).Debug("percentile cache hit", zap.Uint64("height", height))
-				rewards = append(rewards, feesPercentiles(blkPercents.(*blockPercents).ascEffectivePriorityFees, rewardPercentiles))
-			} else {
-				log.T(ctx).Debug("percentile cache miss", zap.Uint64("height", height))
-				receipts, err := gs.dao.GetReceipts(height)
-				if err != nil {
-					return 0, nil, nil, nil, nil, nil, status.Error(codes.NotFound, err.Error())
-				}
-				fees := make([]*big.Int, 0, len(receipts))
-				for _, r := range receipts {
-					if pf := r.PriorityFee(); pf != nil {
-						fees = append(fees, pf)
-					} else {
-						fees = append(fees, big.NewInt(0))
-					}
-				}
-				sort.Slice(fees, func(i, j int) bool {
-					return fees[i].Cmp(fees[j]) < 0
-				})
-				rewards = append(rewards, feesPercentiles(fees, rewardPercentiles))
-				gs.percentileCache.Add(height, &blockPercents{
-					ascEffectivePriorityFees: fees,
-				})
+			tips, err := gs.blockTips(ctx, height)
+			if err != nil {
+				return 0, nil, nil, nil, nil, nil, status.Error(codes.NotFound, err.Error())
 			}
+			rewards = append(rewards, tips.percentiles(rewardPercentiles))
 		}
 	}
 	// fill next block base fee
@@ -231,18 +215,109 @@ func (gs *GasStation) FeeHistory(ctx context.Context, blocks, lastBlock uint64,
 		GasUsed: lastBlk.GasUsed(),
 		BaseFee: lastBlk.BaseFee(),
 	})
-	blobBaseFees[blocks] = protocol.CalcBlobFee(protocol.CalcExcessBlobGas(lastBlk.ExcessBlobGas(), lastBlk.BlobGasUsed()))
+	blobBaseFees[blocks] = protocol.CalcBlobFee(g.Blockchain, lastBlock+1, protocol.CalcExcessBlobGas(g.Blockchain, lastBlock+1, lastBlk.ExcessBlobGas(), lastBlk.BlobGasUsed()))
 	return lastBlock - blocks + 1, rewards, baseFees, gasUsedRatios, blobBaseFees, blobGasUsedRatios, nil
 }
 
-func feesPercentiles(ascFees []*big.Int, percentiles []float64) []*big.Int {
+// SuggestGasTipCap suggests the priority fee per gas, which is the percentile of the lowest effective tips in recent blocks
+func (gs *GasStation) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	var (
+		samples        []*big.Int
+		endBlockHeight uint64
+		tip            = gs.bc.TipHeight()
+	)
+	if tip > uint64(gs.cfg.SuggestBlockWindow) {
+		endBlockHeight = tip - uint64(gs.cfg.SuggestBlockWindow)
+	}
+	for height := tip; height > endBlockHeight; height-- {
+		tips, err := gs.blockTips(ctx, height)
+		if err != nil {
+			return nil, err
+		}
+		samples = append(samples, tips.ascTips[:min(len(tips.ascTips), _tipSamplesPerBlock)]...)
+	}
+	if len(samples) == 0 {
+		return new(big.Int).SetUint64(gs.cfg.DefaultGas), nil
+	}
+	sort.Slice(samples, func(i, j int) bool {
+		return samples[i].Cmp(samples[j]) < 0
+	})
+	return new(big.Int).Set(samples[(len(samples)-1)*gs.cfg.TipPercentile/100]), nil
+}
+
+// blockTips returns the effective tips per gas of the user actions in the block
+func (gs *GasStation) blockTips(ctx context.Context, height uint64) (*blockPercents, error) {
+	if blkPercents, ok := gs.percentileCache.Get(height); ok {
+		log.T(ctx).Debug("percentile cache hit", zap.Uint64("height", height))
+		return blkPercents.(*blockPercents), nil
+	}
+	log.T(ctx).Debug("percentile cache miss", zap.Uint64("height", height))
+	blk, err := gs.dao.GetBlockByHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	receipts, err := gs.dao.GetReceipts(height)
+	if err != nil {
+		return nil, err
+	}
+	if len(receipts) != len(blk.Actions) {
+		return nil, errors.Errorf("number of receipts %d does not match number of actions %d at height %d", len(receipts), len(blk.Actions), height)
+	}
+	type tipGas struct {
+		tip *big.Int
+		gas uint64
+	}
+	tips := make([]tipGas, 0, len(blk.Actions))
+	for i, act := range blk.Actions {
+		if action.IsSystemAction(act) {
+			continue
+		}
+		tip, err := action.EffectiveGasTip(act, blk.BaseFee())
+		if err != nil || tip.Sign() < 0 {
+			tip = big.NewInt(0)
+		}
+		tips = append(tips, tipGas{tip, receipts[i].GasConsumed})
+	}
+	sort.SliceStable(tips, func(i, j int) bool {
+		return tips[i].tip.Cmp(tips[j].tip) < 0
+	})
+	bp := &blockPercents{
+		ascTips: make([]*big.Int, len(tips)),
+		gasUsed: make([]uint64, len(tips)),
+	}
+	for i := range tips {
+		bp.ascTips[i] = tips[i].tip
+		bp.gasUsed[i] = tips[i].gas
+	}
+	gs.percentileCache.Add(height, bp)
+	return bp, nil
+}
+
+// percentiles returns the tips at the percentiles of the gas used in the block
+func (bp *blockPercents) percentiles(percentiles []float64) []*big.Int {
 	res := make([]*big.Int, len(percentiles))
+	if len(bp.ascTips) == 0 {
+		for i := range res {
+			res[i] = big.NewInt(0)
+		}
+		return res
+	}
+	var totalGas uint64
+	for _, gas := range bp.gasUsed {
+		totalGas += gas
+	}
+	var (
+		idx     = 0
+		sumGas  = bp.gasUsed[0]
+		lastIdx = len(bp.ascTips) - 1
+	)
 	for i, p := range percentiles {
-		idx := int(float64(len(ascFees)) * p)
-		if idx >= len(ascFees) {
-			idx = len(ascFees) - 1
+		threshold := uint64(float64(totalGas) * p / 100)
+		for sumGas < threshold && idx < lastIdx {
+			idx++
+			sumGas += bp.gasUsed[idx]
 		}
-		res[i] = ascFees[idx]
+		res[i] = bp.ascTips[idx]
 	}
 	return res
 }
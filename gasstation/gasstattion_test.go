@@ -265,6 +265,56 @@ func TestSuggestGasPrice_GasConsumed(t *testing.T) {
 	}
 }
 
+func TestSuggestGasTipCap(t *testing.T) {
+	r := require.New(t)
+	blocks := prepareBlocks(r, []testActionGas{
+		{},
+		{{1, 21000}, {4, 21000}, {2, 21000}, {3, 21000}},
+		{{5, 21000}},
+		{},
+	})
+	ctrl := gomock.NewController(t)
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	dao := mock_blockdao.NewMockBlockDAO(ctrl)
+	gs := NewGasStation(bc, dao, DefaultConfig)
+	bc.EXPECT().TipHeight().Return(uint64(len(blocks) - 1)).Times(2)
+	dao.EXPECT().GetBlockByHeight(gomock.Any()).DoAndReturn(
+		func(height uint64) (*block.Block, error) {
+			return blocks[height], nil
+		},
+	).Times(3)
+	dao.EXPECT().GetReceipts(gomock.Any()).DoAndReturn(
+		func(height uint64) ([]*action.Receipt, error) {
+			return blocks[height].Receipts, nil
+		},
+	).Times(3)
+	// the lowest 3 tips of each block are 1, 2, 3, 5, and 60% percentile is 2
+	tip, err := gs.SuggestGasTipCap(context.Background())
+	r.NoError(err)
+	r.Equal(big.NewInt(2), tip)
+	// tips are cached
+	tip, err = gs.SuggestGasTipCap(context.Background())
+	r.NoError(err)
+	r.Equal(big.NewInt(2), tip)
+
+	t.Run("no samples", func(t *testing.T) {
+		bc.EXPECT().TipHeight().Return(uint64(0)).Times(1)
+		tip, err := gs.SuggestGasTipCap(context.Background())
+		r.NoError(err)
+		r.Equal(new(big.Int).SetUint64(DefaultConfig.DefaultGas), tip)
+	})
+}
+
+func TestBlockPercents(t *testing.T) {
+	r := require.New(t)
+	bp := &blockPercents{
+		ascTips: []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)},
+		gasUsed: []uint64{10, 10, 80},
+	}
+	r.Equal([]*big.Int{big.NewInt(1), big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(3)}, bp.percentiles([]float64{0, 10, 20, 50, 100}))
+	r.Equal([]*big.Int{big.NewInt(0), big.NewInt(0)}, (&blockPercents{}).percentiles([]float64{25, 75}))
+}
+
 func prepareBlocks(r *require.Assertions, cases []testActionGas) map[uint64]*block.Block {
 	blocks := map[uint64]*block.Block{}
 	for i := range cases {
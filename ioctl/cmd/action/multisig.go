@@ -0,0 +1,264 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/ioctl/config"
+	"github.com/iotexproject/iotex-core/v2/ioctl/output"
+	"github.com/iotexproject/iotex-core/v2/ioctl/util"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+// Multi-language support
+var (
+	_multisigCmdShorts = map[config.Language]string{
+		config.English: "Coordinate the transactions of the Safe multi-sig accounts",
+		config.Chinese: "协调 Safe 多签账户的交易",
+	}
+	_multisigFlagEndpointUsages = map[config.Language]string{
+		config.English: "set endpoint for once",
+		config.Chinese: "一次设置端点",
+	}
+	_multisigFlagInsecureUsages = map[config.Language]string{
+		config.English: "insecure connection for once (default false)",
+		config.Chinese: "一次不安全的连接（默认为false）",
+	}
+	_multisigFlagOutputUsages = map[config.Language]string{
+		config.English: "write the payload into the file",
+		config.Chinese: "将交易数据写入文件",
+	}
+)
+
+// MultisigCmd represents the multisig command.
+//
+// A transaction of the multi-sig account is coordinated offline through a payload file: it is created by one owner,
+// passed around to be signed by the other owners, the signed copies are combined, and it is submitted once the
+// signatures reach the threshold of the account
+var MultisigCmd = &cobra.Command{
+	Use:   "multisig",
+	Short: config.TranslateInLang(_multisigCmdShorts, config.UILanguage),
+}
+
+var _multisigOutput string
+
+func init() {
+	MultisigCmd.AddCommand(_multisigCreateCmd)
+	MultisigCmd.AddCommand(_multisigSignCmd)
+	MultisigCmd.AddCommand(_multisigCombineCmd)
+	MultisigCmd.AddCommand(_multisigSubmitCmd)
+	MultisigCmd.PersistentFlags().StringVar(&config.ReadConfig.Endpoint, "endpoint",
+		config.ReadConfig.Endpoint, config.TranslateInLang(_multisigFlagEndpointUsages, config.UILanguage))
+	MultisigCmd.PersistentFlags().BoolVar(&config.Insecure, "insecure", config.Insecure,
+		config.TranslateInLang(_multisigFlagInsecureUsages, config.UILanguage))
+}
+
+// _safeABIConst is the part of the ABI of the Safe contract used by the multisig commands
+const _safeABIConst = `[
+	{"type":"function","name":"nonce","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"getThreshold","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"getOwners","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"address[]"}]},
+	{"type":"function","name":"getTransactionHash","stateMutability":"view","inputs":[
+		{"name":"to","type":"address"},{"name":"value","type":"uint256"},{"name":"data","type":"bytes"},
+		{"name":"operation","type":"uint8"},{"name":"safeTxGas","type":"uint256"},{"name":"baseGas","type":"uint256"},
+		{"name":"gasPrice","type":"uint256"},{"name":"gasToken","type":"address"},{"name":"refundReceiver","type":"address"},
+		{"name":"_nonce","type":"uint256"}],"outputs":[{"name":"","type":"bytes32"}]},
+	{"type":"function","name":"execTransaction","stateMutability":"payable","inputs":[
+		{"name":"to","type":"address"},{"name":"value","type":"uint256"},{"name":"data","type":"bytes"},
+		{"name":"operation","type":"uint8"},{"name":"safeTxGas","type":"uint256"},{"name":"baseGas","type":"uint256"},
+		{"name":"gasPrice","type":"uint256"},{"name":"gasToken","type":"address"},{"name":"refundReceiver","type":"address"},
+		{"name":"signatures","type":"bytes"}],"outputs":[{"name":"success","type":"bool"}]}
+]`
+
+var _safeABI abi.ABI
+
+func init() {
+	var err error
+	_safeABI, err = abi.JSON(strings.NewReader(_safeABIConst))
+	if err != nil {
+		log.L().Panic("cannot get abi JSON data", zap.Error(err))
+	}
+}
+
+// multisigPayload is the transaction of the multi-sig account passed among the owners. The gas of the transaction is
+// paid by the submitter, so the refund settings of the Safe contract are left as zero
+type multisigPayload struct {
+	Safe      string `json:"safe"`
+	To        string `json:"to"`
+	Value     string `json:"value"`
+	Data      string `json:"data"`
+	Operation uint8  `json:"operation"`
+	Nonce     uint64 `json:"nonce"`
+	Hash      string `json:"hash"`
+	// Signatures are keyed by the address of the owners in hex
+	Signatures map[string]string `json:"signatures"`
+}
+
+func (p *multisigPayload) String() string {
+	if output.Format == "" {
+		b, err := json.MarshalIndent(p, "", "  ")
+		if err != nil {
+			return fmt.Sprintf("%+v", *p)
+		}
+		return string(b)
+	}
+	return output.FormatString(output.Result, p)
+}
+
+// txArgs returns the arguments of the transaction in the order of getTransactionHash and execTransaction
+func (p *multisigPayload) txArgs() ([]any, error) {
+	to, err := address.FromString(p.To)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid recipient %s", p.To)
+	}
+	value, ok := new(big.Int).SetString(p.Value, 10)
+	if !ok {
+		return nil, errors.Errorf("invalid value %s", p.Value)
+	}
+	data, err := hex.DecodeString(util.TrimHexPrefix(p.Data))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid data")
+	}
+	return []any{
+		common.BytesToAddress(to.Bytes()), value, data, p.Operation,
+		big.NewInt(0), big.NewInt(0), big.NewInt(0), common.Address{}, common.Address{},
+	}, nil
+}
+
+// hash returns the hash of the transaction to be signed, computed by the Safe contract
+func (p *multisigPayload) hash() (common.Hash, error) {
+	args, err := p.txArgs()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	res, err := readSafe(p.Safe, "getTransactionHash", append(args, new(big.Int).SetUint64(p.Nonce))...)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.Hash(res[0].([32]byte)), nil
+}
+
+// verifiedSigners returns the addresses of the owners of the valid signatures, the signatures not recovered to the
+// address they are keyed by are rejected
+func (p *multisigPayload) verifiedSigners() ([]common.Address, error) {
+	hash := common.HexToHash(p.Hash)
+	signers := make([]common.Address, 0, len(p.Signatures))
+	for owner, sig := range p.Signatures {
+		b, err := hex.DecodeString(util.TrimHexPrefix(sig))
+		if err != nil || len(b) != crypto.SignatureLength {
+			return nil, errors.Errorf("invalid signature of %s", owner)
+		}
+		// the Safe contract takes v of 27 or 28 for the signature of the hash
+		b = append([]byte{}, b...)
+		b[crypto.RecoveryIDOffset] -= 27
+		pk, err := crypto.SigToPub(hash.Bytes(), b)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid signature of %s", owner)
+		}
+		if signer := crypto.PubkeyToAddress(*pk); signer != common.HexToAddress(owner) {
+			return nil, errors.Errorf("signature of %s is signed by %s", owner, signer)
+		}
+		signers = append(signers, common.HexToAddress(owner))
+	}
+	return signers, nil
+}
+
+// packedSignatures returns the signatures sorted by the address of the owners in ascending order, as required by the
+// Safe contract
+func (p *multisigPayload) packedSignatures() ([]byte, error) {
+	signers, err := p.verifiedSigners()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(signers, func(i, j int) bool {
+		return bytes.Compare(signers[i].Bytes(), signers[j].Bytes()) < 0
+	})
+	var packed []byte
+	for _, signer := range signers {
+		for owner, sig := range p.Signatures {
+			if common.HexToAddress(owner) == signer {
+				b, _ := hex.DecodeString(util.TrimHexPrefix(sig))
+				packed = append(packed, b...)
+				break
+			}
+		}
+	}
+	return packed, nil
+}
+
+// readSafe reads the Safe contract and returns the unpacked outputs
+func readSafe(safe string, method string, args ...any) ([]any, error) {
+	contract, err := address.FromString(safe)
+	if err != nil {
+		return nil, output.NewError(output.AddressError, "invalid safe address", err)
+	}
+	bytecode, err := _safeABI.Pack(method, args...)
+	if err != nil {
+		return nil, output.NewError(output.ConvertError, "cannot generate bytecode from given command", err)
+	}
+	result, err := Read(contract, "0", bytecode)
+	if err != nil {
+		return nil, output.NewError(0, "failed to read safe contract", err)
+	}
+	data, err := hex.DecodeString(result)
+	if err != nil {
+		return nil, output.NewError(output.ConvertError, "failed to decode result", err)
+	}
+	res, err := _safeABI.Unpack(method, data)
+	if err != nil {
+		return nil, output.NewError(output.ConvertError, "failed to unpack result of "+method, err)
+	}
+	return res, nil
+}
+
+func loadMultisigPayload(path string) (*multisigPayload, error) {
+	b, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, output.NewError(output.ReadFileError, "failed to read payload file", err)
+	}
+	p := &multisigPayload{}
+	if err := json.Unmarshal(b, p); err != nil {
+		return nil, output.NewError(output.SerializationError, "failed to parse payload file "+path, err)
+	}
+	if p.Signatures == nil {
+		p.Signatures = map[string]string{}
+	}
+	return p, nil
+}
+
+// outputMultisigPayload writes the payload into the file, or prints it if the file is not given
+func outputMultisigPayload(p *multisigPayload, path string) error {
+	if path == "" {
+		fmt.Println(p.String())
+		return nil
+	}
+	b, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return output.NewError(output.SerializationError, "failed to encode payload", err)
+	}
+	if err := os.WriteFile(path, b, 0600); err != nil {
+		return output.NewError(output.WriteFileError, "failed to write payload file", err)
+	}
+	output.PrintResult(fmt.Sprintf("Payload of the safe transaction %s is written into %s", p.Hash, path))
+	return nil
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/iotexproject/iotex-core/v2/ioctl/config"
+	"github.com/iotexproject/iotex-core/v2/ioctl/output"
+)
+
+// Multi-language support
+var (
+	_multisigCombineCmdUses = map[config.Language]string{
+		config.English: "combine FILE... [-o FILE]",
+		config.Chinese: "combine 文件... [-o 文件]",
+	}
+	_multisigCombineCmdShorts = map[config.Language]string{
+		config.English: "Combine the signatures of the payloads of the same transaction of the multi-sig account",
+		config.Chinese: "合并多签账户同一交易的数据中的签名",
+	}
+)
+
+// _multisigCombineCmd represents the multisig combine command
+var _multisigCombineCmd = &cobra.Command{
+	Use:   config.TranslateInLang(_multisigCombineCmdUses, config.UILanguage),
+	Short: config.TranslateInLang(_multisigCombineCmdShorts, config.UILanguage),
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		err := multisigCombine(args, _multisigOutput)
+		return output.PrintError(err)
+	},
+}
+
+func init() {
+	_multisigCombineCmd.Flags().StringVarP(&_multisigOutput, "output", "o", "",
+		config.TranslateInLang(_multisigFlagOutputUsages, config.UILanguage))
+}
+
+func multisigCombine(paths []string, out string) error {
+	payloads := make([]*multisigPayload, 0, len(paths))
+	for _, path := range paths {
+		p, err := loadMultisigPayload(path)
+		if err != nil {
+			return err
+		}
+		payloads = append(payloads, p)
+	}
+	combined, err := combineMultisigPayloads(payloads)
+	if err != nil {
+		return err
+	}
+	return outputMultisigPayload(combined, out)
+}
+
+// combineMultisigPayloads merges the signatures of the payloads, which must be of the same transaction
+func combineMultisigPayloads(payloads []*multisigPayload) (*multisigPayload, error) {
+	combined := *payloads[0]
+	combined.Signatures = map[string]string{}
+	for _, p := range payloads {
+		if p.Hash != combined.Hash || p.Safe != combined.Safe {
+			return nil, output.NewError(output.ValidationError, "payloads are not of the same transaction", nil)
+		}
+		if _, err := p.verifiedSigners(); err != nil {
+			return nil, output.NewError(output.ValidationError, "invalid signature in the payload", err)
+		}
+		for owner, sig := range p.Signatures {
+			combined.Signatures[owner] = sig
+		}
+	}
+	return &combined, nil
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"encoding/hex"
+	"math/big"
+
+	"github.com/spf13/cobra"
+
+	"github.com/iotexproject/iotex-core/v2/ioctl/cmd/alias"
+	"github.com/iotexproject/iotex-core/v2/ioctl/config"
+	"github.com/iotexproject/iotex-core/v2/ioctl/output"
+	"github.com/iotexproject/iotex-core/v2/ioctl/util"
+)
+
+// Multi-language support
+var (
+	_multisigCreateCmdUses = map[config.Language]string{
+		config.English: "create (ALIAS|SAFE_ADDRESS) (ALIAS|RECIPIENT_ADDRESS) AMOUNT_IOTX [-b BYTE_CODE] [-o FILE]",
+		config.Chinese: "create (别名|Safe地址) (别名|接收人地址) IOTX数量 [-b 类型码] [-o 文件]",
+	}
+	_multisigCreateCmdShorts = map[config.Language]string{
+		config.English: "Create the unsigned payload of a transaction of the multi-sig account",
+		config.Chinese: "创建多签账户交易的未签名数据",
+	}
+)
+
+// _multisigCreateCmd represents the multisig create command
+var _multisigCreateCmd = &cobra.Command{
+	Use:   config.TranslateInLang(_multisigCreateCmdUses, config.UILanguage),
+	Short: config.TranslateInLang(_multisigCreateCmdShorts, config.UILanguage),
+	Args:  cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		err := multisigCreate(args)
+		return output.PrintError(err)
+	},
+}
+
+func init() {
+	_bytecodeFlag.RegisterCommand(_multisigCreateCmd)
+	_multisigCreateCmd.Flags().StringVarP(&_multisigOutput, "output", "o", "",
+		config.TranslateInLang(_multisigFlagOutputUsages, config.UILanguage))
+}
+
+func multisigCreate(args []string) error {
+	safe, err := alias.IOAddress(args[0])
+	if err != nil {
+		return output.NewError(output.AddressError, "failed to get safe address", err)
+	}
+	to, err := alias.IOAddress(args[1])
+	if err != nil {
+		return output.NewError(output.AddressError, "failed to get recipient address", err)
+	}
+	value, err := util.StringToRau(args[2], util.IotxDecimalNum)
+	if err != nil {
+		return output.NewError(output.ConvertError, "invalid amount", err)
+	}
+	data, err := decodeBytecode()
+	if err != nil {
+		return output.NewError(output.ConvertError, "invalid bytecode", err)
+	}
+	res, err := readSafe(safe.String(), "nonce")
+	if err != nil {
+		return err
+	}
+	p := &multisigPayload{
+		Safe:       safe.String(),
+		To:         to.String(),
+		Value:      value.String(),
+		Data:       hex.EncodeToString(data),
+		Nonce:      res[0].(*big.Int).Uint64(),
+		Signatures: map[string]string{},
+	}
+	hash, err := p.hash()
+	if err != nil {
+		return err
+	}
+	p.Hash = hash.Hex()
+	return outputMultisigPayload(p, _multisigOutput)
+}
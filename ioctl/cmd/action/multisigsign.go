@@ -0,0 +1,103 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+
+	"github.com/iotexproject/iotex-core/v2/ioctl/cmd/account"
+	"github.com/iotexproject/iotex-core/v2/ioctl/config"
+	"github.com/iotexproject/iotex-core/v2/ioctl/output"
+)
+
+// Multi-language support
+var (
+	_multisigSignCmdUses = map[config.Language]string{
+		config.English: "sign FILE [-s SIGNER] [-P PASSWORD] [-o FILE]",
+		config.Chinese: "sign 文件 [-s 签署人] [-P 密码] [-o 文件]",
+	}
+	_multisigSignCmdShorts = map[config.Language]string{
+		config.English: "Add the signature of an owner to the payload of the multi-sig account",
+		config.Chinese: "将一个所有者的签名加入多签账户的交易数据",
+	}
+)
+
+// _multisigSignCmd represents the multisig sign command
+var _multisigSignCmd = &cobra.Command{
+	Use:   config.TranslateInLang(_multisigSignCmdUses, config.UILanguage),
+	Short: config.TranslateInLang(_multisigSignCmdShorts, config.UILanguage),
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		err := multisigSign(args[0], _multisigOutput)
+		return output.PrintError(err)
+	},
+}
+
+func init() {
+	_signerFlag.RegisterCommand(_multisigSignCmd)
+	account.RegisterPasswordFlag(_multisigSignCmd)
+	_multisigSignCmd.Flags().StringVarP(&_multisigOutput, "output", "o", "",
+		config.TranslateInLang(_multisigFlagOutputUsages, config.UILanguage))
+}
+
+func multisigSign(path, out string) error {
+	p, err := loadMultisigPayload(path)
+	if err != nil {
+		return err
+	}
+	// the hash is computed again rather than trusting the one in the payload
+	hash, err := p.hash()
+	if err != nil {
+		return err
+	}
+	if hash != common.HexToHash(p.Hash) {
+		return output.NewError(output.ValidationError, "hash of the payload doesn't match the transaction", nil)
+	}
+	signer, err := Signer()
+	if err != nil {
+		return output.NewError(output.AddressError, "failed to get signer address", err)
+	}
+	prvKey, err := account.PrivateKeyFromSigner(signer, account.PasswordByFlag())
+	if err != nil {
+		return err
+	}
+	defer prvKey.Zero()
+	key, ok := prvKey.EcdsaPrivateKey().(*ecdsa.PrivateKey)
+	if !ok {
+		return output.NewError(output.CryptoError, "signer is not a secp256k1 key", nil)
+	}
+	owner := crypto.PubkeyToAddress(key.PublicKey)
+	res, err := readSafe(p.Safe, "getOwners")
+	if err != nil {
+		return err
+	}
+	isOwner := false
+	for _, o := range res[0].([]common.Address) {
+		if o == owner {
+			isOwner = true
+			break
+		}
+	}
+	if !isOwner {
+		return output.NewError(output.ValidationError, "signer is not an owner of the safe", nil)
+	}
+	sig, err := crypto.Sign(hash.Bytes(), key)
+	if err != nil {
+		return output.NewError(output.CryptoError, "failed to sign the payload", err)
+	}
+	sig[crypto.RecoveryIDOffset] += 27
+	p.Signatures[owner.Hex()] = hex.EncodeToString(sig)
+	if out == "" {
+		out = path
+	}
+	return outputMultisigPayload(p, out)
+}
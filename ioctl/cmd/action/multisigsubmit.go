@@ -0,0 +1,72 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/spf13/cobra"
+
+	"github.com/iotexproject/iotex-core/v2/ioctl/config"
+	"github.com/iotexproject/iotex-core/v2/ioctl/output"
+)
+
+// Multi-language support
+var (
+	_multisigSubmitCmdUses = map[config.Language]string{
+		config.English: "submit FILE [-s SIGNER] [-n NONCE] [-l GAS_LIMIT] [-p GAS_PRICE] [-P PASSWORD] [-y]",
+		config.Chinese: "submit 文件 [-s 签署人] [-n NONCE] [-l GAS限制] [-p GAS价格] [-P 密码] [-y]",
+	}
+	_multisigSubmitCmdShorts = map[config.Language]string{
+		config.English: "Submit the transaction of the multi-sig account signed by enough owners",
+		config.Chinese: "提交已由足够多所有者签名的多签账户交易",
+	}
+)
+
+// _multisigSubmitCmd represents the multisig submit command
+var _multisigSubmitCmd = &cobra.Command{
+	Use:   config.TranslateInLang(_multisigSubmitCmdUses, config.UILanguage),
+	Short: config.TranslateInLang(_multisigSubmitCmdShorts, config.UILanguage),
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		err := multisigSubmit(args[0])
+		return output.PrintError(err)
+	},
+}
+
+func init() {
+	RegisterWriteCommand(_multisigSubmitCmd)
+}
+
+func multisigSubmit(path string) error {
+	p, err := loadMultisigPayload(path)
+	if err != nil {
+		return err
+	}
+	signatures, err := p.packedSignatures()
+	if err != nil {
+		return output.NewError(output.ValidationError, "invalid signature in the payload", err)
+	}
+	res, err := readSafe(p.Safe, "getThreshold")
+	if err != nil {
+		return err
+	}
+	if threshold := res[0].(*big.Int); big.NewInt(int64(len(p.Signatures))).Cmp(threshold) < 0 {
+		return output.NewError(output.ValidationError,
+			fmt.Sprintf("%d signatures are less than the threshold %s", len(p.Signatures), threshold), nil)
+	}
+	args, err := p.txArgs()
+	if err != nil {
+		return output.NewError(output.ValidationError, "invalid payload", err)
+	}
+	bytecode, err := _safeABI.Pack("execTransaction", append(args, signatures)...)
+	if err != nil {
+		return output.NewError(output.ConvertError, "cannot generate bytecode from given command", err)
+	}
+	return Execute(p.Safe, big.NewInt(0), bytecode)
+}
@@ -0,0 +1,30 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package chain
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/iotexproject/iotex-core/v2/ioctl/config"
+)
+
+// Multi-language support
+var (
+	_chainCmdShorts = map[config.Language]string{
+		config.English: "Set up the networks of IoTeX blockchain",
+		config.Chinese: "搭建IoTeX区块链网络",
+	}
+)
+
+// ChainCmd represents the chain command
+var ChainCmd = &cobra.Command{
+	Use:   "chain",
+	Short: config.TranslateInLang(_chainCmdShorts, config.UILanguage),
+}
+
+func init() {
+	ChainCmd.AddCommand(_chainInitDevnetCmd)
+}
@@ -0,0 +1,292 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package chain
+
+import (
+	"crypto/sha1"
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/crypto"
+	p2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/consensus/consensusfsm"
+	"github.com/iotexproject/iotex-core/v2/consensus/scheme/rolldpos"
+	"github.com/iotexproject/iotex-core/v2/ioctl/config"
+	"github.com/iotexproject/iotex-core/v2/ioctl/output"
+	"github.com/iotexproject/iotex-core/v2/ioctl/util"
+)
+
+const (
+	_devnetGenesisFile = "genesis.yaml"
+	_devnetConfigFile  = "config.yaml"
+	// the ports of the n-th node are the default ports plus n
+	_devnetP2PPort       = 4689
+	_devnetGRPCPort      = 14014
+	_devnetWeb3Port      = 15014
+	_devnetWebSocketPort = 16014
+	_devnetStatsPort     = 8080
+)
+
+// Multi-language support
+var (
+	_initDevnetCmdShorts = map[config.Language]string{
+		config.English: "Generate the genesis and the node configs of a local devnet",
+		config.Chinese: "生成本地开发网络的创世配置和节点配置",
+	}
+	_initDevnetCmdLong = map[config.Language]string{
+		config.English: "ioctl chain init-devnet generates the genesis, in which all the released features are activated at\nheight 1 and the producers are funded and registered as the delegates, and the config of each node with a new\nproducer key, the ports and the data dir of its own and the fast block interval. The keys are written in plain\ntext, so the devnet must not hold anything of value.",
+		config.Chinese: "ioctl chain init-devnet 生成创世配置, 其中所有已发布的特性在高度1激活, 出块节点获得初始余额并注册为代表,\n以及每个节点的配置, 包括新的出块私钥, 独立的端口和数据目录以及较短的出块间隔. 私钥以明文写入, 开发网络不应持有任何有价值的资产.",
+	}
+	_flagDevnetNodesUsages = map[config.Language]string{
+		config.English: "number of the producer nodes",
+		config.Chinese: "出块节点的数量",
+	}
+	_flagDevnetOutputUsages = map[config.Language]string{
+		config.English: "directory the configs are written into",
+		config.Chinese: "写入配置的目录",
+	}
+	_flagDevnetIntervalUsages = map[config.Language]string{
+		config.English: "block interval",
+		config.Chinese: "出块间隔",
+	}
+	_flagDevnetChainIDUsages = map[config.Language]string{
+		config.English: "chain id and evm network id",
+		config.Chinese: "链ID和EVM网络ID",
+	}
+	_flagDevnetFundUsages = map[config.Language]string{
+		config.English: "addresses funded in addition to the producers",
+		config.Chinese: "除出块节点外获得初始余额的地址",
+	}
+	_flagDevnetBalanceUsages = map[config.Language]string{
+		config.English: "initial balance of the funded addresses in IOTX",
+		config.Chinese: "获得初始余额的地址的余额, 以IOTX为单位",
+	}
+)
+
+var (
+	// _chainInitDevnetCmd represents the chain init-devnet command
+	_chainInitDevnetCmd = &cobra.Command{
+		Use:   "init-devnet [-n NODES] [--dir DIR]",
+		Short: config.TranslateInLang(_initDevnetCmdShorts, config.UILanguage),
+		Long:  config.TranslateInLang(_initDevnetCmdLong, config.UILanguage),
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			err := initDevnet()
+			return output.PrintError(err)
+		},
+	}
+
+	_devnetNodes    int
+	_devnetOutput   string
+	_devnetInterval time.Duration
+	_devnetChainID  uint32
+	_devnetFund     []string
+	_devnetBalance  string
+)
+
+func init() {
+	_chainInitDevnetCmd.Flags().IntVarP(&_devnetNodes, "nodes", "n", 1,
+		config.TranslateInLang(_flagDevnetNodesUsages, config.UILanguage))
+	_chainInitDevnetCmd.Flags().StringVar(&_devnetOutput, "dir", "devnet",
+		config.TranslateInLang(_flagDevnetOutputUsages, config.UILanguage))
+	_chainInitDevnetCmd.Flags().DurationVar(&_devnetInterval, "interval", time.Second,
+		config.TranslateInLang(_flagDevnetIntervalUsages, config.UILanguage))
+	_chainInitDevnetCmd.Flags().Uint32Var(&_devnetChainID, "chain-id", 1337,
+		config.TranslateInLang(_flagDevnetChainIDUsages, config.UILanguage))
+	_chainInitDevnetCmd.Flags().StringSliceVar(&_devnetFund, "fund", nil,
+		config.TranslateInLang(_flagDevnetFundUsages, config.UILanguage))
+	_chainInitDevnetCmd.Flags().StringVar(&_devnetBalance, "balance", "100000000",
+		config.TranslateInLang(_flagDevnetBalanceUsages, config.UILanguage))
+}
+
+type devnetNode struct {
+	Producer string `json:"producer"`
+	Config   string `json:"config"`
+	Endpoint string `json:"endpoint"`
+}
+
+type initDevnetMessage struct {
+	Genesis string       `json:"genesis"`
+	Nodes   []devnetNode `json:"nodes"`
+}
+
+func (m *initDevnetMessage) String() string {
+	if output.Format == "" {
+		lines := []string{fmt.Sprintf("Genesis of the devnet is written into %s, start the nodes with", m.Genesis)}
+		for _, n := range m.Nodes {
+			lines = append(lines, fmt.Sprintf("  server -genesis-path=%s -config-path=%s  # producer %s, endpoint %s",
+				m.Genesis, n.Config, n.Producer, n.Endpoint))
+		}
+		return strings.Join(lines, "\n")
+	}
+	return output.FormatString(output.Result, m)
+}
+
+func initDevnet() error {
+	if _devnetNodes <= 0 {
+		return output.NewError(output.FlagError, "number of the nodes should be positive", nil)
+	}
+	if _devnetInterval < 500*time.Millisecond {
+		return output.NewError(output.FlagError, "block interval should be at least 500ms", nil)
+	}
+	balance, err := util.StringToRau(_devnetBalance, util.IotxDecimalNum)
+	if err != nil {
+		return output.NewError(output.ConvertError, "invalid balance", err)
+	}
+	dir, err := filepath.Abs(_devnetOutput)
+	if err != nil {
+		return output.NewError(output.InputError, "invalid output directory", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, _devnetGenesisFile)); err == nil {
+		return output.NewError(output.InputError, fmt.Sprintf("devnet already exists in %s", dir), nil)
+	}
+
+	builder := genesis.NewBuilder().
+		SetNumDelegates(uint64(_devnetNodes)).
+		SetNumSubEpochs(2).
+		SetTimestamp(time.Now().Unix()).
+		ActivateAllAt(1)
+	for _, addr := range _devnetFund {
+		builder.FundAccount(addr, balance)
+	}
+	keys := make([]crypto.PrivateKey, _devnetNodes)
+	for i := range keys {
+		if keys[i], err = crypto.GenerateKey(); err != nil {
+			return output.NewError(output.CryptoError, "failed to generate producer key", err)
+		}
+		producer := keys[i].PublicKey().Address().String()
+		builder.FundAccount(producer, balance).AddDelegate(producer, producer, balance)
+	}
+	g, err := builder.Build()
+	if err != nil {
+		return output.NewError(output.InputError, "failed to build genesis", err)
+	}
+	message := initDevnetMessage{Genesis: filepath.Join(dir, _devnetGenesisFile)}
+	if err := writeYAML(message.Genesis, &g); err != nil {
+		return err
+	}
+
+	bootstrap, err := devnetPeerAddr(0)
+	if err != nil {
+		return output.NewError(output.CryptoError, "failed to derive bootstrap node", err)
+	}
+	for i, key := range keys {
+		path := filepath.Join(dir, fmt.Sprintf("node%d", i), _devnetConfigFile)
+		if err := writeYAML(path, devnetNodeConfig(i, key, filepath.Dir(path), bootstrap)); err != nil {
+			return err
+		}
+		message.Nodes = append(message.Nodes, devnetNode{
+			Producer: key.PublicKey().Address().String(),
+			Config:   path,
+			Endpoint: fmt.Sprintf("127.0.0.1:%d", _devnetGRPCPort+i),
+		})
+	}
+	fmt.Println(message.String())
+	return nil
+}
+
+// devnetNodeConfig returns the config of the i-th node overriding the default config
+func devnetNodeConfig(i int, key crypto.PrivateKey, dir string, bootstrap string) map[string]any {
+	network := map[string]any{
+		"host":         "127.0.0.1",
+		"port":         _devnetP2PPort + i,
+		"externalHost": "127.0.0.1",
+		"externalPort": _devnetP2PPort + i,
+		"masterKey":    devnetMasterKey(i),
+	}
+	if i > 0 {
+		network["bootstrapNodes"] = []string{bootstrap}
+	}
+	// the timings of the consensus round are scaled from the ones of the wake upgrade to fit in the block interval
+	def := consensusfsm.DefaultWakeUpgradeConfig
+	scale := func(d time.Duration) string {
+		return time.Duration(float64(d) * float64(_devnetInterval) / float64(def.BlockInterval)).Round(time.Millisecond).String()
+	}
+	return map[string]any{
+		"system": map[string]any{
+			"dataDir":       filepath.Join(dir, "data"),
+			"httpStatsPort": _devnetStatsPort + i,
+		},
+		"network": network,
+		"chain": map[string]any{
+			"id":              _devnetChainID,
+			"evmNetworkID":    _devnetChainID,
+			"producerPrivKey": key.HexString(),
+			// the patch of the trie db only applies to the mainnet
+			"trieDBPatchFile": "",
+		},
+		"actPool": map[string]any{
+			"minGasPrice": "0",
+		},
+		"consensus": map[string]any{
+			"scheme": "ROLLDPOS",
+			"rollDPoS": map[string]any{
+				"toleratedOvertime": scale(rolldpos.DefaultConfig.ToleratedOvertime),
+			},
+		},
+		"wakeUpgrade": map[string]any{
+			"unmatchedEventTTL":            scale(def.UnmatchedEventTTL),
+			"unmatchedEventInterval":       scale(def.UnmatchedEventInterval),
+			"acceptBlockTTL":               scale(def.AcceptBlockTTL),
+			"acceptProposalEndorsementTTL": scale(def.AcceptProposalEndorsementTTL),
+			"acceptLockEndorsementTTL":     scale(def.AcceptLockEndorsementTTL),
+			"commitTTL":                    scale(def.CommitTTL),
+			"blockInterval":                _devnetInterval.String(),
+		},
+		"api": map[string]any{
+			"port":          _devnetGRPCPort + i,
+			"web3port":      _devnetWeb3Port + i,
+			"webSocketPort": _devnetWebSocketPort + i,
+		},
+	}
+}
+
+func devnetMasterKey(i int) string {
+	return fmt.Sprintf("devnet-node%d", i)
+}
+
+// devnetPeerAddr returns the multiaddress of the i-th node. The identity of the node is derived from the master key of
+// the p2p network the same way as the p2p host does, so that it is known before the node starts
+func devnetPeerAddr(i int) (string, error) {
+	hash := sha1.Sum([]byte(devnetMasterKey(i)))
+	seedBytes := hash[12:]
+	seedBytes[0] = 0
+	r := rand.New(rand.NewSource(int64(binary.BigEndian.Uint64(seedBytes))))
+	_, pk, err := p2pcrypto.GenerateKeyPairWithReader(p2pcrypto.Ed25519, 2048, r)
+	if err != nil {
+		return "", err
+	}
+	id, err := peer.IDFromPublicKey(pk)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("/ip4/127.0.0.1/tcp/%d/p2p/%s", _devnetP2PPort+i, id), nil
+}
+
+func writeYAML(path string, v any) error {
+	b, err := yaml.Marshal(v)
+	if err != nil {
+		return output.NewError(output.SerializationError, "failed to encode "+path, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return output.NewError(output.WriteFileError, "failed to create directory", err)
+	}
+	if err := os.WriteFile(path, b, 0600); err != nil {
+		return output.NewError(output.WriteFileError, "failed to write "+path, err)
+	}
+	return nil
+}
@@ -39,6 +39,9 @@ func init() {
 	NodeCmd.AddCommand(_nodeDelegateCmd)
 	NodeCmd.AddCommand(_nodeRewardCmd)
 	NodeCmd.AddCommand(_nodeProbationlistCmd)
+	NodeCmd.AddCommand(_nodeStatusCmd)
+	NodeCmd.AddCommand(_nodeConfigCmd)
+	NodeCmd.AddCommand(_nodeDoctorCmd)
 	NodeCmd.PersistentFlags().StringVar(&config.ReadConfig.Endpoint, "endpoint",
 		config.ReadConfig.Endpoint, config.TranslateInLang(_flagEndpointUsages, config.UILanguage))
 	NodeCmd.PersistentFlags().BoolVar(&config.Insecure, "insecure", config.Insecure,
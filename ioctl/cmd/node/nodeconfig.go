@@ -0,0 +1,93 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package node
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	serverCfg "github.com/iotexproject/iotex-core/v2/config"
+	"github.com/iotexproject/iotex-core/v2/ioctl/config"
+	"github.com/iotexproject/iotex-core/v2/ioctl/output"
+)
+
+// Multi-language support
+var (
+	_configCmdShorts = map[config.Language]string{
+		config.English: "Deal with the config of the node",
+		config.Chinese: "处理节点的配置",
+	}
+	_configValidateCmdUses = map[config.Language]string{
+		config.English: "validate CONFIG_PATH...",
+		config.Chinese: "validate 配置文件路径...",
+	}
+	_configValidateCmdShorts = map[config.Language]string{
+		config.English: "Validate the config files of the node",
+		config.Chinese: "校验节点的配置文件",
+	}
+	_configValidateCmdLong = map[config.Language]string{
+		config.English: "ioctl node config validate loads the config files in order, e.g., config.yaml and secret.yaml, as the node\ndoes, and reports the invalid settings, the collisions of the store paths and the ports, the deprecated settings,\nthe inconsistent feature flags and the issues of the producer keys.",
+		config.Chinese: "ioctl node config validate 像节点一样按顺序加载配置文件, 如 config.yaml 和 secret.yaml, 并报告无效的配置,\n存储路径和端口的冲突, 废弃的配置, 不一致的功能开关以及出块私钥的问题.",
+	}
+	_flagPluginUsages = map[config.Language]string{
+		config.English: "plugin of the node, e.g., gateway",
+		config.Chinese: "节点的插件, 如 gateway",
+	}
+)
+
+var (
+	// _nodeConfigCmd represents the node config command
+	_nodeConfigCmd = &cobra.Command{
+		Use:   "config",
+		Short: config.TranslateInLang(_configCmdShorts, config.UILanguage),
+	}
+
+	// _nodeConfigValidateCmd represents the node config validate command
+	_nodeConfigValidateCmd = &cobra.Command{
+		Use:   config.TranslateInLang(_configValidateCmdUses, config.UILanguage),
+		Short: config.TranslateInLang(_configValidateCmdShorts, config.UILanguage),
+		Long:  config.TranslateInLang(_configValidateCmdLong, config.UILanguage),
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			err := validateConfig(args, _plugins)
+			return output.PrintError(err)
+		},
+	}
+
+	_plugins []string
+)
+
+func init() {
+	_nodeConfigValidateCmd.Flags().StringSliceVar(&_plugins, "plugin", nil,
+		config.TranslateInLang(_flagPluginUsages, config.UILanguage))
+	_nodeConfigCmd.AddCommand(_nodeConfigValidateCmd)
+}
+
+type configReportMessage struct {
+	*serverCfg.Report
+}
+
+func (m *configReportMessage) String() string {
+	if output.Format == "" {
+		return m.Report.String()
+	}
+	return output.FormatString(output.Result, m)
+}
+
+func validateConfig(paths, plugins []string) error {
+	cfg, err := serverCfg.New(paths, plugins, serverCfg.DoNotValidate)
+	if err != nil {
+		return output.NewError(output.ConfigError, "failed to load config", err)
+	}
+	report := serverCfg.Diagnose(cfg)
+	fmt.Println((&configReportMessage{report}).String())
+	if report.HasError() {
+		return output.NewError(output.ValidationError, "config is invalid", nil)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package node
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	serverCfg "github.com/iotexproject/iotex-core/v2/config"
+	"github.com/iotexproject/iotex-core/v2/doctor"
+	"github.com/iotexproject/iotex-core/v2/ioctl/config"
+	"github.com/iotexproject/iotex-core/v2/ioctl/output"
+)
+
+// Multi-language support
+var (
+	_doctorCmdUses = map[config.Language]string{
+		config.English: "doctor CONFIG_PATH... [--depth DEPTH]",
+		config.Chinese: "doctor 配置文件路径... [--depth 深度]",
+	}
+	_doctorCmdShorts = map[config.Language]string{
+		config.English: "Check the integrity of the dbs of the stopped node",
+		config.Chinese: "检查已停止节点的数据库完整性",
+	}
+	_doctorCmdLong = map[config.Language]string{
+		config.English: "ioctl node doctor loads the config files of the node, checks the consistency of the heights of chain.db,\ntrie.db and the index dbs, the blocks and the receipts missing at the tip and the orphan files left in the data\ndirectories, and suggests the commands to repair the issues. The node must be stopped.",
+		config.Chinese: "ioctl node doctor 加载节点的配置文件, 检查 chain.db, trie.db 和索引数据库的高度是否一致, 链顶的区块和收据是否缺失\n以及数据目录中遗留的孤立文件, 并给出修复问题的命令. 节点必须已停止.",
+	}
+	_flagDoctorDepthUsages = map[config.Language]string{
+		config.English: "number of the blocks below the tip whose blocks and receipts are checked",
+		config.Chinese: "检查区块和收据的链顶以下区块数量",
+	}
+)
+
+var (
+	// _nodeDoctorCmd represents the node doctor command
+	_nodeDoctorCmd = &cobra.Command{
+		Use:   config.TranslateInLang(_doctorCmdUses, config.UILanguage),
+		Short: config.TranslateInLang(_doctorCmdShorts, config.UILanguage),
+		Long:  config.TranslateInLang(_doctorCmdLong, config.UILanguage),
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			err := nodeDoctor(args, _plugins, _doctorDepth)
+			return output.PrintError(err)
+		},
+	}
+
+	_doctorDepth uint64
+)
+
+func init() {
+	_nodeDoctorCmd.Flags().Uint64Var(&_doctorDepth, "depth", 100,
+		config.TranslateInLang(_flagDoctorDepthUsages, config.UILanguage))
+	_nodeDoctorCmd.Flags().StringSliceVar(&_plugins, "plugin", nil,
+		config.TranslateInLang(_flagPluginUsages, config.UILanguage))
+}
+
+type doctorReportMessage struct {
+	*doctor.Report
+}
+
+func (m *doctorReportMessage) String() string {
+	if output.Format == "" {
+		return m.Report.String()
+	}
+	return output.FormatString(output.Result, m)
+}
+
+func nodeDoctor(paths, plugins []string, depth uint64) error {
+	cfg, err := serverCfg.New(paths, plugins, serverCfg.DoNotValidate)
+	if err != nil {
+		return output.NewError(output.ConfigError, "failed to load config", err)
+	}
+	report := doctor.Run(context.Background(), cfg, doctor.WithDepth(depth))
+	fmt.Println((&doctorReportMessage{report}).String())
+	if report.HasError() {
+		return output.NewError(output.ValidationError, "dbs of the node are inconsistent", nil)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/util/metautils"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/iotexproject/iotex-core/v2/api/apipb"
+	"github.com/iotexproject/iotex-core/v2/ioctl/config"
+	"github.com/iotexproject/iotex-core/v2/ioctl/output"
+	"github.com/iotexproject/iotex-core/v2/ioctl/util"
+)
+
+// Multi-language support
+var (
+	_statusCmdUses = map[config.Language]string{
+		config.English: "status",
+		config.Chinese: "status",
+	}
+	_statusCmdShorts = map[config.Language]string{
+		config.English: "Print the sync status of the node",
+		config.Chinese: "打印节点的同步状态",
+	}
+	_statusCmdLong = map[config.Language]string{
+		config.English: "ioctl node status returns the current stage of the sync, and the progress, the download rate and the ETA of\neach stage: header sync, body download, state sync and indexing.",
+		config.Chinese: "ioctl node status 返回同步的当前阶段, 以及各阶段(区块头同步, 区块下载, 状态同步, 索引)的进度, 下载速率和预计完成时间.",
+	}
+)
+
+// _nodeStatusCmd represents the node status command
+var _nodeStatusCmd = &cobra.Command{
+	Use:   config.TranslateInLang(_statusCmdUses, config.UILanguage),
+	Short: config.TranslateInLang(_statusCmdShorts, config.UILanguage),
+	Long:  config.TranslateInLang(_statusCmdLong, config.UILanguage),
+	Args:  cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		err := syncStatus()
+		return output.PrintError(err)
+	},
+}
+
+type stageMessage struct {
+	Stage         string  `json:"stage"`
+	StartHeight   uint64  `json:"startHeight"`
+	CurrentHeight uint64  `json:"currentHeight"`
+	TargetHeight  uint64  `json:"targetHeight"`
+	Progress      float64 `json:"progress"`
+	Rate          float64 `json:"rate"`
+	ETA           string  `json:"eta"`
+}
+
+type syncStatusMessage struct {
+	Stage  string          `json:"stage"`
+	ETA    string          `json:"eta"`
+	Stages []*stageMessage `json:"stages"`
+}
+
+func (m *syncStatusMessage) String() string {
+	if output.Format == "" {
+		lines := []string{fmt.Sprintf("Stage: %s, ETA: %s", m.Stage, m.ETA)}
+		for _, s := range m.Stages {
+			lines = append(lines, fmt.Sprintf("%-14s %d/%d (%.2f%%), rate: %.2f blocks/s, ETA: %s",
+				s.Stage, s.CurrentHeight, s.TargetHeight, s.Progress, s.Rate, s.ETA))
+		}
+		return strings.Join(lines, "\n")
+	}
+	return output.FormatString(output.Result, m)
+}
+
+func formatETA(eta *durationpb.Duration, completed bool) string {
+	switch {
+	case completed:
+		return "-"
+	case eta == nil:
+		return "unknown"
+	default:
+		return eta.AsDuration().Round(time.Second).String()
+	}
+}
+
+func syncStatus() error {
+	conn, err := util.ConnectToEndpoint(config.ReadConfig.SecureConnect && !config.Insecure)
+	if err != nil {
+		return output.NewError(output.NetworkError, "failed to connect to endpoint", err)
+	}
+	defer conn.Close()
+	cli := apipb.NewSyncServiceClient(conn)
+	ctx := context.Background()
+
+	jwtMD, err := util.JwtAuth()
+	if err == nil {
+		ctx = metautils.NiceMD(jwtMD).ToOutgoing(ctx)
+	}
+
+	response, err := cli.GetSyncStatus(ctx, &apipb.GetSyncStatusRequest{})
+	if err != nil {
+		sta, ok := status.FromError(err)
+		if ok {
+			return output.NewError(output.APIError, sta.Message(), nil)
+		}
+		return output.NewError(output.NetworkError, "failed to invoke GetSyncStatus api", err)
+	}
+	message := &syncStatusMessage{
+		Stage: response.Stage.String(),
+		ETA:   formatETA(response.Eta, response.Stage == apipb.SyncStage_SYNCED),
+	}
+	for _, s := range response.Stages {
+		message.Stages = append(message.Stages, &stageMessage{
+			Stage:         s.Stage.String(),
+			StartHeight:   s.StartHeight,
+			CurrentHeight: s.CurrentHeight,
+			TargetHeight:  s.TargetHeight,
+			Progress:      s.Progress,
+			Rate:          s.Rate,
+			ETA:           formatETA(s.Eta, s.CurrentHeight >= s.TargetHeight),
+		})
+	}
+	fmt.Println(message.String())
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/iotexproject/iotex-core/v2/ioctl/cmd/action"
 	"github.com/iotexproject/iotex-core/v2/ioctl/cmd/alias"
 	"github.com/iotexproject/iotex-core/v2/ioctl/cmd/bc"
+	"github.com/iotexproject/iotex-core/v2/ioctl/cmd/chain"
 	"github.com/iotexproject/iotex-core/v2/ioctl/cmd/contract"
 	"github.com/iotexproject/iotex-core/v2/ioctl/cmd/did"
 	"github.com/iotexproject/iotex-core/v2/ioctl/cmd/hdwallet"
@@ -64,7 +65,9 @@ func NewIoctl() *cobra.Command {
 	rootCmd.AddCommand(action.ActionCmd)
 	rootCmd.AddCommand(action.Xrc20Cmd)
 	rootCmd.AddCommand(action.Stake2Cmd)
+	rootCmd.AddCommand(action.MultisigCmd)
 	rootCmd.AddCommand(bc.BCCmd)
+	rootCmd.AddCommand(chain.ChainCmd)
 	rootCmd.AddCommand(node.NodeCmd)
 	rootCmd.AddCommand(version.VersionCmd)
 	rootCmd.AddCommand(update.UpdateCmd)
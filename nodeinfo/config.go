@@ -13,6 +13,9 @@ type Config struct {
 	BroadcastNodeInfoInterval time.Duration `yaml:"broadcastNodeInfoInterval"`
 	BroadcastListTTL          time.Duration `yaml:"broadcastListTTL"`
 	NodeMapSize               int           `yaml:"nodeMapSize"`
+	// EnableHeartbeat enables the delegates to broadcast the heartbeat with the consensus stats once per epoch
+	EnableHeartbeat        bool          `yaml:"enableHeartbeat"`
+	HeartbeatCheckInterval time.Duration `yaml:"heartbeatCheckInterval"`
 }
 
 // DefaultConfig is the default config
@@ -21,4 +24,6 @@ var DefaultConfig = Config{
 	BroadcastNodeInfoInterval: 5 * time.Minute,
 	BroadcastListTTL:          30 * time.Minute,
 	NodeMapSize:               1000,
+	EnableHeartbeat:           false,
+	HeartbeatCheckInterval:    10 * time.Second,
 }
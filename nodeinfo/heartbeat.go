@@ -0,0 +1,200 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package nodeinfo
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/cache/lru"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/nodeinfo/heartbeatpb"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+// _heartbeatField is the field number of the heartbeat in the node info message. The heartbeat is carried as an
+// unknown field, which is kept and covered by the signature on the nodes not aware of it
+const _heartbeatField protowire.Number = 100
+
+type (
+	// HeartbeatStats are the consensus stats of the delegate reported in the heartbeat
+	HeartbeatStats struct {
+		Epoch uint64
+		// ProposalLatency and CommitLatency are the times from the round start to the receipt of the block proposal
+		// and the quorum of the commit endorsements in the latest round
+		ProposalLatency  time.Duration
+		CommitLatency    time.Duration
+		ViewChanges      uint64
+		LastCommitRounds uint32
+	}
+
+	// Heartbeat is the signed report of the health of a delegate, which is broadcast once per epoch
+	Heartbeat struct {
+		Info
+		HeartbeatStats
+	}
+
+	// EpochFunc returns the epoch number of the height
+	EpochFunc func(uint64) uint64
+
+	// HeartbeatStatsFunc returns the consensus stats of the node
+	HeartbeatStatsFunc func() (HeartbeatStats, error)
+)
+
+var _heartbeatLatencyGauge = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "iotex_node_heartbeat_latency_seconds",
+		Help: "consensus latencies reported in the heartbeats of the delegates",
+	},
+	[]string{"address", "kind"},
+)
+
+func init() {
+	prometheus.MustRegister(_heartbeatLatencyGauge)
+}
+
+// SetHeartbeatSource sets the functions providing the epoch and the consensus stats of the heartbeat
+func (dm *InfoManager) SetHeartbeatSource(epochFunc EpochFunc, statsFunc HeartbeatStatsFunc) {
+	dm.epochFunc = epochFunc
+	dm.statsFunc = statsFunc
+}
+
+// BroadcastHeartbeat broadcasts the node info messages of the addresses carrying the heartbeat
+func (dm *InfoManager) BroadcastHeartbeat(ctx context.Context, addrs []string, stats HeartbeatStats) error {
+	infos, err := dm.genNodeInfoMsg(addrs, &stats)
+	if err != nil {
+		return err
+	}
+	peer, err := dm.transmitter.Info()
+	if err != nil {
+		return err
+	}
+	for _, info := range infos {
+		if err := dm.transmitter.BroadcastOutbound(ctx, info); err != nil {
+			return err
+		}
+		node := Info{
+			Version:   info.Info.Version,
+			Height:    info.Info.Height,
+			Timestamp: info.Info.Timestamp.AsTime(),
+			Address:   info.Info.Address,
+			PeerID:    peer.ID.String(),
+		}
+		dm.updateNode(&node)
+		dm.updateHeartbeat(&Heartbeat{Info: node, HeartbeatStats: stats})
+	}
+	return nil
+}
+
+// Heartbeats returns the latest heartbeats of the delegates, in the order of the addresses
+func (dm *InfoManager) Heartbeats() []Heartbeat {
+	if dm == nil {
+		return nil
+	}
+	heartbeats := make([]Heartbeat, 0, dm.heartbeats.Len())
+	dm.heartbeats.Range(func(_ lru.Key, v interface{}) bool {
+		heartbeats = append(heartbeats, v.(Heartbeat))
+		return true
+	})
+	sort.Slice(heartbeats, func(i, j int) bool {
+		return heartbeats[i].Address < heartbeats[j].Address
+	})
+	return heartbeats
+}
+
+// checkHeartbeat broadcasts the heartbeat of the delegates once the chain enters a new epoch
+func (dm *InfoManager) checkHeartbeat() {
+	if dm.epochFunc == nil || dm.statsFunc == nil {
+		return
+	}
+	addrs := dm.inBroadcastList()
+	if len(addrs) == 0 {
+		return
+	}
+	epoch := dm.epochFunc(dm.chain.TipHeight())
+	if epoch <= dm.heartbeatEpoch.Load() {
+		return
+	}
+	stats, err := dm.statsFunc()
+	if err != nil {
+		log.L().Error("nodeinfo manager failed to get heartbeat stats", zap.Error(err))
+		return
+	}
+	stats.Epoch = epoch
+	if err := dm.BroadcastHeartbeat(context.Background(), addrs, stats); err != nil {
+		log.L().Error("nodeinfo manager broadcast heartbeat failed", zap.Error(err))
+		return
+	}
+	dm.heartbeatEpoch.Store(epoch)
+}
+
+func (dm *InfoManager) updateHeartbeat(hb *Heartbeat) {
+	if v, ok := dm.heartbeats.Get(hb.Address); ok && v.(Heartbeat).Epoch > hb.Epoch {
+		return
+	}
+	dm.heartbeats.Add(hb.Address, *hb)
+	_heartbeatLatencyGauge.WithLabelValues(hb.Address, "proposal").Set(hb.ProposalLatency.Seconds())
+	_heartbeatLatencyGauge.WithLabelValues(hb.Address, "commit").Set(hb.CommitLatency.Seconds())
+}
+
+// setHeartbeat appends the heartbeat to the node info core as an unknown field
+func setHeartbeat(core *iotextypes.NodeInfoCore, stats *HeartbeatStats) error {
+	b, err := proto.Marshal(&heartbeatpb.Heartbeat{
+		Epoch:            stats.Epoch,
+		ProposalLatency:  uint64(stats.ProposalLatency.Milliseconds()),
+		CommitLatency:    uint64(stats.CommitLatency.Milliseconds()),
+		ViewChanges:      stats.ViewChanges,
+		LastCommitRounds: stats.LastCommitRounds,
+	})
+	if err != nil {
+		return err
+	}
+	raw := protowire.AppendTag(nil, _heartbeatField, protowire.BytesType)
+	core.ProtoReflect().SetUnknown(protowire.AppendBytes(raw, b))
+	return nil
+}
+
+// getHeartbeat returns the heartbeat in the node info core, or nil if there is none
+func getHeartbeat(core *iotextypes.NodeInfoCore) (*HeartbeatStats, error) {
+	raw := core.ProtoReflect().GetUnknown()
+	for len(raw) > 0 {
+		num, typ, n := protowire.ConsumeTag(raw)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		raw = raw[n:]
+		if num != _heartbeatField || typ != protowire.BytesType {
+			if n = protowire.ConsumeFieldValue(num, typ, raw); n < 0 {
+				return nil, protowire.ParseError(n)
+			}
+			raw = raw[n:]
+			continue
+		}
+		b, n := protowire.ConsumeBytes(raw)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		pb := &heartbeatpb.Heartbeat{}
+		if err := proto.Unmarshal(b, pb); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal heartbeat")
+		}
+		return &HeartbeatStats{
+			Epoch:            pb.Epoch,
+			ProposalLatency:  time.Duration(pb.ProposalLatency) * time.Millisecond,
+			CommitLatency:    time.Duration(pb.CommitLatency) * time.Millisecond,
+			ViewChanges:      pb.ViewChanges,
+			LastCommitRounds: pb.LastCommitRounds,
+		}, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package nodeinfo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/test/mock/mock_nodeinfo"
+)
+
+func TestHeartbeat(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	privKey, err := crypto.GenerateKey()
+	require.NoError(err)
+	addr := privKey.PublicKey().Address().String()
+
+	var sent []*iotextypes.NodeInfo
+	hMock := mock_nodeinfo.NewMockchain(ctrl)
+	tMock := mock_nodeinfo.NewMocktransmitter(ctrl)
+	tMock.EXPECT().Info().Return(peer.AddrInfo{}, nil).AnyTimes()
+	tMock.EXPECT().BroadcastOutbound(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, msg proto.Message) error {
+		// the message is received by the peers from the wire
+		b, err := proto.Marshal(msg)
+		require.NoError(err)
+		info := &iotextypes.NodeInfo{}
+		require.NoError(proto.Unmarshal(b, info))
+		sent = append(sent, info)
+		return nil
+	}).AnyTimes()
+	tip := uint64(10)
+	hMock.EXPECT().TipHeight().DoAndReturn(func() uint64 { return tip }).AnyTimes()
+
+	dm := NewInfoManager(&DefaultConfig, tMock, hMock, func() []string { return []string{addr} }, privKey)
+	dm.updateBroadcastList()
+	stats := HeartbeatStats{
+		ProposalLatency:  1500 * time.Millisecond,
+		CommitLatency:    3 * time.Second,
+		ViewChanges:      2,
+		LastCommitRounds: 1,
+	}
+	dm.SetHeartbeatSource(func(h uint64) uint64 { return h/5 + 1 }, func() (HeartbeatStats, error) { return stats, nil })
+
+	// broadcast once per epoch
+	dm.checkHeartbeat()
+	dm.checkHeartbeat()
+	require.Len(sent, 1)
+	tip = 15
+	dm.checkHeartbeat()
+	require.Len(sent, 2)
+	heartbeats := dm.Heartbeats()
+	require.Len(heartbeats, 1)
+	require.Equal(addr, heartbeats[0].Address)
+	require.Equal(uint64(4), heartbeats[0].Epoch)
+
+	// the heartbeat is verified and aggregated by the peers
+	dm2 := NewInfoManager(&DefaultConfig, tMock, hMock, nil)
+	dm2.HandleNodeInfo(context.Background(), "peer1", sent[1])
+	heartbeats = dm2.Heartbeats()
+	require.Len(heartbeats, 1)
+	require.Equal(addr, heartbeats[0].Address)
+	require.Equal(uint64(15), heartbeats[0].Height)
+	require.Equal("peer1", heartbeats[0].PeerID)
+	stats.Epoch = 4
+	require.Equal(stats, heartbeats[0].HeartbeatStats)
+	// the heartbeat of an earlier epoch doesn't replace the latest one
+	dm2.HandleNodeInfo(context.Background(), "peer1", sent[0])
+	require.Equal(uint64(4), dm2.Heartbeats()[0].Epoch)
+	// the node info is tracked along with the heartbeat
+	_, ok := dm2.GetNodeInfo(addr)
+	require.True(ok)
+
+	// the tampered heartbeat is covered by the signature
+	tampered := proto.Clone(sent[1]).(*iotextypes.NodeInfo)
+	stats.Epoch = 5
+	require.NoError(setHeartbeat(tampered.Info, &stats))
+	dm3 := NewInfoManager(&DefaultConfig, tMock, hMock, nil)
+	dm3.HandleNodeInfo(context.Background(), "peer1", tampered)
+	require.Empty(dm3.Heartbeats())
+	_, ok = dm3.GetNodeInfo(addr)
+	require.False(ok)
+}
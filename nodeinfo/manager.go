@@ -60,6 +60,10 @@ type (
 		privKeys             map[string]crypto.PrivateKey
 		addrs                []string
 		getBroadcastListFunc getBroadcastListFunc
+		heartbeats           *lru.Cache
+		heartbeatEpoch       atomic.Uint64
+		epochFunc            EpochFunc
+		statsFunc            HeartbeatStatsFunc
 	}
 
 	getBroadcastListFunc func() []string
@@ -94,6 +98,7 @@ func NewInfoManager(cfg *Config, t transmitter, ch chain, broadcastListFunc getB
 		privKeys:             keyMaps,
 		version:              version.PackageVersion,
 		getBroadcastListFunc: broadcastListFunc,
+		heartbeats:           lru.New(cfg.NodeMapSize),
 	}
 	dm.broadcastList.Store([]string{})
 	// init recurring tasks
@@ -115,6 +120,9 @@ func NewInfoManager(cfg *Config, t transmitter, ch chain, broadcastListFunc getB
 		dm.updateBroadcastList()
 	}, cfg.BroadcastListTTL)
 	dm.AddModels(updateBroadcastListTask, broadcastTask)
+	if cfg.EnableHeartbeat {
+		dm.AddModels(routine.NewRecurringTask(dm.checkHeartbeat, cfg.HeartbeatCheckInterval))
+	}
 	return dm
 }
 
@@ -145,13 +153,22 @@ func (dm *InfoManager) HandleNodeInfo(ctx context.Context, peerID string, msg *i
 		return
 	}
 
-	dm.updateNode(&Info{
+	node := Info{
 		Version:   msg.Info.Version,
 		Height:    msg.Info.Height,
 		Timestamp: msg.Info.Timestamp.AsTime(),
 		Address:   msg.Info.Address,
 		PeerID:    peerID,
-	})
+	}
+	dm.updateNode(&node)
+	stats, err := getHeartbeat(msg.Info)
+	if err != nil {
+		log.L().Warn("nodeinfo manager invalid heartbeat", zap.String("address", node.Address), zap.Error(err))
+		return
+	}
+	if stats != nil {
+		dm.updateHeartbeat(&Heartbeat{Info: node, HeartbeatStats: *stats})
+	}
 }
 
 // updateNode update node info
@@ -175,7 +192,7 @@ func (dm *InfoManager) GetNodeInfo(addr string) (Info, bool) {
 // BroadcastNodeInfo broadcast request node info message
 func (dm *InfoManager) BroadcastNodeInfo(ctx context.Context, addrs []string) error {
 	log.L().Debug("nodeinfo manager broadcast node info")
-	infos, err := dm.genNodeInfoMsg(addrs)
+	infos, err := dm.genNodeInfoMsg(addrs, nil)
 	if err != nil {
 		return err
 	}
@@ -209,7 +226,7 @@ func (dm *InfoManager) RequestSingleNodeInfoAsync(ctx context.Context, peer peer
 // HandleNodeInfoRequest tell node info to peer
 func (dm *InfoManager) HandleNodeInfoRequest(ctx context.Context, peer peer.AddrInfo) error {
 	log.L().Debug("nodeinfo manager tell node info", zap.Any("peer", peer.ID.String()))
-	infos, err := dm.genNodeInfoMsg(dm.addrs)
+	infos, err := dm.genNodeInfoMsg(dm.addrs, nil)
 	if err != nil {
 		return err
 	}
@@ -221,7 +238,7 @@ func (dm *InfoManager) HandleNodeInfoRequest(ctx context.Context, peer peer.Addr
 	return nil
 }
 
-func (dm *InfoManager) genNodeInfoMsg(addrs []string) ([]*iotextypes.NodeInfo, error) {
+func (dm *InfoManager) genNodeInfoMsg(addrs []string, heartbeat *HeartbeatStats) ([]*iotextypes.NodeInfo, error) {
 	infos := make([]*iotextypes.NodeInfo, 0, len(addrs))
 	tip := dm.chain.TipHeight()
 	ts := timestamppb.Now()
@@ -237,6 +254,11 @@ func (dm *InfoManager) genNodeInfoMsg(addrs []string) ([]*iotextypes.NodeInfo, e
 			Timestamp: ts,
 			Address:   addr,
 		}
+		if heartbeat != nil {
+			if err := setHeartbeat(core, heartbeat); err != nil {
+				return nil, errors.Wrap(err, "set heartbeat failed")
+			}
+		}
 		// add sig for msg
 		h := hashNodeInfo(core)
 		sig, err := privKey.Sign(h[:])
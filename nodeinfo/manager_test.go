@@ -37,7 +37,7 @@ func TestNewDelegateManager(t *testing.T) {
 	t.Run("disable_broadcast", func(t *testing.T) {
 		hMock := mock_nodeinfo.NewMockchain(ctrl)
 		tMock := mock_nodeinfo.NewMocktransmitter(ctrl)
-		cfg := Config{false, 100 * time.Millisecond, 100 * time.Millisecond, 1000}
+		cfg := Config{false, 100 * time.Millisecond, 100 * time.Millisecond, 1000, false, 0}
 		dm := NewInfoManager(&cfg, tMock, hMock, getEmptyWhiteList, privK)
 		require.NotNil(dm.nodeMap)
 		require.Equal(tMock, dm.transmitter)
@@ -54,7 +54,7 @@ func TestNewDelegateManager(t *testing.T) {
 	t.Run("enable_broadcast", func(t *testing.T) {
 		hMock := mock_nodeinfo.NewMockchain(ctrl)
 		tMock := mock_nodeinfo.NewMocktransmitter(ctrl)
-		cfg := Config{true, 100 * time.Millisecond, 100 * time.Millisecond, 1000}
+		cfg := Config{true, 100 * time.Millisecond, 100 * time.Millisecond, 1000, false, 0}
 		dm := NewInfoManager(&cfg, tMock, hMock, getEmptyWhiteList, privK)
 		require.NotNil(dm.nodeMap)
 		require.Equal(tMock, dm.transmitter)
@@ -71,7 +71,7 @@ func TestNewDelegateManager(t *testing.T) {
 	t.Run("delegate_broadcast", func(t *testing.T) {
 		hMock := mock_nodeinfo.NewMockchain(ctrl)
 		tMock := mock_nodeinfo.NewMocktransmitter(ctrl)
-		cfg := Config{true, 100 * time.Millisecond, 100 * time.Millisecond, 1000}
+		cfg := Config{true, 100 * time.Millisecond, 100 * time.Millisecond, 1000, false, 0}
 		dm := NewInfoManager(&cfg, tMock, hMock, getEmptyWhiteList, privK)
 		require.NotNil(dm.nodeMap)
 		require.Equal(tMock, dm.transmitter)
@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -28,6 +29,7 @@ import (
 	"github.com/iotexproject/go-pkgs/hash"
 	goproto "github.com/iotexproject/iotex-proto/golang"
 	"github.com/iotexproject/iotex-proto/golang/iotexrpc"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 
 	"github.com/iotexproject/iotex-core/v2/blockchain/block"
 	"github.com/iotexproject/iotex-core/v2/pkg/lifecycle"
@@ -58,6 +60,13 @@ var (
 		},
 		[]string{"protocol", "message", "status"},
 	)
+	_peerDiversityCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iotex_p2p_peer_diversity_counter",
+			Help: "Peers evicted, rotated and dialed for the peer diversity",
+		},
+		[]string{"action"},
+	)
 	// ErrAgentNotStarted is the error returned when p2p agent has not been started
 	ErrAgentNotStarted = errors.New("p2p agent has not been started")
 )
@@ -65,6 +74,7 @@ var (
 func init() {
 	prometheus.MustRegister(_p2pMsgCounter)
 	prometheus.MustRegister(_p2pMsgLatency)
+	prometheus.MustRegister(_peerDiversityCounter)
 }
 
 const (
@@ -87,6 +97,10 @@ type (
 	// HandleUnicastInboundAsync handles unicast message when agent listens it from the network
 	HandleUnicastInboundAsync func(context.Context, uint32, peer.AddrInfo, proto.Message)
 
+	// HandleProtocolInbound handles the message of a protocol added to the agent, the message is dropped if an error
+	// is returned
+	HandleProtocolInbound func(context.Context, peer.AddrInfo, []byte) error
+
 	// Config is the config of p2p
 	Config struct {
 		Host           string   `yaml:"host"`
@@ -106,6 +120,30 @@ type (
 		MaxMessageSize    int                 `yaml:"maxMessageSize"`
 		RpcMsgCacheSize   int                 `yaml:"rpcMsgCacheSize"`
 		RpcDedupCacheSize int                 `yaml:"rpcDedupCacheSize"`
+		// PeerBanThreshold is the penalty score at which a peer is disconnected and banned, peer scoring is disabled
+		// if it is 0
+		PeerBanThreshold float64 `yaml:"peerBanThreshold"`
+		// PeerBanDuration is the duration a peer is banned for
+		PeerBanDuration time.Duration `yaml:"peerBanDuration"`
+		// PeerScoreHalfLife is the time for the score of a peer to recover by half
+		PeerScoreHalfLife time.Duration `yaml:"peerScoreHalfLife"`
+		// StaticPeers are the multiaddresses of the peers always connected, which are reconnected once disconnected
+		StaticPeers []string `yaml:"staticPeers"`
+		// TrustedPeers are the peer ids or the multiaddresses of the peers exempt from the peer scoring and bans
+		TrustedPeers []string `yaml:"trustedPeers"`
+		// PeerStorePath is the path of the file persisting the known-good peers across restarts, the peers are not
+		// persisted if it is empty
+		PeerStorePath string `yaml:"peerStorePath"`
+		// Bandwidth caps the upload and download bandwidth of the node and of each peer
+		Bandwidth BandwidthConfig `yaml:"bandwidth"`
+		// Compression is the compression of the large messages
+		Compression CompressionConfig `yaml:"compression"`
+		// NAT is the config of the NAT traversal
+		NAT NATConfig `yaml:"nat"`
+		// BlockPropagation is the config of the block propagation by the announcements
+		BlockPropagation BlockPropagationConfig `yaml:"blockPropagation"`
+		// Diversity is the config of the peer diversity against the eclipse attacks
+		Diversity DiversityConfig `yaml:"diversity"`
 	}
 
 	// Agent is the agent to help the blockchain node connect into the P2P networks and send/receive messages
@@ -124,6 +162,23 @@ type (
 		ConnectedPeers() ([]peer.AddrInfo, error)
 		// BlockPeer blocks the peer in p2p layer
 		BlockPeer(string)
+		// ConnectPeer connects the peer of the multiaddress, which ends with /p2p/<peer id>
+		ConnectPeer(ctx context.Context, addr string) error
+		// ReportPeer lowers the score of the peer by the misbehavior, the peer is disconnected and banned for a while
+		// once its score is too low
+		ReportPeer(id string, event PeerEvent)
+		// PeerScores returns the scores of the peers misbehaved
+		PeerScores() []PeerScore
+		// AddTrustedPeer adds the peer of the id or the multiaddress as a trusted peer, which is persisted and
+		// connected if the address is given
+		AddTrustedPeer(ctx context.Context, addr string) error
+		// SetMaxPeersPerGroup sets the cap of the peers connected in the same subnet or ASN at runtime
+		SetMaxPeersPerGroup(n int)
+		// AddProtocol adds the unicast protocol of the name, whose messages are carried in a topic of its own instead
+		// of the typed rpc messages. It must be called before the agent starts
+		AddProtocol(name string, handler HandleProtocolInbound) error
+		// UnicastProtocol sends the message of the protocol to the peer
+		UnicastProtocol(ctx context.Context, peer peer.AddrInfo, name string, data []byte) error
 	}
 
 	dummyAgent struct{}
@@ -141,7 +196,21 @@ type (
 		bootNodeAddr               []multiaddr.Multiaddr
 		reconnectTimeout           time.Duration
 		reconnectTask              *routine.RecurringTask
+		rotateTask                 *routine.RecurringTask
 		qosMetrics                 *Qos
+		reputation                 *reputation
+		peerStore                  *peerStore
+		peerMu                     sync.RWMutex
+		trusted                    map[peer.ID]struct{}
+		static                     map[peer.ID]struct{}
+		addedTrusted               []string
+		protocols                  map[string]HandleProtocolInbound
+		bandwidth                  *bandwidth
+		codec                      *messageCodec
+		portMapper                 portMapper
+		blockPropagator            *blockPropagator
+		diversity                  *diversity
+		persistedAnchors           []peer.ID
 		unifiedTopic               atomic.Bool
 		isUnifiedTopic             func(height uint64) bool
 	}
@@ -178,6 +247,18 @@ var DefaultConfig = Config{
 	MaxMessageSize:    p2p.DefaultConfig.MaxMessageSize,
 	RpcMsgCacheSize:   10000,
 	RpcDedupCacheSize: 40000,
+	PeerBanThreshold:  100,
+	PeerBanDuration:   time.Hour,
+	PeerScoreHalfLife: 10 * time.Minute,
+	StaticPeers:       []string{},
+	TrustedPeers:      []string{},
+	Compression: CompressionConfig{
+		Algorithm: CompressionNone,
+		Threshold: 16 << 10,
+	},
+	NAT:              DefaultNATConfig,
+	BlockPropagation: DefaultBlockPropagationConfig,
+	Diversity:        DefaultDiversityConfig,
 }
 
 // NewDummyAgent creates a dummy p2p agent
@@ -217,6 +298,30 @@ func (*dummyAgent) BlockPeer(string) {
 	return
 }
 
+func (*dummyAgent) ConnectPeer(context.Context, string) error {
+	return nil
+}
+
+func (*dummyAgent) ReportPeer(string, PeerEvent) {}
+
+func (*dummyAgent) PeerScores() []PeerScore {
+	return nil
+}
+
+func (*dummyAgent) AddTrustedPeer(context.Context, string) error {
+	return nil
+}
+
+func (*dummyAgent) SetMaxPeersPerGroup(int) {}
+
+func (*dummyAgent) AddProtocol(string, HandleProtocolInbound) error {
+	return nil
+}
+
+func (*dummyAgent) UnicastProtocol(context.Context, peer.AddrInfo, string, []byte) error {
+	return nil
+}
+
 func (*dummyAgent) BuildReport() string {
 	return ""
 }
@@ -231,7 +336,7 @@ func NewAgent(
 	unicastHandler HandleUnicastInboundAsync,
 	opts ...Option,
 ) Agent {
-	log.L().Info("p2p agent", log.Hex("topicSuffix", genesisHash[22:]))
+	log.Logger("p2p").Info("p2p agent", log.Hex("topicSuffix", genesisHash[22:]))
 	a := &agent{
 		cfg:     cfg,
 		chainID: chainID,
@@ -245,6 +350,56 @@ func NewAgent(
 		unicastInboundAsyncHandler: unicastHandler,
 		reconnectTimeout:           cfg.ReconnectInterval,
 		qosMetrics:                 NewQoS(time.Now(), 2*cfg.ReconnectInterval),
+		protocols:                  map[string]HandleProtocolInbound{},
+		bandwidth:                  newBandwidth(cfg.Bandwidth),
+	}
+	codec, err := newMessageCodec(cfg.Compression, cfg.MaxMessageSize)
+	if err != nil {
+		log.Logger("p2p").Error("invalid compression config, messages are sent uncompressed", zap.Error(err))
+		codec, _ = newMessageCodec(CompressionConfig{}, cfg.MaxMessageSize)
+	}
+	a.codec = codec
+	diversity, err := newDiversity(cfg.Diversity)
+	if err != nil {
+		log.Logger("p2p").Error("invalid ASN file, peers are grouped by subnets", zap.Error(err))
+		cfg.Diversity.ASNFile = ""
+		diversity, _ = newDiversity(cfg.Diversity)
+	}
+	a.diversity = diversity
+	if cfg.BlockPropagation.FanoutThreshold > 0 {
+		a.blockPropagator = newBlockPropagator(
+			cfg.BlockPropagation,
+			a.ConnectedPeers,
+			func(ctx context.Context, peer peer.AddrInfo, data []byte) error {
+				return a.UnicastProtocol(ctx, peer, _blockProtocol, data)
+			},
+			validateBroadcastInbound,
+			func(ctx context.Context, peer peer.AddrInfo, blk *iotextypes.Block) {
+				a.unicastInboundAsyncHandler(ctx, a.chainID, peer, blk)
+			},
+		)
+		a.protocols[_blockProtocol] = a.blockPropagator.HandleMessage
+	}
+	if cfg.PeerBanThreshold > 0 {
+		a.reputation = newReputation(cfg.PeerBanThreshold, cfg.PeerBanDuration, cfg.PeerScoreHalfLife)
+	}
+	if cfg.PeerStorePath != "" {
+		a.peerStore = newPeerStore(cfg.PeerStorePath)
+	}
+	a.trusted = make(map[peer.ID]struct{}, len(cfg.TrustedPeers))
+	for _, s := range cfg.TrustedPeers {
+		info, err := parsePeer(s)
+		if err != nil {
+			log.Logger("p2p").Error("invalid trusted peer", zap.Error(err))
+			continue
+		}
+		a.trusted[info.ID] = struct{}{}
+	}
+	a.static = make(map[peer.ID]struct{}, len(cfg.StaticPeers))
+	for _, s := range cfg.StaticPeers {
+		if info, err := parsePeer(s); err == nil {
+			a.static[info.ID] = struct{}{}
+		}
 	}
 	a.unifiedTopic.Store(true)
 	for _, opt := range opts {
@@ -270,7 +425,7 @@ func (p *agent) duplicateActions(msg *iotexrpc.BroadcastMsg) bool {
 
 func (p *agent) Start(ctx context.Context) error {
 	ready := make(chan interface{})
-	p2p.SetLogger(log.L())
+	p2p.SetLogger(log.Logger("p2p"))
 	opts := []p2p.Option{
 		p2p.HostName(p.cfg.Host),
 		p2p.Port(p.cfg.Port),
@@ -286,13 +441,7 @@ func (p *agent) Start(ctx context.Context) error {
 	if p.cfg.EnableRateLimit {
 		opts = append(opts, p2p.WithRateLimit(p.cfg.RateLimit))
 	}
-	if p.cfg.ExternalHost != "" {
-		opts = append(opts, p2p.ExternalHostName(p.cfg.ExternalHost))
-		opts = append(opts, p2p.ExternalPort(p.cfg.ExternalPort))
-	}
-	if p.cfg.RelayType != "" {
-		opts = append(opts, p2p.WithRelay(p.cfg.RelayType))
-	}
+	opts = append(opts, p.natOptions(ctx)...)
 	host, err := p2p.NewHost(ctx, opts...)
 	if err != nil {
 		return errors.Wrap(err, "error when instantiating Agent host")
@@ -302,32 +451,44 @@ func (p *agent) Start(ctx context.Context) error {
 		if pid.String() == host.HostIdentity() {
 			return pubsub.ValidationAccept
 		}
+		if p.banned(pid.String()) {
+			return pubsub.ValidationIgnore
+		}
+		data, err := p.codec.Decode(msg.Data)
+		if err != nil {
+			log.Logger("p2p").Debug("error when decompressing broadcast message", zap.Error(err))
+			p.ReportPeer(pid.String(), PeerProtocolViolation)
+			return pubsub.ValidationReject
+		}
 		var broadcast iotexrpc.BroadcastMsg
-		if err := proto.Unmarshal(msg.Data, &broadcast); err != nil {
-			log.L().Debug("error when unmarshaling broadcast message", zap.Error(err))
+		if err := proto.Unmarshal(data, &broadcast); err != nil {
+			log.Logger("p2p").Debug("error when unmarshaling broadcast message", zap.Error(err))
+			p.ReportPeer(pid.String(), PeerProtocolViolation)
 			return pubsub.ValidationReject
 		}
 		if broadcast.ChainId != p.chainID {
-			log.L().Debug("chain ID mismatch", zap.Uint32("received", broadcast.ChainId), zap.Uint32("expecting", p.chainID))
+			log.Logger("p2p").Debug("chain ID mismatch", zap.Uint32("received", broadcast.ChainId), zap.Uint32("expecting", p.chainID))
+			p.ReportPeer(pid.String(), PeerProtocolViolation)
 			return pubsub.ValidationReject
 		}
 		pMsg, err := goproto.TypifyRPCMsg(broadcast.MsgType, broadcast.MsgBody)
 		if err != nil {
-			log.L().Debug("error when typifying broadcast message", zap.Error(err))
+			log.Logger("p2p").Debug("error when typifying broadcast message", zap.Error(err))
+			p.ReportPeer(pid.String(), PeerProtocolViolation)
 			return pubsub.ValidationReject
 		}
 		// dedup message
 		if p.duplicateActions(&broadcast) {
-			log.L().Debug("duplicate msg", zap.Int("type", int(broadcast.MsgType)))
+			log.Logger("p2p").Debug("duplicate msg", zap.Int("type", int(broadcast.MsgType)))
 			return pubsub.ValidationIgnore
 		}
 		ignore, err := p.validatorBroadcastInbound(pMsg)
 		if err != nil {
-			log.L().Debug("error when validating broadcast message", zap.Error(err))
+			log.Logger("p2p").Debug("error when validating broadcast message", zap.Error(err))
 			return pubsub.ValidationReject
 		}
 		if ignore {
-			log.L().Debug("invalid broadcast message")
+			log.Logger("p2p").Debug("invalid broadcast message")
 			return pubsub.ValidationIgnore
 		}
 		p.caches.Add(hash.Hash256b(msg.Data), &cacheValue{
@@ -344,6 +505,9 @@ func (p *agent) Start(ctx context.Context) error {
 		if pid.String() == host.HostIdentity() {
 			return nil
 		}
+		if err = p.bandwidth.WaitDownload(ctx, pid, len(data)); err != nil {
+			return
+		}
 		var (
 			peerID  string
 			pMsg    proto.Message
@@ -367,8 +531,15 @@ func (p *agent) Start(ctx context.Context) error {
 			msgType = value.msgType
 			latency = time.Since(value.timestamp).Nanoseconds() / time.Millisecond.Nanoseconds()
 		} else {
-			var broadcast iotexrpc.BroadcastMsg
-			if err = proto.Unmarshal(data, &broadcast); err != nil {
+			var (
+				broadcast iotexrpc.BroadcastMsg
+				raw       []byte
+			)
+			if raw, err = p.codec.Decode(data); err != nil {
+				err = errors.Wrap(err, "error when decompressing broadcast message")
+				return
+			}
+			if err = proto.Unmarshal(raw, &broadcast); err != nil {
 				// TODO: unexpected error
 				err = errors.Wrap(err, "error when marshaling broadcast message")
 				return
@@ -426,17 +597,32 @@ func (p *agent) Start(ctx context.Context) error {
 			_p2pMsgCounter.WithLabelValues("unicast", strconv.Itoa(int(unicast.MsgType)), "in", peerID, status).Inc()
 			_p2pMsgLatency.WithLabelValues("unicast", strconv.Itoa(int(unicast.MsgType)), status).Observe(float64(latency))
 		}()
+		if p.banned(peerID) {
+			err = errors.Errorf("peer %s is banned", peerID)
+			return
+		}
+		if err = p.bandwidth.WaitDownload(ctx, peerInfo.ID, len(data)); err != nil {
+			return
+		}
+		if data, err = p.codec.Decode(data); err != nil {
+			err = errors.Wrap(err, "error when decompressing unicast message")
+			p.ReportPeer(peerID, PeerProtocolViolation)
+			return
+		}
 		if err = proto.Unmarshal(data, &unicast); err != nil {
 			err = errors.Wrap(err, "error when marshaling unicast message")
+			p.ReportPeer(peerID, PeerProtocolViolation)
 			return
 		}
 		msg, err := goproto.TypifyRPCMsg(unicast.MsgType, unicast.MsgBody)
 		if err != nil {
 			err = errors.Wrap(err, "error when typifying unicast message")
+			p.ReportPeer(peerID, PeerProtocolViolation)
 			return
 		}
 		if unicast.ChainId != p.chainID {
 			err = errors.Errorf("chain ID mismatch, received %d, expecting %d", unicast.ChainId, p.chainID)
+			p.ReportPeer(peerID, PeerProtocolViolation)
 			return
 		}
 
@@ -449,6 +635,11 @@ func (p *agent) Start(ctx context.Context) error {
 	}); err != nil {
 		return errors.Wrap(err, "error when adding unicast pubsub")
 	}
+	for name, handler := range p.protocols {
+		if err := host.AddUnicastPubSub(p.protocolTopic(name), p.protocolHandler(name, handler, ready)); err != nil {
+			return errors.Wrapf(err, "error when adding protocol %s", name)
+		}
+	}
 
 	// create boot nodes list except itself
 	hostName := host.HostIdentity()
@@ -466,7 +657,10 @@ func (p *agent) Start(ctx context.Context) error {
 
 	// connect to bootstrap nodes
 	if err := p.connectBootNode(ctx); err != nil {
-		log.L().Error("fail to connect bootnode", zap.Error(err))
+		log.Logger("p2p").Error("fail to connect bootnode", zap.Error(err))
+		return err
+	}
+	if err := p.connectKnownPeers(ctx); err != nil {
 		return err
 	}
 	if err := p.host.AdvertiseAsync(); err != nil {
@@ -480,17 +674,35 @@ func (p *agent) Start(ctx context.Context) error {
 
 	// check network connectivity every 60 blocks, and reconnect in case of disconnection
 	p.reconnectTask = routine.NewRecurringTask(p.reconnect, p.reconnectTimeout)
-	return p.reconnectTask.Start(ctx)
+	if err := p.reconnectTask.Start(ctx); err != nil {
+		return err
+	}
+	if p.cfg.Diversity.RotationInterval > 0 {
+		p.rotateTask = routine.NewRecurringTask(p.rotatePeers, p.cfg.Diversity.RotationInterval)
+		return p.rotateTask.Start(ctx)
+	}
+	return nil
 }
 
 func (p *agent) Stop(ctx context.Context) error {
 	if p.host == nil {
 		return ErrAgentNotStarted
 	}
-	log.L().Info("p2p is shutting down.", zap.Error(ctx.Err()))
+	log.Logger("p2p").Info("p2p is shutting down.", zap.Error(ctx.Err()))
 	if err := p.reconnectTask.Stop(ctx); err != nil {
 		return err
 	}
+	if p.rotateTask != nil {
+		if err := p.rotateTask.Stop(ctx); err != nil {
+			return err
+		}
+	}
+	p.savePeers()
+	if p.portMapper != nil {
+		if err := p.portMapper.Close(); err != nil {
+			log.Logger("p2p").Warn("error when removing port mapping", zap.Error(err))
+		}
+	}
 	if err := p.host.Close(); err != nil {
 		return errors.Wrap(err, "error when closing Agent host")
 	}
@@ -505,6 +717,9 @@ func (p *agent) BroadcastOutbound(ctx context.Context, msg proto.Message) (err e
 	if host == nil {
 		return ErrAgentNotStarted
 	}
+	if blk, ok := msg.(*iotextypes.Block); ok && p.blockPropagator != nil {
+		return p.blockPropagator.Propagate(ctx, blk, "")
+	}
 	var msgType iotexrpc.MessageType
 	var msgBody []byte
 	defer func() {
@@ -536,6 +751,10 @@ func (p *agent) BroadcastOutbound(ctx context.Context, msg proto.Message) (err e
 		err = errors.Wrap(err, "error when marshaling broadcast message")
 		return
 	}
+	data = p.codec.Encode(data)
+	if err = p.bandwidth.WaitUpload(ctx, "", len(data)); err != nil {
+		return
+	}
 	t := time.Now()
 	topic := p.messageTopic(msgType)
 	if err = host.Broadcast(ctx, topic, data); err != nil {
@@ -601,6 +820,10 @@ func (p *agent) UnicastOutbound(ctx context.Context, peer peer.AddrInfo, msg pro
 		err = errors.Wrap(err, "error when marshaling unicast message")
 		return
 	}
+	data = p.codec.Encode(data)
+	if err = p.bandwidth.WaitUpload(ctx, peer.ID, len(data)); err != nil {
+		return
+	}
 
 	t := time.Now()
 	if err = host.Unicast(ctx, peer, _unicastTopic+p.topicSuffix, data); err != nil {
@@ -612,6 +835,77 @@ func (p *agent) UnicastOutbound(ctx context.Context, peer peer.AddrInfo, msg pro
 	return
 }
 
+func (p *agent) AddProtocol(name string, handler HandleProtocolInbound) error {
+	if p.host != nil {
+		return errors.Errorf("failed to add protocol %s after the agent starts", name)
+	}
+	if _, ok := p.protocols[name]; ok {
+		return errors.Errorf("protocol %s already exists", name)
+	}
+	p.protocols[name] = handler
+	return nil
+}
+
+func (p *agent) UnicastProtocol(ctx context.Context, peer peer.AddrInfo, name string, data []byte) (err error) {
+	host := p.host
+	if host == nil {
+		return ErrAgentNotStarted
+	}
+	if _, ok := p.protocols[name]; !ok {
+		return errors.Errorf("protocol %s doesn't exist", name)
+	}
+	defer func() {
+		status := _successStr
+		if err != nil {
+			status = _failureStr
+		}
+		_p2pMsgCounter.WithLabelValues("unicast", name, "out", peer.ID.String(), status).Inc()
+	}()
+	if err = p.bandwidth.WaitUpload(ctx, peer.ID, len(data)); err != nil {
+		return
+	}
+	t := time.Now()
+	if err = host.Unicast(ctx, peer, p.protocolTopic(name), data); err != nil {
+		err = errors.Wrapf(err, "error when sending message of protocol %s", name)
+		p.qosMetrics.updateSendUnicast(peer.ID.String(), t, false)
+		return
+	}
+	p.qosMetrics.updateSendUnicast(peer.ID.String(), t, true)
+	return
+}
+
+// protocolTopic returns the topic of the protocol, which is bound to the genesis like the other topics
+func (p *agent) protocolTopic(name string) string {
+	return name + p.topicSuffix
+}
+
+func (p *agent) protocolHandler(name string, handler HandleProtocolInbound, ready <-chan interface{}) p2p.HandleUnicast {
+	return func(ctx context.Context, peerInfo peer.AddrInfo, data []byte) (err error) {
+		<-ready
+		peerID := peerInfo.ID.String()
+		defer func() {
+			status := _successStr
+			if err != nil {
+				status = _failureStr
+			}
+			_p2pMsgCounter.WithLabelValues("unicast", name, "in", peerID, status).Inc()
+		}()
+		if p.banned(peerID) {
+			err = errors.Errorf("peer %s is banned", peerID)
+			return
+		}
+		if err = p.bandwidth.WaitDownload(ctx, peerInfo.ID, len(data)); err != nil {
+			return
+		}
+		if err = handler(ctx, peerInfo, data); err != nil {
+			p.ReportPeer(peerID, PeerProtocolViolation)
+			return
+		}
+		p.qosMetrics.updateRecvUnicast(peerID, time.Now())
+		return
+	}
+}
+
 func (p *agent) Info() (peer.AddrInfo, error) {
 	if p.host == nil {
 		return peer.AddrInfo{}, ErrAgentNotStarted
@@ -630,10 +924,23 @@ func (p *agent) ConnectedPeers() ([]peer.AddrInfo, error) {
 	if p.host == nil {
 		return nil, ErrAgentNotStarted
 	}
-	return p.host.ConnectedPeers(), nil
+	peers := p.host.ConnectedPeers()
+	if p.reputation == nil {
+		return peers, nil
+	}
+	connected := make([]peer.AddrInfo, 0, len(peers))
+	for _, pr := range peers {
+		if !p.banned(pr.ID.String()) {
+			connected = append(connected, pr)
+		}
+	}
+	return connected, nil
 }
 
 func (p *agent) BlockPeer(pidStr string) {
+	if p.host == nil {
+		return
+	}
 	pid, err := peer.Decode(pidStr)
 	if err != nil {
 		return
@@ -641,6 +948,184 @@ func (p *agent) BlockPeer(pidStr string) {
 	p.host.BlockPeer(pid)
 }
 
+func (p *agent) ReportPeer(id string, event PeerEvent) {
+	if p.isTrusted(id) {
+		return
+	}
+	if p.reputation == nil {
+		if event == PeerInvalidBlock {
+			// a peer serving an invalid block is blocked right away without the peer scoring
+			p.BlockPeer(id)
+		}
+		return
+	}
+	if !p.reputation.Report(id, event, time.Now()) {
+		return
+	}
+	log.Logger("p2p").Warn("peer is banned for misbehaviors.", zap.String("peer", id), zap.Duration("duration", p.cfg.PeerBanDuration))
+	p.BlockPeer(id)
+}
+
+func (p *agent) PeerScores() []PeerScore {
+	if p.reputation == nil {
+		return nil
+	}
+	return p.reputation.Scores(time.Now())
+}
+
+func (p *agent) SetMaxPeersPerGroup(n int) {
+	p.diversity.SetMaxPeersPerGroup(n)
+	log.Logger("p2p").Info("cap of peers per group is set.", zap.Int("maxPeersPerGroup", n))
+}
+
+func (p *agent) AddTrustedPeer(ctx context.Context, addr string) error {
+	info, err := parsePeer(addr)
+	if err != nil {
+		return err
+	}
+	p.peerMu.Lock()
+	if _, ok := p.trusted[info.ID]; !ok {
+		p.trusted[info.ID] = struct{}{}
+		p.addedTrusted = append(p.addedTrusted, addr)
+	}
+	p.peerMu.Unlock()
+	log.Logger("p2p").Info("trusted peer is added.", zap.String("peer", addr))
+	if p.host == nil {
+		return nil
+	}
+	p.savePeers()
+	if len(info.Addrs) == 0 {
+		return nil
+	}
+	return p.ConnectPeer(ctx, addr)
+}
+
+func (p *agent) isTrusted(id string) bool {
+	pid, err := peer.Decode(id)
+	if err != nil {
+		return false
+	}
+	p.peerMu.RLock()
+	defer p.peerMu.RUnlock()
+	_, ok := p.trusted[pid]
+	return ok
+}
+
+func (p *agent) banned(id string) bool {
+	return p.reputation != nil && !p.isTrusted(id) && p.reputation.Banned(id, time.Now())
+}
+
+// connectKnownPeers connects the static peers, the trusted peers and the peers persisted asynchronously
+func (p *agent) connectKnownPeers(ctx context.Context) error {
+	addrs := make([]string, 0, len(p.cfg.StaticPeers)+len(p.cfg.TrustedPeers))
+	for _, s := range p.cfg.StaticPeers {
+		if _, err := parsePeer(s); err != nil {
+			return errors.Wrap(err, "invalid static peer")
+		}
+		addrs = append(addrs, s)
+	}
+	addrs = append(addrs, p.cfg.TrustedPeers...)
+	if p.peerStore != nil {
+		data, err := p.peerStore.Load()
+		if err != nil {
+			return err
+		}
+		for _, s := range data.Trusted {
+			if info, err := parsePeer(s); err == nil {
+				p.peerMu.Lock()
+				if _, ok := p.trusted[info.ID]; !ok {
+					p.trusted[info.ID] = struct{}{}
+					p.addedTrusted = append(p.addedTrusted, s)
+				}
+				p.peerMu.Unlock()
+			}
+		}
+		for _, s := range data.Anchors {
+			if info, err := parsePeer(s); err == nil {
+				p.persistedAnchors = append(p.persistedAnchors, info.ID)
+			}
+		}
+		// the anchors are connected first
+		addrs = append(append([]string{}, data.Anchors...), addrs...)
+		addrs = append(addrs, data.Trusted...)
+		addrs = append(addrs, data.Peers...)
+	}
+	for _, s := range addrs {
+		ma, err := multiaddr.NewMultiaddr(s)
+		if err != nil {
+			// the trusted peer of the peer id only
+			continue
+		}
+		go func() {
+			if err := p.host.ConnectWithMultiaddr(ctx, ma); err != nil {
+				log.Logger("p2p").Debug("failed to connect known peer", zap.String("address", s), zap.Error(err))
+			}
+		}()
+	}
+	return nil
+}
+
+// connectStaticPeers reconnects the static peers disconnected
+func (p *agent) connectStaticPeers() {
+	if len(p.cfg.StaticPeers) == 0 {
+		return
+	}
+	connected := map[peer.ID]struct{}{}
+	for _, pr := range p.host.ConnectedPeers() {
+		connected[pr.ID] = struct{}{}
+	}
+	for _, s := range p.cfg.StaticPeers {
+		info, err := parsePeer(s)
+		if err != nil {
+			continue
+		}
+		if _, ok := connected[info.ID]; ok {
+			continue
+		}
+		if err := p.ConnectPeer(context.Background(), s); err != nil {
+			log.Logger("p2p").Warn("failed to connect static peer", zap.String("address", s), zap.Error(err))
+		}
+	}
+}
+
+// savePeers persists the peers connected and not banned, and the trusted peers added at runtime
+func (p *agent) savePeers() {
+	if p.peerStore == nil || p.host == nil {
+		return
+	}
+	data := &peerStoreData{}
+	peers := p.host.ConnectedPeers()
+	for _, pr := range p.diversity.Anchors(peers) {
+		data.Anchors = append(data.Anchors, p2pAddrs(pr)...)
+	}
+	for _, pr := range peers {
+		if len(data.Peers) >= p.cfg.MaxPeers {
+			break
+		}
+		if p.banned(pr.ID.String()) {
+			continue
+		}
+		data.Peers = append(data.Peers, p2pAddrs(pr)...)
+	}
+	p.peerMu.RLock()
+	data.Trusted = append(data.Trusted, p.addedTrusted...)
+	p.peerMu.RUnlock()
+	if err := p.peerStore.Save(data); err != nil {
+		log.Logger("p2p").Error("failed to persist peers", zap.Error(err))
+	}
+}
+
+func (p *agent) ConnectPeer(ctx context.Context, addr string) error {
+	if p.host == nil {
+		return ErrAgentNotStarted
+	}
+	ma, err := multiaddr.NewMultiaddr(addr)
+	if err != nil {
+		return errors.Wrapf(err, "invalid multiaddress %s", addr)
+	}
+	return p.host.ConnectWithMultiaddr(ctx, ma)
+}
+
 // BuildReport builds a report of p2p agent
 func (p *agent) BuildReport() string {
 	neighbors, err := p.ConnectedPeers()
@@ -651,6 +1136,9 @@ func (p *agent) BuildReport() string {
 }
 
 func (p *agent) ReceiveBlock(blk *block.Block) error {
+	if p.blockPropagator != nil {
+		p.blockPropagator.Seen(blk.HashBlock())
+	}
 	if p.isUnifiedTopic == nil {
 		return nil
 	}
@@ -680,7 +1168,7 @@ func (p *agent) connectBootNode(ctx context.Context) error {
 				return
 			}
 			conn <- struct{}{}
-			log.L().Info("Connected bootstrap node.", zap.String("address", bootAddr.String()))
+			log.Logger("p2p").Info("Connected bootstrap node.", zap.String("address", bootAddr.String()))
 		}()
 	}
 
@@ -689,7 +1177,7 @@ func (p *agent) connectBootNode(ctx context.Context) error {
 	for {
 		select {
 		case err := <-connErrChan:
-			log.L().Info("Connection failed.", zap.Error(err))
+			log.Logger("p2p").Info("Connection failed.", zap.Error(err))
 			errNum++
 			if errNum == len(p.bootNodeAddr) {
 				return errors.New("failed to connect to any bootstrap node")
@@ -710,20 +1198,81 @@ func (p *agent) reconnect() {
 		return
 	}
 	if len(p.host.ConnectedPeers()) == 0 || p.qosMetrics.lostConnection() {
-		log.L().Info("network lost, try re-connecting.")
+		log.Logger("p2p").Info("network lost, try re-connecting.")
 		p.host.ClearBlocklist()
 		if err := p.connectBootNode(context.Background()); err != nil {
-			log.L().Error("fail to connect bootnode", zap.Error(err))
+			log.Logger("p2p").Error("fail to connect bootnode", zap.Error(err))
 			return
 		}
 		if err := p.host.AdvertiseAsync(); err != nil {
-			log.L().Error("fail to advertise", zap.Error(err))
+			log.Logger("p2p").Error("fail to advertise", zap.Error(err))
 			return
 		}
 	}
 	if err := p.host.FindPeersAsync(); err != nil {
-		log.L().Error("fail to find peer", zap.Error(err))
+		log.Logger("p2p").Error("fail to find peer", zap.Error(err))
+	}
+	p.connectStaticPeers()
+	p.diversify()
+	p.savePeers()
+}
+
+// diversify evicts the peers of the groups over the cap, and dials the peers known of the groups least connected
+func (p *agent) diversify() {
+	peers := p.host.ConnectedPeers()
+	p.diversity.Update(peers, p.persistedAnchors, time.Now())
+	evicted := p.diversity.Evictions(peers, p.protected)
+	p.evictPeers(evicted, "evict")
+	p.dialPeers(len(peers) - len(evicted))
+}
+
+// rotatePeers replaces a fraction of the peers, so that an attacker can't keep the connections occupied forever
+func (p *agent) rotatePeers() {
+	if p.host == nil {
+		return
+	}
+	peers := p.host.ConnectedPeers()
+	p.diversity.Update(peers, p.persistedAnchors, time.Now())
+	rotated := p.diversity.Rotations(peers, p.protected)
+	p.evictPeers(rotated, "rotate")
+	p.dialPeers(len(peers) - len(rotated))
+}
+
+// evictPeers disconnects the peers, which are not redialed for the blacklist timeout of the host
+func (p *agent) evictPeers(ids []peer.ID, action string) {
+	for _, id := range ids {
+		log.Logger("p2p").Debug("disconnect peer for diversity", zap.String("peer", id.String()), zap.String("action", action))
+		p.host.BlockPeer(id)
+		_peerDiversityCounter.WithLabelValues(action).Inc()
+	}
+}
+
+// dialPeers dials the peers known of the groups least connected, up to the max peers
+func (p *agent) dialPeers(connected int) {
+	ctx := context.Background()
+	known := p.host.Neighbors(ctx)
+	candidates := make([]peer.AddrInfo, 0, len(known))
+	for _, pr := range known {
+		if !p.banned(pr.ID.String()) {
+			candidates = append(candidates, pr)
+		}
+	}
+	for _, pr := range p.diversity.Candidates(candidates, p.host.ConnectedPeers(), p.cfg.MaxPeers-connected) {
+		_peerDiversityCounter.WithLabelValues("dial").Inc()
+		go func(pr peer.AddrInfo) {
+			if err := p.host.Connect(ctx, pr); err != nil {
+				log.Logger("p2p").Debug("failed to dial peer", zap.String("peer", pr.ID.String()), zap.Error(err))
+			}
+		}(pr)
+	}
+}
+
+// protected returns whether the peer is never evicted or rotated
+func (p *agent) protected(id peer.ID) bool {
+	if _, ok := p.static[id]; ok {
+		return true
 	}
+	return p.isTrusted(id.String())
 }
 
 func convertAppMsg(msg proto.Message) (iotexrpc.MessageType, []byte, error) {
@@ -743,7 +1292,7 @@ func exponentialRetry(f func() error, retryInterval time.Duration, numRetries in
 		if err = f(); err == nil {
 			return
 		}
-		log.L().Error("Error happens, will retry.", zap.Error(err))
+		log.Logger("p2p").Error("Error happens, will retry.", zap.Error(err))
 		time.Sleep(retryInterval)
 		retryInterval *= 2
 	}
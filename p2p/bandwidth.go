@@ -0,0 +1,115 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"context"
+	"sync"
+
+	"github.com/iotexproject/go-pkgs/cache"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"golang.org/x/time/rate"
+)
+
+// _bandwidthPeerCacheSize is the number of peers whose bandwidth is tracked
+const _bandwidthPeerCacheSize = 1000
+
+type (
+	// BandwidthConfig is the config of the bandwidth caps in bytes per second, the bandwidth is not capped if it is 0
+	BandwidthConfig struct {
+		MaxUpload       int `yaml:"maxUpload"`
+		MaxDownload     int `yaml:"maxDownload"`
+		MaxPeerUpload   int `yaml:"maxPeerUpload"`
+		MaxPeerDownload int `yaml:"maxPeerDownload"`
+	}
+
+	// bandwidth throttles the messages sent and received by the caps of the node and of each peer
+	bandwidth struct {
+		upload       *rate.Limiter
+		download     *rate.Limiter
+		peerUpload   *peerLimiters
+		peerDownload *peerLimiters
+	}
+
+	peerLimiters struct {
+		mu       sync.Mutex
+		limit    int
+		limiters cache.LRUCache
+	}
+)
+
+func newBandwidth(cfg BandwidthConfig) *bandwidth {
+	return &bandwidth{
+		upload:       newLimiter(cfg.MaxUpload),
+		download:     newLimiter(cfg.MaxDownload),
+		peerUpload:   newPeerLimiters(cfg.MaxPeerUpload),
+		peerDownload: newPeerLimiters(cfg.MaxPeerDownload),
+	}
+}
+
+func newLimiter(limit int) *rate.Limiter {
+	if limit <= 0 {
+		return nil
+	}
+	return rate.NewLimiter(rate.Limit(limit), limit)
+}
+
+func newPeerLimiters(limit int) *peerLimiters {
+	if limit <= 0 {
+		return nil
+	}
+	return &peerLimiters{
+		limit:    limit,
+		limiters: cache.NewThreadSafeLruCache(_bandwidthPeerCacheSize),
+	}
+}
+
+// WaitUpload blocks until the message of the size can be sent to the peer, an empty id is for the broadcast
+func (b *bandwidth) WaitUpload(ctx context.Context, id peer.ID, size int) error {
+	if err := b.peerUpload.wait(ctx, id, size); err != nil {
+		return err
+	}
+	return wait(ctx, b.upload, size)
+}
+
+// WaitDownload blocks until the message of the size received from the peer can be handled
+func (b *bandwidth) WaitDownload(ctx context.Context, id peer.ID, size int) error {
+	if err := b.peerDownload.wait(ctx, id, size); err != nil {
+		return err
+	}
+	return wait(ctx, b.download, size)
+}
+
+func (l *peerLimiters) wait(ctx context.Context, id peer.ID, size int) error {
+	if l == nil || id == "" {
+		return nil
+	}
+	l.mu.Lock()
+	v, ok := l.limiters.Get(id)
+	if !ok {
+		v = newLimiter(l.limit)
+		l.limiters.Add(id, v)
+	}
+	l.mu.Unlock()
+	return wait(ctx, v.(*rate.Limiter), size)
+}
+
+// wait consumes the tokens of the size, the message larger than the burst consumes the tokens in several rounds
+func wait(ctx context.Context, limiter *rate.Limiter, size int) error {
+	if limiter == nil {
+		return nil
+	}
+	for burst := limiter.Burst(); size > 0; size -= burst {
+		n := size
+		if n > burst {
+			n = burst
+		}
+		if err := limiter.WaitN(ctx, n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBandwidth(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	id, err := peer.Decode(_testPeerID)
+	require.NoError(err)
+
+	// the bandwidth is not capped by default
+	b := newBandwidth(BandwidthConfig{})
+	start := time.Now()
+	for i := 0; i < 100; i++ {
+		require.NoError(b.WaitUpload(ctx, id, 1<<20))
+		require.NoError(b.WaitDownload(ctx, id, 1<<20))
+	}
+	require.Less(time.Since(start), time.Second)
+
+	// the message larger than the burst waits for the tokens of several rounds
+	b = newBandwidth(BandwidthConfig{MaxPeerUpload: 1000, MaxDownload: 1000})
+	start = time.Now()
+	require.NoError(b.WaitUpload(ctx, id, 1200))
+	require.GreaterOrEqual(time.Since(start), 150*time.Millisecond)
+	// the broadcast and the other peers are not capped by the cap of the peer
+	require.NoError(b.WaitUpload(ctx, "", 1000))
+	require.NoError(b.WaitUpload(ctx, "other", 1000))
+
+	// the wait is canceled with the context
+	require.NoError(b.WaitDownload(ctx, id, 1000))
+	cctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	require.Error(b.WaitDownload(cctx, "other", 1000))
+}
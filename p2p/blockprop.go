@@ -0,0 +1,253 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/cache"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+// _blockProtocol is the protocol propagating the blocks by the announcements
+const _blockProtocol = "block"
+
+// the kinds of the messages of the block protocol, each message starts with its kind
+const (
+	_blockKind    byte = 1 // the full block
+	_announceKind byte = 2 // the header of the block announced
+	_requestKind  byte = 3 // the hash of the block announced and requested
+)
+
+var _blockPropagationCounter = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "iotex_p2p_block_propagation_counter",
+		Help: "Block propagation stats",
+	},
+	[]string{"message", "status"},
+)
+
+func init() {
+	prometheus.MustRegister(_blockPropagationCounter)
+}
+
+type (
+	// BlockPropagationConfig is the config of the block propagation by the announcements
+	BlockPropagationConfig struct {
+		// FanoutThreshold is the number of the peers above which the full block is only sent to a random subset of
+		// the square root of the peers, and announced to the other peers which fetch it on demand. The blocks are
+		// gossiped if it is 0, and all the peers must support the announcements before it is enabled
+		FanoutThreshold int `yaml:"fanoutThreshold"`
+		// SeenCacheSize is the number of the latest blocks remembered to suppress the duplicates
+		SeenCacheSize int `yaml:"seenCacheSize"`
+		// FetchTimeout is the time to wait for a block announced before it is fetched from another peer announcing it
+		FetchTimeout time.Duration `yaml:"fetchTimeout"`
+	}
+
+	// blockPropagator sends the full blocks to a few peers and announces them to the others, which fetch the blocks
+	// unseen. Each block is relayed once the first time it is received
+	blockPropagator struct {
+		cfg      BlockPropagationConfig
+		peers    func() ([]peer.AddrInfo, error)
+		send     func(context.Context, peer.AddrInfo, []byte) error
+		validate ValidateBroadcastInbound
+		deliver  func(context.Context, peer.AddrInfo, *iotextypes.Block)
+		// seen caches the blocks by hash, the value is the message of the block to serve the requests, or nil if
+		// the block is committed without being propagated
+		seen     cache.LRUCache
+		mu       sync.Mutex
+		fetching map[hash.Hash256]time.Time
+	}
+)
+
+// DefaultBlockPropagationConfig is the default config of the block propagation
+var DefaultBlockPropagationConfig = BlockPropagationConfig{
+	FanoutThreshold: 0,
+	SeenCacheSize:   256,
+	FetchTimeout:    2 * time.Second,
+}
+
+func newBlockPropagator(
+	cfg BlockPropagationConfig,
+	peers func() ([]peer.AddrInfo, error),
+	send func(context.Context, peer.AddrInfo, []byte) error,
+	validate ValidateBroadcastInbound,
+	deliver func(context.Context, peer.AddrInfo, *iotextypes.Block),
+) *blockPropagator {
+	return &blockPropagator{
+		cfg:      cfg,
+		peers:    peers,
+		send:     send,
+		validate: validate,
+		deliver:  deliver,
+		seen:     cache.NewThreadSafeLruCache(cfg.SeenCacheSize),
+		fetching: map[hash.Hash256]time.Time{},
+	}
+}
+
+// Seen marks the block as seen, so that it is not fetched once announced
+func (bp *blockPropagator) Seen(h hash.Hash256) {
+	if _, ok := bp.seen.Get(h); !ok {
+		bp.seen.Add(h, nil)
+	}
+}
+
+// Propagate sends the block to the peers except the one it is received from
+func (bp *blockPropagator) Propagate(ctx context.Context, blk *iotextypes.Block, from peer.ID) error {
+	h, err := headerHash(blk.GetHeader())
+	if err != nil {
+		return err
+	}
+	data, err := encodeBlockMessage(_blockKind, blk)
+	if err != nil {
+		return err
+	}
+	announce, err := encodeBlockMessage(_announceKind, blk.GetHeader())
+	if err != nil {
+		return err
+	}
+	bp.seen.Add(h, data)
+	peers, err := bp.peers()
+	if err != nil {
+		return err
+	}
+	targets := make([]peer.AddrInfo, 0, len(peers))
+	for _, p := range peers {
+		if p.ID != from {
+			targets = append(targets, p)
+		}
+	}
+	rand.Shuffle(len(targets), func(i, j int) { targets[i], targets[j] = targets[j], targets[i] })
+	full := len(targets)
+	if full > bp.cfg.FanoutThreshold {
+		full = int(math.Sqrt(float64(full)))
+	}
+	for i, p := range targets {
+		msg, kind := data, "block"
+		if i >= full {
+			msg, kind = announce, "announce"
+		}
+		go func(p peer.AddrInfo) {
+			status := _successStr
+			if err := bp.send(ctx, p, msg); err != nil {
+				log.Logger("p2p").Debug("failed to propagate block", zap.String("peer", p.ID.String()), zap.Error(err))
+				status = _failureStr
+			}
+			_blockPropagationCounter.WithLabelValues(kind, status).Inc()
+		}(p)
+	}
+	return nil
+}
+
+// HandleMessage handles the message of the block protocol
+func (bp *blockPropagator) HandleMessage(ctx context.Context, from peer.AddrInfo, data []byte) error {
+	if len(data) == 0 {
+		return errors.New("empty block message")
+	}
+	switch data[0] {
+	case _blockKind:
+		blk := &iotextypes.Block{}
+		if err := proto.Unmarshal(data[1:], blk); err != nil {
+			return errors.Wrap(err, "failed to unmarshal block")
+		}
+		h, err := headerHash(blk.GetHeader())
+		if err != nil {
+			return err
+		}
+		if _, ok := bp.seen.Get(h); ok {
+			_blockPropagationCounter.WithLabelValues("block", "duplicate").Inc()
+			return nil
+		}
+		if bp.validate != nil {
+			ignore, err := bp.validate(blk)
+			if err != nil {
+				return err
+			}
+			if ignore {
+				return nil
+			}
+		}
+		bp.mu.Lock()
+		delete(bp.fetching, h)
+		bp.mu.Unlock()
+		bp.deliver(ctx, from, blk)
+		return bp.Propagate(context.Background(), blk, from.ID)
+	case _announceKind:
+		header := &iotextypes.BlockHeader{}
+		if err := proto.Unmarshal(data[1:], header); err != nil {
+			return errors.Wrap(err, "failed to unmarshal block header")
+		}
+		h, err := headerHash(header)
+		if err != nil {
+			return err
+		}
+		if _, ok := bp.seen.Get(h); ok || !bp.startFetching(h) {
+			_blockPropagationCounter.WithLabelValues("announce", "duplicate").Inc()
+			return nil
+		}
+		return bp.send(ctx, from, append([]byte{_requestKind}, h[:]...))
+	case _requestKind:
+		if len(data) != 1+len(hash.ZeroHash256) {
+			return errors.New("invalid block request")
+		}
+		v, ok := bp.seen.Get(hash.BytesToHash256(data[1:]))
+		if !ok || v == nil {
+			return nil
+		}
+		return bp.send(ctx, from, v.([]byte))
+	default:
+		return errors.Errorf("unknown kind %d of block message", data[0])
+	}
+}
+
+// startFetching returns whether the block announced should be fetched, which is not being fetched from another peer
+func (bp *blockPropagator) startFetching(h hash.Hash256) bool {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+	now := time.Now()
+	for k, t := range bp.fetching {
+		if now.Sub(t) > bp.cfg.FetchTimeout {
+			delete(bp.fetching, k)
+		}
+	}
+	if _, ok := bp.fetching[h]; ok {
+		return false
+	}
+	bp.fetching[h] = now
+	return true
+}
+
+func headerHash(pb *iotextypes.BlockHeader) (hash.Hash256, error) {
+	if pb.GetCore() == nil {
+		return hash.ZeroHash256, errors.New("block header is missing")
+	}
+	var header block.Header
+	if err := header.LoadFromBlockHeaderProto(pb); err != nil {
+		return hash.ZeroHash256, errors.Wrap(err, "failed to load block header")
+	}
+	return header.HashBlock(), nil
+}
+
+func encodeBlockMessage(kind byte, msg proto.Message) ([]byte, error) {
+	b, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{kind}, b...), nil
+}
@@ -0,0 +1,185 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+// testBlockNetwork connects all the block propagators with each other
+type testBlockNetwork struct {
+	mu        sync.Mutex
+	nodes     map[peer.ID]*blockPropagator
+	delivered map[peer.ID]int
+	sent      map[byte]int
+}
+
+func newTestBlockNetwork(n int, cfg BlockPropagationConfig) (*testBlockNetwork, []peer.ID) {
+	net := &testBlockNetwork{
+		nodes:     map[peer.ID]*blockPropagator{},
+		delivered: map[peer.ID]int{},
+		sent:      map[byte]int{},
+	}
+	ids := make([]peer.ID, n)
+	for i := range ids {
+		ids[i] = peer.ID(fmt.Sprintf("peer%d", i))
+	}
+	for _, id := range ids {
+		self := id
+		net.nodes[self] = newBlockPropagator(cfg, func() ([]peer.AddrInfo, error) {
+			peers := make([]peer.AddrInfo, 0, n-1)
+			for _, other := range ids {
+				if other != self {
+					peers = append(peers, peer.AddrInfo{ID: other})
+				}
+			}
+			return peers, nil
+		}, func(ctx context.Context, to peer.AddrInfo, data []byte) error {
+			net.mu.Lock()
+			net.sent[data[0]]++
+			node := net.nodes[to.ID]
+			net.mu.Unlock()
+			return node.HandleMessage(ctx, peer.AddrInfo{ID: self}, data)
+		}, nil, func(context.Context, peer.AddrInfo, *iotextypes.Block) {
+			net.mu.Lock()
+			net.delivered[self]++
+			net.mu.Unlock()
+		})
+	}
+	return net, ids
+}
+
+func (net *testBlockNetwork) count(m map[peer.ID]int) (int, int) {
+	net.mu.Lock()
+	defer net.mu.Unlock()
+	total := 0
+	for _, n := range m {
+		total += n
+	}
+	return len(m), total
+}
+
+func testBlock(t *testing.T, height uint64) (*iotextypes.Block, hash.Hash256) {
+	blk, err := block.NewTestingBuilder().
+		SetHeight(height).
+		SetTimeStamp(time.Now()).
+		SignAndBuild(identityset.PrivateKey(0))
+	require.NoError(t, err)
+	return blk.ConvertToBlockPb(), blk.HashBlock()
+}
+
+func TestBlockPropagation(t *testing.T) {
+	require := require.New(t)
+	cfg := DefaultBlockPropagationConfig
+	cfg.FanoutThreshold = 4
+	net, ids := newTestBlockNetwork(17, cfg)
+	blk, _ := testBlock(t, 1)
+
+	require.NoError(net.nodes[ids[0]].Propagate(context.Background(), blk, ""))
+	// the full blocks are sent to 4 out of the 16 peers by the producer, to 3 out of the 15 peers by the relays, and
+	// fetched by the others on the announcements
+	require.Eventually(func() bool {
+		nodes, total := net.count(net.delivered)
+		net.mu.Lock()
+		defer net.mu.Unlock()
+		return nodes == len(ids)-1 && total == len(ids)-1 &&
+			net.sent[_announceKind] == 12+(len(ids)-1)*12 &&
+			net.sent[_blockKind] == 4+(len(ids)-1)*3+net.sent[_requestKind]
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the duplicate is suppressed
+	data, err := encodeBlockMessage(_blockKind, blk)
+	require.NoError(err)
+	require.NoError(net.nodes[ids[1]].HandleMessage(context.Background(), peer.AddrInfo{ID: ids[0]}, data))
+	_, total := net.count(net.delivered)
+	require.Equal(len(ids)-1, total)
+}
+
+func TestBlockPropagatorHandleMessage(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	cfg := DefaultBlockPropagationConfig
+	cfg.FanoutThreshold = 1
+	cfg.FetchTimeout = 100 * time.Millisecond
+	var (
+		sent      [][]byte
+		delivered int
+		ignore    bool
+	)
+	bp := newBlockPropagator(cfg, func() ([]peer.AddrInfo, error) {
+		return nil, nil
+	}, func(_ context.Context, _ peer.AddrInfo, data []byte) error {
+		sent = append(sent, data)
+		return nil
+	}, func(proto.Message) (bool, error) {
+		return ignore, nil
+	}, func(context.Context, peer.AddrInfo, *iotextypes.Block) {
+		delivered++
+	})
+	from := peer.AddrInfo{ID: "peer"}
+	blk, h := testBlock(t, 2)
+
+	// the block announced is requested once until the fetch times out
+	announce, err := encodeBlockMessage(_announceKind, blk.GetHeader())
+	require.NoError(err)
+	require.NoError(bp.HandleMessage(ctx, from, announce))
+	require.NoError(bp.HandleMessage(ctx, from, announce))
+	require.Len(sent, 1)
+	require.Equal(append([]byte{_requestKind}, h[:]...), sent[0])
+	time.Sleep(cfg.FetchTimeout)
+	require.NoError(bp.HandleMessage(ctx, from, announce))
+	require.Len(sent, 2)
+
+	// the block ignored by the validation is not delivered
+	data, err := encodeBlockMessage(_blockKind, blk)
+	require.NoError(err)
+	ignore = true
+	require.NoError(bp.HandleMessage(ctx, from, data))
+	require.Zero(delivered)
+	ignore = false
+	require.NoError(bp.HandleMessage(ctx, from, data))
+	require.Equal(1, delivered)
+
+	// the block received is served, and no longer fetched
+	require.NoError(bp.HandleMessage(ctx, from, append([]byte{_requestKind}, h[:]...)))
+	require.Len(sent, 3)
+	require.Equal(data, sent[2])
+	require.NoError(bp.HandleMessage(ctx, from, announce))
+	require.Len(sent, 3)
+
+	// the block committed is not fetched, nor served
+	blk, h = testBlock(t, 3)
+	bp.Seen(h)
+	announce, err = encodeBlockMessage(_announceKind, blk.GetHeader())
+	require.NoError(err)
+	require.NoError(bp.HandleMessage(ctx, from, announce))
+	require.NoError(bp.HandleMessage(ctx, from, append([]byte{_requestKind}, h[:]...)))
+	require.Len(sent, 3)
+
+	// the malformed messages are rejected
+	for _, data := range [][]byte{nil, {9}, {_blockKind, 0xff}, {_announceKind}, {_requestKind, 1}} {
+		require.Error(bp.HandleMessage(ctx, from, data))
+	}
+	bp.validate = func(proto.Message) (bool, error) { return false, errors.New("invalid") }
+	blk, _ = testBlock(t, 4)
+	data, err = encodeBlockMessage(_blockKind, blk)
+	require.NoError(err)
+	require.Error(bp.HandleMessage(ctx, from, data))
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"encoding/binary"
+
+	"github.com/golang/snappy"
+	"github.com/iotexproject/go-p2p"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pkg/errors"
+)
+
+// compression algorithms of the messages
+const (
+	CompressionNone   = ""
+	CompressionSnappy = "snappy"
+	CompressionZstd   = "zstd"
+)
+
+const (
+	// _compressedFlag starts the compressed message, which never starts a protobuf message since the field number 0
+	// is invalid. The flag is followed by the algorithm, the uvarint size of the message and the compressed message
+	_compressedFlag byte = 0
+	_snappyAlgo     byte = 1
+	_zstdAlgo       byte = 2
+)
+
+type (
+	// CompressionConfig is the config of the message compression
+	CompressionConfig struct {
+		// Algorithm is the algorithm compressing the messages sent, either snappy or zstd, the messages are not
+		// compressed if it is empty. The compressed messages are always accepted
+		Algorithm string `yaml:"algorithm"`
+		// Threshold is the size in bytes from which the messages are compressed, such as the blocks and the block
+		// ranges
+		Threshold int `yaml:"threshold"`
+	}
+
+	// messageCodec compresses the large messages sent, and decompresses the messages received
+	messageCodec struct {
+		algo      byte
+		threshold int
+		maxSize   int
+		encoder   *zstd.Encoder
+		decoder   *zstd.Decoder
+	}
+)
+
+func newMessageCodec(cfg CompressionConfig, maxSize int) (*messageCodec, error) {
+	if maxSize <= 0 {
+		maxSize = p2p.DefaultConfig.MaxMessageSize
+	}
+	c := &messageCodec{
+		threshold: cfg.Threshold,
+		maxSize:   maxSize,
+	}
+	switch cfg.Algorithm {
+	case CompressionNone:
+	case CompressionSnappy:
+		c.algo = _snappyAlgo
+	case CompressionZstd:
+		c.algo = _zstdAlgo
+		encoder, err := zstd.NewWriter(nil)
+		if err != nil {
+			return nil, err
+		}
+		c.encoder = encoder
+	default:
+		return nil, errors.Errorf("unsupported compression algorithm %s", cfg.Algorithm)
+	}
+	var err error
+	if c.decoder, err = zstd.NewReader(nil, zstd.WithDecoderMaxMemory(uint64(maxSize))); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Encode compresses the message if it is large enough and shrinks by the compression
+func (c *messageCodec) Encode(data []byte) []byte {
+	if c.algo == 0 || len(data) < c.threshold {
+		return data
+	}
+	b := make([]byte, 0, len(data))
+	b = append(b, _compressedFlag, c.algo)
+	b = binary.AppendUvarint(b, uint64(len(data)))
+	switch c.algo {
+	case _snappyAlgo:
+		b = append(b, snappy.Encode(nil, data)...)
+	case _zstdAlgo:
+		b = c.encoder.EncodeAll(data, b)
+	}
+	if len(b) >= len(data) {
+		return data
+	}
+	return b
+}
+
+// Decode decompresses the message if it is compressed
+func (c *messageCodec) Decode(data []byte) ([]byte, error) {
+	if len(data) == 0 || data[0] != _compressedFlag {
+		return data, nil
+	}
+	if len(data) < 2 {
+		return nil, errors.New("compressed message is too short")
+	}
+	algo := data[1]
+	size, n := binary.Uvarint(data[2:])
+	if n <= 0 {
+		return nil, errors.New("invalid size of compressed message")
+	}
+	if size > uint64(c.maxSize) {
+		return nil, errors.Errorf("size %d of compressed message exceeds the limit %d", size, c.maxSize)
+	}
+	payload := data[2+n:]
+	var (
+		b   []byte
+		err error
+	)
+	switch algo {
+	case _snappyAlgo:
+		var l int
+		if l, err = snappy.DecodedLen(payload); err != nil {
+			return nil, errors.Wrap(err, "failed to decompress snappy message")
+		}
+		if uint64(l) != size {
+			return nil, errors.Errorf("snappy message size %d mismatches %d", l, size)
+		}
+		b, err = snappy.Decode(nil, payload)
+	case _zstdAlgo:
+		b, err = c.decoder.DecodeAll(payload, make([]byte, 0, size))
+	default:
+		return nil, errors.Errorf("unsupported compression algorithm %d", algo)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decompress message")
+	}
+	if uint64(len(b)) != size {
+		return nil, errors.Errorf("decompressed message size %d mismatches %d", len(b), size)
+	}
+	return b, nil
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+
+	"github.com/iotexproject/iotex-proto/golang/iotexrpc"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestMessageCodec(t *testing.T) {
+	require := require.New(t)
+	msg, err := proto.Marshal(&iotexrpc.UnicastMsg{
+		ChainId: 1,
+		PeerId:  "peer",
+		MsgBody: bytes.Repeat([]byte("block"), 1000),
+	})
+	require.NoError(err)
+	small := msg[:100]
+
+	_, err = newMessageCodec(CompressionConfig{Algorithm: "lz4"}, 1<<20)
+	require.Error(err)
+	none, err := newMessageCodec(CompressionConfig{}, 1<<20)
+	require.NoError(err)
+	require.Equal(msg, none.Encode(msg))
+
+	for _, algo := range []string{CompressionSnappy, CompressionZstd} {
+		c, err := newMessageCodec(CompressionConfig{Algorithm: algo, Threshold: 1000}, 1<<20)
+		require.NoError(err)
+		// the small message is not compressed
+		require.Equal(small, c.Encode(small))
+		encoded := c.Encode(msg)
+		require.Less(len(encoded), len(msg))
+		require.Equal(_compressedFlag, encoded[0])
+		// the compressed message is decoded by the node not compressing
+		for _, codec := range []*messageCodec{c, none} {
+			decoded, err := codec.Decode(encoded)
+			require.NoError(err)
+			require.Equal(msg, decoded)
+			decoded, err = codec.Decode(small)
+			require.NoError(err)
+			require.Equal(small, decoded)
+		}
+		// the message decompressed beyond the limit is rejected
+		limited, err := newMessageCodec(CompressionConfig{}, len(msg)-1)
+		require.NoError(err)
+		_, err = limited.Decode(encoded)
+		require.ErrorContains(err, "exceeds the limit")
+		// the message of the forged size is rejected
+		forged := append([]byte{}, encoded...)
+		forged[2]++
+		_, err = c.Decode(forged)
+		require.Error(err)
+	}
+
+	// the random message doesn't shrink by the compression
+	random := make([]byte, 2000)
+	_, err = rand.Read(random)
+	require.NoError(err)
+	c, err := newMessageCodec(CompressionConfig{Algorithm: CompressionZstd, Threshold: 1000}, 1<<20)
+	require.NoError(err)
+	require.Len(c.Encode(random), len(random))
+
+	for _, data := range [][]byte{{_compressedFlag}, {_compressedFlag, 9, 1, 0}, {_compressedFlag, _snappyAlgo, 0x80}} {
+		_, err = c.Decode(data)
+		require.Error(err)
+	}
+}
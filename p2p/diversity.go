@@ -0,0 +1,343 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"bufio"
+	"math"
+	"math/rand"
+	"net/netip"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+	"github.com/pkg/errors"
+)
+
+type (
+	// DiversityConfig is the config of the peer diversity hardening the node against the eclipse attacks, in which
+	// an attacker controlling many addresses of a few networks occupies all the connections of the node
+	DiversityConfig struct {
+		// MaxPeersPerGroup is the maximal number of the peers connected in the same group, which is the ASN of the
+		// peer if it is in the ASN file, or else the /16 subnet of IPv4 or the /32 subnet of IPv6. The peers of the
+		// non-public addresses are exempt. The diversity isn't enforced if it is 0
+		MaxPeersPerGroup int `yaml:"maxPeersPerGroup"`
+		// ASNFile is the path of the file mapping the IP prefixes to the ASNs, each line of which is a prefix and
+		// an ASN separated by the spaces, e.g., "1.0.0.0/24 13335"
+		ASNFile string `yaml:"asnFile"`
+		// AnchorPeers is the number of the longest connected peers kept as the anchors, which are persisted and
+		// reconnected first after a restart, and never evicted or rotated
+		AnchorPeers int `yaml:"anchorPeers"`
+		// RotationInterval is the interval to rotate the peers, the peers are not rotated if it is 0
+		RotationInterval time.Duration `yaml:"rotationInterval"`
+		// RotationFraction is the fraction of the peers disconnected in a rotation and replaced by the peers of
+		// the groups least connected. The anchors, the static and the trusted peers are not rotated
+		RotationFraction float64 `yaml:"rotationFraction"`
+	}
+
+	// asnTable maps the IP prefixes to the ASNs by the longest prefix match
+	asnTable map[netip.Prefix]uint32
+
+	// diversity selects the peers to connect and to evict by the groups of their addresses, and keeps the peers
+	// connected the longest as the anchors
+	diversity struct {
+		cfg DiversityConfig
+		asn asnTable
+		// maxPerGroup is the cap of the peers in a group, which could be adjusted at runtime
+		maxPerGroup atomic.Int64
+
+		mu      sync.Mutex
+		since   map[peer.ID]time.Time
+		anchors map[peer.ID]struct{}
+	}
+)
+
+// DefaultDiversityConfig is the default config of the peer diversity
+var DefaultDiversityConfig = DiversityConfig{
+	MaxPeersPerGroup: 4,
+	ASNFile:          "",
+	AnchorPeers:      2,
+	RotationInterval: time.Hour,
+	RotationFraction: 0.1,
+}
+
+func newDiversity(cfg DiversityConfig) (*diversity, error) {
+	d := &diversity{
+		cfg:     cfg,
+		since:   map[peer.ID]time.Time{},
+		anchors: map[peer.ID]struct{}{},
+	}
+	d.maxPerGroup.Store(int64(cfg.MaxPeersPerGroup))
+	if cfg.ASNFile != "" {
+		asn, err := loadASNTable(cfg.ASNFile)
+		if err != nil {
+			return nil, err
+		}
+		d.asn = asn
+	}
+	return d, nil
+}
+
+func loadASNTable(path string) (asnTable, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open ASN file %s", path)
+	}
+	defer f.Close()
+	table := asnTable{}
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, errors.Errorf("invalid line %d of ASN file %s", line, path)
+		}
+		prefix, err := netip.ParsePrefix(fields[0])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid prefix at line %d of ASN file %s", line, path)
+		}
+		asn, err := strconv.ParseUint(strings.TrimPrefix(strings.ToUpper(fields[1]), "AS"), 10, 32)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid ASN at line %d of ASN file %s", line, path)
+		}
+		table[prefix.Masked()] = uint32(asn)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrapf(err, "failed to read ASN file %s", path)
+	}
+	return table, nil
+}
+
+// Lookup returns the ASN of the longest prefix containing the address
+func (t asnTable) Lookup(addr netip.Addr) (uint32, bool) {
+	for bits := addr.BitLen(); bits >= 0 && len(t) > 0; bits-- {
+		prefix, err := addr.Prefix(bits)
+		if err != nil {
+			return 0, false
+		}
+		if asn, ok := t[prefix]; ok {
+			return asn, true
+		}
+	}
+	return 0, false
+}
+
+// group returns the group of the peer by its first public address, the peer without a public address has no group
+func (d *diversity) group(info peer.AddrInfo) (string, bool) {
+	for _, ma := range info.Addrs {
+		addr, ok := publicAddr(ma)
+		if !ok {
+			continue
+		}
+		if asn, ok := d.asn.Lookup(addr); ok {
+			return "AS" + strconv.FormatUint(uint64(asn), 10), true
+		}
+		bits := 16
+		if addr.Is6() {
+			bits = 32
+		}
+		prefix, _ := addr.Prefix(bits)
+		return prefix.String(), true
+	}
+	return "", false
+}
+
+func publicAddr(ma multiaddr.Multiaddr) (netip.Addr, bool) {
+	ip, err := manet.ToIP(ma)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	addr, ok := netip.AddrFromSlice(ip)
+	if !ok {
+		return netip.Addr{}, false
+	}
+	addr = addr.Unmap()
+	return addr, isPublic(addr)
+}
+
+// Update tracks the time since which the peers are connected, and selects the anchors among the peers connected the
+// longest, in favor of the anchors persisted
+func (d *diversity) Update(peers []peer.AddrInfo, persisted []peer.ID, now time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	connected := make(map[peer.ID]struct{}, len(peers))
+	for _, p := range peers {
+		connected[p.ID] = struct{}{}
+		if _, ok := d.since[p.ID]; !ok {
+			d.since[p.ID] = now
+		}
+	}
+	for id := range d.since {
+		if _, ok := connected[id]; !ok {
+			delete(d.since, id)
+		}
+	}
+	for _, id := range persisted {
+		if _, ok := d.since[id]; ok {
+			// the anchors persisted are regarded as the peers connected the longest
+			d.since[id] = time.Time{}
+		}
+	}
+	ids := make([]peer.ID, 0, len(d.since))
+	for id := range d.since {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if !d.since[ids[i]].Equal(d.since[ids[j]]) {
+			return d.since[ids[i]].Before(d.since[ids[j]])
+		}
+		return ids[i] < ids[j]
+	})
+	d.anchors = make(map[peer.ID]struct{}, d.cfg.AnchorPeers)
+	for i := 0; i < len(ids) && i < d.cfg.AnchorPeers; i++ {
+		d.anchors[ids[i]] = struct{}{}
+	}
+}
+
+// Anchors returns the anchors among the peers
+func (d *diversity) Anchors(peers []peer.AddrInfo) []peer.AddrInfo {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var anchors []peer.AddrInfo
+	for _, p := range peers {
+		if _, ok := d.anchors[p.ID]; ok {
+			anchors = append(anchors, p)
+		}
+	}
+	return anchors
+}
+
+// SetMaxPeersPerGroup sets the cap of the peers connected in the same group, which is enforced from the next check
+func (d *diversity) SetMaxPeersPerGroup(n int) {
+	d.maxPerGroup.Store(int64(n))
+}
+
+// Evictions returns the peers of the groups exceeding the cap, the peers connected the most recently are evicted
+// first, and the protected peers and the anchors are never evicted
+func (d *diversity) Evictions(peers []peer.AddrInfo, protected func(peer.ID) bool) []peer.ID {
+	maxPerGroup := int(d.maxPerGroup.Load())
+	if maxPerGroup <= 0 {
+		return nil
+	}
+	groups := map[string][]peer.AddrInfo{}
+	for _, p := range peers {
+		if g, ok := d.group(p); ok {
+			groups[g] = append(groups[g], p)
+		}
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var evicted []peer.ID
+	for _, members := range groups {
+		if len(members) <= maxPerGroup {
+			continue
+		}
+		sort.Slice(members, func(i, j int) bool {
+			return d.since[members[i].ID].After(d.since[members[j].ID])
+		})
+		excess := len(members) - maxPerGroup
+		for _, p := range members {
+			if excess == 0 {
+				break
+			}
+			if _, ok := d.anchors[p.ID]; ok || protected(p.ID) {
+				continue
+			}
+			evicted = append(evicted, p.ID)
+			excess--
+		}
+	}
+	return evicted
+}
+
+// Rotations returns a random fraction of the peers to rotate, except the protected peers and the anchors
+func (d *diversity) Rotations(peers []peer.AddrInfo, protected func(peer.ID) bool) []peer.ID {
+	if d.cfg.RotationFraction <= 0 {
+		return nil
+	}
+	d.mu.Lock()
+	var candidates []peer.ID
+	for _, p := range peers {
+		if _, ok := d.anchors[p.ID]; !ok && !protected(p.ID) {
+			candidates = append(candidates, p.ID)
+		}
+	}
+	d.mu.Unlock()
+	n := int(math.Ceil(float64(len(peers)) * d.cfg.RotationFraction))
+	if n > len(candidates) {
+		n = len(candidates)
+	}
+	rand.Shuffle(len(candidates), func(i, j int) { candidates[i], candidates[j] = candidates[j], candidates[i] })
+	return candidates[:n]
+}
+
+// Candidates selects up to n peers known but not connected to dial, which don't exceed the cap of their groups. The
+// peers of the groups least connected are selected first
+func (d *diversity) Candidates(known, connected []peer.AddrInfo, n int) []peer.AddrInfo {
+	if n <= 0 {
+		return nil
+	}
+	var (
+		maxPerGroup = int(d.maxPerGroup.Load())
+		counts      = map[string]int{}
+		isConnected = make(map[peer.ID]struct{}, len(connected))
+	)
+	for _, p := range connected {
+		isConnected[p.ID] = struct{}{}
+		if g, ok := d.group(p); ok {
+			counts[g]++
+		}
+	}
+	type candidate struct {
+		info  peer.AddrInfo
+		group string
+	}
+	var candidates []candidate
+	for _, p := range known {
+		if _, ok := isConnected[p.ID]; ok {
+			continue
+		}
+		g, _ := d.group(p)
+		candidates = append(candidates, candidate{info: p, group: g})
+	}
+	rand.Shuffle(len(candidates), func(i, j int) { candidates[i], candidates[j] = candidates[j], candidates[i] })
+	var selected []peer.AddrInfo
+	for len(selected) < n && len(candidates) > 0 {
+		// pick the candidate of the group least connected, the peers without a group are the last resort
+		best, bestCount := -1, 0
+		for i, c := range candidates {
+			count := math.MaxInt
+			if c.group != "" {
+				count = counts[c.group]
+				if maxPerGroup > 0 && count >= maxPerGroup {
+					continue
+				}
+			}
+			if best < 0 || count < bestCount {
+				best, bestCount = i, count
+			}
+		}
+		if best < 0 {
+			break
+		}
+		c := candidates[best]
+		candidates = append(candidates[:best], candidates[best+1:]...)
+		selected = append(selected, c.info)
+		if c.group != "" {
+			counts[c.group]++
+		}
+	}
+	return selected
+}
@@ -0,0 +1,181 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"crypto/rand"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/require"
+)
+
+func testPeer(t *testing.T, addr string) peer.AddrInfo {
+	_, pub, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+	id, err := peer.IDFromPublicKey(pub)
+	require.NoError(t, err)
+	return peer.AddrInfo{ID: id, Addrs: []multiaddr.Multiaddr{multiaddr.StringCast(addr)}}
+}
+
+func TestASNTable(t *testing.T) {
+	require := require.New(t)
+	path := filepath.Join(t.TempDir(), "asn.txt")
+	require.NoError(os.WriteFile(path, []byte("# prefix asn\n1.2.0.0/16 100\n1.2.3.0/24 AS200\n\n2001:db8::/32 300\n"), 0600))
+	table, err := loadASNTable(path)
+	require.NoError(err)
+	for _, c := range []struct {
+		addr string
+		asn  uint32
+		ok   bool
+	}{
+		{"1.2.3.4", 200, true},
+		{"1.2.4.4", 100, true},
+		{"1.3.0.1", 0, false},
+		{"2001:db8::1", 300, true},
+	} {
+		asn, ok := table.Lookup(netip.MustParseAddr(c.addr))
+		require.Equal(c.ok, ok, c.addr)
+		require.Equal(c.asn, asn, c.addr)
+	}
+
+	for _, content := range []string{"1.2.0.0/16", "1.2.0.0/33 100", "1.2.0.0/16 asn"} {
+		require.NoError(os.WriteFile(path, []byte(content), 0600))
+		_, err = loadASNTable(path)
+		require.Error(err, content)
+	}
+	_, err = newDiversity(DiversityConfig{ASNFile: filepath.Join(t.TempDir(), "none")})
+	require.Error(err)
+}
+
+func TestDiversityGroup(t *testing.T) {
+	require := require.New(t)
+	d, err := newDiversity(DefaultDiversityConfig)
+	require.NoError(err)
+	d.asn = asnTable{netip.MustParsePrefix("8.8.0.0/16"): 15169}
+	for _, c := range []struct {
+		addr  string
+		group string
+	}{
+		{"/ip4/1.2.3.4/tcp/4689", "1.2.0.0/16"},
+		{"/ip4/8.8.8.8/tcp/4689", "AS15169"},
+		{"/ip6/2600:1f18::1/tcp/4689", "2600:1f18::/32"},
+		{"/ip4/127.0.0.1/tcp/4689", ""},
+		{"/ip4/192.168.1.1/tcp/4689", ""},
+		{"/dns4/example.com/tcp/4689", ""},
+	} {
+		g, ok := d.group(testPeer(t, c.addr))
+		require.Equal(c.group != "", ok, c.addr)
+		require.Equal(c.group, g, c.addr)
+	}
+}
+
+func TestDiversityEvictions(t *testing.T) {
+	require := require.New(t)
+	cfg := DefaultDiversityConfig
+	cfg.MaxPeersPerGroup = 2
+	cfg.AnchorPeers = 1
+	d, err := newDiversity(cfg)
+	require.NoError(err)
+
+	// 5 peers of the same subnet, 2 of other subnets and a local peer
+	var peers []peer.AddrInfo
+	for _, addr := range []string{"1.2.0.1", "1.2.0.2", "1.2.0.3", "1.2.0.4", "1.2.0.5", "1.3.0.1", "1.4.0.1", "127.0.0.1"} {
+		peers = append(peers, testPeer(t, "/ip4/"+addr+"/tcp/4689"))
+	}
+	now := time.Now()
+	for i := range peers {
+		d.Update(peers[:i+1], nil, now.Add(time.Duration(i)*time.Minute))
+	}
+	require.Equal(peers[:1], d.Anchors(peers))
+
+	// the most recent peers of the subnet are evicted, except the protected ones
+	protected := func(id peer.ID) bool { return id == peers[4].ID }
+	require.ElementsMatch([]peer.ID{peers[1].ID, peers[2].ID, peers[3].ID}, d.Evictions(peers, protected))
+	// the anchor is kept
+	d.Update(peers, []peer.ID{peers[3].ID}, now)
+	require.Equal([]peer.AddrInfo{peers[3]}, d.Anchors(peers))
+	require.ElementsMatch([]peer.ID{peers[0].ID, peers[1].ID, peers[2].ID}, d.Evictions(peers, protected))
+
+	// the peers disconnected are forgotten
+	d.Update(peers[1:], nil, now)
+	require.Equal([]peer.AddrInfo{peers[3]}, d.Anchors(peers))
+	d.Update(peers[4:], nil, now)
+	require.Len(d.Anchors(peers), 1)
+	require.Len(d.since, 4)
+
+	// the diversity isn't enforced if the cap is 0
+	d.SetMaxPeersPerGroup(0)
+	require.Empty(d.Evictions(peers, protected))
+}
+
+func TestDiversityRotations(t *testing.T) {
+	require := require.New(t)
+	cfg := DefaultDiversityConfig
+	cfg.AnchorPeers = 2
+	cfg.RotationFraction = 0.25
+	d, err := newDiversity(cfg)
+	require.NoError(err)
+	var peers []peer.AddrInfo
+	for i := 0; i < 10; i++ {
+		peers = append(peers, testPeer(t, "/ip4/1.2.3.4/tcp/4689"))
+	}
+	d.Update(peers[:2], nil, time.Now())
+	d.Update(peers, nil, time.Now().Add(time.Minute))
+	protected := func(id peer.ID) bool { return id == peers[2].ID }
+	for i := 0; i < 10; i++ {
+		rotated := d.Rotations(peers, protected)
+		require.Len(rotated, 3)
+		for _, id := range rotated {
+			require.NotContains([]peer.ID{peers[0].ID, peers[1].ID, peers[2].ID}, id)
+		}
+	}
+	// the peers are not rotated more than the candidates
+	require.Empty(d.Rotations(peers[:3], protected))
+	d.cfg.RotationFraction = 0
+	require.Empty(d.Rotations(peers, protected))
+}
+
+func TestDiversityCandidates(t *testing.T) {
+	require := require.New(t)
+	cfg := DefaultDiversityConfig
+	cfg.MaxPeersPerGroup = 2
+	d, err := newDiversity(cfg)
+	require.NoError(err)
+	var (
+		connected = []peer.AddrInfo{
+			testPeer(t, "/ip4/1.2.0.1/tcp/4689"),
+			testPeer(t, "/ip4/1.2.0.2/tcp/4689"),
+			testPeer(t, "/ip4/1.3.0.1/tcp/4689"),
+		}
+		full    = testPeer(t, "/ip4/1.2.0.3/tcp/4689")
+		half    = testPeer(t, "/ip4/1.3.0.2/tcp/4689")
+		empty   = testPeer(t, "/ip4/1.4.0.1/tcp/4689")
+		empty2  = testPeer(t, "/ip4/1.4.0.2/tcp/4689")
+		local   = testPeer(t, "/ip4/10.0.0.1/tcp/4689")
+		known   = []peer.AddrInfo{local, full, half, empty, empty2, connected[0]}
+		isEmpty = func(p peer.AddrInfo) bool { return p.ID == empty.ID || p.ID == empty2.ID }
+	)
+	require.Empty(d.Candidates(known, connected, 0))
+	selected := d.Candidates(known, connected, 1)
+	require.Len(selected, 1)
+	require.True(isEmpty(selected[0]))
+	// the group least connected is selected first, and the group at the cap is skipped
+	selected = d.Candidates(known, connected, 10)
+	require.Len(selected, 4)
+	require.True(isEmpty(selected[0]))
+	require.Contains([]peer.ID{half.ID, empty.ID, empty2.ID}, selected[1].ID)
+	require.Equal(local, selected[3])
+	for _, p := range selected {
+		require.NotEqual(full.ID, p.ID)
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"time"
+
+	"github.com/iotexproject/go-p2p"
+	"github.com/libp2p/go-libp2p/p2p/net/nat"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+// _relayActive is the relay type enabling the circuit relay
+const _relayActive = "active"
+
+type (
+	// NATConfig is the config of the NAT traversal
+	NATConfig struct {
+		// PortMapping maps the port on the NAT device via UPnP or NAT-PMP, and advertises the external address to
+		// the peers. It is skipped if the external host is configured
+		PortMapping bool `yaml:"portMapping"`
+		// MappingTimeout is the time to discover the NAT device and map the port
+		MappingTimeout time.Duration `yaml:"mappingTimeout"`
+		// RelayFallback enables the circuit relay if the port fails to be mapped, e.g., behind a symmetric NAT or a
+		// NAT device without UPnP or NAT-PMP, so that the node is reachable via the relays
+		RelayFallback bool `yaml:"relayFallback"`
+	}
+
+	// portMapper maps the port on the NAT device, and renews the mapping until it is closed
+	portMapper interface {
+		AddMapping(ctx context.Context, protocol string, port int) error
+		GetMapping(protocol string, port int) (netip.AddrPort, bool)
+		Close() error
+	}
+)
+
+var (
+	// DefaultNATConfig is the default config of the NAT traversal
+	DefaultNATConfig = NATConfig{
+		PortMapping:    false,
+		MappingTimeout: 10 * time.Second,
+		RelayFallback:  false,
+	}
+
+	// discoverNAT discovers the NAT device in the network
+	discoverNAT = func(ctx context.Context) (portMapper, error) {
+		return nat.DiscoverNAT(ctx)
+	}
+)
+
+// natOptions returns the options of the host to traverse the NAT
+func (p *agent) natOptions(ctx context.Context) []p2p.Option {
+	var (
+		opts      []p2p.Option
+		relayType = p.cfg.RelayType
+	)
+	switch {
+	case p.cfg.ExternalHost != "":
+		opts = append(opts, p2p.ExternalHostName(p.cfg.ExternalHost), p2p.ExternalPort(p.cfg.ExternalPort))
+	case p.cfg.NAT.PortMapping:
+		addr, err := p.mapPort(ctx)
+		if err == nil {
+			log.Logger("p2p").Info("port is mapped on the NAT device", zap.String("address", addr.String()))
+			opts = append(opts, p2p.ExternalHostName(addr.Addr().String()), p2p.ExternalPort(int(addr.Port())))
+			if p.cfg.MasterKey == "" {
+				// keep the identity derived from the listening address rather than the external address
+				ip, err := p2p.EnsureIPv4(p.cfg.Host)
+				if err == nil {
+					opts = append(opts, p2p.MasterKey(fmt.Sprintf("%s:%d", ip, p.cfg.Port)))
+				}
+			}
+			break
+		}
+		log.Logger("p2p").Warn("failed to map port on the NAT device", zap.Error(err))
+		if p.cfg.NAT.RelayFallback && relayType == "" {
+			log.Logger("p2p").Info("fall back to the circuit relay")
+			relayType = _relayActive
+		}
+	}
+	if relayType != "" {
+		opts = append(opts, p2p.WithRelay(relayType))
+	}
+	return opts
+}
+
+// mapPort maps the listening port on the NAT device, and returns the external address
+func (p *agent) mapPort(ctx context.Context) (netip.AddrPort, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.cfg.NAT.MappingTimeout)
+	defer cancel()
+	mapper, err := discoverNAT(ctx)
+	if err != nil {
+		return netip.AddrPort{}, errors.Wrap(err, "failed to discover NAT device")
+	}
+	if err := mapper.AddMapping(ctx, "tcp", p.cfg.Port); err != nil {
+		mapper.Close()
+		return netip.AddrPort{}, errors.Wrapf(err, "failed to map port %d", p.cfg.Port)
+	}
+	addr, ok := mapper.GetMapping("tcp", p.cfg.Port)
+	if !ok {
+		mapper.Close()
+		return netip.AddrPort{}, errors.Errorf("no mapping of port %d", p.cfg.Port)
+	}
+	if !isPublic(addr.Addr()) {
+		// the NAT device is behind another NAT
+		mapper.Close()
+		return netip.AddrPort{}, errors.Errorf("external address %s is not public", addr)
+	}
+	p.portMapper = mapper
+	return addr, nil
+}
+
+// isPublic returns whether the address is reachable from the internet, the shared address space of the carrier-grade
+// NAT isn't
+func isPublic(addr netip.Addr) bool {
+	return addr.IsValid() && addr.IsGlobalUnicast() && !addr.IsPrivate() &&
+		!netip.MustParsePrefix("100.64.0.0/10").Contains(addr)
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+
+	"github.com/iotexproject/go-p2p"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type testPortMapper struct {
+	external netip.Addr
+	mapped   map[int]uint16
+	closed   bool
+}
+
+func (m *testPortMapper) AddMapping(_ context.Context, protocol string, port int) error {
+	if protocol != "tcp" {
+		return errors.New("unsupported protocol")
+	}
+	m.mapped[port] = uint16(port + 1)
+	return nil
+}
+
+func (m *testPortMapper) GetMapping(_ string, port int) (netip.AddrPort, bool) {
+	ext, ok := m.mapped[port]
+	return netip.AddrPortFrom(m.external, ext), ok
+}
+
+func (m *testPortMapper) Close() error {
+	m.closed = true
+	return nil
+}
+
+func TestNATOptions(t *testing.T) {
+	require := require.New(t)
+	var (
+		mapper      *testPortMapper
+		discoverErr error
+	)
+	defer func(f func(context.Context) (portMapper, error)) { discoverNAT = f }(discoverNAT)
+	discoverNAT = func(context.Context) (portMapper, error) {
+		if discoverErr != nil {
+			return nil, discoverErr
+		}
+		return mapper, nil
+	}
+	hostConfig := func(cfg Config) (p2p.Config, *agent) {
+		a := &agent{cfg: cfg}
+		c := p2p.DefaultConfig
+		for _, opt := range a.natOptions(context.Background()) {
+			require.NoError(opt(&c))
+		}
+		return c, a
+	}
+	cfg := DefaultConfig
+	cfg.Host = "127.0.0.1"
+	cfg.NAT.PortMapping = true
+	cfg.NAT.RelayFallback = true
+
+	// the external address mapped is advertised, and the identity is kept
+	mapper = &testPortMapper{external: netip.MustParseAddr("8.8.8.8"), mapped: map[int]uint16{}}
+	c, a := hostConfig(cfg)
+	require.Equal("8.8.8.8", c.ExternalHostName)
+	require.Equal(cfg.Port+1, c.ExternalPort)
+	require.Equal("127.0.0.1:4689", c.MasterKey)
+	require.Equal(p2p.DefaultConfig.Relay, c.Relay)
+	require.Equal(mapper, a.portMapper)
+
+	// the external host configured is not overridden
+	cfg.ExternalHost = "1.2.3.4"
+	c, a = hostConfig(cfg)
+	require.Equal("1.2.3.4", c.ExternalHostName)
+	require.Nil(a.portMapper)
+	cfg.ExternalHost = ""
+
+	// the relay is the fallback once the port fails to be mapped
+	mapper = &testPortMapper{external: netip.MustParseAddr("100.64.1.1"), mapped: map[int]uint16{}}
+	c, a = hostConfig(cfg)
+	require.Empty(c.ExternalHostName)
+	require.Equal(_relayActive, c.Relay)
+	require.True(mapper.closed)
+	require.Nil(a.portMapper)
+	discoverErr = errors.New("no gateway")
+	c, _ = hostConfig(cfg)
+	require.Equal(_relayActive, c.Relay)
+	cfg.RelayType = "nat"
+	c, _ = hostConfig(cfg)
+	require.Equal("nat", c.Relay)
+	cfg.RelayType = ""
+	cfg.NAT.RelayFallback = false
+	c, _ = hostConfig(cfg)
+	require.Equal(p2p.DefaultConfig.Relay, c.Relay)
+}
+
+func TestIsPublic(t *testing.T) {
+	require := require.New(t)
+	for addr, public := range map[string]bool{
+		"8.8.8.8":     true,
+		"192.168.1.1": false,
+		"10.0.0.1":    false,
+		"100.100.1.1": false,
+		"127.0.0.1":   false,
+		"0.0.0.0":     false,
+	} {
+		require.Equal(public, isPublic(netip.MustParseAddr(addr)), addr)
+	}
+	require.False(isPublic(netip.Addr{}))
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/pkg/errors"
+)
+
+type (
+	// peerStore persists the known-good peers and the trusted peers added at runtime in a json file, so that the
+	// node reconnects to them after a restart
+	peerStore struct {
+		path string
+	}
+
+	peerStoreData struct {
+		// Peers are the multiaddresses of the peers connected
+		Peers []string `json:"peers"`
+		// Trusted are the trusted peers added at runtime, either peer ids or multiaddresses
+		Trusted []string `json:"trusted"`
+		// Anchors are the multiaddresses of the anchor peers, which are connected the longest
+		Anchors []string `json:"anchors"`
+	}
+)
+
+func newPeerStore(path string) *peerStore {
+	return &peerStore{path: path}
+}
+
+// Load loads the peers persisted, which are empty if the file doesn't exist
+func (s *peerStore) Load() (*peerStoreData, error) {
+	data := &peerStoreData{}
+	b, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return data, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read peer store %s", s.path)
+	}
+	if err := json.Unmarshal(b, data); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse peer store %s", s.path)
+	}
+	return data, nil
+}
+
+// Save persists the peers, the file is replaced as a whole to survive a crash in the middle
+func (s *peerStore) Save(data *peerStoreData) error {
+	b, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return errors.Wrapf(err, "failed to create the directory of peer store %s", s.path)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0600); err != nil {
+		return errors.Wrapf(err, "failed to write peer store %s", s.path)
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// parsePeer parses the peer id, or the multiaddress ending with /p2p/<peer id>
+func parsePeer(s string) (peer.AddrInfo, error) {
+	if id, err := peer.Decode(s); err == nil {
+		return peer.AddrInfo{ID: id}, nil
+	}
+	ma, err := multiaddr.NewMultiaddr(s)
+	if err != nil {
+		return peer.AddrInfo{}, errors.Wrapf(err, "invalid peer %s", s)
+	}
+	info, err := peer.AddrInfoFromP2pAddr(ma)
+	if err != nil {
+		return peer.AddrInfo{}, errors.Wrapf(err, "invalid peer %s", s)
+	}
+	return *info, nil
+}
+
+// p2pAddrs returns the multiaddresses of the peer ending with /p2p/<peer id>
+func p2pAddrs(info peer.AddrInfo) []string {
+	if len(info.Addrs) == 0 {
+		return nil
+	}
+	addrs, err := peer.AddrInfoToP2pAddrs(&info)
+	if err != nil {
+		return nil
+	}
+	ret := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		ret = append(ret, addr.String())
+	}
+	return ret
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/stretchr/testify/require"
+)
+
+const _testPeerID = "12D3KooWJwW6pUpTkxPTMv84RPLPMQVEAjZ6fvJuX4oZrvW5DAGQ"
+
+func TestPeerStore(t *testing.T) {
+	require := require.New(t)
+	path := filepath.Join(t.TempDir(), "p2p", "peers.json")
+	s := newPeerStore(path)
+
+	data, err := s.Load()
+	require.NoError(err)
+	require.Empty(data.Peers)
+
+	expected := &peerStoreData{
+		Peers:   []string{"/ip4/127.0.0.1/tcp/4689/p2p/" + _testPeerID},
+		Trusted: []string{_testPeerID},
+		Anchors: []string{"/ip4/127.0.0.1/tcp/4689/p2p/" + _testPeerID},
+	}
+	require.NoError(s.Save(expected))
+	data, err = s.Load()
+	require.NoError(err)
+	require.Equal(expected, data)
+
+	require.NoError(os.WriteFile(path, []byte("{"), 0600))
+	_, err = s.Load()
+	require.Error(err)
+}
+
+func TestParsePeer(t *testing.T) {
+	require := require.New(t)
+	info, err := parsePeer(_testPeerID)
+	require.NoError(err)
+	require.Equal(_testPeerID, info.ID.String())
+	require.Empty(info.Addrs)
+	require.Nil(p2pAddrs(info))
+
+	addr := "/ip4/127.0.0.1/tcp/4689/p2p/" + _testPeerID
+	info, err = parsePeer(addr)
+	require.NoError(err)
+	require.Equal(_testPeerID, info.ID.String())
+	require.Equal([]string{addr}, p2pAddrs(info))
+
+	for _, s := range []string{"", "peer", "/ip4/127.0.0.1/tcp/4689"} {
+		_, err = parsePeer(s)
+		require.Error(err)
+	}
+}
+
+func TestAgentTrustedPeer(t *testing.T) {
+	require := require.New(t)
+	cfg := DefaultConfig
+	cfg.PeerBanThreshold = 50
+	cfg.TrustedPeers = []string{_testPeerID, "invalid"}
+	a := NewAgent(cfg, 1, hash.ZeroHash256, nil, nil, nil).(*agent)
+
+	// the trusted peers are exempt from scoring and bans
+	a.ReportPeer(_testPeerID, PeerInvalidBlock)
+	require.False(a.banned(_testPeerID))
+	require.Empty(a.PeerScores())
+
+	other := "12D3KooWHt2yPeWLgWmCVe5xWbCJCCDfQYVaNkfJvrAaJqqbjcoe"
+	a.ReportPeer(other, PeerInvalidBlock)
+	require.True(a.banned(other))
+	require.NoError(a.AddTrustedPeer(context.Background(), other))
+	require.False(a.banned(other))
+	require.Equal([]string{other}, a.addedTrusted)
+	require.Error(a.AddTrustedPeer(context.Background(), "invalid"))
+}
@@ -0,0 +1,179 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PeerEvent is the misbehavior of a peer lowering its score
+type PeerEvent int
+
+const (
+	// PeerInvalidBlock is the event that the peer serves an invalid block
+	PeerInvalidBlock PeerEvent = iota
+	// PeerTimeout is the event that the peer doesn't respond to a request in time
+	PeerTimeout
+	// PeerProtocolViolation is the event that the peer sends a malformed message or a message of another chain
+	PeerProtocolViolation
+)
+
+// _maxTrackedPeers is the number of peers tracked, beyond which the peers recovered are pruned
+const _maxTrackedPeers = 1000
+
+type (
+	// PeerScore is the reputation of a peer
+	PeerScore struct {
+		ID            string
+		Score         float64
+		InvalidBlocks uint64
+		Timeouts      uint64
+		Violations    uint64
+		// BannedUntil is the time the ban of the peer expires, or zero if the peer is not banned
+		BannedUntil time.Time
+	}
+
+	// reputation scores the peers by their misbehaviors. The score of a peer is lowered by the penalty of each
+	// misbehavior and recovers towards 0 with the half life, the peer is banned for a while once its score falls to
+	// the threshold
+	reputation struct {
+		mu          sync.Mutex
+		threshold   float64
+		banDuration time.Duration
+		halfLife    time.Duration
+		peers       map[string]*peerReputation
+	}
+
+	peerReputation struct {
+		PeerScore
+		updated time.Time
+	}
+)
+
+var (
+	_peerEventPenalty = map[PeerEvent]float64{
+		PeerInvalidBlock:      50,
+		PeerTimeout:           10,
+		PeerProtocolViolation: 20,
+	}
+	_peerEventNames = map[PeerEvent]string{
+		PeerInvalidBlock:      "invalidBlock",
+		PeerTimeout:           "timeout",
+		PeerProtocolViolation: "protocolViolation",
+	}
+	_peerEventCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iotex_p2p_peer_event_counter",
+			Help: "misbehaviors of the peers and the bans",
+		},
+		[]string{"event"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(_peerEventCounter)
+}
+
+func newReputation(threshold float64, banDuration, halfLife time.Duration) *reputation {
+	return &reputation{
+		threshold:   threshold,
+		banDuration: banDuration,
+		halfLife:    halfLife,
+		peers:       map[string]*peerReputation{},
+	}
+}
+
+// Report lowers the score of the peer by the penalty of the event, and returns true if the peer gets banned
+func (r *reputation) Report(id string, event PeerEvent, now time.Time) bool {
+	penalty, ok := _peerEventPenalty[event]
+	if !ok {
+		return false
+	}
+	_peerEventCounter.WithLabelValues(_peerEventNames[event]).Inc()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	p, ok := r.peers[id]
+	if !ok {
+		if len(r.peers) >= _maxTrackedPeers {
+			r.prune(now)
+		}
+		p = &peerReputation{PeerScore: PeerScore{ID: id}, updated: now}
+		r.peers[id] = p
+	}
+	r.decay(p, now)
+	p.Score -= penalty
+	switch event {
+	case PeerInvalidBlock:
+		p.InvalidBlocks++
+	case PeerTimeout:
+		p.Timeouts++
+	case PeerProtocolViolation:
+		p.Violations++
+	}
+	if p.BannedUntil.After(now) || p.Score > -r.threshold {
+		return false
+	}
+	p.BannedUntil = now.Add(r.banDuration)
+	_peerEventCounter.WithLabelValues("ban").Inc()
+	return true
+}
+
+// Banned returns true if the peer is banned. The score of the peer is reset once the ban expires
+func (r *reputation) Banned(id string, now time.Time) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	p, ok := r.peers[id]
+	if !ok || p.BannedUntil.IsZero() {
+		return false
+	}
+	if p.BannedUntil.After(now) {
+		return true
+	}
+	p.BannedUntil = time.Time{}
+	p.Score = 0
+	p.updated = now
+	return false
+}
+
+// Scores returns the scores of the peers, in the order of the scores from the lowest
+func (r *reputation) Scores(now time.Time) []PeerScore {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	scores := make([]PeerScore, 0, len(r.peers))
+	for _, p := range r.peers {
+		r.decay(p, now)
+		scores = append(scores, p.PeerScore)
+	}
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].Score != scores[j].Score {
+			return scores[i].Score < scores[j].Score
+		}
+		return scores[i].ID < scores[j].ID
+	})
+	return scores
+}
+
+func (r *reputation) decay(p *peerReputation, now time.Time) {
+	if elapsed := now.Sub(p.updated); elapsed > 0 && r.halfLife > 0 {
+		p.Score *= math.Pow(0.5, float64(elapsed)/float64(r.halfLife))
+	}
+	p.updated = now
+}
+
+// prune removes the peers not banned whose scores have recovered
+func (r *reputation) prune(now time.Time) {
+	for id, p := range r.peers {
+		r.decay(p, now)
+		if !p.BannedUntil.After(now) && p.Score > -1 {
+			delete(r.peers, id)
+		}
+	}
+}
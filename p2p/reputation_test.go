@@ -0,0 +1,72 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReputation(t *testing.T) {
+	require := require.New(t)
+	now := time.Unix(1000, 0)
+	r := newReputation(100, time.Hour, 10*time.Minute)
+
+	require.False(r.Report("peer1", PeerInvalidBlock, now))
+	require.False(r.Report("peer1", PeerProtocolViolation, now))
+	require.False(r.Report("peer2", PeerTimeout, now))
+	require.False(r.Report("peer2", PeerEvent(100), now))
+	require.False(r.Banned("peer1", now))
+	require.Equal([]PeerScore{
+		{ID: "peer1", Score: -70, InvalidBlocks: 1, Violations: 1},
+		{ID: "peer2", Score: -10, Timeouts: 1},
+	}, r.Scores(now))
+
+	// the score recovers by half in the half life
+	now = now.Add(10 * time.Minute)
+	scores := r.Scores(now)
+	require.InDelta(-35, scores[0].Score, 1e-9)
+	require.InDelta(-5, scores[1].Score, 1e-9)
+
+	// the peer is banned once the score falls to the threshold
+	require.False(r.Report("peer1", PeerInvalidBlock, now))
+	require.True(r.Report("peer1", PeerProtocolViolation, now))
+	require.True(r.Banned("peer1", now))
+	require.False(r.Report("peer1", PeerInvalidBlock, now))
+	require.Equal(now.Add(time.Hour), r.Scores(now)[0].BannedUntil)
+
+	// the score is reset once the ban expires
+	now = now.Add(time.Hour)
+	require.False(r.Banned("peer1", now))
+	scores = r.Scores(now)
+	require.Equal("peer2", scores[0].ID)
+	require.Equal(PeerScore{ID: "peer1", InvalidBlocks: 3, Violations: 2}, scores[1])
+}
+
+func TestAgentReportPeer(t *testing.T) {
+	require := require.New(t)
+	cfg := DefaultConfig
+	cfg.PeerBanThreshold = 50
+	a := NewAgent(cfg, 1, hash.ZeroHash256, nil, nil, nil).(*agent)
+	a.ReportPeer("peer1", PeerTimeout)
+	require.False(a.banned("peer1"))
+	a.ReportPeer("peer1", PeerInvalidBlock)
+	require.True(a.banned("peer1"))
+	scores := a.PeerScores()
+	require.Len(scores, 1)
+	require.Equal(uint64(1), scores[0].Timeouts)
+	require.Equal(uint64(1), scores[0].InvalidBlocks)
+
+	// peer scoring is disabled
+	cfg.PeerBanThreshold = 0
+	a = NewAgent(cfg, 1, hash.ZeroHash256, nil, nil, nil).(*agent)
+	a.ReportPeer("peer1", PeerInvalidBlock)
+	require.False(a.banned("peer1"))
+	require.Nil(a.PeerScores())
+}
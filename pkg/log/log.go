@@ -25,14 +25,36 @@ type GlobalConfig struct {
 	StderrRedirectFile *string      `json:"stderrRedirectFile" yaml:"stderrRedirectFile"`
 	RedirectStdLog     bool         `json:"stdLogRedirect" yaml:"stdLogRedirect"`
 	EcsIntegration     bool         `json:"ecsIntegration" yaml:"ecsIntegration"`
+	// ModuleLevels are the levels of the modules logging through the global logger, e.g., p2p: warn, the modules not
+	// set follow the level of the global logger
+	ModuleLevels map[string]zapcore.Level `json:"moduleLevels" yaml:"moduleLevels"`
 }
 
+type (
+	// module logs through the global logger with its own level
+	module struct {
+		level  zap.AtomicLevel
+		logger *zap.Logger
+	}
+
+	// levelCore filters the entries of the core by the level
+	levelCore struct {
+		zapcore.Core
+		level zapcore.LevelEnabler
+	}
+)
+
 var (
 	_globalCfg        GlobalConfig
 	_logMu            sync.RWMutex
 	_logServeMux      = http.NewServeMux()
 	_subLoggers       map[string]*zap.Logger
+	_levels           = map[string]zap.AtomicLevel{}
 	_globalLoggerName = "global"
+	// _globalCore is the core of the global logger without the level, shared by the modules
+	_globalCore zapcore.Core
+	_globalOpts []zap.Option
+	_modules    = map[string]*module{}
 )
 
 func init() {
@@ -47,6 +69,7 @@ func init() {
 	_logMu.Lock()
 	_globalCfg.Zap = &zapCfg
 	_subLoggers = make(map[string]*zap.Logger)
+	_levels[_globalLoggerName] = zapCfg.Level
 	_logMu.Unlock()
 	zap.ReplaceGlobals(l)
 }
@@ -57,15 +80,86 @@ func L() *zap.Logger { return zap.L() }
 // S wraps zap.S().
 func S() *zap.SugaredLogger { return zap.S() }
 
-// Logger returns logger of the given name
+// Logger returns the sub logger of the given name, or the logger of the module of the name logging through the global
+// logger if the sub logger is not configured
 func Logger(name string) *zap.Logger {
+	_logMu.RLock()
 	logger, ok := _subLoggers[name]
 	if !ok {
-		return L()
+		var m *module
+		if m, ok = _modules[name]; ok {
+			logger = m.logger
+		}
+	}
+	_logMu.RUnlock()
+	if !ok {
+		_logMu.Lock()
+		logger = moduleLogger(name).logger
+		_logMu.Unlock()
+	}
+	if logger == nil {
+		// the loggers are not initialized yet, the module logs through the global logger, which may be replaced
+		return L().Named(name)
 	}
 	return logger
 }
 
+// moduleLogger returns the module of the name, which is created with the level of the global logger if it doesn't
+// exist, the caller must hold the lock
+func moduleLogger(name string) *module {
+	if m, ok := _modules[name]; ok {
+		return m
+	}
+	m := &module{level: _levels[_globalLoggerName]}
+	m.build(name)
+	_modules[name] = m
+	return m
+}
+
+func (m *module) build(name string) {
+	if _globalCore == nil {
+		m.logger = nil
+		return
+	}
+	m.logger = zap.New(&levelCore{Core: _globalCore, level: m.level}, _globalOpts...).Named(name)
+}
+
+// Enabled returns true if the level is enabled
+func (c *levelCore) Enabled(lvl zapcore.Level) bool {
+	return c.level.Enabled(lvl)
+}
+
+// With adds the fields to the core
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelCore{Core: c.Core.With(fields), level: c.level}
+}
+
+// Check adds the core to the checked entry if the level is enabled
+func (c *levelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.level.Enabled(ent.Level) {
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
+
+// JSONEncoderConfig returns the config of the json encoder, of which the field names are stable for the log pipelines
+func JSONEncoderConfig() zapcore.EncoderConfig {
+	return zapcore.EncoderConfig{
+		TimeKey:        "ts",
+		LevelKey:       "level",
+		NameKey:        "logger",
+		CallerKey:      "caller",
+		FunctionKey:    zapcore.OmitKey,
+		MessageKey:     "msg",
+		StacktraceKey:  "stacktrace",
+		LineEnding:     zapcore.DefaultLineEnding,
+		EncodeLevel:    zapcore.LowercaseLevelEncoder,
+		EncodeTime:     zapcore.ISO8601TimeEncoder,
+		EncodeDuration: zapcore.SecondsDurationEncoder,
+		EncodeCaller:   zapcore.ShortCallerEncoder,
+	}
+}
+
 // InitLoggers initializes the global logger and other sub loggers.
 func InitLoggers(globalCfg GlobalConfig, subCfgs map[string]GlobalConfig, opts ...zap.Option) error {
 	if _, exists := subCfgs[_globalLoggerName]; exists {
@@ -79,13 +173,18 @@ func InitLoggers(globalCfg GlobalConfig, subCfgs map[string]GlobalConfig, opts .
 		if cfg.Zap == nil {
 			zapCfg := zap.NewProductionConfig()
 			cfg.Zap = &zapCfg
-		} else {
-			cfg.Zap.EncoderConfig = zap.NewProductionEncoderConfig()
 		}
+		cfg.Zap.EncoderConfig = JSONEncoderConfig()
 		if globalCfg.EcsIntegration {
 			cfg.Zap.EncoderConfig = ecszap.ECSCompatibleEncoderConfig(cfg.Zap.EncoderConfig)
 		}
 
+		// the level of the global logger is applied on top of the cores, which are shared by the modules with their
+		// own levels
+		var level zapcore.LevelEnabler = cfg.Zap.Level
+		if name == _globalLoggerName {
+			level = zapcore.DebugLevel
+		}
 		var cores []zapcore.Core
 		if cfg.StderrRedirectFile != nil {
 			stderrF, err := os.OpenFile(*cfg.StderrRedirectFile, os.O_WRONLY|os.O_CREATE|os.O_SYNC|os.O_APPEND, 0600)
@@ -96,7 +195,7 @@ func InitLoggers(globalCfg GlobalConfig, subCfgs map[string]GlobalConfig, opts .
 			cores = append(cores, zapcore.NewCore(
 				zapcore.NewJSONEncoder(cfg.Zap.EncoderConfig),
 				zapcore.AddSync(stderrF),
-				cfg.Zap.Level))
+				level))
 		}
 		switch cfg.Zap.Encoding {
 		case "console":
@@ -105,18 +204,20 @@ func InitLoggers(globalCfg GlobalConfig, subCfgs map[string]GlobalConfig, opts .
 			cores = append(cores, zapcore.NewCore(
 				zapcore.NewConsoleEncoder(consoleCfg.EncoderConfig),
 				zapcore.AddSync(os.Stdout),
-				cfg.Zap.Level))
+				level))
 		case "json":
-			cfg.Zap.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
 			cores = append(cores, zapcore.NewCore(
 				zapcore.NewJSONEncoder(cfg.Zap.EncoderConfig),
 				zapcore.AddSync(os.Stdout),
-				cfg.Zap.Level))
+				level))
 		default:
 			return errors.Errorf("unknown encoding: %s", cfg.Zap.Encoding)
 		}
 
 		core := zapcore.NewTee(cores...)
+		if name == _globalLoggerName {
+			core = &levelCore{Core: core, level: cfg.Zap.Level}
+		}
 		logger := zap.New(core, opts...)
 
 		_logMu.Lock()
@@ -127,18 +228,73 @@ func InitLoggers(globalCfg GlobalConfig, subCfgs map[string]GlobalConfig, opts .
 			}
 			zap.ReplaceGlobals(logger)
 			if err := initTraceLogger(logger, cfg.Trace); err != nil {
+				_logMu.Unlock()
 				return err
 			}
+			_globalCore, _globalOpts = core.(*levelCore).Core, opts
+			for mn, m := range _modules {
+				if _, ok := cfg.ModuleLevels[mn]; !ok {
+					m.level = cfg.Zap.Level
+				}
+			}
+			for mn, lvl := range cfg.ModuleLevels {
+				_modules[mn] = &module{level: zap.NewAtomicLevelAt(lvl)}
+			}
+			for mn, m := range _modules {
+				m.build(mn)
+			}
 		} else {
 			_subLoggers[name] = logger
 		}
 		_logServeMux.HandleFunc("/"+name, cfg.Zap.Level.ServeHTTP)
+		_levels[name] = cfg.Zap.Level
 		_logMu.Unlock()
 	}
 
 	return nil
 }
 
+// SetLevel changes the level of the logger or the module of the given name at runtime, the global logger if name is
+// empty
+func SetLevel(name, level string) error {
+	if name == "" {
+		name = _globalLoggerName
+	}
+	lvl, err := zapcore.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	_logMu.Lock()
+	defer _logMu.Unlock()
+	if atomicLevel, ok := _levels[name]; ok {
+		atomicLevel.SetLevel(lvl)
+		return nil
+	}
+	if _, ok := _modules[name]; !ok {
+		return errors.Errorf("unknown logger %s", name)
+	}
+	setModuleLevel(name, lvl)
+	return nil
+}
+
+// SetModuleLevel changes the level of the module at runtime, the module is created if it doesn't exist
+func SetModuleLevel(name string, level zapcore.Level) {
+	_logMu.Lock()
+	defer _logMu.Unlock()
+	setModuleLevel(name, level)
+}
+
+func setModuleLevel(name string, level zapcore.Level) {
+	m := moduleLogger(name)
+	if m.level == _levels[_globalLoggerName] {
+		// the module following the global logger has its own level from now on
+		m.level = zap.NewAtomicLevelAt(level)
+		m.build(name)
+		return
+	}
+	m.level.SetLevel(level)
+}
+
 // RegisterLevelConfigMux registers log's level config http mux.
 func RegisterLevelConfigMux(root *http.ServeMux) {
 	_logMu.Lock()
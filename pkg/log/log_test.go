@@ -0,0 +1,73 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestModuleLevels(t *testing.T) {
+	r := require.New(t)
+	_logMu.Lock()
+	globalCore, globalLevel, modules := _globalCore, _levels[_globalLoggerName], _modules
+	core, logs := observer.New(zapcore.DebugLevel)
+	level := zap.NewAtomicLevelAt(zap.InfoLevel)
+	_globalCore, _levels[_globalLoggerName], _modules = core, level, map[string]*module{}
+	_logMu.Unlock()
+	defer func() {
+		_logMu.Lock()
+		_globalCore, _levels[_globalLoggerName], _modules = globalCore, globalLevel, modules
+		_logMu.Unlock()
+	}()
+
+	// the modules follow the global level by default
+	Logger("p2p").Debug("p2p debug")
+	Logger("consensus").Info("consensus info")
+	r.Equal(1, logs.Len())
+	r.Equal("consensus", logs.All()[0].LoggerName)
+
+	// the module has its own level
+	r.NoError(SetLevel("p2p", "debug"))
+	Logger("p2p").Debug("p2p debug")
+	r.Equal(2, logs.Len())
+	r.Equal("p2p", logs.All()[1].LoggerName)
+	level.SetLevel(zap.WarnLevel)
+	Logger("p2p").Debug("p2p debug")
+	Logger("consensus").Info("consensus info")
+	r.Equal(3, logs.Len())
+
+	SetModuleLevel("blockchain", zap.ErrorLevel)
+	Logger("blockchain").Warn("blockchain warn")
+	Logger("blockchain").With(zap.Int("height", 1)).Error("blockchain error")
+	r.Equal(4, logs.Len())
+	r.EqualValues(1, logs.All()[3].ContextMap()["height"])
+
+	r.ErrorContains(SetLevel("unknown", "debug"), "unknown logger")
+	r.Error(SetLevel("p2p", "verbose"))
+}
+
+func TestJSONEncoderConfig(t *testing.T) {
+	r := require.New(t)
+	buf := &bytes.Buffer{}
+	logger := zap.New(zapcore.NewCore(zapcore.NewJSONEncoder(JSONEncoderConfig()), zapcore.AddSync(buf), zap.DebugLevel)).Named("p2p")
+	logger.Info("message", zap.Duration("latency", time.Second))
+	fields := map[string]any{}
+	r.NoError(json.Unmarshal(buf.Bytes(), &fields))
+	r.Equal("info", fields["level"])
+	r.Equal("p2p", fields["logger"])
+	r.Equal("message", fields["msg"])
+	r.EqualValues(1, fields["latency"])
+	_, err := time.Parse("2006-01-02T15:04:05.000Z0700", fields["ts"].(string))
+	r.NoError(err)
+}
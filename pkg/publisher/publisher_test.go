@@ -0,0 +1,222 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package publisher
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	r := require.New(t)
+	_, err := New(Config{Sink: SinkHTTP})
+	r.ErrorContains(err, "empty url")
+	_, err = New(Config{Sink: "kafka", URL: "localhost:9092"})
+	r.ErrorContains(err, "unsupported sink")
+	_, err = New(Config{Sink: SinkNATS, URL: "nats://localhost:4222,http://localhost:4222"})
+	r.ErrorContains(err, "invalid nats url")
+	_, err = New(Config{Sink: SinkHTTP, URL: "https://localhost", TLS: TLSConfig{CACert: filepath.Join(t.TempDir(), "ca.pem")}})
+	r.ErrorContains(err, "failed to read the ca certificate")
+}
+
+func TestValidateTopic(t *testing.T) {
+	r := require.New(t)
+	for _, topic := range []string{"iotex", "iotex.actions", "iotex-mainnet.block_logs", "A.1"} {
+		r.NoError(ValidateTopic(topic))
+	}
+	for _, topic := range []string{
+		"",
+		"iotex.",
+		".iotex",
+		"iotex..actions",
+		"iotex actions",
+		"iotex\tactions",
+		"iotex.actions\r\nPUB evil 2",
+		"iotex.*",
+		"iotex.>",
+		"iotex/actions",
+		strings.Repeat("a", 250),
+	} {
+		r.ErrorIs(ValidateTopic(topic), ErrInvalidTopic, topic)
+	}
+}
+
+func TestHTTPPublisher(t *testing.T) {
+	r := require.New(t)
+	var (
+		status = http.StatusOK
+		header http.Header
+		body   []byte
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		header = req.Header
+		body, _ = io.ReadAll(req.Body)
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	p, err := New(Config{Sink: SinkHTTP, URL: srv.URL, Timeout: time.Second})
+	r.NoError(err)
+	defer p.Close()
+	r.NoError(p.Publish(context.Background(), "blocks", "1", []byte(`{"height":1}`)))
+	r.Equal("blocks", header.Get("X-Iotex-Topic"))
+	r.Equal("1", header.Get("X-Iotex-Key"))
+	r.Equal(`{"height":1}`, string(body))
+	r.NoError(p.Flush(context.Background()))
+	// the topic could not inject into the headers
+	body = nil
+	r.ErrorIs(p.Publish(context.Background(), "blocks\r\nX-Evil: 1", "1", []byte(`{}`)), ErrInvalidTopic)
+	r.Nil(body)
+	status = http.StatusServiceUnavailable
+	r.ErrorContains(p.Publish(context.Background(), "blocks", "1", []byte(`{}`)), "status 503")
+}
+
+func TestTLS(t *testing.T) {
+	r := require.New(t)
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer srv.Close()
+	ca := filepath.Join(t.TempDir(), "ca.pem")
+	r.NoError(os.WriteFile(ca, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}), 0600))
+
+	p, err := New(Config{Sink: SinkHTTP, URL: srv.URL, Timeout: time.Second})
+	r.NoError(err)
+	r.ErrorContains(p.Publish(context.Background(), "blocks", "1", []byte(`{}`)), "certificate")
+	r.NoError(p.Close())
+	// the sink is verified by the ca configured
+	p, err = New(Config{Sink: SinkHTTP, URL: srv.URL, Timeout: time.Second, TLS: TLSConfig{CACert: ca}})
+	r.NoError(err)
+	r.NoError(p.Publish(context.Background(), "blocks", "1", []byte(`{}`)))
+	r.NoError(p.Close())
+}
+
+func TestKafkaRESTPublisher(t *testing.T) {
+	r := require.New(t)
+	var (
+		paths   []string
+		records []kafkaRecords
+		resp    = `{"offsets":[{"partition":0,"offset":1}]}`
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		paths = append(paths, req.URL.Path)
+		r.Equal("application/vnd.kafka.json.v2+json", req.Header.Get("Content-Type"))
+		var rs kafkaRecords
+		r.NoError(json.NewDecoder(req.Body).Decode(&rs))
+		records = append(records, rs)
+		_, _ = w.Write([]byte(resp))
+	}))
+	defer srv.Close()
+
+	p, err := New(Config{Sink: SinkKafkaREST, URL: srv.URL + "/", Timeout: time.Second})
+	r.NoError(err)
+	defer p.Close()
+	ctx := context.Background()
+	r.NoError(p.Publish(ctx, "iotex.actions", "1", []byte(`{"height":1}`)))
+	r.NoError(p.Publish(ctx, "iotex.logs", "1", []byte(`{"index":0}`)))
+	r.NoError(p.Publish(ctx, "iotex.actions", "2", []byte(`{"height":2}`)))
+	r.ErrorIs(p.Publish(ctx, "iotex actions", "3", []byte(`{}`)), ErrInvalidTopic)
+	r.ErrorContains(p.Publish(ctx, "iotex.actions", "3", []byte(`{`)), "invalid json")
+	r.Empty(paths)
+	// the messages of a topic are produced in a request
+	r.NoError(p.Flush(ctx))
+	r.Equal([]string{"/topics/iotex.actions", "/topics/iotex.logs"}, paths)
+	r.Len(records[0].Records, 2)
+	r.Equal("1", records[0].Records[0].Key)
+	r.JSONEq(`{"height":1}`, string(records[0].Records[0].Value))
+	r.Equal("2", records[0].Records[1].Key)
+	r.Len(records[1].Records, 1)
+	// nothing is left to flush
+	r.NoError(p.Flush(ctx))
+	r.Len(paths, 2)
+
+	resp = `{"offsets":[{"partition":null,"offset":null,"error_code":40403,"error":"topic not found"}]}`
+	r.NoError(p.Publish(ctx, "iotex.actions", "1", []byte(`{}`)))
+	r.ErrorContains(p.Flush(ctx), "topic not found")
+}
+
+// newNATSServer starts a minimal nats server accepting the messages, which rejects the subject "bad"
+func newNATSServer(t *testing.T) (string, <-chan string) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+	msgs := make(chan string, 10)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				_, _ = conn.Write([]byte(`INFO {"server_id":"test","max_payload":1048576}` + "\r\n"))
+				reader := bufio.NewReader(conn)
+				for {
+					line, err := reader.ReadString('\n')
+					if err != nil {
+						return
+					}
+					fields := strings.Fields(line)
+					if len(fields) == 0 {
+						continue
+					}
+					switch fields[0] {
+					case "PUB":
+						n, _ := strconv.Atoi(fields[len(fields)-1])
+						payload := make([]byte, n+2)
+						if _, err := io.ReadFull(reader, payload); err != nil {
+							return
+						}
+						if fields[1] == "bad" {
+							_, _ = conn.Write([]byte("-ERR 'Permissions Violation for Publish to bad'\r\n"))
+							continue
+						}
+						msgs <- fields[1] + " " + string(payload[:n])
+					case "PING":
+						_, _ = conn.Write([]byte("PONG\r\n"))
+					}
+				}
+			}(conn)
+		}
+	}()
+	return "nats://" + ln.Addr().String(), msgs
+}
+
+func TestNATSPublisher(t *testing.T) {
+	r := require.New(t)
+	url, msgs := newNATSServer(t)
+	p, err := New(Config{Sink: SinkNATS, URL: url, Timeout: time.Second})
+	r.NoError(err)
+	defer p.Close()
+	ctx := context.Background()
+	r.NoError(p.Publish(ctx, "iotex.blocks", "1", []byte(`{"height":1}`)))
+	r.NoError(p.Publish(ctx, "iotex.blocks", "2", []byte(`{"height":2}`)))
+	r.NoError(p.Flush(ctx))
+	r.Equal(`iotex.blocks {"height":1}`, <-msgs)
+	r.Equal(`iotex.blocks {"height":2}`, <-msgs)
+	// the subject could not inject into the protocol
+	r.ErrorIs(p.Publish(ctx, "iotex.blocks 0\r\nPUB evil", "3", []byte(`{}`)), ErrInvalidTopic)
+	r.ErrorIs(p.Publish(ctx, "iotex.>", "3", []byte(`{}`)), ErrInvalidTopic)
+	// the rejection of the server is returned on flush
+	r.NoError(p.Publish(ctx, "bad", "3", []byte(`{}`)))
+	r.ErrorContains(p.Flush(ctx), "Permissions Violation")
+	r.NoError(p.Publish(ctx, "iotex.blocks", "4", []byte(`{"height":4}`)))
+	r.NoError(p.Flush(ctx))
+	r.Equal(`iotex.blocks {"height":4}`, <-msgs)
+	r.Empty(msgs)
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package itx
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+	"github.com/iotexproject/iotex-core/v2/pkg/routine"
+)
+
+var (
+	_diskFreeMtc = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "iotex_disk_free_bytes",
+			Help: "Free space of the volume of the db.",
+		},
+		[]string{"path"},
+	)
+	_diskSafeModeMtc = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "iotex_disk_safe_mode",
+			Help: "1 if the node is in the safe mode for the low disk space.",
+		},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(_diskFreeMtc)
+	prometheus.MustRegister(_diskSafeModeMtc)
+}
+
+// DiskMonitor checks the free space of the volumes of the dbs periodically. If the free space of any volume is below
+// the threshold, the node enters the safe mode, in which the commits of blocks are paused and the actions are
+// rejected, rather than corrupting the dbs when the disk is full. The node resumes once the free space of all the
+// volumes is back above the threshold by 10%
+type DiskMonitor struct {
+	paths    []string
+	warn     uint64
+	min      uint64
+	interval time.Duration
+	pauses   []Pauseable
+	// freeSpace returns the free space of the volume of the path
+	freeSpace func(string) (uint64, error)
+	task      *routine.RecurringTask
+	safeMode  atomic.Bool
+}
+
+// NewDiskMonitor creates a disk monitor of the paths, pausing the pauseables in the safe mode
+func NewDiskMonitor(paths []string, interval time.Duration, warn, min uint64, pauses ...Pauseable) *DiskMonitor {
+	return &DiskMonitor{
+		paths:     paths,
+		warn:      warn,
+		min:       min,
+		interval:  interval,
+		pauses:    pauses,
+		freeSpace: freeSpace,
+	}
+}
+
+// Start starts the disk monitor
+func (m *DiskMonitor) Start(ctx context.Context) error {
+	if m.interval == 0 || len(m.paths) == 0 {
+		return nil
+	}
+	m.check()
+	m.task = routine.NewRecurringTask(m.check, m.interval)
+	return m.task.Start(ctx)
+}
+
+// Stop stops the disk monitor
+func (m *DiskMonitor) Stop(ctx context.Context) error {
+	if m.task == nil {
+		return nil
+	}
+	return m.task.Stop(ctx)
+}
+
+// SafeMode returns true if the node is in the safe mode
+func (m *DiskMonitor) SafeMode() bool {
+	return m.safeMode.Load()
+}
+
+func (m *DiskMonitor) check() {
+	low, recovered := false, true
+	for _, path := range m.paths {
+		free, err := m.freeSpace(path)
+		if err != nil {
+			log.L().Warn("Failed to get the free disk space.", zap.String("path", path), zap.Error(err))
+			recovered = false
+			continue
+		}
+		_diskFreeMtc.WithLabelValues(path).Set(float64(free))
+		switch {
+		case free < m.min:
+			low = true
+			log.L().Error("Disk space is running out.", zap.String("path", path), zap.Uint64("free", free), zap.Uint64("min", m.min))
+		case free < m.warn:
+			log.L().Warn("Disk space is low.", zap.String("path", path), zap.Uint64("free", free), zap.Uint64("warn", m.warn))
+		}
+		if free < m.min+m.min/10 {
+			recovered = false
+		}
+	}
+	switch {
+	case low && !m.safeMode.Load():
+		log.L().Error("Enter the safe mode for the low disk space, the commits of blocks are paused and the actions are rejected.")
+		m.pause(true)
+	case recovered && m.safeMode.Load():
+		log.L().Info("Exit the safe mode, the disk space is recovered.")
+		m.pause(false)
+	}
+}
+
+func (m *DiskMonitor) pause(pause bool) {
+	for _, p := range m.pauses {
+		p.Pause(pause)
+	}
+	m.safeMode.Store(pause)
+	if pause {
+		_diskSafeModeMtc.Set(1)
+	} else {
+		_diskSafeModeMtc.Set(0)
+	}
+}
+
+// freeSpace returns the space available of the volume of the path, the path of a db may not exist before the db is
+// created, in which case the nearest existing parent is checked
+func freeSpace(path string) (uint64, error) {
+	path = filepath.Clean(path)
+	for {
+		if _, err := os.Stat(path); err == nil {
+			break
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			return 0, errors.Errorf("no existing parent of %s", path)
+		}
+		path = parent
+	}
+	fs := syscall.Statfs_t{}
+	if err := syscall.Statfs(path, &fs); err != nil {
+		return 0, err
+	}
+	return fs.Bavail * uint64(fs.Bsize), nil
+}
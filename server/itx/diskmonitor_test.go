@@ -0,0 +1,65 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package itx
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakePauseable struct {
+	paused bool
+}
+
+func (p *fakePauseable) Pause(pause bool) { p.paused = pause }
+
+func TestDiskMonitor(t *testing.T) {
+	r := require.New(t)
+	p1, p2 := &fakePauseable{}, &fakePauseable{}
+	free := map[string]uint64{"/data/chain.db": 100, "/ssd/trie.db": 100}
+	m := NewDiskMonitor([]string{"/data/chain.db", "/ssd/trie.db"}, time.Hour, 50, 10, p1, p2)
+	m.freeSpace = func(path string) (uint64, error) { return free[path], nil }
+	r.NoError(m.Start(context.Background()))
+	defer func() { r.NoError(m.Stop(context.Background())) }()
+	r.False(m.SafeMode())
+
+	// warning only
+	free["/ssd/trie.db"] = 20
+	m.check()
+	r.False(m.SafeMode())
+	r.False(p1.paused)
+
+	// enter the safe mode if any volume is below the threshold
+	free["/ssd/trie.db"] = 9
+	m.check()
+	r.True(m.SafeMode())
+	r.True(p1.paused)
+	r.True(p2.paused)
+
+	// stay in the safe mode until the free space is above the threshold by 10%
+	free["/ssd/trie.db"] = 10
+	m.check()
+	r.True(m.SafeMode())
+	free["/ssd/trie.db"] = 11
+	m.check()
+	r.False(m.SafeMode())
+	r.False(p1.paused)
+	r.False(p2.paused)
+
+	// disabled
+	m = NewDiskMonitor([]string{"/data/chain.db"}, 0, 50, 10, p1)
+	r.NoError(m.Start(context.Background()))
+	r.NoError(m.Stop(context.Background()))
+
+	// the nearest existing parent is checked for the db not created yet
+	n, err := freeSpace(filepath.Join(t.TempDir(), "not", "created", "chain.db"))
+	r.NoError(err)
+	r.Positive(n)
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package itx
+
+import (
+	"github.com/iotexproject/iotex-core/v2/actpool"
+	"github.com/iotexproject/iotex-core/v2/api"
+	"github.com/iotexproject/iotex-core/v2/chainservice"
+	"github.com/iotexproject/iotex-core/v2/config"
+	"github.com/iotexproject/iotex-core/v2/p2p"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+// registerDynamicSettings registers the settings applied at runtime once they are changed in the config files, the
+// changes of other settings are rejected by the reloader
+func registerDynamicSettings(r *config.Reloader, cs *chainservice.ChainService, apiServer *api.ServerV2, p2pAgent p2p.Agent) {
+	r.Register("log.zap.level", func(cfg config.Config) error {
+		if cfg.Log.Zap == nil {
+			return log.SetLevel("", "info")
+		}
+		return log.SetLevel("", cfg.Log.Zap.Level.String())
+	})
+	r.Register("subLogs.*.zap.level", func(cfg config.Config) error {
+		for name, sub := range cfg.SubLogs {
+			if sub.Zap == nil {
+				continue
+			}
+			if err := log.SetLevel(name, sub.Zap.Level.String()); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	r.Register("log.moduleLevels.*", func(cfg config.Config) error {
+		for name, level := range cfg.Log.ModuleLevels {
+			log.SetModuleLevel(name, level)
+		}
+		return nil
+	})
+	ap := cs.ActionPool()
+	setLimits := func(cfg config.Config) error {
+		ap.SetLimits(actpool.Limits{
+			MaxNumActsPerPool:  cfg.ActPool.MaxNumActsPerPool,
+			MaxGasLimitPerPool: cfg.ActPool.MaxGasLimitPerPool,
+			MaxNumActsPerAcct:  cfg.ActPool.MaxNumActsPerAcct,
+		})
+		return nil
+	}
+	r.Register("actPool.maxNumActsPerPool", setLimits)
+	r.Register("actPool.maxGasLimitPerPool", setLimits)
+	r.Register("actPool.maxNumActsPerAcct", setLimits)
+	r.Register("actPool.minGasPrice", func(cfg config.Config) error {
+		ap.SetMinGasPrice(cfg.ActPool.MinGasPrice())
+		return nil
+	})
+	if apiServer != nil {
+		r.Register("api.rateLimit", func(cfg config.Config) error {
+			apiServer.SetRateLimit(cfg.API.RateLimit)
+			return nil
+		})
+	}
+	r.Register("network.diversity.maxPeersPerGroup", func(cfg config.Config) error {
+		p2pAgent.SetMaxPeersPerGroup(cfg.Network.Diversity.MaxPeersPerGroup)
+		return nil
+	})
+}
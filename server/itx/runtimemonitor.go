@@ -0,0 +1,213 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package itx
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"math"
+	"runtime"
+	"runtime/debug"
+	"runtime/pprof"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+	"github.com/iotexproject/iotex-core/v2/pkg/routine"
+)
+
+const (
+	// _modulePrefix is the prefix of the functions of the packages of the node
+	_modulePrefix = "github.com/iotexproject/iotex-core/v2/"
+	// _otherSubsystem is the subsystem of the goroutines not running the code of the node
+	_otherSubsystem = "other"
+)
+
+var (
+	_heapMtc = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "iotex_runtime_heap_bytes",
+			Help: "Heap memory of the node.",
+		},
+		[]string{"type"},
+	)
+	_gcPauseMtc = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "iotex_runtime_gc_last_pause_seconds",
+			Help: "Duration of the last GC pause.",
+		},
+	)
+	_gcCountMtc = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "iotex_runtime_gc_count",
+			Help: "Number of the completed GC cycles.",
+		},
+	)
+	_memoryLimitMtc = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "iotex_runtime_memory_limit_bytes",
+			Help: "Soft memory limit of the runtime, 0 if unlimited.",
+		},
+	)
+	_goroutinesMtc = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "iotex_runtime_goroutines",
+			Help: "Number of the goroutines per subsystem.",
+		},
+		[]string{"subsystem"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(_heapMtc)
+	prometheus.MustRegister(_gcPauseMtc)
+	prometheus.MustRegister(_gcCountMtc)
+	prometheus.MustRegister(_memoryLimitMtc)
+	prometheus.MustRegister(_goroutinesMtc)
+}
+
+// RuntimeMonitor exports the heap, the GC pauses and the goroutines per subsystem periodically. If the memory limit
+// is set, it is applied as the soft memory limit of the runtime, so that the GC runs more often as the heap grows
+// close to the limit rather than the node being killed by OOM, and the warnings are logged once the memory used is
+// above the warn ratio of the limit
+type RuntimeMonitor struct {
+	interval    time.Duration
+	memoryLimit uint64
+	warnRatio   float64
+	prevLimit   int64
+	task        *routine.RecurringTask
+}
+
+// NewRuntimeMonitor creates a runtime monitor, 0 memory limit leaves the limit of the runtime unchanged
+func NewRuntimeMonitor(interval time.Duration, memoryLimit uint64, warnRatio float64) *RuntimeMonitor {
+	return &RuntimeMonitor{
+		interval:    interval,
+		memoryLimit: memoryLimit,
+		warnRatio:   warnRatio,
+	}
+}
+
+// Start starts the runtime monitor
+func (m *RuntimeMonitor) Start(ctx context.Context) error {
+	if m.memoryLimit > 0 {
+		m.prevLimit = debug.SetMemoryLimit(int64(min(m.memoryLimit, math.MaxInt64)))
+		log.L().Info("Set the soft memory limit.", zap.Uint64("limit", m.memoryLimit))
+	}
+	if m.interval == 0 {
+		return nil
+	}
+	m.check()
+	m.task = routine.NewRecurringTask(m.check, m.interval)
+	return m.task.Start(ctx)
+}
+
+// Stop stops the runtime monitor
+func (m *RuntimeMonitor) Stop(ctx context.Context) error {
+	if m.memoryLimit > 0 {
+		debug.SetMemoryLimit(m.prevLimit)
+	}
+	if m.task == nil {
+		return nil
+	}
+	return m.task.Stop(ctx)
+}
+
+func (m *RuntimeMonitor) check() {
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	_heapMtc.WithLabelValues("alloc").Set(float64(stats.HeapAlloc))
+	_heapMtc.WithLabelValues("inuse").Set(float64(stats.HeapInuse))
+	_heapMtc.WithLabelValues("idle").Set(float64(stats.HeapIdle))
+	_heapMtc.WithLabelValues("released").Set(float64(stats.HeapReleased))
+	_heapMtc.WithLabelValues("sys").Set(float64(stats.Sys))
+	_gcCountMtc.Set(float64(stats.NumGC))
+	if stats.NumGC > 0 {
+		_gcPauseMtc.Set(time.Duration(stats.PauseNs[(stats.NumGC+255)%256]).Seconds())
+	}
+	limit := debug.SetMemoryLimit(-1)
+	if limit == math.MaxInt64 {
+		_memoryLimitMtc.Set(0)
+	} else {
+		_memoryLimitMtc.Set(float64(limit))
+		// the memory limit covers all the memory managed by the runtime, except the released
+		if used := stats.Sys - stats.HeapReleased; m.warnRatio > 0 && float64(used) > m.warnRatio*float64(limit) {
+			log.L().Warn("Memory used is close to the limit.", zap.Uint64("used", used), zap.Int64("limit", limit))
+		}
+	}
+	_goroutinesMtc.Reset()
+	for subsystem, n := range goroutinesBySubsystem() {
+		_goroutinesMtc.WithLabelValues(subsystem).Set(float64(n))
+	}
+}
+
+// goroutinesBySubsystem counts the goroutines by the package of the node in which they run, e.g., blocksync or
+// api, the goroutines not running the code of the node are counted as other
+func goroutinesBySubsystem() map[string]int {
+	var buf bytes.Buffer
+	if err := pprof.Lookup("goroutine").WriteTo(&buf, 1); err != nil {
+		log.L().Warn("Failed to get the goroutine profile.", zap.Error(err))
+		return nil
+	}
+	return parseGoroutineProfile(&buf)
+}
+
+// parseGoroutineProfile parses the goroutine profile in the debug=1 format, in which the goroutines of the same stack
+// are grouped by a record led by the count, e.g., "3 @ 0x43e1 0x44a2", followed by the frames from the innermost,
+// e.g., "#	0x44a1	github.com/iotexproject/iotex-core/v2/blocksync.(*blockSyncer).sync+0x41	/blocksync/blocksync.go:10"
+func parseGoroutineProfile(profile *bytes.Buffer) map[string]int {
+	counts := map[string]int{}
+	var (
+		count     int
+		subsystem string
+	)
+	flush := func() {
+		if count == 0 {
+			return
+		}
+		if subsystem == "" {
+			subsystem = _otherSubsystem
+		}
+		counts[subsystem] += count
+		count, subsystem = 0, ""
+	}
+	scanner := bufio.NewScanner(profile)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.Contains(line, " @ "):
+			flush()
+			n, err := strconv.Atoi(strings.TrimSpace(line[:strings.Index(line, " @ ")]))
+			if err != nil {
+				continue
+			}
+			count = n
+		case strings.HasPrefix(line, "#") && count > 0 && subsystem == "":
+			fields := strings.Fields(line)
+			if len(fields) < 3 || !strings.HasPrefix(fields[2], _modulePrefix) {
+				continue
+			}
+			pkg := strings.TrimPrefix(fields[2], _modulePrefix)
+			// the subsystem is the top level package, e.g., blocksync for blocksync.(*blockSyncer).sync
+			if i := strings.IndexAny(pkg, "/."); i > 0 {
+				pkg = pkg[:i]
+			}
+			// the shared utilities, e.g., pkg/routine, are attributed to the callers
+			if pkg != "pkg" {
+				subsystem = pkg
+			}
+		case line == "":
+			flush()
+		}
+	}
+	flush()
+	return counts
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package itx
+
+import (
+	"bytes"
+	"context"
+	"runtime/debug"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuntimeMonitor(t *testing.T) {
+	r := require.New(t)
+	prev := debug.SetMemoryLimit(-1)
+	m := NewRuntimeMonitor(time.Hour, 1<<40, 0.9)
+	r.NoError(m.Start(context.Background()))
+	r.Equal(int64(1<<40), debug.SetMemoryLimit(-1))
+	r.NoError(m.Stop(context.Background()))
+	r.Equal(prev, debug.SetMemoryLimit(-1))
+
+	// the limit is left unchanged
+	m = NewRuntimeMonitor(0, 0, 0.9)
+	r.NoError(m.Start(context.Background()))
+	r.Equal(prev, debug.SetMemoryLimit(-1))
+	r.NoError(m.Stop(context.Background()))
+}
+
+func TestParseGoroutineProfile(t *testing.T) {
+	r := require.New(t)
+	profile := `goroutine profile: total 7
+3 @ 0x43e1 0x44a2 0x44b3
+#	0x43e0	runtime.gopark+0x40	/go/src/runtime/proc.go:398
+#	0x44a1	github.com/iotexproject/iotex-core/v2/blocksync.(*blockSyncer).sync+0x41	/src/blocksync/blocksync.go:10
+#	0x44b2	github.com/iotexproject/iotex-core/v2/server/itx.(*Server).Start+0x41	/src/server/itx/server.go:10
+
+2 @ 0x43e1 0x45a2 0x45b3
+#	0x43e0	runtime.gopark+0x40	/go/src/runtime/proc.go:398
+#	0x45a1	github.com/iotexproject/iotex-core/v2/pkg/routine.(*RecurringTask).Start.func1+0x41	/src/pkg/routine/recurringtask.go:10
+#	0x45b2	github.com/iotexproject/iotex-core/v2/api.(*coreService).Start+0x41	/src/api/coreservice.go:10
+
+1 @ 0x43e1 0x46a2
+#	0x43e0	runtime.gopark+0x40	/go/src/runtime/proc.go:398
+#	0x46a1	net/http.(*Server).Serve+0x41	/go/src/net/http/server.go:10
+
+1 @ 0x43e1
+#	0x43e0	runtime.gopark+0x40	/go/src/runtime/proc.go:398
+`
+	r.Equal(map[string]int{
+		"blocksync": 3,
+		"api":       2,
+		"other":     2,
+	}, parseGoroutineProfile(bytes.NewBufferString(profile)))
+
+	// the goroutines of the test are counted
+	counts := goroutinesBySubsystem()
+	r.NotZero(counts["other"] + counts["server"])
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/iotexproject/iotex-core/v2/action"
 	"github.com/iotexproject/iotex-core/v2/api"
+	"github.com/iotexproject/iotex-core/v2/chainevent"
 	"github.com/iotexproject/iotex-core/v2/chainservice"
 	"github.com/iotexproject/iotex-core/v2/config"
 	"github.com/iotexproject/iotex-core/v2/dispatcher"
@@ -32,6 +33,8 @@ import (
 	"github.com/iotexproject/iotex-core/v2/pkg/routine"
 	"github.com/iotexproject/iotex-core/v2/pkg/util/httputil"
 	"github.com/iotexproject/iotex-core/v2/server/itx/nodestats"
+	"github.com/iotexproject/iotex-core/v2/streamsink"
+	"github.com/iotexproject/iotex-core/v2/telemetry"
 )
 
 // Server is the iotex server instance containing all components.
@@ -43,16 +46,46 @@ type Server struct {
 	p2pAgent             p2p.Agent
 	dispatcher           dispatcher.Dispatcher
 	nodeStats            *nodestats.NodeStats
+	diskMonitor          *DiskMonitor
+	runtimeMonitor       *RuntimeMonitor
+	telemetry            *telemetry.Reporter
+	chainEvent           *chainevent.Notifier
+	streamSink           *streamsink.Sink
 	pauseMgr             *PauseMgr
 	initializedSubChains map[uint32]bool
 	mutex                sync.RWMutex
 	subModuleCancel      context.CancelFunc
+	reloader             *config.Reloader
+
+	upgradePauses  []Pauseable
+	upgradeOnce    sync.Once
+	upgrading      chan struct{}
+	shutdownMarker *ShutdownMarker
+}
+
+type (
+	// Option sets the server construction parameter
+	Option func(*options)
+
+	options struct {
+		configPaths []string
+		plugins     []string
+	}
+)
+
+// WithConfigReload enables reloading the config files of the paths on SIGHUP and through the admin api, which applies
+// the changes of the dynamic settings without restart
+func WithConfigReload(paths []string, plugins []string) Option {
+	return func(ops *options) {
+		ops.configPaths = paths
+		ops.plugins = plugins
+	}
 }
 
 // NewServer creates a new server
 // TODO clean up config, make root config contains network, dispatch and chainservice
-func NewServer(cfg config.Config) (*Server, error) {
-	return newServer(cfg, false)
+func NewServer(cfg config.Config, opts ...Option) (*Server, error) {
+	return newServer(cfg, false, opts...)
 }
 
 // NewInMemTestServer creates a test server in memory
@@ -60,7 +93,11 @@ func NewInMemTestServer(cfg config.Config) (*Server, error) { // notest
 	return newServer(cfg, true)
 }
 
-func newServer(cfg config.Config, testing bool) (*Server, error) {
+func newServer(cfg config.Config, testing bool, opts ...Option) (*Server, error) {
+	var ops options
+	for _, opt := range opts {
+		opt(&ops)
+	}
 	// TODO: move to a separate package
 	actionDeserializer := (&action.Deserializer{}).SetEvmNetworkID(cfg.Chain.EVMNetworkID)
 	// create dispatcher instance
@@ -110,6 +147,20 @@ func newServer(cfg config.Config, testing bool) (*Server, error) {
 	var cs *chainservice.ChainService
 	builder := chainservice.NewBuilder(cfg)
 	builder.SetP2PAgent(p2pAgent)
+	if !testing {
+		marker, err := consumeShutdownMarker(shutdownMarkerDir(cfg))
+		if err != nil {
+			return nil, err
+		}
+		if marker != nil {
+			log.L().Info("The node was shut down cleanly.",
+				zap.Uint64("height", marker.Height),
+				zap.String("version", marker.Version),
+				zap.Uint64("snapshotHeight", marker.SnapshotHeight),
+				zap.String("snapshotDir", marker.SnapshotDir))
+			builder.SetCleanShutdownHeight(marker.Height)
+		}
+	}
 	rpcStats := nodestats.NewAPILocalStats()
 	builder.SetRPCStats(rpcStats)
 	if testing {
@@ -122,10 +173,27 @@ func newServer(cfg config.Config, testing bool) (*Server, error) {
 	}
 	nodeStats := nodestats.NewNodeStats(rpcStats, cs.BlockSync(), p2pAgent)
 	pauseMgr := NewPauseMgr(cs.Blockchain(), cs)
-	apiServer, err := cs.NewAPIServer(cfg.API, len(cfg.Chain.HistoryIndexPath) > 0)
+	// the actpool rejects the actions in the safe mode, in addition to the paused chain
+	safeModePauses := []Pauseable{cs.Blockchain(), cs}
+	if ap, ok := cs.ActionPool().(Pauseable); ok {
+		safeModePauses = append(safeModePauses, ap)
+	}
+	diskMonitor := NewDiskMonitor(cfg.DBPaths(), cfg.System.DiskMonitorInterval, cfg.System.WarnFreeDiskSpace, cfg.System.MinFreeDiskSpace, safeModePauses...)
+	var (
+		reloader   *config.Reloader
+		apiOptions []api.Option
+	)
+	if len(ops.configPaths) > 0 {
+		reloader = config.NewReloader(cfg, ops.configPaths, ops.plugins)
+		apiOptions = append(apiOptions, api.WithConfigReloader(reloader))
+	}
+	apiServer, err := cs.NewAPIServer(cfg.API, len(cfg.Chain.HistoryIndexPath) > 0, apiOptions...)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create api server")
 	}
+	if reloader != nil {
+		registerDynamicSettings(reloader, cs, apiServer, p2pAgent)
+	}
 	if apiServer != nil {
 		apiServers[cs.ChainID()] = apiServer
 		if err := cs.Blockchain().AddSubscriber(apiServer); err != nil {
@@ -143,8 +211,16 @@ func newServer(cfg config.Config, testing bool) (*Server, error) {
 		chainservices:        chains,
 		apiServers:           apiServers,
 		nodeStats:            nodeStats,
+		diskMonitor:          diskMonitor,
+		runtimeMonitor:       NewRuntimeMonitor(cfg.System.RuntimeMonitorInterval, cfg.System.MemoryLimit, cfg.System.WarnMemoryRatio),
+		telemetry:            telemetry.NewReporter(cfg.Telemetry, cfg.Chain.ID, cs.Blockchain(), p2pAgent),
+		chainEvent:           chainevent.NewNotifier(cfg.ChainEvent, cfg.Chain.ID, cs.Blockchain()),
+		streamSink:           streamsink.NewSink(cfg.StreamSink, cs.BlockDAO(), cs.Blockchain()),
 		pauseMgr:             pauseMgr,
 		initializedSubChains: map[uint32]bool{},
+		reloader:             reloader,
+		upgradePauses:        safeModePauses,
+		upgrading:            make(chan struct{}),
 	}
 	// Setup sub-chain starter
 	// TODO: sub-chain infra should use main-chain API instead of protocol directly
@@ -174,12 +250,52 @@ func (s *Server) Start(ctx context.Context) error {
 	if err := s.nodeStats.Start(cctx); err != nil {
 		return errors.Wrap(err, "error when starting node stats")
 	}
+	if err := s.diskMonitor.Start(cctx); err != nil {
+		return errors.Wrap(err, "error when starting disk monitor")
+	}
+	if err := s.runtimeMonitor.Start(cctx); err != nil {
+		return errors.Wrap(err, "error when starting runtime monitor")
+	}
+	if err := s.telemetry.Start(cctx); err != nil {
+		return errors.Wrap(err, "error when starting telemetry")
+	}
+	if err := s.chainEvent.Start(cctx); err != nil {
+		return errors.Wrap(err, "error when starting chain event notifier")
+	}
+	if err := s.streamSink.Start(cctx); err != nil {
+		return errors.Wrap(err, "error when starting stream sink")
+	}
+	if s.reloader != nil {
+		if err := s.reloader.Start(cctx); err != nil {
+			return errors.Wrap(err, "error when starting config reloader")
+		}
+	}
 	return nil
 }
 
 // Stop stops the server
 func (s *Server) Stop(ctx context.Context) error {
 	defer s.subModuleCancel()
+	if s.reloader != nil {
+		if err := s.reloader.Stop(ctx); err != nil {
+			return errors.Wrap(err, "error when stopping config reloader")
+		}
+	}
+	if err := s.streamSink.Stop(ctx); err != nil {
+		return errors.Wrap(err, "error when stopping stream sink")
+	}
+	if err := s.chainEvent.Stop(ctx); err != nil {
+		return errors.Wrap(err, "error when stopping chain event notifier")
+	}
+	if err := s.telemetry.Stop(ctx); err != nil {
+		return errors.Wrap(err, "error when stopping telemetry")
+	}
+	if err := s.runtimeMonitor.Stop(ctx); err != nil {
+		return errors.Wrap(err, "error when stopping runtime monitor")
+	}
+	if err := s.diskMonitor.Stop(ctx); err != nil {
+		return errors.Wrap(err, "error when stopping disk monitor")
+	}
 	if err := s.nodeStats.Stop(ctx); err != nil {
 		return errors.Wrap(err, "error when stopping node stats")
 	}
@@ -201,6 +317,7 @@ func (s *Server) Stop(ctx context.Context) error {
 			return errors.Wrap(err, "error when stopping blockchain")
 		}
 	}
+	s.markCleanShutdown()
 	return nil
 }
 
@@ -307,6 +424,7 @@ func StartServer(ctx context.Context, svr *Server, probeSvr *probe.Server, cfg c
 		mux.Handle("/debug/pprof/trace", http.HandlerFunc(pprof.Trace))
 		mux.Handle("/pause", http.HandlerFunc(svr.pauseMgr.HandlePause))
 		mux.Handle("/unpause", http.HandlerFunc(svr.pauseMgr.HandleUnPause))
+		mux.Handle("/upgrade", http.HandlerFunc(svr.HandleUpgrade))
 
 		port := fmt.Sprintf(":%d", cfg.System.HTTPAdminPort)
 		adminserv = httputil.NewServer(port, mux)
@@ -329,7 +447,10 @@ func StartServer(ctx context.Context, svr *Server, probeSvr *probe.Server, cfg c
 		}()
 	}
 
-	<-ctx.Done()
+	select {
+	case <-ctx.Done():
+	case <-svr.Upgrading():
+	}
 	if err := probeSvr.TurnOff(); err != nil {
 		log.L().Panic("Failed to turn off probe server.", zap.Error(err))
 	}
@@ -0,0 +1,136 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package itx
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/config"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+	"github.com/iotexproject/iotex-core/v2/pkg/version"
+)
+
+// _shutdownMarkerFile is the file written at the clean shutdown of the upgrade
+const _shutdownMarkerFile = "CLEAN_SHUTDOWN"
+
+// ShutdownMarker records the state of the node at the clean shutdown of the upgrade, with which the restarted node
+// skips the recovery of the indexers
+type ShutdownMarker struct {
+	// Height is the tip height at the shutdown
+	Height uint64 `json:"height"`
+	// SnapshotHeight is the height of the latest state snapshot, 0 if there is no snapshot
+	SnapshotHeight uint64 `json:"snapshotHeight,omitempty"`
+	// SnapshotDir is the directory of the state snapshots
+	SnapshotDir string    `json:"snapshotDir,omitempty"`
+	Version     string    `json:"version"`
+	Time        time.Time `json:"time"`
+}
+
+// shutdownMarkerDir returns the directory of the shutdown marker, which is the data dir, or the directory of the
+// chain db if the data dir is not set
+func shutdownMarkerDir(cfg config.Config) string {
+	if cfg.System.DataDir != "" {
+		return cfg.System.DataDir
+	}
+	return filepath.Dir(cfg.Chain.ChainDBPath)
+}
+
+// writeShutdownMarker writes the marker into the directory, the marker is written into a temporary file and renamed
+// so that a partial marker is never read
+func writeShutdownMarker(dir string, marker *ShutdownMarker) error {
+	b, err := json.Marshal(marker)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return errors.Wrapf(err, "failed to create dir %s", dir)
+	}
+	path := filepath.Join(dir, _shutdownMarkerFile)
+	if err := os.WriteFile(path+".tmp", b, 0600); err != nil {
+		return errors.Wrap(err, "failed to write shutdown marker")
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+// consumeShutdownMarker reads and removes the marker in the directory, nil if there is no marker. The marker is only
+// valid for the start right after the shutdown, so that a later crash isn't taken as a clean shutdown
+func consumeShutdownMarker(dir string) (*ShutdownMarker, error) {
+	path := filepath.Join(dir, _shutdownMarkerFile)
+	b, err := os.ReadFile(filepath.Clean(path))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read shutdown marker")
+	}
+	if err := os.Remove(path); err != nil {
+		return nil, errors.Wrap(err, "failed to remove shutdown marker")
+	}
+	marker := &ShutdownMarker{}
+	if err := json.Unmarshal(b, marker); err != nil {
+		return nil, errors.Wrap(err, "failed to parse shutdown marker")
+	}
+	return marker, nil
+}
+
+// Upgrade enters the upgrade mode, in which the node stops accepting the actions and committing the blocks, and
+// then shuts down, writing the clean shutdown marker once all the dbs are flushed and closed
+func (s *Server) Upgrade() {
+	s.upgradeOnce.Do(func() {
+		log.L().Info("Enter the upgrade mode, the node is shutting down.")
+		for _, p := range s.upgradePauses {
+			p.Pause(true)
+		}
+		marker := &ShutdownMarker{
+			Height:  s.rootChainService.Blockchain().TipHeight(),
+			Version: version.PackageVersion,
+		}
+		if ss := s.rootChainService.StateSync(); ss != nil {
+			if m := ss.Latest(); m != nil {
+				marker.SnapshotHeight = m.Height
+				marker.SnapshotDir = s.cfg.StateSync.SnapshotDir
+			}
+		}
+		s.shutdownMarker = marker
+		close(s.upgrading)
+	})
+}
+
+// Upgrading returns a channel closed once the node enters the upgrade mode, upon which the node should be stopped
+func (s *Server) Upgrading() <-chan struct{} {
+	return s.upgrading
+}
+
+// HandleUpgrade handles the request of the admin api to enter the upgrade mode
+func (s *Server) HandleUpgrade(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	s.Upgrade()
+	w.WriteHeader(http.StatusOK)
+}
+
+// markCleanShutdown writes the shutdown marker if the node is stopped for the upgrade
+func (s *Server) markCleanShutdown() {
+	if s.shutdownMarker == nil {
+		return
+	}
+	s.shutdownMarker.Time = time.Now()
+	dir := shutdownMarkerDir(s.cfg)
+	if err := writeShutdownMarker(dir, s.shutdownMarker); err != nil {
+		log.L().Error("Failed to write the shutdown marker.", zap.Error(err))
+		return
+	}
+	log.L().Info("Shut down cleanly for the upgrade.", zap.String("dir", dir), zap.Uint64("height", s.shutdownMarker.Height))
+}
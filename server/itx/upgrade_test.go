@@ -0,0 +1,56 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package itx
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/config"
+)
+
+func TestShutdownMarker(t *testing.T) {
+	r := require.New(t)
+	dir := filepath.Join(t.TempDir(), "data")
+
+	marker, err := consumeShutdownMarker(dir)
+	r.NoError(err)
+	r.Nil(marker)
+
+	expected := &ShutdownMarker{
+		Height:         100,
+		SnapshotHeight: 90,
+		SnapshotDir:    "/var/data/snapshot",
+		Version:        "v2.2.0",
+		Time:           time.Unix(1700000000, 0).UTC(),
+	}
+	r.NoError(writeShutdownMarker(dir, expected))
+	marker, err = consumeShutdownMarker(dir)
+	r.NoError(err)
+	r.Equal(expected, marker)
+
+	// the marker is consumed at the first start
+	marker, err = consumeShutdownMarker(dir)
+	r.NoError(err)
+	r.Nil(marker)
+
+	r.NoError(os.WriteFile(filepath.Join(dir, _shutdownMarkerFile), []byte("invalid"), 0600))
+	_, err = consumeShutdownMarker(dir)
+	r.ErrorContains(err, "failed to parse shutdown marker")
+}
+
+func TestShutdownMarkerDir(t *testing.T) {
+	r := require.New(t)
+	cfg := config.Default
+	cfg.Chain.ChainDBPath = "/data/chain.db"
+	r.Equal("/data", shutdownMarkerDir(cfg))
+	cfg.System.DataDir = "/iotex"
+	r.Equal("/iotex", shutdownMarkerDir(cfg))
+}
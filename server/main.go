@@ -97,10 +97,20 @@ func main() {
 		glog.Fatalln("Genesis hash is not set, call block.LoadGenesisHash() first")
 	}
 
-	cfg, err := config.New([]string{_overwritePath, _secretPath}, _plugins)
+	cfg, err := config.New([]string{_overwritePath, _secretPath}, _plugins, config.DoNotValidate)
 	if err != nil {
 		glog.Fatalln("Failed to new config.", zap.Error(err))
 	}
+	// all the issues of the config are reported at once, rather than failing at the first one
+	if report := config.Diagnose(cfg); len(report.Diagnostics) > 0 {
+		_, _ = fmt.Fprintln(os.Stderr, report)
+		if report.HasError() {
+			glog.Fatalln("Invalid config, see the report above.")
+		}
+	}
+	if err := config.InitDataDir(cfg); err != nil {
+		glog.Fatalln("Failed to init data dir.", zap.Error(err))
+	}
 	if err = initLogger(cfg); err != nil {
 		glog.Fatalln("Cannot config global logger, use default one: ", zap.Error(err))
 	}
@@ -153,7 +163,7 @@ func main() {
 		}()
 	}
 	// create and start the node
-	svr, err := itx.NewServer(cfg)
+	svr, err := itx.NewServer(cfg, itx.WithConfigReload([]string{_overwritePath, _secretPath}, _plugins))
 	if err != nil {
 		log.L().Fatal("Failed to create server.", zap.Error(err))
 	}
@@ -174,6 +184,14 @@ func main() {
 		}
 	}
 
+	go func() {
+		// the node stops as on the signal in the upgrade mode
+		<-svr.Upgrading()
+		select {
+		case stop <- syscall.SIGTERM:
+		default:
+		}
+	}()
 	itx.StartServer(ctx, svr, probeSvr, cfg)
 	close(stopped)
 	<-livenessCtx.Done()
@@ -0,0 +1,333 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package factory
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/db/batch"
+	"github.com/iotexproject/iotex-core/v2/db/trie"
+	"github.com/iotexproject/iotex-core/v2/db/trie/mptrie"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/v2/state"
+)
+
+type (
+	// StateProver generates the merkle proofs of the states
+	StateProver interface {
+		// StateProof returns the proof of the account, and the proofs of the storage keys if it is a contract
+		StateProof(uint64, hash.Hash160, []hash.Hash256) (*StateProof, error)
+	}
+
+	// StateProof is the proof of an account at a height
+	StateProof struct {
+		Height uint64
+		// Root is the root of the account trie at the height
+		Root []byte
+		// Account is nil if the account does not exist
+		Account       *state.Account
+		AccountProof  [][]byte
+		StorageProofs []*StorageProof
+	}
+
+	// StorageProof is the proof of a storage key against the storage root of the contract
+	StorageProof struct {
+		Key hash.Hash256
+		// Value is nil if the key does not exist
+		Value []byte
+		Proof [][]byte
+	}
+
+	// PruneEstimator estimates the space reclaimed by pruning the history of the state, without pruning it
+	PruneEstimator interface {
+		// EstimatePruning returns the estimate of each retention, which is the number of the most recent heights of
+		// which the history is kept
+		EstimatePruning([]uint64) ([]*PruneEstimate, error)
+	}
+
+	// PruneEstimate is the space reclaimed by pruning the history of the account trie below a height
+	PruneEstimate struct {
+		Retention  uint64
+		PruneBelow uint64
+		// Roots is the number of the roots of the pruned heights, Nodes is the number of the nodes not reachable from
+		// the roots of the kept heights, and Bytes is the size of the keys and values of them
+		Roots uint64
+		Nodes uint64
+		Bytes uint64
+		// TotalNodes and TotalBytes are the number and the size of all the nodes and roots of the account trie
+		TotalNodes uint64
+		TotalBytes uint64
+	}
+
+	// accountTrie is a merkle patricia trie of the accounts, the root of which at every height since the trie is
+	// enabled is kept to prove the accounts at the height. The accounts are updated after the block is committed,
+	// out of the consensus, and the nodes are never deleted, so the tries at the past heights stay intact
+	accountTrie struct {
+		dao       db.KVStore
+		nodeCache *mptrie.NodeCache
+	}
+
+	// appendOnlyKVStore is a trie.KVStore ignoring the deletes
+	appendOnlyKVStore struct {
+		trie.KVStore
+	}
+)
+
+func (kv *appendOnlyKVStore) Delete([]byte) error {
+	return nil
+}
+
+func newAccountTrie(dao db.KVStore, nodeCache *mptrie.NodeCache) *accountTrie {
+	return &accountTrie{dao: dao, nodeCache: nodeCache}
+}
+
+func accountTrieRootKey(height uint64) []byte {
+	return append([]byte(ArchiveTrieRootKey), byteutil.Uint64ToBytesBigEndian(height)...)
+}
+
+func isAccountKey(key []byte) bool {
+	return len(key) == len(hash.Hash160{})
+}
+
+func (at *accountTrie) rootAt(height uint64) ([]byte, error) {
+	root, err := at.dao.Get(ArchiveTrieNamespace, accountTrieRootKey(height))
+	switch errors.Cause(err) {
+	case nil:
+		return root, nil
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		return nil, errors.Wrapf(ErrNoArchiveData, "no account trie at height %d", height)
+	default:
+		return nil, err
+	}
+}
+
+func (at *accountTrie) open(kv db.KVStore, root []byte) (trie.Trie, error) {
+	kvStore, err := trie.NewKVStore(ArchiveTrieNamespace, kv)
+	if err != nil {
+		return nil, err
+	}
+	tr, err := mptrie.New(
+		mptrie.KVStoreOption(&appendOnlyKVStore{kvStore}),
+		mptrie.RootHashOption(root),
+		mptrie.AsyncOption(),
+		mptrie.NodeCacheOption(at.nodeCache),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := tr.Start(context.Background()); err != nil {
+		return nil, errors.Wrap(err, "failed to start account trie")
+	}
+	return tr, nil
+}
+
+// init builds the trie from the accounts at height if the trie is just enabled
+func (at *accountTrie) init(height uint64) error {
+	_, err := at.rootAt(height)
+	if errors.Cause(err) == ErrNoArchiveData {
+		return at.build(height)
+	}
+	return err
+}
+
+// build builds the trie at height from all the accounts in the state
+func (at *accountTrie) build(height uint64) error {
+	keys, values, err := at.dao.Filter(AccountKVNamespace, func(k, _ []byte) bool {
+		return isAccountKey(k)
+	}, nil, nil)
+	if err != nil && errors.Cause(err) != db.ErrBucketNotExist {
+		return errors.Wrap(err, "failed to read accounts")
+	}
+	flusher, err := db.NewKVStoreFlusher(at.dao, batch.NewCachedBatch())
+	if err != nil {
+		return err
+	}
+	tr, err := at.open(flusher.KVStoreWithBuffer(), nil)
+	if err != nil {
+		return err
+	}
+	for i := range keys {
+		if err := tr.Upsert(keys[i], values[i]); err != nil {
+			return err
+		}
+	}
+	return at.commit(flusher, tr, height)
+}
+
+// update applies the accounts updated in the block at height to the trie at the previous height, the trie is rebuilt
+// if the trie at the previous height is not available
+func (at *accountTrie) update(height uint64, keys [][]byte) error {
+	parent, err := at.rootAt(height - 1)
+	switch errors.Cause(err) {
+	case nil:
+	case ErrNoArchiveData:
+		return at.build(height)
+	default:
+		return err
+	}
+	flusher, err := db.NewKVStoreFlusher(at.dao, batch.NewCachedBatch())
+	if err != nil {
+		return err
+	}
+	tr, err := at.open(flusher.KVStoreWithBuffer(), parent)
+	if err != nil {
+		return err
+	}
+	for _, k := range keys {
+		v, err := at.dao.Get(AccountKVNamespace, k)
+		switch errors.Cause(err) {
+		case nil:
+			err = tr.Upsert(k, v)
+		case db.ErrNotExist:
+			if err = tr.Delete(k); errors.Cause(err) == trie.ErrNotExist {
+				err = nil
+			}
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to update account %x", k)
+		}
+	}
+	return at.commit(flusher, tr, height)
+}
+
+func (at *accountTrie) commit(flusher db.KVStoreFlusher, tr trie.Trie, height uint64) error {
+	root, err := tr.RootHash()
+	if err != nil {
+		return err
+	}
+	flusher.KVStoreWithBuffer().MustPut(ArchiveTrieNamespace, accountTrieRootKey(height), root)
+	return flusher.Flush()
+}
+
+// prove returns the root of the trie at height, the account and the proof of it
+func (at *accountTrie) prove(height uint64, key []byte) ([]byte, []byte, [][]byte, error) {
+	root, err := at.rootAt(height)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	tr, err := at.open(at.dao, root)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	value, proof, err := tr.(trie.Prover).Prove(key)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return root, value, proof, nil
+}
+
+// estimatePruning estimates the pruning of the history below tip+1-retention for each of the retentions. The nodes
+// are shared by the tries of the heights, so a node is reclaimed only if it is not reachable from any kept root. The
+// tries of the kept heights are walked from the tip down, so each node is visited once for all the retentions
+func (at *accountTrie) estimatePruning(tip uint64, retentions []uint64) ([]*PruneEstimate, error) {
+	var (
+		prefix                 = []byte(ArchiveTrieRootKey)
+		totalNodes, totalBytes uint64
+	)
+	// the nodes are only counted rather than read out, as there are far more nodes than roots
+	keys, values, err := at.dao.Filter(ArchiveTrieNamespace, func(k, v []byte) bool {
+		if len(k) == len(prefix)+8 && bytes.HasPrefix(k, prefix) {
+			return true
+		}
+		totalNodes++
+		totalBytes += uint64(len(k) + len(v))
+		return false
+	}, nil, nil)
+	if err != nil && errors.Cause(err) != db.ErrBucketNotExist {
+		return nil, errors.Wrap(err, "failed to read account trie")
+	}
+	// rootBytes[i] is the size of the roots before keys[i], which are in the ascending order of heights
+	rootBytes := make([]uint64, len(keys)+1)
+	for i := range keys {
+		rootBytes[i+1] = rootBytes[i] + uint64(len(keys[i])+len(values[i]))
+	}
+	totalNodes += uint64(len(keys))
+	totalBytes += rootBytes[len(keys)]
+	kvStore, err := trie.NewKVStore(ArchiveTrieNamespace, at.dao)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		visited      = make(map[string]struct{})
+		visitedBytes uint64
+		next         = len(keys) - 1
+		order        = make([]int, len(retentions))
+		ret          = make([]*PruneEstimate, len(retentions))
+	)
+	for i, r := range retentions {
+		if r == 0 {
+			return nil, errors.New("retention should be positive")
+		}
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		return retentions[order[i]] < retentions[order[j]]
+	})
+	for _, i := range order {
+		e := &PruneEstimate{Retention: retentions[i], TotalNodes: totalNodes, TotalBytes: totalBytes}
+		if retentions[i] <= tip {
+			e.PruneBelow = tip + 1 - retentions[i]
+		}
+		for ; next >= 0; next-- {
+			if byteutil.BytesToUint64BigEndian(keys[next][len(prefix):]) < e.PruneBelow {
+				break
+			}
+			if err := mptrie.Walk(kvStore, values[next], nil, func(h, node []byte) bool {
+				if _, ok := visited[string(h)]; ok {
+					return false
+				}
+				visited[string(h)] = struct{}{}
+				visitedBytes += uint64(len(h) + len(node))
+				return true
+			}); err != nil {
+				return nil, errors.Wrapf(err, "failed to walk account trie of root %x", values[next])
+			}
+		}
+		e.Roots = uint64(next + 1)
+		e.Nodes = totalNodes - uint64(len(keys)) - uint64(len(visited))
+		e.Bytes = rootBytes[next+1] + totalBytes - rootBytes[len(keys)] - visitedBytes
+		ret[i] = e
+	}
+	return ret, nil
+}
+
+// updatedAccounts returns the sorted keys of the accounts written by the working set store
+func updatedAccounts(store workingSetStore) [][]byte {
+	switch s := store.(type) {
+	case *stateDBWorkingSetStore:
+		var (
+			mutex sync.Mutex
+			keys  = make(map[string]struct{})
+		)
+		s.flusher.KVStoreWithBuffer().SerializeQueue(func(wi *batch.WriteInfo) []byte {
+			if wi.Namespace() == AccountKVNamespace && isAccountKey(wi.Key()) {
+				mutex.Lock()
+				keys[string(wi.Key())] = struct{}{}
+				mutex.Unlock()
+			}
+			return nil
+		}, nil)
+		ret := make([][]byte, 0, len(keys))
+		for k := range keys {
+			ret = append(ret, []byte(k))
+		}
+		sort.Slice(ret, func(i, j int) bool {
+			return bytes.Compare(ret[i], ret[j]) < 0
+		})
+		return ret
+	case *workingSetStoreWithSecondary:
+		return updatedAccounts(s.writer)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,234 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package factory
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol/execution/evm"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/db/batch"
+	"github.com/iotexproject/iotex-core/v2/db/trie"
+	"github.com/iotexproject/iotex-core/v2/db/trie/mptrie"
+	"github.com/iotexproject/iotex-core/v2/state"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+func TestStateProof(t *testing.T) {
+	require := require.New(t)
+	dao := db.NewMemKVStore()
+	require.NoError(dao.Start(context.Background()))
+	putAccount := func(addr hash.Hash160, balance int64, root hash.Hash256) {
+		acct, err := state.NewAccount()
+		require.NoError(err)
+		require.NoError(acct.AddBalance(big.NewInt(balance)))
+		if root != hash.ZeroHash256 {
+			acct.Root = root
+			acct.CodeHash = []byte("code")
+		}
+		v, err := state.Serialize(acct)
+		require.NoError(err)
+		require.NoError(dao.Put(AccountKVNamespace, addr[:], v))
+	}
+	a1, a2, c := identityset.Address(1).Bytes(), identityset.Address(2).Bytes(), identityset.Address(3).Bytes()
+	addr1, addr2, contract := hash.BytesToHash160(a1), hash.BytesToHash160(a2), hash.BytesToHash160(c)
+
+	// the storage of the contract
+	kvStore, err := trie.NewKVStore(evm.ContractKVNameSpace, dao)
+	require.NoError(err)
+	storage, err := mptrie.New(
+		mptrie.KVStoreOption(kvStore),
+		mptrie.KeyLengthOption(len(hash.Hash256{})),
+		mptrie.HashFuncOption(evm.StorageTrieHashFunc(contract)),
+	)
+	require.NoError(err)
+	require.NoError(storage.Start(context.Background()))
+	slot1, slot2 := hash.Hash256b([]byte("slot1")), hash.Hash256b([]byte("slot2"))
+	require.NoError(storage.Upsert(slot1[:], []byte("value1")))
+	storageRoot, err := storage.RootHash()
+	require.NoError(err)
+
+	// the trie is built from the accounts when enabled
+	putAccount(addr1, 100, hash.ZeroHash256)
+	putAccount(contract, 0, hash.BytesToHash256(storageRoot))
+	require.NoError(dao.Put(AccountKVNamespace, []byte(CurrentHeightKey), []byte{0}))
+	at := newAccountTrie(dao, nil)
+	require.NoError(at.init(5))
+	root5, err := at.rootAt(5)
+	require.NoError(err)
+	_, err = at.rootAt(4)
+	require.Equal(ErrNoArchiveData, errors.Cause(err))
+
+	putAccount(addr1, 50, hash.ZeroHash256)
+	putAccount(addr2, 50, hash.ZeroHash256)
+	require.NoError(at.update(6, [][]byte{a1, a2}))
+	// the trie is rebuilt if the previous height is missing
+	require.NoError(at.update(8, [][]byte{a1}))
+	_, err = at.rootAt(8)
+	require.NoError(err)
+
+	flusher, err := db.NewKVStoreFlusher(db.NewMemKVStore(), batch.NewCachedBatch())
+	require.NoError(err)
+	store := newStateDBWorkingSetStore(flusher, true)
+	require.NoError(store.Put(AccountKVNamespace, c, []byte("v")))
+	require.NoError(store.Put(AccountKVNamespace, a1, []byte("v")))
+	require.NoError(store.Put(AccountKVNamespace, []byte(CurrentHeightKey), []byte("v")))
+	require.NoError(store.Put(evm.ContractKVNameSpace, a2, []byte("v")))
+	require.NoError(store.Delete(AccountKVNamespace, a1))
+	keys := updatedAccounts(store)
+	require.ElementsMatch([][]byte{a1, c}, keys)
+	require.Negative(bytes.Compare(keys[0], keys[1]))
+
+	sdb := &stateDB{
+		dao:                newDaoRetrofitter(dao),
+		currentChainHeight: 8,
+	}
+	_, err = sdb.StateProof(6, addr1, nil)
+	require.Equal(ErrNotSupported, errors.Cause(err))
+	sdb.accountTrie = at
+	_, err = sdb.StateProof(9, addr1, nil)
+	require.Equal(ErrNoArchiveData, errors.Cause(err))
+	for _, e := range []struct {
+		height  uint64
+		addr    hash.Hash160
+		balance int64
+	}{
+		{5, addr1, 100},
+		{5, addr2, -1},
+		{6, addr1, 50},
+		{6, addr2, 50},
+	} {
+		sp, err := sdb.StateProof(e.height, e.addr, nil)
+		require.NoError(err)
+		root, err := at.rootAt(e.height)
+		require.NoError(err)
+		require.Equal(root, sp.Root)
+		v, err := mptrie.VerifyProof(sp.Root, e.addr[:], sp.AccountProof, nil)
+		require.NoError(err)
+		if e.balance < 0 {
+			require.Nil(sp.Account)
+			require.Nil(v)
+			continue
+		}
+		require.EqualValues(e.balance, sp.Account.Balance.Int64())
+		acct := &state.Account{}
+		require.NoError(acct.Deserialize(v))
+		require.Equal(sp.Account, acct)
+	}
+	// the proof at the previous height stays valid
+	sp, err := sdb.StateProof(5, addr1, nil)
+	require.NoError(err)
+	require.Equal(root5, sp.Root)
+
+	sp, err = sdb.StateProof(6, contract, []hash.Hash256{slot1, slot2})
+	require.NoError(err)
+	require.Equal(storageRoot, sp.Account.Root[:])
+	require.Len(sp.StorageProofs, 2)
+	for i, v := range [][]byte{[]byte("value1"), nil} {
+		p := sp.StorageProofs[i]
+		require.Equal(v, p.Value)
+		value, err := mptrie.VerifyProof(storageRoot, p.Key[:], p.Proof, evm.StorageTrieHashFunc(contract))
+		require.NoError(err)
+		require.Equal(v, value)
+	}
+}
+
+func TestEstimatePruning(t *testing.T) {
+	require := require.New(t)
+	dao := db.NewMemKVStore()
+	require.NoError(dao.Start(context.Background()))
+	putAccount := func(addr []byte, balance int64) {
+		acct, err := state.NewAccount()
+		require.NoError(err)
+		require.NoError(acct.AddBalance(big.NewInt(balance)))
+		v, err := state.Serialize(acct)
+		require.NoError(err)
+		require.NoError(dao.Put(AccountKVNamespace, addr, v))
+	}
+	at := newAccountTrie(dao, nil)
+	sdb := &stateDB{
+		dao:                newDaoRetrofitter(dao),
+		currentChainHeight: 7,
+	}
+	_, err := sdb.EstimatePruning([]uint64{1})
+	require.Equal(ErrNotSupported, errors.Cause(err))
+	sdb.accountTrie = at
+
+	// nothing to prune before the trie is built
+	es, err := sdb.EstimatePruning([]uint64{1})
+	require.NoError(err)
+	require.Equal(&PruneEstimate{Retention: 1, PruneBelow: 7}, es[0])
+
+	a1, a2, a3 := identityset.Address(1).Bytes(), identityset.Address(2).Bytes(), identityset.Address(3).Bytes()
+	putAccount(a1, 100)
+	putAccount(a2, 100)
+	require.NoError(at.init(4))
+	putAccount(a1, 50)
+	require.NoError(at.update(5, [][]byte{a1}))
+	putAccount(a3, 50)
+	require.NoError(at.update(6, [][]byte{a3}))
+	// the account is changed back, so the trie at 7 shares the nodes with the one at 5
+	putAccount(a1, 100)
+	require.NoError(dao.Delete(AccountKVNamespace, a3))
+	require.NoError(at.update(7, [][]byte{a1, a3}))
+
+	// the space reclaimed by keeping the tries at and above the height
+	expected := func(retention, height uint64) *PruneEstimate {
+		keys, values, err := dao.Filter(ArchiveTrieNamespace, func([]byte, []byte) bool { return true }, nil, nil)
+		require.NoError(err)
+		kvStore, err := trie.NewKVStore(ArchiveTrieNamespace, dao)
+		require.NoError(err)
+		e := &PruneEstimate{Retention: retention, PruneBelow: height, TotalNodes: uint64(len(keys))}
+		kept := make(map[string]struct{})
+		for h := height; h <= 7; h++ {
+			root, err := at.rootAt(h)
+			if errors.Cause(err) == ErrNoArchiveData {
+				continue
+			}
+			require.NoError(err)
+			kept[string(accountTrieRootKey(h))] = struct{}{}
+			require.NoError(mptrie.Walk(kvStore, root, nil, func(h, _ []byte) bool {
+				kept[string(h)] = struct{}{}
+				return true
+			}))
+		}
+		for i, k := range keys {
+			size := uint64(len(k) + len(values[i]))
+			e.TotalBytes += size
+			if _, ok := kept[string(k)]; ok {
+				continue
+			}
+			if len(k) == len(hash.Hash160{}) {
+				e.Nodes++
+			} else {
+				e.Roots++
+			}
+			e.Bytes += size
+		}
+		return e
+	}
+	es, err = sdb.EstimatePruning([]uint64{2, 100, 1, 3})
+	require.NoError(err)
+	require.Equal([]*PruneEstimate{expected(2, 6), expected(100, 0), expected(1, 7), expected(3, 5)}, es)
+	require.Zero(es[1].Bytes)
+	require.EqualValues(3, es[2].Roots)
+	require.NotZero(es[2].Nodes)
+	require.Less(es[3].Nodes, es[0].Nodes)
+	// the estimate is deterministic
+	es2, err := sdb.EstimatePruning([]uint64{2, 100, 1, 3})
+	require.NoError(err)
+	require.Equal(es, es2)
+
+	_, err = sdb.EstimatePruning([]uint64{0})
+	require.Error(err)
+}
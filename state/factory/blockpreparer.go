@@ -7,18 +7,39 @@ import (
 
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 
 	"github.com/iotexproject/iotex-core/v2/blockchain/block"
 	"github.com/iotexproject/iotex-core/v2/pkg/log"
 )
 
+var _draftBlockMtc = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "iotex_draft_blocks",
+		Help: "Draft blocks minted ahead of the proposals, by whether they are reused or discarded",
+	},
+	[]string{"result"},
+)
+
+func init() {
+	prometheus.MustRegister(_draftBlockMtc)
+}
+
 type (
+	// blockPreparer mints the draft blocks ahead of the proposals, e.g., the next proposer starts minting on the
+	// proposed block while the endorsements of it are being collected. The drafts are keyed by the previous block
+	// hash and the timestamp, and the drafts not built on the new tip are discarded once a block is received
 	blockPreparer struct {
-		tasks   map[hash.Hash256]map[int64]chan struct{}
+		tasks   map[hash.Hash256]map[int64]*mintTask
 		results map[hash.Hash256]map[int64]*mintResult
 		mu      sync.Mutex
 	}
+	mintTask struct {
+		done   chan struct{}
+		cancel context.CancelFunc
+		used   bool
+	}
 	mintResult struct {
 		blk *block.Block
 		err error
@@ -27,18 +48,18 @@ type (
 
 func newBlockPreparer() *blockPreparer {
 	return &blockPreparer{
-		tasks:   make(map[hash.Hash256]map[int64]chan struct{}),
+		tasks:   make(map[hash.Hash256]map[int64]*mintTask),
 		results: make(map[hash.Hash256]map[int64]*mintResult),
 	}
 }
 
-func (d *blockPreparer) PrepareOrWait(ctx context.Context, prevHash []byte, timestamp time.Time, fn func() (*block.Block, error)) (*block.Block, error) {
+func (d *blockPreparer) PrepareOrWait(ctx context.Context, prevHash []byte, timestamp time.Time, fn func(context.Context) (*block.Block, error)) (*block.Block, error) {
 	d.mu.Lock()
-	task := d.prepare(prevHash, timestamp, fn)
+	task := d.prepare(ctx, prevHash, timestamp, fn)
 	d.mu.Unlock()
 
 	select {
-	case <-task:
+	case <-task.done:
 	case <-ctx.Done():
 		var null *block.Block
 		return null, errors.Wrapf(ctx.Err(), "wait for draft block timeout %v", timestamp)
@@ -48,42 +69,70 @@ func (d *blockPreparer) PrepareOrWait(ctx context.Context, prevHash []byte, time
 	defer d.mu.Unlock()
 	if blks, ok := d.results[hash.BytesToHash256(prevHash)]; ok && blks[timestamp.UnixNano()] != nil {
 		res := blks[timestamp.UnixNano()]
+		task.used = true
 		return res.blk, res.err
 	}
 	return nil, errors.New("mint result not found")
 }
 
-func (d *blockPreparer) prepare(prevHash []byte, timestamp time.Time, mintFn func() (*block.Block, error)) chan struct{} {
+func (d *blockPreparer) prepare(ctx context.Context, prevHash []byte, timestamp time.Time, mintFn func(context.Context) (*block.Block, error)) *mintTask {
 	if forks, ok := d.tasks[hash.BytesToHash256(prevHash)]; ok {
-		if ch, ok := forks[timestamp.UnixNano()]; ok {
+		if task, ok := forks[timestamp.UnixNano()]; ok {
 			log.L().Debug("draft block already exists", log.Hex("prevHash", prevHash))
-			return ch
+			_draftBlockMtc.WithLabelValues("reused").Inc()
+			return task
 		}
 	} else {
-		d.tasks[hash.BytesToHash256(prevHash)] = make(map[int64]chan struct{})
+		d.tasks[hash.BytesToHash256(prevHash)] = make(map[int64]*mintTask)
+	}
+	// the minting is canceled if the draft is discarded
+	mintCtx, cancel := context.WithCancel(ctx)
+	task := &mintTask{
+		done:   make(chan struct{}),
+		cancel: cancel,
 	}
-	task := make(chan struct{})
 	d.tasks[hash.BytesToHash256(prevHash)][timestamp.UnixNano()] = task
 
 	go func() {
-		blk, err := mintFn()
+		defer cancel()
+		blk, err := mintFn(mintCtx)
 		d.mu.Lock()
-		if _, ok := d.results[hash.BytesToHash256(prevHash)]; !ok {
-			d.results[hash.BytesToHash256(prevHash)] = make(map[int64]*mintResult)
+		// the result of a discarded draft is dropped
+		if d.tasks[hash.BytesToHash256(prevHash)][timestamp.UnixNano()] == task {
+			if _, ok := d.results[hash.BytesToHash256(prevHash)]; !ok {
+				d.results[hash.BytesToHash256(prevHash)] = make(map[int64]*mintResult)
+			}
+			d.results[hash.BytesToHash256(prevHash)][timestamp.UnixNano()] = &mintResult{blk: blk, err: err}
 		}
-		d.results[hash.BytesToHash256(prevHash)][timestamp.UnixNano()] = &mintResult{blk: blk, err: err}
 		d.mu.Unlock()
-		close(task)
+		close(task.done)
 		log.L().Debug("prepare mint returned", zap.Error(err))
 	}()
 
 	return task
 }
 
+// ReceiveBlock discards the drafts which are not built on the received block, as the tip has changed
 func (d *blockPreparer) ReceiveBlock(blk *block.Block) error {
+	tip := blk.HashBlock()
 	d.mu.Lock()
-	delete(d.tasks, blk.PrevHash())
-	delete(d.results, blk.PrevHash())
-	d.mu.Unlock()
+	defer d.mu.Unlock()
+	for prevHash, forks := range d.tasks {
+		if prevHash == tip {
+			continue
+		}
+		for _, task := range forks {
+			task.cancel()
+			if !task.used {
+				_draftBlockMtc.WithLabelValues("discarded").Inc()
+			}
+		}
+		delete(d.tasks, prevHash)
+	}
+	for prevHash := range d.results {
+		if prevHash != tip {
+			delete(d.results, prevHash)
+		}
+	}
 	return nil
 }
@@ -23,14 +23,14 @@ func TestBlockPreparer_PrepareOrWait(t *testing.T) {
 	called := false
 
 	// Mock mint function
-	mintFn := func() (*block.Block, error) {
+	mintFn := func(context.Context) (*block.Block, error) {
 		if called {
 			return nil, errors.New("block already minted")
 		}
 		called = true
 		return mockBlk, nil
 	}
-	mintFn2 := func() (*block.Block, error) {
+	mintFn2 := func(context.Context) (*block.Block, error) {
 		return &block.Block{
 			Body: block.Body{
 				Actions: []*action.SealedEnvelope{},
@@ -67,7 +67,7 @@ func TestBlockPreparer_PrepareOrWait_Timeout(t *testing.T) {
 	timestamp := time.Now()
 
 	// Mock mint function that takes too long
-	mintFn := func() (*block.Block, error) {
+	mintFn := func(context.Context) (*block.Block, error) {
 		time.Sleep(2 * time.Second)
 		return &block.Block{}, nil
 	}
@@ -86,7 +86,7 @@ func TestBlockPreparer_ReceiveBlock(t *testing.T) {
 	timestamp := time.Now()
 
 	// Mock mint function
-	mintFn := func() (*block.Block, error) {
+	mintFn := func(context.Context) (*block.Block, error) {
 		builder := &block.TestingBuilder{}
 		blk, err := builder.SetPrevBlockHash(prevHash).SignAndBuild(identityset.PrivateKey(0))
 		return &blk, err
@@ -98,16 +98,52 @@ func TestBlockPreparer_ReceiveBlock(t *testing.T) {
 	blk, err := preparer.PrepareOrWait(ctx, prevHash[:], timestamp, mintFn)
 	require.NoError(t, err)
 
-	emptyblk := &block.Block{}
-	require.NoError(t, preparer.ReceiveBlock(emptyblk))
-	_, ok := preparer.tasks[prevHash]
-	require.True(t, ok)
-	_, ok = preparer.results[prevHash]
-	require.True(t, ok)
-
+	// the drafts built on the received block are kept
+	preparer.tasks[blk.HashBlock()] = map[int64]*mintTask{0: {done: make(chan struct{}), cancel: func() {}}}
 	require.NoError(t, preparer.ReceiveBlock(blk))
-	_, ok = preparer.tasks[prevHash]
+	_, ok := preparer.tasks[prevHash]
 	require.False(t, ok)
 	_, ok = preparer.results[prevHash]
 	require.False(t, ok)
+	_, ok = preparer.tasks[blk.HashBlock()]
+	require.True(t, ok)
+
+	emptyblk := &block.Block{}
+	require.NoError(t, preparer.ReceiveBlock(emptyblk))
+	require.Empty(t, preparer.tasks)
+	require.Empty(t, preparer.results)
+}
+
+func TestBlockPreparer_DiscardDraft(t *testing.T) {
+	require := require.New(t)
+	preparer := newBlockPreparer()
+	prevHash := hash.Hash256b([]byte("previousHash"))
+	timestamp := time.Now()
+
+	// the minting of the draft on a stale tip is canceled
+	canceled := make(chan struct{})
+	mintFn := func(ctx context.Context) (*block.Block, error) {
+		<-ctx.Done()
+		close(canceled)
+		return nil, ctx.Err()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	done := make(chan error)
+	go func() {
+		_, err := preparer.PrepareOrWait(ctx, prevHash[:], timestamp, mintFn)
+		done <- err
+	}()
+	require.Eventually(func() bool {
+		preparer.mu.Lock()
+		defer preparer.mu.Unlock()
+		return len(preparer.tasks) == 1
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(preparer.ReceiveBlock(&block.Block{}))
+	<-canceled
+	require.ErrorContains(<-done, "mint result not found")
+	preparer.mu.Lock()
+	defer preparer.mu.Unlock()
+	require.Empty(preparer.tasks)
+	require.Empty(preparer.results)
 }
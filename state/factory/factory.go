@@ -80,6 +80,11 @@ type (
 		StateReaderAt(blkHeight uint64, blkHash hash.Hash256) (protocol.StateReader, error)
 	}
 
+	// StateIterator is the factory iterating all the states, to take the snapshot of the states
+	StateIterator interface {
+		ForEachState(namespaces []string, fn func(ns string, k, v []byte) error) (uint64, error)
+	}
+
 	// factory implements StateFactory interface, tracks changes to account/contract and batch-commits to DB
 	factory struct {
 		lifecycle                lifecycle.Lifecycle
@@ -6,6 +6,7 @@
 package factory
 
 import (
+	"bytes"
 	"context"
 	"encoding/csv"
 	"encoding/hex"
@@ -37,6 +38,7 @@ import (
 	"github.com/iotexproject/iotex-core/v2/action/protocol/rolldpos"
 	"github.com/iotexproject/iotex-core/v2/action/protocol/staking"
 	"github.com/iotexproject/iotex-core/v2/action/protocol/vote/candidatesutil"
+	"github.com/iotexproject/iotex-core/v2/actpool"
 	"github.com/iotexproject/iotex-core/v2/blockchain"
 	"github.com/iotexproject/iotex-core/v2/blockchain/block"
 	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
@@ -899,6 +901,52 @@ func TestSDBLoadStoreHeightInMem(t *testing.T) {
 	testLoadStoreHeight(db, t)
 }
 
+func TestSDBForEachState(t *testing.T) {
+	require := require.New(t)
+	testDBPath, err := testutil.PathOfTempFile(_stateDBPath)
+	require.NoError(err)
+	defer testutil.CleanupPath(testDBPath)
+
+	cfg := DefaultConfig
+	cfg.Chain.TrieDBPath = testDBPath
+	cfg.Genesis = genesis.TestDefault()
+	registry := protocol.NewRegistry()
+	acc := account.NewProtocol(rewarding.DepositGas)
+	require.NoError(acc.Register(registry))
+	db2, err := db.CreateKVStore(db.DefaultConfig, cfg.Chain.TrieDBPath)
+	require.NoError(err)
+	sf, err := NewStateDB(cfg, db2, RegistryStateDBOption(registry), SkipBlockValidationStateDBOption())
+	require.NoError(err)
+	ctx := protocol.WithBlockCtx(genesis.WithGenesisContext(context.Background(), cfg.Genesis), protocol.BlockCtx{})
+	require.NoError(sf.Start(ctx))
+	defer func() {
+		require.NoError(sf.Stop(ctx))
+	}()
+
+	var (
+		namespaces = map[string]int{}
+		keys       [][]byte
+	)
+	height, err := sf.(StateIterator).ForEachState([]string{AccountKVNamespace, "notExist"}, func(ns string, k, v []byte) error {
+		namespaces[ns]++
+		keys = append(keys, append([]byte(nil), k...))
+		return nil
+	})
+	require.NoError(err)
+	require.Zero(height)
+	// the accounts of the initial balances and the height
+	require.Equal(map[string]int{AccountKVNamespace: len(cfg.Genesis.InitBalanceMap) + 1}, namespaces)
+	for i := 1; i < len(keys); i++ {
+		require.Equal(-1, bytes.Compare(keys[i-1], keys[i]))
+	}
+
+	errStop := errors.New("stop")
+	_, err = sf.(StateIterator).ForEachState([]string{AccountKVNamespace}, func(string, []byte, []byte) error {
+		return errStop
+	})
+	require.Equal(errStop, err)
+}
+
 func testLoadStoreHeight(sf Factory, t *testing.T) {
 	require := require.New(t)
 	ctx := genesis.WithGenesisContext(context.Background(), genesis.TestDefault())
@@ -1671,6 +1719,217 @@ func TestMintBlocksWithTransfers(t *testing.T) {
 	require.Equal(big.NewInt(30), accountC.Balance)
 }
 
+// unsponsoredProtocol fails to pay the gas of the actions of the sender
+type unsponsoredProtocol struct {
+	sender address.Address
+}
+
+func (p *unsponsoredProtocol) Handle(context.Context, action.Envelope, protocol.StateManager) (*action.Receipt, error) {
+	return nil, nil
+}
+
+func (p *unsponsoredProtocol) ReadState(context.Context, protocol.StateReader, []byte, ...[]byte) ([]byte, uint64, error) {
+	return nil, 0, nil
+}
+
+func (p *unsponsoredProtocol) Register(r *protocol.Registry) error {
+	return r.Register(p.Name(), p)
+}
+
+func (p *unsponsoredProtocol) ForceRegister(r *protocol.Registry) error {
+	return r.ForceRegister(p.Name(), p)
+}
+
+func (p *unsponsoredProtocol) Name() string {
+	return "unsponsored"
+}
+
+func (p *unsponsoredProtocol) PreHandle(ctx context.Context, _ action.Envelope, _ protocol.StateManager) error {
+	if address.Equal(protocol.MustGetActionCtx(ctx).Caller, p.sender) {
+		return errors.Wrap(protocol.ErrGasNotSponsored, "failed to pay the gas")
+	}
+	return nil
+}
+
+func TestMintBlocksWithUnsponsoredGas(t *testing.T) {
+	require := require.New(t)
+	testStateDBPath, err := testutil.PathOfTempFile(_stateDBPath)
+	require.NoError(err)
+	defer testutil.CleanupPath(testStateDBPath)
+
+	a, b := identityset.Address(28), identityset.Address(29)
+	cfg := DefaultConfig
+	cfg.Chain.TrieDBPath = testStateDBPath
+	cfg.Genesis.InitBalanceMap[a.String()] = "100"
+	cfg.Genesis.InitBalanceMap[b.String()] = "100"
+
+	registry := protocol.NewRegistry()
+	require.NoError(account.NewProtocol(rewarding.DepositGas).Register(registry))
+	require.NoError((&unsponsoredProtocol{sender: a}).Register(registry))
+	db2, err := db.CreateKVStoreWithCache(db.DefaultConfig, cfg.Chain.TrieDBPath, cfg.Chain.StateDBCacheSize)
+	require.NoError(err)
+	sdb, err := NewStateDB(cfg, db2, SkipBlockValidationStateDBOption(), RegistryStateDBOption(registry))
+	require.NoError(err)
+	ctx := protocol.WithBlockCtx(
+		genesis.WithGenesisContext(context.Background(), cfg.Genesis),
+		protocol.BlockCtx{},
+	)
+	require.NoError(sdb.Start(ctx))
+	defer func() {
+		require.NoError(sdb.Stop(ctx))
+	}()
+
+	apCfg := actpool.DefaultConfig
+	apCfg.MinGasPriceStr = "0"
+	ap, err := actpool.NewActPool(cfg.Genesis, sdb, apCfg)
+	require.NoError(err)
+	for _, sk := range []crypto.PrivateKey{identityset.PrivateKey(28), identityset.PrivateKey(29)} {
+		elp := (&action.EnvelopeBuilder{}).SetNonce(1).SetGasLimit(20000).
+			SetAction(action.NewTransfer(big.NewInt(10), identityset.Address(30).String(), nil)).Build()
+		selp, err := action.Sign(elp, sk)
+		require.NoError(err)
+		require.NoError(ap.Add(ctx, selp))
+	}
+
+	tip := protocol.TipInfo{Hash: hash.ZeroHash256}
+	for height := uint64(1); height <= 2; height++ {
+		mintCtx := protocol.WithFeatureCtx(protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+			BlockHeight: height,
+			Producer:    identityset.Address(27),
+			GasLimit:    testutil.TestGasLimit * 10,
+		}))
+		blk, err := sdb.Mint(protocol.WithBlockchainCtx(mintCtx, protocol.BlockchainCtx{
+			ChainID: 1,
+			Tip:     tip,
+		}), ap, identityset.PrivateKey(27))
+		require.NoError(err)
+		for _, selp := range blk.Actions {
+			// the action whose gas is not paid is never included
+			require.False(address.Equal(a, selp.SenderAddress()))
+		}
+		if height == 1 {
+			require.Len(blk.Actions, 1)
+		} else {
+			require.Empty(blk.Actions)
+		}
+		// the action is evicted instead of being retried in the next block
+		pending, err := ap.GetPendingNonce(a.String())
+		require.NoError(err)
+		require.EqualValues(1, pending)
+		require.Zero(len(ap.PendingActionMap()[a.String()]))
+		require.NoError(sdb.PutBlock(mintCtx, blk))
+		require.NoError(ap.ReceiveBlock(blk))
+		tip = protocol.TipInfo{Height: height, Hash: blk.HashBlock()}
+	}
+	require.Zero(ap.GetSize())
+}
+
+type bundleActPool struct {
+	actpool.ActPool
+	bundles []*actpool.Bundle
+}
+
+func (ap *bundleActPool) SubmitBundle(context.Context, *actpool.Bundle) (hash.Hash256, error) {
+	return hash.ZeroHash256, nil
+}
+
+func (ap *bundleActPool) PendingBundles(uint64) []*actpool.Bundle {
+	return ap.bundles
+}
+
+func TestMintBlocksWithBundles(t *testing.T) {
+	require := require.New(t)
+	testStateDBPath, err := testutil.PathOfTempFile(_stateDBPath)
+	require.NoError(err)
+	defer testutil.CleanupPath(testStateDBPath)
+
+	cfg := DefaultConfig
+	cfg.Chain.TrieDBPath = testStateDBPath
+	cfg.Genesis.InitBalanceMap[identityset.Address(28).String()] = "100"
+	cfg.Genesis.InitBalanceMap[identityset.Address(29).String()] = "50"
+	cfg.Genesis.InitBalanceMap[identityset.Address(30).String()] = "0"
+
+	registry := protocol.NewRegistry()
+	acc := account.NewProtocol(rewarding.DepositGas)
+	require.NoError(acc.Register(registry))
+
+	db2, err := db.CreateKVStoreWithCache(db.DefaultConfig, cfg.Chain.TrieDBPath, cfg.Chain.StateDBCacheSize)
+	require.NoError(err)
+	sdb, err := NewStateDB(cfg, db2, SkipBlockValidationStateDBOption(), RegistryStateDBOption(registry))
+	require.NoError(err)
+	ctx := protocol.WithBlockCtx(
+		genesis.WithGenesisContext(context.Background(), cfg.Genesis),
+		protocol.BlockCtx{},
+	)
+	require.NoError(sdb.Start(ctx))
+	defer func() {
+		require.NoError(sdb.Stop(ctx))
+	}()
+
+	a, b, c := identityset.Address(28), identityset.Address(29), identityset.Address(30)
+	transfer := func(to address.Address, amount int64, nonce uint64, priKey crypto.PrivateKey) *action.SealedEnvelope {
+		elp := (&action.EnvelopeBuilder{}).SetNonce(nonce).SetGasLimit(20000).
+			SetAction(action.NewTransfer(big.NewInt(amount), to.String(), nil)).Build()
+		selp, err := action.Sign(elp, priKey)
+		require.NoError(err)
+		return selp
+	}
+	ap := &bundleActPool{
+		ActPool: mock_actpool.NewMockActPool(gomock.NewController(t)),
+		bundles: []*actpool.Bundle{
+			// the bundle is reverted as the second transfer fails
+			{
+				Actions:      []*action.SealedEnvelope{transfer(b, 20, 1, identityset.PrivateKey(28)), transfer(c, 200, 2, identityset.PrivateKey(28))},
+				AllOrNothing: true,
+			},
+			// the failed transfer is skipped
+			{
+				Actions: []*action.SealedEnvelope{transfer(c, 10, 1, identityset.PrivateKey(29)), transfer(c, 1000, 2, identityset.PrivateKey(29))},
+			},
+		},
+	}
+	pending := transfer(b, 5, 1, identityset.PrivateKey(28))
+	ap.ActPool.(*mock_actpool.MockActPool).EXPECT().PendingActionMap().Return(map[string][]*action.SealedEnvelope{
+		a.String(): {pending},
+	}).Times(1)
+
+	ctx = protocol.WithFeatureCtx(protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+		BlockHeight: 1,
+		Producer:    identityset.Address(27),
+		GasLimit:    testutil.TestGasLimit * 10,
+	}))
+	blk, err := sdb.Mint(protocol.WithBlockchainCtx(ctx, protocol.BlockchainCtx{
+		ChainID: 1,
+		Tip:     protocol.TipInfo{Hash: hash.ZeroHash256},
+	}), ap, identityset.PrivateKey(27))
+	require.NoError(err)
+	// the bundles are included ahead of the pending actions
+	require.Len(blk.Actions, 2)
+	for i, selp := range []*action.SealedEnvelope{ap.bundles[1].Actions[0], pending} {
+		h, err := selp.Hash()
+		require.NoError(err)
+		blkActHash, err := blk.Actions[i].Hash()
+		require.NoError(err)
+		require.Equal(h, blkActHash)
+	}
+
+	ws, exist, err := sdb.(*stateDB).getFromWorkingSets(ctx, blk.HashBlock())
+	require.NoError(err)
+	require.True(exist)
+	for _, e := range []struct {
+		addr    address.Address
+		balance int64
+	}{
+		{a, 95},
+		{b, 45},
+		{c, 10},
+	} {
+		acct, err := accountutil.AccountState(ctx, ws, e.addr)
+		require.NoError(err)
+		require.Equal(big.NewInt(e.balance), acct.Balance)
+	}
+}
+
 func BenchmarkSDBInMemRunAction(b *testing.B) {
 	cfg := DefaultConfig
 	sdb, err := NewStateDB(cfg, db.NewMemKVStore(), SkipBlockValidationStateDBOption())
@@ -71,7 +71,7 @@ func (m *Minter) Mint(ctx context.Context, pk crypto.PrivateKey) (*block.Block,
 	bcCtx := protocol.MustGetBlockchainCtx(ctx)
 	blkCtx := protocol.MustGetBlockCtx(ctx)
 
-	return m.blockPreparer.PrepareOrWait(ctx, bcCtx.Tip.Hash[:], blkCtx.BlockTimeStamp, func() (*block.Block, error) {
+	return m.blockPreparer.PrepareOrWait(ctx, bcCtx.Tip.Hash[:], blkCtx.BlockTimeStamp, func(ctx context.Context) (*block.Block, error) {
 		return m.mint(ctx, pk)
 	})
 }
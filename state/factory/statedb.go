@@ -28,9 +28,12 @@ import (
 	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/v2/db"
 	"github.com/iotexproject/iotex-core/v2/db/batch"
+	"github.com/iotexproject/iotex-core/v2/db/trie"
+	"github.com/iotexproject/iotex-core/v2/db/trie/mptrie"
 	"github.com/iotexproject/iotex-core/v2/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/v2/pkg/log"
 	"github.com/iotexproject/iotex-core/v2/pkg/prometheustimer"
+	"github.com/iotexproject/iotex-core/v2/pkg/version"
 	"github.com/iotexproject/iotex-core/v2/state"
 )
 
@@ -50,11 +53,13 @@ type (
 		registry                 *protocol.Registry
 		dao                      daoRetrofitter
 		timerFactory             *prometheustimer.TimerFactory
-		workingsets              cache.LRUCache // lru cache for workingsets
+		workingsets              *workingSetCache
 		protocolViews            *protocol.Views
 		skipBlockValidationOnPut bool
 		ps                       *patchStore
 		erigonDB                 *erigonDB
+		accountTrie              *accountTrie
+		trieNodeCache            *mptrie.NodeCache
 	}
 )
 
@@ -88,7 +93,7 @@ func SkipBlockValidationStateDBOption() StateDBOption {
 // DisableWorkingSetCacheOption disable workingset cache
 func DisableWorkingSetCacheOption() StateDBOption {
 	return func(sdb *stateDB, cfg *Config) error {
-		sdb.workingsets = cache.NewDummyLruCache()
+		sdb.workingsets = newWorkingSetCache(cache.NewDummyLruCache(), cfg.Chain.WorkingSetPinLimit)
 		return nil
 	}
 }
@@ -100,7 +105,8 @@ func NewStateDB(cfg Config, dao db.KVStore, opts ...StateDBOption) (Factory, err
 		currentChainHeight: 0,
 		registry:           protocol.NewRegistry(),
 		protocolViews:      &protocol.Views{},
-		workingsets:        cache.NewThreadSafeLruCache(int(cfg.Chain.WorkingSetCacheSize)),
+		workingsets:        newWorkingSetCache(cache.NewThreadSafeLruCache(int(cfg.Chain.WorkingSetCacheSize)), cfg.Chain.WorkingSetPinLimit),
+		trieNodeCache:      mptrie.NewNodeCache(cfg.Chain.TrieNodeCacheSize),
 	}
 	for _, opt := range opts {
 		if err := opt(&sdb, &cfg); err != nil {
@@ -122,6 +128,9 @@ func NewStateDB(cfg Config, dao db.KVStore, opts ...StateDBOption) (Factory, err
 	if len(cfg.Chain.HistoryIndexPath) > 0 {
 		sdb.erigonDB = newErigonDB(cfg.Chain.HistoryIndexPath)
 	}
+	if cfg.Chain.EnableAccountTrie {
+		sdb.accountTrie = newAccountTrie(dao, sdb.trieNodeCache)
+	}
 
 	return &sdb, nil
 }
@@ -169,6 +178,11 @@ func (sdb *stateDB) Start(ctx context.Context) error {
 	default:
 		return err
 	}
+	if sdb.accountTrie != nil {
+		if err := sdb.accountTrie.init(sdb.currentChainHeight); err != nil {
+			return errors.Wrap(err, "failed to init account trie")
+		}
+	}
 	return nil
 }
 
@@ -224,7 +238,9 @@ func (sdb *stateDB) newWorkingSetWithKVStore(ctx context.Context, height uint64,
 	if err := views.Commit(ctx, sdb); err != nil {
 		return nil, err
 	}
-	return newWorkingSet(height, views, store, sdb), nil
+	ws := newWorkingSet(height, views, store, sdb)
+	ws.trieNodeCache = sdb.trieNodeCache
+	return ws, nil
 }
 
 func (sdb *stateDB) CreateWorkingSetStore(ctx context.Context, height uint64, kvstore db.KVStore) (workingSetStore, error) {
@@ -299,15 +315,24 @@ func (sdb *stateDB) Mint(
 	sdb.mutex.RUnlock()
 	switch {
 	case currHeight+1 < expectedBlockHeight:
-		parent, ok := sdb.workingsets.Get(bcCtx.Tip.Hash)
+		var (
+			parent *workingSet
+			ok     bool
+		)
+		parent, ok, err = sdb.workingsets.Get(bcCtx.Tip.Hash)
+		if err != nil {
+			return nil, err
+		}
 		if !ok {
 			return nil, errors.Wrapf(ErrNotSupported, "failed to create block at height %d, current height is %d", expectedBlockHeight, sdb.currentChainHeight)
 		}
-		ws, err = parent.(*workingSet).NewWorkingSet(ctx)
+		ws, err = parent.NewWorkingSet(ctx)
 	case currHeight+1 > expectedBlockHeight:
 		return nil, errors.Wrapf(ErrNotSupported, "cannot create block at height %d, current height is %d", expectedBlockHeight, sdb.currentChainHeight)
 	default:
-		ws, err = sdb.newWorkingSet(ctx, currHeight+1)
+		if ws = sdb.workingsets.TakePrewarmed(currHeight + 1); ws == nil {
+			ws, err = sdb.newWorkingSet(ctx, currHeight+1)
+		}
 	}
 	if err != nil {
 		return nil, err
@@ -319,6 +344,9 @@ func (sdb *stateDB) Mint(
 	if err != nil {
 		return nil, err
 	}
+	if fCtx := protocol.MustGetFeatureCtx(ctx); fCtx.EnableBlockExtraData && sdb.cfg.Chain.CommitBuildInfo {
+		blkBuilder.SetExtraData(block.NewBuildInfo(version.PackageVersion, fCtx.Hash()).Encode())
+	}
 
 	blk, err := blkBuilder.SignAndBuild(pk)
 	if err != nil {
@@ -404,11 +432,22 @@ func (sdb *stateDB) PutBlock(ctx context.Context, blk *block.Block) error {
 		)
 	}
 
+	var accounts [][]byte
+	if sdb.accountTrie != nil {
+		accounts = updatedAccounts(ws.store)
+	}
 	if err := ws.Commit(ctx); err != nil {
 		return err
 	}
 	sdb.protocolViews = ws.views
 	sdb.currentChainHeight = h
+	sdb.workingsets.Prune(h)
+	if sdb.accountTrie != nil {
+		// the account trie is out of the consensus, failing to update it does not fail the block
+		if err := sdb.accountTrie.update(h, accounts); err != nil {
+			log.L().Error("Failed to update account trie.", zap.Uint64("height", h), zap.Error(err))
+		}
+	}
 	return nil
 }
 
@@ -449,6 +488,31 @@ func (sdb *stateDB) States(opts ...protocol.StateOption) (uint64, state.Iterator
 	return sdb.currentChainHeight, iter, nil
 }
 
+// ForEachState iterates the states of the namespaces at the current height in the order of the keys, and returns the
+// height. The blocks can't be committed during the iteration
+func (sdb *stateDB) ForEachState(namespaces []string, fn func(ns string, k, v []byte) error) (uint64, error) {
+	sdb.mutex.RLock()
+	defer sdb.mutex.RUnlock()
+	kv := sdb.dao.atHeight(sdb.currentChainHeight)
+	for _, ns := range namespaces {
+		var err error
+		// the condition never matches, so that the states are streamed to fn instead of being collected
+		_, _, ferr := kv.Filter(ns, func(k, v []byte) bool {
+			if err == nil {
+				err = fn(ns, k, v)
+			}
+			return false
+		}, nil, nil)
+		if err != nil {
+			return 0, err
+		}
+		if ferr != nil && errors.Cause(ferr) != db.ErrNotExist && errors.Cause(ferr) != db.ErrBucketNotExist {
+			return 0, errors.Wrapf(ferr, "failed to iterate namespace %s", ns)
+		}
+	}
+	return sdb.currentChainHeight, nil
+}
+
 // ReadView reads the view
 func (sdb *stateDB) ReadView(name string) (protocol.View, error) {
 	return sdb.protocolViews.Read(name)
@@ -464,11 +528,12 @@ func (sdb *stateDB) StateReaderAt(blkHeight uint64, blkHash hash.Hash256) (proto
 	} else if blkHeight < curHeight {
 		return nil, errors.Wrapf(ErrNotSupported, "cannot read state at height %d, current height is %d", blkHeight, curHeight)
 	}
-	if data, ok := sdb.workingsets.Get(blkHash); ok {
-		if ws, ok := data.(*workingSet); ok {
-			return ws, nil
-		}
-		return nil, errors.New("type assertion failed to be WorkingSet")
+	ws, ok, err := sdb.workingsets.Get(blkHash)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return ws, nil
 	}
 	return nil, errors.Errorf("failed to get workingset at %x", blkHash)
 }
@@ -533,30 +598,131 @@ func (sdb *stateDB) createGenesisStates(ctx context.Context) error {
 
 // getFromWorkingSets returns (workingset, true) if it exists in a cache, otherwise generates new workingset and return (ws, false)
 func (sdb *stateDB) getFromWorkingSets(ctx context.Context, key hash.Hash256) (*workingSet, bool, error) {
-	if data, ok := sdb.workingsets.Get(key); ok {
-		if ws, ok := data.(*workingSet); ok {
-			// if it is already validated, return workingset
-			return ws, true, nil
-		}
-		return nil, false, errors.New("type assertion failed to be WorkingSet")
+	ws, ok, err := sdb.workingsets.Get(key)
+	if err != nil {
+		return nil, false, err
+	}
+	if ok {
+		// if it is already validated, return workingset
+		return ws, true, nil
 	}
 	sdb.mutex.RLock()
 	currHeight := sdb.currentChainHeight
 	sdb.mutex.RUnlock()
-	var (
-		tx  *workingSet
-		err error
-	)
-	tx, err = sdb.newWorkingSet(ctx, currHeight+1)
-	return tx, false, err
+	if ws = sdb.workingsets.TakePrewarmed(currHeight + 1); ws != nil {
+		return ws, false, nil
+	}
+	ws, err = sdb.newWorkingSet(ctx, currHeight+1)
+	return ws, false, err
 }
 
 func (sdb *stateDB) addWorkingSetIfNotExist(key hash.Hash256, ws *workingSet) (existed *workingSet) {
-	sdb.mutex.Lock()
-	defer sdb.mutex.Unlock()
-	if existed, ok := sdb.workingsets.Get(key); ok {
-		return existed.(*workingSet)
+	existed, err := sdb.workingsets.AddIfNotExist(key, ws)
+	if err != nil {
+		log.L().Error("Failed to add working set to cache", log.Hex("hash", key[:]), zap.Error(err))
+	}
+	return existed
+}
+
+// PinWorkingSet keeps the working set of the block in the cache until it is unpinned, or the chain passes its height
+func (sdb *stateDB) PinWorkingSet(key hash.Hash256) error {
+	return sdb.workingsets.Pin(key)
+}
+
+// UnpinWorkingSet returns the working set of the block to the lru cache, it returns false if not pinned
+func (sdb *stateDB) UnpinWorkingSet(key hash.Hash256) bool {
+	return sdb.workingsets.Unpin(key)
+}
+
+// PrewarmWorkingSet creates the working set of the next height, which is taken by the validation or the minting of
+// the next block instead of creating one on the spot
+func (sdb *stateDB) PrewarmWorkingSet(ctx context.Context) error {
+	sdb.mutex.RLock()
+	height := sdb.currentChainHeight + 1
+	sdb.mutex.RUnlock()
+	ws, err := sdb.newWorkingSet(ctx, height)
+	if err != nil {
+		return err
 	}
-	sdb.workingsets.Add(key, ws)
+	sdb.workingsets.SetPrewarmed(ws)
 	return nil
 }
+
+// StateProof returns the proof of the account at height against the account trie, and the proofs of the storage keys
+// against the storage root if the account is a contract
+func (sdb *stateDB) StateProof(height uint64, addr hash.Hash160, keys []hash.Hash256) (*StateProof, error) {
+	if sdb.accountTrie == nil {
+		return nil, errors.Wrap(ErrNotSupported, "account trie is not enabled")
+	}
+	sdb.mutex.RLock()
+	tip := sdb.currentChainHeight
+	sdb.mutex.RUnlock()
+	if height > tip {
+		return nil, errors.Wrapf(ErrNoArchiveData, "height %d is higher than current height %d", height, tip)
+	}
+	root, value, proof, err := sdb.accountTrie.prove(height, addr[:])
+	if err != nil {
+		return nil, err
+	}
+	sp := &StateProof{
+		Height:       height,
+		Root:         root,
+		AccountProof: proof,
+	}
+	if value == nil {
+		return sp, nil
+	}
+	sp.Account = &state.Account{}
+	if err := sp.Account.Deserialize(value); err != nil {
+		return nil, errors.Wrapf(err, "failed to deserialize account %x", addr)
+	}
+	if len(keys) == 0 || sp.Account.Root == hash.ZeroHash256 {
+		for _, k := range keys {
+			sp.StorageProofs = append(sp.StorageProofs, &StorageProof{Key: k})
+		}
+		return sp, nil
+	}
+	kvStore, err := trie.NewKVStore(evm.ContractKVNameSpace, sdb.dao.atHeight(height))
+	if err != nil {
+		return nil, err
+	}
+	tr, err := mptrie.New(
+		mptrie.KVStoreOption(kvStore),
+		mptrie.KeyLengthOption(len(hash.Hash256{})),
+		mptrie.HashFuncOption(evm.StorageTrieHashFunc(addr)),
+		mptrie.RootHashOption(sp.Account.Root[:]),
+		mptrie.NodeCacheOption(sdb.trieNodeCache),
+	)
+	if err != nil {
+		return nil, err
+	}
+	// the nodes of the storage trie are only kept at the current height in the state
+	if err := tr.Start(context.Background()); err != nil {
+		return nil, errors.Wrapf(err, "failed to load the storage of %x at height %d", addr, height)
+	}
+	for _, k := range keys {
+		v, proof, err := tr.(trie.Prover).Prove(k[:])
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to prove the storage of %x at height %d", addr, height)
+		}
+		sp.StorageProofs = append(sp.StorageProofs, &StorageProof{Key: k, Value: v, Proof: proof})
+	}
+	return sp, nil
+}
+
+// EstimatePruning estimates the space reclaimed by pruning the history of the account trie with each of the
+// retentions at the current height
+func (sdb *stateDB) EstimatePruning(retentions []uint64) ([]*PruneEstimate, error) {
+	if sdb.accountTrie == nil {
+		return nil, errors.Wrap(ErrNotSupported, "account trie is not enabled")
+	}
+	sdb.mutex.RLock()
+	tip := sdb.currentChainHeight
+	sdb.mutex.RUnlock()
+	return sdb.accountTrie.estimatePruning(tip, retentions)
+}
+
+// WorkingSetCacheEntries returns the working sets in the cache in the ascending order of heights
+func (sdb *stateDB) WorkingSetCacheEntries() []*WorkingSetCacheEntry {
+	return sdb.workingsets.Entries()
+}
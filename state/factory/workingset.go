@@ -25,12 +25,17 @@ import (
 	"github.com/iotexproject/iotex-core/v2/action"
 	"github.com/iotexproject/iotex-core/v2/action/protocol"
 	accountutil "github.com/iotexproject/iotex-core/v2/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/bridge"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/poll"
 	"github.com/iotexproject/iotex-core/v2/action/protocol/rewarding"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/vote/candidatesutil"
 	"github.com/iotexproject/iotex-core/v2/actpool"
 	"github.com/iotexproject/iotex-core/v2/actpool/actioniterator"
 	"github.com/iotexproject/iotex-core/v2/blockchain/block"
 	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/db/trie/mptrie"
 	"github.com/iotexproject/iotex-core/v2/pkg/log"
 	"github.com/iotexproject/iotex-core/v2/state"
 )
@@ -74,6 +79,7 @@ type (
 		finalized              bool
 		txValidator            *protocol.GenericValidator
 		receipts               []*action.Receipt
+		trieNodeCache          *mptrie.NodeCache
 	}
 )
 
@@ -181,6 +187,15 @@ func (ws *workingSet) runAction(
 	if err := ws.freshAccountConversion(ctx, &actCtx); err != nil {
 		return nil, err
 	}
+	if !action.IsSystemAction(selp) {
+		for _, p := range reg.All() {
+			if pp, ok := p.(protocol.PreActionHandler); ok {
+				if err := pp.PreHandle(ctx, selp.Envelope, ws); err != nil {
+					return nil, errors.Wrapf(err, "error when pre handle action %x", selpHash)
+				}
+			}
+		}
+	}
 	fCtx := protocol.MustGetFeatureCtx(ctx)
 	var receipt *action.Receipt
 	for _, actionHandler := range reg.All() {
@@ -199,10 +214,17 @@ func (ws *workingSet) runAction(
 	if receipt == nil {
 		return nil, errors.New("receipt is empty")
 	}
+	if receipt.GasBreakdown() == nil {
+		// the native actions consume the intrinsic gas, the rest is attributed to the execution
+		gb := &action.GasBreakdown{Intrinsic: min(actCtx.IntrinsicGas, receipt.GasConsumed)}
+		gb.Execution = receipt.GasConsumed - gb.Intrinsic
+		receipt.SetGasBreakdown(gb)
+	}
 	if fCtx.EnableBlobTransaction && len(selp.BlobHashes()) > 0 {
 		if err = ws.handleBlob(ctx, selp, receipt); err != nil {
 			return nil, err
 		}
+		receipt.GasBreakdown().Sidecar = receipt.BlobGasUsed
 	}
 	for _, p := range reg.All() {
 		if pp, ok := p.(protocol.PostActionHandler); ok {
@@ -217,7 +239,7 @@ func (ws *workingSet) runAction(
 func (ws *workingSet) handleBlob(ctx context.Context, act *action.SealedEnvelope, receipt *action.Receipt) error {
 	// Deposit blob fee
 	receipt.BlobGasUsed = act.BlobGas()
-	receipt.BlobGasPrice = protocol.CalcBlobFee(protocol.MustGetBlockchainCtx(ctx).Tip.ExcessBlobGas)
+	receipt.BlobGasPrice = protocol.CalcBlobFee(genesis.MustExtractGenesisContext(ctx).Blockchain, protocol.MustGetBlockCtx(ctx).BlockHeight, protocol.MustGetBlockchainCtx(ctx).Tip.ExcessBlobGas)
 	blobFee := new(big.Int).Mul(receipt.BlobGasPrice, new(big.Int).SetUint64(receipt.BlobGasUsed))
 	logs, err := rewarding.DepositGas(ctx, ws, new(big.Int), protocol.BlobGasFeeOption(blobFee))
 	if err != nil {
@@ -260,6 +282,18 @@ func (ws *workingSet) checkContract(ctx context.Context, to *common.Address) (bo
 	return sender.IsContract(), false, false, nil
 }
 
+// handleBlockEnd calls the block end handlers after all the actions of the block are run
+func (ws *workingSet) handleBlockEnd(ctx context.Context) error {
+	for _, p := range protocol.MustGetRegistry(ctx).All() {
+		if h, ok := p.(protocol.BlockEndHandler); ok {
+			if err := h.HandleBlockEnd(ctx, ws); err != nil {
+				return errors.Wrapf(err, "error when handle block end of protocol %s", p.Name())
+			}
+		}
+	}
+	return nil
+}
+
 func (ws *workingSet) finalize(ctx context.Context) error {
 	if ws.finalized {
 		return errors.New("Cannot finalize a working set twice")
@@ -557,6 +591,9 @@ func (ws *workingSet) process(ctx context.Context, actions []*action.SealedEnvel
 		updateReceiptIndex(receipts)
 	}
 	ws.receipts = receipts
+	if err := ws.handleBlockEnd(ctxWithBlockContext); err != nil {
+		return err
+	}
 	return ws.finalize(ctx)
 }
 
@@ -732,16 +769,36 @@ func (ws *workingSet) pickAndRunActions(
 		blkCtx              = protocol.MustGetBlockCtx(ctx)
 		fCtx                = protocol.MustGetFeatureCtx(ctx)
 		blobCnt             = uint64(0)
-		blobLimit           = params.MaxBlobGasPerBlock / params.BlobTxBlobGasPerBlob
 		deadline            *time.Time
 		fullGas             = blkCtx.GasLimit
+		g                   = genesis.MustExtractGenesisContext(ctx)
+		blobLimit           = g.MaxBlobGasPerBlock(blkCtx.BlockHeight) / params.BlobTxBlobGasPerBlob
 	)
 	if ap != nil {
 		if dl, ok := ctx.Deadline(); ok {
 			deadline = &dl
 		}
-		actionIterator := actioniterator.NewActionIterator(ap.PendingActionMap())
+		// the private bundles are included ahead of the pending actions
+		if bp, ok := ap.(actpool.BundlePool); ok {
+			for _, bundle := range bp.PendingBundles(blkCtx.BlockHeight) {
+				bundleReceipts, bundleActions, err := ws.runBundle(ctx, &blkCtx, bundle)
+				if err != nil {
+					return nil, err
+				}
+				receipts = append(receipts, bundleReceipts...)
+				executedActions = append(executedActions, bundleActions...)
+			}
+			ctxWithBlockContext = protocol.WithBlockCtx(ctx, blkCtx)
+		}
+		var iterOpts []actioniterator.Option
+		if optioner, ok := ap.(actpool.ActionIteratorOptioner); ok {
+			iterOpts = optioner.ActionIteratorOptions(ctx)
+		}
+		actionIterator := actioniterator.NewActionIterator(ap.PendingActionMap(), iterOpts...)
 		for {
+			if errors.Is(ctx.Err(), context.Canceled) {
+				return nil, errors.Wrap(ctx.Err(), "minting is canceled")
+			}
 			if deadline != nil && time.Now().After(*deadline) {
 				duration := time.Since(blkCtx.BlockTimeStamp)
 				log.L().Warn("Stop processing actions due to deadline, please consider increasing hardware", zap.Time("deadline", *deadline), zap.Duration("duration", duration), zap.Int("actions", len(executedActions)), zap.Uint64("gas", fullGas-blkCtx.GasLimit))
@@ -757,7 +814,7 @@ func (ws *workingSet) pickAndRunActions(
 				actionIterator.PopAccount()
 				continue
 			}
-			if blobCnt+uint64(len(nextAction.BlobHashes())) > uint64(blobLimit) {
+			if blobCnt+uint64(len(nextAction.BlobHashes())) > blobLimit {
 				actionIterator.PopAccount()
 				continue
 			}
@@ -804,7 +861,7 @@ func (ws *workingSet) pickAndRunActions(
 			case action.ErrGasLimit:
 				actionIterator.PopAccount()
 				continue
-			case action.ErrChainID, errUnfoldTxContainer, errDeployerNotWhitelisted:
+			case action.ErrChainID, errUnfoldTxContainer, errDeployerNotWhitelisted, protocol.ErrGasNotSponsored:
 				log.L().Debug("runAction() failed", zap.Uint64("height", ws.height), zap.Error(err))
 				ap.DeleteAction(caller)
 				actionIterator.PopAccount()
@@ -859,7 +916,9 @@ func (ws *workingSet) pickAndRunActions(
 		updateReceiptIndex(receipts)
 	}
 	ws.receipts = receipts
-
+	if err := ws.handleBlockEnd(ctxWithBlockContext); err != nil {
+		return nil, err
+	}
 	return executedActions, ws.finalize(ctx)
 }
 
@@ -887,6 +946,21 @@ func updateReceiptIndex(receipts []*action.Receipt) {
 	}
 }
 
+// validateBlobCount checks the number of the blobs in the actions doesn't exceed the limit at the height
+func validateBlobCount(g *genesis.Blockchain, height uint64, acts []*action.SealedEnvelope) error {
+	var (
+		blobCnt   = uint64(0)
+		blobLimit = g.MaxBlobGasPerBlock(height) / params.BlobTxBlobGasPerBlob
+	)
+	for _, selp := range acts {
+		blobCnt += uint64(len(selp.BlobHashes()))
+		if blobCnt > blobLimit {
+			return errors.New("too many blob transactions in a block")
+		}
+	}
+	return nil
+}
+
 func (ws *workingSet) ValidateBlock(ctx context.Context, blk *block.Block) error {
 	fCtx := protocol.MustGetFeatureCtx(ctx)
 	if fCtx.SkipSystemActionNonce {
@@ -904,6 +978,14 @@ func (ws *workingSet) ValidateBlock(ctx context.Context, blk *block.Block) error
 		}
 	}
 
+	if extra := blk.ExtraData(); len(extra) > 0 {
+		if !fCtx.EnableBlockExtraData {
+			return errors.Wrap(block.ErrInvalidExtraData, "extra data is not enabled")
+		}
+		if len(extra) > block.MaxExtraDataSize {
+			return errors.Wrapf(block.ErrInvalidExtraData, "extra data size %d exceeds the limit %d", len(extra), block.MaxExtraDataSize)
+		}
+	}
 	if fCtx.EnableDynamicFeeTx {
 		bcCtx := protocol.MustGetBlockchainCtx(ctx)
 		if err := protocol.VerifyEIP1559Header(
@@ -912,16 +994,12 @@ func (ws *workingSet) ValidateBlock(ctx context.Context, blk *block.Block) error
 		}
 	}
 	if fCtx.EnableBlobTransaction {
-		blobCnt := uint64(0)
-		blobLimit := uint64(params.MaxBlobGasPerBlock / params.BlobTxBlobGasPerBlob)
-		for _, selp := range blk.Actions {
-			blobCnt += uint64(len(selp.BlobHashes()))
-			if blobCnt > blobLimit {
-				return errors.New("too many blob transactions in a block")
-			}
+		g := genesis.MustExtractGenesisContext(ctx).Blockchain
+		if err := validateBlobCount(&g, blk.Height(), blk.Actions); err != nil {
+			return err
 		}
 		bcCtx := protocol.MustGetBlockchainCtx(ctx)
-		if err := protocol.VerifyEIP4844Header(&bcCtx.Tip, &blk.Header); err != nil {
+		if err := protocol.VerifyEIP4844Header(g, &bcCtx.Tip, &blk.Header); err != nil {
 			return err
 		}
 	}
@@ -941,10 +1019,140 @@ func (ws *workingSet) ValidateBlock(ctx context.Context, blk *block.Block) error
 	if !blk.VerifyReceiptRoot(receiptRoot) {
 		return errors.Wrapf(block.ErrReceiptRootMismatch, "receipt root in block '%x' vs receipt root in workingset '%x'", blk.ReceiptRoot(), receiptRoot)
 	}
+	candidatesRoot, err := ws.candidatesRoot(ctx)
+	if err != nil {
+		return err
+	}
+	if blk.CandidatesRoot() != candidatesRoot {
+		return errors.Wrapf(block.ErrCandidatesRootMismatch, "candidates root in block '%x' vs candidates root in workingset '%x'", blk.CandidatesRoot(), candidatesRoot)
+	}
+	beacon, err := ws.beacon(ctx)
+	if err != nil {
+		return err
+	}
+	if blk.Beacon() != beacon {
+		return errors.Wrapf(block.ErrBeaconMismatch, "beacon in block '%x' vs beacon in workingset '%x'", blk.Beacon(), beacon)
+	}
+	outboundRoot, err := ws.outboundRoot(ctx)
+	if err != nil {
+		return err
+	}
+	if blk.OutboundRoot() != outboundRoot {
+		return errors.Wrapf(block.ErrOutboundRootMismatch, "outbound root in block '%x' vs outbound root in workingset '%x'", blk.OutboundRoot(), outboundRoot)
+	}
 
 	return nil
 }
 
+// candidatesRoot returns the root of the epoch candidates snapshot if the working set is at an epoch start height
+func (ws *workingSet) candidatesRoot(ctx context.Context) (hash.Hash256, error) {
+	if !protocol.MustGetFeatureCtx(ctx).EnableEpochCandidateSnapshot {
+		return hash.ZeroHash256, nil
+	}
+	rp := rolldpos.FindProtocol(protocol.MustGetRegistry(ctx))
+	if rp == nil {
+		return hash.ZeroHash256, nil
+	}
+	epochNum := rp.GetEpochNum(ws.height)
+	if rp.GetEpochHeight(epochNum) != ws.height {
+		return hash.ZeroHash256, nil
+	}
+	candidates, err := candidatesutil.EpochCandidatesFromDB(ws, epochNum)
+	switch errors.Cause(err) {
+	case nil:
+		return candidatesutil.CandidatesRoot(candidates)
+	case state.ErrStateNotExist:
+		return hash.ZeroHash256, nil
+	default:
+		return hash.ZeroHash256, errors.Wrapf(err, "failed to get candidates of epoch %d", epochNum)
+	}
+}
+
+// runBundle runs the actions of the bundle in order, an action failing to run is skipped, and the whole bundle is
+// reverted if it is all-or-nothing, in which a reverted execution is taken as a failure too
+func (ws *workingSet) runBundle(
+	ctx context.Context,
+	blkCtx *protocol.BlockCtx,
+	bundle *actpool.Bundle,
+) ([]*action.Receipt, []*action.SealedEnvelope, error) {
+	var (
+		origin   = *blkCtx
+		snapshot = ws.Snapshot()
+		receipts []*action.Receipt
+		actions  []*action.SealedEnvelope
+		fCtx     = protocol.MustGetFeatureCtx(ctx)
+	)
+	for _, selp := range bundle.Actions {
+		actSnapshot := ws.Snapshot()
+		receipt, err := ws.runBundleAction(protocol.WithBlockCtx(ctx, *blkCtx), selp)
+		if err == nil && bundle.AllOrNothing && receipt.Status != uint64(iotextypes.ReceiptStatus_Success) {
+			err = errors.Errorf("action failed with status %d", receipt.Status)
+		}
+		if err != nil {
+			h, _ := selp.Hash()
+			log.L().Debug("failed to run action of bundle", zap.Uint64("height", ws.height), log.Hex("action", h[:]), zap.Error(err))
+			if bundle.AllOrNothing {
+				*blkCtx = origin
+				return nil, nil, ws.Revert(snapshot)
+			}
+			if err := ws.Revert(actSnapshot); err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+		blkCtx.GasLimit -= receipt.GasConsumed
+		if fCtx.EnableDynamicFeeTx && receipt.PriorityFee() != nil {
+			(&blkCtx.AccumulatedTips).Add(&blkCtx.AccumulatedTips, receipt.PriorityFee())
+		}
+		receipts = append(receipts, receipt)
+		actions = append(actions, selp)
+	}
+	return receipts, actions, nil
+}
+
+func (ws *workingSet) runBundleAction(ctx context.Context, selp *action.SealedEnvelope) (*action.Receipt, error) {
+	if selp.Gas() > protocol.MustGetBlockCtx(ctx).GasLimit {
+		return nil, action.ErrGasLimit
+	}
+	if container, ok := selp.Envelope.(action.TxContainer); ok {
+		if err := container.Unfold(selp, ctx, ws.checkContract); err != nil {
+			return nil, err
+		}
+	}
+	if err := ws.txValidator.ValidateWithState(ctx, selp); err != nil {
+		return nil, err
+	}
+	actionCtx, err := withActionCtx(ctx, selp)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range protocol.MustGetRegistry(ctx).All() {
+		if validator, ok := p.(protocol.ActionValidator); ok {
+			if err := validator.Validate(actionCtx, selp.Envelope, ws); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return ws.runAction(actionCtx, selp)
+}
+
+// beacon returns the mix of the random beacon if it is enabled at the height of the working set
+func (ws *workingSet) beacon(ctx context.Context) (hash.Hash256, error) {
+	fCtx, ok := protocol.GetFeatureWithHeightCtx(ctx)
+	if !ok || !fCtx.EnableRandomBeacon(ws.height) {
+		return hash.ZeroHash256, nil
+	}
+	return poll.BeaconMix(ws)
+}
+
+// outboundRoot returns the root of the bridge outbound queue if the bridge is enabled
+func (ws *workingSet) outboundRoot(ctx context.Context) (hash.Hash256, error) {
+	if !protocol.MustGetFeatureCtx(ctx).EnableBridge || bridge.FindProtocol(protocol.MustGetRegistry(ctx)) == nil {
+		return hash.ZeroHash256, nil
+	}
+	return bridge.OutboundRoot(ws)
+}
+
 func (ws *workingSet) CreateBuilder(
 	ctx context.Context,
 	ap actpool.ActPool,
@@ -965,6 +1173,18 @@ func (ws *workingSet) CreateBuilder(
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get digest")
 	}
+	candidatesRoot, err := ws.candidatesRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	beacon, err := ws.beacon(ctx)
+	if err != nil {
+		return nil, err
+	}
+	outboundRoot, err := ws.outboundRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	ra := block.NewRunnableActionsBuilder().
 		AddActions(actions...).
@@ -976,7 +1196,10 @@ func (ws *workingSet) CreateBuilder(
 		SetDeltaStateDigest(digest).
 		SetReceipts(ws.receipts).
 		SetReceiptRoot(calculateReceiptRoot(ws.receipts)).
-		SetLogsBloom(calculateLogsBloom(ctx, ws.receipts))
+		SetLogsBloom(calculateLogsBloom(ctx, ws.receipts)).
+		SetCandidatesRoot(candidatesRoot).
+		SetBeacon(beacon).
+		SetOutboundRoot(outboundRoot)
 	if fCtx.EnableDynamicFeeTx {
 		blkBuilder.SetGasUsed(calculateGasUsed(ws.receipts))
 		blkBuilder.SetBaseFee(blkCtx.BaseFee)
@@ -1000,7 +1223,14 @@ func (ws *workingSet) NewWorkingSet(ctx context.Context) (*workingSet, error) {
 	if err := views.Commit(ctx, ws); err != nil {
 		return nil, err
 	}
-	return newWorkingSet(ws.height+1, views, store, ws.workingSetStoreFactory), nil
+	child := newWorkingSet(ws.height+1, views, store, ws.workingSetStoreFactory)
+	child.trieNodeCache = ws.trieNodeCache
+	return child, nil
+}
+
+// TrieNodeCache returns the cache of the trie nodes shared by the working sets
+func (ws *workingSet) TrieNodeCache() *mptrie.NodeCache {
+	return ws.trieNodeCache
 }
 
 func (ws *workingSet) Close() {
@@ -8,22 +8,33 @@ package factory
 import (
 	"context"
 	"math/big"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
 	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/iotex-core/v2/action"
 	"github.com/iotexproject/iotex-core/v2/action/protocol"
 	"github.com/iotexproject/iotex-core/v2/action/protocol/account"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/bridge"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/poll"
 	"github.com/iotexproject/iotex-core/v2/action/protocol/rewarding"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/vote/candidatesutil"
 	"github.com/iotexproject/iotex-core/v2/blockchain"
 	"github.com/iotexproject/iotex-core/v2/blockchain/block"
 	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/v2/db"
 	"github.com/iotexproject/iotex-core/v2/pkg/unit"
+	"github.com/iotexproject/iotex-core/v2/state"
 	"github.com/iotexproject/iotex-core/v2/test/identityset"
 	"github.com/iotexproject/iotex-core/v2/testutil"
 )
@@ -327,3 +338,147 @@ func makeBlock(t *testing.T, prevHash hash.Hash256, receiptRoot hash.Hash256, di
 	require.NoError(t, err)
 	return &blk
 }
+
+func TestWorkingSet_CandidatesRoot(t *testing.T) {
+	require := require.New(t)
+	ws := newStateDBWorkingSet(t)
+	registry := protocol.NewRegistry()
+	rp := rolldpos.NewProtocol(24, 24, 1)
+	require.NoError(rp.Register(registry))
+	g := genesis.TestDefault()
+	newCtx := func(g genesis.Genesis) context.Context {
+		ctx := protocol.WithBlockCtx(genesis.WithGenesisContext(context.Background(), g), protocol.BlockCtx{BlockHeight: ws.height})
+		return protocol.WithRegistry(protocol.WithFeatureCtx(ctx), registry)
+	}
+	candidates := state.CandidateList{
+		{Address: identityset.Address(1).String(), Votes: big.NewInt(100), RewardAddress: identityset.Address(1).String()},
+		{Address: identityset.Address(2).String(), Votes: big.NewInt(50), RewardAddress: identityset.Address(2).String()},
+	}
+	expected, err := candidatesutil.CandidatesRoot(candidates)
+	require.NoError(err)
+	require.NotEqual(hash.ZeroHash256, expected)
+
+	// the snapshot is not enabled
+	require.NoError(candidatesutil.PutEpochCandidates(ws, rp.GetEpochNum(ws.height), candidates))
+	root, err := ws.candidatesRoot(newCtx(g))
+	require.NoError(err)
+	require.Equal(hash.ZeroHash256, root)
+
+	g.ToBeEnabledBlockHeight = 1
+	root, err = ws.candidatesRoot(newCtx(g))
+	require.NoError(err)
+	require.Equal(expected, root)
+
+	// not at the epoch start height
+	ws.height = rp.GetEpochHeight(2) + 1
+	root, err = ws.candidatesRoot(newCtx(g))
+	require.NoError(err)
+	require.Equal(hash.ZeroHash256, root)
+
+	// no snapshot of the epoch
+	ws.height = rp.GetEpochHeight(2)
+	root, err = ws.candidatesRoot(newCtx(g))
+	require.NoError(err)
+	require.Equal(hash.ZeroHash256, root)
+}
+
+func TestWorkingSet_Beacon(t *testing.T) {
+	require := require.New(t)
+	ws := newStateDBWorkingSet(t)
+	g := genesis.TestDefault()
+	newCtx := func(g genesis.Genesis) context.Context {
+		return protocol.WithFeatureWithHeightCtx(genesis.WithGenesisContext(context.Background(), g))
+	}
+	mix := hash.Hash256b([]byte("beacon"))
+	key := candidatesutil.ConstructKey("RandomBeacon.")
+	_, err := ws.PutState(&poll.Beacon{Mix: mix}, protocol.KeyOption(key[:]), protocol.NamespaceOption(protocol.SystemNamespace))
+	require.NoError(err)
+
+	// the beacon is not enabled
+	beacon, err := ws.beacon(newCtx(g))
+	require.NoError(err)
+	require.Equal(hash.ZeroHash256, beacon)
+	beacon, err = ws.beacon(context.Background())
+	require.NoError(err)
+	require.Equal(hash.ZeroHash256, beacon)
+
+	g.ToBeEnabledBlockHeight = ws.height
+	beacon, err = ws.beacon(newCtx(g))
+	require.NoError(err)
+	require.Equal(mix, beacon)
+}
+
+func TestValidateBlobCount(t *testing.T) {
+	r := require.New(t)
+
+	g := genesis.TestDefault().Blockchain
+	g.ToBeEnabledBlockHeight = 10
+	g.MaxBlobsPerBlock = 2
+	oldLimit := int(params.MaxBlobGasPerBlock / params.BlobTxBlobGasPerBlob)
+	r.Greater(oldLimit, 4)
+	withBlobs := func(n int) []*action.SealedEnvelope {
+		hashes := make([]common.Hash, n)
+		elp := (&action.EnvelopeBuilder{}).SetTxType(action.BlobTxType).SetGasLimit(21000).
+			SetBlobTxData(uint256.NewInt(1), hashes, nil).
+			SetAction(action.NewTransfer(big.NewInt(1), identityset.Address(1).String(), nil)).Build()
+		return []*action.SealedEnvelope{action.FakeSeal(elp, identityset.PrivateKey(1).PublicKey())}
+	}
+	// the blocks before the fork are validated against the limit of EIP-4844
+	r.NoError(validateBlobCount(&g, 9, withBlobs(4)))
+	r.NoError(validateBlobCount(&g, 9, withBlobs(oldLimit)))
+	r.ErrorContains(validateBlobCount(&g, 9, withBlobs(oldLimit+1)), "too many blob transactions")
+	// the limit configured takes effect after the fork
+	r.NoError(validateBlobCount(&g, 10, withBlobs(2)))
+	r.ErrorContains(validateBlobCount(&g, 10, withBlobs(4)), "too many blob transactions")
+}
+
+func TestWorkingSet_OutboundRoot(t *testing.T) {
+	require := require.New(t)
+	ws := newStateDBWorkingSet(t)
+	g := genesis.TestDefault()
+	bp, err := bridge.NewProtocol(genesis.Bridge{
+		CounterpartChainID:       2,
+		CounterpartBridgeAddress: "0x0000000000000000000000000000000000000002",
+	}, nil)
+	require.NoError(err)
+	reg := protocol.NewRegistry()
+	newCtx := func(g genesis.Genesis, reg *protocol.Registry) context.Context {
+		ctx := genesis.WithGenesisContext(protocol.WithRegistry(context.Background(), reg), g)
+		return protocol.WithFeatureCtx(protocol.WithBlockCtx(ctx, protocol.BlockCtx{BlockHeight: ws.height}))
+	}
+	// send a message to fill the outbound queue
+	ctx := genesis.WithGenesisContext(context.Background(), g)
+	ctx = protocol.WithBlockCtx(ctx, protocol.BlockCtx{BlockHeight: ws.height})
+	ctx = protocol.WithBlockchainCtx(ctx, protocol.BlockchainCtx{EvmNetworkID: 1})
+	ctx = protocol.WithActionCtx(ctx, protocol.ActionCtx{Caller: identityset.Address(1)})
+	g.ToBeEnabledBlockHeight = ws.height
+	ctx = protocol.WithFeatureCtx(genesis.WithGenesisContext(ctx, g))
+	data, err := abi.JSON(strings.NewReader(bridge.ContractABI()))
+	require.NoError(err)
+	input, err := data.Pack("sendMessage", common.HexToAddress("0x03"), []byte{1})
+	require.NoError(err)
+	elp := (&action.EnvelopeBuilder{}).SetGasPrice(big.NewInt(0)).SetGasLimit(100000).
+		SetAction(action.NewExecution(bridge.ProtocolAddr().String(), big.NewInt(0), input)).Build()
+	receipt, err := bp.Handle(ctx, elp, ws)
+	require.NoError(err)
+	require.EqualValues(iotextypes.ReceiptStatus_Success, receipt.Status)
+	expected, err := bridge.OutboundRoot(ws)
+	require.NoError(err)
+	require.NotEqual(hash.ZeroHash256, expected)
+
+	// the bridge is not registered
+	root, err := ws.outboundRoot(newCtx(g, reg))
+	require.NoError(err)
+	require.Equal(hash.ZeroHash256, root)
+	require.NoError(bp.Register(reg))
+	// the bridge is not enabled
+	g.ToBeEnabledBlockHeight = ws.height + 1
+	root, err = ws.outboundRoot(newCtx(g, reg))
+	require.NoError(err)
+	require.Equal(hash.ZeroHash256, root)
+
+	g.ToBeEnabledBlockHeight = ws.height
+	root, err = ws.outboundRoot(newCtx(g, reg))
+	require.NoError(err)
+	require.Equal(expected, root)
+}
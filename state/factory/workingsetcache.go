@@ -0,0 +1,249 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package factory
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/iotexproject/go-pkgs/cache"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/db/batch"
+)
+
+var (
+	// ErrWorkingSetNotCached indicates the working set of the block is not in the cache
+	ErrWorkingSetNotCached = errors.New("working set is not cached")
+	// ErrTooManyPinnedWorkingSets indicates the number of the pinned working sets reaches the limit
+	ErrTooManyPinnedWorkingSets = errors.New("too many pinned working sets")
+)
+
+type (
+	// WorkingSetCacheManager is implemented by a factory whose cache of the working sets of the validated and the
+	// minted blocks could be managed explicitly, e.g., by a consensus engine which knows the blocks to be committed
+	WorkingSetCacheManager interface {
+		// PinWorkingSet keeps the working set of the block in the cache until it is unpinned, or the chain passes
+		// its height
+		PinWorkingSet(hash.Hash256) error
+		// UnpinWorkingSet returns the working set of the block to the lru cache, it returns false if not pinned
+		UnpinWorkingSet(hash.Hash256) bool
+		// PrewarmWorkingSet creates the working set of the next height ahead of validating or minting the block
+		PrewarmWorkingSet(context.Context) error
+		// WorkingSetCacheEntries returns the working sets in the cache in the ascending order of heights
+		WorkingSetCacheEntries() []*WorkingSetCacheEntry
+	}
+
+	// WorkingSetCacheEntry is a working set in the cache
+	WorkingSetCacheEntry struct {
+		// Hash is the hash of the block of the working set, empty for the prewarmed one
+		Hash      hash.Hash256
+		Height    uint64
+		Pinned    bool
+		Prewarmed bool
+		// Writes and Bytes are the number and the size of the writes buffered by the working set
+		Writes int
+		Bytes  uint64
+	}
+
+	// workingSetCache is an lru cache of the working sets, along with the pinned ones exempt from eviction and the
+	// one prewarmed for the next height
+	workingSetCache struct {
+		mu        sync.Mutex
+		lru       cache.LRUCache
+		pinned    map[hash.Hash256]*workingSet
+		pinLimit  int
+		prewarmed *workingSet
+	}
+)
+
+func newWorkingSetCache(lru cache.LRUCache, pinLimit uint64) *workingSetCache {
+	return &workingSetCache{
+		lru:      lru,
+		pinned:   make(map[hash.Hash256]*workingSet),
+		pinLimit: int(pinLimit),
+	}
+}
+
+func (c *workingSetCache) Get(key hash.Hash256) (*workingSet, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.get(key)
+}
+
+func (c *workingSetCache) get(key hash.Hash256) (*workingSet, bool, error) {
+	if ws, ok := c.pinned[key]; ok {
+		return ws, true, nil
+	}
+	data, ok := c.lru.Get(key)
+	if !ok {
+		return nil, false, nil
+	}
+	ws, ok := data.(*workingSet)
+	if !ok {
+		return nil, false, errors.New("type assertion failed to be WorkingSet")
+	}
+	return ws, true, nil
+}
+
+// AddIfNotExist adds the working set of the block, or returns the one already cached
+func (c *workingSetCache) AddIfNotExist(key hash.Hash256, ws *workingSet) (*workingSet, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	existed, ok, err := c.get(key)
+	if err != nil || ok {
+		return existed, err
+	}
+	c.lru.Add(key, ws)
+	return nil, nil
+}
+
+func (c *workingSetCache) Pin(key hash.Hash256) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.pinned[key]; ok {
+		return nil
+	}
+	if len(c.pinned) >= c.pinLimit {
+		return errors.Wrapf(ErrTooManyPinnedWorkingSets, "limit %d", c.pinLimit)
+	}
+	ws, ok, err := c.get(key)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.Wrapf(ErrWorkingSetNotCached, "block %x", key)
+	}
+	c.lru.Remove(key)
+	c.pinned[key] = ws
+	return nil
+}
+
+func (c *workingSetCache) Unpin(key hash.Hash256) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ws, ok := c.pinned[key]
+	if !ok {
+		return false
+	}
+	delete(c.pinned, key)
+	c.lru.Add(key, ws)
+	return true
+}
+
+// SetPrewarmed sets the working set prewarmed for the next height, replacing the previous one
+func (c *workingSetCache) SetPrewarmed(ws *workingSet) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.prewarmed != nil {
+		c.prewarmed.Close()
+	}
+	c.prewarmed = ws
+}
+
+// TakePrewarmed returns the working set prewarmed for the height, which is taken out of the cache since the working
+// set could only be used to run one block
+func (c *workingSetCache) TakePrewarmed(height uint64) *workingSet {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ws := c.prewarmed
+	if ws == nil || ws.height != height {
+		return nil
+	}
+	c.prewarmed = nil
+	return ws
+}
+
+// Prune releases the pinned and the prewarmed working sets which are useless after the height is committed, the
+// ones in the lru cache are left to be evicted
+func (c *workingSetCache) Prune(height uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, ws := range c.pinned {
+		if ws.height <= height {
+			delete(c.pinned, key)
+		}
+	}
+	if c.prewarmed != nil && c.prewarmed.height <= height {
+		c.prewarmed.Close()
+		c.prewarmed = nil
+	}
+}
+
+func (c *workingSetCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lru.Clear()
+	c.pinned = make(map[hash.Hash256]*workingSet)
+	if c.prewarmed != nil {
+		c.prewarmed.Close()
+		c.prewarmed = nil
+	}
+}
+
+func (c *workingSetCache) Entries() []*WorkingSetCacheEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ret := make([]*WorkingSetCacheEntry, 0, c.lru.Len()+len(c.pinned)+1)
+	for key, ws := range c.pinned {
+		ret = append(ret, newWorkingSetCacheEntry(key, ws, true))
+	}
+	c.lru.Range(func(k cache.Key, v interface{}) bool {
+		key, ok := k.(hash.Hash256)
+		if !ok {
+			return true
+		}
+		if ws, ok := v.(*workingSet); ok {
+			ret = append(ret, newWorkingSetCacheEntry(key, ws, false))
+		}
+		return true
+	})
+	if c.prewarmed != nil {
+		e := newWorkingSetCacheEntry(hash.ZeroHash256, c.prewarmed, false)
+		e.Prewarmed = true
+		ret = append(ret, e)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Height != ret[j].Height {
+			return ret[i].Height < ret[j].Height
+		}
+		return bytes.Compare(ret[i].Hash[:], ret[j].Hash[:]) < 0
+	})
+	return ret
+}
+
+func newWorkingSetCacheEntry(key hash.Hash256, ws *workingSet, pinned bool) *WorkingSetCacheEntry {
+	writes, size := bufferedWrites(ws.store)
+	return &WorkingSetCacheEntry{
+		Hash:   key,
+		Height: ws.height,
+		Pinned: pinned,
+		Writes: writes,
+		Bytes:  size,
+	}
+}
+
+// bufferedWrites returns the number and the size of the writes buffered by the working set store
+func bufferedWrites(store workingSetStore) (int, uint64) {
+	switch s := store.(type) {
+	case *stateDBWorkingSetStore:
+		kvb := s.flusher.KVStoreWithBuffer()
+		var size atomic.Uint64
+		kvb.SerializeQueue(func(wi *batch.WriteInfo) []byte {
+			size.Add(uint64(len(wi.Namespace()) + len(wi.Key()) + len(wi.Value())))
+			return nil
+		}, nil)
+		return kvb.Size(), size.Load()
+	case *workingSetStoreWithSecondary:
+		return bufferedWrites(s.writer)
+	default:
+		return 0, 0
+	}
+}
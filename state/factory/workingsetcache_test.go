@@ -0,0 +1,116 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package factory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+	"github.com/iotexproject/iotex-core/v2/test/mock/mock_actpool"
+	"github.com/iotexproject/iotex-core/v2/testutil"
+)
+
+func TestWorkingSetCacheManager(t *testing.T) {
+	require := require.New(t)
+	cfg := DefaultConfig
+	cfg.Chain.WorkingSetCacheSize = 2
+	cfg.Chain.WorkingSetPinLimit = 1
+	sf, err := NewStateDB(cfg, db.NewMemKVStore(), SkipBlockValidationStateDBOption())
+	require.NoError(err)
+	ctx := genesis.WithGenesisContext(context.Background(), genesis.TestDefault())
+	require.NoError(sf.Start(ctx))
+	defer func() {
+		require.NoError(sf.Stop(ctx))
+	}()
+	m, ok := sf.(WorkingSetCacheManager)
+	require.True(ok)
+
+	ap := mock_actpool.NewMockActPool(gomock.NewController(t))
+	ap.EXPECT().PendingActionMap().Return(map[string][]*action.SealedEnvelope{}).AnyTimes()
+	mint := func(producer int) *block.Block {
+		blk, err := sf.Mint(
+			protocol.WithBlockchainCtx(
+				protocol.WithFeatureCtx(
+					protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+						BlockHeight: 1,
+						Producer:    identityset.Address(producer),
+						GasLimit:    testutil.TestGasLimit,
+					}),
+				),
+				protocol.BlockchainCtx{ChainID: 1},
+			),
+			ap,
+			identityset.PrivateKey(producer),
+		)
+		require.NoError(err)
+		return blk
+	}
+	cached := func(blk *block.Block) bool {
+		_, ok, err := sf.(*stateDB).workingsets.Get(blk.HashBlock())
+		require.NoError(err)
+		return ok
+	}
+
+	// the pinned working set is not evicted
+	blk1 := mint(27)
+	require.ErrorIs(m.PinWorkingSet(hash.ZeroHash256), ErrWorkingSetNotCached)
+	require.NoError(m.PinWorkingSet(blk1.HashBlock()))
+	require.NoError(m.PinWorkingSet(blk1.HashBlock()))
+	blk2, blk3, blk4 := mint(26), mint(25), mint(24)
+	require.True(cached(blk1))
+	require.False(cached(blk2))
+	require.ErrorIs(m.PinWorkingSet(blk3.HashBlock()), ErrTooManyPinnedWorkingSets)
+	entries := m.WorkingSetCacheEntries()
+	require.Len(entries, 3)
+	for _, e := range entries {
+		require.EqualValues(1, e.Height)
+		require.Equal(e.Hash == blk1.HashBlock(), e.Pinned)
+		require.NotZero(e.Writes)
+		require.NotZero(e.Bytes)
+	}
+
+	// the unpinned working set goes back to the lru cache
+	require.True(m.UnpinWorkingSet(blk1.HashBlock()))
+	require.False(m.UnpinWorkingSet(blk1.HashBlock()))
+	mint(23)
+	require.True(cached(blk1))
+	require.False(cached(blk3))
+	require.False(cached(blk4))
+
+	// the prewarmed working set is taken by the next block
+	require.NoError(m.PrewarmWorkingSet(ctx))
+	entries = m.WorkingSetCacheEntries()
+	require.Len(entries, 3)
+	require.True(entries[0].Prewarmed)
+	require.Equal(hash.ZeroHash256, entries[0].Hash)
+	prewarmed := sf.(*stateDB).workingsets.prewarmed
+	require.NotNil(prewarmed)
+	blk5 := mint(22)
+	require.Nil(sf.(*stateDB).workingsets.prewarmed)
+	ws, ok, err := sf.(*stateDB).workingsets.Get(blk5.HashBlock())
+	require.NoError(err)
+	require.True(ok)
+	require.Equal(prewarmed, ws)
+
+	// the pinned and the prewarmed working sets are released once the height is committed
+	require.NoError(m.PinWorkingSet(blk5.HashBlock()))
+	require.NoError(m.PrewarmWorkingSet(ctx))
+	require.NoError(sf.PutBlock(ctx, blk1))
+	require.Empty(sf.(*stateDB).workingsets.pinned)
+	require.Nil(sf.(*stateDB).workingsets.prewarmed)
+	require.NoError(m.PrewarmWorkingSet(ctx))
+	require.EqualValues(2, sf.(*stateDB).workingsets.prewarmed.height)
+}
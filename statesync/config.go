@@ -0,0 +1,44 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package statesync
+
+import "time"
+
+// Config is the config of the state sync
+type Config struct {
+	// Serve enables taking the state snapshots and serving them to the peers. The commit of the blocks is blocked
+	// while the snapshot is being taken
+	Serve bool `yaml:"serve"`
+	// SnapshotDir is the directory storing the snapshots
+	SnapshotDir string `yaml:"snapshotDir"`
+	// SnapshotInterval is the number of blocks between the snapshots
+	SnapshotInterval uint64 `yaml:"snapshotInterval"`
+	// SnapshotRetention is the number of the latest snapshots kept
+	SnapshotRetention int `yaml:"snapshotRetention"`
+	// ChunkSize is the size in bytes above which the states are split into another chunk, it must be well below the
+	// maximal message size of p2p
+	ChunkSize int `yaml:"chunkSize"`
+	// Namespaces are the namespaces of the states in the snapshot
+	Namespaces []string `yaml:"namespaces"`
+	// Quorum is the number of peers which must serve the same manifest before it is fetched
+	Quorum int `yaml:"quorum"`
+	// RequestTimeout is the time to wait for a manifest or a chunk from a peer
+	RequestTimeout time.Duration `yaml:"requestTimeout"`
+}
+
+// DefaultConfig is the default config
+var DefaultConfig = Config{
+	Serve:             false,
+	SnapshotDir:       "/var/data/snapshot",
+	SnapshotInterval:  17280,
+	SnapshotRetention: 2,
+	ChunkSize:         4 << 20,
+	Namespaces: []string{
+		"Account", "Code", "Contract", "Preimage", "Staking", "Candidate", "CandsMap", "Rewarding", "System",
+	},
+	Quorum:         2,
+	RequestTimeout: 10 * time.Second,
+}
@@ -0,0 +1,288 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package statesync
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/crypto"
+	"github.com/iotexproject/iotex-core/v2/statesync/statesyncpb"
+)
+
+const _manifestFile = "manifest"
+
+type (
+	// Manifest describes the snapshot of the states at the height of the block. The states are split into chunks in
+	// the order of the namespaces and the keys, and the root commits to the hashes of the chunks
+	Manifest struct {
+		Height    uint64
+		BlockHash hash.Hash256
+		Root      hash.Hash256
+		Chunks    []hash.Hash256
+	}
+
+	// snapshotStore stores the snapshots on the disk, each in the directory named by its height
+	snapshotStore struct {
+		mu        sync.RWMutex
+		dir       string
+		retention int
+		latest    *Manifest
+	}
+
+	// chunkWriter splits the states into chunks of the size and writes them into the directory
+	chunkWriter struct {
+		dir     string
+		limit   int
+		size    int
+		entries []*statesyncpb.Entry
+		hashes  []hash.Hash256
+	}
+)
+
+// chunkHash returns the hash of the states in the chunk, which doesn't depend on the encoding of the message
+func chunkHash(entries []*statesyncpb.Entry) hash.Hash256 {
+	var b []byte
+	for _, e := range entries {
+		for _, field := range [][]byte{[]byte(e.GetNamespace()), e.GetKey(), e.GetValue()} {
+			b = binary.AppendUvarint(b, uint64(len(field)))
+			b = append(b, field...)
+		}
+	}
+	return hash.Hash256b(b)
+}
+
+// chunksRoot returns the merkle root of the chunk hashes
+func chunksRoot(hashes []hash.Hash256) hash.Hash256 {
+	if len(hashes) == 0 {
+		return hash.ZeroHash256
+	}
+	return crypto.NewMerkleTree(hashes).HashTree()
+}
+
+// Verify verifies that the root commits to the chunk hashes
+func (m *Manifest) Verify() error {
+	if root := chunksRoot(m.Chunks); root != m.Root {
+		return errors.Errorf("root %x of the chunks mismatches the manifest root %x", root, m.Root)
+	}
+	return nil
+}
+
+func (m *Manifest) toProto() *statesyncpb.Manifest {
+	pb := &statesyncpb.Manifest{
+		Height:      m.Height,
+		BlockHash:   m.BlockHash[:],
+		Root:        m.Root[:],
+		ChunkHashes: make([][]byte, 0, len(m.Chunks)),
+	}
+	for _, h := range m.Chunks {
+		pb.ChunkHashes = append(pb.ChunkHashes, h[:])
+	}
+	return pb
+}
+
+func manifestFromProto(pb *statesyncpb.Manifest) (*Manifest, error) {
+	if len(pb.GetBlockHash()) != len(hash.ZeroHash256) || len(pb.GetRoot()) != len(hash.ZeroHash256) {
+		return nil, errors.New("invalid hash length in the manifest")
+	}
+	m := &Manifest{
+		Height:    pb.GetHeight(),
+		BlockHash: hash.BytesToHash256(pb.GetBlockHash()),
+		Root:      hash.BytesToHash256(pb.GetRoot()),
+		Chunks:    make([]hash.Hash256, 0, len(pb.GetChunkHashes())),
+	}
+	for _, h := range pb.GetChunkHashes() {
+		if len(h) != len(hash.ZeroHash256) {
+			return nil, errors.New("invalid chunk hash length in the manifest")
+		}
+		m.Chunks = append(m.Chunks, hash.BytesToHash256(h))
+	}
+	return m, nil
+}
+
+func newSnapshotStore(dir string, retention int) *snapshotStore {
+	return &snapshotStore{
+		dir:       dir,
+		retention: retention,
+	}
+}
+
+// Load loads the latest snapshot in the directory
+func (s *snapshotStore) Load() error {
+	heights, err := s.heights()
+	if err != nil {
+		return err
+	}
+	if len(heights) == 0 {
+		return nil
+	}
+	m, err := s.manifest(heights[len(heights)-1])
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.latest = m
+	s.mu.Unlock()
+	return nil
+}
+
+// Latest returns the manifest of the latest snapshot, or nil if there is no snapshot
+func (s *snapshotStore) Latest() *Manifest {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.latest
+}
+
+// Chunk returns the chunk of the index in the snapshot of the height
+func (s *snapshotStore) Chunk(height uint64, index uint32) (*statesyncpb.Chunk, error) {
+	b, err := os.ReadFile(filepath.Join(s.dir, strconv.FormatUint(height, 10), strconv.FormatUint(uint64(index), 10)))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read chunk %d of snapshot %d", index, height)
+	}
+	chunk := &statesyncpb.Chunk{}
+	if err := proto.Unmarshal(b, chunk); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse chunk %d of snapshot %d", index, height)
+	}
+	chunk.Height, chunk.Index = height, index
+	return chunk, nil
+}
+
+// Create takes the snapshot of the states iterated, and returns its manifest. The snapshot is written into a
+// temporary directory and renamed once completed, so that a partial snapshot is never served
+func (s *snapshotStore) Create(iterate IterateStates, namespaces []string, chunkSize int, blockHash BlockHash) (*Manifest, error) {
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return nil, errors.Wrapf(err, "failed to create snapshot directory %s", s.dir)
+	}
+	tmp, err := os.MkdirTemp(s.dir, "tmp-")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create temporary snapshot directory")
+	}
+	defer os.RemoveAll(tmp)
+	w := &chunkWriter{dir: tmp, limit: chunkSize}
+	height, err := iterate(namespaces, w.Add)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to iterate states")
+	}
+	if err := w.Flush(); err != nil {
+		return nil, err
+	}
+	blkHash, err := blockHash(height)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the hash of block %d", height)
+	}
+	m := &Manifest{
+		Height:    height,
+		BlockHash: blkHash,
+		Root:      chunksRoot(w.hashes),
+		Chunks:    w.hashes,
+	}
+	b, err := proto.Marshal(m.toProto())
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(tmp, _manifestFile), b, 0600); err != nil {
+		return nil, errors.Wrap(err, "failed to write manifest")
+	}
+	dir := filepath.Join(s.dir, strconv.FormatUint(height, 10))
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tmp, dir); err != nil {
+		return nil, errors.Wrapf(err, "failed to move snapshot into %s", dir)
+	}
+	s.mu.Lock()
+	s.latest = m
+	s.mu.Unlock()
+	return m, s.prune()
+}
+
+// heights returns the heights of the snapshots in ascending order
+func (s *snapshotStore) heights() ([]uint64, error) {
+	files, err := os.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read snapshot directory %s", s.dir)
+	}
+	var heights []uint64
+	for _, f := range files {
+		if !f.IsDir() {
+			continue
+		}
+		if h, err := strconv.ParseUint(f.Name(), 10, 64); err == nil {
+			heights = append(heights, h)
+		}
+	}
+	sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+	return heights, nil
+}
+
+func (s *snapshotStore) manifest(height uint64) (*Manifest, error) {
+	b, err := os.ReadFile(filepath.Join(s.dir, strconv.FormatUint(height, 10), _manifestFile))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read manifest of snapshot %d", height)
+	}
+	pb := &statesyncpb.Manifest{}
+	if err := proto.Unmarshal(b, pb); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse manifest of snapshot %d", height)
+	}
+	return manifestFromProto(pb)
+}
+
+// prune removes the snapshots beyond the retention
+func (s *snapshotStore) prune() error {
+	heights, err := s.heights()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(heights)-s.retention; i++ {
+		if err := os.RemoveAll(filepath.Join(s.dir, strconv.FormatUint(heights[i], 10))); err != nil {
+			return errors.Wrapf(err, "failed to remove snapshot %d", heights[i])
+		}
+	}
+	return nil
+}
+
+// Add adds the state into the current chunk, the chunk is written once it exceeds the size
+func (w *chunkWriter) Add(ns string, k, v []byte) error {
+	w.entries = append(w.entries, &statesyncpb.Entry{
+		Namespace: ns,
+		Key:       append([]byte(nil), k...),
+		Value:     append([]byte(nil), v...),
+	})
+	w.size += len(ns) + len(k) + len(v)
+	if w.size < w.limit {
+		return nil
+	}
+	return w.Flush()
+}
+
+// Flush writes the current chunk
+func (w *chunkWriter) Flush() error {
+	if len(w.entries) == 0 {
+		return nil
+	}
+	index := len(w.hashes)
+	b, err := proto.Marshal(&statesyncpb.Chunk{Entries: w.entries})
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(w.dir, strconv.Itoa(index)), b, 0600); err != nil {
+		return errors.Wrapf(err, "failed to write chunk %d", index)
+	}
+	w.hashes = append(w.hashes, chunkHash(w.entries))
+	w.entries, w.size = nil, 0
+	return nil
+}
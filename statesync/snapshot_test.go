@@ -0,0 +1,141 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package statesync
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/stretchr/testify/require"
+)
+
+type testState struct {
+	ns    string
+	key   string
+	value string
+}
+
+func testIterator(height uint64, states []testState) IterateStates {
+	return func(namespaces []string, fn func(ns string, k, v []byte) error) (uint64, error) {
+		for _, ns := range namespaces {
+			for _, s := range states {
+				if s.ns != ns {
+					continue
+				}
+				if err := fn(s.ns, []byte(s.key), []byte(s.value)); err != nil {
+					return 0, err
+				}
+			}
+		}
+		return height, nil
+	}
+}
+
+func testBlockHash(height uint64) (hash.Hash256, error) {
+	return hash.Hash256b([]byte(fmt.Sprintf("block%d", height))), nil
+}
+
+func testStates(n int) []testState {
+	states := make([]testState, 0, n)
+	for i := 0; i < n; i++ {
+		ns := "Account"
+		if i%3 == 0 {
+			ns = "Contract"
+		}
+		states = append(states, testState{ns: ns, key: fmt.Sprintf("key%03d", i), value: fmt.Sprintf("value%03d", i)})
+	}
+	return states
+}
+
+func TestSnapshotStore(t *testing.T) {
+	require := require.New(t)
+	dir := t.TempDir()
+	s := newSnapshotStore(dir, 2)
+	require.NoError(s.Load())
+	require.Nil(s.Latest())
+
+	states := testStates(10)
+	namespaces := []string{"Account", "Contract"}
+	// each state is over 20 bytes, so a chunk holds 2 states
+	m, err := s.Create(testIterator(10, states), namespaces, 40, testBlockHash)
+	require.NoError(err)
+	require.Equal(uint64(10), m.Height)
+	blkHash, _ := testBlockHash(10)
+	require.Equal(blkHash, m.BlockHash)
+	require.Len(m.Chunks, 5)
+	require.NoError(m.Verify())
+	require.Equal(m, s.Latest())
+
+	var restored []testState
+	for i, h := range m.Chunks {
+		chunk, err := s.Chunk(10, uint32(i))
+		require.NoError(err)
+		require.Equal(uint64(10), chunk.Height)
+		require.Equal(uint32(i), chunk.Index)
+		require.Equal(h, chunkHash(chunk.Entries))
+		for _, e := range chunk.Entries {
+			restored = append(restored, testState{ns: e.Namespace, key: string(e.Key), value: string(e.Value)})
+		}
+	}
+	require.Len(restored, len(states))
+	require.Equal("Account", restored[0].ns)
+	require.Equal("Contract", restored[len(restored)-1].ns)
+	_, err = s.Chunk(10, 5)
+	require.Error(err)
+
+	// the manifest is reloaded, and the snapshots beyond the retention are pruned
+	_, err = s.Create(testIterator(20, states), namespaces, 40, testBlockHash)
+	require.NoError(err)
+	_, err = s.Create(testIterator(30, states[:1]), namespaces, 40, testBlockHash)
+	require.NoError(err)
+	heights, err := s.heights()
+	require.NoError(err)
+	require.Equal([]uint64{20, 30}, heights)
+	s = newSnapshotStore(dir, 2)
+	require.NoError(s.Load())
+	require.Equal(uint64(30), s.Latest().Height)
+	require.Len(s.Latest().Chunks, 1)
+	files, err := filepath.Glob(filepath.Join(dir, "tmp-*"))
+	require.NoError(err)
+	require.Empty(files)
+
+	// the snapshot of no state
+	m, err = s.Create(testIterator(40, nil), namespaces, 40, testBlockHash)
+	require.NoError(err)
+	require.Empty(m.Chunks)
+	require.Equal(hash.ZeroHash256, m.Root)
+
+	// a corrupted manifest fails the load
+	require.NoError(os.WriteFile(filepath.Join(dir, "40", _manifestFile), []byte{0xff}, 0600))
+	require.Error(newSnapshotStore(dir, 2).Load())
+}
+
+func TestManifest(t *testing.T) {
+	require := require.New(t)
+	m := &Manifest{
+		Height:    5,
+		BlockHash: hash.Hash256b([]byte("block")),
+		Chunks:    []hash.Hash256{hash.Hash256b([]byte("chunk0")), hash.Hash256b([]byte("chunk1"))},
+	}
+	require.Error(m.Verify())
+	m.Root = chunksRoot(m.Chunks)
+	require.NoError(m.Verify())
+
+	decoded, err := manifestFromProto(m.toProto())
+	require.NoError(err)
+	require.Equal(m, decoded)
+
+	pb := m.toProto()
+	pb.ChunkHashes[1] = pb.ChunkHashes[1][:10]
+	_, err = manifestFromProto(pb)
+	require.Error(err)
+	pb.Root = nil
+	_, err = manifestFromProto(pb)
+	require.Error(err)
+}
@@ -0,0 +1,456 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+// Package statesync serves and fetches the snapshots of the states over p2p, so that a node gets the states at a
+// recent height without executing the blocks before it.
+//
+// The block headers don't commit to the states, so the manifest of a snapshot is trusted once a quorum of peers serve
+// the same manifest, and its block hash is verified against the header of the height. Each chunk is verified against
+// the chunk hash in the manifest, and the manifest root commits to all the chunk hashes.
+package statesync
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/db/batch"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/v2/statesync/statesyncpb"
+)
+
+// Protocol is the name of the p2p protocol of the state sync
+const Protocol = "statesync"
+
+type (
+	// Neighbors acquires the peers to fetch the snapshot from
+	Neighbors func() ([]peer.AddrInfo, error)
+	// UnicastOutbound sends the message of the state sync protocol to the peer
+	UnicastOutbound func(context.Context, peer.AddrInfo, []byte) error
+	// IterateStates iterates the states of the namespaces at the current height, and returns the height
+	IterateStates func(namespaces []string, fn func(ns string, k, v []byte) error) (uint64, error)
+	// BlockHash returns the hash of the block committed at the height
+	BlockHash func(uint64) (hash.Hash256, error)
+	// HeaderVerifier verifies the block hash of the height against a header verified
+	HeaderVerifier func(height uint64, blkHash hash.Hash256) error
+	// Restorer writes the states of a chunk verified
+	Restorer func([]*statesyncpb.Entry) error
+	// PeerReporter reports the peer serving a manifest or a chunk failing the verification
+	PeerReporter func(string)
+
+	// Helper is the helper of the state sync
+	Helper struct {
+		P2PNeighbor     Neighbors
+		UnicastOutbound UnicastOutbound
+		IterateStates   IterateStates
+		BlockHash       BlockHash
+		ReportPeer      PeerReporter
+	}
+
+	// StateSync takes the snapshots of the states, serves them to the peers, and fetches a snapshot from the peers
+	StateSync struct {
+		cfg      Config
+		helper   *Helper
+		store    *snapshotStore
+		taking   atomic.Bool
+		fetching atomic.Bool
+
+		mu        sync.Mutex
+		manifests chan peerManifest
+		waiters   map[uint32]*chunkWaiter
+	}
+
+	peerManifest struct {
+		peer     peer.AddrInfo
+		manifest *Manifest
+	}
+
+	chunkWaiter struct {
+		peer   peer.ID
+		height uint64
+		chunk  chan *statesyncpb.Chunk
+	}
+)
+
+var _stateSyncCounter = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "iotex_statesync_counter",
+		Help: "snapshots taken, manifests and chunks served and fetched",
+	},
+	[]string{"type"},
+)
+
+func init() {
+	prometheus.MustRegister(_stateSyncCounter)
+}
+
+// NewStateSync creates the state sync
+func NewStateSync(cfg Config, helper *Helper) *StateSync {
+	return &StateSync{
+		cfg:     cfg,
+		helper:  helper,
+		store:   newSnapshotStore(cfg.SnapshotDir, cfg.SnapshotRetention),
+		waiters: map[uint32]*chunkWaiter{},
+	}
+}
+
+// Start loads the latest snapshot to serve
+func (ss *StateSync) Start(_ context.Context) error {
+	if !ss.cfg.Serve {
+		return nil
+	}
+	return ss.store.Load()
+}
+
+// Stop stops the state sync
+func (ss *StateSync) Stop(_ context.Context) error {
+	return nil
+}
+
+// ReceiveBlock takes the snapshot in the background once the block of the snapshot interval is committed
+func (ss *StateSync) ReceiveBlock(blk *block.Block) error {
+	if !ss.cfg.Serve || ss.cfg.SnapshotInterval == 0 || blk.Height()%ss.cfg.SnapshotInterval != 0 {
+		return nil
+	}
+	if !ss.taking.CompareAndSwap(false, true) {
+		log.L().Warn("the previous snapshot is still being taken", zap.Uint64("height", blk.Height()))
+		return nil
+	}
+	go func() {
+		defer ss.taking.Store(false)
+		if _, err := ss.TakeSnapshot(); err != nil {
+			log.L().Error("failed to take state snapshot", zap.Error(err))
+		}
+	}()
+	return nil
+}
+
+// TakeSnapshot takes the snapshot of the states at the current height
+func (ss *StateSync) TakeSnapshot() (*Manifest, error) {
+	start := time.Now()
+	m, err := ss.store.Create(ss.helper.IterateStates, ss.cfg.Namespaces, ss.cfg.ChunkSize, ss.helper.BlockHash)
+	if err != nil {
+		return nil, err
+	}
+	_stateSyncCounter.WithLabelValues("snapshot").Inc()
+	log.L().Info("state snapshot is taken.",
+		zap.Uint64("height", m.Height),
+		log.Hex("root", m.Root[:]),
+		zap.Int("chunks", len(m.Chunks)),
+		zap.Duration("duration", time.Since(start)))
+	return m, nil
+}
+
+// Latest returns the manifest of the latest snapshot served, or nil if there is no snapshot
+func (ss *StateSync) Latest() *Manifest {
+	return ss.store.Latest()
+}
+
+// HandleMessage handles the message of the state sync protocol from the peer. An error is returned only if the
+// message is malformed
+func (ss *StateSync) HandleMessage(ctx context.Context, from peer.AddrInfo, data []byte) error {
+	msg := &statesyncpb.Message{}
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return errors.Wrap(err, "failed to parse state sync message")
+	}
+	switch {
+	case msg.GetManifestRequest() != nil:
+		m := ss.store.Latest()
+		if !ss.cfg.Serve || m == nil {
+			return nil
+		}
+		_stateSyncCounter.WithLabelValues("serveManifest").Inc()
+		ss.send(ctx, from, &statesyncpb.Message{Msg: &statesyncpb.Message_Manifest{Manifest: m.toProto()}})
+	case msg.GetChunkRequest() != nil:
+		if !ss.cfg.Serve {
+			return nil
+		}
+		req := msg.GetChunkRequest()
+		chunk, err := ss.store.Chunk(req.GetHeight(), req.GetIndex())
+		if err != nil {
+			log.L().Debug("chunk not served", zap.Error(err))
+			return nil
+		}
+		_stateSyncCounter.WithLabelValues("serveChunk").Inc()
+		ss.send(ctx, from, &statesyncpb.Message{Msg: &statesyncpb.Message_Chunk{Chunk: chunk}})
+	case msg.GetManifest() != nil:
+		m, err := manifestFromProto(msg.GetManifest())
+		if err != nil {
+			return err
+		}
+		ss.mu.Lock()
+		defer ss.mu.Unlock()
+		if ss.manifests == nil {
+			return nil
+		}
+		select {
+		case ss.manifests <- peerManifest{peer: from, manifest: m}:
+		default:
+		}
+	case msg.GetChunk() != nil:
+		chunk := msg.GetChunk()
+		ss.mu.Lock()
+		defer ss.mu.Unlock()
+		w, ok := ss.waiters[chunk.GetIndex()]
+		if !ok || w.peer != from.ID || w.height != chunk.GetHeight() {
+			return nil
+		}
+		select {
+		case w.chunk <- chunk:
+		default:
+		}
+	default:
+		return errors.New("unknown state sync message")
+	}
+	return nil
+}
+
+// KVStoreRestorer returns the restorer writing the states into the kv store
+func KVStoreRestorer(kv db.KVStore) Restorer {
+	return func(entries []*statesyncpb.Entry) error {
+		b := batch.NewBatch()
+		for _, e := range entries {
+			b.Put(e.GetNamespace(), e.GetKey(), e.GetValue(), "failed to restore state")
+		}
+		return kv.WriteBatch(b)
+	}
+}
+
+// Fetch fetches the latest snapshot served by a quorum of the peers, whose block hash is verified by the verifier,
+// and restores the states of the chunks verified by the restorer. It returns the manifest of the snapshot restored
+func (ss *StateSync) Fetch(ctx context.Context, verify HeaderVerifier, restore Restorer) (*Manifest, error) {
+	if !ss.fetching.CompareAndSwap(false, true) {
+		return nil, errors.New("snapshot is being fetched")
+	}
+	defer ss.fetching.Store(false)
+	peers, err := ss.helper.P2PNeighbor()
+	if err != nil {
+		return nil, err
+	}
+	m, servers, err := ss.fetchManifest(ctx, peers, verify)
+	if err != nil {
+		return nil, err
+	}
+	log.L().Info("fetching state snapshot.",
+		zap.Uint64("height", m.Height),
+		log.Hex("root", m.Root[:]),
+		zap.Int("chunks", len(m.Chunks)),
+		zap.Int("peers", len(servers)))
+	if err := ss.fetchChunks(ctx, m, servers, restore); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// fetchManifest requests the manifests from the peers, and returns the manifest of the highest height served by a
+// quorum of the peers and verified, along with the peers serving it
+func (ss *StateSync) fetchManifest(ctx context.Context, peers []peer.AddrInfo, verify HeaderVerifier) (*Manifest, []peer.AddrInfo, error) {
+	manifests := make(chan peerManifest, len(peers))
+	ss.mu.Lock()
+	ss.manifests = manifests
+	ss.mu.Unlock()
+	defer func() {
+		ss.mu.Lock()
+		ss.manifests = nil
+		ss.mu.Unlock()
+	}()
+	req := &statesyncpb.Message{Msg: &statesyncpb.Message_ManifestRequest{ManifestRequest: &statesyncpb.ManifestRequest{}}}
+	for _, p := range peers {
+		ss.send(ctx, p, req)
+	}
+
+	type candidate struct {
+		manifest *Manifest
+		peers    []peer.AddrInfo
+	}
+	var (
+		candidates = map[hash.Hash256]*candidate{}
+		received   = map[peer.ID]bool{}
+		timer      = time.NewTimer(ss.cfg.RequestTimeout)
+	)
+	defer timer.Stop()
+collect:
+	for len(received) < len(peers) {
+		select {
+		case pm := <-manifests:
+			if received[pm.peer.ID] {
+				continue
+			}
+			received[pm.peer.ID] = true
+			if err := pm.manifest.Verify(); err != nil {
+				log.L().Warn("invalid manifest", zap.String("peer", pm.peer.ID.String()), zap.Error(err))
+				ss.reportPeer(pm.peer.ID)
+				continue
+			}
+			key := manifestKey(pm.manifest)
+			c, ok := candidates[key]
+			if !ok {
+				c = &candidate{manifest: pm.manifest}
+				candidates[key] = c
+			}
+			c.peers = append(c.peers, pm.peer)
+		case <-timer.C:
+			break collect
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		}
+	}
+
+	ranked := make([]*candidate, 0, len(candidates))
+	for _, c := range candidates {
+		if len(c.peers) >= ss.cfg.Quorum {
+			ranked = append(ranked, c)
+		}
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].manifest.Height != ranked[j].manifest.Height {
+			return ranked[i].manifest.Height > ranked[j].manifest.Height
+		}
+		return len(ranked[i].peers) > len(ranked[j].peers)
+	})
+	for _, c := range ranked {
+		if err := verify(c.manifest.Height, c.manifest.BlockHash); err != nil {
+			log.L().Warn("manifest mismatches the header", zap.Uint64("height", c.manifest.Height), zap.Error(err))
+			continue
+		}
+		return c.manifest, c.peers, nil
+	}
+	return nil, nil, errors.Errorf("no manifest is served by a quorum of %d peers out of %d", ss.cfg.Quorum, len(received))
+}
+
+// fetchChunks downloads the chunks from the peers in parallel. A peer is dropped once it fails to serve a chunk, and
+// the chunk is requested from the other peers
+func (ss *StateSync) fetchChunks(ctx context.Context, m *Manifest, peers []peer.AddrInfo, restore Restorer) error {
+	if len(m.Chunks) == 0 {
+		return nil
+	}
+	pending := make(chan uint32, len(m.Chunks))
+	for i := range m.Chunks {
+		pending <- uint32(i)
+	}
+	var (
+		remaining  = int64(len(m.Chunks))
+		done       = make(chan struct{})
+		closeDone  sync.Once
+		restoreMu  sync.Mutex
+		restoreErr error
+		wg         sync.WaitGroup
+	)
+	for _, p := range peers {
+		wg.Add(1)
+		go func(p peer.AddrInfo) {
+			defer wg.Done()
+			for {
+				var index uint32
+				select {
+				case index = <-pending:
+				case <-done:
+					return
+				case <-ctx.Done():
+					return
+				}
+				chunk, err := ss.requestChunk(ctx, p, m.Height, index)
+				if err == nil && chunkHash(chunk.GetEntries()) != m.Chunks[index] {
+					err = errors.Errorf("hash of chunk %d mismatches the manifest", index)
+					ss.reportPeer(p.ID)
+				}
+				if err != nil {
+					log.L().Warn("failed to fetch chunk", zap.String("peer", p.ID.String()), zap.Error(err))
+					pending <- index
+					return
+				}
+				_stateSyncCounter.WithLabelValues("fetchChunk").Inc()
+				restoreMu.Lock()
+				if restoreErr == nil {
+					restoreErr = restore(chunk.GetEntries())
+				}
+				failed := restoreErr != nil
+				restoreMu.Unlock()
+				if atomic.AddInt64(&remaining, -1) == 0 || failed {
+					closeDone.Do(func() { close(done) })
+				}
+			}
+		}(p)
+	}
+	wg.Wait()
+	if restoreErr != nil {
+		return errors.Wrap(restoreErr, "failed to restore states")
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if remaining > 0 {
+		return errors.Errorf("failed to fetch %d chunks out of %d", remaining, len(m.Chunks))
+	}
+	return nil
+}
+
+func (ss *StateSync) requestChunk(ctx context.Context, p peer.AddrInfo, height uint64, index uint32) (*statesyncpb.Chunk, error) {
+	w := &chunkWaiter{peer: p.ID, height: height, chunk: make(chan *statesyncpb.Chunk, 1)}
+	ss.mu.Lock()
+	ss.waiters[index] = w
+	ss.mu.Unlock()
+	defer func() {
+		ss.mu.Lock()
+		if ss.waiters[index] == w {
+			delete(ss.waiters, index)
+		}
+		ss.mu.Unlock()
+	}()
+	req := &statesyncpb.Message{Msg: &statesyncpb.Message_ChunkRequest{ChunkRequest: &statesyncpb.ChunkRequest{Height: height, Index: index}}}
+	if err := ss.unicast(ctx, p, req); err != nil {
+		return nil, err
+	}
+	timer := time.NewTimer(ss.cfg.RequestTimeout)
+	defer timer.Stop()
+	select {
+	case chunk := <-w.chunk:
+		return chunk, nil
+	case <-timer.C:
+		return nil, errors.Errorf("timeout to fetch chunk %d", index)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (ss *StateSync) send(ctx context.Context, p peer.AddrInfo, msg *statesyncpb.Message) {
+	if err := ss.unicast(ctx, p, msg); err != nil {
+		log.L().Debug("failed to send state sync message", zap.String("peer", p.ID.String()), zap.Error(err))
+	}
+}
+
+func (ss *StateSync) unicast(ctx context.Context, p peer.AddrInfo, msg *statesyncpb.Message) error {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return ss.helper.UnicastOutbound(ctx, p, data)
+}
+
+func (ss *StateSync) reportPeer(id peer.ID) {
+	if ss.helper.ReportPeer != nil {
+		ss.helper.ReportPeer(id.String())
+	}
+}
+
+// manifestKey identifies the snapshot of the manifest
+func manifestKey(m *Manifest) hash.Hash256 {
+	b := byteutil.Uint64ToBytesBigEndian(m.Height)
+	b = append(b, m.BlockHash[:]...)
+	b = append(b, m.Root[:]...)
+	return hash.Hash256b(b)
+}
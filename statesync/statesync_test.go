@@ -0,0 +1,196 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package statesync
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/statesync/statesyncpb"
+)
+
+var _testPeerIDs = []string{
+	"12D3KooWJwW6pUpTkxPTMv84RPLPMQVEAjZ6fvJuX4oZrvW5DAGQ",
+	"12D3KooWHt2yPeWLgWmCVe5xWbCJCCDfQYVaNkfJvrAaJqqbjcoe",
+	"12D3KooWL2mUXJjLqz7h4bbQ3LeZdmV2Ta4xsG1xm8CLTKYBZyvj",
+	"12D3KooWSmZJ8f2RK1dxcJ2ZnjMGEohwDGuBqCcN5VmLHXHVJQG8",
+	"12D3KooWDYZvFrwYH26WfZjhDUPYPLoRU5xcg98qk24M7ovbyP3c",
+}
+
+// testNetwork delivers the messages between the state syncs synchronously
+type testNetwork struct {
+	mu    sync.Mutex
+	nodes map[peer.ID]*StateSync
+}
+
+func (n *testNetwork) add(t *testing.T, i int, cfg Config, helper *Helper) (*StateSync, peer.AddrInfo) {
+	id, err := peer.Decode(_testPeerIDs[i])
+	require.NoError(t, err)
+	self := peer.AddrInfo{ID: id}
+	helper.UnicastOutbound = func(ctx context.Context, to peer.AddrInfo, data []byte) error {
+		n.mu.Lock()
+		node, ok := n.nodes[to.ID]
+		n.mu.Unlock()
+		if !ok {
+			return errors.New("peer not found")
+		}
+		return node.HandleMessage(ctx, self, data)
+	}
+	ss := NewStateSync(cfg, helper)
+	n.mu.Lock()
+	n.nodes[id] = ss
+	n.mu.Unlock()
+	return ss, self
+}
+
+func TestStateSyncFetch(t *testing.T) {
+	require := require.New(t)
+	states := testStates(20)
+	cfg := DefaultConfig
+	cfg.Serve = true
+	cfg.ChunkSize = 60
+	cfg.Namespaces = []string{"Account", "Contract"}
+	cfg.RequestTimeout = 200 * time.Millisecond
+
+	network := &testNetwork{nodes: map[peer.ID]*StateSync{}}
+	var (
+		servers []peer.AddrInfo
+		syncs   []*StateSync
+	)
+	for i := 0; i < 4; i++ {
+		c := cfg
+		c.SnapshotDir = filepath.Join(t.TempDir(), "snapshot")
+		iter := testIterator(100, states)
+		if i == 3 {
+			// the peer serves a snapshot of other states
+			iter = testIterator(100, states[:5])
+		}
+		ss, p := network.add(t, i, c, &Helper{IterateStates: iter, BlockHash: testBlockHash})
+		require.NoError(ss.Start(context.Background()))
+		m, err := ss.TakeSnapshot()
+		require.NoError(err)
+		require.Equal(m, ss.Latest())
+		servers = append(servers, p)
+		syncs = append(syncs, ss)
+	}
+	// the peer serves the corrupted chunks of the same manifest
+	for i := range syncs[2].Latest().Chunks {
+		require.NoError(os.WriteFile(filepath.Join(syncs[2].cfg.SnapshotDir, "100", strconv.Itoa(i)), []byte{}, 0600))
+	}
+
+	var (
+		reported []string
+		restored = map[string]string{}
+	)
+	fetchCfg := cfg
+	fetchCfg.Serve = false
+	fetcher, _ := network.add(t, 4, fetchCfg, &Helper{
+		P2PNeighbor: func() ([]peer.AddrInfo, error) { return servers, nil },
+		ReportPeer:  func(id string) { reported = append(reported, id) },
+	})
+	restore := func(entries []*statesyncpb.Entry) error {
+		for _, e := range entries {
+			restored[e.Namespace+"/"+string(e.Key)] = string(e.Value)
+		}
+		return nil
+	}
+	verify := func(height uint64, blkHash hash.Hash256) error {
+		expected, _ := testBlockHash(height)
+		if blkHash != expected {
+			return errors.New("block hash mismatch")
+		}
+		return nil
+	}
+
+	m, err := fetcher.Fetch(context.Background(), verify, restore)
+	require.NoError(err)
+	require.Equal(syncs[0].Latest(), m)
+	require.Len(restored, len(states))
+	for _, s := range states {
+		require.Equal(s.value, restored[s.ns+"/"+s.key])
+	}
+	// only the peer serving the corrupted chunks is reported, unless the other peers have served all the chunks
+	for _, id := range reported {
+		require.Equal(_testPeerIDs[2], id)
+	}
+
+	// the manifest is rejected if it mismatches the header
+	_, err = fetcher.Fetch(context.Background(), func(uint64, hash.Hash256) error {
+		return errors.New("header mismatch")
+	}, restore)
+	require.ErrorContains(err, "no manifest is served by a quorum")
+
+	// the error of the restorer stops the fetch
+	_, err = fetcher.Fetch(context.Background(), verify, func([]*statesyncpb.Entry) error {
+		return errors.New("disk full")
+	})
+	require.ErrorContains(err, "disk full")
+
+	// the chunks can't be fetched once all the peers fail
+	for _, ss := range syncs[:2] {
+		require.NoError(os.RemoveAll(filepath.Join(ss.cfg.SnapshotDir, "100", "1")))
+	}
+	_, err = fetcher.Fetch(context.Background(), verify, restore)
+	require.ErrorContains(err, "failed to fetch")
+}
+
+func TestStateSyncHandleMessage(t *testing.T) {
+	require := require.New(t)
+	cfg := DefaultConfig
+	cfg.SnapshotDir = t.TempDir()
+	var sent []*statesyncpb.Message
+	ss := NewStateSync(cfg, &Helper{
+		UnicastOutbound: func(_ context.Context, _ peer.AddrInfo, data []byte) error {
+			msg := &statesyncpb.Message{}
+			require.NoError(proto.Unmarshal(data, msg))
+			sent = append(sent, msg)
+			return nil
+		},
+		IterateStates: testIterator(10, testStates(3)),
+		BlockHash:     testBlockHash,
+	})
+	_, err := ss.TakeSnapshot()
+	require.NoError(err)
+	req, err := proto.Marshal(&statesyncpb.Message{Msg: &statesyncpb.Message_ManifestRequest{ManifestRequest: &statesyncpb.ManifestRequest{}}})
+	require.NoError(err)
+
+	// the snapshots are not served unless enabled
+	require.NoError(ss.HandleMessage(context.Background(), peer.AddrInfo{}, req))
+	require.Empty(sent)
+	ss.cfg.Serve = true
+	require.NoError(ss.HandleMessage(context.Background(), peer.AddrInfo{}, req))
+	require.Len(sent, 1)
+	require.Equal(uint64(10), sent[0].GetManifest().GetHeight())
+
+	// the chunk not in the snapshot is ignored
+	req, err = proto.Marshal(&statesyncpb.Message{Msg: &statesyncpb.Message_ChunkRequest{ChunkRequest: &statesyncpb.ChunkRequest{Height: 10, Index: 1}}})
+	require.NoError(err)
+	require.NoError(ss.HandleMessage(context.Background(), peer.AddrInfo{}, req))
+	require.Len(sent, 1)
+	req, err = proto.Marshal(&statesyncpb.Message{Msg: &statesyncpb.Message_ChunkRequest{ChunkRequest: &statesyncpb.ChunkRequest{Height: 10}}})
+	require.NoError(err)
+	require.NoError(ss.HandleMessage(context.Background(), peer.AddrInfo{}, req))
+	require.Len(sent, 2)
+	require.Len(sent[1].GetChunk().GetEntries(), 3)
+
+	// the malformed messages are rejected
+	require.Error(ss.HandleMessage(context.Background(), peer.AddrInfo{}, []byte{0xff}))
+	require.Error(ss.HandleMessage(context.Background(), peer.AddrInfo{}, nil))
+	req, err = proto.Marshal(&statesyncpb.Message{Msg: &statesyncpb.Message_Manifest{Manifest: &statesyncpb.Manifest{}}})
+	require.NoError(err)
+	require.Error(ss.HandleMessage(context.Background(), peer.AddrInfo{}, req))
+}
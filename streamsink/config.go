@@ -0,0 +1,44 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package streamsink
+
+import (
+	"time"
+
+	"github.com/iotexproject/iotex-core/v2/pkg/publisher"
+)
+
+// Config is the config of the stream sink
+type Config struct {
+	// Enable enables streaming the actions, the receipts and the logs to the sink
+	Enable    bool             `yaml:"enable"`
+	Publisher publisher.Config `yaml:"publisher"`
+	// TopicPrefix is the prefix of the topics, the actions, the receipts and the logs are published to the topics
+	// <prefix>.actions, <prefix>.receipts and <prefix>.logs. It is made of the dot-separated tokens of letters,
+	// digits, '_' and '-'
+	TopicPrefix string `yaml:"topicPrefix"`
+	// CursorDBPath is the path of the db persisting the last height published
+	CursorDBPath string `yaml:"cursorDBPath"`
+	// StartHeight is the first height published if no height has been published, 0 to start from the next block
+	StartHeight uint64 `yaml:"startHeight"`
+	// RetryInterval is the interval before retrying a block failed to publish, which is doubled for each retry up to
+	// a minute
+	RetryInterval time.Duration `yaml:"retryInterval"`
+}
+
+// DefaultConfig is the default config
+var DefaultConfig = Config{
+	Enable: false,
+	Publisher: publisher.Config{
+		Sink:    publisher.SinkKafkaREST,
+		URL:     "",
+		Timeout: 10 * time.Second,
+	},
+	TopicPrefix:   "iotex",
+	CursorDBPath:  "/var/data/streamsink.db",
+	StartHeight:   0,
+	RetryInterval: time.Second,
+}
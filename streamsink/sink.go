@@ -0,0 +1,406 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+// Package streamsink streams the decoded actions, receipts and logs of the committed blocks to Kafka or NATS, so that
+// the data pipelines could be built without polling the api. The blocks are published in order from a cursor
+// persisted after each block, so every message is delivered at least once, and the messages of the block at the
+// cursor could be delivered again after a restart
+package streamsink
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/blockchain"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/db/batch"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+	"github.com/iotexproject/iotex-core/v2/pkg/publisher"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+)
+
+const (
+	_cursorNS         = "StreamSink"
+	_maxRetryInterval = time.Minute
+	// _pollInterval is the interval to check the new blocks if no block is received
+	_pollInterval = 10 * time.Second
+)
+
+var _cursorKey = []byte("cursor")
+
+type (
+	blockDAO interface {
+		Height() (uint64, error)
+		GetBlockByHeight(uint64) (*block.Block, error)
+		GetReceipts(uint64) ([]*action.Receipt, error)
+	}
+
+	chain interface {
+		AddSubscriber(blockchain.BlockCreationSubscriber) error
+		RemoveSubscriber(blockchain.BlockCreationSubscriber) error
+	}
+
+	// ActionMessage is the message of an action published to the topic <prefix>.actions
+	ActionMessage struct {
+		Height     uint64    `json:"height"`
+		BlockHash  string    `json:"blockHash"`
+		Timestamp  time.Time `json:"timestamp"`
+		Index      int       `json:"index"`
+		ActionHash string    `json:"actionHash"`
+		// Type is the type of the action, e.g. Transfer, Execution, CreateStake
+		Type     string `json:"type"`
+		Sender   string `json:"sender"`
+		Nonce    uint64 `json:"nonce"`
+		GasLimit uint64 `json:"gasLimit"`
+		GasPrice string `json:"gasPrice"`
+		To       string `json:"to,omitempty"`
+		Amount   string `json:"amount,omitempty"`
+		Data     string `json:"data,omitempty"`
+	}
+
+	// ReceiptMessage is the message of a receipt published to the topic <prefix>.receipts
+	ReceiptMessage struct {
+		Height             uint64 `json:"height"`
+		BlockHash          string `json:"blockHash"`
+		Index              uint32 `json:"index"`
+		ActionHash         string `json:"actionHash"`
+		Status             uint64 `json:"status"`
+		GasConsumed        uint64 `json:"gasConsumed"`
+		EffectiveGasPrice  string `json:"effectiveGasPrice,omitempty"`
+		ContractAddress    string `json:"contractAddress,omitempty"`
+		ExecutionRevertMsg string `json:"executionRevertMsg,omitempty"`
+		NumLogs            int    `json:"numLogs"`
+	}
+
+	// LogMessage is the message of a log published to the topic <prefix>.logs
+	LogMessage struct {
+		Height     uint64   `json:"height"`
+		BlockHash  string   `json:"blockHash"`
+		ActionHash string   `json:"actionHash"`
+		TxIndex    uint32   `json:"txIndex"`
+		Index      uint32   `json:"index"`
+		Address    string   `json:"address"`
+		Topics     []string `json:"topics"`
+		Data       string   `json:"data"`
+	}
+
+	// Sink publishes the actions, the receipts and the logs of the blocks from the cursor to the tip
+	Sink struct {
+		cfg       Config
+		dao       blockDAO
+		chain     chain
+		kv        db.KVStore
+		publisher publisher.Publisher
+		cursor    uint64
+		notify    chan struct{}
+		cancel    context.CancelFunc
+		done      chan struct{}
+	}
+
+	message struct {
+		topic string
+		key   string
+		value interface{}
+	}
+)
+
+var (
+	_streamSinkHeightMtc = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "iotex_stream_sink_height",
+		Help: "The last height published by the stream sink",
+	})
+	_streamSinkMsgMtc = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iotex_stream_sink_messages",
+			Help: "Messages published by the stream sink by the topic",
+		},
+		[]string{"topic"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(_streamSinkHeightMtc, _streamSinkMsgMtc)
+}
+
+// NewSink creates a stream sink publishing the blocks of the dao, which are notified by the chain
+func NewSink(cfg Config, dao blockDAO, ch chain) *Sink {
+	return &Sink{
+		cfg:   cfg,
+		dao:   dao,
+		chain: ch,
+	}
+}
+
+// Start starts streaming from the cursor if the sink is enabled
+func (s *Sink) Start(ctx context.Context) error {
+	if !s.cfg.Enable {
+		return nil
+	}
+	// the topics of the prefix are valid if the prefix is
+	if err := publisher.ValidateTopic(s.cfg.TopicPrefix); err != nil {
+		return errors.Wrap(err, "invalid topic prefix of stream sink")
+	}
+	if s.kv == nil {
+		dbCfg := db.DefaultConfig
+		dbCfg.DbPath = s.cfg.CursorDBPath
+		s.kv = db.NewBoltDB(dbCfg)
+	}
+	if err := s.kv.Start(ctx); err != nil {
+		return errors.Wrap(err, "failed to start the cursor db of stream sink")
+	}
+	if err := s.loadCursor(); err != nil {
+		return err
+	}
+	pub, err := publisher.New(s.cfg.Publisher)
+	if err != nil {
+		return errors.Wrap(err, "failed to create the publisher of stream sink")
+	}
+	s.publisher = pub
+	s.notify = make(chan struct{}, 1)
+	cctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.done = make(chan struct{})
+	go s.run(cctx)
+	log.L().Info("Stream sink is started.", zap.String("sink", s.cfg.Publisher.Sink), zap.Uint64("cursor", s.cursor))
+	return s.chain.AddSubscriber(s)
+}
+
+// Stop stops streaming, the blocks after the cursor are published after restart
+func (s *Sink) Stop(ctx context.Context) error {
+	if s.cancel == nil {
+		return nil
+	}
+	if err := s.chain.RemoveSubscriber(s); err != nil {
+		log.L().Warn("Failed to unsubscribe stream sink.", zap.Error(err))
+	}
+	s.cancel()
+	<-s.done
+	s.cancel = nil
+	if err := s.publisher.Close(); err != nil {
+		return err
+	}
+	return s.kv.Stop(ctx)
+}
+
+// ReceiveBlock notifies the sink of the new block, which is read from the dao
+func (s *Sink) ReceiveBlock(*block.Block) error {
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func (s *Sink) loadCursor() error {
+	v, err := s.kv.Get(_cursorNS, _cursorKey)
+	switch errors.Cause(err) {
+	case nil:
+		s.cursor = byteutil.BytesToUint64BigEndian(v)
+		return nil
+	case db.ErrNotExist, db.ErrBucketNotExist:
+	default:
+		return err
+	}
+	// persist the cursor of the first start, so that the blocks committed before a restart are not skipped
+	if s.cfg.StartHeight > 0 {
+		s.cursor = s.cfg.StartHeight - 1
+	} else if s.cursor, err = s.dao.Height(); err != nil {
+		return err
+	}
+	return s.putCursor(s.cursor)
+}
+
+func (s *Sink) putCursor(height uint64) error {
+	b := batch.NewBatch()
+	b.Put(_cursorNS, _cursorKey, byteutil.Uint64ToBytesBigEndian(height), "failed to put stream sink cursor")
+	return s.kv.WriteBatch(b)
+}
+
+func (s *Sink) run(ctx context.Context) {
+	defer close(s.done)
+	ticker := time.NewTicker(_pollInterval)
+	defer ticker.Stop()
+	interval := s.cfg.RetryInterval
+	for {
+		if err := s.catchUp(ctx); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.L().Error("Failed to publish block to stream sink.", zap.Uint64("height", s.cursor+1), zap.Error(err))
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(interval):
+			}
+			if interval *= 2; interval > _maxRetryInterval {
+				interval = _maxRetryInterval
+			}
+			continue
+		}
+		interval = s.cfg.RetryInterval
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.notify:
+		case <-ticker.C:
+		}
+	}
+}
+
+// catchUp publishes the blocks after the cursor up to the tip, and moves the cursor after each block
+func (s *Sink) catchUp(ctx context.Context) error {
+	tip, err := s.dao.Height()
+	if err != nil {
+		return err
+	}
+	for s.cursor < tip {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		height := s.cursor + 1
+		if err := s.publishBlock(ctx, height); err != nil {
+			return err
+		}
+		if err := s.putCursor(height); err != nil {
+			return err
+		}
+		s.cursor = height
+		_streamSinkHeightMtc.Set(float64(height))
+	}
+	return nil
+}
+
+func (s *Sink) publishBlock(ctx context.Context, height uint64) error {
+	blk, err := s.dao.GetBlockByHeight(height)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get block %d", height)
+	}
+	receipts, err := s.dao.GetReceipts(height)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get receipts of block %d", height)
+	}
+	msgs, err := s.messages(blk, receipts)
+	if err != nil {
+		return err
+	}
+	for _, msg := range msgs {
+		data, err := json.Marshal(msg.value)
+		if err != nil {
+			return err
+		}
+		if err := s.publisher.Publish(ctx, msg.topic, msg.key, data); err != nil {
+			return errors.Wrapf(err, "failed to publish to topic %s", msg.topic)
+		}
+	}
+	// the messages of the block are flushed together
+	if err := s.publisher.Flush(ctx); err != nil {
+		return errors.Wrapf(err, "failed to flush block %d", height)
+	}
+	for _, msg := range msgs {
+		_streamSinkMsgMtc.WithLabelValues(msg.topic).Inc()
+	}
+	return nil
+}
+
+// messages returns the messages of the block, the actions, then the receipts and the logs of each action
+func (s *Sink) messages(blk *block.Block, receipts []*action.Receipt) ([]*message, error) {
+	var (
+		height    = blk.Height()
+		blkHash   = blk.HashBlock()
+		blkHashS  = hex.EncodeToString(blkHash[:])
+		actTopic  = s.cfg.TopicPrefix + ".actions"
+		rcptTopic = s.cfg.TopicPrefix + ".receipts"
+		logTopic  = s.cfg.TopicPrefix + ".logs"
+		msgs      = make([]*message, 0, len(blk.Actions)+2*len(receipts))
+	)
+	for i, act := range blk.Actions {
+		h, err := act.Hash()
+		if err != nil {
+			return nil, err
+		}
+		msg := &ActionMessage{
+			Height:     height,
+			BlockHash:  blkHashS,
+			Timestamp:  blk.Timestamp(),
+			Index:      i,
+			ActionHash: hexHash(h),
+			Type:       actionType(act),
+			Sender:     act.SenderAddress().String(),
+			Nonce:      act.Nonce(),
+			GasLimit:   act.Gas(),
+			GasPrice:   act.GasPrice().String(),
+		}
+		if to, ok := act.Destination(); ok {
+			msg.To = to
+		}
+		if v := act.Value(); v != nil && v.Sign() != 0 {
+			msg.Amount = v.String()
+		}
+		if data := act.Data(); len(data) > 0 {
+			msg.Data = hex.EncodeToString(data)
+		}
+		msgs = append(msgs, &message{topic: actTopic, key: msg.ActionHash, value: msg})
+	}
+	for _, r := range receipts {
+		actHash := hexHash(r.ActionHash)
+		msg := &ReceiptMessage{
+			Height:             height,
+			BlockHash:          blkHashS,
+			Index:              r.TxIndex,
+			ActionHash:         actHash,
+			Status:             r.Status,
+			GasConsumed:        r.GasConsumed,
+			ContractAddress:    r.ContractAddress,
+			ExecutionRevertMsg: r.ExecutionRevertMsg(),
+			NumLogs:            len(r.Logs()),
+		}
+		if r.EffectiveGasPrice != nil {
+			msg.EffectiveGasPrice = r.EffectiveGasPrice.String()
+		}
+		msgs = append(msgs, &message{topic: rcptTopic, key: actHash, value: msg})
+		for _, l := range r.Logs() {
+			lm := &LogMessage{
+				Height:     height,
+				BlockHash:  blkHashS,
+				ActionHash: actHash,
+				TxIndex:    l.TxIndex,
+				Index:      l.Index,
+				Address:    l.Address,
+				Topics:     make([]string, len(l.Topics)),
+				Data:       hex.EncodeToString(l.Data),
+			}
+			for i, topic := range l.Topics {
+				lm.Topics[i] = hexHash(topic)
+			}
+			msgs = append(msgs, &message{topic: logTopic, key: actHash, value: lm})
+		}
+	}
+	return msgs, nil
+}
+
+func actionType(act *action.SealedEnvelope) string {
+	t := reflect.TypeOf(act.Action())
+	if t == nil {
+		return ""
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Name()
+}
+
+func hexHash(h hash.Hash256) string {
+	return hex.EncodeToString(h[:])
+}
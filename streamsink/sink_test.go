@@ -0,0 +1,243 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package streamsink
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/blockchain"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/pkg/publisher"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+type testDAO struct {
+	mutex    sync.RWMutex
+	blocks   []*block.Block
+	receipts [][]*action.Receipt
+}
+
+func (d *testDAO) Height() (uint64, error) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+	return uint64(len(d.blocks)), nil
+}
+
+func (d *testDAO) GetBlockByHeight(height uint64) (*block.Block, error) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+	if height == 0 || height > uint64(len(d.blocks)) {
+		return nil, errors.Wrapf(db.ErrNotExist, "block %d", height)
+	}
+	return d.blocks[height-1], nil
+}
+
+func (d *testDAO) GetReceipts(height uint64) ([]*action.Receipt, error) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+	if height == 0 || height > uint64(len(d.receipts)) {
+		return nil, errors.Wrapf(db.ErrNotExist, "receipts %d", height)
+	}
+	return d.receipts[height-1], nil
+}
+
+func (d *testDAO) addBlock(t *testing.T) *block.Block {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	height := uint64(len(d.blocks)) + 1
+	act, err := action.SignedExecution(identityset.Address(2).String(), identityset.PrivateKey(1), height, big.NewInt(10), 100000, big.NewInt(1), []byte{1, 2})
+	require.NoError(t, err)
+	actHash, err := act.Hash()
+	require.NoError(t, err)
+	blk, err := block.NewTestingBuilder().
+		SetHeight(height).
+		SetTimeStamp(time.Unix(int64(height), 0)).
+		AddActions(act).
+		SignAndBuild(identityset.PrivateKey(0))
+	require.NoError(t, err)
+	r := &action.Receipt{Status: 1, BlockHeight: height, ActionHash: actHash, GasConsumed: 21000}
+	r.AddLogs(&action.Log{
+		Address:     identityset.Address(2).String(),
+		Topics:      action.Topics{hash.Hash256b([]byte("topic"))},
+		Data:        []byte{3},
+		BlockHeight: height,
+		ActionHash:  actHash,
+	})
+	d.blocks = append(d.blocks, &blk)
+	d.receipts = append(d.receipts, []*action.Receipt{r})
+	return &blk
+}
+
+type testChain struct{}
+
+func (testChain) AddSubscriber(blockchain.BlockCreationSubscriber) error    { return nil }
+func (testChain) RemoveSubscriber(blockchain.BlockCreationSubscriber) error { return nil }
+
+func TestSink(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	type received struct {
+		topic string
+		key   string
+		data  []byte
+	}
+	var failures int32
+	msgs := make(chan received, 100)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if atomic.AddInt32(&failures, -1) >= 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var data json.RawMessage
+		r.NoError(json.NewDecoder(req.Body).Decode(&data))
+		msgs <- received{req.Header.Get("X-Iotex-Topic"), req.Header.Get("X-Iotex-Key"), data}
+	}))
+	defer srv.Close()
+
+	dao := &testDAO{}
+	dao.addBlock(t)
+	dao.addBlock(t)
+	cfg := DefaultConfig
+	cfg.Enable = true
+	cfg.Publisher = publisher.Config{Sink: publisher.SinkHTTP, URL: srv.URL, Timeout: time.Second}
+	cfg.StartHeight = 2
+	cfg.RetryInterval = 10 * time.Millisecond
+	kv := db.NewMemKVStore()
+	s := NewSink(cfg, dao, testChain{})
+	s.kv = kv
+	r.NoError(s.Start(ctx))
+
+	expect := func(blk *block.Block) {
+		actHash, err := blk.Actions[0].Hash()
+		r.NoError(err)
+		m := <-msgs
+		r.Equal("iotex.actions", m.topic)
+		r.Equal(hexHash(actHash), m.key)
+		am := &ActionMessage{}
+		r.NoError(json.Unmarshal(m.data, am))
+		r.Equal(blk.Height(), am.Height)
+		r.Equal("Execution", am.Type)
+		r.Equal(identityset.Address(1).String(), am.Sender)
+		r.Equal(identityset.Address(2).String(), am.To)
+		r.Equal("10", am.Amount)
+		r.Equal("0102", am.Data)
+		m = <-msgs
+		r.Equal("iotex.receipts", m.topic)
+		rm := &ReceiptMessage{}
+		r.NoError(json.Unmarshal(m.data, rm))
+		r.Equal(hexHash(actHash), rm.ActionHash)
+		r.EqualValues(21000, rm.GasConsumed)
+		r.Equal(1, rm.NumLogs)
+		m = <-msgs
+		r.Equal("iotex.logs", m.topic)
+		lm := &LogMessage{}
+		r.NoError(json.Unmarshal(m.data, lm))
+		r.Equal([]string{hexHash(hash.Hash256b([]byte("topic")))}, lm.Topics)
+		r.Equal("03", lm.Data)
+	}
+	// the block before the start height is skipped
+	expect(dao.blocks[1])
+
+	// the block is published again after the sink recovers
+	atomic.StoreInt32(&failures, 2)
+	blk := dao.addBlock(t)
+	r.NoError(s.ReceiveBlock(blk))
+	expect(blk)
+	r.Eventually(func() bool {
+		v, err := kv.Get(_cursorNS, _cursorKey)
+		return err == nil && v[7] == 3
+	}, 3*time.Second, 10*time.Millisecond)
+	r.NoError(s.Stop(ctx))
+	r.Empty(msgs)
+
+	// resume from the cursor persisted
+	dao.addBlock(t)
+	s = NewSink(cfg, dao, testChain{})
+	s.kv = kv
+	r.NoError(s.Start(ctx))
+	r.EqualValues(3, s.cursor)
+	expect(dao.blocks[3])
+	r.NoError(s.Stop(ctx))
+}
+
+func TestSinkInvalidTopicPrefix(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	// a nats server recording the subjects published to
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	r.NoError(err)
+	defer ln.Close()
+	subjects := make(chan string, 10)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				_, _ = conn.Write([]byte(`INFO {"server_id":"test","max_payload":1048576}` + "\r\n"))
+				reader := bufio.NewReader(conn)
+				for {
+					line, err := reader.ReadString('\n')
+					if err != nil {
+						return
+					}
+					switch fields := strings.Fields(line); {
+					case len(fields) == 3 && fields[0] == "PUB":
+						subjects <- fields[1]
+					case len(fields) == 1 && fields[0] == "PING":
+						_, _ = conn.Write([]byte("PONG\r\n"))
+					}
+				}
+			}(conn)
+		}
+	}()
+
+	dao := &testDAO{}
+	dao.addBlock(t)
+	cfg := DefaultConfig
+	cfg.Enable = true
+	cfg.Publisher = publisher.Config{Sink: publisher.SinkNATS, URL: "nats://" + ln.Addr().String(), Timeout: time.Second}
+	for _, prefix := range []string{"iotex actions", "iotex\r\nPUB evil 2\r\nhi", "iotex\tactions", "iotex.>"} {
+		cfg.TopicPrefix = prefix
+		s := NewSink(cfg, dao, testChain{})
+		s.kv = db.NewMemKVStore()
+		r.ErrorIs(s.Start(ctx), publisher.ErrInvalidTopic, prefix)
+	}
+
+	// the subjects with the delimiters are not sent even if the prefix is not validated
+	pub, err := publisher.New(cfg.Publisher)
+	r.NoError(err)
+	defer pub.Close()
+	s := NewSink(cfg, dao, testChain{})
+	s.publisher = pub
+	s.cfg.TopicPrefix = "iotex\r\nPUB evil 2\r\nhi"
+	r.ErrorIs(s.publishBlock(ctx, 1), publisher.ErrInvalidTopic)
+	s.cfg.TopicPrefix = "iotex"
+	r.NoError(s.publishBlock(ctx, 1))
+	r.Equal("iotex.actions", <-subjects)
+	r.Equal("iotex.receipts", <-subjects)
+	r.Equal("iotex.logs", <-subjects)
+	r.Empty(subjects)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/iotexproject/iotex-address/address"
 
 	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/staking"
 	"github.com/iotexproject/iotex-core/v2/db/batch"
 	"github.com/iotexproject/iotex-core/v2/pkg/util/abiutil"
 	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
@@ -198,6 +199,13 @@ func (eh *eventHandler) HandleTransferEvent(event *abiutil.EventParam) error {
 	}
 
 	tokenID := tokenIDParam.Uint64()
+	// the bucket transferred to the stake migration address has been migrated into a native bucket
+	if address.Equal(to, staking.StakeMigrationAddr) {
+		if eh.dirty.Bucket(tokenID) != nil {
+			eh.delBucket(tokenID)
+		}
+		return nil
+	}
 	// cache token owner for stake event
 	eh.tokenOwner[tokenID] = to
 	// update bucket owner if token exists
@@ -45,6 +45,20 @@ func (s *stakeView) BucketsByCandidate(candidate address.Address) ([]*VoteBucket
 	return vbs, nil
 }
 
+func (s *stakeView) BucketsByIndices(indices []uint64) ([]*VoteBucket, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	bkts := s.cache.Buckets(indices)
+	// muted buckets are treated as non-existent
+	for i := range bkts {
+		if bkts[i] != nil && bkts[i].Muted {
+			bkts[i] = nil
+		}
+	}
+	vbs := batchAssembleVoteBucket(indices, bkts, s.helper.common.ContractAddress(), s.helper.genBlockDurationFn(s.height))
+	return vbs, nil
+}
+
 func (s *stakeView) CreatePreStates(ctx context.Context) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
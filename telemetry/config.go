@@ -0,0 +1,24 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package telemetry
+
+import "time"
+
+// Config is the config of the telemetry, which is disabled unless the operator opts in
+type Config struct {
+	// Enable enables reporting the telemetry to the endpoint
+	Enable bool `yaml:"enable"`
+	// Endpoint is the url the telemetry is posted to
+	Endpoint string        `yaml:"endpoint"`
+	Interval time.Duration `yaml:"interval"`
+}
+
+// DefaultConfig is the default config
+var DefaultConfig = Config{
+	Enable:   false,
+	Endpoint: "",
+	Interval: time.Hour,
+}
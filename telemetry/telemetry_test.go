@@ -0,0 +1,76 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/pkg/version"
+)
+
+type (
+	fakeChain   uint64
+	fakeNetwork int
+)
+
+func (c fakeChain) TipHeight() uint64 { return uint64(c) }
+
+func (n fakeNetwork) Info() (peer.AddrInfo, error) {
+	return peer.AddrInfo{ID: peer.ID("12D3KooWnode")}, nil
+}
+
+func (n fakeNetwork) ConnectedPeers() ([]peer.AddrInfo, error) {
+	return make([]peer.AddrInfo, n), nil
+}
+
+func TestReporter(t *testing.T) {
+	r := require.New(t)
+	reports := make(chan Report, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r.Equal(http.MethodPost, req.Method)
+		var report Report
+		r.NoError(json.NewDecoder(req.Body).Decode(&report))
+		select {
+		case reports <- report:
+		default:
+		}
+	}))
+	defer srv.Close()
+
+	// disabled by default
+	rp := NewReporter(DefaultConfig, 4689, fakeChain(100), fakeNetwork(3))
+	r.NoError(rp.Start(context.Background()))
+	r.Nil(rp.task)
+	r.NoError(rp.Stop(context.Background()))
+
+	rp = NewReporter(Config{Enable: true, Endpoint: srv.URL, Interval: 10 * time.Millisecond}, 4689, fakeChain(100), fakeNetwork(3))
+	r.NoError(rp.Start(context.Background()))
+	defer func() { r.NoError(rp.Stop(context.Background())) }()
+	select {
+	case report := <-reports:
+		r.Len(report.NodeID, 32)
+		r.NotContains(report.NodeID, "12D3KooWnode")
+		r.Equal(version.PackageVersion, report.Version)
+		r.EqualValues(4689, report.ChainID)
+		r.EqualValues(100, report.Height)
+		r.Equal(3, report.Peers)
+		r.Equal(runtime.GOOS, report.OS)
+		r.Equal(runtime.GOARCH, report.Arch)
+	case <-time.After(5 * time.Second):
+		r.Fail("no telemetry is reported")
+	}
+	// the node id is stable
+	r.Equal(rp.Report().NodeID, NewReporter(DefaultConfig, 4689, fakeChain(1), fakeNetwork(0)).Report().NodeID)
+}
@@ -11,6 +11,7 @@ package mock_actpool
 
 import (
 	context "context"
+	big "math/big"
 	reflect "reflect"
 
 	hash "github.com/iotexproject/go-pkgs/hash"
@@ -87,6 +88,20 @@ func (mr *MockActPoolMockRecorder) AddSubscriber(sub any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSubscriber", reflect.TypeOf((*MockActPool)(nil).AddSubscriber), sub)
 }
 
+// CheckAction mocks base method.
+func (m *MockActPool) CheckAction(ctx context.Context, act *action.SealedEnvelope) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckAction", ctx, act)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckAction indicates an expected call of CheckAction.
+func (mr *MockActPoolMockRecorder) CheckAction(ctx, act any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckAction", reflect.TypeOf((*MockActPool)(nil).CheckAction), ctx, act)
+}
+
 // DeleteAction mocks base method.
 func (m *MockActPool) DeleteAction(arg0 address.Address) {
 	m.ctrl.T.Helper()
@@ -199,6 +214,20 @@ func (mr *MockActPoolMockRecorder) GetUnconfirmedActs(addr any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnconfirmedActs", reflect.TypeOf((*MockActPool)(nil).GetUnconfirmedActs), addr)
 }
 
+// MinGasPrice mocks base method.
+func (m *MockActPool) MinGasPrice() *big.Int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MinGasPrice")
+	ret0, _ := ret[0].(*big.Int)
+	return ret0
+}
+
+// MinGasPrice indicates an expected call of MinGasPrice.
+func (mr *MockActPoolMockRecorder) MinGasPrice() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MinGasPrice", reflect.TypeOf((*MockActPool)(nil).MinGasPrice))
+}
+
 // PendingActionMap mocks base method.
 func (m *MockActPool) PendingActionMap() map[string][]*action.SealedEnvelope {
 	m.ctrl.T.Helper()
@@ -239,6 +268,30 @@ func (mr *MockActPoolMockRecorder) Reset() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockActPool)(nil).Reset))
 }
 
+// SetLimits mocks base method.
+func (m *MockActPool) SetLimits(arg0 actpool.Limits) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetLimits", arg0)
+}
+
+// SetLimits indicates an expected call of SetLimits.
+func (mr *MockActPoolMockRecorder) SetLimits(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLimits", reflect.TypeOf((*MockActPool)(nil).SetLimits), arg0)
+}
+
+// SetMinGasPrice mocks base method.
+func (m *MockActPool) SetMinGasPrice(arg0 *big.Int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetMinGasPrice", arg0)
+}
+
+// SetMinGasPrice indicates an expected call of SetMinGasPrice.
+func (mr *MockActPoolMockRecorder) SetMinGasPrice(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMinGasPrice", reflect.TypeOf((*MockActPool)(nil).SetMinGasPrice), arg0)
+}
+
 // Start mocks base method.
 func (m *MockActPool) Start(arg0 context.Context) error {
 	m.ctrl.T.Helper()
@@ -12,6 +12,7 @@ package mock_apitypes
 import (
 	reflect "reflect"
 
+	action "github.com/iotexproject/iotex-core/v2/action"
 	apitypes "github.com/iotexproject/iotex-core/v2/api/types"
 	block "github.com/iotexproject/iotex-core/v2/blockchain/block"
 	gomock "go.uber.org/mock/gomock"
@@ -106,6 +107,44 @@ func (mr *MockResponderMockRecorder) Respond(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Respond", reflect.TypeOf((*MockResponder)(nil).Respond), arg0, arg1)
 }
 
+// MockActionResponder is a mock of ActionResponder interface.
+type MockActionResponder struct {
+	ctrl     *gomock.Controller
+	recorder *MockActionResponderMockRecorder
+	isgomock struct{}
+}
+
+// MockActionResponderMockRecorder is the mock recorder for MockActionResponder.
+type MockActionResponderMockRecorder struct {
+	mock *MockActionResponder
+}
+
+// NewMockActionResponder creates a new mock instance.
+func NewMockActionResponder(ctrl *gomock.Controller) *MockActionResponder {
+	mock := &MockActionResponder{ctrl: ctrl}
+	mock.recorder = &MockActionResponderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockActionResponder) EXPECT() *MockActionResponderMockRecorder {
+	return m.recorder
+}
+
+// RespondAction mocks base method.
+func (m *MockActionResponder) RespondAction(arg0 string, arg1 *action.SealedEnvelope) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RespondAction", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RespondAction indicates an expected call of RespondAction.
+func (mr *MockActionResponderMockRecorder) RespondAction(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RespondAction", reflect.TypeOf((*MockActionResponder)(nil).RespondAction), arg0, arg1)
+}
+
 // MockListener is a mock of Listener interface.
 type MockListener struct {
 	ctrl     *gomock.Controller
@@ -145,6 +184,20 @@ func (mr *MockListenerMockRecorder) AddResponder(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddResponder", reflect.TypeOf((*MockListener)(nil).AddResponder), arg0)
 }
 
+// ReceiveAction mocks base method.
+func (m *MockListener) ReceiveAction(arg0 *action.SealedEnvelope) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReceiveAction", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReceiveAction indicates an expected call of ReceiveAction.
+func (mr *MockListenerMockRecorder) ReceiveAction(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiveAction", reflect.TypeOf((*MockListener)(nil).ReceiveAction), arg0)
+}
+
 // ReceiveBlock mocks base method.
 func (m *MockListener) ReceiveBlock(arg0 *block.Block) error {
 	m.ctrl.T.Helper()
@@ -88,6 +88,21 @@ func (mr *MockIndexerMockRecorder) GetActionIndex(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActionIndex", reflect.TypeOf((*MockIndexer)(nil).GetActionIndex), arg0)
 }
 
+// GetActionResult mocks base method.
+func (m *MockIndexer) GetActionResult(arg0 []byte) (*blockindex.ActionResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActionResult", arg0)
+	ret0, _ := ret[0].(*blockindex.ActionResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActionResult indicates an expected call of GetActionResult.
+func (mr *MockIndexerMockRecorder) GetActionResult(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActionResult", reflect.TypeOf((*MockIndexer)(nil).GetActionResult), arg0)
+}
+
 // GetActionsByAddress mocks base method.
 func (m *MockIndexer) GetActionsByAddress(arg0 hash.Hash160, arg1, arg2 uint64) ([][]byte, error) {
 	m.ctrl.T.Helper()
@@ -13,6 +13,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	hash "github.com/iotexproject/go-pkgs/hash"
 	block "github.com/iotexproject/iotex-core/v2/blockchain/block"
 	peer "github.com/libp2p/go-libp2p/core/peer"
 	gomock "go.uber.org/mock/gomock"
@@ -56,6 +57,21 @@ func (mr *MockBlockSyncMockRecorder) BuildReport() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuildReport", reflect.TypeOf((*MockBlockSync)(nil).BuildReport))
 }
 
+// HeaderTip mocks base method.
+func (m *MockBlockSync) HeaderTip() (uint64, hash.Hash256) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HeaderTip")
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(hash.Hash256)
+	return ret0, ret1
+}
+
+// HeaderTip indicates an expected call of HeaderTip.
+func (mr *MockBlockSyncMockRecorder) HeaderTip() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HeaderTip", reflect.TypeOf((*MockBlockSync)(nil).HeaderTip))
+}
+
 // ProcessBlock mocks base method.
 func (m *MockBlockSync) ProcessBlock(arg0 context.Context, arg1 string, arg2 *block.Block) error {
 	m.ctrl.T.Helper()
@@ -14,6 +14,7 @@ import (
 	reflect "reflect"
 
 	block "github.com/iotexproject/iotex-core/v2/blockchain/block"
+	consensusfsm "github.com/iotexproject/iotex-core/v2/consensus/consensusfsm"
 	scheme "github.com/iotexproject/iotex-core/v2/consensus/scheme"
 	iotextypes "github.com/iotexproject/iotex-proto/golang/iotextypes"
 	gomock "go.uber.org/mock/gomock"
@@ -110,6 +111,20 @@ func (mr *MockConsensusMockRecorder) Metrics() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Metrics", reflect.TypeOf((*MockConsensus)(nil).Metrics))
 }
 
+// SetTimeouts mocks base method.
+func (m *MockConsensus) SetTimeouts(arg0 *consensusfsm.Timeouts) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTimeouts", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTimeouts indicates an expected call of SetTimeouts.
+func (mr *MockConsensusMockRecorder) SetTimeouts(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTimeouts", reflect.TypeOf((*MockConsensus)(nil).SetTimeouts), arg0)
+}
+
 // Start mocks base method.
 func (m *MockConsensus) Start(arg0 context.Context) error {
 	m.ctrl.T.Helper()
@@ -124,6 +139,21 @@ func (mr *MockConsensusMockRecorder) Start(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockConsensus)(nil).Start), arg0)
 }
 
+// Status mocks base method.
+func (m *MockConsensus) Status() (scheme.ConsensusStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Status")
+	ret0, _ := ret[0].(scheme.ConsensusStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Status indicates an expected call of Status.
+func (mr *MockConsensusMockRecorder) Status() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockConsensus)(nil).Status))
+}
+
 // Stop mocks base method.
 func (m *MockConsensus) Stop(arg0 context.Context) error {
 	m.ctrl.T.Helper()
@@ -138,6 +168,21 @@ func (mr *MockConsensusMockRecorder) Stop(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockConsensus)(nil).Stop), arg0)
 }
 
+// Timeouts mocks base method.
+func (m *MockConsensus) Timeouts() (consensusfsm.Timeouts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Timeouts")
+	ret0, _ := ret[0].(consensusfsm.Timeouts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Timeouts indicates an expected call of Timeouts.
+func (mr *MockConsensusMockRecorder) Timeouts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Timeouts", reflect.TypeOf((*MockConsensus)(nil).Timeouts))
+}
+
 // ValidateBlockFooter mocks base method.
 func (m *MockConsensus) ValidateBlockFooter(arg0 *block.Block) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,162 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+// Package statefixture dumps the states of the protocol namespaces into a fixture file, and loads the fixture into an
+// in-memory state factory, so that the protocol tests could start from the states of a real chain instead of
+// constructing them by hand
+package statefixture
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/db/batch"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/v2/state/factory"
+)
+
+type (
+	// IterateStates iterates the states of the namespaces, and returns the height of the states
+	IterateStates func(namespaces []string, fn func(ns string, k, v []byte) error) (uint64, error)
+
+	// Fixture is the states of the namespaces at a height
+	Fixture struct {
+		Height uint64   `json:"height"`
+		States []*Entry `json:"states"`
+	}
+
+	// Entry is a state in a namespace
+	Entry struct {
+		Namespace string        `json:"namespace"`
+		Key       hexutil.Bytes `json:"key"`
+		Value     hexutil.Bytes `json:"value"`
+	}
+)
+
+// Dump dumps the states of the namespaces iterated by the iterator, e.g., the ForEachState of the state factory
+func Dump(iterate IterateStates, namespaces []string) (*Fixture, error) {
+	f := &Fixture{}
+	height, err := iterate(namespaces, func(ns string, k, v []byte) error {
+		if ns == factory.AccountKVNamespace && bytes.Equal(k, []byte(factory.CurrentHeightKey)) {
+			// the height of the states is recorded by the fixture
+			return nil
+		}
+		f.States = append(f.States, &Entry{
+			Namespace: ns,
+			Key:       bytes.Clone(k),
+			Value:     bytes.Clone(v),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to iterate states")
+	}
+	// sort the states by the keys in each namespace, so that the same states always dump the same fixture
+	for start := 0; start < len(f.States); {
+		end := start + 1
+		for end < len(f.States) && f.States[end].Namespace == f.States[start].Namespace {
+			end++
+		}
+		ns := f.States[start:end]
+		sort.Slice(ns, func(i, j int) bool { return bytes.Compare(ns[i].Key, ns[j].Key) < 0 })
+		start = end
+	}
+	f.Height = height
+	return f, nil
+}
+
+// KVStoreIterator returns the iterator of the states in the kv store of a state factory, which does not need to start
+// the state factory and its protocols
+func KVStoreIterator(kv db.KVStore) IterateStates {
+	return func(namespaces []string, fn func(ns string, k, v []byte) error) (uint64, error) {
+		h, err := kv.Get(factory.AccountKVNamespace, []byte(factory.CurrentHeightKey))
+		if err != nil {
+			return 0, errors.Wrap(err, "failed to get the height of the states")
+		}
+		for _, ns := range namespaces {
+			var err error
+			// the condition never matches, so that the states are streamed to fn instead of being collected
+			_, _, ferr := kv.Filter(ns, func(k, v []byte) bool {
+				if err == nil {
+					err = fn(ns, k, v)
+				}
+				return false
+			}, nil, nil)
+			if err != nil {
+				return 0, err
+			}
+			if ferr != nil && errors.Cause(ferr) != db.ErrNotExist && errors.Cause(ferr) != db.ErrBucketNotExist {
+				return 0, errors.Wrapf(ferr, "failed to iterate namespace %s", ns)
+			}
+		}
+		return byteutil.BytesToUint64(h), nil
+	}
+}
+
+// Load loads the fixture from the file
+func Load(path string) (*Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read fixture %s", path)
+	}
+	f := &Fixture{}
+	if err := json.Unmarshal(data, f); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse fixture %s", path)
+	}
+	return f, nil
+}
+
+// Save saves the fixture into the file
+func (f *Fixture) Save(path string) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// KVStore returns an in-memory kv store of a state factory at the height of the fixture
+func (f *Fixture) KVStore() (db.KVStore, error) {
+	kv := db.NewMemKVStore()
+	b := batch.NewBatch()
+	for _, e := range f.States {
+		b.Put(e.Namespace, e.Key, e.Value, "failed to put state")
+	}
+	b.Put(factory.AccountKVNamespace, []byte(factory.CurrentHeightKey), byteutil.Uint64ToBytes(f.Height), "failed to put height")
+	if err := kv.WriteBatch(b); err != nil {
+		return nil, errors.Wrap(err, "failed to load the states")
+	}
+	return kv, nil
+}
+
+// NewFactory returns a started in-memory state factory at the height of the fixture, with the protocols registered
+func (f *Fixture) NewFactory(ctx context.Context, cfg factory.Config, protocols ...protocol.Protocol) (factory.Factory, error) {
+	kv, err := f.KVStore()
+	if err != nil {
+		return nil, err
+	}
+	registry := protocol.NewRegistry()
+	for _, p := range protocols {
+		if err := p.Register(registry); err != nil {
+			return nil, err
+		}
+	}
+	sf, err := factory.NewStateDB(cfg, kv, factory.RegistryStateDBOption(registry), factory.SkipBlockValidationStateDBOption())
+	if err != nil {
+		return nil, err
+	}
+	if err := sf.Start(ctx); err != nil {
+		return nil, errors.Wrap(err, "failed to start state factory")
+	}
+	return sf, nil
+}
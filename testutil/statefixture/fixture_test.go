@@ -0,0 +1,84 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package statefixture
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/db/batch"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/v2/state"
+	"github.com/iotexproject/iotex-core/v2/state/factory"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+func TestFixture(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	// the states of a chain at height 5
+	kv := db.NewMemKVStore()
+	b := batch.NewBatch()
+	for i := 0; i < 3; i++ {
+		acc, err := state.NewAccount()
+		require.NoError(err)
+		require.NoError(acc.AddBalance(big.NewInt(int64(100 * (i + 1)))))
+		data, err := acc.Serialize()
+		require.NoError(err)
+		b.Put(factory.AccountKVNamespace, identityset.Address(i).Bytes(), data, "")
+	}
+	b.Put("Contract", []byte("key"), []byte("value"), "")
+	b.Put("Other", []byte("key"), []byte("value"), "")
+	b.Put(factory.AccountKVNamespace, []byte(factory.CurrentHeightKey), byteutil.Uint64ToBytes(5), "")
+	require.NoError(kv.WriteBatch(b))
+	sf, err := factory.NewStateDB(factory.DefaultConfig, kv)
+	require.NoError(err)
+	require.NoError(sf.Start(ctx))
+	defer func() { require.NoError(sf.Stop(ctx)) }()
+
+	namespaces := []string{factory.AccountKVNamespace, "Contract", "NotExist"}
+	f, err := Dump(sf.(factory.StateIterator).ForEachState, namespaces)
+	require.NoError(err)
+	require.EqualValues(5, f.Height)
+	require.Len(f.States, 4)
+	f2, err := Dump(KVStoreIterator(kv), namespaces)
+	require.NoError(err)
+	require.Equal(f, f2)
+
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	require.NoError(f.Save(path))
+	f2, err = Load(path)
+	require.NoError(err)
+	require.Equal(f, f2)
+	_, err = Load(filepath.Join(t.TempDir(), "notexist.json"))
+	require.Error(err)
+
+	sf2, err := f2.NewFactory(ctx, factory.DefaultConfig)
+	require.NoError(err)
+	defer func() { require.NoError(sf2.Stop(ctx)) }()
+	height, err := sf2.Height()
+	require.NoError(err)
+	require.EqualValues(5, height)
+	for i := 0; i < 3; i++ {
+		acc := &state.Account{}
+		_, err := sf2.State(acc, protocol.LegacyKeyOption(hash.BytesToHash160(identityset.Address(i).Bytes())))
+		require.NoError(err)
+		require.Equal(big.NewInt(int64(100*(i+1))), acc.Balance)
+	}
+	_, iter, err := sf2.States(protocol.NamespaceOption("Contract"))
+	require.NoError(err)
+	require.Equal(1, iter.Size())
+	_, _, err = sf2.States(protocol.NamespaceOption("Other"))
+	require.ErrorIs(err, state.ErrStateNotExist)
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+// This is a tool that dumps the states of the protocol namespaces in a state database into a test fixture file, which
+// is loaded into an in-memory state factory by the tests with the testutil/statefixture package. The states are
+// dumped at the height of the state database, use the state recoverer on a copy of the database to dump at a lower
+// height. The node must be stopped.
+// To use, run "make build-statefixture"
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/config"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+	"github.com/iotexproject/iotex-core/v2/testutil/statefixture"
+)
+
+var (
+	// _stateDBPath is the path of state db
+	_stateDBPath string
+	// overwritePath is the path to the config file which overwrite default values
+	_overwritePath string
+	// secretPath is the path to the  config file store secret values
+	_secretPath string
+	// _namespaces is the comma separated namespaces to dump
+	_namespaces string
+	// _height is the expected height of the states, 0 for any height
+	_height uint64
+	// _output is the path of the fixture file
+	_output string
+)
+
+func init() {
+	flag.StringVar(&_stateDBPath, "state-db-path", "", "State DB path")
+	flag.StringVar(&_overwritePath, "config-path", "", "Config path")
+	flag.StringVar(&_secretPath, "secret-path", "", "Secret path")
+	flag.StringVar(&_namespaces, "namespaces", "", "Comma separated namespaces to dump")
+	flag.Uint64Var(&_height, "height", 0, "Expected height of the states")
+	flag.StringVar(&_output, "output", "fixture.json", "Fixture file path")
+	flag.Usage = func() {
+		_, _ = fmt.Fprintf(os.Stderr, "usage: statefixture -config-path=[string] -namespaces=[string] -output=[string]\n")
+		flag.PrintDefaults()
+		os.Exit(2)
+	}
+	flag.Parse()
+}
+
+func readStateDBPath() string {
+	if _stateDBPath != "" {
+		return _stateDBPath
+	}
+	cfg, err := config.New([]string{_overwritePath, _secretPath}, []string{})
+	if err != nil {
+		log.S().Panic("failed to new config.", zap.Error(err))
+	}
+	return cfg.Chain.TrieDBPath
+}
+
+func main() {
+	if _namespaces == "" {
+		flag.Usage()
+	}
+	cfg := db.DefaultConfig
+	cfg.ReadOnly = true
+	store, err := db.CreateKVStore(cfg, readStateDBPath())
+	if err != nil {
+		log.S().Panic("failed to load state db", zap.Error(err))
+	}
+	if err := store.Start(context.Background()); err != nil {
+		log.S().Panic("failed to start db", zap.Error(err))
+	}
+	defer func() {
+		if err := store.Stop(context.Background()); err != nil {
+			log.S().Panic("failed to stop db", zap.Error(err))
+		}
+	}()
+	f, err := statefixture.Dump(statefixture.KVStoreIterator(store), strings.Split(_namespaces, ","))
+	if err != nil {
+		log.S().Panic("failed to dump states", zap.Error(err))
+	}
+	if _height != 0 && f.Height != _height {
+		log.S().Panicf("the states are at height %d instead of %d", f.Height, _height)
+	}
+	if err := f.Save(_output); err != nil {
+		log.S().Panic("failed to save fixture", zap.Error(err))
+	}
+	fmt.Printf("dumped %d states at height %d into %s\n", len(f.States), f.Height, _output)
+}
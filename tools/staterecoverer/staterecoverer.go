@@ -12,16 +12,25 @@ import (
 	"flag"
 	"fmt"
 	glog "log"
+	"net/url"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
 	"github.com/iotexproject/iotex-core/v2/blockchain/blockdao"
+	"github.com/iotexproject/iotex-core/v2/blockchain/filedao"
 	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/blockindex"
 	"github.com/iotexproject/iotex-core/v2/config"
+	"github.com/iotexproject/iotex-core/v2/db"
 	"github.com/iotexproject/iotex-core/v2/pkg/log"
-	"github.com/iotexproject/iotex-core/v2/server/itx"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/fileutil"
 	"github.com/iotexproject/iotex-core/v2/state/factory"
 )
 
@@ -80,50 +89,125 @@ func main() {
 
 	log.S().Infof("Config in use: %+v", cfg)
 
-	// create server
-	svr, err := itx.NewServer(cfg)
-	if err != nil {
-		log.L().Fatal("Failed to create server.", zap.Error(err))
-	}
-
-	// recover chain and state
-	bc := svr.ChainService(cfg.Chain.ID).Blockchain()
-	sf := svr.ChainService(cfg.Chain.ID).StateFactory()
-	dao := svr.ChainService(cfg.Chain.ID).BlockDAO()
-	if err := bc.Start(context.Background()); err == nil {
-		log.L().Info("State DB status is normal.")
-	}
-	defer func() {
-		if err := bc.Stop(context.Background()); err != nil {
-			log.L().Fatal("Failed to stop blockchain")
-		}
-	}()
-	if err := recoverChainAndState(dao, sf, cfg, uint64(recoveryHeight)); err != nil {
+	ctx := genesis.WithGenesisContext(
+		protocol.WithBlockchainCtx(context.Background(), protocol.BlockchainCtx{
+			ChainID:      cfg.Chain.ID,
+			EvmNetworkID: cfg.Chain.EVMNetworkID,
+		}),
+		cfg.Genesis,
+	)
+	if err := recoverChainAndState(ctx, cfg, uint64(recoveryHeight)); err != nil {
 		log.L().Fatal("Failed to recover chain and state.", zap.Error(err))
 	} else {
 		log.S().Infof("Success to recover chain and state to target height %d", recoveryHeight)
 	}
 }
 
-// recoverChainAndState recovers the chain to target height and refresh state db if necessary
-func recoverChainAndState(dao blockdao.BlockDAO, sf factory.Factory, cfg config.Config, targetHeight uint64) error {
-	// TODO: not all indexers could be reverted, e.g., state factory. Thus, dao.DeleteTipBlockToTarget will always
-	// return error. Therefore, it is not hard to tell that this tool is not working. The right way is to revert indexer
-	// one by one. Before we implement it in that way, comment out the following code.
-	/*
-		if err := dao.DeleteBlockToTarget(targetHeight); err != nil {
-			return errors.Wrapf(err, "failed to recover blockchain to target height %d", targetHeight)
+// recoverChainAndState recovers the chain to target height and refresh state db if necessary. The blocks above the
+// target height are deleted along with the index of the block indexers, the state db and the indexers built with the
+// states are removed if the states are above the target height, they are rebuilt by replaying the blocks at the next
+// start. The node must be stopped.
+func recoverChainAndState(ctx context.Context, cfg config.Config, targetHeight uint64) error {
+	stateHeight, err := stateDBHeight(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	if err := setChainTip(ctx, cfg, targetHeight); err != nil {
+		return errors.Wrapf(err, "failed to recover blockchain to target height %d", targetHeight)
+	}
+	if stateHeight <= targetHeight {
+		return nil
+	}
+	// there is no history of the states to revert to, delete existing state DB (build from scratch)
+	for _, path := range []string{
+		cfg.Chain.TrieDBPath,
+		cfg.Chain.HistoryIndexPath,
+		cfg.Chain.ContractStakingIndexDBPath,
+		cfg.Chain.StakingIndexDBPath,
+		cfg.Chain.CandidateIndexDBPath,
+	} {
+		if path == "" || !fileutil.FileExists(path) {
+			continue
 		}
-		stateHeight, err := sf.Height()
-		if err != nil {
-			return err
+		if err := os.RemoveAll(path); err != nil {
+			return errors.Wrapf(err, "failed to delete %s", path)
+		}
+		log.L().Info("Deleted db to rebuild.", zap.String("path", path))
+	}
+	return nil
+}
+
+// setChainTip deletes the blocks, the blobs and the index of the block indexers above the target height
+func setChainTip(ctx context.Context, cfg config.Config, targetHeight uint64) error {
+	if uri, err := url.Parse(cfg.Chain.ChainDBPath); err == nil && uri.Scheme != "" && uri.Scheme != "file" {
+		return errors.Errorf("unsupported chain db %s", cfg.Chain.ChainDBPath)
+	}
+	dbConfig := cfg.DB
+	dbConfig.DbPath = strings.TrimPrefix(cfg.Chain.ChainDBPath, "file://")
+	store, err := filedao.NewFileDAO(dbConfig, block.NewDeserializer(cfg.Chain.EVMNetworkID))
+	if err != nil {
+		return err
+	}
+	var (
+		indexers []blockdao.BlockIndexer
+		opts     []blockdao.Option
+	)
+	if _, gateway := cfg.Plugins[config.GatewayPlugin]; gateway {
+		if path := cfg.Chain.IndexDBPath; fileutil.FileExists(path) {
+			dbConfig.DbPath = path
+			indexer, err := blockindex.NewIndexer(db.NewBoltDB(dbConfig), cfg.Genesis.Hash())
+			if err != nil {
+				return err
+			}
+			indexers = append(indexers, indexer)
 		}
-		if targetHeight < stateHeight {
-			// delete existing state DB (build from scratch)
-			if fileutil.FileExists(cfg.Chain.TrieDBPath) && os.Remove(cfg.Chain.TrieDBPath) != nil {
-				return errors.New("failed to delete existing state DB")
+		if path := cfg.Chain.BloomfilterIndexDBPath; fileutil.FileExists(path) {
+			dbConfig.DbPath = path
+			bfIndexer, err := blockindex.NewBloomfilterIndexer(db.NewBoltDB(dbConfig), cfg.Indexer)
+			if err != nil {
+				return err
 			}
+			indexers = append(indexers, bfIndexer)
 		}
-	*/
-	panic("not implemented")
+	}
+	if path := cfg.Chain.BlobStoreDBPath; path != "" && fileutil.FileExists(path) {
+		dbConfig.DbPath = path
+		blocksPerHour := time.Hour / cfg.WakeUpgrade.BlockInterval
+		opts = append(opts, blockdao.WithBlobStore(blockdao.NewBlobStore(
+			db.NewBoltDB(dbConfig),
+			uint64(blocksPerHour)*uint64(cfg.Chain.BlobStoreRetentionDays)*24,
+		)))
+	}
+	dao := blockdao.NewBlockDAOWithIndexersAndCache(store, indexers, 0, opts...)
+	if err := dao.Start(ctx); err != nil {
+		return err
+	}
+	defer func() {
+		if err := dao.Stop(ctx); err != nil {
+			log.L().Error("Failed to stop block dao.", zap.Error(err))
+		}
+	}()
+	return dao.(blockdao.TipSetter).SetTip(ctx, targetHeight)
+}
+
+// stateDBHeight returns the height of the states, 0 if the state db doesn't exist
+func stateDBHeight(ctx context.Context, cfg config.Config) (uint64, error) {
+	if !fileutil.FileExists(cfg.Chain.TrieDBPath) {
+		return 0, nil
+	}
+	dbConfig := cfg.DB
+	dbConfig.DBType = cfg.Chain.FactoryDBType
+	kv, err := db.CreateKVStore(dbConfig, cfg.Chain.TrieDBPath)
+	if err != nil {
+		return 0, err
+	}
+	if err := kv.Start(ctx); err != nil {
+		return 0, err
+	}
+	defer kv.Stop(ctx)
+	h, err := kv.Get(factory.AccountKVNamespace, []byte(factory.CurrentHeightKey))
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to read the state height")
+	}
+	return byteutil.BytesToUint64(h), nil
 }